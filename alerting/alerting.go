@@ -0,0 +1,225 @@
+// Package alerting delivers operator alerts, such as a sender going down or a
+// supply running low, to the admins who want them over the channels each
+// chose: WhatsApp, email or webhook. An admin without saved preferences gets
+// the alerts they are configured for in the environment on WhatsApp.
+package alerting
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/repository"
+	"github.com/wa-serv/webhook"
+)
+
+// Alert types admins can choose to receive
+const (
+	SenderDown     = "sender_down"
+	FraudFlag      = "fraud_flag"
+	LowStock       = "low_stock"
+	FailedCampaign = "failed_campaign"
+)
+
+// Types lists every alert type
+var Types = []string{SenderDown, FraudFlag, LowStock, FailedCampaign}
+
+// Channels alerts are delivered over
+const (
+	ChannelWhatsApp = "whatsapp"
+	ChannelEmail    = "email"
+	ChannelWebhook  = "webhook"
+)
+
+// Channels lists every channel
+var Channels = []string{ChannelWhatsApp, ChannelEmail, ChannelWebhook}
+
+// webhookEventPrefix names the webhook event of an alert, e.g. alert.low_stock
+const webhookEventPrefix = "alert."
+
+// mailTimeout bounds emailing one alert
+const mailTimeout = time.Minute
+
+// Alert is one notice for the admins
+type Alert struct {
+	Type    string
+	Subject string         // subject of the email
+	Text    string         // the message sent on WhatsApp and as the email body
+	Phones  []string       // admins the environment configures to receive it
+	Data    map[string]any // details posted to webhooks along with the text
+}
+
+// WhatsAppFunc sends text to an admin's phone number on WhatsApp
+type WhatsAppFunc func(phone, text string)
+
+// MailFunc sends an email
+type MailFunc func(ctx context.Context, to []string, subject, body string) error
+
+// Notifier routes alerts by the preferences admins saved. A nil Notifier, or
+// one without a database, sends every alert to its configured admins on
+// WhatsApp.
+type Notifier struct {
+	db            *sql.DB
+	mail          MailFunc
+	webhookSecret string
+}
+
+// New creates a notifier reading preferences from db. mail is nil when email
+// is not configured; webhookSecret signs webhook alerts like WEBHOOK_SECRET.
+func New(db *sql.DB, mail MailFunc, webhookSecret string) *Notifier {
+	return &Notifier{db: db, mail: mail, webhookSecret: webhookSecret}
+}
+
+// recipient is an admin an alert goes to and the channels it goes over
+type recipient struct {
+	phone      string
+	email      string
+	webhookURL string
+	channels   []string
+}
+
+// Notify delivers alert to each admin who wants it. WhatsApp messages are sent
+// through whatsapp before Notify returns, or skipped when it is nil because no
+// sender is connected; emails and webhooks go out in the background.
+func (n *Notifier) Notify(alert Alert, whatsapp WhatsAppFunc) {
+	for _, r := range n.recipients(alert) {
+		for _, channel := range r.channels {
+			switch channel {
+			case ChannelWhatsApp:
+				if whatsapp == nil {
+					log.Printf("No sender available to alert %s of %s on WhatsApp", r.phone, alert.Type)
+					continue
+				}
+				whatsapp(r.phone, alert.Text)
+			case ChannelEmail:
+				n.email(r, alert)
+			case ChannelWebhook:
+				n.webhook(r, alert)
+			}
+		}
+	}
+}
+
+// recipients loads the saved preferences and routes alert by them. Preferences
+// that cannot be loaded leave the configured admins alerted on WhatsApp.
+func (n *Notifier) recipients(alert Alert) []recipient {
+	if n == nil || n.db == nil {
+		return route(alert, nil)
+	}
+	prefs, err := repository.ListNotificationPreferences(n.db)
+	if err != nil {
+		log.Printf("Failed to load notification preferences, alerting the configured admins on WhatsApp: %v", err)
+		return route(alert, nil)
+	}
+	return route(alert, prefs)
+}
+
+// route returns the admins alert goes to: the configured admins, on WhatsApp
+// unless they saved preferences, and every admin whose preferences ask for
+// alert's type, over the channels they chose
+func route(alert Alert, prefs []repository.NotificationPreference) []recipient {
+	byPhone := make(map[string]repository.NotificationPreference, len(prefs))
+	for _, p := range prefs {
+		byPhone[p.PhoneNumber] = p
+	}
+
+	var recipients []recipient
+	seen := make(map[string]bool)
+	add := func(phone string) {
+		if phone == "" || seen[phone] {
+			return
+		}
+		seen[phone] = true
+		p, saved := byPhone[phone]
+		if !saved {
+			recipients = append(recipients, recipient{phone: phone, channels: []string{ChannelWhatsApp}})
+			return
+		}
+		if channels := p.Alerts[alert.Type]; len(channels) > 0 {
+			recipients = append(recipients, recipient{phone: phone, email: p.Email, webhookURL: p.WebhookURL, channels: channels})
+		}
+	}
+
+	for _, phone := range alert.Phones {
+		add(strings.TrimSpace(phone))
+	}
+	for _, p := range prefs {
+		if len(p.Alerts[alert.Type]) > 0 {
+			add(p.PhoneNumber)
+		}
+	}
+	return recipients
+}
+
+// email sends alert to r's email address in the background
+func (n *Notifier) email(r recipient, alert Alert) {
+	if n == nil || n.mail == nil || r.email == "" {
+		log.Printf("Cannot email %s alert to %s: email is not configured", alert.Type, r.phone)
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+		if err := n.mail(ctx, []string{r.email}, alert.Subject, alert.Text); err != nil {
+			log.Printf("Failed to email %s alert to %s: %v", alert.Type, r.email, err)
+		}
+	}()
+}
+
+// webhook posts alert to r's webhook URL in the background, with the retries
+// and signature of the other webhooks
+func (n *Notifier) webhook(r recipient, alert Alert) {
+	if r.webhookURL == "" {
+		log.Printf("Cannot post %s alert to %s: no webhook URL", alert.Type, r.phone)
+		return
+	}
+	data := make(map[string]any, len(alert.Data)+3)
+	for k, v := range alert.Data {
+		data[k] = v
+	}
+	data["admin"] = r.phone
+	data["subject"] = alert.Subject
+	data["text"] = alert.Text
+
+	var secret string
+	if n != nil {
+		secret = n.webhookSecret
+	}
+	webhook.NewDispatcher(config.WebhookConfig{URL: r.webhookURL, Secret: secret}).Dispatch(webhookEventPrefix+alert.Type, data)
+}
+
+// IsType reports whether alertType is one of Types
+func IsType(alertType string) bool {
+	return slices.Contains(Types, alertType)
+}
+
+// IsChannel reports whether channel is one of Channels
+func IsChannel(channel string) bool {
+	return slices.Contains(Channels, channel)
+}
+
+var (
+	defaultMu       sync.RWMutex
+	defaultNotifier *Notifier
+)
+
+// SetDefault makes n the notifier Default returns, once the database and
+// mailer it needs are ready
+func SetDefault(n *Notifier) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultNotifier = n
+}
+
+// Default returns the process-wide notifier. Until SetDefault is called it
+// alerts the configured admins on WhatsApp only.
+func Default() *Notifier {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultNotifier
+}
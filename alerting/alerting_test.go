@@ -0,0 +1,69 @@
+package alerting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/repository"
+)
+
+func TestRoute_WithoutPreferencesUsesWhatsApp(t *testing.T) {
+	recipients := route(Alert{Type: LowStock, Phones: []string{"6281111111111", " 6281111111111 ", ""}}, nil)
+
+	assert.Equal(t, []recipient{{phone: "6281111111111", channels: []string{ChannelWhatsApp}}}, recipients)
+}
+
+func TestRoute_HonorsPreferences(t *testing.T) {
+	prefs := []repository.NotificationPreference{
+		// Configured for every alert but only wants sender alerts, by email
+		{PhoneNumber: "6281111111111", Email: "ops@example.com", Alerts: map[string][]string{SenderDown: {ChannelEmail}}},
+		// Not configured, but asked for low stock alerts on two channels
+		{PhoneNumber: "6282222222222", WebhookURL: "https://hooks.example.com/wa", Alerts: map[string][]string{LowStock: {ChannelWhatsApp, ChannelWebhook}}},
+	}
+	alert := Alert{Type: LowStock, Phones: []string{"6281111111111", "6283333333333"}}
+
+	recipients := route(alert, prefs)
+
+	assert.Equal(t, []recipient{
+		{phone: "6283333333333", channels: []string{ChannelWhatsApp}},
+		{phone: "6282222222222", webhookURL: "https://hooks.example.com/wa", channels: []string{ChannelWhatsApp, ChannelWebhook}},
+	}, recipients)
+}
+
+func TestNotifier_NilSendsConfiguredAdminsOnWhatsApp(t *testing.T) {
+	var n *Notifier
+	var sent []string
+
+	n.Notify(Alert{Type: SenderDown, Text: "down", Phones: []string{"6281111111111", "6282222222222"}}, func(phone, text string) {
+		sent = append(sent, phone+": "+text)
+	})
+
+	assert.Equal(t, []string{"6281111111111: down", "6282222222222: down"}, sent)
+}
+
+func TestNotifier_Email(t *testing.T) {
+	mailed := make(chan []string, 1)
+	n := New(nil, func(ctx context.Context, to []string, subject, body string) error {
+		mailed <- append(to, subject, body)
+		return nil
+	}, "")
+
+	n.email(recipient{phone: "6281111111111", email: "ops@example.com"}, Alert{Type: FailedCampaign, Subject: "Broadcast failed", Text: "3 of 10 failed"})
+
+	select {
+	case got := <-mailed:
+		assert.Equal(t, []string{"ops@example.com", "Broadcast failed", "3 of 10 failed"}, got)
+	case <-time.After(time.Second):
+		require.Fail(t, "alert was not emailed")
+	}
+}
+
+func TestIsTypeAndChannel(t *testing.T) {
+	assert.True(t, IsType(FraudFlag))
+	assert.False(t, IsType("sender.down"))
+	assert.True(t, IsChannel(ChannelWebhook))
+	assert.False(t, IsChannel("sms"))
+}
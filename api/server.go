@@ -1,16 +1,26 @@
 package api
 
 import (
+	"context"
 	"database/sql"
+	"log"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/alerting"
+	"github.com/wa-serv/bootreport"
+	"github.com/wa-serv/buildinfo"
 	"github.com/wa-serv/config"
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/faults"
 	"github.com/wa-serv/internal/application"
 	"github.com/wa-serv/internal/domain"
 	"github.com/wa-serv/internal/infrastructure"
 	"github.com/wa-serv/internal/presentation"
+	"github.com/wa-serv/mirror"
+	"github.com/wa-serv/safesend"
+	"github.com/wa-serv/scheduler"
 	"github.com/wa-serv/whatsapp"
 	"go.mau.fi/whatsmeow"
 )
@@ -28,16 +38,175 @@ func buildAIHandler() *presentation.AIHandler {
 	return presentation.NewAIHandler(aiService, aiCfg)
 }
 
+// newVersionService reports the running build, checking UPDATE_CHECK_URL for
+// a newer release when it is set
+func newVersionService() domain.VersionService {
+	cfg := config.LoadUpdateCheckConfig()
+	var feed domain.ReleaseFeed
+	if cfg.FeedURL != "" {
+		feed = infrastructure.NewReleaseFeed(cfg.FeedURL)
+	}
+	return application.NewVersionService(buildinfo.Get(), feed, cfg.Interval)
+}
+
+// alertMailFunc emails alerts through mailer, or is nil when email is not
+// configured
+func alertMailFunc(mailer domain.Mailer) alerting.MailFunc {
+	if mailer == nil {
+		return nil
+	}
+	return func(ctx context.Context, to []string, subject, body string) error {
+		return mailer.SendMail(ctx, to, subject, body)
+	}
+}
+
+// subscribeAutomations runs the automation rules for every domain event. Rules
+// load from the database and send messages, so they run off the publisher's
+// goroutine.
+func subscribeAutomations(bus *eventbus.Bus, automationService domain.AutomationService) {
+	bus.Subscribe(eventbus.All, func(evt eventbus.Event) {
+		go automationService.RunRules(context.Background(), evt.Type, evt.Data)
+	})
+}
+
+// subscribeNewMemberGroup adds every newly registered member to groupJID, such
+// as a VIP customers group. Nothing subscribes when groupJID is empty.
+func subscribeNewMemberGroup(bus *eventbus.Bus, groupService domain.GroupService, groupJID string) {
+	if groupJID == "" {
+		return
+	}
+	bus.Subscribe(eventbus.MemberRegistered, func(evt eventbus.Event) {
+		phone, _ := evt.Data["phone_number"].(string)
+		if phone == "" {
+			return
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			resp, err := groupService.AddParticipants(ctx, groupJID, &domain.UpdateGroupParticipantsRequest{Participants: []string{phone}})
+			if err != nil {
+				log.Printf("Failed to add new member %s to group %s: %v", phone, groupJID, err)
+				return
+			}
+			for _, p := range resp.Participants {
+				if !p.Success {
+					log.Printf("WhatsApp refused to add new member %s to group %s: %s", p.PhoneNumber, groupJID, p.Error)
+				}
+			}
+		}()
+	})
+}
+
+// subscribeSupplyConsumption takes the supplies of every confirmed order out of
+// stock. Consuming writes to the database and may alert admins, so it runs off
+// the publisher's goroutine.
+func subscribeSupplyConsumption(bus *eventbus.Bus, inventoryService domain.InventoryService) {
+	bus.Subscribe(eventbus.OrderConfirmed, func(evt eventbus.Event) {
+		orderID, ok := evt.Data["order_id"].(int)
+		if !ok {
+			return
+		}
+		go func() {
+			if err := inventoryService.ConsumeOrder(context.Background(), orderID); err != nil {
+				log.Printf("Failed to consume supplies of order %d: %v", orderID, err)
+			}
+		}()
+	})
+}
+
+// startAutoLabels applies the automatic WhatsApp Business labels every
+// cfg.AutoInterval until ctx is done. Nothing runs when the interval is 0.
+func startAutoLabels(ctx context.Context, labelService domain.LabelService, cfg config.LabelConfig) {
+	if cfg.AutoInterval <= 0 {
+		return
+	}
+	jobs := scheduler.NewScheduler()
+	jobs.Every("auto-labels", cfg.AutoInterval, func(ctx context.Context) error {
+		result, err := labelService.AutoLabel(ctx, "")
+		if err == domain.ErrWhatsAppNotConnected {
+			return nil // try again next tick
+		}
+		if err != nil {
+			return err
+		}
+		for _, l := range result.Labels {
+			if l.Added > 0 || l.Removed > 0 || l.Failed > 0 {
+				log.Printf("Auto label %q: added to %d chat(s), removed from %d, %d failed", l.Label, l.Added, l.Removed, l.Failed)
+			}
+		}
+		return nil
+	})
+	jobs.Start(ctx)
+}
+
+// startVIPGroupSync syncs the VIP group every interval until ctx is done.
+// Nothing runs when the interval is 0.
+func startVIPGroupSync(ctx context.Context, vipGroupService domain.VIPGroupService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	jobs := scheduler.NewScheduler()
+	jobs.Every("vip-group-sync", interval, func(ctx context.Context) error {
+		result, err := vipGroupService.Sync(ctx)
+		if err == domain.ErrWhatsAppNotConnected {
+			return nil // try again next tick
+		}
+		if err != nil {
+			return err
+		}
+		if len(result.Changes) > 0 {
+			log.Printf("VIP group sync: %d added, %d invited, %d removed, %d failed", result.Added, result.Invited, result.Removed, result.Failed)
+		}
+		return nil
+	})
+	jobs.Start(ctx)
+}
+
+// startScheduledReports delivers the report schedules that are due, checking
+// every interval until ctx is done
+func startScheduledReports(ctx context.Context, reportService domain.ReportService, interval time.Duration) {
+	jobs := scheduler.NewScheduler()
+	jobs.Every("scheduled-reports", interval, func(ctx context.Context) error {
+		deliveries, err := reportService.RunDueSchedules(ctx, time.Now())
+		for _, d := range deliveries {
+			failed := 0
+			for _, r := range d.Recipients {
+				if !r.Success {
+					failed++
+				}
+			}
+			log.Printf("Report schedule %d: sent %s to %d recipient(s), %d failed", d.ScheduleID, d.FileName, len(d.Recipients)-failed, failed)
+		}
+		return err
+	})
+	jobs.Start(ctx)
+}
+
+// subscribeStatusCallbacks reports the delivery status of messages sent through
+// the API to statusCallbacks. Nothing subscribes when callbackURL is empty.
+func subscribeStatusCallbacks(bus *eventbus.Bus, statusCallbacks domain.StatusCallbackService, callbackURL string) {
+	if callbackURL == "" {
+		return
+	}
+	for _, eventType := range []string{eventbus.MessageSent, eventbus.MessageFailed, eventbus.MessageExpired, eventbus.MessageStatus} {
+		bus.Subscribe(eventType, func(evt eventbus.Event) {
+			go statusCallbacks.HandleEvent(context.Background(), evt.Type, evt.Data)
+		})
+	}
+}
+
 // APIServer represents the API server using clean architecture
 type APIServer struct {
 	router     *gin.Engine
 	httpServer *http.Server
+	stop       context.CancelFunc // stops background workers such as the send queue
 }
 
 // NewAPIServer creates a new API server instance using clean architecture
 func NewAPIServer(db *sql.DB, client *whatsmeow.Client, username, password string, port string) *APIServer {
 	// Infrastructure layer - use repository with database support
-	whatsappRepo := infrastructure.NewWhatsAppRepositoryWithDB(client, db)
+	whatsappRepo := infrastructure.NewSafeSendWhatsAppRepository(infrastructure.NewMirroringWhatsAppRepository(
+		infrastructure.NewWhatsAppRepositoryWithDB(client, db), mirror.Default()), safesend.Default())
 
 	// Application layer
 	messageService := application.NewMessageService(whatsappRepo)
@@ -68,17 +237,174 @@ func NewAPIServer(db *sql.DB, client *whatsmeow.Client, username, password strin
 // NewAPIServerWithClientManager creates a new API server with multi-client support
 func NewAPIServerWithClientManager(db *sql.DB, clientManager *whatsapp.ClientManager, username, password string, port string) *APIServer {
 	// Infrastructure layer - use repository with client manager for dynamic client updates
-	whatsappRepo := infrastructure.NewWhatsAppRepositoryWithClientManager(db, clientManager)
+	// Safe sends come first, so a dropped send is not mirrored either
+	whatsappRepo := infrastructure.NewSafeSendWhatsAppRepository(infrastructure.NewMirroringWhatsAppRepository(
+		infrastructure.NewFaultyWhatsAppRepository(infrastructure.NewWhatsAppRepositoryWithQuota(db, clientManager, config.LoadSendQuotaConfig()), faults.Default()),
+		mirror.Default()), safesend.Default())
+	senderChainRepo := infrastructure.NewSenderChainRepository(db)
+	senderPoolRepo := infrastructure.NewSenderPoolRepository(db)
+	templateRepo := infrastructure.NewTemplateRepository(db)
+	automationRepo := infrastructure.NewAutomationRepository(db)
+	segmentRepo := infrastructure.NewSegmentRepository(db)
+	sendQueueRepo := infrastructure.NewSendQueueRepository(db)
+	suppressionRepo := infrastructure.NewSuppressionRepository(db)
+	messageHistoryRepo := infrastructure.NewMessageHistoryRepository(db, config.LoadLoyaltyConfig().TenantSlug)
+	apiCfg := config.LoadAPIConfig()
 
 	// Application layer
-	messageService := application.NewMessageService(whatsappRepo)
-	authService := application.NewAuthService(username, password)
+	workers, stop := context.WithCancel(context.Background())
+	// History sits inside the queue so every retry of a queued message is recorded
+	// Duplicates are refused before they are queued, so retries of a queued
+	// message are not mistaken for them
+	messageService := application.NewDuplicateGuardMessageService(application.NewQueuedMessageService(workers,
+		application.NewRecordingMessageService(application.NewMessageServiceWithFallback(whatsappRepo, senderChainRepo, config.LoadSenderConfig().Pool, suppressionRepo, senderPoolRepo), messageHistoryRepo),
+		sendQueueRepo, config.LoadSendQueueConfig()), config.LoadDuplicateSendConfig().Window)
+	messageHistoryService := application.NewMessageHistoryService(messageHistoryRepo)
+	deadLetterService := application.NewDeadLetterService(sendQueueRepo)
+	suppressionService := application.NewSuppressionService(suppressionRepo)
+	blocklistService := application.NewBlocklistService(infrastructure.NewBlocklistRepositoryWithClientManager(clientManager), suppressionRepo)
+	authService := application.NewAuthServiceWithAPIKeys(username, password, db)
 	registrationService := application.NewSenderRegistrationService(db, clientManager)
+	registrationBatchService := application.NewRegistrationBatchService(registrationService, config.LoadSenderConfig().BatchMax)
+	tenantService := application.NewTenantService(db)
+	locationService := application.NewLocationService(db)
+	memberService := application.NewMemberService(db)
+	driverService := application.NewDriverService(db, whatsappRepo)
+	orderService := application.NewOrderService(db, whatsappRepo, config.LoadReceiptConfig())
+	pickupService := application.NewPickupService(db)
+	reminderService := application.NewReminderService(db)
+	senderChainService := application.NewSenderChainService(senderChainRepo)
+	senderPoolService := application.NewSenderPoolService(senderPoolRepo)
+	prospectService := application.NewProspectService(db)
+	templateService := application.NewTemplateService(templateRepo, messageService)
+	automationService := application.NewAutomationService(automationRepo, templateService)
+	subscribeAutomations(eventbus.Default(), automationService)
+	broadcastCfg := config.LoadBroadcastConfig()
+	broadcastService := application.NewBroadcastService(messageService, segmentRepo, broadcastCfg.PerMinute, broadcastCfg.MaxRecipients)
+	campaignService := application.NewCampaignService(db, broadcastService)
+	sendJobService := application.NewSendJobService()
+	batchSendCfg := config.LoadBatchSendConfig()
+	batchSendService := application.NewBatchSendService(messageService, batchSendCfg.MaxMessages, batchSendCfg.Concurrency)
+	voucherService := application.NewVoucherService(db)
+	staffService := application.NewStaffService(db)
+	var mailer domain.Mailer
+	if smtpCfg := config.LoadSMTPConfig(); smtpCfg.Enabled() {
+		mailer = infrastructure.NewSMTPMailer(smtpCfg.Host, smtpCfg.Port, smtpCfg.Username, smtpCfg.Password, smtpCfg.From)
+	}
+	alerting.SetDefault(alerting.New(db, alertMailFunc(mailer), config.LoadWebhookConfig().Secret))
+	notificationPreferenceService := application.NewNotificationPreferenceService(db, mailer)
+	reportService := application.NewReportService(db, whatsappRepo, mailer, messageHistoryService, staffService, voucherService)
+	startScheduledReports(workers, reportService, config.LoadSchedulerConfig().Interval)
+	statusPageService := application.NewStatusPageService(db, whatsappRepo)
+	senderActivityService := application.NewSenderActivityService(db)
+	senderService := application.NewSenderService(db, clientManager, config.LoadSendQuotaConfig())
+	softLaunchService := application.NewSoftLaunchService(db, config.LoadSoftLaunchConfig().Percent)
+	storageService := application.NewStorageService(db, config.LoadStorageConfig())
+	versionService := newVersionService()
+	confirmationService := application.NewConfirmationService(whatsappRepo, config.LoadConfirmationConfig())
+	branchService := application.NewBranchService(db, messageService)
+	inventoryService := application.NewInventoryService(db, whatsappRepo, config.LoadInventoryConfig().AlertPhones)
+	subscribeSupplyConsumption(eventbus.Default(), inventoryService)
+	groupService := application.NewGroupService(infrastructure.NewGroupRepositoryWithClientManager(clientManager))
+	groupCfg := config.LoadGroupConfig()
+	subscribeNewMemberGroup(eventbus.Default(), groupService, groupCfg.NewMemberGroupJID)
+	var vipGroupService domain.VIPGroupService
+	if groupCfg.VIPGroupJID != "" {
+		vipGroupService = application.NewVIPGroupService(infrastructure.NewGroupRepositoryWithClientManager(clientManager),
+			segmentRepo, messageService, infrastructure.NewGroupSyncLogRepository(db), groupCfg)
+		startVIPGroupSync(workers, vipGroupService, groupCfg.VIPSyncInterval)
+	}
+	labelCfg := config.LoadLabelConfig()
+	labelService := application.NewLabelService(infrastructure.NewLabelRepositoryWithClientManager(db, clientManager),
+		infrastructure.NewAutoLabelRepository(db), segmentRepo, labelCfg)
+	startAutoLabels(workers, labelService, labelCfg)
+	statusCallbackCfg := config.LoadStatusCallbackConfig()
+	statusCallbackService := application.NewStatusCallbackService(infrastructure.NewMessageStatusRepository(db), statusCallbackCfg)
+	subscribeStatusCallbacks(eventbus.Default(), statusCallbackService, statusCallbackCfg.URL)
 
 	// Presentation layer
-	messageHandler := presentation.NewMessageHandler(messageService, authService)
-	registrationHandler := presentation.NewSenderRegistrationHandler(registrationService, authService)
-	router := presentation.NewRouterWithRegistration(messageHandler, registrationHandler, buildAIHandler(), authService)
+	messageHandler := presentation.NewMessageHandler(messageService, authService).
+		WithIdempotency(infrastructure.NewIdempotencyRepository(db, apiCfg.IdempotencyTTL)).
+		WithSendJobs(sendJobService).
+		WithBatchSend(batchSendService)
+	registrationHandler := presentation.NewSenderRegistrationHandler(registrationService, authService).
+		WithBatches(registrationBatchService)
+	messageHistoryHandler := presentation.NewMessageHistoryHandler(messageHistoryService)
+	deadLetterHandler := presentation.NewDeadLetterHandler(deadLetterService)
+	suppressionHandler := presentation.NewSuppressionHandler(suppressionService)
+	tenantHandler := presentation.NewTenantHandler(tenantService)
+	locationHandler := presentation.NewLocationHandler(locationService)
+	memberHandler := presentation.NewMemberHandler(memberService)
+	driverHandler := presentation.NewDriverHandler(driverService)
+	orderHandler := presentation.NewOrderHandler(orderService)
+	pickupHandler := presentation.NewPickupHandler(pickupService)
+	reminderHandler := presentation.NewReminderHandler(reminderService)
+	senderChainHandler := presentation.NewSenderChainHandler(senderChainService)
+	senderPoolHandler := presentation.NewSenderPoolHandler(senderPoolService)
+	notificationPreferenceHandler := presentation.NewNotificationPreferenceHandler(notificationPreferenceService)
+	prospectHandler := presentation.NewProspectHandler(prospectService)
+	templateHandler := presentation.NewTemplateHandler(templateService)
+	automationHandler := presentation.NewAutomationHandler(automationService)
+	broadcastHandler := presentation.NewBroadcastHandler(broadcastService)
+	campaignHandler := presentation.NewCampaignHandler(campaignService)
+	voucherHandler := presentation.NewVoucherHandler(voucherService)
+	staffHandler := presentation.NewStaffHandler(staffService)
+	reportHandler := presentation.NewReportHandler(reportService)
+	softLaunchHandler := presentation.NewSoftLaunchHandler(softLaunchService)
+	statusPageHandler := presentation.NewStatusPageHandler(statusPageService, config.LoadStatusPageConfig().PerMinute)
+	senderActivityHandler := presentation.NewSenderActivityHandler(senderActivityService)
+	senderHandler := presentation.NewSenderHandler(senderService)
+	blocklistHandler := presentation.NewBlocklistHandler(blocklistService)
+	storageHandler := presentation.NewStorageHandler(storageService)
+	systemInfoHandler := presentation.NewSystemInfoHandler(bootreport.Current)
+	versionHandler := presentation.NewVersionHandler(versionService)
+	confirmationHandler := presentation.NewConfirmationHandler(confirmationService)
+	branchHandler := presentation.NewBranchHandler(branchService)
+	groupHandler := presentation.NewGroupHandler(groupService)
+	labelHandler := presentation.NewLabelHandler(labelService)
+	var vipGroupHandler *presentation.VIPGroupHandler
+	if vipGroupService != nil {
+		vipGroupHandler = presentation.NewVIPGroupHandler(vipGroupService)
+	}
+	inventoryHandler := presentation.NewInventoryHandler(inventoryService)
+	router := presentation.NewRouterWithRegistration(messageHandler, registrationHandler, buildAIHandler(), authService).
+		WithMessageHistoryHandler(messageHistoryHandler).
+		WithDeadLetterHandler(deadLetterHandler).
+		WithSuppressionHandler(suppressionHandler).
+		WithBlocklistHandler(blocklistHandler).
+		WithTenantHandler(tenantHandler).
+		WithLocationHandler(locationHandler).
+		WithMemberHandler(memberHandler).
+		WithDriverHandler(driverHandler).
+		WithOrderHandler(orderHandler).
+		WithPickupHandler(pickupHandler).
+		WithReminderHandler(reminderHandler).
+		WithSenderChainHandler(senderChainHandler).
+		WithSenderPoolHandler(senderPoolHandler).
+		WithNotificationPreferenceHandler(notificationPreferenceHandler).
+		WithProspectHandler(prospectHandler).
+		WithTemplateHandler(templateHandler).
+		WithAutomationHandler(automationHandler).
+		WithBroadcastHandler(broadcastHandler).
+		WithCampaignHandler(campaignHandler).
+		WithVoucherHandler(voucherHandler).
+		WithBranchHandler(branchHandler).
+		WithGroupHandler(groupHandler).
+		WithVIPGroupHandler(vipGroupHandler).
+		WithLabelHandler(labelHandler).
+		WithInventoryHandler(inventoryHandler).
+		WithStaffHandler(staffHandler).
+		WithReportHandler(reportHandler).
+		WithStatusPageHandler(statusPageHandler).
+		WithSoftLaunchHandler(softLaunchHandler).
+		WithSenderActivityHandler(senderActivityHandler).
+		WithSenderHandler(senderHandler).
+		WithStorageHandler(storageHandler).
+		WithSystemInfoHandler(systemInfoHandler).
+		WithVersionHandler(versionHandler).
+		WithConfirmationHandler(confirmationHandler).
+		WithUnversionedSunset(apiCfg.UnversionedSunset).
+		WithTrustedProxies(apiCfg.TrustedProxies)
 
 	// Setup routes
 	ginRouter := router.SetupRoutes()
@@ -95,6 +421,7 @@ func NewAPIServerWithClientManager(db *sql.DB, clientManager *whatsapp.ClientMan
 	return &APIServer{
 		router:     ginRouter,
 		httpServer: httpServer,
+		stop:       stop,
 	}
 }
 
@@ -105,6 +432,9 @@ func (s *APIServer) Start() error {
 
 // Shutdown gracefully shuts down the API server
 func (s *APIServer) Shutdown() error {
+	if s.stop != nil {
+		s.stop()
+	}
 	return s.httpServer.Close()
 }
 
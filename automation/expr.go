@@ -0,0 +1,507 @@
+// Package automation evaluates the conditions operators attach to domain
+// events, such as `reason == "earned" && points > 100`. Conditions are a small
+// expression language with no function calls, loops or access to anything but
+// the event data, so a stored condition cannot do more than compute a boolean.
+//
+// Grammar, loosest binding first:
+//
+//	or      = and { ("||" | "or") and }
+//	and     = not { ("&&" | "and") not }
+//	not     = ("!" | "not") not | compare
+//	compare = sum [ ("==" | "!=" | "<" | "<=" | ">" | ">=") sum ]
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary
+//	primary = number | string | "true" | "false" | "null" | field | "(" or ")"
+//
+// A field is an event data key such as points or phone_number; keys the event
+// does not carry evaluate to null.
+package automation
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Limits that keep a stored condition cheap to parse and evaluate
+const (
+	MaxLength = 500
+	maxDepth  = 32
+)
+
+// Expr is a parsed condition
+type Expr struct {
+	src  string
+	root node
+}
+
+// String returns the source of the condition
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Parse compiles a condition. An empty condition always matches.
+func Parse(src string) (*Expr, error) {
+	src = strings.TrimSpace(src)
+	if len(src) > MaxLength {
+		return nil, fmt.Errorf("condition is longer than %d characters", MaxLength)
+	}
+	if src == "" {
+		return &Expr{root: literal{true}}, nil
+	}
+
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// Match evaluates the condition against event data. The result must be a boolean.
+func (e *Expr) Match(data map[string]any) (bool, error) {
+	v, err := e.root.eval(data)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition evaluates to %s, not a boolean", describe(v))
+	}
+	return b, nil
+}
+
+// Values are nil, bool, float64 or string
+
+type node interface {
+	eval(data map[string]any) (any, error)
+}
+
+type literal struct{ value any }
+
+func (n literal) eval(map[string]any) (any, error) { return n.value, nil }
+
+type field struct{ name string }
+
+func (n field) eval(data map[string]any) (any, error) { return normalize(data[n.name]), nil }
+
+type unary struct {
+	op      string
+	operand node
+}
+
+func (n unary) eval(data map[string]any) (any, error) {
+	v, err := n.operand.eval(data)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "!":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot negate %s", describe(v))
+		}
+		return !b, nil
+	default: // "-"
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("cannot negate %s", describe(v))
+		}
+		return -f, nil
+	}
+}
+
+type logical struct {
+	op          string // "&&" or "||"
+	left, right node
+}
+
+func (n logical) eval(data map[string]any) (any, error) {
+	left, err := evalBool(n.left, data)
+	if err != nil {
+		return nil, err
+	}
+	// Short-circuit, so `points != null && points > 100` is safe
+	if (n.op == "&&" && !left) || (n.op == "||" && left) {
+		return left, nil
+	}
+	return evalBool(n.right, data)
+}
+
+type binary struct {
+	op          string
+	left, right node
+}
+
+func (n binary) eval(data map[string]any) (any, error) {
+	left, err := n.left.eval(data)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return left == right, nil
+	case "!=":
+		return left != right, nil
+	}
+
+	if l, ok := left.(float64); ok {
+		if r, ok := right.(float64); ok {
+			return arithmetic(n.op, l, r)
+		}
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			switch n.op {
+			case "<":
+				return l < r, nil
+			case "<=":
+				return l <= r, nil
+			case ">":
+				return l > r, nil
+			case ">=":
+				return l >= r, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot apply %s to %s and %s", n.op, describe(left), describe(right))
+}
+
+func arithmetic(op string, l, r float64) (any, error) {
+	switch op {
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	default: // "/"
+		if r == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return l / r, nil
+	}
+}
+
+func evalBool(n node, data map[string]any) (bool, error) {
+	v, err := n.eval(data)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expected a boolean, got %s", describe(v))
+	}
+	return b, nil
+}
+
+// normalize converts event data to expression values: numbers become float64
+// and anything else that is not a string, bool or nil is compared as text
+func normalize(v any) any {
+	switch x := v.(type) {
+	case nil, bool, string, float64:
+		return x
+	case int:
+		return float64(x)
+	case int64:
+		return float64(x)
+	case int32:
+		return float64(x)
+	case float32:
+		return float64(x)
+	}
+	return fmt.Sprint(v)
+}
+
+func describe(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(x)
+	}
+	return fmt.Sprint(v)
+}
+
+// Tokens
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokString
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	num  float64
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(src) && (src[i] >= '0' && src[i] <= '9' || src[i] == '.') {
+				i++
+			}
+			n, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", src[start:i], start+1)
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[start:i], pos: start, num: n})
+		case c == '"' || c == '\'':
+			start := i
+			end := strings.IndexByte(src[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", start+1)
+			}
+			i += end + 2
+			tokens = append(tokens, token{kind: tokString, text: src[start+1 : i-1], pos: start})
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(src) && (src[i] == '_' || unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], pos: start})
+		default:
+			op := ""
+			for _, candidate := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "+", "-", "*", "/", "(", ")"} {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at position %d", c, i+1)
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, text: "end of condition", pos: len(src)}), nil
+}
+
+// Parser
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is one of the operators or keywords
+// and returns its canonical operator
+func (p *parser) accept(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp && tok.kind != tokIdent {
+		return "", false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			p.next()
+			switch op {
+			case "and":
+				return "&&", true
+			case "or":
+				return "||", true
+			case "not":
+				return "!", true
+			}
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) parseOr(depth int) (node, error) {
+	if depth > maxDepth {
+		return nil, errors.New("condition is nested too deeply")
+	}
+	left, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = logical{op: "||", left: left, right: right}
+	}
+}
+
+func (p *parser) parseAnd(depth int) (node, error) {
+	left, err := p.parseNot(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = logical{op: "&&", left: left, right: right}
+	}
+}
+
+func (p *parser) parseNot(depth int) (node, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		if depth+1 > maxDepth {
+			return nil, errors.New("condition is nested too deeply")
+		}
+		operand, err := p.parseNot(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: "!", operand: operand}, nil
+	}
+	return p.parseCompare(depth)
+}
+
+func (p *parser) parseCompare(depth int) (node, error) {
+	left, err := p.parseSum(depth)
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<=", ">=", "<", ">")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseSum(depth)
+	if err != nil {
+		return nil, err
+	}
+	return binary{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) parseSum(depth int) (node, error) {
+	left, err := p.parseProduct(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("+", "-")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseProduct(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseProduct(depth int) (node, error) {
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept("*", "/")
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary(depth int) (node, error) {
+	if _, ok := p.accept("-"); ok {
+		if depth+1 > maxDepth {
+			return nil, errors.New("condition is nested too deeply")
+		}
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return unary{op: "-", operand: operand}, nil
+	}
+	return p.parsePrimary(depth)
+}
+
+func (p *parser) parsePrimary(depth int) (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		return literal{tok.num}, nil
+	case tokString:
+		return literal{tok.text}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true":
+			return literal{true}, nil
+		case "false":
+			return literal{false}, nil
+		case "null":
+			return literal{nil}, nil
+		case "and", "or", "not":
+			return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+		}
+		return field{tok.text}, nil
+	case tokOp:
+		if tok.text == "(" {
+			inner, err := p.parseOr(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if closing := p.next(); closing.text != ")" || closing.kind != tokOp {
+				return nil, fmt.Errorf("expected ) at position %d", closing.pos+1)
+			}
+			return inner, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos+1)
+}
@@ -0,0 +1,103 @@
+package automation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpr_Match(t *testing.T) {
+	data := map[string]any{
+		"reason":             "earned",
+		"points":             150,
+		"accumulated_points": int64(980),
+		"tier":               "Gold",
+		"verified":           true,
+	}
+
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		{"", true},
+		{`reason == "earned" && points > 100`, true},
+		{`reason == 'redeemed' and points > 100`, false},
+		{`points >= 150 || tier == "Bronze"`, true},
+		{`not (points < 100)`, true},
+		{`!verified`, false},
+		{`accumulated_points - points < 1000`, true},
+		{`points * 2 / 3 == 100`, true},
+		{`-points < 0`, true},
+		{`tier > "Bronze"`, true},
+		{`missing == null`, true},
+		{`missing != null && missing > 5`, false}, // short-circuits before comparing null
+		{`reason == "earned" && (tier == "Gold" || tier == "Platinum")`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.condition, func(t *testing.T) {
+			expr, err := Parse(tt.condition)
+			require.NoError(t, err)
+
+			got, err := expr.Match(data)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []string{
+		`points >`,
+		`points > 100)`,
+		`(points > 100`,
+		`reason == "earned`,
+		`points # 3`,
+		`points > 1.2.3`,
+		`and points`,
+		strings.Repeat("(", maxDepth+2) + "true" + strings.Repeat(")", maxDepth+2),
+		strings.Repeat("x", MaxLength+1),
+	}
+
+	for _, condition := range tests {
+		_, err := Parse(condition)
+		assert.Error(t, err, condition)
+	}
+}
+
+func TestExpr_Match_Errors(t *testing.T) {
+	tests := []string{
+		`points`,             // not a boolean
+		`points > "100"`,     // number vs string
+		`points / 0 > 1`,     // division by zero
+		`missing > 5`,        // null is not ordered
+		`!points`,            // not a boolean
+		`points > 1 && tier`, // right side is not a boolean
+	}
+
+	data := map[string]any{"points": 150, "tier": "Gold"}
+	for _, condition := range tests {
+		expr, err := Parse(condition)
+		require.NoError(t, err, condition)
+
+		_, err = expr.Match(data)
+		assert.Error(t, err, condition)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{`reason == "earned" && points > 100`, `not (a < 1) or b`, `-x * (y + 2)`, `"`, `((`} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, src string) {
+		expr, err := Parse(src)
+		if err != nil {
+			return
+		}
+		// Evaluation may fail on type errors but must never panic
+		_, _ = expr.Match(map[string]any{"a": 1, "b": true, "x": "s", "y": nil, "points": 5, "reason": "earned"})
+	})
+}
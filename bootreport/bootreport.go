@@ -0,0 +1,56 @@
+// Package bootreport describes how the service started: its configuration with
+// secrets masked, the database tables in place, the senders loaded and where it
+// listens. The report is logged once at startup and served to support so a
+// misbehaving deployment can be diagnosed without shell access.
+package bootreport
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+// Report is the state of the service at startup
+type Report struct {
+	StartedAt     time.Time         `json:"started_at"`
+	Version       string            `json:"version"`
+	Commit        string            `json:"commit"`
+	Profile       string            `json:"profile"`
+	Config        map[string]string `json:"config"`         // settings from config.Summary, secrets masked
+	Tables        []string          `json:"tables"`         // tables the schema migrations left in place
+	Senders       []string          `json:"senders"`        // senders whose clients were loaded
+	DefaultSender string            `json:"default_sender"` // empty when no sender is connected
+	Listen        []string          `json:"listen"`         // addresses the HTTP servers listen on
+}
+
+var (
+	mu      sync.RWMutex
+	current *Report
+)
+
+// Publish logs report as a single JSON line and makes it the one Current
+// returns. Only the first report is kept, so it is logged once however many
+// times startup code calls it.
+func Publish(report *Report) {
+	mu.Lock()
+	defer mu.Unlock()
+	if current != nil {
+		return
+	}
+	current = report
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		log.Printf("Failed to encode boot report: %v", err)
+		return
+	}
+	log.Printf("Boot report: %s", data)
+}
+
+// Current returns the published report, or nil before startup has finished
+func Current() *Report {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
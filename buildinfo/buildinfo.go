@@ -0,0 +1,36 @@
+// Package buildinfo holds the version of the running binary. The values are
+// stamped in at build time with ldflags, for example:
+//
+//	go build -ldflags "-X github.com/wa-serv/buildinfo.Version=1.4.0 \
+//	  -X github.com/wa-serv/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/wa-serv/buildinfo.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// A binary built without them reports the version "dev".
+package buildinfo
+
+import "runtime"
+
+// Set with -ldflags "-X github.com/wa-serv/buildinfo.<Name>=<value>"
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = ""
+)
+
+// Info is the version of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the version of the running binary
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+	}
+}
@@ -0,0 +1,261 @@
+// Package command parses the #-separated chat commands customers and admins
+// type, such as REG#Nama#Alamat. Fields are trimmed, may be wrapped in double
+// quotes to contain a # (a doubled "" is a literal quote), and every failure is
+// reported as a ParseError whose message names the offending field.
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the type a field value must have
+type Kind int
+
+const (
+	Text   Kind = iota // any non-empty text
+	Number             // a positive whole number
+	Phone              // a phone number of 6 to 15 digits, optional leading +
+)
+
+// Field is one #-separated argument of a command
+type Field struct {
+	Name     string // key in the parsed Args
+	Label    string // name shown to the user in error messages
+	Kind     Kind
+	Optional bool // may be left off; only trailing fields can be optional
+}
+
+// Spec describes a command: its keyword and the fields that follow it
+type Spec struct {
+	Keyword string
+	Fields  []Field
+}
+
+// Commands understood by the bot
+var (
+	Registration = Spec{Keyword: "REG", Fields: []Field{
+		{Name: "name", Label: "Nama", Kind: Text},
+		{Name: "address", Label: "Alamat", Kind: Text},
+	}}
+	UpsertPoints = Spec{Keyword: "INPUT", Fields: []Field{
+		{Name: "phone_number", Label: "NomorHP", Kind: Phone},
+		{Name: "points", Label: "Poin", Kind: Number},
+		// Item the points were earned on, matched against points campaigns
+		{Name: "item", Label: "Item", Kind: Text, Optional: true},
+	}}
+	Redeem = Spec{Keyword: "RED", Fields: []Field{
+		{Name: "points", Label: "Poin", Kind: Number},
+	}}
+	AddStamps = Spec{Keyword: "STEMPEL", Fields: []Field{
+		{Name: "phone_number", Label: "NomorHP", Kind: Phone},
+		{Name: "stamps", Label: "Jumlah", Kind: Number, Optional: true}, // defaults to 1
+	}}
+	ClaimStampReward = Spec{Keyword: "KLAIM", Fields: []Field{
+		{Name: "phone_number", Label: "NomorHP", Kind: Phone},
+	}}
+	BranchDetails = Spec{Keyword: "CABANG", Fields: []Field{
+		{Name: "branch_id", Label: "NoCabang", Kind: Number},
+	}}
+)
+
+// Usage returns the command format, e.g. REG#Nama#Alamat. Optional fields
+// are shown in brackets, e.g. INPUT#NomorHP#Poin#[Item].
+func (s Spec) Usage() string {
+	parts := []string{s.Keyword}
+	for _, f := range s.Fields {
+		if f.Optional {
+			parts = append(parts, "["+f.Label+"]")
+			continue
+		}
+		parts = append(parts, f.Label)
+	}
+	return strings.Join(parts, "#")
+}
+
+// Args holds the parsed field values by field name
+type Args map[string]string
+
+// Int returns the value of a Number field. Parse has already validated it.
+func (a Args) Int(name string) int {
+	n, _ := strconv.Atoi(a[name])
+	return n
+}
+
+// Reason says what is wrong with a command
+type Reason int
+
+const (
+	ReasonKeyword    Reason = iota // does not start with the expected keyword
+	ReasonMissing                  // the field is absent
+	ReasonEmpty                    // the field is present but blank
+	ReasonExtra                    // there are more fields than expected
+	ReasonUnclosed                 // an opening quote is never closed
+	ReasonAfterQuote               // text follows a closing quote
+	ReasonNotNumber                // a Number field is not a positive whole number
+	ReasonNotPhone                 // a Phone field is not a phone number
+)
+
+// ParseError reports why a command could not be parsed. Its message is in
+// Indonesian and is meant to be sent back to the user as is.
+type ParseError struct {
+	Spec   Spec
+	Field  string // label of the offending field; empty for keyword errors
+	Value  string // offending value, when there is one
+	Reason Reason
+}
+
+func (e *ParseError) Error() string {
+	var msg string
+	switch e.Reason {
+	case ReasonKeyword:
+		msg = fmt.Sprintf("Perintah harus diawali %s#.", e.Spec.Keyword)
+	case ReasonMissing:
+		msg = fmt.Sprintf("%s belum diisi.", e.Field)
+	case ReasonEmpty:
+		msg = fmt.Sprintf("%s tidak boleh kosong.", e.Field)
+	case ReasonExtra:
+		msg = fmt.Sprintf("Ada tanda # berlebih setelah %s. Apit %s dengan tanda kutip jika berisi #, contoh: \"%s #1\".", e.Field, e.Field, e.Field)
+	case ReasonUnclosed:
+		msg = fmt.Sprintf("Tanda kutip pada %s belum ditutup.", e.Field)
+	case ReasonAfterQuote:
+		msg = fmt.Sprintf("Ada teks setelah tanda kutip penutup pada %s.", e.Field)
+	case ReasonNotNumber:
+		msg = fmt.Sprintf("%s harus berupa angka positif, bukan %q.", e.Field, e.Value)
+	case ReasonNotPhone:
+		msg = fmt.Sprintf("%s %q tidak valid. Gunakan 6-15 digit angka, contoh: 6281234567890.", e.Field, e.Value)
+	default:
+		msg = "Format salah!"
+	}
+	return msg + " Format: " + e.Spec.Usage()
+}
+
+// Parse checks text against the spec and returns its fields. The keyword is
+// matched case-insensitively.
+func (s Spec) Parse(text string) (Args, error) {
+	keyword, rest, found := strings.Cut(strings.TrimSpace(text), "#")
+	if !found || !strings.EqualFold(strings.TrimSpace(keyword), s.Keyword) {
+		return nil, &ParseError{Spec: s, Reason: ReasonKeyword}
+	}
+
+	values, err := s.splitFields(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(Args, len(s.Fields))
+	for i, f := range s.Fields {
+		if i >= len(values) {
+			if f.Optional {
+				continue
+			}
+			return nil, &ParseError{Spec: s, Field: f.Label, Reason: ReasonMissing}
+		}
+		value, err := f.validate(values[i])
+		if err != nil {
+			err.Spec = s
+			return nil, err
+		}
+		args[f.Name] = value
+	}
+	return args, nil
+}
+
+// splitFields splits the text after the keyword into trimmed, unquoted fields
+func (s Spec) splitFields(rest string) ([]string, error) {
+	var values []string
+	for {
+		label := s.label(len(values))
+		value, next, err := nextField(rest)
+		if err != nil {
+			err.Spec = s
+			err.Field = label
+			return nil, err
+		}
+		values = append(values, value)
+		if next < 0 {
+			return values, nil
+		}
+		if len(values) == len(s.Fields) {
+			return nil, &ParseError{Spec: s, Field: label, Reason: ReasonExtra}
+		}
+		rest = rest[next:]
+	}
+}
+
+// label returns the label of field i, or of the last field past the end
+func (s Spec) label(i int) string {
+	if i >= len(s.Fields) {
+		i = len(s.Fields) - 1
+	}
+	if i < 0 {
+		return s.Keyword
+	}
+	return s.Fields[i].Label
+}
+
+// nextField reads one field from the start of text. It returns the field and
+// the offset just past the # that ends it, or -1 if it ends the text.
+func nextField(text string) (string, int, *ParseError) {
+	trimmed := strings.TrimLeft(text, " \t")
+	if !strings.HasPrefix(trimmed, `"`) {
+		if i := strings.Index(text, "#"); i >= 0 {
+			return strings.TrimSpace(text[:i]), i + 1, nil
+		}
+		return strings.TrimSpace(text), -1, nil
+	}
+
+	offset := len(text) - len(trimmed) + 1
+	var b strings.Builder
+	for i := offset; i < len(text); i++ {
+		if text[i] != '"' {
+			b.WriteByte(text[i])
+			continue
+		}
+		if i+1 < len(text) && text[i+1] == '"' {
+			b.WriteByte('"')
+			i++
+			continue
+		}
+
+		// Closing quote: only blanks may follow before the next # or the end
+		after := text[i+1:]
+		end := strings.Index(after, "#")
+		tail := after
+		if end >= 0 {
+			tail = after[:end]
+		}
+		if strings.TrimSpace(tail) != "" {
+			return "", 0, &ParseError{Reason: ReasonAfterQuote}
+		}
+		if end < 0 {
+			return b.String(), -1, nil
+		}
+		return b.String(), i + 1 + end + 1, nil
+	}
+	return "", 0, &ParseError{Reason: ReasonUnclosed}
+}
+
+// validate checks value against the field kind and returns its normalized form
+func (f Field) validate(value string) (string, *ParseError) {
+	if strings.TrimSpace(value) == "" {
+		return "", &ParseError{Field: f.Label, Reason: ReasonEmpty}
+	}
+
+	switch f.Kind {
+	case Number:
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return "", &ParseError{Field: f.Label, Value: value, Reason: ReasonNotNumber}
+		}
+		return strconv.Itoa(n), nil
+	case Phone:
+		digits := strings.TrimPrefix(value, "+")
+		if len(digits) < 6 || len(digits) > 15 || strings.Trim(digits, "0123456789") != "" {
+			return "", &ParseError{Field: f.Label, Value: value, Reason: ReasonNotPhone}
+		}
+		return digits, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,137 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+		text string
+		want Args
+	}{
+		{"registration", Registration, "REG#Budi#Jl. Mawar 5", Args{"name": "Budi", "address": "Jl. Mawar 5"}},
+		{"lower case with spaces", Registration, "reg # budi # jl. mawar ", Args{"name": "budi", "address": "jl. mawar"}},
+		{"quoted hash", Registration, `REG#Budi#"Jl. Mawar #5, RT 02"`, Args{"name": "Budi", "address": "Jl. Mawar #5, RT 02"}},
+		{"escaped quote", Registration, `REG#"Budi ""Bro"""#Jl. Melati`, Args{"name": `Budi "Bro"`, "address": "Jl. Melati"}},
+		{"upsert points", UpsertPoints, "INPUT#+6281234567890#50", Args{"phone_number": "6281234567890", "points": "50"}},
+		{"upsert points with item", UpsertPoints, "INPUT#6281234567890#50#cuci kering", Args{"phone_number": "6281234567890", "points": "50", "item": "cuci kering"}},
+		{"redeem", Redeem, "red#020", Args{"points": "20"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := tt.spec.Parse(tt.text)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, args)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   Spec
+		text   string
+		field  string
+		reason Reason
+	}{
+		{"wrong keyword", Redeem, "REDEEM#50", "", ReasonKeyword},
+		{"no separator", Redeem, "RED 50", "", ReasonKeyword},
+		{"missing address", Registration, "REG#Budi", "Alamat", ReasonMissing},
+		{"empty name", Registration, "REG# #Jl. Mawar", "Nama", ReasonEmpty},
+		{"unquoted hash in address", Registration, "REG#Budi#Jl. Mawar #5", "Alamat", ReasonExtra},
+		{"unclosed quote", Registration, `REG#Budi#"Jl. Mawar`, "Alamat", ReasonUnclosed},
+		{"text after quote", Registration, `REG#"Budi" S#Jl. Mawar`, "Nama", ReasonAfterQuote},
+		{"negative points", Redeem, "RED#-5", "Poin", ReasonNotNumber},
+		{"points not a number", UpsertPoints, "INPUT#6281234567890#lima", "Poin", ReasonNotNumber},
+		{"bad phone", UpsertPoints, "INPUT#0812-345#50", "NomorHP", ReasonNotPhone},
+		{"empty optional item", UpsertPoints, "INPUT#6281234567890#50# ", "Item", ReasonEmpty},
+		{"field after optional item", UpsertPoints, "INPUT#6281234567890#50#cuci#setrika", "Item", ReasonExtra},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.spec.Parse(tt.text)
+
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr), "expected a ParseError, got %v", err)
+			assert.Equal(t, tt.reason, parseErr.Reason)
+			assert.Equal(t, tt.field, parseErr.Field)
+			assert.True(t, strings.HasSuffix(err.Error(), "Format: "+tt.spec.Usage()))
+			if tt.field != "" {
+				assert.Contains(t, err.Error(), tt.field)
+			}
+		})
+	}
+}
+
+func TestSpec_Usage(t *testing.T) {
+	assert.Equal(t, "REG#Nama#Alamat", Registration.Usage())
+	assert.Equal(t, "INPUT#NomorHP#Poin#[Item]", UpsertPoints.Usage())
+	assert.Equal(t, "RED#Poin", Redeem.Usage())
+}
+
+// quote renders a field so that Parse reads it back unchanged
+func quote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"REG#Budi#Jl. Mawar",
+		`REG#Budi#"Jl. Mawar #5"`,
+		`REG#"a""b"#c`,
+		`REG#"unclosed`,
+		"INPUT#6281234567890#50",
+		"RED#20",
+		"RED##",
+		"#",
+		"",
+	} {
+		f.Add(seed)
+	}
+
+	specs := []Spec{Registration, UpsertPoints, Redeem}
+	f.Fuzz(func(t *testing.T, text string) {
+		for _, spec := range specs {
+			args, err := spec.Parse(text)
+			if err != nil {
+				var parseErr *ParseError
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("%s: non-ParseError %v", spec.Keyword, err)
+				}
+				continue
+			}
+
+			// Every required field is present, and quoting the parsed values parses back to them
+			fields := []string{spec.Keyword}
+			for _, field := range spec.Fields {
+				value, ok := args[field.Name]
+				if !ok && field.Optional {
+					break
+				}
+				if !ok || strings.TrimSpace(value) == "" {
+					t.Fatalf("%s: field %s missing from %v", spec.Keyword, field.Name, args)
+				}
+				fields = append(fields, quote(value))
+			}
+			again, err := spec.Parse(strings.Join(fields, "#"))
+			if err != nil {
+				t.Fatalf("%s: re-parsing %v failed: %v", spec.Keyword, args, err)
+			}
+			for name, value := range args {
+				if again[name] != value {
+					t.Fatalf("%s: field %s changed from %q to %q", spec.Keyword, name, value, again[name])
+				}
+			}
+		}
+	})
+}
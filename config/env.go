@@ -1,9 +1,16 @@
 package config
 
 import (
+	"encoding/base32"
+	"encoding/base64"
+	"fmt"
 	"log"
+	"net"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -77,6 +84,958 @@ func LoadAIConfig() AIConfig {
 	return cfg
 }
 
+// WebhookConfig holds configuration for outbound event webhooks (CRM sync etc.)
+type WebhookConfig struct {
+	URL    string
+	Secret string          // HMAC-SHA256 signing secret; signature header omitted when empty
+	Events map[string]bool // subscribed event types; empty means all events
+}
+
+// LoadWebhookConfig reads webhook configuration from the environment.
+//
+// WEBHOOK_URL enables delivery when set. WEBHOOK_EVENTS is a comma-separated
+// allowlist such as "member.created,points.earned"; leave it empty to receive
+// every event type.
+func LoadWebhookConfig() WebhookConfig {
+	return WebhookConfig{
+		URL:    strings.TrimSpace(os.Getenv("WEBHOOK_URL")),
+		Secret: os.Getenv("WEBHOOK_SECRET"),
+		Events: parseCSVSet(os.Getenv("WEBHOOK_EVENTS")),
+	}
+}
+
+// LoadStatusCallbackConfig reads the message status callback configuration
+// from the environment. STATUS_CALLBACK_URL receives message.sent,
+// message.delivered, message.read and message.failed for messages sent through
+// the API; STATUS_CALLBACK_SECRET signs them like WEBHOOK_SECRET.
+func LoadStatusCallbackConfig() WebhookConfig {
+	return WebhookConfig{
+		URL:    strings.TrimSpace(os.Getenv("STATUS_CALLBACK_URL")),
+		Secret: os.Getenv("STATUS_CALLBACK_SECRET"),
+	}
+}
+
+// Wants reports whether the webhook is enabled and subscribed to eventType.
+func (c WebhookConfig) Wants(eventType string) bool {
+	if c.URL == "" {
+		return false
+	}
+	return len(c.Events) == 0 || c.Events[eventType]
+}
+
+// SchedulerConfig holds intervals for background jobs run by the scheduler
+type SchedulerConfig struct {
+	Interval           time.Duration // how often jobs wake up
+	PickupReminderLead time.Duration // how long before a pickup slot the reminder is sent
+	EscalationPhones   []string      // admin numbers alerted when reminders go unanswered
+}
+
+// LoadSchedulerConfig reads scheduler configuration from the environment.
+//
+// SCHEDULER_INTERVAL defaults to 1m and PICKUP_REMINDER_LEAD to 2h; both use Go
+// duration syntax such as "30s" or "90m". REMINDER_ESCALATION_PHONES is a
+// comma-separated list of admin numbers (country code, no + sign).
+func LoadSchedulerConfig() SchedulerConfig {
+	cfg := SchedulerConfig{
+		Interval:           parseDurationEnv("SCHEDULER_INTERVAL", time.Minute),
+		PickupReminderLead: parseDurationEnv("PICKUP_REMINDER_LEAD", 2*time.Hour),
+	}
+	for phone := range parseCSVSet(os.Getenv("REMINDER_ESCALATION_PHONES")) {
+		cfg.EscalationPhones = append(cfg.EscalationPhones, phone)
+	}
+	sort.Strings(cfg.EscalationPhones)
+	return cfg
+}
+
+// ShadowConfig gates shadow-mode evaluation of handler refactors
+type ShadowConfig struct {
+	RouterEnabled bool // run the candidate command router alongside the live one
+}
+
+// LoadShadowConfig reads shadow-mode flags from the environment.
+//
+// SHADOW_ROUTER_ENABLED accepts true/1/yes/on (default false).
+func LoadShadowConfig() ShadowConfig {
+	return ShadowConfig{
+		RouterEnabled: parseBoolEnv("SHADOW_ROUTER_ENABLED"),
+	}
+}
+
+// SoftLaunchConfig splits conversations between the live bot flows and a new
+// flow being soft-launched
+type SoftLaunchConfig struct {
+	Percent int // share of conversations, 0-100, given the new flow
+}
+
+// LoadSoftLaunchConfig reads the soft launch split from the environment.
+//
+// SOFT_LAUNCH_PERCENT is the percentage of conversations shown the new flow
+// (the interactive-button menu) and defaults to 0, which turns it off.
+func LoadSoftLaunchConfig() SoftLaunchConfig {
+	return SoftLaunchConfig{
+		Percent: parsePercentEnv("SOFT_LAUNCH_PERCENT"),
+	}
+}
+
+// FaultConfig configures fault injection for resilience testing in staging.
+// Nothing is injected unless Enabled is true.
+type FaultConfig struct {
+	Enabled         bool
+	DBErrorRate     float64       // fraction of DB calls that fail with a simulated timeout
+	DBDelay         time.Duration // latency added to every DB call
+	SendFailureRate float64       // fraction of WhatsApp sends that fail
+	SendDelay       time.Duration // latency added to every WhatsApp send
+	ReceiptDelay    time.Duration // delay before receipt events are handled
+}
+
+// LoadFaultConfig reads fault injection settings from the environment.
+//
+// FAULT_INJECTION_ENABLED must be true for any other FAULT_* value to take
+// effect. Rates are fractions between 0 and 1; delays use Go duration syntax.
+func LoadFaultConfig() FaultConfig {
+	cfg := FaultConfig{Enabled: parseBoolEnv("FAULT_INJECTION_ENABLED")}
+	if !cfg.Enabled {
+		return cfg
+	}
+	cfg.DBErrorRate = parseRateEnv("FAULT_DB_ERROR_RATE")
+	cfg.DBDelay = parseDurationEnv("FAULT_DB_DELAY", 0)
+	cfg.SendFailureRate = parseRateEnv("FAULT_SEND_FAILURE_RATE")
+	cfg.SendDelay = parseDurationEnv("FAULT_SEND_DELAY", 0)
+	cfg.ReceiptDelay = parseDurationEnv("FAULT_RECEIPT_DELAY", 0)
+	return cfg
+}
+
+// Environment profiles
+const (
+	ProfileProduction  = "production"
+	ProfileStaging     = "staging"
+	ProfileDevelopment = "development"
+)
+
+// EnvironmentConfig names the environment this instance runs in. Outside
+// production, WhatsApp sends only reach SafeRecipients; the rest go to
+// RedirectTo, or nowhere when it is empty.
+type EnvironmentConfig struct {
+	Profile        string
+	SafeRecipients []string // phone numbers or group JIDs that may be messaged
+	RedirectTo     string   // phone number that gets sends meant for anyone else
+}
+
+// Production reports whether sends go to their recipients unchecked
+func (c EnvironmentConfig) Production() bool {
+	return c.Profile == ProfileProduction
+}
+
+// LoadEnvironmentConfig reads the environment profile from the environment.
+//
+// APP_PROFILE is production (the default), staging or development; unknown
+// values are logged and treated as staging, so a typo never unlocks sends.
+// SAFE_SEND_RECIPIENTS is a comma-separated list of phone numbers (without the
+// + sign) or group JIDs. SAFE_SEND_REDIRECT is a phone number; when unset,
+// sends to other recipients are dropped.
+func LoadEnvironmentConfig() EnvironmentConfig {
+	cfg := EnvironmentConfig{
+		Profile:    strings.ToLower(strings.TrimSpace(getEnv("APP_PROFILE", ProfileProduction))),
+		RedirectTo: strings.TrimPrefix(strings.TrimSpace(os.Getenv("SAFE_SEND_REDIRECT")), "+"),
+	}
+	switch cfg.Profile {
+	case ProfileProduction, ProfileStaging, ProfileDevelopment:
+	default:
+		log.Printf("Warning: unknown APP_PROFILE %q, treating it as %s", cfg.Profile, ProfileStaging)
+		cfg.Profile = ProfileStaging
+	}
+	for _, recipient := range parseCSVList(os.Getenv("SAFE_SEND_RECIPIENTS")) {
+		cfg.SafeRecipients = append(cfg.SafeRecipients, strings.TrimPrefix(recipient, "+"))
+	}
+	return cfg
+}
+
+// Message mirror directions
+const (
+	MirrorOutbound = "outbound"
+	MirrorInbound  = "inbound"
+	MirrorBoth     = "both"
+)
+
+// MirrorConfig copies a sender's messages to an archive chat on WhatsApp
+type MirrorConfig struct {
+	Targets  map[string]string // sender ID, or * for every other sender -> archive phone number or group JID
+	Outbound bool              // mirror messages the senders send
+	Inbound  bool              // mirror messages the senders receive
+}
+
+// LoadMirrorConfig reads message mirroring settings from the environment.
+//
+// MESSAGE_MIRROR_TARGETS is a comma-separated list of sender=archive pairs,
+// where sender is a sender ID or * and archive a phone number or group JID;
+// malformed pairs are logged and skipped. MESSAGE_MIRROR_DIRECTION is outbound
+// (the default), inbound or both.
+func LoadMirrorConfig() MirrorConfig {
+	cfg := MirrorConfig{Targets: make(map[string]string)}
+	for _, pair := range parseCSVList(os.Getenv("MESSAGE_MIRROR_TARGETS")) {
+		sender, archive, ok := strings.Cut(pair, "=")
+		sender = strings.TrimPrefix(strings.TrimSpace(sender), "+")
+		archive = strings.TrimPrefix(strings.TrimSpace(archive), "+")
+		if !ok || sender == "" || archive == "" {
+			log.Printf("Warning: invalid MESSAGE_MIRROR_TARGETS entry %q, expected sender=archive", pair)
+			continue
+		}
+		cfg.Targets[sender] = archive
+	}
+
+	switch direction := strings.ToLower(strings.TrimSpace(getEnv("MESSAGE_MIRROR_DIRECTION", MirrorOutbound))); direction {
+	case MirrorOutbound:
+		cfg.Outbound = true
+	case MirrorInbound:
+		cfg.Inbound = true
+	case MirrorBoth:
+		cfg.Outbound, cfg.Inbound = true, true
+	default:
+		log.Printf("Warning: invalid MESSAGE_MIRROR_DIRECTION %q, using %s", direction, MirrorOutbound)
+		cfg.Outbound = true
+	}
+	return cfg
+}
+
+// APIConfig configures HTTP API versioning and request handling
+type APIConfig struct {
+	UnversionedSunset time.Time     // advertised removal date for the unversioned /api routes; zero if unset
+	IdempotencyTTL    time.Duration // how long an Idempotency-Key replays its first result
+	TrustedProxies    []string      // proxies whose X-Forwarded-For names the client; none if empty
+}
+
+// LoadAPIConfig reads API settings from the environment.
+//
+// API_UNVERSIONED_SUNSET is a date (YYYY-MM-DD) sent in the Sunset header of
+// the deprecated unversioned routes. Invalid values are logged and ignored.
+// IDEMPOTENCY_KEY_TTL defaults to 24h. TRUSTED_PROXIES lists the IP addresses
+// or CIDR ranges of the reverse proxies in front of the API; only requests from
+// them may name the client IP in X-Forwarded-For. Invalid entries are logged
+// and ignored, and by default no proxy is trusted.
+func LoadAPIConfig() APIConfig {
+	cfg := APIConfig{
+		IdempotencyTTL: parseDurationEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+		TrustedProxies: parseTrustedProxies(os.Getenv("TRUSTED_PROXIES")),
+	}
+	value := strings.TrimSpace(os.Getenv("API_UNVERSIONED_SUNSET"))
+	if value == "" {
+		return cfg
+	}
+	sunset, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		log.Printf("Warning: invalid API_UNVERSIONED_SUNSET %q, expected YYYY-MM-DD", value)
+		return cfg
+	}
+	cfg.UnversionedSunset = sunset
+	return cfg
+}
+
+// Routing strategies for sends that do not name a sender
+const (
+	RoutingDefault           = "default"             // every send goes through the default sender
+	RoutingRoundRobin        = "round_robin"         // connected senders take turns
+	RoutingLeastRecentlyUsed = "least_recently_used" // the sender idle the longest sends next
+)
+
+// SenderConfig controls how the default sender is re-elected when it logs out
+// and how sends without a sender are spread across the senders
+type SenderConfig struct {
+	DefaultPriority []string // sender IDs in promotion order; unlisted senders fall back to oldest first
+	AlertPhones     []string // admin numbers told when the default sender changes
+	Pool            []string // sender IDs that take over sends, in order, while the requested sender is offline
+	BatchMax        int      // most phone numbers a bulk registration accepts
+
+	FailoverDisabled bool   // keep a logged-out or banned default sender as the default instead of promoting another
+	RoutingStrategy  string // RoutingDefault, RoutingRoundRobin or RoutingLeastRecentlyUsed
+}
+
+// LoadSenderConfig reads sender election settings from the environment.
+//
+// SENDER_DEFAULT_PRIORITY is an ordered, comma-separated list of sender IDs
+// (phone numbers without the + sign). SENDER_ALERT_PHONES lists admin numbers
+// and defaults to REMINDER_ESCALATION_PHONES when unset. SENDER_POOL is an
+// ordered, comma-separated list of sender IDs; empty disables the fallback.
+// SENDER_REGISTRATION_BATCH_MAX caps a bulk registration and defaults to 20.
+// SENDER_FAILOVER_DISABLED turns off promoting another sender when the
+// default one logs out, is banned, paused or removed; admins are still alerted.
+// SENDER_ROUTING_STRATEGY picks the sender of a send without one: default
+// (the default sender), round_robin or least_recently_used.
+func LoadSenderConfig() SenderConfig {
+	alertPhones := os.Getenv("SENDER_ALERT_PHONES")
+	if strings.TrimSpace(alertPhones) == "" {
+		alertPhones = os.Getenv("REMINDER_ESCALATION_PHONES")
+	}
+	return SenderConfig{
+		DefaultPriority: parseCSVList(os.Getenv("SENDER_DEFAULT_PRIORITY")),
+		AlertPhones:     parseCSVList(alertPhones),
+		Pool:            parseCSVList(os.Getenv("SENDER_POOL")),
+		BatchMax:        parsePositiveIntEnv("SENDER_REGISTRATION_BATCH_MAX", 20),
+
+		FailoverDisabled: parseBoolEnv("SENDER_FAILOVER_DISABLED"),
+		RoutingStrategy:  parseRoutingStrategy(os.Getenv("SENDER_ROUTING_STRATEGY")),
+	}
+}
+
+// parseRoutingStrategy returns the routing strategy named by value, or
+// RoutingDefault when it is empty or unknown
+func parseRoutingStrategy(value string) string {
+	switch strategy := strings.ToLower(strings.TrimSpace(value)); strategy {
+	case "", RoutingDefault:
+		return RoutingDefault
+	case RoutingRoundRobin, RoutingLeastRecentlyUsed:
+		return strategy
+	default:
+		log.Printf("Warning: invalid SENDER_ROUTING_STRATEGY %q, using %s", value, RoutingDefault)
+		return RoutingDefault
+	}
+}
+
+// HistorySyncConfig controls the optional history/contact sync requested when
+// a sender is linked
+type HistorySyncConfig struct {
+	Enabled   bool // request a limited sync and enrich members with WhatsApp push names
+	DaysLimit int  // how many days of history the phone is asked to send
+}
+
+// LoadHistorySyncConfig reads history sync settings from the environment.
+//
+// HISTORY_SYNC_ENABLED defaults to false. HISTORY_SYNC_DAYS defaults to 7 and
+// only applies when the sync is enabled.
+func LoadHistorySyncConfig() HistorySyncConfig {
+	return HistorySyncConfig{
+		Enabled:   parseBoolEnv("HISTORY_SYNC_ENABLED"),
+		DaysLimit: parsePositiveIntEnv("HISTORY_SYNC_DAYS", 7),
+	}
+}
+
+// OnboardingConfig controls the nudge sent to unregistered contacts
+type OnboardingConfig struct {
+	Enabled      bool   // reply to unregistered contacts with the loyalty program intro
+	TenantSlug   string // tenant whose nudge template and interval are used; empty uses the built-in copy
+	IntervalDays int    // minimum days between nudges to the same number, unless the tenant overrides it
+}
+
+// LoadOnboardingConfig reads onboarding nudge settings from the environment.
+//
+// ONBOARDING_NUDGE_ENABLED defaults to false. ONBOARDING_TENANT is the slug of
+// the tenant served by this bot. ONBOARDING_NUDGE_INTERVAL_DAYS defaults to 7.
+func LoadOnboardingConfig() OnboardingConfig {
+	return OnboardingConfig{
+		Enabled:      parseBoolEnv("ONBOARDING_NUDGE_ENABLED"),
+		TenantSlug:   strings.TrimSpace(os.Getenv("ONBOARDING_TENANT")),
+		IntervalDays: parsePositiveIntEnv("ONBOARDING_NUDGE_INTERVAL_DAYS", 7),
+	}
+}
+
+// LoyaltyConfig selects the tenant whose loyalty program the bot runs
+type LoyaltyConfig struct {
+	TenantSlug     string // empty runs the points program only
+	RupiahPerPoint int    // spend that earns one point, used by price estimates
+}
+
+// LoadLoyaltyConfig reads the loyalty program tenant from the environment.
+//
+// LOYALTY_TENANT defaults to ONBOARDING_TENANT, which is the bot's tenant in
+// single-tenant deployments. POINTS_RUPIAH_PER_POINT defaults to 1000, one point
+// per Rp1.000 spent.
+func LoadLoyaltyConfig() LoyaltyConfig {
+	slug := strings.TrimSpace(os.Getenv("LOYALTY_TENANT"))
+	if slug == "" {
+		slug = LoadOnboardingConfig().TenantSlug
+	}
+	return LoyaltyConfig{
+		TenantSlug:     slug,
+		RupiahPerPoint: parsePositiveIntEnv("POINTS_RUPIAH_PER_POINT", 1000),
+	}
+}
+
+// ReplyLimitConfig caps how often the bot replies to a single contact
+type ReplyLimitConfig struct {
+	PerMinute int // replies allowed per contact in any one-minute window
+}
+
+// LoadReplyLimitConfig reads reply throttling settings from the environment.
+//
+// REPLY_LIMIT_PER_MINUTE defaults to 10, well above what a person types but low
+// enough to stop a reply loop with another bot before WhatsApp flags the sender.
+func LoadReplyLimitConfig() ReplyLimitConfig {
+	return ReplyLimitConfig{
+		PerMinute: parsePositiveIntEnv("REPLY_LIMIT_PER_MINUTE", 10),
+	}
+}
+
+// BroadcastConfig paces bulk broadcasts
+type BroadcastConfig struct {
+	PerMinute     int // broadcast messages sent per sender per minute
+	MaxRecipients int // largest broadcast accepted in one request
+}
+
+// LoadBroadcastConfig reads broadcast settings from the environment.
+//
+// BROADCAST_PER_MINUTE defaults to 20 per sender, a pace WhatsApp tolerates for
+// bulk sends; BROADCAST_MAX_RECIPIENTS defaults to 5000.
+func LoadBroadcastConfig() BroadcastConfig {
+	return BroadcastConfig{
+		PerMinute:     parsePositiveIntEnv("BROADCAST_PER_MINUTE", 20),
+		MaxRecipients: parsePositiveIntEnv("BROADCAST_MAX_RECIPIENTS", 5000),
+	}
+}
+
+// BatchSendConfig limits the send-messages batch endpoint
+type BatchSendConfig struct {
+	MaxMessages int // largest batch accepted in one request
+	Concurrency int // messages of a batch sent at the same time
+}
+
+// LoadBatchSendConfig reads batch send settings from the environment.
+//
+// SEND_BATCH_MAX_MESSAGES defaults to 100 and SEND_BATCH_CONCURRENCY to 5, so a
+// full batch usually completes within the HTTP write timeout.
+func LoadBatchSendConfig() BatchSendConfig {
+	return BatchSendConfig{
+		MaxMessages: parsePositiveIntEnv("SEND_BATCH_MAX_MESSAGES", 100),
+		Concurrency: parsePositiveIntEnv("SEND_BATCH_CONCURRENCY", 5),
+	}
+}
+
+// VoucherConfig controls the vouchers issued for points redemptions
+type VoucherConfig struct {
+	ValidityDays int // days a voucher can be used after it is issued
+}
+
+// LoadVoucherConfig reads voucher settings from the environment.
+//
+// VOUCHER_VALIDITY_DAYS defaults to 30.
+func LoadVoucherConfig() VoucherConfig {
+	return VoucherConfig{
+		ValidityDays: parsePositiveIntEnv("VOUCHER_VALIDITY_DAYS", 30),
+	}
+}
+
+// GroupConfig controls the WhatsApp groups the service manages
+type GroupConfig struct {
+	NewMemberGroupJID string        // group every newly registered member is added to; empty adds no one
+	VIPGroupJID       string        // group kept in sync with the members of VIPTiers; empty turns the sync off
+	VIPTiers          []string      // membership tiers that belong in the VIP group
+	VIPSyncInterval   time.Duration // how often the VIP group is synced; 0 syncs only on request
+	VIPSender         string        // sender that administers the VIP group; empty uses the default sender
+}
+
+// LoadGroupConfig reads group settings from the environment.
+//
+// NEW_MEMBER_GROUP_JID, e.g. 120363025246125486@g.us, names the group new
+// members join, such as a VIP customers group. The default sender must be an
+// admin of it.
+//
+// VIP_GROUP_JID names a group whose members are kept in line with the
+// members of VIP_GROUP_TIERS (Platinum by default), every
+// VIP_GROUP_SYNC_INTERVAL, e.g. 6h. VIP_GROUP_SENDER must be an admin of it.
+func LoadGroupConfig() GroupConfig {
+	return GroupConfig{
+		NewMemberGroupJID: strings.TrimSpace(os.Getenv("NEW_MEMBER_GROUP_JID")),
+		VIPGroupJID:       strings.TrimSpace(os.Getenv("VIP_GROUP_JID")),
+		VIPTiers:          parseCSVList(getEnv("VIP_GROUP_TIERS", "Platinum")),
+		VIPSyncInterval:   parseDurationEnv("VIP_GROUP_SYNC_INTERVAL", 0),
+		VIPSender:         strings.TrimPrefix(strings.TrimSpace(os.Getenv("VIP_GROUP_SENDER")), "+"),
+	}
+}
+
+// LabelConfig controls the WhatsApp Business labels applied automatically
+type LabelConfig struct {
+	AutoInterval  time.Duration // how often the automatic labels are applied; 0 turns them off
+	AutoSender    string        // sender whose chats are labeled; empty uses the default sender
+	NewLead       string        // label of prospects not yet registered; empty leaves it alone
+	VIP           string        // label of members in VIPTiers; empty leaves it alone
+	VIPTiers      []string      // membership tiers that earn the VIP label
+	PendingPickup string        // label of members with laundry ready for pickup; empty leaves it alone
+}
+
+// labelOff turns an automatic label off
+const labelOff = "none"
+
+// LoadLabelConfig reads label settings from the environment.
+//
+// AUTO_LABEL_INTERVAL, e.g. 1h, turns automatic labels on and AUTO_LABEL_SENDER
+// picks the sender, which must be a WhatsApp Business account.
+// AUTO_LABEL_NEW_LEAD, AUTO_LABEL_VIP and AUTO_LABEL_PENDING_PICKUP name the
+// labels ("New lead", "VIP" and "Pending pickup" by default); set one to none
+// to leave that label alone. AUTO_LABEL_VIP_TIERS defaults to Gold,Platinum.
+func LoadLabelConfig() LabelConfig {
+	cfg := LabelConfig{
+		AutoInterval:  parseDurationEnv("AUTO_LABEL_INTERVAL", 0),
+		AutoSender:    strings.TrimPrefix(strings.TrimSpace(os.Getenv("AUTO_LABEL_SENDER")), "+"),
+		NewLead:       strings.TrimSpace(getEnv("AUTO_LABEL_NEW_LEAD", "New lead")),
+		VIP:           strings.TrimSpace(getEnv("AUTO_LABEL_VIP", "VIP")),
+		VIPTiers:      parseCSVList(getEnv("AUTO_LABEL_VIP_TIERS", "Gold,Platinum")),
+		PendingPickup: strings.TrimSpace(getEnv("AUTO_LABEL_PENDING_PICKUP", "Pending pickup")),
+	}
+	for _, name := range []*string{&cfg.NewLead, &cfg.VIP, &cfg.PendingPickup} {
+		if strings.EqualFold(*name, labelOff) {
+			*name = ""
+		}
+	}
+	return cfg
+}
+
+// InventoryConfig controls the supplies inventory
+type InventoryConfig struct {
+	AlertPhones []string // admin numbers told when a supply runs low
+}
+
+// LoadInventoryConfig reads inventory settings from the environment.
+//
+// INVENTORY_ALERT_PHONES defaults to the sender alert phones.
+func LoadInventoryConfig() InventoryConfig {
+	alertPhones := parseCSVList(os.Getenv("INVENTORY_ALERT_PHONES"))
+	if len(alertPhones) == 0 {
+		alertPhones = LoadSenderConfig().AlertPhones
+	}
+	return InventoryConfig{AlertPhones: alertPhones}
+}
+
+// DuplicateSendConfig guards recipients against receiving the same message
+// twice in quick succession
+type DuplicateSendConfig struct {
+	Window time.Duration // how long an identical message to the same recipient is refused; 0 turns the guard off
+}
+
+// LoadDuplicateSendConfig reads the duplicate-send guard from the environment.
+//
+// DUPLICATE_SEND_WINDOW uses Go duration syntax such as "2m" and defaults to
+// 0, which turns the guard off.
+func LoadDuplicateSendConfig() DuplicateSendConfig {
+	return DuplicateSendConfig{
+		Window: parseDurationEnv("DUPLICATE_SEND_WINDOW", 0),
+	}
+}
+
+// SendQueueConfig controls the retry of outbound messages that failed to send
+type SendQueueConfig struct {
+	MaxAttempts  int           // sends tried per message, including the first, before it is marked failed
+	BaseDelay    time.Duration // wait before the first retry; doubled on each later one
+	MaxDelay     time.Duration // cap on the wait between retries
+	PollInterval time.Duration // how often the queue is checked for due retries
+
+	HoldWhileOffline bool          // queue sends made while no sender is connected until one reconnects, without using up attempts
+	MaxAge           time.Duration // how long a held message may wait before it expires unsent
+
+	Quota SendQuotaConfig // when daily send quotas reset, and whether sends past them are queued
+}
+
+// LoadSendQueueConfig reads send queue settings from the environment.
+//
+// SEND_RETRY_MAX_ATTEMPTS defaults to 8, SEND_RETRY_BASE_DELAY to 15s,
+// SEND_RETRY_MAX_DELAY to 30m and SEND_QUEUE_POLL_INTERVAL to 5s, so a message
+// keeps being retried for about an hour. SEND_QUEUE_WHILE_OFFLINE holds sends
+// made with no sender connected until one reconnects, for up to
+// SEND_QUEUE_MAX_AGE (default 24h).
+func LoadSendQueueConfig() SendQueueConfig {
+	return SendQueueConfig{
+		MaxAttempts:      parsePositiveIntEnv("SEND_RETRY_MAX_ATTEMPTS", 8),
+		BaseDelay:        parseDurationEnv("SEND_RETRY_BASE_DELAY", 15*time.Second),
+		MaxDelay:         parseDurationEnv("SEND_RETRY_MAX_DELAY", 30*time.Minute),
+		PollInterval:     parseDurationEnv("SEND_QUEUE_POLL_INTERVAL", 5*time.Second),
+		HoldWhileOffline: parseBoolEnv("SEND_QUEUE_WHILE_OFFLINE"),
+		MaxAge:           parseDurationEnv("SEND_QUEUE_MAX_AGE", 24*time.Hour),
+
+		Quota: LoadSendQuotaConfig(),
+	}
+}
+
+// SendQuotaConfig controls the per-sender daily send quotas set through the API
+type SendQuotaConfig struct {
+	Location      *time.Location // quotas reset at midnight here
+	QueueExceeded bool           // queue a text send past its sender's quota until the reset instead of refusing it
+}
+
+// LoadSendQuotaConfig reads send quota settings from the environment.
+//
+// SEND_QUOTA_TIMEZONE is an IANA time zone such as Asia/Jakarta and defaults
+// to UTC; invalid values are logged and UTC is used. SEND_QUOTA_EXCEEDED is
+// reject (the default) or queue.
+func LoadSendQuotaConfig() SendQuotaConfig {
+	cfg := SendQuotaConfig{Location: time.UTC}
+	if name := strings.TrimSpace(os.Getenv("SEND_QUOTA_TIMEZONE")); name != "" {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			log.Printf("Warning: invalid SEND_QUOTA_TIMEZONE %q, using UTC", name)
+		} else {
+			cfg.Location = location
+		}
+	}
+
+	switch exceeded := strings.ToLower(strings.TrimSpace(os.Getenv("SEND_QUOTA_EXCEEDED"))); exceeded {
+	case "", "reject":
+	case "queue":
+		cfg.QueueExceeded = true
+	default:
+		log.Printf("Warning: invalid SEND_QUOTA_EXCEEDED %q, using reject", exceeded)
+	}
+	return cfg
+}
+
+// StartOfDay returns the most recent midnight before t in the quota time zone
+func (c SendQuotaConfig) StartOfDay(t time.Time) time.Time {
+	location := c.Location
+	if location == nil {
+		location = time.UTC
+	}
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+}
+
+// Day returns the date t falls on in the quota time zone, as YYYY-MM-DD; the
+// sends of a sender are counted against its quota per such day
+func (c SendQuotaConfig) Day(t time.Time) string {
+	return c.StartOfDay(t).Format("2006-01-02")
+}
+
+// NextReset returns the first midnight after t in the quota time zone, when
+// the quotas of the day t falls in reset
+func (c SendQuotaConfig) NextReset(t time.Time) time.Time {
+	start := c.StartOfDay(t)
+	return time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, start.Location())
+}
+
+// LoopConfig controls detection of bot-to-bot reply loops
+type LoopConfig struct {
+	RepeatThreshold int           // identical fast messages in a row that mark a contact as a bot; twice this many fast messages of any text also do
+	FastReply       time.Duration // messages closer together than this are too fast for a person
+	MuteDuration    time.Duration // how long a suspected bot is ignored
+	AlertPhones     []string      // admin numbers told when a conversation is muted
+	AutoBlock       bool          // also block a suspected bot on the sender and suppress it
+}
+
+// LoadLoopConfig reads loop detection settings from the environment.
+//
+// LOOP_REPEAT_THRESHOLD defaults to 3, LOOP_FAST_REPLY to 3s and
+// LOOP_MUTE_DURATION to 1h. LOOP_ALERT_PHONES defaults to the sender alert phones.
+// LOOP_AUTO_BLOCK blocks suspected bots on WhatsApp and adds them to the
+// suppression list instead of only muting them.
+func LoadLoopConfig() LoopConfig {
+	alertPhones := parseCSVList(os.Getenv("LOOP_ALERT_PHONES"))
+	if len(alertPhones) == 0 {
+		alertPhones = LoadSenderConfig().AlertPhones
+	}
+	return LoopConfig{
+		RepeatThreshold: parsePositiveIntEnv("LOOP_REPEAT_THRESHOLD", 3),
+		FastReply:       parseDurationEnv("LOOP_FAST_REPLY", 3*time.Second),
+		MuteDuration:    parseDurationEnv("LOOP_MUTE_DURATION", time.Hour),
+		AlertPhones:     alertPhones,
+		AutoBlock:       parseBoolEnv("LOOP_AUTO_BLOCK"),
+	}
+}
+
+// RetentionConfig sets how long the rows of each operational table are kept
+// before the scheduled pruning job deletes them. Zero keeps them forever.
+type RetentionConfig struct {
+	Interval        time.Duration // how often old rows are pruned
+	MessageHistory  time.Duration
+	InboundEvents   time.Duration
+	MessageStatus   time.Duration
+	SendQueue       time.Duration // sent, failed and expired sends; pending ones are never pruned
+	IdempotencyKeys time.Duration
+	ShadowDiffs     time.Duration
+	SenderActivity  time.Duration
+	DeadLetters     time.Duration
+}
+
+// LoadRetentionConfig reads data retention settings from the environment.
+//
+// RETENTION_INTERVAL defaults to 24h. Each table's retention is a number of
+// days, 0 to keep its rows forever: RETENTION_MESSAGE_HISTORY_DAYS and
+// RETENTION_INBOUND_EVENTS_DAYS default to 90, RETENTION_MESSAGE_STATUS_DAYS,
+// RETENTION_SEND_QUEUE_DAYS and RETENTION_SHADOW_DIFFS_DAYS to 30,
+// RETENTION_IDEMPOTENCY_KEYS_DAYS to 7, RETENTION_SENDER_ACTIVITY_DAYS to 365
+// and RETENTION_DEAD_LETTERS_DAYS to 90.
+// Idempotency keys are kept at least as long as IDEMPOTENCY_KEY_TTL.
+func LoadRetentionConfig() RetentionConfig {
+	cfg := RetentionConfig{
+		Interval:        parseDurationEnv("RETENTION_INTERVAL", 24*time.Hour),
+		MessageHistory:  parseRetentionDaysEnv("RETENTION_MESSAGE_HISTORY_DAYS", 90),
+		InboundEvents:   parseRetentionDaysEnv("RETENTION_INBOUND_EVENTS_DAYS", 90),
+		MessageStatus:   parseRetentionDaysEnv("RETENTION_MESSAGE_STATUS_DAYS", 30),
+		SendQueue:       parseRetentionDaysEnv("RETENTION_SEND_QUEUE_DAYS", 30),
+		IdempotencyKeys: parseRetentionDaysEnv("RETENTION_IDEMPOTENCY_KEYS_DAYS", 7),
+		ShadowDiffs:     parseRetentionDaysEnv("RETENTION_SHADOW_DIFFS_DAYS", 30),
+		SenderActivity:  parseRetentionDaysEnv("RETENTION_SENDER_ACTIVITY_DAYS", 365),
+		DeadLetters:     parseRetentionDaysEnv("RETENTION_DEAD_LETTERS_DAYS", 90),
+	}
+	if ttl := LoadAPIConfig().IdempotencyTTL; cfg.IdempotencyKeys > 0 && cfg.IdempotencyKeys < ttl {
+		cfg.IdempotencyKeys = ttl
+	}
+	return cfg
+}
+
+// StorageConfig sets the storage limits GET /api/system/storage warns about.
+// A zero limit is not checked.
+type StorageConfig struct {
+	DatabaseLimitBytes int64
+	MediaLimitBytes    int64  // S3 bucket the bot uploads received images to
+	LogDir             string // directory of log files to measure; empty to skip
+	LogLimitBytes      int64
+	WarnPercent        int // warn once usage reaches this share of a limit
+}
+
+// LoadStorageConfig reads storage limits from the environment.
+//
+// STORAGE_DATABASE_LIMIT_MB defaults to 500, the database size cap of the
+// Supabase free plan. STORAGE_MEDIA_LIMIT_MB and STORAGE_LOG_LIMIT_MB default to
+// 0, no limit. STORAGE_LOG_DIR is unset by default. STORAGE_WARN_PERCENT
+// defaults to 80.
+func LoadStorageConfig() StorageConfig {
+	warnPercent := parsePositiveIntEnv("STORAGE_WARN_PERCENT", 80)
+	if warnPercent > 100 {
+		log.Printf("Invalid STORAGE_WARN_PERCENT %d, expected at most 100; using 80", warnPercent)
+		warnPercent = 80
+	}
+	return StorageConfig{
+		DatabaseLimitBytes: parseMegabytesEnv("STORAGE_DATABASE_LIMIT_MB", 500),
+		MediaLimitBytes:    parseMegabytesEnv("STORAGE_MEDIA_LIMIT_MB", 0),
+		LogDir:             strings.TrimSpace(os.Getenv("STORAGE_LOG_DIR")),
+		LogLimitBytes:      parseMegabytesEnv("STORAGE_LOG_LIMIT_MB", 0),
+		WarnPercent:        warnPercent,
+	}
+}
+
+// SessionEncryptionConfig holds the master keys that encrypt the WhatsApp
+// session keys at rest. Encryption is off when Key is empty.
+type SessionEncryptionConfig struct {
+	Key          []byte
+	PreviousKeys [][]byte // replaced keys, still accepted for decryption while rotating
+}
+
+// LoadSessionEncryptionConfig reads SESSION_ENCRYPTION_KEY, a base64-encoded
+// 32-byte master key, and SESSION_ENCRYPTION_PREVIOUS_KEYS, a comma-separated
+// list of the keys it replaced. Unlike other settings, an invalid key is an
+// error: falling back would store session keys unencrypted.
+func LoadSessionEncryptionConfig() (SessionEncryptionConfig, error) {
+	var cfg SessionEncryptionConfig
+	if value := strings.TrimSpace(os.Getenv("SESSION_ENCRYPTION_KEY")); value != "" {
+		key, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid SESSION_ENCRYPTION_KEY: %w", err)
+		}
+		cfg.Key = key
+	}
+	for _, value := range parseCSVList(os.Getenv("SESSION_ENCRYPTION_PREVIOUS_KEYS")) {
+		key, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid SESSION_ENCRYPTION_PREVIOUS_KEYS: %w", err)
+		}
+		cfg.PreviousKeys = append(cfg.PreviousKeys, key)
+	}
+	if cfg.Key == nil && cfg.PreviousKeys != nil {
+		return cfg, fmt.Errorf("SESSION_ENCRYPTION_PREVIOUS_KEYS is set without SESSION_ENCRYPTION_KEY")
+	}
+	return cfg, nil
+}
+
+// ConfirmationConfig controls the second factor destructive API actions need
+type ConfirmationConfig struct {
+	OwnerPhones []string      // numbers sent a one-time code on WhatsApp
+	TOTPSecret  []byte        // TOTP secret; when set, codes come from an authenticator app instead
+	TTL         time.Duration // how long a requested confirmation can be used
+}
+
+// LoadConfirmationConfig reads second-factor settings from the environment.
+//
+// CONFIRMATION_PHONES defaults to the sender alert phones.
+// CONFIRMATION_TOTP_SECRET is a base32 secret as shown by authenticator apps;
+// an invalid secret is logged and ignored. CONFIRMATION_TTL defaults to 5m.
+func LoadConfirmationConfig() ConfirmationConfig {
+	phones := parseCSVList(os.Getenv("CONFIRMATION_PHONES"))
+	if len(phones) == 0 {
+		phones = LoadSenderConfig().AlertPhones
+	}
+	cfg := ConfirmationConfig{
+		OwnerPhones: phones,
+		TTL:         parseDurationEnv("CONFIRMATION_TTL", 5*time.Minute),
+	}
+	value := strings.ToUpper(strings.Join(strings.Fields(os.Getenv("CONFIRMATION_TOTP_SECRET")), ""))
+	if value == "" {
+		return cfg
+	}
+	secret, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(value, "="))
+	if err != nil || len(secret) == 0 {
+		log.Printf("Warning: invalid CONFIRMATION_TOTP_SECRET, expected a base32 secret; using WhatsApp codes")
+		return cfg
+	}
+	cfg.TOTPSecret = secret
+	return cfg
+}
+
+// ReceiptConfig holds the letterhead of the PDF receipts sent to members
+type ReceiptConfig struct {
+	BusinessName string
+	Address      string
+	Phone        string
+	Footer       string
+}
+
+// LoadReceiptConfig reads receipt settings from the environment.
+//
+// RECEIPT_BUSINESS_NAME defaults to WhatsPoints Laundry and RECEIPT_FOOTER to a
+// thank-you note; RECEIPT_ADDRESS and RECEIPT_PHONE are left off when unset.
+func LoadReceiptConfig() ReceiptConfig {
+	return ReceiptConfig{
+		BusinessName: strings.TrimSpace(getEnv("RECEIPT_BUSINESS_NAME", "WhatsPoints Laundry")),
+		Address:      strings.TrimSpace(os.Getenv("RECEIPT_ADDRESS")),
+		Phone:        strings.TrimSpace(os.Getenv("RECEIPT_PHONE")),
+		Footer:       strings.TrimSpace(getEnv("RECEIPT_FOOTER", "Terima kasih telah mempercayakan cucian Anda kepada kami.")),
+	}
+}
+
+// SMTPConfig holds the mail server scheduled reports are emailed through
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // empty sends without authenticating
+	Password string
+	From     string
+}
+
+// Enabled reports whether email can be sent
+func (c SMTPConfig) Enabled() bool {
+	return c.Host != "" && c.From != ""
+}
+
+// LoadSMTPConfig reads mail server settings from the environment.
+//
+// Email is off until SMTP_HOST and SMTP_FROM are set. SMTP_PORT defaults to
+// 587, which upgrades to TLS with STARTTLS; port 465 connects over TLS.
+func LoadSMTPConfig() SMTPConfig {
+	return SMTPConfig{
+		Host:     strings.TrimSpace(os.Getenv("SMTP_HOST")),
+		Port:     parsePositiveIntEnv("SMTP_PORT", 587),
+		Username: strings.TrimSpace(os.Getenv("SMTP_USERNAME")),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     strings.TrimSpace(os.Getenv("SMTP_FROM")),
+	}
+}
+
+// StatusPageConfig holds the settings of the public status page
+type StatusPageConfig struct {
+	PerMinute int // requests each client may make per minute
+}
+
+// LoadStatusPageConfig reads status page settings from the environment.
+//
+// STATUS_PAGE_RATE_LIMIT caps the requests per minute from one IP address to
+// GET /status-page and defaults to 30.
+func LoadStatusPageConfig() StatusPageConfig {
+	return StatusPageConfig{
+		PerMinute: parsePositiveIntEnv("STATUS_PAGE_RATE_LIMIT", 30),
+	}
+}
+
+// UpdateCheckConfig points the version endpoint at a release feed, so the
+// dashboard can tell operators a newer build is out
+type UpdateCheckConfig struct {
+	FeedURL  string        // JSON feed of the latest release; empty disables the check
+	Interval time.Duration // how long the latest release is cached between checks
+}
+
+// LoadUpdateCheckConfig reads update check settings from the environment.
+//
+// UPDATE_CHECK_URL is a URL returning the latest release as JSON with a
+// tag_name or version field, such as GitHub's releases/latest API; unset
+// disables the check. UPDATE_CHECK_INTERVAL defaults to 6h.
+func LoadUpdateCheckConfig() UpdateCheckConfig {
+	return UpdateCheckConfig{
+		FeedURL:  strings.TrimSpace(os.Getenv("UPDATE_CHECK_URL")),
+		Interval: parseDurationEnv("UPDATE_CHECK_INTERVAL", 6*time.Hour),
+	}
+}
+
+// parseRateEnv parses a fraction between 0 and 1, returning 0 when unset or invalid
+func parseRateEnv(key string) float64 {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return 0
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 || rate > 1 {
+		log.Printf("Invalid %s %q, expected a value between 0 and 1; using 0", key, value)
+		return 0
+	}
+	return rate
+}
+
+// parsePercentEnv parses a whole percentage, returning 0 when unset or invalid
+func parsePercentEnv(key string) int {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return 0
+	}
+	percent, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+	if err != nil || percent < 0 || percent > 100 {
+		log.Printf("Invalid %s %q, expected a percentage between 0 and 100; using 0", key, value)
+		return 0
+	}
+	return percent
+}
+
+// parsePositiveIntEnv parses a positive integer, falling back to defaultValue
+func parsePositiveIntEnv(key string, defaultValue int) int {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s %q, expected a positive integer; using %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// parseDurationEnv parses a positive Go duration, falling back to defaultValue
+func parseDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s %q, using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+// parseRetentionDaysEnv parses a number of days as a duration. 0 means forever
+// and is returned as 0; an invalid value falls back to defaultDays.
+func parseRetentionDaysEnv(key string, defaultDays int) time.Duration {
+	value := strings.TrimSpace(os.Getenv(key))
+	days := defaultDays
+	if value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			log.Printf("Invalid %s %q, expected a number of days (0 keeps rows forever); using %d", key, value, defaultDays)
+		} else {
+			days = n
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// parseMegabytesEnv parses a size in megabytes as bytes. 0 is returned as 0; an
+// invalid value falls back to defaultMB.
+func parseMegabytesEnv(key string, defaultMB int64) int64 {
+	value := strings.TrimSpace(os.Getenv(key))
+	mb := defaultMB
+	if value != "" {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			log.Printf("Invalid %s %q, expected a size in megabytes (0 for no limit); using %d", key, value, defaultMB)
+		} else {
+			mb = n
+		}
+	}
+	return mb << 20
+}
+
 // parseBoolEnv treats true/1/yes/on (case-insensitive) as true; anything else false.
 func parseBoolEnv(key string) bool {
 	switch strings.ToLower(strings.TrimSpace(os.Getenv(key))) {
@@ -96,15 +1055,51 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// parseTrustedProxies parses a comma-separated list of IP addresses and CIDR
+// ranges, dropping the entries that are neither
+func parseTrustedProxies(csv string) []string {
+	var proxies []string
+	for _, proxy := range parseCSVList(csv) {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				log.Printf("Warning: invalid TRUSTED_PROXIES entry %q, expected an IP address or CIDR range", proxy)
+				continue
+			}
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies
+}
+
 // parseAllowedPhoneNumbers parses a comma-separated string into a map
 func parseAllowedPhoneNumbers(csv string) map[string]bool {
-	phoneNumbers := strings.Split(csv, ",")
-	allowed := make(map[string]bool)
-	for _, phone := range phoneNumbers {
-		trimmed := strings.TrimSpace(phone)
+	return parseCSVSet(csv)
+}
+
+// parseCSVList parses a comma-separated string into a slice, keeping order and
+// dropping blanks and duplicates
+func parseCSVList(csv string) []string {
+	var list []string
+	seen := make(map[string]bool)
+	for _, value := range strings.Split(csv, ",") {
+		trimmed := strings.TrimSpace(value)
+		if trimmed != "" && !seen[trimmed] {
+			seen[trimmed] = true
+			list = append(list, trimmed)
+		}
+	}
+	return list
+}
+
+// parseCSVSet parses a comma-separated string into a set, ignoring blank entries
+func parseCSVSet(csv string) map[string]bool {
+	values := strings.Split(csv, ",")
+	set := make(map[string]bool)
+	for _, value := range values {
+		trimmed := strings.TrimSpace(value)
 		if trimmed != "" {
-			allowed[trimmed] = true
+			set[trimmed] = true
 		}
 	}
-	return allowed
+	return set
 }
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"strings"
+)
+
+// summaryVars are the settings shown in the boot report, the ones support
+// asks about first when a deployment misbehaves
+var summaryVars = []string{
+	"APP_PROFILE",
+	"SUPABASE_HOST", "SUPABASE_PORT", "SUPABASE_USER", "SUPABASE_PASSWORD", "SUPABASE_DB", "SUPABASE_SSLMODE",
+	"API_PORT", "API_USERNAME", "API_PASSWORD", "API_UNVERSIONED_SUNSET",
+	"AWS_REGION", "S3_BUCKET_NAME", "AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY",
+	"SENDER_DEFAULT_PRIORITY", "SENDER_POOL", "SENDER_FAILOVER_DISABLED", "SENDER_ROUTING_STRATEGY",
+	"SAFE_SEND_RECIPIENTS", "SAFE_SEND_REDIRECT",
+	"SEND_QUEUE_WHILE_OFFLINE", "DUPLICATE_SEND_WINDOW", "REPLY_LIMIT_PER_MINUTE",
+	"ENABLE_AI_RESPONSE", "ENABLE_AI_AUTO_SEND", "AI_SERVICE_URL",
+	"WEBHOOK_URL", "WEBHOOK_SECRET", "STATUS_CALLBACK_URL", "STATUS_CALLBACK_SECRET",
+	"SMTP_HOST", "SMTP_PORT", "SMTP_USERNAME", "SMTP_PASSWORD",
+	"SESSION_ENCRYPTION_KEY", "CONFIRMATION_TOTP_SECRET",
+	"LOOP_AUTO_BLOCK", "FAULT_INJECTION_ENABLED", "SHADOW_ROUTER_ENABLED", "SCHEDULER_INTERVAL",
+}
+
+// maskedValue replaces a secret in the boot report
+const maskedValue = "********"
+
+// Summary returns the settings of summaryVars that are set. Passwords, secrets,
+// keys and tokens are masked, as are credentials inside URLs, so the summary
+// is safe to log and to hand to support.
+func Summary() map[string]string {
+	summary := make(map[string]string)
+	for _, name := range summaryVars {
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+		summary[name] = maskSetting(name, value)
+	}
+	return summary
+}
+
+// maskSetting returns value as it may be shown for the setting name
+func maskSetting(name, value string) string {
+	for _, secret := range []string{"PASSWORD", "SECRET", "KEY", "TOKEN"} {
+		if strings.Contains(name, secret) {
+			return maskedValue
+		}
+	}
+	if strings.HasSuffix(name, "_URL") {
+		if u, err := url.Parse(value); err == nil {
+			return u.Redacted()
+		}
+	}
+	return value
+}
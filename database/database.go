@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+
+	"github.com/wa-serv/repository"
 )
 
 // BuildPostgresConnectionString builds a PostgreSQL connection string from environment variables
@@ -28,6 +30,30 @@ func getSSLMode() string {
 	return sslMode
 }
 
+// ListTables returns the tables of the public schema, which the Init
+// functions create, sorted by name
+func ListTables(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE'
+		ORDER BY table_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, fmt.Errorf("failed to scan table: %w", err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
+
 // InitImageTable initializes the images table
 func InitImageTable(db *sql.DB) error {
 	query := `
@@ -39,7 +65,7 @@ func InitImageTable(db *sql.DB) error {
 			   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			   FOREIGN KEY (member_id) REFERENCES members(member_id)
 	   )`
-	_, err := db.Exec(query)
+	_, err := db.Exec(repository.DialectOf(db).Schema(query))
 	if err != nil {
 		return fmt.Errorf("failed to create images table: %w", err)
 	}
@@ -57,13 +83,52 @@ func InitMemberTable(db *sql.DB) error {
 			   created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	   )`
-	_, err := db.Exec(query)
+	_, err := db.Exec(repository.DialectOf(db).Schema(query))
 	if err != nil {
 		return fmt.Errorf("failed to create members table: %w", err)
 	}
 	return nil
 }
 
+// InitMemberPushNameColumn adds the WhatsApp push name captured from inbound
+// messages and history sync to the members table
+func InitMemberPushNameColumn(db *sql.DB) error {
+	err := repository.AddColumns(db, "members",
+		"push_name VARCHAR(100)",
+		"push_name_updated_at TIMESTAMP")
+	if err != nil {
+		return fmt.Errorf("failed to add member push name columns: %w", err)
+	}
+	return nil
+}
+
+// InitProspectsTable initializes the prospects table holding WhatsApp contacts
+// that have messaged the bot but are not registered members yet
+func InitProspectsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS prospects (
+		phone_number VARCHAR(20) PRIMARY KEY,
+		push_name VARCHAR(100),
+		first_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create prospects table: %w", err)
+	}
+
+	// Lead tracking: activity counters and the member the prospect became
+	err := repository.AddColumns(db, "prospects",
+		"message_count INT DEFAULT 0",
+		"last_intent VARCHAR(50)",
+		"converted_member_id INT REFERENCES members(member_id) ON DELETE SET NULL",
+		"converted_at TIMESTAMP",
+		"last_nudged_at TIMESTAMP")
+	if err != nil {
+		return fmt.Errorf("failed to add prospect tracking columns: %w", err)
+	}
+	return nil
+}
+
 // InitReceiptsTable initializes the receipts table
 func InitReceiptsTable(db *sql.DB) error {
 	query := `
@@ -99,7 +164,7 @@ func InitPointsTable(db *sql.DB) error {
 			   updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			   FOREIGN KEY (member_id) REFERENCES members(member_id)
 	   )`
-	_, err := db.Exec(query)
+	_, err := db.Exec(repository.DialectOf(db).Schema(query))
 	if err != nil {
 		return fmt.Errorf("failed to create points table: %w", err)
 	}
@@ -129,6 +194,26 @@ func InitPointTransactionsTable(db *sql.DB) error {
 	return nil
 }
 
+// InitPointTransactionStaffColumn records which admin phone credited a point
+// transaction, for the per-staff activity report
+func InitPointTransactionStaffColumn(db *sql.DB) error {
+	query := `
+	ALTER TABLE point_transactions
+		ADD COLUMN IF NOT EXISTS performed_by VARCHAR(30)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to add performed_by column to point_transactions: %w", err)
+	}
+
+	indexQuery := `
+	CREATE INDEX IF NOT EXISTS idx_point_transactions_performed_by
+		ON point_transactions (performed_by, transaction_date)
+		WHERE performed_by IS NOT NULL`
+	if _, err := db.Exec(indexQuery); err != nil {
+		return fmt.Errorf("failed to create point_transactions performed_by index: %w", err)
+	}
+	return nil
+}
+
 // InitItemsTable initializes the items table
 func InitItemsTable(db *sql.DB) error {
 	query := `
@@ -363,5 +448,939 @@ func InitSendersTable(db *sql.DB) error {
 	if err != nil {
 		return fmt.Errorf("failed to create senders table: %w", err)
 	}
+
+	// Account state reported by WhatsApp (active, restricted, banned)
+	alterQuery := `
+	ALTER TABLE senders
+		ADD COLUMN IF NOT EXISTS state VARCHAR(20) DEFAULT 'active',
+		ADD COLUMN IF NOT EXISTS state_reason TEXT,
+		ADD COLUMN IF NOT EXISTS state_until TIMESTAMP`
+	if _, err := db.Exec(alterQuery); err != nil {
+		return fmt.Errorf("failed to add sender state columns: %w", err)
+	}
+
+	// Labels operators give a sender, such as the branch it serves
+	profileQuery := `
+	ALTER TABLE senders
+		ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS tags TEXT NOT NULL DEFAULT ''`
+	if _, err := db.Exec(profileQuery); err != nil {
+		return fmt.Errorf("failed to add sender profile columns: %w", err)
+	}
+
+	// Senders paused by an operator stay connected but out of send rotation
+	pausedQuery := `ALTER TABLE senders ADD COLUMN IF NOT EXISTS is_paused BOOLEAN NOT NULL DEFAULT FALSE`
+	if _, err := db.Exec(pausedQuery); err != nil {
+		return fmt.Errorf("failed to add sender paused column: %w", err)
+	}
+
+	// What monitoring checks: the last send and the last lost connection
+	healthQuery := `
+	ALTER TABLE senders
+		ADD COLUMN IF NOT EXISTS last_sent_at TIMESTAMPTZ,
+		ADD COLUMN IF NOT EXISTS last_disconnect_reason TEXT NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS last_disconnected_at TIMESTAMPTZ`
+	if _, err := db.Exec(healthQuery); err != nil {
+		return fmt.Errorf("failed to add sender health columns: %w", err)
+	}
+
+	// Most messages a sender may send a day; 0 is no cap
+	quotaQuery := `ALTER TABLE senders ADD COLUMN IF NOT EXISTS daily_quota INT NOT NULL DEFAULT 0`
+	if _, err := db.Exec(quotaQuery); err != nil {
+		return fmt.Errorf("failed to add sender daily quota column: %w", err)
+	}
+
+	// The sends counted against daily_quota, per day in the quota time zone
+	dailySendsQuery := `
+	CREATE TABLE IF NOT EXISTS sender_daily_sends (
+		sender_id VARCHAR(50) NOT NULL,
+		day DATE NOT NULL,
+		sent INT NOT NULL DEFAULT 0,
+		PRIMARY KEY (sender_id, day)
+	)`
+	if _, err := db.Exec(dailySendsQuery); err != nil {
+		return fmt.Errorf("failed to create sender daily sends table: %w", err)
+	}
+	return nil
+}
+
+// InitTenantsTable initializes the tenants table used by tenant onboarding
+func InitTenantsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS tenants (
+		tenant_id SERIAL PRIMARY KEY,
+		slug VARCHAR(60) UNIQUE NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		owner_phone VARCHAR(30) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("failed to create tenants table: %w", err)
+	}
+
+	// Onboarding nudge settings; a NULL interval uses ONBOARDING_NUDGE_INTERVAL_DAYS
+	alterQuery := `
+	ALTER TABLE tenants
+		ADD COLUMN IF NOT EXISTS nudge_enabled BOOLEAN DEFAULT TRUE,
+		ADD COLUMN IF NOT EXISTS nudge_interval_days INT`
+	if _, err := db.Exec(alterQuery); err != nil {
+		return fmt.Errorf("failed to add tenant nudge columns: %w", err)
+	}
+	return nil
+}
+
+// InitTenantAdminsTable initializes the tenant_admins table
+func InitTenantAdminsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS tenant_admins (
+		admin_id SERIAL PRIMARY KEY,
+		tenant_id INTEGER NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		phone_number VARCHAR(30) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (tenant_id, phone_number),
+		FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id)
+	)`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("failed to create tenant_admins table: %w", err)
+	}
+	return nil
+}
+
+// InitTenantAPIKeysTable initializes the tenant_api_keys table.
+// Only a SHA-256 hash of each key is stored; the plaintext is shown once on creation.
+func InitTenantAPIKeysTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS tenant_api_keys (
+		key_id SERIAL PRIMARY KEY,
+		tenant_id INTEGER NOT NULL,
+		key_prefix VARCHAR(16) NOT NULL,
+		key_hash VARCHAR(64) UNIQUE NOT NULL,
+		label VARCHAR(100),
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id)
+	)`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("failed to create tenant_api_keys table: %w", err)
+	}
+	return nil
+}
+
+// InitRewardCatalogTable initializes the reward_catalog table
+func InitRewardCatalogTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS reward_catalog (
+		reward_id SERIAL PRIMARY KEY,
+		tenant_id INTEGER NOT NULL,
+		points INTEGER NOT NULL,
+		description TEXT NOT NULL,
+		is_active BOOLEAN DEFAULT TRUE,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (tenant_id, points),
+		FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id)
+	)`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("failed to create reward_catalog table: %w", err)
+	}
+	return nil
+}
+
+// InitTemplatesTable initializes the message_templates table
+func InitTemplatesTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS message_templates (
+		template_id SERIAL PRIMARY KEY,
+		tenant_id INTEGER NOT NULL,
+		name VARCHAR(60) NOT NULL,
+		body TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (tenant_id, name),
+		FOREIGN KEY (tenant_id) REFERENCES tenants(tenant_id)
+	)`
+	_, err := db.Exec(query)
+	if err != nil {
+		return fmt.Errorf("failed to create message_templates table: %w", err)
+	}
+
+	// Templates without a tenant are the global ones managed through /api/templates
+	if _, err := db.Exec(`ALTER TABLE message_templates ALTER COLUMN tenant_id DROP NOT NULL`); err != nil {
+		return fmt.Errorf("failed to allow global message templates: %w", err)
+	}
+	if _, err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_message_templates_global_name ON message_templates (name) WHERE tenant_id IS NULL`); err != nil {
+		return fmt.Errorf("failed to create message_templates index: %w", err)
+	}
+	return nil
+}
+
+// InitAutomationRulesTable initializes the automation_rules table holding the
+// operator-defined rules that send a template when a domain event matches
+func InitAutomationRulesTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS automation_rules (
+		rule_id SERIAL PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		event_type VARCHAR(50) NOT NULL,
+		condition TEXT NOT NULL DEFAULT '',
+		template_name VARCHAR(60) NOT NULL,
+		recipient VARCHAR(20) NOT NULL DEFAULT '',
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create automation_rules table: %w", err)
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_automation_rules_event ON automation_rules (event_type) WHERE is_active`); err != nil {
+		return fmt.Errorf("failed to create automation_rules index: %w", err)
+	}
+	return nil
+}
+
+// InitPointsCampaignsTable initializes the points_campaigns table holding
+// time-limited earning multipliers such as a double-points happy hour
+func InitPointsCampaignsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS points_campaigns (
+		campaign_id SERIAL PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		starts_at TIMESTAMP NOT NULL,
+		ends_at TIMESTAMP NOT NULL,
+		multiplier NUMERIC(4, 2) NOT NULL CHECK (multiplier > 1),
+		items TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		CHECK (ends_at > starts_at)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create points_campaigns table: %w", err)
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_points_campaigns_window ON points_campaigns (starts_at, ends_at)`); err != nil {
+		return fmt.Errorf("failed to create points_campaigns index: %w", err)
+	}
+	return nil
+}
+
+// InitStampCardsTable adds the per-tenant loyalty program choice and creates
+// the member_stamp_cards and stamp_transactions tables for the stamp card program
+func InitStampCardsTable(db *sql.DB) error {
+	alterQuery := `
+	ALTER TABLE tenants
+		ADD COLUMN IF NOT EXISTS loyalty_program VARCHAR(10) NOT NULL DEFAULT 'points',
+		ADD COLUMN IF NOT EXISTS stamp_card_size INT NOT NULL DEFAULT 10,
+		ADD COLUMN IF NOT EXISTS stamp_reward TEXT NOT NULL DEFAULT 'Gratis 1x cuci'`
+	if _, err := db.Exec(alterQuery); err != nil {
+		return fmt.Errorf("failed to add tenant loyalty program columns: %w", err)
+	}
+
+	query := `
+	CREATE TABLE IF NOT EXISTS member_stamp_cards (
+		member_id INTEGER PRIMARY KEY,
+		stamps INTEGER NOT NULL DEFAULT 0,
+		rewards_available INTEGER NOT NULL DEFAULT 0 CHECK (rewards_available >= 0),
+		cards_completed INTEGER NOT NULL DEFAULT 0,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (member_id) REFERENCES members(member_id)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create member_stamp_cards table: %w", err)
+	}
+
+	transactionsQuery := `
+	CREATE TABLE IF NOT EXISTS stamp_transactions (
+		transaction_id SERIAL PRIMARY KEY,
+		member_id INTEGER NOT NULL REFERENCES members(member_id),
+		stamps INTEGER NOT NULL,
+		transaction_type VARCHAR(10) NOT NULL,
+		performed_by VARCHAR(30) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(transactionsQuery); err != nil {
+		return fmt.Errorf("failed to create stamp_transactions table: %w", err)
+	}
+	return nil
+}
+
+// InitVouchersTable initializes the vouchers table holding the code issued for
+// each points redemption and when it was used at the counter
+func InitVouchersTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS vouchers (
+		voucher_id SERIAL PRIMARY KEY,
+		code VARCHAR(20) UNIQUE NOT NULL,
+		transaction_id INTEGER UNIQUE NOT NULL REFERENCES point_transactions(transaction_id),
+		member_id INTEGER NOT NULL REFERENCES members(member_id),
+		reward TEXT NOT NULL,
+		points INTEGER NOT NULL,
+		issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		redeemed_at TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create vouchers table: %w", err)
+	}
+
+	// Expiry and the branch that redeemed the voucher, for settlement reports
+	alterQuery := `
+	ALTER TABLE vouchers
+		ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP,
+		ADD COLUMN IF NOT EXISTS branch VARCHAR(60)`
+	if _, err := db.Exec(alterQuery); err != nil {
+		return fmt.Errorf("failed to add voucher expiry and branch columns: %w", err)
+	}
+	return nil
+}
+
+// InitSendQueueTable initializes the send_queue table of outbound messages
+// waiting to be retried after a transient send failure
+func InitSendQueueTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS send_queue (
+		queue_id BIGSERIAL PRIMARY KEY,
+		payload TEXT NOT NULL,
+		status VARCHAR(10) NOT NULL DEFAULT 'pending',
+		attempts INTEGER NOT NULL DEFAULT 0,
+		next_attempt_at TIMESTAMP NOT NULL,
+		last_error TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create send_queue table: %w", err)
+	}
+
+	// The X-Request-ID of the API call that queued the message, so retries are
+	// traced back to it
+	if _, err := db.Exec(`ALTER TABLE send_queue ADD COLUMN IF NOT EXISTS request_id VARCHAR(128) NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("failed to add request_id column to send_queue: %w", err)
+	}
+
+	indexQuery := `CREATE INDEX IF NOT EXISTS idx_send_queue_due ON send_queue (next_attempt_at) WHERE status = 'pending'`
+	if _, err := db.Exec(indexQuery); err != nil {
+		return fmt.Errorf("failed to create send_queue index: %w", err)
+	}
+	return nil
+}
+
+// InitDeadLettersTable initializes the dead_letters table of queued messages
+// that were given up on, kept for review and requeueing after send_queue rows
+// are pruned
+func InitDeadLettersTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS dead_letters (
+		dead_letter_id BIGSERIAL PRIMARY KEY,
+		queue_id BIGINT NOT NULL,
+		payload TEXT NOT NULL,
+		request_id VARCHAR(128) NOT NULL DEFAULT '',
+		status VARCHAR(10) NOT NULL,
+		attempts INTEGER NOT NULL,
+		last_error TEXT,
+		queued_at TIMESTAMP NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		requeued_at TIMESTAMP,
+		requeue_id BIGINT
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create dead_letters table: %w", err)
+	}
+	return nil
+}
+
+// InitMessageStatusTable initializes the message_status table tracking the
+// delivery of messages sent through the API, for status callbacks
+func InitMessageStatusTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS message_status (
+		message_id VARCHAR(100) PRIMARY KEY,
+		request_id VARCHAR(128) NOT NULL DEFAULT '',
+		recipient VARCHAR(64) NOT NULL,
+		status VARCHAR(10) NOT NULL DEFAULT 'sent',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create message_status table: %w", err)
+	}
+	return nil
+}
+
+// InitIdempotencyKeysTable initializes the idempotency_keys table holding the
+// result of each send request made with an Idempotency-Key header
+func InitIdempotencyKeysTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS idempotency_keys (
+		idem_key VARCHAR(255) PRIMARY KEY,
+		fingerprint CHAR(64) NOT NULL,
+		status_code INTEGER,
+		response TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		completed_at TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create idempotency_keys table: %w", err)
+	}
+	return nil
+}
+
+// InitSuppressionsTable initializes the suppressions table of recipients,
+// phone numbers or group JIDs, that opted out or were blocked and must not be
+// messaged
+func InitSuppressionsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS suppressions (
+		recipient VARCHAR(64) PRIMARY KEY,
+		reason VARCHAR(16) NOT NULL,
+		note TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create suppressions table: %w", err)
+	}
+	return nil
+}
+
+// InitWhatsAppLabelsTable initializes the whatsapp_labels and
+// whatsapp_label_chats tables, the WhatsApp Business labels of each sender and
+// the chats they are on, as last synced from WhatsApp
+func InitWhatsAppLabelsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS whatsapp_labels (
+		sender_id VARCHAR(50) NOT NULL,
+		label_id VARCHAR(20) NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		color INTEGER NOT NULL DEFAULT 0,
+		deleted BOOLEAN NOT NULL DEFAULT FALSE,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (sender_id, label_id)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create whatsapp_labels table: %w", err)
+	}
+
+	chatsQuery := `
+	CREATE TABLE IF NOT EXISTS whatsapp_label_chats (
+		sender_id VARCHAR(50) NOT NULL,
+		label_id VARCHAR(20) NOT NULL,
+		chat_jid VARCHAR(100) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (sender_id, label_id, chat_jid)
+	)`
+	if _, err := db.Exec(chatsQuery); err != nil {
+		return fmt.Errorf("failed to create whatsapp_label_chats table: %w", err)
+	}
+	return nil
+}
+
+// InitDisappearingTimersTable initializes the disappearing_timers table, the
+// disappearing-message timer each sender's chats are set to
+func InitDisappearingTimersTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS disappearing_timers (
+		sender_id VARCHAR(50) NOT NULL,
+		chat_jid VARCHAR(100) NOT NULL,
+		seconds INTEGER NOT NULL,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (sender_id, chat_jid)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create disappearing_timers table: %w", err)
+	}
+	return nil
+}
+
+// InitMessageHistoryTable initializes the message_history table, the audit
+// trail of every message sent through the API
+func InitMessageHistoryTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS message_history (
+		history_id BIGSERIAL PRIMARY KEY,
+		recipient VARCHAR(64) NOT NULL,
+		sender_id VARCHAR(50) NOT NULL DEFAULT '',
+		kind VARCHAR(20) NOT NULL,
+		content TEXT NOT NULL DEFAULT '',
+		status VARCHAR(10) NOT NULL,
+		message_id VARCHAR(100) NOT NULL DEFAULT '',
+		error TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		sent_at TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create message_history table: %w", err)
+	}
+
+	// Group recipients are stored as JIDs, longer than the phone numbers the
+	// column first held
+	if _, err := db.Exec(`ALTER TABLE message_history ALTER COLUMN recipient TYPE VARCHAR(64)`); err != nil {
+		return fmt.Errorf("failed to widen message_history recipient: %w", err)
+	}
+
+	// The X-Request-ID of the API call that sent the message
+	if _, err := db.Exec(`ALTER TABLE message_history ADD COLUMN IF NOT EXISTS request_id VARCHAR(128) NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("failed to add request_id column to message_history: %w", err)
+	}
+
+	// A fingerprint of the content, kept even when a tenant's privacy mode
+	// leaves the content itself out
+	if _, err := db.Exec(`
+	ALTER TABLE message_history
+		ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64) NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS content_length INT NOT NULL DEFAULT 0`); err != nil {
+		return fmt.Errorf("failed to add content fingerprint columns to message_history: %w", err)
+	}
+
+	// Whether the tenant's message log keeps message content ('full') or only
+	// its fingerprint ('private')
+	if _, err := db.Exec(`ALTER TABLE tenants ADD COLUMN IF NOT EXISTS message_log_mode VARCHAR(10) NOT NULL DEFAULT 'full'`); err != nil {
+		return fmt.Errorf("failed to add tenant message log mode column: %w", err)
+	}
+
+	// The text a message had before each edit, oldest first per message
+	if _, err := db.Exec(`
+	CREATE TABLE IF NOT EXISTS message_edits (
+		edit_id BIGSERIAL PRIMARY KEY,
+		message_id VARCHAR(100) NOT NULL,
+		previous_content TEXT NOT NULL DEFAULT '',
+		content TEXT NOT NULL DEFAULT '',
+		content_hash VARCHAR(64) NOT NULL DEFAULT '',
+		content_length INT NOT NULL DEFAULT 0,
+		request_id VARCHAR(128) NOT NULL DEFAULT '',
+		edited_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("failed to create message_edits table: %w", err)
+	}
+
+	indexQueries := []string{
+		`CREATE INDEX IF NOT EXISTS idx_message_edits_message_id ON message_edits (message_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_message_history_created_at ON message_history (created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_message_history_recipient ON message_history (recipient, created_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_message_history_request_id ON message_history (request_id) WHERE request_id <> ''`,
+	}
+	for _, indexQuery := range indexQueries {
+		if _, err := db.Exec(indexQuery); err != nil {
+			return fmt.Errorf("failed to create message_history index: %w", err)
+		}
+	}
+	return nil
+}
+
+// InitBranchesTable initializes the branches table holding the store directory
+// customers browse with the CABANG command
+func InitBranchesTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS branches (
+		branch_id SERIAL PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		address TEXT NOT NULL,
+		hours VARCHAR(200) NOT NULL DEFAULT '',
+		whatsapp_number VARCHAR(20) NOT NULL DEFAULT '',
+		latitude DOUBLE PRECISION NOT NULL,
+		longitude DOUBLE PRECISION NOT NULL,
+		maps_url TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create branches table: %w", err)
+	}
+	return nil
+}
+
+// InitSuppliesTables initializes the supplies inventory: the supplies with their
+// stock, how much of each a catalog item uses, and the ledger of stock changes
+func InitSuppliesTables(db *sql.DB) error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS supplies (
+			supply_id SERIAL PRIMARY KEY,
+			name VARCHAR(100) NOT NULL UNIQUE,
+			unit VARCHAR(20) NOT NULL,
+			stock NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (stock >= 0),
+			low_stock_threshold NUMERIC(12, 2) NOT NULL DEFAULT 0,
+			low_alerted BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS supply_usage (
+			item_id INTEGER NOT NULL REFERENCES items(item_id) ON DELETE CASCADE,
+			supply_id INTEGER NOT NULL REFERENCES supplies(supply_id) ON DELETE CASCADE,
+			per_kilo NUMERIC(10, 3) NOT NULL DEFAULT 0,
+			per_unit NUMERIC(10, 3) NOT NULL DEFAULT 0,
+			PRIMARY KEY (item_id, supply_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS stock_movements (
+			movement_id SERIAL PRIMARY KEY,
+			supply_id INTEGER NOT NULL REFERENCES supplies(supply_id) ON DELETE CASCADE,
+			change NUMERIC(12, 2) NOT NULL,
+			reason VARCHAR(20) NOT NULL,
+			order_id INTEGER REFERENCES orders(order_id),
+			note TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		// An order's supplies are consumed once, however often it is processed
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_movements_order ON stock_movements (order_id, supply_id) WHERE order_id IS NOT NULL`,
+	}
+	for _, query := range queries {
+		if _, err := db.Exec(query); err != nil {
+			return fmt.Errorf("failed to create supplies tables: %w", err)
+		}
+	}
+	return nil
+}
+
+// InitMemberLocationsTable initializes the member_locations table holding each
+// member's last shared pickup/delivery pin, and adds pickup coordinates to orders
+func InitMemberLocationsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS member_locations (
+		member_id INTEGER PRIMARY KEY,
+		latitude DOUBLE PRECISION NOT NULL,
+		longitude DOUBLE PRECISION NOT NULL,
+		label TEXT,
+		address TEXT,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (member_id) REFERENCES members(member_id)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create member_locations table: %w", err)
+	}
+
+	alterQuery := `
+	ALTER TABLE orders
+		ADD COLUMN IF NOT EXISTS pickup_latitude DOUBLE PRECISION,
+		ADD COLUMN IF NOT EXISTS pickup_longitude DOUBLE PRECISION`
+	if _, err := db.Exec(alterQuery); err != nil {
+		return fmt.Errorf("failed to add pickup location columns to orders: %w", err)
+	}
+	return nil
+}
+
+// InitDriversTable initializes the drivers table and adds delivery tracking
+// columns (status, assigned driver) to orders
+func InitDriversTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS drivers (
+		driver_id SERIAL PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		phone_number VARCHAR(30) UNIQUE NOT NULL,
+		is_active BOOLEAN DEFAULT TRUE,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create drivers table: %w", err)
+	}
+
+	alterQuery := `
+	ALTER TABLE orders
+		ADD COLUMN IF NOT EXISTS status VARCHAR(30) DEFAULT 'pending',
+		ADD COLUMN IF NOT EXISTS driver_id INTEGER REFERENCES drivers(driver_id)`
+	if _, err := db.Exec(alterQuery); err != nil {
+		return fmt.Errorf("failed to add delivery columns to orders: %w", err)
+	}
+	return nil
+}
+
+// InitPickupSlotsTable initializes the pickup slot and booking tables
+func InitPickupSlotsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS pickup_slots (
+		slot_id SERIAL PRIMARY KEY,
+		starts_at TIMESTAMP NOT NULL,
+		ends_at TIMESTAMP NOT NULL,
+		capacity INTEGER NOT NULL CHECK (capacity > 0),
+		booked_count INTEGER NOT NULL DEFAULT 0,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (starts_at)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create pickup_slots table: %w", err)
+	}
+
+	bookingsQuery := `
+	CREATE TABLE IF NOT EXISTS pickup_bookings (
+		booking_id SERIAL PRIMARY KEY,
+		slot_id INTEGER NOT NULL REFERENCES pickup_slots(slot_id),
+		member_id INTEGER NOT NULL REFERENCES members(member_id),
+		status VARCHAR(20) NOT NULL DEFAULT 'booked',
+		reminder_sent_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		UNIQUE (slot_id, member_id)
+	)`
+	if _, err := db.Exec(bookingsQuery); err != nil {
+		return fmt.Errorf("failed to create pickup_bookings table: %w", err)
+	}
+	return nil
+}
+
+// InitOrderIntakeColumns adds the pickup slot an order placed on WhatsApp was
+// booked for. Requires the orders and pickup_slots tables.
+func InitOrderIntakeColumns(db *sql.DB) error {
+	query := `
+	ALTER TABLE orders
+		ADD COLUMN IF NOT EXISTS pickup_slot_id INTEGER REFERENCES pickup_slots(slot_id)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to add pickup slot column to orders: %w", err)
+	}
+	return nil
+}
+
+// InitReminderTables initializes the reminder rule tables, adds redemption claim
+// tracking and seeds the default rules on first run
+func InitReminderTables(db *sql.DB) error {
+	rulesQuery := `
+	CREATE TABLE IF NOT EXISTS reminder_rules (
+		rule_id SERIAL PRIMARY KEY,
+		name VARCHAR(100) UNIQUE NOT NULL,
+		trigger_type VARCHAR(30) NOT NULL,
+		delay_hours INTEGER NOT NULL,
+		repeat_hours INTEGER NOT NULL DEFAULT 24,
+		max_reminders INTEGER NOT NULL DEFAULT 1,
+		escalate_after INTEGER NOT NULL DEFAULT 0,
+		message TEXT NOT NULL,
+		is_active BOOLEAN DEFAULT TRUE,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(rulesQuery); err != nil {
+		return fmt.Errorf("failed to create reminder_rules table: %w", err)
+	}
+
+	deliveriesQuery := `
+	CREATE TABLE IF NOT EXISTS reminder_deliveries (
+		rule_id INTEGER NOT NULL REFERENCES reminder_rules(rule_id),
+		subject_id INTEGER NOT NULL,
+		member_id INTEGER NOT NULL,
+		reminders_sent INTEGER NOT NULL DEFAULT 0,
+		last_sent_at TIMESTAMP,
+		escalated_at TIMESTAMP,
+		PRIMARY KEY (rule_id, subject_id)
+	)`
+	if _, err := db.Exec(deliveriesQuery); err != nil {
+		return fmt.Errorf("failed to create reminder_deliveries table: %w", err)
+	}
+
+	if _, err := db.Exec(`ALTER TABLE point_transactions ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP`); err != nil {
+		return fmt.Errorf("failed to add claimed_at to point_transactions: %w", err)
+	}
+
+	seedQuery := `
+	INSERT INTO reminder_rules (name, trigger_type, delay_hours, repeat_hours, max_reminders, escalate_after, message)
+	VALUES
+		('order-ready-pickup', 'order_ready', 48, 24, 3, 3,
+			'Halo {{name}}, pesanan #{{subject_id}} Anda sudah siap diambil. Silakan ambil di outlet kami.'),
+		('reward-unclaimed', 'reward_unclaimed', 72, 48, 2, 2,
+			'Halo {{name}}, hadiah Anda ({{detail}}) belum diambil. Silakan ambil di outlet kami.')
+	ON CONFLICT (name) DO NOTHING`
+	if _, err := db.Exec(seedQuery); err != nil {
+		return fmt.Errorf("failed to seed reminder rules: %w", err)
+	}
+	return nil
+}
+
+// InitInboundEventsTable initializes the inbound_events table, an append-only log
+// of every inbound WhatsApp command used for replay and debugging
+func InitInboundEventsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS inbound_events (
+		event_id SERIAL PRIMARY KEY,
+		message_id VARCHAR(100),
+		sender_jid VARCHAR(100) NOT NULL,
+		raw_text TEXT,
+		command VARCHAR(30) NOT NULL,
+		args TEXT,
+		outcome VARCHAR(10) NOT NULL,
+		error_message TEXT,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create inbound_events table: %w", err)
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_inbound_events_sender ON inbound_events (sender_jid, created_at)`); err != nil {
+		return fmt.Errorf("failed to create inbound_events index: %w", err)
+	}
+	// The soft-launch variant the conversation was given, empty outside a soft launch
+	if _, err := db.Exec(`ALTER TABLE inbound_events ADD COLUMN IF NOT EXISTS variant VARCHAR(30) NOT NULL DEFAULT ''`); err != nil {
+		return fmt.Errorf("failed to add variant column to inbound_events: %w", err)
+	}
+	return nil
+}
+
+// InitShadowDiffsTable initializes the shadow_diffs table, where disagreements
+// between the live and a shadow handler implementation are recorded
+func InitShadowDiffsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS shadow_diffs (
+		diff_id SERIAL PRIMARY KEY,
+		component VARCHAR(50) NOT NULL,
+		message_id VARCHAR(100),
+		raw_text TEXT,
+		live_result TEXT NOT NULL,
+		shadow_result TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create shadow_diffs table: %w", err)
+	}
+	return nil
+}
+
+// InitSenderFallbackTable initializes sender_fallback_chains, the ordered list
+// of senders tried for each message category (requires the senders table)
+func InitSenderFallbackTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS sender_fallback_chains (
+		category VARCHAR(50) NOT NULL,
+		position INT NOT NULL,
+		sender_id VARCHAR(50) NOT NULL REFERENCES senders(sender_id) ON DELETE CASCADE,
+		PRIMARY KEY (category, position),
+		UNIQUE (category, sender_id)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create sender_fallback_chains table: %w", err)
+	}
+	return nil
+}
+
+// InitSenderPoolsTable initializes sender_pools, the named groups of senders
+// such as "sales" a send can target (requires the senders table)
+func InitSenderPoolsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS sender_pools (
+		name VARCHAR(50) NOT NULL,
+		position INT NOT NULL,
+		sender_id VARCHAR(50) NOT NULL REFERENCES senders(sender_id) ON DELETE CASCADE,
+		PRIMARY KEY (name, position),
+		UNIQUE (name, sender_id)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create sender_pools table: %w", err)
+	}
+	return nil
+}
+
+// InitSenderActivityTable initializes sender_activity, the hourly count of
+// messages each sender sent and received
+func InitSenderActivityTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS sender_activity (
+		sender_id VARCHAR(50) NOT NULL,
+		hour TIMESTAMP NOT NULL,
+		sent INT NOT NULL DEFAULT 0,
+		received INT NOT NULL DEFAULT 0,
+		PRIMARY KEY (sender_id, hour)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create sender_activity table: %w", err)
+	}
+	return nil
+}
+
+// InitDeviceKeysTable creates the table holding the sealed private keys of
+// WhatsApp devices when session encryption is on. The whatsmeow_device row
+// then keeps zeroed placeholders in their place.
+func InitDeviceKeysTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS device_keys (
+		jid TEXT PRIMARY KEY,
+		sealed_keys BYTEA NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create device_keys table: %w", err)
+	}
+	return nil
+}
+
+// InitReportSchedulesTable creates the table of report schedules, each
+// delivering a spreadsheet of reports daily or weekly
+func InitReportSchedulesTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS report_schedules (
+		schedule_id SERIAL PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		reports TEXT NOT NULL,
+		frequency VARCHAR(10) NOT NULL,
+		send_hour INTEGER NOT NULL,
+		weekday INTEGER NOT NULL DEFAULT 0,
+		whatsapp_recipients TEXT NOT NULL DEFAULT '',
+		email_recipients TEXT NOT NULL DEFAULT '',
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		last_run_at TIMESTAMPTZ,
+		last_error TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create report_schedules table: %w", err)
+	}
+	return nil
+}
+
+// InitIncidentsTable initializes the incidents announced on the public status page
+func InitIncidentsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS incidents (
+		incident_id SERIAL PRIMARY KEY,
+		title VARCHAR(200) NOT NULL,
+		message TEXT NOT NULL DEFAULT '',
+		status VARCHAR(20) NOT NULL,
+		started_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+		resolved_at TIMESTAMPTZ
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create incidents table: %w", err)
+	}
+	return nil
+}
+
+// InitGroupSyncChangesTable initializes the log of the VIP group sync's
+// membership changes
+func InitGroupSyncChangesTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS group_sync_changes (
+		change_id SERIAL PRIMARY KEY,
+		group_jid VARCHAR(100) NOT NULL,
+		phone_number VARCHAR(20) NOT NULL,
+		action VARCHAR(20) NOT NULL,
+		error TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create group_sync_changes table: %w", err)
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_group_sync_changes_group ON group_sync_changes (group_jid, created_at)`); err != nil {
+		return fmt.Errorf("failed to create group_sync_changes index: %w", err)
+	}
+	return nil
+}
+
+// InitNotificationPreferencesTables initializes notification_preferences, the
+// email and webhook of each admin who chose how to be alerted, and
+// notification_alert_channels, the channels each admin gets each alert type on
+func InitNotificationPreferencesTables(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS notification_preferences (
+		phone_number VARCHAR(30) PRIMARY KEY,
+		email VARCHAR(255) NOT NULL DEFAULT '',
+		webhook_url TEXT NOT NULL DEFAULT '',
+		updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create notification_preferences table: %w", err)
+	}
+
+	query = `
+	CREATE TABLE IF NOT EXISTS notification_alert_channels (
+		phone_number VARCHAR(30) NOT NULL REFERENCES notification_preferences(phone_number) ON DELETE CASCADE,
+		alert_type VARCHAR(30) NOT NULL,
+		channel VARCHAR(20) NOT NULL,
+		PRIMARY KEY (phone_number, alert_type, channel)
+	)`
+	if _, err := db.Exec(query); err != nil {
+		return fmt.Errorf("failed to create notification_alert_channels table: %w", err)
+	}
 	return nil
 }
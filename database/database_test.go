@@ -2,45 +2,245 @@ package database
 
 import (
 	"database/sql"
+	"os"
 	"testing"
 
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
+	"github.com/wa-serv/repository"
 )
 
-func setupTestDB() (*sql.DB, error) {
-	db, err := sql.Open("sqlite3", ":memory:")
+// testDatabases opens the databases the repository is tested against: an
+// in-memory SQLite database, and Postgres when TEST_POSTGRES_URL is set
+func testDatabases(t *testing.T) map[string]*sql.DB {
+	t.Helper()
+	dbs := make(map[string]*sql.DB)
+
+	sqlite, err := sql.Open("sqlite3", ":memory:")
 	if err != nil {
-		return nil, err
+		t.Fatalf("Failed to open SQLite: %v", err)
 	}
+	dbs["sqlite"] = sqlite
 
-	// Initialize tables
-	if err := InitMemberTable(db); err != nil {
-		return nil, err
+	if url := os.Getenv("TEST_POSTGRES_URL"); url != "" {
+		postgres, err := sql.Open("postgres", url)
+		if err != nil {
+			t.Fatalf("Failed to open Postgres: %v", err)
+		}
+		dbs["postgres"] = postgres
+	} else {
+		t.Log("TEST_POSTGRES_URL is not set; testing against SQLite only")
 	}
-	if err := InitImageTable(db); err != nil {
-		return nil, err
+
+	for name, db := range dbs {
+		t.Cleanup(func() { db.Close() })
+		for _, init := range []func(*sql.DB) error{InitMemberTable, InitMemberPushNameColumn, InitPointsTable, InitProspectsTable, InitImageTable} {
+			if err := init(db); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
 	}
-	return db, nil
+	return dbs
 }
 
 func TestSaveImageURL(t *testing.T) {
-	db, err := setupTestDB()
-	if err != nil {
-		t.Fatalf("Failed to set up test database: %v", err)
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			dialect := repository.DialectOf(db)
+			phone := "1234567890"
+			db.Exec(dialect.Rebind("DELETE FROM images WHERE member_id IN (SELECT member_id FROM members WHERE phone_number = $1)"), phone)
+			db.Exec(dialect.Rebind("DELETE FROM members WHERE phone_number = $1"), phone)
+
+			// Insert a test member
+			_, err := db.Exec(dialect.Rebind("INSERT INTO members (phone_number, name, address) VALUES ($1, $2, $3)"), phone, "Test User", "Test Address")
+			if err != nil {
+				t.Fatalf("Failed to insert test member: %v", err)
+			}
+
+			// Get the member ID, which the database numbers itself
+			var memberID int
+			err = db.QueryRow(dialect.Rebind("SELECT member_id FROM members WHERE phone_number = $1"), phone).Scan(&memberID)
+			if err != nil {
+				t.Fatalf("Failed to retrieve member ID: %v", err)
+			}
+
+			imageURL := "https://example.com/receipt.jpg"
+			if err := repository.SaveImageURL(db, memberID, imageURL); err != nil {
+				t.Fatalf("Failed to save image URL: %v", err)
+			}
+
+			var savedURL string
+			err = db.QueryRow(dialect.Rebind("SELECT image_url FROM images WHERE member_id = $1"), memberID).Scan(&savedURL)
+			if err != nil {
+				t.Fatalf("Failed to retrieve saved image URL: %v", err)
+			}
+			if savedURL != imageURL {
+				t.Errorf("Expected image URL %s, got %s", imageURL, savedURL)
+			}
+		})
 	}
-	defer db.Close()
+}
 
-	// Insert a test member
-	_, err = db.Exec("INSERT INTO members (phone_number, name, address) VALUES (?, ?, ?)", "1234567890", "Test User", "Test Address")
-	if err != nil {
-		t.Fatalf("Failed to insert test member: %v", err)
+func TestRebind_KeepsArgumentOrder(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			// $2 is used before $1, which SQLite's $N named parameters get wrong
+			var first, second string
+			query := repository.DialectOf(db).Rebind("SELECT CAST($2 AS TEXT), CAST($1 AS TEXT)")
+			if err := db.QueryRow(query, "a", "b").Scan(&first, &second); err != nil {
+				t.Fatalf("Failed to query: %v", err)
+			}
+			if first != "b" || second != "a" {
+				t.Errorf("Expected b, a, got %s, %s", first, second)
+			}
+		})
 	}
+}
 
-	// Get the member ID
-	var memberID int
-	err = db.QueryRow("SELECT member_id FROM members WHERE phone_number = ?", "1234567890").Scan(&memberID)
-	if err != nil {
-		t.Fatalf("Failed to retrieve member ID: %v", err)
+// deleteTestMember removes the member with phone and what registration created
+// for them, so tests can run again against a persistent Postgres
+func deleteTestMember(db *sql.DB, phone string) {
+	dialect := repository.DialectOf(db)
+	db.Exec(dialect.Rebind("UPDATE prospects SET converted_member_id = NULL WHERE phone_number = $1"), phone)
+	db.Exec(dialect.Rebind("DELETE FROM prospects WHERE phone_number = $1"), phone)
+	db.Exec(dialect.Rebind("DELETE FROM points WHERE member_id IN (SELECT member_id FROM members WHERE phone_number = $1)"), phone)
+	db.Exec(dialect.Rebind("DELETE FROM members WHERE phone_number = $1"), phone)
+}
+
+func TestRegisterMember(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			phone := "6281200000001"
+			deleteTestMember(db, phone)
+			t.Cleanup(func() { deleteTestMember(db, phone) })
+
+			if err := repository.RegisterMember(db, "Budi", "Jl. Merdeka No. 1", phone); err != nil {
+				t.Fatalf("Failed to register member: %v", err)
+			}
+
+			registered, err := repository.IsMemberRegistered(db, phone)
+			if err != nil || !registered {
+				t.Fatalf("Expected the member to be registered, got %v, %v", registered, err)
+			}
+
+			// Registration starts the member on zero points in the points table
+			memberID, err := repository.GetMemberIDByPhoneNumber(db, phone)
+			if err != nil {
+				t.Fatalf("Failed to look up member ID: %v", err)
+			}
+			var accumulated, current int
+			err = db.QueryRow(repository.DialectOf(db).Rebind("SELECT accumulated_points, current_points FROM points WHERE member_id = $1"), memberID).Scan(&accumulated, &current)
+			if err != nil {
+				t.Fatalf("Expected a points row for the member: %v", err)
+			}
+			if accumulated != 0 || current != 0 {
+				t.Errorf("Expected 0 points, got %d accumulated and %d current", accumulated, current)
+			}
+
+			// A number registers once
+			if err := repository.RegisterMember(db, "Budi", "Jl. Merdeka No. 1", phone); err == nil {
+				t.Error("Expected registering the same number twice to fail")
+			}
+		})
+	}
+}
+
+func TestRegisterMember_ConvertsProspect(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			phone := "6281200000002"
+			deleteTestMember(db, phone)
+			t.Cleanup(func() { deleteTestMember(db, phone) })
+			dialect := repository.DialectOf(db)
+
+			_, err := db.Exec(dialect.Rebind("INSERT INTO prospects (phone_number, push_name) VALUES ($1, $2)"), phone, "Budi WA")
+			if err != nil {
+				t.Fatalf("Failed to insert prospect: %v", err)
+			}
+
+			if err := repository.RegisterMember(db, "Budi", "Jl. Merdeka No. 1", phone); err != nil {
+				t.Fatalf("Failed to register member: %v", err)
+			}
+
+			memberID, err := repository.GetMemberIDByPhoneNumber(db, phone)
+			if err != nil {
+				t.Fatalf("Failed to look up member ID: %v", err)
+			}
+			var pushName string
+			var convertedID int
+			err = db.QueryRow(dialect.Rebind(`
+				SELECT m.push_name, p.converted_member_id
+				FROM members m JOIN prospects p ON p.phone_number = m.phone_number
+				WHERE m.member_id = $1`), memberID).Scan(&pushName, &convertedID)
+			if err != nil {
+				t.Fatalf("Failed to read member and prospect: %v", err)
+			}
+			if pushName != "Budi WA" {
+				t.Errorf("Expected the prospect's push name to carry over, got %q", pushName)
+			}
+			if convertedID != memberID {
+				t.Errorf("Expected the prospect converted to member %d, got %d", memberID, convertedID)
+			}
+		})
+	}
+}
+
+func TestMemberLookups(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			phone := "6281200000003"
+			deleteTestMember(db, phone)
+			t.Cleanup(func() { deleteTestMember(db, phone) })
+
+			if registered, err := repository.IsMemberRegistered(db, phone); err != nil || registered {
+				t.Fatalf("Expected an unknown number to be unregistered, got %v, %v", registered, err)
+			}
+			if _, err := repository.GetMemberIDByPhoneNumber(db, phone); err == nil {
+				t.Error("Expected looking up an unknown number to fail")
+			}
+			if profile, err := repository.GetMemberProfile(db, phone); err != nil || profile != nil {
+				t.Fatalf("Expected no profile for an unknown number, got %v, %v", profile, err)
+			}
+
+			if err := repository.RegisterMember(db, "Budi", "Jl. Merdeka No. 1", phone); err != nil {
+				t.Fatalf("Failed to register member: %v", err)
+			}
+
+			memberID, memberName, err := repository.GetMemberDetailsByPhoneNumber(db, phone)
+			if err != nil {
+				t.Fatalf("Failed to look up member details: %v", err)
+			}
+			if memberName != "Budi" {
+				t.Errorf("Expected name Budi, got %s", memberName)
+			}
+			if name, err := repository.GetMemberNameByID(db, memberID); err != nil || name != "Budi" {
+				t.Errorf("Expected name Budi by ID, got %q, %v", name, err)
+			}
+
+			updated, err := repository.UpdateMemberProfile(db, phone, "Budi Santoso", "Jl. Sudirman No. 2")
+			if err != nil || !updated {
+				t.Fatalf("Expected the profile to be updated, got %v, %v", updated, err)
+			}
+			profile, err := repository.GetMemberProfile(db, phone)
+			if err != nil || profile == nil {
+				t.Fatalf("Expected a profile, got %v, %v", profile, err)
+			}
+			if profile.MemberID != memberID || profile.Name != "Budi Santoso" || profile.Address != "Jl. Sudirman No. 2" {
+				t.Errorf("Unexpected profile %+v", profile)
+			}
+		})
 	}
+}
 
+func TestInitTables_RunTwice(t *testing.T) {
+	for name, db := range testDatabases(t) {
+		t.Run(name, func(t *testing.T) {
+			// Every startup runs the migrations again over the existing columns
+			for _, init := range []func(*sql.DB) error{InitMemberPushNameColumn, InitProspectsTable} {
+				if err := init(db); err != nil {
+					t.Errorf("Expected the migration to be repeatable: %v", err)
+				}
+			}
+		})
+	}
 }
@@ -0,0 +1,163 @@
+// Package envelope encrypts secrets at rest with envelope encryption: every
+// value is encrypted with its own random data key, and the data key with a
+// master key held outside the database. A database dump alone does not reveal
+// the values, and the master key can be rotated without re-encrypting them
+// all at once.
+package envelope
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// KeySize is the size of master keys and data keys: AES-256
+const KeySize = 32
+
+// Layout of a sealed value: magic, master key ID, the data key encrypted with
+// the master key, then the value encrypted with the data key
+const (
+	magicString    = "WPE1"
+	keyIDSize      = 8
+	nonceSize      = 12
+	tagSize        = 16
+	wrappedKeySize = nonceSize + KeySize + tagSize
+	headerSize     = len(magicString) + keyIDSize + wrappedKeySize
+)
+
+var magic = []byte(magicString)
+
+var (
+	ErrInvalidKey = errors.New("master key must be 32 bytes")
+	ErrNotSealed  = errors.New("value is not sealed")
+	ErrUnknownKey = errors.New("value is sealed with an unknown master key")
+)
+
+type masterKey struct {
+	id   [keyIDSize]byte
+	aead cipher.AEAD
+}
+
+// Sealer seals values with the current master key and opens values sealed
+// with the current or a previous one
+type Sealer struct {
+	current *masterKey
+	keys    map[[keyIDSize]byte]*masterKey
+}
+
+// NewSealer creates a sealer for current, still able to open values sealed
+// with the previous master keys
+func NewSealer(current []byte, previous ...[]byte) (*Sealer, error) {
+	s := &Sealer{keys: make(map[[keyIDSize]byte]*masterKey)}
+	for i, key := range append([][]byte{current}, previous...) {
+		mk, err := newMasterKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			s.current = mk
+		}
+		if _, ok := s.keys[mk.id]; !ok {
+			s.keys[mk.id] = mk
+		}
+	}
+	return s, nil
+}
+
+func newMasterKey(key []byte) (*masterKey, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	mk := &masterKey{aead: aead}
+	copy(mk.id[:], sum[:keyIDSize])
+	return mk, nil
+}
+
+// IsSealed reports whether value looks like a sealed value rather than
+// plaintext written before sealing was turned on
+func IsSealed(value []byte) bool {
+	return len(value) >= headerSize+nonceSize+tagSize && bytes.HasPrefix(value, magic)
+}
+
+// Seal encrypts plaintext. label binds the sealed value to where it is stored;
+// the same label must be given to open it.
+func (s *Sealer) Seal(plaintext, label []byte) ([]byte, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := make([]byte, 0, headerSize+nonceSize+len(plaintext)+tagSize)
+	sealed = append(sealed, magic...)
+	sealed = append(sealed, s.current.id[:]...)
+	if sealed, err = appendSealed(sealed, s.current.aead, dataKey, label); err != nil {
+		return nil, err
+	}
+	return appendSealed(sealed, dataAEAD, plaintext, label)
+}
+
+// Open decrypts a value sealed with Seal under the same label
+func (s *Sealer) Open(sealed, label []byte) ([]byte, error) {
+	if !IsSealed(sealed) {
+		return nil, ErrNotSealed
+	}
+	var id [keyIDSize]byte
+	copy(id[:], sealed[len(magic):])
+	mk, ok := s.keys[id]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	wrapped := sealed[len(magic)+keyIDSize : headerSize]
+	dataKey, err := mk.aead.Open(nil, wrapped[:nonceSize], wrapped[nonceSize:], label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	data := sealed[headerSize:]
+	plaintext, err := dataAEAD.Open(nil, data[:nonceSize], data[nonceSize:], label)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plaintext, nil
+}
+
+// NeedsRotation reports whether sealed was sealed with a previous master key
+// and should be sealed again
+func (s *Sealer) NeedsRotation(sealed []byte) bool {
+	return IsSealed(sealed) && !bytes.Equal(sealed[len(magic):len(magic)+keyIDSize], s.current.id[:])
+}
+
+// appendSealed appends a random nonce and plaintext encrypted under it to dst
+func appendSealed(dst []byte, aead cipher.AEAD, plaintext, label []byte) ([]byte, error) {
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	dst = append(dst, nonce...)
+	return aead.Seal(dst, nonce, plaintext, label), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,84 @@
+package envelope
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func TestSealer_RoundTrip(t *testing.T) {
+	sealer, err := NewSealer(testKey(1))
+	require.NoError(t, err)
+
+	sealed, err := sealer.Seal([]byte("signal session"), []byte("sessions:6281234567890"))
+	require.NoError(t, err)
+
+	assert.True(t, IsSealed(sealed))
+	assert.NotContains(t, string(sealed), "signal session")
+	opened, err := sealer.Open(sealed, []byte("sessions:6281234567890"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("signal session"), opened)
+}
+
+func TestSealer_SealsEachValueDifferently(t *testing.T) {
+	sealer, err := NewSealer(testKey(1))
+	require.NoError(t, err)
+
+	first, err := sealer.Seal([]byte("same"), nil)
+	require.NoError(t, err)
+	second, err := sealer.Seal([]byte("same"), nil)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestSealer_Open_Rejects(t *testing.T) {
+	sealer, err := NewSealer(testKey(1))
+	require.NoError(t, err)
+	sealed, err := sealer.Seal([]byte("secret"), []byte("device:1"))
+	require.NoError(t, err)
+
+	other, err := NewSealer(testKey(2))
+	require.NoError(t, err)
+	tampered := append([]byte(nil), sealed...)
+	tampered[len(tampered)-1] ^= 1
+
+	_, err = sealer.Open(sealed, []byte("device:2"))
+	assert.Error(t, err, "a value moved to another label does not open")
+	_, err = sealer.Open(tampered, []byte("device:1"))
+	assert.Error(t, err)
+	_, err = other.Open(sealed, []byte("device:1"))
+	assert.Equal(t, ErrUnknownKey, err)
+	_, err = sealer.Open([]byte("plaintext"), nil)
+	assert.Equal(t, ErrNotSealed, err)
+}
+
+func TestSealer_Rotation(t *testing.T) {
+	old, err := NewSealer(testKey(1))
+	require.NoError(t, err)
+	sealed, err := old.Seal([]byte("secret"), nil)
+	require.NoError(t, err)
+
+	rotated, err := NewSealer(testKey(2), testKey(1))
+	require.NoError(t, err)
+
+	opened, err := rotated.Open(sealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), opened)
+	assert.True(t, rotated.NeedsRotation(sealed))
+	assert.False(t, old.NeedsRotation(sealed))
+}
+
+func TestNewSealer_InvalidKey(t *testing.T) {
+	_, err := NewSealer([]byte("too short"))
+	assert.Equal(t, ErrInvalidKey, err)
+
+	_, err = NewSealer(testKey(1), []byte("too short"))
+	assert.Equal(t, ErrInvalidKey, err)
+}
@@ -0,0 +1,127 @@
+// Package eventbus is an in-process publish/subscribe bus for domain events.
+// Processors publish what happened (a member registered, points changed, a
+// sender went down) and side effects such as webhooks, metrics or automations
+// subscribe to it, so adding a consumer does not mean editing the handlers.
+package eventbus
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Domain event types
+const (
+	MemberRegistered = "member.registered"
+	MemberUpdated    = "member.updated"
+	// PointsChanged carries a "reason" of ReasonEarned or ReasonRedeemed
+	PointsChanged = "points.changed"
+	TierChanged   = "tier.changed"
+	// StampCardCompleted fires when stamps fill one or more stamp cards
+	StampCardCompleted = "stamp.card_completed"
+	// OrderConfirmed fires when staff confirm an order a member placed in chat
+	OrderConfirmed = "order.confirmed"
+	// MessageSent fires for every message sent through the API
+	MessageSent = "message.sent"
+	// MessageExpired fires when a message held in the send queue for a sender
+	// to reconnect expires unsent
+	MessageExpired = "message.expired"
+	// MessageFailed fires when a text or interactive message is given up on
+	// after its last send attempt
+	MessageFailed = "message.failed"
+	// MessageStatus fires when WhatsApp reports that sent messages were
+	// delivered or read; it carries a "status" of StatusDelivered or StatusRead
+	MessageStatus = "message.status"
+	// SenderDown fires when WhatsApp bans or restricts a sender
+	SenderDown           = "sender.down"
+	DefaultSenderChanged = "sender.default_changed"
+)
+
+// Reasons carried by PointsChanged events
+const (
+	ReasonEarned   = "earned"
+	ReasonRedeemed = "redeemed"
+)
+
+// Statuses carried by MessageStatus events
+const (
+	StatusDelivered = "delivered"
+	StatusRead      = "read"
+)
+
+// DataRequestID is the data key carrying the X-Request-ID of the API call an
+// event happened in, when it happened in one
+const DataRequestID = "request_id"
+
+// All subscribes a handler to every event type
+const All = "*"
+
+// Event is one occurrence of a domain event
+type Event struct {
+	Type string
+	Data map[string]any
+	Time time.Time
+}
+
+// Handler receives published events. Handlers run on the publisher's
+// goroutine, so anything slow must hand the work off.
+type Handler func(Event)
+
+// Bus delivers published events to the handlers subscribed to their type
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// New creates an empty bus
+func New() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers h for eventType, or for every type when eventType is All
+func (b *Bus) Subscribe(eventType string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], h)
+}
+
+// Publish delivers an event to its subscribers in registration order. A
+// panicking handler is logged and does not stop the others.
+func (b *Bus) Publish(eventType string, data map[string]any) {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers[eventType])+len(b.handlers[All]))
+	handlers = append(handlers, b.handlers[eventType]...)
+	handlers = append(handlers, b.handlers[All]...)
+	b.mu.RUnlock()
+
+	evt := Event{Type: eventType, Data: data, Time: time.Now().UTC()}
+	for _, h := range handlers {
+		deliver(h, evt)
+	}
+}
+
+func deliver(h Handler, evt Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Event %s subscriber panicked: %v", evt.Type, r)
+		}
+	}()
+	h(evt)
+}
+
+var defaultBus = New()
+
+// Default returns the process-wide bus used by Subscribe and Publish
+func Default() *Bus {
+	return defaultBus
+}
+
+// Subscribe registers h on the default bus
+func Subscribe(eventType string, h Handler) {
+	defaultBus.Subscribe(eventType, h)
+}
+
+// Publish sends an event through the default bus
+func Publish(eventType string, data map[string]any) {
+	defaultBus.Publish(eventType, data)
+}
@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_Publish_DeliversToSubscribersOfType(t *testing.T) {
+	bus := New()
+	var got []string
+	bus.Subscribe(MemberRegistered, func(evt Event) { got = append(got, "member:"+evt.Data["name"].(string)) })
+	bus.Subscribe(PointsChanged, func(evt Event) { got = append(got, "points") })
+	bus.Subscribe(All, func(evt Event) { got = append(got, "all:"+evt.Type) })
+
+	bus.Publish(MemberRegistered, map[string]any{"name": "Budi"})
+
+	assert.Equal(t, []string{"member:Budi", "all:" + MemberRegistered}, got)
+}
+
+func TestBus_Publish_SetsTime(t *testing.T) {
+	bus := New()
+	var got Event
+	bus.Subscribe(SenderDown, func(evt Event) { got = evt })
+
+	bus.Publish(SenderDown, nil)
+
+	assert.Equal(t, SenderDown, got.Type)
+	assert.False(t, got.Time.IsZero())
+}
+
+func TestBus_Publish_RecoversPanickingSubscriber(t *testing.T) {
+	bus := New()
+	delivered := false
+	bus.Subscribe(MessageSent, func(Event) { panic("boom") })
+	bus.Subscribe(MessageSent, func(Event) { delivered = true })
+
+	assert.NotPanics(t, func() { bus.Publish(MessageSent, nil) })
+	assert.True(t, delivered)
+}
+
+func TestBus_Publish_NoSubscribers(t *testing.T) {
+	assert.NotPanics(t, func() { New().Publish(TierChanged, map[string]any{}) })
+}
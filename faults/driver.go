@@ -0,0 +1,75 @@
+package faults
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// WrapDriver returns a database/sql driver that consults inj before every
+// query, exec, prepare and transaction begin on connections from d
+func WrapDriver(d driver.Driver, inj *Injector) driver.Driver {
+	return &faultyDriver{Driver: d, inj: inj}
+}
+
+type faultyDriver struct {
+	driver.Driver
+	inj *Injector
+}
+
+func (d *faultyDriver) Open(name string) (driver.Conn, error) {
+	if err := d.inj.DB(context.Background()); err != nil {
+		return nil, err
+	}
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &faultyConn{Conn: conn, inj: d.inj}, nil
+}
+
+type faultyConn struct {
+	driver.Conn
+	inj *Injector
+}
+
+func (c *faultyConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if err := c.inj.DB(ctx); err != nil {
+		return nil, err
+	}
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *faultyConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if err := c.inj.DB(ctx); err != nil {
+		return nil, err
+	}
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *faultyConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := c.inj.DB(ctx); err != nil {
+		return nil, err
+	}
+	return e.ExecContext(ctx, query, args)
+}
+
+func (c *faultyConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	if err := c.inj.DB(ctx); err != nil {
+		return nil, err
+	}
+	return q.QueryContext(ctx, query, args)
+}
@@ -0,0 +1,103 @@
+// Package faults injects simulated failures (DB timeouts, WhatsApp send
+// failures, delayed receipts) for resilience testing in staging. It is inert
+// unless FAULT_INJECTION_ENABLED is set.
+package faults
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/wa-serv/config"
+)
+
+var (
+	ErrInjectedDBTimeout   = errors.New("fault injection: simulated database timeout")
+	ErrInjectedSendFailure = errors.New("fault injection: simulated WhatsApp send failure")
+)
+
+// Injector decides when to inject faults. A nil or disabled Injector never
+// injects anything, so call sites need no guards.
+type Injector struct {
+	cfg    config.FaultConfig
+	mu     sync.Mutex
+	random func() float64
+}
+
+// NewInjector creates an injector from cfg
+func NewInjector(cfg config.FaultConfig) *Injector {
+	return &Injector{cfg: cfg, random: rand.Float64}
+}
+
+// Enabled reports whether any fault may be injected
+func (i *Injector) Enabled() bool {
+	return i != nil && i.cfg.Enabled
+}
+
+// DB is called before each database operation. It adds the configured delay
+// and fails a fraction of calls with ErrInjectedDBTimeout.
+func (i *Injector) DB(ctx context.Context) error {
+	if !i.Enabled() {
+		return nil
+	}
+	return i.inject(ctx, i.cfg.DBDelay, i.cfg.DBErrorRate, ErrInjectedDBTimeout)
+}
+
+// Send is called before each outgoing WhatsApp message. It adds the configured
+// delay and fails a fraction of sends with ErrInjectedSendFailure.
+func (i *Injector) Send(ctx context.Context) error {
+	if !i.Enabled() {
+		return nil
+	}
+	return i.inject(ctx, i.cfg.SendDelay, i.cfg.SendFailureRate, ErrInjectedSendFailure)
+}
+
+// ReceiptDelay is how long receipt events should be held back before handling
+func (i *Injector) ReceiptDelay() time.Duration {
+	if !i.Enabled() {
+		return 0
+	}
+	return i.cfg.ReceiptDelay
+}
+
+func (i *Injector) inject(ctx context.Context, delay time.Duration, rate float64, fault error) error {
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if rate > 0 && i.roll() < rate {
+		return fault
+	}
+	return nil
+}
+
+func (i *Injector) roll() float64 {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.random()
+}
+
+// Default injector, built once from env on first use
+var (
+	defaultOnce     sync.Once
+	defaultInjector *Injector
+)
+
+// Default returns the environment-configured injector
+func Default() *Injector {
+	defaultOnce.Do(func() {
+		cfg := config.LoadFaultConfig()
+		defaultInjector = NewInjector(cfg)
+		if cfg.Enabled {
+			log.Printf("⚠ FAULT INJECTION ENABLED: db_error_rate=%.2f db_delay=%s send_failure_rate=%.2f send_delay=%s receipt_delay=%s",
+				cfg.DBErrorRate, cfg.DBDelay, cfg.SendFailureRate, cfg.SendDelay, cfg.ReceiptDelay)
+		}
+	})
+	return defaultInjector
+}
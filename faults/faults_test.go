@@ -0,0 +1,83 @@
+package faults
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/config"
+)
+
+func TestInjector_DisabledNeverInjects(t *testing.T) {
+	var nilInjector *Injector
+	assert.False(t, nilInjector.Enabled())
+	assert.NoError(t, nilInjector.DB(context.Background()))
+
+	disabled := NewInjector(config.FaultConfig{DBErrorRate: 1, SendFailureRate: 1, ReceiptDelay: time.Second})
+	assert.NoError(t, disabled.DB(context.Background()))
+	assert.NoError(t, disabled.Send(context.Background()))
+	assert.Zero(t, disabled.ReceiptDelay())
+}
+
+func TestInjector_Rates(t *testing.T) {
+	inj := NewInjector(config.FaultConfig{Enabled: true, DBErrorRate: 0.5, SendFailureRate: 1})
+
+	inj.random = func() float64 { return 0.4 }
+	assert.Equal(t, ErrInjectedDBTimeout, inj.DB(context.Background()))
+
+	inj.random = func() float64 { return 0.6 }
+	assert.NoError(t, inj.DB(context.Background()))
+	assert.Equal(t, ErrInjectedSendFailure, inj.Send(context.Background()))
+}
+
+func TestInjector_DelayRespectsContext(t *testing.T) {
+	inj := NewInjector(config.FaultConfig{Enabled: true, DBDelay: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, inj.DB(ctx))
+}
+
+type stubConn struct{ execs int }
+
+func (c *stubConn) Prepare(string) (driver.Stmt, error) { return nil, nil }
+func (c *stubConn) Close() error                        { return nil }
+func (c *stubConn) Begin() (driver.Tx, error)           { return nil, nil }
+func (c *stubConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	c.execs++
+	return driver.RowsAffected(1), nil
+}
+
+type stubDriver struct{ conn *stubConn }
+
+func (d stubDriver) Open(string) (driver.Conn, error) { return d.conn, nil }
+
+func TestWrapDriver_InjectsBeforeExec(t *testing.T) {
+	stub := &stubConn{}
+	inj := NewInjector(config.FaultConfig{Enabled: true, DBErrorRate: 0.5})
+	inj.random = func() float64 { return 0.9 }
+
+	conn, err := WrapDriver(stubDriver{conn: stub}, inj).Open("dsn")
+	assert.NoError(t, err)
+	execer := conn.(driver.ExecerContext)
+
+	_, err = execer.ExecContext(context.Background(), "UPDATE x", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stub.execs)
+
+	inj.random = func() float64 { return 0.1 }
+	_, err = execer.ExecContext(context.Background(), "UPDATE x", nil)
+	assert.Equal(t, ErrInjectedDBTimeout, err)
+	assert.Equal(t, 1, stub.execs, "the real driver must not be called when a fault is injected")
+}
+
+func TestWrapDriver_SkipsWhenUnderlyingLacksQueryer(t *testing.T) {
+	conn, err := WrapDriver(stubDriver{conn: &stubConn{}}, NewInjector(config.FaultConfig{Enabled: true})).Open("dsn")
+	assert.NoError(t, err)
+
+	_, err = conn.(driver.QueryerContext).QueryContext(context.Background(), "SELECT 1", nil)
+	assert.Equal(t, driver.ErrSkip, err)
+}
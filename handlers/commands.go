@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	cmd "github.com/wa-serv/command"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Inbound command names, as recorded in the inbound_events log
+const (
+	cmdImage              = "image"
+	cmdLocation           = "location"
+	cmdMenu               = "menu"
+	cmdCheckPoints        = "check_points"
+	cmdRedeemInstructions = "redeem_instructions"
+	cmdRewards            = "rewards"
+	cmdPickupSlots        = "pickup_slots"
+	cmdPickupBooking      = "pickup_booking"
+	cmdUpsertPoints       = "upsert_points"
+	cmdRedeemPoints       = "redeem_points"
+	cmdDriverStatus       = "driver_status"
+	cmdStampCard          = "stamp_card"
+	cmdAddStamps          = "add_stamps"
+	cmdClaimStampReward   = "claim_stamp_reward"
+	cmdBranches           = "branches"
+	cmdBranchDetails      = "branch_details"
+	cmdOrderStart         = "order_start"
+	cmdOrderReply         = "order_reply"
+	cmdPriceEstimate      = "price_estimate"
+	cmdRegistration       = "registration"
+	cmdRegistrationUpdate = "registration_update"
+	cmdPing               = "ping"
+	cmdHelp               = "help"
+	cmdAIReply            = "ai_reply"
+
+	// Messages handled by a plugin are recorded as plugin:<name>
+	cmdPluginPrefix = "plugin:"
+)
+
+// classifyCommand maps an inbound message to the command that handles it.
+// msgText must already be lower-cased and trimmed.
+func classifyCommand(v *events.Message, msgText string) string {
+	switch {
+	case v.Message.GetImageMessage() != nil:
+		return cmdImage
+	case v.Message.GetLocationMessage() != nil:
+		return cmdLocation
+	case processor.IsRegistrationConfirmation(v.Info.Sender.String(), msgText):
+		return cmdRegistrationUpdate
+	case processor.IsOrderFlowReply(v.Info.Sender.String(), msgText):
+		return cmdOrderReply
+	}
+	return classifyText(msgText)
+}
+
+// classifyText maps a text message to its command
+func classifyText(msgText string) string {
+	switch {
+	case msgText == "menu":
+		return cmdMenu
+	case msgText == "1":
+		return cmdCheckPoints
+	case msgText == "2":
+		return cmdRedeemInstructions
+	case msgText == "3":
+		return cmdRewards
+	case msgText == "4":
+		return cmdPickupSlots
+	case msgText == "5":
+		return cmdStampCard
+	case processor.IsPickupBookingCommand(msgText):
+		return cmdPickupBooking
+	case isUpsertPointsCommand(msgText):
+		return cmdUpsertPoints
+	case isRedeemPointsCommand(msgText):
+		return cmdRedeemPoints
+	case processor.IsDriverStatusCommand(msgText):
+		return cmdDriverStatus
+	case strings.HasPrefix(msgText, "stempel#"):
+		return cmdAddStamps
+	case strings.HasPrefix(msgText, "klaim#"):
+		return cmdClaimStampReward
+	case strings.HasPrefix(msgText, "reg#"):
+		return cmdRegistration
+	case msgText == "cabang":
+		return cmdBranches
+	case strings.HasPrefix(msgText, "cabang#"):
+		return cmdBranchDetails
+	case processor.IsOrderStartCommand(msgText):
+		return cmdOrderStart
+	case processor.IsPriceEstimateCommand(msgText):
+		return cmdPriceEstimate
+	case msgText == "ping":
+		return cmdPing
+	case msgText == "help":
+		return cmdHelp
+	default:
+		return cmdAIReply
+	}
+}
+
+// parseCommandArgs extracts the parsed interpretation of a command, e.g. the
+// points of a RED# command. Parse failures are recorded as a "parse_error" arg.
+func parseCommandArgs(command, msgText string) map[string]string {
+	parts := strings.Split(msgText, "#")
+	args := map[string]string{}
+	switch command {
+	case cmdUpsertPoints:
+		return parseSpecArgs(cmd.UpsertPoints, msgText)
+	case cmdRedeemPoints:
+		return parseSpecArgs(cmd.Redeem, msgText)
+	case cmdRegistration:
+		return parseSpecArgs(cmd.Registration, msgText)
+	case cmdAddStamps:
+		return parseSpecArgs(cmd.AddStamps, msgText)
+	case cmdClaimStampReward:
+		return parseSpecArgs(cmd.ClaimStampReward, msgText)
+	case cmdBranchDetails:
+		return parseSpecArgs(cmd.BranchDetails, msgText)
+	case cmdPickupBooking:
+		if len(parts) == 2 {
+			args["slot_id"] = parts[1]
+		}
+	case cmdDriverStatus:
+		orderID, status, err := processor.ParseDriverStatusReply(msgText)
+		if err == nil {
+			args["order_id"] = strconv.Itoa(orderID)
+			args["status"] = status
+		}
+	case cmdPriceEstimate:
+		return priceEstimateArgs(msgText)
+	default:
+		return args
+	}
+	if len(args) == 0 {
+		args["parse_error"] = "unexpected format"
+	}
+	return args
+}
+
+// priceEstimateArgs records the service and quantity of a HITUNG command
+func priceEstimateArgs(msgText string) map[string]string {
+	service, quantity, err := processor.ParsePriceEstimate(msgText)
+	if err != nil {
+		return map[string]string{"parse_error": err.Error()}
+	}
+	return map[string]string{"service": service, "quantity": strconv.FormatFloat(quantity, 'f', -1, 64)}
+}
+
+// parseSpecArgs parses a command with the strict parser, recording the
+// reason as a "parse_error" arg when it fails
+func parseSpecArgs(spec cmd.Spec, msgText string) map[string]string {
+	args, err := spec.Parse(msgText)
+	if err != nil {
+		return map[string]string{"parse_error": err.Error()}
+	}
+	return args
+}
+
+// Errors reported to the user while handling a message, keyed by message ID, so
+// the outcome can be recorded without threading errors through every handler.
+var (
+	failuresMu sync.Mutex
+	failures   = make(map[string]string)
+)
+
+// recordFailure notes that handling message id ended in an error reply
+func recordFailure(id, errorMsg string) {
+	if id == "" {
+		return
+	}
+	failuresMu.Lock()
+	defer failuresMu.Unlock()
+	failures[id] = errorMsg
+}
+
+// takeFailure returns and clears the recorded failure for message id
+func takeFailure(id string) (string, bool) {
+	failuresMu.Lock()
+	defer failuresMu.Unlock()
+	msg, ok := failures[id]
+	delete(failures, id)
+	return msg, ok
+}
+
+// logInboundEvent appends the handled command to the inbound event log. Logging
+// failures never affect message handling.
+func logInboundEvent(db *sql.DB, v *events.Message, msgText, command string, handleErr error) {
+	evt := repository.InboundEvent{
+		MessageID: v.Info.ID,
+		SenderJID: v.Info.Sender.String(),
+		RawText:   msgText,
+		Command:   command,
+		Args:      parseCommandArgs(command, msgText),
+		Outcome:   repository.OutcomeOK,
+		Variant:   messageVariant(v),
+	}
+	if failure, failed := takeFailure(v.Info.ID); failed {
+		evt.Outcome = repository.OutcomeError
+		evt.ErrorMessage = failure
+	}
+	if handleErr != nil {
+		evt.Outcome = repository.OutcomeError
+		evt.ErrorMessage = handleErr.Error()
+	}
+	switch handleErr {
+	case errReplyThrottled:
+		evt.Outcome = repository.OutcomeThrottled
+	case errConversationMuted:
+		evt.Outcome = repository.OutcomeMuted
+	}
+
+	if err := repository.InsertInboundEvent(db, evt); err != nil {
+		fmt.Printf("Failed to log inbound event %s: %v\n", v.Info.ID, err)
+	}
+}
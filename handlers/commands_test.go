@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/wa-serv/repository"
+)
+
+func TestClassifyText(t *testing.T) {
+	cases := map[string]string{
+		"menu":               cmdMenu,
+		"1":                  cmdCheckPoints,
+		"4":                  cmdPickupSlots,
+		"5":                  cmdStampCard,
+		"stempel#62812#2":    cmdAddStamps,
+		"klaim#62812":        cmdClaimStampReward,
+		"jadwal#3":           cmdPickupBooking,
+		"input#62812#50":     cmdUpsertPoints,
+		"red#50":             cmdRedeemPoints,
+		"selesai#42":         cmdDriverStatus,
+		"reg#budi#jl. mawar": cmdRegistration,
+		"cabang":             cmdBranches,
+		"cabang#2":           cmdBranchDetails,
+		"pesan":              cmdOrderStart,
+		"hitung cuci 5kg":    cmdPriceEstimate,
+		"hitungan":           cmdAIReply,
+		"ping":               cmdPing,
+		"berapa harga cuci?": cmdAIReply,
+	}
+	for text, want := range cases {
+		if got := classifyText(text); got != want {
+			t.Errorf("classifyText(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestParseCommandArgs(t *testing.T) {
+	args := parseCommandArgs(cmdRedeemPoints, "red#50")
+	if args["points"] != "50" {
+		t.Fatalf("expected points=50, got %v", args)
+	}
+
+	args = parseCommandArgs(cmdDriverStatus, "jemput#12")
+	if args["order_id"] != "12" || args["status"] != repository.OrderStatusPickedUp {
+		t.Fatalf("unexpected driver args %v", args)
+	}
+
+	args = parseCommandArgs(cmdUpsertPoints, "input#62812")
+	if args["parse_error"] == "" {
+		t.Fatalf("malformed command should record a parse error, got %v", args)
+	}
+
+	args = parseCommandArgs(cmdAddStamps, "stempel#6281234567890")
+	if args["phone_number"] != "6281234567890" || args["parse_error"] != "" {
+		t.Fatalf("stamp count should be optional, got %v", args)
+	}
+
+	args = parseCommandArgs(cmdBranchDetails, "cabang#dago")
+	if args["parse_error"] == "" {
+		t.Fatalf("branch number should be a number, got %v", args)
+	}
+
+	args = parseCommandArgs(cmdPriceEstimate, "hitung cuci kering 3,5 kg")
+	if args["service"] != "cuci kering" || args["quantity"] != "3.5" {
+		t.Fatalf("unexpected price estimate args %v", args)
+	}
+
+	args = parseCommandArgs(cmdPriceEstimate, "hitung cuci")
+	if args["parse_error"] == "" {
+		t.Fatalf("estimate without a quantity should record a parse error, got %v", args)
+	}
+
+	if args := parseCommandArgs(cmdMenu, "menu"); len(args) != 0 {
+		t.Fatalf("commands without arguments should have no args, got %v", args)
+	}
+}
+
+func TestRecordFailure_TakeClearsEntry(t *testing.T) {
+	recordFailure("msg-1", "Poin Anda tidak mencukupi")
+
+	msg, ok := takeFailure("msg-1")
+	if !ok || msg != "Poin Anda tidak mencukupi" {
+		t.Fatalf("expected recorded failure, got %q %v", msg, ok)
+	}
+	if _, ok := takeFailure("msg-1"); ok {
+		t.Fatal("failure should be cleared after it is taken")
+	}
+}
+
+func TestReplayResult_ChangeDetection(t *testing.T) {
+	recorded := repository.InboundEvent{
+		Command: cmdRedeemPoints,
+		Args:    map[string]string{"points": "50"},
+		Outcome: repository.OutcomeOK,
+	}
+
+	same := ReplayResult{Event: recorded, Command: cmdRedeemPoints, Args: map[string]string{"points": "50"}, Outcome: repository.OutcomeOK}
+	if same.InterpretationChanged() || same.OutcomeChanged() {
+		t.Fatal("identical replay should report no changes")
+	}
+
+	changed := ReplayResult{Event: recorded, Command: cmdAIReply, Args: map[string]string{}, Outcome: repository.OutcomeError}
+	if !changed.InterpretationChanged() || !changed.OutcomeChanged() {
+		t.Fatal("different command and outcome should be reported")
+	}
+
+	skipped := ReplayResult{Event: recorded, Command: cmdRedeemPoints, Args: recorded.Args, Outcome: "skipped"}
+	if skipped.OutcomeChanged() {
+		t.Fatal("skipped replays should not be reported as outcome changes")
+	}
+}
+
+func TestReplayEvents_SkipsRegistrationConfirmations(t *testing.T) {
+	events := []repository.InboundEvent{{
+		EventID:   1,
+		SenderJID: "6281234567890@s.whatsapp.net",
+		RawText:   "ya",
+		Command:   cmdRegistrationUpdate,
+		Outcome:   repository.OutcomeOK,
+	}}
+
+	results := ReplayEvents(nil, events)
+	if results[0].Command != cmdRegistrationUpdate || results[0].Outcome != "skipped" {
+		t.Fatalf("registration confirmation should keep its command and be skipped, got %+v", results[0])
+	}
+}
@@ -4,17 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	cmd "github.com/wa-serv/command"
 	"github.com/wa-serv/config"
 	"github.com/wa-serv/internal/infrastructure"
+	"github.com/wa-serv/plugins"
 	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
 	"github.com/wa-serv/s3uploader"
+	"github.com/wa-serv/safesend"
 	"go.mau.fi/whatsmeow"
 	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 	"google.golang.org/protobuf/proto"
 )
@@ -73,61 +77,154 @@ func getAIClient() *infrastructure.AIClient {
 	return aiClient
 }
 
+// messageText returns the text of a message. A tapped reply button or list row
+// reads as its ID, so menus sent with buttons trigger the same commands as typing.
+func messageText(msg *waProto.Message) string {
+	switch {
+	case msg.GetButtonsResponseMessage().GetSelectedButtonID() != "":
+		return msg.GetButtonsResponseMessage().GetSelectedButtonID()
+	case msg.GetListResponseMessage().GetSingleSelectReply().GetSelectedRowID() != "":
+		return msg.GetListResponseMessage().GetSingleSelectReply().GetSelectedRowID()
+	case msg.GetExtendedTextMessage().GetText() != "":
+		return msg.GetExtendedTextMessage().GetText()
+	}
+	return msg.GetConversation()
+}
+
 func HandleMessageEvent(v *events.Message, db *sql.DB, client *whatsmeow.Client) {
 	if !markSeen(v.Info.ID) {
 		fmt.Printf("Duplicate message %s from %s skipped\n", v.Info.ID, v.Info.Sender.String())
 		return
 	}
 
-	var msgText string
-	if v.Message.GetExtendedTextMessage().GetText() != "" {
-		msgText = v.Message.GetExtendedTextMessage().GetText()
-	} else {
-		msgText = v.Message.GetConversation()
+	msgText := messageText(v.Message)
+	msgText = normalizeText(msgText) // Make the message case-insensitive and emoji/smart-punctuation safe
+	fmt.Printf("Received message from %s: %s\n", v.Info.Sender.String(), msgText)
+	recordPushName(db, v)
+	recordReceived(db, client, v)
+	mirrorReceived(client, v)
+
+	live := legacyRouter{}.Route(v, msgText)
+	if shadow := getShadowRouter(); shadow != nil {
+		compareShadowRoute(db, shadow, v, msgText, live)
 	}
 
-	msgText = strings.ToLower(strings.TrimSpace(msgText)) // Make the message case-insensitive
-	fmt.Printf("Received message from %s: %s\n", v.Info.Sender.String(), msgText)
+	command := live.Command
+	var handleErr error
+
+	if err := guardReply(v, db, client, msgText); err != nil {
+		fmt.Printf("Not replying to %s: %v\n", v.Info.Sender.String(), err)
+		logInboundEvent(db, v, msgText, command, err)
+		return
+	}
 
-	if v.Message.GetImageMessage() != nil {
+	switch command {
+	case cmdImage:
 		handleMediaMessage(v, db, client)
-	} else if msgText == "menu" {
-		handleMenu(v, client)
-	} else if msgText == "1" {
-		handleCheckPoints(v, db, client)
-	} else if msgText == "2" {
-		handleRedeemInstructions(v, client)
-	} else if msgText == "3" {
-		handlePointRewards(v, client)
-	} else if isUpsertPointsCommand(msgText) {
-		handleUpsertPoints(v, db, client, msgText)
-	} else if isRedeemPointsCommand(msgText) {
-		handleRedeemPoints(v, db, client, msgText)
-	} else {
-		err := processor.ProcessRegistration(client, db, msgText, v.Info.Sender.String())
-		if err != nil {
-			fmt.Printf("Registration processing error: %v\n", err)
+	case cmdLocation:
+		handleLocationMessage(v, db, client)
+	case cmdMenu:
+		handleMenu(v, db, client)
+	case cmdCheckPoints:
+		if pointsProgramActive(v, db, client) {
+			handleCheckPoints(v, db, client)
 		}
-
-		if msgText == "ping" {
-			replyToMessage(v, client)
-		} else if msgText == "help" {
-			sendHelpMessage(v, client)
-		} else {
-			// Goroutine so the 15s AI call never blocks the whatsmeow read loop,
-			// bounded by aiSem. Non-blocking acquire: at capacity we skip the
-			// reply rather than block the loop or pile up goroutines.
-			select {
-			case aiSem <- struct{}{}:
-				go func() {
-					defer func() { <-aiSem }()
-					handleAIReply(v, client, msgText)
-				}()
-			default:
-				fmt.Printf("AI reply skipped (at capacity) for %s\n", v.Info.Sender.String())
+	case cmdRedeemInstructions:
+		if pointsProgramActive(v, db, client) {
+			handleRedeemInstructions(v, client)
+		}
+	case cmdRewards:
+		if pointsProgramActive(v, db, client) {
+			handlePointRewards(v, client)
+		}
+	case cmdStampCard:
+		handleStampCard(v, db, client)
+	case cmdAddStamps:
+		handleAddStamps(v, db, client, msgText)
+	case cmdClaimStampReward:
+		handleClaimStampReward(v, db, client, msgText)
+	case cmdBranches:
+		handleBranches(v, db, client)
+	case cmdBranchDetails:
+		handleBranchDetails(v, db, client, msgText)
+	case cmdOrderStart:
+		handleOrderStart(v, db, client)
+	case cmdOrderReply:
+		handleOrderReply(v, db, client, msgText)
+	case cmdPriceEstimate:
+		handlePriceEstimate(v, db, client, msgText)
+	case cmdPickupSlots:
+		handlePickupSlots(v, db, client)
+	case cmdPickupBooking:
+		handlePickupBooking(v, db, client, msgText)
+	case cmdUpsertPoints:
+		if pointsProgramActive(v, db, client) {
+			handleUpsertPoints(v, db, client, msgText)
+		}
+	case cmdRedeemPoints:
+		if pointsProgramActive(v, db, client) {
+			handleRedeemPoints(v, db, client, msgText)
+		}
+	case cmdDriverStatus:
+		handleDriverStatusReply(v, db, client, msgText)
+	case cmdPing:
+		replyToMessage(v, client)
+	case cmdHelp:
+		sendHelpMessage(v, client)
+	case cmdRegistration:
+		handleErr = processor.ProcessRegistration(client, db, msgText, v.Info.Sender.String())
+		if handleErr != nil {
+			fmt.Printf("Registration processing error: %v\n", handleErr)
+		}
+		dispatchAIReply(v, client, msgText)
+	case cmdRegistrationUpdate:
+		handleErr = processor.ProcessRegistrationConfirmation(client, db, v.Info.Sender.String())
+		if handleErr != nil {
+			fmt.Printf("Registration update error: %v\n", handleErr)
+		}
+	default:
+		if name, err := dispatchPlugins(v, client, msgText); name != "" {
+			command = cmdPluginPrefix + name
+			handleErr = err
+			if err != nil {
+				fmt.Printf("Plugin %s error: %v\n", name, err)
 			}
+		} else {
+			dispatchAIReply(v, client, msgText)
 		}
 	}
+
+	handleProspect(db, client, v, command)
+	logInboundEvent(db, v, msgText, command, handleErr)
+}
+
+// dispatchPlugins offers a message no core command matched to the registered
+// plugins, returning the name of the plugin that handled it
+func dispatchPlugins(v *events.Message, client *whatsmeow.Client, msgText string) (string, error) {
+	return plugins.Dispatch(context.Background(), &plugins.Message{
+		Event:  v,
+		Text:   msgText,
+		Sender: v.Info.Sender.String(),
+		Reply: func(ctx context.Context, text string) error {
+			_, err := client.SendMessage(ctx, v.Info.Sender, &waProto.Message{Conversation: proto.String(text)})
+			return err
+		},
+	})
+}
+
+// dispatchAIReply runs the AI reply in a goroutine so the 15s AI call never
+// blocks the whatsmeow read loop, bounded by aiSem. Non-blocking acquire: at
+// capacity we skip the reply rather than block the loop or pile up goroutines.
+func dispatchAIReply(v *events.Message, client *whatsmeow.Client, msgText string) {
+	select {
+	case aiSem <- struct{}{}:
+		go func() {
+			defer func() { <-aiSem }()
+			handleAIReply(v, client, msgText)
+		}()
+	default:
+		fmt.Printf("AI reply skipped (at capacity) for %s\n", v.Info.Sender.String())
+	}
 }
 
 // handleAIReply asks the AI sidecar for a suggested reply and sends it when the
@@ -162,22 +259,104 @@ func handleAIReply(evt *events.Message, client *whatsmeow.Client, msgText string
 	}
 }
 
-func handleMenu(evt *events.Message, client *whatsmeow.Client) {
-	menuText := `📋 *Menu* 📋
-
-Balas dengan angka pilihan Anda:
-1️⃣ Cek Total Poin yang Anda miliki.
-2️⃣ Tukarkan Poin.
-3️⃣ Lihat Hadiah Poin.`
+func handleMenu(evt *events.Message, db *sql.DB, client *whatsmeow.Client) {
+	program, err := processor.LoyaltyProgram(db)
+	if err != nil {
+		fmt.Printf("Failed to load loyalty program, showing the points menu: %v\n", err)
+		program.Program = repository.ProgramPoints
+	}
 	msg := &waProto.Message{
-		Conversation: proto.String(menuText),
+		Conversation: proto.String(menuText(program)),
 	}
-	_, err := client.SendMessage(context.Background(), evt.Info.Sender, msg)
+	if messageVariant(evt) == variantButtonMenu {
+		msg = menuList(program)
+	}
+	_, err = client.SendMessage(context.Background(), evt.Info.Sender, msg)
 	if err != nil {
 		fmt.Printf("Gagal mengirim menu: %v\n", err)
 	}
 }
 
+// menuText lists the options of the loyalty programs the tenant runs
+func menuText(program repository.LoyaltyProgramSettings) string {
+	var b strings.Builder
+	b.WriteString("📋 *Menu* 📋\n\nBalas dengan angka pilihan Anda:")
+	if program.PointsEnabled() {
+		b.WriteString("\n1️⃣ Cek Total Poin yang Anda miliki.\n2️⃣ Tukarkan Poin.\n3️⃣ Lihat Hadiah Poin.")
+	}
+	b.WriteString("\n4️⃣ Jadwalkan Penjemputan.")
+	if program.StampsEnabled() {
+		b.WriteString("\n5️⃣ Lihat Kartu Stempel.")
+	}
+	b.WriteString("\n\nKetik *pesan* untuk memesan laundry dan melihat estimasi harganya.")
+	b.WriteString("\nKetik *hitung <layanan> <berat>*, mis. hitung cuci 5kg, untuk cek harga dan poin.")
+	b.WriteString("\nKetik *cabang* untuk melihat alamat dan jam buka cabang kami.")
+	return b.String()
+}
+
+// pointsProgramActive reports whether the tenant runs the points program,
+// telling the sender when it does not
+func pointsProgramActive(evt *events.Message, db *sql.DB, client *whatsmeow.Client) bool {
+	program, err := processor.LoyaltyProgram(db)
+	if err != nil {
+		// Fail open: the points program predates the choice of programs
+		fmt.Printf("Failed to load loyalty program: %v\n", err)
+		return true
+	}
+	if !program.PointsEnabled() {
+		sendErrorMessage(evt, client, "Program poin tidak tersedia. Ketik *menu* untuk melihat pilihan.")
+		return false
+	}
+	return true
+}
+
+func handleStampCard(evt *events.Message, db *sql.DB, client *whatsmeow.Client) {
+	result, err := processor.GetMemberStampCard(db, evt.Info.Sender.String())
+	if err != nil {
+		switch err {
+		case processor.ErrStampsDisabled:
+			sendErrorMessage(evt, client, "Program kartu stempel tidak tersedia. Ketik *menu* untuk melihat pilihan.")
+		case processor.ErrMemberNotRegistered:
+			sendErrorMessage(evt, client, "Anda belum terdaftar. Daftar dengan format REG#Nama#Alamat.")
+		default:
+			fmt.Printf("Failed to load stamp card: %v\n", err)
+			sendErrorMessage(evt, client, "Gagal mengambil kartu stempel Anda. Silakan coba lagi nanti.")
+		}
+		return
+	}
+	sendText(evt.Info.Sender, client, processor.FormatStampCard(result))
+}
+
+func handleAddStamps(evt *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) {
+	result, err := processor.ProcessAddStamps(db, evt.Info.Sender.String(), msgText)
+	if err != nil {
+		fmt.Printf("Failed to add stamps: %v\n", err)
+		sendErrorMessage(evt, client, err.Error())
+		return
+	}
+
+	sendText(evt.Info.Sender, client, fmt.Sprintf("Stempel ditambahkan. %s sekarang %d/%d, hadiah tersedia: %d.",
+		result.PhoneNumber, result.Card.Stamps, result.Settings.StampCardSize, result.Card.RewardsAvailable))
+	// Let the member see their progress, and celebrate a completed card
+	sendText(types.NewJID(result.PhoneNumber, types.DefaultUserServer), client, processor.FormatStampCard(result))
+}
+
+func handleClaimStampReward(evt *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) {
+	result, err := processor.ProcessClaimStampReward(db, evt.Info.Sender.String(), msgText)
+	if err != nil {
+		if err == processor.ErrNoStampReward {
+			sendErrorMessage(evt, client, "Member belum memiliki kartu stempel penuh untuk diklaim.")
+			return
+		}
+		fmt.Printf("Failed to claim stamp reward: %v\n", err)
+		sendErrorMessage(evt, client, err.Error())
+		return
+	}
+
+	sendText(evt.Info.Sender, client, fmt.Sprintf("Hadiah %q untuk %s diklaim. Sisa hadiah: %d.",
+		result.Settings.StampReward, result.PhoneNumber, result.Card.RewardsAvailable))
+}
+
 func handleCheckPoints(evt *events.Message, db *sql.DB, client *whatsmeow.Client) {
 	phoneNumber := evt.Info.Sender.String()
 	memberID, err := processor.GetMemberIDByPhoneNumber(db, phoneNumber)
@@ -257,6 +436,199 @@ func handleMediaMessage(evt *events.Message, db *sql.DB, client *whatsmeow.Clien
 	}
 }
 
+// handleLocationMessage saves a shared location pin as the member's pickup/delivery point
+func handleLocationMessage(evt *events.Message, db *sql.DB, client *whatsmeow.Client) {
+	loc := evt.Message.GetLocationMessage()
+	fmt.Printf("Received location from %s: %f,%f\n", evt.Info.Sender.String(), loc.GetDegreesLatitude(), loc.GetDegreesLongitude())
+
+	orderID, err := processor.ProcessLocation(db, evt.Info.Sender.String(),
+		loc.GetDegreesLatitude(), loc.GetDegreesLongitude(), loc.GetName(), loc.GetAddress())
+	if err != nil {
+		switch err {
+		case processor.ErrMemberNotRegistered:
+			sendErrorMessage(evt, client, "Nomor Anda belum terdaftar. Silakan daftar dengan format REG#Nama#Alamat.")
+		case processor.ErrInvalidLocation:
+			sendErrorMessage(evt, client, "Lokasi tidak valid. Silakan kirim ulang lokasi Anda.")
+		default:
+			fmt.Printf("Failed to save location: %v\n", err)
+			sendErrorMessage(evt, client, "Gagal menyimpan lokasi. Silakan coba lagi nanti.")
+		}
+		return
+	}
+
+	confirmation := "📍 Lokasi penjemputan/pengantaran Anda berhasil disimpan."
+	if orderID > 0 {
+		confirmation += fmt.Sprintf("\nLokasi juga diperbarui untuk pesanan #%d.", orderID)
+	}
+	msg := &waProto.Message{
+		Conversation: proto.String(confirmation),
+	}
+	_, err = client.SendMessage(context.Background(), evt.Info.Sender, msg)
+	if err != nil {
+		fmt.Printf("Gagal mengirim konfirmasi lokasi: %v\n", err)
+	}
+}
+
+func handlePickupSlots(evt *events.Message, db *sql.DB, client *whatsmeow.Client) {
+	text, err := processor.FormatAvailablePickupSlots(db)
+	if err != nil {
+		fmt.Printf("Failed to list pickup slots: %v\n", err)
+		sendErrorMessage(evt, client, "Gagal mengambil jadwal penjemputan. Silakan coba lagi nanti.")
+		return
+	}
+
+	msg := &waProto.Message{
+		Conversation: proto.String(text),
+	}
+	_, err = client.SendMessage(context.Background(), evt.Info.Sender, msg)
+	if err != nil {
+		fmt.Printf("Gagal mengirim jadwal penjemputan: %v\n", err)
+	}
+}
+
+func handleBranches(evt *events.Message, db *sql.DB, client *whatsmeow.Client) {
+	text, err := processor.FormatBranchDirectory(db)
+	if err != nil {
+		fmt.Printf("Failed to list branches: %v\n", err)
+		sendErrorMessage(evt, client, "Gagal mengambil daftar cabang. Silakan coba lagi nanti.")
+		return
+	}
+	sendText(evt.Info.Sender, client, text)
+}
+
+// handleBranchDetails sends a branch's details followed by its location pin
+func handleBranchDetails(evt *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) {
+	branch, err := processor.ProcessBranchDetails(db, msgText)
+	if err != nil {
+		switch err {
+		case processor.ErrBranchNotFound:
+			sendErrorMessage(evt, client, "Cabang tidak ditemukan. Ketik *cabang* untuk melihat daftar cabang.")
+		default:
+			fmt.Printf("Failed to load branch: %v\n", err)
+			sendErrorMessage(evt, client, err.Error())
+		}
+		return
+	}
+
+	sendText(evt.Info.Sender, client, processor.FormatBranch(branch))
+	msg := &waProto.Message{
+		LocationMessage: &waProto.LocationMessage{
+			DegreesLatitude:  proto.Float64(branch.Latitude),
+			DegreesLongitude: proto.Float64(branch.Longitude),
+			Name:             proto.String(branch.Name),
+			Address:          proto.String(branch.Address),
+		},
+	}
+	if _, err := client.SendMessage(context.Background(), evt.Info.Sender, msg); err != nil {
+		fmt.Printf("Gagal mengirim lokasi cabang: %v\n", err)
+	}
+}
+
+// handleOrderStart opens the order flow with the service catalog
+func handleOrderStart(evt *events.Message, db *sql.DB, client *whatsmeow.Client) {
+	text, err := processor.StartOrder(db, evt.Info.Sender.String())
+	if err != nil {
+		switch err {
+		case processor.ErrMemberNotRegistered:
+			sendErrorMessage(evt, client, "Nomor Anda belum terdaftar. Silakan daftar dengan format REG#Nama#Alamat.")
+		case processor.ErrCatalogEmpty:
+			sendErrorMessage(evt, client, "Maaf, layanan belum tersedia untuk dipesan lewat WhatsApp.")
+		default:
+			fmt.Printf("Failed to start order: %v\n", err)
+			sendErrorMessage(evt, client, "Gagal memulai pemesanan. Silakan coba lagi nanti.")
+		}
+		return
+	}
+	sendText(evt.Info.Sender, client, text)
+}
+
+// handleOrderReply answers the current question of the sender's order in progress
+func handleOrderReply(evt *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) {
+	text, err := processor.ProcessOrderReply(db, evt.Info.Sender.String(), msgText)
+	if err != nil {
+		fmt.Printf("Failed to process order reply: %v\n", err)
+		sendErrorMessage(evt, client, "Gagal memproses pesanan. Silakan coba lagi nanti.")
+		return
+	}
+	sendText(evt.Info.Sender, client, text)
+}
+
+// handlePriceEstimate answers a HITUNG command with the estimated price and points
+func handlePriceEstimate(evt *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) {
+	text, err := processor.ProcessPriceEstimate(db, msgText)
+	if err != nil {
+		switch err {
+		case processor.ErrInvalidEstimateCommand:
+			sendErrorMessage(evt, client, "Format tidak valid. Gunakan HITUNG <layanan> <berat/jumlah>, contoh: HITUNG cuci 5kg")
+		case processor.ErrCatalogEmpty:
+			sendErrorMessage(evt, client, "Maaf, daftar harga layanan belum tersedia.")
+		default:
+			fmt.Printf("Failed to estimate price: %v\n", err)
+			sendErrorMessage(evt, client, "Gagal menghitung estimasi harga. Silakan coba lagi nanti.")
+		}
+		return
+	}
+	sendText(evt.Info.Sender, client, text)
+}
+
+func handlePickupBooking(evt *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) {
+	slot, err := processor.ProcessPickupBooking(db, evt.Info.Sender.String(), msgText)
+	if err != nil {
+		switch err {
+		case processor.ErrInvalidPickupCommand:
+			sendErrorMessage(evt, client, "Format tidak valid. Gunakan JADWAL#<nomor>. Kirim '4' untuk melihat jadwal.")
+		case processor.ErrMemberNotRegistered:
+			sendErrorMessage(evt, client, "Nomor Anda belum terdaftar. Silakan daftar dengan format REG#Nama#Alamat.")
+		case processor.ErrSlotNotFound:
+			sendErrorMessage(evt, client, "Jadwal tidak ditemukan atau sudah lewat. Kirim '4' untuk melihat jadwal.")
+		case processor.ErrSlotFull:
+			sendErrorMessage(evt, client, "Maaf, jadwal tersebut sudah penuh. Kirim '4' untuk memilih jadwal lain.")
+		case processor.ErrAlreadyBooked:
+			sendErrorMessage(evt, client, "Anda sudah memesan jadwal tersebut.")
+		default:
+			fmt.Printf("Failed to book pickup slot: %v\n", err)
+			sendErrorMessage(evt, client, "Gagal memesan jadwal. Silakan coba lagi nanti.")
+		}
+		return
+	}
+
+	confirmation := fmt.Sprintf("✅ Penjemputan dijadwalkan: %s\nKami akan mengirim pengingat sebelum jadwal.",
+		processor.FormatPickupWindow(slot.StartsAt, slot.EndsAt))
+	msg := &waProto.Message{
+		Conversation: proto.String(confirmation),
+	}
+	_, err = client.SendMessage(context.Background(), evt.Info.Sender, msg)
+	if err != nil {
+		fmt.Printf("Gagal mengirim konfirmasi jadwal: %v\n", err)
+	}
+}
+
+func handleDriverStatusReply(evt *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) {
+	orderID, status, err := processor.ProcessDriverStatusReply(db, evt.Info.Sender.String(), msgText)
+	if err != nil {
+		switch err {
+		case processor.ErrNotADriver:
+			sendErrorMessage(evt, client, "Nomor Anda tidak terdaftar sebagai driver.")
+		case processor.ErrInvalidDriverReply:
+			sendErrorMessage(evt, client, "Format status tidak valid. Gunakan JEMPUT#<no_pesanan>, SELESAI#<no_pesanan> atau GAGAL#<no_pesanan>.")
+		case processor.ErrOrderNotAssigned:
+			sendErrorMessage(evt, client, "Pesanan tidak ditemukan atau tidak ditugaskan kepada Anda.")
+		default:
+			fmt.Printf("Failed to process driver status: %v\n", err)
+			sendErrorMessage(evt, client, "Gagal memperbarui status pesanan. Silakan coba lagi nanti.")
+		}
+		return
+	}
+
+	msg := &waProto.Message{
+		Conversation: proto.String(fmt.Sprintf("✅ Status pesanan #%d diperbarui: %s", orderID, status)),
+	}
+	_, err = client.SendMessage(context.Background(), evt.Info.Sender, msg)
+	if err != nil {
+		fmt.Printf("Gagal mengirim konfirmasi status driver: %v\n", err)
+	}
+}
+
 func handleUpsertPoints(evt *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) {
 	err := processor.ProcessUpsertPoints(db, evt.Info.Sender.String(), msgText)
 	if err != nil {
@@ -275,19 +647,13 @@ func handleUpsertPoints(evt *events.Message, db *sql.DB, client *whatsmeow.Clien
 }
 
 func handleRedeemPoints(evt *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) {
-	parts := strings.Split(msgText, "#")
-	if len(parts) != 2 || !strings.EqualFold(parts[0], "red") {
-		sendErrorMessage(evt, client, "Format penukaran poin tidak valid. Gunakan format RED#<jumlah_poin>")
-		return
-	}
-
-	pointsToRedeem, err := strconv.Atoi(parts[1])
-	if err != nil || pointsToRedeem <= 0 {
-		sendErrorMessage(evt, client, "Jumlah poin tidak valid. Gunakan angka positif.")
+	pointsToRedeem, errMsg := parseRedeemCommand(msgText)
+	if errMsg != "" {
+		sendErrorMessage(evt, client, errMsg)
 		return
 	}
 
-	reward, err := processor.RedeemPoints(db, evt.Info.Sender.String(), pointsToRedeem)
+	redemption, err := processor.RedeemPoints(db, evt.Info.Sender.String(), pointsToRedeem)
 	if err != nil {
 		if err == processor.ErrMinimumPoints {
 			sendErrorMessage(evt, client, "Minimal poin untuk penukaran adalah 20.")
@@ -310,7 +676,6 @@ func handleRedeemPoints(evt *events.Message, db *sql.DB, client *whatsmeow.Clien
 	}
 
 	// Prepare the success message
-	redeemID := fmt.Sprintf("RL-%s-#%d", time.Now().Format("20060102"), time.Now().UnixNano()%10000)
 	successMessage := fmt.Sprintf(`🎉 *Penukaran Poin Berhasil!* 🎉
 Terima kasih sudah setia bersama *Ruang Laundry*.
 
@@ -320,11 +685,12 @@ Terima kasih sudah setia bersama *Ruang Laundry*.
 *Poin Ditukar*: %d poin
 *Hadiah*: %s
 
-🔐 *ID Redeem:* %s
-_(Harap simpan ID ini sebagai bukti klaim hadiah)_
+🔐 *Kode Voucher:* %s
+⏳ *Berlaku hingga:* %s
+_(Tunjukkan kode atau QR berikut di kasir untuk klaim hadiah)_
 
 📦 Hadiah akan segera kami proses dalam waktu *1–3 hari kerja*.
-Jika ada kendala atau pertanyaan, silakan hubungi admin melalui WhatsApp.`, memberName, pointsToRedeem, reward, redeemID)
+Jika ada kendala atau pertanyaan, silakan hubungi admin melalui WhatsApp.`, memberName, pointsToRedeem, redemption.Reward, redemption.VoucherCode, redemption.ExpiresAt.Format("02-01-2006"))
 
 	// Send the success message
 	msg := &waProto.Message{
@@ -334,6 +700,23 @@ Jika ada kendala atau pertanyaan, silakan hubungi admin melalui WhatsApp.`, memb
 	if err != nil {
 		fmt.Printf("Gagal mengirim pesan konfirmasi penukaran: %v\n", err)
 	}
+
+	qr, err := processor.VoucherQRCode(redemption.VoucherCode)
+	if err != nil {
+		// The code in the confirmation is enough to claim the reward
+		fmt.Printf("Gagal membuat QR voucher: %v\n", err)
+		return
+	}
+	sendImage(evt.Info.Sender, client, qr, "image/png", "Voucher "+redemption.VoucherCode)
+}
+
+// parseRedeemCommand parses RED#<points>, returning a user-facing error message on failure
+func parseRedeemCommand(msgText string) (int, string) {
+	args, err := cmd.Redeem.Parse(msgText)
+	if err != nil {
+		return 0, err.Error()
+	}
+	return args.Int("points"), ""
 }
 
 func isUpsertPointsCommand(msgText string) bool {
@@ -368,7 +751,70 @@ func sendHelpMessage(evt *events.Message, client *whatsmeow.Client) {
 	}
 }
 
+func sendText(to types.JID, client *whatsmeow.Client, text string) {
+	to, text, ok := safeRecipient(to, text)
+	if !ok {
+		return
+	}
+	msg := &waProto.Message{
+		Conversation: proto.String(text),
+	}
+	_, err := client.SendMessage(context.Background(), to, msg)
+	if err != nil {
+		fmt.Printf("Error sending message to %s: %v\n", to, err)
+	}
+}
+
+func sendImage(to types.JID, client *whatsmeow.Client, image []byte, mimeType, caption string) {
+	to, caption, ok := safeRecipient(to, caption)
+	if !ok {
+		return
+	}
+	uploaded, err := client.Upload(context.Background(), image, whatsmeow.MediaImage)
+	if err != nil {
+		fmt.Printf("Error uploading image for %s: %v\n", to, err)
+		return
+	}
+	msg := &waProto.Message{
+		ImageMessage: &waProto.ImageMessage{
+			URL:           proto.String(uploaded.URL),
+			DirectPath:    proto.String(uploaded.DirectPath),
+			MediaKey:      uploaded.MediaKey,
+			FileEncSHA256: uploaded.FileEncSHA256,
+			FileSHA256:    uploaded.FileSHA256,
+			FileLength:    proto.Uint64(uploaded.FileLength),
+			Mimetype:      proto.String(mimeType),
+			Caption:       proto.String(caption),
+		},
+	}
+	_, err = client.SendMessage(context.Background(), to, msg)
+	if err != nil {
+		fmt.Printf("Error sending image to %s: %v\n", to, err)
+	}
+}
+
+// safeRecipient applies the safe-send list of staging and development
+// profiles to a send of text to to. It returns where to send and the text to
+// send, labelled when redirected, or false when the send is dropped.
+func safeRecipient(to types.JID, text string) (types.JID, string, bool) {
+	guard := safesend.Default()
+	decision, routed := guard.Route(to.String())
+	switch decision {
+	case safesend.Block:
+		return to, text, false
+	case safesend.Redirect:
+		jid, err := types.ParseJID(routed)
+		if err != nil {
+			fmt.Printf("Error parsing SAFE_SEND_REDIRECT %s: %v\n", routed, err)
+			return to, text, false
+		}
+		return jid, guard.Label(to.String(), text), true
+	}
+	return to, text, true
+}
+
 func sendErrorMessage(evt *events.Message, client *whatsmeow.Client, errorMsg string) {
+	recordFailure(evt.Info.ID, errorMsg)
 	msg := &waProto.Message{
 		Conversation: proto.String(fmt.Sprintf("Error: %s", errorMsg)),
 	}
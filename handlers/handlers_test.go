@@ -3,6 +3,9 @@ package handlers
 import (
 	"testing"
 	"time"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestMarkSeen_DedupsByID(t *testing.T) {
@@ -24,3 +27,50 @@ func TestMarkSeen_DedupsByID(t *testing.T) {
 		t.Fatal("empty id should always be processed")
 	}
 }
+
+func TestPushNameChanged_OnlyReportsNewNames(t *testing.T) {
+	pushNameMu.Lock()
+	pushNames = make(map[string]string)
+	pushNameMu.Unlock()
+
+	if !pushNameChanged("628111", "Budi") {
+		t.Fatal("first push name for a phone should be stored")
+	}
+	if pushNameChanged("628111", "Budi") {
+		t.Fatal("an unchanged push name should not be stored again")
+	}
+	if !pushNameChanged("628111", "Budi S") {
+		t.Fatal("a changed push name should be stored")
+	}
+
+	forgetPushName("628111")
+	if !pushNameChanged("628111", "Budi S") {
+		t.Fatal("a forgotten phone should be stored again")
+	}
+}
+
+func TestMessageText_ReadsButtonAndListReplies(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *waProto.Message
+		want string
+	}{
+		{"conversation", &waProto.Message{Conversation: proto.String("menu")}, "menu"},
+		{"extended text", &waProto.Message{ExtendedTextMessage: &waProto.ExtendedTextMessage{Text: proto.String("poin")}}, "poin"},
+		{"button reply", &waProto.Message{ButtonsResponseMessage: &waProto.ButtonsResponseMessage{
+			SelectedButtonID: proto.String("poin"),
+		}}, "poin"},
+		{"list reply", &waProto.Message{ListResponseMessage: &waProto.ListResponseMessage{
+			SingleSelectReply: &waProto.ListResponseMessage_SingleSelectReply{SelectedRowID: proto.String("red")},
+		}}, "red"},
+		{"empty", &waProto.Message{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messageText(tt.msg); got != tt.want {
+				t.Fatalf("messageText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
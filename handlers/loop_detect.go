@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// errConversationMuted is recorded for messages from a contact muted as a suspected bot
+var errConversationMuted = errors.New("conversation muted: suspected bot loop")
+
+// conversationState tracks the recent rhythm of messages from one contact
+type conversationState struct {
+	lastAt    time.Time
+	lastText  string
+	identical int // identical fast messages in a row
+	fast      int // fast messages of any text in a row
+}
+
+// loopDetector spots contacts that behave like another bot, replying
+// instantly with identical or rapid-fire messages, and mutes them so two bots
+// can't ping-pong forever
+type loopDetector struct {
+	mu          sync.Mutex
+	cfg         config.LoopConfig
+	contacts    map[string]*conversationState
+	muted       map[string]time.Time // contact -> muted until
+	maxContacts int
+	now         func() time.Time
+}
+
+func newLoopDetector(cfg config.LoopConfig) *loopDetector {
+	return &loopDetector{
+		cfg:         cfg,
+		contacts:    make(map[string]*conversationState),
+		muted:       make(map[string]time.Time),
+		maxContacts: 5000,
+		now:         time.Now,
+	}
+}
+
+// Observe records a message from contact. It returns muted when the contact
+// is muted, and a non-empty reason when this message got it muted.
+func (d *loopDetector) Observe(contact, text string) (muted bool, reason string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	if until, ok := d.muted[contact]; ok {
+		if now.Before(until) {
+			return true, ""
+		}
+		delete(d.muted, contact)
+	}
+
+	state, ok := d.contacts[contact]
+	if !ok {
+		if len(d.contacts) >= d.maxContacts {
+			d.prune(now)
+		}
+		state = &conversationState{}
+		d.contacts[contact] = state
+	}
+
+	fast := !state.lastAt.IsZero() && now.Sub(state.lastAt) < d.cfg.FastReply
+	switch {
+	case !fast:
+		state.fast, state.identical = 1, 1
+	case text == state.lastText:
+		state.fast++
+		state.identical++
+	default:
+		state.fast++
+		state.identical = 1
+	}
+	state.lastAt, state.lastText = now, text
+
+	switch {
+	case state.identical >= d.cfg.RepeatThreshold:
+		reason = fmt.Sprintf("%d pesan identik berturut-turut dalam waktu singkat", state.identical)
+	case state.fast >= 2*d.cfg.RepeatThreshold:
+		reason = fmt.Sprintf("%d pesan berturut-turut dengan jeda kurang dari %s", state.fast, d.cfg.FastReply)
+	default:
+		return false, ""
+	}
+
+	d.muted[contact] = now.Add(d.cfg.MuteDuration)
+	delete(d.contacts, contact)
+	return true, reason
+}
+
+// prune drops contacts that have been quiet long enough to have no streak
+func (d *loopDetector) prune(now time.Time) {
+	for contact, state := range d.contacts {
+		if now.Sub(state.lastAt) >= d.cfg.FastReply {
+			delete(d.contacts, contact)
+		}
+	}
+}
+
+// Loop detector, built once from env
+var (
+	loopOnce   sync.Once
+	loopDetect *loopDetector
+)
+
+func getLoopDetector() *loopDetector {
+	loopOnce.Do(func() {
+		loopDetect = newLoopDetector(config.LoadLoopConfig())
+	})
+	return loopDetect
+}
+
+// blockSuspectedBot blocks contact on the sender client is logged in as and
+// suppresses it, so no sender messages it again, broadcasts included. It
+// returns whether the contact was blocked; a failed suppression is only logged.
+func blockSuspectedBot(db *sql.DB, client *whatsmeow.Client, contact types.JID, reason string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := client.UpdateBlocklist(ctx, contact, events.BlocklistChangeActionBlock); err != nil {
+		fmt.Printf("Failed to block suspected bot %s: %v\n", contact.String(), err)
+		return false
+	}
+
+	senderID := ""
+	if client.Store != nil && client.Store.ID != nil {
+		senderID = client.Store.ID.User
+	}
+	fmt.Printf("Blocked suspected bot %s on sender %s\n", contact.String(), senderID)
+	if db == nil {
+		return true
+	}
+	note := fmt.Sprintf("auto-blocked on sender %s as a suspected bot: %s", senderID, reason)
+	if _, err := repository.CreateSuppression(db, contact.User, repository.SuppressionBlocked, note); err != nil && !errors.Is(err, repository.ErrSuppressionExists) {
+		fmt.Printf("Failed to suppress blocked bot %s: %v\n", contact.String(), err)
+	}
+	return true
+}
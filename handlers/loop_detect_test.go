@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wa-serv/config"
+)
+
+func newTestLoopDetector(now *time.Time) *loopDetector {
+	d := newLoopDetector(config.LoopConfig{RepeatThreshold: 3, FastReply: 3 * time.Second, MuteDuration: time.Hour})
+	d.now = func() time.Time { return *now }
+	return d
+}
+
+func TestLoopDetector_MutesInstantIdenticalReplies(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	d := newTestLoopDetector(&now)
+
+	for i := 0; i < 2; i++ {
+		if muted, _ := d.Observe("bot", "terima kasih, pesan anda diterima"); muted {
+			t.Fatalf("message %d should not mute yet", i+1)
+		}
+		now = now.Add(time.Second)
+	}
+	muted, reason := d.Observe("bot", "terima kasih, pesan anda diterima")
+	if !muted || reason == "" {
+		t.Fatal("the third identical instant reply should mute the contact with a reason")
+	}
+
+	now = now.Add(30 * time.Minute)
+	if muted, reason := d.Observe("bot", "halo"); !muted || reason != "" {
+		t.Fatal("a muted contact stays muted without raising another alert")
+	}
+
+	now = now.Add(31 * time.Minute)
+	if muted, _ := d.Observe("bot", "halo"); muted {
+		t.Fatal("the mute should expire")
+	}
+}
+
+func TestLoopDetector_MutesRapidFireMessages(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	d := newTestLoopDetector(&now)
+
+	texts := []string{"menu", "1", "2", "3", "4", "menu"}
+	for i, text := range texts {
+		muted, _ := d.Observe("bot", text)
+		if want := i == len(texts)-1; muted != want {
+			t.Fatalf("message %d: muted = %v, want %v", i+1, muted, want)
+		}
+		now = now.Add(500 * time.Millisecond)
+	}
+}
+
+func TestLoopDetector_HumanPaceIsNotMuted(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	d := newTestLoopDetector(&now)
+
+	// A customer repeating "1" while waiting, but at human speed
+	for i := 0; i < 10; i++ {
+		if muted, _ := d.Observe("human", "1"); muted {
+			t.Fatalf("message %d at human pace should not mute", i+1)
+		}
+		now = now.Add(5 * time.Second)
+	}
+
+	// A quick double-send followed by a pause resets the streak
+	d.Observe("human", "ping")
+	now = now.Add(time.Second)
+	d.Observe("human", "ping")
+	now = now.Add(10 * time.Second)
+	if muted, _ := d.Observe("human", "ping"); muted {
+		t.Fatal("a pause should reset the streak")
+	}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wa-serv/mirror"
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// mirrorReceived copies an inbound message to the archive chat of the sender
+// that received it, when MESSAGE_MIRROR_DIRECTION includes inbound. The bot's
+// own messages, status updates and messages in the archive are not copied.
+func mirrorReceived(client *whatsmeow.Client, v *events.Message) {
+	if v.Info.IsFromMe || v.Info.Chat == types.StatusBroadcastJID || client == nil || client.Store == nil || client.Store.ID == nil {
+		return
+	}
+	m := mirror.Default()
+	archive, ok := m.Inbound(client.Store.ID.User)
+	if !ok || m.IsArchive(v.Info.Chat.String()) {
+		return
+	}
+	jid, err := types.ParseJID(archive)
+	if err != nil {
+		fmt.Printf("Error parsing mirror archive %s: %v\n", archive, err)
+		return
+	}
+
+	text := mirror.InboundText(v.Info.Chat.String(), v.Info.Sender.ToNonAD().String(), v.Info.PushName, receivedContent(v.Message))
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if _, err := client.SendMessage(ctx, jid, &waProto.Message{Conversation: proto.String(text)}); err != nil {
+			fmt.Printf("Error mirroring message from %s to %s: %v\n", v.Info.Sender.String(), archive, err)
+		}
+	}()
+}
+
+// receivedContent describes an inbound message for its archive copy
+func receivedContent(msg *waProto.Message) string {
+	if text := messageText(msg); text != "" {
+		return text
+	}
+	switch {
+	case msg.GetImageMessage() != nil:
+		return mirror.MediaText("[gambar]", msg.GetImageMessage().GetCaption())
+	case msg.GetDocumentMessage() != nil:
+		return mirror.MediaText("[dokumen: "+msg.GetDocumentMessage().GetFileName()+"]", msg.GetDocumentMessage().GetCaption())
+	case msg.GetAudioMessage() != nil:
+		return "[audio]"
+	case msg.GetLocationMessage() != nil:
+		return "[lokasi]"
+	case msg.GetContactMessage() != nil:
+		return mirror.MediaText("[kontak]", msg.GetContactMessage().GetDisplayName())
+	}
+	return "[pesan]"
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// punctuationReplacer maps the smart punctuation iOS and Android keyboards
+// substitute, and full-width forms, to the ASCII characters commands use
+var punctuationReplacer = strings.NewReplacer(
+	"\u2018", "'", "\u2019", "'", "\u201A", "'", "\u2032", "'", // ‘ ’ ‚ ′
+	"\u201C", `"`, "\u201D", `"`, "\u201E", `"`, "\u2033", `"`, // “ ” „ ″
+	"\u2013", "-", "\u2014", "-", "\u2212", "-", // – — −
+	"\uFF03", "#", "\uFE5F", "#", // full-width and small number signs
+	"\u00A0", " ", "\u202F", " ", "\u3000", " ", // non-breaking and ideographic spaces
+)
+
+// normalizeText prepares inbound text for command matching: invisible
+// characters are removed, smart punctuation and non-ASCII digits are mapped to
+// ASCII, emoji around the text are stripped, and the result is trimmed and
+// lower-cased. Emoji inside the text are kept.
+func normalizeText(text string) string {
+	text = punctuationReplacer.Replace(text)
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case isInvisible(r):
+			return -1
+		case r > unicode.MaxASCII && unicode.IsDigit(r):
+			return asciiDigit(r)
+		}
+		return r
+	}, text)
+	text = strings.TrimFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || isEmoji(r)
+	})
+	return strings.ToLower(text)
+}
+
+// isInvisible reports zero-width and formatting characters that keyboards and
+// copy-paste slip into messages, including emoji variation selectors and the
+// keycap mark that turns "1" into 1️⃣
+func isInvisible(r rune) bool {
+	switch r {
+	case '\u00AD', // soft hyphen
+		'\u200B', '\u200C', '\u200D', // zero-width space, non-joiner, joiner
+		'\u200E', '\u200F', // left-to-right and right-to-left marks
+		'\u2060', '\uFEFF', // word joiner, byte order mark
+		'\u20E3': // combining enclosing keycap
+		return true
+	}
+	return unicode.Is(unicode.Variation_Selector, r)
+}
+
+// isEmoji reports pictographs, symbols and emoji modifiers that may decorate a
+// command, e.g. "👋 menu" or "ping 🙏🏽"
+func isEmoji(r rune) bool {
+	if r <= unicode.MaxASCII {
+		return false
+	}
+	return unicode.In(r, unicode.So, unicode.Sk, unicode.Regional_Indicator) ||
+		(r >= 0x1F000 && r <= 0x1FAFF)
+}
+
+// asciiDigit maps a Unicode decimal digit, such as ٣ or ３, to its ASCII form.
+// Decimal digits are encoded in runs of ten starting at zero, so the value is
+// the offset within its run of unicode.Nd.
+func asciiDigit(r rune) rune {
+	for _, rng := range unicode.Nd.R16 {
+		if lo, hi := rune(rng.Lo), rune(rng.Hi); r >= lo && r <= hi {
+			return '0' + (r-lo)%10
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if lo, hi := rune(rng.Lo), rune(rng.Hi); r >= lo && r <= hi {
+			return '0' + (r-lo)%10
+		}
+	}
+	return r
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"testing"
+	"unicode"
+
+	"github.com/wa-serv/command"
+)
+
+func TestNormalizeText(t *testing.T) {
+	cases := map[string]string{
+		"  MENU  ":         "menu",
+		"1\uFE0F\u20E3":    "1",         // keycap emoji
+		"\u200Bmenu\u200D": "menu",      // zero-width characters
+		"\uFEFFping":       "ping",      // byte order mark from copy-paste
+		"👋 Menu 🙏🏽":        "menu",      // emoji around a command
+		"🇮🇩 help":          "help",      // flag emoji
+		"RED#٥٠":           "red#50",    // Arabic-Indic digits
+		"RED#５０":           "red#50",    // full-width digits
+		"RED＃50":           "red#50",    // full-width number sign
+		"JADWAL#\u00A03":   "jadwal# 3", // non-breaking space
+		"INPUT#６２８１２３４５６#１０":      "input#628123456#10",
+		"reg#budi 😊#jl. mawar":    "reg#budi 😊#jl. mawar", // emoji inside text are kept
+		"REG#Budi#“Jl. Mawar #5”": `reg#budi#"jl. mawar #5"`,
+		"It’s 50–60 poin":         "it's 50-60 poin",
+		"berapa harga cuci?":      "berapa harga cuci?",
+		"^_^":                     "^_^", // ASCII symbols are not emoji
+	}
+	for text, want := range cases {
+		if got := normalizeText(text); got != want {
+			t.Errorf("normalizeText(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestNormalizeText_CommandsStillMatch(t *testing.T) {
+	cases := map[string]string{
+		"1\uFE0F\u20E3":            cmdCheckPoints,
+		"📋 Menu":                   cmdMenu,
+		"RED#٥٠ 🎁":                 cmdRedeemPoints,
+		"Reg＃Budi＃Jl. Mawar":       cmdRegistration,
+		"\u200Breg#budi#jl. mawar": cmdRegistration,
+	}
+	for text, want := range cases {
+		if got := classifyText(normalizeText(text)); got != want {
+			t.Errorf("classifyText(normalizeText(%q)) = %q, want %q", text, got, want)
+		}
+	}
+
+	// Curly quotes from iOS smart punctuation still quote a field containing #
+	args, err := command.Registration.Parse(normalizeText("REG#Budi#“Jl. Mawar #5”"))
+	if err != nil || args["address"] != "jl. mawar #5" {
+		t.Fatalf("expected quoted address, got %v %v", args, err)
+	}
+}
+
+func TestASCIIDigit_AllDecimalDigits(t *testing.T) {
+	for _, table := range [][]unicode.Range16{unicode.Nd.R16} {
+		for _, rng := range table {
+			if (int(rng.Hi)-int(rng.Lo)+1)%10 != 0 || rng.Stride != 1 {
+				t.Fatalf("decimal digit range %U-%U is not made of runs of ten", rng.Lo, rng.Hi)
+			}
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if (int(rng.Hi)-int(rng.Lo)+1)%10 != 0 || rng.Stride != 1 {
+			t.Fatalf("decimal digit range %U-%U is not made of runs of ten", rng.Lo, rng.Hi)
+		}
+	}
+
+	for r, want := range map[rune]rune{'٣': '3', '३': '3', '৭': '7', '０': '0', '９': '9', '𝟘': '0', '𝟡': '9', '𝟫': '9'} {
+		if got := asciiDigit(r); got != want {
+			t.Errorf("asciiDigit(%q) = %q, want %q", r, got, want)
+		}
+	}
+}
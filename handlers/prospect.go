@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Onboarding nudge settings, read once from env
+var (
+	onboardingOnce sync.Once
+	onboardingCfg  config.OnboardingConfig
+)
+
+func getOnboardingConfig() config.OnboardingConfig {
+	onboardingOnce.Do(func() {
+		onboardingCfg = config.LoadOnboardingConfig()
+	})
+	return onboardingCfg
+}
+
+// handleProspect tracks a message from an unregistered number and, unless the
+// contact is registering right now, nudges them towards the loyalty program
+func handleProspect(db *sql.DB, client *whatsmeow.Client, v *events.Message, command string) {
+	sender, tracked := trackProspect(db, v, command)
+	if !tracked || command == cmdRegistration {
+		return
+	}
+	if _, err := processor.SendOnboardingNudge(db, client, sender.String(), getOnboardingConfig()); err != nil {
+		fmt.Printf("Failed to send onboarding nudge to %s: %v\n", sender.User, err)
+	}
+}
+
+// trackProspect counts a message from an unregistered number as lead activity,
+// keeping the command it was routed to as the prospect's last intent. Members
+// are skipped, including anyone who just registered with this message. It
+// returns the prospect's JID and true when the message was tracked.
+func trackProspect(db *sql.DB, v *events.Message, command string) (types.JID, bool) {
+	sender, ok := directSender(v)
+	if !ok {
+		return sender, false
+	}
+	registered, err := repository.IsMemberRegistered(db, sender.User)
+	if err != nil {
+		fmt.Printf("Failed to check registration for prospect %s: %v\n", sender.User, err)
+		return sender, false
+	}
+	if registered {
+		return sender, false
+	}
+	if err := repository.RecordProspectMessage(db, sender.User, v.Info.PushName, command); err != nil {
+		fmt.Printf("Failed to track prospect %s: %v\n", sender.User, err)
+		return sender, false
+	}
+	return sender, true
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// Push names rarely change, so remember the last name stored per phone and only
+// touch the database when it differs. Bounded so a flood of new numbers can't
+// grow it forever; clearing it just costs a few redundant no-op updates.
+var (
+	pushNameMu     sync.Mutex
+	pushNames      = make(map[string]string)
+	pushNameMaxLen = 5000
+)
+
+// pushNameChanged records name for phone and returns true if it differs from
+// the last name seen, meaning it should be written to the database
+func pushNameChanged(phone, name string) bool {
+	pushNameMu.Lock()
+	defer pushNameMu.Unlock()
+	if pushNames[phone] == name {
+		return false
+	}
+	if len(pushNames) >= pushNameMaxLen {
+		pushNames = make(map[string]string)
+	}
+	pushNames[phone] = name
+	return true
+}
+
+// forgetPushName drops phone from the cache so a failed write is retried
+func forgetPushName(phone string) {
+	pushNameMu.Lock()
+	delete(pushNames, phone)
+	pushNameMu.Unlock()
+}
+
+// StorePushName saves the WhatsApp push name of a user JID on their member
+// record, or on a prospect record for unregistered numbers. Groups and
+// broadcasts are ignored.
+func StorePushName(db *sql.DB, jid types.JID, pushName string) {
+	pushName = strings.TrimSpace(pushName)
+	if pushName == "" || jid.Server != types.DefaultUserServer || jid.User == "" {
+		return
+	}
+	if !pushNameChanged(jid.User, pushName) {
+		return
+	}
+	if err := repository.SavePushName(db, jid.User, pushName); err != nil {
+		forgetPushName(jid.User)
+		fmt.Printf("Failed to store push name for %s: %v\n", jid.User, err)
+	}
+}
+
+// directSender returns the phone-number JID of the user who sent a one-to-one
+// message, or false for groups, broadcasts and the bot's own messages
+func directSender(v *events.Message) (types.JID, bool) {
+	if v.Info.IsFromMe || v.Info.IsGroup {
+		return types.JID{}, false
+	}
+	sender := v.Info.Sender
+	if sender.Server != types.DefaultUserServer && v.Info.SenderAlt.Server == types.DefaultUserServer {
+		sender = v.Info.SenderAlt
+	}
+	if sender.Server != types.DefaultUserServer || sender.User == "" {
+		return types.JID{}, false
+	}
+	return sender.ToNonAD(), true
+}
+
+// recordPushName stores the sender's push name from an inbound message
+func recordPushName(db *sql.DB, v *events.Message) {
+	if sender, ok := directSender(v); ok {
+		StorePushName(db, sender, v.Info.PushName)
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+)
+
+// ReplayResult compares a recorded inbound event with how the current code
+// interprets it and what a dry run predicts would happen now
+type ReplayResult struct {
+	Event   repository.InboundEvent
+	Command string
+	Args    map[string]string
+	Outcome string // ok, error or skipped
+	Error   string
+}
+
+// InterpretationChanged reports whether the current code parses the event differently
+func (r ReplayResult) InterpretationChanged() bool {
+	return r.Command != r.Event.Command || !reflect.DeepEqual(r.Args, r.Event.Args)
+}
+
+// OutcomeChanged reports whether the dry run disagrees with the recorded
+// outcome. Throttled and muted events were never handled, so there is nothing
+// to compare.
+func (r ReplayResult) OutcomeChanged() bool {
+	if r.Outcome == "skipped" || r.Event.Outcome == repository.OutcomeThrottled || r.Event.Outcome == repository.OutcomeMuted {
+		return false
+	}
+	return r.Outcome != r.Event.Outcome
+}
+
+var errNotReplayable = errors.New("media messages, registration confirmations and plugin commands cannot be replayed from the event log")
+
+// ReplayEvents re-runs recorded events against the current code in dry-run mode:
+// commands are re-parsed and validated against the current database, but no
+// data is written and no WhatsApp messages are sent.
+func ReplayEvents(db *sql.DB, events []repository.InboundEvent) []ReplayResult {
+	results := make([]ReplayResult, 0, len(events))
+	for _, evt := range events {
+		command := evt.Command
+		// Text is re-classified; commands that depend on more than the text are kept
+		if command != cmdImage && command != cmdLocation && command != cmdRegistrationUpdate && !strings.HasPrefix(command, cmdPluginPrefix) {
+			command = classifyText(evt.RawText)
+		}
+
+		result := ReplayResult{
+			Event:   evt,
+			Command: command,
+			Args:    parseCommandArgs(command, evt.RawText),
+			Outcome: repository.OutcomeOK,
+		}
+		switch err := dryRunCommand(db, command, evt.SenderJID, evt.RawText); {
+		case err == errNotReplayable:
+			result.Outcome = "skipped"
+			result.Error = err.Error()
+		case err != nil:
+			result.Outcome = repository.OutcomeError
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// dryRunCommand validates a command the way its handler would, without side effects
+func dryRunCommand(db *sql.DB, command, senderJID, msgText string) error {
+	if strings.HasPrefix(command, cmdPluginPrefix) {
+		return errNotReplayable
+	}
+	switch command {
+	case cmdImage, cmdLocation, cmdRegistrationUpdate:
+		return errNotReplayable
+	case cmdUpsertPoints:
+		return processor.DryRunUpsertPoints(db, senderJID, msgText)
+	case cmdRedeemPoints:
+		points, errMsg := parseRedeemCommand(msgText)
+		if errMsg != "" {
+			return errors.New(errMsg)
+		}
+		return processor.DryRunRedeemPoints(db, senderJID, points)
+	case cmdRegistration:
+		return processor.DryRunRegistration(db, senderJID, msgText)
+	case cmdDriverStatus:
+		return processor.DryRunDriverStatusReply(db, senderJID, msgText)
+	case cmdPickupBooking:
+		return processor.DryRunPickupBooking(db, senderJID, msgText)
+	case cmdAddStamps:
+		return processor.DryRunAddStamps(db, senderJID, msgText)
+	case cmdClaimStampReward:
+		return processor.DryRunClaimStampReward(db, senderJID, msgText)
+	default:
+		// Menus, lookups and AI replies have no state-changing decision to check
+		return nil
+	}
+}
+
+// PrintReplayResults writes a human-readable replay report to w
+func PrintReplayResults(w io.Writer, results []ReplayResult) {
+	for _, r := range results {
+		fmt.Fprintf(w, "#%d %s %s %q\n", r.Event.EventID, r.Event.CreatedAt.Format("2006-01-02 15:04:05"), r.Event.SenderJID, r.Event.RawText)
+		fmt.Fprintf(w, "  recorded: %s %v -> %s %s\n", r.Event.Command, r.Event.Args, r.Event.Outcome, r.Event.ErrorMessage)
+		fmt.Fprintf(w, "  replayed: %s %v -> %s %s\n", r.Command, r.Args, r.Outcome, r.Error)
+		if r.InterpretationChanged() {
+			fmt.Fprintln(w, "  ! interpretation changed")
+		}
+		if r.OutcomeChanged() {
+			fmt.Fprintln(w, "  ! outcome changed")
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/processor"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// errReplyThrottled is recorded for messages the reply limit left unanswered
+var errReplyThrottled = errors.New("reply limit exceeded for contact")
+
+// replyLimiter allows at most limit replies per contact in any sliding window,
+// so a looping customer or another bot can't trigger a reply storm
+type replyLimiter struct {
+	mu          sync.Mutex
+	limit       int
+	window      time.Duration
+	replies     map[string][]time.Time
+	maxContacts int
+	now         func() time.Time
+}
+
+func newReplyLimiter(limit int, window time.Duration) *replyLimiter {
+	return &replyLimiter{
+		limit:       limit,
+		window:      window,
+		replies:     make(map[string][]time.Time),
+		maxContacts: 5000,
+		now:         time.Now,
+	}
+}
+
+// Allow records a reply to contact and returns true, or returns false without
+// recording anything when contact already had limit replies in the window
+func (l *replyLimiter) Allow(contact string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	cutoff := now.Add(-l.window)
+	recent := l.replies[contact]
+	for len(recent) > 0 && !recent[0].After(cutoff) {
+		recent = recent[1:]
+	}
+	if len(recent) >= l.limit {
+		l.replies[contact] = recent
+		return false
+	}
+
+	if _, tracked := l.replies[contact]; !tracked && len(l.replies) >= l.maxContacts {
+		l.prune(cutoff)
+	}
+	l.replies[contact] = append(recent, now)
+	return true
+}
+
+// prune drops contacts with no replies in the window
+func (l *replyLimiter) prune(cutoff time.Time) {
+	for contact, times := range l.replies {
+		if len(times) == 0 || !times[len(times)-1].After(cutoff) {
+			delete(l.replies, contact)
+		}
+	}
+}
+
+// guardReply decides whether the bot may answer v. Contacts that look like
+// another bot are muted and reported to admins; everyone else is held to the
+// per-contact reply limit. Admin numbers are never treated as bots. Own
+// messages never get a reply, so they are not counted.
+func guardReply(v *events.Message, db *sql.DB, client *whatsmeow.Client, msgText string) error {
+	if v.Info.IsFromMe {
+		return nil
+	}
+	contact := v.Info.Sender.ToNonAD()
+
+	if !config.Env.AllowedPhoneNumbers[contact.User] {
+		detector := getLoopDetector()
+		muted, reason := detector.Observe(contact.String(), msgText)
+		if reason != "" {
+			fmt.Printf("Muting %s for %s: %s\n", contact.String(), detector.cfg.MuteDuration, reason)
+			blocked := detector.cfg.AutoBlock && blockSuspectedBot(db, client, contact, reason)
+			processor.AnnounceConversationMuted(client, detector.cfg.AlertPhones, contact.User, reason, detector.cfg.MuteDuration, blocked)
+		}
+		if muted {
+			return errConversationMuted
+		}
+	}
+
+	if !getReplyLimiter().Allow(contact.String()) {
+		return errReplyThrottled
+	}
+	return nil
+}
+
+// Per-contact reply limiter, built once from env
+var (
+	replyLimitOnce sync.Once
+	replyLimit     *replyLimiter
+)
+
+func getReplyLimiter() *replyLimiter {
+	replyLimitOnce.Do(func() {
+		replyLimit = newReplyLimiter(config.LoadReplyLimitConfig().PerMinute, time.Minute)
+	})
+	return replyLimit
+}
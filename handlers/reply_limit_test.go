@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wa-serv/repository"
+)
+
+func TestReplyLimiter_SlidingWindowPerContact(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	limiter := newReplyLimiter(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	if !limiter.Allow("a") || !limiter.Allow("a") {
+		t.Fatal("replies within the limit should be allowed")
+	}
+	if limiter.Allow("a") {
+		t.Fatal("a third reply within a minute should be throttled")
+	}
+	if !limiter.Allow("b") {
+		t.Fatal("other contacts have their own limit")
+	}
+
+	now = now.Add(30 * time.Second)
+	if limiter.Allow("a") {
+		t.Fatal("throttled attempts must not extend the window, but the limit still applies")
+	}
+
+	now = now.Add(31 * time.Second)
+	if !limiter.Allow("a") {
+		t.Fatal("replies should be allowed again once the window has passed")
+	}
+}
+
+func TestReplyLimiter_PrunesIdleContacts(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	limiter := newReplyLimiter(1, time.Minute)
+	limiter.now = func() time.Time { return now }
+	limiter.maxContacts = 2
+
+	limiter.Allow("a")
+	limiter.Allow("b")
+	now = now.Add(2 * time.Minute)
+	limiter.Allow("c")
+
+	if len(limiter.replies) != 1 {
+		t.Fatalf("idle contacts should be pruned, tracking %d", len(limiter.replies))
+	}
+}
+
+func TestReplayResult_ThrottledEventsNotCompared(t *testing.T) {
+	throttled := ReplayResult{
+		Event:   repository.InboundEvent{Command: cmdMenu, Outcome: repository.OutcomeThrottled},
+		Command: cmdMenu,
+		Outcome: repository.OutcomeOK,
+	}
+	if throttled.OutcomeChanged() {
+		t.Fatal("throttled events were never handled and should not be reported as outcome changes")
+	}
+}
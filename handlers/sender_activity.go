@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// recordReceived counts an inbound message toward the hourly activity of the
+// sender that received it. The bot's own messages are not counted.
+func recordReceived(db *sql.DB, client *whatsmeow.Client, v *events.Message) {
+	if v.Info.IsFromMe || client == nil || client.Store == nil || client.Store.ID == nil {
+		return
+	}
+	senderID := client.Store.ID.User
+	if err := repository.RecordSenderActivity(db, senderID, 0, 1); err != nil {
+		fmt.Printf("Failed to record activity of sender %s: %v\n", senderID, err)
+	}
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// routeDecision is what a command router decides for an inbound message
+type routeDecision struct {
+	Command string            `json:"command"`
+	Args    map[string]string `json:"args"`
+}
+
+// commandRouter turns an inbound message into a routing decision. Routers are
+// pure: they never reply or touch the database, so a candidate can be run on
+// live traffic in shadow mode.
+type commandRouter interface {
+	Route(v *events.Message, msgText string) routeDecision
+}
+
+// legacyRouter is the live router built on classifyCommand/parseCommandArgs
+type legacyRouter struct{}
+
+func (legacyRouter) Route(v *events.Message, msgText string) routeDecision {
+	command := classifyCommand(v, msgText)
+	return routeDecision{Command: command, Args: parseCommandArgs(command, msgText)}
+}
+
+// commandRoute is one entry of the table-driven router
+type commandRoute struct {
+	command string
+	match   func(msgText string) bool
+	args    []string // names of the #-separated fields after the keyword
+}
+
+func exact(text string) func(string) bool {
+	return func(msgText string) bool { return msgText == text }
+}
+
+func keyword(word string, fields int) func(string) bool {
+	return func(msgText string) bool {
+		parts := splitCommand(msgText)
+		return len(parts) > 1 && parts[0] == word && (fields == 0 || len(parts) == fields+1)
+	}
+}
+
+// tableRouter is the candidate replacement for the if/else router: routes are
+// data, and command fields are trimmed so "RED # 50" parses like "RED#50"
+type tableRouter struct {
+	routes []commandRoute
+}
+
+func newTableRouter() *tableRouter {
+	return &tableRouter{routes: []commandRoute{
+		{command: cmdMenu, match: exact("menu")},
+		{command: cmdCheckPoints, match: exact("1")},
+		{command: cmdRedeemInstructions, match: exact("2")},
+		{command: cmdRewards, match: exact("3")},
+		{command: cmdPickupSlots, match: exact("4")},
+		{command: cmdStampCard, match: exact("5")},
+		{command: cmdPickupBooking, match: keyword("jadwal", 0), args: []string{"slot_id"}},
+		{command: cmdUpsertPoints, match: keyword("input", 0), args: []string{"phone_number", "points"}},
+		{command: cmdRedeemPoints, match: keyword("red", 0), args: []string{"points"}},
+		{command: cmdDriverStatus, match: func(msgText string) bool {
+			return processor.IsDriverStatusCommand(strings.Join(splitCommand(msgText), "#"))
+		}},
+		{command: cmdAddStamps, match: keyword("stempel", 0), args: []string{"phone_number", "stamps"}},
+		{command: cmdClaimStampReward, match: keyword("klaim", 0), args: []string{"phone_number"}},
+		{command: cmdRegistration, match: keyword("reg", 0), args: []string{"name", "address"}},
+		{command: cmdBranches, match: exact("cabang")},
+		{command: cmdBranchDetails, match: keyword("cabang", 0), args: []string{"branch_id"}},
+		{command: cmdOrderStart, match: exact("pesan")},
+		{command: cmdPriceEstimate, match: processor.IsPriceEstimateCommand},
+		{command: cmdPing, match: exact("ping")},
+		{command: cmdHelp, match: exact("help")},
+	}}
+}
+
+func (r *tableRouter) Route(v *events.Message, msgText string) routeDecision {
+	switch {
+	case v.Message.GetImageMessage() != nil:
+		return routeDecision{Command: cmdImage, Args: map[string]string{}}
+	case v.Message.GetLocationMessage() != nil:
+		return routeDecision{Command: cmdLocation, Args: map[string]string{}}
+	case processor.IsRegistrationConfirmation(v.Info.Sender.String(), msgText):
+		return routeDecision{Command: cmdRegistrationUpdate, Args: map[string]string{}}
+	case processor.IsOrderFlowReply(v.Info.Sender.String(), msgText):
+		return routeDecision{Command: cmdOrderReply, Args: map[string]string{}}
+	}
+
+	for _, route := range r.routes {
+		if !route.match(msgText) {
+			continue
+		}
+		return routeDecision{Command: route.command, Args: r.parseArgs(route, msgText)}
+	}
+	return routeDecision{Command: cmdAIReply, Args: map[string]string{}}
+}
+
+func (r *tableRouter) parseArgs(route commandRoute, msgText string) map[string]string {
+	args := map[string]string{}
+	if route.command == cmdDriverStatus {
+		orderID, status, err := processor.ParseDriverStatusReply(strings.Join(splitCommand(msgText), "#"))
+		if err != nil {
+			args["parse_error"] = "unexpected format"
+			return args
+		}
+		args["order_id"] = fmt.Sprint(orderID)
+		args["status"] = status
+		return args
+	}
+	if route.command == cmdPriceEstimate {
+		return priceEstimateArgs(msgText)
+	}
+	if len(route.args) == 0 {
+		return args
+	}
+
+	fields := splitCommand(msgText)[1:]
+	if len(fields) != len(route.args) {
+		args["parse_error"] = "unexpected format"
+		return args
+	}
+	for i, name := range route.args {
+		args[name] = fields[i]
+	}
+	return args
+}
+
+// splitCommand splits on # and trims every field
+func splitCommand(msgText string) []string {
+	parts := strings.Split(msgText, "#")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// Shadow router, built once from env. nil when shadow mode is disabled.
+var (
+	shadowOnce   sync.Once
+	shadowRouter commandRouter
+)
+
+func getShadowRouter() commandRouter {
+	shadowOnce.Do(func() {
+		if config.LoadShadowConfig().RouterEnabled {
+			shadowRouter = newTableRouter()
+		}
+	})
+	return shadowRouter
+}
+
+// compareShadowRoute runs the shadow router on a copy of a live message and
+// records any disagreement with the live decision. A panicking shadow router is
+// contained here so it can never affect live handling.
+func compareShadowRoute(db *sql.DB, shadow commandRouter, v *events.Message, msgText string, live routeDecision) (diff *repository.ShadowDiff) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Shadow router panicked on message %s: %v\n", v.Info.ID, r)
+			diff = nil
+		}
+	}()
+
+	candidate := shadow.Route(v, msgText)
+	if candidate.Command == live.Command && reflect.DeepEqual(candidate.Args, live.Args) {
+		return nil
+	}
+
+	liveJSON, _ := json.Marshal(live)
+	shadowJSON, _ := json.Marshal(candidate)
+	diff = &repository.ShadowDiff{
+		Component:    "command_router",
+		MessageID:    v.Info.ID,
+		RawText:      msgText,
+		LiveResult:   string(liveJSON),
+		ShadowResult: string(shadowJSON),
+	}
+	fmt.Printf("Shadow router diff on %s: live=%s shadow=%s\n", v.Info.ID, liveJSON, shadowJSON)
+
+	if db != nil {
+		if err := repository.InsertShadowDiff(db, *diff); err != nil {
+			fmt.Printf("Failed to record shadow diff: %v\n", err)
+		}
+	}
+	return diff
+}
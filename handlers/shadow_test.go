@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"testing"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+func textEvent(id string) *events.Message {
+	evt := &events.Message{Message: &waProto.Message{}}
+	evt.Info.ID = id
+	return evt
+}
+
+func TestTableRouter_MatchesLegacyOnWellFormedCommands(t *testing.T) {
+	inputs := []string{
+		"menu", "1", "2", "3", "4", "jadwal#3", "input#6281234567890#50", "red#50",
+		"jemput#12", "selesai#12", "gagal#12", "reg#budi#jl. mawar 1", "ping", "help",
+		"5", "stempel#6281234567890#2", "klaim#6281234567890", "cabang", "cabang#2", "pesan",
+		"hitung cuci 5kg", "hitung setrika 2 kg", "hitung",
+		"halo, buka jam berapa?",
+	}
+	legacy := legacyRouter{}
+	candidate := newTableRouter()
+
+	for _, text := range inputs {
+		if diff := compareShadowRoute(nil, candidate, textEvent("m"), text, legacy.Route(textEvent("m"), text)); diff != nil {
+			t.Errorf("routers disagree on %q: live=%s shadow=%s", text, diff.LiveResult, diff.ShadowResult)
+		}
+	}
+}
+
+func TestCompareShadowRoute_ReportsDifference(t *testing.T) {
+	text := "red # 50"
+	live := legacyRouter{}.Route(textEvent("m1"), text)
+
+	diff := compareShadowRoute(nil, newTableRouter(), textEvent("m1"), text, live)
+	if diff == nil {
+		t.Fatal("expected the candidate's whitespace handling to produce a diff")
+	}
+	if diff.Component != "command_router" || diff.MessageID != "m1" {
+		t.Fatalf("unexpected diff metadata: %+v", diff)
+	}
+}
+
+type panickingRouter struct{}
+
+func (panickingRouter) Route(*events.Message, string) routeDecision { panic("boom") }
+
+func TestCompareShadowRoute_ContainsPanics(t *testing.T) {
+	live := legacyRouter{}.Route(textEvent("m2"), "menu")
+
+	if diff := compareShadowRoute(nil, panickingRouter{}, textEvent("m2"), "menu", live); diff != nil {
+		t.Fatalf("a panicking shadow router should be ignored, got %+v", diff)
+	}
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"hash/fnv"
+	"sync"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/repository"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// Soft-launch variants, as recorded on every inbound event of a conversation
+const (
+	variantLegacy     = "legacy"      // the text menu
+	variantButtonMenu = "button_menu" // the menu as an interactive list
+)
+
+// Soft launch split, read once from env
+var (
+	softLaunchOnce    sync.Once
+	softLaunchPercent int
+)
+
+func getSoftLaunchPercent() int {
+	softLaunchOnce.Do(func() {
+		softLaunchPercent = config.LoadSoftLaunchConfig().Percent
+	})
+	return softLaunchPercent
+}
+
+// messageVariant returns the soft-launch variant of the conversation a
+// message belongs to, or "" when no soft launch runs
+func messageVariant(v *events.Message) string {
+	return conversationVariant(v.Info.Sender.ToNonAD().User, getSoftLaunchPercent())
+}
+
+// conversationVariant puts percent out of 100 contacts on the new flow. A
+// contact always lands in the same bucket, so it keeps its variant across
+// messages and restarts, and raising percent only moves contacts onto the
+// new flow.
+func conversationVariant(contact string, percent int) string {
+	if percent <= 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	h.Write([]byte(contact))
+	if int(h.Sum32()%100) < percent {
+		return variantButtonMenu
+	}
+	return variantLegacy
+}
+
+// menuList is the menu of the button_menu variant: the options of menuText
+// as list rows, whose IDs are the text a customer would type for them
+func menuList(program repository.LoyaltyProgramSettings) *waProto.Message {
+	var rows []*waProto.ListMessage_Row
+	row := func(id, title, description string) {
+		rows = append(rows, &waProto.ListMessage_Row{
+			RowID:       proto.String(id),
+			Title:       proto.String(title),
+			Description: proto.String(description),
+		})
+	}
+	if program.PointsEnabled() {
+		row("1", "Cek Poin", "Lihat total poin Anda")
+		row("2", "Tukar Poin", "Cara menukarkan poin")
+		row("3", "Hadiah Poin", "Daftar hadiah yang bisa ditukar")
+	}
+	row("4", "Jadwalkan Penjemputan", "Pilih jadwal jemput cucian")
+	if program.StampsEnabled() {
+		row("5", "Kartu Stempel", "Lihat stempel yang terkumpul")
+	}
+	row("pesan", "Pesan Laundry", "Pesan dan lihat estimasi harga")
+	row("cabang", "Cabang", "Alamat dan jam buka cabang")
+
+	return &waProto.Message{ListMessage: &waProto.ListMessage{
+		Title:       proto.String("📋 Menu"),
+		Description: proto.String("Pilih layanan yang Anda butuhkan.\nKetik *hitung <layanan> <berat>*, mis. hitung cuci 5kg, untuk cek harga dan poin."),
+		ButtonText:  proto.String("Lihat Menu"),
+		ListType:    waProto.ListMessage_SINGLE_SELECT.Enum(),
+		Sections:    []*waProto.ListMessage_Section{{Title: proto.String("Layanan"), Rows: rows}},
+	}}
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/wa-serv/repository"
+)
+
+func TestConversationVariant(t *testing.T) {
+	if got := conversationVariant("6281234567890", 0); got != "" {
+		t.Fatalf("no soft launch should give no variant, got %q", got)
+	}
+	if got := conversationVariant("6281234567890", 100); got != variantButtonMenu {
+		t.Fatalf("100%% should put everyone on the new flow, got %q", got)
+	}
+
+	onNewFlow := 0
+	for i := 0; i < 1000; i++ {
+		contact := fmt.Sprintf("62812%08d", i)
+		variant := conversationVariant(contact, 20)
+		if variant != conversationVariant(contact, 20) {
+			t.Fatalf("contact %s changed variant between messages", contact)
+		}
+		if variant == variantButtonMenu {
+			onNewFlow++
+			if conversationVariant(contact, 50) != variantButtonMenu {
+				t.Fatalf("raising the percentage moved contact %s off the new flow", contact)
+			}
+		}
+	}
+	if onNewFlow < 150 || onNewFlow > 250 {
+		t.Fatalf("expected about 200 of 1000 contacts on the new flow, got %d", onNewFlow)
+	}
+}
+
+func TestMenuList_RowsRouteLikeTypedOptions(t *testing.T) {
+	program := repository.LoyaltyProgramSettings{Program: repository.ProgramBoth}
+	want := map[string]string{
+		"1": cmdCheckPoints, "2": cmdRedeemInstructions, "3": cmdRewards, "4": cmdPickupSlots,
+		"5": cmdStampCard, "pesan": cmdOrderStart, "cabang": cmdBranches,
+	}
+
+	rows := menuList(program).GetListMessage().GetSections()[0].GetRows()
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d", len(want), len(rows))
+	}
+	for _, row := range rows {
+		if got := classifyText(normalizeText(row.GetRowID())); got != want[row.GetRowID()] {
+			t.Errorf("row %q routes to %q, want %q", row.GetRowID(), got, want[row.GetRowID()])
+		}
+	}
+}
+
+func TestMenuList_PointsOnly(t *testing.T) {
+	rows := menuList(repository.LoyaltyProgramSettings{Program: repository.ProgramPoints}).GetListMessage().GetSections()[0].GetRows()
+	for _, row := range rows {
+		if row.GetRowID() == "5" {
+			t.Fatal("the stamp card row should be left out without the stamps program")
+		}
+	}
+}
@@ -1,10 +1,17 @@
 package application
 
-import "github.com/wa-serv/internal/domain"
+import (
+	"database/sql"
+	"log"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
 
 type authService struct {
 	username string
 	password string
+	db       *sql.DB // tenant API keys; nil accepts none
 }
 
 // NewAuthService creates a new auth service
@@ -15,7 +22,31 @@ func NewAuthService(username, password string) domain.AuthService {
 	}
 }
 
+// NewAuthServiceWithAPIKeys creates an auth service that also accepts the
+// tenant API keys stored in db
+func NewAuthServiceWithAPIKeys(username, password string, db *sql.DB) domain.AuthService {
+	return &authService{
+		username: username,
+		password: password,
+		db:       db,
+	}
+}
+
 // ValidateCredentials validates the provided credentials
 func (s *authService) ValidateCredentials(username, password string) bool {
 	return s.username == username && s.password == password
 }
+
+// ValidateAPIKey looks up the tenant holding key by its hash. A failed lookup
+// is logged and rejects the key.
+func (s *authService) ValidateAPIKey(key string) (string, bool) {
+	if s.db == nil || key == "" {
+		return "", false
+	}
+	slug, err := repository.GetTenantSlugByAPIKeyHash(s.db, hashAPIKey(key))
+	if err != nil {
+		log.Printf("Failed to validate API key: %v", err)
+		return "", false
+	}
+	return slug, slug != ""
+}
@@ -64,3 +64,15 @@ func TestAuthService_ValidateCredentials_BothWrong(t *testing.T) {
 	// Assert
 	assert.False(t, result)
 }
+
+func TestAuthService_ValidateAPIKey_WithoutKeyStore(t *testing.T) {
+	// Arrange
+	service := NewAuthService("testuser", "testpass")
+
+	// Act
+	tenant, ok := service.ValidateAPIKey("wp_secret")
+
+	// Assert
+	assert.False(t, ok)
+	assert.Empty(t, tenant)
+}
@@ -0,0 +1,135 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/wa-serv/automation"
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/internal/domain"
+)
+
+// automationEvents are the domain events rules can react to. message.sent is
+// left out because a rule sending on it would trigger itself.
+var automationEvents = map[string]bool{
+	eventbus.MemberRegistered:     true,
+	eventbus.MemberUpdated:        true,
+	eventbus.PointsChanged:        true,
+	eventbus.TierChanged:          true,
+	eventbus.SenderDown:           true,
+	eventbus.DefaultSenderChanged: true,
+	eventbus.StampCardCompleted:   true,
+}
+
+type automationService struct {
+	rules     domain.AutomationRepository
+	templates domain.TemplateService
+}
+
+// NewAutomationService creates an automation rule service that sends matching
+// rules' templates through templates
+func NewAutomationService(rules domain.AutomationRepository, templates domain.TemplateService) domain.AutomationService {
+	return &automationService{rules: rules, templates: templates}
+}
+
+// ListRules returns all automation rules
+func (s *automationService) ListRules(ctx context.Context) ([]*domain.AutomationRule, error) {
+	return s.rules.ListRules()
+}
+
+// CreateRule validates and stores a rule. The condition must parse and the
+// template must exist, so mistakes surface here rather than when an event fires.
+func (s *automationService) CreateRule(ctx context.Context, req *domain.CreateAutomationRuleRequest) (*domain.AutomationRule, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidAutomationRule
+	}
+	rule := &domain.AutomationRule{
+		Name:      strings.TrimSpace(req.Name),
+		Event:     strings.TrimSpace(req.Event),
+		Condition: strings.TrimSpace(req.Condition),
+		Template:  strings.ToLower(strings.TrimSpace(req.Template)),
+		To:        strings.TrimSpace(req.To),
+		Active:    req.Active == nil || *req.Active,
+	}
+
+	if rule.Name == "" || len(rule.Name) > 100 {
+		return nil, fmt.Errorf("%w: name is required and at most 100 characters", domain.ErrInvalidAutomationRule)
+	}
+	if !automationEvents[rule.Event] {
+		return nil, fmt.Errorf("%w: unsupported event %q", domain.ErrInvalidAutomationRule, rule.Event)
+	}
+	if _, err := automation.Parse(rule.Condition); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidAutomationRule, err)
+	}
+	if _, err := s.templates.GetTemplate(ctx, rule.Template); err != nil {
+		if err == domain.ErrTemplateNotFound {
+			return nil, fmt.Errorf("%w: template %q not found", domain.ErrInvalidAutomationRule, rule.Template)
+		}
+		return nil, err
+	}
+
+	id, err := s.rules.CreateRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	rule.ID = id
+	return rule, nil
+}
+
+// DeleteRule removes a rule
+func (s *automationService) DeleteRule(ctx context.Context, id int) error {
+	return s.rules.DeleteRule(id)
+}
+
+// RunRules sends the template of every active rule for event whose condition
+// matches data. The event data doubles as the template variables. Failures are
+// logged per rule so one broken rule does not stop the others.
+func (s *automationService) RunRules(ctx context.Context, event string, data map[string]any) int {
+	if !automationEvents[event] {
+		return 0
+	}
+	rules, err := s.rules.ListActiveRules(event)
+	if err != nil {
+		log.Printf("Automation: failed to load rules for %s: %v", event, err)
+		return 0
+	}
+
+	sent := 0
+	for _, rule := range rules {
+		expr, err := automation.Parse(rule.Condition)
+		if err != nil {
+			log.Printf("Automation rule %d (%s): invalid condition: %v", rule.ID, rule.Name, err)
+			continue
+		}
+		matched, err := expr.Match(data)
+		if err != nil {
+			log.Printf("Automation rule %d (%s): %v", rule.ID, rule.Name, err)
+			continue
+		}
+		if !matched {
+			continue
+		}
+
+		to := rule.To
+		if to == "" {
+			to, _ = data["phone_number"].(string)
+		}
+		if to == "" {
+			log.Printf("Automation rule %d (%s): %s event has no phone_number and the rule no recipient", rule.ID, rule.Name, event)
+			continue
+		}
+
+		if _, err := s.templates.SendTemplate(ctx, &domain.SendTemplateRequest{
+			To:        to,
+			Template:  rule.Template,
+			Variables: data,
+		}); err != nil {
+			log.Printf("Automation rule %d (%s): failed to send %s to %s: %v", rule.ID, rule.Name, rule.Template, to, err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
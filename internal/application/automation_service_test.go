@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestAutomationService_CreateRule(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockAutomationRepository{}
+	mockTemplates := &mocks.MockTemplateService{}
+	service := NewAutomationService(mockRepo, mockTemplates)
+
+	mockTemplates.On("GetTemplate", mock.Anything, "big_earner").Return(&domain.MessageTemplate{Name: "big_earner"}, nil)
+	mockRepo.On("CreateRule", mock.MatchedBy(func(r *domain.AutomationRule) bool {
+		return r.Event == "points.changed" && r.Template == "big_earner" && r.Active
+	})).Return(7, nil)
+
+	// Act
+	rule, err := service.CreateRule(context.Background(), &domain.CreateAutomationRuleRequest{
+		Name:      "Thank big earners",
+		Event:     "points.changed",
+		Condition: `reason == "earned" && points > 100`,
+		Template:  " Big_Earner ",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 7, rule.ID)
+	assert.Equal(t, "big_earner", rule.Template)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAutomationService_CreateRule_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.CreateAutomationRuleRequest
+	}{
+		{"nil request", nil},
+		{"missing name", &domain.CreateAutomationRuleRequest{Event: "points.changed", Template: "big_earner"}},
+		{"unknown event", &domain.CreateAutomationRuleRequest{Name: "x", Event: "points.earned", Template: "big_earner"}},
+		{"message.sent would loop", &domain.CreateAutomationRuleRequest{Name: "x", Event: "message.sent", Template: "big_earner"}},
+		{"bad condition", &domain.CreateAutomationRuleRequest{Name: "x", Event: "points.changed", Condition: "points >", Template: "big_earner"}},
+		{"unknown template", &domain.CreateAutomationRuleRequest{Name: "x", Event: "points.changed", Template: "missing"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockAutomationRepository{}
+			mockTemplates := &mocks.MockTemplateService{}
+			mockTemplates.On("GetTemplate", mock.Anything, "missing").Return(nil, domain.ErrTemplateNotFound)
+			service := NewAutomationService(mockRepo, mockTemplates)
+
+			_, err := service.CreateRule(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidAutomationRule)
+			mockRepo.AssertNotCalled(t, "CreateRule", mock.Anything)
+		})
+	}
+}
+
+func TestAutomationService_RunRules(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockAutomationRepository{}
+	mockTemplates := &mocks.MockTemplateService{}
+	service := NewAutomationService(mockRepo, mockTemplates)
+
+	mockRepo.On("ListActiveRules", "points.changed").Return([]*domain.AutomationRule{
+		{ID: 1, Name: "big earners", Condition: `reason == "earned" && points > 100`, Template: "big_earner"},
+		{ID: 2, Name: "small earners", Condition: `points <= 100`, Template: "small_earner"},
+		{ID: 3, Name: "notify admin", Template: "admin_alert", To: "628999"},
+		{ID: 4, Name: "broken", Condition: `points > "x"`, Template: "never"},
+		{ID: 5, Name: "send fails", Template: "flaky"},
+	}, nil)
+	data := map[string]any{"reason": "earned", "points": 150, "phone_number": "628123"}
+	mockTemplates.On("SendTemplate", mock.Anything, &domain.SendTemplateRequest{To: "628123", Template: "big_earner", Variables: data}).
+		Return(&domain.SendMessageResponse{Success: true}, nil)
+	mockTemplates.On("SendTemplate", mock.Anything, &domain.SendTemplateRequest{To: "628999", Template: "admin_alert", Variables: data}).
+		Return(&domain.SendMessageResponse{Success: true}, nil)
+	mockTemplates.On("SendTemplate", mock.Anything, &domain.SendTemplateRequest{To: "628123", Template: "flaky", Variables: data}).
+		Return(nil, errors.New("sender offline"))
+
+	// Act
+	sent := service.RunRules(context.Background(), "points.changed", data)
+
+	// Assert
+	assert.Equal(t, 2, sent)
+	mockTemplates.AssertExpectations(t)
+}
+
+func TestAutomationService_RunRules_IgnoresMessageSent(t *testing.T) {
+	mockRepo := &mocks.MockAutomationRepository{}
+	service := NewAutomationService(mockRepo, &mocks.MockTemplateService{})
+
+	sent := service.RunRules(context.Background(), "message.sent", map[string]any{"to": "628123"})
+
+	assert.Zero(t, sent)
+	mockRepo.AssertNotCalled(t, "ListActiveRules", mock.Anything)
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"sync"
+
+	"github.com/wa-serv/internal/domain"
+)
+
+type batchSendService struct {
+	messages    domain.MessageService
+	maxMessages int
+	concurrency int
+}
+
+// NewBatchSendService creates a service sending batches of up to maxMessages
+// messages through messages, concurrency of them at a time
+func NewBatchSendService(messages domain.MessageService, maxMessages, concurrency int) domain.BatchSendService {
+	return &batchSendService{
+		messages:    messages,
+		maxMessages: maxMessages,
+		concurrency: concurrency,
+	}
+}
+
+// SendMessages sends every message of req. A message that fails does not stop
+// the others; its result says why.
+func (s *batchSendService) SendMessages(ctx context.Context, req *domain.SendMessagesRequest) (*domain.SendMessagesResponse, error) {
+	if req == nil || len(req.Messages) == 0 {
+		return nil, domain.ErrInvalidBatch
+	}
+	if len(req.Messages) > s.maxMessages {
+		return nil, domain.ErrBatchTooLarge
+	}
+
+	results := make([]domain.SendMessagesResult, len(req.Messages))
+	errs := make([]error, len(req.Messages))
+	slots := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+	for i := range req.Messages {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i], errs[i] = s.send(ctx, &req.Messages[i])
+		}(i)
+	}
+	wg.Wait()
+
+	response := &domain.SendMessagesResponse{Total: len(results), Results: results}
+	for i, result := range results {
+		switch {
+		case errs[i] != nil:
+			response.Failed++
+		case result.Queued:
+			response.Queued++
+		default:
+			response.Sent++
+		}
+	}
+	return response, nil
+}
+
+func (s *batchSendService) send(ctx context.Context, req *domain.SendMessageRequest) (domain.SendMessagesResult, error) {
+	result := domain.SendMessagesResult{To: req.To}
+	resp, err := s.messages.SendMessage(ctx, req)
+	if resp != nil {
+		result.SendMessageResponse = *resp
+	} else if err != nil {
+		result.Message = err.Error()
+	}
+	return result, err
+}
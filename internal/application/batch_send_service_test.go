@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestBatchSendService_SendMessages_PerItemResults(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	service := NewBatchSendService(mockMessages, 10, 2)
+
+	req := &domain.SendMessagesRequest{Messages: []domain.SendMessageRequest{
+		{To: "6281111", Message: "Cucian Anda siap diambil"},
+		{To: "6282222", Message: "Cucian Anda siap diambil", From: "6289999"},
+		{To: "bad", Message: "Cucian Anda siap diambil"},
+	}}
+	mockMessages.On("SendMessage", mock.Anything, &req.Messages[0]).
+		Return(&domain.SendMessageResponse{Success: true, Message: "Message sent successfully", ID: "msg-1"}, nil)
+	mockMessages.On("SendMessage", mock.Anything, &req.Messages[1]).
+		Return(&domain.SendMessageResponse{Success: true, Queued: true, QueueID: 7}, nil)
+	mockMessages.On("SendMessage", mock.Anything, &req.Messages[2]).
+		Return(&domain.SendMessageResponse{Success: false, Message: "Invalid phone number format"}, domain.ErrInvalidPhoneNumber)
+
+	// Act
+	response, err := service.SendMessages(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, response.Total)
+	assert.Equal(t, 1, response.Sent)
+	assert.Equal(t, 1, response.Queued)
+	assert.Equal(t, 1, response.Failed)
+	require.Len(t, response.Results, 3)
+	assert.Equal(t, "6281111", response.Results[0].To)
+	assert.Equal(t, "msg-1", response.Results[0].ID)
+	assert.Equal(t, int64(7), response.Results[1].QueueID)
+	assert.Equal(t, "bad", response.Results[2].To)
+	assert.False(t, response.Results[2].Success)
+	assert.Equal(t, "Invalid phone number format", response.Results[2].Message)
+}
+
+func TestBatchSendService_SendMessages_BoundsConcurrency(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	service := NewBatchSendService(mockMessages, 10, 2)
+
+	var mu sync.Mutex
+	var running, peak int32
+	mockMessages.On("SendMessage", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		now := atomic.AddInt32(&running, 1)
+		mu.Lock()
+		if now > peak {
+			peak = now
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}).Return(&domain.SendMessageResponse{Success: true}, nil)
+
+	messages := make([]domain.SendMessageRequest, 6)
+	for i := range messages {
+		messages[i] = domain.SendMessageRequest{To: "6281111", Message: "Halo"}
+	}
+
+	// Act
+	response, err := service.SendMessages(context.Background(), &domain.SendMessagesRequest{Messages: messages})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 6, response.Sent)
+	assert.LessOrEqual(t, peak, int32(2))
+}
+
+func TestBatchSendService_SendMessages_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *domain.SendMessagesRequest
+		wantErr error
+	}{
+		{"nil request", nil, domain.ErrInvalidBatch},
+		{"no messages", &domain.SendMessagesRequest{}, domain.ErrInvalidBatch},
+		{"too many messages", &domain.SendMessagesRequest{Messages: make([]domain.SendMessageRequest, 3)}, domain.ErrBatchTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMessages := &mocks.MockMessageService{}
+			service := NewBatchSendService(mockMessages, 2, 1)
+
+			response, err := service.SendMessages(context.Background(), tt.req)
+
+			assert.Nil(t, response)
+			assert.Equal(t, tt.wantErr, err)
+			mockMessages.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/wa-serv/internal/domain"
+)
+
+type blocklistService struct {
+	blocklist    domain.BlocklistRepository
+	suppressions domain.SuppressionRepository
+}
+
+// NewBlocklistService creates a service that blocks contacts on a sender and
+// keeps them on the suppression list, so no sender messages them
+func NewBlocklistService(blocklist domain.BlocklistRepository, suppressions domain.SuppressionRepository) domain.BlocklistService {
+	return &blocklistService{blocklist: blocklist, suppressions: suppressions}
+}
+
+// GetBlocklist returns the contacts the sender blocked on WhatsApp
+func (s *blocklistService) GetBlocklist(ctx context.Context, senderID string) (*domain.SenderBlocklist, error) {
+	blocked, err := s.blocklist.GetBlocklist(ctx, senderID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SenderBlocklist{SenderID: senderID, Blocked: blocked}, nil
+}
+
+// BlockContact blocks the contact on the sender and suppresses it with reason
+// blocked. A contact already suppressed keeps its suppression.
+func (s *blocklistService) BlockContact(ctx context.Context, senderID string, req *domain.BlockContactRequest) (*domain.SenderBlocklist, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidBlock
+	}
+	phone, err := blockedPhone(req.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked, err := s.blocklist.UpdateBlocklist(ctx, senderID, phone, true)
+	if err != nil {
+		return nil, err
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		note = fmt.Sprintf("blocked on sender %s", senderID)
+	}
+	if _, err := s.suppressions.CreateSuppression(phone, domain.SuppressionBlocked, note); err != nil && !errors.Is(err, domain.ErrSuppressionExists) {
+		// The contact is blocked on WhatsApp either way
+		log.Printf("Failed to suppress blocked contact %s: %v", phone, err)
+	}
+	return &domain.SenderBlocklist{SenderID: senderID, Blocked: blocked}, nil
+}
+
+// UnblockContact unblocks the contact on the sender and lifts its suppression
+// when it was suppressed for being blocked. A contact that opted out stays
+// suppressed.
+func (s *blocklistService) UnblockContact(ctx context.Context, senderID, phone string) (*domain.SenderBlocklist, error) {
+	phone, err := blockedPhone(phone)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked, err := s.blocklist.UpdateBlocklist(ctx, senderID, phone, false)
+	if err != nil {
+		return nil, err
+	}
+
+	suppression, err := s.suppressions.GetSuppression(phone)
+	switch {
+	case errors.Is(err, domain.ErrSuppressionNotFound):
+	case err != nil:
+		log.Printf("Failed to check the suppression of unblocked contact %s: %v", phone, err)
+	case suppression.Reason == domain.SuppressionBlocked:
+		if err := s.suppressions.DeleteSuppression(phone); err != nil && !errors.Is(err, domain.ErrSuppressionNotFound) {
+			log.Printf("Failed to lift the suppression of unblocked contact %s: %v", phone, err)
+		}
+	}
+	return &domain.SenderBlocklist{SenderID: senderID, Blocked: blocked}, nil
+}
+
+// blockedPhone is phone in digits only; groups cannot be blocked
+func blockedPhone(phone string) (string, error) {
+	if strings.HasSuffix(strings.TrimSpace(phone), "@g.us") {
+		return "", domain.ErrInvalidBlock
+	}
+	key, err := suppressionKey(phone)
+	if err != nil {
+		return "", domain.ErrInvalidBlock
+	}
+	return key, nil
+}
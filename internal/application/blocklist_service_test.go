@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestBlocklistService_BlockContact_Suppresses(t *testing.T) {
+	// Arrange
+	mockBlocklist := &mocks.MockBlocklistRepository{}
+	mockSuppressions := &mocks.MockSuppressionRepository{}
+	service := NewBlocklistService(mockBlocklist, mockSuppressions)
+
+	mockBlocklist.On("UpdateBlocklist", mock.Anything, "6281111111111", "6281234567890", true).
+		Return([]string{"6281234567890"}, nil)
+	mockSuppressions.On("CreateSuppression", "6281234567890", domain.SuppressionBlocked, "blocked on sender 6281111111111").
+		Return(&domain.Suppression{Recipient: "6281234567890", Reason: domain.SuppressionBlocked}, nil)
+
+	// Act
+	blocklist, err := service.BlockContact(context.Background(), "6281111111111", &domain.BlockContactRequest{Phone: "+62 812-3456-7890"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"6281234567890"}, blocklist.Blocked)
+	mockBlocklist.AssertExpectations(t)
+	mockSuppressions.AssertExpectations(t)
+}
+
+func TestBlocklistService_BlockContact_AlreadySuppressed(t *testing.T) {
+	// Arrange
+	mockBlocklist := &mocks.MockBlocklistRepository{}
+	mockSuppressions := &mocks.MockSuppressionRepository{}
+	service := NewBlocklistService(mockBlocklist, mockSuppressions)
+
+	mockBlocklist.On("UpdateBlocklist", mock.Anything, "6281111111111", "6281234567890", true).
+		Return([]string{"6281234567890"}, nil)
+	mockSuppressions.On("CreateSuppression", "6281234567890", domain.SuppressionBlocked, "spam").
+		Return(nil, domain.ErrSuppressionExists)
+
+	// Act
+	_, err := service.BlockContact(context.Background(), "6281111111111", &domain.BlockContactRequest{Phone: "6281234567890", Note: " spam "})
+
+	// Assert
+	assert.NoError(t, err, "a contact that opted out earlier can still be blocked")
+}
+
+func TestBlocklistService_BlockContact_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.BlockContactRequest
+	}{
+		{"nil request", nil},
+		{"short phone", &domain.BlockContactRequest{Phone: "12345"}},
+		{"group", &domain.BlockContactRequest{Phone: "120363025246125486@g.us"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBlocklist := &mocks.MockBlocklistRepository{}
+			service := NewBlocklistService(mockBlocklist, &mocks.MockSuppressionRepository{})
+
+			_, err := service.BlockContact(context.Background(), "6281111111111", tt.req)
+
+			assert.Equal(t, domain.ErrInvalidBlock, err)
+			mockBlocklist.AssertNotCalled(t, "UpdateBlocklist", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBlocklistService_UnblockContact(t *testing.T) {
+	tests := []struct {
+		name       string
+		reason     string
+		wantLifted bool
+	}{
+		{"suppressed for being blocked", domain.SuppressionBlocked, true},
+		{"opted out", domain.SuppressionOptedOut, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockBlocklist := &mocks.MockBlocklistRepository{}
+			mockSuppressions := &mocks.MockSuppressionRepository{}
+			service := NewBlocklistService(mockBlocklist, mockSuppressions)
+
+			mockBlocklist.On("UpdateBlocklist", mock.Anything, "6281111111111", "6281234567890", false).Return([]string{}, nil)
+			mockSuppressions.On("GetSuppression", "6281234567890").
+				Return(&domain.Suppression{Recipient: "6281234567890", Reason: tt.reason}, nil)
+			mockSuppressions.On("DeleteSuppression", "6281234567890").Return(nil)
+
+			// Act
+			blocklist, err := service.UnblockContact(context.Background(), "6281111111111", "6281234567890")
+
+			// Assert
+			require.NoError(t, err)
+			assert.Empty(t, blocklist.Blocked)
+			if tt.wantLifted {
+				mockSuppressions.AssertCalled(t, "DeleteSuppression", "6281234567890")
+			} else {
+				mockSuppressions.AssertNotCalled(t, "DeleteSuppression", mock.Anything)
+			}
+		})
+	}
+}
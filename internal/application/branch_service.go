@@ -0,0 +1,169 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+)
+
+type branchService struct {
+	db       *sql.DB
+	messages domain.MessageService
+}
+
+// NewBranchService creates a branch directory service that shares branch
+// locations through messages
+func NewBranchService(db *sql.DB, messages domain.MessageService) domain.BranchService {
+	return &branchService{db: db, messages: messages}
+}
+
+// ListBranches returns all branches in directory order
+func (s *branchService) ListBranches(ctx context.Context) ([]*domain.Branch, error) {
+	branches, err := repository.GetBranches(s.db)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Branch, 0, len(branches))
+	for _, b := range branches {
+		result = append(result, toDomainBranch(b))
+	}
+	return result, nil
+}
+
+// GetBranch returns one branch
+func (s *branchService) GetBranch(ctx context.Context, id int) (*domain.Branch, error) {
+	branch, err := repository.GetBranch(s.db, id)
+	if err == repository.ErrBranchNotFound {
+		return nil, domain.ErrBranchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBranch(*branch), nil
+}
+
+// CreateBranch adds a branch to the directory
+func (s *branchService) CreateBranch(ctx context.Context, req *domain.SaveBranchRequest) (*domain.Branch, error) {
+	branch, err := validateSaveBranchRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	branch.ID, err = repository.CreateBranch(s.db, branch)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBranch(branch), nil
+}
+
+// UpdateBranch replaces the details of a branch
+func (s *branchService) UpdateBranch(ctx context.Context, id int, req *domain.SaveBranchRequest) (*domain.Branch, error) {
+	branch, err := validateSaveBranchRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	branch.ID = id
+	err = repository.UpdateBranch(s.db, branch)
+	if err == repository.ErrBranchNotFound {
+		return nil, domain.ErrBranchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainBranch(branch), nil
+}
+
+// DeleteBranch removes a branch from the directory
+func (s *branchService) DeleteBranch(ctx context.Context, id int) error {
+	err := repository.DeleteBranch(s.db, id)
+	if err == repository.ErrBranchNotFound {
+		return domain.ErrBranchNotFound
+	}
+	return err
+}
+
+// SendBranchLocation shares a branch as a WhatsApp location pin named after it
+func (s *branchService) SendBranchLocation(ctx context.Context, id int, req *domain.SendBranchLocationRequest) (*domain.SendMessageResponse, error) {
+	branch, err := s.GetBranch(ctx, id)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+	return s.messages.SendLocation(ctx, &domain.SendLocationRequest{
+		To:        req.To,
+		Latitude:  &branch.Latitude,
+		Longitude: &branch.Longitude,
+		Name:      branch.Name,
+		Address:   branch.Address,
+		From:      req.From,
+	})
+}
+
+// validateSaveBranchRequest trims the branch details and checks the fields the
+// chat directory and location pin need
+func validateSaveBranchRequest(req *domain.SaveBranchRequest) (repository.Branch, error) {
+	if req == nil {
+		return repository.Branch{}, domain.ErrInvalidBranch
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return repository.Branch{}, fmt.Errorf("%w: name is required and at most 100 characters", domain.ErrInvalidBranch)
+	}
+	address := strings.TrimSpace(req.Address)
+	if address == "" {
+		return repository.Branch{}, fmt.Errorf("%w: address is required", domain.ErrInvalidBranch)
+	}
+	hours := strings.TrimSpace(req.Hours)
+	if len(hours) > 200 {
+		return repository.Branch{}, fmt.Errorf("%w: hours must be at most 200 characters", domain.ErrInvalidBranch)
+	}
+	if req.Latitude == nil || req.Longitude == nil {
+		return repository.Branch{}, fmt.Errorf("%w: latitude and longitude are required", domain.ErrInvalidBranch)
+	}
+	latitude, longitude := *req.Latitude, *req.Longitude
+	if math.IsNaN(latitude) || math.IsNaN(longitude) || math.Abs(latitude) > 90 || math.Abs(longitude) > 180 {
+		return repository.Branch{}, fmt.Errorf("%w: latitude must be between -90 and 90 and longitude between -180 and 180", domain.ErrInvalidBranch)
+	}
+
+	var whatsAppNumber string
+	if strings.TrimSpace(req.WhatsAppNumber) != "" {
+		whatsAppNumber = cleanPhoneNumber(req.WhatsAppNumber)
+		if len(whatsAppNumber) < 10 || len(whatsAppNumber) > 15 {
+			return repository.Branch{}, fmt.Errorf("%w: whatsapp_number must have 10 to 15 digits", domain.ErrInvalidBranch)
+		}
+	}
+	mapsURL := strings.TrimSpace(req.MapsURL)
+	if mapsURL != "" && !strings.HasPrefix(mapsURL, "https://") {
+		return repository.Branch{}, fmt.Errorf("%w: maps_url must be an https link", domain.ErrInvalidBranch)
+	}
+
+	return repository.Branch{
+		Name:           name,
+		Address:        address,
+		Hours:          hours,
+		WhatsAppNumber: whatsAppNumber,
+		Latitude:       latitude,
+		Longitude:      longitude,
+		MapsURL:        mapsURL,
+	}, nil
+}
+
+func toDomainBranch(b repository.Branch) *domain.Branch {
+	return &domain.Branch{
+		ID:             b.ID,
+		Name:           b.Name,
+		Address:        b.Address,
+		Hours:          b.Hours,
+		WhatsAppNumber: b.WhatsAppNumber,
+		Latitude:       b.Latitude,
+		Longitude:      b.Longitude,
+		MapsURL:        processor.BranchMapsURL(b),
+	}
+}
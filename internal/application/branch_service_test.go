@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/repository"
+)
+
+func TestValidateSaveBranchRequest(t *testing.T) {
+	latitude, longitude := -6.914744, 107.60981
+	valid := func() *domain.SaveBranchRequest {
+		return &domain.SaveBranchRequest{
+			Name:      "Ruang Laundry Dago",
+			Address:   "Jl. Ir. H. Juanda No. 12, Bandung",
+			Latitude:  &latitude,
+			Longitude: &longitude,
+		}
+	}
+	outOfRange := 95.0
+
+	tests := []struct {
+		name    string
+		modify  func(req *domain.SaveBranchRequest)
+		wantErr bool
+	}{
+		{"valid", func(req *domain.SaveBranchRequest) {}, false},
+		{"blank name", func(req *domain.SaveBranchRequest) { req.Name = " " }, true},
+		{"blank address", func(req *domain.SaveBranchRequest) { req.Address = "" }, true},
+		{"missing coordinates", func(req *domain.SaveBranchRequest) { req.Longitude = nil }, true},
+		{"latitude out of range", func(req *domain.SaveBranchRequest) { req.Latitude = &outOfRange }, true},
+		{"short whatsapp number", func(req *domain.SaveBranchRequest) { req.WhatsAppNumber = "0812" }, true},
+		{"maps url not https", func(req *domain.SaveBranchRequest) { req.MapsURL = "javascript:alert(1)" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(req)
+
+			_, err := validateSaveBranchRequest(req)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidBranch)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateSaveBranchRequest_CleansWhatsAppNumber(t *testing.T) {
+	latitude, longitude := -6.9, 107.6
+	branch, err := validateSaveBranchRequest(&domain.SaveBranchRequest{
+		Name:           " Cabang Dago ",
+		Address:        "Jl. Dago 1",
+		WhatsAppNumber: "+62 812-3456-7890",
+		Latitude:       &latitude,
+		Longitude:      &longitude,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Cabang Dago", branch.Name)
+	assert.Equal(t, "6281234567890", branch.WhatsAppNumber)
+}
+
+func TestToDomainBranch_MapsURL(t *testing.T) {
+	branch := repository.Branch{ID: 1, Name: "Dago", Latitude: -6.914744, Longitude: 107.60981}
+	assert.Equal(t, "https://www.google.com/maps/search/?api=1&query=-6.914744,107.609810", toDomainBranch(branch).MapsURL)
+
+	// An explicit link, e.g. to the Google Maps place with reviews, wins
+	branch.MapsURL = "https://maps.app.goo.gl/abc123"
+	assert.Equal(t, "https://maps.app.goo.gl/abc123", toDomainBranch(branch).MapsURL)
+}
+
+func TestBranchService_CreateBranch_InvalidNeverSends(t *testing.T) {
+	// Invalid requests are rejected before touching the database
+	mockMessageService := &mocks.MockMessageService{}
+	service := NewBranchService(nil, mockMessageService)
+
+	branch, err := service.CreateBranch(context.Background(), &domain.SaveBranchRequest{Name: "Dago"})
+
+	assert.Nil(t, branch)
+	assert.ErrorIs(t, err, domain.ErrInvalidBranch)
+	mockMessageService.AssertNotCalled(t, "SendLocation", mock.Anything, mock.Anything)
+}
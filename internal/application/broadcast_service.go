@@ -0,0 +1,272 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wa-serv/alerting"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+)
+
+// broadcastRetention is how long a finished broadcast stays queryable
+const broadcastRetention = 24 * time.Hour
+
+type broadcastJob struct {
+	job         domain.BroadcastJob
+	completedAt time.Time
+}
+
+type broadcastService struct {
+	messages      domain.MessageService
+	segments      domain.SegmentRepository
+	pacer         *senderPacer
+	maxRecipients int
+
+	mu   sync.RWMutex
+	jobs map[string]*broadcastJob
+}
+
+// NewBroadcastService creates a broadcast service that sends through messages at
+// most perMinute messages per sender per minute
+func NewBroadcastService(messages domain.MessageService, segments domain.SegmentRepository, perMinute, maxRecipients int) domain.BroadcastService {
+	return &broadcastService{
+		messages:      messages,
+		segments:      segments,
+		pacer:         newSenderPacer(time.Minute / time.Duration(perMinute)),
+		maxRecipients: maxRecipients,
+		jobs:          make(map[string]*broadcastJob),
+	}
+}
+
+// StartBroadcast resolves the recipients and starts sending in the background.
+// The returned job is a snapshot; poll GetBroadcast for progress.
+func (s *broadcastService) StartBroadcast(ctx context.Context, req *domain.BroadcastRequest) (*domain.BroadcastJob, error) {
+	if req == nil || strings.TrimSpace(req.Message) == "" {
+		return nil, domain.ErrInvalidBroadcast
+	}
+
+	recipients, err := s.resolveRecipients(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipients) == 0 {
+		return nil, domain.ErrInvalidBroadcast
+	}
+	if len(recipients) > s.maxRecipients {
+		return nil, domain.ErrBroadcastTooLarge
+	}
+
+	job := &broadcastJob{job: domain.BroadcastJob{
+		ID:        uuid.New().String(),
+		Status:    domain.BroadcastRunning,
+		Total:     len(recipients),
+		CreatedAt: time.Now().Format(time.RFC3339),
+		Results:   make([]domain.BroadcastResult, len(recipients)),
+	}}
+	for i, to := range recipients {
+		job.job.Results[i] = domain.BroadcastResult{To: to, Status: domain.BroadcastPending}
+	}
+
+	s.mu.Lock()
+	s.cleanupLocked()
+	s.jobs[job.job.ID] = job
+	s.mu.Unlock()
+
+	// The request context ends with the HTTP response, so the job runs on its own
+	go s.run(context.Background(), job, req)
+
+	return s.snapshot(job), nil
+}
+
+// GetBroadcast returns the progress of a broadcast
+func (s *broadcastService) GetBroadcast(ctx context.Context, id string) (*domain.BroadcastJob, error) {
+	s.mu.RLock()
+	job, ok := s.jobs[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, domain.ErrBroadcastNotFound
+	}
+	return s.snapshot(job), nil
+}
+
+// resolveRecipients merges the explicit recipients with the segment's members,
+// dropping blanks and duplicates while keeping the order. Numbers are
+// normalized first, so one written two ways is sent once.
+func (s *broadcastService) resolveRecipients(req *domain.BroadcastRequest) ([]string, error) {
+	candidates := append([]string(nil), req.Recipients...)
+
+	if req.Segment != nil {
+		members, err := s.segments.ListSegmentMembers(req.Segment.MinPoints)
+		if err != nil {
+			return nil, err
+		}
+		tier := strings.TrimSpace(req.Segment.Tier)
+		for _, m := range members {
+			if tier == "" || strings.EqualFold(processor.TierForPoints(m.AccumulatedPoints), tier) {
+				candidates = append(candidates, m.PhoneNumber)
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(candidates))
+	recipients := make([]string, 0, len(candidates))
+	for _, to := range candidates {
+		to = normalizeBroadcastRecipient(to)
+		if to == "" || seen[to] {
+			continue
+		}
+		seen[to] = true
+		recipients = append(recipients, to)
+	}
+	return recipients, nil
+}
+
+// defaultCountryCode replaces the leading 0 of numbers written the local way
+const defaultCountryCode = "62"
+
+// phoneSeparators are the characters people put in phone numbers
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "+", "")
+
+// normalizeBroadcastRecipient writes a phone number the way members are
+// stored, so +62 812-3456-7890, 6281234567890 and 081234567890 are the same
+// recipient. Group JIDs, and anything else that is not a number, are kept as
+// written for the send to accept or reject.
+func normalizeBroadcastRecipient(to string) string {
+	to = strings.TrimSpace(to)
+	phone := phoneSeparators.Replace(strings.TrimSuffix(to, "@s.whatsapp.net"))
+	for _, c := range phone {
+		if c < '0' || c > '9' {
+			return to
+		}
+	}
+	if strings.HasPrefix(phone, "0") {
+		phone = defaultCountryCode + phone[1:]
+	}
+	return phone
+}
+
+func (s *broadcastService) run(ctx context.Context, job *broadcastJob, req *domain.BroadcastRequest) {
+	for i := range job.job.Results {
+		if err := s.pacer.wait(ctx, req.From); err != nil {
+			return
+		}
+
+		s.mu.RLock()
+		to := job.job.Results[i].To
+		s.mu.RUnlock()
+
+		resp, err := s.messages.SendMessage(ctx, &domain.SendMessageRequest{
+			To:       to,
+			Message:  req.Message,
+			From:     req.From,
+			Category: req.Category,
+		})
+
+		s.mu.Lock()
+		result := &job.job.Results[i]
+		if err != nil {
+			result.Status = domain.BroadcastFailed
+			result.Error = err.Error()
+			job.job.Failed++
+		} else if resp.Queued {
+			result.Status = domain.BroadcastQueued
+			job.job.Queued++
+		} else {
+			result.Status = domain.BroadcastSent
+			result.MessageID = resp.ID
+			job.job.Sent++
+		}
+		s.mu.Unlock()
+	}
+
+	s.mu.Lock()
+	job.completedAt = time.Now()
+	job.job.Status = domain.BroadcastCompleted
+	job.job.CompletedAt = job.completedAt.Format(time.RFC3339)
+	sent, failed, queued := job.job.Sent, job.job.Failed, job.job.Queued
+	s.mu.Unlock()
+
+	log.Printf("Broadcast %s completed: %d sent, %d failed, %d queued for retry", job.job.ID, sent, failed, queued)
+	if failed > 0 {
+		s.alertFailed(ctx, job.job.ID, job.job.Total, failed)
+	}
+}
+
+// alertFailed tells the admins who asked for failed campaign alerts that a
+// broadcast finished with failed sends. It is sent from the default sender, as
+// the broadcast's own may be what failed.
+func (s *broadcastService) alertFailed(ctx context.Context, id string, total, failed int) {
+	alerting.Default().Notify(alerting.Alert{
+		Type:    alerting.FailedCampaign,
+		Subject: "Broadcast gagal terkirim",
+		Text:    fmt.Sprintf("⚠️ *Broadcast Gagal*\n\n%d dari %d pesan broadcast %s gagal terkirim. Periksa hasilnya di GET /api/broadcast/%s.", failed, total, id, id),
+		Data:    map[string]any{"broadcast_id": id, "total": total, "failed": failed},
+	}, func(phone, text string) {
+		if _, err := s.messages.SendMessage(ctx, &domain.SendMessageRequest{To: phone, Message: text}); err != nil {
+			log.Printf("Failed to send failed broadcast alert to %s: %v", phone, err)
+		}
+	})
+}
+
+func (s *broadcastService) snapshot(job *broadcastJob) *domain.BroadcastJob {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	copied := job.job
+	copied.Results = append([]domain.BroadcastResult(nil), job.job.Results...)
+	return &copied
+}
+
+// cleanupLocked forgets broadcasts that finished more than broadcastRetention ago
+func (s *broadcastService) cleanupLocked() {
+	cutoff := time.Now().Add(-broadcastRetention)
+	for id, job := range s.jobs {
+		if job.job.Status == domain.BroadcastCompleted && job.completedAt.Before(cutoff) {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// senderPacer spaces sends on each sender at least interval apart. Broadcasts
+// on the same sender share its pace, so running two does not double the rate.
+type senderPacer struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func newSenderPacer(interval time.Duration) *senderPacer {
+	return &senderPacer{interval: interval, next: make(map[string]time.Time)}
+}
+
+// wait blocks until sender may send again, reserving the following slot
+func (p *senderPacer) wait(ctx context.Context, sender string) error {
+	p.mu.Lock()
+	now := time.Now()
+	slot := p.next[sender]
+	if slot.Before(now) {
+		slot = now
+	}
+	p.next[sender] = slot.Add(p.interval)
+	p.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,170 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+// waitForBroadcast polls until the broadcast completes
+func waitForBroadcast(t *testing.T, service domain.BroadcastService, id string) *domain.BroadcastJob {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := service.GetBroadcast(context.Background(), id)
+		require.NoError(t, err)
+		if job.Status == domain.BroadcastCompleted {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("broadcast %s did not complete", id)
+	return nil
+}
+
+func TestBroadcastService_StartBroadcast_SegmentAndRecipients(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockSegments := &mocks.MockSegmentRepository{}
+	service := NewBroadcastService(mockMessages, mockSegments, 60000, 100)
+
+	mockSegments.On("ListSegmentMembers", 0).Return([]*domain.SegmentMember{
+		{PhoneNumber: "628111", AccumulatedPoints: 600}, // Gold
+		{PhoneNumber: "628222", AccumulatedPoints: 50},  // Bronze
+		{PhoneNumber: "628333", AccumulatedPoints: 700}, // Gold, also listed explicitly
+	}, nil)
+	mockMessages.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *domain.SendMessageRequest) bool {
+		return req.To == "628333" || req.To == "628111"
+	})).Return(&domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil)
+	mockMessages.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *domain.SendMessageRequest) bool {
+		return req.To == "628999"
+	})).Return(&domain.SendMessageResponse{Success: false}, errors.New("not on WhatsApp"))
+
+	// Act
+	job, err := service.StartBroadcast(context.Background(), &domain.BroadcastRequest{
+		Recipients: []string{"+628333", "628999", " "},
+		Segment:    &domain.BroadcastSegment{Tier: "gold"},
+		Message:    "Promo cuci 2x gratis 1!",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, job.Total)
+	assert.Equal(t, domain.BroadcastRunning, job.Status)
+
+	done := waitForBroadcast(t, service, job.ID)
+	assert.Equal(t, 2, done.Sent)
+	assert.Equal(t, 1, done.Failed)
+	assert.Equal(t, []string{"628333", "628999", "628111"}, []string{done.Results[0].To, done.Results[1].To, done.Results[2].To})
+	assert.Equal(t, domain.BroadcastFailed, done.Results[1].Status)
+	assert.Equal(t, "not on WhatsApp", done.Results[1].Error)
+	assert.Equal(t, "msg-1", done.Results[2].MessageID)
+	assert.NotEmpty(t, done.CompletedAt)
+}
+
+func TestBroadcastService_StartBroadcast_DedupesNumberFormats(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockSegments := &mocks.MockSegmentRepository{}
+	service := NewBroadcastService(mockMessages, mockSegments, 60000, 100)
+
+	mockSegments.On("ListSegmentMembers", 0).Return([]*domain.SegmentMember{
+		{PhoneNumber: "6281234567890"},
+	}, nil)
+	mockMessages.On("SendMessage", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil)
+
+	// Act
+	job, err := service.StartBroadcast(context.Background(), &domain.BroadcastRequest{
+		Recipients: []string{"+62 812-3456-7890", "081234567890", "6281234567890@s.whatsapp.net", "120363025246125486@g.us"},
+		Segment:    &domain.BroadcastSegment{},
+		Message:    "Promo cuci 2x gratis 1!",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, job.Total)
+	assert.Equal(t, "6281234567890", job.Results[0].To)
+	assert.Equal(t, "120363025246125486@g.us", job.Results[1].To)
+
+	waitForBroadcast(t, service, job.ID)
+	mockMessages.AssertNumberOfCalls(t, "SendMessage", 2)
+}
+
+func TestNormalizeBroadcastRecipient(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"+62 812-3456-7890", "6281234567890"},
+		{"6281234567890", "6281234567890"},
+		{"081234567890", "6281234567890"},
+		{" (0812) 3456.7890 ", "6281234567890"},
+		{"14155550123", "14155550123"},
+		{"120363025246125486@g.us", "120363025246125486@g.us"},
+		{"not-a-number", "not-a-number"},
+		{" ", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, normalizeBroadcastRecipient(tt.in), tt.in)
+	}
+}
+
+func TestBroadcastService_StartBroadcast_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *domain.BroadcastRequest
+		wantErr error
+	}{
+		{"nil request", nil, domain.ErrInvalidBroadcast},
+		{"blank message", &domain.BroadcastRequest{Recipients: []string{"628111"}, Message: " "}, domain.ErrInvalidBroadcast},
+		{"no recipients", &domain.BroadcastRequest{Recipients: []string{""}, Message: "Halo"}, domain.ErrInvalidBroadcast},
+		{"too many recipients", &domain.BroadcastRequest{Recipients: []string{"628111", "628222", "628333"}, Message: "Halo"}, domain.ErrBroadcastTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMessages := &mocks.MockMessageService{}
+			service := NewBroadcastService(mockMessages, &mocks.MockSegmentRepository{}, 60000, 2)
+
+			_, err := service.StartBroadcast(context.Background(), tt.req)
+
+			assert.Equal(t, tt.wantErr, err)
+			mockMessages.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBroadcastService_GetBroadcast_NotFound(t *testing.T) {
+	service := NewBroadcastService(&mocks.MockMessageService{}, &mocks.MockSegmentRepository{}, 20, 100)
+
+	_, err := service.GetBroadcast(context.Background(), "missing")
+
+	assert.Equal(t, domain.ErrBroadcastNotFound, err)
+}
+
+func TestSenderPacer_SpacesSendsPerSender(t *testing.T) {
+	// Arrange
+	pacer := newSenderPacer(30 * time.Millisecond)
+	ctx := context.Background()
+
+	// Act
+	start := time.Now()
+	require.NoError(t, pacer.wait(ctx, "628111"))
+	require.NoError(t, pacer.wait(ctx, "628222")) // another sender is not held back
+	firstSenderFree := time.Since(start)
+	require.NoError(t, pacer.wait(ctx, "628111"))
+	require.NoError(t, pacer.wait(ctx, "628111"))
+
+	// Assert
+	assert.Less(t, firstSenderFree, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+}
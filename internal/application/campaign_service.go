@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+// maxCampaignMultiplier keeps a typo such as 20 instead of 2.0 from flooding
+// members with points
+const maxCampaignMultiplier = 10
+
+type campaignService struct {
+	db         *sql.DB
+	broadcasts domain.BroadcastService
+}
+
+// NewCampaignService creates a points campaign service that announces new
+// campaigns through broadcasts
+func NewCampaignService(db *sql.DB, broadcasts domain.BroadcastService) domain.CampaignService {
+	return &campaignService{db: db, broadcasts: broadcasts}
+}
+
+// CreateCampaign schedules a campaign and, when asked, starts a broadcast
+// announcing it. A failed announcement does not undo the campaign; it is
+// reported in the response instead.
+func (s *campaignService) CreateCampaign(ctx context.Context, req *domain.CreatePointsCampaignRequest) (*domain.CreatePointsCampaignResponse, error) {
+	campaign, err := validateCreateCampaignRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign.ID, err = repository.CreateCampaign(s.db, campaign)
+	if err != nil {
+		return nil, err
+	}
+	resp := &domain.CreatePointsCampaignResponse{Campaign: toDomainCampaign(campaign, time.Now())}
+
+	if req.Announce != nil {
+		segment := req.Announce.Segment
+		if segment == nil {
+			segment = &domain.BroadcastSegment{}
+		}
+		job, err := s.broadcasts.StartBroadcast(ctx, &domain.BroadcastRequest{
+			Segment: segment,
+			Message: req.Announce.Message,
+		})
+		if err != nil {
+			resp.AnnounceError = err.Error()
+		} else {
+			resp.BroadcastID = job.ID
+		}
+	}
+	return resp, nil
+}
+
+// ListCampaigns returns all campaigns, latest start first
+func (s *campaignService) ListCampaigns(ctx context.Context) ([]*domain.PointsCampaign, error) {
+	campaigns, err := repository.GetCampaigns(s.db)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	result := make([]*domain.PointsCampaign, 0, len(campaigns))
+	for _, c := range campaigns {
+		result = append(result, toDomainCampaign(c, now))
+	}
+	return result, nil
+}
+
+// DeleteCampaign removes a campaign, ending it early if it is running
+func (s *campaignService) DeleteCampaign(ctx context.Context, id int) error {
+	err := repository.DeleteCampaign(s.db, id)
+	if err == repository.ErrCampaignNotFound {
+		return domain.ErrCampaignNotFound
+	}
+	return err
+}
+
+// validateCreateCampaignRequest parses the campaign window into the server
+// time zone, which is how points_campaigns timestamps are stored, and
+// normalizes the items to the lower-case form chat commands arrive in
+func validateCreateCampaignRequest(req *domain.CreatePointsCampaignRequest) (repository.Campaign, error) {
+	if req == nil {
+		return repository.Campaign{}, domain.ErrInvalidCampaign
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return repository.Campaign{}, fmt.Errorf("%w: name is required and at most 100 characters", domain.ErrInvalidCampaign)
+	}
+	startsAt, err := time.Parse(time.RFC3339, req.StartsAt)
+	if err != nil {
+		return repository.Campaign{}, fmt.Errorf("%w: starts_at must be RFC3339", domain.ErrInvalidCampaign)
+	}
+	endsAt, err := time.Parse(time.RFC3339, req.EndsAt)
+	if err != nil || !endsAt.After(startsAt) {
+		return repository.Campaign{}, fmt.Errorf("%w: ends_at must be RFC3339 and after starts_at", domain.ErrInvalidCampaign)
+	}
+	if req.Multiplier <= 1 || req.Multiplier > maxCampaignMultiplier {
+		return repository.Campaign{}, fmt.Errorf("%w: multiplier must be greater than 1 and at most %d", domain.ErrInvalidCampaign, maxCampaignMultiplier)
+	}
+	if req.Announce != nil && strings.TrimSpace(req.Announce.Message) == "" {
+		return repository.Campaign{}, fmt.Errorf("%w: announce needs a message", domain.ErrInvalidCampaign)
+	}
+
+	var items []string
+	seen := make(map[string]bool)
+	for _, item := range req.Items {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" || seen[item] {
+			continue
+		}
+		if strings.ContainsAny(item, ",#") {
+			return repository.Campaign{}, fmt.Errorf("%w: item %q may not contain , or #", domain.ErrInvalidCampaign, item)
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+
+	return repository.Campaign{
+		Name:       name,
+		StartsAt:   startsAt.In(time.Local),
+		EndsAt:     endsAt.In(time.Local),
+		Multiplier: req.Multiplier,
+		Items:      items,
+	}, nil
+}
+
+func toDomainCampaign(c repository.Campaign, now time.Time) *domain.PointsCampaign {
+	return &domain.PointsCampaign{
+		ID:         c.ID,
+		Name:       c.Name,
+		StartsAt:   c.StartsAt.Format(time.RFC3339),
+		EndsAt:     c.EndsAt.Format(time.RFC3339),
+		Multiplier: c.Multiplier,
+		Items:      c.Items,
+		Active:     !now.Before(c.StartsAt) && now.Before(c.EndsAt),
+	}
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+)
+
+func TestValidateCreateCampaignRequest(t *testing.T) {
+	valid := func() *domain.CreatePointsCampaignRequest {
+		return &domain.CreatePointsCampaignRequest{
+			Name:       "Happy hour",
+			StartsAt:   "2026-10-17T15:00:00+07:00",
+			EndsAt:     "2026-10-17T17:00:00+07:00",
+			Multiplier: 2,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(req *domain.CreatePointsCampaignRequest)
+		wantErr bool
+	}{
+		{"valid", func(req *domain.CreatePointsCampaignRequest) {}, false},
+		{"blank name", func(req *domain.CreatePointsCampaignRequest) { req.Name = " " }, true},
+		{"bad start", func(req *domain.CreatePointsCampaignRequest) { req.StartsAt = "friday" }, true},
+		{"end before start", func(req *domain.CreatePointsCampaignRequest) { req.EndsAt = "2026-10-17T14:00:00+07:00" }, true},
+		{"multiplier of one", func(req *domain.CreatePointsCampaignRequest) { req.Multiplier = 1 }, true},
+		{"multiplier too large", func(req *domain.CreatePointsCampaignRequest) { req.Multiplier = 20 }, true},
+		{"item with separator", func(req *domain.CreatePointsCampaignRequest) { req.Items = []string{"cuci#kering"} }, true},
+		{"announce without message", func(req *domain.CreatePointsCampaignRequest) {
+			req.Announce = &domain.CampaignAnnouncement{Message: " "}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(req)
+
+			_, err := validateCreateCampaignRequest(req)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidCampaign)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateCreateCampaignRequest_NormalizesItems(t *testing.T) {
+	campaign, err := validateCreateCampaignRequest(&domain.CreatePointsCampaignRequest{
+		Name:       "Double points on dry cleaning",
+		StartsAt:   "2026-10-17T15:00:00+07:00",
+		EndsAt:     "2026-10-17T17:00:00+07:00",
+		Multiplier: 1.5,
+		Items:      []string{" Cuci Kering ", "cuci kering", "", "setrika"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cuci kering", "setrika"}, campaign.Items)
+}
+
+func TestCampaignService_CreateCampaign_Invalid(t *testing.T) {
+	service := NewCampaignService(nil, nil)
+
+	resp, err := service.CreateCampaign(context.Background(), nil)
+
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, domain.ErrInvalidCampaign)
+}
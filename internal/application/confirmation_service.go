@@ -0,0 +1,232 @@
+package application
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+)
+
+// maxConfirmationAttempts is how many wrong codes use a confirmation up
+const maxConfirmationAttempts = 5
+
+// totpStep is the RFC 6238 time step authenticator apps use
+const totpStep = 30 * time.Second
+
+// confirmActions describes, in the owner's WhatsApp message, each action that
+// can be confirmed
+var confirmActions = map[string]string{
+	domain.ConfirmActionDeleteSender:     "menghapus sender",
+	domain.ConfirmActionClearSessions:    "menghapus sesi WhatsApp",
+	domain.ConfirmActionEraseMember:      "menghapus data member",
+	domain.ConfirmActionRevokeMessage:    "menarik pesan",
+	domain.ConfirmActionDeleteTemplate:   "menghapus template",
+	domain.ConfirmActionDeleteAutomation: "menghapus aturan otomatis",
+	domain.ConfirmActionDeleteCampaign:   "menghapus kampanye",
+	domain.ConfirmActionDeleteBranch:     "menghapus cabang",
+	domain.ConfirmActionRemoveFromGroup:  "mengeluarkan anggota grup",
+	domain.ConfirmActionDeleteLabel:      "menghapus label",
+	domain.ConfirmActionDeleteReport:     "menghapus jadwal laporan",
+}
+
+type pendingConfirmation struct {
+	confirmation domain.Confirmation
+	code         string // empty for TOTP, whose code changes every step
+	expiresAt    time.Time
+	attempts     int
+}
+
+type confirmationService struct {
+	whatsappRepo domain.WhatsAppRepository
+	cfg          config.ConfirmationConfig
+	now          func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]*pendingConfirmation // key: token
+	// lastTOTPStep is the time step of the last TOTP code accepted. The secret
+	// is the one authenticator every admin confirms with, so a code is accepted
+	// once, whichever token it is used for.
+	lastTOTPStep int64
+}
+
+// NewConfirmationService creates a service confirming destructive actions with
+// a TOTP code when cfg has a TOTP secret, or else with a one-time code sent to
+// the owner phones on WhatsApp. Pending confirmations, with their codes and
+// attempt counts, are kept in memory: they do not survive a restart, and the
+// API is assumed to run as a single instance, since a confirmation can only be
+// used on the instance that issued it.
+func NewConfirmationService(whatsappRepo domain.WhatsAppRepository, cfg config.ConfirmationConfig) domain.ConfirmationService {
+	return &confirmationService{
+		whatsappRepo: whatsappRepo,
+		cfg:          cfg,
+		now:          time.Now,
+		pending:      make(map[string]*pendingConfirmation),
+	}
+}
+
+// CreateConfirmation issues a confirmation token for action on target and, for
+// WhatsApp confirmations, sends its code to the owner phones
+func (s *confirmationService) CreateConfirmation(ctx context.Context, req *domain.CreateConfirmationRequest) (*domain.Confirmation, error) {
+	action := strings.TrimSpace(req.Action)
+	target := strings.TrimSpace(req.Target)
+	description, ok := confirmActions[action]
+	if !ok || target == "" {
+		return nil, domain.ErrInvalidConfirmation
+	}
+
+	now := s.now()
+	pending := &pendingConfirmation{
+		confirmation: domain.Confirmation{
+			Token:     uuid.New().String(),
+			Action:    action,
+			Target:    target,
+			Method:    domain.ConfirmationMethodTOTP,
+			ExpiresAt: now.Add(s.cfg.TTL).UTC().Format(time.RFC3339),
+		},
+		expiresAt: now.Add(s.cfg.TTL),
+	}
+
+	if s.cfg.TOTPSecret == nil {
+		if len(s.cfg.OwnerPhones) == 0 {
+			return nil, domain.ErrConfirmationDisabled
+		}
+		code, err := newConfirmationCode()
+		if err != nil {
+			return nil, err
+		}
+		pending.code = code
+		pending.confirmation.Method = domain.ConfirmationMethodWhatsApp
+		if err := s.sendCode(ctx, code, description, target); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	s.cleanupLocked(now)
+	s.pending[pending.confirmation.Token] = pending
+	s.mu.Unlock()
+
+	confirmation := pending.confirmation
+	return &confirmation, nil
+}
+
+// Confirm checks code against the confirmation token issued for action on
+// target. A confirmation is used up by the first correct code, or by too many
+// wrong ones.
+func (s *confirmationService) Confirm(ctx context.Context, token, code, action, target string) error {
+	if token == "" || code == "" {
+		return domain.ErrConfirmationRequired
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	pending, ok := s.pending[token]
+	if !ok || now.After(pending.expiresAt) ||
+		pending.confirmation.Action != action || pending.confirmation.Target != target {
+		return domain.ErrConfirmationFailed
+	}
+
+	if !s.codeMatches(pending, strings.TrimSpace(code), now) {
+		pending.attempts++
+		if pending.attempts >= maxConfirmationAttempts {
+			delete(s.pending, token)
+		}
+		return domain.ErrConfirmationFailed
+	}
+	delete(s.pending, token)
+	return nil
+}
+
+// codeMatches reports whether code confirms pending. A TOTP code is only
+// accepted for a step later than the last one accepted, so it cannot be used
+// again for another token while it is still valid. Called with s.mu held.
+func (s *confirmationService) codeMatches(pending *pendingConfirmation, code string, now time.Time) bool {
+	if pending.code != "" {
+		return subtle.ConstantTimeCompare([]byte(code), []byte(pending.code)) == 1
+	}
+	// Accept the neighbouring steps too, for clocks that drift a little
+	for _, skew := range []time.Duration{0, -totpStep, totpStep} {
+		at := now.Add(skew)
+		if subtle.ConstantTimeCompare([]byte(code), []byte(totpCode(s.cfg.TOTPSecret, at))) == 1 {
+			step := totpCounter(at)
+			if step <= s.lastTOTPStep {
+				return false
+			}
+			s.lastTOTPStep = step
+			return true
+		}
+	}
+	return false
+}
+
+// sendCode sends code to the owner phones, succeeding if any of them got it
+func (s *confirmationService) sendCode(ctx context.Context, code, description, target string) error {
+	message := fmt.Sprintf("🔐 *Kode Konfirmasi*\n\nKode *%s* untuk %s %s. Berlaku %d menit.\n\nJangan bagikan kode ini. Abaikan pesan ini jika Anda tidak memintanya.",
+		code, description, target, max(1, int(math.Ceil(s.cfg.TTL.Minutes()))))
+
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	var lastErr error
+	sent := false
+	for _, phone := range s.cfg.OwnerPhones {
+		if _, err := s.whatsappRepo.SendMessage(sendCtx, phone+"@s.whatsapp.net", message); err != nil {
+			log.Printf("Failed to send confirmation code to %s: %v", phone, err)
+			lastErr = err
+			continue
+		}
+		sent = true
+	}
+	if !sent {
+		return fmt.Errorf("failed to send confirmation code: %w", lastErr)
+	}
+	return nil
+}
+
+func (s *confirmationService) cleanupLocked(now time.Time) {
+	for token, pending := range s.pending {
+		if now.After(pending.expiresAt) {
+			delete(s.pending, token)
+		}
+	}
+}
+
+// newConfirmationCode returns a random 6-digit code
+func newConfirmationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate confirmation code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// totpCode is the 6-digit RFC 6238 code of secret at t, as authenticator apps
+// show it
+func totpCode(secret []byte, t time.Time) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(totpCounter(t)))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+// totpCounter is the RFC 6238 time step t falls in
+func totpCounter(t time.Time) int64 {
+	return t.Unix() / int64(totpStep/time.Second)
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func newTestConfirmationService(repo domain.WhatsAppRepository, cfg config.ConfirmationConfig, now *time.Time) *confirmationService {
+	s := NewConfirmationService(repo, cfg).(*confirmationService)
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestConfirmationService_WhatsAppCode(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	repo := &mocks.MockWhatsAppRepository{}
+	var sent string
+	repo.On("SendMessage", mock.Anything, "6281111111111@s.whatsapp.net", mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.String(2) }).
+		Return(&domain.Message{}, nil)
+	s := newTestConfirmationService(repo, config.ConfirmationConfig{OwnerPhones: []string{"6281111111111"}, TTL: 5 * time.Minute}, &now)
+
+	confirmation, err := s.CreateConfirmation(context.Background(), &domain.CreateConfirmationRequest{
+		Action: domain.ConfirmActionDeleteSender,
+		Target: "6289999999999",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ConfirmationMethodWhatsApp, confirmation.Method)
+	assert.Equal(t, "2026-10-16T09:05:00Z", confirmation.ExpiresAt)
+	code := s.pending[confirmation.Token].code
+	assert.Contains(t, sent, code)
+	assert.Contains(t, sent, "6289999999999")
+
+	ctx := context.Background()
+	assert.Equal(t, domain.ErrConfirmationFailed,
+		s.Confirm(ctx, confirmation.Token, code, domain.ConfirmActionDeleteSender, "6287777777777"),
+		"a confirmation only covers its own target")
+	assert.NoError(t, s.Confirm(ctx, confirmation.Token, code, domain.ConfirmActionDeleteSender, "6289999999999"))
+	assert.Equal(t, domain.ErrConfirmationFailed,
+		s.Confirm(ctx, confirmation.Token, code, domain.ConfirmActionDeleteSender, "6289999999999"),
+		"a confirmation is used up")
+}
+
+func TestConfirmationService_ExpiresAndLocksOut(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	repo := &mocks.MockWhatsAppRepository{}
+	repo.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(&domain.Message{}, nil)
+	s := newTestConfirmationService(repo, config.ConfirmationConfig{OwnerPhones: []string{"6281111111111"}, TTL: 5 * time.Minute}, &now)
+	req := &domain.CreateConfirmationRequest{Action: domain.ConfirmActionDeleteSender, Target: "6289999999999"}
+	ctx := context.Background()
+
+	expiring, err := s.CreateConfirmation(ctx, req)
+	require.NoError(t, err)
+	locked, err := s.CreateConfirmation(ctx, req)
+	require.NoError(t, err)
+	expiringCode, lockedCode := s.pending[expiring.Token].code, s.pending[locked.Token].code
+
+	for i := 0; i < maxConfirmationAttempts; i++ {
+		s.Confirm(ctx, locked.Token, "wrong", domain.ConfirmActionDeleteSender, "6289999999999")
+	}
+	assert.Equal(t, domain.ErrConfirmationFailed,
+		s.Confirm(ctx, locked.Token, lockedCode, domain.ConfirmActionDeleteSender, "6289999999999"),
+		"too many wrong codes use the confirmation up")
+
+	now = now.Add(6 * time.Minute)
+	assert.Equal(t, domain.ErrConfirmationFailed,
+		s.Confirm(ctx, expiring.Token, expiringCode, domain.ConfirmActionDeleteSender, "6289999999999"))
+}
+
+func TestConfirmationService_TOTP(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	secret := []byte("12345678901234567890")
+	s := newTestConfirmationService(&mocks.MockWhatsAppRepository{}, config.ConfirmationConfig{TOTPSecret: secret, TTL: 5 * time.Minute}, &now)
+	ctx := context.Background()
+
+	confirmation, err := s.CreateConfirmation(ctx, &domain.CreateConfirmationRequest{Action: domain.ConfirmActionDeleteSender, Target: "6289999999999"})
+	require.NoError(t, err)
+	assert.Equal(t, domain.ConfirmationMethodTOTP, confirmation.Method)
+
+	assert.Equal(t, domain.ErrConfirmationFailed,
+		s.Confirm(ctx, confirmation.Token, totpCode(secret, now.Add(-2*totpStep)), domain.ConfirmActionDeleteSender, "6289999999999"))
+	assert.NoError(t, s.Confirm(ctx, confirmation.Token, totpCode(secret, now.Add(-totpStep)), domain.ConfirmActionDeleteSender, "6289999999999"),
+		"the previous step is accepted for clock drift")
+}
+
+func TestConfirmationService_TOTPReuse(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	secret := []byte("12345678901234567890")
+	s := newTestConfirmationService(&mocks.MockWhatsAppRepository{}, config.ConfirmationConfig{TOTPSecret: secret, TTL: 5 * time.Minute}, &now)
+	ctx := context.Background()
+	create := func() string {
+		confirmation, err := s.CreateConfirmation(ctx, &domain.CreateConfirmationRequest{Action: domain.ConfirmActionDeleteSender, Target: "6289999999999"})
+		require.NoError(t, err)
+		return confirmation.Token
+	}
+	first, second, third := create(), create(), create()
+
+	code := totpCode(secret, now)
+	require.NoError(t, s.Confirm(ctx, first, code, domain.ConfirmActionDeleteSender, "6289999999999"))
+	assert.Equal(t, domain.ErrConfirmationFailed,
+		s.Confirm(ctx, second, code, domain.ConfirmActionDeleteSender, "6289999999999"),
+		"an accepted code cannot confirm another token")
+	assert.Equal(t, domain.ErrConfirmationFailed,
+		s.Confirm(ctx, second, totpCode(secret, now.Add(-totpStep)), domain.ConfirmActionDeleteSender, "6289999999999"),
+		"nor can a code from an earlier step")
+
+	now = now.Add(totpStep)
+	assert.NoError(t, s.Confirm(ctx, third, totpCode(secret, now), domain.ConfirmActionDeleteSender, "6289999999999"),
+		"the next step's code is accepted")
+}
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	assert.Equal(t, "287082", totpCode(secret, time.Unix(59, 0)))
+	assert.Equal(t, "081804", totpCode(secret, time.Unix(1111111109, 0)))
+	assert.Equal(t, "005924", totpCode(secret, time.Unix(1234567890, 0)))
+}
+
+func TestConfirmationService_CreateConfirmation_Errors(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	s := newTestConfirmationService(&mocks.MockWhatsAppRepository{}, config.ConfirmationConfig{TTL: time.Minute}, &now)
+	_, err := s.CreateConfirmation(ctx, &domain.CreateConfirmationRequest{Action: domain.ConfirmActionDeleteSender, Target: "6289999999999"})
+	assert.Equal(t, domain.ErrConfirmationDisabled, err)
+	_, err = s.CreateConfirmation(ctx, &domain.CreateConfirmationRequest{Action: "drop_everything", Target: "all"})
+	assert.Equal(t, domain.ErrInvalidConfirmation, err)
+
+	repo := &mocks.MockWhatsAppRepository{}
+	repo.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not connected"))
+	s = newTestConfirmationService(repo, config.ConfirmationConfig{OwnerPhones: []string{"6281111111111"}, TTL: time.Minute}, &now)
+	_, err = s.CreateConfirmation(ctx, &domain.CreateConfirmationRequest{Action: domain.ConfirmActionDeleteSender, Target: "6289999999999"})
+	assert.Error(t, err, "a code nobody received is not issued")
+	assert.Empty(t, s.pending)
+}
@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+	"log"
+
+	"github.com/wa-serv/internal/domain"
+)
+
+// Bounds on the dead letters listed at once
+const (
+	defaultDeadLetterLimit = 50
+	maxDeadLetterLimit     = 200
+)
+
+type deadLetterService struct {
+	queue domain.SendQueueRepository
+}
+
+// NewDeadLetterService creates a service over the messages the send queue gave
+// up on. Requeued messages are sent by the send queue's retry worker.
+func NewDeadLetterService(queue domain.SendQueueRepository) domain.DeadLetterService {
+	return &deadLetterService{queue: queue}
+}
+
+// ListDeadLetters returns the newest dead letters, leaving out requeued ones
+// unless asked for
+func (s *deadLetterService) ListDeadLetters(ctx context.Context, query *domain.DeadLetterQuery) ([]*domain.DeadLetter, error) {
+	limit, includeRequeued := defaultDeadLetterLimit, false
+	if query != nil {
+		if query.Limit != 0 {
+			limit = query.Limit
+		}
+		includeRequeued = query.IncludeRequeued
+	}
+	if limit < 1 || limit > maxDeadLetterLimit {
+		return nil, domain.ErrInvalidDeadLetterQuery
+	}
+	return s.queue.DeadLetters(includeRequeued, limit)
+}
+
+// RetryDeadLetter puts a dead letter back on the send queue with its attempts
+// reset. Each dead letter can be requeued once; if the retries fail again it
+// comes back as a new dead letter.
+func (s *deadLetterService) RetryDeadLetter(ctx context.Context, id int64) (*domain.DeadLetter, error) {
+	letter, err := s.queue.Requeue(id)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Dead letter %d requeued as queued message %d", letter.ID, letter.RequeueID)
+	return letter, nil
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestDeadLetterService_ListDeadLetters(t *testing.T) {
+	queue := &mocks.MockSendQueueRepository{}
+	letters := []*domain.DeadLetter{{ID: 7, QueueID: 412, Status: "failed"}}
+	queue.On("DeadLetters", false, defaultDeadLetterLimit).Return(letters, nil)
+	queue.On("DeadLetters", true, 10).Return(letters, nil)
+	s := NewDeadLetterService(queue)
+
+	got, err := s.ListDeadLetters(context.Background(), &domain.DeadLetterQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, letters, got)
+	_, err = s.ListDeadLetters(context.Background(), &domain.DeadLetterQuery{Limit: 10, IncludeRequeued: true})
+	require.NoError(t, err)
+
+	_, err = s.ListDeadLetters(context.Background(), &domain.DeadLetterQuery{Limit: maxDeadLetterLimit + 1})
+	assert.Equal(t, domain.ErrInvalidDeadLetterQuery, err)
+	queue.AssertExpectations(t)
+}
+
+func TestDeadLetterService_RetryDeadLetter(t *testing.T) {
+	queue := &mocks.MockSendQueueRepository{}
+	requeued := &domain.DeadLetter{ID: 7, QueueID: 412, RequeueID: 530, RequeuedAt: "2026-10-16T10:30:00Z"}
+	queue.On("Requeue", int64(7)).Return(requeued, nil)
+	queue.On("Requeue", int64(8)).Return(nil, domain.ErrDeadLetterRequeued)
+	s := NewDeadLetterService(queue)
+
+	got, err := s.RetryDeadLetter(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(530), got.RequeueID)
+
+	_, err = s.RetryDeadLetter(context.Background(), 8)
+	assert.Equal(t, domain.ErrDeadLetterRequeued, err)
+	queue.AssertExpectations(t)
+}
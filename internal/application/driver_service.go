@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+)
+
+type driverService struct {
+	db           *sql.DB
+	whatsappRepo domain.WhatsAppRepository
+}
+
+// NewDriverService creates a new driver management and dispatch service
+func NewDriverService(db *sql.DB, whatsappRepo domain.WhatsAppRepository) domain.DriverService {
+	return &driverService{db: db, whatsappRepo: whatsappRepo}
+}
+
+// CreateDriver registers a driver with their WhatsApp number
+func (s *driverService) CreateDriver(ctx context.Context, req *domain.CreateDriverRequest) (*domain.Driver, error) {
+	if req == nil || strings.TrimSpace(req.Name) == "" {
+		return nil, domain.ErrInvalidDriver
+	}
+	phone := cleanPhoneNumber(req.PhoneNumber)
+	if len(phone) < 10 {
+		return nil, domain.ErrInvalidPhoneNumber
+	}
+
+	driverID, err := repository.CreateDriver(s.db, strings.TrimSpace(req.Name), phone)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Driver{
+		ID:          driverID,
+		Name:        strings.TrimSpace(req.Name),
+		PhoneNumber: phone,
+		IsActive:    true,
+	}, nil
+}
+
+// ListDrivers returns all registered drivers
+func (s *driverService) ListDrivers(ctx context.Context) ([]*domain.Driver, error) {
+	drivers, err := repository.GetAllDrivers(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.Driver, 0, len(drivers))
+	for _, d := range drivers {
+		result = append(result, &domain.Driver{
+			ID:          d.DriverID,
+			Name:        d.Name,
+			PhoneNumber: d.PhoneNumber,
+			IsActive:    d.IsActive,
+		})
+	}
+	return result, nil
+}
+
+// DispatchOrder assigns a driver to an order that is ready for delivery and
+// messages the driver the order details and a location link
+func (s *driverService) DispatchOrder(ctx context.Context, orderID int, req *domain.DispatchOrderRequest) (*domain.DispatchOrderResponse, error) {
+	if orderID <= 0 || req == nil || req.DriverID <= 0 {
+		return &domain.DispatchOrderResponse{
+			Success: false,
+			Message: "order ID and driver ID are required",
+		}, domain.ErrInvalidDriver
+	}
+
+	driver, err := repository.GetDriverByID(s.db, req.DriverID)
+	if err != nil {
+		return &domain.DispatchOrderResponse{Success: false, Message: "Failed to load driver"}, err
+	}
+	if driver == nil || !driver.IsActive {
+		return &domain.DispatchOrderResponse{
+			Success: false,
+			Message: fmt.Sprintf("Active driver %d not found", req.DriverID),
+		}, domain.ErrDriverNotFound
+	}
+
+	info, err := repository.GetOrderDispatchInfo(s.db, orderID)
+	if err != nil {
+		return &domain.DispatchOrderResponse{Success: false, Message: "Failed to load order"}, err
+	}
+	if info == nil {
+		return &domain.DispatchOrderResponse{
+			Success: false,
+			Message: fmt.Sprintf("Order %d not found", orderID),
+		}, domain.ErrOrderNotFound
+	}
+
+	if err := repository.AssignDriverToOrder(s.db, orderID, driver.DriverID); err != nil {
+		return &domain.DispatchOrderResponse{Success: false, Message: "Failed to assign driver"}, err
+	}
+
+	// The assignment stands even if the notification fails, so the caller can retry the message
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	message, err := s.whatsappRepo.SendMessage(sendCtx, driver.PhoneNumber, buildDispatchMessage(info))
+	if err != nil {
+		return &domain.DispatchOrderResponse{
+			Success:  false,
+			Message:  "Driver assigned but notification failed: " + err.Error(),
+			OrderID:  orderID,
+			DriverID: driver.DriverID,
+			Status:   repository.OrderStatusOutForDelivery,
+		}, domain.ErrMessageSendFailed
+	}
+
+	return &domain.DispatchOrderResponse{
+		Success:   true,
+		Message:   "Driver assigned and notified",
+		OrderID:   orderID,
+		DriverID:  driver.DriverID,
+		Status:    repository.OrderStatusOutForDelivery,
+		MessageID: message.ID,
+	}, nil
+}
+
+// buildDispatchMessage formats the WhatsApp message sent to a driver for an order
+func buildDispatchMessage(info *repository.OrderDispatchInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🛵 *Pesanan Baru #%d*\n\n", info.OrderID)
+	fmt.Fprintf(&b, "Pelanggan: %s\n", info.MemberName)
+	fmt.Fprintf(&b, "Telepon: %s\n", info.MemberPhone)
+	if info.Address != "" {
+		fmt.Fprintf(&b, "Alamat: %s\n", info.Address)
+	}
+	fmt.Fprintf(&b, "Total: Rp%.0f\n", info.TotalPrice)
+	if info.Latitude.Valid && info.Longitude.Valid {
+		fmt.Fprintf(&b, "Lokasi: %s\n", googleMapsURL(info.Latitude.Float64, info.Longitude.Float64))
+	}
+	fmt.Fprintf(&b, "\nBalas dengan:\n")
+	fmt.Fprintf(&b, "%s#%d - pesanan sudah dijemput\n", strings.ToUpper(processor.DriverCodePickedUp), info.OrderID)
+	fmt.Fprintf(&b, "%s#%d - pesanan sudah diantar\n", strings.ToUpper(processor.DriverCodeDelivered), info.OrderID)
+	fmt.Fprintf(&b, "%s#%d - pengantaran gagal", strings.ToUpper(processor.DriverCodeFailed), info.OrderID)
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/repository"
+)
+
+func TestDriverService_CreateDriver_Validation(t *testing.T) {
+	service := NewDriverService(nil, &mocks.MockWhatsAppRepository{})
+
+	tests := []struct {
+		name    string
+		req     *domain.CreateDriverRequest
+		wantErr error
+	}{
+		{"nil request", nil, domain.ErrInvalidDriver},
+		{"missing name", &domain.CreateDriverRequest{PhoneNumber: "6281234567890"}, domain.ErrInvalidDriver},
+		{"short phone", &domain.CreateDriverRequest{Name: "Budi", PhoneNumber: "0812"}, domain.ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, err := service.CreateDriver(context.Background(), tt.req)
+			assert.Nil(t, driver)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestDriverService_DispatchOrder_Validation(t *testing.T) {
+	service := NewDriverService(nil, &mocks.MockWhatsAppRepository{})
+
+	response, err := service.DispatchOrder(context.Background(), 0, &domain.DispatchOrderRequest{DriverID: 1})
+	assert.Equal(t, domain.ErrInvalidDriver, err)
+	assert.False(t, response.Success)
+
+	response, err = service.DispatchOrder(context.Background(), 10, &domain.DispatchOrderRequest{})
+	assert.Equal(t, domain.ErrInvalidDriver, err)
+	assert.False(t, response.Success)
+}
+
+func TestBuildDispatchMessage(t *testing.T) {
+	info := &repository.OrderDispatchInfo{
+		OrderID:     42,
+		MemberName:  "Siti",
+		MemberPhone: "6281234567890",
+		Address:     "Jl. Merdeka 1",
+		TotalPrice:  25000,
+		Latitude:    sql.NullFloat64{Float64: -6.914744, Valid: true},
+		Longitude:   sql.NullFloat64{Float64: 107.60981, Valid: true},
+	}
+
+	msg := buildDispatchMessage(info)
+
+	assert.Contains(t, msg, "#42")
+	assert.Contains(t, msg, "Siti")
+	assert.Contains(t, msg, "Rp25000")
+	assert.Contains(t, msg, "query=-6.914744,107.609810")
+	assert.Contains(t, msg, "SELESAI#42")
+}
+
+func TestBuildDispatchMessage_NoLocation(t *testing.T) {
+	msg := buildDispatchMessage(&repository.OrderDispatchInfo{OrderID: 7, MemberName: "Siti"})
+
+	assert.NotContains(t, msg, "Lokasi:")
+	assert.Contains(t, msg, "JEMPUT#7")
+}
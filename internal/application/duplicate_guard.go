@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+)
+
+// duplicateGuardPruneAt is how many recent sends are remembered before the
+// expired ones are dropped
+const duplicateGuardPruneAt = 10000
+
+type duplicateGuardMessageService struct {
+	domain.MessageService
+	window time.Duration
+
+	mu     sync.Mutex
+	recent map[string]time.Time // recipient and body hash -> when it was sent
+	now    func() time.Time
+}
+
+// NewDuplicateGuardMessageService wraps messages so a text identical to one
+// sent to the same recipient less than window ago is refused with
+// domain.ErrDuplicateSend, unless the request is forced. This stops an
+// integrator's retries or a double-clicked button from messaging a customer
+// twice. A send counts from when it is accepted; one that fails without
+// sending anything may be tried again right away. A window of 0 turns the
+// guard off.
+func NewDuplicateGuardMessageService(messages domain.MessageService, window time.Duration) domain.MessageService {
+	return &duplicateGuardMessageService{
+		MessageService: messages,
+		window:         window,
+		recent:         make(map[string]time.Time),
+		now:            time.Now,
+	}
+}
+
+func (s *duplicateGuardMessageService) SendMessage(ctx context.Context, req *domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
+	if s.window <= 0 || req == nil || req.DryRun || req.Force {
+		return s.MessageService.SendMessage(ctx, req)
+	}
+
+	key := duplicateSendKey(req)
+	if !s.reserve(key) {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("An identical message was sent to %s less than %s ago; set force to send it again", req.To, s.window),
+		}, domain.ErrDuplicateSend
+	}
+
+	resp, err := s.MessageService.SendMessage(ctx, req)
+	if err != nil && err != domain.ErrMessagePartlySent {
+		s.release(key)
+	}
+	return resp, err
+}
+
+// reserve records a send under key, or returns false when one was recorded
+// within the window
+func (s *duplicateGuardMessageService) reserve(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if sentAt, ok := s.recent[key]; ok && now.Sub(sentAt) < s.window {
+		return false
+	}
+	if len(s.recent) >= duplicateGuardPruneAt {
+		for k, sentAt := range s.recent {
+			if now.Sub(sentAt) >= s.window {
+				delete(s.recent, k)
+			}
+		}
+	}
+	s.recent[key] = now
+	return true
+}
+
+func (s *duplicateGuardMessageService) release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.recent, key)
+}
+
+// duplicateSendKey identifies a message by its recipient, however the number
+// is written, and a hash of its kind and body
+func duplicateSendKey(req *domain.SendMessageRequest) string {
+	sum := sha256.Sum256([]byte(messageKind(req) + "\x00" + req.Message))
+	return normalizeBroadcastRecipient(req.To) + "|" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func newTestDuplicateGuard(messages domain.MessageService, now *time.Time) *duplicateGuardMessageService {
+	guard := NewDuplicateGuardMessageService(messages, 2*time.Minute).(*duplicateGuardMessageService)
+	guard.now = func() time.Time { return *now }
+	return guard
+}
+
+func TestDuplicateGuard_RefusesRepeatWithinWindow(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	guard := newTestDuplicateGuard(mockMessages, &now)
+	mockMessages.On("SendMessage", mock.Anything, mock.Anything).Return(&domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil)
+
+	// Act
+	_, first := guard.SendMessage(context.Background(), &domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap"})
+	now = now.Add(30 * time.Second)
+	resp, repeat := guard.SendMessage(context.Background(), &domain.SendMessageRequest{To: "0812-3456-7890", Message: "Pesanan siap"})
+	_, other := guard.SendMessage(context.Background(), &domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan sudah diantar"})
+
+	// Assert
+	assert.NoError(t, first)
+	assert.Equal(t, domain.ErrDuplicateSend, repeat)
+	assert.False(t, resp.Success)
+	assert.NoError(t, other)
+	mockMessages.AssertNumberOfCalls(t, "SendMessage", 2)
+}
+
+func TestDuplicateGuard_AllowsAfterWindowOrForced(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	guard := newTestDuplicateGuard(mockMessages, &now)
+	mockMessages.On("SendMessage", mock.Anything, mock.Anything).Return(&domain.SendMessageResponse{Success: true}, nil)
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap"}
+
+	// Act & Assert
+	_, err := guard.SendMessage(context.Background(), req)
+	assert.NoError(t, err)
+	_, err = guard.SendMessage(context.Background(), &domain.SendMessageRequest{To: req.To, Message: req.Message, Force: true})
+	assert.NoError(t, err)
+	_, err = guard.SendMessage(context.Background(), &domain.SendMessageRequest{To: req.To, Message: req.Message, DryRun: true})
+	assert.NoError(t, err)
+	now = now.Add(2 * time.Minute)
+	_, err = guard.SendMessage(context.Background(), req)
+	assert.NoError(t, err)
+	mockMessages.AssertNumberOfCalls(t, "SendMessage", 4)
+}
+
+func TestDuplicateGuard_FailedSendCanBeRetried(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	guard := newTestDuplicateGuard(mockMessages, &now)
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap"}
+	mockMessages.On("SendMessage", mock.Anything, req).Return(&domain.SendMessageResponse{Success: false}, domain.ErrRecipientSuppressed).Once()
+	mockMessages.On("SendMessage", mock.Anything, req).Return(&domain.SendMessageResponse{Success: true}, nil).Once()
+
+	// Act
+	_, failed := guard.SendMessage(context.Background(), req)
+	_, retried := guard.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.Equal(t, domain.ErrRecipientSuppressed, failed)
+	assert.NoError(t, retried)
+	mockMessages.AssertExpectations(t)
+}
+
+func TestDuplicateGuard_DisabledWithZeroWindow(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	guard := NewDuplicateGuardMessageService(mockMessages, 0)
+	mockMessages.On("SendMessage", mock.Anything, mock.Anything).Return(&domain.SendMessageResponse{Success: true}, nil)
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap"}
+
+	// Act
+	_, first := guard.SendMessage(context.Background(), req)
+	_, second := guard.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, first)
+	assert.NoError(t, second)
+	mockMessages.AssertNumberOfCalls(t, "SendMessage", 2)
+}
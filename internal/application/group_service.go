@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wa-serv/internal/domain"
+)
+
+// Longest group name and description WhatsApp accepts
+const (
+	maxGroupNameLength        = 25
+	maxGroupDescriptionLength = 2048
+)
+
+type groupService struct {
+	groups domain.GroupRepository
+}
+
+// NewGroupService creates a service for managing the sender's WhatsApp groups
+func NewGroupService(groups domain.GroupRepository) domain.GroupService {
+	return &groupService{groups: groups}
+}
+
+// ListGroups returns the groups the sender is a member of
+func (s *groupService) ListGroups(ctx context.Context, from string) ([]*domain.Group, error) {
+	return s.groups.ListGroups(ctx, strings.TrimSpace(from))
+}
+
+// CreateGroup creates a group with the given members and the sender as admin
+func (s *groupService) CreateGroup(ctx context.Context, req *domain.CreateGroupRequest) (*domain.Group, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxGroupNameLength {
+		return nil, fmt.Errorf("%w: name must be 1-%d characters", domain.ErrInvalidGroup, maxGroupNameLength)
+	}
+	participants, err := participantJIDs(req.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.groups.CreateGroup(ctx, strings.TrimSpace(req.From), name, participants)
+}
+
+// AddParticipants adds members to a group
+func (s *groupService) AddParticipants(ctx context.Context, groupJID string, req *domain.UpdateGroupParticipantsRequest) (*domain.UpdateGroupParticipantsResponse, error) {
+	return s.updateParticipants(ctx, groupJID, req, true)
+}
+
+// RemoveParticipants removes members from a group
+func (s *groupService) RemoveParticipants(ctx context.Context, groupJID string, req *domain.UpdateGroupParticipantsRequest) (*domain.UpdateGroupParticipantsResponse, error) {
+	return s.updateParticipants(ctx, groupJID, req, false)
+}
+
+// GetInviteLink returns the link to join a group, for customers whose privacy
+// settings keep them from being added. With reset the old link stops working.
+func (s *groupService) GetInviteLink(ctx context.Context, groupJID, from string, reset bool) (*domain.GroupInviteLink, error) {
+	groupJID = strings.TrimSpace(groupJID)
+	if !groupJIDPattern.MatchString(groupJID) {
+		return nil, fmt.Errorf("%w: invalid group JID", domain.ErrInvalidGroup)
+	}
+
+	link, err := s.groups.GetInviteLink(ctx, strings.TrimSpace(from), groupJID, reset)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.GroupInviteLink{GroupJID: groupJID, Link: link}, nil
+}
+
+// SetDescription replaces the description of a group
+func (s *groupService) SetDescription(ctx context.Context, groupJID string, req *domain.SetGroupDescriptionRequest) error {
+	groupJID = strings.TrimSpace(groupJID)
+	if !groupJIDPattern.MatchString(groupJID) {
+		return fmt.Errorf("%w: invalid group JID", domain.ErrInvalidGroup)
+	}
+	description := strings.TrimSpace(req.Description)
+	if utf8.RuneCountInString(description) > maxGroupDescriptionLength {
+		return fmt.Errorf("%w: description must be at most %d characters", domain.ErrInvalidGroup, maxGroupDescriptionLength)
+	}
+
+	return s.groups.SetDescription(ctx, strings.TrimSpace(req.From), groupJID, description)
+}
+
+func (s *groupService) updateParticipants(ctx context.Context, groupJID string, req *domain.UpdateGroupParticipantsRequest, add bool) (*domain.UpdateGroupParticipantsResponse, error) {
+	groupJID = strings.TrimSpace(groupJID)
+	if !groupJIDPattern.MatchString(groupJID) {
+		return nil, fmt.Errorf("%w: invalid group JID", domain.ErrInvalidGroup)
+	}
+	participants, err := participantJIDs(req.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.groups.UpdateParticipants(ctx, strings.TrimSpace(req.From), groupJID, participants, add)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.UpdateGroupParticipantsResponse{GroupJID: groupJID, Participants: changes}, nil
+}
+
+// participantJIDs validates the phone numbers of group participants and
+// returns their JIDs, dropping duplicates
+func participantJIDs(phones []string) ([]string, error) {
+	if len(phones) == 0 {
+		return nil, fmt.Errorf("%w: at least one participant is required", domain.ErrInvalidGroup)
+	}
+	seen := make(map[string]bool, len(phones))
+	jids := make([]string, 0, len(phones))
+	for _, phone := range phones {
+		cleaned := cleanPhoneNumber(strings.TrimSuffix(strings.TrimSpace(phone), "@s.whatsapp.net"))
+		if len(cleaned) < 10 {
+			return nil, fmt.Errorf("%w: invalid participant phone number %q", domain.ErrInvalidGroup, phone)
+		}
+		if seen[cleaned] {
+			continue
+		}
+		seen[cleaned] = true
+		jids = append(jids, cleaned+"@s.whatsapp.net")
+	}
+	return jids, nil
+}
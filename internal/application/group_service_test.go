@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestGroupService_CreateGroup(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockGroupRepository{}
+	service := NewGroupService(mockRepo)
+	created := &domain.Group{JID: "120363025246125486@g.us", Name: "VIP Customers", ParticipantCount: 2}
+
+	mockRepo.On("CreateGroup", mock.Anything, "", "VIP Customers",
+		[]string{"6281234567890@s.whatsapp.net", "6289876543210@s.whatsapp.net"}).Return(created, nil)
+
+	// Act
+	group, err := service.CreateGroup(context.Background(), &domain.CreateGroupRequest{
+		Name:         " VIP Customers ",
+		Participants: []string{"+62 812-3456-7890", "6289876543210", "6281234567890@s.whatsapp.net"},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, created, group)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGroupService_CreateGroup_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.CreateGroupRequest
+	}{
+		{"blank name", &domain.CreateGroupRequest{Name: " ", Participants: []string{"6281234567890"}}},
+		{"name too long", &domain.CreateGroupRequest{Name: "Pelanggan VIP Ruang Laundry Dago", Participants: []string{"6281234567890"}}},
+		{"no participants", &domain.CreateGroupRequest{Name: "VIP Customers"}},
+		{"short phone number", &domain.CreateGroupRequest{Name: "VIP Customers", Participants: []string{"0812"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockGroupRepository{}
+			service := NewGroupService(mockRepo)
+
+			_, err := service.CreateGroup(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, domain.ErrInvalidGroup)
+			mockRepo.AssertNotCalled(t, "CreateGroup", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGroupService_AddParticipants(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockGroupRepository{}
+	service := NewGroupService(mockRepo)
+	changes := []*domain.GroupParticipantChange{
+		{PhoneNumber: "6281234567890", Success: true},
+		{PhoneNumber: "6289876543210", Success: false, Error: "already a participant of the group"},
+	}
+
+	mockRepo.On("UpdateParticipants", mock.Anything, "sender-1", "120363025246125486@g.us",
+		[]string{"6281234567890@s.whatsapp.net", "6289876543210@s.whatsapp.net"}, true).Return(changes, nil)
+
+	// Act
+	resp, err := service.AddParticipants(context.Background(), "120363025246125486@g.us", &domain.UpdateGroupParticipantsRequest{
+		Participants: []string{"6281234567890", "6289876543210"},
+		From:         "sender-1",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "120363025246125486@g.us", resp.GroupJID)
+	assert.Equal(t, changes, resp.Participants)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGroupService_RemoveParticipants(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockGroupRepository{}
+	service := NewGroupService(mockRepo)
+
+	mockRepo.On("UpdateParticipants", mock.Anything, "", "6281234567890-1612345678@g.us",
+		[]string{"6289876543210@s.whatsapp.net"}, false).Return(nil, domain.ErrNotGroupAdmin)
+
+	// Act
+	_, err := service.RemoveParticipants(context.Background(), "6281234567890-1612345678@g.us", &domain.UpdateGroupParticipantsRequest{
+		Participants: []string{"6289876543210"},
+	})
+
+	// Assert
+	assert.Equal(t, domain.ErrNotGroupAdmin, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGroupService_UpdateParticipants_InvalidGroupJID(t *testing.T) {
+	mockRepo := &mocks.MockGroupRepository{}
+	service := NewGroupService(mockRepo)
+
+	for _, jid := range []string{"", "6281234567890@s.whatsapp.net", "abc@g.us"} {
+		_, err := service.AddParticipants(context.Background(), jid, &domain.UpdateGroupParticipantsRequest{
+			Participants: []string{"6281234567890"},
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidGroup, jid)
+	}
+	mockRepo.AssertNotCalled(t, "UpdateParticipants", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGroupService_GetInviteLink(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockGroupRepository{}
+	service := NewGroupService(mockRepo)
+
+	mockRepo.On("GetInviteLink", mock.Anything, "sender-1", "120363025246125486@g.us", true).
+		Return("https://chat.whatsapp.com/AbCdEfGhIjK", nil)
+
+	// Act
+	link, err := service.GetInviteLink(context.Background(), " 120363025246125486@g.us", " sender-1", true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &domain.GroupInviteLink{GroupJID: "120363025246125486@g.us", Link: "https://chat.whatsapp.com/AbCdEfGhIjK"}, link)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGroupService_SetDescription(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockGroupRepository{}
+	service := NewGroupService(mockRepo)
+
+	mockRepo.On("SetDescription", mock.Anything, "", "120363025246125486@g.us", "Promo khusus member Gold").Return(nil)
+
+	// Act
+	err := service.SetDescription(context.Background(), "120363025246125486@g.us", &domain.SetGroupDescriptionRequest{
+		Description: "  Promo khusus member Gold\n",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGroupService_SetDescription_Invalid(t *testing.T) {
+	mockRepo := &mocks.MockGroupRepository{}
+	service := NewGroupService(mockRepo)
+
+	err := service.SetDescription(context.Background(), "abc@g.us", &domain.SetGroupDescriptionRequest{Description: "VIP"})
+	assert.ErrorIs(t, err, domain.ErrInvalidGroup)
+
+	err = service.SetDescription(context.Background(), "120363025246125486@g.us", &domain.SetGroupDescriptionRequest{
+		Description: strings.Repeat("a", maxGroupDescriptionLength+1),
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidGroup)
+	mockRepo.AssertNotCalled(t, "SetDescription", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,208 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/alerting"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type inventoryService struct {
+	db           *sql.DB
+	whatsappRepo domain.WhatsAppRepository
+	alertPhones  []string
+}
+
+// NewInventoryService creates a supplies inventory service that alerts
+// alertPhones, and the admins who asked for low stock alerts, when a supply
+// runs low
+func NewInventoryService(db *sql.DB, whatsappRepo domain.WhatsAppRepository, alertPhones []string) domain.InventoryService {
+	return &inventoryService{db: db, whatsappRepo: whatsappRepo, alertPhones: alertPhones}
+}
+
+// ListSupplies returns all supplies by name
+func (s *inventoryService) ListSupplies(ctx context.Context) ([]*domain.Supply, error) {
+	supplies, err := repository.GetSupplies(s.db)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Supply, 0, len(supplies))
+	for _, supply := range supplies {
+		result = append(result, toDomainSupply(supply))
+	}
+	return result, nil
+}
+
+// CreateSupply adds a supply with its opening stock
+func (s *inventoryService) CreateSupply(ctx context.Context, req *domain.CreateSupplyRequest) (*domain.Supply, error) {
+	supply := repository.Supply{
+		Name:              strings.TrimSpace(req.Name),
+		Unit:              strings.TrimSpace(req.Unit),
+		Stock:             req.Stock,
+		LowStockThreshold: req.LowStockThreshold,
+	}
+	switch {
+	case supply.Name == "" || len(supply.Name) > 100:
+		return nil, fmt.Errorf("%w: name must be 1-100 characters", domain.ErrInvalidSupply)
+	case supply.Unit == "" || len(supply.Unit) > 20:
+		return nil, fmt.Errorf("%w: unit must be 1-20 characters", domain.ErrInvalidSupply)
+	case !validAmount(supply.Stock) || !validAmount(supply.LowStockThreshold):
+		return nil, fmt.Errorf("%w: stock and low_stock_threshold must not be negative", domain.ErrInvalidSupply)
+	}
+
+	id, err := repository.CreateSupply(s.db, supply)
+	if err == repository.ErrSupplyExists {
+		return nil, domain.ErrSupplyExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	supply.ID = id
+	return toDomainSupply(supply), nil
+}
+
+// AdjustStock applies a manual stock change and alerts admins if the supply ran low
+func (s *inventoryService) AdjustStock(ctx context.Context, supplyID int, req *domain.AdjustStockRequest) (*domain.Supply, error) {
+	if req.Change == 0 || math.IsNaN(req.Change) || math.IsInf(req.Change, 0) {
+		return nil, fmt.Errorf("%w: change must be a non-zero amount", domain.ErrInvalidSupply)
+	}
+
+	supply, low, err := repository.AdjustSupplyStock(s.db, supplyID, req.Change, strings.TrimSpace(req.Note))
+	switch err {
+	case nil:
+	case repository.ErrSupplyNotFound:
+		return nil, domain.ErrSupplyNotFound
+	case repository.ErrStockNegative:
+		return nil, domain.ErrStockNegative
+	default:
+		return nil, err
+	}
+
+	s.alertLowStock(ctx, low)
+	return toDomainSupply(*supply), nil
+}
+
+// GetItemUsage returns the supplies a catalog item uses
+func (s *inventoryService) GetItemUsage(ctx context.Context, itemID int) (*domain.ItemSupplyUsage, error) {
+	usage, err := repository.GetItemSupplyUsage(s.db, itemID)
+	if err == repository.ErrItemNotFound {
+		return nil, domain.ErrItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomainItemSupplyUsage(itemID, usage), nil
+}
+
+// SetItemUsage replaces the supplies a catalog item uses per kilo or per piece
+func (s *inventoryService) SetItemUsage(ctx context.Context, itemID int, req *domain.SetItemSupplyUsageRequest) (*domain.ItemSupplyUsage, error) {
+	seen := make(map[int]bool, len(req.Supplies))
+	usage := make([]repository.SupplyUsage, 0, len(req.Supplies))
+	for _, u := range req.Supplies {
+		if u.SupplyID <= 0 || seen[u.SupplyID] {
+			return nil, fmt.Errorf("%w: each supply_id must be given once", domain.ErrInvalidSupply)
+		}
+		if !validAmount(u.PerKilo) || !validAmount(u.PerUnit) || u.PerKilo+u.PerUnit == 0 {
+			return nil, fmt.Errorf("%w: per_kilo or per_unit must be positive", domain.ErrInvalidSupply)
+		}
+		seen[u.SupplyID] = true
+		usage = append(usage, repository.SupplyUsage{SupplyID: u.SupplyID, PerKilo: u.PerKilo, PerUnit: u.PerUnit})
+	}
+
+	switch err := repository.SetItemSupplyUsage(s.db, itemID, usage); err {
+	case nil:
+	case repository.ErrItemNotFound:
+		return nil, domain.ErrItemNotFound
+	case repository.ErrSupplyNotFound:
+		return nil, domain.ErrSupplyNotFound
+	default:
+		return nil, err
+	}
+	return toDomainItemSupplyUsage(itemID, usage), nil
+}
+
+// ConsumeOrder takes the supplies an order uses out of stock and alerts admins
+// about the supplies that ran low
+func (s *inventoryService) ConsumeOrder(ctx context.Context, orderID int) error {
+	low, err := repository.ConsumeOrderSupplies(s.db, orderID)
+	if err != nil {
+		return err
+	}
+	s.alertLowStock(ctx, low)
+	return nil
+}
+
+// alertLowStock tells the admins which supplies ran low. A failed alert is
+// logged; the stock change stands.
+func (s *inventoryService) alertLowStock(ctx context.Context, low []repository.Supply) {
+	if len(low) == 0 {
+		return
+	}
+
+	supplies := make([]string, len(low))
+	for i, supply := range low {
+		supplies[i] = supply.Name
+	}
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	alerting.Default().Notify(alerting.Alert{
+		Type:    alerting.LowStock,
+		Subject: "Stok menipis",
+		Text:    buildLowStockAlert(low),
+		Phones:  s.alertPhones,
+		Data:    map[string]any{"supplies": supplies},
+	}, func(phone, text string) {
+		if _, err := s.whatsappRepo.SendMessage(sendCtx, phone+"@s.whatsapp.net", text); err != nil {
+			log.Printf("Failed to send low stock alert to %s: %v", phone, err)
+		}
+	})
+}
+
+// buildLowStockAlert formats the WhatsApp message telling admins supplies ran low
+func buildLowStockAlert(low []repository.Supply) string {
+	var b strings.Builder
+	b.WriteString("⚠️ *Stok Menipis*\n\n")
+	for _, supply := range low {
+		fmt.Fprintf(&b, "• %s: %s %s (batas %s %s)\n", supply.Name,
+			formatStock(supply.Stock), supply.Unit, formatStock(supply.LowStockThreshold), supply.Unit)
+	}
+	b.WriteString("\nSegera lakukan pengisian ulang.")
+	return b.String()
+}
+
+// formatStock renders a stock amount the Indonesian way, e.g. 2,5
+func formatStock(amount float64) string {
+	return strings.Replace(strconv.FormatFloat(amount, 'f', -1, 64), ".", ",", 1)
+}
+
+// validAmount reports whether amount is a usable, non-negative quantity
+func validAmount(amount float64) bool {
+	return amount >= 0 && !math.IsInf(amount, 0)
+}
+
+func toDomainSupply(s repository.Supply) *domain.Supply {
+	return &domain.Supply{
+		ID:                s.ID,
+		Name:              s.Name,
+		Unit:              s.Unit,
+		Stock:             s.Stock,
+		LowStockThreshold: s.LowStockThreshold,
+		Low:               s.Low(),
+	}
+}
+
+func toDomainItemSupplyUsage(itemID int, usage []repository.SupplyUsage) *domain.ItemSupplyUsage {
+	result := &domain.ItemSupplyUsage{ItemID: itemID, Supplies: make([]domain.SupplyUsage, 0, len(usage))}
+	for _, u := range usage {
+		result.Supplies = append(result.Supplies, domain.SupplyUsage{SupplyID: u.SupplyID, PerKilo: u.PerKilo, PerUnit: u.PerUnit})
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/repository"
+)
+
+func TestInventoryService_CreateSupply_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.CreateSupplyRequest
+	}{
+		{"blank name", &domain.CreateSupplyRequest{Name: " ", Unit: "liter"}},
+		{"blank unit", &domain.CreateSupplyRequest{Name: "Deterjen", Unit: ""}},
+		{"negative stock", &domain.CreateSupplyRequest{Name: "Deterjen", Unit: "liter", Stock: -1}},
+		{"negative threshold", &domain.CreateSupplyRequest{Name: "Deterjen", Unit: "liter", LowStockThreshold: -5}},
+	}
+
+	service := NewInventoryService(nil, &mocks.MockWhatsAppRepository{}, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateSupply(context.Background(), tt.req)
+			assert.ErrorIs(t, err, domain.ErrInvalidSupply)
+		})
+	}
+}
+
+func TestInventoryService_AdjustStock_ZeroChange(t *testing.T) {
+	service := NewInventoryService(nil, &mocks.MockWhatsAppRepository{}, nil)
+
+	for _, change := range []float64{0, math.NaN(), math.Inf(1)} {
+		_, err := service.AdjustStock(context.Background(), 1, &domain.AdjustStockRequest{Change: change})
+		assert.ErrorIs(t, err, domain.ErrInvalidSupply)
+	}
+}
+
+func TestInventoryService_SetItemUsage_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		supplies []domain.SupplyUsage
+	}{
+		{"missing supply", []domain.SupplyUsage{{PerKilo: 0.05}}},
+		{"duplicate supply", []domain.SupplyUsage{{SupplyID: 1, PerKilo: 0.05}, {SupplyID: 1, PerUnit: 1}}},
+		{"no amount", []domain.SupplyUsage{{SupplyID: 1}}},
+		{"negative amount", []domain.SupplyUsage{{SupplyID: 1, PerKilo: -0.05}}},
+	}
+
+	service := NewInventoryService(nil, &mocks.MockWhatsAppRepository{}, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SetItemUsage(context.Background(), 1, &domain.SetItemSupplyUsageRequest{Supplies: tt.supplies})
+			assert.ErrorIs(t, err, domain.ErrInvalidSupply)
+		})
+	}
+}
+
+func TestInventoryService_AlertLowStock(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewInventoryService(nil, mockRepo, []string{"6281234567890", "6289876543210"}).(*inventoryService)
+	low := []repository.Supply{
+		{ID: 1, Name: "Deterjen Cair", Unit: "liter", Stock: 2.5, LowStockThreshold: 5, LowAlerted: true},
+	}
+	want := "⚠️ *Stok Menipis*\n\n• Deterjen Cair: 2,5 liter (batas 5 liter)\n\nSegera lakukan pengisian ulang."
+
+	mockRepo.On("SendMessage", mock.Anything, "6281234567890@s.whatsapp.net", want).Return(&domain.Message{ID: "a"}, nil)
+	mockRepo.On("SendMessage", mock.Anything, "6289876543210@s.whatsapp.net", want).Return(nil, domain.ErrWhatsAppNotConnected)
+
+	// Act
+	service.alertLowStock(context.Background(), low)
+
+	// Assert
+	mockRepo.AssertExpectations(t)
+}
+
+func TestInventoryService_AlertLowStock_NothingLow(t *testing.T) {
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewInventoryService(nil, mockRepo, []string{"6281234567890"}).(*inventoryService)
+
+	service.alertLowStock(context.Background(), nil)
+
+	mockRepo.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+}
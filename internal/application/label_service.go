@@ -0,0 +1,305 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+)
+
+const (
+	// maxLabelNameLength is the longest label name accepted
+	maxLabelNameLength = 100
+	// labelColors is the size of WhatsApp's label palette
+	labelColors = 20
+)
+
+type labelService struct {
+	labels   domain.LabelRepository
+	targets  domain.AutoLabelRepository
+	segments domain.SegmentRepository
+	cfg      config.LabelConfig
+}
+
+// NewLabelService creates a service for the WhatsApp Business labels of the
+// senders. The automatic labels go on the prospects targets lists, the members
+// of segments in the VIP tiers and the members with laundry ready for pickup.
+func NewLabelService(labels domain.LabelRepository, targets domain.AutoLabelRepository, segments domain.SegmentRepository, cfg config.LabelConfig) domain.LabelService {
+	return &labelService{labels: labels, targets: targets, segments: segments, cfg: cfg}
+}
+
+// ListLabels returns the sender's labels
+func (s *labelService) ListLabels(ctx context.Context, from string) ([]*domain.Label, error) {
+	_, labels, err := s.labels.ListLabels(ctx, strings.TrimSpace(from))
+	return labels, err
+}
+
+// SyncLabels fetches the sender's labels from WhatsApp again and returns them
+func (s *labelService) SyncLabels(ctx context.Context, from string) ([]*domain.Label, error) {
+	from = strings.TrimSpace(from)
+	if err := s.labels.SyncLabels(ctx, from); err != nil {
+		return nil, err
+	}
+	return s.ListLabels(ctx, from)
+}
+
+// CreateLabel creates a label. Without a color it takes the next one of the
+// palette, as labels made in the WhatsApp Business app do.
+func (s *labelService) CreateLabel(ctx context.Context, req *domain.SaveLabelRequest) (*domain.Label, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidLabel)
+	}
+	name, err := labelName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	from := strings.TrimSpace(req.From)
+	_, labels, err := s.labels.ListLabels(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	if findLabelByName(labels, name) != nil {
+		return nil, domain.ErrLabelExists
+	}
+
+	color := len(labels) % labelColors
+	if req.Color != nil {
+		if color, err = labelColor(*req.Color); err != nil {
+			return nil, err
+		}
+	}
+	return s.labels.SaveLabel(ctx, from, "", name, color)
+}
+
+// UpdateLabel renames a label and, when a color is given, recolors it
+func (s *labelService) UpdateLabel(ctx context.Context, id string, req *domain.SaveLabelRequest) (*domain.Label, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidLabel)
+	}
+	name, err := labelName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	id = strings.TrimSpace(id)
+	from := strings.TrimSpace(req.From)
+	_, labels, err := s.labels.ListLabels(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(labels, func(l *domain.Label) bool { return l.ID == id })
+	if i < 0 {
+		return nil, domain.ErrLabelNotFound
+	}
+	if other := findLabelByName(labels, name); other != nil && other.ID != id {
+		return nil, domain.ErrLabelExists
+	}
+
+	color := labels[i].Color
+	if req.Color != nil {
+		if color, err = labelColor(*req.Color); err != nil {
+			return nil, err
+		}
+	}
+	return s.labels.SaveLabel(ctx, from, id, name, color)
+}
+
+// DeleteLabel deletes a label, which takes it off every chat
+func (s *labelService) DeleteLabel(ctx context.Context, from, id string) error {
+	return s.labels.DeleteLabel(ctx, strings.TrimSpace(from), strings.TrimSpace(id))
+}
+
+// ListLabelChats returns the JIDs of the chats a label is on
+func (s *labelService) ListLabelChats(ctx context.Context, from, id string) ([]string, error) {
+	return s.labels.ListLabelChats(ctx, strings.TrimSpace(from), strings.TrimSpace(id))
+}
+
+// LabelChat puts a label on a chat
+func (s *labelService) LabelChat(ctx context.Context, id string, req *domain.LabelChatRequest) error {
+	return s.setLabelChat(ctx, id, req, true)
+}
+
+// UnlabelChat takes a label off a chat
+func (s *labelService) UnlabelChat(ctx context.Context, id string, req *domain.LabelChatRequest) error {
+	return s.setLabelChat(ctx, id, req, false)
+}
+
+func (s *labelService) setLabelChat(ctx context.Context, id string, req *domain.LabelChatRequest, labeled bool) error {
+	if req == nil {
+		return fmt.Errorf("%w: chat is required", domain.ErrInvalidLabel)
+	}
+	chat, err := labelChatJID(req.Chat)
+	if err != nil {
+		return err
+	}
+	return s.labels.SetLabelChat(ctx, strings.TrimSpace(req.From), strings.TrimSpace(id), chat, labeled)
+}
+
+// autoLabel is an automatic label and the phone numbers it belongs on
+type autoLabel struct {
+	name   string
+	phones func() ([]string, error)
+}
+
+// AutoLabel puts each automatic label on the chats of the contacts it belongs
+// on and takes it off the other contacts, so the labels stay managed by the
+// service. Group chats are left alone. A missing label is created first.
+func (s *labelService) AutoLabel(ctx context.Context, from string) (*domain.AutoLabelResult, error) {
+	from = strings.TrimSpace(from)
+	if from == "" {
+		from = s.cfg.AutoSender
+	}
+	senderID, labels, err := s.labels.ListLabels(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []autoLabel{
+		{name: s.cfg.NewLead, phones: s.targets.ListLeadPhones},
+		{name: s.cfg.VIP, phones: s.vipPhones},
+		{name: s.cfg.PendingPickup, phones: s.targets.ListPendingPickupPhones},
+	}
+	result := &domain.AutoLabelResult{SenderID: senderID, Labels: []*domain.AutoLabelOutcome{}}
+	for _, rule := range rules {
+		if rule.name == "" {
+			continue
+		}
+		phones, err := rule.phones()
+		if err != nil {
+			return nil, err
+		}
+
+		label := findLabelByName(labels, rule.name)
+		if label == nil {
+			if label, err = s.labels.SaveLabel(ctx, from, "", rule.name, len(labels)%labelColors); err != nil {
+				return nil, err
+			}
+			labels = append(labels, label)
+		}
+
+		outcome, err := s.applyLabel(ctx, from, label, phones)
+		if err != nil {
+			return nil, err
+		}
+		result.Labels = append(result.Labels, outcome)
+	}
+	return result, nil
+}
+
+// applyLabel puts label on the chats of phones and takes it off the chats of
+// other contacts. Chats WhatsApp refuses are logged and counted; losing the
+// connection stops the run.
+func (s *labelService) applyLabel(ctx context.Context, from string, label *domain.Label, phones []string) (*domain.AutoLabelOutcome, error) {
+	current, err := s.labels.ListLabelChats(ctx, from, label.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var want []string
+	wanted := make(map[string]bool, len(phones))
+	for _, phone := range phones {
+		phone = cleanPhoneNumber(phone)
+		if len(phone) < 10 {
+			continue
+		}
+		jid := phone + "@s.whatsapp.net"
+		if !wanted[jid] {
+			wanted[jid] = true
+			want = append(want, jid)
+		}
+	}
+
+	outcome := &domain.AutoLabelOutcome{Label: label.Name, LabelID: label.ID}
+	update := func(chat string, labeled bool, done *int) error {
+		err := s.labels.SetLabelChat(ctx, from, label.ID, chat, labeled)
+		switch {
+		case err == nil:
+			*done++
+		case err == domain.ErrWhatsAppNotConnected:
+			return err
+		default:
+			log.Printf("Auto label %q: failed to update %s: %v", label.Name, chat, err)
+			outcome.Failed++
+		}
+		return nil
+	}
+
+	for _, chat := range want {
+		if slices.Contains(current, chat) {
+			continue
+		}
+		if err := update(chat, true, &outcome.Added); err != nil {
+			return nil, err
+		}
+	}
+	for _, chat := range current {
+		if wanted[chat] || !strings.HasSuffix(chat, "@s.whatsapp.net") {
+			continue
+		}
+		if err := update(chat, false, &outcome.Removed); err != nil {
+			return nil, err
+		}
+	}
+	return outcome, nil
+}
+
+// vipPhones returns the members whose tier earns the VIP label
+func (s *labelService) vipPhones() ([]string, error) {
+	members, err := s.segments.ListSegmentMembers(0)
+	if err != nil {
+		return nil, err
+	}
+	var phones []string
+	for _, m := range members {
+		tier := processor.TierForPoints(m.AccumulatedPoints)
+		if slices.ContainsFunc(s.cfg.VIPTiers, func(t string) bool { return strings.EqualFold(t, tier) }) {
+			phones = append(phones, m.PhoneNumber)
+		}
+	}
+	return phones, nil
+}
+
+func findLabelByName(labels []*domain.Label, name string) *domain.Label {
+	for _, l := range labels {
+		if strings.EqualFold(strings.TrimSpace(l.Name), name) {
+			return l
+		}
+	}
+	return nil
+}
+
+func labelName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxLabelNameLength {
+		return "", fmt.Errorf("%w: name must be 1-%d characters", domain.ErrInvalidLabel, maxLabelNameLength)
+	}
+	return name, nil
+}
+
+func labelColor(color int) (int, error) {
+	if color < 0 || color >= labelColors {
+		return 0, fmt.Errorf("%w: color must be 0-%d", domain.ErrInvalidLabel, labelColors-1)
+	}
+	return color, nil
+}
+
+// labelChatJID is the JID of a chat given as a phone number or group JID
+func labelChatJID(chat string) (string, error) {
+	chat = strings.TrimSpace(chat)
+	if strings.HasSuffix(chat, "@g.us") {
+		if !groupJIDPattern.MatchString(chat) {
+			return "", fmt.Errorf("%w: invalid group JID", domain.ErrInvalidLabel)
+		}
+		return chat, nil
+	}
+	phone := cleanPhoneNumber(strings.TrimSuffix(chat, "@s.whatsapp.net"))
+	if len(phone) < 10 {
+		return "", fmt.Errorf("%w: chat must be a phone number or group JID", domain.ErrInvalidLabel)
+	}
+	return phone + "@s.whatsapp.net", nil
+}
@@ -0,0 +1,152 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestLabelService_CreateLabel(t *testing.T) {
+	// Arrange
+	mockLabels := &mocks.MockLabelRepository{}
+	service := NewLabelService(mockLabels, nil, nil, config.LabelConfig{})
+
+	existing := []*domain.Label{{ID: "1", Name: "New customer", Color: 0}, {ID: "2", Name: "VIP", Color: 1}}
+	mockLabels.On("ListLabels", mock.Anything, "").Return("6281111111111", existing, nil)
+	mockLabels.On("SaveLabel", mock.Anything, "", "", "Komplain", 2).Return(&domain.Label{ID: "3", Name: "Komplain", Color: 2}, nil)
+
+	// Act
+	label, err := service.CreateLabel(context.Background(), &domain.SaveLabelRequest{Name: " Komplain "})
+	_, dupErr := service.CreateLabel(context.Background(), &domain.SaveLabelRequest{Name: "vip"})
+	badColor := 20
+	_, colorErr := service.CreateLabel(context.Background(), &domain.SaveLabelRequest{Name: "Other", Color: &badColor})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "3", label.ID)
+	assert.Equal(t, domain.ErrLabelExists, dupErr)
+	assert.ErrorIs(t, colorErr, domain.ErrInvalidLabel)
+	mockLabels.AssertExpectations(t)
+}
+
+func TestLabelService_UpdateLabel(t *testing.T) {
+	// Arrange
+	mockLabels := &mocks.MockLabelRepository{}
+	service := NewLabelService(mockLabels, nil, nil, config.LabelConfig{})
+
+	existing := []*domain.Label{{ID: "1", Name: "Lead", Color: 4}, {ID: "2", Name: "VIP", Color: 1}}
+	mockLabels.On("ListLabels", mock.Anything, "").Return("6281111111111", existing, nil)
+	mockLabels.On("SaveLabel", mock.Anything, "", "1", "New lead", 4).Return(&domain.Label{ID: "1", Name: "New lead", Color: 4}, nil)
+
+	// Act
+	label, err := service.UpdateLabel(context.Background(), "1", &domain.SaveLabelRequest{Name: "New lead"})
+	_, missingErr := service.UpdateLabel(context.Background(), "9", &domain.SaveLabelRequest{Name: "Other"})
+	_, dupErr := service.UpdateLabel(context.Background(), "1", &domain.SaveLabelRequest{Name: "VIP"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "New lead", label.Name)
+	assert.Equal(t, domain.ErrLabelNotFound, missingErr)
+	assert.Equal(t, domain.ErrLabelExists, dupErr)
+	mockLabels.AssertExpectations(t)
+}
+
+func TestLabelService_LabelChat(t *testing.T) {
+	tests := []struct {
+		name    string
+		chat    string
+		wantJID string
+	}{
+		{"phone number", "+62 812-3456-7890", "6281234567890@s.whatsapp.net"},
+		{"user JID", "6281234567890@s.whatsapp.net", "6281234567890@s.whatsapp.net"},
+		{"group JID", "120363025246125486@g.us", "120363025246125486@g.us"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLabels := &mocks.MockLabelRepository{}
+			service := NewLabelService(mockLabels, nil, nil, config.LabelConfig{})
+			mockLabels.On("SetLabelChat", mock.Anything, "6281111111111", "2", tt.wantJID, true).Return(nil)
+
+			err := service.LabelChat(context.Background(), "2", &domain.LabelChatRequest{Chat: tt.chat, From: "6281111111111"})
+
+			assert.NoError(t, err)
+			mockLabels.AssertExpectations(t)
+		})
+	}
+
+	service := NewLabelService(&mocks.MockLabelRepository{}, nil, nil, config.LabelConfig{})
+	err := service.UnlabelChat(context.Background(), "2", &domain.LabelChatRequest{Chat: "12345"})
+	assert.ErrorIs(t, err, domain.ErrInvalidLabel)
+}
+
+func TestLabelService_AutoLabel(t *testing.T) {
+	// Arrange
+	mockLabels := &mocks.MockLabelRepository{}
+	mockTargets := &mocks.MockAutoLabelRepository{}
+	mockSegments := &mocks.MockSegmentRepository{}
+	service := NewLabelService(mockLabels, mockTargets, mockSegments, config.LabelConfig{
+		AutoSender: "6281111111111",
+		NewLead:    "New lead",
+		VIP:        "VIP",
+		VIPTiers:   []string{"Gold", "Platinum"},
+	})
+
+	mockLabels.On("ListLabels", mock.Anything, "6281111111111").
+		Return("6281111111111", []*domain.Label{{ID: "1", Name: "vip", Color: 0}}, nil)
+	mockTargets.On("ListLeadPhones").Return([]string{"6282222222222"}, nil)
+	mockSegments.On("ListSegmentMembers", 0).Return([]*domain.SegmentMember{
+		{PhoneNumber: "6283333333333", AccumulatedPoints: 1200}, // Platinum
+		{PhoneNumber: "6284444444444", AccumulatedPoints: 600},  // Gold
+		{PhoneNumber: "6285555555555", AccumulatedPoints: 50},   // Bronze
+	}, nil)
+
+	// The lead label is missing and is created with the next palette color
+	mockLabels.On("SaveLabel", mock.Anything, "6281111111111", "", "New lead", 1).Return(&domain.Label{ID: "2", Name: "New lead", Color: 1}, nil)
+	mockLabels.On("ListLabelChats", mock.Anything, "6281111111111", "2").Return([]string{}, nil)
+	mockLabels.On("SetLabelChat", mock.Anything, "6281111111111", "2", "6282222222222@s.whatsapp.net", true).Return(nil)
+
+	// VIP is already on one member and on a group, and still on a downgraded member
+	mockLabels.On("ListLabelChats", mock.Anything, "6281111111111", "1").
+		Return([]string{"6283333333333@s.whatsapp.net", "120363025246125486@g.us", "6285555555555@s.whatsapp.net"}, nil)
+	mockLabels.On("SetLabelChat", mock.Anything, "6281111111111", "1", "6284444444444@s.whatsapp.net", true).Return(nil)
+	mockLabels.On("SetLabelChat", mock.Anything, "6281111111111", "1", "6285555555555@s.whatsapp.net", false).Return(nil)
+
+	// Act
+	result, err := service.AutoLabel(context.Background(), "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "6281111111111", result.SenderID)
+	assert.Equal(t, []*domain.AutoLabelOutcome{
+		{Label: "New lead", LabelID: "2", Added: 1},
+		{Label: "vip", LabelID: "1", Added: 1, Removed: 1},
+	}, result.Labels)
+	mockLabels.AssertExpectations(t)
+	mockLabels.AssertNotCalled(t, "SetLabelChat", mock.Anything, mock.Anything, "1", "120363025246125486@g.us", false)
+}
+
+func TestLabelService_AutoLabel_StopsWhenDisconnected(t *testing.T) {
+	// Arrange
+	mockLabels := &mocks.MockLabelRepository{}
+	mockTargets := &mocks.MockAutoLabelRepository{}
+	service := NewLabelService(mockLabels, mockTargets, nil, config.LabelConfig{PendingPickup: "Pending pickup"})
+
+	mockLabels.On("ListLabels", mock.Anything, "").Return("6281111111111", []*domain.Label{{ID: "5", Name: "Pending pickup"}}, nil)
+	mockTargets.On("ListPendingPickupPhones").Return([]string{"6282222222222", "6283333333333"}, nil)
+	mockLabels.On("ListLabelChats", mock.Anything, "", "5").Return([]string{}, nil)
+	mockLabels.On("SetLabelChat", mock.Anything, "", "5", "6282222222222@s.whatsapp.net", true).Return(domain.ErrWhatsAppNotConnected)
+
+	// Act
+	result, err := service.AutoLabel(context.Background(), "")
+
+	// Assert
+	assert.Nil(t, result)
+	assert.Equal(t, domain.ErrWhatsAppNotConnected, err)
+	mockLabels.AssertNumberOfCalls(t, "SetLabelChat", 1)
+}
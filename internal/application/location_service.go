@@ -0,0 +1,51 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type locationService struct {
+	db *sql.DB
+}
+
+// NewLocationService creates a new member location service
+func NewLocationService(db *sql.DB) domain.LocationService {
+	return &locationService{db: db}
+}
+
+// GetMemberLocation returns the last location pin a member shared over WhatsApp
+func (s *locationService) GetMemberLocation(ctx context.Context, phoneNumber string) (*domain.MemberLocation, error) {
+	phone := cleanPhoneNumber(phoneNumber)
+	if len(phone) < 10 {
+		return nil, domain.ErrInvalidPhoneNumber
+	}
+
+	loc, err := repository.GetMemberLocationByPhoneNumber(s.db, phone)
+	if err != nil {
+		return nil, err
+	}
+	if loc == nil {
+		return nil, domain.ErrLocationNotFound
+	}
+
+	return &domain.MemberLocation{
+		PhoneNumber: loc.PhoneNumber,
+		Latitude:    loc.Latitude,
+		Longitude:   loc.Longitude,
+		Label:       loc.Label,
+		Address:     loc.Address,
+		MapsURL:     googleMapsURL(loc.Latitude, loc.Longitude),
+		UpdatedAt:   loc.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// googleMapsURL builds a Google Maps search link for the given coordinates
+func googleMapsURL(latitude, longitude float64) string {
+	return fmt.Sprintf("https://www.google.com/maps/search/?api=1&query=%.6f,%.6f", latitude, longitude)
+}
@@ -0,0 +1,25 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+)
+
+func TestLocationService_GetMemberLocation_InvalidPhone(t *testing.T) {
+	// Short numbers are rejected before touching the database
+	service := NewLocationService(nil)
+
+	loc, err := service.GetMemberLocation(context.Background(), "12-34")
+
+	assert.Nil(t, loc)
+	assert.Equal(t, domain.ErrInvalidPhoneNumber, err)
+}
+
+func TestGoogleMapsURL(t *testing.T) {
+	assert.Equal(t,
+		"https://www.google.com/maps/search/?api=1&query=-6.914744,107.609810",
+		googleMapsURL(-6.914744, 107.60981))
+}
@@ -0,0 +1,37 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type memberService struct {
+	db *sql.DB
+}
+
+// NewMemberService creates a new member service
+func NewMemberService(db *sql.DB) domain.MemberService {
+	return &memberService{db: db}
+}
+
+// EraseMember removes the personal data of the member registered with
+// phoneNumber. Their points, orders and vouchers are kept without their name,
+// number or address; the number can register again as a new member.
+func (s *memberService) EraseMember(ctx context.Context, phoneNumber string) error {
+	phone := cleanPhoneNumber(phoneNumber)
+	if len(phone) < 10 {
+		return domain.ErrInvalidPhoneNumber
+	}
+
+	erased, err := repository.EraseMember(s.db, phone)
+	if err != nil {
+		return err
+	}
+	if !erased {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+)
+
+func TestMemberService_EraseMember_InvalidPhone(t *testing.T) {
+	// Short numbers are rejected before touching the database
+	service := NewMemberService(nil)
+
+	err := service.EraseMember(context.Background(), "12-34")
+
+	assert.Equal(t, domain.ErrInvalidPhoneNumber, err)
+}
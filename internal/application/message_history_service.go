@@ -0,0 +1,496 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+	"github.com/wa-serv/requestid"
+)
+
+// Page sizes of GET /api/messages
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 500
+)
+
+// Bounds of the message reports
+const (
+	defaultReportDays  = 30
+	maxReportDays      = 366
+	defaultReportLimit = 10
+	maxReportLimit     = 100
+)
+
+// revokeWindow is how long after sending WhatsApp still lets a message be
+// deleted for everyone
+const revokeWindow = 60 * time.Hour
+
+// editWindow is how long after sending WhatsApp still lets a message be edited
+const editWindow = 15 * time.Minute
+
+type recordingMessageService struct {
+	domain.MessageService // status and sender lookups pass straight through
+
+	history domain.MessageHistoryRepository
+}
+
+// NewRecordingMessageService wraps messages so every send that reaches WhatsApp
+// is recorded in history, delivered or not. Requests rejected as invalid and
+// dry runs send nothing and are not recorded.
+func NewRecordingMessageService(messages domain.MessageService, history domain.MessageHistoryRepository) domain.MessageService {
+	return &recordingMessageService{MessageService: messages, history: history}
+}
+
+func (s *recordingMessageService) SendMessage(ctx context.Context, req *domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
+	resp, err := s.MessageService.SendMessage(ctx, req)
+	if !req.DryRun {
+		s.record(ctx, req.To, req.From, messageKind(req), req.Message, resp, err)
+	}
+	return resp, err
+}
+
+func (s *recordingMessageService) SendImage(ctx context.Context, req *domain.SendImageRequest) (*domain.SendMessageResponse, error) {
+	resp, err := s.MessageService.SendImage(ctx, req)
+	s.record(ctx, req.To, req.From, "image", req.Caption, resp, err)
+	return resp, err
+}
+
+func (s *recordingMessageService) SendDocument(ctx context.Context, req *domain.SendDocumentRequest) (*domain.SendMessageResponse, error) {
+	resp, err := s.MessageService.SendDocument(ctx, req)
+	content := req.FileName
+	if req.Caption != "" {
+		content += ": " + req.Caption
+	}
+	s.record(ctx, req.To, req.From, "document", content, resp, err)
+	return resp, err
+}
+
+func (s *recordingMessageService) SendAudio(ctx context.Context, req *domain.SendAudioRequest) (*domain.SendMessageResponse, error) {
+	resp, err := s.MessageService.SendAudio(ctx, req)
+	content := "audio"
+	if req.PTT {
+		content = "voice note"
+	}
+	s.record(ctx, req.To, req.From, "audio", content, resp, err)
+	return resp, err
+}
+
+func (s *recordingMessageService) SendLocation(ctx context.Context, req *domain.SendLocationRequest) (*domain.SendMessageResponse, error) {
+	resp, err := s.MessageService.SendLocation(ctx, req)
+	var content string
+	if req.Latitude != nil && req.Longitude != nil {
+		content = fmt.Sprintf("%f,%f", *req.Latitude, *req.Longitude)
+	}
+	if req.Name != "" {
+		content = strings.TrimSpace(req.Name + " " + content)
+	}
+	s.record(ctx, req.To, req.From, "location", content, resp, err)
+	return resp, err
+}
+
+func (s *recordingMessageService) SendContact(ctx context.Context, req *domain.SendContactRequest) (*domain.SendMessageResponse, error) {
+	resp, err := s.MessageService.SendContact(ctx, req)
+	names := make([]string, 0, len(req.Contacts))
+	for _, c := range req.Contacts {
+		names = append(names, c.Name)
+	}
+	s.record(ctx, req.To, req.From, "contact", strings.Join(names, ", "), resp, err)
+	return resp, err
+}
+
+func (s *recordingMessageService) SendReaction(ctx context.Context, req *domain.SendReactionRequest) (*domain.SendMessageResponse, error) {
+	resp, err := s.MessageService.SendReaction(ctx, req)
+	s.record(ctx, req.ChatJID, req.From, "reaction", req.Emoji, resp, err)
+	return resp, err
+}
+
+// RevokeMessage deletes a sent message for everyone. The chat and sender are
+// taken from the message history when not given, and a message recorded as
+// sent longer than revokeWindow ago is refused before WhatsApp is asked.
+func (s *recordingMessageService) RevokeMessage(ctx context.Context, req *domain.RevokeMessageRequest) (*domain.SendMessageResponse, error) {
+	if req == nil || strings.TrimSpace(req.MessageID) == "" {
+		return s.MessageService.RevokeMessage(ctx, req)
+	}
+
+	record, err := s.history.GetByMessageID(strings.TrimSpace(req.MessageID))
+	if err != nil {
+		// WhatsApp enforces the window itself, so the caller's chat will do
+		log.Printf("Failed to look up message %s in history: %v", req.MessageID, err)
+	}
+	if record == nil && strings.TrimSpace(req.To) == "" {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Message not found in the message history; give the chat it was sent to",
+		}, domain.ErrMessageNotFound
+	}
+
+	if record != nil {
+		revoke := *req
+		if strings.TrimSpace(revoke.To) == "" {
+			revoke.To = record.Recipient
+		}
+		if revoke.From == "" {
+			revoke.From = record.SenderID
+		}
+		req = &revoke
+
+		sentAt := record.SentAt
+		if sentAt == "" {
+			sentAt = record.CreatedAt
+		}
+		if sent, err := time.Parse(time.RFC3339, sentAt); err == nil && time.Since(sent) > revokeWindow {
+			return &domain.SendMessageResponse{
+				Success: false,
+				Message: fmt.Sprintf("Message was sent at %s; WhatsApp only deletes messages for everyone within %s", sentAt, revokeWindow),
+			}, domain.ErrRevokeWindowExpired
+		}
+	}
+
+	resp, err := s.MessageService.RevokeMessage(ctx, req)
+	if err == nil && record != nil {
+		if err := s.history.MarkRevoked(record.MessageID); err != nil {
+			log.Printf("Failed to mark message %s revoked in history: %v", record.MessageID, err)
+		}
+	}
+	return resp, err
+}
+
+// EditMessage replaces the text of a sent text message and records the edit.
+// Only messages in the history can be edited: the chat and sender are taken
+// from their record, and one sent longer than editWindow ago is refused before
+// WhatsApp is asked.
+func (s *recordingMessageService) EditMessage(ctx context.Context, req *domain.EditMessageRequest) (*domain.SendMessageResponse, error) {
+	if req == nil || strings.TrimSpace(req.MessageID) == "" || strings.TrimSpace(req.Message) == "" {
+		return s.MessageService.EditMessage(ctx, req)
+	}
+
+	record, err := s.history.GetByMessageID(strings.TrimSpace(req.MessageID))
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to look up message: %v", err),
+		}, err
+	}
+	if record == nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Message not found in the message history",
+		}, domain.ErrMessageNotFound
+	}
+	if record.Kind != "text" || record.Status != repository.MessageHistorySent {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Message is a %s message that is %s; only sent text messages can be edited", record.Kind, record.Status),
+		}, domain.ErrMessageNotEditable
+	}
+
+	sentAt := record.SentAt
+	if sentAt == "" {
+		sentAt = record.CreatedAt
+	}
+	if sent, err := time.Parse(time.RFC3339, sentAt); err == nil && time.Since(sent) > editWindow {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Message was sent at %s; WhatsApp only edits messages within %s", sentAt, editWindow),
+		}, domain.ErrEditWindowExpired
+	}
+
+	edit := *req
+	if strings.TrimSpace(edit.To) == "" {
+		edit.To = record.Recipient
+	}
+	if edit.From == "" {
+		edit.From = record.SenderID
+	}
+	resp, err := s.MessageService.EditMessage(ctx, &edit)
+	if err == nil {
+		s.recordEdit(ctx, record, req.Message)
+	}
+	return resp, err
+}
+
+// recordEdit stores the edit of record to content. A history write that fails
+// is logged; the message was still edited.
+func (s *recordingMessageService) recordEdit(ctx context.Context, record *domain.MessageRecord, content string) {
+	sum := sha256.Sum256([]byte(content))
+	edit := &domain.MessageEdit{
+		MessageID:       record.MessageID,
+		PreviousContent: record.Content,
+		Content:         content,
+		ContentHash:     hex.EncodeToString(sum[:]),
+		ContentLength:   utf8.RuneCountInString(content),
+		RequestID:       requestid.FromContext(ctx),
+	}
+	mode, err := s.history.LogMode()
+	if err != nil {
+		log.Printf("Failed to get message log mode, logging without content: %v", err)
+		mode = repository.MessageLogPrivate
+	}
+	if mode == repository.MessageLogPrivate {
+		edit.PreviousContent = ""
+		edit.Content = ""
+	}
+
+	if err := s.history.RecordEdit(edit); err != nil {
+		log.Printf("Failed to record edit of message %s in history: %v", record.MessageID, err)
+	}
+}
+
+// record stores the outcome of a send. Only sends that were attempted are
+// recorded: successes and transient failures. A history write that fails is
+// logged; it never fails the send.
+func (s *recordingMessageService) record(ctx context.Context, to, from, kind, content string, resp *domain.SendMessageResponse, err error) {
+	if err != nil && !isTransientSendError(err) {
+		return
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	record := &domain.MessageRecord{
+		Recipient:     historyRecipient(to),
+		SenderID:      from,
+		Kind:          kind,
+		Content:       content,
+		ContentHash:   hex.EncodeToString(sum[:]),
+		ContentLength: utf8.RuneCountInString(content),
+		Status:        repository.MessageHistorySent,
+		RequestID:     requestid.FromContext(ctx),
+	}
+	mode, modeErr := s.history.LogMode()
+	if modeErr != nil {
+		// Err on the side of privacy; the hash still identifies the content
+		log.Printf("Failed to get message log mode, logging without content: %v", modeErr)
+		mode = repository.MessageLogPrivate
+	}
+	if mode == repository.MessageLogPrivate {
+		record.Content = ""
+	}
+	if resp != nil {
+		record.MessageID = resp.ID
+		if resp.SenderID != "" {
+			record.SenderID = resp.SenderID
+		}
+	}
+	if err != nil {
+		record.Status = repository.MessageHistoryFailed
+		record.Error = sendErrorText(resp, err)
+	}
+
+	if err := s.history.Record(record); err != nil {
+		log.Printf("Failed to record %s message to %s in history: %v", kind, record.Recipient, err)
+	}
+}
+
+// historyRecipient is the recipient as stored in history: the group JID for a
+// group, the phone number's digits otherwise
+func historyRecipient(to string) string {
+	to = strings.TrimSpace(to)
+	if strings.HasSuffix(to, "@g.us") {
+		return to
+	}
+	return cleanPhoneNumber(strings.TrimSuffix(to, "@s.whatsapp.net"))
+}
+
+type messageHistoryService struct {
+	history domain.MessageHistoryRepository
+}
+
+// NewMessageHistoryService creates a service for querying the message history
+func NewMessageHistoryService(history domain.MessageHistoryRepository) domain.MessageHistoryService {
+	return &messageHistoryService{history: history}
+}
+
+// ListMessages returns a page of the messages matching query, newest first
+func (s *messageHistoryService) ListMessages(ctx context.Context, query *domain.MessageHistoryQuery) (*domain.MessageHistoryPage, error) {
+	filter, err := historyFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	records, total, err := s.history.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MessageHistoryPage{
+		Messages: records,
+		Total:    total,
+		Limit:    filter.Limit,
+		Offset:   filter.Offset,
+	}, nil
+}
+
+// ListEdits returns the edits of a sent message, oldest first
+func (s *messageHistoryService) ListEdits(ctx context.Context, messageID string) ([]*domain.MessageEdit, error) {
+	messageID = strings.TrimSpace(messageID)
+	record, err := s.history.GetByMessageID(messageID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, domain.ErrMessageNotFound
+	}
+	return s.history.ListEdits(messageID)
+}
+
+// historyFilter validates query and turns its days into a time range that
+// includes the whole last day
+func historyFilter(query *domain.MessageHistoryQuery) (domain.MessageHistoryFilter, error) {
+	filter := domain.MessageHistoryFilter{Limit: defaultHistoryLimit}
+	if query == nil {
+		return filter, nil
+	}
+
+	if strings.TrimSpace(query.Recipient) != "" {
+		filter.Recipient = historyRecipient(query.Recipient)
+		if filter.Recipient == "" {
+			return filter, fmt.Errorf("%w: recipient must be a phone number or group JID", domain.ErrInvalidHistoryQuery)
+		}
+	}
+	filter.SenderID = strings.TrimSpace(query.Sender)
+	filter.RequestID = strings.TrimSpace(query.RequestID)
+
+	if query.From != "" {
+		day, err := time.ParseInLocation("2006-01-02", query.From, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("%w: from must be YYYY-MM-DD", domain.ErrInvalidHistoryQuery)
+		}
+		filter.Since = day
+	}
+	if query.To != "" {
+		day, err := time.ParseInLocation("2006-01-02", query.To, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("%w: to must be YYYY-MM-DD", domain.ErrInvalidHistoryQuery)
+		}
+		filter.Until = day.AddDate(0, 0, 1)
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && !filter.Since.Before(filter.Until) {
+		return filter, fmt.Errorf("%w: from must not be after to", domain.ErrInvalidHistoryQuery)
+	}
+
+	if query.Limit < 0 || query.Limit > maxHistoryLimit {
+		return filter, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidHistoryQuery, maxHistoryLimit)
+	}
+	if query.Limit > 0 {
+		filter.Limit = query.Limit
+	}
+	if query.Offset < 0 {
+		return filter, fmt.Errorf("%w: offset must not be negative", domain.ErrInvalidHistoryQuery)
+	}
+	filter.Offset = query.Offset
+	return filter, nil
+}
+
+// GetBusiestContacts returns the contacts that exchanged the most messages over
+// the query's days, busiest first
+func (s *messageHistoryService) GetBusiestContacts(ctx context.Context, query *domain.MessageReportQuery) (*domain.BusiestContactsReport, error) {
+	since, until, err := reportRange(query)
+	if err != nil {
+		return nil, err
+	}
+	limit := defaultReportLimit
+	if query != nil && query.Limit != 0 {
+		limit = query.Limit
+	}
+	if limit < 1 || limit > maxReportLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidReportQuery, maxReportLimit)
+	}
+
+	contacts, err := s.history.BusiestContacts(since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.BusiestContactsReport{
+		From:     since.Format("2006-01-02"),
+		To:       until.AddDate(0, 0, -1).Format("2006-01-02"),
+		Contacts: contacts,
+	}, nil
+}
+
+// GetMessageVolume returns the inbound and outbound messages per day or week
+// over the query's days. Periods without messages are reported as zero so
+// trends can be charted as they are.
+func (s *messageHistoryService) GetMessageVolume(ctx context.Context, query *domain.MessageReportQuery) (*domain.MessageVolumeReport, error) {
+	since, until, err := reportRange(query)
+	if err != nil {
+		return nil, err
+	}
+	interval := domain.ReportIntervalDay
+	if query != nil && query.Interval != "" {
+		interval = query.Interval
+	}
+	if interval != domain.ReportIntervalDay && interval != domain.ReportIntervalWeek {
+		return nil, fmt.Errorf("%w: interval must be day or week", domain.ErrInvalidReportQuery)
+	}
+
+	volume, err := s.history.Volume(since, until, interval)
+	if err != nil {
+		return nil, err
+	}
+	byPeriod := make(map[string]*domain.MessageVolume, len(volume))
+	for _, v := range volume {
+		byPeriod[v.Period] = v
+	}
+
+	report := &domain.MessageVolumeReport{
+		From:     since.Format("2006-01-02"),
+		To:       until.AddDate(0, 0, -1).Format("2006-01-02"),
+		Interval: interval,
+		Volume:   []*domain.MessageVolume{},
+	}
+	step := 1
+	start := since
+	if interval == domain.ReportIntervalWeek {
+		step = 7
+		// Weeks start on Monday, so the first one may begin before since
+		start = since.AddDate(0, 0, -(int(since.Weekday())+6)%7)
+	}
+	for day := start; day.Before(until); day = day.AddDate(0, 0, step) {
+		period := day.Format("2006-01-02")
+		v, ok := byPeriod[period]
+		if !ok {
+			v = &domain.MessageVolume{Period: period}
+		}
+		report.Volume = append(report.Volume, v)
+		report.TotalInbound += v.Inbound
+		report.TotalOutbound += v.Outbound
+	}
+	return report, nil
+}
+
+// reportRange turns the query's days into a time range that includes the whole
+// last day, defaulting to the last 30 days up to today
+func reportRange(query *domain.MessageReportQuery) (time.Time, time.Time, error) {
+	now := time.Now()
+	last := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if query != nil && query.To != "" {
+		day, err := time.ParseInLocation("2006-01-02", query.To, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: to must be YYYY-MM-DD", domain.ErrInvalidReportQuery)
+		}
+		last = day
+	}
+	first := last.AddDate(0, 0, 1-defaultReportDays)
+	if query != nil && query.From != "" {
+		day, err := time.ParseInLocation("2006-01-02", query.From, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: from must be YYYY-MM-DD", domain.ErrInvalidReportQuery)
+		}
+		first = day
+	}
+
+	until := last.AddDate(0, 0, 1)
+	if !first.Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: from must not be after to", domain.ErrInvalidReportQuery)
+	}
+	if first.AddDate(0, 0, maxReportDays).Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: reports cover at most %d days", domain.ErrInvalidReportQuery, maxReportDays)
+	}
+	return first, until, nil
+}
@@ -0,0 +1,475 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/requestid"
+)
+
+func TestRecordingMessageService_SendMessage_RecordsSent(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	req := &domain.SendMessageRequest{To: "+62 812-3456-7890", Message: "Pesanan siap diambil", Category: "order"}
+	mockMessages.On("SendMessage", mock.Anything, req).
+		Return(&domain.SendMessageResponse{Success: true, ID: "msg-1", SenderID: "sender-2"}, nil)
+	mockHistory.On("LogMode").Return("full", nil)
+	mockHistory.On("Record", &domain.MessageRecord{
+		Recipient:     "6281234567890",
+		SenderID:      "sender-2",
+		Kind:          "text",
+		Content:       "Pesanan siap diambil",
+		ContentHash:   "64b766ab98454e4f3511da83e9b9f1041b62523f5c02c975aa691e7d95ea57d8",
+		ContentLength: 20,
+		Status:        "sent",
+		MessageID:     "msg-1",
+	}).Return(nil)
+
+	// Act
+	resp, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestRecordingMessageService_RecordsRequestID(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	req := &domain.SendImageRequest{To: "6281234567890", Caption: "Nota"}
+	mockMessages.On("SendImage", mock.Anything, req).Return(&domain.SendMessageResponse{Success: true, ID: "msg-3"}, nil)
+	mockHistory.On("LogMode").Return("full", nil)
+	mockHistory.On("Record", mock.MatchedBy(func(r *domain.MessageRecord) bool {
+		return r.RequestID == "req-42"
+	})).Return(nil)
+
+	// Act
+	_, err := service.SendImage(requestid.NewContext(context.Background(), "req-42"), req)
+
+	// Assert
+	require.NoError(t, err)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestRecordingMessageService_SendMessage_RecordsTransientFailure(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Halo"}
+	mockMessages.On("SendMessage", mock.Anything, req).
+		Return(&domain.SendMessageResponse{Success: false, Message: "Failed to send message: stream error"}, domain.ErrMessageSendFailed)
+	mockHistory.On("LogMode").Return("full", nil)
+	mockHistory.On("Record", mock.MatchedBy(func(r *domain.MessageRecord) bool {
+		return r.Status == "failed" && r.Error == "Failed to send message: stream error"
+	})).Return(errors.New("db down"))
+
+	// Act
+	_, err := service.SendMessage(context.Background(), req)
+
+	// Assert: a history failure never changes the send's outcome
+	assert.Equal(t, domain.ErrMessageSendFailed, err)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestRecordingMessageService_SkipsInvalidRequestsAndDryRuns(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	invalid := &domain.SendMessageRequest{To: "abc", Message: "Halo"}
+	dryRun := &domain.SendMessageRequest{To: "6281234567890", Message: "Halo", DryRun: true}
+	mockMessages.On("SendMessage", mock.Anything, invalid).
+		Return(&domain.SendMessageResponse{Success: false}, domain.ErrInvalidPhoneNumber)
+	mockMessages.On("SendMessage", mock.Anything, dryRun).
+		Return(&domain.SendMessageResponse{Success: true}, nil)
+
+	// Act
+	_, _ = service.SendMessage(context.Background(), invalid)
+	_, _ = service.SendMessage(context.Background(), dryRun)
+
+	// Assert
+	mockHistory.AssertNotCalled(t, "Record", mock.Anything)
+}
+
+func TestRecordingMessageService_SendDocument_RecordsFileName(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	req := &domain.SendDocumentRequest{To: "6281234567890", FileName: "invoice-0042.pdf", Caption: "Invoice Oktober"}
+	mockMessages.On("SendDocument", mock.Anything, req).Return(&domain.SendMessageResponse{Success: true, ID: "msg-2"}, nil)
+	mockHistory.On("LogMode").Return("full", nil)
+	mockHistory.On("Record", mock.MatchedBy(func(r *domain.MessageRecord) bool {
+		return r.Kind == "document" && r.Content == "invoice-0042.pdf: Invoice Oktober"
+	})).Return(nil)
+
+	// Act
+	_, err := service.SendDocument(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestRecordingMessageService_PrivateMode_RecordsHashOnly(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Kode promo: HEMAT50"}
+	mockMessages.On("SendMessage", mock.Anything, req).Return(&domain.SendMessageResponse{Success: true, ID: "msg-4"}, nil)
+	mockHistory.On("LogMode").Return("private", nil)
+	mockHistory.On("Record", mock.MatchedBy(func(r *domain.MessageRecord) bool {
+		return r.Content == "" && len(r.ContentHash) == 64 && r.ContentLength == 19
+	})).Return(nil)
+
+	// Act
+	_, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestRecordingMessageService_LogModeError_RecordsWithoutContent(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Halo"}
+	mockMessages.On("SendMessage", mock.Anything, req).Return(&domain.SendMessageResponse{Success: true, ID: "msg-5"}, nil)
+	mockHistory.On("LogMode").Return("", errors.New("db down"))
+	mockHistory.On("Record", mock.MatchedBy(func(r *domain.MessageRecord) bool {
+		return r.Content == "" && r.ContentHash != "" && r.Status == "sent"
+	})).Return(nil)
+
+	// Act
+	_, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestMessageHistoryService_ListMessages(t *testing.T) {
+	// Arrange
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewMessageHistoryService(mockHistory)
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	records := []*domain.MessageRecord{{ID: 9, Recipient: "6281234567890", Status: "sent"}}
+	mockHistory.On("List", domain.MessageHistoryFilter{
+		Recipient: "6281234567890",
+		SenderID:  "sender-2",
+		Since:     since,
+		Until:     since.AddDate(0, 0, 16),
+		Limit:     20,
+		Offset:    40,
+	}).Return(records, 41, nil)
+
+	// Act
+	page, err := service.ListMessages(context.Background(), &domain.MessageHistoryQuery{
+		Recipient: "+62 812 3456 7890",
+		Sender:    "sender-2",
+		From:      "2026-10-01",
+		To:        "2026-10-16",
+		Limit:     20,
+		Offset:    40,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 41, page.Total)
+	assert.Equal(t, 20, page.Limit)
+	assert.Len(t, page.Messages, 1)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestMessageHistoryService_ListMessages_InvalidQuery(t *testing.T) {
+	service := NewMessageHistoryService(&mocks.MockMessageHistoryRepository{})
+
+	tests := []struct {
+		name  string
+		query *domain.MessageHistoryQuery
+	}{
+		{"bad from", &domain.MessageHistoryQuery{From: "01-10-2026"}},
+		{"bad to", &domain.MessageHistoryQuery{To: "kemarin"}},
+		{"from after to", &domain.MessageHistoryQuery{From: "2026-10-16", To: "2026-10-01"}},
+		{"limit too large", &domain.MessageHistoryQuery{Limit: 501}},
+		{"negative offset", &domain.MessageHistoryQuery{Offset: -1}},
+		{"recipient without digits", &domain.MessageHistoryQuery{Recipient: "budi"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := service.ListMessages(context.Background(), tt.query)
+			assert.Nil(t, page)
+			assert.ErrorIs(t, err, domain.ErrInvalidHistoryQuery)
+		})
+	}
+}
+
+func TestMessageHistoryService_GetBusiestContacts(t *testing.T) {
+	// Arrange
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewMessageHistoryService(mockHistory)
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	contacts := []*domain.ContactVolume{{PhoneNumber: "6281234567890", Inbound: 12, Outbound: 30, Total: 42}}
+	mockHistory.On("BusiestContacts", since, since.AddDate(0, 0, 16), 5).Return(contacts, nil)
+
+	// Act
+	report, err := service.GetBusiestContacts(context.Background(), &domain.MessageReportQuery{
+		From:  "2026-10-01",
+		To:    "2026-10-16",
+		Limit: 5,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-01", report.From)
+	assert.Equal(t, "2026-10-16", report.To)
+	assert.Equal(t, contacts, report.Contacts)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestMessageHistoryService_GetMessageVolume_FillsEmptyDays(t *testing.T) {
+	// Arrange
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewMessageHistoryService(mockHistory)
+
+	since := time.Date(2026, 10, 14, 0, 0, 0, 0, time.Local)
+	mockHistory.On("Volume", since, since.AddDate(0, 0, 3), "day").Return([]*domain.MessageVolume{
+		{Period: "2026-10-14", Inbound: 4, Outbound: 10},
+		{Period: "2026-10-16", Inbound: 1},
+	}, nil)
+
+	// Act
+	report, err := service.GetMessageVolume(context.Background(), &domain.MessageReportQuery{From: "2026-10-14", To: "2026-10-16"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.MessageVolume{
+		{Period: "2026-10-14", Inbound: 4, Outbound: 10},
+		{Period: "2026-10-15"},
+		{Period: "2026-10-16", Inbound: 1},
+	}, report.Volume)
+	assert.Equal(t, 5, report.TotalInbound)
+	assert.Equal(t, 10, report.TotalOutbound)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestMessageHistoryService_GetMessageVolume_WeeksStartOnMonday(t *testing.T) {
+	// Arrange: Thursday 1 October to Friday 16 October 2026
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewMessageHistoryService(mockHistory)
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	mockHistory.On("Volume", since, since.AddDate(0, 0, 16), "week").Return([]*domain.MessageVolume{
+		{Period: "2026-10-05", Outbound: 7},
+	}, nil)
+
+	// Act
+	report, err := service.GetMessageVolume(context.Background(), &domain.MessageReportQuery{
+		From:     "2026-10-01",
+		To:       "2026-10-16",
+		Interval: "week",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.MessageVolume{
+		{Period: "2026-09-28"},
+		{Period: "2026-10-05", Outbound: 7},
+		{Period: "2026-10-12"},
+	}, report.Volume)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestMessageHistoryService_Reports_InvalidQuery(t *testing.T) {
+	service := NewMessageHistoryService(&mocks.MockMessageHistoryRepository{})
+
+	tests := []struct {
+		name  string
+		query *domain.MessageReportQuery
+	}{
+		{"bad from", &domain.MessageReportQuery{From: "01-10-2026"}},
+		{"from after to", &domain.MessageReportQuery{From: "2026-10-16", To: "2026-10-01"}},
+		{"range too long", &domain.MessageReportQuery{From: "2025-01-01", To: "2026-10-16"}},
+		{"limit too large", &domain.MessageReportQuery{Limit: maxReportLimit + 1}},
+		{"unknown interval", &domain.MessageReportQuery{Interval: "month"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.query.Interval != "" {
+				_, err = service.GetMessageVolume(context.Background(), tt.query)
+			} else {
+				_, err = service.GetBusiestContacts(context.Background(), tt.query)
+			}
+			assert.ErrorIs(t, err, domain.ErrInvalidReportQuery)
+		})
+	}
+}
+
+func TestRecordingMessageService_RevokeMessage_UsesHistory(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	mockHistory.On("GetByMessageID", "msg-1").Return(&domain.MessageRecord{
+		Recipient: "6281234567890",
+		SenderID:  "sender-2",
+		Status:    "sent",
+		MessageID: "msg-1",
+		CreatedAt: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		SentAt:    time.Now().Add(-time.Hour).Format(time.RFC3339),
+	}, nil)
+	mockMessages.On("RevokeMessage", mock.Anything, &domain.RevokeMessageRequest{MessageID: "msg-1", To: "6281234567890", From: "sender-2"}).
+		Return(&domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil)
+	mockHistory.On("MarkRevoked", "msg-1").Return(nil)
+
+	// Act
+	resp, err := service.RevokeMessage(context.Background(), &domain.RevokeMessageRequest{MessageID: "msg-1"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	mockMessages.AssertExpectations(t)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestRecordingMessageService_RevokeMessage_WindowExpired(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	sentAt := time.Now().Add(-72 * time.Hour).Format(time.RFC3339)
+	mockHistory.On("GetByMessageID", "msg-1").Return(&domain.MessageRecord{
+		Recipient: "6281234567890",
+		Status:    "sent",
+		MessageID: "msg-1",
+		CreatedAt: sentAt,
+		SentAt:    sentAt,
+	}, nil)
+
+	// Act
+	resp, err := service.RevokeMessage(context.Background(), &domain.RevokeMessageRequest{MessageID: "msg-1"})
+
+	// Assert
+	assert.Equal(t, domain.ErrRevokeWindowExpired, err)
+	assert.False(t, resp.Success)
+	mockMessages.AssertNotCalled(t, "RevokeMessage", mock.Anything, mock.Anything)
+	mockHistory.AssertNotCalled(t, "MarkRevoked", mock.Anything)
+}
+
+func TestRecordingMessageService_RevokeMessage_Unknown(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	mockHistory.On("GetByMessageID", mock.Anything).Return(nil, nil)
+	withChat := &domain.RevokeMessageRequest{MessageID: "msg-2", To: "6281234567890"}
+	mockMessages.On("RevokeMessage", mock.Anything, withChat).
+		Return(&domain.SendMessageResponse{Success: true, ID: "msg-2"}, nil)
+
+	// Act
+	_, errWithout := service.RevokeMessage(context.Background(), &domain.RevokeMessageRequest{MessageID: "msg-1"})
+	_, errWith := service.RevokeMessage(context.Background(), withChat)
+
+	// Assert
+	assert.Equal(t, domain.ErrMessageNotFound, errWithout)
+	assert.NoError(t, errWith)
+	mockHistory.AssertNotCalled(t, "MarkRevoked", mock.Anything)
+}
+
+func TestRecordingMessageService_EditMessage_RecordsEdit(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockHistory := &mocks.MockMessageHistoryRepository{}
+	service := NewRecordingMessageService(mockMessages, mockHistory)
+
+	mockHistory.On("GetByMessageID", "msg-1").Return(&domain.MessageRecord{
+		Recipient: "6281234567890",
+		SenderID:  "sender-2",
+		Kind:      "text",
+		Content:   "Pesanan siap diambil jam 3",
+		Status:    "sent",
+		MessageID: "msg-1",
+		SentAt:    time.Now().Add(-5 * time.Minute).Format(time.RFC3339),
+	}, nil)
+	mockMessages.On("EditMessage", mock.Anything, &domain.EditMessageRequest{MessageID: "msg-1", Message: "Pesanan siap diambil jam 4", To: "6281234567890", From: "sender-2"}).
+		Return(&domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil)
+	mockHistory.On("LogMode").Return("full", nil)
+	mockHistory.On("RecordEdit", mock.MatchedBy(func(e *domain.MessageEdit) bool {
+		return e.MessageID == "msg-1" && e.PreviousContent == "Pesanan siap diambil jam 3" &&
+			e.Content == "Pesanan siap diambil jam 4" && e.ContentLength == 26 && e.ContentHash != ""
+	})).Return(nil)
+
+	// Act
+	resp, err := service.EditMessage(context.Background(), &domain.EditMessageRequest{MessageID: "msg-1", Message: "Pesanan siap diambil jam 4"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	mockMessages.AssertExpectations(t)
+	mockHistory.AssertExpectations(t)
+}
+
+func TestRecordingMessageService_EditMessage_Refused(t *testing.T) {
+	recent := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	tests := []struct {
+		name   string
+		record *domain.MessageRecord
+		want   error
+	}{
+		{"unknown message", nil, domain.ErrMessageNotFound},
+		{"too old", &domain.MessageRecord{Kind: "text", Status: "sent", SentAt: time.Now().Add(-20 * time.Minute).Format(time.RFC3339)}, domain.ErrEditWindowExpired},
+		{"image", &domain.MessageRecord{Kind: "image", Status: "sent", SentAt: recent}, domain.ErrMessageNotEditable},
+		{"revoked", &domain.MessageRecord{Kind: "text", Status: "revoked", SentAt: recent}, domain.ErrMessageNotEditable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMessages := &mocks.MockMessageService{}
+			mockHistory := &mocks.MockMessageHistoryRepository{}
+			service := NewRecordingMessageService(mockMessages, mockHistory)
+			if tt.record != nil {
+				mockHistory.On("GetByMessageID", "msg-1").Return(tt.record, nil)
+			} else {
+				mockHistory.On("GetByMessageID", "msg-1").Return(nil, nil)
+			}
+
+			resp, err := service.EditMessage(context.Background(), &domain.EditMessageRequest{MessageID: "msg-1", Message: "Halo"})
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, resp.Success)
+			mockMessages.AssertNotCalled(t, "EditMessage", mock.Anything, mock.Anything)
+			mockHistory.AssertNotCalled(t, "RecordEdit", mock.Anything)
+		})
+	}
+}
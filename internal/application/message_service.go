@@ -1,16 +1,32 @@
 package application
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"log"
+	"math"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/wa-serv/eventbus"
 	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/requestid"
 )
 
 type messageService struct {
 	whatsappRepo domain.WhatsAppRepository
+	chains       domain.SenderChainRepository // optional per-category fallback chains
+	pool         []string                     // senders that take over, in order, while the requested one is offline
+	suppressions domain.SuppressionRepository // optional list of recipients that must not be messaged
+	senderPools  domain.SenderPoolRepository  // optional named pools a send can target
 }
 
 // NewMessageService creates a new message service
@@ -20,68 +36,1217 @@ func NewMessageService(whatsappRepo domain.WhatsAppRepository) domain.MessageSer
 	}
 }
 
+// NewMessageServiceWithFallback creates a message service that sends through
+// the configured per-category sender fallback chains, and through the first
+// connected sender of pool whenever the requested sender is offline. Sends to
+// recipients on the suppression list are refused; suppressions may be nil.
+// A send naming a pool of senderPools goes out from a healthy member of it;
+// senderPools may be nil.
+func NewMessageServiceWithFallback(whatsappRepo domain.WhatsAppRepository, chains domain.SenderChainRepository, pool []string, suppressions domain.SuppressionRepository, senderPools domain.SenderPoolRepository) domain.MessageService {
+	return &messageService{
+		whatsappRepo: whatsappRepo,
+		chains:       chains,
+		pool:         pool,
+		suppressions: suppressions,
+		senderPools:  senderPools,
+	}
+}
+
 // SendMessage implements the business logic for sending messages
 func (s *messageService) SendMessage(ctx context.Context, req *domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
 	// Validate input
 	if err := s.validateSendMessageRequest(req); err != nil {
 		return &domain.SendMessageResponse{
 			Success: false,
-			Message: err.Error(),
-		}, err
+			Message: err.Error(),
+		}, err
+	}
+
+	if req.Interactive != nil {
+		if err := validateInteractive(req.Interactive); err != nil {
+			return &domain.SendMessageResponse{
+				Success: false,
+				Message: err.Error(),
+			}, domain.ErrInvalidInteractive
+		}
+	}
+
+	if req.DisappearAfter != "" {
+		if _, err := disappearingTimer(req.DisappearAfter); err != nil {
+			return &domain.SendMessageResponse{
+				Success: false,
+				Message: err.Error(),
+			}, err
+		}
+	}
+
+	var poolMembers []string
+	if req.Pool != "" && req.From != "" {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Set either from or pool, not both",
+		}, domain.ErrInvalidSenderPool
+	}
+	if req.Pool != "" {
+		members, err := s.poolMembers(req.Pool)
+		if err != nil {
+			return &domain.SendMessageResponse{
+				Success: false,
+				Message: err.Error(),
+			}, err
+		}
+		poolMembers = members
+	}
+
+	// Dry runs validate the request (used by post-deploy contract tests) without
+	// needing a connected client or sending anything
+	if req.DryRun {
+		to, err := s.formatRecipient(req.To)
+		if err != nil {
+			return &domain.SendMessageResponse{
+				Success: false,
+				Message: "Invalid phone number format",
+			}, domain.ErrInvalidPhoneNumber
+		}
+		if _, err := s.replySender(req, to); err != nil {
+			return &domain.SendMessageResponse{
+				Success: false,
+				Message: err.Error(),
+			}, err
+		}
+		if resp, err := s.checkSuppressed(to); err != nil {
+			return resp, err
+		}
+		message := "Dry run: message is valid and was not sent"
+		if parts := len(splitMessage(req.Message, maxTextLength)); parts > 1 {
+			message = fmt.Sprintf("Dry run: message is valid and would be sent as %d messages; nothing was sent", parts)
+		}
+		return &domain.SendMessageResponse{
+			Success: true,
+			Message: message,
+		}, nil
+	}
+
+	// A pool send goes out from whichever member is healthy, as if the
+	// request had named it
+	if len(poolMembers) > 0 {
+		senderID, err := s.whatsappRepo.PickSender(poolMembers)
+		if err != nil {
+			return &domain.SendMessageResponse{
+				Success: false,
+				Message: fmt.Sprintf("No sender of pool %s can send right now", req.Pool),
+			}, err
+		}
+		picked := *req
+		picked.From = senderID
+		req = &picked
+	}
+
+	// A fallback chain names its own senders, so the default client being
+	// offline does not block the send
+	chain := s.fallbackChain(req)
+	from, fellBack := req.From, false
+	if len(chain) == 0 {
+		from, fellBack = s.poolSender(req.From)
+	}
+
+	// Check if WhatsApp is connected
+	if len(chain) == 0 && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	// Format phone number
+	formattedPhone, err := s.formatRecipient(req.To)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid phone number format",
+		}, domain.ErrInvalidPhoneNumber
+	}
+	if resp, err := s.checkSuppressed(formattedPhone); err != nil {
+		return resp, err
+	}
+
+	replySender, err := s.replySender(req, formattedPhone)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	// Create a context with timeout to prevent hanging
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if len(chain) > 0 {
+		return s.sendWithFallback(sendCtx, chain, formattedPhone, replySender, req)
+	}
+
+	ids, err := s.sendParts(sendCtx, from, formattedPhone, replySender, req)
+	if err != nil {
+		return partlySent(ids, from, err)
+	}
+
+	response := sentResponse(ids, from)
+	response.Fallback = fellBack
+	return response, nil
+}
+
+// maxImageBytes is WhatsApp's size limit for image messages
+const maxImageBytes = 16 << 20
+
+// SendImage implements the business logic for sending an image with an optional caption
+func (s *messageService) SendImage(ctx context.Context, req *domain.SendImageRequest) (*domain.SendMessageResponse, error) {
+	if req == nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "request cannot be nil",
+		}, domain.ErrInvalidImage
+	}
+
+	formattedPhone, err := s.formatRecipient(req.To)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid phone number format",
+		}, domain.ErrInvalidPhoneNumber
+	}
+	if resp, err := s.checkSuppressed(formattedPhone); err != nil {
+		return resp, err
+	}
+
+	image, mimeType, err := decodeImage(req)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: err.Error(),
+		}, domain.ErrInvalidImage
+	}
+
+	from, fellBack := s.poolSender(req.From)
+	if from == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	// Uploads take longer than text sends, so allow more time
+	sendCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	message, err := s.whatsappRepo.SendImage(sendCtx, from, formattedPhone, image, mimeType, strings.TrimSpace(req.Caption))
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to send image: %v", err),
+		}, sendFailure(err)
+	}
+
+	publishSent(ctx, "image", formattedPhone, from, message.ID)
+	return &domain.SendMessageResponse{
+		Success:  true,
+		Message:  "Image sent successfully",
+		ID:       message.ID,
+		SenderID: from,
+		Fallback: fellBack,
+	}, nil
+}
+
+// decodeImage returns the image bytes of req and their MIME type. Data from a
+// multipart upload takes precedence over the base64 Image field.
+func decodeImage(req *domain.SendImageRequest) ([]byte, string, error) {
+	data := req.Data
+	if len(data) == 0 {
+		if strings.TrimSpace(req.Image) == "" {
+			return nil, "", fmt.Errorf("image is required")
+		}
+		decoded, err := decodeBase64File(req.Image)
+		if err != nil {
+			return nil, "", fmt.Errorf("image is not valid base64")
+		}
+		data = decoded
+	}
+
+	if len(data) > maxImageBytes {
+		return nil, "", fmt.Errorf("image is larger than 16 MB")
+	}
+	mimeType := http.DetectContentType(data)
+	if mimeType != "image/jpeg" && mimeType != "image/png" {
+		return nil, "", fmt.Errorf("unsupported image type %s, expected JPEG or PNG", mimeType)
+	}
+	return data, mimeType, nil
+}
+
+// maxDocumentBytes is WhatsApp's size limit for document messages
+const maxDocumentBytes = 100 << 20
+
+// documentMimeTypes covers common office formats missing from Go's built-in
+// table, which otherwise depends on the host's mime.types file
+var documentMimeTypes = map[string]string{
+	".csv":  "text/csv",
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".txt":  "text/plain",
+	".xls":  "application/vnd.ms-excel",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// SendDocument implements the business logic for sending a file such as a PDF
+// invoice as a document with an optional caption
+func (s *messageService) SendDocument(ctx context.Context, req *domain.SendDocumentRequest) (*domain.SendMessageResponse, error) {
+	if req == nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "request cannot be nil",
+		}, domain.ErrInvalidDocument
+	}
+
+	formattedPhone, err := s.formatRecipient(req.To)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid phone number format",
+		}, domain.ErrInvalidPhoneNumber
+	}
+	if resp, err := s.checkSuppressed(formattedPhone); err != nil {
+		return resp, err
+	}
+
+	document, fileName, mimeType, err := decodeDocument(req)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: err.Error(),
+		}, domain.ErrInvalidDocument
+	}
+
+	from, fellBack := s.poolSender(req.From)
+	if from == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	// Documents can be large, so allow more time for the upload
+	sendCtx, cancel := context.WithTimeout(ctx, 120*time.Second)
+	defer cancel()
+
+	message, err := s.whatsappRepo.SendDocument(sendCtx, from, formattedPhone, document, fileName, mimeType, strings.TrimSpace(req.Caption))
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to send document: %v", err),
+		}, sendFailure(err)
+	}
+
+	publishSent(ctx, "document", formattedPhone, from, message.ID)
+	return &domain.SendMessageResponse{
+		Success:  true,
+		Message:  "Document sent successfully",
+		ID:       message.ID,
+		SenderID: from,
+		Fallback: fellBack,
+	}, nil
+}
+
+// decodeDocument returns the file bytes of req with its file name and MIME
+// type. Data from a multipart upload takes precedence over the base64 Document
+// field; a missing MIME type is derived from the file extension, then the content.
+func decodeDocument(req *domain.SendDocumentRequest) ([]byte, string, string, error) {
+	fileName := strings.TrimSpace(filepath.Base(req.FileName))
+	if fileName == "" || fileName == "." || fileName == string(filepath.Separator) {
+		return nil, "", "", fmt.Errorf("filename is required")
+	}
+
+	data := req.Data
+	if len(data) == 0 {
+		if strings.TrimSpace(req.Document) == "" {
+			return nil, "", "", fmt.Errorf("document is required")
+		}
+		decoded, err := decodeBase64File(req.Document)
+		if err != nil {
+			return nil, "", "", fmt.Errorf("document is not valid base64")
+		}
+		data = decoded
+	}
+	if len(data) == 0 {
+		return nil, "", "", fmt.Errorf("document is empty")
+	}
+	if len(data) > maxDocumentBytes {
+		return nil, "", "", fmt.Errorf("document is larger than 100 MB")
+	}
+
+	mimeType := strings.TrimSpace(req.MimeType)
+	if mimeType == "" {
+		ext := strings.ToLower(filepath.Ext(fileName))
+		if mimeType = documentMimeTypes[ext]; mimeType == "" {
+			mimeType = mime.TypeByExtension(ext)
+		}
+	}
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err != nil || !strings.Contains(mediaType, "/") {
+		return nil, "", "", fmt.Errorf("invalid mimetype %q", mimeType)
+	}
+	return data, fileName, mimeType, nil
+}
+
+// maxAudioBytes is WhatsApp's size limit for audio messages
+const maxAudioBytes = 16 << 20
+
+// SendAudio implements the business logic for sending a voice note or audio message
+func (s *messageService) SendAudio(ctx context.Context, req *domain.SendAudioRequest) (*domain.SendMessageResponse, error) {
+	if req == nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "request cannot be nil",
+		}, domain.ErrInvalidAudio
+	}
+
+	formattedPhone, err := s.formatRecipient(req.To)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid phone number format",
+		}, domain.ErrInvalidPhoneNumber
+	}
+	if resp, err := s.checkSuppressed(formattedPhone); err != nil {
+		return resp, err
+	}
+
+	audio, mimeType, err := decodeAudio(req)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: err.Error(),
+		}, domain.ErrInvalidAudio
+	}
+
+	from, fellBack := s.poolSender(req.From)
+	if from == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	// Uploads take longer than text sends, so allow more time
+	sendCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	message, err := s.whatsappRepo.SendAudio(sendCtx, from, formattedPhone, audio, mimeType, req.PTT)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to send audio: %v", err),
+		}, sendFailure(err)
+	}
+
+	publishSent(ctx, "audio", formattedPhone, from, message.ID)
+	return &domain.SendMessageResponse{
+		Success:  true,
+		Message:  "Audio sent successfully",
+		ID:       message.ID,
+		SenderID: from,
+		Fallback: fellBack,
+	}, nil
+}
+
+// decodeAudio returns the audio bytes of req and their MIME type. Data from a
+// multipart upload takes precedence over the base64 Audio field.
+func decodeAudio(req *domain.SendAudioRequest) ([]byte, string, error) {
+	data := req.Data
+	if len(data) == 0 {
+		if strings.TrimSpace(req.Audio) == "" {
+			return nil, "", fmt.Errorf("audio is required")
+		}
+		decoded, err := decodeBase64File(req.Audio)
+		if err != nil {
+			return nil, "", fmt.Errorf("audio is not valid base64")
+		}
+		data = decoded
+	}
+
+	if len(data) > maxAudioBytes {
+		return nil, "", fmt.Errorf("audio is larger than 16 MB")
+	}
+	mimeType := detectAudioType(data)
+	switch {
+	case mimeType == "":
+		return nil, "", fmt.Errorf("unsupported audio type, expected OGG/Opus or MP3")
+	case req.PTT && mimeType != oggOpusMimeType:
+		// WhatsApp clients only play voice notes encoded as Opus in an OGG container
+		return nil, "", fmt.Errorf("voice notes must be OGG/Opus")
+	}
+	return data, mimeType, nil
+}
+
+// oggOpusMimeType is the MIME type WhatsApp uses for voice notes
+const oggOpusMimeType = "audio/ogg; codecs=opus"
+
+// detectAudioType sniffs OGG and MP3 files, returning "" for anything else.
+// http.DetectContentType misses MP3 files without an ID3 tag.
+func detectAudioType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("OggS")):
+		return oggOpusMimeType
+	case bytes.HasPrefix(data, []byte("ID3")):
+		return "audio/mpeg"
+	case len(data) >= 2 && data[0] == 0xFF && data[1]&0xE0 == 0xE0:
+		// MPEG audio frame sync
+		return "audio/mpeg"
+	}
+	return ""
+}
+
+// SendLocation implements the business logic for sharing a map location
+func (s *messageService) SendLocation(ctx context.Context, req *domain.SendLocationRequest) (*domain.SendMessageResponse, error) {
+	if req == nil || req.Latitude == nil || req.Longitude == nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "latitude and longitude are required",
+		}, domain.ErrInvalidLocation
+	}
+
+	formattedPhone, err := s.formatRecipient(req.To)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid phone number format",
+		}, domain.ErrInvalidPhoneNumber
+	}
+	if resp, err := s.checkSuppressed(formattedPhone); err != nil {
+		return resp, err
+	}
+
+	latitude, longitude := *req.Latitude, *req.Longitude
+	if math.IsNaN(latitude) || math.IsNaN(longitude) || math.Abs(latitude) > 90 || math.Abs(longitude) > 180 {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "latitude must be between -90 and 90 and longitude between -180 and 180",
+		}, domain.ErrInvalidLocation
+	}
+
+	from, fellBack := s.poolSender(req.From)
+	if from == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	message, err := s.whatsappRepo.SendLocation(sendCtx, from, formattedPhone, latitude, longitude,
+		strings.TrimSpace(req.Name), strings.TrimSpace(req.Address))
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to send location: %v", err),
+		}, sendFailure(err)
+	}
+
+	publishSent(ctx, "location", formattedPhone, from, message.ID)
+	return &domain.SendMessageResponse{
+		Success:  true,
+		Message:  "Location sent successfully",
+		ID:       message.ID,
+		SenderID: from,
+		Fallback: fellBack,
+	}, nil
+}
+
+// maxContactsPerMessage bounds how many cards one send-contact request can carry
+const maxContactsPerMessage = 20
+
+// SendContact implements the business logic for sharing one or more contact cards
+func (s *messageService) SendContact(ctx context.Context, req *domain.SendContactRequest) (*domain.SendMessageResponse, error) {
+	if req == nil || len(req.Contacts) == 0 || len(req.Contacts) > maxContactsPerMessage {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("between 1 and %d contacts are required", maxContactsPerMessage),
+		}, domain.ErrInvalidContact
+	}
+
+	formattedPhone, err := s.formatRecipient(req.To)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid phone number format",
+		}, domain.ErrInvalidPhoneNumber
+	}
+	if resp, err := s.checkSuppressed(formattedPhone); err != nil {
+		return resp, err
+	}
+
+	contacts := make([]domain.ContactCard, len(req.Contacts))
+	for i, contact := range req.Contacts {
+		card, err := s.buildContactCard(contact)
+		if err != nil {
+			return &domain.SendMessageResponse{
+				Success: false,
+				Message: fmt.Sprintf("contact %d: %v", i+1, err),
+			}, domain.ErrInvalidContact
+		}
+		contacts[i] = card
+	}
+
+	from, fellBack := s.poolSender(req.From)
+	if from == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	message, err := s.whatsappRepo.SendContacts(sendCtx, from, formattedPhone, contacts)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to send contacts: %v", err),
+		}, sendFailure(err)
+	}
+
+	publishSent(ctx, "contact", formattedPhone, from, message.ID)
+	return &domain.SendMessageResponse{
+		Success:  true,
+		Message:  "Contacts sent successfully",
+		ID:       message.ID,
+		SenderID: from,
+		Fallback: fellBack,
+	}, nil
+}
+
+// buildContactCard validates contact and fills in its vCard. The waid
+// parameter lets WhatsApp show "Message" and "Add" buttons for the number.
+func (s *messageService) buildContactCard(contact domain.ContactCard) (domain.ContactCard, error) {
+	contact.Name = strings.TrimSpace(contact.Name)
+	contact.Organization = strings.TrimSpace(contact.Organization)
+	if contact.Name == "" {
+		return contact, fmt.Errorf("name is required")
+	}
+
+	formatted, err := s.formatPhoneNumber(contact.Phone)
+	if err != nil {
+		return contact, fmt.Errorf("invalid phone number %q", contact.Phone)
+	}
+	digits := strings.TrimSuffix(formatted, "@s.whatsapp.net")
+	contact.Phone = "+" + digits
+
+	var vcard strings.Builder
+	vcard.WriteString("BEGIN:VCARD\nVERSION:3.0\n")
+	fmt.Fprintf(&vcard, "FN:%s\n", escapeVCard(contact.Name))
+	if contact.Organization != "" {
+		fmt.Fprintf(&vcard, "ORG:%s\n", escapeVCard(contact.Organization))
+	}
+	fmt.Fprintf(&vcard, "TEL;type=CELL;type=VOICE;waid=%s:%s\n", digits, contact.Phone)
+	vcard.WriteString("END:VCARD")
+	contact.VCard = vcard.String()
+	return contact, nil
+}
+
+// maxReactionRunes bounds a reaction; an emoji with skin tone and joiners takes
+// several runes, but WhatsApp shows only one emoji per reaction
+const maxReactionRunes = 10
+
+// SendReaction implements the business logic for reacting to a message
+func (s *messageService) SendReaction(ctx context.Context, req *domain.SendReactionRequest) (*domain.SendMessageResponse, error) {
+	if req == nil || strings.TrimSpace(req.MessageID) == "" || !validReaction(req.Emoji) {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "message_id and a single emoji are required",
+		}, domain.ErrInvalidReaction
+	}
+
+	chat, err := s.formatRecipient(req.ChatJID)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid chat JID",
+		}, domain.ErrInvalidPhoneNumber
+	}
+
+	// In a one-to-one chat the reacted message came from the customer unless
+	// told otherwise; in a group WhatsApp needs to know who sent it
+	sender := chat
+	if strings.TrimSpace(req.Sender) != "" {
+		sender, err = s.formatPhoneNumber(strings.TrimSuffix(strings.TrimSpace(req.Sender), "@s.whatsapp.net"))
+		if err != nil {
+			return &domain.SendMessageResponse{
+				Success: false,
+				Message: "Invalid sender phone number",
+			}, domain.ErrInvalidPhoneNumber
+		}
+	} else if strings.HasSuffix(chat, "@g.us") {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "sender is required to react to a group message",
+		}, domain.ErrInvalidReaction
+	}
+
+	if req.From == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	message, err := s.whatsappRepo.SendReaction(sendCtx, req.From, chat, sender, strings.TrimSpace(req.MessageID), req.Emoji)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to send reaction: %v", err),
+		}, sendFailure(err)
+	}
+
+	publishSent(ctx, "reaction", chat, req.From, message.ID)
+	responseMessage := "Reaction sent successfully"
+	if req.Emoji == "" {
+		responseMessage = "Reaction removed successfully"
+	}
+	return &domain.SendMessageResponse{
+		Success: true,
+		Message: responseMessage,
+		ID:      message.ID,
+	}, nil
+}
+
+// validReaction reports whether emoji can be sent as a reaction: empty, which
+// removes a reaction, or a short run of non-ASCII characters such as 👍 or 👍🏽
+func validReaction(emoji string) bool {
+	if !utf8.ValidString(emoji) || utf8.RuneCountInString(emoji) > maxReactionRunes {
+		return false
+	}
+	for _, r := range emoji {
+		if r < utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// RevokeMessage implements the business logic for deleting a sent message for
+// everyone. The deletion is not a new message and is not published.
+func (s *messageService) RevokeMessage(ctx context.Context, req *domain.RevokeMessageRequest) (*domain.SendMessageResponse, error) {
+	if req == nil || strings.TrimSpace(req.MessageID) == "" {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "message_id is required",
+		}, domain.ErrInvalidRevoke
 	}
 
-	// Check if WhatsApp is connected
-	if !s.whatsappRepo.IsConnected() {
+	chat, err := s.formatRecipient(req.To)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid chat JID",
+		}, domain.ErrInvalidPhoneNumber
+	}
+
+	if req.From == "" && !s.whatsappRepo.IsConnected() {
 		return &domain.SendMessageResponse{
 			Success: false,
 			Message: "WhatsApp client is not connected",
 		}, domain.ErrWhatsAppNotConnected
 	}
 
-	// Format phone number
-	formattedPhone, err := s.formatPhoneNumber(req.To)
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	messageID := strings.TrimSpace(req.MessageID)
+	if _, err := s.whatsappRepo.RevokeMessage(sendCtx, req.From, chat, messageID); err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to delete message: %v", err),
+		}, sendFailure(err)
+	}
+
+	return &domain.SendMessageResponse{
+		Success: true,
+		Message: "Message deleted for everyone",
+		ID:      messageID,
+	}, nil
+}
+
+// EditMessage implements the business logic for replacing the text of a sent
+// message. The edit is not a new message and is not published.
+func (s *messageService) EditMessage(ctx context.Context, req *domain.EditMessageRequest) (*domain.SendMessageResponse, error) {
+	if req == nil || strings.TrimSpace(req.MessageID) == "" || strings.TrimSpace(req.Message) == "" {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "message_id and message are required",
+		}, domain.ErrInvalidEdit
+	}
+
+	chat, err := s.formatRecipient(req.To)
 	if err != nil {
 		return &domain.SendMessageResponse{
 			Success: false,
-			Message: "Invalid phone number format",
+			Message: "Invalid chat JID",
 		}, domain.ErrInvalidPhoneNumber
 	}
 
-	// Create a context with timeout to prevent hanging
+	if req.From == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
 	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	// Send message - either from a specific sender or the default one
-	var message *domain.Message
-	if req.From != "" {
-		// Send from specific sender
-		message, err = s.whatsappRepo.SendMessageFrom(sendCtx, req.From, formattedPhone, req.Message)
-	} else {
-		// Send from default sender
-		message, err = s.whatsappRepo.SendMessage(sendCtx, formattedPhone, req.Message)
+	messageID := strings.TrimSpace(req.MessageID)
+	if _, err := s.whatsappRepo.EditMessage(sendCtx, req.From, chat, messageID, req.Message); err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to edit message: %v", err),
+		}, sendFailure(err)
+	}
+
+	return &domain.SendMessageResponse{
+		Success: true,
+		Message: "Message edited",
+		ID:      messageID,
+	}, nil
+}
+
+// SendChatPresence implements the business logic for showing typing or
+// recording in a chat. Presence is not a message and is not published.
+func (s *messageService) SendChatPresence(ctx context.Context, req *domain.SendChatPresenceRequest) (*domain.SendMessageResponse, error) {
+	state := ""
+	if req != nil {
+		state = strings.ToLower(strings.TrimSpace(req.State))
+	}
+	switch state {
+	case domain.ChatPresenceTyping, domain.ChatPresenceRecording, domain.ChatPresencePaused:
+	default:
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "state must be typing, recording or paused",
+		}, domain.ErrInvalidPresence
 	}
 
+	chat, err := s.formatRecipient(req.ChatJID)
 	if err != nil {
 		return &domain.SendMessageResponse{
 			Success: false,
-			Message: fmt.Sprintf("Failed to send message: %v", err),
-		}, domain.ErrMessageSendFailed
+			Message: "Invalid chat JID",
+		}, domain.ErrInvalidPhoneNumber
+	}
+
+	if req.From == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := s.whatsappRepo.SendChatPresence(sendCtx, req.From, chat, state); err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to send chat presence: %v", err),
+		}, sendFailure(err)
 	}
 
 	return &domain.SendMessageResponse{
 		Success: true,
-		Message: "Message sent successfully",
-		ID:      message.ID,
+		Message: "Chat presence sent successfully",
+	}, nil
+}
+
+// SetPresence implements the business logic for showing a sender as online or
+// offline
+func (s *messageService) SetPresence(ctx context.Context, req *domain.SetPresenceRequest) (*domain.SendMessageResponse, error) {
+	presence := ""
+	if req != nil {
+		presence = strings.ToLower(strings.TrimSpace(req.Presence))
+	}
+	if presence != domain.PresenceAvailable && presence != domain.PresenceUnavailable {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "presence must be available or unavailable",
+		}, domain.ErrInvalidPresence
+	}
+
+	if req.From == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := s.whatsappRepo.SetPresence(sendCtx, req.From, presence); err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to set presence: %v", err),
+		}, sendFailure(err)
+	}
+
+	return &domain.SendMessageResponse{
+		Success: true,
+		Message: "Presence set to " + presence,
+	}, nil
+}
+
+// disappearingTimers are the durations of the disappearing-message timers
+// WhatsApp apps offer; chats ignore any other
+var disappearingTimers = map[string]time.Duration{
+	domain.DisappearingOff:     0,
+	domain.Disappearing24Hours: 24 * time.Hour,
+	domain.Disappearing7Days:   7 * 24 * time.Hour,
+	domain.Disappearing90Days:  90 * 24 * time.Hour,
+}
+
+func disappearingTimer(value string) (time.Duration, error) {
+	timer, ok := disappearingTimers[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return 0, domain.ErrInvalidDisappearing
+	}
+	return timer, nil
+}
+
+// SetDisappearing implements the business logic for setting how long messages
+// in a chat last. WhatsApp announces the change in the chat.
+func (s *messageService) SetDisappearing(ctx context.Context, req *domain.SetDisappearingRequest) (*domain.SendMessageResponse, error) {
+	if req == nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: domain.ErrInvalidDisappearing.Error(),
+		}, domain.ErrInvalidDisappearing
+	}
+	timer, err := disappearingTimer(req.Timer)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	chat, err := s.formatRecipient(req.ChatJID)
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid chat JID",
+		}, domain.ErrInvalidPhoneNumber
+	}
+
+	if req.From == "" && !s.whatsappRepo.IsConnected() {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "WhatsApp client is not connected",
+		}, domain.ErrWhatsAppNotConnected
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := s.whatsappRepo.SetDisappearingTimer(sendCtx, req.From, chat, timer); err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to set disappearing timer: %v", err),
+		}, sendFailure(err)
+	}
+
+	message := "Disappearing messages turned off"
+	if timer > 0 {
+		message = "Messages now disappear after " + strings.ToLower(strings.TrimSpace(req.Timer))
+	}
+	return &domain.SendMessageResponse{
+		Success: true,
+		Message: message,
 	}, nil
 }
 
+// vcardEscaper escapes the characters vCard 3.0 treats as separators
+var vcardEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeVCard(value string) string {
+	return vcardEscaper.Replace(value)
+}
+
+// decodeBase64File decodes a base64 payload, accepting data URIs such as
+// "data:image/png;base64,iVBOR..."
+func decodeBase64File(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if i := strings.Index(encoded, ";base64,"); strings.HasPrefix(encoded, "data:") && i >= 0 {
+		encoded = encoded[i+len(";base64,"):]
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// fallbackChain returns the senders to try for req, or nil when the request
+// names an explicit sender or no chain is configured for its category
+func (s *messageService) fallbackChain(req *domain.SendMessageRequest) []string {
+	if s.chains == nil || req.From != "" {
+		return nil
+	}
+
+	category := strings.ToLower(strings.TrimSpace(req.Category))
+	if category == "" {
+		category = domain.DefaultMessageCategory
+	}
+	chain, err := s.chains.GetChain(category)
+	if err != nil {
+		// Fall back to the default sender rather than failing the send
+		log.Printf("Failed to load %s sender chain, using default sender: %v", category, err)
+		return nil
+	}
+	return chain
+}
+
+// poolMembers returns the senders of the named pool, in order
+func (s *messageService) poolMembers(name string) ([]string, error) {
+	if s.senderPools == nil {
+		return nil, domain.ErrSenderPoolNotFound
+	}
+	members, err := s.senderPools.GetPool(strings.ToLower(strings.TrimSpace(name)))
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, domain.ErrSenderPoolNotFound
+	}
+	return members, nil
+}
+
+// poolSender picks who sends a message requested from from, the default sender
+// when empty: from itself while it is connected, otherwise the first connected
+// sender of the pool. fellBack reports whether the pool took over. With no
+// sender connected the send goes ahead from from and fails as it would have.
+func (s *messageService) poolSender(from string) (sender string, fellBack bool) {
+	if len(s.pool) == 0 || s.whatsappRepo.IsSenderConnected(from) {
+		return from, false
+	}
+	for _, senderID := range s.pool {
+		if senderID != from && s.whatsappRepo.IsSenderConnected(senderID) {
+			return senderID, true
+		}
+	}
+	return from, false
+}
+
+// sendFrom sends req as text, a reply to a message replySender sent, or with
+// its buttons or list menu, from a specific sender or the default one when
+// from is empty
+func (s *messageService) sendFrom(ctx context.Context, from, to, replySender string, req *domain.SendMessageRequest) (*domain.Message, error) {
+	if req.Interactive != nil {
+		return s.whatsappRepo.SendInteractive(ctx, from, to, req.Message, req.Interactive)
+	}
+	if replySender != "" {
+		return s.whatsappRepo.SendReply(ctx, from, to, req.Message, replySender, strings.TrimSpace(req.ReplyTo))
+	}
+	if from != "" {
+		return s.whatsappRepo.SendMessageFrom(ctx, from, to, req.Message)
+	}
+	return s.whatsappRepo.SendMessage(ctx, to, req.Message)
+}
+
+// sendParts sends req's text from sender from, split into several messages
+// when it is too long for one, and returns the IDs of the parts sent. Only the
+// first part quotes the message replied to and only the last one carries the
+// buttons or list menu. A requested disappearing timer is set on the chat
+// first, so every part disappears after it.
+func (s *messageService) sendParts(ctx context.Context, from, to, replySender string, req *domain.SendMessageRequest) ([]string, error) {
+	if req.DisappearAfter != "" {
+		timer, err := disappearingTimer(req.DisappearAfter)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.whatsappRepo.SetDisappearingTimer(ctx, from, to, timer); err != nil {
+			return nil, fmt.Errorf("failed to set disappearing timer: %w", err)
+		}
+	}
+
+	texts := splitMessage(req.Message, maxTextLength)
+	ids := make([]string, 0, len(texts))
+	for i, text := range texts {
+		part := *req
+		part.Message = text
+		if i > 0 {
+			replySender = ""
+		}
+		if i < len(texts)-1 {
+			part.Interactive = nil
+		}
+		message, err := s.sendFrom(ctx, from, to, replySender, &part)
+		if err != nil {
+			if len(texts) > 1 {
+				return ids, fmt.Errorf("part %d of %d: %w", i+1, len(texts), err)
+			}
+			return ids, err
+		}
+		publishSent(ctx, messageKind(&part), to, from, message.ID)
+		ids = append(ids, message.ID)
+	}
+	return ids, nil
+}
+
+// sentResponse reports a text sent from senderID as the messages ids
+func sentResponse(ids []string, senderID string) *domain.SendMessageResponse {
+	response := &domain.SendMessageResponse{
+		Success:  true,
+		Message:  "Message sent successfully",
+		ID:       ids[0],
+		SenderID: senderID,
+	}
+	if len(ids) > 1 {
+		response.Message = fmt.Sprintf("Message sent successfully as %d messages", len(ids))
+		response.IDs = ids
+	}
+	return response
+}
+
+// partlySent reports a text that failed with err after the parts ids were
+// sent. Once a part is out, a retry would repeat it, so that is not reported as
+// ErrMessageSendFailed, which the send queue retries.
+func partlySent(ids []string, senderID string, err error) (*domain.SendMessageResponse, error) {
+	if len(ids) == 0 {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to send message: %v", err),
+		}, sendFailure(err)
+	}
+	return &domain.SendMessageResponse{
+		Success:  false,
+		Message:  fmt.Sprintf("Failed to send the rest of the message: %v", err),
+		ID:       ids[0],
+		IDs:      ids,
+		SenderID: senderID,
+	}, domain.ErrMessagePartlySent
+}
+
+// sendFailure is how a send that failed with err is reported: as
+// ErrSenderPaused when the sender is paused, or ErrSenderQuotaExceeded when it
+// has used up its daily quota, which retrying will not fix, otherwise as
+// ErrMessageSendFailed
+func sendFailure(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrSenderPaused):
+		return domain.ErrSenderPaused
+	case errors.Is(err, domain.ErrSenderQuotaExceeded):
+		return domain.ErrSenderQuotaExceeded
+	}
+	return domain.ErrMessageSendFailed
+}
+
+// replySender returns who sent the message req replies to in the chat with
+// the formatted recipient to, or "" when req is not a reply. In a one-to-one
+// chat that is the customer unless told otherwise; in a group WhatsApp needs
+// to know.
+func (s *messageService) replySender(req *domain.SendMessageRequest, to string) (string, error) {
+	if strings.TrimSpace(req.ReplyTo) == "" {
+		if strings.TrimSpace(req.ReplyToSender) != "" {
+			return "", domain.ErrInvalidReply
+		}
+		return "", nil
+	}
+	if req.Interactive != nil {
+		return "", domain.ErrInvalidReply
+	}
+
+	if sender := strings.TrimSpace(req.ReplyToSender); sender != "" {
+		formatted, err := s.formatPhoneNumber(strings.TrimSuffix(sender, "@s.whatsapp.net"))
+		if err != nil {
+			return "", domain.ErrInvalidReply
+		}
+		return formatted, nil
+	}
+	if strings.HasSuffix(to, "@g.us") {
+		return "", domain.ErrInvalidReply
+	}
+	return to, nil
+}
+
+// messageKind names the kind of message req sends, for message.sent events
+func messageKind(req *domain.SendMessageRequest) string {
+	if req.Interactive != nil {
+		return "interactive"
+	}
+	return "text"
+}
+
+// sendWithFallback tries each sender in chain until one delivers the message
+func (s *messageService) sendWithFallback(ctx context.Context, chain []string, to, replySender string, req *domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
+	failures := make([]string, 0, len(chain))
+	for _, senderID := range chain {
+		ids, err := s.sendParts(ctx, senderID, to, replySender, req)
+		if len(ids) > 0 && err != nil {
+			// The rest of a split message from another sender would read as
+			// a different conversation
+			return partlySent(ids, senderID, err)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", senderID, err))
+			continue
+		}
+		return sentResponse(ids, senderID), nil
+	}
+
+	return &domain.SendMessageResponse{
+		Success: false,
+		Message: "Failed to send message: every sender in the fallback chain failed (" + strings.Join(failures, "; ") + ")",
+	}, domain.ErrMessageSendFailed
+}
+
+// publishSent announces a delivered message to event subscribers. senderID is
+// empty when the default sender was used.
+func publishSent(ctx context.Context, kind, to, senderID, messageID string) {
+	eventbus.Publish(eventbus.MessageSent, withRequestID(ctx, map[string]any{
+		"kind":       kind,
+		"to":         to,
+		"sender_id":  senderID,
+		"message_id": messageID,
+	}))
+}
+
+// withRequestID adds the ID of the API request ctx belongs to, if any, to
+// event data so webhooks can be traced back to the call
+func withRequestID(ctx context.Context, data map[string]any) map[string]any {
+	if id := requestid.FromContext(ctx); id != "" {
+		data[eventbus.DataRequestID] = id
+	}
+	return data
+}
+
 // GetStatus implements the business logic for getting service status
 func (s *messageService) GetStatus(ctx context.Context) (*domain.ServiceStatus, error) {
 	whatsappStatus := domain.WhatsAppStatus{
+		Status:    domain.WhatsAppStatusOK,
 		Connected: s.whatsappRepo.IsConnected(),
 		LoggedIn:  s.whatsappRepo.IsLoggedIn(),
 		JID:       s.whatsappRepo.GetJID(),
 	}
+	// A fresh install has no sender until one is paired, which is reported
+	// rather than treated as an error
+	switch {
+	case !whatsappStatus.LoggedIn:
+		whatsappStatus.Status = domain.WhatsAppStatusDegraded
+		whatsappStatus.Error = "no sender is logged in"
+	case !whatsappStatus.Connected:
+		whatsappStatus.Status = domain.WhatsAppStatusDegraded
+		whatsappStatus.Error = "no sender is connected"
+	}
 
 	return &domain.ServiceStatus{
 		WhatsApp: whatsappStatus,
@@ -95,6 +1260,25 @@ func (s *messageService) ListSenders(ctx context.Context) ([]*domain.Sender, err
 		return nil, fmt.Errorf("failed to get senders: %w", err)
 	}
 
+	if s.chains != nil {
+		chains, err := s.chains.ListChains()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sender fallback chains: %w", err)
+		}
+		for _, sender := range senders {
+			for category, senderIDs := range chains {
+				for i, id := range senderIDs {
+					if id == sender.ID {
+						if sender.FallbackChains == nil {
+							sender.FallbackChains = make(map[string]int)
+						}
+						sender.FallbackChains[category] = i + 1
+					}
+				}
+			}
+		}
+	}
+
 	return senders, nil
 }
 
@@ -115,6 +1299,123 @@ func (s *messageService) validateSendMessageRequest(req *domain.SendMessageReque
 	return nil
 }
 
+// WhatsApp limits for interactive messages
+const (
+	maxReplyButtons   = 3
+	maxListRows       = 10
+	maxButtonTitleLen = 20
+	maxRowTitleLen    = 24
+	maxRowDescLen     = 72
+	maxFooterLen      = 60
+)
+
+// validateInteractive checks reply buttons or a list menu against WhatsApp's
+// limits. Button and row IDs must be unique, since they identify the reply.
+func validateInteractive(interactive *domain.InteractiveMessage) error {
+	if utf8.RuneCountInString(interactive.Footer) > maxFooterLen {
+		return fmt.Errorf("footer must be at most %d characters", maxFooterLen)
+	}
+
+	hasButtons, hasList := len(interactive.Buttons) > 0, interactive.List != nil
+	if hasButtons == hasList {
+		return fmt.Errorf("interactive message needs either buttons or a list")
+	}
+
+	ids := make(map[string]bool)
+	checkOption := func(kind, id, title string, maxTitle int) error {
+		id, title = strings.TrimSpace(id), strings.TrimSpace(title)
+		if id == "" || title == "" {
+			return fmt.Errorf("every %s needs an id and a title", kind)
+		}
+		if utf8.RuneCountInString(title) > maxTitle {
+			return fmt.Errorf("%s title %q must be at most %d characters", kind, title, maxTitle)
+		}
+		if ids[id] {
+			return fmt.Errorf("duplicate %s id %q", kind, id)
+		}
+		ids[id] = true
+		return nil
+	}
+
+	if hasButtons {
+		if len(interactive.Buttons) > maxReplyButtons {
+			return fmt.Errorf("at most %d reply buttons are allowed", maxReplyButtons)
+		}
+		for _, button := range interactive.Buttons {
+			if err := checkOption("button", button.ID, button.Title, maxButtonTitleLen); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	label := strings.TrimSpace(interactive.List.Button)
+	if label == "" || utf8.RuneCountInString(label) > maxButtonTitleLen {
+		return fmt.Errorf("list button label must be 1-%d characters", maxButtonTitleLen)
+	}
+	rows := 0
+	for _, section := range interactive.List.Sections {
+		if len(section.Rows) == 0 {
+			return fmt.Errorf("list sections need at least one row")
+		}
+		for _, row := range section.Rows {
+			if err := checkOption("row", row.ID, row.Title, maxRowTitleLen); err != nil {
+				return err
+			}
+			if utf8.RuneCountInString(row.Description) > maxRowDescLen {
+				return fmt.Errorf("row description must be at most %d characters", maxRowDescLen)
+			}
+			rows++
+		}
+	}
+	if rows == 0 || rows > maxListRows {
+		return fmt.Errorf("list needs 1-%d rows", maxListRows)
+	}
+	return nil
+}
+
+// groupJIDPattern matches a WhatsApp group JID: the creator's number and a
+// timestamp joined by a dash, or the numeric ID newer groups get
+var groupJIDPattern = regexp.MustCompile(`^[0-9]+(-[0-9]+)?@g\.us$`)
+
+// checkSuppressed refuses a send to the formatted recipient to when it is on
+// the suppression list. When the list cannot be read, the send fails
+// transiently, so a queued send is retried rather than risk messaging someone
+// who opted out.
+func (s *messageService) checkSuppressed(to string) (*domain.SendMessageResponse, error) {
+	if s.suppressions == nil {
+		return nil, nil
+	}
+	suppression, err := s.suppressions.GetSuppression(strings.TrimSuffix(to, "@s.whatsapp.net"))
+	if err == domain.ErrSuppressionNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("Failed to check the suppression list: %v", err),
+		}, domain.ErrMessageSendFailed
+	}
+	return &domain.SendMessageResponse{
+		Success: false,
+		Message: fmt.Sprintf("Recipient %s is on the suppression list (%s) and cannot be messaged", suppression.Recipient, suppression.Reason),
+	}, domain.ErrRecipientSuppressed
+}
+
+// formatRecipient validates the recipient of a message and returns its JID. A
+// group JID (ending in @g.us) is passed through; anything else must be a phone
+// number, optionally already suffixed with @s.whatsapp.net.
+func (s *messageService) formatRecipient(to string) (string, error) {
+	to = strings.TrimSpace(to)
+	if strings.HasSuffix(to, "@g.us") {
+		if !groupJIDPattern.MatchString(to) {
+			return "", fmt.Errorf("invalid group JID")
+		}
+		return to, nil
+	}
+	return s.formatPhoneNumber(strings.TrimSuffix(to, "@s.whatsapp.net"))
+}
+
 // formatPhoneNumber formats and validates phone number
 func (s *messageService) formatPhoneNumber(phone string) (string, error) {
 	phone = strings.TrimSpace(phone)
@@ -2,10 +2,16 @@ package application
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/eventbus"
 	"github.com/wa-serv/internal/domain"
 	"github.com/wa-serv/internal/mocks"
 )
@@ -42,6 +48,156 @@ func TestMessageService_SendMessage_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMessageService_SendMessage_ToGroup(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	req := &domain.SendMessageRequest{
+		To:      "120363025246125486@g.us",
+		Message: "Promo poin ganda akhir pekan ini!",
+	}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessage", mock.Anything, "120363025246125486@g.us", "Promo poin ganda akhir pekan ini!").
+		Return(&domain.Message{ID: "group-msg-1"}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "group-msg-1", response.ID)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_SplitsLongText(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	first := strings.Repeat("Poin ", 800)
+	second := strings.Repeat("Ganda ", 200)
+	req := &domain.SendMessageRequest{
+		To:      "6281234567890",
+		Message: first + "\n\n" + second,
+	}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessage", mock.Anything, "6281234567890@s.whatsapp.net", strings.TrimSpace(first)).
+		Return(&domain.Message{ID: "part-1"}, nil).Once()
+	mockRepo.On("SendMessage", mock.Anything, "6281234567890@s.whatsapp.net", second).
+		Return(&domain.Message{ID: "part-2"}, nil).Once()
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "Message sent successfully as 2 messages", response.Message)
+	assert.Equal(t, "part-1", response.ID)
+	assert.Equal(t, []string{"part-1", "part-2"}, response.IDs)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_SplitPartlySent(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	req := &domain.SendMessageRequest{
+		To:      "6281234567890",
+		Message: strings.Repeat("Poin ", 1000),
+	}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessage", mock.Anything, "6281234567890@s.whatsapp.net", mock.Anything).
+		Return(&domain.Message{ID: "part-1"}, nil).Once()
+	mockRepo.On("SendMessage", mock.Anything, "6281234567890@s.whatsapp.net", mock.Anything).
+		Return(nil, errors.New("stream error")).Once()
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrMessagePartlySent)
+	assert.False(t, response.Success)
+	assert.Contains(t, response.Message, "part 2 of 2")
+	assert.Equal(t, []string{"part-1"}, response.IDs)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_Suppressed(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockSuppressions := &mocks.MockSuppressionRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, nil, nil, mockSuppressions, nil)
+
+	mockRepo.On("IsConnected").Return(true)
+	mockSuppressions.On("GetSuppression", "6281234567890").
+		Return(&domain.Suppression{Recipient: "6281234567890", Reason: domain.SuppressionOptedOut}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), &domain.SendMessageRequest{To: "+6281234567890", Message: "Promo"})
+
+	// Assert
+	assert.Equal(t, domain.ErrRecipientSuppressed, err)
+	assert.False(t, response.Success)
+	assert.Contains(t, response.Message, "opted_out")
+	mockRepo.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageService_SendMessage_NotSuppressed(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockSuppressions := &mocks.MockSuppressionRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, nil, nil, mockSuppressions, nil)
+
+	mockRepo.On("IsConnected").Return(true)
+	mockSuppressions.On("GetSuppression", "6281234567890").Return(nil, domain.ErrSuppressionNotFound)
+	mockRepo.On("SendMessage", mock.Anything, "6281234567890@s.whatsapp.net", "Promo").Return(&domain.Message{ID: "msg-1"}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), &domain.SendMessageRequest{To: "6281234567890", Message: "Promo"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", response.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_PublishesMessageSent(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	var sent []eventbus.Event
+	eventbus.Subscribe(eventbus.MessageSent, func(evt eventbus.Event) { sent = append(sent, evt) })
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessageFrom", mock.Anything, "sender1", "1234567890@s.whatsapp.net", "Hi").
+		Return(&domain.Message{ID: "msg-1"}, nil)
+
+	// Act
+	_, err := service.SendMessage(context.Background(), &domain.SendMessageRequest{To: "+1234567890", Message: "Hi", From: "sender1"})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, sent, 1) {
+		assert.Equal(t, map[string]any{
+			"kind":       "text",
+			"to":         "1234567890@s.whatsapp.net",
+			"sender_id":  "sender1",
+			"message_id": "msg-1",
+		}, sent[0].Data)
+	}
+}
+
 func TestMessageService_SendMessage_NotConnected(t *testing.T) {
 	// Arrange
 	mockRepo := &mocks.MockWhatsAppRepository{}
@@ -109,6 +265,47 @@ func TestMessageService_SendMessage_EmptyRequest(t *testing.T) {
 	assert.Contains(t, response.Message, "phone number is required")
 }
 
+func TestMessageService_SendMessage_DryRun(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	req := &domain.SendMessageRequest{
+		To:      "+1234567890",
+		Message: "Test message",
+		DryRun:  true,
+	}
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Empty(t, response.ID)
+	mockRepo.AssertNotCalled(t, "IsConnected")
+	mockRepo.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageService_SendMessage_DryRunInvalidPhone(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	req := &domain.SendMessageRequest{
+		To:      "123",
+		Message: "Test message",
+		DryRun:  true,
+	}
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.Equal(t, domain.ErrInvalidPhoneNumber, err)
+	assert.False(t, response.Success)
+}
+
 func TestMessageService_GetStatus_Success(t *testing.T) {
 	// Arrange
 	mockRepo := &mocks.MockWhatsAppRepository{}
@@ -126,10 +323,81 @@ func TestMessageService_GetStatus_Success(t *testing.T) {
 	assert.True(t, status.WhatsApp.Connected)
 	assert.True(t, status.WhatsApp.LoggedIn)
 	assert.Equal(t, "test@s.whatsapp.net", status.WhatsApp.JID)
+	assert.Equal(t, domain.WhatsAppStatusOK, status.WhatsApp.Status)
+	assert.Empty(t, status.WhatsApp.Error)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMessageService_GetStatus_Degraded(t *testing.T) {
+	tests := []struct {
+		name          string
+		connected     bool
+		loggedIn      bool
+		jid           string
+		expectedError string
+	}{
+		{name: "no sender paired", connected: false, loggedIn: false, jid: "", expectedError: "no sender is logged in"},
+		{name: "sender disconnected", connected: false, loggedIn: true, jid: "test@s.whatsapp.net", expectedError: "no sender is connected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			mockRepo.On("IsConnected").Return(tt.connected)
+			mockRepo.On("IsLoggedIn").Return(tt.loggedIn)
+			mockRepo.On("GetJID").Return(tt.jid)
+
+			// Act
+			status, err := service.GetStatus(context.Background())
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, domain.WhatsAppStatusDegraded, status.WhatsApp.Status)
+			assert.Equal(t, tt.expectedError, status.WhatsApp.Error)
+			assert.Equal(t, tt.jid, status.WhatsApp.JID)
+		})
+	}
+}
+
+func TestMessageService_FormatRecipient(t *testing.T) {
+	service := &messageService{whatsappRepo: &mocks.MockWhatsAppRepository{}}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		hasError bool
+	}{
+		{"phone number", "+62 812-3456-7890", "6281234567890@s.whatsapp.net", false},
+		{"user JID", "6281234567890@s.whatsapp.net", "6281234567890@s.whatsapp.net", false},
+		{"group JID", "120363025246125486@g.us", "120363025246125486@g.us", false},
+		{"legacy group JID", " 6281234567890-1596701234@g.us ", "6281234567890-1596701234@g.us", false},
+		{"group JID with letters", "promo@g.us", "", true},
+		{"group JID with empty part", "6281234567890-@g.us", "", true},
+		{"other server", "6281234567890@broadcast", "", true},
+		{"too short phone", "123", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			result, err := service.formatRecipient(tt.input)
+
+			// Assert
+			if tt.hasError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestMessageService_FormatPhoneNumber(t *testing.T) {
 	// Arrange
 	mockRepo := &mocks.MockWhatsAppRepository{}
@@ -241,3 +509,1058 @@ func TestMessageService_SendMessage_WithSender_NotFound(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestMessageService_SendMessage_WithSender_Paused(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	req := &domain.SendMessageRequest{
+		To:      "+1234567890",
+		Message: "Test message",
+		From:    "sender123",
+	}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessageFrom", mock.Anything, "sender123", "1234567890@s.whatsapp.net", "Test message").
+		Return(nil, fmt.Errorf("sender not found or not initialized: sender123: %w", domain.ErrSenderPaused))
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.Equal(t, domain.ErrSenderPaused, err)
+	assert.False(t, response.Success)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_FallbackChain(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockChains := &mocks.MockSenderChainRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, mockChains, nil, nil, nil)
+
+	req := &domain.SendMessageRequest{
+		To:       "+1234567890",
+		Message:  "Test message",
+		Category: "transactional",
+	}
+
+	mockChains.On("GetChain", "transactional").Return([]string{"6281111", "6282222"}, nil)
+	mockRepo.On("SendMessageFrom", mock.Anything, "6281111", "1234567890@s.whatsapp.net", "Test message").Return(nil, assert.AnError)
+	mockRepo.On("SendMessageFrom", mock.Anything, "6282222", "1234567890@s.whatsapp.net", "Test message").Return(&domain.Message{ID: "msg-2"}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "msg-2", response.ID)
+	assert.Equal(t, "6282222", response.SenderID)
+	mockRepo.AssertNotCalled(t, "IsConnected")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_FallbackChainExhausted(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockChains := &mocks.MockSenderChainRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, mockChains, nil, nil, nil)
+
+	req := &domain.SendMessageRequest{To: "+1234567890", Message: "Test message"}
+
+	mockChains.On("GetChain", domain.DefaultMessageCategory).Return([]string{"6281111"}, nil)
+	mockRepo.On("SendMessageFrom", mock.Anything, "6281111", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.Equal(t, domain.ErrMessageSendFailed, err)
+	assert.False(t, response.Success)
+	assert.Contains(t, response.Message, "6281111")
+}
+
+func TestMessageService_SendMessage_NoChainUsesDefaultSender(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockChains := &mocks.MockSenderChainRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, mockChains, nil, nil, nil)
+
+	req := &domain.SendMessageRequest{To: "+1234567890", Message: "Test message", Category: "promo"}
+
+	mockChains.On("GetChain", "promo").Return(nil, nil)
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessage", mock.Anything, "1234567890@s.whatsapp.net", "Test message").Return(&domain.Message{ID: "msg-1"}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", response.ID)
+	assert.Empty(t, response.SenderID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_PoolTakesOverOfflineDefault(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockChains := &mocks.MockSenderChainRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, mockChains, []string{"6281111", "6282222"}, nil, nil)
+
+	req := &domain.SendMessageRequest{To: "+1234567890", Message: "Test message"}
+
+	mockChains.On("GetChain", domain.DefaultMessageCategory).Return(nil, nil)
+	mockRepo.On("IsSenderConnected", "").Return(false)
+	mockRepo.On("IsSenderConnected", "6281111").Return(false)
+	mockRepo.On("IsSenderConnected", "6282222").Return(true)
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessageFrom", mock.Anything, "6282222", "1234567890@s.whatsapp.net", "Test message").
+		Return(&domain.Message{ID: "msg-1"}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "6282222", response.SenderID)
+	assert.True(t, response.Fallback)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_PoolUnusedWhileSenderConnected(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockChains := &mocks.MockSenderChainRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, mockChains, []string{"6282222"}, nil, nil)
+
+	req := &domain.SendMessageRequest{To: "+1234567890", Message: "Test message", From: "6281111"}
+
+	mockRepo.On("IsSenderConnected", "6281111").Return(true)
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessageFrom", mock.Anything, "6281111", "1234567890@s.whatsapp.net", "Test message").
+		Return(&domain.Message{ID: "msg-1"}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "6281111", response.SenderID)
+	assert.False(t, response.Fallback)
+	mockRepo.AssertNotCalled(t, "IsSenderConnected", "6282222")
+}
+
+func TestMessageService_SendMessage_PoolAllOfflineSendsFromRequested(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockChains := &mocks.MockSenderChainRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, mockChains, []string{"6281111", "6282222"}, nil, nil)
+
+	req := &domain.SendMessageRequest{To: "+1234567890", Message: "Test message", From: "6281111"}
+
+	mockRepo.On("IsSenderConnected", mock.Anything).Return(false)
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessageFrom", mock.Anything, "6281111", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.Equal(t, domain.ErrMessageSendFailed, err)
+	assert.False(t, response.Success)
+	assert.False(t, response.Fallback)
+}
+
+func TestMessageService_SendImage_PoolTakesOverOfflineSender(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, nil, []string{"6282222"}, nil, nil)
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	req := &domain.SendImageRequest{
+		To:    "+6281234567890",
+		Image: base64.StdEncoding.EncodeToString(png),
+		From:  "6281111",
+	}
+
+	mockRepo.On("IsSenderConnected", "6281111").Return(false)
+	mockRepo.On("IsSenderConnected", "6282222").Return(true)
+	mockRepo.On("SendImage", mock.Anything, "6282222", "6281234567890@s.whatsapp.net", png, "image/png", "").
+		Return(&domain.Message{ID: "img-1"}, nil)
+
+	// Act
+	response, err := service.SendImage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "6282222", response.SenderID)
+	assert.True(t, response.Fallback)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_PoolSendsFromHealthyMember(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockPools := &mocks.MockSenderPoolRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, nil, nil, nil, mockPools)
+
+	req := &domain.SendMessageRequest{To: "+1234567890", Message: "Test message", Pool: " Sales "}
+
+	mockPools.On("GetPool", "sales").Return([]string{"6281111", "6282222"}, nil)
+	mockRepo.On("PickSender", []string{"6281111", "6282222"}).Return("6282222", nil)
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendMessageFrom", mock.Anything, "6282222", "1234567890@s.whatsapp.net", "Test message").
+		Return(&domain.Message{ID: "msg-1"}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "6282222", response.SenderID)
+	assert.Empty(t, req.From, "the caller's request is left as it was")
+	mockRepo.AssertExpectations(t)
+	mockPools.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_PoolErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		members []string
+		pickErr error
+		wantErr error
+	}{
+		{"pool and sender", "6281111", nil, nil, domain.ErrInvalidSenderPool},
+		{"unknown pool", "", nil, nil, domain.ErrSenderPoolNotFound},
+		{"no healthy member", "", []string{"6281111"}, domain.ErrNoActiveSender, domain.ErrNoActiveSender},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			mockPools := &mocks.MockSenderPoolRepository{}
+			service := NewMessageServiceWithFallback(mockRepo, nil, nil, nil, mockPools)
+			mockPools.On("GetPool", "support").Return(tt.members, nil)
+			mockRepo.On("PickSender", tt.members).Return("", tt.pickErr)
+
+			// Act
+			response, err := service.SendMessage(context.Background(), &domain.SendMessageRequest{
+				To: "+1234567890", Message: "Test message", From: tt.from, Pool: "support",
+			})
+
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			assert.False(t, response.Success)
+			mockRepo.AssertNotCalled(t, "SendMessageFrom", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMessageService_ListSenders_IncludesFallbackPositions(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	mockChains := &mocks.MockSenderChainRepository{}
+	service := NewMessageServiceWithFallback(mockRepo, mockChains, nil, nil, nil)
+
+	mockRepo.On("ListSenders").Return([]*domain.Sender{{ID: "6281111"}, {ID: "6283333"}}, nil)
+	mockChains.On("ListChains").Return(map[string][]string{
+		"transactional": {"6282222", "6281111"},
+	}, nil)
+
+	// Act
+	senders, err := service.ListSenders(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"transactional": 2}, senders[0].FallbackChains)
+	assert.Nil(t, senders[1].FallbackChains)
+}
+
+func TestMessageService_SendImage_Base64(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	req := &domain.SendImageRequest{
+		To:      "+6281234567890",
+		Image:   "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+		Caption: " Promo minggu ini ",
+	}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendImage", mock.Anything, "", "6281234567890@s.whatsapp.net", png, "image/png", "Promo minggu ini").
+		Return(&domain.Message{ID: "img-1"}, nil)
+
+	// Act
+	response, err := service.SendImage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "img-1", response.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendImage_FromSenderSkipsDefaultCheck(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	jpeg := []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+	req := &domain.SendImageRequest{To: "+6281234567890", From: "6289999", Data: jpeg}
+
+	mockRepo.On("SendImage", mock.Anything, "6289999", "6281234567890@s.whatsapp.net", jpeg, "image/jpeg", "").
+		Return(&domain.Message{ID: "img-2"}, nil)
+
+	// Act
+	response, err := service.SendImage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "img-2", response.ID)
+	mockRepo.AssertNotCalled(t, "IsConnected")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendImage_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.SendImageRequest
+		want error
+	}{
+		{"invalid phone", &domain.SendImageRequest{To: "123", Image: "aGVsbG8="}, domain.ErrInvalidPhoneNumber},
+		{"missing image", &domain.SendImageRequest{To: "+6281234567890"}, domain.ErrInvalidImage},
+		{"not base64", &domain.SendImageRequest{To: "+6281234567890", Image: "%%%"}, domain.ErrInvalidImage},
+		{"not an image", &domain.SendImageRequest{To: "+6281234567890", Data: []byte("%PDF-1.7")}, domain.ErrInvalidImage},
+		{"too large", &domain.SendImageRequest{To: "+6281234567890", Data: make([]byte, maxImageBytes+1)}, domain.ErrInvalidImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.SendImage(context.Background(), tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+			mockRepo.AssertNotCalled(t, "SendImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMessageService_SendDocument_DetectsMimeTypeFromFileName(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	sheet := []byte("PK\x03\x04statement")
+	req := &domain.SendDocumentRequest{
+		To:       "+6281234567890",
+		Document: base64.StdEncoding.EncodeToString(sheet),
+		FileName: "statement-2026-09.xlsx",
+		Caption:  "Laporan poin September",
+	}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendDocument", mock.Anything, "", "6281234567890@s.whatsapp.net", sheet, "statement-2026-09.xlsx",
+		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "Laporan poin September").
+		Return(&domain.Message{ID: "doc-1"}, nil)
+
+	// Act
+	response, err := service.SendDocument(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "doc-1", response.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendDocument_ExplicitMimeTypeAndSender(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	pdf := []byte("%PDF-1.7 invoice")
+	req := &domain.SendDocumentRequest{
+		To:       "+6281234567890",
+		Data:     pdf,
+		FileName: "invoice-0042.pdf",
+		MimeType: "application/pdf",
+		From:     "6289999",
+	}
+
+	mockRepo.On("SendDocument", mock.Anything, "6289999", "6281234567890@s.whatsapp.net", pdf, "invoice-0042.pdf", "application/pdf", "").
+		Return(&domain.Message{ID: "doc-2"}, nil)
+
+	// Act
+	response, err := service.SendDocument(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "doc-2", response.ID)
+	mockRepo.AssertNotCalled(t, "IsConnected")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendDocument_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.SendDocumentRequest
+		want error
+	}{
+		{"invalid phone", &domain.SendDocumentRequest{To: "123", Document: "aGVsbG8=", FileName: "a.pdf"}, domain.ErrInvalidPhoneNumber},
+		{"missing filename", &domain.SendDocumentRequest{To: "+6281234567890", Document: "aGVsbG8="}, domain.ErrInvalidDocument},
+		{"missing document", &domain.SendDocumentRequest{To: "+6281234567890", FileName: "a.pdf"}, domain.ErrInvalidDocument},
+		{"not base64", &domain.SendDocumentRequest{To: "+6281234567890", Document: "%%%", FileName: "a.pdf"}, domain.ErrInvalidDocument},
+		{"bad mimetype", &domain.SendDocumentRequest{To: "+6281234567890", Data: []byte("x"), FileName: "a.pdf", MimeType: "pdf"}, domain.ErrInvalidDocument},
+		{"too large", &domain.SendDocumentRequest{To: "+6281234567890", Data: make([]byte, maxDocumentBytes+1), FileName: "a.pdf"}, domain.ErrInvalidDocument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.SendDocument(context.Background(), tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+		})
+	}
+}
+
+func TestMessageService_SendAudio_VoiceNote(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	ogg := []byte("OggS\x00\x02OpusHead")
+	req := &domain.SendAudioRequest{To: "+6281234567890", Audio: base64.StdEncoding.EncodeToString(ogg), PTT: true}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendAudio", mock.Anything, "", "6281234567890@s.whatsapp.net", ogg, "audio/ogg; codecs=opus", true).
+		Return(&domain.Message{ID: "ptt-1"}, nil)
+
+	// Act
+	response, err := service.SendAudio(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "ptt-1", response.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendAudio_MP3(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	mp3 := []byte("\xff\xfb\x90\x64\x00")
+	req := &domain.SendAudioRequest{To: "+6281234567890", Data: mp3, From: "6289999"}
+
+	mockRepo.On("SendAudio", mock.Anything, "6289999", "6281234567890@s.whatsapp.net", mp3, "audio/mpeg", false).
+		Return(&domain.Message{ID: "aud-1"}, nil)
+
+	// Act
+	response, err := service.SendAudio(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "aud-1", response.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendAudio_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.SendAudioRequest
+		want error
+	}{
+		{"invalid phone", &domain.SendAudioRequest{To: "123", Data: []byte("OggS")}, domain.ErrInvalidPhoneNumber},
+		{"missing audio", &domain.SendAudioRequest{To: "+6281234567890"}, domain.ErrInvalidAudio},
+		{"unsupported type", &domain.SendAudioRequest{To: "+6281234567890", Data: []byte("RIFF....WAVE")}, domain.ErrInvalidAudio},
+		{"mp3 voice note", &domain.SendAudioRequest{To: "+6281234567890", Data: []byte("ID3\x04"), PTT: true}, domain.ErrInvalidAudio},
+		{"too large", &domain.SendAudioRequest{To: "+6281234567890", Data: append([]byte("OggS"), make([]byte, maxAudioBytes)...)}, domain.ErrInvalidAudio},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.SendAudio(context.Background(), tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+		})
+	}
+}
+
+func TestMessageService_SendLocation_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	lat, lng := -6.2088, 106.8456
+	req := &domain.SendLocationRequest{To: "+6281234567890", Latitude: &lat, Longitude: &lng, Name: " Laundry Bersih ", Address: "Jl. Mawar 5"}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendLocation", mock.Anything, "", "6281234567890@s.whatsapp.net", lat, lng, "Laundry Bersih", "Jl. Mawar 5").
+		Return(&domain.Message{ID: "loc-1"}, nil)
+
+	// Act
+	response, err := service.SendLocation(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "loc-1", response.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendLocation_Invalid(t *testing.T) {
+	zero, lat, lng, tooFar := 0.0, -6.2, 106.8, 181.0
+	tests := []struct {
+		name string
+		req  *domain.SendLocationRequest
+		want error
+	}{
+		{"missing coordinates", &domain.SendLocationRequest{To: "+6281234567890"}, domain.ErrInvalidLocation},
+		{"missing longitude", &domain.SendLocationRequest{To: "+6281234567890", Latitude: &lat}, domain.ErrInvalidLocation},
+		{"longitude out of range", &domain.SendLocationRequest{To: "+6281234567890", Latitude: &lat, Longitude: &tooFar}, domain.ErrInvalidLocation},
+		{"invalid phone", &domain.SendLocationRequest{To: "123", Latitude: &zero, Longitude: &lng}, domain.ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.SendLocation(context.Background(), tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+		})
+	}
+}
+
+func TestMessageService_SendMessage_Reply(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *domain.SendMessageRequest
+		wantTo     string
+		wantSender string
+	}{
+		{
+			name:       "customer message",
+			req:        &domain.SendMessageRequest{To: "+6281234567890", Message: "Siap, kami jemput jam 3", ReplyTo: "3EB0C767D71A"},
+			wantTo:     "6281234567890@s.whatsapp.net",
+			wantSender: "6281234567890@s.whatsapp.net",
+		},
+		{
+			name:       "group message",
+			req:        &domain.SendMessageRequest{To: "120363025246125486@g.us", Message: "Siap, kami jemput jam 3", ReplyTo: "3EB0C767D71A", ReplyToSender: "6289876543210"},
+			wantTo:     "120363025246125486@g.us",
+			wantSender: "6289876543210@s.whatsapp.net",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			mockRepo.On("IsConnected").Return(true)
+			mockRepo.On("SendReply", mock.Anything, "", tt.wantTo, "Siap, kami jemput jam 3", tt.wantSender, "3EB0C767D71A").
+				Return(&domain.Message{ID: "reply-1"}, nil)
+
+			// Act
+			response, err := service.SendMessage(context.Background(), tt.req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.True(t, response.Success)
+			assert.Equal(t, "reply-1", response.ID)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageService_SendMessage_InvalidReply(t *testing.T) {
+	buttons := &domain.InteractiveMessage{Buttons: []domain.ReplyButton{{ID: "ya", Title: "Ya"}}}
+	tests := []struct {
+		name string
+		req  *domain.SendMessageRequest
+	}{
+		{"group without sender", &domain.SendMessageRequest{To: "120363025246125486@g.us", Message: "Halo", ReplyTo: "ABC"}},
+		{"sender without message", &domain.SendMessageRequest{To: "6281234567890", Message: "Halo", ReplyToSender: "6289876543210"}},
+		{"invalid sender", &domain.SendMessageRequest{To: "6281234567890", Message: "Halo", ReplyTo: "ABC", ReplyToSender: "123"}},
+		{"interactive", &domain.SendMessageRequest{To: "6281234567890", Message: "Halo", ReplyTo: "ABC", Interactive: buttons}},
+		{"dry run", &domain.SendMessageRequest{To: "120363025246125486@g.us", Message: "Halo", ReplyTo: "ABC", DryRun: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+			mockRepo.On("IsConnected").Return(true).Maybe()
+
+			response, err := service.SendMessage(context.Background(), tt.req)
+
+			assert.Equal(t, domain.ErrInvalidReply, err)
+			assert.False(t, response.Success)
+			mockRepo.AssertNotCalled(t, "SendReply", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMessageService_SendReaction(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *domain.SendReactionRequest
+		wantChat   string
+		wantSender string
+	}{
+		{
+			name:       "customer message",
+			req:        &domain.SendReactionRequest{MessageID: "3EB0C767D71A", ChatJID: "+6281234567890", Emoji: "👍"},
+			wantChat:   "6281234567890@s.whatsapp.net",
+			wantSender: "6281234567890@s.whatsapp.net",
+		},
+		{
+			name:       "group message",
+			req:        &domain.SendReactionRequest{MessageID: "3EB0C767D71A", ChatJID: "120363025246125486@g.us", Emoji: "👍🏽", Sender: "6289876543210"},
+			wantChat:   "120363025246125486@g.us",
+			wantSender: "6289876543210@s.whatsapp.net",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			mockRepo.On("IsConnected").Return(true)
+			mockRepo.On("SendReaction", mock.Anything, "", tt.wantChat, tt.wantSender, "3EB0C767D71A", tt.req.Emoji).
+				Return(&domain.Message{ID: "react-1"}, nil)
+
+			// Act
+			response, err := service.SendReaction(context.Background(), tt.req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.True(t, response.Success)
+			assert.Equal(t, "react-1", response.ID)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageService_SendReaction_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.SendReactionRequest
+		want error
+	}{
+		{"missing message ID", &domain.SendReactionRequest{ChatJID: "+6281234567890", Emoji: "👍"}, domain.ErrInvalidReaction},
+		{"text instead of emoji", &domain.SendReactionRequest{MessageID: "ABC", ChatJID: "+6281234567890", Emoji: "ok"}, domain.ErrInvalidReaction},
+		{"several emoji", &domain.SendReactionRequest{MessageID: "ABC", ChatJID: "+6281234567890", Emoji: "👍👍👍👍👍👍👍👍👍👍👍"}, domain.ErrInvalidReaction},
+		{"group without sender", &domain.SendReactionRequest{MessageID: "ABC", ChatJID: "120363025246125486@g.us", Emoji: "👍"}, domain.ErrInvalidReaction},
+		{"invalid chat", &domain.SendReactionRequest{MessageID: "ABC", ChatJID: "123", Emoji: "👍"}, domain.ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.SendReaction(context.Background(), tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+			mockRepo.AssertNotCalled(t, "SendReaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMessageService_SendReaction_Remove(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendReaction", mock.Anything, "", "6281234567890@s.whatsapp.net", "6281234567890@s.whatsapp.net", "ABC", "").
+		Return(&domain.Message{ID: "react-2"}, nil)
+
+	// Act
+	response, err := service.SendReaction(context.Background(), &domain.SendReactionRequest{MessageID: "ABC", ChatJID: "6281234567890"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Reaction removed successfully", response.Message)
+}
+
+func TestMessageService_RevokeMessage(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("RevokeMessage", mock.Anything, "", "6281234567890@s.whatsapp.net", "3EB0C767D71A").
+		Return(&domain.Message{ID: "revoke-1"}, nil)
+
+	// Act
+	response, err := service.RevokeMessage(context.Background(), &domain.RevokeMessageRequest{MessageID: "3EB0C767D71A", To: "6281234567890"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "3EB0C767D71A", response.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_RevokeMessage_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.RevokeMessageRequest
+		want error
+	}{
+		{"missing message ID", &domain.RevokeMessageRequest{To: "6281234567890"}, domain.ErrInvalidRevoke},
+		{"invalid chat", &domain.RevokeMessageRequest{MessageID: "ABC", To: "123"}, domain.ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.RevokeMessage(context.Background(), tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+			mockRepo.AssertNotCalled(t, "RevokeMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMessageService_EditMessage(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("EditMessage", mock.Anything, "", "6281234567890@s.whatsapp.net", "3EB0C767D71A", "Pesanan siap diambil jam 4").
+		Return(&domain.Message{ID: "3EB0C767D71A"}, nil)
+
+	// Act
+	response, err := service.EditMessage(context.Background(), &domain.EditMessageRequest{MessageID: "3EB0C767D71A", Message: "Pesanan siap diambil jam 4", To: "6281234567890"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "3EB0C767D71A", response.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_EditMessage_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.EditMessageRequest
+		want error
+	}{
+		{"missing message ID", &domain.EditMessageRequest{Message: "Halo", To: "6281234567890"}, domain.ErrInvalidEdit},
+		{"blank text", &domain.EditMessageRequest{MessageID: "ABC", Message: "  ", To: "6281234567890"}, domain.ErrInvalidEdit},
+		{"invalid chat", &domain.EditMessageRequest{MessageID: "ABC", Message: "Halo", To: "123"}, domain.ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.EditMessage(context.Background(), tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+			mockRepo.AssertNotCalled(t, "EditMessage", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMessageService_SendChatPresence(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendChatPresence", mock.Anything, "", "6281234567890@s.whatsapp.net", domain.ChatPresenceRecording).Return(nil)
+
+	// Act
+	response, err := service.SendChatPresence(context.Background(), &domain.SendChatPresenceRequest{ChatJID: "+6281234567890", State: " Recording "})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendChatPresence_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.SendChatPresenceRequest
+		want error
+	}{
+		{"unknown state", &domain.SendChatPresenceRequest{ChatJID: "+6281234567890", State: "online"}, domain.ErrInvalidPresence},
+		{"missing state", &domain.SendChatPresenceRequest{ChatJID: "+6281234567890"}, domain.ErrInvalidPresence},
+		{"invalid chat", &domain.SendChatPresenceRequest{ChatJID: "123", State: "typing"}, domain.ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.SendChatPresence(context.Background(), tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+			mockRepo.AssertNotCalled(t, "SendChatPresence", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMessageService_SetPresence(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("SetPresence", mock.Anything, "sender1", domain.PresenceUnavailable).Return(nil)
+
+	// Act
+	response, err := service.SetPresence(context.Background(), &domain.SetPresenceRequest{Presence: "unavailable", From: "sender1"})
+	_, invalidErr := service.SetPresence(context.Background(), &domain.SetPresenceRequest{Presence: "away"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, domain.ErrInvalidPresence, invalidErr)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SetDisappearing(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("SetDisappearingTimer", mock.Anything, "sender1", "6281234567890@s.whatsapp.net", 7*24*time.Hour).Return(nil)
+
+	// Act
+	response, err := service.SetDisappearing(context.Background(), &domain.SetDisappearingRequest{ChatJID: "+6281234567890", Timer: " 7D ", From: "sender1"})
+	_, invalidErr := service.SetDisappearing(context.Background(), &domain.SetDisappearingRequest{ChatJID: "+6281234567890", Timer: "1h"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "Messages now disappear after 7d", response.Message)
+	assert.Equal(t, domain.ErrInvalidDisappearing, invalidErr)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_DisappearAfter(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	req := &domain.SendMessageRequest{To: "+6281234567890", Message: "Kode penukaran: XK42", DisappearAfter: "24h"}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SetDisappearingTimer", mock.Anything, "", "6281234567890@s.whatsapp.net", 24*time.Hour).Return(nil).Once()
+	mockRepo.On("SendMessage", mock.Anything, "6281234567890@s.whatsapp.net", "Kode penukaran: XK42").
+		Return(&domain.Message{ID: "msg-1"}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_DisappearAfterInvalid(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	req := &domain.SendMessageRequest{To: "+6281234567890", Message: "Kode penukaran: XK42", DisappearAfter: "1h", DryRun: true}
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.Equal(t, domain.ErrInvalidDisappearing, err)
+	assert.False(t, response.Success)
+	mockRepo.AssertNotCalled(t, "SetDisappearingTimer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageService_SendMessage_DisappearAfterFails(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	req := &domain.SendMessageRequest{To: "+6281234567890", Message: "Kode penukaran: XK42", DisappearAfter: "24h"}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SetDisappearingTimer", mock.Anything, "", "6281234567890@s.whatsapp.net", 24*time.Hour).Return(errors.New("timeout"))
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.False(t, response.Success)
+	mockRepo.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageService_SendContact_BuildsVCards(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	req := &domain.SendContactRequest{
+		To: "+6281234567890",
+		Contacts: []domain.ContactCard{
+			{Name: "Admin Laundry", Phone: "+62 811-2222-3333", Organization: "Laundry Bersih; Cabang 1"},
+			{Name: "Kurir", Phone: "6281344445555"},
+		},
+	}
+
+	var sent []domain.ContactCard
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendContacts", mock.Anything, "", "6281234567890@s.whatsapp.net", mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.Get(3).([]domain.ContactCard) }).
+		Return(&domain.Message{ID: "vcard-1"}, nil)
+
+	// Act
+	response, err := service.SendContact(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Len(t, sent, 2)
+	assert.Equal(t, "+6281122223333", sent[0].Phone)
+	assert.Equal(t, "BEGIN:VCARD\nVERSION:3.0\nFN:Admin Laundry\nORG:Laundry Bersih\\; Cabang 1\n"+
+		"TEL;type=CELL;type=VOICE;waid=6281122223333:+6281122223333\nEND:VCARD", sent[0].VCard)
+	assert.Contains(t, sent[1].VCard, "waid=6281344445555:+6281344445555")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendContact_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.SendContactRequest
+		want error
+	}{
+		{"no contacts", &domain.SendContactRequest{To: "+6281234567890"}, domain.ErrInvalidContact},
+		{"too many contacts", &domain.SendContactRequest{To: "+6281234567890", Contacts: make([]domain.ContactCard, maxContactsPerMessage+1)}, domain.ErrInvalidContact},
+		{"missing name", &domain.SendContactRequest{To: "+6281234567890", Contacts: []domain.ContactCard{{Phone: "6281122223333"}}}, domain.ErrInvalidContact},
+		{"bad contact phone", &domain.SendContactRequest{To: "+6281234567890", Contacts: []domain.ContactCard{{Name: "Admin", Phone: "12ab"}}}, domain.ErrInvalidContact},
+		{"bad recipient", &domain.SendContactRequest{To: "123", Contacts: []domain.ContactCard{{Name: "Admin", Phone: "6281122223333"}}}, domain.ErrInvalidPhoneNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.SendContact(context.Background(), tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+		})
+	}
+}
+
+func TestMessageService_SendMessage_Interactive(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockWhatsAppRepository{}
+	service := NewMessageService(mockRepo)
+
+	interactive := &domain.InteractiveMessage{
+		Footer: "Laundry Bersih",
+		Buttons: []domain.ReplyButton{
+			{ID: "poin", Title: "Cek Poin"},
+			{ID: "menu", Title: "Menu"},
+		},
+	}
+	req := &domain.SendMessageRequest{To: "+6281234567890", Message: "Halo! Pilih menu:", Interactive: interactive}
+
+	mockRepo.On("IsConnected").Return(true)
+	mockRepo.On("SendInteractive", mock.Anything, "", "6281234567890@s.whatsapp.net", "Halo! Pilih menu:", interactive).
+		Return(&domain.Message{ID: "btn-1"}, nil)
+
+	// Act
+	response, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, "btn-1", response.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SendMessage_InvalidInteractive(t *testing.T) {
+	row := func(id string) domain.ListRow { return domain.ListRow{ID: id, Title: "Row " + id} }
+	rows := make([]domain.ListRow, maxListRows+1)
+	for i := range rows {
+		rows[i] = row(string(rune('a' + i)))
+	}
+
+	tests := []struct {
+		name        string
+		interactive *domain.InteractiveMessage
+	}{
+		{"neither buttons nor list", &domain.InteractiveMessage{Footer: "x"}},
+		{"both buttons and list", &domain.InteractiveMessage{
+			Buttons: []domain.ReplyButton{{ID: "a", Title: "A"}},
+			List:    &domain.ListMenu{Button: "Menu", Sections: []domain.ListSection{{Rows: []domain.ListRow{row("b")}}}},
+		}},
+		{"too many buttons", &domain.InteractiveMessage{Buttons: []domain.ReplyButton{
+			{ID: "a", Title: "A"}, {ID: "b", Title: "B"}, {ID: "c", Title: "C"}, {ID: "d", Title: "D"},
+		}}},
+		{"duplicate button id", &domain.InteractiveMessage{Buttons: []domain.ReplyButton{{ID: "a", Title: "A"}, {ID: "a", Title: "B"}}}},
+		{"button title too long", &domain.InteractiveMessage{Buttons: []domain.ReplyButton{{ID: "a", Title: "Tukarkan poin sekarang juga"}}}},
+		{"button without id", &domain.InteractiveMessage{Buttons: []domain.ReplyButton{{Title: "A"}}}},
+		{"list without button label", &domain.InteractiveMessage{List: &domain.ListMenu{Sections: []domain.ListSection{{Rows: []domain.ListRow{row("a")}}}}}},
+		{"list without rows", &domain.InteractiveMessage{List: &domain.ListMenu{Button: "Menu"}}},
+		{"too many rows", &domain.InteractiveMessage{List: &domain.ListMenu{Button: "Menu", Sections: []domain.ListSection{{Rows: rows}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockWhatsAppRepository{}
+			service := NewMessageService(mockRepo)
+
+			response, err := service.SendMessage(context.Background(), &domain.SendMessageRequest{
+				To: "+6281234567890", Message: "Pilih menu", Interactive: tt.interactive,
+			})
+
+			assert.Equal(t, domain.ErrInvalidInteractive, err)
+			assert.False(t, response.Success)
+			mockRepo.AssertNotCalled(t, "SendInteractive", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxTextLength is the longest text, in characters, sent as one WhatsApp
+// message; longer texts are split
+const maxTextLength = 4096
+
+// codeFence opens and closes a WhatsApp monospace block
+const codeFence = "```"
+
+// splitMessage splits text into parts of at most limit characters, cutting at
+// a blank line, a line break or a space when one is in the second half of the
+// part. A monospace block or bold, italic or strikethrough text that a cut
+// falls inside is closed at the end of the part and reopened in the next one,
+// so every part keeps its formatting. limit must leave room for that.
+func splitMessage(text string, limit int) []string {
+	if utf8.RuneCountInString(text) <= limit {
+		return []string{text}
+	}
+
+	// Line breaks opening the text would otherwise be cut off as an empty part
+	var parts []string
+	rest := []rune(strings.TrimLeftFunc(text, unicode.IsSpace))
+	for len(rest) > limit {
+		// Leave room to close a monospace block or the inline styles. A cut
+		// inside the markers opening rest would not shorten it.
+		cut := splitPoint(rest[:limit-len("\n"+codeFence)], leadingMarkers(rest))
+		part := strings.TrimRightFunc(string(rest[:cut]), unicode.IsSpace)
+		next := strings.TrimLeftFunc(string(rest[cut:]), unicode.IsSpace)
+
+		if strings.Count(part, codeFence)%2 == 1 {
+			part += "\n" + codeFence
+			if strings.HasPrefix(next, codeFence) {
+				// The block ends right at the cut, so its own fence closes it
+				next = strings.TrimLeftFunc(strings.TrimPrefix(next, codeFence), unicode.IsSpace)
+			} else {
+				next = codeFence + "\n" + next
+			}
+		} else if open := openStyles(part[strings.LastIndex(part, "\n")+1:]); len(open) > 0 {
+			closing := slices.Clone(open)
+			slices.Reverse(closing)
+			part += string(closing)
+			next = string(open) + next
+		}
+
+		parts = append(parts, part)
+		rest = []rune(next)
+	}
+	if len(rest) > 0 {
+		parts = append(parts, string(rest))
+	}
+	return parts
+}
+
+// splitPoint returns where to cut window: after its last blank line or line
+// break in its second half, else at its last space after its first skip
+// runes, else at its end
+func splitPoint(window []rune, skip int) int {
+	half := max(len(window)/2, skip)
+	for i := len(window) - 1; i > half; i-- {
+		if window[i] == '\n' && window[i-1] == '\n' {
+			return i
+		}
+	}
+	for i := len(window) - 1; i > half; i-- {
+		if window[i] == '\n' {
+			return i
+		}
+	}
+	for i := len(window) - 1; i > skip; i-- {
+		if unicode.IsSpace(window[i]) {
+			return i
+		}
+	}
+	return len(window)
+}
+
+// leadingMarkers returns the length of the monospace fence line or the bold,
+// italic and strikethrough markers text starts with
+func leadingMarkers(text []rune) int {
+	if strings.HasPrefix(string(text[:min(len(text), len(codeFence)+1)]), codeFence+"\n") {
+		return len(codeFence) + 1
+	}
+	n := 0
+	for n < len(text) && (text[n] == '*' || text[n] == '_' || text[n] == '~') {
+		n++
+	}
+	return n
+}
+
+// openStyles returns the bold (*), italic (_) and strikethrough (~) markers
+// line opens and does not close, in the order they were opened. As in
+// WhatsApp, a marker opens at the start of a word and closes at its end.
+func openStyles(line string) []rune {
+	runes := []rune(line)
+	var open []rune
+	for i, r := range runes {
+		if r != '*' && r != '_' && r != '~' {
+			continue
+		}
+		afterWord := i > 0 && !unicode.IsSpace(runes[i-1])
+		beforeWord := i+1 < len(runes) && !unicode.IsSpace(runes[i+1])
+		if j := slices.Index(open, r); j >= 0 && afterWord {
+			open = slices.Delete(open, j, j+1)
+		} else if beforeWord && (i == 0 || unicode.IsSpace(runes[i-1]) || unicode.IsPunct(runes[i-1])) {
+			open = append(open, r)
+		}
+	}
+	return open
+}
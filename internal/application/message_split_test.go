@@ -0,0 +1,89 @@
+package application
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitMessage_ShortTextIsOnePart(t *testing.T) {
+	assert.Equal(t, []string{"Halo, poin Anda 120"}, splitMessage("Halo, poin Anda 120", 40))
+}
+
+func TestSplitMessage_CutsAtWordBoundaries(t *testing.T) {
+	text := "Terima kasih sudah mencuci di Ruang Laundry minggu ini"
+
+	parts := splitMessage(text, 24)
+
+	assert.Equal(t, []string{"Terima kasih sudah", "mencuci di Ruang", "Laundry minggu ini"}, parts)
+	for _, part := range parts {
+		assert.LessOrEqual(t, utf8.RuneCountInString(part), 24)
+	}
+}
+
+func TestSplitMessage_PrefersParagraphs(t *testing.T) {
+	text := "Promo minggu ini:\n\nCuci kering 5 kg gratis setrika"
+
+	assert.Equal(t, []string{"Promo minggu ini:", "Cuci kering 5 kg gratis setrika"}, splitMessage(text, 36))
+}
+
+func TestSplitMessage_CutsLongWords(t *testing.T) {
+	parts := splitMessage(strings.Repeat("a", 25), 12)
+
+	assert.Equal(t, []string{"aaaaaaaa", "aaaaaaaa", "aaaaaaaaa"}, parts)
+}
+
+func TestSplitMessage_ReopensInlineStyles(t *testing.T) {
+	text := "Selamat *poin Anda sudah mencapai seratus* _terima kasih_"
+
+	assert.Equal(t, []string{"Selamat *poin Anda sudah*", "*mencapai seratus*", "_terima kasih_"}, splitMessage(text, 30))
+}
+
+func TestSplitMessage_IgnoresMarkersInsideWords(t *testing.T) {
+	text := "Kode promo_ganda_minggu berlaku sampai hari Minggu"
+
+	parts := splitMessage(text, 30)
+
+	assert.Equal(t, "Kode promo_ganda_minggu", parts[0])
+	assert.Equal(t, "berlaku sampai hari Minggu", parts[1])
+}
+
+func TestSplitMessage_ReopensCodeBlocks(t *testing.T) {
+	text := "Rincian:\n```\nCuci 5 kg   25000\nSetrika     10000\nTotal       35000\n```"
+
+	parts := splitMessage(text, 48)
+
+	assert.Equal(t, []string{
+		"Rincian:\n```\nCuci 5 kg   25000\n```",
+		"```\nSetrika     10000\nTotal       35000\n```",
+	}, parts)
+}
+
+func TestSplitMessage_CodeBlockLongerThanLimit(t *testing.T) {
+	text := "```\n" + strings.Repeat("x", 9000) + "\n```"
+
+	parts := splitMessage(text, maxTextLength)
+
+	assert.Len(t, parts, 3)
+	for _, part := range parts {
+		assert.LessOrEqual(t, utf8.RuneCountInString(part), maxTextLength)
+		assert.True(t, strings.HasPrefix(part, "```\n") && strings.HasSuffix(part, "\n```"))
+	}
+	assert.Equal(t, 9000, strings.Count(strings.Join(parts, ""), "x"))
+}
+
+func TestSplitMessage_LeadingLineBreaks(t *testing.T) {
+	text := "\n\n\n\n😀a~a```a😀``````" + strings.Repeat("a", 24)
+
+	parts := splitMessage(text, 20)
+
+	require.NotEmpty(t, parts)
+	assert.True(t, strings.HasPrefix(parts[0], "😀a~a"), "the first part starts at the text, not at an empty cut: %q", parts)
+	for _, part := range parts {
+		assert.NotEmpty(t, strings.TrimSpace(part))
+		assert.LessOrEqual(t, utf8.RuneCountInString(part), 20)
+	}
+}
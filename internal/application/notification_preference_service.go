@@ -0,0 +1,142 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/alerting"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type notificationPreferenceService struct {
+	db     *sql.DB
+	mailer domain.Mailer
+}
+
+// NewNotificationPreferenceService creates a service managing the alerts each
+// admin receives. mailer is nil when email is not configured, and the email
+// channel is then refused.
+func NewNotificationPreferenceService(db *sql.DB, mailer domain.Mailer) domain.NotificationPreferenceService {
+	return &notificationPreferenceService{db: db, mailer: mailer}
+}
+
+// ListPreferences returns the preferences of every admin who saved them
+func (s *notificationPreferenceService) ListPreferences(ctx context.Context) ([]*domain.NotificationPreferences, error) {
+	prefs, err := repository.ListNotificationPreferences(s.db)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.NotificationPreferences, len(prefs))
+	for i, p := range prefs {
+		result[i] = toDomainNotificationPreferences(p)
+	}
+	return result, nil
+}
+
+// GetPreferences returns the preferences of the admin with phone
+func (s *notificationPreferenceService) GetPreferences(ctx context.Context, phone string) (*domain.NotificationPreferences, error) {
+	pref, err := repository.GetNotificationPreference(s.db, normalizeBroadcastRecipient(phone))
+	if err != nil {
+		return nil, notificationPreferenceError(err)
+	}
+	return toDomainNotificationPreferences(*pref), nil
+}
+
+// SetPreferences replaces the alerts the admin with phone receives. Once saved,
+// they get only the alert types listed, over the channels listed, whether or
+// not the environment configures them for more.
+func (s *notificationPreferenceService) SetPreferences(ctx context.Context, phone string, req *domain.SetNotificationPreferencesRequest) (*domain.NotificationPreferences, error) {
+	pref, err := s.validatePreferences(phone, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := repository.ReplaceNotificationPreference(s.db, pref); err != nil {
+		return nil, err
+	}
+	return s.GetPreferences(ctx, pref.PhoneNumber)
+}
+
+// DeletePreferences forgets the preferences of the admin with phone, so they
+// get the alerts the environment configures for them on WhatsApp again
+func (s *notificationPreferenceService) DeletePreferences(ctx context.Context, phone string) error {
+	return notificationPreferenceError(repository.DeleteNotificationPreference(s.db, normalizeBroadcastRecipient(phone)))
+}
+
+// validatePreferences checks req and returns the preferences it sets for phone.
+// Repeated channels are dropped, as are alert types without channels.
+func (s *notificationPreferenceService) validatePreferences(phone string, req *domain.SetNotificationPreferencesRequest) (repository.NotificationPreference, error) {
+	if req == nil {
+		return repository.NotificationPreference{}, domain.ErrInvalidNotificationPreferences
+	}
+	pref := repository.NotificationPreference{
+		PhoneNumber: normalizeBroadcastRecipient(phone),
+		Email:       strings.TrimSpace(req.Email),
+		WebhookURL:  strings.TrimSpace(req.WebhookURL),
+		Alerts:      make(map[string][]string),
+	}
+	if !phoneNumberPattern.MatchString(pref.PhoneNumber) {
+		return repository.NotificationPreference{}, fmt.Errorf("%w: %q is not a phone number", domain.ErrInvalidNotificationPreferences, phone)
+	}
+	if pref.Email != "" {
+		address, err := mail.ParseAddress(pref.Email)
+		if err != nil || address.Address != pref.Email {
+			return repository.NotificationPreference{}, fmt.Errorf("%w: %q is not an email address", domain.ErrInvalidNotificationPreferences, pref.Email)
+		}
+	}
+	if pref.WebhookURL != "" {
+		u, err := url.Parse(pref.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return repository.NotificationPreference{}, fmt.Errorf("%w: webhook_url must be an http or https URL", domain.ErrInvalidNotificationPreferences)
+		}
+	}
+
+	for alertType, channels := range req.Alerts {
+		if !alerting.IsType(alertType) {
+			return repository.NotificationPreference{}, fmt.Errorf("%w: alert type must be one of %s", domain.ErrInvalidNotificationPreferences, strings.Join(alerting.Types, ", "))
+		}
+		for _, channel := range channels {
+			channel = strings.ToLower(strings.TrimSpace(channel))
+			switch {
+			case !alerting.IsChannel(channel):
+				return repository.NotificationPreference{}, fmt.Errorf("%w: channel must be one of %s", domain.ErrInvalidNotificationPreferences, strings.Join(alerting.Channels, ", "))
+			case channel == alerting.ChannelEmail && pref.Email == "":
+				return repository.NotificationPreference{}, fmt.Errorf("%w: the email channel needs an email", domain.ErrInvalidNotificationPreferences)
+			case channel == alerting.ChannelEmail && s.mailer == nil:
+				return repository.NotificationPreference{}, fmt.Errorf("%w: the email channel needs SMTP_HOST and SMTP_FROM", domain.ErrInvalidNotificationPreferences)
+			case channel == alerting.ChannelWebhook && pref.WebhookURL == "":
+				return repository.NotificationPreference{}, fmt.Errorf("%w: the webhook channel needs a webhook_url", domain.ErrInvalidNotificationPreferences)
+			}
+			if !slices.Contains(pref.Alerts[alertType], channel) {
+				pref.Alerts[alertType] = append(pref.Alerts[alertType], channel)
+			}
+		}
+	}
+	return pref, nil
+}
+
+// notificationPreferenceError reports an admin without saved preferences as
+// ErrNotificationPreferencesNotFound
+func notificationPreferenceError(err error) error {
+	if errors.Is(err, repository.ErrNotificationPreferenceNotFound) {
+		return domain.ErrNotificationPreferencesNotFound
+	}
+	return err
+}
+
+func toDomainNotificationPreferences(p repository.NotificationPreference) *domain.NotificationPreferences {
+	return &domain.NotificationPreferences{
+		PhoneNumber: p.PhoneNumber,
+		Email:       p.Email,
+		WebhookURL:  p.WebhookURL,
+		Alerts:      p.Alerts,
+		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
+	}
+}
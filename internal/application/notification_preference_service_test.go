@@ -0,0 +1,73 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/alerting"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestNotificationPreferenceService_ValidatePreferences(t *testing.T) {
+	// Arrange
+	service := NewNotificationPreferenceService(nil, &mocks.MockMailer{}).(*notificationPreferenceService)
+
+	// Act
+	pref, err := service.validatePreferences("+62 812-3456-7890", &domain.SetNotificationPreferencesRequest{
+		Email:      " ops@example.com ",
+		WebhookURL: "https://hooks.example.com/wa",
+		Alerts: map[string][]string{
+			alerting.SenderDown: {"whatsapp", "Email", "whatsapp"},
+			alerting.LowStock:   {"webhook"},
+			alerting.FraudFlag:  {},
+		},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "6281234567890", pref.PhoneNumber)
+	assert.Equal(t, "ops@example.com", pref.Email)
+	assert.Equal(t, map[string][]string{
+		alerting.SenderDown: {alerting.ChannelWhatsApp, alerting.ChannelEmail},
+		alerting.LowStock:   {alerting.ChannelWebhook},
+	}, pref.Alerts)
+}
+
+func TestNotificationPreferenceService_ValidatePreferences_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		phone  string
+		mailer domain.Mailer
+		req    *domain.SetNotificationPreferencesRequest
+	}{
+		{"bad phone", "admin", &mocks.MockMailer{}, &domain.SetNotificationPreferencesRequest{}},
+		{"bad email", "6281234567890", &mocks.MockMailer{}, &domain.SetNotificationPreferencesRequest{Email: "ops"}},
+		{"bad webhook URL", "6281234567890", &mocks.MockMailer{}, &domain.SetNotificationPreferencesRequest{WebhookURL: "ftp://hooks.example.com"}},
+		{"unknown alert type", "6281234567890", &mocks.MockMailer{}, &domain.SetNotificationPreferencesRequest{
+			Alerts: map[string][]string{"sender.down": {"whatsapp"}},
+		}},
+		{"unknown channel", "6281234567890", &mocks.MockMailer{}, &domain.SetNotificationPreferencesRequest{
+			Alerts: map[string][]string{alerting.LowStock: {"sms"}},
+		}},
+		{"email channel without email", "6281234567890", &mocks.MockMailer{}, &domain.SetNotificationPreferencesRequest{
+			Alerts: map[string][]string{alerting.LowStock: {"email"}},
+		}},
+		{"email channel without SMTP", "6281234567890", nil, &domain.SetNotificationPreferencesRequest{
+			Email:  "ops@example.com",
+			Alerts: map[string][]string{alerting.LowStock: {"email"}},
+		}},
+		{"webhook channel without URL", "6281234567890", &mocks.MockMailer{}, &domain.SetNotificationPreferencesRequest{
+			Alerts: map[string][]string{alerting.FailedCampaign: {"webhook"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewNotificationPreferenceService(nil, tt.mailer).(*notificationPreferenceService)
+			_, err := service.validatePreferences(tt.phone, tt.req)
+			assert.ErrorIs(t, err, domain.ErrInvalidNotificationPreferences)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/pricing"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/receipt"
+	"github.com/wa-serv/repository"
+)
+
+type orderService struct {
+	db           *sql.DB
+	whatsappRepo domain.WhatsAppRepository
+	letterhead   receipt.Business
+}
+
+// NewOrderService creates a service for confirming the orders members place in
+// chat and sending their receipts, printed with the letterhead in receiptCfg
+func NewOrderService(db *sql.DB, whatsappRepo domain.WhatsAppRepository, receiptCfg config.ReceiptConfig) domain.OrderService {
+	return &orderService{
+		db:           db,
+		whatsappRepo: whatsappRepo,
+		letterhead: receipt.Business{
+			Name:    receiptCfg.BusinessName,
+			Address: receiptCfg.Address,
+			Phone:   receiptCfg.Phone,
+			Footer:  receiptCfg.Footer,
+		},
+	}
+}
+
+// ListDraftOrders returns the orders awaiting staff confirmation, oldest first
+func (s *orderService) ListDraftOrders(ctx context.Context) ([]*domain.DraftOrder, error) {
+	orders, err := repository.GetDraftOrders(s.db)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.DraftOrder, 0, len(orders))
+	for _, o := range orders {
+		result = append(result, toDomainDraftOrder(o))
+	}
+	return result, nil
+}
+
+// ConfirmOrder moves a draft order to pending and tells the member it was accepted
+func (s *orderService) ConfirmOrder(ctx context.Context, orderID int) (*domain.ConfirmOrderResponse, error) {
+	if orderID <= 0 {
+		return &domain.ConfirmOrderResponse{
+			Success: false,
+			Message: fmt.Sprintf("Order %d not found", orderID),
+		}, domain.ErrOrderNotFound
+	}
+
+	order, err := repository.ConfirmDraftOrder(s.db, orderID)
+	switch err {
+	case nil:
+	case repository.ErrOrderNotFound:
+		return &domain.ConfirmOrderResponse{
+			Success: false,
+			Message: fmt.Sprintf("Order %d not found", orderID),
+		}, domain.ErrOrderNotFound
+	case repository.ErrOrderNotDraft:
+		return &domain.ConfirmOrderResponse{
+			Success: false,
+			Message: fmt.Sprintf("Order %d is not awaiting confirmation", orderID),
+		}, domain.ErrOrderNotDraft
+	default:
+		return &domain.ConfirmOrderResponse{Success: false, Message: "Failed to confirm order"}, err
+	}
+
+	eventbus.Publish(eventbus.OrderConfirmed, withRequestID(ctx, map[string]any{
+		"order_id":     orderID,
+		"phone_number": order.PhoneNumber,
+		"service":      order.ItemName,
+	}))
+
+	// The confirmation stands even if the notification fails, so the caller can retry the message
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	message, err := s.whatsappRepo.SendMessage(sendCtx, order.PhoneNumber, buildOrderConfirmedMessage(order))
+	if err != nil {
+		return &domain.ConfirmOrderResponse{
+			Success: false,
+			Message: "Order confirmed but notification failed: " + err.Error(),
+			OrderID: orderID,
+			Status:  repository.OrderStatusPending,
+		}, domain.ErrMessageSendFailed
+	}
+
+	return &domain.ConfirmOrderResponse{
+		Success:   true,
+		Message:   "Order confirmed and member notified",
+		OrderID:   orderID,
+		Status:    repository.OrderStatusPending,
+		MessageID: message.ID,
+	}, nil
+}
+
+// SendReceipt renders an order's receipt as a PDF and sends it to the member
+// as a document from the default sender
+func (s *orderService) SendReceipt(ctx context.Context, orderID int) (*domain.SendReceiptResponse, error) {
+	notFound := &domain.SendReceiptResponse{Success: false, Message: fmt.Sprintf("Order %d not found", orderID)}
+	if orderID <= 0 {
+		return notFound, domain.ErrOrderNotFound
+	}
+
+	order, err := repository.GetOrderReceipt(s.db, orderID)
+	switch err {
+	case nil:
+	case repository.ErrOrderNotFound:
+		return notFound, domain.ErrOrderNotFound
+	default:
+		return &domain.SendReceiptResponse{Success: false, Message: "Failed to load order"}, err
+	}
+	if order.PhoneNumber == "" {
+		return &domain.SendReceiptResponse{
+			Success: false,
+			Message: fmt.Sprintf("Order %d has no member phone number to send the receipt to", orderID),
+			OrderID: orderID,
+		}, domain.ErrOrderMemberNoPhone
+	}
+
+	r := buildReceipt(s.letterhead, order)
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	caption := fmt.Sprintf("Kwitansi pesanan #%d - %s", orderID, pricing.FormatRupiah(order.TotalPrice))
+	message, err := s.whatsappRepo.SendDocument(sendCtx, "", order.PhoneNumber, receipt.PDF(r), r.FileName(), "application/pdf", caption)
+	if err != nil {
+		return &domain.SendReceiptResponse{
+			Success: false,
+			Message: "Failed to send receipt: " + err.Error(),
+			OrderID: orderID,
+		}, domain.ErrMessageSendFailed
+	}
+
+	return &domain.SendReceiptResponse{
+		Success:   true,
+		Message:   "Receipt sent to the member",
+		OrderID:   orderID,
+		FileName:  r.FileName(),
+		MessageID: message.ID,
+	}, nil
+}
+
+// buildReceipt lays an order out as a receipt under letterhead
+func buildReceipt(letterhead receipt.Business, order *repository.OrderReceipt) receipt.Receipt {
+	r := receipt.Receipt{
+		Business:    letterhead,
+		OrderID:     order.OrderID,
+		Date:        order.OrderDate,
+		MemberName:  order.MemberName,
+		PhoneNumber: order.PhoneNumber,
+		Total:       order.TotalPrice,
+	}
+	for _, line := range order.Lines {
+		r.Lines = append(r.Lines, receipt.Line{Name: line.ItemName, Kilos: line.Kilos, Units: line.Units, Price: line.Price})
+	}
+	return r
+}
+
+// buildOrderConfirmedMessage formats the WhatsApp message telling a member
+// their order was accepted
+func buildOrderConfirmedMessage(order *repository.DraftOrder) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "✅ *Pesanan #%d Dikonfirmasi*\n\n", order.OrderID)
+	fmt.Fprintf(&b, "Layanan: %s\n", order.ItemName)
+	fmt.Fprintf(&b, "Estimasi harga: %s\n", pricing.FormatRupiah(order.TotalPrice))
+	if order.PickupStartsAt.Valid && order.PickupEndsAt.Valid {
+		fmt.Fprintf(&b, "Penjemputan: %s\n", processor.FormatPickupWindow(order.PickupStartsAt.Time, order.PickupEndsAt.Time))
+	} else {
+		b.WriteString("Silakan antar cucian Anda ke outlet kami.\n")
+	}
+	b.WriteString("\nTerima kasih telah memesan!")
+	return b.String()
+}
+
+func toDomainDraftOrder(o repository.DraftOrder) *domain.DraftOrder {
+	order := &domain.DraftOrder{
+		ID:             o.OrderID,
+		MemberName:     o.MemberName,
+		PhoneNumber:    o.PhoneNumber,
+		Service:        o.ItemName,
+		Kilos:          o.Kilos,
+		Units:          o.Units,
+		EstimatedPrice: o.TotalPrice,
+		CreatedAt:      o.CreatedAt.Format(time.RFC3339),
+	}
+	if o.PickupStartsAt.Valid && o.PickupEndsAt.Valid {
+		order.PickupStartsAt = o.PickupStartsAt.Time.Format(time.RFC3339)
+		order.PickupEndsAt = o.PickupEndsAt.Time.Format(time.RFC3339)
+	}
+	return order
+}
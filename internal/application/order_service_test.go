@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/receipt"
+	"github.com/wa-serv/repository"
+)
+
+func TestOrderService_ConfirmOrder_InvalidID(t *testing.T) {
+	service := NewOrderService(nil, &mocks.MockWhatsAppRepository{}, config.ReceiptConfig{})
+
+	response, err := service.ConfirmOrder(context.Background(), 0)
+	assert.Equal(t, domain.ErrOrderNotFound, err)
+	assert.False(t, response.Success)
+}
+
+func TestOrderService_SendReceipt_InvalidID(t *testing.T) {
+	whatsappRepo := &mocks.MockWhatsAppRepository{}
+	service := NewOrderService(nil, whatsappRepo, config.ReceiptConfig{})
+
+	response, err := service.SendReceipt(context.Background(), -1)
+	assert.Equal(t, domain.ErrOrderNotFound, err)
+	assert.False(t, response.Success)
+	whatsappRepo.AssertNotCalled(t, "SendDocument")
+}
+
+func TestBuildReceipt(t *testing.T) {
+	ordered := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	r := buildReceipt(receipt.Business{Name: "Laundry Bersih"}, &repository.OrderReceipt{
+		OrderID:     42,
+		MemberName:  "Siti",
+		PhoneNumber: "6281234567890",
+		TotalPrice:  94500,
+		OrderDate:   ordered,
+		Lines: []repository.OrderReceiptLine{
+			{ItemName: "Cuci Kering", Kilos: 3.5, Price: 24500},
+			{ItemName: "Bed Cover", Units: 2, Price: 70000},
+		},
+	})
+
+	assert.Equal(t, "Laundry Bersih", r.Business.Name)
+	assert.Equal(t, ordered, r.Date)
+	assert.Equal(t, 94500.0, r.Total)
+	assert.Equal(t, []receipt.Line{
+		{Name: "Cuci Kering", Kilos: 3.5, Price: 24500},
+		{Name: "Bed Cover", Units: 2, Price: 70000},
+	}, r.Lines)
+}
+
+func TestBuildOrderConfirmedMessage(t *testing.T) {
+	starts := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	order := &repository.DraftOrder{
+		OrderID:        42,
+		ItemName:       "Cuci Kering",
+		TotalPrice:     24500,
+		PickupStartsAt: sql.NullTime{Time: starts, Valid: true},
+		PickupEndsAt:   sql.NullTime{Time: starts.Add(2 * time.Hour), Valid: true},
+	}
+
+	msg := buildOrderConfirmedMessage(order)
+	assert.Contains(t, msg, "#42")
+	assert.Contains(t, msg, "Cuci Kering")
+	assert.Contains(t, msg, "Rp24.500")
+	assert.Contains(t, msg, "Sab 17/10 09:00-11:00")
+
+	order.PickupStartsAt, order.PickupEndsAt = sql.NullTime{}, sql.NullTime{}
+	assert.Contains(t, buildOrderConfirmedMessage(order), "antar cucian Anda ke outlet")
+}
+
+func TestToDomainDraftOrder(t *testing.T) {
+	created := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	order := toDomainDraftOrder(repository.DraftOrder{
+		OrderID:     42,
+		MemberName:  "Siti",
+		PhoneNumber: "6281234567890",
+		ItemName:    "Cuci Kering",
+		Kilos:       3.5,
+		TotalPrice:  24500,
+		CreatedAt:   created,
+	})
+
+	assert.Equal(t, 42, order.ID)
+	assert.Equal(t, "Cuci Kering", order.Service)
+	assert.Equal(t, 24500.0, order.EstimatedPrice)
+	assert.Empty(t, order.PickupStartsAt)
+	assert.Equal(t, "2026-10-16T08:00:00Z", order.CreatedAt)
+}
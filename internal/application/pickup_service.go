@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type pickupService struct {
+	db *sql.DB
+}
+
+// NewPickupService creates a new pickup scheduling service
+func NewPickupService(db *sql.DB) domain.PickupService {
+	return &pickupService{db: db}
+}
+
+// CreatePickupSlot opens a pickup window that members can book over WhatsApp
+func (s *pickupService) CreatePickupSlot(ctx context.Context, req *domain.CreatePickupSlotRequest) (*domain.PickupSlot, error) {
+	startsAt, endsAt, err := validateCreatePickupSlotRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	slotID, err := repository.CreatePickupSlot(s.db, startsAt, endsAt, req.Capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PickupSlot{
+		ID:       slotID,
+		StartsAt: startsAt.Format(time.RFC3339),
+		EndsAt:   endsAt.Format(time.RFC3339),
+		Capacity: req.Capacity,
+	}, nil
+}
+
+// GetSchedule returns every booked pickup for the given day (YYYY-MM-DD, server time zone)
+func (s *pickupService) GetSchedule(ctx context.Context, date string) (*domain.PickupSchedule, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, domain.ErrInvalidDate
+	}
+
+	bookings, err := repository.GetPickupSchedule(s.db, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	pickups := make([]*domain.PickupScheduleEntry, 0, len(bookings))
+	for _, b := range bookings {
+		pickups = append(pickups, &domain.PickupScheduleEntry{
+			BookingID:   b.BookingID,
+			SlotID:      b.SlotID,
+			StartsAt:    b.StartsAt.Format(time.RFC3339),
+			EndsAt:      b.EndsAt.Format(time.RFC3339),
+			MemberName:  b.MemberName,
+			PhoneNumber: b.PhoneNumber,
+			Address:     b.Address,
+		})
+	}
+
+	return &domain.PickupSchedule{
+		Date:    day.Format("2006-01-02"),
+		Count:   len(pickups),
+		Pickups: pickups,
+	}, nil
+}
+
+// validateCreatePickupSlotRequest parses the slot window and converts it to the
+// server time zone, which is how pickup_slots timestamps are stored
+func validateCreatePickupSlotRequest(req *domain.CreatePickupSlotRequest) (time.Time, time.Time, error) {
+	if req == nil || req.Capacity <= 0 {
+		return time.Time{}, time.Time{}, domain.ErrInvalidPickupSlot
+	}
+	startsAt, err := time.Parse(time.RFC3339, req.StartsAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, domain.ErrInvalidPickupSlot
+	}
+	endsAt, err := time.Parse(time.RFC3339, req.EndsAt)
+	if err != nil || !endsAt.After(startsAt) {
+		return time.Time{}, time.Time{}, domain.ErrInvalidPickupSlot
+	}
+	return startsAt.In(time.Local), endsAt.In(time.Local), nil
+}
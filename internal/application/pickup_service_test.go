@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+)
+
+func TestValidateCreatePickupSlotRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *domain.CreatePickupSlotRequest
+		wantErr bool
+	}{
+		{"valid", &domain.CreatePickupSlotRequest{StartsAt: "2026-10-17T09:00:00+07:00", EndsAt: "2026-10-17T11:00:00+07:00", Capacity: 5}, false},
+		{"nil request", nil, true},
+		{"zero capacity", &domain.CreatePickupSlotRequest{StartsAt: "2026-10-17T09:00:00+07:00", EndsAt: "2026-10-17T11:00:00+07:00"}, true},
+		{"bad start", &domain.CreatePickupSlotRequest{StartsAt: "tomorrow", EndsAt: "2026-10-17T11:00:00+07:00", Capacity: 5}, true},
+		{"end before start", &domain.CreatePickupSlotRequest{StartsAt: "2026-10-17T11:00:00+07:00", EndsAt: "2026-10-17T09:00:00+07:00", Capacity: 5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := validateCreatePickupSlotRequest(tt.req)
+			if tt.wantErr {
+				assert.Equal(t, domain.ErrInvalidPickupSlot, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPickupService_GetSchedule_InvalidDate(t *testing.T) {
+	service := NewPickupService(nil)
+
+	schedule, err := service.GetSchedule(context.Background(), "17/10/2026")
+
+	assert.Nil(t, schedule)
+	assert.Equal(t, domain.ErrInvalidDate, err)
+}
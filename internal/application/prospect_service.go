@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+)
+
+type prospectService struct {
+	db *sql.DB
+}
+
+// NewProspectService creates a new prospect (lead) tracking service
+func NewProspectService(db *sql.DB) domain.ProspectService {
+	return &prospectService{db: db}
+}
+
+// ListProspects returns unregistered contacts, most recently active first
+func (s *prospectService) ListProspects(ctx context.Context, includeConverted bool) ([]*domain.Prospect, error) {
+	prospects, err := repository.ListProspects(s.db, includeConverted)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.Prospect, 0, len(prospects))
+	for _, p := range prospects {
+		result = append(result, toDomainProspect(p))
+	}
+	return result, nil
+}
+
+// ConvertProspect registers a prospect as a member, e.g. after the owner
+// collected their details by phone
+func (s *prospectService) ConvertProspect(ctx context.Context, phoneNumber string, req *domain.ConvertProspectRequest) (*domain.ConvertProspectResponse, error) {
+	phone := cleanPhoneNumber(phoneNumber)
+	if len(phone) < 10 {
+		return &domain.ConvertProspectResponse{Success: false, Message: "Invalid phone number"}, domain.ErrInvalidPhoneNumber
+	}
+	if req == nil || strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Address) == "" {
+		return &domain.ConvertProspectResponse{Success: false, Message: "Name and address are required"}, domain.ErrInvalidProspect
+	}
+	name := strings.TrimSpace(req.Name)
+	address := strings.TrimSpace(req.Address)
+
+	memberID, err := repository.ConvertProspect(s.db, phone, name, address)
+	switch err {
+	case nil:
+	case repository.ErrProspectNotFound:
+		return &domain.ConvertProspectResponse{Success: false, Message: "Prospect not found"}, domain.ErrProspectNotFound
+	case repository.ErrProspectConverted, repository.ErrMemberExists:
+		return &domain.ConvertProspectResponse{Success: false, Message: "Prospect is already a member"}, domain.ErrProspectConverted
+	default:
+		return &domain.ConvertProspectResponse{Success: false, Message: "Failed to convert prospect"}, err
+	}
+
+	eventbus.Publish(eventbus.MemberRegistered, withRequestID(ctx, map[string]any{
+		"phone_number": phone,
+		"name":         name,
+		"address":      address,
+		"tier":         processor.TierForPoints(0),
+		"source":       "prospect",
+	}))
+
+	return &domain.ConvertProspectResponse{
+		Success:     true,
+		Message:     "Prospect registered as member",
+		PhoneNumber: phone,
+		MemberID:    memberID,
+	}, nil
+}
+
+func toDomainProspect(p repository.Prospect) *domain.Prospect {
+	prospect := &domain.Prospect{
+		PhoneNumber:    p.PhoneNumber,
+		PushName:       p.PushName,
+		FirstContactAt: p.FirstSeenAt.Format(time.RFC3339),
+		LastContactAt:  p.LastSeenAt.Format(time.RFC3339),
+		MessageCount:   p.MessageCount,
+		LastIntent:     p.LastIntent,
+	}
+	if p.ConvertedMemberID.Valid {
+		prospect.ConvertedMemberID = int(p.ConvertedMemberID.Int64)
+	}
+	if p.ConvertedAt.Valid {
+		prospect.ConvertedAt = p.ConvertedAt.Time.Format(time.RFC3339)
+	}
+	return prospect
+}
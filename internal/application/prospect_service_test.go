@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+)
+
+func TestProspectService_ConvertProspect_Validation(t *testing.T) {
+	service := NewProspectService(nil)
+
+	tests := []struct {
+		name  string
+		phone string
+		req   *domain.ConvertProspectRequest
+		want  error
+	}{
+		{"short phone", "12345", &domain.ConvertProspectRequest{Name: "Budi", Address: "Jl. Merdeka 1"}, domain.ErrInvalidPhoneNumber},
+		{"missing request", "6281234567890", nil, domain.ErrInvalidProspect},
+		{"blank name", "6281234567890", &domain.ConvertProspectRequest{Name: " ", Address: "Jl. Merdeka 1"}, domain.ErrInvalidProspect},
+		{"blank address", "+62 812-3456-7890", &domain.ConvertProspectRequest{Name: "Budi"}, domain.ErrInvalidProspect},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := service.ConvertProspect(context.Background(), tt.phone, tt.req)
+
+			assert.Equal(t, tt.want, err)
+			assert.False(t, response.Success)
+		})
+	}
+}
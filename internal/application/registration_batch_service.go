@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/requestid"
+)
+
+// registrationBatchRetention is how long a bulk registration stays queryable.
+// Its sessions expire long before.
+const registrationBatchRetention = 24 * time.Hour
+
+type registrationBatch struct {
+	batch     domain.RegistrationBatch
+	createdAt time.Time
+	poll      sync.Mutex // one poll of the sessions at a time, since a finished session is read once
+}
+
+type registrationBatchService struct {
+	registrations domain.SenderRegistrationService
+	maxNumbers    int
+
+	mu      sync.RWMutex
+	batches map[string]*registrationBatch
+}
+
+// NewRegistrationBatchService creates a service that registers up to
+// maxNumbers senders at once through registrations. Batches are kept in
+// memory, like the registration sessions they track.
+func NewRegistrationBatchService(registrations domain.SenderRegistrationService, maxNumbers int) domain.RegistrationBatchService {
+	return &registrationBatchService{
+		registrations: registrations,
+		maxNumbers:    maxNumbers,
+		batches:       make(map[string]*registrationBatch),
+	}
+}
+
+// StartRegistrationBatch requests a pairing code for each number in the
+// background, one after another. Invalid numbers fail right away and
+// duplicates are dropped. The returned batch is a snapshot; poll
+// GetRegistrationBatch for the codes and progress.
+func (s *registrationBatchService) StartRegistrationBatch(ctx context.Context, req *domain.RegisterSendersRequest) (*domain.RegistrationBatch, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidSenderBatch
+	}
+
+	seen := make(map[string]bool, len(req.PhoneNumbers))
+	var entries []domain.RegistrationBatchEntry
+	for _, phone := range req.PhoneNumbers {
+		phone = cleanPhoneNumber(phone)
+		if phone == "" || seen[phone] {
+			continue
+		}
+		seen[phone] = true
+		entry := domain.RegistrationBatchEntry{PhoneNumber: phone, Status: domain.RegistrationQueued}
+		if len(phone) < 10 || len(phone) > 15 {
+			entry.Status = domain.RegistrationFailed
+			entry.Error = domain.ErrInvalidPhoneNumber.Error()
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, domain.ErrInvalidSenderBatch
+	}
+	if len(entries) > s.maxNumbers {
+		return nil, domain.ErrSenderBatchTooLarge
+	}
+
+	now := time.Now()
+	batch := &registrationBatch{
+		createdAt: now,
+		batch: domain.RegistrationBatch{
+			ID:        uuid.New().String(),
+			CreatedAt: now.Format(time.RFC3339),
+			Numbers:   entries,
+		},
+	}
+
+	s.mu.Lock()
+	s.cleanupLocked()
+	s.batches[batch.batch.ID] = batch
+	s.mu.Unlock()
+
+	// The request context ends with the HTTP response, so the pairing codes are
+	// requested on their own while keeping the request ID for the webhooks
+	go s.run(requestid.NewContext(context.Background(), requestid.FromContext(ctx)), batch)
+
+	return s.snapshot(batch), nil
+}
+
+// GetRegistrationBatch checks the registration session of every number still
+// waiting to pair and returns the batch's progress. Like the
+// register-sender-status endpoint, this completes the registration of the
+// numbers that paired.
+func (s *registrationBatchService) GetRegistrationBatch(ctx context.Context, id string) (*domain.RegistrationBatch, error) {
+	s.mu.RLock()
+	batch, ok := s.batches[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, domain.ErrSenderBatchNotFound
+	}
+
+	batch.poll.Lock()
+	defer batch.poll.Unlock()
+
+	for i := range batch.batch.Numbers {
+		s.mu.RLock()
+		entry := batch.batch.Numbers[i]
+		s.mu.RUnlock()
+		if entry.Status != domain.RegistrationPending {
+			continue
+		}
+
+		status, err := s.registrations.GetRegistrationStatus(ctx, entry.SessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		s.mu.Lock()
+		entry = batch.batch.Numbers[i]
+		switch status.Status {
+		case "connected":
+			entry.Status = domain.RegistrationConnected
+			entry.SenderID = status.SenderID
+			entry.PairingCode = ""
+		case "failed":
+			entry.Status = domain.RegistrationFailed
+			entry.Error = status.Message
+			entry.PairingCode = ""
+		case "not_found":
+			entry.Status = domain.RegistrationFailed
+			entry.Error = "registration session expired before the pairing code was entered"
+			entry.PairingCode = ""
+		}
+		batch.batch.Numbers[i] = entry
+		s.mu.Unlock()
+	}
+
+	return s.snapshot(batch), nil
+}
+
+func (s *registrationBatchService) run(ctx context.Context, batch *registrationBatch) {
+	for i := range batch.batch.Numbers {
+		s.mu.RLock()
+		entry := batch.batch.Numbers[i]
+		s.mu.RUnlock()
+		if entry.Status != domain.RegistrationQueued {
+			continue
+		}
+
+		resp, err := s.registrations.StartCodeRegistration(ctx, &domain.RegisterSenderCodeRequest{PhoneNumber: entry.PhoneNumber})
+
+		s.mu.Lock()
+		entry = batch.batch.Numbers[i]
+		switch {
+		case err != nil:
+			entry.Status = domain.RegistrationFailed
+			entry.Error = err.Error()
+			if resp != nil && resp.Message != "" {
+				entry.Error = resp.Message
+			}
+		default:
+			entry.Status = domain.RegistrationPending
+			entry.SessionID = resp.SessionID
+			entry.PairingCode = resp.PairingCode
+		}
+		batch.batch.Numbers[i] = entry
+		s.mu.Unlock()
+	}
+}
+
+// snapshot copies batch and counts its numbers by status
+func (s *registrationBatchService) snapshot(batch *registrationBatch) *domain.RegistrationBatch {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	copied := batch.batch
+	copied.Numbers = append([]domain.RegistrationBatchEntry(nil), batch.batch.Numbers...)
+	copied.Total = len(copied.Numbers)
+	for _, entry := range copied.Numbers {
+		switch entry.Status {
+		case domain.RegistrationQueued:
+			copied.Queued++
+		case domain.RegistrationPending:
+			copied.Pending++
+		case domain.RegistrationConnected:
+			copied.Connected++
+		case domain.RegistrationFailed:
+			copied.Failed++
+		}
+	}
+	copied.Status = domain.RegistrationBatchCompleted
+	if copied.Queued+copied.Pending > 0 {
+		copied.Status = domain.RegistrationBatchRunning
+	}
+	return &copied
+}
+
+// cleanupLocked forgets batches started more than registrationBatchRetention ago
+func (s *registrationBatchService) cleanupLocked() {
+	cutoff := time.Now().Add(-registrationBatchRetention)
+	for id, batch := range s.batches {
+		if batch.createdAt.Before(cutoff) {
+			delete(s.batches, id)
+		}
+	}
+}
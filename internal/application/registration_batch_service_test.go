@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+// waitForPairingCodes polls until every number of the batch has left the queue
+func waitForPairingCodes(t *testing.T, service domain.RegistrationBatchService, id string) *domain.RegistrationBatch {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		batch, err := service.GetRegistrationBatch(context.Background(), id)
+		require.NoError(t, err)
+		if batch.Queued == 0 {
+			return batch
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("registration batch %s did not request every pairing code", id)
+	return nil
+}
+
+func codeRequest(phone string) any {
+	return mock.MatchedBy(func(req *domain.RegisterSenderCodeRequest) bool { return req.PhoneNumber == phone })
+}
+
+func TestRegistrationBatchService_StartRegistrationBatch(t *testing.T) {
+	// Arrange
+	mockRegistrations := &mocks.MockSenderRegistrationService{}
+	service := NewRegistrationBatchService(mockRegistrations, 10)
+
+	mockRegistrations.On("StartCodeRegistration", mock.Anything, codeRequest("6281111111111")).
+		Return(&domain.RegisterSenderCodeResponse{Success: true, SessionID: "s-1", PairingCode: "ABCD-1234"}, nil)
+	mockRegistrations.On("StartCodeRegistration", mock.Anything, codeRequest("6282222222222")).
+		Return(&domain.RegisterSenderCodeResponse{Success: false, Message: "Failed to request pairing code: rate limited"}, errors.New("rate limited"))
+	mockRegistrations.On("GetRegistrationStatus", mock.Anything, "s-1").
+		Return(&domain.RegistrationStatusResponse{Success: true, Status: "pending"}, nil)
+
+	// Act
+	batch, err := service.StartRegistrationBatch(context.Background(), &domain.RegisterSendersRequest{
+		PhoneNumbers: []string{"+62 811-1111-1111", "6282222222222", "6281111111111", "123"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, batch.Total)
+	assert.Equal(t, domain.RegistrationBatchRunning, batch.Status)
+	assert.Equal(t, domain.RegistrationFailed, batch.Numbers[2].Status)
+	assert.Equal(t, domain.ErrInvalidPhoneNumber.Error(), batch.Numbers[2].Error)
+
+	progress := waitForPairingCodes(t, service, batch.ID)
+	assert.Equal(t, 1, progress.Pending)
+	assert.Equal(t, 2, progress.Failed)
+	assert.Equal(t, "ABCD-1234", progress.Numbers[0].PairingCode)
+	assert.Equal(t, "s-1", progress.Numbers[0].SessionID)
+	assert.Equal(t, "Failed to request pairing code: rate limited", progress.Numbers[1].Error)
+	mockRegistrations.AssertNumberOfCalls(t, "StartCodeRegistration", 2)
+}
+
+func TestRegistrationBatchService_GetRegistrationBatch_TracksSessions(t *testing.T) {
+	// Arrange
+	mockRegistrations := &mocks.MockSenderRegistrationService{}
+	service := NewRegistrationBatchService(mockRegistrations, 10)
+
+	mockRegistrations.On("StartCodeRegistration", mock.Anything, codeRequest("6281111111111")).
+		Return(&domain.RegisterSenderCodeResponse{Success: true, SessionID: "s-1", PairingCode: "ABCD-1234"}, nil)
+	mockRegistrations.On("StartCodeRegistration", mock.Anything, codeRequest("6282222222222")).
+		Return(&domain.RegisterSenderCodeResponse{Success: true, SessionID: "s-2", PairingCode: "EFGH-5678"}, nil)
+	waiting := mockRegistrations.On("GetRegistrationStatus", mock.Anything, mock.Anything).
+		Return(&domain.RegistrationStatusResponse{Success: true, Status: "pending"}, nil)
+
+	batch, err := service.StartRegistrationBatch(context.Background(), &domain.RegisterSendersRequest{
+		PhoneNumbers: []string{"6281111111111", "6282222222222"},
+	})
+	require.NoError(t, err)
+	pending := waitForPairingCodes(t, service, batch.ID)
+
+	waiting.Unset()
+	mockRegistrations.On("GetRegistrationStatus", mock.Anything, "s-1").
+		Return(&domain.RegistrationStatusResponse{Success: true, Status: "connected", SenderID: "6281111111111"}, nil).Once()
+	mockRegistrations.On("GetRegistrationStatus", mock.Anything, "s-2").
+		Return(&domain.RegistrationStatusResponse{Success: false, Status: "not_found"}, nil).Once()
+
+	// Act
+	done, err := service.GetRegistrationBatch(context.Background(), batch.ID)
+	require.NoError(t, err)
+	again, err := service.GetRegistrationBatch(context.Background(), batch.ID)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, pending.Pending)
+	assert.Equal(t, domain.RegistrationBatchCompleted, done.Status)
+	assert.Equal(t, 1, done.Connected)
+	assert.Equal(t, "6281111111111", done.Numbers[0].SenderID)
+	assert.Empty(t, done.Numbers[0].PairingCode)
+	assert.Equal(t, domain.RegistrationFailed, done.Numbers[1].Status)
+	assert.Contains(t, done.Numbers[1].Error, "expired")
+	assert.Equal(t, done, again) // finished sessions are not checked again
+	mockRegistrations.AssertExpectations(t)
+}
+
+func TestRegistrationBatchService_StartRegistrationBatch_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *domain.RegisterSendersRequest
+		wantErr error
+	}{
+		{"nil request", nil, domain.ErrInvalidSenderBatch},
+		{"no numbers", &domain.RegisterSendersRequest{PhoneNumbers: []string{" ", "+"}}, domain.ErrInvalidSenderBatch},
+		{"too many numbers", &domain.RegisterSendersRequest{PhoneNumbers: []string{"6281111111111", "6282222222222", "6283333333333"}}, domain.ErrSenderBatchTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRegistrations := &mocks.MockSenderRegistrationService{}
+			service := NewRegistrationBatchService(mockRegistrations, 2)
+
+			batch, err := service.StartRegistrationBatch(context.Background(), tt.req)
+
+			assert.Nil(t, batch)
+			assert.Equal(t, tt.wantErr, err)
+			mockRegistrations.AssertNotCalled(t, "StartCodeRegistration", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRegistrationBatchService_GetRegistrationBatch_NotFound(t *testing.T) {
+	service := NewRegistrationBatchService(&mocks.MockSenderRegistrationService{}, 10)
+
+	batch, err := service.GetRegistrationBatch(context.Background(), "missing")
+
+	assert.Nil(t, batch)
+	assert.Equal(t, domain.ErrSenderBatchNotFound, err)
+}
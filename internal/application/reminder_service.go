@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type reminderService struct {
+	db *sql.DB
+}
+
+// NewReminderService creates a new reminder rule service
+func NewReminderService(db *sql.DB) domain.ReminderService {
+	return &reminderService{db: db}
+}
+
+// CreateRule adds an active reminder rule; it is picked up on the next scheduler tick
+func (s *reminderService) CreateRule(ctx context.Context, req *domain.CreateReminderRuleRequest) (*domain.ReminderRule, error) {
+	if err := validateCreateReminderRuleRequest(req); err != nil {
+		return nil, err
+	}
+
+	rule := repository.ReminderRule{
+		Name:          strings.TrimSpace(req.Name),
+		TriggerType:   req.Trigger,
+		DelayHours:    req.DelayHours,
+		RepeatHours:   req.RepeatHours,
+		MaxReminders:  req.MaxReminders,
+		EscalateAfter: req.EscalateAfter,
+		Message:       req.Message,
+		IsActive:      true,
+	}
+	if rule.RepeatHours == 0 {
+		rule.RepeatHours = 24
+	}
+	if rule.MaxReminders == 0 {
+		rule.MaxReminders = 1
+	}
+
+	ruleID, err := repository.CreateReminderRule(s.db, rule)
+	if err != nil {
+		return nil, err
+	}
+	rule.RuleID = ruleID
+	return toDomainReminderRule(rule), nil
+}
+
+// ListRules returns all reminder rules, active or not
+func (s *reminderService) ListRules(ctx context.Context) ([]*domain.ReminderRule, error) {
+	rules, err := repository.GetReminderRules(s.db, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.ReminderRule, 0, len(rules))
+	for _, r := range rules {
+		result = append(result, toDomainReminderRule(r))
+	}
+	return result, nil
+}
+
+// ClaimRedemption marks a redeemed reward as handed over, which stops its reminders
+func (s *reminderService) ClaimRedemption(ctx context.Context, transactionID int) error {
+	if transactionID <= 0 {
+		return domain.ErrRedemptionNotFound
+	}
+	claimed, err := repository.MarkRedemptionClaimed(s.db, transactionID)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return domain.ErrRedemptionNotFound
+	}
+	return nil
+}
+
+// validateCreateReminderRuleRequest validates the reminder rule request
+func validateCreateReminderRuleRequest(req *domain.CreateReminderRuleRequest) error {
+	if req == nil || strings.TrimSpace(req.Name) == "" || strings.TrimSpace(req.Message) == "" {
+		return domain.ErrInvalidReminderRule
+	}
+	switch req.Trigger {
+	case repository.ReminderTriggerOrderReady, repository.ReminderTriggerRewardUnclaimed:
+	default:
+		return domain.ErrInvalidReminderRule
+	}
+	if req.DelayHours < 0 || req.RepeatHours < 0 || req.MaxReminders < 0 || req.EscalateAfter < 0 {
+		return domain.ErrInvalidReminderRule
+	}
+	return nil
+}
+
+func toDomainReminderRule(r repository.ReminderRule) *domain.ReminderRule {
+	return &domain.ReminderRule{
+		ID:            r.RuleID,
+		Name:          r.Name,
+		Trigger:       r.TriggerType,
+		DelayHours:    r.DelayHours,
+		RepeatHours:   r.RepeatHours,
+		MaxReminders:  r.MaxReminders,
+		EscalateAfter: r.EscalateAfter,
+		Message:       r.Message,
+		IsActive:      r.IsActive,
+	}
+}
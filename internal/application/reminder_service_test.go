@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+)
+
+func TestValidateCreateReminderRuleRequest(t *testing.T) {
+	valid := func() *domain.CreateReminderRuleRequest {
+		return &domain.CreateReminderRuleRequest{
+			Name:       "reward-3d",
+			Trigger:    "reward_unclaimed",
+			DelayHours: 72,
+			Message:    "Hadiah {{detail}} belum diambil",
+		}
+	}
+
+	assert.NoError(t, validateCreateReminderRuleRequest(valid()))
+
+	tests := []struct {
+		name   string
+		mutate func(*domain.CreateReminderRuleRequest)
+	}{
+		{"missing name", func(r *domain.CreateReminderRuleRequest) { r.Name = " " }},
+		{"missing message", func(r *domain.CreateReminderRuleRequest) { r.Message = "" }},
+		{"unknown trigger", func(r *domain.CreateReminderRuleRequest) { r.Trigger = "birthday" }},
+		{"negative delay", func(r *domain.CreateReminderRuleRequest) { r.DelayHours = -1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(req)
+			assert.Equal(t, domain.ErrInvalidReminderRule, validateCreateReminderRuleRequest(req))
+		})
+	}
+}
+
+func TestReminderService_ClaimRedemption_InvalidID(t *testing.T) {
+	service := NewReminderService(nil)
+
+	assert.Equal(t, domain.ErrRedemptionNotFound, service.ClaimRedemption(context.Background(), 0))
+}
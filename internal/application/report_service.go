@@ -0,0 +1,457 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+	"github.com/wa-serv/xlsx"
+)
+
+// reportSendTimeout bounds the delivery of a workbook to one recipient
+const reportSendTimeout = time.Minute
+
+// errEmailNotConfigured fails the email recipients of a schedule created
+// before SMTP was turned off
+var errEmailNotConfigured = errors.New("email is not configured; set SMTP_HOST and SMTP_FROM")
+
+// reportBusiestContacts is how many contacts the busiest contacts sheet lists
+const reportBusiestContacts = 100
+
+type reportService struct {
+	db           *sql.DB
+	whatsappRepo domain.WhatsAppRepository
+	mailer       domain.Mailer // nil when email is not configured
+	history      domain.MessageHistoryService
+	staff        domain.StaffService
+	vouchers     domain.VoucherService
+}
+
+// NewReportService creates a service delivering report workbooks on the
+// schedules in the database. The reports come from history, staff and
+// vouchers; mailer may be nil, which refuses email recipients.
+func NewReportService(db *sql.DB, whatsappRepo domain.WhatsAppRepository, mailer domain.Mailer,
+	history domain.MessageHistoryService, staff domain.StaffService, vouchers domain.VoucherService) domain.ReportService {
+	return &reportService{
+		db:           db,
+		whatsappRepo: whatsappRepo,
+		mailer:       mailer,
+		history:      history,
+		staff:        staff,
+		vouchers:     vouchers,
+	}
+}
+
+// CreateSchedule adds a report schedule; its first run is at the next
+// scheduled hour
+func (s *reportService) CreateSchedule(ctx context.Context, req *domain.ReportScheduleRequest) (*domain.ReportSchedule, error) {
+	schedule, err := s.validateScheduleRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	schedule.ID, err = repository.CreateReportSchedule(s.db, schedule)
+	if err != nil {
+		return nil, err
+	}
+	schedule.CreatedAt = time.Now()
+	return toDomainReportSchedule(schedule, time.Now()), nil
+}
+
+// ListSchedules returns every report schedule, active or not
+func (s *reportService) ListSchedules(ctx context.Context) ([]*domain.ReportSchedule, error) {
+	schedules, err := repository.GetReportSchedules(s.db, false)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	result := make([]*domain.ReportSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		result = append(result, toDomainReportSchedule(schedule, now))
+	}
+	return result, nil
+}
+
+// UpdateSchedule replaces the settings of a schedule
+func (s *reportService) UpdateSchedule(ctx context.Context, id int, req *domain.ReportScheduleRequest) (*domain.ReportSchedule, error) {
+	schedule, err := s.validateScheduleRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	schedule.ID = id
+	if err := repository.UpdateReportSchedule(s.db, schedule); err != nil {
+		if err == repository.ErrReportScheduleNotFound {
+			return nil, domain.ErrReportScheduleNotFound
+		}
+		return nil, err
+	}
+	updated, err := repository.GetReportSchedule(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainReportSchedule(*updated, time.Now()), nil
+}
+
+// DeleteSchedule removes a schedule
+func (s *reportService) DeleteSchedule(ctx context.Context, id int) error {
+	err := repository.DeleteReportSchedule(s.db, id)
+	if err == repository.ErrReportScheduleNotFound {
+		return domain.ErrReportScheduleNotFound
+	}
+	return err
+}
+
+// SendSchedule delivers a schedule's workbook now, covering the period of its
+// latest scheduled run, whether or not it is active
+func (s *reportService) SendSchedule(ctx context.Context, id int) (*domain.ReportDelivery, error) {
+	schedule, err := repository.GetReportSchedule(s.db, id)
+	if err == repository.ErrReportScheduleNotFound {
+		return nil, domain.ErrReportScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	delivery, err := s.deliver(ctx, *schedule, lastReportRun(*schedule, now))
+	if err != nil {
+		return nil, err
+	}
+	if err := repository.MarkReportScheduleRun(s.db, id, now, deliveryErrors(delivery)); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// RunDueSchedules delivers the workbooks of the active schedules whose hour has
+// come since they last ran. A schedule whose reports fail to render is left
+// for the next call; failed deliveries are recorded and not retried.
+func (s *reportService) RunDueSchedules(ctx context.Context, now time.Time) ([]*domain.ReportDelivery, error) {
+	schedules, err := repository.GetReportSchedules(s.db, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []*domain.ReportDelivery
+	for _, schedule := range schedules {
+		run := lastReportRun(schedule, now)
+		if !reportRunDue(schedule, run) {
+			continue
+		}
+		delivery, err := s.deliver(ctx, schedule, run)
+		if err != nil {
+			log.Printf("Failed to render report schedule %d (%s): %v", schedule.ID, schedule.Name, err)
+			continue
+		}
+		if err := repository.MarkReportScheduleRun(s.db, schedule.ID, now, deliveryErrors(delivery)); err != nil {
+			return deliveries, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// deliver renders the schedule's reports for the period before run and sends
+// the workbook to each recipient
+func (s *reportService) deliver(ctx context.Context, schedule repository.ReportSchedule, run time.Time) (*domain.ReportDelivery, error) {
+	from, to := reportPeriod(schedule.Frequency, run)
+	sheets := make([]xlsx.Sheet, 0, len(schedule.Reports))
+	for _, report := range schedule.Reports {
+		sheet, err := s.renderReport(ctx, report, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", report, err)
+		}
+		sheets = append(sheets, sheet)
+	}
+	workbook, err := xlsx.Write(sheets...)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &domain.ReportDelivery{
+		ScheduleID: schedule.ID,
+		FileName:   fmt.Sprintf("%s-%s.xlsx", reportFileSlug(schedule.Name), to.Format("2006-01-02")),
+		From:       from.Format("2006-01-02"),
+		To:         to.Format("2006-01-02"),
+	}
+	period := delivery.From
+	if delivery.To != delivery.From {
+		period += " - " + delivery.To
+	}
+	caption := fmt.Sprintf("%s (%s)", schedule.Name, period)
+
+	for _, recipient := range schedule.WhatsAppRecipients {
+		sendCtx, cancel := context.WithTimeout(ctx, reportSendTimeout)
+		_, err := s.whatsappRepo.SendDocument(sendCtx, "", recipient, workbook, delivery.FileName, xlsx.MIMEType, caption)
+		cancel()
+		delivery.Recipients = append(delivery.Recipients, reportRecipientResult(domain.ReportChannelWhatsApp, recipient, err))
+	}
+	if len(schedule.EmailRecipients) > 0 {
+		err := errEmailNotConfigured
+		if s.mailer != nil {
+			sendCtx, cancel := context.WithTimeout(ctx, reportSendTimeout)
+			err = s.mailer.SendMail(sendCtx, schedule.EmailRecipients, caption,
+				fmt.Sprintf("Attached is %s for %s.", schedule.Name, period),
+				domain.MailAttachment{FileName: delivery.FileName, MIMEType: xlsx.MIMEType, Data: workbook})
+			cancel()
+		}
+		for _, recipient := range schedule.EmailRecipients {
+			delivery.Recipients = append(delivery.Recipients, reportRecipientResult(domain.ReportChannelEmail, recipient, err))
+		}
+	}
+	return delivery, nil
+}
+
+// renderReport builds the sheet of one report over the days from to to
+func (s *reportService) renderReport(ctx context.Context, report string, from, to time.Time) (xlsx.Sheet, error) {
+	query := &domain.MessageReportQuery{From: from.Format("2006-01-02"), To: to.Format("2006-01-02")}
+	switch report {
+	case domain.ReportMessageVolume:
+		query.Interval = "day"
+		volume, err := s.history.GetMessageVolume(ctx, query)
+		if err != nil {
+			return xlsx.Sheet{}, err
+		}
+		sheet := xlsx.Sheet{Name: "Message volume", Header: []string{"Day", "Inbound", "Outbound"}}
+		for _, v := range volume.Volume {
+			sheet.Rows = append(sheet.Rows, []any{v.Period, v.Inbound, v.Outbound})
+		}
+		sheet.Rows = append(sheet.Rows, []any{"Total", volume.TotalInbound, volume.TotalOutbound})
+		return sheet, nil
+
+	case domain.ReportBusiestContacts:
+		query.Limit = reportBusiestContacts
+		busiest, err := s.history.GetBusiestContacts(ctx, query)
+		if err != nil {
+			return xlsx.Sheet{}, err
+		}
+		sheet := xlsx.Sheet{Name: "Busiest contacts", Header: []string{"Phone number", "Inbound", "Outbound", "Total", "Last message"}}
+		for _, c := range busiest.Contacts {
+			sheet.Rows = append(sheet.Rows, []any{c.PhoneNumber, c.Inbound, c.Outbound, c.Total, c.LastMessageAt})
+		}
+		return sheet, nil
+
+	case domain.ReportStaffActivity:
+		sheet := xlsx.Sheet{Name: "Staff activity", Header: []string{"Date", "Phone number", "Points credits", "Points credited",
+			"Stamp entries", "Stamps added", "Members served", "Self credits", "First entry", "Last entry", "Anomalies"}}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			activity, err := s.staff.GetDailyActivity(ctx, day.Format("2006-01-02"))
+			if err != nil {
+				return xlsx.Sheet{}, err
+			}
+			for _, a := range activity.Staff {
+				sheet.Rows = append(sheet.Rows, []any{activity.Date, a.PhoneNumber, a.PointsCredits, a.PointsCredited,
+					a.StampEntries, a.StampsAdded, a.MembersServed, a.SelfCredits, a.FirstEntryAt, a.LastEntryAt, strings.Join(a.Anomalies, ", ")})
+			}
+		}
+		return sheet, nil
+
+	case domain.ReportVoucherSettlement:
+		sheet := xlsx.Sheet{Name: "Voucher settlement", Header: []string{"Date", "Issued", "Issued points", "Redeemed", "Redeemed points",
+			"Expired", "Expired points", "Outstanding", "Outstanding points"}}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			v, err := s.vouchers.GetSettlement(ctx, day.Format("2006-01-02"))
+			if err != nil {
+				return xlsx.Sheet{}, err
+			}
+			sheet.Rows = append(sheet.Rows, []any{v.Date, v.Issued.Count, v.Issued.Points, v.Redeemed.Count, v.Redeemed.Points,
+				v.Expired.Count, v.Expired.Points, v.Outstanding.Count, v.Outstanding.Points})
+		}
+		return sheet, nil
+	}
+	return xlsx.Sheet{}, fmt.Errorf("%w: unknown report %q", domain.ErrInvalidReportSchedule, report)
+}
+
+// validateScheduleRequest checks a schedule and normalizes its recipients.
+// Email recipients need a mailer.
+func (s *reportService) validateScheduleRequest(req *domain.ReportScheduleRequest) (repository.ReportSchedule, error) {
+	if req == nil {
+		return repository.ReportSchedule{}, domain.ErrInvalidReportSchedule
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return repository.ReportSchedule{}, fmt.Errorf("%w: name is required and at most 100 characters", domain.ErrInvalidReportSchedule)
+	}
+
+	schedule := repository.ReportSchedule{Name: name, Frequency: req.Frequency, Hour: req.Hour, IsActive: true}
+	if req.Active != nil {
+		schedule.IsActive = *req.Active
+	}
+
+	seen := make(map[string]bool)
+	for _, report := range req.Reports {
+		report = strings.ToLower(strings.TrimSpace(report))
+		switch report {
+		case domain.ReportMessageVolume, domain.ReportBusiestContacts, domain.ReportStaffActivity, domain.ReportVoucherSettlement:
+		default:
+			return repository.ReportSchedule{}, fmt.Errorf("%w: unknown report %q", domain.ErrInvalidReportSchedule, report)
+		}
+		if !seen[report] {
+			seen[report] = true
+			schedule.Reports = append(schedule.Reports, report)
+		}
+	}
+	if len(schedule.Reports) == 0 {
+		return repository.ReportSchedule{}, fmt.Errorf("%w: reports must list at least one report", domain.ErrInvalidReportSchedule)
+	}
+
+	switch req.Frequency {
+	case domain.ReportDaily:
+	case domain.ReportWeekly:
+		if req.Weekday < 0 || req.Weekday > 6 {
+			return repository.ReportSchedule{}, fmt.Errorf("%w: weekday must be between 0 (Sunday) and 6", domain.ErrInvalidReportSchedule)
+		}
+		schedule.Weekday = req.Weekday
+	default:
+		return repository.ReportSchedule{}, fmt.Errorf("%w: frequency must be daily or weekly", domain.ErrInvalidReportSchedule)
+	}
+	if req.Hour < 0 || req.Hour > 23 {
+		return repository.ReportSchedule{}, fmt.Errorf("%w: hour must be between 0 and 23", domain.ErrInvalidReportSchedule)
+	}
+
+	seen = make(map[string]bool)
+	for _, recipient := range req.WhatsAppRecipients {
+		recipient = normalizeBroadcastRecipient(recipient)
+		if recipient == "" || seen[recipient] {
+			continue
+		}
+		if !strings.HasSuffix(recipient, "@g.us") && !phoneNumberPattern.MatchString(recipient) {
+			return repository.ReportSchedule{}, fmt.Errorf("%w: whatsapp recipient %q must be a phone number or group JID", domain.ErrInvalidReportSchedule, recipient)
+		}
+		seen[recipient] = true
+		schedule.WhatsAppRecipients = append(schedule.WhatsAppRecipients, recipient)
+	}
+	for _, recipient := range req.EmailRecipients {
+		recipient = strings.TrimSpace(recipient)
+		if recipient == "" || seen[strings.ToLower(recipient)] {
+			continue
+		}
+		address, err := mail.ParseAddress(recipient)
+		if err != nil || address.Address != recipient || strings.Contains(recipient, ",") {
+			return repository.ReportSchedule{}, fmt.Errorf("%w: %q is not an email address", domain.ErrInvalidReportSchedule, recipient)
+		}
+		seen[strings.ToLower(recipient)] = true
+		schedule.EmailRecipients = append(schedule.EmailRecipients, recipient)
+	}
+	if len(schedule.EmailRecipients) > 0 && s.mailer == nil {
+		return repository.ReportSchedule{}, fmt.Errorf("%w: email recipients need SMTP_HOST and SMTP_FROM", domain.ErrInvalidReportSchedule)
+	}
+	if len(schedule.WhatsAppRecipients)+len(schedule.EmailRecipients) == 0 {
+		return repository.ReportSchedule{}, fmt.Errorf("%w: add at least one whatsapp or email recipient", domain.ErrInvalidReportSchedule)
+	}
+	return schedule, nil
+}
+
+// phoneNumberPattern matches a phone number in international form without the +
+var phoneNumberPattern = regexp.MustCompile(`^[0-9]{10,15}$`)
+
+// lastReportRun returns the latest time at or before now the schedule was due
+// to run: today or yesterday at its hour, or for weekly schedules the latest
+// of its weekday at its hour
+func lastReportRun(schedule repository.ReportSchedule, now time.Time) time.Time {
+	run := time.Date(now.Year(), now.Month(), now.Day(), schedule.Hour, 0, 0, 0, now.Location())
+	if schedule.Frequency == domain.ReportWeekly {
+		run = run.AddDate(0, 0, -((int(run.Weekday()) - schedule.Weekday + 7) % 7))
+		if run.After(now) {
+			run = run.AddDate(0, 0, -7)
+		}
+		return run
+	}
+	if run.After(now) {
+		run = run.AddDate(0, 0, -1)
+	}
+	return run
+}
+
+// reportRunDue reports whether the run was missed: the schedule last ran, or
+// was created when it never ran, before it
+func reportRunDue(schedule repository.ReportSchedule, run time.Time) bool {
+	since := schedule.CreatedAt
+	if schedule.LastRunAt.Valid {
+		since = schedule.LastRunAt.Time
+	}
+	return since.Before(run)
+}
+
+// reportPeriod returns the first and last day a run covers: the day before it
+// for daily schedules, the seven days before it for weekly ones
+func reportPeriod(frequency string, run time.Time) (time.Time, time.Time) {
+	to := time.Date(run.Year(), run.Month(), run.Day(), 0, 0, 0, 0, run.Location()).AddDate(0, 0, -1)
+	if frequency == domain.ReportWeekly {
+		return to.AddDate(0, 0, -6), to
+	}
+	return to, to
+}
+
+// reportFileSlug turns a schedule name into a file name, e.g.
+// "Laporan Harian" into laporan-harian
+func reportFileSlug(name string) string {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if slug == "" {
+		return "report"
+	}
+	return slug
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+func reportRecipientResult(channel, recipient string, err error) domain.ReportRecipientResult {
+	result := domain.ReportRecipientResult{Channel: channel, Recipient: recipient, Success: err == nil}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// deliveryErrors summarizes the failed deliveries, empty when all succeeded
+func deliveryErrors(delivery *domain.ReportDelivery) string {
+	var failed []string
+	for _, r := range delivery.Recipients {
+		if !r.Success {
+			failed = append(failed, fmt.Sprintf("%s %s: %s", r.Channel, r.Recipient, r.Error))
+		}
+	}
+	return strings.Join(failed, "; ")
+}
+
+func toDomainReportSchedule(schedule repository.ReportSchedule, now time.Time) *domain.ReportSchedule {
+	result := &domain.ReportSchedule{
+		ID:                 schedule.ID,
+		Name:               schedule.Name,
+		Reports:            schedule.Reports,
+		Frequency:          schedule.Frequency,
+		Hour:               schedule.Hour,
+		Weekday:            schedule.Weekday,
+		WhatsAppRecipients: schedule.WhatsAppRecipients,
+		EmailRecipients:    schedule.EmailRecipients,
+		Active:             schedule.IsActive,
+		LastError:          schedule.LastError,
+	}
+	if result.WhatsAppRecipients == nil {
+		result.WhatsAppRecipients = []string{}
+	}
+	if result.EmailRecipients == nil {
+		result.EmailRecipients = []string{}
+	}
+	if schedule.LastRunAt.Valid {
+		result.LastRunAt = schedule.LastRunAt.Time.Format(time.RFC3339)
+	}
+	if schedule.IsActive {
+		next := lastReportRun(schedule, now)
+		if !reportRunDue(schedule, next) {
+			if schedule.Frequency == domain.ReportWeekly {
+				next = next.AddDate(0, 0, 7)
+			} else {
+				next = next.AddDate(0, 0, 1)
+			}
+		}
+		result.NextRunAt = next.Format(time.RFC3339)
+	}
+	return result
+}
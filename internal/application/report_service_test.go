@@ -0,0 +1,196 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/repository"
+	"github.com/wa-serv/xlsx"
+)
+
+func TestReportService_ValidateScheduleRequest(t *testing.T) {
+	service := &reportService{mailer: &mocks.MockMailer{}}
+
+	schedule, err := service.validateScheduleRequest(&domain.ReportScheduleRequest{
+		Name:               "  Laporan Harian ",
+		Reports:            []string{"Message_Volume", "message_volume", "staff_activity"},
+		Frequency:          domain.ReportDaily,
+		Hour:               7,
+		WhatsAppRecipients: []string{"0812-3456-7890", "6281234567890@s.whatsapp.net", "120363025246125486@g.us"},
+		EmailRecipients:    []string{"owner@example.com", "OWNER@example.com"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Laporan Harian", schedule.Name)
+	assert.Equal(t, []string{domain.ReportMessageVolume, domain.ReportStaffActivity}, schedule.Reports)
+	assert.Equal(t, []string{"6281234567890", "120363025246125486@g.us"}, schedule.WhatsAppRecipients)
+	assert.Equal(t, []string{"owner@example.com"}, schedule.EmailRecipients)
+	assert.True(t, schedule.IsActive)
+}
+
+func TestReportService_ValidateScheduleRequest_Invalid(t *testing.T) {
+	valid := func() *domain.ReportScheduleRequest {
+		return &domain.ReportScheduleRequest{Name: "Daily", Reports: []string{domain.ReportMessageVolume},
+			Frequency: domain.ReportDaily, WhatsAppRecipients: []string{"6281234567890"}}
+	}
+	tests := []struct {
+		name   string
+		mutate func(req *domain.ReportScheduleRequest)
+	}{
+		{"blank name", func(req *domain.ReportScheduleRequest) { req.Name = " " }},
+		{"no reports", func(req *domain.ReportScheduleRequest) { req.Reports = nil }},
+		{"unknown report", func(req *domain.ReportScheduleRequest) { req.Reports = []string{"revenue"} }},
+		{"unknown frequency", func(req *domain.ReportScheduleRequest) { req.Frequency = "monthly" }},
+		{"hour out of range", func(req *domain.ReportScheduleRequest) { req.Hour = 24 }},
+		{"weekday out of range", func(req *domain.ReportScheduleRequest) { req.Frequency, req.Weekday = domain.ReportWeekly, 7 }},
+		{"bad phone number", func(req *domain.ReportScheduleRequest) { req.WhatsAppRecipients = []string{"12ab"} }},
+		{"no recipients", func(req *domain.ReportScheduleRequest) { req.WhatsAppRecipients = nil }},
+		{"email without mailer", func(req *domain.ReportScheduleRequest) { req.EmailRecipients = []string{"owner@example.com"} }},
+	}
+
+	service := &reportService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(req)
+			_, err := service.validateScheduleRequest(req)
+			assert.ErrorIs(t, err, domain.ErrInvalidReportSchedule)
+		})
+	}
+}
+
+func TestReportService_ValidateScheduleRequest_BadEmail(t *testing.T) {
+	service := &reportService{mailer: &mocks.MockMailer{}}
+
+	for _, email := range []string{"not-an-email", "Owner <owner@example.com>", "a@example.com,b@example.com"} {
+		_, err := service.validateScheduleRequest(&domain.ReportScheduleRequest{Name: "Daily", Reports: []string{domain.ReportMessageVolume},
+			Frequency: domain.ReportDaily, EmailRecipients: []string{email}})
+		assert.ErrorIs(t, err, domain.ErrInvalidReportSchedule, email)
+	}
+}
+
+func TestLastReportRun(t *testing.T) {
+	loc := time.FixedZone("WIB", 7*3600)
+	// Friday 16 October 2026
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, loc)
+
+	tests := []struct {
+		name     string
+		schedule repository.ReportSchedule
+		want     time.Time
+	}{
+		{"daily, hour passed", repository.ReportSchedule{Frequency: domain.ReportDaily, Hour: 7}, time.Date(2026, 10, 16, 7, 0, 0, 0, loc)},
+		{"daily, hour to come", repository.ReportSchedule{Frequency: domain.ReportDaily, Hour: 18}, time.Date(2026, 10, 15, 18, 0, 0, 0, loc)},
+		{"weekly, earlier weekday", repository.ReportSchedule{Frequency: domain.ReportWeekly, Weekday: 1, Hour: 8}, time.Date(2026, 10, 12, 8, 0, 0, 0, loc)},
+		{"weekly, today passed", repository.ReportSchedule{Frequency: domain.ReportWeekly, Weekday: 5, Hour: 9}, time.Date(2026, 10, 16, 9, 0, 0, 0, loc)},
+		{"weekly, today to come", repository.ReportSchedule{Frequency: domain.ReportWeekly, Weekday: 5, Hour: 10}, time.Date(2026, 10, 9, 10, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lastReportRun(tt.schedule, now))
+		})
+	}
+}
+
+func TestReportRunDue(t *testing.T) {
+	run := time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC)
+
+	assert.True(t, reportRunDue(repository.ReportSchedule{CreatedAt: run.Add(-time.Hour)}, run))
+	assert.False(t, reportRunDue(repository.ReportSchedule{CreatedAt: run.Add(time.Hour)}, run))
+	assert.True(t, reportRunDue(repository.ReportSchedule{CreatedAt: run.AddDate(0, 0, -7),
+		LastRunAt: sql.NullTime{Time: run.AddDate(0, 0, -1), Valid: true}}, run))
+	assert.False(t, reportRunDue(repository.ReportSchedule{CreatedAt: run.AddDate(0, 0, -7),
+		LastRunAt: sql.NullTime{Time: run.Add(time.Minute), Valid: true}}, run))
+}
+
+func TestReportPeriod(t *testing.T) {
+	run := time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC)
+
+	from, to := reportPeriod(domain.ReportDaily, run)
+	assert.Equal(t, "2026-10-15", from.Format("2006-01-02"))
+	assert.Equal(t, "2026-10-15", to.Format("2006-01-02"))
+
+	from, to = reportPeriod(domain.ReportWeekly, run)
+	assert.Equal(t, "2026-10-09", from.Format("2006-01-02"))
+	assert.Equal(t, "2026-10-15", to.Format("2006-01-02"))
+}
+
+func TestReportFileSlug(t *testing.T) {
+	assert.Equal(t, "laporan-harian", reportFileSlug("Laporan Harian"))
+	assert.Equal(t, "owner-s-weekly", reportFileSlug("  Owner's Weekly!"))
+	assert.Equal(t, "report", reportFileSlug("***"))
+}
+
+func TestReportService_Deliver(t *testing.T) {
+	history := &mocks.MockMessageHistoryService{}
+	history.On("GetMessageVolume", mock.Anything, &domain.MessageReportQuery{From: "2026-10-15", To: "2026-10-15", Interval: "day"}).
+		Return(&domain.MessageVolumeReport{TotalInbound: 3, TotalOutbound: 5}, nil)
+	whatsappRepo := &mocks.MockWhatsAppRepository{}
+	whatsappRepo.On("SendDocument", mock.Anything, "", "6281234567890", mock.Anything, "laporan-harian-2026-10-15.xlsx", xlsx.MIMEType, "Laporan Harian (2026-10-15)").
+		Return(&domain.Message{}, nil)
+	whatsappRepo.On("SendDocument", mock.Anything, "", "6289876543210", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("not on whatsapp"))
+	mailer := &mocks.MockMailer{}
+	mailer.On("SendMail", mock.Anything, []string{"owner@example.com"}, "Laporan Harian (2026-10-15)", mock.Anything, mock.Anything).Return(nil)
+
+	service := &reportService{whatsappRepo: whatsappRepo, mailer: mailer, history: history}
+	schedule := repository.ReportSchedule{ID: 4, Name: "Laporan Harian", Reports: []string{domain.ReportMessageVolume}, Frequency: domain.ReportDaily,
+		WhatsAppRecipients: []string{"6281234567890", "6289876543210"}, EmailRecipients: []string{"owner@example.com"}}
+
+	delivery, err := service.deliver(context.Background(), schedule, time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, "laporan-harian-2026-10-15.xlsx", delivery.FileName)
+	assert.Equal(t, []domain.ReportRecipientResult{
+		{Channel: domain.ReportChannelWhatsApp, Recipient: "6281234567890", Success: true},
+		{Channel: domain.ReportChannelWhatsApp, Recipient: "6289876543210", Error: "not on whatsapp"},
+		{Channel: domain.ReportChannelEmail, Recipient: "owner@example.com", Success: true},
+	}, delivery.Recipients)
+	assert.Equal(t, "whatsapp 6289876543210: not on whatsapp", deliveryErrors(delivery))
+	whatsappRepo.AssertExpectations(t)
+	mailer.AssertExpectations(t)
+}
+
+func TestReportService_Deliver_EmailNotConfigured(t *testing.T) {
+	vouchers := &mocks.MockVoucherService{}
+	for _, day := range []string{"2026-10-09", "2026-10-10", "2026-10-11", "2026-10-12", "2026-10-13", "2026-10-14", "2026-10-15"} {
+		vouchers.On("GetSettlement", mock.Anything, day).Return(&domain.VoucherSettlement{Date: day}, nil).Once()
+	}
+
+	service := &reportService{vouchers: vouchers}
+	schedule := repository.ReportSchedule{Name: "Weekly", Reports: []string{domain.ReportVoucherSettlement}, Frequency: domain.ReportWeekly,
+		EmailRecipients: []string{"owner@example.com"}}
+
+	delivery, err := service.deliver(context.Background(), schedule, time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-09", delivery.From)
+	assert.Equal(t, []domain.ReportRecipientResult{
+		{Channel: domain.ReportChannelEmail, Recipient: "owner@example.com", Error: errEmailNotConfigured.Error()},
+	}, delivery.Recipients)
+	vouchers.AssertExpectations(t)
+}
+
+func TestReportService_Deliver_RenderFails(t *testing.T) {
+	staff := &mocks.MockStaffService{}
+	staff.On("GetDailyActivity", mock.Anything, "2026-10-15").Return(nil, errors.New("database is down"))
+	whatsappRepo := &mocks.MockWhatsAppRepository{}
+
+	service := &reportService{whatsappRepo: whatsappRepo, staff: staff}
+	schedule := repository.ReportSchedule{Name: "Staff", Reports: []string{domain.ReportStaffActivity}, Frequency: domain.ReportDaily,
+		WhatsAppRecipients: []string{"6281234567890"}}
+
+	_, err := service.deliver(context.Background(), schedule, time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC))
+
+	assert.Error(t, err)
+	whatsappRepo.AssertNotCalled(t, "SendDocument")
+}
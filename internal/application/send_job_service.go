@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/requestid"
+)
+
+// sendJobRetention is how long a finished send job stays queryable
+const sendJobRetention = 24 * time.Hour
+
+type sendJob struct {
+	job         domain.SendJob
+	completedAt time.Time
+}
+
+type sendJobService struct {
+	mu   sync.RWMutex
+	jobs map[string]*sendJob
+}
+
+// NewSendJobService creates a service running sends in the background. Jobs are
+// kept in memory, so they do not survive a restart.
+func NewSendJobService() domain.SendJobService {
+	return &sendJobService{jobs: make(map[string]*sendJob)}
+}
+
+// StartSend runs send in the background and returns the pending job. The
+// returned job is a snapshot; poll GetSendJob for the outcome.
+func (s *sendJobService) StartSend(ctx context.Context, kind string, send func(ctx context.Context) (*domain.SendMessageResponse, error)) (*domain.SendJob, error) {
+	job := &sendJob{job: domain.SendJob{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		Status:    domain.SendJobPending,
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}}
+
+	s.mu.Lock()
+	s.cleanupLocked()
+	s.jobs[job.job.ID] = job
+	s.mu.Unlock()
+
+	// The request context ends with the HTTP response, so the send runs on its
+	// own while keeping the request ID for events and status callbacks
+	sendCtx := requestid.NewContext(context.Background(), requestid.FromContext(ctx))
+	go s.run(sendCtx, job, send)
+
+	return s.snapshot(job), nil
+}
+
+// GetSendJob returns the status of a send job
+func (s *sendJobService) GetSendJob(ctx context.Context, id string) (*domain.SendJob, error) {
+	s.mu.RLock()
+	job, ok := s.jobs[id]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, domain.ErrSendJobNotFound
+	}
+	return s.snapshot(job), nil
+}
+
+func (s *sendJobService) run(ctx context.Context, job *sendJob, send func(ctx context.Context) (*domain.SendMessageResponse, error)) {
+	resp, err := send(ctx)
+	if resp == nil {
+		resp = &domain.SendMessageResponse{Success: err == nil}
+		if err != nil {
+			resp.Message = err.Error()
+		}
+	}
+
+	status := domain.SendJobSent
+	switch {
+	case err != nil:
+		status = domain.SendJobFailed
+	case resp.Queued:
+		status = domain.SendJobQueued
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job.completedAt = time.Now()
+	job.job.Status = status
+	job.job.CompletedAt = job.completedAt.Format(time.RFC3339)
+	job.job.Result = resp
+}
+
+func (s *sendJobService) snapshot(job *sendJob) *domain.SendJob {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	copied := job.job
+	if job.job.Result != nil {
+		result := *job.job.Result
+		copied.Result = &result
+	}
+	return &copied
+}
+
+// cleanupLocked forgets send jobs that finished more than sendJobRetention ago
+func (s *sendJobService) cleanupLocked() {
+	cutoff := time.Now().Add(-sendJobRetention)
+	for id, job := range s.jobs {
+		if job.job.Status != domain.SendJobPending && job.completedAt.Before(cutoff) {
+			delete(s.jobs, id)
+		}
+	}
+}
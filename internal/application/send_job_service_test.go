@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/requestid"
+)
+
+// waitForSendJob polls until the send job finishes
+func waitForSendJob(t *testing.T, service domain.SendJobService, id string) *domain.SendJob {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := service.GetSendJob(context.Background(), id)
+		require.NoError(t, err)
+		if job.Status != domain.SendJobPending {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("send job %s did not finish", id)
+	return nil
+}
+
+func TestSendJobService_StartSend_Outcomes(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       *domain.SendMessageResponse
+		err        error
+		wantStatus string
+	}{
+		{"sent", &domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil, domain.SendJobSent},
+		{"queued", &domain.SendMessageResponse{Success: true, Queued: true, QueueID: 7}, nil, domain.SendJobQueued},
+		{"failed", &domain.SendMessageResponse{Success: false, Message: "Invalid phone number format"}, domain.ErrInvalidPhoneNumber, domain.SendJobFailed},
+		{"failed without response", nil, domain.ErrMessageSendFailed, domain.SendJobFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := NewSendJobService()
+			release := make(chan struct{})
+
+			// Act
+			job, err := service.StartSend(context.Background(), "message", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+				<-release
+				return tt.resp, tt.err
+			})
+			require.NoError(t, err)
+			pending, err := service.GetSendJob(context.Background(), job.ID)
+			require.NoError(t, err)
+			close(release)
+
+			// Assert
+			assert.Equal(t, domain.SendJobPending, job.Status)
+			assert.Equal(t, "message", job.Kind)
+			assert.Equal(t, domain.SendJobPending, pending.Status)
+			assert.Nil(t, pending.Result)
+
+			done := waitForSendJob(t, service, job.ID)
+			assert.Equal(t, tt.wantStatus, done.Status)
+			assert.NotEmpty(t, done.CompletedAt)
+			require.NotNil(t, done.Result)
+			if tt.resp != nil {
+				assert.Equal(t, *tt.resp, *done.Result)
+			} else {
+				assert.False(t, done.Result.Success)
+				assert.Equal(t, tt.err.Error(), done.Result.Message)
+			}
+		})
+	}
+}
+
+func TestSendJobService_StartSend_OutlivesRequestAndKeepsRequestID(t *testing.T) {
+	// Arrange
+	service := NewSendJobService()
+	ctx, cancel := context.WithCancel(requestid.NewContext(context.Background(), "req-123"))
+	seen := make(chan error, 1)
+	seenID := make(chan string, 1)
+
+	// Act
+	job, err := service.StartSend(ctx, "image", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+		cancel() // the HTTP request finishing must not cancel the send
+		seen <- ctx.Err()
+		seenID <- requestid.FromContext(ctx)
+		return &domain.SendMessageResponse{Success: true}, nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, <-seen)
+	assert.Equal(t, "req-123", <-seenID)
+	assert.Equal(t, domain.SendJobSent, waitForSendJob(t, service, job.ID).Status)
+}
+
+func TestSendJobService_GetSendJob_NotFound(t *testing.T) {
+	service := NewSendJobService()
+
+	job, err := service.GetSendJob(context.Background(), "missing")
+
+	assert.Nil(t, job)
+	assert.Equal(t, domain.ErrSendJobNotFound, err)
+}
@@ -0,0 +1,248 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/requestid"
+)
+
+// sendQueueBatch bounds the retries sent per poll so a long outage drains gradually
+const sendQueueBatch = 50
+
+type queuedMessageService struct {
+	domain.MessageService // media sends and lookups pass straight through
+
+	queue domain.SendQueueRepository
+	cfg   config.SendQueueConfig
+	now   func() time.Time
+}
+
+// NewQueuedMessageService wraps messages so a text or interactive send that
+// fails transiently (a disconnect or stream error) is queued and retried with
+// exponential backoff instead of failing the caller. Retries run until ctx ends.
+//
+// With HoldWhileOffline set, a send made while no sender is connected is held
+// until one reconnects instead of using up its attempts, and expires with a
+// message.expired event once it is older than MaxAge.
+//
+// With Quota.QueueExceeded set, a send refused because its sender reached its
+// daily quota is held until the quota resets at midnight.
+func NewQueuedMessageService(ctx context.Context, messages domain.MessageService, queue domain.SendQueueRepository, cfg config.SendQueueConfig) domain.MessageService {
+	s := &queuedMessageService{
+		MessageService: messages,
+		queue:          queue,
+		cfg:            cfg,
+		now:            time.Now,
+	}
+	go s.run(ctx)
+	return s
+}
+
+// SendMessage sends req right away and queues it for a retry when that fails
+// transiently. A queued message is reported as success with Queued set; one
+// that cannot be queued is announced as failed.
+func (s *queuedMessageService) SendMessage(ctx context.Context, req *domain.SendMessageRequest) (*domain.SendMessageResponse, error) {
+	resp, err := s.MessageService.SendMessage(ctx, req)
+	if !(isTransientSendError(err) || s.holdsOverQuota(err)) || req.DryRun {
+		return resp, err
+	}
+
+	attempts, nextAttempt := 1, s.now().Add(s.backoff(1))
+	message := "Sending failed; the message is queued and will be retried"
+	if s.holdsOverQuota(err) {
+		attempts, nextAttempt = 0, s.cfg.Quota.NextReset(s.now())
+		message = "The sender has reached its daily quota; the message is queued until the quota resets"
+	} else if s.holdsOffline(err) {
+		// Waiting for a sender to reconnect is not a failed attempt; the
+		// message is sent on the first poll that finds one connected
+		attempts, nextAttempt = 0, s.now()
+		message = "No sender is connected; the message is queued until one reconnects"
+	} else if s.cfg.MaxAttempts <= 1 {
+		publishFailed(requestid.FromContext(ctx), 0, req.To, sendErrorText(resp, err))
+		return resp, err
+	}
+
+	id, qerr := s.queue.Enqueue(req, requestid.FromContext(ctx), attempts, nextAttempt, sendErrorText(resp, err))
+	if qerr != nil {
+		log.Printf("Failed to queue message to %s for retry: %v", req.To, qerr)
+		publishFailed(requestid.FromContext(ctx), 0, req.To, sendErrorText(resp, err))
+		return resp, err
+	}
+
+	return &domain.SendMessageResponse{
+		Success: true,
+		Message: message,
+		Queued:  true,
+		QueueID: id,
+	}, nil
+}
+
+// GetStatus adds the send queue's depth and failures to the service status
+func (s *queuedMessageService) GetStatus(ctx context.Context) (*domain.ServiceStatus, error) {
+	status, err := s.MessageService.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.queue.Stats()
+	if err != nil {
+		// The connection status is still worth reporting
+		log.Printf("Failed to get send queue stats: %v", err)
+		return status, nil
+	}
+	status.Queue = stats
+	return status, nil
+}
+
+func (s *queuedMessageService) run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.retryDue(ctx)
+		}
+	}
+}
+
+// retryDue resends the queued messages whose retry is due. When holding sends
+// while offline, nothing is resent until a sender is connected and messages
+// past MaxAge expire instead.
+func (s *queuedMessageService) retryDue(ctx context.Context) {
+	due, err := s.queue.Due(s.now(), sendQueueBatch)
+	if err != nil {
+		log.Printf("Failed to load queued sends: %v", err)
+		return
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	online := !s.cfg.HoldWhileOffline || s.connected(ctx)
+	for _, q := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		if s.cfg.HoldWhileOffline && s.now().Sub(q.QueuedAt) > s.cfg.MaxAge {
+			s.expire(q)
+			continue
+		}
+		if !online {
+			continue
+		}
+
+		// Retries are traced back to the API call that queued the message
+		attempts := q.Attempts + 1
+		resp, err := s.MessageService.SendMessage(requestid.NewContext(ctx, q.RequestID), q.Request)
+		switch {
+		case err == nil:
+			err = s.queue.MarkSent(q.ID, attempts)
+		case s.holdsOffline(err):
+			// The sender dropped again before this send; keep waiting
+			err = s.queue.Retry(q.ID, q.Attempts, s.now().Add(s.cfg.PollInterval), sendErrorText(resp, err))
+		case s.holdsOverQuota(err):
+			// Still over quota; try again once it resets
+			err = s.queue.Retry(q.ID, q.Attempts, s.cfg.Quota.NextReset(s.now()), sendErrorText(resp, err))
+		case isTransientSendError(err) && attempts < s.cfg.MaxAttempts:
+			err = s.queue.Retry(q.ID, attempts, s.now().Add(s.backoff(attempts)), sendErrorText(resp, err))
+		default:
+			reason := sendErrorText(resp, err)
+			log.Printf("Giving up on queued message %d to %s after %d attempts: %s", q.ID, q.Request.To, attempts, reason)
+			if err = s.queue.MarkFailed(q.ID, attempts, reason); err == nil {
+				publishFailed(q.RequestID, q.ID, q.Request.To, reason)
+			}
+		}
+		if err != nil {
+			log.Printf("Failed to update queued message %d: %v", q.ID, err)
+		}
+	}
+}
+
+// holdsOffline reports whether a send that failed with err is held until a
+// sender reconnects rather than retried with backoff
+func (s *queuedMessageService) holdsOffline(err error) bool {
+	return s.cfg.HoldWhileOffline && errors.Is(err, domain.ErrWhatsAppNotConnected)
+}
+
+// holdsOverQuota reports whether a send that failed with err is held until its
+// sender's daily quota resets rather than refused
+func (s *queuedMessageService) holdsOverQuota(err error) bool {
+	return s.cfg.Quota.QueueExceeded && errors.Is(err, domain.ErrSenderQuotaExceeded)
+}
+
+// connected reports whether a sender is connected to retry through
+func (s *queuedMessageService) connected(ctx context.Context) bool {
+	status, err := s.MessageService.GetStatus(ctx)
+	return err == nil && status.WhatsApp.Connected
+}
+
+// expire gives up on a message held longer than MaxAge and announces it so the
+// caller can notify the recipient another way
+func (s *queuedMessageService) expire(q *domain.QueuedSend) {
+	log.Printf("Queued message %d to %s expired after waiting since %s for a sender", q.ID, q.Request.To, q.QueuedAt.Format(time.RFC3339))
+	if err := s.queue.MarkExpired(q.ID, q.Attempts, q.LastError); err != nil {
+		log.Printf("Failed to update queued message %d: %v", q.ID, err)
+		return
+	}
+	data := map[string]any{
+		"queue_id":   q.ID,
+		"to":         q.Request.To,
+		"queued_at":  q.QueuedAt.Format(time.RFC3339),
+		"attempts":   q.Attempts,
+		"last_error": q.LastError,
+	}
+	if q.RequestID != "" {
+		data[eventbus.DataRequestID] = q.RequestID
+	}
+	eventbus.Publish(eventbus.MessageExpired, data)
+}
+
+// publishFailed announces that a message was given up on. queueID is 0 when
+// the message was never queued.
+func publishFailed(requestID string, queueID int64, to, reason string) {
+	data := map[string]any{
+		"to":    to,
+		"error": reason,
+	}
+	if queueID != 0 {
+		data["queue_id"] = queueID
+	}
+	if requestID != "" {
+		data[eventbus.DataRequestID] = requestID
+	}
+	eventbus.Publish(eventbus.MessageFailed, data)
+}
+
+// backoff is the wait after the given number of failed attempts: BaseDelay,
+// then doubling up to MaxDelay
+func (s *queuedMessageService) backoff(attempts int) time.Duration {
+	delay := s.cfg.BaseDelay
+	for i := 1; i < attempts && delay < s.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.cfg.MaxDelay {
+		delay = s.cfg.MaxDelay
+	}
+	return delay
+}
+
+// isTransientSendError reports whether a send may succeed when retried. Invalid
+// requests fail the same way every time.
+func isTransientSendError(err error) bool {
+	return errors.Is(err, domain.ErrMessageSendFailed) || errors.Is(err, domain.ErrWhatsAppNotConnected)
+}
+
+func sendErrorText(resp *domain.SendMessageResponse, err error) string {
+	if resp != nil && resp.Message != "" {
+		return resp.Message
+	}
+	return err.Error()
+}
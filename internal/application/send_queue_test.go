@@ -0,0 +1,308 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/requestid"
+)
+
+var testSendQueueConfig = config.SendQueueConfig{
+	MaxAttempts:  3,
+	BaseDelay:    10 * time.Second,
+	MaxDelay:     25 * time.Second,
+	PollInterval: time.Hour,
+}
+
+// newTestQueuedMessageService returns a queued message service on a fixed clock
+// whose background retries never run; tests call retryDue themselves
+func newTestQueuedMessageService(t *testing.T, messages domain.MessageService, queue domain.SendQueueRepository) (*queuedMessageService, time.Time) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := NewQueuedMessageService(ctx, messages, queue, testSendQueueConfig).(*queuedMessageService)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	return s, now
+}
+
+func TestQueuedMessageService_SendMessage_QueuesTransientFailure(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, now := newTestQueuedMessageService(t, mockMessages, mockQueue)
+
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap diambil"}
+	mockMessages.On("SendMessage", mock.Anything, req).
+		Return(&domain.SendMessageResponse{Success: false, Message: "Failed to send message: stream error"}, domain.ErrMessageSendFailed)
+	mockQueue.On("Enqueue", req, "", 1, now.Add(10*time.Second), "Failed to send message: stream error").Return(int64(7), nil)
+
+	// Act
+	resp, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.True(t, resp.Queued)
+	assert.Equal(t, int64(7), resp.QueueID)
+	mockQueue.AssertExpectations(t)
+}
+
+func TestQueuedMessageService_SendMessage_PermanentFailureNotQueued(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, _ := newTestQueuedMessageService(t, mockMessages, mockQueue)
+
+	req := &domain.SendMessageRequest{To: "abc", Message: "Halo"}
+	mockMessages.On("SendMessage", mock.Anything, req).
+		Return(&domain.SendMessageResponse{Success: false, Message: "Invalid phone number format"}, domain.ErrInvalidPhoneNumber)
+
+	// Act
+	resp, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.Equal(t, domain.ErrInvalidPhoneNumber, err)
+	assert.False(t, resp.Queued)
+	mockQueue.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestQueuedMessageService_RetryDue(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, now := newTestQueuedMessageService(t, mockMessages, mockQueue)
+
+	delivered := &domain.SendMessageRequest{To: "628111", Message: "a"}
+	retried := &domain.SendMessageRequest{To: "628222", Message: "b"}
+	exhausted := &domain.SendMessageRequest{To: "628333", Message: "c"}
+	mockQueue.On("Due", now, sendQueueBatch).Return([]*domain.QueuedSend{
+		{ID: 1, Request: delivered, Attempts: 1},
+		{ID: 2, Request: retried, Attempts: 1},
+		{ID: 3, Request: exhausted, Attempts: 2},
+	}, nil)
+
+	mockMessages.On("SendMessage", mock.Anything, delivered).Return(&domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil)
+	notConnected := &domain.SendMessageResponse{Success: false, Message: "WhatsApp client is not connected"}
+	mockMessages.On("SendMessage", mock.Anything, retried).Return(notConnected, domain.ErrWhatsAppNotConnected)
+	mockMessages.On("SendMessage", mock.Anything, exhausted).Return(notConnected, domain.ErrWhatsAppNotConnected)
+
+	mockQueue.On("MarkSent", int64(1), 2).Return(nil)
+	mockQueue.On("Retry", int64(2), 2, now.Add(20*time.Second), "WhatsApp client is not connected").Return(nil)
+	mockQueue.On("MarkFailed", int64(3), 3, "WhatsApp client is not connected").Return(nil)
+
+	// Act
+	service.retryDue(context.Background())
+
+	// Assert
+	mockQueue.AssertExpectations(t)
+	mockMessages.AssertExpectations(t)
+}
+
+func TestQueuedMessageService_RetryDue_GiveUpPublishesFailed(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, now := newTestQueuedMessageService(t, mockMessages, mockQueue)
+
+	exhausted := &domain.SendMessageRequest{To: "628333", Message: "c"}
+	mockQueue.On("Due", now, sendQueueBatch).Return([]*domain.QueuedSend{
+		{ID: 3, Request: exhausted, RequestID: "req-1", Attempts: 2},
+	}, nil)
+	// The retry is traced back to the API call that queued the message
+	mockMessages.On("SendMessage", mock.MatchedBy(func(ctx context.Context) bool {
+		return requestid.FromContext(ctx) == "req-1"
+	}), exhausted).Return(&domain.SendMessageResponse{Success: false, Message: "stream error"}, domain.ErrMessageSendFailed)
+	mockQueue.On("MarkFailed", int64(3), 3, "stream error").Return(nil)
+
+	var failed []eventbus.Event
+	eventbus.Subscribe(eventbus.MessageFailed, func(evt eventbus.Event) { failed = append(failed, evt) })
+
+	// Act
+	service.retryDue(context.Background())
+
+	// Assert
+	mockMessages.AssertExpectations(t)
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, map[string]any{
+			"to":                   "628333",
+			"error":                "stream error",
+			"queue_id":             int64(3),
+			eventbus.DataRequestID: "req-1",
+		}, failed[0].Data)
+	}
+}
+
+// holdWhileOffline switches service to holding sends for a sender to reconnect
+func holdWhileOffline(service *queuedMessageService) {
+	service.cfg.HoldWhileOffline = true
+	service.cfg.MaxAge = 24 * time.Hour
+	service.cfg.MaxAttempts = 1
+}
+
+func TestQueuedMessageService_SendMessage_HoldsWhileOffline(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, now := newTestQueuedMessageService(t, mockMessages, mockQueue)
+	holdWhileOffline(service)
+
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap diambil"}
+	mockMessages.On("SendMessage", mock.Anything, req).
+		Return(&domain.SendMessageResponse{Success: false, Message: "WhatsApp client is not connected"}, domain.ErrWhatsAppNotConnected)
+	mockQueue.On("Enqueue", req, "", 0, now, "WhatsApp client is not connected").Return(int64(8), nil)
+
+	// Act
+	resp, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Queued)
+	assert.Equal(t, int64(8), resp.QueueID)
+	mockQueue.AssertExpectations(t)
+}
+
+func TestQueuedMessageService_RetryDue_WaitsWhileOffline(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, now := newTestQueuedMessageService(t, mockMessages, mockQueue)
+	holdWhileOffline(service)
+
+	held := &domain.SendMessageRequest{To: "628111", Message: "a"}
+	stale := &domain.SendMessageRequest{To: "628222", Message: "b"}
+	mockQueue.On("Due", now, sendQueueBatch).Return([]*domain.QueuedSend{
+		{ID: 1, Request: held, QueuedAt: now.Add(-time.Hour)},
+		{ID: 2, Request: stale, QueuedAt: now.Add(-25 * time.Hour), LastError: "WhatsApp client is not connected"},
+	}, nil)
+	mockMessages.On("GetStatus", mock.Anything).Return(&domain.ServiceStatus{WhatsApp: domain.WhatsAppStatus{Connected: false}}, nil)
+	mockQueue.On("MarkExpired", int64(2), 0, "WhatsApp client is not connected").Return(nil)
+
+	var expired []eventbus.Event
+	eventbus.Subscribe(eventbus.MessageExpired, func(evt eventbus.Event) { expired = append(expired, evt) })
+
+	// Act
+	service.retryDue(context.Background())
+
+	// Assert
+	mockQueue.AssertExpectations(t)
+	mockMessages.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+	if assert.Len(t, expired, 1) {
+		assert.Equal(t, int64(2), expired[0].Data["queue_id"])
+		assert.Equal(t, "628222", expired[0].Data["to"])
+	}
+}
+
+func TestQueuedMessageService_RetryDue_SendsHeldOnReconnect(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, now := newTestQueuedMessageService(t, mockMessages, mockQueue)
+	holdWhileOffline(service)
+
+	delivered := &domain.SendMessageRequest{To: "628111", Message: "a"}
+	dropped := &domain.SendMessageRequest{To: "628222", Message: "b"}
+	mockQueue.On("Due", now, sendQueueBatch).Return([]*domain.QueuedSend{
+		{ID: 1, Request: delivered, QueuedAt: now.Add(-time.Hour)},
+		{ID: 2, Request: dropped, QueuedAt: now.Add(-time.Hour)},
+	}, nil)
+	mockMessages.On("GetStatus", mock.Anything).Return(&domain.ServiceStatus{WhatsApp: domain.WhatsAppStatus{Connected: true}}, nil)
+	mockMessages.On("SendMessage", mock.Anything, delivered).Return(&domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil)
+	mockMessages.On("SendMessage", mock.Anything, dropped).
+		Return(&domain.SendMessageResponse{Success: false, Message: "WhatsApp client is not connected"}, domain.ErrWhatsAppNotConnected)
+
+	mockQueue.On("MarkSent", int64(1), 1).Return(nil)
+	// A sender dropping again does not use up the message's attempts
+	mockQueue.On("Retry", int64(2), 0, now.Add(time.Hour), "WhatsApp client is not connected").Return(nil)
+
+	// Act
+	service.retryDue(context.Background())
+
+	// Assert
+	mockQueue.AssertExpectations(t)
+	mockMessages.AssertExpectations(t)
+}
+
+func TestQueuedMessageService_SendMessage_HoldsOverQuota(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, now := newTestQueuedMessageService(t, mockMessages, mockQueue)
+	service.cfg.Quota = config.SendQuotaConfig{Location: time.UTC, QueueExceeded: true}
+
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap diambil", From: "628111"}
+	mockMessages.On("SendMessage", mock.Anything, req).
+		Return(&domain.SendMessageResponse{Success: false, Message: "Failed to send message: sender has reached its daily quota"}, domain.ErrSenderQuotaExceeded)
+	mockQueue.On("Enqueue", req, "", 0, now.Truncate(24*time.Hour).Add(24*time.Hour), "Failed to send message: sender has reached its daily quota").Return(int64(9), nil)
+
+	// Act
+	resp, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Queued)
+	assert.Equal(t, int64(9), resp.QueueID)
+	mockQueue.AssertExpectations(t)
+}
+
+func TestQueuedMessageService_SendMessage_RejectsOverQuota(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, _ := newTestQueuedMessageService(t, mockMessages, mockQueue)
+
+	req := &domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap diambil", From: "628111"}
+	mockMessages.On("SendMessage", mock.Anything, req).
+		Return(&domain.SendMessageResponse{Success: false}, domain.ErrSenderQuotaExceeded)
+
+	// Act
+	_, err := service.SendMessage(context.Background(), req)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrSenderQuotaExceeded)
+	mockQueue.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestQueuedMessageService_Backoff(t *testing.T) {
+	service, _ := newTestQueuedMessageService(t, &mocks.MockMessageService{}, &mocks.MockSendQueueRepository{})
+
+	assert.Equal(t, 10*time.Second, service.backoff(1))
+	assert.Equal(t, 20*time.Second, service.backoff(2))
+	assert.Equal(t, 25*time.Second, service.backoff(3)) // capped at MaxDelay
+	assert.Equal(t, 25*time.Second, service.backoff(40))
+}
+
+func TestQueuedMessageService_GetStatus_IncludesQueue(t *testing.T) {
+	// Arrange
+	mockMessages := &mocks.MockMessageService{}
+	mockQueue := &mocks.MockSendQueueRepository{}
+	service, _ := newTestQueuedMessageService(t, mockMessages, mockQueue)
+
+	mockMessages.On("GetStatus", mock.Anything).Return(&domain.ServiceStatus{WhatsApp: domain.WhatsAppStatus{Connected: false}}, nil)
+	mockQueue.On("Stats").Return(&domain.SendQueueStatus{Depth: 4, Failed: 1, FailedAttempts: 9}, nil)
+
+	// Act
+	status, err := service.GetStatus(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, status.Queue)
+	assert.Equal(t, 4, status.Queue.Depth)
+	assert.Equal(t, 1, status.Queue.Failed)
+}
+
+func TestIsTransientSendError(t *testing.T) {
+	assert.True(t, isTransientSendError(domain.ErrMessageSendFailed))
+	assert.True(t, isTransientSendError(domain.ErrWhatsAppNotConnected))
+	assert.False(t, isTransientSendError(domain.ErrInvalidPhoneNumber))
+	assert.False(t, isTransientSendError(errors.New("boom")))
+	assert.False(t, isTransientSendError(nil))
+}
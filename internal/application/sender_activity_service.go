@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+// Bounds on the days a sender activity report covers
+const (
+	defaultActivityDays = 7
+	maxActivityDays     = 90
+)
+
+type senderActivityService struct {
+	db *sql.DB
+}
+
+// NewSenderActivityService creates a new sender activity reporting service
+func NewSenderActivityService(db *sql.DB) domain.SenderActivityService {
+	return &senderActivityService{db: db}
+}
+
+// GetActivity sums the sender's hourly counts over the last query.Days days,
+// today included, into a weekday by hour heatmap
+func (s *senderActivityService) GetActivity(ctx context.Context, senderID string, query *domain.SenderActivityQuery) (*domain.SenderActivityReport, error) {
+	days := defaultActivityDays
+	if query != nil && query.Days != 0 {
+		days = query.Days
+	}
+	if days < 1 || days > maxActivityDays {
+		return nil, domain.ErrInvalidActivityQuery
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	from := today.AddDate(0, 0, 1-days)
+	activity, err := repository.GetSenderActivity(s.db, senderID, from, today.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	return senderActivityReport(senderID, from, today, activity), nil
+}
+
+// senderActivityReport lays hourly activity out on the week's 168 hours
+func senderActivityReport(senderID string, from, to time.Time, activity []repository.SenderActivityHour) *domain.SenderActivityReport {
+	report := &domain.SenderActivityReport{
+		SenderID: senderID,
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		Heatmap:  make([]domain.SenderActivityCell, 7*24),
+	}
+	for i := range report.Heatmap {
+		report.Heatmap[i].Weekday = i / 24
+		report.Heatmap[i].Hour = i % 24
+	}
+
+	for _, h := range activity {
+		cell := &report.Heatmap[int(h.Hour.Weekday())*24+h.Hour.Hour()]
+		cell.Sent += h.Sent
+		cell.Received += h.Received
+		report.TotalSent += h.Sent
+		report.TotalReceived += h.Received
+		if h.Sent+h.Received > 0 {
+			// Activity is oldest first
+			report.LastActiveAt = h.Hour.Format(time.RFC3339)
+		}
+	}
+	return report
+}
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+func TestSenderActivityService_GetActivity_InvalidDays(t *testing.T) {
+	service := NewSenderActivityService(nil)
+
+	for _, days := range []int{-1, maxActivityDays + 1} {
+		report, err := service.GetActivity(context.Background(), "6281234567890", &domain.SenderActivityQuery{Days: days})
+		assert.Nil(t, report)
+		assert.Equal(t, domain.ErrInvalidActivityQuery, err)
+	}
+}
+
+func TestSenderActivityReport(t *testing.T) {
+	// Arrange: two Fridays at 09:00 and a Saturday at 20:00
+	from := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	activity := []repository.SenderActivityHour{
+		{Hour: time.Date(2026, 10, 9, 9, 0, 0, 0, time.UTC), Sent: 40, Received: 3},
+		{Hour: time.Date(2026, 10, 10, 20, 0, 0, 0, time.UTC), Received: 5},
+		{Hour: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC), Sent: 10, Received: 1},
+	}
+
+	// Act
+	report := senderActivityReport("6281234567890", from, to, activity)
+
+	// Assert
+	require.Len(t, report.Heatmap, 168)
+	assert.Equal(t, "2026-10-02", report.From)
+	assert.Equal(t, "2026-10-16", report.To)
+	assert.Equal(t, domain.SenderActivityCell{Weekday: 5, Hour: 9, Sent: 50, Received: 4}, report.Heatmap[5*24+9])
+	assert.Equal(t, domain.SenderActivityCell{Weekday: 6, Hour: 20, Received: 5}, report.Heatmap[6*24+20])
+	assert.Equal(t, domain.SenderActivityCell{Weekday: 0, Hour: 0}, report.Heatmap[0])
+	assert.Equal(t, 50, report.TotalSent)
+	assert.Equal(t, 9, report.TotalReceived)
+	assert.Equal(t, "2026-10-16T09:00:00Z", report.LastActiveAt)
+}
+
+func TestSenderActivityReport_NoActivity(t *testing.T) {
+	day := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	report := senderActivityReport("6281234567890", day, day, nil)
+
+	assert.Len(t, report.Heatmap, 168)
+	assert.Zero(t, report.TotalSent)
+	assert.Zero(t, report.TotalReceived)
+	assert.Empty(t, report.LastActiveAt)
+}
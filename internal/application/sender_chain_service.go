@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wa-serv/internal/domain"
+)
+
+// messageCategoryPattern restricts categories to short slugs such as "transactional"
+var messageCategoryPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,49}$`)
+
+type senderChainService struct {
+	chains domain.SenderChainRepository
+}
+
+// NewSenderChainService creates a new sender fallback chain service
+func NewSenderChainService(chains domain.SenderChainRepository) domain.SenderChainService {
+	return &senderChainService{chains: chains}
+}
+
+// ListChains returns all configured chains sorted by category
+func (s *senderChainService) ListChains(ctx context.Context) ([]*domain.SenderFallbackChain, error) {
+	chains, err := s.chains.ListChains()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.SenderFallbackChain, 0, len(chains))
+	for category, senderIDs := range chains {
+		result = append(result, &domain.SenderFallbackChain{Category: category, SenderIDs: senderIDs})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Category < result[j].Category })
+	return result, nil
+}
+
+// SetChain replaces the chain for category; an empty list removes it
+func (s *senderChainService) SetChain(ctx context.Context, category string, req *domain.SetSenderFallbackChainRequest) (*domain.SenderFallbackChain, error) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	if !messageCategoryPattern.MatchString(category) || req == nil {
+		return nil, domain.ErrInvalidFallbackChain
+	}
+
+	senderIDs := make([]string, 0, len(req.SenderIDs))
+	seen := make(map[string]bool)
+	for _, id := range req.SenderIDs {
+		id = strings.TrimPrefix(strings.TrimSpace(id), "+")
+		if id == "" || seen[id] {
+			return nil, domain.ErrInvalidFallbackChain
+		}
+		seen[id] = true
+		senderIDs = append(senderIDs, id)
+	}
+
+	if err := s.chains.SetChain(category, senderIDs); err != nil {
+		return nil, err
+	}
+	return &domain.SenderFallbackChain{Category: category, SenderIDs: senderIDs}, nil
+}
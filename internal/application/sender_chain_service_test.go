@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSenderChainService_SetChain_Normalizes(t *testing.T) {
+	// Arrange
+	mockChains := &mocks.MockSenderChainRepository{}
+	service := NewSenderChainService(mockChains)
+	mockChains.On("SetChain", "transactional", []string{"6281111", "6282222"}).Return(nil)
+
+	// Act
+	chain, err := service.SetChain(context.Background(), " Transactional ", &domain.SetSenderFallbackChainRequest{
+		SenderIDs: []string{"+6281111", "6282222"},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "transactional", chain.Category)
+	assert.Equal(t, []string{"6281111", "6282222"}, chain.SenderIDs)
+	mockChains.AssertExpectations(t)
+}
+
+func TestSenderChainService_SetChain_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		category  string
+		senderIDs []string
+	}{
+		{"bad category", "Promo Blast!", []string{"6281111"}},
+		{"blank sender", "promo", []string{"6281111", " "}},
+		{"duplicate sender", "promo", []string{"6281111", "+6281111"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewSenderChainService(&mocks.MockSenderChainRepository{})
+			_, err := service.SetChain(context.Background(), tt.category, &domain.SetSenderFallbackChainRequest{SenderIDs: tt.senderIDs})
+			assert.Equal(t, domain.ErrInvalidFallbackChain, err)
+		})
+	}
+}
+
+func TestSenderChainService_ListChains_SortedByCategory(t *testing.T) {
+	// Arrange
+	mockChains := &mocks.MockSenderChainRepository{}
+	service := NewSenderChainService(mockChains)
+	mockChains.On("ListChains").Return(map[string][]string{
+		"transactional": {"6281111"},
+		"default":       {"6282222", "6281111"},
+	}, nil)
+
+	// Act
+	chains, err := service.ListChains(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, chains, 2)
+	assert.Equal(t, "default", chains[0].Category)
+	assert.Equal(t, "transactional", chains[1].Category)
+}
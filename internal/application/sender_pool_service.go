@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/wa-serv/internal/domain"
+)
+
+type senderPoolService struct {
+	pools domain.SenderPoolRepository
+}
+
+// NewSenderPoolService creates a new named sender pool service
+func NewSenderPoolService(pools domain.SenderPoolRepository) domain.SenderPoolService {
+	return &senderPoolService{pools: pools}
+}
+
+// ListPools returns all pools sorted by name
+func (s *senderPoolService) ListPools(ctx context.Context) ([]*domain.SenderPool, error) {
+	pools, err := s.pools.ListPools()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.SenderPool, 0, len(pools))
+	for name, senderIDs := range pools {
+		result = append(result, &domain.SenderPool{Name: name, SenderIDs: senderIDs})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// SetPool replaces the members of pool name; an empty list removes it. Pool
+// names are slugs like message categories, such as "sales" or "support".
+func (s *senderPoolService) SetPool(ctx context.Context, name string, req *domain.SetSenderPoolRequest) (*domain.SenderPool, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !messageCategoryPattern.MatchString(name) || req == nil {
+		return nil, domain.ErrInvalidSenderPool
+	}
+
+	senderIDs := make([]string, 0, len(req.SenderIDs))
+	seen := make(map[string]bool)
+	for _, id := range req.SenderIDs {
+		id = strings.TrimPrefix(strings.TrimSpace(id), "+")
+		if id == "" || seen[id] {
+			return nil, domain.ErrInvalidSenderPool
+		}
+		seen[id] = true
+		senderIDs = append(senderIDs, id)
+	}
+
+	if err := s.pools.SetPool(name, senderIDs); err != nil {
+		return nil, err
+	}
+	return &domain.SenderPool{Name: name, SenderIDs: senderIDs}, nil
+}
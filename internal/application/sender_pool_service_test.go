@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSenderPoolService_SetPool_Normalizes(t *testing.T) {
+	// Arrange
+	mockPools := &mocks.MockSenderPoolRepository{}
+	service := NewSenderPoolService(mockPools)
+	mockPools.On("SetPool", "sales", []string{"6281111", "6282222"}).Return(nil)
+
+	// Act
+	pool, err := service.SetPool(context.Background(), " Sales ", &domain.SetSenderPoolRequest{
+		SenderIDs: []string{"+6281111", "6282222"},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "sales", pool.Name)
+	assert.Equal(t, []string{"6281111", "6282222"}, pool.SenderIDs)
+	mockPools.AssertExpectations(t)
+}
+
+func TestSenderPoolService_SetPool_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		pool      string
+		senderIDs []string
+	}{
+		{"bad name", "Sales Team!", []string{"6281111"}},
+		{"blank sender", "sales", []string{"6281111", " "}},
+		{"duplicate sender", "sales", []string{"6281111", "+6281111"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewSenderPoolService(&mocks.MockSenderPoolRepository{})
+			_, err := service.SetPool(context.Background(), tt.pool, &domain.SetSenderPoolRequest{SenderIDs: tt.senderIDs})
+			assert.Equal(t, domain.ErrInvalidSenderPool, err)
+		})
+	}
+}
+
+func TestSenderPoolService_ListPools_SortedByName(t *testing.T) {
+	// Arrange
+	mockPools := &mocks.MockSenderPoolRepository{}
+	service := NewSenderPoolService(mockPools)
+	mockPools.On("ListPools").Return(map[string][]string{
+		"support": {"6281111"},
+		"sales":   {"6282222", "6281111"},
+	}, nil)
+
+	// Act
+	pools, err := service.ListPools(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, pools, 2)
+	assert.Equal(t, "sales", pools[0].Name)
+	assert.Equal(t, "support", pools[1].Name)
+}
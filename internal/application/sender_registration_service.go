@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -12,6 +13,8 @@ import (
 	qrcode "github.com/skip2/go-qrcode"
 	"github.com/wa-serv/internal/domain"
 	"github.com/wa-serv/repository"
+	"github.com/wa-serv/requestid"
+	"github.com/wa-serv/webhook"
 	"github.com/wa-serv/whatsapp"
 	"go.mau.fi/whatsmeow"
 	waCompanionReg "go.mau.fi/whatsmeow/proto/waCompanionReg"
@@ -30,8 +33,12 @@ type RegistrationSession struct {
 	QRCode      string
 	PairingCode string
 	PhoneNumber string
+	Method      string // qr or code
+	RequestID   string // X-Request-ID of the call that started the registration
 	CreatedAt   time.Time
 	mu          sync.RWMutex
+
+	connectedNotified bool // registration.connected webhook already sent
 }
 
 // SenderRegistrationService implements sender registration business logic
@@ -58,9 +65,10 @@ func (s *SenderRegistrationService) StartQRRegistration(ctx context.Context) (*d
 	// Create a new device store for the new phone number
 	deviceStore := s.clientManager.GetContainer().NewDevice()
 
-	// Set custom device name and platform type before pairing
+	// Set custom device name, platform type and history sync limits before pairing
 	store.DeviceProps.Os = proto.String(whatsapp.DeviceName)
 	store.DeviceProps.PlatformType = waCompanionReg.DeviceProps_DESKTOP.Enum()
+	whatsapp.ApplyHistorySyncConfig()
 
 	logLevel := whatsapp.GetLogLevel()
 	clientLog := waLog.Stdout("RegisterSession", logLevel, true)
@@ -76,6 +84,8 @@ func (s *SenderRegistrationService) StartQRRegistration(ctx context.Context) (*d
 		SessionID: sessionID,
 		Client:    client,
 		Status:    "pending",
+		Method:    "qr",
+		RequestID: requestid.FromContext(ctx),
 		CreatedAt: time.Now(),
 	}
 
@@ -91,18 +101,23 @@ func (s *SenderRegistrationService) StartQRRegistration(ctx context.Context) (*d
 				// Register sender in database
 				s.registerSender(session.SenderID, client.Store.ID.User)
 			}
+			s.emitRegistrationEvent(webhook.EventRegistrationPaired, session, "")
 			session.mu.Unlock()
 		case *events.LoggedOut:
 			session.mu.Lock()
-			session.Status = "failed"
+			s.failSession(session, "logged_out")
 			session.mu.Unlock()
 		case *events.Connected:
-			// Client connected to WhatsApp servers
+			// Client connected to WhatsApp servers; after pairing this means
+			// the new sender is online
+			session.mu.Lock()
+			s.notifyConnected(session)
+			session.mu.Unlock()
 		case *events.Disconnected:
 			// Only mark as failed if not already connected
 			session.mu.Lock()
 			if session.Status == "pending" {
-				session.Status = "failed"
+				s.failSession(session, "disconnected")
 			}
 			session.mu.Unlock()
 		}
@@ -123,6 +138,7 @@ func (s *SenderRegistrationService) StartQRRegistration(ctx context.Context) (*d
 	}()
 	qrChan, err := client.GetQRChannel(qrCtx)
 	if err != nil {
+		s.failSessionLocked(session, "qr_channel_error")
 		return &domain.RegisterSenderQRResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to get QR channel: %v", err),
@@ -131,6 +147,7 @@ func (s *SenderRegistrationService) StartQRRegistration(ctx context.Context) (*d
 
 	// Connect the client
 	if err := client.Connect(); err != nil {
+		s.failSessionLocked(session, "connect_error")
 		return &domain.RegisterSenderQRResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to connect: %v", err),
@@ -178,6 +195,12 @@ func (s *SenderRegistrationService) StartQRRegistration(ctx context.Context) (*d
 				fmt.Println("Waiting for pairing to complete...")
 			} else {
 				fmt.Printf("QR Event: %s\n", evt.Event)
+				// Any other event (timeout, err-*) ends the QR flow without pairing
+				session.mu.Lock()
+				if session.Status == "pending" {
+					s.failSession(session, "qr_"+evt.Event)
+				}
+				session.mu.Unlock()
 			}
 		}
 		fmt.Println("QR Channel closed")
@@ -191,6 +214,7 @@ func (s *SenderRegistrationService) StartQRRegistration(ctx context.Context) (*d
 	case <-time.After(10 * time.Second):
 		// Timeout waiting for QR code - increased from 5 to 10 seconds
 		fmt.Println("Timeout waiting for QR code")
+		s.failSessionLocked(session, "qr_timeout")
 		client.Disconnect()
 		return &domain.RegisterSenderQRResponse{
 			Success: false,
@@ -203,6 +227,10 @@ func (s *SenderRegistrationService) StartQRRegistration(ctx context.Context) (*d
 	s.sessions[sessionID] = session
 	s.sessionsMu.Unlock()
 
+	session.mu.Lock()
+	s.emitRegistrationEvent(webhook.EventRegistrationCreated, session, "")
+	session.mu.Unlock()
+
 	// Clean up old sessions (older than 10 minutes)
 	go s.cleanupOldSessions()
 
@@ -238,9 +266,10 @@ func (s *SenderRegistrationService) StartCodeRegistration(ctx context.Context, r
 	// Create a new device store for the new phone number
 	deviceStore := s.clientManager.GetContainer().NewDevice()
 
-	// Set custom device name and platform type before pairing
+	// Set custom device name, platform type and history sync limits before pairing
 	store.DeviceProps.Os = proto.String(whatsapp.DeviceName)
 	store.DeviceProps.PlatformType = waCompanionReg.DeviceProps_DESKTOP.Enum()
+	whatsapp.ApplyHistorySyncConfig()
 
 	logLevel := whatsapp.GetLogLevel()
 	clientLog := waLog.Stdout("RegisterSession", logLevel, true)
@@ -257,6 +286,8 @@ func (s *SenderRegistrationService) StartCodeRegistration(ctx context.Context, r
 		Client:      client,
 		Status:      "pending",
 		PhoneNumber: cleanedPhone,
+		Method:      "code",
+		RequestID:   requestid.FromContext(ctx),
 		CreatedAt:   time.Now(),
 	}
 
@@ -272,10 +303,15 @@ func (s *SenderRegistrationService) StartCodeRegistration(ctx context.Context, r
 				// Register sender in database
 				s.registerSender(session.SenderID, cleanedPhone)
 			}
+			s.emitRegistrationEvent(webhook.EventRegistrationPaired, session, "")
 			session.mu.Unlock()
 		case *events.LoggedOut:
 			session.mu.Lock()
-			session.Status = "failed"
+			s.failSession(session, "logged_out")
+			session.mu.Unlock()
+		case *events.Connected:
+			session.mu.Lock()
+			s.notifyConnected(session)
 			session.mu.Unlock()
 		}
 
@@ -286,6 +322,7 @@ func (s *SenderRegistrationService) StartCodeRegistration(ctx context.Context, r
 
 	// Connect first
 	if err := client.Connect(); err != nil {
+		s.failSessionLocked(session, "connect_error")
 		return &domain.RegisterSenderCodeResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to connect: %v", err),
@@ -295,6 +332,7 @@ func (s *SenderRegistrationService) StartCodeRegistration(ctx context.Context, r
 	// Request pairing code
 	code, err := client.PairPhone(ctx, cleanedPhone, true, whatsmeow.PairClientChrome, "Chrome (Linux)")
 	if err != nil {
+		s.failSessionLocked(session, "pairing_code_error")
 		client.Disconnect()
 		return &domain.RegisterSenderCodeResponse{
 			Success: false,
@@ -302,13 +340,19 @@ func (s *SenderRegistrationService) StartCodeRegistration(ctx context.Context, r
 		}, err
 	}
 
+	session.mu.Lock()
 	session.PairingCode = code
+	session.mu.Unlock()
 
 	// Store session
 	s.sessionsMu.Lock()
 	s.sessions[sessionID] = session
 	s.sessionsMu.Unlock()
 
+	session.mu.Lock()
+	s.emitRegistrationEvent(webhook.EventRegistrationCreated, session, "")
+	session.mu.Unlock()
+
 	// Clean up old sessions
 	go s.cleanupOldSessions()
 
@@ -380,6 +424,45 @@ func (s *SenderRegistrationService) GetRegistrationStatus(ctx context.Context, s
 	return response, nil
 }
 
+// DeleteSender disconnects a sender, deletes its session and marks it inactive.
+// The sender has to be registered again to send.
+func (s *SenderRegistrationService) DeleteSender(ctx context.Context, senderID string) error {
+	if err := s.clientManager.RemoveClient(senderID); err != nil {
+		if errors.Is(err, whatsapp.ErrClientNotFound) {
+			return domain.ErrSenderNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ClearSessions cancels the pending registrations, then disconnects every
+// sender, deletes its session and marks it inactive, as DeleteSender does one
+// at a time. Senders that fail to be removed are reported in the error and the
+// rest are still removed.
+func (s *SenderRegistrationService) ClearSessions(ctx context.Context) ([]string, error) {
+	s.sessionsMu.Lock()
+	for sessionID, session := range s.sessions {
+		s.failSessionLocked(session, "cleared")
+		if session.Client != nil {
+			session.Client.Disconnect()
+		}
+		delete(s.sessions, sessionID)
+	}
+	s.sessionsMu.Unlock()
+
+	removed := []string{}
+	var errs []error
+	for _, senderID := range s.clientManager.ListClients() {
+		if err := s.clientManager.RemoveClient(senderID); err != nil {
+			errs = append(errs, fmt.Errorf("sender %s: %w", senderID, err))
+			continue
+		}
+		removed = append(removed, senderID)
+	}
+	return removed, errors.Join(errs...)
+}
+
 // registerSender creates a sender record in the database
 func (s *SenderRegistrationService) registerSender(senderID, phoneNumber string) {
 	name := fmt.Sprintf("Sender %s", senderID)
@@ -394,6 +477,53 @@ func (s *SenderRegistrationService) registerSender(senderID, phoneNumber string)
 	}
 }
 
+// emitRegistrationEvent sends a registration lifecycle webhook for session,
+// traced to the call that started it. Callers must hold session.mu.
+func (s *SenderRegistrationService) emitRegistrationEvent(eventType string, session *RegistrationSession, reason string) {
+	data := map[string]any{
+		"session_id": session.SessionID,
+		"method":     session.Method,
+		"status":     session.Status,
+	}
+	if session.PhoneNumber != "" {
+		data["phone_number"] = session.PhoneNumber
+	}
+	if session.SenderID != "" {
+		data["sender_id"] = session.SenderID
+	}
+	if reason != "" {
+		data["reason"] = reason
+	}
+	webhook.EmitRequest(session.RequestID, eventType, data)
+}
+
+// failSession marks session as failed and emits registration.failed once.
+// Callers must hold session.mu.
+func (s *SenderRegistrationService) failSession(session *RegistrationSession, reason string) {
+	if session.Status == "failed" {
+		return
+	}
+	session.Status = "failed"
+	s.emitRegistrationEvent(webhook.EventRegistrationFailed, session, reason)
+}
+
+// failSessionLocked is failSession for callers that do not hold session.mu
+func (s *SenderRegistrationService) failSessionLocked(session *RegistrationSession, reason string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	s.failSession(session, reason)
+}
+
+// notifyConnected emits registration.connected the first time a paired
+// session's client comes online. Callers must hold session.mu.
+func (s *SenderRegistrationService) notifyConnected(session *RegistrationSession) {
+	if session.SenderID == "" || session.connectedNotified {
+		return
+	}
+	session.connectedNotified = true
+	s.emitRegistrationEvent(webhook.EventRegistrationConnected, session, "")
+}
+
 // cleanupOldSessions removes sessions older than 10 minutes
 func (s *SenderRegistrationService) cleanupOldSessions() {
 	s.sessionsMu.Lock()
@@ -402,6 +532,11 @@ func (s *SenderRegistrationService) cleanupOldSessions() {
 	cutoff := time.Now().Add(-10 * time.Minute)
 	for sessionID, session := range s.sessions {
 		if session.CreatedAt.Before(cutoff) {
+			session.mu.Lock()
+			if session.Status == "pending" {
+				s.failSession(session, "expired")
+			}
+			session.mu.Unlock()
 			if session.Client != nil {
 				session.Client.Disconnect()
 			}
@@ -0,0 +1,223 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+	"github.com/wa-serv/whatsapp"
+)
+
+// Limits on the labels of a sender
+const (
+	maxSenderNameLength        = 100
+	maxSenderDescriptionLength = 500
+	maxSenderTags              = 20
+	maxSenderTagLength         = 50
+	maxSenderDailyQuota        = 100000
+)
+
+type senderService struct {
+	db      *sql.DB
+	clients domain.SenderClients
+	quota   config.SendQuotaConfig
+	now     func() time.Time
+}
+
+// NewSenderService creates a service managing the senders in the database
+// and their connected clients. Daily send quotas reset at midnight in the
+// time zone of quota.
+func NewSenderService(db *sql.DB, clients domain.SenderClients, quota config.SendQuotaConfig) domain.SenderService {
+	return &senderService{db: db, clients: clients, quota: quota, now: time.Now}
+}
+
+// UpdateSender replaces the name, description and tags of a sender, so
+// "Sender 628123..." can be shown as the branch it serves
+func (s *senderService) UpdateSender(ctx context.Context, senderID string, req *domain.UpdateSenderRequest) (*domain.Sender, error) {
+	name, description, tags, err := validateSenderProfile(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repository.UpdateSenderProfile(s.db, senderID, name, description, tags); err != nil {
+		return nil, senderError(err)
+	}
+	return s.getSender(senderID)
+}
+
+// PauseSender takes a sender out of routing, during number warm-up or an
+// investigation, without logging it out
+func (s *senderService) PauseSender(ctx context.Context, senderID string) (*domain.Sender, error) {
+	if err := s.clients.PauseSender(senderID); err != nil {
+		return nil, senderError(err)
+	}
+	return s.getSender(senderID)
+}
+
+// ResumeSender puts a paused sender back into routing
+func (s *senderService) ResumeSender(ctx context.Context, senderID string) (*domain.Sender, error) {
+	if err := s.clients.ResumeSender(senderID); err != nil {
+		return nil, senderError(err)
+	}
+	return s.getSender(senderID)
+}
+
+// GetSenderHealth combines the live state of a sender's client with the last
+// send and the last lost connection recorded in the database
+func (s *senderService) GetSenderHealth(ctx context.Context, senderID string) (*domain.SenderHealth, error) {
+	sender, err := repository.GetSenderByID(s.db, senderID)
+	if err != nil {
+		return nil, senderError(err)
+	}
+
+	connected, loggedIn, pushName := s.clients.SenderConnection(senderID)
+	return senderHealth(*sender, connected, loggedIn, pushName), nil
+}
+
+// ReconnectSender drops the connection of a sender operators see stuck and
+// opens a new one with the same session, without restarting the service, and
+// returns its health after. Logging in again completes in the background, so
+// logged_in may still be false.
+func (s *senderService) ReconnectSender(ctx context.Context, senderID string) (*domain.SenderHealth, error) {
+	if err := s.clients.ReconnectSender(senderID); err != nil {
+		switch {
+		case errors.Is(err, whatsapp.ErrClientNotFound):
+			return nil, domain.ErrSenderNotFound
+		case errors.Is(err, whatsapp.ErrNoSession):
+			return nil, domain.ErrSenderNoSession
+		case errors.Is(err, whatsapp.ErrReconnectTooSoon):
+			return nil, domain.ErrReconnectTooSoon
+		}
+		return nil, err
+	}
+	return s.GetSenderHealth(ctx, senderID)
+}
+
+// GetSenderQuota reports a sender's daily send quota and the messages it has
+// sent since midnight
+func (s *senderService) GetSenderQuota(ctx context.Context, senderID string) (*domain.SenderQuota, error) {
+	now := s.now()
+	quota, sent, err := repository.GetSenderQuotaUsage(s.db, senderID, s.quota.Day(now))
+	if err != nil {
+		return nil, senderError(err)
+	}
+
+	usage := &domain.SenderQuota{
+		SenderID:   senderID,
+		DailyQuota: quota,
+		SentToday:  sent,
+		ResetsAt:   s.quota.NextReset(now),
+	}
+	if quota > 0 {
+		remaining := max(quota-sent, 0)
+		usage.Remaining = &remaining
+	}
+	return usage, nil
+}
+
+// SetSenderQuota caps the messages a sender may send a day, to stay within
+// the volume WhatsApp tolerates from one number; 0 removes the cap. Sends past
+// it are refused, or queued until midnight when SEND_QUOTA_EXCEEDED is queue.
+func (s *senderService) SetSenderQuota(ctx context.Context, senderID string, req *domain.SetSenderQuotaRequest) (*domain.SenderQuota, error) {
+	if req == nil || req.DailyQuota < 0 || req.DailyQuota > maxSenderDailyQuota {
+		return nil, fmt.Errorf("%w: daily_quota must be 0-%d", domain.ErrInvalidSenderQuota, maxSenderDailyQuota)
+	}
+	if err := repository.SetSenderDailyQuota(s.db, senderID, req.DailyQuota); err != nil {
+		return nil, senderError(err)
+	}
+	return s.GetSenderQuota(ctx, senderID)
+}
+
+// senderHealth is the health of sender given the live state of its client
+func senderHealth(sender repository.Sender, connected, loggedIn bool, pushName string) *domain.SenderHealth {
+	health := &domain.SenderHealth{
+		SenderID:             sender.SenderID,
+		PhoneNumber:          sender.PhoneNumber,
+		Healthy:              connected && loggedIn && sender.State == repository.SenderStateActive && !sender.IsPaused,
+		Connected:            connected,
+		LoggedIn:             loggedIn,
+		PushName:             pushName,
+		State:                sender.State,
+		IsPaused:             sender.IsPaused,
+		LastDisconnectReason: sender.LastDisconnectReason,
+	}
+	if sender.LastSentAt.Valid {
+		health.LastSentAt = &sender.LastSentAt.Time
+	}
+	if sender.LastDisconnectedAt.Valid {
+		health.LastDisconnectedAt = &sender.LastDisconnectedAt.Time
+	}
+	return health
+}
+
+func (s *senderService) getSender(senderID string) (*domain.Sender, error) {
+	sender, err := repository.GetSenderByID(s.db, senderID)
+	if err != nil {
+		return nil, senderError(err)
+	}
+	return toDomainSender(*sender), nil
+}
+
+// senderError reports a sender missing from the database as ErrSenderNotFound
+func senderError(err error) error {
+	if errors.Is(err, repository.ErrSenderNotFound) {
+		return domain.ErrSenderNotFound
+	}
+	return err
+}
+
+// validateSenderProfile trims the labels of req and drops repeated tags,
+// however they are capitalized
+func validateSenderProfile(req *domain.UpdateSenderRequest) (name, description string, tags []string, err error) {
+	if req == nil {
+		return "", "", nil, domain.ErrInvalidSender
+	}
+	name = strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxSenderNameLength {
+		return "", "", nil, fmt.Errorf("%w: name is required and at most %d characters", domain.ErrInvalidSender, maxSenderNameLength)
+	}
+	description = strings.TrimSpace(req.Description)
+	if len(description) > maxSenderDescriptionLength {
+		return "", "", nil, fmt.Errorf("%w: description must be at most %d characters", domain.ErrInvalidSender, maxSenderDescriptionLength)
+	}
+
+	tags = []string{}
+	seen := make(map[string]bool)
+	for _, tag := range req.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || len(tag) > maxSenderTagLength || strings.Contains(tag, ",") {
+			return "", "", nil, fmt.Errorf("%w: tags must be 1-%d characters without commas", domain.ErrInvalidSender, maxSenderTagLength)
+		}
+		if seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		tags = append(tags, tag)
+	}
+	if len(tags) > maxSenderTags {
+		return "", "", nil, fmt.Errorf("%w: at most %d tags", domain.ErrInvalidSender, maxSenderTags)
+	}
+	return name, description, tags, nil
+}
+
+func toDomainSender(sender repository.Sender) *domain.Sender {
+	return &domain.Sender{
+		ID:          sender.SenderID,
+		PhoneNumber: sender.PhoneNumber,
+		Name:        sender.Name,
+		Description: sender.Description,
+		Tags:        sender.Tags,
+		IsDefault:   sender.IsDefault,
+		IsActive:    sender.IsActive,
+		IsPaused:    sender.IsPaused,
+		State:       sender.State,
+		StateReason: sender.StateReason,
+		DailyQuota:  sender.DailyQuota,
+	}
+}
@@ -0,0 +1,162 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/repository"
+	"github.com/wa-serv/whatsapp"
+)
+
+func TestValidateSenderProfile(t *testing.T) {
+	name, description, tags, err := validateSenderProfile(&domain.UpdateSenderRequest{
+		Name:        "  Cabang Bandung ",
+		Description: " Nomor pelanggan cabang Dago ",
+		Tags:        []string{"bandung", " Dago ", "Bandung"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Cabang Bandung", name)
+	assert.Equal(t, "Nomor pelanggan cabang Dago", description)
+	assert.Equal(t, []string{"bandung", "Dago"}, tags)
+}
+
+func TestValidateSenderProfile_NoTags(t *testing.T) {
+	_, _, tags, err := validateSenderProfile(&domain.UpdateSenderRequest{Name: "Cabang Bandung"})
+
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+}
+
+func TestValidateSenderProfile_Invalid(t *testing.T) {
+	tooManyTags := make([]string, 0, maxSenderTags+1)
+	for i := 0; i <= maxSenderTags; i++ {
+		tooManyTags = append(tooManyTags, string(rune('a'+i)))
+	}
+
+	tests := []struct {
+		name string
+		req  *domain.UpdateSenderRequest
+	}{
+		{"nil request", nil},
+		{"blank name", &domain.UpdateSenderRequest{Name: "  "}},
+		{"long name", &domain.UpdateSenderRequest{Name: string(make([]byte, maxSenderNameLength+1))}},
+		{"long description", &domain.UpdateSenderRequest{Name: "Bandung", Description: string(make([]byte, maxSenderDescriptionLength+1))}},
+		{"blank tag", &domain.UpdateSenderRequest{Name: "Bandung", Tags: []string{" "}}},
+		{"tag with comma", &domain.UpdateSenderRequest{Name: "Bandung", Tags: []string{"jawa,barat"}}},
+		{"too many tags", &domain.UpdateSenderRequest{Name: "Bandung", Tags: tooManyTags}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := validateSenderProfile(tt.req)
+			assert.ErrorIs(t, err, domain.ErrInvalidSender)
+		})
+	}
+}
+
+func TestSenderService_PauseSender_NotFound(t *testing.T) {
+	clients := &mocks.MockSenderClients{}
+	clients.On("PauseSender", "628123456789").Return(fmt.Errorf("failed to pause sender 628123456789: %w", repository.ErrSenderNotFound))
+	service := NewSenderService(nil, clients, config.SendQuotaConfig{})
+
+	_, err := service.PauseSender(context.Background(), "628123456789")
+
+	assert.Equal(t, domain.ErrSenderNotFound, err)
+	clients.AssertExpectations(t)
+}
+
+func TestSenderService_ResumeSender_Fails(t *testing.T) {
+	clients := &mocks.MockSenderClients{}
+	clients.On("ResumeSender", "628123456789").Return(errors.New("database is down"))
+	service := NewSenderService(nil, clients, config.SendQuotaConfig{})
+
+	_, err := service.ResumeSender(context.Background(), "628123456789")
+
+	assert.EqualError(t, err, "database is down")
+	clients.AssertExpectations(t)
+}
+
+func TestSenderHealth(t *testing.T) {
+	sentAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	active := repository.Sender{
+		SenderID:             "628123456789",
+		PhoneNumber:          "628123456789",
+		State:                repository.SenderStateActive,
+		LastSentAt:           sql.NullTime{Time: sentAt, Valid: true},
+		LastDisconnectReason: "disconnected",
+	}
+	paused := active
+	paused.IsPaused = true
+	banned := active
+	banned.State = repository.SenderStateBanned
+
+	tests := []struct {
+		name      string
+		sender    repository.Sender
+		connected bool
+		loggedIn  bool
+		healthy   bool
+	}{
+		{"connected and logged in", active, true, true, true},
+		{"disconnected", active, false, true, false},
+		{"logged out", active, true, false, false},
+		{"paused", paused, true, true, false},
+		{"banned", banned, true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := senderHealth(tt.sender, tt.connected, tt.loggedIn, "Laundry Bersih")
+
+			assert.Equal(t, tt.healthy, health.Healthy)
+			assert.Equal(t, "Laundry Bersih", health.PushName)
+			assert.Equal(t, &sentAt, health.LastSentAt)
+			assert.Equal(t, "disconnected", health.LastDisconnectReason)
+			assert.Nil(t, health.LastDisconnectedAt)
+		})
+	}
+}
+
+func TestSenderService_SetSenderQuota_Invalid(t *testing.T) {
+	service := NewSenderService(nil, &mocks.MockSenderClients{}, config.SendQuotaConfig{})
+
+	for _, quota := range []int{-1, maxSenderDailyQuota + 1} {
+		_, err := service.SetSenderQuota(context.Background(), "628123456789", &domain.SetSenderQuotaRequest{DailyQuota: quota})
+		assert.ErrorIs(t, err, domain.ErrInvalidSenderQuota)
+	}
+}
+
+func TestSenderService_ReconnectSender_Fails(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"no client", fmt.Errorf("%w: 628123456789", whatsapp.ErrClientNotFound), domain.ErrSenderNotFound},
+		{"no session", fmt.Errorf("%w: 628123456789", whatsapp.ErrNoSession), domain.ErrSenderNoSession},
+		{"too soon", fmt.Errorf("%w: 628123456789", whatsapp.ErrReconnectTooSoon), domain.ErrReconnectTooSoon},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clients := &mocks.MockSenderClients{}
+			clients.On("ReconnectSender", "628123456789").Return(tt.err)
+			service := NewSenderService(nil, clients, config.SendQuotaConfig{})
+
+			_, err := service.ReconnectSender(context.Background(), "628123456789")
+
+			assert.Equal(t, tt.want, err)
+			clients.AssertExpectations(t)
+		})
+	}
+}
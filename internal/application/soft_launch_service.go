@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+// The inbound commands, as the bot records them, of showing the menu and of
+// picking one of its options
+const softLaunchMenuCommand = "menu"
+
+var softLaunchOptionCommands = []string{
+	"check_points", "redeem_instructions", "rewards", "pickup_slots", "stamp_card", "order_start", "branches",
+}
+
+// softLaunchCompletionWindow is how soon after the menu an option must be
+// picked to count as completing it
+const softLaunchCompletionWindow = 30 * time.Minute
+
+type softLaunchService struct {
+	db      *sql.DB
+	percent int
+}
+
+// NewSoftLaunchService creates a service comparing the soft-launch variants
+// in the inbound event log, with percent of conversations on the new flow
+func NewSoftLaunchService(db *sql.DB, percent int) domain.SoftLaunchService {
+	return &softLaunchService{db: db, percent: percent}
+}
+
+// GetReport returns the completion rate of each variant over the last
+// query.Days days, today included
+func (s *softLaunchService) GetReport(ctx context.Context, query *domain.SoftLaunchQuery) (*domain.SoftLaunchReport, error) {
+	days := defaultActivityDays
+	if query != nil && query.Days != 0 {
+		days = query.Days
+	}
+	if days < 1 || days > maxActivityDays {
+		return nil, domain.ErrInvalidActivityQuery
+	}
+
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1-days)
+	completions, err := repository.GetVariantCompletions(s.db, softLaunchMenuCommand, softLaunchOptionCommands, from, softLaunchCompletionWindow)
+	if err != nil {
+		return nil, err
+	}
+	return softLaunchReport(s.percent, from, completions), nil
+}
+
+func softLaunchReport(percent int, from time.Time, completions []repository.VariantCompletion) *domain.SoftLaunchReport {
+	report := &domain.SoftLaunchReport{
+		Percent:  percent,
+		From:     from.Format("2006-01-02"),
+		Variants: make([]domain.SoftLaunchVariant, 0, len(completions)),
+	}
+	for _, c := range completions {
+		variant := domain.SoftLaunchVariant{Variant: c.Variant, Conversations: c.Conversations, Completions: c.Completions}
+		if c.Conversations > 0 {
+			variant.CompletionRate = float64(c.Completions) / float64(c.Conversations)
+		}
+		report.Variants = append(report.Variants, variant)
+	}
+	return report
+}
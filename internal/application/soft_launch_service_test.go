@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+func TestSoftLaunchService_GetReport_InvalidDays(t *testing.T) {
+	service := NewSoftLaunchService(nil, 20)
+
+	for _, days := range []int{-1, 91} {
+		report, err := service.GetReport(context.Background(), &domain.SoftLaunchQuery{Days: days})
+		assert.Nil(t, report)
+		assert.Equal(t, domain.ErrInvalidActivityQuery, err)
+	}
+}
+
+func TestSoftLaunchReport(t *testing.T) {
+	from := time.Date(2026, 10, 10, 0, 0, 0, 0, time.Local)
+
+	report := softLaunchReport(20, from, []repository.VariantCompletion{
+		{Variant: "button_menu", Conversations: 40, Completions: 30},
+		{Variant: "legacy", Conversations: 160, Completions: 96},
+	})
+
+	assert.Equal(t, &domain.SoftLaunchReport{
+		Percent: 20,
+		From:    "2026-10-10",
+		Variants: []domain.SoftLaunchVariant{
+			{Variant: "button_menu", Conversations: 40, Completions: 30, CompletionRate: 0.75},
+			{Variant: "legacy", Conversations: 160, Completions: 96, CompletionRate: 0.6},
+		},
+	}, report)
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type staffService struct {
+	db *sql.DB
+}
+
+// NewStaffService creates a new staff activity reporting service
+func NewStaffService(db *sql.DB) domain.StaffService {
+	return &staffService{db: db}
+}
+
+// GetDailyActivity reports the points and stamps each admin phone entered on
+// date, by phone number
+func (s *staffService) GetDailyActivity(ctx context.Context, date string) (*domain.StaffActivityReport, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, domain.ErrInvalidDate
+	}
+
+	activity, err := repository.GetStaffActivity(s.db, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.StaffActivityReport{
+		Date:  day.Format("2006-01-02"),
+		Staff: make([]domain.StaffActivity, 0, len(activity)),
+	}
+	for _, a := range activity {
+		report.Staff = append(report.Staff, toDomainStaffActivity(a))
+	}
+	return report, nil
+}
+
+// staffAnomalies lists what a manager should look into about a staff member's
+// day. It is never nil so the report always carries the field.
+func staffAnomalies(a repository.StaffActivity) []string {
+	anomalies := []string{}
+	if a.SelfCredits > 0 {
+		anomalies = append(anomalies, domain.AnomalySelfCredit)
+	}
+	return anomalies
+}
+
+func toDomainStaffActivity(a repository.StaffActivity) domain.StaffActivity {
+	return domain.StaffActivity{
+		PhoneNumber:    a.PhoneNumber,
+		PointsCredits:  a.PointsCredits,
+		PointsCredited: a.PointsCredited,
+		StampEntries:   a.StampEntries,
+		StampsAdded:    a.StampsAdded,
+		MembersServed:  a.MembersServed,
+		SelfCredits:    a.SelfCredits,
+		FirstEntryAt:   a.FirstEntryAt.Format(time.RFC3339),
+		LastEntryAt:    a.LastEntryAt.Format(time.RFC3339),
+		Anomalies:      staffAnomalies(a),
+	}
+}
@@ -0,0 +1,36 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+func TestStaffService_GetDailyActivity_InvalidDate(t *testing.T) {
+	service := NewStaffService(nil)
+
+	report, err := service.GetDailyActivity(context.Background(), "16-10-2026")
+
+	assert.Nil(t, report)
+	assert.Equal(t, domain.ErrInvalidDate, err)
+}
+
+func TestStaffAnomalies(t *testing.T) {
+	tests := []struct {
+		name     string
+		activity repository.StaffActivity
+		want     []string
+	}{
+		{"clean day", repository.StaffActivity{PointsCredits: 12, MembersServed: 10}, []string{}},
+		{"credited themselves", repository.StaffActivity{PointsCredits: 12, SelfCredits: 1}, []string{domain.AnomalySelfCredit}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, staffAnomalies(tt.activity))
+		})
+	}
+}
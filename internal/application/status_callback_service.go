@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"log"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/webhook"
+)
+
+type statusCallbackService struct {
+	statuses domain.MessageStatusRepository
+	emit     func(requestID, eventType string, data any)
+}
+
+// NewStatusCallbackService creates a service posting the delivery status of
+// messages sent through the API to cfg.URL, each with the request ID of the API
+// call that sent the message
+func NewStatusCallbackService(statuses domain.MessageStatusRepository, cfg config.WebhookConfig) domain.StatusCallbackService {
+	return &statusCallbackService{
+		statuses: statuses,
+		emit:     webhook.NewDispatcher(cfg).DispatchRequest,
+	}
+}
+
+// HandleEvent posts message.sent and message.failed as sends complete, and
+// message.delivered and message.read as WhatsApp reports them
+func (s *statusCallbackService) HandleEvent(ctx context.Context, event string, data map[string]any) {
+	requestID, _ := data[eventbus.DataRequestID].(string)
+	switch event {
+	case eventbus.MessageSent:
+		s.sent(requestID, data)
+	case eventbus.MessageFailed:
+		s.emit(requestID, webhook.EventMessageFailed, failedCallback(data, "error"))
+	case eventbus.MessageExpired:
+		callback := failedCallback(data, "last_error")
+		callback["expired"] = true
+		s.emit(requestID, webhook.EventMessageFailed, callback)
+	case eventbus.MessageStatus:
+		s.receipt(data)
+	}
+}
+
+// sent tracks a sent message so its receipts can be reported, then reports it
+func (s *statusCallbackService) sent(requestID string, data map[string]any) {
+	messageID, _ := data["message_id"].(string)
+	to, _ := data["to"].(string)
+	if messageID == "" {
+		return
+	}
+
+	if err := s.statuses.Track(messageID, requestID, to); err != nil {
+		// The sent callback is still worth posting
+		log.Printf("Failed to track status of message %s: %v", messageID, err)
+	}
+	s.emit(requestID, webhook.EventMessageSent, map[string]any{
+		"message_id": messageID,
+		"to":         to,
+		"kind":       data["kind"],
+		"status":     "sent",
+	})
+}
+
+// receipt reports the tracked messages a delivery or read receipt moves on
+func (s *statusCallbackService) receipt(data map[string]any) {
+	status, _ := data["status"].(string)
+	eventType := webhook.EventMessageDelivered
+	if status == eventbus.StatusRead {
+		eventType = webhook.EventMessageRead
+	}
+
+	messageIDs, _ := data["message_ids"].([]string)
+	for _, messageID := range messageIDs {
+		tracked, err := s.statuses.Advance(messageID, status)
+		if err != nil {
+			log.Printf("Failed to update status of message %s: %v", messageID, err)
+			continue
+		}
+		if tracked == nil {
+			// Not sent through the API, or this status was already reported
+			continue
+		}
+		s.emit(tracked.RequestID, eventType, map[string]any{
+			"message_id": tracked.MessageID,
+			"to":         tracked.Recipient,
+			"status":     status,
+		})
+	}
+}
+
+// failedCallback is the message.failed payload for a given-up or expired
+// message, whose reason is under errorKey
+func failedCallback(data map[string]any, errorKey string) map[string]any {
+	callback := map[string]any{
+		"to":     data["to"],
+		"status": "failed",
+		"error":  data[errorKey],
+	}
+	if queueID, ok := data["queue_id"]; ok {
+		callback["queue_id"] = queueID
+	}
+	return callback
+}
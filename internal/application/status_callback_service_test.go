@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/webhook"
+)
+
+type postedCallback struct {
+	requestID string
+	eventType string
+	data      map[string]any
+}
+
+// newTestStatusCallbackService returns a status callback service that records
+// the callbacks it would post
+func newTestStatusCallbackService(statuses domain.MessageStatusRepository) (*statusCallbackService, *[]postedCallback) {
+	var posted []postedCallback
+	s := &statusCallbackService{
+		statuses: statuses,
+		emit: func(requestID, eventType string, data any) {
+			posted = append(posted, postedCallback{requestID, eventType, data.(map[string]any)})
+		},
+	}
+	return s, &posted
+}
+
+func TestStatusCallbackService_Sent(t *testing.T) {
+	// Arrange
+	mockStatuses := &mocks.MockMessageStatusRepository{}
+	service, posted := newTestStatusCallbackService(mockStatuses)
+	mockStatuses.On("Track", "msg-1", "req-1", "6281234567890@s.whatsapp.net").Return(nil)
+
+	// Act
+	service.HandleEvent(context.Background(), eventbus.MessageSent, map[string]any{
+		"kind":                 "text",
+		"to":                   "6281234567890@s.whatsapp.net",
+		"message_id":           "msg-1",
+		eventbus.DataRequestID: "req-1",
+	})
+
+	// Assert
+	mockStatuses.AssertExpectations(t)
+	if assert.Len(t, *posted, 1) {
+		assert.Equal(t, "req-1", (*posted)[0].requestID)
+		assert.Equal(t, webhook.EventMessageSent, (*posted)[0].eventType)
+		assert.Equal(t, "msg-1", (*posted)[0].data["message_id"])
+	}
+}
+
+func TestStatusCallbackService_Receipt(t *testing.T) {
+	// Arrange
+	mockStatuses := &mocks.MockMessageStatusRepository{}
+	service, posted := newTestStatusCallbackService(mockStatuses)
+	mockStatuses.On("Advance", "msg-1", eventbus.StatusRead).
+		Return(&domain.TrackedMessage{MessageID: "msg-1", RequestID: "req-1", Recipient: "6281234567890@s.whatsapp.net"}, nil)
+	// Sent by the bot rather than the API, or already reported
+	mockStatuses.On("Advance", "msg-2", eventbus.StatusRead).Return(nil, nil)
+
+	// Act
+	service.HandleEvent(context.Background(), eventbus.MessageStatus, map[string]any{
+		"message_ids": []string{"msg-1", "msg-2"},
+		"status":      eventbus.StatusRead,
+	})
+
+	// Assert
+	mockStatuses.AssertExpectations(t)
+	if assert.Len(t, *posted, 1) {
+		assert.Equal(t, postedCallback{"req-1", webhook.EventMessageRead, map[string]any{
+			"message_id": "msg-1",
+			"to":         "6281234567890@s.whatsapp.net",
+			"status":     "read",
+		}}, (*posted)[0])
+	}
+}
+
+func TestStatusCallbackService_Failed(t *testing.T) {
+	tests := []struct {
+		name  string
+		event string
+		data  map[string]any
+		want  map[string]any
+	}{
+		{
+			name:  "given up",
+			event: eventbus.MessageFailed,
+			data:  map[string]any{"to": "628111", "error": "stream error", "queue_id": int64(7), eventbus.DataRequestID: "req-1"},
+			want:  map[string]any{"to": "628111", "status": "failed", "error": "stream error", "queue_id": int64(7)},
+		},
+		{
+			name:  "expired",
+			event: eventbus.MessageExpired,
+			data:  map[string]any{"to": "628111", "last_error": "WhatsApp client is not connected", "queue_id": int64(8), eventbus.DataRequestID: "req-1"},
+			want:  map[string]any{"to": "628111", "status": "failed", "error": "WhatsApp client is not connected", "queue_id": int64(8), "expired": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, posted := newTestStatusCallbackService(&mocks.MockMessageStatusRepository{})
+
+			service.HandleEvent(context.Background(), tt.event, tt.data)
+
+			assert.Equal(t, []postedCallback{{"req-1", webhook.EventMessageFailed, tt.want}}, *posted)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+// incidentListLimit is how many of the latest incidents the admin list shows
+const incidentListLimit = 50
+
+type statusPageService struct {
+	db           *sql.DB
+	whatsappRepo domain.WhatsAppRepository
+}
+
+// NewStatusPageService creates a service reporting service health from the
+// connection of the senders and the incidents in the database
+func NewStatusPageService(db *sql.DB, whatsappRepo domain.WhatsAppRepository) domain.StatusPageService {
+	return &statusPageService{db: db, whatsappRepo: whatsappRepo}
+}
+
+// GetStatusPage returns the service health as shown to customers. Messaging is
+// operational when every active sender is connected, degraded when only some
+// are or an incident is open, and an outage when none is.
+func (s *statusPageService) GetStatusPage(ctx context.Context) (*domain.StatusPage, error) {
+	senders, err := s.whatsappRepo.ListSenders()
+	if err != nil {
+		return nil, err
+	}
+	incidents, err := repository.GetIncidents(s.db, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.StatusPage{
+		API:       domain.ServiceOperational,
+		Messaging: messagingStatus(senders, s.whatsappRepo.IsSenderConnected),
+		UpdatedAt: time.Now().Format(time.RFC3339),
+	}
+	if len(incidents) > 0 {
+		page.LastIncident = toDomainIncident(incidents[0])
+		if page.Messaging == domain.ServiceOperational && incidents[0].Status != repository.IncidentStatusResolved {
+			page.Messaging = domain.ServiceDegraded
+		}
+	}
+	page.Status = page.Messaging
+	return page, nil
+}
+
+// messagingStatus rates the senders that may send: operational when all are
+// connected, degraded when some are, an outage when none is or there are none.
+// Paused senders do not send, so they do not count.
+func messagingStatus(senders []*domain.Sender, connected func(senderID string) bool) string {
+	active, up := 0, 0
+	for _, sender := range senders {
+		if !sender.IsActive || sender.IsPaused || (sender.State != "" && sender.State != repository.SenderStateActive) {
+			continue
+		}
+		active++
+		if connected(sender.ID) {
+			up++
+		}
+	}
+	switch {
+	case up == 0:
+		return domain.ServiceOutage
+	case up < active:
+		return domain.ServiceDegraded
+	}
+	return domain.ServiceOperational
+}
+
+// CreateIncident opens an incident, or records a past one when its status is
+// resolved
+func (s *statusPageService) CreateIncident(ctx context.Context, req *domain.IncidentRequest) (*domain.Incident, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidIncident
+	}
+	incident := repository.Incident{Title: strings.TrimSpace(req.Title), Message: strings.TrimSpace(req.Message), Status: req.Status}
+	if incident.Status == "" {
+		incident.Status = domain.IncidentInvestigating
+	}
+	if err := validateIncident(incident); err != nil {
+		return nil, err
+	}
+
+	id, err := repository.CreateIncident(s.db, incident)
+	if err != nil {
+		return nil, err
+	}
+	created, err := repository.GetIncident(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainIncident(*created), nil
+}
+
+// ListIncidents returns the latest incidents, newest first
+func (s *statusPageService) ListIncidents(ctx context.Context) ([]*domain.Incident, error) {
+	incidents, err := repository.GetIncidents(s.db, incidentListLimit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Incident, 0, len(incidents))
+	for _, incident := range incidents {
+		result = append(result, toDomainIncident(incident))
+	}
+	return result, nil
+}
+
+// UpdateIncident changes the status of an incident, and its title or message
+// when given
+func (s *statusPageService) UpdateIncident(ctx context.Context, id int, req *domain.IncidentRequest) (*domain.Incident, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidIncident
+	}
+	incident, err := repository.GetIncident(s.db, id)
+	if err == repository.ErrIncidentNotFound {
+		return nil, domain.ErrIncidentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if title := strings.TrimSpace(req.Title); title != "" {
+		incident.Title = title
+	}
+	if message := strings.TrimSpace(req.Message); message != "" {
+		incident.Message = message
+	}
+	if req.Status != "" {
+		incident.Status = req.Status
+	}
+	if err := validateIncident(*incident); err != nil {
+		return nil, err
+	}
+
+	if err := repository.UpdateIncident(s.db, *incident); err != nil {
+		if err == repository.ErrIncidentNotFound {
+			return nil, domain.ErrIncidentNotFound
+		}
+		return nil, err
+	}
+	updated, err := repository.GetIncident(s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainIncident(*updated), nil
+}
+
+func validateIncident(incident repository.Incident) error {
+	if incident.Title == "" || len(incident.Title) > 200 {
+		return fmt.Errorf("%w: title is required and at most 200 characters", domain.ErrInvalidIncident)
+	}
+	switch incident.Status {
+	case domain.IncidentInvestigating, domain.IncidentIdentified, domain.IncidentMonitoring, domain.IncidentResolved:
+		return nil
+	}
+	return fmt.Errorf("%w: status must be investigating, identified, monitoring or resolved", domain.ErrInvalidIncident)
+}
+
+func toDomainIncident(incident repository.Incident) *domain.Incident {
+	result := &domain.Incident{
+		ID:        incident.ID,
+		Title:     incident.Title,
+		Message:   incident.Message,
+		Status:    incident.Status,
+		StartedAt: incident.StartedAt.Format(time.RFC3339),
+		UpdatedAt: incident.UpdatedAt.Format(time.RFC3339),
+	}
+	if incident.ResolvedAt.Valid {
+		result.ResolvedAt = incident.ResolvedAt.Time.Format(time.RFC3339)
+	}
+	return result
+}
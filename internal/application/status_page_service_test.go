@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/repository"
+)
+
+func TestMessagingStatus(t *testing.T) {
+	senders := []*domain.Sender{
+		{ID: "a", IsActive: true, State: repository.SenderStateActive},
+		{ID: "b", IsActive: true},
+		{ID: "banned", IsActive: true, State: repository.SenderStateBanned},
+		{ID: "inactive"},
+		{ID: "paused", IsActive: true, IsPaused: true},
+	}
+	tests := []struct {
+		name      string
+		senders   []*domain.Sender
+		connected map[string]bool
+		want      string
+	}{
+		{"all connected", senders, map[string]bool{"a": true, "b": true}, domain.ServiceOperational},
+		{"some connected", senders, map[string]bool{"a": true}, domain.ServiceDegraded},
+		{"only excluded senders connected", senders, map[string]bool{"banned": true, "inactive": true, "paused": true}, domain.ServiceOutage},
+		{"no senders", nil, nil, domain.ServiceOutage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := messagingStatus(tt.senders, func(senderID string) bool { return tt.connected[senderID] })
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStatusPageService_CreateIncident_Invalid(t *testing.T) {
+	service := NewStatusPageService(nil, &mocks.MockWhatsAppRepository{})
+
+	tests := []struct {
+		name string
+		req  *domain.IncidentRequest
+	}{
+		{"nil request", nil},
+		{"blank title", &domain.IncidentRequest{Title: "  "}},
+		{"unknown status", &domain.IncidentRequest{Title: "Pesan terlambat", Status: "fixed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incident, err := service.CreateIncident(context.Background(), tt.req)
+			assert.Nil(t, incident)
+			assert.ErrorIs(t, err, domain.ErrInvalidIncident)
+		})
+	}
+}
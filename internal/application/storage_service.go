@@ -0,0 +1,124 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"math"
+	"path/filepath"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+	"github.com/wa-serv/s3uploader"
+)
+
+type storageService struct {
+	db  *sql.DB
+	cfg config.StorageConfig
+}
+
+// NewStorageService creates a service reporting storage usage against the
+// limits in cfg
+func NewStorageService(db *sql.DB, cfg config.StorageConfig) domain.StorageService {
+	return &storageService{db: db, cfg: cfg}
+}
+
+// GetStorage measures the database, the S3 media bucket and the log directory.
+// Media and logs that cannot be measured are reported as warnings rather than
+// failing the report.
+func (s *storageService) GetStorage(ctx context.Context) (*domain.StorageReport, error) {
+	databaseSize, err := repository.GetDatabaseSize(s.db)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := repository.GetTableSizes(s.db)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.StorageReport{
+		Database:  storageUsage(databaseSize, s.cfg.DatabaseLimitBytes),
+		Tables:    make([]domain.TableStorage, 0, len(tables)),
+		Warnings:  []string{},
+		CheckedAt: time.Now().Format(time.RFC3339),
+	}
+	for _, t := range tables {
+		report.Tables = append(report.Tables, domain.TableStorage{Name: t.Name, Bytes: t.Bytes})
+	}
+
+	if s3uploader.Configured() {
+		objects, size, err := s3uploader.BucketUsage()
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("media usage unavailable: %v", err))
+		} else {
+			media := storageUsage(size, s.cfg.MediaLimitBytes)
+			media.Files = objects
+			report.Media = &media
+		}
+	}
+
+	if s.cfg.LogDir != "" {
+		files, size, err := dirUsage(s.cfg.LogDir)
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("log usage unavailable: %v", err))
+		} else {
+			logs := storageUsage(size, s.cfg.LogLimitBytes)
+			logs.Files = files
+			report.Logs = &logs
+		}
+	}
+
+	report.Warnings = append(report.Warnings, storageWarnings(report, s.cfg.WarnPercent)...)
+	return report, nil
+}
+
+// storageUsage is used bytes against limit, with the share used to one decimal
+func storageUsage(used, limit int64) domain.StorageUsage {
+	usage := domain.StorageUsage{UsedBytes: used, LimitBytes: limit}
+	if limit > 0 {
+		usage.UsedPercent = math.Round(float64(used)*1000/float64(limit)) / 10
+	}
+	return usage
+}
+
+// storageWarnings lists the storage at or above warnPercent of its limit
+func storageWarnings(report *domain.StorageReport, warnPercent int) []string {
+	var warnings []string
+	check := func(name string, usage *domain.StorageUsage) {
+		if usage == nil || usage.LimitBytes == 0 || usage.UsedPercent < float64(warnPercent) {
+			return
+		}
+		if usage.UsedBytes >= usage.LimitBytes {
+			warnings = append(warnings, fmt.Sprintf("%s has reached its %d MB limit", name, usage.LimitBytes>>20))
+			return
+		}
+		warnings = append(warnings, fmt.Sprintf("%s is at %.1f%% of its %d MB limit", name, usage.UsedPercent, usage.LimitBytes>>20))
+	}
+	check("database", &report.Database)
+	check("media", report.Media)
+	check("logs", report.Logs)
+	return warnings
+}
+
+// dirUsage counts the regular files under dir and their total size
+func dirUsage(dir string) (files, size int64, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files++
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to measure %s: %w", dir, err)
+	}
+	return files, size, nil
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+)
+
+func TestStorageWarnings(t *testing.T) {
+	const limit = 500 << 20
+	tests := []struct {
+		name   string
+		report *domain.StorageReport
+		want   []string
+	}{
+		{
+			name:   "plenty of room",
+			report: &domain.StorageReport{Database: storageUsage(100<<20, limit)},
+			want:   nil,
+		},
+		{
+			name:   "approaching the limit",
+			report: &domain.StorageReport{Database: storageUsage(425<<20, limit)},
+			want:   []string{"database is at 85.0% of its 500 MB limit"},
+		},
+		{
+			name:   "limit reached",
+			report: &domain.StorageReport{Database: storageUsage(510<<20, limit)},
+			want:   []string{"database has reached its 500 MB limit"},
+		},
+		{
+			name: "no limit configured",
+			report: &domain.StorageReport{
+				Database: storageUsage(100<<20, limit),
+				Logs:     &domain.StorageUsage{UsedBytes: 10 << 30},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, storageWarnings(tt.report, 80))
+		})
+	}
+}
+
+func TestDirUsage(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.log"), make([]byte, 1500), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "archive"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "archive", "app.log.1"), make([]byte, 500), 0o644))
+
+	files, size, err := dirUsage(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), files)
+	assert.Equal(t, int64(2000), size)
+
+	_, _, err = dirUsage(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
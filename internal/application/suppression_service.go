@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/wa-serv/internal/domain"
+)
+
+type suppressionService struct {
+	suppressions domain.SuppressionRepository
+}
+
+// NewSuppressionService creates a service managing the suppression list that
+// message sends are checked against
+func NewSuppressionService(suppressions domain.SuppressionRepository) domain.SuppressionService {
+	return &suppressionService{suppressions: suppressions}
+}
+
+// ListSuppressions returns every suppressed recipient, newest first
+func (s *suppressionService) ListSuppressions(ctx context.Context) ([]*domain.Suppression, error) {
+	return s.suppressions.ListSuppressions()
+}
+
+// GetSuppression returns the suppression of recipient, given as a phone number
+// or group JID
+func (s *suppressionService) GetSuppression(ctx context.Context, recipient string) (*domain.Suppression, error) {
+	key, err := suppressionKey(recipient)
+	if err != nil {
+		return nil, err
+	}
+	return s.suppressions.GetSuppression(key)
+}
+
+// CreateSuppression stops recipient from being messaged
+func (s *suppressionService) CreateSuppression(ctx context.Context, req *domain.CreateSuppressionRequest) (*domain.Suppression, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidSuppression
+	}
+	key, err := suppressionKey(req.Recipient)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason != domain.SuppressionOptedOut && reason != domain.SuppressionBlocked {
+		return nil, domain.ErrInvalidSuppression
+	}
+	return s.suppressions.CreateSuppression(key, reason, strings.TrimSpace(req.Note))
+}
+
+// DeleteSuppression lets recipient be messaged again
+func (s *suppressionService) DeleteSuppression(ctx context.Context, recipient string) error {
+	key, err := suppressionKey(recipient)
+	if err != nil {
+		return err
+	}
+	return s.suppressions.DeleteSuppression(key)
+}
+
+// suppressionKey is how recipient is kept on the suppression list: a phone
+// number in digits only, as the message service sends to it, or a group JID
+func suppressionKey(recipient string) (string, error) {
+	recipient = strings.TrimSpace(recipient)
+	if strings.HasSuffix(recipient, "@g.us") {
+		if !groupJIDPattern.MatchString(recipient) {
+			return "", domain.ErrInvalidSuppression
+		}
+		return recipient, nil
+	}
+	phone := cleanPhoneNumber(strings.TrimSuffix(recipient, "@s.whatsapp.net"))
+	if len(phone) < 10 {
+		return "", domain.ErrInvalidSuppression
+	}
+	return phone, nil
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSuppressionService_CreateSuppression(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockSuppressionRepository{}
+	service := NewSuppressionService(mockRepo)
+
+	created := &domain.Suppression{Recipient: "6281234567890", Reason: domain.SuppressionBlocked, Note: "spam"}
+	mockRepo.On("CreateSuppression", "6281234567890", domain.SuppressionBlocked, "spam").Return(created, nil)
+
+	// Act
+	suppression, err := service.CreateSuppression(context.Background(), &domain.CreateSuppressionRequest{
+		Recipient: "+62 812-3456-7890",
+		Reason:    " blocked ",
+		Note:      " spam ",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, created, suppression)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSuppressionService_CreateSuppression_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.CreateSuppressionRequest
+	}{
+		{"nil request", nil},
+		{"short phone", &domain.CreateSuppressionRequest{Recipient: "12345", Reason: domain.SuppressionOptedOut}},
+		{"bad group JID", &domain.CreateSuppressionRequest{Recipient: "pelanggan@g.us", Reason: domain.SuppressionOptedOut}},
+		{"unknown reason", &domain.CreateSuppressionRequest{Recipient: "6281234567890", Reason: "annoying"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewSuppressionService(&mocks.MockSuppressionRepository{})
+
+			_, err := service.CreateSuppression(context.Background(), tt.req)
+
+			assert.Equal(t, domain.ErrInvalidSuppression, err)
+		})
+	}
+}
+
+func TestSuppressionService_DeleteSuppression_Group(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockSuppressionRepository{}
+	service := NewSuppressionService(mockRepo)
+	mockRepo.On("DeleteSuppression", "120363025246125486@g.us").Return(nil)
+
+	// Act
+	err := service.DeleteSuppression(context.Background(), "120363025246125486@g.us")
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+)
+
+var (
+	// templateNamePattern restricts template names to short slugs such as "points_earned"
+	templateNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,59}$`)
+	// templateVariablePattern matches the {{variable}} placeholders RenderTemplate fills
+	templateVariablePattern = regexp.MustCompile(`\{\{([A-Za-z0-9_]+)\}\}`)
+)
+
+type templateService struct {
+	templates domain.TemplateRepository
+	messages  domain.MessageService
+}
+
+// NewTemplateService creates a message template service that sends rendered
+// templates through messages
+func NewTemplateService(templates domain.TemplateRepository, messages domain.MessageService) domain.TemplateService {
+	return &templateService{templates: templates, messages: messages}
+}
+
+// ListTemplates returns all templates with their variables
+func (s *templateService) ListTemplates(ctx context.Context) ([]*domain.MessageTemplate, error) {
+	templates, err := s.templates.ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range templates {
+		t.Variables = templateVariables(t.Body)
+	}
+	return templates, nil
+}
+
+// GetTemplate returns the named template with its variables
+func (s *templateService) GetTemplate(ctx context.Context, name string) (*domain.MessageTemplate, error) {
+	t, err := s.templates.GetTemplate(strings.TrimSpace(name))
+	if err != nil {
+		return nil, err
+	}
+	t.Variables = templateVariables(t.Body)
+	return t, nil
+}
+
+// CreateTemplate adds a template; the name must not be taken
+func (s *templateService) CreateTemplate(ctx context.Context, req *domain.SaveTemplateRequest) (*domain.MessageTemplate, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidTemplate
+	}
+	name, body, err := validateTemplate(req.Name, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templates.CreateTemplate(name, body); err != nil {
+		return nil, err
+	}
+	return &domain.MessageTemplate{Name: name, Body: body, Variables: templateVariables(body)}, nil
+}
+
+// UpdateTemplate replaces the body of an existing template
+func (s *templateService) UpdateTemplate(ctx context.Context, name string, req *domain.SaveTemplateRequest) (*domain.MessageTemplate, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidTemplate
+	}
+	name, body, err := validateTemplate(name, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.templates.UpdateTemplate(name, body); err != nil {
+		return nil, err
+	}
+	return &domain.MessageTemplate{Name: name, Body: body, Variables: templateVariables(body)}, nil
+}
+
+// DeleteTemplate removes a template
+func (s *templateService) DeleteTemplate(ctx context.Context, name string) error {
+	return s.templates.DeleteTemplate(strings.TrimSpace(name))
+}
+
+// SendTemplate renders a template with the request variables and sends it.
+// Every placeholder must have a value, so customers never see "{{points}}".
+func (s *templateService) SendTemplate(ctx context.Context, req *domain.SendTemplateRequest) (*domain.SendMessageResponse, error) {
+	if req == nil || strings.TrimSpace(req.Template) == "" {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "template is required",
+		}, domain.ErrInvalidTemplate
+	}
+
+	t, err := s.templates.GetTemplate(strings.TrimSpace(req.Template))
+	if err != nil {
+		message := "Failed to load template"
+		if err == domain.ErrTemplateNotFound {
+			message = "Template not found"
+		}
+		return &domain.SendMessageResponse{Success: false, Message: message}, err
+	}
+
+	values := make(map[string]string, len(req.Variables))
+	var missing []string
+	for _, name := range templateVariables(t.Body) {
+		value, ok := req.Variables[name]
+		if !ok || value == nil {
+			missing = append(missing, name)
+			continue
+		}
+		values[name] = fmt.Sprint(value)
+	}
+	if len(missing) > 0 {
+		return &domain.SendMessageResponse{
+			Success: false,
+			Message: "Missing template variables: " + strings.Join(missing, ", "),
+		}, domain.ErrMissingVariables
+	}
+
+	return s.messages.SendMessage(ctx, &domain.SendMessageRequest{
+		To:       req.To,
+		Message:  processor.RenderTemplate(t.Body, values),
+		From:     req.From,
+		Category: req.Category,
+		Force:    req.Force,
+	})
+}
+
+// validateTemplate checks and normalizes a template name and body
+func validateTemplate(name, body string) (string, string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !templateNamePattern.MatchString(name) || strings.TrimSpace(body) == "" {
+		return "", "", domain.ErrInvalidTemplate
+	}
+	return name, body, nil
+}
+
+// templateVariables returns the distinct placeholders in body, sorted
+func templateVariables(body string) []string {
+	seen := make(map[string]bool)
+	variables := []string{}
+	for _, match := range templateVariablePattern.FindAllStringSubmatch(body, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			variables = append(variables, match[1])
+		}
+	}
+	sort.Strings(variables)
+	return variables
+}
@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestTemplateService_CreateTemplate(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockTemplateRepository{}
+	service := NewTemplateService(mockRepo, &mocks.MockMessageService{})
+
+	body := "Halo {{name}}, Anda mendapat {{points}} poin. Total: {{total}} ({{name}})"
+	mockRepo.On("CreateTemplate", "points_earned", body).Return(nil)
+
+	// Act
+	template, err := service.CreateTemplate(context.Background(), &domain.SaveTemplateRequest{Name: " Points_Earned ", Body: body})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "points_earned", template.Name)
+	assert.Equal(t, []string{"name", "points", "total"}, template.Variables)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTemplateService_CreateTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *domain.SaveTemplateRequest
+	}{
+		{"nil request", nil},
+		{"missing name", &domain.SaveTemplateRequest{Body: "Halo"}},
+		{"name with spaces", &domain.SaveTemplateRequest{Name: "points earned", Body: "Halo"}},
+		{"blank body", &domain.SaveTemplateRequest{Name: "welcome", Body: "  "}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mocks.MockTemplateRepository{}
+			service := NewTemplateService(mockRepo, &mocks.MockMessageService{})
+
+			_, err := service.CreateTemplate(context.Background(), tt.req)
+
+			assert.Equal(t, domain.ErrInvalidTemplate, err)
+			mockRepo.AssertNotCalled(t, "CreateTemplate", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTemplateService_SendTemplate_RendersVariables(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockTemplateRepository{}
+	mockMessages := &mocks.MockMessageService{}
+	service := NewTemplateService(mockRepo, mockMessages)
+
+	mockRepo.On("GetTemplate", "points_earned").
+		Return(&domain.MessageTemplate{Name: "points_earned", Body: "Halo {{name}}, +{{points}} poin!"}, nil)
+	mockMessages.On("SendMessage", mock.Anything, &domain.SendMessageRequest{
+		To:      "+6281234567890",
+		Message: "Halo Budi, +50 poin!",
+		From:    "sender1",
+	}).Return(&domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil)
+
+	// Act
+	response, err := service.SendTemplate(context.Background(), &domain.SendTemplateRequest{
+		To:        "+6281234567890",
+		Template:  "points_earned",
+		Variables: map[string]any{"name": "Budi", "points": 50.0}, // JSON numbers decode as float64
+		From:      "sender1",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", response.ID)
+	mockRepo.AssertExpectations(t)
+	mockMessages.AssertExpectations(t)
+}
+
+func TestTemplateService_SendTemplate_MissingVariables(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockTemplateRepository{}
+	mockMessages := &mocks.MockMessageService{}
+	service := NewTemplateService(mockRepo, mockMessages)
+
+	mockRepo.On("GetTemplate", "points_earned").
+		Return(&domain.MessageTemplate{Name: "points_earned", Body: "Halo {{name}}, +{{points}} poin! Total {{total}}"}, nil)
+
+	// Act
+	response, err := service.SendTemplate(context.Background(), &domain.SendTemplateRequest{
+		To:        "+6281234567890",
+		Template:  "points_earned",
+		Variables: map[string]any{"name": "Budi", "total": nil},
+	})
+
+	// Assert
+	assert.Equal(t, domain.ErrMissingVariables, err)
+	assert.Equal(t, "Missing template variables: points, total", response.Message)
+	mockMessages.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestTemplateService_SendTemplate_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &mocks.MockTemplateRepository{}
+	service := NewTemplateService(mockRepo, &mocks.MockMessageService{})
+
+	mockRepo.On("GetTemplate", "missing").Return(nil, domain.ErrTemplateNotFound)
+
+	// Act
+	response, err := service.SendTemplate(context.Background(), &domain.SendTemplateRequest{To: "+6281234567890", Template: "missing"})
+
+	// Assert
+	assert.Equal(t, domain.ErrTemplateNotFound, err)
+	assert.False(t, response.Success)
+}
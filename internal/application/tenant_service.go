@@ -0,0 +1,335 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+)
+
+// defaultTemplates are seeded for every new tenant so the bot has sensible
+// copy out of the box. Placeholders use the {{variable}} syntax.
+var defaultTemplates = map[string]string{
+	"welcome":          "Halo {{name}}! 👋 Selamat datang di program poin {{tenant}}. Ketik *menu* untuk melihat pilihan.",
+	"points_balance":   "Poin Anda saat ini: {{points}}",
+	"redeem_success":   "🎉 Penukaran {{points}} poin berhasil! Hadiah: {{reward}}",
+	"registration":     "✅ Registrasi Berhasil!\n\nNama: {{name}}\nAlamat: {{address}}\n\nTerima kasih telah mendaftar!",
+	"onboarding_nudge": "Halo {{name}}! 👋 Terima kasih sudah menghubungi {{tenant}}. Daftar gratis di program poin kami dan kumpulkan poin dari setiap transaksi.\n\nKirim *REG#Nama#Alamat* untuk mendaftar, contoh: REG#Budi#Jl. Merdeka No. 1",
+}
+
+type tenantService struct {
+	db *sql.DB
+}
+
+// NewTenantService creates a new tenant onboarding service
+func NewTenantService(db *sql.DB) domain.TenantService {
+	return &tenantService{db: db}
+}
+
+// CreateTenant provisions a tenant with its admin, API key, reward catalog and
+// templates in a single transaction, so a failure never leaves a half-built tenant.
+func (s *tenantService) CreateTenant(ctx context.Context, req *domain.CreateTenantRequest) (*domain.CreateTenantResponse, error) {
+	if err := validateCreateTenantRequest(req); err != nil {
+		return &domain.CreateTenantResponse{
+			Success: false,
+			Message: err.Error(),
+		}, domain.ErrInvalidTenant
+	}
+
+	slug := req.Slug
+	if slug == "" {
+		slug = slugify(req.Name)
+	}
+	if slug == "" {
+		return &domain.CreateTenantResponse{
+			Success: false,
+			Message: "slug could not be derived from name",
+		}, domain.ErrInvalidTenant
+	}
+	adminPhone := cleanPhoneNumber(req.AdminPhone)
+
+	apiKey, keyPrefix, keyHash, err := generateAPIKey()
+	if err != nil {
+		return &domain.CreateTenantResponse{
+			Success: false,
+			Message: "Failed to generate API key",
+		}, err
+	}
+
+	tx, err := repository.BeginTx(ctx, s.db, nil)
+	if err != nil {
+		return &domain.CreateTenantResponse{
+			Success: false,
+			Message: "Failed to start provisioning",
+		}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	exists, err := repository.TenantSlugExists(tx, slug)
+	if err != nil {
+		return &domain.CreateTenantResponse{
+			Success: false,
+			Message: "Failed to check tenant slug",
+		}, err
+	}
+	if exists {
+		return &domain.CreateTenantResponse{
+			Success: false,
+			Message: fmt.Sprintf("Tenant with slug %q already exists", slug),
+		}, domain.ErrTenantExists
+	}
+
+	tenantID, err := repository.CreateTenant(tx, slug, strings.TrimSpace(req.Name), adminPhone)
+	if err != nil {
+		return &domain.CreateTenantResponse{Success: false, Message: "Failed to create tenant"}, err
+	}
+
+	if err := repository.CreateTenantAdmin(tx, tenantID, strings.TrimSpace(req.AdminName), adminPhone); err != nil {
+		return &domain.CreateTenantResponse{Success: false, Message: "Failed to create tenant admin"}, err
+	}
+
+	if err := repository.CreateTenantAPIKey(tx, tenantID, keyPrefix, keyHash, "initial admin key"); err != nil {
+		return &domain.CreateTenantResponse{Success: false, Message: "Failed to create API key"}, err
+	}
+
+	// Seed rewards from the built-in catalog in ascending point order
+	points := make([]int, 0, len(processor.RewardMapping))
+	for p := range processor.RewardMapping {
+		points = append(points, p)
+	}
+	sort.Ints(points)
+	for _, p := range points {
+		if err := repository.InsertRewardCatalogEntry(tx, tenantID, p, processor.RewardMapping[p]); err != nil {
+			return &domain.CreateTenantResponse{Success: false, Message: "Failed to seed reward catalog"}, err
+		}
+	}
+
+	for name, body := range defaultTemplates {
+		if err := repository.InsertTemplate(tx, tenantID, name, body); err != nil {
+			return &domain.CreateTenantResponse{Success: false, Message: "Failed to seed templates"}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &domain.CreateTenantResponse{
+			Success: false,
+			Message: "Failed to commit provisioning",
+		}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &domain.CreateTenantResponse{
+		Success:         true,
+		Message:         "Tenant provisioned successfully. Store the API key now; it will not be shown again.",
+		TenantID:        tenantID,
+		Slug:            slug,
+		APIKey:          apiKey,
+		RewardsSeeded:   len(points),
+		TemplatesSeeded: len(defaultTemplates),
+	}, nil
+}
+
+// maxNudgeIntervalDays caps how rarely a tenant can nudge the same number
+const maxNudgeIntervalDays = 365
+
+// GetOnboardingNudge returns the tenant's nudge settings for unregistered contacts
+func (s *tenantService) GetOnboardingNudge(ctx context.Context, slug string) (*domain.OnboardingNudgeSettings, error) {
+	settings, err := repository.GetOnboardingNudgeSettings(s.db, slug)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, domain.ErrTenantNotFound
+	}
+	return &domain.OnboardingNudgeSettings{
+		Enabled:      settings.Enabled,
+		IntervalDays: settings.IntervalDays,
+		Message:      settings.Message,
+	}, nil
+}
+
+// SetOnboardingNudge updates the tenant's nudge settings and returns the result
+func (s *tenantService) SetOnboardingNudge(ctx context.Context, slug string, req *domain.OnboardingNudgeSettings) (*domain.OnboardingNudgeSettings, error) {
+	if req == nil || req.IntervalDays < 0 || req.IntervalDays > maxNudgeIntervalDays {
+		return nil, domain.ErrInvalidNudgeSettings
+	}
+
+	settings, err := repository.GetOnboardingNudgeSettings(s.db, slug)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	message := strings.TrimSpace(req.Message)
+	if err := repository.UpdateOnboardingNudgeSettings(s.db, settings.TenantID, req.Enabled, req.IntervalDays, message); err != nil {
+		return nil, err
+	}
+	if message == "" {
+		message = settings.Message
+	}
+
+	return &domain.OnboardingNudgeSettings{
+		Enabled:      req.Enabled,
+		IntervalDays: req.IntervalDays,
+		Message:      message,
+	}, nil
+}
+
+// Bounds on the stamp card size a tenant can choose
+const (
+	minStampCardSize = 2
+	maxStampCardSize = 50
+)
+
+// GetLoyaltyProgram returns the loyalty program the tenant runs
+func (s *tenantService) GetLoyaltyProgram(ctx context.Context, slug string) (*domain.LoyaltyProgramSettings, error) {
+	settings, err := repository.GetLoyaltyProgramSettings(s.db, slug)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, domain.ErrTenantNotFound
+	}
+	return &domain.LoyaltyProgramSettings{
+		Program:       settings.Program,
+		StampCardSize: settings.StampCardSize,
+		StampReward:   settings.StampReward,
+	}, nil
+}
+
+// SetLoyaltyProgram switches the tenant's loyalty program. Points and stamp
+// balances are kept either way, so switching back resumes where members left off.
+func (s *tenantService) SetLoyaltyProgram(ctx context.Context, slug string, req *domain.LoyaltyProgramSettings) (*domain.LoyaltyProgramSettings, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidLoyaltyProgram
+	}
+	switch req.Program {
+	case repository.ProgramPoints, repository.ProgramStamps, repository.ProgramBoth:
+	default:
+		return nil, domain.ErrInvalidLoyaltyProgram
+	}
+	reward := strings.TrimSpace(req.StampReward)
+	if req.StampCardSize < minStampCardSize || req.StampCardSize > maxStampCardSize || reward == "" {
+		return nil, domain.ErrInvalidLoyaltyProgram
+	}
+
+	settings, err := repository.GetLoyaltyProgramSettings(s.db, slug)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	settings.Program = req.Program
+	settings.StampCardSize = req.StampCardSize
+	settings.StampReward = reward
+	if err := repository.UpdateLoyaltyProgramSettings(s.db, *settings); err != nil {
+		return nil, err
+	}
+
+	return &domain.LoyaltyProgramSettings{
+		Program:       settings.Program,
+		StampCardSize: settings.StampCardSize,
+		StampReward:   settings.StampReward,
+	}, nil
+}
+
+// GetMessageLog returns what the message log keeps of the tenant's messages
+func (s *tenantService) GetMessageLog(ctx context.Context, slug string) (*domain.MessageLogSettings, error) {
+	_, mode, err := repository.GetMessageLogMode(s.db, slug)
+	if err != nil {
+		return nil, err
+	}
+	if mode == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+	return &domain.MessageLogSettings{Mode: mode}, nil
+}
+
+// SetMessageLog switches between logging message content and logging only its
+// hash and length. Content already logged is kept as it is.
+func (s *tenantService) SetMessageLog(ctx context.Context, slug string, req *domain.MessageLogSettings) (*domain.MessageLogSettings, error) {
+	if req == nil {
+		return nil, domain.ErrInvalidMessageLogMode
+	}
+	switch req.Mode {
+	case repository.MessageLogFull, repository.MessageLogPrivate:
+	default:
+		return nil, domain.ErrInvalidMessageLogMode
+	}
+
+	tenantID, mode, err := repository.GetMessageLogMode(s.db, slug)
+	if err != nil {
+		return nil, err
+	}
+	if mode == "" {
+		return nil, domain.ErrTenantNotFound
+	}
+
+	if err := repository.UpdateMessageLogMode(s.db, tenantID, req.Mode); err != nil {
+		return nil, err
+	}
+	return &domain.MessageLogSettings{Mode: req.Mode}, nil
+}
+
+// validateCreateTenantRequest validates the tenant onboarding request
+func validateCreateTenantRequest(req *domain.CreateTenantRequest) error {
+	if req == nil {
+		return fmt.Errorf("request cannot be nil")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return fmt.Errorf("tenant name is required")
+	}
+	if strings.TrimSpace(req.AdminName) == "" {
+		return fmt.Errorf("admin name is required")
+	}
+	if len(cleanPhoneNumber(req.AdminPhone)) < 10 {
+		return fmt.Errorf("admin phone number is invalid")
+	}
+	if req.Slug != "" && slugify(req.Slug) != req.Slug {
+		return fmt.Errorf("slug may only contain lowercase letters, digits and dashes")
+	}
+	return nil
+}
+
+// slugify lowercases s and collapses every run of non-alphanumerics into a dash
+func slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// generateAPIKey returns a new random API key, its display prefix and its SHA-256 hash
+func generateAPIKey() (key, prefix, hash string, err error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	key = "wp_" + hex.EncodeToString(buf)
+	return key, key[:11], hashAPIKey(key), nil
+}
+
+// hashAPIKey returns the SHA-256 hash of key, as stored in tenant_api_keys
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,199 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+)
+
+func TestTenantService_CreateTenant_InvalidRequest(t *testing.T) {
+	// Validation must reject bad input before any database work, so a nil DB is safe here
+	service := NewTenantService(nil)
+
+	tests := []struct {
+		name    string
+		req     *domain.CreateTenantRequest
+		message string
+	}{
+		{"nil request", nil, "request cannot be nil"},
+		{"missing name", &domain.CreateTenantRequest{AdminName: "Budi", AdminPhone: "+6281234567890"}, "tenant name is required"},
+		{"missing admin", &domain.CreateTenantRequest{Name: "Ruang Laundry", AdminPhone: "+6281234567890"}, "admin name is required"},
+		{"short phone", &domain.CreateTenantRequest{Name: "Ruang Laundry", AdminName: "Budi", AdminPhone: "123"}, "admin phone number is invalid"},
+		{"bad slug", &domain.CreateTenantRequest{Name: "Ruang Laundry", Slug: "Ruang Laundry!", AdminName: "Budi", AdminPhone: "+6281234567890"}, "slug may only contain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.CreateTenant(context.Background(), tt.req)
+
+			assert.Equal(t, domain.ErrInvalidTenant, err)
+			assert.False(t, resp.Success)
+			assert.Contains(t, resp.Message, tt.message)
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	assert.Equal(t, "ruang-laundry", slugify("Ruang Laundry"))
+	assert.Equal(t, "ruang-laundry-bandung", slugify("  Ruang  Laundry -- Bandung! "))
+	assert.Equal(t, "cabang-2", slugify("Cabang #2"))
+	assert.Equal(t, "", slugify("!!!"))
+}
+
+func TestGenerateAPIKey_UniqueAndHashed(t *testing.T) {
+	key1, prefix1, hash1, err := generateAPIKey()
+	assert.NoError(t, err)
+	key2, _, hash2, err := generateAPIKey()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(key1, "wp_"))
+	assert.True(t, strings.HasPrefix(key1, prefix1))
+	assert.NotEqual(t, key1, key2)
+	assert.NotEqual(t, hash1, hash2)
+	// The stored hash must never be the plaintext key
+	assert.NotContains(t, hash1, key1)
+	assert.Len(t, hash1, 64)
+}
+
+func TestTenantService_SetOnboardingNudge_InvalidInterval(t *testing.T) {
+	service := NewTenantService(nil)
+
+	for _, days := range []int{-1, maxNudgeIntervalDays + 1} {
+		_, err := service.SetOnboardingNudge(context.Background(), "ruang-laundry", &domain.OnboardingNudgeSettings{Enabled: true, IntervalDays: days})
+		assert.Equal(t, domain.ErrInvalidNudgeSettings, err)
+	}
+}
+
+func TestTenantService_SetLoyaltyProgram_Invalid(t *testing.T) {
+	service := NewTenantService(nil)
+
+	tests := []*domain.LoyaltyProgramSettings{
+		nil,
+		{Program: "cashback", StampCardSize: 10, StampReward: "Gratis 1x cuci"},
+		{Program: "stamps", StampCardSize: minStampCardSize - 1, StampReward: "Gratis 1x cuci"},
+		{Program: "stamps", StampCardSize: maxStampCardSize + 1, StampReward: "Gratis 1x cuci"},
+		{Program: "both", StampCardSize: 10, StampReward: "  "},
+	}
+	for _, req := range tests {
+		_, err := service.SetLoyaltyProgram(context.Background(), "ruang-laundry", req)
+		assert.Equal(t, domain.ErrInvalidLoyaltyProgram, err)
+	}
+}
+
+func TestTenantService_SetMessageLog_InvalidMode(t *testing.T) {
+	service := NewTenantService(nil)
+
+	for _, req := range []*domain.MessageLogSettings{nil, {Mode: ""}, {Mode: "hashed"}} {
+		_, err := service.SetMessageLog(context.Background(), "ruang-laundry", req)
+		assert.Equal(t, domain.ErrInvalidMessageLogMode, err)
+	}
+}
+
+// setupTenantDB returns an in-memory database with the tables a tenant is
+// provisioned into
+func setupTenantDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	for _, ddl := range []string{
+		`CREATE TABLE tenants (tenant_id INTEGER PRIMARY KEY AUTOINCREMENT, slug VARCHAR(60) UNIQUE NOT NULL, name VARCHAR(100) NOT NULL, owner_phone VARCHAR(30) NOT NULL, created_at TIMESTAMP, updated_at TIMESTAMP)`,
+		`CREATE TABLE tenant_admins (admin_id INTEGER PRIMARY KEY AUTOINCREMENT, tenant_id INTEGER NOT NULL, name VARCHAR(100) NOT NULL, phone_number VARCHAR(30) NOT NULL, created_at TIMESTAMP, updated_at TIMESTAMP, UNIQUE (tenant_id, phone_number))`,
+		`CREATE TABLE tenant_api_keys (key_id INTEGER PRIMARY KEY AUTOINCREMENT, tenant_id INTEGER NOT NULL, key_prefix VARCHAR(16) NOT NULL, key_hash VARCHAR(64) UNIQUE NOT NULL, label VARCHAR(100), created_at TIMESTAMP)`,
+		`CREATE TABLE reward_catalog (reward_id INTEGER PRIMARY KEY AUTOINCREMENT, tenant_id INTEGER NOT NULL, points INTEGER NOT NULL, description TEXT NOT NULL, is_active BOOLEAN DEFAULT TRUE, created_at TIMESTAMP, updated_at TIMESTAMP, UNIQUE (tenant_id, points))`,
+		`CREATE TABLE message_templates (template_id INTEGER PRIMARY KEY AUTOINCREMENT, tenant_id INTEGER, name VARCHAR(60) NOT NULL, body TEXT NOT NULL, created_at TIMESTAMP, updated_at TIMESTAMP, UNIQUE (tenant_id, name))`,
+	} {
+		_, err := db.Exec(ddl)
+		require.NoError(t, err)
+	}
+	return db
+}
+
+// countTenantRows returns how many rows each provisioning table holds
+func countTenantRows(t *testing.T, db *sql.DB) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	for _, table := range []string{"tenants", "tenant_admins", "tenant_api_keys", "reward_catalog", "message_templates"} {
+		var n int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&n))
+		counts[table] = n
+	}
+	return counts
+}
+
+func TestTenantService_CreateTenant_Provisions(t *testing.T) {
+	// Arrange
+	db := setupTenantDB(t)
+	service := NewTenantService(db)
+
+	// Act
+	resp, err := service.CreateTenant(context.Background(), &domain.CreateTenantRequest{
+		Name:       "Ruang Laundry",
+		AdminName:  "Budi",
+		AdminPhone: "+62 812-3456-7890",
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.Equal(t, map[string]int{
+		"tenants":           1,
+		"tenant_admins":     1,
+		"tenant_api_keys":   1,
+		"reward_catalog":    len(processor.RewardMapping),
+		"message_templates": len(defaultTemplates),
+	}, countTenantRows(t, db))
+}
+
+func TestTenantService_CreateTenant_RollsBackOnFailure(t *testing.T) {
+	// Arrange: templates are seeded last, after every other table was written
+	db := setupTenantDB(t)
+	_, err := db.Exec(`CREATE TRIGGER fail_templates BEFORE INSERT ON message_templates BEGIN SELECT RAISE(ABORT, 'templates unavailable'); END`)
+	require.NoError(t, err)
+	service := NewTenantService(db)
+
+	// Act
+	resp, err := service.CreateTenant(context.Background(), &domain.CreateTenantRequest{
+		Name:       "Ruang Laundry",
+		AdminName:  "Budi",
+		AdminPhone: "+62 812-3456-7890",
+	})
+
+	// Assert
+	require.Error(t, err)
+	assert.False(t, resp.Success)
+	assert.Equal(t, "Failed to seed templates", resp.Message)
+	assert.Equal(t, map[string]int{
+		"tenants":           0,
+		"tenant_admins":     0,
+		"tenant_api_keys":   0,
+		"reward_catalog":    0,
+		"message_templates": 0,
+	}, countTenantRows(t, db))
+}
+
+func TestTenantService_CreateTenant_SlugTaken(t *testing.T) {
+	// Arrange
+	db := setupTenantDB(t)
+	service := NewTenantService(db)
+	req := &domain.CreateTenantRequest{Name: "Ruang Laundry", AdminName: "Budi", AdminPhone: "+6281234567890"}
+	_, err := service.CreateTenant(context.Background(), req)
+	require.NoError(t, err)
+
+	// Act
+	resp, err := service.CreateTenant(context.Background(), req)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrTenantExists)
+	assert.False(t, resp.Success)
+	assert.Equal(t, 1, countTenantRows(t, db)["tenants"])
+}
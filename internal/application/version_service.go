@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wa-serv/buildinfo"
+	"github.com/wa-serv/internal/domain"
+)
+
+type versionService struct {
+	build    buildinfo.Info
+	feed     domain.ReleaseFeed // nil when no release feed is configured
+	interval time.Duration
+	now      func() time.Time
+
+	mu          sync.Mutex
+	latest      string
+	checkedAt   time.Time // when the feed last answered
+	attemptedAt time.Time // when the feed was last asked, answered or not
+}
+
+// NewVersionService creates a service reporting build, and comparing it with
+// the latest release on feed at most once per interval. feed may be nil.
+func NewVersionService(build buildinfo.Info, feed domain.ReleaseFeed, interval time.Duration) domain.VersionService {
+	return &versionService{build: build, feed: feed, interval: interval, now: time.Now}
+}
+
+// GetVersion returns the running build and the latest release. A feed that
+// cannot be reached is logged and leaves the last answer in place; the build
+// is still reported.
+func (s *versionService) GetVersion(ctx context.Context) (*domain.VersionInfo, error) {
+	info := &domain.VersionInfo{
+		Version:   s.build.Version,
+		Commit:    s.build.Commit,
+		BuildDate: s.build.BuildDate,
+		GoVersion: s.build.GoVersion,
+	}
+	if s.feed == nil {
+		return info, nil
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	due := s.attemptedAt.IsZero() || now.Sub(s.attemptedAt) >= s.interval
+	if due {
+		s.attemptedAt = now
+	}
+	s.mu.Unlock()
+
+	// Only the request that finds the check due asks the feed, without holding
+	// the lock; the others meanwhile answer with the last release known
+	if due {
+		latest, err := s.feed.LatestVersion(ctx)
+		if err != nil {
+			log.Printf("Failed to check for a newer release: %v", err)
+		} else {
+			s.mu.Lock()
+			s.latest, s.checkedAt = latest, now
+			s.mu.Unlock()
+		}
+	}
+
+	s.mu.Lock()
+	latest, checkedAt := s.latest, s.checkedAt
+	s.mu.Unlock()
+	if latest != "" {
+		info.LatestVersion = latest
+		info.UpdateAvailable = newerVersion(latest, s.build.Version)
+		info.CheckedAt = &checkedAt
+	}
+	return info, nil
+}
+
+// newerVersion reports whether release is a later version than current. Both
+// are read as dotted numbers with an optional "v" prefix and a pre-release or
+// build suffix ignored; a version that is not, such as "dev", is never older.
+func newerVersion(release, current string) bool {
+	r, ok := versionNumbers(release)
+	if !ok {
+		return false
+	}
+	c, ok := versionNumbers(current)
+	if !ok {
+		return false
+	}
+	for i := 0; i < len(r) || i < len(c); i++ {
+		var rn, cn int
+		if i < len(r) {
+			rn = r[i]
+		}
+		if i < len(c) {
+			cn = c[i]
+		}
+		if rn != cn {
+			return rn > cn
+		}
+	}
+	return false
+}
+
+// versionNumbers splits a version such as "v1.4.0-rc1" into [1 4 0]
+func versionNumbers(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	if version == "" {
+		return nil, false
+	}
+	parts := strings.Split(version, ".")
+	numbers := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, true
+}
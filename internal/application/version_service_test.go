@@ -0,0 +1,119 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/buildinfo"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestVersionService_GetVersion_WithoutFeed(t *testing.T) {
+	// Arrange
+	service := NewVersionService(buildinfo.Info{Version: "1.3.0", Commit: "abc1234"}, nil, time.Hour)
+
+	// Act
+	info, err := service.GetVersion(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.0", info.Version)
+	assert.Equal(t, "abc1234", info.Commit)
+	assert.Empty(t, info.LatestVersion)
+	assert.False(t, info.UpdateAvailable)
+}
+
+func TestVersionService_GetVersion_CachesFeedForInterval(t *testing.T) {
+	// Arrange
+	mockFeed := &mocks.MockReleaseFeed{}
+	service := NewVersionService(buildinfo.Info{Version: "1.3.0"}, mockFeed, time.Hour).(*versionService)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	mockFeed.On("LatestVersion", mock.Anything).Return("v1.4.0", nil).Once()
+
+	// Act
+	first, _ := service.GetVersion(context.Background())
+	now = now.Add(30 * time.Minute)
+	second, _ := service.GetVersion(context.Background())
+
+	// Assert
+	assert.Equal(t, "v1.4.0", first.LatestVersion)
+	assert.True(t, first.UpdateAvailable)
+	assert.True(t, second.UpdateAvailable)
+	mockFeed.AssertNumberOfCalls(t, "LatestVersion", 1)
+}
+
+func TestVersionService_GetVersion_FeedDownKeepsLastAnswer(t *testing.T) {
+	// Arrange
+	mockFeed := &mocks.MockReleaseFeed{}
+	service := NewVersionService(buildinfo.Info{Version: "1.4.0"}, mockFeed, time.Hour).(*versionService)
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	mockFeed.On("LatestVersion", mock.Anything).Return("v1.4.0", nil).Once()
+	mockFeed.On("LatestVersion", mock.Anything).Return("", assert.AnError).Once()
+
+	// Act
+	_, _ = service.GetVersion(context.Background())
+	now = now.Add(2 * time.Hour)
+	info, err := service.GetVersion(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.4.0", info.LatestVersion)
+	assert.False(t, info.UpdateAvailable)
+	mockFeed.AssertNumberOfCalls(t, "LatestVersion", 2)
+}
+
+func TestVersionService_GetVersion_DoesNotWaitOnFeed(t *testing.T) {
+	// Arrange
+	mockFeed := &mocks.MockReleaseFeed{}
+	service := NewVersionService(buildinfo.Info{Version: "1.3.0"}, mockFeed, time.Hour)
+	started, release := make(chan struct{}), make(chan struct{})
+	mockFeed.On("LatestVersion", mock.Anything).Run(func(mock.Arguments) {
+		close(started)
+		<-release
+	}).Return("v1.4.0", nil).Once()
+
+	checking := make(chan struct{})
+	go func() {
+		defer close(checking)
+		_, _ = service.GetVersion(context.Background())
+	}()
+	<-started
+
+	// Act
+	info, err := service.GetVersion(context.Background())
+	close(release)
+	<-checking
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3.0", info.Version)
+	assert.Empty(t, info.LatestVersion)
+	mockFeed.AssertNumberOfCalls(t, "LatestVersion", 1)
+}
+
+func TestNewerVersion(t *testing.T) {
+	tests := []struct {
+		release, current string
+		want             bool
+	}{
+		{"v1.4.0", "1.3.9", true},
+		{"1.10.0", "1.9.0", true},
+		{"v1.4", "1.4.0", false},
+		{"1.4.0", "1.4.0", false},
+		{"1.3.0", "1.4.0", false},
+		{"v1.4.0-rc1", "1.3.0", true},
+		{"v1.4.0", "dev", false},
+		{"latest", "1.3.0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.release+" vs "+tt.current, func(t *testing.T) {
+			assert.Equal(t, tt.want, newerVersion(tt.release, tt.current))
+		})
+	}
+}
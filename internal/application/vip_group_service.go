@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+)
+
+const (
+	// vipInviteResendAfter is how long a member sent the invite link waits
+	// before a sync sends it again
+	vipInviteResendAfter = 7 * 24 * time.Hour
+
+	// Bounds on the VIP group changes listed at once
+	defaultGroupSyncChangeLimit = 50
+	maxGroupSyncChangeLimit     = 200
+)
+
+// vipInviteMessage invites a qualifying member whose privacy settings keep
+// them from being added to the VIP group
+const vipInviteMessage = "🎉 Selamat! Anda kini termasuk pelanggan VIP kami.\n\nGabung ke grup VIP untuk promo dan info eksklusif:\n%s"
+
+type vipGroupService struct {
+	groups   domain.GroupRepository
+	segments domain.SegmentRepository
+	messages domain.MessageService
+	changes  domain.GroupSyncLogRepository
+	cfg      config.GroupConfig
+	now      func() time.Time
+}
+
+// NewVIPGroupService creates a service that keeps the members of
+// cfg.VIPGroupJID in line with the members of cfg.VIPTiers
+func NewVIPGroupService(groups domain.GroupRepository, segments domain.SegmentRepository, messages domain.MessageService, changes domain.GroupSyncLogRepository, cfg config.GroupConfig) domain.VIPGroupService {
+	return &vipGroupService{groups: groups, segments: segments, messages: messages, changes: changes, cfg: cfg, now: time.Now}
+}
+
+// Sync adds the qualifying members missing from the VIP group and removes the
+// members who dropped out of the qualifying tiers. A member WhatsApp will not
+// add for their privacy settings is sent the invite link instead, at most once
+// a week. Admins, and participants who are not members at all, are left alone.
+// Every change is logged.
+func (s *vipGroupService) Sync(ctx context.Context) (*domain.GroupSyncResult, error) {
+	groupJID := s.cfg.VIPGroupJID
+	if !groupJIDPattern.MatchString(groupJID) {
+		return nil, fmt.Errorf("%w: VIP_GROUP_JID is not a group JID", domain.ErrInvalidGroup)
+	}
+	group, err := s.groups.GetGroup(ctx, s.cfg.VIPSender, groupJID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.segments.ListSegmentMembers(0)
+	if err != nil {
+		return nil, err
+	}
+
+	add, remove := planVIPGroupSync(group.Participants, members, s.cfg.VIPTiers)
+	result := &domain.GroupSyncResult{GroupJID: groupJID, Changes: []*domain.GroupSyncChange{}}
+	if len(add) > 0 {
+		jids, err := participantJIDs(add)
+		if err != nil {
+			return nil, err
+		}
+		changes, err := s.groups.UpdateParticipants(ctx, s.cfg.VIPSender, groupJID, jids, true)
+		if err != nil {
+			return nil, err
+		}
+		var invite []string
+		for _, c := range changes {
+			switch {
+			case c.Success:
+				s.record(result, c.PhoneNumber, domain.GroupSyncAdded, "")
+			case c.NeedsInvite:
+				invite = append(invite, c.PhoneNumber)
+			default:
+				s.record(result, c.PhoneNumber, domain.GroupSyncFailed, c.Error)
+			}
+		}
+		if len(invite) > 0 {
+			if err := s.invite(ctx, result, invite); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(remove) > 0 {
+		jids, err := participantJIDs(remove)
+		if err != nil {
+			return nil, err
+		}
+		changes, err := s.groups.UpdateParticipants(ctx, s.cfg.VIPSender, groupJID, jids, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range changes {
+			if c.Success {
+				s.record(result, c.PhoneNumber, domain.GroupSyncRemoved, "")
+			} else {
+				s.record(result, c.PhoneNumber, domain.GroupSyncFailed, c.Error)
+			}
+		}
+	}
+	return result, nil
+}
+
+// invite sends the group's invite link to phones, skipping those sent it
+// within vipInviteResendAfter
+func (s *vipGroupService) invite(ctx context.Context, result *domain.GroupSyncResult, phones []string) error {
+	invited, err := s.changes.InvitedSince(result.GroupJID, s.now().Add(-vipInviteResendAfter))
+	if err != nil {
+		return err
+	}
+	phones = slices.DeleteFunc(phones, func(p string) bool { return slices.Contains(invited, p) })
+	if len(phones) == 0 {
+		return nil
+	}
+	link, err := s.groups.GetInviteLink(ctx, s.cfg.VIPSender, result.GroupJID, false)
+	if err != nil {
+		return err
+	}
+
+	for _, phone := range phones {
+		_, err := s.messages.SendMessage(ctx, &domain.SendMessageRequest{
+			To:      phone,
+			Message: fmt.Sprintf(vipInviteMessage, link),
+			From:    s.cfg.VIPSender,
+		})
+		if err != nil {
+			s.record(result, phone, domain.GroupSyncFailed, "failed to send invite link: "+err.Error())
+			continue
+		}
+		s.record(result, phone, domain.GroupSyncInvited, "")
+	}
+	return nil
+}
+
+// record counts and logs a change. A change that cannot be written to the log
+// was still made, so the sync carries on.
+func (s *vipGroupService) record(result *domain.GroupSyncResult, phone, action, reason string) {
+	change := &domain.GroupSyncChange{
+		GroupJID:    result.GroupJID,
+		PhoneNumber: phone,
+		Action:      action,
+		Error:       reason,
+		CreatedAt:   s.now(),
+	}
+	result.Changes = append(result.Changes, change)
+	switch action {
+	case domain.GroupSyncAdded:
+		result.Added++
+	case domain.GroupSyncInvited:
+		result.Invited++
+	case domain.GroupSyncRemoved:
+		result.Removed++
+	case domain.GroupSyncFailed:
+		result.Failed++
+	}
+
+	if reason != "" {
+		log.Printf("VIP group %s: %s %s: %s", result.GroupJID, action, phone, reason)
+	} else {
+		log.Printf("VIP group %s: %s %s", result.GroupJID, action, phone)
+	}
+	if err := s.changes.RecordChange(change); err != nil {
+		log.Printf("Failed to log VIP group change for %s: %v", phone, err)
+	}
+}
+
+// ListChanges returns the newest changes the sync made to the VIP group
+func (s *vipGroupService) ListChanges(ctx context.Context, limit int) ([]*domain.GroupSyncChange, error) {
+	if limit == 0 {
+		limit = defaultGroupSyncChangeLimit
+	}
+	if limit < 1 || limit > maxGroupSyncChangeLimit {
+		return nil, fmt.Errorf("%w: limit must be 1-%d", domain.ErrInvalidGroup, maxGroupSyncChangeLimit)
+	}
+	return s.changes.ListChanges(s.cfg.VIPGroupJID, limit)
+}
+
+// planVIPGroupSync returns the phone numbers of the members in tiers missing
+// from the group, and of the participants who are members outside tiers.
+// Admins stay, as do participants who are not members, such as staff.
+func planVIPGroupSync(participants []*domain.GroupParticipant, members []*domain.SegmentMember, tiers []string) (add, remove []string) {
+	qualifies := make(map[string]bool, len(members))
+	for _, m := range members {
+		phone := cleanPhoneNumber(m.PhoneNumber)
+		if len(phone) < 10 {
+			continue
+		}
+		tier := processor.TierForPoints(m.AccumulatedPoints)
+		qualifies[phone] = qualifies[phone] || slices.ContainsFunc(tiers, func(t string) bool { return strings.EqualFold(t, tier) })
+	}
+
+	inGroup := make(map[string]bool, len(participants))
+	for _, p := range participants {
+		phone := cleanPhoneNumber(p.PhoneNumber)
+		inGroup[phone] = true
+		if q, isMember := qualifies[phone]; isMember && !q && !p.IsAdmin {
+			remove = append(remove, phone)
+		}
+	}
+	for phone, q := range qualifies {
+		if q && !inGroup[phone] {
+			add = append(add, phone)
+		}
+	}
+	sort.Strings(add)
+	sort.Strings(remove)
+	return add, remove
+}
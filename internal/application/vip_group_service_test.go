@@ -0,0 +1,136 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+const vipGroupJID = "120363025246125486@g.us"
+
+func TestPlanVIPGroupSync(t *testing.T) {
+	participants := []*domain.GroupParticipant{
+		{PhoneNumber: "6281111111111"},                // still Platinum
+		{PhoneNumber: "6282222222222"},                // dropped to Gold
+		{PhoneNumber: "6283333333333", IsAdmin: true}, // admin, whatever the tier
+		{PhoneNumber: "6289999999999"},                // not a member, e.g. staff
+	}
+	members := []*domain.SegmentMember{
+		{PhoneNumber: "+62 811-1111-1111", AccumulatedPoints: 1500},
+		{PhoneNumber: "6282222222222", AccumulatedPoints: 600},
+		{PhoneNumber: "6283333333333", AccumulatedPoints: 0},
+		{PhoneNumber: "6285555555555", AccumulatedPoints: 1000}, // new Platinum
+		{PhoneNumber: "6284444444444", AccumulatedPoints: 2000},
+		{PhoneNumber: "123", AccumulatedPoints: 5000}, // not a phone number
+	}
+
+	add, remove := planVIPGroupSync(participants, members, []string{"platinum"})
+
+	assert.Equal(t, []string{"6284444444444", "6285555555555"}, add)
+	assert.Equal(t, []string{"6282222222222"}, remove)
+}
+
+func TestVIPGroupService_Sync(t *testing.T) {
+	// Arrange
+	mockGroups := &mocks.MockGroupRepository{}
+	mockSegments := &mocks.MockSegmentRepository{}
+	mockMessages := &mocks.MockMessageService{}
+	mockChanges := &mocks.MockGroupSyncLogRepository{}
+	cfg := config.GroupConfig{VIPGroupJID: vipGroupJID, VIPTiers: []string{"Platinum"}, VIPSender: "6280000000000"}
+	service := NewVIPGroupService(mockGroups, mockSegments, mockMessages, mockChanges, cfg)
+
+	mockGroups.On("GetGroup", mock.Anything, "6280000000000", vipGroupJID).Return(&domain.Group{
+		JID:          vipGroupJID,
+		Participants: []*domain.GroupParticipant{{PhoneNumber: "6282222222222"}},
+	}, nil)
+	mockSegments.On("ListSegmentMembers", 0).Return([]*domain.SegmentMember{
+		{PhoneNumber: "6281111111111", AccumulatedPoints: 1200},
+		{PhoneNumber: "6282222222222", AccumulatedPoints: 200},
+		{PhoneNumber: "6283333333333", AccumulatedPoints: 1100},
+		{PhoneNumber: "6284444444444", AccumulatedPoints: 1300},
+	}, nil)
+	mockGroups.On("UpdateParticipants", mock.Anything, "6280000000000", vipGroupJID,
+		[]string{"6281111111111@s.whatsapp.net", "6283333333333@s.whatsapp.net", "6284444444444@s.whatsapp.net"}, true).
+		Return([]*domain.GroupParticipantChange{
+			{PhoneNumber: "6281111111111", Success: true},
+			{PhoneNumber: "6283333333333", Error: "privacy", NeedsInvite: true},
+			{PhoneNumber: "6284444444444", Error: "privacy", NeedsInvite: true},
+		}, nil)
+	mockGroups.On("UpdateParticipants", mock.Anything, "6280000000000", vipGroupJID,
+		[]string{"6282222222222@s.whatsapp.net"}, false).
+		Return([]*domain.GroupParticipantChange{{PhoneNumber: "6282222222222", Success: true}}, nil)
+	mockChanges.On("InvitedSince", vipGroupJID, mock.AnythingOfType("time.Time")).Return([]string{"6284444444444"}, nil)
+	mockGroups.On("GetInviteLink", mock.Anything, "6280000000000", vipGroupJID, false).Return("https://chat.whatsapp.com/AbCd", nil)
+	mockMessages.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *domain.SendMessageRequest) bool {
+		return req.To == "6283333333333" && req.From == "6280000000000"
+	})).Return(&domain.SendMessageResponse{Success: true}, nil)
+	mockChanges.On("RecordChange", mock.Anything).Return(nil)
+
+	// Act
+	result, err := service.Sync(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Added)
+	assert.Equal(t, 1, result.Invited) // 6284444444444 was invited this week already
+	assert.Equal(t, 1, result.Removed)
+	assert.Equal(t, 0, result.Failed)
+	assert.Len(t, result.Changes, 3)
+	mockMessages.AssertNumberOfCalls(t, "SendMessage", 1)
+	mockChanges.AssertNumberOfCalls(t, "RecordChange", 3)
+	mockGroups.AssertExpectations(t)
+}
+
+func TestVIPGroupService_Sync_NothingToChange(t *testing.T) {
+	// Arrange
+	mockGroups := &mocks.MockGroupRepository{}
+	mockSegments := &mocks.MockSegmentRepository{}
+	service := NewVIPGroupService(mockGroups, mockSegments, nil, nil, config.GroupConfig{VIPGroupJID: vipGroupJID, VIPTiers: []string{"Platinum"}})
+
+	mockGroups.On("GetGroup", mock.Anything, "", vipGroupJID).Return(&domain.Group{
+		JID:          vipGroupJID,
+		Participants: []*domain.GroupParticipant{{PhoneNumber: "6281111111111"}},
+	}, nil)
+	mockSegments.On("ListSegmentMembers", 0).Return([]*domain.SegmentMember{
+		{PhoneNumber: "6281111111111", AccumulatedPoints: 1200},
+	}, nil)
+
+	// Act
+	result, err := service.Sync(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result.Changes)
+	mockGroups.AssertNotCalled(t, "UpdateParticipants", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVIPGroupService_Sync_NotConnected(t *testing.T) {
+	mockGroups := &mocks.MockGroupRepository{}
+	service := NewVIPGroupService(mockGroups, nil, nil, nil, config.GroupConfig{VIPGroupJID: vipGroupJID})
+	mockGroups.On("GetGroup", mock.Anything, "", vipGroupJID).Return(nil, domain.ErrWhatsAppNotConnected)
+
+	_, err := service.Sync(context.Background())
+
+	assert.Equal(t, domain.ErrWhatsAppNotConnected, err)
+}
+
+func TestVIPGroupService_ListChanges(t *testing.T) {
+	mockChanges := &mocks.MockGroupSyncLogRepository{}
+	service := NewVIPGroupService(nil, nil, nil, mockChanges, config.GroupConfig{VIPGroupJID: vipGroupJID})
+	mockChanges.On("ListChanges", vipGroupJID, 50).Return([]*domain.GroupSyncChange{
+		{GroupJID: vipGroupJID, PhoneNumber: "6281111111111", Action: domain.GroupSyncAdded, CreatedAt: time.Now()},
+	}, nil)
+
+	changes, err := service.ListChanges(context.Background(), 0)
+	_, limitErr := service.ListChanges(context.Background(), 1000)
+
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.ErrorIs(t, limitErr, domain.ErrInvalidGroup)
+}
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+)
+
+// maxBranchLength matches the vouchers.branch column
+const maxBranchLength = 60
+
+type voucherService struct {
+	db *sql.DB
+}
+
+// NewVoucherService creates a new voucher redemption service
+func NewVoucherService(db *sql.DB) domain.VoucherService {
+	return &voucherService{db: db}
+}
+
+// RedeemVoucher marks a voucher used at the counter of the request's branch. A
+// voucher can be redeemed once and only before it expires.
+func (s *voucherService) RedeemVoucher(ctx context.Context, code string, req *domain.RedeemVoucherRequest) (*domain.Voucher, error) {
+	code = processor.NormalizeVoucherCode(code)
+	if code == "" {
+		return nil, domain.ErrVoucherNotFound
+	}
+	var branch string
+	if req != nil {
+		branch = strings.TrimSpace(req.Branch)
+	}
+	if runes := []rune(branch); len(runes) > maxBranchLength {
+		branch = string(runes[:maxBranchLength])
+	}
+
+	voucher, err := repository.RedeemVoucher(s.db, code, branch)
+	switch err {
+	case nil:
+	case repository.ErrVoucherNotFound:
+		return nil, domain.ErrVoucherNotFound
+	case repository.ErrVoucherAlreadyRedeemed:
+		return nil, domain.ErrVoucherRedeemed
+	case repository.ErrVoucherExpired:
+		return nil, domain.ErrVoucherExpired
+	default:
+		return nil, err
+	}
+
+	result := &domain.Voucher{
+		Code:        voucher.Code,
+		PhoneNumber: voucher.PhoneNumber,
+		Reward:      voucher.Reward,
+		Points:      voucher.Points,
+		IssuedAt:    voucher.IssuedAt.Format(time.RFC3339),
+		Branch:      voucher.Branch,
+	}
+	if voucher.ExpiresAt != nil {
+		result.ExpiresAt = voucher.ExpiresAt.Format(time.RFC3339)
+	}
+	if voucher.RedeemedAt != nil {
+		result.RedeemedAt = voucher.RedeemedAt.Format(time.RFC3339)
+	}
+	return result, nil
+}
+
+// GetSettlement reports the vouchers issued, redeemed (per branch) and expired on
+// date, and those outstanding at the end of it. Values are in points.
+func (s *voucherService) GetSettlement(ctx context.Context, date string) (*domain.VoucherSettlement, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, domain.ErrInvalidDate
+	}
+
+	settlement, err := repository.GetVoucherSettlement(s.db, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.VoucherSettlement{
+		Date:        day.Format("2006-01-02"),
+		Issued:      domain.VoucherTally(settlement.Issued),
+		Redeemed:    domain.VoucherTally(settlement.Redeemed),
+		Expired:     domain.VoucherTally(settlement.Expired),
+		Outstanding: domain.VoucherTally(settlement.Outstanding),
+		Branches:    make([]domain.BranchVoucherRedemptions, 0, len(settlement.Branches)),
+	}
+	for _, b := range settlement.Branches {
+		result.Branches = append(result.Branches, domain.BranchVoucherRedemptions{
+			Branch:   b.Branch,
+			Redeemed: domain.VoucherTally(b.Redeemed),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,163 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+)
+
+func TestVoucherService_RedeemVoucher_BlankCode(t *testing.T) {
+	service := NewVoucherService(nil)
+
+	voucher, err := service.RedeemVoucher(context.Background(), "   ", nil)
+
+	assert.Nil(t, voucher)
+	assert.Equal(t, domain.ErrVoucherNotFound, err)
+}
+
+func TestVoucherService_GetSettlement_InvalidDate(t *testing.T) {
+	service := NewVoucherService(nil)
+
+	settlement, err := service.GetSettlement(context.Background(), "16-10-2026")
+
+	assert.Nil(t, settlement)
+	assert.Equal(t, domain.ErrInvalidDate, err)
+}
+
+// setupVoucherDB returns an in-memory database with a member and the
+// redemption transactions vouchers are issued for
+func setupVoucherDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	for _, ddl := range []string{
+		`CREATE TABLE members (member_id INTEGER PRIMARY KEY, phone_number VARCHAR(20) NOT NULL)`,
+		`CREATE TABLE point_transactions (transaction_id INTEGER PRIMARY KEY, claimed_at TIMESTAMP, updated_at TIMESTAMP)`,
+		`CREATE TABLE vouchers (voucher_id INTEGER PRIMARY KEY AUTOINCREMENT, code VARCHAR(20) UNIQUE NOT NULL, transaction_id INTEGER UNIQUE NOT NULL, member_id INTEGER NOT NULL, reward TEXT NOT NULL, points INTEGER NOT NULL, issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP, redeemed_at TIMESTAMP, expires_at TIMESTAMP, branch VARCHAR(60))`,
+		`INSERT INTO members (member_id, phone_number) VALUES (1, '6281234567890')`,
+	} {
+		_, err := db.Exec(ddl)
+		require.NoError(t, err)
+	}
+	return db
+}
+
+// insertVoucher issues a voucher for a new redemption transaction
+func insertVoucher(t *testing.T, db *sql.DB, transactionID int, code string, points int, issuedAt time.Time, expiresAt *time.Time) {
+	t.Helper()
+	_, err := db.Exec("INSERT INTO point_transactions (transaction_id) VALUES (?)", transactionID)
+	require.NoError(t, err)
+	_, err = db.Exec(
+		"INSERT INTO vouchers (code, transaction_id, member_id, reward, points, issued_at, expires_at) VALUES (?, ?, 1, 'Free wash', ?, ?, ?)",
+		code, transactionID, points, issuedAt, expiresAt,
+	)
+	require.NoError(t, err)
+}
+
+func TestVoucherService_RedeemVoucher_OnlyOnce(t *testing.T) {
+	// Arrange
+	db := setupVoucherDB(t)
+	now := time.Now().UTC()
+	expiresAt := now.AddDate(0, 0, 30)
+	insertVoucher(t, db, 10, "WP-ABC123", 100, now, &expiresAt)
+	service := NewVoucherService(db)
+
+	// Act
+	first, firstErr := service.RedeemVoucher(context.Background(), " wp-abc123 ", &domain.RedeemVoucherRequest{Branch: "Kemang"})
+	second, secondErr := service.RedeemVoucher(context.Background(), "WP-ABC123", &domain.RedeemVoucherRequest{Branch: "Menteng"})
+
+	// Assert
+	require.NoError(t, firstErr)
+	assert.Equal(t, "Kemang", first.Branch)
+	assert.NotEmpty(t, first.RedeemedAt)
+	assert.Nil(t, second)
+	assert.Equal(t, domain.ErrVoucherRedeemed, secondErr)
+
+	var branch string
+	var claimed bool
+	require.NoError(t, db.QueryRow("SELECT branch FROM vouchers WHERE code = 'WP-ABC123'").Scan(&branch))
+	require.NoError(t, db.QueryRow("SELECT claimed_at IS NOT NULL FROM point_transactions WHERE transaction_id = 10").Scan(&claimed))
+	assert.Equal(t, "Kemang", branch)
+	assert.True(t, claimed)
+}
+
+func TestVoucherService_RedeemVoucher_Refused(t *testing.T) {
+	db := setupVoucherDB(t)
+	expiredAt := time.Now().UTC().AddDate(0, 0, -1)
+	insertVoucher(t, db, 10, "WP-OLD001", 100, expiredAt.AddDate(0, 0, -30), &expiredAt)
+	service := NewVoucherService(db)
+
+	tests := []struct {
+		name string
+		code string
+		err  error
+	}{
+		{name: "expired", code: "WP-OLD001", err: domain.ErrVoucherExpired},
+		{name: "unknown", code: "WP-NONE00", err: domain.ErrVoucherNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			voucher, err := service.RedeemVoucher(context.Background(), tt.code, nil)
+
+			assert.Nil(t, voucher)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+
+	var claimed bool
+	require.NoError(t, db.QueryRow("SELECT claimed_at IS NOT NULL FROM point_transactions WHERE transaction_id = 10").Scan(&claimed))
+	assert.False(t, claimed)
+}
+
+func TestVoucherService_GetSettlement(t *testing.T) {
+	// Arrange: times are local, as the settlement day is
+	db := setupVoucherDB(t)
+	day := time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)
+	at := func(days, hour int) *time.Time {
+		ts := day.AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+		return &ts
+	}
+	insertVoucher(t, db, 1, "WP-KEMANG", 100, *at(0, 9), at(30, 0))  // issued and redeemed at Kemang
+	insertVoucher(t, db, 2, "WP-UNUSED", 200, *at(0, 10), at(30, 0)) // issued, still outstanding
+	insertVoucher(t, db, 3, "WP-EXPIRE", 50, *at(-10, 9), at(0, 12)) // expired unused
+	insertVoucher(t, db, 4, "WP-NOBRCH", 300, *at(-5, 9), nil)       // redeemed without a branch
+	insertVoucher(t, db, 5, "WP-LATER1", 400, *at(1, 9), at(30, 0))  // issued the next day
+	for _, redeemed := range []struct {
+		code   string
+		at     *time.Time
+		branch any
+	}{
+		{"WP-KEMANG", at(0, 15), "Kemang"},
+		{"WP-NOBRCH", at(0, 11), nil},
+	} {
+		_, err := db.Exec("UPDATE vouchers SET redeemed_at = ?, branch = ? WHERE code = ?", redeemed.at, redeemed.branch, redeemed.code)
+		require.NoError(t, err)
+	}
+	service := NewVoucherService(db)
+
+	// Act
+	settlement, err := service.GetSettlement(context.Background(), "2026-10-15")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, &domain.VoucherSettlement{
+		Date:        "2026-10-15",
+		Issued:      domain.VoucherTally{Count: 2, Points: 300},
+		Redeemed:    domain.VoucherTally{Count: 2, Points: 400},
+		Expired:     domain.VoucherTally{Count: 1, Points: 50},
+		Outstanding: domain.VoucherTally{Count: 1, Points: 200},
+		Branches: []domain.BranchVoucherRedemptions{
+			{Branch: "", Redeemed: domain.VoucherTally{Count: 1, Points: 300}},
+			{Branch: "Kemang", Redeemed: domain.VoucherTally{Count: 1, Points: 100}},
+		},
+	}, settlement)
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Message represents a WhatsApp message
 type Message struct {
 	ID      string
@@ -10,37 +12,323 @@ type Message struct {
 
 // SendMessageRequest represents the request to send a message
 type SendMessageRequest struct {
-	To      string `json:"to" validate:"required"`
-	Message string `json:"message" validate:"required"`
-	From    string `json:"from,omitempty"` // Optional: sender phone number identifier
+	To       string `json:"to" validate:"required"`
+	Message  string `json:"message" validate:"required"`
+	From     string `json:"from,omitempty"`     // Optional: sender phone number identifier
+	Category string `json:"category,omitempty"` // Optional: message category whose fallback chain is used
+	Pool     string `json:"pool,omitempty"`     // Optional: named sender pool a healthy member of sends from; not with From
+	DryRun   bool   `json:"dry_run,omitempty"`  // Validate only; nothing is sent
+	Force    bool   `json:"force,omitempty"`    // Send even when the same message just went to this recipient
+
+	// Optional: thread Message under a received message, quoting it
+	ReplyTo       string `json:"reply_to,omitempty"`        // WhatsApp ID of the message replied to
+	ReplyToSender string `json:"reply_to_sender,omitempty"` // Who sent it; required in groups, defaults to the recipient
+
+	// Optional: send Message as the body of tappable reply buttons or a list menu
+	Interactive *InteractiveMessage `json:"interactive,omitempty"`
+
+	// Optional: first set the chat's disappearing-message timer (off, 24h, 7d
+	// or 90d), such as for redemption codes. It stays set for later messages.
+	DisappearAfter string `json:"disappear_after,omitempty"`
+}
+
+// InteractiveMessage adds reply buttons or a list menu to a text message. Exactly
+// one of Buttons or List is set. A tapped button or row arrives back as a
+// message whose text is its ID.
+type InteractiveMessage struct {
+	Footer  string        `json:"footer,omitempty"`  // Optional small text under the body
+	Buttons []ReplyButton `json:"buttons,omitempty"` // Up to 3 reply buttons
+	List    *ListMenu     `json:"list,omitempty"`    // A menu opened by a single button
+}
+
+// ReplyButton is one tappable reply button
+type ReplyButton struct {
+	ID    string `json:"id"`    // Sent back when tapped, e.g. "poin"
+	Title string `json:"title"` // Up to 20 characters
+}
+
+// ListMenu is a menu of rows grouped in sections, opened by a button
+type ListMenu struct {
+	Button   string        `json:"button"` // Label of the button that opens the menu
+	Sections []ListSection `json:"sections"`
+}
+
+// ListSection groups rows under an optional title
+type ListSection struct {
+	Title string    `json:"title,omitempty"`
+	Rows  []ListRow `json:"rows"`
+}
+
+// ListRow is one selectable menu entry
+type ListRow struct {
+	ID          string `json:"id"`    // Sent back when selected
+	Title       string `json:"title"` // Up to 24 characters
+	Description string `json:"description,omitempty"`
 }
 
 // SendMessageResponse represents the response after sending a message
 type SendMessageResponse struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
-	ID      string `json:"id,omitempty"`
+	Success  bool     `json:"success"`
+	Message  string   `json:"message"`
+	ID       string   `json:"id,omitempty"`
+	IDs      []string `json:"ids,omitempty"`       // every part's message ID, in order, when a long text was split; ID is the first
+	SenderID string   `json:"sender_id,omitempty"` // sender used when one was named or a fallback chain or the sender pool was applied
+	Fallback bool     `json:"fallback,omitempty"`  // the requested sender was offline, so SenderID from the sender pool sent it
+	Queued   bool     `json:"queued,omitempty"`    // sending failed transiently; the message will be retried
+	QueueID  int64    `json:"queue_id,omitempty"`  // send queue entry of a queued message
+}
+
+// SendImageRequest represents the request to send an image message. The image
+// is either base64 in JSON or a multipart file upload, which fills Data.
+type SendImageRequest struct {
+	To      string `json:"to" form:"to" validate:"required"`
+	Image   string `json:"image,omitempty"`                  // Base64-encoded JPEG or PNG; a data: URI prefix is accepted
+	Caption string `json:"caption,omitempty" form:"caption"` // Optional text shown under the image
+	From    string `json:"from,omitempty" form:"from"`       // Optional: sender phone number identifier
+	Data    []byte `json:"-"`                                // Raw image bytes, decoded from Image or read from the upload
+}
+
+// SendDocumentRequest represents the request to send a document such as a PDF
+// invoice. The file is either base64 in JSON or a multipart upload, which fills Data.
+type SendDocumentRequest struct {
+	To       string `json:"to" form:"to" validate:"required"`
+	Document string `json:"document,omitempty"`                 // Base64-encoded file; a data: URI prefix is accepted
+	FileName string `json:"filename" form:"filename"`           // Name shown to the recipient, e.g. invoice-0042.pdf
+	MimeType string `json:"mimetype,omitempty" form:"mimetype"` // Optional: detected from the filename when empty
+	Caption  string `json:"caption,omitempty" form:"caption"`   // Optional text shown under the document
+	From     string `json:"from,omitempty" form:"from"`         // Optional: sender phone number identifier
+	Data     []byte `json:"-"`                                  // Raw file bytes, decoded from Document or read from the upload
+}
+
+// SendAudioRequest represents the request to send an OGG or MP3 file as a voice
+// note or audio message. The file is either base64 in JSON or a multipart upload.
+type SendAudioRequest struct {
+	To    string `json:"to" form:"to" validate:"required"`
+	Audio string `json:"audio,omitempty"`            // Base64-encoded OGG/Opus or MP3; a data: URI prefix is accepted
+	PTT   bool   `json:"ptt" form:"ptt"`             // Send as a voice note (push-to-talk); requires OGG/Opus
+	From  string `json:"from,omitempty" form:"from"` // Optional: sender phone number identifier
+	Data  []byte `json:"-"`                          // Raw audio bytes, decoded from Audio or read from the upload
+}
+
+// SendLocationRequest represents the request to share a map location, such as
+// the store for pickup and drop-off
+type SendLocationRequest struct {
+	To        string   `json:"to" validate:"required"`
+	Latitude  *float64 `json:"latitude"`          // Required, -90 to 90
+	Longitude *float64 `json:"longitude"`         // Required, -180 to 180
+	Name      string   `json:"name,omitempty"`    // Optional place name, e.g. the store name
+	Address   string   `json:"address,omitempty"` // Optional address shown under the name
+	From      string   `json:"from,omitempty"`    // Optional: sender phone number identifier
+}
+
+// SendReactionRequest represents the request to react to a message in a chat,
+// such as acknowledging a customer's message with 👍
+type SendReactionRequest struct {
+	MessageID string `json:"message_id" validate:"required"` // WhatsApp ID of the message reacted to
+	ChatJID   string `json:"chat_jid" validate:"required"`   // Phone number or group JID of the chat
+	Emoji     string `json:"emoji"`                          // Reaction emoji; empty removes an earlier reaction
+	Sender    string `json:"sender,omitempty"`               // Who sent the message; required in groups, defaults to the chat
+	From      string `json:"from,omitempty"`                 // Optional: sender phone number identifier
+}
+
+// RevokeMessageRequest represents the request to delete a sent message for
+// everyone
+type RevokeMessageRequest struct {
+	MessageID string `json:"message_id"`     // WhatsApp ID of the message to delete
+	To        string `json:"to,omitempty"`   // Chat the message was sent to; looked up in the message history when empty
+	From      string `json:"from,omitempty"` // Sender that sent it; looked up in the message history when empty
+}
+
+// EditMessageRequest represents the request to change the text of a sent
+// message. The message ID is taken from the URL.
+type EditMessageRequest struct {
+	MessageID string `json:"-"`
+	Message   string `json:"message" validate:"required"` // The new text
+	To        string `json:"to,omitempty"`                // Chat the message was sent to; looked up in the message history when empty
+	From      string `json:"from,omitempty"`              // Sender that sent it; looked up in the message history when empty
+}
+
+// Chat presence states shown to the other side of a chat
+const (
+	ChatPresenceTyping    = "typing"
+	ChatPresenceRecording = "recording" // recording a voice note
+	ChatPresencePaused    = "paused"    // clears typing or recording
+)
+
+// Sender presence states
+const (
+	PresenceAvailable   = "available"
+	PresenceUnavailable = "unavailable"
+)
+
+// SendChatPresenceRequest represents the request to show typing or recording in
+// a chat, such as while a multi-step flow prepares its next message
+type SendChatPresenceRequest struct {
+	ChatJID string `json:"chat_jid" validate:"required"` // Phone number or group JID of the chat
+	State   string `json:"state" validate:"required"`    // typing, recording or paused
+	From    string `json:"from,omitempty"`               // Optional: sender phone number identifier
+}
+
+// SetPresenceRequest represents the request to show a sender as online or offline
+type SetPresenceRequest struct {
+	Presence string `json:"presence" validate:"required"` // available or unavailable
+	From     string `json:"from,omitempty"`               // Optional: sender phone number identifier
+}
+
+// Disappearing-message timers a chat can have
+const (
+	DisappearingOff     = "off"
+	Disappearing24Hours = "24h"
+	Disappearing7Days   = "7d"
+	Disappearing90Days  = "90d"
+)
+
+// SetDisappearingRequest represents the request to set how long messages in a
+// chat last before they disappear
+type SetDisappearingRequest struct {
+	ChatJID string `json:"chat_jid" validate:"required"` // Phone number or group JID of the chat
+	Timer   string `json:"timer" validate:"required"`    // off, 24h, 7d or 90d
+	From    string `json:"from,omitempty"`               // Optional: sender phone number identifier
+}
+
+// ContactCard is one contact to share, such as the admin's number
+type ContactCard struct {
+	Name         string `json:"name"`                   // Display name
+	Phone        string `json:"phone"`                  // Phone number with country code, e.g. +6281234567890
+	Organization string `json:"organization,omitempty"` // Optional company, e.g. the laundry name
+	VCard        string `json:"-"`                      // vCard built from the fields above
+}
+
+// SendContactRequest represents the request to send one or more contact cards
+type SendContactRequest struct {
+	To       string        `json:"to" validate:"required"`
+	Contacts []ContactCard `json:"contacts"`       // One or more contacts, sent as a single message
+	From     string        `json:"from,omitempty"` // Optional: sender phone number identifier
 }
 
 // WhatsAppStatus represents the status of WhatsApp client
 type WhatsAppStatus struct {
+	Status    string `json:"status"` // WhatsAppStatusOK, or WhatsAppStatusDegraded when messages cannot be sent
 	Connected bool   `json:"connected"`
 	LoggedIn  bool   `json:"logged_in"`
 	JID       string `json:"jid,omitempty"`
+	Error     string `json:"error,omitempty"` // why the status is degraded
 }
 
+// WhatsApp client statuses
+const (
+	WhatsAppStatusOK       = "ok"
+	WhatsAppStatusDegraded = "degraded"
+)
+
 // ServiceStatus represents the overall service status
 type ServiceStatus struct {
-	WhatsApp WhatsAppStatus `json:"whatsapp"`
+	WhatsApp WhatsAppStatus   `json:"whatsapp"`
+	Queue    *SendQueueStatus `json:"queue,omitempty"` // set when sends are retried through the send queue
+}
+
+// SendQueueStatus reports the messages waiting in the send queue
+type SendQueueStatus struct {
+	Depth          int `json:"depth"`           // messages waiting for a retry
+	Failed         int `json:"failed"`          // messages given up on after the last retry
+	Expired        int `json:"expired"`         // messages held for a sender to reconnect until they expired
+	FailedAttempts int `json:"failed_attempts"` // failed sends of waiting and given-up messages
+}
+
+// IdempotencyRecord is the outcome of the first request made with an
+// Idempotency-Key, replayed for duplicates
+type IdempotencyRecord struct {
+	Fingerprint string // hash of the first request, to detect a key reused for another one
+	StatusCode  int
+	Response    []byte
+	Completed   bool // false while the first request is still being processed
+}
+
+// QueuedSend is a message in the send queue
+type QueuedSend struct {
+	ID        int64
+	Request   *SendMessageRequest
+	RequestID string // X-Request-ID of the API call that queued it
+	Attempts  int    // sends tried so far
+	LastError string
+	QueuedAt  time.Time
+}
+
+// DeadLetter is a queued message that was given up on, after its last retry
+// failed or while it waited too long for a sender to reconnect
+type DeadLetter struct {
+	ID         int64               `json:"id"`
+	QueueID    int64               `json:"queue_id"` // the send queue entry it was given up on as
+	Request    *SendMessageRequest `json:"request"`
+	RequestID  string              `json:"request_id,omitempty"`
+	Status     string              `json:"status"` // failed or expired
+	Attempts   int                 `json:"attempts"`
+	LastError  string              `json:"last_error"`
+	QueuedAt   string              `json:"queued_at"`             // RFC3339
+	FailedAt   string              `json:"failed_at"`             // RFC3339
+	RequeuedAt string              `json:"requeued_at,omitempty"` // RFC3339; empty until requeued
+	RequeueID  int64               `json:"requeue_id,omitempty"`  // the send queue entry it was requeued as
+}
+
+// DeadLetterQuery filters the dead letters listed
+type DeadLetterQuery struct {
+	Limit           int  `form:"limit"`            // 1-200, default 50
+	IncludeRequeued bool `form:"include_requeued"` // also list dead letters already requeued
 }
 
 // Sender represents a WhatsApp sender account
 type Sender struct {
-	ID          string `json:"id"`           // Unique identifier for the sender
-	PhoneNumber string `json:"phone_number"` // Phone number in WhatsApp format
-	Name        string `json:"name"`         // Friendly name for the sender
-	IsDefault   bool   `json:"is_default"`   // Whether this is the default sender
-	IsActive    bool   `json:"is_active"`    // Whether this sender is currently active
+	ID          string   `json:"id"`                     // Unique identifier for the sender
+	PhoneNumber string   `json:"phone_number"`           // Phone number in WhatsApp format
+	Name        string   `json:"name"`                   // Friendly name for the sender
+	Description string   `json:"description,omitempty"`  // What the sender is used for
+	Tags        []string `json:"tags,omitempty"`         // Labels for grouping senders, e.g. by branch
+	IsDefault   bool     `json:"is_default"`             // Whether this is the default sender
+	IsActive    bool     `json:"is_active"`              // Whether this sender is currently active
+	IsPaused    bool     `json:"is_paused"`              // Taken out of rotation by an operator; still connected
+	State       string   `json:"state,omitempty"`        // active, restricted or banned
+	StateReason string   `json:"state_reason,omitempty"` // WhatsApp's reason when not active
+	DailyQuota  int      `json:"daily_quota,omitempty"`  // Most messages the sender may send a day; 0 is no cap
+
+	FallbackChains map[string]int `json:"fallback_chains,omitempty"` // category -> 1-based position in its chain
+}
+
+// UpdateSenderRequest represents the request to relabel a sender. It replaces
+// the name, description and tags.
+type UpdateSenderRequest struct {
+	Name        string   `json:"name"`                  // Required, at most 100 characters
+	Description string   `json:"description,omitempty"` // At most 500 characters
+	Tags        []string `json:"tags,omitempty"`        // Up to 20 tags of at most 50 characters, without commas
+}
+
+// SenderHealth is what monitoring checks about a sender. It is healthy when it
+// is connected, logged in, in an active state and not paused.
+type SenderHealth struct {
+	SenderID             string     `json:"sender_id"`
+	PhoneNumber          string     `json:"phone_number"`
+	Healthy              bool       `json:"healthy"`
+	Connected            bool       `json:"connected"`
+	LoggedIn             bool       `json:"logged_in"`
+	PushName             string     `json:"push_name,omitempty"` // name shown to recipients
+	State                string     `json:"state"`               // active, restricted or banned
+	IsPaused             bool       `json:"is_paused"`
+	LastSentAt           *time.Time `json:"last_sent_at"` // null until the sender sends a message
+	LastDisconnectReason string     `json:"last_disconnect_reason,omitempty"`
+	LastDisconnectedAt   *time.Time `json:"last_disconnected_at,omitempty"`
+}
+
+// SenderQuota is a sender's daily send quota and how much of it is used
+type SenderQuota struct {
+	SenderID   string    `json:"sender_id"`
+	DailyQuota int       `json:"daily_quota"`         // 0 is no cap
+	SentToday  int       `json:"sent_today"`          // messages sent since midnight in the quota time zone
+	Remaining  *int      `json:"remaining,omitempty"` // absent when there is no cap
+	ResetsAt   time.Time `json:"resets_at"`
+}
+
+// SetSenderQuotaRequest represents the request to cap a sender's daily sends
+type SetSenderQuotaRequest struct {
+	DailyQuota int `json:"daily_quota"` // 0 removes the cap
 }
 
 // RegisterSenderQRRequest represents the request to start QR registration
@@ -70,6 +358,49 @@ type RegisterSenderCodeResponse struct {
 	Message     string `json:"message,omitempty"`      // Status or error message
 }
 
+// RegisterSendersRequest represents the request to register many senders with
+// pairing codes at once, such as an agency onboarding its clients' numbers
+type RegisterSendersRequest struct {
+	PhoneNumbers []string `json:"phone_numbers"` // Phone numbers with country code
+}
+
+// Registration batch entry statuses
+const (
+	RegistrationQueued    = "queued"    // pairing code not requested yet
+	RegistrationPending   = "pending"   // pairing code issued, waiting for it to be entered
+	RegistrationConnected = "connected" // paired and registered as a sender
+	RegistrationFailed    = "failed"
+)
+
+// Registration batch statuses
+const (
+	RegistrationBatchRunning   = "running"
+	RegistrationBatchCompleted = "completed"
+)
+
+// RegistrationBatch is the progress of a bulk sender registration
+type RegistrationBatch struct {
+	ID        string                   `json:"id"`
+	Status    string                   `json:"status"` // running or completed
+	Total     int                      `json:"total"`
+	Queued    int                      `json:"queued"`
+	Pending   int                      `json:"pending"`
+	Connected int                      `json:"connected"`
+	Failed    int                      `json:"failed"`
+	CreatedAt string                   `json:"created_at"` // RFC3339
+	Numbers   []RegistrationBatchEntry `json:"numbers"`
+}
+
+// RegistrationBatchEntry is the progress of one number of a bulk registration
+type RegistrationBatchEntry struct {
+	PhoneNumber string `json:"phone_number"`
+	Status      string `json:"status"`                 // queued, pending, connected or failed
+	SessionID   string `json:"session_id,omitempty"`   // Registration session, also readable with register-sender-status
+	PairingCode string `json:"pairing_code,omitempty"` // The pairing code to enter in WhatsApp, while pending
+	SenderID    string `json:"sender_id,omitempty"`    // Set when connected
+	Error       string `json:"error,omitempty"`
+}
+
 // AIReplyRequest is the request to generate a suggested AI reply.
 type AIReplyRequest struct {
 	Message     string `json:"message" validate:"required"`
@@ -103,3 +434,1066 @@ type RegistrationStatusResponse struct {
 	QRCode   string `json:"qr_code,omitempty"`   // Updated QR code (for refresh scenarios)
 	Message  string `json:"message,omitempty"`   // Status or error message
 }
+
+// CreateTenantRequest represents the request to onboard a new tenant
+type CreateTenantRequest struct {
+	Name       string `json:"name" validate:"required"`        // Business name, e.g. "Ruang Laundry"
+	Slug       string `json:"slug,omitempty"`                  // Optional URL-safe identifier; derived from Name when empty
+	AdminName  string `json:"admin_name" validate:"required"`  // Name of the initial tenant administrator
+	AdminPhone string `json:"admin_phone" validate:"required"` // Administrator phone number with country code
+}
+
+// CreateTenantResponse represents the result of tenant provisioning
+type CreateTenantResponse struct {
+	Success         bool   `json:"success"`
+	Message         string `json:"message,omitempty"`
+	TenantID        int    `json:"tenant_id,omitempty"`
+	Slug            string `json:"slug,omitempty"`
+	APIKey          string `json:"api_key,omitempty"`          // Plaintext key, only returned once
+	RewardsSeeded   int    `json:"rewards_seeded,omitempty"`   // Number of default rewards created
+	TemplatesSeeded int    `json:"templates_seeded,omitempty"` // Number of default templates created
+}
+
+// OnboardingNudgeSettings configures the loyalty program intro sent to
+// unregistered contacts on behalf of a tenant
+type OnboardingNudgeSettings struct {
+	Enabled      bool   `json:"enabled"`
+	IntervalDays int    `json:"interval_days"`     // minimum days between nudges per number; 0 uses ONBOARDING_NUDGE_INTERVAL_DAYS
+	Message      string `json:"message,omitempty"` // supports {{name}}, {{tenant}} and {{phone}}; empty keeps the current message
+}
+
+// LoyaltyProgramSettings selects the loyalty program a tenant runs: points,
+// stamps (a punch card, e.g. every 10 washes earns a free one) or both
+type LoyaltyProgramSettings struct {
+	Program       string `json:"program"`         // points, stamps or both
+	StampCardSize int    `json:"stamp_card_size"` // stamps needed to complete a card
+	StampReward   string `json:"stamp_reward"`    // reward earned per completed card
+}
+
+// MessageLogSettings controls what the message log keeps of the messages a
+// tenant sends: the content ("full") or only its hash and length ("private")
+type MessageLogSettings struct {
+	Mode string `json:"mode"` // full or private
+}
+
+// MemberLocation is the pickup/delivery point a member last shared via WhatsApp
+type MemberLocation struct {
+	PhoneNumber string  `json:"phone_number"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Label       string  `json:"label,omitempty"`   // Place name attached to the pin, if any
+	Address     string  `json:"address,omitempty"` // Address attached to the pin, if any
+	MapsURL     string  `json:"maps_url"`          // Google Maps link for drivers
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// Driver is a delivery driver reachable on their own WhatsApp number
+type Driver struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	PhoneNumber string `json:"phone_number"`
+	IsActive    bool   `json:"is_active"`
+}
+
+// CreateDriverRequest represents the request to register a driver
+type CreateDriverRequest struct {
+	Name        string `json:"name" validate:"required"`
+	PhoneNumber string `json:"phone_number" validate:"required"` // Driver's WhatsApp number with country code
+}
+
+// DispatchOrderRequest assigns a driver to an order that is ready for delivery
+type DispatchOrderRequest struct {
+	DriverID int `json:"driver_id" validate:"required"`
+}
+
+// DispatchOrderResponse represents the result of dispatching an order to a driver
+type DispatchOrderResponse struct {
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+	OrderID   int    `json:"order_id,omitempty"`
+	DriverID  int    `json:"driver_id,omitempty"`
+	Status    string `json:"status,omitempty"`     // New order status
+	MessageID string `json:"message_id,omitempty"` // WhatsApp message ID of the driver notification
+}
+
+// PickupSlot is a bookable pickup window with limited capacity
+type PickupSlot struct {
+	ID       int    `json:"id"`
+	StartsAt string `json:"starts_at"` // RFC3339
+	EndsAt   string `json:"ends_at"`   // RFC3339
+	Capacity int    `json:"capacity"`
+	Booked   int    `json:"booked"`
+}
+
+// CreatePickupSlotRequest represents the request to open a pickup window
+type CreatePickupSlotRequest struct {
+	StartsAt string `json:"starts_at" validate:"required"` // RFC3339, e.g. "2026-10-17T09:00:00+07:00"
+	EndsAt   string `json:"ends_at" validate:"required"`   // RFC3339
+	Capacity int    `json:"capacity" validate:"required"`  // Maximum bookings for this window
+}
+
+// PickupScheduleEntry is one booked pickup in the admin schedule
+type PickupScheduleEntry struct {
+	BookingID   int    `json:"booking_id"`
+	SlotID      int    `json:"slot_id"`
+	StartsAt    string `json:"starts_at"`
+	EndsAt      string `json:"ends_at"`
+	MemberName  string `json:"member_name"`
+	PhoneNumber string `json:"phone_number"`
+	Address     string `json:"address,omitempty"`
+}
+
+// PickupSchedule lists the booked pickups for a single day
+type PickupSchedule struct {
+	Date    string                 `json:"date"` // YYYY-MM-DD
+	Count   int                    `json:"count"`
+	Pickups []*PickupScheduleEntry `json:"pickups"`
+}
+
+// ReminderRule configures an automated reminder, e.g. "remind after 48h if an
+// order is ready but not picked up"
+type ReminderRule struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Trigger       string `json:"trigger"`        // order_ready or reward_unclaimed
+	DelayHours    int    `json:"delay_hours"`    // hours after the trigger before the first reminder
+	RepeatHours   int    `json:"repeat_hours"`   // minimum hours between reminders
+	MaxReminders  int    `json:"max_reminders"`  // reminders sent per order/redemption at most
+	EscalateAfter int    `json:"escalate_after"` // alert admins after this many reminders; 0 disables
+	Message       string `json:"message"`        // supports {{name}}, {{subject_id}}, {{detail}}, {{count}}
+	IsActive      bool   `json:"is_active"`
+}
+
+// CreateReminderRuleRequest represents the request to add a reminder rule
+type CreateReminderRuleRequest struct {
+	Name          string `json:"name" validate:"required"`
+	Trigger       string `json:"trigger" validate:"required"`
+	DelayHours    int    `json:"delay_hours"`
+	RepeatHours   int    `json:"repeat_hours"`
+	MaxReminders  int    `json:"max_reminders"`
+	EscalateAfter int    `json:"escalate_after"`
+	Message       string `json:"message" validate:"required"`
+}
+
+// MessageTemplate is a reusable message body with {{variable}} placeholders
+type MessageTemplate struct {
+	Name      string   `json:"name"`
+	Body      string   `json:"body"`
+	Variables []string `json:"variables"` // placeholders in the body, e.g. ["name", "points"]
+}
+
+// Suppression reasons
+const (
+	SuppressionOptedOut = "opted_out" // the recipient asked not to be messaged
+	SuppressionBlocked  = "blocked"   // the business decided not to message them
+)
+
+// Suppression is a recipient that sends are refused for
+type Suppression struct {
+	Recipient string `json:"recipient"` // phone number without the + sign, or group JID
+	Reason    string `json:"reason"`
+	Note      string `json:"note,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CreateSuppressionRequest represents the request to suppress a recipient
+type CreateSuppressionRequest struct {
+	Recipient string `json:"recipient"` // phone number, with or without +, or group JID
+	Reason    string `json:"reason"`    // opted_out or blocked
+	Note      string `json:"note,omitempty"`
+}
+
+// SenderBlocklist is the contacts a sender blocked on WhatsApp
+type SenderBlocklist struct {
+	SenderID string   `json:"sender_id"`
+	Blocked  []string `json:"blocked"` // phone numbers without the + sign
+}
+
+// BlockContactRequest represents the request to block a contact on a sender
+type BlockContactRequest struct {
+	Phone string `json:"phone" validate:"required"` // phone number, with or without +
+	Note  string `json:"note,omitempty"`            // kept on the contact's suppression
+}
+
+// SaveTemplateRequest represents the request to create or update a template.
+// Name is taken from the URL on update.
+type SaveTemplateRequest struct {
+	Name string `json:"name,omitempty"`
+	Body string `json:"body" validate:"required"`
+}
+
+// SendTemplateRequest represents the request to render a template and send it
+type SendTemplateRequest struct {
+	To        string         `json:"to" validate:"required"`
+	Template  string         `json:"template" validate:"required"`
+	Variables map[string]any `json:"variables,omitempty"` // placeholder values: strings or numbers
+	From      string         `json:"from,omitempty"`      // Optional: sender phone number identifier
+	Category  string         `json:"category,omitempty"`  // Optional: message category whose fallback chain is used
+	Force     bool           `json:"force,omitempty"`     // Send even when the same message just went to this recipient
+}
+
+// AutomationRule sends a message template when a domain event matches its condition
+type AutomationRule struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Event     string `json:"event"`        // domain event type, e.g. "points.changed"
+	Condition string `json:"condition"`    // expression over the event data; empty always matches
+	Template  string `json:"template"`     // message template sent when the rule matches
+	To        string `json:"to,omitempty"` // recipient; defaults to the event's phone_number
+	Active    bool   `json:"active"`
+}
+
+// CreateAutomationRuleRequest represents the request to add an automation rule
+type CreateAutomationRuleRequest struct {
+	Name      string `json:"name" validate:"required"`
+	Event     string `json:"event" validate:"required"`
+	Condition string `json:"condition,omitempty"` // e.g. `reason == "earned" && points > 100`
+	Template  string `json:"template" validate:"required"`
+	To        string `json:"to,omitempty"`
+	Active    *bool  `json:"active,omitempty"` // defaults to true
+}
+
+// BroadcastRequest represents the request to send one message to many recipients.
+// Recipients and Segment may be combined; duplicates are sent once, whether a
+// number is written +62..., 62... or 0....
+type BroadcastRequest struct {
+	Recipients []string          `json:"recipients,omitempty"`
+	Segment    *BroadcastSegment `json:"segment,omitempty"` // Optional: members to add to the recipients
+	Message    string            `json:"message" validate:"required"`
+	From       string            `json:"from,omitempty"`     // Optional: sender phone number identifier
+	Category   string            `json:"category,omitempty"` // Optional: message category whose fallback chain is used
+}
+
+// BroadcastSegment selects registered members by tier and lifetime points
+type BroadcastSegment struct {
+	Tier      string `json:"tier,omitempty"`       // e.g. "Gold"; empty selects every tier
+	MinPoints int    `json:"min_points,omitempty"` // minimum accumulated points
+}
+
+// SegmentMember is a member considered for a broadcast segment
+type SegmentMember struct {
+	PhoneNumber       string
+	AccumulatedPoints int
+}
+
+// Broadcast job statuses
+const (
+	BroadcastRunning   = "running"
+	BroadcastCompleted = "completed"
+)
+
+// Broadcast recipient statuses
+const (
+	BroadcastPending = "pending"
+	BroadcastSent    = "sent"
+	BroadcastQueued  = "queued"
+	BroadcastFailed  = "failed"
+)
+
+// BroadcastJob is the progress of a background broadcast
+type BroadcastJob struct {
+	ID          string            `json:"id"`
+	Status      string            `json:"status"` // running or completed
+	Total       int               `json:"total"`
+	Sent        int               `json:"sent"`
+	Failed      int               `json:"failed"`
+	Queued      int               `json:"queued"`                 // failed transiently and left to the send queue
+	CreatedAt   string            `json:"created_at"`             // RFC3339
+	CompletedAt string            `json:"completed_at,omitempty"` // RFC3339
+	Results     []BroadcastResult `json:"results,omitempty"`
+}
+
+// BroadcastResult is the outcome of a broadcast for one recipient
+type BroadcastResult struct {
+	To        string `json:"to"`
+	Status    string `json:"status"` // pending, sent or failed
+	MessageID string `json:"message_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SendMessagesRequest represents a batch of messages, each with its own
+// recipient, body and optional sender, sent in one call
+type SendMessagesRequest struct {
+	Messages []SendMessageRequest `json:"messages"`
+}
+
+// SendMessagesResponse reports the outcome of every message of a batch
+type SendMessagesResponse struct {
+	Total   int                  `json:"total"`
+	Sent    int                  `json:"sent"`
+	Queued  int                  `json:"queued"` // failed transiently and left to the send queue
+	Failed  int                  `json:"failed"`
+	Results []SendMessagesResult `json:"results"` // in the order of the request
+}
+
+// SendMessagesResult is the outcome of one message of a batch: what
+// send-message would have answered for it
+type SendMessagesResult struct {
+	To string `json:"to"`
+	SendMessageResponse
+}
+
+// Send job statuses
+const (
+	SendJobPending = "pending"
+	SendJobSent    = "sent"
+	SendJobQueued  = "queued"
+	SendJobFailed  = "failed"
+)
+
+// SendJob is the outcome of a send accepted with async=true
+type SendJob struct {
+	ID          string               `json:"id"`
+	Kind        string               `json:"kind"`                   // message, image, document, audio, location, contact or reaction
+	Status      string               `json:"status"`                 // pending, sent, queued or failed
+	CreatedAt   string               `json:"created_at"`             // RFC3339
+	CompletedAt string               `json:"completed_at,omitempty"` // RFC3339
+	Result      *SendMessageResponse `json:"result,omitempty"`       // what the synchronous endpoint would have answered
+}
+
+// PointsCampaign multiplies the points credited during a time window, such as a
+// double-points happy hour
+type PointsCampaign struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	StartsAt   string   `json:"starts_at"` // RFC3339
+	EndsAt     string   `json:"ends_at"`   // RFC3339
+	Multiplier float64  `json:"multiplier"`
+	Items      []string `json:"items,omitempty"` // items it applies to; empty means all
+	Active     bool     `json:"active"`          // whether the window contains the current time
+}
+
+// CreatePointsCampaignRequest represents the request to schedule a points campaign
+type CreatePointsCampaignRequest struct {
+	Name       string                `json:"name" validate:"required"`
+	StartsAt   string                `json:"starts_at" validate:"required"`  // RFC3339, e.g. "2026-10-17T15:00:00+07:00"
+	EndsAt     string                `json:"ends_at" validate:"required"`    // RFC3339
+	Multiplier float64               `json:"multiplier" validate:"required"` // greater than 1 and at most 10, e.g. 2 for double points
+	Items      []string              `json:"items,omitempty"`
+	Announce   *CampaignAnnouncement `json:"announce,omitempty"` // Optional: broadcast the campaign to members
+}
+
+// CampaignAnnouncement is the broadcast sent when a campaign is created
+type CampaignAnnouncement struct {
+	Message string            `json:"message" validate:"required"`
+	Segment *BroadcastSegment `json:"segment,omitempty"` // defaults to every member
+}
+
+// CreatePointsCampaignResponse is a created campaign with its announcement broadcast
+type CreatePointsCampaignResponse struct {
+	Campaign      *PointsCampaign `json:"campaign"`
+	BroadcastID   string          `json:"broadcast_id,omitempty"`   // poll GET /api/broadcast/:id for progress
+	AnnounceError string          `json:"announce_error,omitempty"` // why the announcement could not be started
+}
+
+// DefaultMessageCategory is the fallback chain used when a send names no category
+const DefaultMessageCategory = "default"
+
+// SenderFallbackChain is the ordered list of senders tried for a message category
+type SenderFallbackChain struct {
+	Category  string   `json:"category"`
+	SenderIDs []string `json:"sender_ids"`
+}
+
+// SetSenderFallbackChainRequest represents the request to replace a category's chain
+type SetSenderFallbackChainRequest struct {
+	SenderIDs []string `json:"sender_ids"` // tried in order; empty removes the chain
+}
+
+// SenderPool is a named group of senders, such as "sales", that a send can
+// target instead of a single sender
+type SenderPool struct {
+	Name      string   `json:"name"`
+	SenderIDs []string `json:"sender_ids"`
+}
+
+// SetSenderPoolRequest represents the request to replace a pool's members
+type SetSenderPoolRequest struct {
+	SenderIDs []string `json:"sender_ids"` // empty removes the pool
+}
+
+// NotificationPreferences is how an admin, known by phone number, wants to be
+// alerted: the channels each alert type reaches them on
+type NotificationPreferences struct {
+	PhoneNumber string              `json:"phone_number"`
+	Email       string              `json:"email,omitempty"`
+	WebhookURL  string              `json:"webhook_url,omitempty"`
+	Alerts      map[string][]string `json:"alerts"`     // alert type -> channels: whatsapp, email, webhook
+	UpdatedAt   string              `json:"updated_at"` // RFC3339
+}
+
+// SetNotificationPreferencesRequest represents the request to replace an
+// admin's notification preferences
+type SetNotificationPreferencesRequest struct {
+	Email      string              `json:"email"`       // required for the email channel
+	WebhookURL string              `json:"webhook_url"` // required for the webhook channel
+	Alerts     map[string][]string `json:"alerts"`      // alert types left out are not sent to the admin
+}
+
+// Prospect is an unregistered WhatsApp contact that has messaged the bot
+type Prospect struct {
+	PhoneNumber       string `json:"phone_number"`
+	PushName          string `json:"push_name,omitempty"`
+	FirstContactAt    string `json:"first_contact_at"` // RFC3339
+	LastContactAt     string `json:"last_contact_at"`  // RFC3339
+	MessageCount      int    `json:"message_count"`
+	LastIntent        string `json:"last_intent,omitempty"` // command the last message was routed to, e.g. menu or ai_reply
+	ConvertedMemberID int    `json:"converted_member_id,omitempty"`
+	ConvertedAt       string `json:"converted_at,omitempty"` // RFC3339
+}
+
+// ConvertProspectRequest registers a prospect as a member on their behalf
+type ConvertProspectRequest struct {
+	Name    string `json:"name" validate:"required"`
+	Address string `json:"address" validate:"required"`
+}
+
+// ConvertProspectResponse represents the result of converting a prospect
+type ConvertProspectResponse struct {
+	Success     bool   `json:"success"`
+	Message     string `json:"message,omitempty"`
+	PhoneNumber string `json:"phone_number,omitempty"`
+	MemberID    int    `json:"member_id,omitempty"`
+}
+
+// Voucher is issued when a member redeems points and is used once at the counter
+// to collect the reward
+type Voucher struct {
+	Code        string `json:"code"`
+	PhoneNumber string `json:"phone_number"`
+	Reward      string `json:"reward"`
+	Points      int    `json:"points"`
+	IssuedAt    string `json:"issued_at"`             // RFC3339
+	ExpiresAt   string `json:"expires_at,omitempty"`  // RFC3339
+	RedeemedAt  string `json:"redeemed_at,omitempty"` // RFC3339
+	Branch      string `json:"branch,omitempty"`      // branch that redeemed the voucher
+}
+
+// RedeemVoucherRequest names the branch whose counter redeems a voucher
+type RedeemVoucherRequest struct {
+	Branch string `json:"branch,omitempty"`
+}
+
+// VoucherTally counts vouchers and the points they are worth
+type VoucherTally struct {
+	Count  int `json:"count"`
+	Points int `json:"points"`
+}
+
+// BranchVoucherRedemptions are the vouchers one branch redeemed
+type BranchVoucherRedemptions struct {
+	Branch   string       `json:"branch"` // empty when the counter did not name its branch
+	Redeemed VoucherTally `json:"redeemed"`
+}
+
+// VoucherSettlement is the daily voucher report the cashiers reconcile against
+type VoucherSettlement struct {
+	Date        string                     `json:"date"`        // YYYY-MM-DD
+	Issued      VoucherTally               `json:"issued"`      // issued that day
+	Redeemed    VoucherTally               `json:"redeemed"`    // redeemed that day, at any branch
+	Expired     VoucherTally               `json:"expired"`     // expired unused that day
+	Outstanding VoucherTally               `json:"outstanding"` // still redeemable at the end of the day
+	Branches    []BranchVoucherRedemptions `json:"branches"`
+}
+
+// Staff activity anomalies
+const (
+	AnomalySelfCredit = "self_credit" // the admin credited their own membership
+)
+
+// StaffActivity is what one admin phone entered through the INPUT# and
+// STEMPEL# commands on one day
+type StaffActivity struct {
+	PhoneNumber    string   `json:"phone_number"`
+	PointsCredits  int      `json:"points_credits"`  // INPUT# entries
+	PointsCredited int      `json:"points_credited"` // points those entries credited
+	StampEntries   int      `json:"stamp_entries"`   // STEMPEL# entries
+	StampsAdded    int      `json:"stamps_added"`
+	MembersServed  int      `json:"members_served"` // distinct members credited
+	SelfCredits    int      `json:"self_credits"`   // entries crediting the admin's own membership
+	FirstEntryAt   string   `json:"first_entry_at"` // RFC3339; with last_entry_at, the span of the shift
+	LastEntryAt    string   `json:"last_entry_at"`  // RFC3339
+	Anomalies      []string `json:"anomalies"`      // e.g. self_credit
+}
+
+// StaffActivityReport is the daily per-staff activity report for performance reviews
+type StaffActivityReport struct {
+	Date  string          `json:"date"` // YYYY-MM-DD
+	Staff []StaffActivity `json:"staff"`
+}
+
+// SenderActivityQuery represents the query parameters of
+// GET /api/senders/:id/activity
+type SenderActivityQuery struct {
+	Days int `form:"days"` // Days up to and including today, default 7, at most 90
+}
+
+// SoftLaunchQuery represents the query parameters of GET /api/soft-launch
+type SoftLaunchQuery struct {
+	Days int `form:"days"` // Days up to and including today, default 7, at most 90
+}
+
+// SoftLaunchReport compares how conversations on each soft-launch variant of
+// the menu get on
+type SoftLaunchReport struct {
+	Percent  int                 `json:"percent"` // share of conversations given the new flow
+	From     string              `json:"from"`    // YYYY-MM-DD
+	Variants []SoftLaunchVariant `json:"variants"`
+}
+
+// SoftLaunchVariant is the completion rate of one variant: how many of the
+// contacts shown the menu went on to pick one of its options
+type SoftLaunchVariant struct {
+	Variant        string  `json:"variant"`         // legacy or button_menu
+	Conversations  int     `json:"conversations"`   // contacts shown the menu
+	Completions    int     `json:"completions"`     // of them, contacts who picked an option
+	CompletionRate float64 `json:"completion_rate"` // completions / conversations, 0-1
+}
+
+// SenderActivityCell is what a sender sent and received in one hour of the
+// week, summed over the report's days
+type SenderActivityCell struct {
+	Weekday  int `json:"weekday"` // 0 is Sunday
+	Hour     int `json:"hour"`    // 0-23
+	Sent     int `json:"sent"`
+	Received int `json:"received"`
+}
+
+// SenderActivityReport is a sender's weekly activity heatmap: all 168 hours of
+// the week, Sunday 00:00 first
+type SenderActivityReport struct {
+	SenderID      string               `json:"sender_id"`
+	From          string               `json:"from"` // YYYY-MM-DD
+	To            string               `json:"to"`   // YYYY-MM-DD, inclusive
+	TotalSent     int                  `json:"total_sent"`
+	TotalReceived int                  `json:"total_received"`
+	LastActiveAt  string               `json:"last_active_at,omitempty"` // start of the last hour with messages
+	Heatmap       []SenderActivityCell `json:"heatmap"`
+}
+
+// StorageUsage is the space one kind of storage takes against its limit
+type StorageUsage struct {
+	UsedBytes   int64   `json:"used_bytes"`
+	LimitBytes  int64   `json:"limit_bytes,omitempty"`  // 0 when no limit is configured
+	UsedPercent float64 `json:"used_percent,omitempty"` // share of the limit used
+	Files       int64   `json:"files,omitempty"`        // objects in the bucket or files in the log directory
+}
+
+// TableStorage is the disk space one database table takes, indexes included
+type TableStorage struct {
+	Name  string `json:"name"`
+	Bytes int64  `json:"bytes"`
+}
+
+// StorageReport summarizes the storage the service uses, warning when any of
+// it approaches its limit
+type StorageReport struct {
+	Database  StorageUsage   `json:"database"`
+	Tables    []TableStorage `json:"tables"`          // largest first
+	Media     *StorageUsage  `json:"media,omitempty"` // S3 bucket of received images; absent when S3 is not configured
+	Logs      *StorageUsage  `json:"logs,omitempty"`  // absent when no log directory is configured
+	Warnings  []string       `json:"warnings"`        // empty when all is well
+	CheckedAt string         `json:"checked_at"`      // RFC3339
+}
+
+// VersionInfo is the version of the running build and, when a release feed
+// is configured, whether a newer release is out
+type VersionInfo struct {
+	Version         string     `json:"version"`
+	Commit          string     `json:"commit"`
+	BuildDate       string     `json:"build_date,omitempty"`
+	GoVersion       string     `json:"go_version"`
+	LatestVersion   string     `json:"latest_version,omitempty"`    // newest release on the feed; empty when unchecked
+	UpdateAvailable bool       `json:"update_available"`            // LatestVersion is newer than Version
+	CheckedAt       *time.Time `json:"update_checked_at,omitempty"` // when the feed last answered
+}
+
+// Destructive actions that need a confirmation
+const (
+	ConfirmActionDeleteSender     = "delete_sender"
+	ConfirmActionClearSessions    = "clear_sessions" // target "all"
+	ConfirmActionEraseMember      = "erase_member"
+	ConfirmActionRevokeMessage    = "revoke_message"
+	ConfirmActionDeleteTemplate   = "delete_template"
+	ConfirmActionDeleteAutomation = "delete_automation"
+	ConfirmActionDeleteCampaign   = "delete_campaign"
+	ConfirmActionDeleteBranch     = "delete_branch"
+	ConfirmActionRemoveFromGroup  = "remove_group_participants"
+	ConfirmActionDeleteLabel      = "delete_label"
+	ConfirmActionDeleteReport     = "delete_report_schedule"
+)
+
+// ConfirmationTargetAll is the target of actions on everything, such as
+// clearing every session
+const ConfirmationTargetAll = "all"
+
+// Second factors a confirmation code comes from
+const (
+	ConfirmationMethodWhatsApp = "whatsapp"
+	ConfirmationMethodTOTP     = "totp"
+)
+
+// CreateConfirmationRequest asks for a confirmation of one destructive action
+// on one target, such as deleting a sender
+type CreateConfirmationRequest struct {
+	Action string `json:"action" validate:"required"` // e.g. delete_sender
+	Target string `json:"target" validate:"required"` // what the action applies to, e.g. the sender ID
+}
+
+// Confirmation is a pending second-factor check. Its token and the code sent
+// to the owner (or shown by their authenticator app) are passed to the
+// destructive request, which uses the confirmation up.
+type Confirmation struct {
+	Token     string `json:"token"`
+	Action    string `json:"action"`
+	Target    string `json:"target"`
+	Method    string `json:"method"`     // whatsapp or totp
+	ExpiresAt string `json:"expires_at"` // RFC3339
+}
+
+// Branch is one store in the branch directory
+type Branch struct {
+	ID             int     `json:"id"`
+	Name           string  `json:"name"`
+	Address        string  `json:"address"`
+	Hours          string  `json:"hours,omitempty"`           // opening hours as shown to customers
+	WhatsAppNumber string  `json:"whatsapp_number,omitempty"` // the branch's own number
+	Latitude       float64 `json:"latitude"`
+	Longitude      float64 `json:"longitude"`
+	MapsURL        string  `json:"maps_url"` // Google Maps link, built from the coordinates when not set
+}
+
+// SaveBranchRequest represents the request to create or update a branch
+type SaveBranchRequest struct {
+	Name           string   `json:"name" validate:"required"`
+	Address        string   `json:"address" validate:"required"`
+	Hours          string   `json:"hours,omitempty"`
+	WhatsAppNumber string   `json:"whatsapp_number,omitempty"`
+	Latitude       *float64 `json:"latitude"`           // Required, -90 to 90
+	Longitude      *float64 `json:"longitude"`          // Required, -180 to 180
+	MapsURL        string   `json:"maps_url,omitempty"` // Optional, e.g. a Google Maps place link
+}
+
+// SendBranchLocationRequest represents the request to share a branch's location
+type SendBranchLocationRequest struct {
+	To   string `json:"to" validate:"required"`
+	From string `json:"from,omitempty"` // Optional: sender phone number identifier
+}
+
+// DraftOrder is an order a member placed through the chat order flow, awaiting
+// staff confirmation
+type DraftOrder struct {
+	ID             int     `json:"id"`
+	MemberName     string  `json:"member_name"`
+	PhoneNumber    string  `json:"phone_number"`
+	Service        string  `json:"service"`
+	Kilos          float64 `json:"kilos,omitempty"` // estimated weight for services priced by the kilo
+	Units          int     `json:"units,omitempty"` // pieces for services priced per piece
+	EstimatedPrice float64 `json:"estimated_price"`
+	PickupStartsAt string  `json:"pickup_starts_at,omitempty"` // RFC3339, empty when the member drops the laundry off
+	PickupEndsAt   string  `json:"pickup_ends_at,omitempty"`   // RFC3339
+	CreatedAt      string  `json:"created_at"`                 // RFC3339
+}
+
+// ConfirmOrderResponse represents the result of confirming a draft order
+type ConfirmOrderResponse struct {
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+	OrderID   int    `json:"order_id,omitempty"`
+	Status    string `json:"status,omitempty"`     // New order status
+	MessageID string `json:"message_id,omitempty"` // WhatsApp message ID of the member notification
+}
+
+// SendReceiptResponse is the result of sending an order's PDF receipt to the member
+type SendReceiptResponse struct {
+	Success   bool   `json:"success"`
+	Message   string `json:"message,omitempty"`
+	OrderID   int    `json:"order_id,omitempty"`
+	FileName  string `json:"file_name,omitempty"`
+	MessageID string `json:"message_id,omitempty"`
+}
+
+// TrackedMessage is a sent message whose delivery is reported to the status callback
+type TrackedMessage struct {
+	MessageID string
+	RequestID string // X-Request-ID of the API call that sent it
+	Recipient string
+}
+
+// MessageRecord is one message sent, or attempted, through the API
+type MessageRecord struct {
+	ID            int64  `json:"id"`
+	Recipient     string `json:"recipient"`
+	SenderID      string `json:"sender_id,omitempty"`    // empty for the default sender
+	Kind          string `json:"kind"`                   // text, interactive, image, document, audio, location, contact or reaction
+	Content       string `json:"content"`                // text or caption; a summary for media, locations and contacts. Empty when the message log is private
+	ContentHash   string `json:"content_hash,omitempty"` // SHA-256 (hex) of the content, to check a disputed message against
+	ContentLength int    `json:"content_length"`         // length of the content in characters
+	Status        string `json:"status"`                 // sent, failed or revoked
+	MessageID     string `json:"message_id,omitempty"`
+	Error         string `json:"error,omitempty"`
+	RequestID     string `json:"request_id,omitempty"` // X-Request-ID of the API call that sent it
+	CreatedAt     string `json:"created_at"`           // RFC3339
+	SentAt        string `json:"sent_at,omitempty"`    // RFC3339
+}
+
+// MessageEdit is one change to the text of a sent message
+type MessageEdit struct {
+	MessageID       string `json:"message_id"`
+	PreviousContent string `json:"previous_content"`       // Empty when the message log is private
+	Content         string `json:"content"`                // Empty when the message log is private
+	ContentHash     string `json:"content_hash,omitempty"` // SHA-256 (hex) of the new text
+	ContentLength   int    `json:"content_length"`         // length of the new text in characters
+	RequestID       string `json:"request_id,omitempty"`   // X-Request-ID of the API call that edited it
+	EditedAt        string `json:"edited_at"`              // RFC3339
+}
+
+// MessageHistoryQuery represents the query parameters of GET /api/messages
+type MessageHistoryQuery struct {
+	Recipient string `form:"recipient"`  // Optional phone number
+	Sender    string `form:"sender"`     // Optional sender ID
+	RequestID string `form:"request_id"` // Optional X-Request-ID of the sending API call
+	From      string `form:"from"`       // Optional first day, YYYY-MM-DD
+	To        string `form:"to"`         // Optional last day, YYYY-MM-DD
+	Limit     int    `form:"limit"`      // Page size, default 50, at most 500
+	Offset    int    `form:"offset"`
+}
+
+// MessageHistoryPage is a page of message history, newest first
+type MessageHistoryPage struct {
+	Messages []*MessageRecord `json:"messages"`
+	Total    int              `json:"total"` // matching messages across all pages
+	Limit    int              `json:"limit"`
+	Offset   int              `json:"offset"`
+}
+
+// Intervals of a message volume report
+const (
+	ReportIntervalDay  = "day"
+	ReportIntervalWeek = "week" // weeks start on Monday
+)
+
+// MessageReportQuery represents the query parameters of the message reports
+type MessageReportQuery struct {
+	From     string `form:"from"`     // Optional first day, YYYY-MM-DD; defaults to 29 days before to
+	To       string `form:"to"`       // Optional last day, YYYY-MM-DD; defaults to today
+	Limit    int    `form:"limit"`    // Busiest contacts: how many, default 10, at most 100
+	Interval string `form:"interval"` // Message volume: day (default) or week
+}
+
+// ContactVolume is how many messages one contact exchanged with the senders
+type ContactVolume struct {
+	PhoneNumber   string `json:"phone_number"`
+	Inbound       int    `json:"inbound"`  // messages the contact sent
+	Outbound      int    `json:"outbound"` // messages sent to the contact through the API
+	Total         int    `json:"total"`
+	LastMessageAt string `json:"last_message_at"`
+}
+
+// BusiestContactsReport lists the contacts that exchanged the most messages,
+// busiest first
+type BusiestContactsReport struct {
+	From     string           `json:"from"` // YYYY-MM-DD
+	To       string           `json:"to"`   // YYYY-MM-DD, inclusive
+	Contacts []*ContactVolume `json:"contacts"`
+}
+
+// MessageVolume is the number of messages exchanged in one day or week
+type MessageVolume struct {
+	Period   string `json:"period"` // first day of the period, YYYY-MM-DD
+	Inbound  int    `json:"inbound"`
+	Outbound int    `json:"outbound"`
+}
+
+// MessageVolumeReport is the inbound and outbound message volume per day or
+// week, oldest first, with every period in the range present
+type MessageVolumeReport struct {
+	From          string           `json:"from"` // YYYY-MM-DD
+	To            string           `json:"to"`   // YYYY-MM-DD, inclusive
+	Interval      string           `json:"interval"`
+	TotalInbound  int              `json:"total_inbound"`
+	TotalOutbound int              `json:"total_outbound"`
+	Volume        []*MessageVolume `json:"volume"`
+}
+
+// Reports a report schedule can include, one sheet each
+const (
+	ReportMessageVolume     = "message_volume"
+	ReportBusiestContacts   = "busiest_contacts"
+	ReportStaffActivity     = "staff_activity"
+	ReportVoucherSettlement = "voucher_settlement"
+)
+
+// Report schedule frequencies
+const (
+	ReportDaily  = "daily"  // every day, covering the day before
+	ReportWeekly = "weekly" // every week, covering the seven days before
+)
+
+// Report delivery channels
+const (
+	ReportChannelWhatsApp = "whatsapp"
+	ReportChannelEmail    = "email"
+)
+
+// ReportScheduleRequest creates or replaces a report schedule
+type ReportScheduleRequest struct {
+	Name               string   `json:"name"`
+	Reports            []string `json:"reports"`                       // e.g. message_volume and staff_activity
+	Frequency          string   `json:"frequency"`                     // daily or weekly
+	Hour               int      `json:"hour"`                          // hour of the day it is sent, server time, 0-23
+	Weekday            int      `json:"weekday"`                       // weekly only: day it is sent, 0 is Sunday
+	WhatsAppRecipients []string `json:"whatsapp_recipients,omitempty"` // phone numbers or group JIDs
+	EmailRecipients    []string `json:"email_recipients,omitempty"`
+	Active             *bool    `json:"active,omitempty"` // defaults to true
+}
+
+// ReportSchedule delivers a workbook of reports to its recipients as a
+// WhatsApp document and by email
+type ReportSchedule struct {
+	ID                 int      `json:"id"`
+	Name               string   `json:"name"`
+	Reports            []string `json:"reports"`
+	Frequency          string   `json:"frequency"`
+	Hour               int      `json:"hour"`
+	Weekday            int      `json:"weekday"`
+	WhatsAppRecipients []string `json:"whatsapp_recipients"`
+	EmailRecipients    []string `json:"email_recipients"`
+	Active             bool     `json:"active"`
+	LastRunAt          string   `json:"last_run_at,omitempty"` // RFC3339
+	LastError          string   `json:"last_error,omitempty"`  // deliveries that failed on the last run
+	NextRunAt          string   `json:"next_run_at,omitempty"` // RFC3339; empty when inactive
+}
+
+// ReportDelivery is the outcome of sending a schedule's workbook
+type ReportDelivery struct {
+	ScheduleID int                     `json:"schedule_id"`
+	FileName   string                  `json:"file_name"`
+	From       string                  `json:"from"` // YYYY-MM-DD
+	To         string                  `json:"to"`   // YYYY-MM-DD, inclusive
+	Recipients []ReportRecipientResult `json:"recipients"`
+}
+
+// ReportRecipientResult is whether a workbook reached one recipient
+type ReportRecipientResult struct {
+	Channel   string `json:"channel"` // whatsapp or email
+	Recipient string `json:"recipient"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+}
+
+// MailAttachment is a file attached to an email
+type MailAttachment struct {
+	FileName string
+	MIMEType string
+	Data     []byte
+}
+
+// Incident statuses, in the order an incident usually moves through them
+const (
+	IncidentInvestigating = "investigating"
+	IncidentIdentified    = "identified"
+	IncidentMonitoring    = "monitoring"
+	IncidentResolved      = "resolved"
+)
+
+// Service statuses shown on the public status page, from best to worst
+const (
+	ServiceOperational = "operational"
+	ServiceDegraded    = "degraded"
+	ServiceOutage      = "outage"
+)
+
+// IncidentRequest opens an incident or updates one. On update, a blank title
+// or message keeps the current one.
+type IncidentRequest struct {
+	Title   string `json:"title"`
+	Message string `json:"message"`          // shown to customers as is
+	Status  string `json:"status,omitempty"` // defaults to investigating
+}
+
+// Incident is an outage or degradation announced on the public status page
+type Incident struct {
+	ID         int    `json:"id"`
+	Title      string `json:"title"`
+	Message    string `json:"message"`
+	Status     string `json:"status"`
+	StartedAt  string `json:"started_at"`            // RFC3339
+	UpdatedAt  string `json:"updated_at"`            // RFC3339
+	ResolvedAt string `json:"resolved_at,omitempty"` // RFC3339; empty while open
+}
+
+// StatusPage is the service health shown to customers. It names no senders,
+// numbers or counts.
+type StatusPage struct {
+	Status       string    `json:"status"`    // the worse of api and messaging
+	API          string    `json:"api"`       // operational while it answers
+	Messaging    string    `json:"messaging"` // operational, degraded or outage
+	LastIncident *Incident `json:"last_incident"`
+	UpdatedAt    string    `json:"updated_at"` // RFC3339
+}
+
+// Group is a WhatsApp group the sender is a member of
+type Group struct {
+	JID              string              `json:"jid"` // e.g. 120363025246125486@g.us
+	Name             string              `json:"name"`
+	Topic            string              `json:"topic,omitempty"`
+	ParticipantCount int                 `json:"participant_count"`
+	Participants     []*GroupParticipant `json:"participants,omitempty"`
+	CreatedAt        string              `json:"created_at,omitempty"` // RFC3339
+}
+
+// GroupParticipant is a member of a group
+type GroupParticipant struct {
+	PhoneNumber string `json:"phone_number"`
+	IsAdmin     bool   `json:"is_admin"`
+	IsOwner     bool   `json:"is_owner,omitempty"`
+}
+
+// CreateGroupRequest represents the request to create a group
+type CreateGroupRequest struct {
+	Name         string   `json:"name" validate:"required"`         // At most 25 characters
+	Participants []string `json:"participants" validate:"required"` // Phone numbers
+	From         string   `json:"from,omitempty"`                   // Optional: sender phone number identifier
+}
+
+// UpdateGroupParticipantsRequest represents the request to add members to, or
+// remove members from, a group
+type UpdateGroupParticipantsRequest struct {
+	Participants []string `json:"participants" validate:"required"` // Phone numbers
+	From         string   `json:"from,omitempty"`                   // Optional: sender phone number identifier
+}
+
+// GroupParticipantChange is the outcome of adding or removing one participant
+type GroupParticipantChange struct {
+	PhoneNumber string `json:"phone_number"`
+	Success     bool   `json:"success"`
+	Error       string `json:"error,omitempty"`        // why WhatsApp refused the change
+	NeedsInvite bool   `json:"needs_invite,omitempty"` // privacy settings only let them join through the invite link
+}
+
+// UpdateGroupParticipantsResponse lists the outcome for every participant; a
+// change can fail for some participants and succeed for others
+type UpdateGroupParticipantsResponse struct {
+	GroupJID     string                    `json:"group_jid"`
+	Participants []*GroupParticipantChange `json:"participants"`
+}
+
+// GroupInviteLink is the link that lets anyone join a group, for numbers whose
+// privacy settings do not allow adding them directly
+type GroupInviteLink struct {
+	GroupJID string `json:"group_jid"`
+	Link     string `json:"link"` // e.g. https://chat.whatsapp.com/AbCdEf...
+}
+
+// SetGroupDescriptionRequest represents the request to change the description
+// of a group
+type SetGroupDescriptionRequest struct {
+	Description string `json:"description"`    // At most 2048 characters; empty removes it
+	From        string `json:"from,omitempty"` // Optional: sender phone number identifier
+}
+
+// What a VIP group sync did for a member
+const (
+	GroupSyncAdded   = "added"   // added to the group
+	GroupSyncInvited = "invited" // sent the invite link, as privacy settings kept them from being added
+	GroupSyncRemoved = "removed" // removed from the group after leaving the qualifying tiers
+	GroupSyncFailed  = "failed"  // WhatsApp refused the change
+)
+
+// GroupSyncChange is a membership change made by the VIP group sync
+type GroupSyncChange struct {
+	GroupJID    string    `json:"group_jid"`
+	PhoneNumber string    `json:"phone_number"`
+	Action      string    `json:"action"`          // added, invited, removed or failed
+	Error       string    `json:"error,omitempty"` // why the change failed
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// GroupSyncResult lists what a VIP group sync changed
+type GroupSyncResult struct {
+	GroupJID string             `json:"group_jid"`
+	Added    int                `json:"added"`
+	Invited  int                `json:"invited"`
+	Removed  int                `json:"removed"`
+	Failed   int                `json:"failed"`
+	Changes  []*GroupSyncChange `json:"changes"`
+}
+
+// Label is a WhatsApp Business label of a sender, such as "VIP"
+type Label struct {
+	ID        string `json:"id"` // numeric ID WhatsApp knows the label by
+	Name      string `json:"name"`
+	Color     int    `json:"color"`                // index into WhatsApp's palette, 0-19
+	UpdatedAt string `json:"updated_at,omitempty"` // RFC3339
+}
+
+// SaveLabelRequest represents the request to create or update a label
+type SaveLabelRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Color *int   `json:"color,omitempty"` // Optional: 0-19; new labels default to 0, updates keep the color
+	From  string `json:"from,omitempty"`  // Optional: sender phone number identifier
+}
+
+// LabelChatRequest represents the request to put a label on, or take it off, a chat
+type LabelChatRequest struct {
+	Chat string `json:"chat" validate:"required"` // Phone number or group JID
+	From string `json:"from,omitempty"`           // Optional: sender phone number identifier
+}
+
+// AutoLabelOutcome is what one automatic label run changed for a label
+type AutoLabelOutcome struct {
+	Label   string `json:"label"`
+	LabelID string `json:"label_id"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+	Failed  int    `json:"failed,omitempty"` // chats WhatsApp refused to (un)label
+}
+
+// AutoLabelResult lists what an automatic label run changed
+type AutoLabelResult struct {
+	SenderID string              `json:"sender_id,omitempty"`
+	Labels   []*AutoLabelOutcome `json:"labels"`
+}
+
+// Supply is a consumable kept in stock, such as detergent or softener
+type Supply struct {
+	ID                int     `json:"id"`
+	Name              string  `json:"name"`
+	Unit              string  `json:"unit"` // e.g. liter, kg or pcs
+	Stock             float64 `json:"stock"`
+	LowStockThreshold float64 `json:"low_stock_threshold"`
+	Low               bool    `json:"low"` // stock is at or below the threshold
+}
+
+// CreateSupplyRequest represents the request to add a supply to the inventory
+type CreateSupplyRequest struct {
+	Name              string  `json:"name" validate:"required"`
+	Unit              string  `json:"unit" validate:"required"`
+	Stock             float64 `json:"stock"`               // Opening stock, default 0
+	LowStockThreshold float64 `json:"low_stock_threshold"` // Admins are alerted when stock falls to this
+}
+
+// AdjustStockRequest represents a manual stock change, such as a delivery or a count correction
+type AdjustStockRequest struct {
+	Change float64 `json:"change"`         // Positive to restock, negative to take stock out
+	Note   string  `json:"note,omitempty"` // e.g. "delivery from supplier"
+}
+
+// SupplyUsage is how much of a supply one kilo or one piece of a catalog item uses
+type SupplyUsage struct {
+	SupplyID int     `json:"supply_id"`
+	PerKilo  float64 `json:"per_kilo,omitempty"`
+	PerUnit  float64 `json:"per_unit,omitempty"`
+}
+
+// ItemSupplyUsage lists the supplies a catalog item uses
+type ItemSupplyUsage struct {
+	ItemID   int           `json:"item_id"`
+	Supplies []SupplyUsage `json:"supplies"`
+}
+
+// SetItemSupplyUsageRequest represents the request to replace the supplies a catalog item uses
+type SetItemSupplyUsageRequest struct {
+	Supplies []SupplyUsage `json:"supplies"`
+}
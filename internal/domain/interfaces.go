@@ -3,18 +3,122 @@ package domain
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 // Common errors
 var (
-	ErrWhatsAppNotConnected = errors.New("whatsapp client is not connected")
-	ErrInvalidPhoneNumber   = errors.New("invalid phone number format")
-	ErrMessageSendFailed    = errors.New("failed to send message")
-	ErrUnauthorized         = errors.New("unauthorized access")
-	ErrSenderNotFound       = errors.New("sender not found")
-	ErrNoActiveSender       = errors.New("no active sender available")
-	ErrAIResponseDisabled   = errors.New("AI response feature is disabled")
-	ErrEmptyMessage         = errors.New("message is required")
+	ErrWhatsAppNotConnected   = errors.New("whatsapp client is not connected")
+	ErrInvalidPhoneNumber     = errors.New("invalid phone number format")
+	ErrMessageSendFailed      = errors.New("failed to send message")
+	ErrMessagePartlySent      = errors.New("only some parts of the split message were sent")
+	ErrUnauthorized           = errors.New("unauthorized access")
+	ErrSenderNotFound         = errors.New("sender not found")
+	ErrMemberNotFound         = errors.New("member not found")
+	ErrNoActiveSender         = errors.New("no active sender available")
+	ErrAIResponseDisabled     = errors.New("AI response feature is disabled")
+	ErrEmptyMessage           = errors.New("message is required")
+	ErrInvalidImage           = errors.New("image must be a JPEG or PNG of at most 16 MB")
+	ErrInvalidDocument        = errors.New("document must have a filename and be at most 100 MB")
+	ErrInvalidLocation        = errors.New("latitude must be between -90 and 90 and longitude between -180 and 180")
+	ErrInvalidContact         = errors.New("contacts need a name and a valid phone number")
+	ErrInvalidInteractive     = errors.New("interactive messages need 1-3 reply buttons or a list menu of 1-10 rows with unique IDs and short titles")
+	ErrInvalidAudio           = errors.New("audio must be OGG/Opus or MP3 of at most 16 MB; voice notes must be OGG/Opus")
+	ErrInvalidReaction        = errors.New("reactions need a message_id and a single emoji; group reactions need the message sender")
+	ErrInvalidPresence        = errors.New("chat presence must be typing, recording or paused; sender presence must be available or unavailable")
+	ErrInvalidDisappearing    = errors.New("disappearing timer must be off, 24h, 7d or 90d")
+	ErrInvalidReply           = errors.New("reply_to must be a message ID; group replies need reply_to_sender, and interactive messages cannot be replies")
+	ErrInvalidTenant          = errors.New("invalid tenant details")
+	ErrTenantExists           = errors.New("tenant already exists")
+	ErrTenantNotFound         = errors.New("tenant not found")
+	ErrInvalidNudgeSettings   = errors.New("interval_days must be between 0 and 365")
+	ErrInvalidMessageLogMode  = errors.New("mode must be full or private")
+	ErrInvalidLoyaltyProgram  = errors.New("program must be points, stamps or both, with a stamp_card_size between 2 and 50 and a stamp_reward")
+	ErrLocationNotFound       = errors.New("no location shared for this member")
+	ErrInvalidDriver          = errors.New("invalid driver details")
+	ErrDriverNotFound         = errors.New("driver not found")
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderNotDraft          = errors.New("order is not awaiting confirmation")
+	ErrOrderMemberNoPhone     = errors.New("the member of this order has no phone number")
+	ErrInvalidPickupSlot      = errors.New("invalid pickup slot")
+	ErrInvalidDate            = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrInvalidReminderRule    = errors.New("invalid reminder rule")
+	ErrRedemptionNotFound     = errors.New("unclaimed redemption not found")
+	ErrInvalidFallbackChain   = errors.New("invalid sender fallback chain")
+	ErrInvalidSenderPool      = errors.New("invalid sender pool")
+	ErrSenderPoolNotFound     = errors.New("sender pool not found")
+	ErrInvalidProspect        = errors.New("name and address are required")
+	ErrProspectNotFound       = errors.New("prospect not found")
+	ErrProspectConverted      = errors.New("prospect is already a member")
+	ErrInvalidTemplate        = errors.New("template needs a name of lower-case letters, digits, _ or - and a body")
+	ErrTemplateNotFound       = errors.New("template not found")
+	ErrTemplateExists         = errors.New("template already exists")
+	ErrMissingVariables       = errors.New("template variables are missing")
+	ErrInvalidAutomationRule  = errors.New("invalid automation rule")
+	ErrAutomationRuleNotFound = errors.New("automation rule not found")
+	ErrInvalidBroadcast       = errors.New("broadcast needs a message and at least one recipient")
+	ErrBroadcastTooLarge      = errors.New("broadcast has too many recipients")
+	ErrBroadcastNotFound      = errors.New("broadcast not found")
+	ErrSendJobNotFound        = errors.New("send job not found")
+	ErrInvalidSenderBatch     = errors.New("registration batch needs at least one phone number")
+	ErrSenderBatchTooLarge    = errors.New("registration batch has too many phone numbers")
+	ErrSenderBatchNotFound    = errors.New("registration batch not found")
+	ErrInvalidDeadLetterQuery = errors.New("limit must be between 1 and 200")
+	ErrDeadLetterNotFound     = errors.New("dead letter not found")
+	ErrDeadLetterRequeued     = errors.New("dead letter already requeued")
+	ErrInvalidBatch           = errors.New("batch needs at least one message")
+	ErrBatchTooLarge          = errors.New("batch has too many messages")
+	ErrInvalidCampaign        = errors.New("invalid points campaign")
+	ErrCampaignNotFound       = errors.New("points campaign not found")
+	ErrVoucherNotFound        = errors.New("voucher not found")
+	ErrVoucherRedeemed        = errors.New("voucher already redeemed")
+	ErrVoucherExpired         = errors.New("voucher expired")
+	ErrInvalidBranch          = errors.New("invalid branch")
+	ErrBranchNotFound         = errors.New("branch not found")
+	ErrInvalidHistoryQuery    = errors.New("invalid message history query")
+	ErrInvalidReportQuery     = errors.New("invalid message report query")
+	ErrInvalidActivityQuery   = errors.New("days must be between 1 and 90")
+	ErrInvalidRevoke          = errors.New("message_id is required")
+	ErrMessageNotFound        = errors.New("message not found in the message history")
+	ErrRevokeWindowExpired    = errors.New("message is too old to delete for everyone")
+	ErrInvalidEdit            = errors.New("message_id and message are required")
+	ErrEditWindowExpired      = errors.New("message is too old to edit")
+	ErrMessageNotEditable     = errors.New("only sent text messages can be edited")
+	ErrInvalidGroup           = errors.New("invalid group request")
+	ErrGroupNotFound          = errors.New("group not found")
+	ErrNotGroupAdmin          = errors.New("sender is not an admin of the group")
+	ErrInvalidSupply          = errors.New("invalid supply")
+	ErrSupplyNotFound         = errors.New("supply not found")
+	ErrSupplyExists           = errors.New("supply already exists")
+	ErrStockNegative          = errors.New("stock cannot go below zero")
+	ErrItemNotFound           = errors.New("item not found")
+	ErrInvalidConfirmation    = errors.New("confirmation needs a known action and a target")
+	ErrConfirmationRequired   = errors.New("this action needs a confirmation token and code")
+	ErrConfirmationFailed     = errors.New("confirmation code is wrong, expired or already used")
+	ErrConfirmationDisabled   = errors.New("no second factor is configured; set CONFIRMATION_PHONES or CONFIRMATION_TOTP_SECRET")
+	ErrRecipientSuppressed    = errors.New("recipient opted out or is blocked and cannot be messaged")
+	ErrDuplicateSend          = errors.New("an identical message was just sent to this recipient")
+	ErrInvalidSuppression     = errors.New("suppression needs a valid phone number or group JID and a reason of opted_out or blocked")
+	ErrSuppressionNotFound    = errors.New("recipient is not suppressed")
+	ErrSuppressionExists      = errors.New("recipient is already suppressed")
+	ErrInvalidBlock           = errors.New("a valid phone number is required to block or unblock a contact")
+	ErrNotBusinessAccount     = errors.New("labels need a WhatsApp Business sender")
+	ErrInvalidLabel           = errors.New("invalid label")
+	ErrLabelNotFound          = errors.New("label not found")
+	ErrLabelExists            = errors.New("a label with this name already exists")
+	ErrInvalidReportSchedule  = errors.New("invalid report schedule")
+	ErrReportScheduleNotFound = errors.New("report schedule not found")
+	ErrInvalidIncident        = errors.New("invalid incident")
+	ErrIncidentNotFound       = errors.New("incident not found")
+	ErrInvalidSender          = errors.New("invalid sender details")
+	ErrSenderPaused           = errors.New("sender is paused")
+	ErrSenderQuotaExceeded    = errors.New("sender has reached its daily send quota")
+	ErrSenderNoSession        = errors.New("sender has no session; register it again")
+	ErrReconnectTooSoon       = errors.New("sender was reconnected less than a minute ago")
+	ErrInvalidSenderQuota     = errors.New("invalid sender quota")
+
+	ErrInvalidNotificationPreferences  = errors.New("invalid notification preferences")
+	ErrNotificationPreferencesNotFound = errors.New("notification preferences not found")
 )
 
 // AIClient talks to the external AI sidecar service over HTTP.
@@ -31,7 +135,46 @@ type AIService interface {
 type WhatsAppRepository interface {
 	SendMessage(ctx context.Context, to, message string) (*Message, error)
 	SendMessageFrom(ctx context.Context, from, to, message string) (*Message, error)
+	// SendImage uploads and sends an image; an empty from uses the default sender
+	SendImage(ctx context.Context, from, to string, image []byte, mimeType, caption string) (*Message, error)
+	// SendDocument uploads and sends a file as a document; an empty from uses the default sender
+	SendDocument(ctx context.Context, from, to string, document []byte, fileName, mimeType, caption string) (*Message, error)
+	// SendAudio uploads and sends audio, as a voice note when ptt is set; an empty from uses the default sender
+	SendAudio(ctx context.Context, from, to string, audio []byte, mimeType string, ptt bool) (*Message, error)
+	// SendLocation sends a map pin; an empty from uses the default sender
+	SendLocation(ctx context.Context, from, to string, latitude, longitude float64, name, address string) (*Message, error)
+	// SendContacts sends contact cards whose VCard is filled in; an empty from uses the default sender
+	SendContacts(ctx context.Context, from, to string, contacts []ContactCard) (*Message, error)
+	// SendInteractive sends body with reply buttons or a list menu; an empty from uses the default sender
+	SendInteractive(ctx context.Context, from, to, body string, interactive *InteractiveMessage) (*Message, error)
+	// SendReaction reacts with emoji to message messageID that sender sent in chat;
+	// an empty emoji removes the reaction and an empty from uses the default sender
+	SendReaction(ctx context.Context, from, chat, sender, messageID, emoji string) (*Message, error)
+	// SendReply sends message threaded under message replyTo that sender sent in
+	// the chat with to; an empty from uses the default sender
+	SendReply(ctx context.Context, from, to, message, sender, replyTo string) (*Message, error)
+	// RevokeMessage deletes message messageID, sent by from in chat, for
+	// everyone; an empty from uses the default sender
+	RevokeMessage(ctx context.Context, from, chat, messageID string) (*Message, error)
+	// EditMessage replaces the text of message messageID, sent by from in
+	// chat; an empty from uses the default sender
+	EditMessage(ctx context.Context, from, chat, messageID, text string) (*Message, error)
+	// SendChatPresence shows state (typing, recording or paused) in chat; an
+	// empty from uses the default sender
+	SendChatPresence(ctx context.Context, from, chat, state string) error
+	// SetPresence shows the sender as available or unavailable; an empty from
+	// uses the default sender
+	SetPresence(ctx context.Context, from, presence string) error
+	// SetDisappearingTimer sets how long messages in chat last, 0 turning
+	// disappearing messages off; an empty from uses the default sender. Later
+	// sends to chat disappear after the timer.
+	SetDisappearingTimer(ctx context.Context, from, chat string, timer time.Duration) error
 	IsConnected() bool
+	// IsSenderConnected reports whether senderID, or the default sender when
+	// empty, is connected and logged in
+	IsSenderConnected(senderID string) bool
+	// PickSender returns the healthy member of a sender pool that sends next
+	PickSender(senderIDs []string) (string, error)
 	IsLoggedIn() bool
 	GetJID() string
 	GetSenderJID(senderID string) (string, error)
@@ -39,9 +182,193 @@ type WhatsAppRepository interface {
 	GetDefaultSender() (*Sender, error)
 }
 
+// GroupRepository manages WhatsApp groups through a sender's client. An empty
+// from uses the default sender; participants and groups are given as JIDs.
+type GroupRepository interface {
+	ListGroups(ctx context.Context, from string) ([]*Group, error)
+	CreateGroup(ctx context.Context, from, name string, participants []string) (*Group, error)
+	// UpdateParticipants adds or removes participants, returning the outcome per participant
+	UpdateParticipants(ctx context.Context, from, groupJID string, participants []string, add bool) ([]*GroupParticipantChange, error)
+	// GetInviteLink returns the group's invite link, revoking the old one
+	// for a new one when reset is set
+	GetInviteLink(ctx context.Context, from, groupJID string, reset bool) (string, error)
+	// SetDescription replaces the group's description; an empty one removes it
+	SetDescription(ctx context.Context, from, groupJID, description string) error
+	// GetGroup returns a group the sender is a member of, with its participants
+	GetGroup(ctx context.Context, from, groupJID string) (*Group, error)
+}
+
+// GroupSyncLogRepository records the membership changes of the VIP group sync
+type GroupSyncLogRepository interface {
+	RecordChange(change *GroupSyncChange) error
+	// ListChanges returns the limit most recent changes to a group, newest first
+	ListChanges(groupJID string, limit int) ([]*GroupSyncChange, error)
+	// InvitedSince returns the phone numbers sent the group's invite link since since
+	InvitedSince(groupJID string, since time.Time) ([]string, error)
+}
+
+// LabelRepository manages the WhatsApp Business labels of a sender and the
+// chats they are on. An empty from uses the default sender; chats are JIDs.
+// Labels are read from the copy kept in sync with WhatsApp.
+type LabelRepository interface {
+	// ListLabels returns the sender's ID and its labels
+	ListLabels(ctx context.Context, from string) (string, []*Label, error)
+	// SyncLabels fetches every label and labeled chat from WhatsApp again
+	SyncLabels(ctx context.Context, from string) error
+	// SaveLabel creates the label when id is empty, else renames or recolors it
+	SaveLabel(ctx context.Context, from, id, name string, color int) (*Label, error)
+	DeleteLabel(ctx context.Context, from, id string) error
+	ListLabelChats(ctx context.Context, from, id string) ([]string, error)
+	SetLabelChat(ctx context.Context, from, id, chat string, labeled bool) error
+}
+
+// AutoLabelRepository finds the contacts the automatic labels belong on, as
+// phone numbers without the + sign
+type AutoLabelRepository interface {
+	// ListLeadPhones returns the prospects not yet converted to members
+	ListLeadPhones() ([]string, error)
+	// ListPendingPickupPhones returns the members with laundry ready for pickup
+	ListPendingPickupPhones() ([]string, error)
+}
+
+// SenderChainRepository stores the per-category sender fallback chains
+type SenderChainRepository interface {
+	GetChain(category string) ([]string, error)
+	ListChains() (map[string][]string, error)
+	SetChain(category string, senderIDs []string) error
+}
+
+// SenderPoolRepository stores the named sender pools
+type SenderPoolRepository interface {
+	GetPool(name string) ([]string, error)
+	ListPools() (map[string][]string, error)
+	SetPool(name string, senderIDs []string) error
+}
+
+// SuppressionRepository stores the recipients that must not be messaged, keyed
+// by phone number without the + sign or by group JID
+type SuppressionRepository interface {
+	ListSuppressions() ([]*Suppression, error)
+	// GetSuppression returns ErrSuppressionNotFound when recipient may be messaged
+	GetSuppression(recipient string) (*Suppression, error)
+	CreateSuppression(recipient, reason, note string) (*Suppression, error)
+	DeleteSuppression(recipient string) error
+}
+
+// BlocklistRepository blocks and unblocks contacts on a sender's WhatsApp
+// account. Phone numbers are digits only.
+type BlocklistRepository interface {
+	GetBlocklist(ctx context.Context, from string) ([]string, error)
+	// UpdateBlocklist blocks or unblocks phone, returning the new blocklist
+	UpdateBlocklist(ctx context.Context, from, phone string, block bool) ([]string, error)
+}
+
+// TemplateRepository stores the global message templates
+type TemplateRepository interface {
+	ListTemplates() ([]*MessageTemplate, error)
+	GetTemplate(name string) (*MessageTemplate, error)
+	CreateTemplate(name, body string) error
+	UpdateTemplate(name, body string) error
+	DeleteTemplate(name string) error
+}
+
+// AutomationRepository stores the operator-defined automation rules
+type AutomationRepository interface {
+	ListRules() ([]*AutomationRule, error)
+	ListActiveRules(event string) ([]*AutomationRule, error)
+	CreateRule(rule *AutomationRule) (int, error)
+	DeleteRule(id int) error
+}
+
+// SegmentRepository lists the members broadcast segments are chosen from
+type SegmentRepository interface {
+	ListSegmentMembers(minPoints int) ([]*SegmentMember, error)
+}
+
+// SendQueueRepository persists messages that failed to send so they can be retried
+type SendQueueRepository interface {
+	// Enqueue stores req for a retry at nextAttempt; requestID is the API call that sent it
+	Enqueue(req *SendMessageRequest, requestID string, attempts int, nextAttempt time.Time, lastError string) (int64, error)
+	Due(now time.Time, limit int) ([]*QueuedSend, error)
+	MarkSent(id int64, attempts int) error
+	Retry(id int64, attempts int, nextAttempt time.Time, lastError string) error
+	MarkFailed(id int64, attempts int, lastError string) error
+	MarkExpired(id int64, attempts int, lastError string) error
+	Stats() (*SendQueueStatus, error)
+	// DeadLetters returns up to limit messages given up on, newest first,
+	// leaving out requeued ones unless includeRequeued
+	DeadLetters(includeRequeued bool, limit int) ([]*DeadLetter, error)
+	// Requeue puts a dead letter back on the queue as a new message due now
+	Requeue(deadLetterID int64) (*DeadLetter, error)
+}
+
+// MessageStatusRepository tracks the delivery of sent messages for status callbacks
+type MessageStatusRepository interface {
+	Track(messageID, requestID, recipient string) error
+	// Advance moves a tracked message on to status, returning nil when it is not
+	// tracked or already reached that status or a later one
+	Advance(messageID, status string) (*TrackedMessage, error)
+}
+
+// MessageHistoryRepository stores the audit trail of messages sent through the API
+type MessageHistoryRepository interface {
+	Record(record *MessageRecord) error
+	List(filter MessageHistoryFilter) ([]*MessageRecord, int, error)
+	// LogMode is the message log mode of the tenant whose sends are recorded:
+	// full, or private to keep only the content's hash and length
+	LogMode() (string, error)
+	// GetByMessageID returns the sent message with WhatsApp ID messageID, or
+	// nil when none was recorded
+	GetByMessageID(messageID string) (*MessageRecord, error)
+	MarkRevoked(messageID string) error
+	// RecordEdit keeps the text a message had before edit and stores its new text
+	RecordEdit(edit *MessageEdit) error
+	// ListEdits returns the edits of a message, oldest first
+	ListEdits(messageID string) ([]*MessageEdit, error)
+	// BusiestContacts returns the limit contacts that exchanged the most
+	// messages in [since, until), busiest first
+	BusiestContacts(since, until time.Time, limit int) ([]*ContactVolume, error)
+	// Volume returns the messages exchanged in [since, until) per interval,
+	// oldest first, leaving out periods without messages
+	Volume(since, until time.Time, interval string) ([]*MessageVolume, error)
+}
+
+// MessageHistoryFilter narrows a message history query. Empty fields match
+// everything; Until is exclusive.
+type MessageHistoryFilter struct {
+	Recipient string
+	SenderID  string
+	RequestID string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+	Offset    int
+}
+
+// IdempotencyRepository remembers the result of requests made with an
+// Idempotency-Key so retried requests are not carried out twice
+type IdempotencyRepository interface {
+	// Reserve claims key for a request. It returns nil once reserved, or the
+	// existing record when the key is already in use.
+	Reserve(key, fingerprint string) (*IdempotencyRecord, error)
+	Complete(key string, statusCode int, response []byte) error
+	Release(key string) error
+}
+
 // MessageService defines the business logic interface for messaging
 type MessageService interface {
 	SendMessage(ctx context.Context, req *SendMessageRequest) (*SendMessageResponse, error)
+	SendImage(ctx context.Context, req *SendImageRequest) (*SendMessageResponse, error)
+	SendDocument(ctx context.Context, req *SendDocumentRequest) (*SendMessageResponse, error)
+	SendAudio(ctx context.Context, req *SendAudioRequest) (*SendMessageResponse, error)
+	SendLocation(ctx context.Context, req *SendLocationRequest) (*SendMessageResponse, error)
+	SendContact(ctx context.Context, req *SendContactRequest) (*SendMessageResponse, error)
+	SendReaction(ctx context.Context, req *SendReactionRequest) (*SendMessageResponse, error)
+	RevokeMessage(ctx context.Context, req *RevokeMessageRequest) (*SendMessageResponse, error)
+	EditMessage(ctx context.Context, req *EditMessageRequest) (*SendMessageResponse, error)
+	SendChatPresence(ctx context.Context, req *SendChatPresenceRequest) (*SendMessageResponse, error)
+	SetPresence(ctx context.Context, req *SetPresenceRequest) (*SendMessageResponse, error)
+	SetDisappearing(ctx context.Context, req *SetDisappearingRequest) (*SendMessageResponse, error)
 	GetStatus(ctx context.Context) (*ServiceStatus, error)
 	ListSenders(ctx context.Context) ([]*Sender, error)
 }
@@ -51,9 +378,354 @@ type SenderRegistrationService interface {
 	StartQRRegistration(ctx context.Context) (*RegisterSenderQRResponse, error)
 	StartCodeRegistration(ctx context.Context, req *RegisterSenderCodeRequest) (*RegisterSenderCodeResponse, error)
 	GetRegistrationStatus(ctx context.Context, sessionID string) (*RegistrationStatusResponse, error)
+	// DeleteSender disconnects a sender, deletes its session and marks it inactive
+	DeleteSender(ctx context.Context, senderID string) error
+	// ClearSessions deletes every sender's session and cancels pending
+	// registrations, returning the IDs of the senders removed
+	ClearSessions(ctx context.Context) ([]string, error)
+}
+
+// RegistrationBatchService registers many senders with pairing codes in the
+// background for callers that poll for each number's progress
+type RegistrationBatchService interface {
+	StartRegistrationBatch(ctx context.Context, req *RegisterSendersRequest) (*RegistrationBatch, error)
+	GetRegistrationBatch(ctx context.Context, id string) (*RegistrationBatch, error)
+}
+
+// TenantService defines the business logic interface for tenant onboarding
+type TenantService interface {
+	CreateTenant(ctx context.Context, req *CreateTenantRequest) (*CreateTenantResponse, error)
+	GetOnboardingNudge(ctx context.Context, slug string) (*OnboardingNudgeSettings, error)
+	SetOnboardingNudge(ctx context.Context, slug string, req *OnboardingNudgeSettings) (*OnboardingNudgeSettings, error)
+	GetLoyaltyProgram(ctx context.Context, slug string) (*LoyaltyProgramSettings, error)
+	SetLoyaltyProgram(ctx context.Context, slug string, req *LoyaltyProgramSettings) (*LoyaltyProgramSettings, error)
+	GetMessageLog(ctx context.Context, slug string) (*MessageLogSettings, error)
+	SetMessageLog(ctx context.Context, slug string, req *MessageLogSettings) (*MessageLogSettings, error)
+}
+
+// MemberService manages registered members
+type MemberService interface {
+	// EraseMember removes a member's personal data, keeping their points and
+	// orders anonymously
+	EraseMember(ctx context.Context, phoneNumber string) error
+}
+
+// LocationService exposes member pickup/delivery locations to integrations
+type LocationService interface {
+	GetMemberLocation(ctx context.Context, phoneNumber string) (*MemberLocation, error)
+}
+
+// DriverService manages delivery drivers and dispatches orders to them
+type DriverService interface {
+	CreateDriver(ctx context.Context, req *CreateDriverRequest) (*Driver, error)
+	ListDrivers(ctx context.Context) ([]*Driver, error)
+	DispatchOrder(ctx context.Context, orderID int, req *DispatchOrderRequest) (*DispatchOrderResponse, error)
+}
+
+// MessageHistoryService answers queries over the messages sent through the API
+type MessageHistoryService interface {
+	ListMessages(ctx context.Context, query *MessageHistoryQuery) (*MessageHistoryPage, error)
+	GetBusiestContacts(ctx context.Context, query *MessageReportQuery) (*BusiestContactsReport, error)
+	GetMessageVolume(ctx context.Context, query *MessageReportQuery) (*MessageVolumeReport, error)
+	ListEdits(ctx context.Context, messageID string) ([]*MessageEdit, error)
+}
+
+// ReportService renders reports to spreadsheets and delivers them on the
+// schedules stored in the database
+type ReportService interface {
+	CreateSchedule(ctx context.Context, req *ReportScheduleRequest) (*ReportSchedule, error)
+	ListSchedules(ctx context.Context) ([]*ReportSchedule, error)
+	UpdateSchedule(ctx context.Context, id int, req *ReportScheduleRequest) (*ReportSchedule, error)
+	DeleteSchedule(ctx context.Context, id int) error
+	SendSchedule(ctx context.Context, id int) (*ReportDelivery, error)
+	RunDueSchedules(ctx context.Context, now time.Time) ([]*ReportDelivery, error)
+}
+
+// Mailer sends email, such as scheduled reports
+type Mailer interface {
+	SendMail(ctx context.Context, to []string, subject, body string, attachments ...MailAttachment) error
+}
+
+// StatusPageService reports the service health shown on the public status page
+// and manages the incidents announced there
+type StatusPageService interface {
+	GetStatusPage(ctx context.Context) (*StatusPage, error)
+	CreateIncident(ctx context.Context, req *IncidentRequest) (*Incident, error)
+	ListIncidents(ctx context.Context) ([]*Incident, error)
+	UpdateIncident(ctx context.Context, id int, req *IncidentRequest) (*Incident, error)
+}
+
+// SoftLaunchService reports how the soft-launched bot flow compares with the
+// live one
+type SoftLaunchService interface {
+	GetReport(ctx context.Context, query *SoftLaunchQuery) (*SoftLaunchReport, error)
+}
+
+// OrderService lets staff review and confirm the orders members place in chat
+type OrderService interface {
+	ListDraftOrders(ctx context.Context) ([]*DraftOrder, error)
+	ConfirmOrder(ctx context.Context, orderID int) (*ConfirmOrderResponse, error)
+	SendReceipt(ctx context.Context, orderID int) (*SendReceiptResponse, error)
+}
+
+// PickupService manages pickup windows and the admin pickup schedule
+type PickupService interface {
+	CreatePickupSlot(ctx context.Context, req *CreatePickupSlotRequest) (*PickupSlot, error)
+	GetSchedule(ctx context.Context, date string) (*PickupSchedule, error)
+}
+
+// ReminderService manages reminder rules and the state they react to
+type ReminderService interface {
+	CreateRule(ctx context.Context, req *CreateReminderRuleRequest) (*ReminderRule, error)
+	ListRules(ctx context.Context) ([]*ReminderRule, error)
+	ClaimRedemption(ctx context.Context, transactionID int) error
+}
+
+// SenderService manages the senders operators have registered
+type SenderService interface {
+	// UpdateSender replaces the name, description and tags of a sender
+	UpdateSender(ctx context.Context, senderID string, req *UpdateSenderRequest) (*Sender, error)
+	// PauseSender takes a sender out of routing and refuses sends through it,
+	// keeping it connected
+	PauseSender(ctx context.Context, senderID string) (*Sender, error)
+	// ResumeSender puts a paused sender back into routing
+	ResumeSender(ctx context.Context, senderID string) (*Sender, error)
+	// GetSenderHealth reports whether a sender can send right now, and when
+	// it last sent or lost its connection
+	GetSenderHealth(ctx context.Context, senderID string) (*SenderHealth, error)
+	// GetSenderQuota reports a sender's daily send quota and how much of it
+	// is used today
+	GetSenderQuota(ctx context.Context, senderID string) (*SenderQuota, error)
+	// SetSenderQuota caps the messages a sender may send a day
+	SetSenderQuota(ctx context.Context, senderID string, req *SetSenderQuotaRequest) (*SenderQuota, error)
+	// ReconnectSender drops a stuck sender's connection and opens a new one
+	ReconnectSender(ctx context.Context, senderID string) (*SenderHealth, error)
+}
+
+// SenderClients controls the connected clients of the senders.
+// whatsapp.ClientManager implements it.
+type SenderClients interface {
+	PauseSender(senderID string) error
+	ResumeSender(senderID string) error
+	// SenderConnection reports the live state of a sender's client
+	SenderConnection(senderID string) (connected, loggedIn bool, pushName string)
+	// ReconnectSender disconnects a sender's client and connects it again
+	// with the same session
+	ReconnectSender(senderID string) error
+}
+
+// SenderChainService manages the per-category sender fallback chains
+type SenderChainService interface {
+	ListChains(ctx context.Context) ([]*SenderFallbackChain, error)
+	SetChain(ctx context.Context, category string, req *SetSenderFallbackChainRequest) (*SenderFallbackChain, error)
+}
+
+// NotificationPreferenceService manages which alerts each admin receives and
+// over which channels
+type NotificationPreferenceService interface {
+	ListPreferences(ctx context.Context) ([]*NotificationPreferences, error)
+	GetPreferences(ctx context.Context, phone string) (*NotificationPreferences, error)
+	SetPreferences(ctx context.Context, phone string, req *SetNotificationPreferencesRequest) (*NotificationPreferences, error)
+	// DeletePreferences returns an admin to the alerts the environment
+	// configures for them
+	DeletePreferences(ctx context.Context, phone string) error
+}
+
+// SenderPoolService manages the named sender pools
+type SenderPoolService interface {
+	ListPools(ctx context.Context) ([]*SenderPool, error)
+	SetPool(ctx context.Context, name string, req *SetSenderPoolRequest) (*SenderPool, error)
+}
+
+// TemplateService manages message templates and sends rendered templates
+type TemplateService interface {
+	ListTemplates(ctx context.Context) ([]*MessageTemplate, error)
+	GetTemplate(ctx context.Context, name string) (*MessageTemplate, error)
+	CreateTemplate(ctx context.Context, req *SaveTemplateRequest) (*MessageTemplate, error)
+	UpdateTemplate(ctx context.Context, name string, req *SaveTemplateRequest) (*MessageTemplate, error)
+	DeleteTemplate(ctx context.Context, name string) error
+	SendTemplate(ctx context.Context, req *SendTemplateRequest) (*SendMessageResponse, error)
+}
+
+// StatusCallbackService reports the delivery status of messages sent through
+// the API to the configured callback URL
+type StatusCallbackService interface {
+	// HandleEvent posts the callback for a message event such as message.sent
+	// or message.status; other events are ignored
+	HandleEvent(ctx context.Context, event string, data map[string]any)
+}
+
+// AutomationService manages automation rules and runs them on domain events
+type AutomationService interface {
+	ListRules(ctx context.Context) ([]*AutomationRule, error)
+	CreateRule(ctx context.Context, req *CreateAutomationRuleRequest) (*AutomationRule, error)
+	DeleteRule(ctx context.Context, id int) error
+	// RunRules sends the templates of the active rules for event whose condition
+	// matches data, returning how many were sent
+	RunRules(ctx context.Context, event string, data map[string]any) int
+}
+
+// BroadcastService sends one message to many recipients in the background
+type BroadcastService interface {
+	StartBroadcast(ctx context.Context, req *BroadcastRequest) (*BroadcastJob, error)
+	GetBroadcast(ctx context.Context, id string) (*BroadcastJob, error)
+}
+
+// BatchSendService sends many messages in one call
+type BatchSendService interface {
+	// SendMessages sends every message of req, reporting each outcome in order
+	SendMessages(ctx context.Context, req *SendMessagesRequest) (*SendMessagesResponse, error)
+}
+
+// SendJobService runs sends in the background for callers that poll for the
+// outcome instead of waiting on the WhatsApp round trip
+type SendJobService interface {
+	// StartSend runs send in the background and returns the pending job
+	StartSend(ctx context.Context, kind string, send func(ctx context.Context) (*SendMessageResponse, error)) (*SendJob, error)
+	GetSendJob(ctx context.Context, id string) (*SendJob, error)
+}
+
+// CampaignService schedules time-limited points multipliers
+type CampaignService interface {
+	CreateCampaign(ctx context.Context, req *CreatePointsCampaignRequest) (*CreatePointsCampaignResponse, error)
+	ListCampaigns(ctx context.Context) ([]*PointsCampaign, error)
+	DeleteCampaign(ctx context.Context, id int) error
+}
+
+// BranchService manages the branch directory and shares branch locations
+type BranchService interface {
+	ListBranches(ctx context.Context) ([]*Branch, error)
+	GetBranch(ctx context.Context, id int) (*Branch, error)
+	CreateBranch(ctx context.Context, req *SaveBranchRequest) (*Branch, error)
+	UpdateBranch(ctx context.Context, id int, req *SaveBranchRequest) (*Branch, error)
+	DeleteBranch(ctx context.Context, id int) error
+	SendBranchLocation(ctx context.Context, id int, req *SendBranchLocationRequest) (*SendMessageResponse, error)
+}
+
+// GroupService manages the sender's WhatsApp groups and their members
+type GroupService interface {
+	ListGroups(ctx context.Context, from string) ([]*Group, error)
+	CreateGroup(ctx context.Context, req *CreateGroupRequest) (*Group, error)
+	AddParticipants(ctx context.Context, groupJID string, req *UpdateGroupParticipantsRequest) (*UpdateGroupParticipantsResponse, error)
+	RemoveParticipants(ctx context.Context, groupJID string, req *UpdateGroupParticipantsRequest) (*UpdateGroupParticipantsResponse, error)
+	GetInviteLink(ctx context.Context, groupJID, from string, reset bool) (*GroupInviteLink, error)
+	SetDescription(ctx context.Context, groupJID string, req *SetGroupDescriptionRequest) error
+}
+
+// VIPGroupService keeps the VIP group's members in line with the members of
+// the qualifying tiers
+type VIPGroupService interface {
+	// Sync adds the qualifying members missing from the group, or sends them
+	// the invite link, and removes the members who no longer qualify
+	Sync(ctx context.Context) (*GroupSyncResult, error)
+	ListChanges(ctx context.Context, limit int) ([]*GroupSyncChange, error)
+}
+
+// LabelService manages the WhatsApp Business labels of a sender and applies
+// the automatic ones (new lead, VIP, pending pickup) from member and order data
+type LabelService interface {
+	ListLabels(ctx context.Context, from string) ([]*Label, error)
+	SyncLabels(ctx context.Context, from string) ([]*Label, error)
+	CreateLabel(ctx context.Context, req *SaveLabelRequest) (*Label, error)
+	UpdateLabel(ctx context.Context, id string, req *SaveLabelRequest) (*Label, error)
+	DeleteLabel(ctx context.Context, from, id string) error
+	ListLabelChats(ctx context.Context, from, id string) ([]string, error)
+	LabelChat(ctx context.Context, id string, req *LabelChatRequest) error
+	UnlabelChat(ctx context.Context, id string, req *LabelChatRequest) error
+	// AutoLabel brings the automatic labels of from in line with member and
+	// order data, creating the labels it is missing
+	AutoLabel(ctx context.Context, from string) (*AutoLabelResult, error)
+}
+
+// InventoryService tracks the stock of supplies such as detergent, takes what
+// orders use out of stock and alerts admins when a supply runs low
+type InventoryService interface {
+	ListSupplies(ctx context.Context) ([]*Supply, error)
+	CreateSupply(ctx context.Context, req *CreateSupplyRequest) (*Supply, error)
+	AdjustStock(ctx context.Context, supplyID int, req *AdjustStockRequest) (*Supply, error)
+	GetItemUsage(ctx context.Context, itemID int) (*ItemSupplyUsage, error)
+	SetItemUsage(ctx context.Context, itemID int, req *SetItemSupplyUsageRequest) (*ItemSupplyUsage, error)
+	// ConsumeOrder takes the supplies an order uses out of stock, once per order
+	ConsumeOrder(ctx context.Context, orderID int) error
+}
+
+// VoucherService redeems the vouchers issued for points redemptions and
+// reports on them for cash register reconciliation
+type VoucherService interface {
+	RedeemVoucher(ctx context.Context, code string, req *RedeemVoucherRequest) (*Voucher, error)
+	GetSettlement(ctx context.Context, date string) (*VoucherSettlement, error)
+}
+
+// StaffService reports what each admin phone entered through the admin chat
+// commands, flagging anomalies such as admins crediting themselves
+type StaffService interface {
+	GetDailyActivity(ctx context.Context, date string) (*StaffActivityReport, error)
+}
+
+// SenderActivityService reports when each sender sends and receives messages,
+// for planning campaign timing and spotting numbers nobody talks to
+type SenderActivityService interface {
+	GetActivity(ctx context.Context, senderID string, query *SenderActivityQuery) (*SenderActivityReport, error)
+}
+
+// ReleaseFeed reports the newest release of the service
+type ReleaseFeed interface {
+	LatestVersion(ctx context.Context) (string, error)
+}
+
+// VersionService reports the running build and whether it is out of date
+type VersionService interface {
+	GetVersion(ctx context.Context) (*VersionInfo, error)
+}
+
+// StorageService reports how much database, media and log storage is used,
+// so operators hear about approaching limits before inserts start failing
+type StorageService interface {
+	GetStorage(ctx context.Context) (*StorageReport, error)
+}
+
+// ProspectService tracks unregistered contacts as leads and converts them to members
+type ProspectService interface {
+	ListProspects(ctx context.Context, includeConverted bool) ([]*Prospect, error)
+	ConvertProspect(ctx context.Context, phoneNumber string, req *ConvertProspectRequest) (*ConvertProspectResponse, error)
+}
+
+// ConfirmationService guards destructive actions with a second factor: a
+// one-time code sent to the owner on WhatsApp, or a TOTP code
+type ConfirmationService interface {
+	CreateConfirmation(ctx context.Context, req *CreateConfirmationRequest) (*Confirmation, error)
+	// Confirm checks code against the confirmation token issued for action on
+	// target, using the confirmation up
+	Confirm(ctx context.Context, token, code, action, target string) error
+}
+
+// DeadLetterService lists the queued messages that were given up on and puts
+// them back on the send queue
+type DeadLetterService interface {
+	ListDeadLetters(ctx context.Context, query *DeadLetterQuery) ([]*DeadLetter, error)
+	RetryDeadLetter(ctx context.Context, id int64) (*DeadLetter, error)
+}
+
+// SuppressionService manages the suppression list that message sends are
+// checked against
+type SuppressionService interface {
+	ListSuppressions(ctx context.Context) ([]*Suppression, error)
+	GetSuppression(ctx context.Context, recipient string) (*Suppression, error)
+	CreateSuppression(ctx context.Context, req *CreateSuppressionRequest) (*Suppression, error)
+	DeleteSuppression(ctx context.Context, recipient string) error
+}
+
+// BlocklistService manages the contacts each sender blocked, keeping the
+// suppression list in step so blocked contacts are not messaged by any sender
+type BlocklistService interface {
+	GetBlocklist(ctx context.Context, senderID string) (*SenderBlocklist, error)
+	BlockContact(ctx context.Context, senderID string, req *BlockContactRequest) (*SenderBlocklist, error)
+	UnblockContact(ctx context.Context, senderID, phone string) (*SenderBlocklist, error)
 }
 
 // AuthService defines the authentication interface
 type AuthService interface {
 	ValidateCredentials(username, password string) bool
+	// ValidateAPIKey returns the slug of the tenant the API key was issued to
+	ValidateAPIKey(key string) (string, bool)
 }
@@ -0,0 +1,35 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type autoLabelRepository struct {
+	db *sql.DB
+}
+
+// NewAutoLabelRepository creates a repository of automatic label targets backed by Postgres
+func NewAutoLabelRepository(db *sql.DB) domain.AutoLabelRepository {
+	return &autoLabelRepository{db: db}
+}
+
+// ListLeadPhones returns the prospects not yet converted to members
+func (r *autoLabelRepository) ListLeadPhones() ([]string, error) {
+	prospects, err := repository.ListProspects(r.db, false)
+	if err != nil {
+		return nil, err
+	}
+	phones := make([]string, 0, len(prospects))
+	for _, p := range prospects {
+		phones = append(phones, p.PhoneNumber)
+	}
+	return phones, nil
+}
+
+// ListPendingPickupPhones returns the members with an order ready for pickup
+func (r *autoLabelRepository) ListPendingPickupPhones() ([]string, error) {
+	return repository.GetPhonesWithOrderStatus(r.db, repository.OrderStatusReady)
+}
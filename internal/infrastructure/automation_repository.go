@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type automationRepository struct {
+	db *sql.DB
+}
+
+// NewAutomationRepository creates an automation rule repository backed by Postgres
+func NewAutomationRepository(db *sql.DB) domain.AutomationRepository {
+	return &automationRepository{db: db}
+}
+
+// ListRules returns all rules ordered by ID
+func (r *automationRepository) ListRules() ([]*domain.AutomationRule, error) {
+	rules, err := repository.GetAutomationRules(r.db)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainAutomationRules(rules), nil
+}
+
+// ListActiveRules returns the active rules for an event type
+func (r *automationRepository) ListActiveRules(event string) ([]*domain.AutomationRule, error) {
+	rules, err := repository.GetActiveAutomationRules(r.db, event)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainAutomationRules(rules), nil
+}
+
+// CreateRule stores a rule and returns its ID
+func (r *automationRepository) CreateRule(rule *domain.AutomationRule) (int, error) {
+	return repository.CreateAutomationRule(r.db, repository.AutomationRule{
+		Name:      rule.Name,
+		EventType: rule.Event,
+		Condition: rule.Condition,
+		Template:  rule.Template,
+		Recipient: rule.To,
+		Active:    rule.Active,
+	})
+}
+
+// DeleteRule removes a rule
+func (r *automationRepository) DeleteRule(id int) error {
+	err := repository.DeleteAutomationRule(r.db, id)
+	if err == repository.ErrAutomationRuleNotFound {
+		return domain.ErrAutomationRuleNotFound
+	}
+	return err
+}
+
+func toDomainAutomationRules(rules []repository.AutomationRule) []*domain.AutomationRule {
+	result := make([]*domain.AutomationRule, 0, len(rules))
+	for _, r := range rules {
+		result = append(result, &domain.AutomationRule{
+			ID:        r.ID,
+			Name:      r.Name,
+			Event:     r.EventType,
+			Condition: r.Condition,
+			Template:  r.Template,
+			To:        r.Recipient,
+			Active:    r.Active,
+		})
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wa-serv/internal/domain"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// NewBlocklistRepositoryWithClientManager creates a blocklist repository that
+// picks the sender's client from the client manager on every call
+func NewBlocklistRepositoryWithClientManager(clientManager ClientManager) domain.BlocklistRepository {
+	if clientManager == nil {
+		clientManager = newStaticClients(nil, nil)
+	}
+	return &whatsappRepository{clients: clientManager}
+}
+
+// GetBlocklist returns the phone numbers the sender blocked
+func (r *whatsappRepository) GetBlocklist(ctx context.Context, from string) ([]string, error) {
+	client, err := r.groupClient(from)
+	if err != nil {
+		return nil, err
+	}
+
+	blocklist, err := client.GetBlocklist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocklist: %w", err)
+	}
+	return blockedPhones(blocklist), nil
+}
+
+// UpdateBlocklist blocks or unblocks phone on the sender
+func (r *whatsappRepository) UpdateBlocklist(ctx context.Context, from, phone string, block bool) ([]string, error) {
+	client, err := r.groupClient(from)
+	if err != nil {
+		return nil, err
+	}
+
+	action := events.BlocklistChangeActionUnblock
+	if block {
+		action = events.BlocklistChangeActionBlock
+	}
+	blocklist, err := client.UpdateBlocklist(ctx, types.NewJID(phone, types.DefaultUserServer), action)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s %s: %w", action, phone, err)
+	}
+	return blockedPhones(blocklist), nil
+}
+
+func blockedPhones(blocklist *types.Blocklist) []string {
+	phones := []string{}
+	if blocklist == nil {
+		return phones
+	}
+	for _, jid := range blocklist.JIDs {
+		phones = append(phones, jid.User)
+	}
+	return phones
+}
@@ -0,0 +1,119 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// SetDisappearingTimer sets how long messages in chat last before they
+// disappear, from a specific sender or the default client when from is empty.
+// WhatsApp announces every change in the chat, so a timer the chat already has
+// is not set again.
+func (r *whatsappRepository) SetDisappearingTimer(ctx context.Context, from, chat string, timer time.Duration) error {
+	client, jid, err := r.chatTarget(from, chat)
+	if err != nil {
+		return err
+	}
+	if !client.IsConnected() || client.Store.ID == nil {
+		return domain.ErrWhatsAppNotConnected
+	}
+	seconds := int(timer.Seconds())
+	if r.db != nil {
+		current, err := repository.GetDisappearingTimer(r.db, client.Store.ID.User, jid.String())
+		if err != nil {
+			return err
+		}
+		if current == seconds {
+			return nil
+		}
+	}
+
+	if err := client.SetDisappearingTimer(ctx, jid, timer, time.Time{}); err != nil {
+		return fmt.Errorf("failed to set disappearing timer: %w", err)
+	}
+	if r.db == nil {
+		return nil
+	}
+	return repository.SetDisappearingTimer(r.db, client.Store.ID.User, jid.String(), seconds)
+}
+
+// sendMessage sends msg to jid, set to disappear after the chat's
+// disappearing timer when it has one, as WhatsApp apps do in such chats. The
+// send takes its place in the sender's daily quota first, and gives it back
+// if it fails.
+func (r *whatsappRepository) sendMessage(ctx context.Context, client *whatsmeow.Client, jid types.JID, msg *waProto.Message) (whatsmeow.SendResponse, error) {
+	reservation, ok := r.quotas.reserve(client)
+	if !ok {
+		return whatsmeow.SendResponse{}, domain.ErrSenderQuotaExceeded
+	}
+	if seconds := r.disappearingTimer(client, jid); seconds > 0 {
+		msg = withExpiration(msg, uint32(seconds))
+	}
+	resp, err := client.SendMessage(ctx, jid, msg)
+	if err != nil {
+		r.quotas.release(reservation)
+		return resp, err
+	}
+	r.quotas.commit(reservation)
+	return resp, nil
+}
+
+// disappearingTimer returns the disappearing timer, in seconds, of client's
+// chat with jid. A failed lookup is logged and sends a lasting message.
+func (r *whatsappRepository) disappearingTimer(client *whatsmeow.Client, jid types.JID) int {
+	if r.db == nil || client.Store.ID == nil {
+		return 0
+	}
+	seconds, err := repository.GetDisappearingTimer(r.db, client.Store.ID.User, jid.String())
+	if err != nil {
+		log.Printf("Failed to look up disappearing timer of %s: %v", jid, err)
+		return 0
+	}
+	return seconds
+}
+
+// withExpiration marks msg to disappear after seconds. A plain text becomes an
+// extended text, the simplest message that carries an expiration.
+func withExpiration(msg *waProto.Message, seconds uint32) *waProto.Message {
+	if msg.Conversation != nil {
+		msg = &waProto.Message{ExtendedTextMessage: &waProto.ExtendedTextMessage{Text: msg.Conversation}}
+	}
+
+	var info **waProto.ContextInfo
+	switch {
+	case msg.ExtendedTextMessage != nil:
+		info = &msg.ExtendedTextMessage.ContextInfo
+	case msg.ImageMessage != nil:
+		info = &msg.ImageMessage.ContextInfo
+	case msg.DocumentMessage != nil:
+		info = &msg.DocumentMessage.ContextInfo
+	case msg.AudioMessage != nil:
+		info = &msg.AudioMessage.ContextInfo
+	case msg.LocationMessage != nil:
+		info = &msg.LocationMessage.ContextInfo
+	case msg.ContactMessage != nil:
+		info = &msg.ContactMessage.ContextInfo
+	case msg.ContactsArrayMessage != nil:
+		info = &msg.ContactsArrayMessage.ContextInfo
+	case msg.ListMessage != nil:
+		info = &msg.ListMessage.ContextInfo
+	case msg.ButtonsMessage != nil:
+		info = &msg.ButtonsMessage.ContextInfo
+	default:
+		return msg
+	}
+	if *info == nil {
+		*info = &waProto.ContextInfo{}
+	}
+	(*info).Expiration = proto.Uint32(seconds)
+	return msg
+}
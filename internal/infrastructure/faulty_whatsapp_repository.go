@@ -0,0 +1,121 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wa-serv/faults"
+	"github.com/wa-serv/internal/domain"
+)
+
+// faultyWhatsAppRepository injects send delays and failures in front of a real
+// repository for resilience testing. Everything except sending is passed through.
+type faultyWhatsAppRepository struct {
+	domain.WhatsAppRepository
+	injector *faults.Injector
+}
+
+// NewFaultyWhatsAppRepository wraps repo with fault injection. It returns repo
+// unchanged when the injector is disabled.
+func NewFaultyWhatsAppRepository(repo domain.WhatsAppRepository, injector *faults.Injector) domain.WhatsAppRepository {
+	if !injector.Enabled() {
+		return repo
+	}
+	return &faultyWhatsAppRepository{WhatsAppRepository: repo, injector: injector}
+}
+
+// SendMessage sends from the default client unless a fault is injected
+func (r *faultyWhatsAppRepository) SendMessage(ctx context.Context, to, message string) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send message: %w", err)
+	}
+	return r.WhatsAppRepository.SendMessage(ctx, to, message)
+}
+
+// SendMessageFrom sends from a specific sender unless a fault is injected
+func (r *faultyWhatsAppRepository) SendMessageFrom(ctx context.Context, from, to, message string) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send message from %s: %w", from, err)
+	}
+	return r.WhatsAppRepository.SendMessageFrom(ctx, from, to, message)
+}
+
+// SendImage sends an image unless a fault is injected
+func (r *faultyWhatsAppRepository) SendImage(ctx context.Context, from, to string, image []byte, mimeType, caption string) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send image: %w", err)
+	}
+	return r.WhatsAppRepository.SendImage(ctx, from, to, image, mimeType, caption)
+}
+
+// SendDocument sends a document unless a fault is injected
+func (r *faultyWhatsAppRepository) SendDocument(ctx context.Context, from, to string, document []byte, fileName, mimeType, caption string) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send document: %w", err)
+	}
+	return r.WhatsAppRepository.SendDocument(ctx, from, to, document, fileName, mimeType, caption)
+}
+
+// SendAudio sends audio unless a fault is injected
+func (r *faultyWhatsAppRepository) SendAudio(ctx context.Context, from, to string, audio []byte, mimeType string, ptt bool) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send audio: %w", err)
+	}
+	return r.WhatsAppRepository.SendAudio(ctx, from, to, audio, mimeType, ptt)
+}
+
+// SendLocation sends a location unless a fault is injected
+func (r *faultyWhatsAppRepository) SendLocation(ctx context.Context, from, to string, latitude, longitude float64, name, address string) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send location: %w", err)
+	}
+	return r.WhatsAppRepository.SendLocation(ctx, from, to, latitude, longitude, name, address)
+}
+
+// SendContacts sends contact cards unless a fault is injected
+func (r *faultyWhatsAppRepository) SendContacts(ctx context.Context, from, to string, contacts []domain.ContactCard) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send contacts: %w", err)
+	}
+	return r.WhatsAppRepository.SendContacts(ctx, from, to, contacts)
+}
+
+// SendInteractive sends buttons or a list menu unless a fault is injected
+func (r *faultyWhatsAppRepository) SendInteractive(ctx context.Context, from, to, body string, interactive *domain.InteractiveMessage) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send interactive message: %w", err)
+	}
+	return r.WhatsAppRepository.SendInteractive(ctx, from, to, body, interactive)
+}
+
+// SendReaction sends a reaction unless a fault is injected
+func (r *faultyWhatsAppRepository) SendReaction(ctx context.Context, from, chat, sender, messageID, emoji string) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send reaction: %w", err)
+	}
+	return r.WhatsAppRepository.SendReaction(ctx, from, chat, sender, messageID, emoji)
+}
+
+// SendReply sends a reply unless a fault is injected
+func (r *faultyWhatsAppRepository) SendReply(ctx context.Context, from, to, message, sender, replyTo string) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to send reply: %w", err)
+	}
+	return r.WhatsAppRepository.SendReply(ctx, from, to, message, sender, replyTo)
+}
+
+// RevokeMessage deletes a message for everyone unless a fault is injected
+func (r *faultyWhatsAppRepository) RevokeMessage(ctx context.Context, from, chat, messageID string) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to revoke message: %w", err)
+	}
+	return r.WhatsAppRepository.RevokeMessage(ctx, from, chat, messageID)
+}
+
+// EditMessage edits a message unless a fault is injected
+func (r *faultyWhatsAppRepository) EditMessage(ctx context.Context, from, chat, messageID, text string) (*domain.Message, error) {
+	if err := r.injector.Send(ctx); err != nil {
+		return nil, fmt.Errorf("failed to edit message: %w", err)
+	}
+	return r.WhatsAppRepository.EditMessage(ctx, from, chat, messageID, text)
+}
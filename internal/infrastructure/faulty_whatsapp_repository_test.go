@@ -0,0 +1,48 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/faults"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/infrastructure"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestNewFaultyWhatsAppRepository_DisabledReturnsInner(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+
+	repo := infrastructure.NewFaultyWhatsAppRepository(inner, faults.NewInjector(config.FaultConfig{}))
+
+	assert.Same(t, inner, repo)
+}
+
+func TestFaultyWhatsAppRepository_FailsSends(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+	injector := faults.NewInjector(config.FaultConfig{Enabled: true, SendFailureRate: 1})
+
+	repo := infrastructure.NewFaultyWhatsAppRepository(inner, injector)
+	_, err := repo.SendMessage(context.Background(), "6281234567890", "hi")
+
+	assert.True(t, errors.Is(err, faults.ErrInjectedSendFailure))
+	inner.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFaultyWhatsAppRepository_PassesThroughWithoutFault(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+	injector := faults.NewInjector(config.FaultConfig{Enabled: true})
+	inner.On("SendMessageFrom", mock.Anything, "sender-1", "6281234567890", "hi").
+		Return(&domain.Message{ID: "msg-1"}, nil)
+
+	repo := infrastructure.NewFaultyWhatsAppRepository(inner, injector)
+	msg, err := repo.SendMessageFrom(context.Background(), "sender-1", "6281234567890", "hi")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", msg.ID)
+	inner.AssertExpectations(t)
+}
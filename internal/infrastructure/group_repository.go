@@ -0,0 +1,237 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// NewGroupRepositoryWithClientManager creates a group repository that, like the
+// WhatsApp repository, picks its client from the client manager on every call
+func NewGroupRepositoryWithClientManager(clientManager ClientManager) domain.GroupRepository {
+	if clientManager == nil {
+		clientManager = newStaticClients(nil, nil)
+	}
+	return &whatsappRepository{clients: clientManager}
+}
+
+// groupClient resolves the connected client that manages groups for from
+func (r *whatsappRepository) groupClient(from string) (*whatsmeow.Client, error) {
+	client, err := r.getClient(from)
+	if err != nil {
+		return nil, fmt.Errorf("sender not found or not initialized: %s: %w", from, err)
+	}
+	if !client.IsConnected() {
+		return nil, domain.ErrWhatsAppNotConnected
+	}
+	return client, nil
+}
+
+// ListGroups returns the groups the sender is a member of
+func (r *whatsappRepository) ListGroups(ctx context.Context, from string) ([]*domain.Group, error) {
+	client, err := r.groupClient(from)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := client.GetJoinedGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get joined groups: %w", err)
+	}
+	result := make([]*domain.Group, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, toDomainGroup(g))
+	}
+	return result, nil
+}
+
+// CreateGroup creates a group with the sender as its admin
+func (r *whatsappRepository) CreateGroup(ctx context.Context, from, name string, participants []string) (*domain.Group, error) {
+	client, err := r.groupClient(from)
+	if err != nil {
+		return nil, err
+	}
+	jids, err := parseJIDs(participants)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.CreateGroup(ctx, whatsmeow.ReqCreateGroup{Name: name, Participants: jids})
+	if err != nil {
+		return nil, groupError("failed to create group", err)
+	}
+	return toDomainGroup(info), nil
+}
+
+// UpdateParticipants adds participants to, or removes them from, a group
+func (r *whatsappRepository) UpdateParticipants(ctx context.Context, from, groupJID string, participants []string, add bool) ([]*domain.GroupParticipantChange, error) {
+	client, err := r.groupClient(from)
+	if err != nil {
+		return nil, err
+	}
+	group, err := types.ParseJID(groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JID: %w", err)
+	}
+	jids, err := parseJIDs(participants)
+	if err != nil {
+		return nil, err
+	}
+
+	action := whatsmeow.ParticipantChangeRemove
+	if add {
+		action = whatsmeow.ParticipantChangeAdd
+	}
+	updated, err := client.UpdateGroupParticipants(ctx, group, jids, action)
+	if err != nil {
+		return nil, groupError("failed to update group participants", err)
+	}
+
+	changes := make([]*domain.GroupParticipantChange, 0, len(updated))
+	for _, p := range updated {
+		change := &domain.GroupParticipantChange{
+			PhoneNumber: participantPhone(p),
+			Success:     p.Error == 0,
+		}
+		if p.Error != 0 {
+			change.Error = participantErrorText(p.Error)
+			change.NeedsInvite = p.Error == 403
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// GetInviteLink returns the invite link of a group the sender administers
+func (r *whatsappRepository) GetInviteLink(ctx context.Context, from, groupJID string, reset bool) (string, error) {
+	client, err := r.groupClient(from)
+	if err != nil {
+		return "", err
+	}
+	group, err := types.ParseJID(groupJID)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse JID: %w", err)
+	}
+
+	link, err := client.GetGroupInviteLink(ctx, group, reset)
+	if err != nil {
+		return "", groupError("failed to get group invite link", err)
+	}
+	return link, nil
+}
+
+// SetDescription replaces the description of a group
+func (r *whatsappRepository) SetDescription(ctx context.Context, from, groupJID, description string) error {
+	client, err := r.groupClient(from)
+	if err != nil {
+		return err
+	}
+	group, err := types.ParseJID(groupJID)
+	if err != nil {
+		return fmt.Errorf("failed to parse JID: %w", err)
+	}
+
+	// An empty previous ID makes whatsmeow look up the current description,
+	// which WhatsApp needs to replace it
+	if err := client.SetGroupTopic(ctx, group, "", "", description); err != nil {
+		return groupError("failed to set group description", err)
+	}
+	return nil
+}
+
+// GetGroup returns a group the sender is a member of, with its participants
+func (r *whatsappRepository) GetGroup(ctx context.Context, from, groupJID string) (*domain.Group, error) {
+	client, err := r.groupClient(from)
+	if err != nil {
+		return nil, err
+	}
+	group, err := types.ParseJID(groupJID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JID: %w", err)
+	}
+
+	info, err := client.GetGroupInfo(ctx, group)
+	if err != nil {
+		return nil, groupError("failed to get group info", err)
+	}
+	return toDomainGroup(info), nil
+}
+
+func parseJIDs(values []string) ([]types.JID, error) {
+	jids := make([]types.JID, 0, len(values))
+	for _, v := range values {
+		jid, err := types.ParseJID(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JID %s: %w", v, err)
+		}
+		jids = append(jids, jid)
+	}
+	return jids, nil
+}
+
+// groupError maps the IQ errors WhatsApp answers group requests with
+func groupError(action string, err error) error {
+	switch {
+	case errors.Is(err, whatsmeow.ErrIQNotFound), errors.Is(err, whatsmeow.ErrGroupNotFound):
+		return domain.ErrGroupNotFound
+	case errors.Is(err, whatsmeow.ErrIQForbidden), errors.Is(err, whatsmeow.ErrIQNotAuthorized),
+		errors.Is(err, whatsmeow.ErrGroupInviteLinkUnauthorized), errors.Is(err, whatsmeow.ErrNotInGroup):
+		return domain.ErrNotGroupAdmin
+	case errors.Is(err, whatsmeow.ErrIQNotAcceptable), errors.Is(err, whatsmeow.ErrIQBadRequest):
+		return fmt.Errorf("%w: %v", domain.ErrInvalidGroup, err)
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// participantErrorText explains the per-participant error codes WhatsApp
+// returns when adding or removing members
+func participantErrorText(code int) string {
+	switch code {
+	case 403:
+		return "privacy settings do not allow adding this number; send them an invite link instead"
+	case 404:
+		return "not a participant of the group"
+	case 408:
+		return "recently left the group"
+	case 409:
+		return "already a participant of the group"
+	}
+	return fmt.Sprintf("WhatsApp refused the change (code %d)", code)
+}
+
+// participantPhone is the phone number of a participant, who may be addressed
+// by a hidden LID
+func participantPhone(p types.GroupParticipant) string {
+	if !p.PhoneNumber.IsEmpty() {
+		return p.PhoneNumber.User
+	}
+	return p.JID.User
+}
+
+func toDomainGroup(info *types.GroupInfo) *domain.Group {
+	group := &domain.Group{
+		JID:              info.JID.String(),
+		Name:             info.Name,
+		Topic:            info.Topic,
+		ParticipantCount: info.ParticipantCount,
+	}
+	for _, p := range info.Participants {
+		group.Participants = append(group.Participants, &domain.GroupParticipant{
+			PhoneNumber: participantPhone(p),
+			IsAdmin:     p.IsAdmin || p.IsSuperAdmin,
+			IsOwner:     p.IsSuperAdmin,
+		})
+	}
+	if group.ParticipantCount == 0 {
+		group.ParticipantCount = len(info.Participants)
+	}
+	if !info.GroupCreated.IsZero() {
+		group.CreatedAt = info.GroupCreated.Format(time.RFC3339)
+	}
+	return group
+}
@@ -0,0 +1,52 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type groupSyncLogRepository struct {
+	db *sql.DB
+}
+
+// NewGroupSyncLogRepository creates a log of VIP group sync changes backed by Postgres
+func NewGroupSyncLogRepository(db *sql.DB) domain.GroupSyncLogRepository {
+	return &groupSyncLogRepository{db: db}
+}
+
+// RecordChange records a membership change
+func (r *groupSyncLogRepository) RecordChange(change *domain.GroupSyncChange) error {
+	return repository.InsertGroupSyncChange(r.db, repository.GroupSyncChange{
+		GroupJID:    change.GroupJID,
+		PhoneNumber: change.PhoneNumber,
+		Action:      change.Action,
+		Error:       change.Error,
+	})
+}
+
+// ListChanges returns the limit most recent changes to a group, newest first
+func (r *groupSyncLogRepository) ListChanges(groupJID string, limit int) ([]*domain.GroupSyncChange, error) {
+	changes, err := repository.GetGroupSyncChanges(r.db, groupJID, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.GroupSyncChange, 0, len(changes))
+	for _, c := range changes {
+		result = append(result, &domain.GroupSyncChange{
+			GroupJID:    c.GroupJID,
+			PhoneNumber: c.PhoneNumber,
+			Action:      c.Action,
+			Error:       c.Error,
+			CreatedAt:   c.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// InvitedSince returns the phone numbers sent the group's invite link since since
+func (r *groupSyncLogRepository) InvitedSince(groupJID string, since time.Time) ([]string, error) {
+	return repository.GetGroupSyncPhones(r.db, groupJID, domain.GroupSyncInvited, since)
+}
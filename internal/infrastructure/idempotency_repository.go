@@ -0,0 +1,61 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+// idempotencyAbandonAfter is when an unfinished reservation is assumed to belong
+// to a crashed request and may be taken over. Sends time out well before this.
+const idempotencyAbandonAfter = 2 * time.Minute
+
+type idempotencyRepository struct {
+	db  *sql.DB
+	ttl time.Duration
+}
+
+// NewIdempotencyRepository creates an idempotency key store backed by Postgres
+// whose keys replay their result for ttl
+func NewIdempotencyRepository(db *sql.DB, ttl time.Duration) domain.IdempotencyRepository {
+	return &idempotencyRepository{db: db, ttl: ttl}
+}
+
+// Reserve claims key, or returns the record of the request already using it
+func (r *idempotencyRepository) Reserve(key, fingerprint string) (*domain.IdempotencyRecord, error) {
+	now := time.Now()
+	reserved, err := repository.ReserveIdempotencyKey(r.db, key, fingerprint, now.Add(-r.ttl), now.Add(-idempotencyAbandonAfter))
+	if err != nil {
+		return nil, err
+	}
+	if reserved {
+		return nil, nil
+	}
+
+	record, err := repository.GetIdempotencyRecord(r.db, key)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		// Released between the two queries; let the caller try again later
+		return &domain.IdempotencyRecord{Fingerprint: fingerprint}, nil
+	}
+	return &domain.IdempotencyRecord{
+		Fingerprint: record.Fingerprint,
+		StatusCode:  record.StatusCode,
+		Response:    []byte(record.Response),
+		Completed:   record.Completed,
+	}, nil
+}
+
+// Complete stores the response to replay for key
+func (r *idempotencyRepository) Complete(key string, statusCode int, response []byte) error {
+	return repository.CompleteIdempotencyKey(r.db, key, statusCode, string(response))
+}
+
+// Release frees key so the request can be retried
+func (r *idempotencyRepository) Release(key string) error {
+	return repository.DeleteIdempotencyKey(r.db, key)
+}
@@ -0,0 +1,185 @@
+package infrastructure
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/appstate"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// NewLabelRepositoryWithClientManager creates a label repository that changes
+// labels through the sender's client and reads them from the copy the
+// WhatsApp event handler keeps in db
+func NewLabelRepositoryWithClientManager(db *sql.DB, clientManager ClientManager) domain.LabelRepository {
+	if clientManager == nil {
+		clientManager = newStaticClients(nil, nil)
+	}
+	return &whatsappRepository{db: db, clients: clientManager}
+}
+
+// labelClient resolves the connected WhatsApp Business client of from and its
+// sender ID. Only business accounts have labels.
+func (r *whatsappRepository) labelClient(from string) (*whatsmeow.Client, string, error) {
+	client, err := r.groupClient(from)
+	if err != nil {
+		return nil, "", err
+	}
+	if client.Store.ID == nil {
+		return nil, "", domain.ErrWhatsAppNotConnected
+	}
+	if client.Store.BusinessName == "" {
+		return nil, "", domain.ErrNotBusinessAccount
+	}
+	return client, client.Store.ID.User, nil
+}
+
+// ListLabels returns the sender's ID and its labels
+func (r *whatsappRepository) ListLabels(ctx context.Context, from string) (string, []*domain.Label, error) {
+	_, senderID, err := r.labelClient(from)
+	if err != nil {
+		return "", nil, err
+	}
+	labels, err := repository.GetLabels(r.db, senderID, false)
+	if err != nil {
+		return "", nil, err
+	}
+	result := make([]*domain.Label, 0, len(labels))
+	for i := range labels {
+		result = append(result, toDomainLabel(&labels[i]))
+	}
+	return senderID, result, nil
+}
+
+// SyncLabels fetches the regular app state, which holds the labels, from
+// scratch. whatsmeow emits every label and labeled chat in it as events, which
+// the event handler records before this returns.
+func (r *whatsappRepository) SyncLabels(ctx context.Context, from string) error {
+	client, _, err := r.labelClient(from)
+	if err != nil {
+		return err
+	}
+	if err := client.FetchAppState(ctx, appstate.WAPatchRegular, true, false); err != nil {
+		return fmt.Errorf("failed to sync labels: %w", err)
+	}
+	return nil
+}
+
+// SaveLabel creates the label when id is empty, else renames or recolors it
+func (r *whatsappRepository) SaveLabel(ctx context.Context, from, id, name string, color int) (*domain.Label, error) {
+	client, senderID, err := r.labelClient(from)
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		if id, err = r.nextLabelID(senderID); err != nil {
+			return nil, err
+		}
+	} else if _, err := r.findLabel(senderID, id); err != nil {
+		return nil, err
+	}
+
+	if err := client.SendAppState(ctx, appstate.BuildLabelEdit(id, name, int32(color), false)); err != nil {
+		return nil, fmt.Errorf("failed to save label: %w", err)
+	}
+	label, err := repository.SaveLabel(r.db, senderID, id, name, color, false)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainLabel(label), nil
+}
+
+// DeleteLabel deletes a label, which takes it off every chat
+func (r *whatsappRepository) DeleteLabel(ctx context.Context, from, id string) error {
+	client, senderID, err := r.labelClient(from)
+	if err != nil {
+		return err
+	}
+	label, err := r.findLabel(senderID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := client.SendAppState(ctx, appstate.BuildLabelEdit(id, label.Name, int32(label.Color), true)); err != nil {
+		return fmt.Errorf("failed to delete label: %w", err)
+	}
+	_, err = repository.SaveLabel(r.db, senderID, id, label.Name, label.Color, true)
+	return err
+}
+
+// ListLabelChats returns the JIDs of the chats a label is on
+func (r *whatsappRepository) ListLabelChats(ctx context.Context, from, id string) ([]string, error) {
+	_, senderID, err := r.labelClient(from)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.findLabel(senderID, id); err != nil {
+		return nil, err
+	}
+	return repository.GetLabelChats(r.db, senderID, id)
+}
+
+// SetLabelChat puts a label on chat, or takes it off when labeled is false
+func (r *whatsappRepository) SetLabelChat(ctx context.Context, from, id, chat string, labeled bool) error {
+	client, senderID, err := r.labelClient(from)
+	if err != nil {
+		return err
+	}
+	if _, err := r.findLabel(senderID, id); err != nil {
+		return err
+	}
+	jid, err := types.ParseJID(chat)
+	if err != nil {
+		return fmt.Errorf("failed to parse JID: %w", err)
+	}
+
+	if err := client.SendAppState(ctx, appstate.BuildLabelChat(jid, id, labeled)); err != nil {
+		return fmt.Errorf("failed to update label on chat: %w", err)
+	}
+	return repository.SetLabelChat(r.db, senderID, id, jid.String(), labeled)
+}
+
+// findLabel returns the label id of senderID, or ErrLabelNotFound
+func (r *whatsappRepository) findLabel(senderID, id string) (*repository.Label, error) {
+	labels, err := repository.GetLabels(r.db, senderID, false)
+	if err != nil {
+		return nil, err
+	}
+	for i := range labels {
+		if labels[i].LabelID == id {
+			return &labels[i], nil
+		}
+	}
+	return nil, domain.ErrLabelNotFound
+}
+
+// nextLabelID returns the ID after the highest one senderID ever used, so a
+// deleted label's ID is not handed out again
+func (r *whatsappRepository) nextLabelID(senderID string) (string, error) {
+	labels, err := repository.GetLabels(r.db, senderID, true)
+	if err != nil {
+		return "", err
+	}
+	highest := 0
+	for _, l := range labels {
+		if n, err := strconv.Atoi(l.LabelID); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return strconv.Itoa(highest + 1), nil
+}
+
+func toDomainLabel(l *repository.Label) *domain.Label {
+	return &domain.Label{
+		ID:        l.LabelID,
+		Name:      l.Name,
+		Color:     l.Color,
+		UpdatedAt: l.UpdatedAt.Format(time.RFC3339),
+	}
+}
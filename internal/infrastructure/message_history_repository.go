@@ -0,0 +1,177 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type messageHistoryRepository struct {
+	db         *sql.DB
+	tenantSlug string
+}
+
+// NewMessageHistoryRepository creates a message history store backed by
+// Postgres, recording sends under the message log mode of tenant tenantSlug
+func NewMessageHistoryRepository(db *sql.DB, tenantSlug string) domain.MessageHistoryRepository {
+	return &messageHistoryRepository{db: db, tenantSlug: tenantSlug}
+}
+
+// LogMode returns the tenant's message log mode. Without a tenant the content
+// is logged in full.
+func (r *messageHistoryRepository) LogMode() (string, error) {
+	if r.tenantSlug == "" {
+		return repository.MessageLogFull, nil
+	}
+	_, mode, err := repository.GetMessageLogMode(r.db, r.tenantSlug)
+	if err != nil {
+		return "", err
+	}
+	if mode == "" {
+		return repository.MessageLogFull, nil
+	}
+	return mode, nil
+}
+
+// Record stores a send. SentAt is set for sent messages.
+func (r *messageHistoryRepository) Record(record *domain.MessageRecord) error {
+	entry := repository.MessageHistoryEntry{
+		Recipient:     record.Recipient,
+		SenderID:      record.SenderID,
+		Kind:          record.Kind,
+		Content:       record.Content,
+		ContentHash:   record.ContentHash,
+		ContentLength: record.ContentLength,
+		Status:        record.Status,
+		MessageID:     record.MessageID,
+		Error:         record.Error,
+		RequestID:     record.RequestID,
+	}
+	if record.Status == repository.MessageHistorySent {
+		entry.SentAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+	return repository.InsertMessageHistory(r.db, entry)
+}
+
+// List returns a page of the sends matching filter and the number of matches
+func (r *messageHistoryRepository) List(filter domain.MessageHistoryFilter) ([]*domain.MessageRecord, int, error) {
+	entries, total, err := repository.GetMessageHistory(r.db, repository.MessageHistoryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	records := make([]*domain.MessageRecord, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, toDomainMessageRecord(e))
+	}
+	return records, total, nil
+}
+
+// GetByMessageID returns the sent message with WhatsApp ID messageID, or nil
+// if none was recorded
+func (r *messageHistoryRepository) GetByMessageID(messageID string) (*domain.MessageRecord, error) {
+	entry, err := repository.GetMessageHistoryByMessageID(r.db, messageID)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+	return toDomainMessageRecord(*entry), nil
+}
+
+// MarkRevoked records that a sent message was deleted for everyone
+func (r *messageHistoryRepository) MarkRevoked(messageID string) error {
+	return repository.MarkMessageHistoryRevoked(r.db, messageID)
+}
+
+// RecordEdit keeps the text a message had before edit and stores its new text
+func (r *messageHistoryRepository) RecordEdit(edit *domain.MessageEdit) error {
+	return repository.InsertMessageEdit(r.db, repository.MessageEdit{
+		MessageID:       edit.MessageID,
+		PreviousContent: edit.PreviousContent,
+		Content:         edit.Content,
+		ContentHash:     edit.ContentHash,
+		ContentLength:   edit.ContentLength,
+		RequestID:       edit.RequestID,
+	})
+}
+
+// ListEdits returns the edits of a message, oldest first
+func (r *messageHistoryRepository) ListEdits(messageID string) ([]*domain.MessageEdit, error) {
+	edits, err := repository.GetMessageEdits(r.db, messageID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.MessageEdit, 0, len(edits))
+	for _, e := range edits {
+		result = append(result, &domain.MessageEdit{
+			MessageID:       e.MessageID,
+			PreviousContent: e.PreviousContent,
+			Content:         e.Content,
+			ContentHash:     e.ContentHash,
+			ContentLength:   e.ContentLength,
+			RequestID:       e.RequestID,
+			EditedAt:        e.EditedAt.Format(time.RFC3339),
+		})
+	}
+	return result, nil
+}
+
+func toDomainMessageRecord(e repository.MessageHistoryEntry) *domain.MessageRecord {
+	record := &domain.MessageRecord{
+		ID:            e.ID,
+		Recipient:     e.Recipient,
+		SenderID:      e.SenderID,
+		Kind:          e.Kind,
+		Content:       e.Content,
+		ContentHash:   e.ContentHash,
+		ContentLength: e.ContentLength,
+		Status:        e.Status,
+		MessageID:     e.MessageID,
+		Error:         e.Error,
+		RequestID:     e.RequestID,
+		CreatedAt:     e.CreatedAt.Format(time.RFC3339),
+	}
+	if e.SentAt.Valid {
+		record.SentAt = e.SentAt.Time.Format(time.RFC3339)
+	}
+	return record
+}
+
+// BusiestContacts returns the contacts that exchanged the most messages
+func (r *messageHistoryRepository) BusiestContacts(since, until time.Time, limit int) ([]*domain.ContactVolume, error) {
+	traffic, err := repository.GetBusiestContacts(r.db, since, until, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	contacts := make([]*domain.ContactVolume, 0, len(traffic))
+	for _, t := range traffic {
+		contacts = append(contacts, &domain.ContactVolume{
+			PhoneNumber:   t.PhoneNumber,
+			Inbound:       t.Inbound,
+			Outbound:      t.Outbound,
+			Total:         t.Inbound + t.Outbound,
+			LastMessageAt: t.LastMessageAt.Format(time.RFC3339),
+		})
+	}
+	return contacts, nil
+}
+
+// Volume returns the messages exchanged per day or week
+func (r *messageHistoryRepository) Volume(since, until time.Time, interval string) ([]*domain.MessageVolume, error) {
+	periods, err := repository.GetMessageVolume(r.db, since, until, interval)
+	if err != nil {
+		return nil, err
+	}
+
+	volume := make([]*domain.MessageVolume, 0, len(periods))
+	for _, p := range periods {
+		volume = append(volume, &domain.MessageVolume{
+			Period:   p.Period.Format("2006-01-02"),
+			Inbound:  p.Inbound,
+			Outbound: p.Outbound,
+		})
+	}
+	return volume, nil
+}
@@ -0,0 +1,31 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type messageStatusRepository struct {
+	db *sql.DB
+}
+
+// NewMessageStatusRepository creates a message status tracker backed by Postgres
+func NewMessageStatusRepository(db *sql.DB) domain.MessageStatusRepository {
+	return &messageStatusRepository{db: db}
+}
+
+// Track starts tracking the delivery of a sent message
+func (r *messageStatusRepository) Track(messageID, requestID, recipient string) error {
+	return repository.TrackMessageStatus(r.db, messageID, requestID, recipient)
+}
+
+// Advance moves a tracked message on to status
+func (r *messageStatusRepository) Advance(messageID, status string) (*domain.TrackedMessage, error) {
+	m, err := repository.AdvanceMessageStatus(r.db, messageID, status)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return &domain.TrackedMessage{MessageID: m.MessageID, RequestID: m.RequestID, Recipient: m.Recipient}, nil
+}
@@ -0,0 +1,137 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/mirror"
+)
+
+// mirrorTimeout bounds sending one archive copy
+const mirrorTimeout = 30 * time.Second
+
+// mirroringWhatsAppRepository copies every message sent through it to the
+// sender's archive chat. Copies are sent from the same sender in the
+// background, after the message itself was sent, so they never delay or fail
+// the send; reactions, deletions and presence are not copied.
+type mirroringWhatsAppRepository struct {
+	domain.WhatsAppRepository
+	mirror *mirror.Mirror
+}
+
+// NewMirroringWhatsAppRepository wraps repo with m. It returns repo unchanged
+// when m mirrors nothing.
+func NewMirroringWhatsAppRepository(repo domain.WhatsAppRepository, m *mirror.Mirror) domain.WhatsAppRepository {
+	if !m.Enabled() {
+		return repo
+	}
+	return &mirroringWhatsAppRepository{WhatsAppRepository: repo, mirror: m}
+}
+
+// SendMessage sends from the default client and mirrors the text
+func (r *mirroringWhatsAppRepository) SendMessage(ctx context.Context, to, message string) (*domain.Message, error) {
+	msg, err := r.WhatsAppRepository.SendMessage(ctx, to, message)
+	r.copy(err, "", to, message)
+	return msg, err
+}
+
+// SendMessageFrom sends from a specific sender and mirrors the text
+func (r *mirroringWhatsAppRepository) SendMessageFrom(ctx context.Context, from, to, message string) (*domain.Message, error) {
+	msg, err := r.WhatsAppRepository.SendMessageFrom(ctx, from, to, message)
+	r.copy(err, from, to, message)
+	return msg, err
+}
+
+// SendImage sends an image and mirrors its caption
+func (r *mirroringWhatsAppRepository) SendImage(ctx context.Context, from, to string, image []byte, mimeType, caption string) (*domain.Message, error) {
+	msg, err := r.WhatsAppRepository.SendImage(ctx, from, to, image, mimeType, caption)
+	r.copy(err, from, to, mirror.MediaText("[gambar]", caption))
+	return msg, err
+}
+
+// SendDocument sends a document and mirrors its name and caption
+func (r *mirroringWhatsAppRepository) SendDocument(ctx context.Context, from, to string, document []byte, fileName, mimeType, caption string) (*domain.Message, error) {
+	msg, err := r.WhatsAppRepository.SendDocument(ctx, from, to, document, fileName, mimeType, caption)
+	r.copy(err, from, to, mirror.MediaText("[dokumen: "+fileName+"]", caption))
+	return msg, err
+}
+
+// SendAudio sends audio and mirrors that it was sent
+func (r *mirroringWhatsAppRepository) SendAudio(ctx context.Context, from, to string, audio []byte, mimeType string, ptt bool) (*domain.Message, error) {
+	msg, err := r.WhatsAppRepository.SendAudio(ctx, from, to, audio, mimeType, ptt)
+	kind := "[audio]"
+	if ptt {
+		kind = "[pesan suara]"
+	}
+	r.copy(err, from, to, kind)
+	return msg, err
+}
+
+// SendLocation sends a location and mirrors its name and address
+func (r *mirroringWhatsAppRepository) SendLocation(ctx context.Context, from, to string, latitude, longitude float64, name, address string) (*domain.Message, error) {
+	msg, err := r.WhatsAppRepository.SendLocation(ctx, from, to, latitude, longitude, name, address)
+	r.copy(err, from, to, mirror.MediaText("[lokasi]", strings.TrimSpace(name+" "+address)))
+	return msg, err
+}
+
+// SendContacts sends contact cards and mirrors their names
+func (r *mirroringWhatsAppRepository) SendContacts(ctx context.Context, from, to string, contacts []domain.ContactCard) (*domain.Message, error) {
+	msg, err := r.WhatsAppRepository.SendContacts(ctx, from, to, contacts)
+	names := make([]string, 0, len(contacts))
+	for _, contact := range contacts {
+		names = append(names, contact.Name)
+	}
+	r.copy(err, from, to, mirror.MediaText("[kontak]", strings.Join(names, ", ")))
+	return msg, err
+}
+
+// SendInteractive sends buttons or a list menu and mirrors the body
+func (r *mirroringWhatsAppRepository) SendInteractive(ctx context.Context, from, to, body string, interactive *domain.InteractiveMessage) (*domain.Message, error) {
+	msg, err := r.WhatsAppRepository.SendInteractive(ctx, from, to, body, interactive)
+	r.copy(err, from, to, body)
+	return msg, err
+}
+
+// SendReply sends a reply and mirrors the text
+func (r *mirroringWhatsAppRepository) SendReply(ctx context.Context, from, to, message, sender, replyTo string) (*domain.Message, error) {
+	msg, err := r.WhatsAppRepository.SendReply(ctx, from, to, message, sender, replyTo)
+	r.copy(err, from, to, message)
+	return msg, err
+}
+
+// copy sends the archive copy of content sent from sender from to the chat to,
+// unless sending failed (sendErr) or to is itself an archive
+func (r *mirroringWhatsAppRepository) copy(sendErr error, from, to, content string) {
+	if sendErr != nil || r.mirror.IsArchive(to) {
+		return
+	}
+
+	senderID := from
+	if senderID == "" {
+		if sender, err := r.WhatsAppRepository.GetDefaultSender(); err == nil {
+			senderID = sender.ID
+		}
+	}
+	archive, ok := r.mirror.Outbound(senderID)
+	if !ok {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mirrorTimeout)
+		defer cancel()
+		text := mirror.OutboundText(to, content)
+		var err error
+		if from == "" {
+			_, err = r.WhatsAppRepository.SendMessage(ctx, archive, text)
+		} else {
+			_, err = r.WhatsAppRepository.SendMessageFrom(ctx, from, archive, text)
+		}
+		if err != nil {
+			log.Printf("Failed to mirror message to %s into archive %s: %v", to, archive, err)
+		}
+	}()
+}
@@ -0,0 +1,54 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/infrastructure"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/mirror"
+)
+
+func TestNewMirroringWhatsAppRepository_WithoutTargetsReturnsInner(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+
+	repo := infrastructure.NewMirroringWhatsAppRepository(inner, mirror.New(config.MirrorConfig{Outbound: true}))
+
+	assert.Same(t, inner, repo)
+}
+
+func TestMirroringWhatsAppRepository_CopiesSentMessages(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+	m := mirror.New(config.MirrorConfig{Targets: map[string]string{"sender-1": "120363025246125486@g.us"}, Outbound: true})
+	inner.On("SendMessageFrom", mock.Anything, "sender-1", "6281234567890@s.whatsapp.net", "Halo").
+		Return(&domain.Message{ID: "msg-1"}, nil)
+	copied := make(chan string, 1)
+	inner.On("SendMessageFrom", mock.Anything, "sender-1", "120363025246125486@g.us", mock.Anything).
+		Run(func(args mock.Arguments) { copied <- args.String(3) }).
+		Return(&domain.Message{ID: "copy-1"}, nil)
+
+	repo := infrastructure.NewMirroringWhatsAppRepository(inner, m)
+	msg, err := repo.SendMessageFrom(context.Background(), "sender-1", "6281234567890@s.whatsapp.net", "Halo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", msg.ID)
+	assert.Equal(t, "📤 *Ke 6281234567890*\nHalo", <-copied)
+}
+
+func TestMirroringWhatsAppRepository_SkipsFailedSends(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+	m := mirror.New(config.MirrorConfig{Targets: map[string]string{"*": "6289999999999"}, Outbound: true})
+	inner.On("SendMessageFrom", mock.Anything, "sender-1", "6281234567890@s.whatsapp.net", "Halo").
+		Return(nil, errors.New("not connected"))
+
+	repo := infrastructure.NewMirroringWhatsAppRepository(inner, m)
+	_, err := repo.SendMessageFrom(context.Background(), "sender-1", "6281234567890@s.whatsapp.net", "Halo")
+
+	assert.Error(t, err)
+	inner.AssertNumberOfCalls(t, "SendMessageFrom", 1)
+}
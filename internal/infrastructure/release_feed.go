@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+)
+
+// releaseFeed reads the latest release from a JSON feed such as GitHub's
+// releases/latest API
+type releaseFeed struct {
+	url    string
+	client *http.Client
+}
+
+// releaseVersionPattern is a semantic version with an optional "v" prefix,
+// e.g. v1.4.0 or 1.5.0-rc.1+build.7. Anything else from the feed is refused,
+// since it ends up on the dashboard.
+var releaseVersionPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// releaseFeedResponse is the part of the feed read: GitHub names the release
+// tag_name, simpler feeds version
+type releaseFeedResponse struct {
+	TagName string `json:"tag_name"`
+	Version string `json:"version"`
+}
+
+// NewReleaseFeed creates a release feed client with a 5s request timeout
+func NewReleaseFeed(url string) domain.ReleaseFeed {
+	return &releaseFeed{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// LatestVersion fetches the feed and returns the version of its release, which
+// must be a semantic version
+func (f *releaseFeed) LatestVersion(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return "", fmt.Errorf("build release feed request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("call release feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("release feed returned status %d", resp.StatusCode)
+	}
+
+	var out releaseFeedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("decode release feed: %w", err)
+	}
+	version := strings.TrimSpace(out.TagName)
+	if version == "" {
+		version = strings.TrimSpace(out.Version)
+	}
+	if version == "" {
+		return "", fmt.Errorf("release feed has no version")
+	}
+	if !releaseVersionPattern.MatchString(version) {
+		return "", fmt.Errorf("release feed version %q is not a semantic version", version)
+	}
+	return version, nil
+}
@@ -0,0 +1,64 @@
+package infrastructure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseFeed_LatestVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"github release", `{"tag_name":"v1.4.0","name":"Spring release"}`, "v1.4.0"},
+		{"plain feed", `{"version":"1.4.0"}`, "1.4.0"},
+		{"pre-release", `{"tag_name":"v1.5.0-rc.1+build.7"}`, "v1.5.0-rc.1+build.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			version, err := NewReleaseFeed(server.URL).LatestVersion(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, version)
+		})
+	}
+}
+
+func TestReleaseFeed_LatestVersion_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"server error", http.StatusBadGateway, `{}`},
+		{"no version", http.StatusOK, `{"name":"Spring release"}`},
+		{"not json", http.StatusOK, `<html>`},
+		{"markup in tag", http.StatusOK, `{"tag_name":"v9.0.0-<img src=x onerror=alert(1)>"}`},
+		{"not semver", http.StatusOK, `{"tag_name":"latest"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewReleaseFeed(server.URL).LatestVersion(context.Background())
+
+			assert.Error(t, err)
+		})
+	}
+}
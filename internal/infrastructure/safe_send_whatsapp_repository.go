@@ -0,0 +1,180 @@
+package infrastructure
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/safesend"
+)
+
+// safeSendWhatsAppRepository keeps a staging or development instance from
+// messaging anyone outside its safe recipients. Sends to others are redirected,
+// labelled with who they were meant for, or dropped; a dropped send looks sent
+// to the caller, without an ID, so flows such as broadcasts run to the end.
+type safeSendWhatsAppRepository struct {
+	domain.WhatsAppRepository
+	guard *safesend.Guard
+}
+
+// NewSafeSendWhatsAppRepository wraps repo with guard. It returns repo
+// unchanged when the guard is disabled, as in production.
+func NewSafeSendWhatsAppRepository(repo domain.WhatsAppRepository, guard *safesend.Guard) domain.WhatsAppRepository {
+	if !guard.Enabled() {
+		return repo
+	}
+	return &safeSendWhatsAppRepository{WhatsAppRepository: repo, guard: guard}
+}
+
+// SendMessage sends from the default client to a safe recipient
+func (r *safeSendWhatsAppRepository) SendMessage(ctx context.Context, to, message string) (*domain.Message, error) {
+	decision, routed := r.guard.Route(to)
+	switch decision {
+	case safesend.Block:
+		return dropped(to, message), nil
+	case safesend.Redirect:
+		message = r.guard.Label(to, message)
+	}
+	return r.WhatsAppRepository.SendMessage(ctx, routed, message)
+}
+
+// SendMessageFrom sends from a specific sender to a safe recipient
+func (r *safeSendWhatsAppRepository) SendMessageFrom(ctx context.Context, from, to, message string) (*domain.Message, error) {
+	decision, routed := r.guard.Route(to)
+	switch decision {
+	case safesend.Block:
+		return dropped(to, message), nil
+	case safesend.Redirect:
+		message = r.guard.Label(to, message)
+	}
+	return r.WhatsAppRepository.SendMessageFrom(ctx, from, routed, message)
+}
+
+// SendImage sends an image to a safe recipient
+func (r *safeSendWhatsAppRepository) SendImage(ctx context.Context, from, to string, image []byte, mimeType, caption string) (*domain.Message, error) {
+	decision, routed := r.guard.Route(to)
+	switch decision {
+	case safesend.Block:
+		return dropped(to, caption), nil
+	case safesend.Redirect:
+		caption = r.guard.Label(to, caption)
+	}
+	return r.WhatsAppRepository.SendImage(ctx, from, routed, image, mimeType, caption)
+}
+
+// SendDocument sends a document to a safe recipient
+func (r *safeSendWhatsAppRepository) SendDocument(ctx context.Context, from, to string, document []byte, fileName, mimeType, caption string) (*domain.Message, error) {
+	decision, routed := r.guard.Route(to)
+	switch decision {
+	case safesend.Block:
+		return dropped(to, fileName), nil
+	case safesend.Redirect:
+		caption = r.guard.Label(to, caption)
+	}
+	return r.WhatsAppRepository.SendDocument(ctx, from, routed, document, fileName, mimeType, caption)
+}
+
+// SendAudio sends audio to a safe recipient. Audio has no caption, so a
+// redirected one is not labelled.
+func (r *safeSendWhatsAppRepository) SendAudio(ctx context.Context, from, to string, audio []byte, mimeType string, ptt bool) (*domain.Message, error) {
+	decision, routed := r.guard.Route(to)
+	if decision == safesend.Block {
+		return dropped(to, "audio"), nil
+	}
+	return r.WhatsAppRepository.SendAudio(ctx, from, routed, audio, mimeType, ptt)
+}
+
+// SendLocation sends a location to a safe recipient, unlabelled when redirected
+func (r *safeSendWhatsAppRepository) SendLocation(ctx context.Context, from, to string, latitude, longitude float64, name, address string) (*domain.Message, error) {
+	decision, routed := r.guard.Route(to)
+	if decision == safesend.Block {
+		return dropped(to, name), nil
+	}
+	return r.WhatsAppRepository.SendLocation(ctx, from, routed, latitude, longitude, name, address)
+}
+
+// SendContacts sends contact cards to a safe recipient, unlabelled when redirected
+func (r *safeSendWhatsAppRepository) SendContacts(ctx context.Context, from, to string, contacts []domain.ContactCard) (*domain.Message, error) {
+	decision, routed := r.guard.Route(to)
+	if decision == safesend.Block {
+		return dropped(to, "contacts"), nil
+	}
+	return r.WhatsAppRepository.SendContacts(ctx, from, routed, contacts)
+}
+
+// SendInteractive sends buttons or a list menu to a safe recipient
+func (r *safeSendWhatsAppRepository) SendInteractive(ctx context.Context, from, to, body string, interactive *domain.InteractiveMessage) (*domain.Message, error) {
+	decision, routed := r.guard.Route(to)
+	switch decision {
+	case safesend.Block:
+		return dropped(to, body), nil
+	case safesend.Redirect:
+		body = r.guard.Label(to, body)
+	}
+	return r.WhatsAppRepository.SendInteractive(ctx, from, routed, body, interactive)
+}
+
+// SendReply sends a reply to a safe recipient. The quoted message is not in
+// the redirect recipient's chat, so a redirected reply is sent as a plain
+// message.
+func (r *safeSendWhatsAppRepository) SendReply(ctx context.Context, from, to, message, sender, replyTo string) (*domain.Message, error) {
+	decision, routed := r.guard.Route(to)
+	switch decision {
+	case safesend.Block:
+		return dropped(to, message), nil
+	case safesend.Redirect:
+		return r.WhatsAppRepository.SendMessageFrom(ctx, from, routed, r.guard.Label(to, message))
+	}
+	return r.WhatsAppRepository.SendReply(ctx, from, to, message, sender, replyTo)
+}
+
+// SendReaction reacts only in the chats of safe recipients
+func (r *safeSendWhatsAppRepository) SendReaction(ctx context.Context, from, chat, sender, messageID, emoji string) (*domain.Message, error) {
+	if !r.guard.Allows(chat) {
+		log.Printf("Safe send: dropped reaction in %s, which is not in SAFE_SEND_RECIPIENTS", chat)
+		return dropped(chat, emoji), nil
+	}
+	return r.WhatsAppRepository.SendReaction(ctx, from, chat, sender, messageID, emoji)
+}
+
+// RevokeMessage deletes messages only in the chats of safe recipients
+func (r *safeSendWhatsAppRepository) RevokeMessage(ctx context.Context, from, chat, messageID string) (*domain.Message, error) {
+	if !r.guard.Allows(chat) {
+		log.Printf("Safe send: dropped deletion of %s in %s, which is not in SAFE_SEND_RECIPIENTS", messageID, chat)
+		return dropped(chat, ""), nil
+	}
+	return r.WhatsAppRepository.RevokeMessage(ctx, from, chat, messageID)
+}
+
+// EditMessage edits messages only in the chats of safe recipients
+func (r *safeSendWhatsAppRepository) EditMessage(ctx context.Context, from, chat, messageID, text string) (*domain.Message, error) {
+	if !r.guard.Allows(chat) {
+		log.Printf("Safe send: dropped edit of %s in %s, which is not in SAFE_SEND_RECIPIENTS", messageID, chat)
+		return dropped(chat, text), nil
+	}
+	return r.WhatsAppRepository.EditMessage(ctx, from, chat, messageID, text)
+}
+
+// SendChatPresence shows typing or recording only in the chats of safe recipients
+func (r *safeSendWhatsAppRepository) SendChatPresence(ctx context.Context, from, chat, state string) error {
+	if !r.guard.Allows(chat) {
+		return nil
+	}
+	return r.WhatsAppRepository.SendChatPresence(ctx, from, chat, state)
+}
+
+// SetDisappearingTimer changes the disappearing timer only in the chats of safe
+// recipients, since WhatsApp announces the change in the chat
+func (r *safeSendWhatsAppRepository) SetDisappearingTimer(ctx context.Context, from, chat string, timer time.Duration) error {
+	if !r.guard.Allows(chat) {
+		log.Printf("Safe send: dropped disappearing timer change in %s, which is not in SAFE_SEND_RECIPIENTS", chat)
+		return nil
+	}
+	return r.WhatsAppRepository.SetDisappearingTimer(ctx, from, chat, timer)
+}
+
+// dropped is what a send that was not made returns
+func dropped(to, content string) *domain.Message {
+	return &domain.Message{To: to, Content: content, SentAt: time.Now().String()}
+}
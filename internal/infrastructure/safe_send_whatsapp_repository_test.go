@@ -0,0 +1,78 @@
+package infrastructure_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/infrastructure"
+	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/safesend"
+)
+
+func TestNewSafeSendWhatsAppRepository_ProductionReturnsInner(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+
+	repo := infrastructure.NewSafeSendWhatsAppRepository(inner, safesend.NewGuard(config.EnvironmentConfig{Profile: config.ProfileProduction}))
+
+	assert.Same(t, inner, repo)
+}
+
+func TestSafeSendWhatsAppRepository_SendsToSafeRecipients(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+	guard := safesend.NewGuard(config.EnvironmentConfig{Profile: config.ProfileStaging, SafeRecipients: []string{"6281111111111"}})
+	inner.On("SendMessageFrom", mock.Anything, "sender-1", "6281111111111@s.whatsapp.net", "Halo").
+		Return(&domain.Message{ID: "msg-1"}, nil)
+
+	repo := infrastructure.NewSafeSendWhatsAppRepository(inner, guard)
+	msg, err := repo.SendMessageFrom(context.Background(), "sender-1", "6281111111111@s.whatsapp.net", "Halo")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-1", msg.ID)
+	inner.AssertExpectations(t)
+}
+
+func TestSafeSendWhatsAppRepository_DropsOtherRecipients(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+	guard := safesend.NewGuard(config.EnvironmentConfig{Profile: config.ProfileStaging, SafeRecipients: []string{"6281111111111"}})
+
+	repo := infrastructure.NewSafeSendWhatsAppRepository(inner, guard)
+	msg, err := repo.SendMessage(context.Background(), "6289999999999@s.whatsapp.net", "Promo")
+
+	assert.NoError(t, err)
+	assert.Empty(t, msg.ID)
+	inner.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSafeSendWhatsAppRepository_RedirectsOtherRecipients(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+	guard := safesend.NewGuard(config.EnvironmentConfig{Profile: config.ProfileStaging, RedirectTo: "6282222222222"})
+	inner.On("SendMessageFrom", mock.Anything, "sender-1", "6282222222222@s.whatsapp.net", "[STAGING → 6289999999999]\nSiap").
+		Return(&domain.Message{ID: "msg-2"}, nil)
+
+	repo := infrastructure.NewSafeSendWhatsAppRepository(inner, guard)
+	msg, err := repo.SendReply(context.Background(), "sender-1", "6289999999999@s.whatsapp.net", "Siap", "6289999999999@s.whatsapp.net", "3EB0C767D71A")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "msg-2", msg.ID)
+	inner.AssertExpectations(t)
+}
+
+func TestSafeSendWhatsAppRepository_KeepsDisappearingTimerOfOtherChats(t *testing.T) {
+	inner := &mocks.MockWhatsAppRepository{}
+	guard := safesend.NewGuard(config.EnvironmentConfig{Profile: config.ProfileStaging, SafeRecipients: []string{"6281111111111"}})
+	inner.On("SetDisappearingTimer", mock.Anything, "", "6281111111111@s.whatsapp.net", 24*time.Hour).Return(nil)
+
+	repo := infrastructure.NewSafeSendWhatsAppRepository(inner, guard)
+	safeErr := repo.SetDisappearingTimer(context.Background(), "", "6281111111111@s.whatsapp.net", 24*time.Hour)
+	otherErr := repo.SetDisappearingTimer(context.Background(), "", "6289999999999@s.whatsapp.net", 24*time.Hour)
+
+	assert.NoError(t, safeErr)
+	assert.NoError(t, otherErr)
+	inner.AssertNumberOfCalls(t, "SetDisappearingTimer", 1)
+	inner.AssertExpectations(t)
+}
@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type segmentRepository struct {
+	db *sql.DB
+}
+
+// NewSegmentRepository creates a broadcast segment repository backed by Postgres
+func NewSegmentRepository(db *sql.DB) domain.SegmentRepository {
+	return &segmentRepository{db: db}
+}
+
+// ListSegmentMembers returns the members with at least minPoints accumulated points
+func (r *segmentRepository) ListSegmentMembers(minPoints int) ([]*domain.SegmentMember, error) {
+	members, err := repository.GetMembersWithPoints(r.db, minPoints)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.SegmentMember, 0, len(members))
+	for _, m := range members {
+		result = append(result, &domain.SegmentMember{PhoneNumber: m.PhoneNumber, AccumulatedPoints: m.AccumulatedPoints})
+	}
+	return result, nil
+}
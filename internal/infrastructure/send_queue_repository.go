@@ -0,0 +1,141 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type sendQueueRepository struct {
+	db *sql.DB
+}
+
+// NewSendQueueRepository creates a send queue backed by Postgres
+func NewSendQueueRepository(db *sql.DB) domain.SendQueueRepository {
+	return &sendQueueRepository{db: db}
+}
+
+// Enqueue stores req for a retry at nextAttempt
+func (r *sendQueueRepository) Enqueue(req *domain.SendMessageRequest, requestID string, attempts int, nextAttempt time.Time, lastError string) (int64, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode queued send: %w", err)
+	}
+	return repository.EnqueueSend(r.db, string(payload), requestID, attempts, nextAttempt, lastError)
+}
+
+// Due returns up to limit messages whose retry is due
+func (r *sendQueueRepository) Due(now time.Time, limit int) ([]*domain.QueuedSend, error) {
+	sends, err := repository.GetDueSends(r.db, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*domain.QueuedSend, 0, len(sends))
+	for _, s := range sends {
+		var req domain.SendMessageRequest
+		if err := json.Unmarshal([]byte(s.Payload), &req); err != nil {
+			// A payload that cannot be decoded never will be; stop retrying it
+			log.Printf("Dropping undecodable queued send %d: %v", s.ID, err)
+			if err := repository.DeadLetterSend(r.db, s.ID, repository.SendQueueFailed, s.Attempts, "undecodable payload"); err != nil {
+				log.Printf("Failed to mark queued send %d failed: %v", s.ID, err)
+			}
+			continue
+		}
+		result = append(result, &domain.QueuedSend{ID: s.ID, Request: &req, RequestID: s.RequestID, Attempts: s.Attempts, LastError: s.LastError, QueuedAt: s.QueuedAt})
+	}
+	return result, nil
+}
+
+// MarkSent records that a retry delivered the message
+func (r *sendQueueRepository) MarkSent(id int64, attempts int) error {
+	return repository.UpdateSend(r.db, id, repository.SendQueueSent, attempts, time.Now(), "")
+}
+
+// Retry records a failed retry and schedules the next one
+func (r *sendQueueRepository) Retry(id int64, attempts int, nextAttempt time.Time, lastError string) error {
+	return repository.UpdateSend(r.db, id, repository.SendQueuePending, attempts, nextAttempt, lastError)
+}
+
+// MarkFailed records that the message was given up on and dead-letters it
+func (r *sendQueueRepository) MarkFailed(id int64, attempts int, lastError string) error {
+	return repository.DeadLetterSend(r.db, id, repository.SendQueueFailed, attempts, lastError)
+}
+
+// MarkExpired records that the message waited too long for a sender to
+// reconnect and dead-letters it
+func (r *sendQueueRepository) MarkExpired(id int64, attempts int, lastError string) error {
+	return repository.DeadLetterSend(r.db, id, repository.SendQueueExpired, attempts, lastError)
+}
+
+// Stats counts the waiting, given-up and expired messages
+func (r *sendQueueRepository) Stats() (*domain.SendQueueStatus, error) {
+	stats, err := repository.GetSendQueueStats(r.db)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SendQueueStatus{
+		Depth:          stats.Pending,
+		Failed:         stats.Failed,
+		Expired:        stats.Expired,
+		FailedAttempts: stats.FailedAttempts,
+	}, nil
+}
+
+// DeadLetters returns up to limit messages given up on, newest first
+func (r *sendQueueRepository) DeadLetters(includeRequeued bool, limit int) ([]*domain.DeadLetter, error) {
+	letters, err := repository.GetDeadLetters(r.db, includeRequeued, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.DeadLetter, 0, len(letters))
+	for i := range letters {
+		result = append(result, toDomainDeadLetter(&letters[i]))
+	}
+	return result, nil
+}
+
+// Requeue puts a dead letter back on the queue as a new message due now
+func (r *sendQueueRepository) Requeue(deadLetterID int64) (*domain.DeadLetter, error) {
+	letter, err := repository.RequeueDeadLetter(r.db, deadLetterID, time.Now())
+	switch err {
+	case nil:
+		return toDomainDeadLetter(letter), nil
+	case repository.ErrDeadLetterNotFound:
+		return nil, domain.ErrDeadLetterNotFound
+	case repository.ErrDeadLetterRequeued:
+		return nil, domain.ErrDeadLetterRequeued
+	}
+	return nil, err
+}
+
+func toDomainDeadLetter(letter *repository.DeadLetter) *domain.DeadLetter {
+	result := &domain.DeadLetter{
+		ID:        letter.ID,
+		QueueID:   letter.QueueID,
+		RequestID: letter.RequestID,
+		Status:    letter.Status,
+		Attempts:  letter.Attempts,
+		LastError: letter.LastError,
+		QueuedAt:  letter.QueuedAt.Format(time.RFC3339),
+		FailedAt:  letter.CreatedAt.Format(time.RFC3339),
+	}
+	// An undecodable payload is listed without its request; requeueing it
+	// dead-letters it again
+	var req domain.SendMessageRequest
+	if err := json.Unmarshal([]byte(letter.Payload), &req); err == nil {
+		result.Request = &req
+	}
+	if letter.RequeuedAt != nil {
+		result.RequeuedAt = letter.RequeuedAt.Format(time.RFC3339)
+	}
+	if letter.RequeueID != nil {
+		result.RequeueID = *letter.RequeueID
+	}
+	return result
+}
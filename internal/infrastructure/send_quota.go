@@ -0,0 +1,145 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+)
+
+// quotaRefresh is how long a sender's daily quota is trusted before it is
+// read again, so a quota changed through the API applies within it
+const quotaRefresh = time.Minute
+
+// quotaRouter is told which senders have sent their daily quota, to route
+// sends around them until it resets
+type quotaRouter interface {
+	SetQuotaExhausted(senderID string, until time.Time)
+}
+
+// sendQuotas counts each sender's sends of the day against its daily quota.
+// A send reserves its place in memory before it goes out, so concurrent sends
+// cannot overshoot the quota and sending costs no database read. A sender's
+// count is read from the database on its first send of the day, and every
+// send made is written back.
+type sendQuotas struct {
+	db     *sql.DB
+	cfg    config.SendQuotaConfig
+	router quotaRouter
+	now    func() time.Time
+
+	mu      sync.Mutex
+	senders map[string]*senderQuota
+}
+
+// senderQuota is one sender's quota and its sends of a day
+type senderQuota struct {
+	day      string    // YYYY-MM-DD in the quota time zone
+	quota    int       // most sends a day; 0 is no cap
+	sent     int       // sends of the day, including those under way
+	loadedAt time.Time // when quota was read
+}
+
+// quotaReservation is a send's place in its sender's quota of a day. The zero
+// value is a send that is not counted.
+type quotaReservation struct {
+	senderID string
+	day      string
+}
+
+func newSendQuotas(db *sql.DB, cfg config.SendQuotaConfig, router quotaRouter) *sendQuotas {
+	return &sendQuotas{
+		db:      db,
+		cfg:     cfg,
+		router:  router,
+		now:     time.Now,
+		senders: make(map[string]*senderQuota),
+	}
+}
+
+// reserve takes a place in the daily quota of client's sender for a send, or
+// returns false when it has sent its quota. A quota that cannot be read is
+// logged and lets the send through uncounted.
+func (q *sendQuotas) reserve(client *whatsmeow.Client) (quotaReservation, bool) {
+	if q == nil || client.Store == nil || client.Store.ID == nil {
+		return quotaReservation{}, true
+	}
+	senderID := client.Store.ID.User
+	now := q.now()
+	day := q.cfg.Day(now)
+
+	q.mu.Lock()
+	s := q.senders[senderID]
+	stale := s == nil || s.day != day || now.Sub(s.loadedAt) >= quotaRefresh
+	q.mu.Unlock()
+
+	// Read outside the lock, so a slow database holds up only this sender
+	var quota, sent int
+	if stale {
+		var err error
+		quota, sent, err = repository.GetSenderQuotaUsage(q.db, senderID, day)
+		if err != nil {
+			log.Printf("Failed to look up daily quota of sender %s: %v", senderID, err)
+			return quotaReservation{}, true
+		}
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	s = q.senders[senderID]
+	switch {
+	case s == nil || s.day != day:
+		// A new day starts from the sends stored for it; sends reserved
+		// meanwhile by others on the same day are already counted in s
+		s = &senderQuota{day: day, quota: quota, sent: sent, loadedAt: now}
+		q.senders[senderID] = s
+	case stale:
+		s.quota, s.loadedAt = quota, now
+	}
+
+	if s.quota > 0 && s.sent >= s.quota {
+		q.route(senderID, s, now)
+		return quotaReservation{}, false
+	}
+	s.sent++
+	q.route(senderID, s, now)
+	return quotaReservation{senderID: senderID, day: day}, true
+}
+
+// release gives back the place of a send that failed
+func (q *sendQuotas) release(r quotaReservation) {
+	if q == nil || r.senderID == "" {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if s := q.senders[r.senderID]; s != nil && s.day == r.day && s.sent > 0 {
+		s.sent--
+		q.route(r.senderID, s, q.now())
+	}
+}
+
+// commit stores a send that went out, so the count survives a restart and
+// shows in the sender's quota usage
+func (q *sendQuotas) commit(r quotaReservation) {
+	if q == nil || r.senderID == "" {
+		return
+	}
+	if err := repository.AddSenderDailySend(q.db, r.senderID, r.day); err != nil {
+		log.Printf("Failed to count send of sender %s against its daily quota: %v", r.senderID, err)
+	}
+}
+
+// route tells the router whether senderID can take more sends today. The
+// caller must hold q.mu.
+func (q *sendQuotas) route(senderID string, s *senderQuota, now time.Time) {
+	if s.quota > 0 && s.sent >= s.quota {
+		q.router.SetQuotaExhausted(senderID, q.cfg.NextReset(now))
+		return
+	}
+	q.router.SetQuotaExhausted(senderID, time.Time{})
+}
@@ -0,0 +1,160 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// fakeQuotaRouter records which senders were taken out of routing, and until
+// when
+type fakeQuotaRouter struct {
+	mu        sync.Mutex
+	exhausted map[string]time.Time
+}
+
+func (f *fakeQuotaRouter) SetQuotaExhausted(senderID string, until time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if until.IsZero() {
+		delete(f.exhausted, senderID)
+		return
+	}
+	f.exhausted[senderID] = until
+}
+
+// setupSendQuotas returns quotas over an in-memory database with one sender of
+// the given daily quota, which has already sent sent messages today
+func setupSendQuotas(t *testing.T, quota, sent int) (*sendQuotas, *fakeQuotaRouter, *whatsmeow.Client) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	for _, ddl := range []string{
+		`CREATE TABLE senders (sender_id VARCHAR(50) PRIMARY KEY, daily_quota INT NOT NULL DEFAULT 0)`,
+		`CREATE TABLE sender_daily_sends (sender_id VARCHAR(50) NOT NULL, day DATE NOT NULL, sent INT NOT NULL DEFAULT 0, PRIMARY KEY (sender_id, day))`,
+	} {
+		_, err := db.Exec(ddl)
+		require.NoError(t, err)
+	}
+
+	now := time.Date(2026, 10, 16, 18, 45, 0, 0, time.UTC)
+	location, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+	cfg := config.SendQuotaConfig{Location: location}
+	_, err = db.Exec("INSERT INTO senders (sender_id, daily_quota) VALUES (?, ?)", "6281111111111", quota)
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO sender_daily_sends (sender_id, day, sent) VALUES (?, ?, ?)", "6281111111111", cfg.Day(now), sent)
+	require.NoError(t, err)
+
+	router := &fakeQuotaRouter{exhausted: make(map[string]time.Time)}
+	quotas := newSendQuotas(db, cfg, router)
+	quotas.now = func() time.Time { return now }
+	jid := types.JID{User: "6281111111111", Server: types.DefaultUserServer}
+	return quotas, router, &whatsmeow.Client{Store: &store.Device{ID: &jid}}
+}
+
+func TestSendQuotas_ReserveUpToQuota(t *testing.T) {
+	// Arrange
+	quotas, router, client := setupSendQuotas(t, 3, 1)
+
+	// Act
+	first, firstOK := quotas.reserve(client)
+	quotas.commit(first)
+	_, secondOK := quotas.reserve(client)
+	_, thirdOK := quotas.reserve(client)
+
+	// Assert
+	assert.True(t, firstOK)
+	assert.True(t, secondOK, "the send that fills the quota goes out")
+	assert.False(t, thirdOK)
+	assert.Equal(t, time.Date(2026, 10, 18, 0, 0, 0, 0, quotas.cfg.Location), router.exhausted["6281111111111"].In(quotas.cfg.Location),
+		"taken out of routing until midnight in the quota time zone")
+
+	quota, sent, err := repository.GetSenderQuotaUsage(quotas.db, "6281111111111", "2026-10-17")
+	require.NoError(t, err)
+	assert.Equal(t, 3, quota)
+	assert.Equal(t, 2, sent, "only committed sends are stored")
+}
+
+func TestSendQuotas_ReleaseGivesBackPlace(t *testing.T) {
+	// Arrange
+	quotas, router, client := setupSendQuotas(t, 1, 0)
+	reservation, ok := quotas.reserve(client)
+	require.True(t, ok)
+	require.Contains(t, router.exhausted, "6281111111111")
+
+	// Act
+	quotas.release(reservation)
+	_, again := quotas.reserve(client)
+
+	// Assert
+	assert.True(t, again)
+}
+
+func TestSendQuotas_ConcurrentSendsStayWithinQuota(t *testing.T) {
+	// Arrange
+	quotas, _, client := setupSendQuotas(t, 50, 0)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved := 0
+
+	// Act
+	for range 200 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, ok := quotas.reserve(client); ok {
+				mu.Lock()
+				reserved++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, 50, reserved)
+}
+
+func TestSendQuotas_NoCap(t *testing.T) {
+	// Arrange
+	quotas, router, client := setupSendQuotas(t, 0, 1000)
+
+	// Act
+	_, ok := quotas.reserve(client)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Empty(t, router.exhausted)
+}
+
+func TestSendQuotas_RaisedQuotaAppliesAfterRefresh(t *testing.T) {
+	// Arrange
+	quotas, router, client := setupSendQuotas(t, 1, 1)
+	_, ok := quotas.reserve(client)
+	require.False(t, ok)
+	_, err := quotas.db.Exec("UPDATE senders SET daily_quota = 5")
+	require.NoError(t, err)
+	now := quotas.now().Add(quotaRefresh)
+	quotas.now = func() time.Time { return now }
+
+	// Act
+	_, ok = quotas.reserve(client)
+
+	// Assert
+	assert.True(t, ok)
+	assert.Empty(t, router.exhausted)
+}
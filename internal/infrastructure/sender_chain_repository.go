@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type senderChainRepository struct {
+	db *sql.DB
+}
+
+// NewSenderChainRepository creates a sender fallback chain repository backed by Postgres
+func NewSenderChainRepository(db *sql.DB) domain.SenderChainRepository {
+	return &senderChainRepository{db: db}
+}
+
+// GetChain returns the senders configured for category, in order
+func (r *senderChainRepository) GetChain(category string) ([]string, error) {
+	return repository.GetSenderFallbackChain(r.db, category)
+}
+
+// ListChains returns every configured chain keyed by category
+func (r *senderChainRepository) ListChains() (map[string][]string, error) {
+	return repository.GetSenderFallbackChains(r.db)
+}
+
+// SetChain replaces the chain for category
+func (r *senderChainRepository) SetChain(category string, senderIDs []string) error {
+	if err := repository.ReplaceSenderFallbackChain(r.db, category, senderIDs); err != nil {
+		if err == repository.ErrUnknownSender {
+			return domain.ErrSenderNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type senderPoolRepository struct {
+	db *sql.DB
+}
+
+// NewSenderPoolRepository creates a named sender pool repository backed by Postgres
+func NewSenderPoolRepository(db *sql.DB) domain.SenderPoolRepository {
+	return &senderPoolRepository{db: db}
+}
+
+// GetPool returns the members of pool name, in order
+func (r *senderPoolRepository) GetPool(name string) ([]string, error) {
+	return repository.GetSenderPool(r.db, name)
+}
+
+// ListPools returns every pool keyed by name
+func (r *senderPoolRepository) ListPools() (map[string][]string, error) {
+	return repository.GetSenderPools(r.db)
+}
+
+// SetPool replaces the members of pool name
+func (r *senderPoolRepository) SetPool(name string, senderIDs []string) error {
+	if err := repository.ReplaceSenderPool(r.db, name, senderIDs); err != nil {
+		if err == repository.ErrUnknownSender {
+			return domain.ErrSenderNotFound
+		}
+		return err
+	}
+	return nil
+}
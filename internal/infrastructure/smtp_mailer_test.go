@@ -0,0 +1,59 @@
+package infrastructure
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/internal/domain"
+)
+
+func TestBuildMail(t *testing.T) {
+	attachment := bytes.Repeat([]byte("report "), 40)
+	raw, err := buildMail("reports@example.com", []string{"owner@example.com", "ops@example.com"}, "Laporan harian – 15/10",
+		"Terlampir laporan.", time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC),
+		[]domain.MailAttachment{{FileName: "laporan.xlsx", MIMEType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Data: attachment}})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "owner@example.com, ops@example.com", msg.Header.Get("To"))
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Laporan harian – 15/10", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/mixed", mediaType)
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	body, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "Terlampir laporan.", decodePart(t, body))
+
+	file, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "laporan.xlsx", file.FileName())
+	assert.Equal(t, string(attachment), decodePart(t, file))
+
+	_, err = reader.NextPart()
+	assert.Equal(t, io.EOF, err)
+
+	for _, line := range bytes.Split(raw, []byte("\r\n")) {
+		assert.LessOrEqual(t, len(line), 998)
+	}
+}
+
+func decodePart(t *testing.T, part *multipart.Part) string {
+	t.Helper()
+	data, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,74 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type suppressionRepository struct {
+	db *sql.DB
+}
+
+// NewSuppressionRepository creates a suppression list repository backed by Postgres
+func NewSuppressionRepository(db *sql.DB) domain.SuppressionRepository {
+	return &suppressionRepository{db: db}
+}
+
+// ListSuppressions returns every suppressed recipient, newest first
+func (r *suppressionRepository) ListSuppressions() ([]*domain.Suppression, error) {
+	suppressions, err := repository.GetSuppressions(r.db)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.Suppression, 0, len(suppressions))
+	for i := range suppressions {
+		result = append(result, toDomainSuppression(&suppressions[i]))
+	}
+	return result, nil
+}
+
+// GetSuppression returns the suppression of recipient
+func (r *suppressionRepository) GetSuppression(recipient string) (*domain.Suppression, error) {
+	s, err := repository.GetSuppression(r.db, recipient)
+	if err != nil {
+		return nil, suppressionError(err)
+	}
+	return toDomainSuppression(s), nil
+}
+
+// CreateSuppression suppresses recipient
+func (r *suppressionRepository) CreateSuppression(recipient, reason, note string) (*domain.Suppression, error) {
+	s, err := repository.CreateSuppression(r.db, recipient, reason, note)
+	if err != nil {
+		return nil, suppressionError(err)
+	}
+	return toDomainSuppression(s), nil
+}
+
+// DeleteSuppression lets recipient be messaged again
+func (r *suppressionRepository) DeleteSuppression(recipient string) error {
+	return suppressionError(repository.DeleteSuppression(r.db, recipient))
+}
+
+func toDomainSuppression(s *repository.Suppression) *domain.Suppression {
+	return &domain.Suppression{
+		Recipient: s.Recipient,
+		Reason:    s.Reason,
+		Note:      s.Note,
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// suppressionError maps repository errors to their domain equivalents
+func suppressionError(err error) error {
+	switch err {
+	case repository.ErrSuppressionNotFound:
+		return domain.ErrSuppressionNotFound
+	case repository.ErrSuppressionExists:
+		return domain.ErrSuppressionExists
+	}
+	return err
+}
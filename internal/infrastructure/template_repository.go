@@ -0,0 +1,66 @@
+package infrastructure
+
+import (
+	"database/sql"
+
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/repository"
+)
+
+type templateRepository struct {
+	db *sql.DB
+}
+
+// NewTemplateRepository creates a message template repository backed by Postgres
+func NewTemplateRepository(db *sql.DB) domain.TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+// ListTemplates returns the global templates ordered by name; Variables is left
+// for the service to fill in
+func (r *templateRepository) ListTemplates() ([]*domain.MessageTemplate, error) {
+	templates, err := repository.GetTemplates(r.db)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*domain.MessageTemplate, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, &domain.MessageTemplate{Name: t.Name, Body: t.Body})
+	}
+	return result, nil
+}
+
+// GetTemplate returns the named template
+func (r *templateRepository) GetTemplate(name string) (*domain.MessageTemplate, error) {
+	t, err := repository.GetTemplate(r.db, name)
+	if err != nil {
+		return nil, templateError(err)
+	}
+	return &domain.MessageTemplate{Name: t.Name, Body: t.Body}, nil
+}
+
+// CreateTemplate adds a template
+func (r *templateRepository) CreateTemplate(name, body string) error {
+	return templateError(repository.CreateTemplate(r.db, name, body))
+}
+
+// UpdateTemplate replaces the body of a template
+func (r *templateRepository) UpdateTemplate(name, body string) error {
+	return templateError(repository.UpdateTemplate(r.db, name, body))
+}
+
+// DeleteTemplate removes a template
+func (r *templateRepository) DeleteTemplate(name string) error {
+	return templateError(repository.DeleteTemplate(r.db, name))
+}
+
+// templateError maps repository errors to their domain equivalents
+func templateError(err error) error {
+	switch err {
+	case repository.ErrTemplateNotFound:
+		return domain.ErrTemplateNotFound
+	case repository.ErrTemplateExists:
+		return domain.ErrTemplateExists
+	}
+	return err
+}
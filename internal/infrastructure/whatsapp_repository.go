@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"sync"
+	"log"
+	"time"
 
+	"github.com/wa-serv/config"
 	"github.com/wa-serv/internal/domain"
 	"github.com/wa-serv/repository"
 	"go.mau.fi/whatsmeow"
@@ -14,120 +16,180 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// ClientManager supplies the WhatsApp clients the repository sends through.
+// whatsapp.ClientManager implements it. Senders are registered, removed,
+// paused and re-elected as default at runtime, so implementations must be
+// safe for concurrent use and the repository asks for a client on every call
+// instead of keeping one.
+type ClientManager interface {
+	GetClient(senderID string) (*whatsmeow.Client, error)
+	GetDefaultClient() (*whatsmeow.Client, error)
+	GetAllClients() map[string]*whatsmeow.Client
+	// NextClient returns the client that sends a message without a named
+	// sender, as spread by the routing strategy
+	NextClient() (*whatsmeow.Client, error)
+	// PickSender returns the healthy member of a sender pool that sends next
+	PickSender(members []string) (string, error)
+	// IsPaused reports whether an operator took senderID out of rotation
+	IsPaused(senderID string) bool
+	// SetQuotaExhausted keeps senderID out of routing until until, when its
+	// daily send quota resets; a zero until puts it back
+	SetQuotaExhausted(senderID string, until time.Time)
+}
+
 type whatsappRepository struct {
-	client        *whatsmeow.Client // Default client for backward compatibility
-	db            *sql.DB
-	clientMap     map[string]*whatsmeow.Client // Map of sender_id -> client
-	mu            sync.RWMutex                 // Protects clientMap
-	clientManager interface {                  // Interface to get clients dynamically
-		GetClient(senderID string) (*whatsmeow.Client, error)
-		GetDefaultClient() (*whatsmeow.Client, error)
-		GetAllClients() map[string]*whatsmeow.Client
-	}
+	db      *sql.DB
+	clients ClientManager
+	quotas  *sendQuotas // nil when daily send quotas are not enforced
 }
 
-// NewWhatsAppRepository creates a new WhatsApp repository
-func NewWhatsAppRepository(client *whatsmeow.Client) domain.WhatsAppRepository {
-	return &whatsappRepository{
-		client:    client,
-		clientMap: make(map[string]*whatsmeow.Client),
+// staticClients is the ClientManager of a repository built from a fixed set of
+// clients. The set never changes, so it needs no lock.
+type staticClients struct {
+	defaultClient *whatsmeow.Client
+	clients       map[string]*whatsmeow.Client // key: sender_id
+}
+
+func newStaticClients(defaultClient *whatsmeow.Client, clients map[string]*whatsmeow.Client) *staticClients {
+	s := &staticClients{
+		defaultClient: defaultClient,
+		clients:       make(map[string]*whatsmeow.Client, len(clients)),
+	}
+	for senderID, client := range clients {
+		s.clients[senderID] = client
 	}
+	return s
 }
 
-// NewWhatsAppRepositoryWithDB creates a new WhatsApp repository with database support
-func NewWhatsAppRepositoryWithDB(client *whatsmeow.Client, db *sql.DB) domain.WhatsAppRepository {
-	return &whatsappRepository{
-		client:    client,
-		db:        db,
-		clientMap: make(map[string]*whatsmeow.Client),
+func (s *staticClients) GetClient(senderID string) (*whatsmeow.Client, error) {
+	client, ok := s.clients[senderID]
+	if !ok || client == nil {
+		return nil, domain.ErrSenderNotFound
 	}
+	return client, nil
 }
 
-// NewWhatsAppRepositoryWithClients creates a new WhatsApp repository with multiple clients
-func NewWhatsAppRepositoryWithClients(defaultClient *whatsmeow.Client, db *sql.DB, clients map[string]*whatsmeow.Client) domain.WhatsAppRepository {
-	repo := &whatsappRepository{
-		client:    defaultClient,
-		db:        db,
-		clientMap: make(map[string]*whatsmeow.Client),
+func (s *staticClients) GetDefaultClient() (*whatsmeow.Client, error) {
+	if s.defaultClient == nil {
+		return nil, fmt.Errorf("no WhatsApp client available")
 	}
+	return s.defaultClient, nil
+}
 
-	// Register all clients
-	for senderID, client := range clients {
-		repo.clientMap[senderID] = client
+// NextClient is always the default client; a fixed set of clients is not
+// routed
+func (s *staticClients) NextClient() (*whatsmeow.Client, error) {
+	return s.GetDefaultClient()
+}
+
+// PickSender is the first connected and logged in member; a fixed set of
+// clients is not routed
+func (s *staticClients) PickSender(members []string) (string, error) {
+	for _, senderID := range members {
+		if client := s.clients[senderID]; client != nil && client.IsConnected() && client.IsLoggedIn() {
+			return senderID, nil
+		}
 	}
+	return "", domain.ErrNoActiveSender
+}
 
-	return repo
+// IsPaused is always false; a fixed set of clients cannot be paused
+func (s *staticClients) IsPaused(senderID string) bool {
+	return false
 }
 
-// NewWhatsAppRepositoryWithClientManager creates a repository that uses ClientManager dynamically
-func NewWhatsAppRepositoryWithClientManager(db *sql.DB, clientManager interface {
-	GetClient(senderID string) (*whatsmeow.Client, error)
-	GetDefaultClient() (*whatsmeow.Client, error)
-	GetAllClients() map[string]*whatsmeow.Client
-}) domain.WhatsAppRepository {
-	// Try to get default client, but don't fail if it's not available yet
-	// The repository will handle nil client gracefully via getClient accessor
-	defaultClient, err := clientManager.GetDefaultClient()
-	if err != nil {
-		// Log but continue - getClient will handle getting a valid client later
-		fmt.Printf("No default client available during repository initialization: %v\n", err)
-		defaultClient = nil
+// SetQuotaExhausted does nothing; a fixed set of clients is not routed
+func (s *staticClients) SetQuotaExhausted(senderID string, until time.Time) {}
+
+// GetAllClients returns a copy of the registered senders, plus the default
+// client under the empty sender ID that selects it when sending
+func (s *staticClients) GetAllClients() map[string]*whatsmeow.Client {
+	clients := make(map[string]*whatsmeow.Client, len(s.clients)+1)
+	for senderID, client := range s.clients {
+		clients[senderID] = client
 	}
-	return &whatsappRepository{
-		client:        defaultClient,
-		db:            db,
-		clientMap:     make(map[string]*whatsmeow.Client),
-		clientManager: clientManager,
+	if s.defaultClient != nil {
+		clients[""] = s.defaultClient
 	}
+	return clients
 }
 
-// RegisterClient registers a client for a specific sender
-func (r *whatsappRepository) RegisterClient(senderID string, client *whatsmeow.Client) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	r.clientMap[senderID] = client
+// NewWhatsAppRepository creates a new WhatsApp repository
+func NewWhatsAppRepository(client *whatsmeow.Client) domain.WhatsAppRepository {
+	return &whatsappRepository{clients: newStaticClients(client, nil)}
 }
 
-// getClient safely retrieves a client, falling back to defaults if necessary
+// NewWhatsAppRepositoryWithDB creates a new WhatsApp repository with database support
+func NewWhatsAppRepositoryWithDB(client *whatsmeow.Client, db *sql.DB) domain.WhatsAppRepository {
+	return &whatsappRepository{db: db, clients: newStaticClients(client, nil)}
+}
+
+// NewWhatsAppRepositoryWithClients creates a new WhatsApp repository with multiple clients
+func NewWhatsAppRepositoryWithClients(defaultClient *whatsmeow.Client, db *sql.DB, clients map[string]*whatsmeow.Client) domain.WhatsAppRepository {
+	return &whatsappRepository{db: db, clients: newStaticClients(defaultClient, clients)}
+}
+
+// NewWhatsAppRepositoryWithClientManager creates a repository that uses ClientManager
+// dynamically. Without a manager it has no clients and reports itself disconnected.
+func NewWhatsAppRepositoryWithClientManager(db *sql.DB, clientManager ClientManager) domain.WhatsAppRepository {
+	if clientManager == nil {
+		clientManager = newStaticClients(nil, nil)
+	}
+	return &whatsappRepository{db: db, clients: clientManager}
+}
+
+// NewWhatsAppRepositoryWithQuota creates a repository that uses ClientManager
+// and refuses sends from a sender that has reached its daily quota, counting
+// the day from midnight in the time zone of quota
+func NewWhatsAppRepositoryWithQuota(db *sql.DB, clientManager ClientManager, quota config.SendQuotaConfig) domain.WhatsAppRepository {
+	repo := NewWhatsAppRepositoryWithClientManager(db, clientManager).(*whatsappRepository)
+	if db != nil {
+		repo.quotas = newSendQuotas(db, quota, repo.clients)
+	}
+	return repo
+}
+
+// getClient returns the client of a specific sender, or the current default
+// client when senderID is empty. A missing sender is never replaced by the
+// default.
 func (r *whatsappRepository) getClient(senderID string) (*whatsmeow.Client, error) {
-	// If a specific sender is requested, try to get that client
 	if senderID != "" {
-		if r.clientManager != nil {
-			client, err := r.clientManager.GetClient(senderID)
-			if err == nil && client != nil {
-				return client, nil
-			}
+		if r.clients.IsPaused(senderID) {
+			return nil, domain.ErrSenderPaused
 		}
-		r.mu.RLock()
-		client, ok := r.clientMap[senderID]
-		r.mu.RUnlock()
-		if ok && client != nil {
-			return client, nil
+		client, err := r.clients.GetClient(senderID)
+		if err != nil || client == nil {
+			return nil, domain.ErrSenderNotFound
 		}
-		// Specific sender was requested but not found - return error instead of falling back
-		return nil, domain.ErrSenderNotFound
+		return client, nil
 	}
 
-	// Fall back to default client from repository (only when senderID == "")
-	if r.client != nil {
-		return r.client, nil
+	client, err := r.clients.GetDefaultClient()
+	if err != nil || client == nil {
+		return nil, fmt.Errorf("no WhatsApp client available")
 	}
+	return client, nil
+}
 
-	// Try to get default client from manager
-	if r.clientManager != nil {
-		client, err := r.clientManager.GetDefaultClient()
-		if err == nil && client != nil {
-			return client, nil
-		}
+// sendClient returns the client a message from senderID goes out through:
+// that sender's client, or the one picked by the routing strategy when
+// senderID is empty
+func (r *whatsappRepository) sendClient(senderID string) (*whatsmeow.Client, error) {
+	if senderID != "" {
+		return r.getClient(senderID)
 	}
 
-	return nil, fmt.Errorf("no WhatsApp client available")
+	client, err := r.clients.NextClient()
+	if err != nil || client == nil {
+		return nil, fmt.Errorf("no WhatsApp client available")
+	}
+	return client, nil
 }
 
-// SendMessage sends a WhatsApp message using the default client
+// SendMessage sends a WhatsApp message through the routed client
 func (r *whatsappRepository) SendMessage(ctx context.Context, to, message string) (*domain.Message, error) {
 	// Get a valid client
-	client, err := r.getClient("")
+	client, err := r.sendClient("")
 	if err != nil {
 		return nil, fmt.Errorf("no client available: %w", err)
 	}
@@ -144,10 +206,11 @@ func (r *whatsappRepository) SendMessage(ctx context.Context, to, message string
 	}
 
 	// Send message
-	resp, err := client.SendMessage(ctx, jid, msg)
+	resp, err := r.sendMessage(ctx, client, jid, msg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
+	r.recordSent(client)
 
 	return &domain.Message{
 		ID:      resp.ID,
@@ -159,8 +222,8 @@ func (r *whatsappRepository) SendMessage(ctx context.Context, to, message string
 
 // SendMessageFrom sends a WhatsApp message from a specific sender
 func (r *whatsappRepository) SendMessageFrom(ctx context.Context, from, to, message string) (*domain.Message, error) {
-	// Use getClient helper to safely retrieve the client with proper nil checks
-	client, err := r.getClient(from)
+	// Use sendClient helper to safely retrieve the client with proper nil checks
+	client, err := r.sendClient(from)
 	if err != nil {
 		return nil, fmt.Errorf("sender not found or not initialized: %s: %w", from, err)
 	}
@@ -182,10 +245,11 @@ func (r *whatsappRepository) SendMessageFrom(ctx context.Context, from, to, mess
 	}
 
 	// Send message
-	resp, err := client.SendMessage(ctx, jid, msg)
+	resp, err := r.sendMessage(ctx, client, jid, msg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
+	r.recordSent(client)
 
 	return &domain.Message{
 		ID:      resp.ID,
@@ -195,47 +259,502 @@ func (r *whatsappRepository) SendMessageFrom(ctx context.Context, from, to, mess
 	}, nil
 }
 
-// IsConnected checks if WhatsApp client is connected
-func (r *whatsappRepository) IsConnected() bool {
-	// If we have a client manager, check if any client is connected
-	if r.clientManager != nil {
-		clients := r.clientManager.GetAllClients()
-		for _, client := range clients {
-			// Guard against nil clients
-			if client != nil && client.IsConnected() {
-				return true
-			}
+// mediaTarget resolves the client for from, which must be connected when named
+// explicitly, and the recipient JID for a media or location message. Without
+// from the routing strategy picks the client.
+func (r *whatsappRepository) mediaTarget(from, to string) (*whatsmeow.Client, types.JID, error) {
+	return r.target(r.sendClient, from, to)
+}
+
+// chatTarget resolves the client and JID like mediaTarget, for a message that
+// acts on an existing conversation such as a reaction, reply, edit or
+// deletion. Without from it is the default client, which the conversation was
+// held with, whatever the routing strategy.
+func (r *whatsappRepository) chatTarget(from, chat string) (*whatsmeow.Client, types.JID, error) {
+	return r.target(r.getClient, from, chat)
+}
+
+func (r *whatsappRepository) target(clientFor func(string) (*whatsmeow.Client, error), from, to string) (*whatsmeow.Client, types.JID, error) {
+	client, err := clientFor(from)
+	if err != nil {
+		return nil, types.JID{}, fmt.Errorf("sender not found or not initialized: %s: %w", from, err)
+	}
+	if from != "" && !client.IsConnected() {
+		return nil, types.JID{}, fmt.Errorf("sender %s is not connected", from)
+	}
+
+	jid, err := types.ParseJID(to)
+	if err != nil {
+		return nil, types.JID{}, fmt.Errorf("failed to parse JID: %w", err)
+	}
+	return client, jid, nil
+}
+
+// SendImage uploads an image to WhatsApp's media servers and sends it, from a
+// specific sender or the default client when from is empty
+func (r *whatsappRepository) SendImage(ctx context.Context, from, to string, image []byte, mimeType, caption string) (*domain.Message, error) {
+	client, jid, err := r.mediaTarget(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded, err := client.Upload(ctx, image, whatsmeow.MediaImage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload image: %w", err)
+	}
+
+	imageMsg := &waProto.ImageMessage{
+		URL:           proto.String(uploaded.URL),
+		DirectPath:    proto.String(uploaded.DirectPath),
+		MediaKey:      uploaded.MediaKey,
+		FileEncSHA256: uploaded.FileEncSHA256,
+		FileSHA256:    uploaded.FileSHA256,
+		FileLength:    proto.Uint64(uploaded.FileLength),
+		Mimetype:      proto.String(mimeType),
+	}
+	if caption != "" {
+		imageMsg.Caption = proto.String(caption)
+	}
+
+	resp, err := r.sendMessage(ctx, client, jid, &waProto.Message{ImageMessage: imageMsg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send image: %w", err)
+	}
+	r.recordSent(client)
+
+	return &domain.Message{
+		ID:      resp.ID,
+		To:      to,
+		Content: caption,
+		SentAt:  resp.Timestamp.String(),
+	}, nil
+}
+
+// SendDocument uploads a file to WhatsApp's media servers and sends it as a
+// document, from a specific sender or the default client when from is empty
+func (r *whatsappRepository) SendDocument(ctx context.Context, from, to string, document []byte, fileName, mimeType, caption string) (*domain.Message, error) {
+	client, jid, err := r.mediaTarget(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded, err := client.Upload(ctx, document, whatsmeow.MediaDocument)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload document: %w", err)
+	}
+
+	documentMsg := &waProto.DocumentMessage{
+		URL:           proto.String(uploaded.URL),
+		DirectPath:    proto.String(uploaded.DirectPath),
+		MediaKey:      uploaded.MediaKey,
+		FileEncSHA256: uploaded.FileEncSHA256,
+		FileSHA256:    uploaded.FileSHA256,
+		FileLength:    proto.Uint64(uploaded.FileLength),
+		Mimetype:      proto.String(mimeType),
+		FileName:      proto.String(fileName),
+		Title:         proto.String(fileName),
+	}
+	if caption != "" {
+		documentMsg.Caption = proto.String(caption)
+	}
+
+	resp, err := r.sendMessage(ctx, client, jid, &waProto.Message{DocumentMessage: documentMsg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send document: %w", err)
+	}
+	r.recordSent(client)
+
+	return &domain.Message{
+		ID:      resp.ID,
+		To:      to,
+		Content: caption,
+		SentAt:  resp.Timestamp.String(),
+	}, nil
+}
+
+// SendAudio uploads an audio file and sends it as a voice note when ptt is set,
+// or as a regular audio message, from a specific sender or the default client
+func (r *whatsappRepository) SendAudio(ctx context.Context, from, to string, audio []byte, mimeType string, ptt bool) (*domain.Message, error) {
+	client, jid, err := r.mediaTarget(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded, err := client.Upload(ctx, audio, whatsmeow.MediaAudio)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload audio: %w", err)
+	}
+
+	audioMsg := &waProto.AudioMessage{
+		URL:           proto.String(uploaded.URL),
+		DirectPath:    proto.String(uploaded.DirectPath),
+		MediaKey:      uploaded.MediaKey,
+		FileEncSHA256: uploaded.FileEncSHA256,
+		FileSHA256:    uploaded.FileSHA256,
+		FileLength:    proto.Uint64(uploaded.FileLength),
+		Mimetype:      proto.String(mimeType),
+		PTT:           proto.Bool(ptt),
+	}
+
+	resp, err := r.sendMessage(ctx, client, jid, &waProto.Message{AudioMessage: audioMsg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send audio: %w", err)
+	}
+	r.recordSent(client)
+
+	return &domain.Message{
+		ID:     resp.ID,
+		To:     to,
+		SentAt: resp.Timestamp.String(),
+	}, nil
+}
+
+// SendLocation sends a location pin with an optional name and address, from a
+// specific sender or the default client when from is empty
+func (r *whatsappRepository) SendLocation(ctx context.Context, from, to string, latitude, longitude float64, name, address string) (*domain.Message, error) {
+	client, jid, err := r.mediaTarget(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	locationMsg := &waProto.LocationMessage{
+		DegreesLatitude:  proto.Float64(latitude),
+		DegreesLongitude: proto.Float64(longitude),
+	}
+	if name != "" {
+		locationMsg.Name = proto.String(name)
+	}
+	if address != "" {
+		locationMsg.Address = proto.String(address)
+	}
+
+	resp, err := r.sendMessage(ctx, client, jid, &waProto.Message{LocationMessage: locationMsg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send location: %w", err)
+	}
+	r.recordSent(client)
+
+	return &domain.Message{
+		ID:      resp.ID,
+		To:      to,
+		Content: name,
+		SentAt:  resp.Timestamp.String(),
+	}, nil
+}
+
+// SendContacts sends one contact card, or several as a single contacts array
+// message, from a specific sender or the default client when from is empty
+func (r *whatsappRepository) SendContacts(ctx context.Context, from, to string, contacts []domain.ContactCard) (*domain.Message, error) {
+	if len(contacts) == 0 {
+		return nil, fmt.Errorf("no contacts to send")
+	}
+	client, jid, err := r.mediaTarget(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	cards := make([]*waProto.ContactMessage, len(contacts))
+	for i, contact := range contacts {
+		cards[i] = &waProto.ContactMessage{
+			DisplayName: proto.String(contact.Name),
+			Vcard:       proto.String(contact.VCard),
 		}
-		return false
 	}
 
-	// Fallback to default client check
-	if r.client != nil {
-		return r.client.IsConnected()
+	msg := &waProto.Message{ContactMessage: cards[0]}
+	if len(cards) > 1 {
+		msg = &waProto.Message{ContactsArrayMessage: &waProto.ContactsArrayMessage{
+			DisplayName: proto.String(fmt.Sprintf("%d kontak", len(cards))),
+			Contacts:    cards,
+		}}
 	}
 
+	resp, err := r.sendMessage(ctx, client, jid, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send contacts: %w", err)
+	}
+	r.recordSent(client)
+
+	return &domain.Message{
+		ID:      resp.ID,
+		To:      to,
+		Content: contacts[0].Name,
+		SentAt:  resp.Timestamp.String(),
+	}, nil
+}
+
+// SendInteractive sends body with reply buttons or a list menu, from a specific
+// sender or the default client when from is empty
+func (r *whatsappRepository) SendInteractive(ctx context.Context, from, to, body string, interactive *domain.InteractiveMessage) (*domain.Message, error) {
+	if interactive == nil {
+		return nil, fmt.Errorf("no buttons or list to send")
+	}
+	client, jid, err := r.mediaTarget(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.sendMessage(ctx, client, jid, interactiveMessage(body, interactive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send interactive message: %w", err)
+	}
+	r.recordSent(client)
+
+	return &domain.Message{
+		ID:      resp.ID,
+		To:      to,
+		Content: body,
+		SentAt:  resp.Timestamp.String(),
+	}, nil
+}
+
+// SendReaction reacts to a message, from a specific sender or the default
+// client when from is empty. An empty emoji removes an earlier reaction.
+func (r *whatsappRepository) SendReaction(ctx context.Context, from, chat, sender, messageID, emoji string) (*domain.Message, error) {
+	client, chatJID, err := r.chatTarget(from, chat)
+	if err != nil {
+		return nil, err
+	}
+	senderJID, err := types.ParseJID(sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sender JID: %w", err)
+	}
+
+	resp, err := client.SendMessage(ctx, chatJID, client.BuildReaction(chatJID, senderJID, messageID, emoji))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send reaction: %w", err)
+	}
+	r.recordSent(client)
+
+	return &domain.Message{
+		ID:      resp.ID,
+		To:      chat,
+		Content: emoji,
+		SentAt:  resp.Timestamp.String(),
+	}, nil
+}
+
+// RevokeMessage deletes a message the sender sent in chat for everyone, from
+// a specific sender or the default client when from is empty
+func (r *whatsappRepository) RevokeMessage(ctx context.Context, from, chat, messageID string) (*domain.Message, error) {
+	client, chatJID, err := r.chatTarget(from, chat)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.SendMessage(ctx, chatJID, client.BuildRevoke(chatJID, types.EmptyJID, messageID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke message: %w", err)
+	}
+
+	return &domain.Message{
+		ID:     resp.ID,
+		To:     chat,
+		SentAt: resp.Timestamp.String(),
+	}, nil
+}
+
+// EditMessage replaces the text of a message the sender sent in chat, from
+// sender from or the default sender
+func (r *whatsappRepository) EditMessage(ctx context.Context, from, chat, messageID, text string) (*domain.Message, error) {
+	client, chatJID, err := r.chatTarget(from, chat)
+	if err != nil {
+		return nil, err
+	}
+
+	edit := client.BuildEdit(chatJID, messageID, &waProto.Message{Conversation: proto.String(text)})
+	resp, err := client.SendMessage(ctx, chatJID, edit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit message: %w", err)
+	}
+
+	return &domain.Message{
+		ID:      messageID,
+		To:      chat,
+		Content: text,
+		SentAt:  resp.Timestamp.String(),
+	}, nil
+}
+
+// SendReply sends message as a reply quoting message replyTo, which sender sent
+// in the chat with to
+func (r *whatsappRepository) SendReply(ctx context.Context, from, to, message, sender, replyTo string) (*domain.Message, error) {
+	client, jid, err := r.chatTarget(from, to)
+	if err != nil {
+		return nil, err
+	}
+	senderJID, err := types.ParseJID(sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sender JID: %w", err)
+	}
+
+	// WhatsApp clients render the quote from their own copy of replyTo, so
+	// the quoted message only needs to identify it
+	msg := &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text: proto.String(message),
+			ContextInfo: &waProto.ContextInfo{
+				StanzaID:      proto.String(replyTo),
+				Participant:   proto.String(senderJID.ToNonAD().String()),
+				QuotedMessage: &waProto.Message{Conversation: proto.String("")},
+			},
+		},
+	}
+
+	resp, err := r.sendMessage(ctx, client, jid, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send reply: %w", err)
+	}
+	r.recordSent(client)
+
+	return &domain.Message{
+		ID:      resp.ID,
+		To:      to,
+		Content: message,
+		SentAt:  resp.Timestamp.String(),
+	}, nil
+}
+
+// SendChatPresence shows typing, recording or paused in a chat, from a specific
+// sender or the default client when from is empty
+func (r *whatsappRepository) SendChatPresence(ctx context.Context, from, chat, state string) error {
+	client, chatJID, err := r.chatTarget(from, chat)
+	if err != nil {
+		return err
+	}
+
+	presence, media := types.ChatPresenceComposing, types.ChatPresenceMediaText
+	switch state {
+	case domain.ChatPresenceRecording:
+		media = types.ChatPresenceMediaAudio
+	case domain.ChatPresencePaused:
+		presence = types.ChatPresencePaused
+	}
+	if err := client.SendChatPresence(ctx, chatJID, presence, media); err != nil {
+		return fmt.Errorf("failed to send chat presence: %w", err)
+	}
+	return nil
+}
+
+// SetPresence shows a specific sender, or the default client when from is
+// empty, as available or unavailable
+func (r *whatsappRepository) SetPresence(ctx context.Context, from, presence string) error {
+	client, err := r.getClient(from)
+	if err != nil {
+		return fmt.Errorf("sender not found or not initialized: %s: %w", from, err)
+	}
+	if from != "" && !client.IsConnected() {
+		return fmt.Errorf("sender %s is not connected", from)
+	}
+
+	state := types.PresenceAvailable
+	if presence == domain.PresenceUnavailable {
+		state = types.PresenceUnavailable
+	}
+	if err := client.SendPresence(ctx, state); err != nil {
+		return fmt.Errorf("failed to set presence: %w", err)
+	}
+	return nil
+}
+
+// interactiveMessage builds a buttons message, or a single-select list message
+// when a list menu is given
+func interactiveMessage(body string, interactive *domain.InteractiveMessage) *waProto.Message {
+	var footer *string
+	if interactive.Footer != "" {
+		footer = proto.String(interactive.Footer)
+	}
+
+	if interactive.List != nil {
+		sections := make([]*waProto.ListMessage_Section, len(interactive.List.Sections))
+		for i, section := range interactive.List.Sections {
+			rows := make([]*waProto.ListMessage_Row, len(section.Rows))
+			for j, row := range section.Rows {
+				rows[j] = &waProto.ListMessage_Row{
+					RowID: proto.String(row.ID),
+					Title: proto.String(row.Title),
+				}
+				if row.Description != "" {
+					rows[j].Description = proto.String(row.Description)
+				}
+			}
+			sections[i] = &waProto.ListMessage_Section{Rows: rows}
+			if section.Title != "" {
+				sections[i].Title = proto.String(section.Title)
+			}
+		}
+		return &waProto.Message{ListMessage: &waProto.ListMessage{
+			Description: proto.String(body),
+			ButtonText:  proto.String(interactive.List.Button),
+			ListType:    waProto.ListMessage_SINGLE_SELECT.Enum(),
+			Sections:    sections,
+			FooterText:  footer,
+		}}
+	}
+
+	buttons := make([]*waProto.ButtonsMessage_Button, len(interactive.Buttons))
+	for i, button := range interactive.Buttons {
+		buttons[i] = &waProto.ButtonsMessage_Button{
+			ButtonID:   proto.String(button.ID),
+			ButtonText: &waProto.ButtonsMessage_Button_ButtonText{DisplayText: proto.String(button.Title)},
+			Type:       waProto.ButtonsMessage_Button_RESPONSE.Enum(),
+		}
+	}
+	return &waProto.Message{ButtonsMessage: &waProto.ButtonsMessage{
+		ContentText: proto.String(body),
+		FooterText:  footer,
+		Buttons:     buttons,
+		HeaderType:  waProto.ButtonsMessage_EMPTY.Enum(),
+	}}
+}
+
+// IsConnected reports whether any client is connected
+func (r *whatsappRepository) IsConnected() bool {
+	for _, client := range r.clients.GetAllClients() {
+		// Guard against nil clients
+		if client != nil && client.IsConnected() {
+			return true
+		}
+	}
 	return false
 }
 
+// IsSenderConnected reports whether senderID, or the default sender when
+// empty, is connected and logged in
+func (r *whatsappRepository) IsSenderConnected(senderID string) bool {
+	client, err := r.getClient(senderID)
+	if err != nil {
+		return false
+	}
+	return client.IsConnected() && client.IsLoggedIn()
+}
+
+// PickSender returns the member of a sender pool that sends next, or
+// ErrNoActiveSender when none of them can send
+func (r *whatsappRepository) PickSender(members []string) (string, error) {
+	senderID, err := r.clients.PickSender(members)
+	if err != nil {
+		return "", domain.ErrNoActiveSender
+	}
+	return senderID, nil
+}
+
 // IsLoggedIn checks if WhatsApp client is logged in
 func (r *whatsappRepository) IsLoggedIn() bool {
 	client, err := r.getClient("")
-	if err != nil || client == nil {
+	if err != nil {
 		return false
 	}
 	return client.IsLoggedIn()
 }
 
-// GetJID gets the WhatsApp JID
+// GetJID gets the WhatsApp JID, or "" when there is no default client yet
 func (r *whatsappRepository) GetJID() string {
 	client, err := r.getClient("")
-	if err != nil || client == nil {
+	if err != nil {
 		return ""
 	}
-	if client.Store.ID != nil {
-		return client.Store.ID.String()
-	}
-	return ""
+	return clientJID(client)
 }
 
 // GetSenderJID gets the WhatsApp JID for a specific sender
@@ -244,10 +763,31 @@ func (r *whatsappRepository) GetSenderJID(senderID string) (string, error) {
 	if err != nil {
 		return "", domain.ErrSenderNotFound
 	}
-	if client.Store.ID != nil {
-		return client.Store.ID.String(), nil
+	return clientJID(client), nil
+}
+
+// clientJID is the JID client is logged in as, or "" before it has paired
+func clientJID(client *whatsmeow.Client) string {
+	if client == nil || client.Store == nil || client.Store.ID == nil {
+		return ""
+	}
+	return client.Store.ID.String()
+}
+
+// recordSent counts a sent message toward the hourly activity of the sender
+// client is logged in as, and stamps it as the sender's last send. A failed
+// count is logged; the message was sent.
+func (r *whatsappRepository) recordSent(client *whatsmeow.Client) {
+	if r.db == nil || client == nil || client.Store == nil || client.Store.ID == nil {
+		return
+	}
+	senderID := client.Store.ID.User
+	if err := repository.RecordSenderActivity(r.db, senderID, 1, 0); err != nil {
+		log.Printf("Failed to record activity of sender %s: %v", senderID, err)
+	}
+	if err := repository.SetSenderLastSent(r.db, senderID); err != nil {
+		log.Printf("Failed to record last send of sender %s: %v", senderID, err)
 	}
-	return "", nil
 }
 
 // ListSenders returns all active senders
@@ -271,16 +811,23 @@ func (r *whatsappRepository) ListSenders() ([]*domain.Sender, error) {
 		return nil, fmt.Errorf("failed to get senders: %w", err)
 	}
 
-	// Convert repository.Sender to domain.Sender
+	// Convert repository.Sender to domain.Sender. Banned and restricted senders
+	// are listed too so operators can see why they stopped sending.
 	domainSenders := make([]*domain.Sender, 0, len(senders))
 	for _, s := range senders {
-		if s.IsActive {
+		if s.IsActive || s.State != repository.SenderStateActive {
 			domainSenders = append(domainSenders, &domain.Sender{
 				ID:          s.SenderID,
 				PhoneNumber: s.PhoneNumber,
 				Name:        s.Name,
+				Description: s.Description,
+				Tags:        s.Tags,
 				IsDefault:   s.IsDefault,
 				IsActive:    s.IsActive,
+				IsPaused:    s.IsPaused,
+				State:       s.State,
+				StateReason: s.StateReason,
+				DailyQuota:  s.DailyQuota,
 			})
 		}
 	}
@@ -314,6 +861,8 @@ func (r *whatsappRepository) GetDefaultSender() (*domain.Sender, error) {
 				ID:          s.SenderID,
 				PhoneNumber: s.PhoneNumber,
 				Name:        s.Name,
+				Description: s.Description,
+				Tags:        s.Tags,
 				IsDefault:   s.IsDefault,
 				IsActive:    s.IsActive,
 			}, nil
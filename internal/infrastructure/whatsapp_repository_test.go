@@ -2,9 +2,11 @@ package infrastructure_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/wa-serv/internal/domain"
 	"github.com/wa-serv/internal/infrastructure"
@@ -15,13 +17,28 @@ import (
 
 // mockClientManager implements the client manager interface for testing
 type mockClientManager struct {
+	mu            sync.RWMutex // guards clients and defaultClient for setDefault
 	clients       map[string]*whatsmeow.Client
 	defaultClient *whatsmeow.Client
 	getClientErr  error
 	getDefaultErr error
+	paused        map[string]bool
+	routedErr     error // returned by NextClient instead of the default client
+	routedCalls   int
+}
+
+// setDefault elects a new default sender the way the real client manager does
+// when the default logs out
+func (m *mockClientManager) setDefault(senderID string, client *whatsmeow.Client) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clients[senderID] = client
+	m.defaultClient = client
 }
 
 func (m *mockClientManager) GetClient(senderID string) (*whatsmeow.Client, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	if m.getClientErr != nil {
 		return nil, m.getClientErr
 	}
@@ -32,6 +49,8 @@ func (m *mockClientManager) GetClient(senderID string) (*whatsmeow.Client, error
 }
 
 func (m *mockClientManager) GetDefaultClient() (*whatsmeow.Client, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	if m.getDefaultErr != nil {
 		return nil, m.getDefaultErr
 	}
@@ -39,9 +58,45 @@ func (m *mockClientManager) GetDefaultClient() (*whatsmeow.Client, error) {
 }
 
 func (m *mockClientManager) GetAllClients() map[string]*whatsmeow.Client {
-	return m.clients
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	clients := make(map[string]*whatsmeow.Client, len(m.clients))
+	for senderID, client := range m.clients {
+		clients[senderID] = client
+	}
+	return clients
+}
+
+func (m *mockClientManager) NextClient() (*whatsmeow.Client, error) {
+	m.mu.Lock()
+	m.routedCalls++
+	routedErr := m.routedErr
+	m.mu.Unlock()
+	if routedErr != nil {
+		return nil, routedErr
+	}
+	return m.GetDefaultClient()
+}
+
+func (m *mockClientManager) PickSender(members []string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, senderID := range members {
+		if m.clients[senderID] != nil && !m.paused[senderID] {
+			return senderID, nil
+		}
+	}
+	return "", domain.ErrNoActiveSender
+}
+
+func (m *mockClientManager) IsPaused(senderID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.paused[senderID]
 }
 
+func (m *mockClientManager) SetQuotaExhausted(senderID string, until time.Time) {}
+
 // createMockClient creates a mock whatsmeow client with basic setup
 func createMockClient(jidUser string, connected bool) *whatsmeow.Client {
 	jid := types.JID{
@@ -317,6 +372,42 @@ func TestSendMessageFrom_ClientNotFound(t *testing.T) {
 	}
 }
 
+func TestSendMessageFrom_PausedSender(t *testing.T) {
+	mockManager := &mockClientManager{
+		clients: map[string]*whatsmeow.Client{"sender1": createMockClient("2222222222", true)},
+		paused:  map[string]bool{"sender1": true},
+	}
+	repo := infrastructure.NewWhatsAppRepositoryWithClientManager(nil, mockManager)
+
+	_, err := repo.SendMessageFrom(context.Background(), "sender1", "1234567890@s.whatsapp.net", "test")
+	if !errors.Is(err, domain.ErrSenderPaused) {
+		t.Errorf("Expected ErrSenderPaused, got %v", err)
+	}
+	if repo.IsSenderConnected("sender1") {
+		t.Error("Expected a paused sender to be out of rotation")
+	}
+}
+
+func TestSendMessage_UsesRoutedClient(t *testing.T) {
+	mockManager := &mockClientManager{
+		clients:       map[string]*whatsmeow.Client{},
+		getDefaultErr: domain.ErrNoActiveSender,
+		routedErr:     domain.ErrNoActiveSender,
+	}
+	repo := infrastructure.NewWhatsAppRepositoryWithClientManager(nil, mockManager)
+
+	_, errText := repo.SendMessage(context.Background(), "1234567890@s.whatsapp.net", "test")
+	_, errImage := repo.SendImage(context.Background(), "", "1234567890@s.whatsapp.net", []byte("img"), "image/jpeg", "")
+	_, errRevoke := repo.RevokeMessage(context.Background(), "", "1234567890@s.whatsapp.net", "3EB0C767D71A")
+
+	if errText == nil || errImage == nil || errRevoke == nil {
+		t.Fatal("Expected every send to fail without a client")
+	}
+	if mockManager.routedCalls != 2 {
+		t.Errorf("Expected new messages to be routed and a deletion to use the default sender, got %d routed calls", mockManager.routedCalls)
+	}
+}
+
 func TestGetClient_FromClientManager(t *testing.T) {
 	client := createMockClient("1234567890", true)
 	mockManager := &mockClientManager{
@@ -770,6 +861,34 @@ func TestConcurrentMultipleSenders(t *testing.T) {
 	})
 }
 
+// TestClientManagerDefaultChange checks that a default sender elected after
+// the repository was created is used, including while sends are in flight
+func TestClientManagerDefaultChange(t *testing.T) {
+	mockManager := &mockClientManager{
+		clients:       map[string]*whatsmeow.Client{},
+		defaultClient: createMockClient("6281111111111", true),
+	}
+	repo := infrastructure.NewWhatsAppRepositoryWithClientManager(nil, mockManager)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_ = repo.GetJID()
+				_ = repo.IsConnected()
+			}
+		}()
+	}
+	mockManager.setDefault("sender2", createMockClient("6282222222222", true))
+	wg.Wait()
+
+	if jid := repo.GetJID(); !contains(jid, "6282222222222") {
+		t.Errorf("Expected the newly elected default sender, got %s", jid)
+	}
+}
+
 // TestSenderSelection tests that the correct sender is selected for operations
 func TestSenderSelection(t *testing.T) {
 	// Create three distinct clients
@@ -823,3 +942,98 @@ func containsAt(s, substr string, start int) bool {
 	}
 	return false
 }
+
+func TestStatusMethods_NilClientManager(t *testing.T) {
+	repo := infrastructure.NewWhatsAppRepositoryWithClientManager(nil, nil)
+
+	if repo.IsConnected() {
+		t.Error("Expected not connected without a client manager")
+	}
+	if repo.IsLoggedIn() {
+		t.Error("Expected not logged in without a client manager")
+	}
+	if jid := repo.GetJID(); jid != "" {
+		t.Errorf("Expected empty JID without a client manager, got %s", jid)
+	}
+	if _, err := repo.GetSenderJID("sender1"); err != domain.ErrSenderNotFound {
+		t.Errorf("Expected ErrSenderNotFound, got %v", err)
+	}
+}
+
+func TestStatusMethods_NilDefaultClient(t *testing.T) {
+	// A fresh install has no paired sender, so the manager has no default client
+	mockManager := &mockClientManager{
+		clients: map[string]*whatsmeow.Client{"": nil},
+	}
+	repo := infrastructure.NewWhatsAppRepositoryWithClientManager(nil, mockManager)
+
+	if repo.IsConnected() {
+		t.Error("Expected not connected with a nil default client")
+	}
+	if repo.IsLoggedIn() {
+		t.Error("Expected not logged in with a nil default client")
+	}
+	if jid := repo.GetJID(); jid != "" {
+		t.Errorf("Expected empty JID with a nil default client, got %s", jid)
+	}
+}
+
+func TestStatusMethods_ClientManagerError(t *testing.T) {
+	mockManager := &mockClientManager{
+		clients:       map[string]*whatsmeow.Client{"sender1": createMockClient("1234567890", true)},
+		getClientErr:  fmt.Errorf("manager unavailable"),
+		getDefaultErr: fmt.Errorf("manager unavailable"),
+	}
+	repo := infrastructure.NewWhatsAppRepositoryWithClientManager(nil, mockManager)
+
+	if repo.IsLoggedIn() {
+		t.Error("Expected not logged in when the manager fails")
+	}
+	if jid := repo.GetJID(); jid != "" {
+		t.Errorf("Expected empty JID when the manager fails, got %s", jid)
+	}
+	if _, err := repo.GetSenderJID("sender1"); err != domain.ErrSenderNotFound {
+		t.Errorf("Expected ErrSenderNotFound when the manager fails, got %v", err)
+	}
+}
+
+func TestGetJID_NilStore(t *testing.T) {
+	// A client whose device store has not been loaded yet
+	repo := infrastructure.NewWhatsAppRepository(&whatsmeow.Client{})
+
+	if jid := repo.GetJID(); jid != "" {
+		t.Errorf("Expected empty JID for a client without a store, got %s", jid)
+	}
+	jid, err := repo.GetSenderJID("")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if jid != "" {
+		t.Errorf("Expected empty sender JID for a client without a store, got %s", jid)
+	}
+}
+
+func TestGroupRepository_NilClientManager(t *testing.T) {
+	repo := infrastructure.NewGroupRepositoryWithClientManager(nil)
+
+	if _, err := repo.ListGroups(context.Background(), ""); err == nil {
+		t.Error("Expected an error listing groups without a client manager")
+	}
+}
+
+func TestIsSenderConnected(t *testing.T) {
+	mockManager := &mockClientManager{
+		clients: map[string]*whatsmeow.Client{"sender1": createMockClient("1234567890", false)},
+	}
+	repo := infrastructure.NewWhatsAppRepositoryWithClientManager(nil, mockManager)
+
+	if repo.IsSenderConnected("sender1") {
+		t.Error("Expected a client without a socket to be reported offline")
+	}
+	if repo.IsSenderConnected("unknown") {
+		t.Error("Expected an unknown sender to be reported offline")
+	}
+	if repo.IsSenderConnected("") {
+		t.Error("Expected no default client to be reported offline")
+	}
+}
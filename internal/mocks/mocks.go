@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/wa-serv/internal/domain"
@@ -28,11 +29,116 @@ func (m *MockWhatsAppRepository) SendMessageFrom(ctx context.Context, from, to,
 	return args.Get(0).(*domain.Message), args.Error(1)
 }
 
+func (m *MockWhatsAppRepository) SendImage(ctx context.Context, from, to string, image []byte, mimeType, caption string) (*domain.Message, error) {
+	args := m.Called(ctx, from, to, image, mimeType, caption)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) SendDocument(ctx context.Context, from, to string, document []byte, fileName, mimeType, caption string) (*domain.Message, error) {
+	args := m.Called(ctx, from, to, document, fileName, mimeType, caption)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) SendAudio(ctx context.Context, from, to string, audio []byte, mimeType string, ptt bool) (*domain.Message, error) {
+	args := m.Called(ctx, from, to, audio, mimeType, ptt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) SendLocation(ctx context.Context, from, to string, latitude, longitude float64, name, address string) (*domain.Message, error) {
+	args := m.Called(ctx, from, to, latitude, longitude, name, address)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) SendContacts(ctx context.Context, from, to string, contacts []domain.ContactCard) (*domain.Message, error) {
+	args := m.Called(ctx, from, to, contacts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) SendInteractive(ctx context.Context, from, to, body string, interactive *domain.InteractiveMessage) (*domain.Message, error) {
+	args := m.Called(ctx, from, to, body, interactive)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) SendReaction(ctx context.Context, from, chat, sender, messageID, emoji string) (*domain.Message, error) {
+	args := m.Called(ctx, from, chat, sender, messageID, emoji)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) RevokeMessage(ctx context.Context, from, chat, messageID string) (*domain.Message, error) {
+	args := m.Called(ctx, from, chat, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) EditMessage(ctx context.Context, from, chat, messageID, text string) (*domain.Message, error) {
+	args := m.Called(ctx, from, chat, messageID, text)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) SendReply(ctx context.Context, from, to, message, sender, replyTo string) (*domain.Message, error) {
+	args := m.Called(ctx, from, to, message, sender, replyTo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockWhatsAppRepository) SendChatPresence(ctx context.Context, from, chat, state string) error {
+	args := m.Called(ctx, from, chat, state)
+	return args.Error(0)
+}
+
+func (m *MockWhatsAppRepository) SetPresence(ctx context.Context, from, presence string) error {
+	args := m.Called(ctx, from, presence)
+	return args.Error(0)
+}
+
+func (m *MockWhatsAppRepository) SetDisappearingTimer(ctx context.Context, from, chat string, timer time.Duration) error {
+	args := m.Called(ctx, from, chat, timer)
+	return args.Error(0)
+}
+
 func (m *MockWhatsAppRepository) IsConnected() bool {
 	args := m.Called()
 	return args.Bool(0)
 }
 
+func (m *MockWhatsAppRepository) IsSenderConnected(senderID string) bool {
+	args := m.Called(senderID)
+	return args.Bool(0)
+}
+
+func (m *MockWhatsAppRepository) PickSender(senderIDs []string) (string, error) {
+	args := m.Called(senderIDs)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockWhatsAppRepository) IsLoggedIn() bool {
 	args := m.Called()
 	return args.Bool(0)
@@ -77,6 +183,94 @@ func (m *MockMessageService) SendMessage(ctx context.Context, req *domain.SendMe
 	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
 }
 
+func (m *MockMessageService) SendImage(ctx context.Context, req *domain.SendImageRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) SendDocument(ctx context.Context, req *domain.SendDocumentRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) SendAudio(ctx context.Context, req *domain.SendAudioRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) SendLocation(ctx context.Context, req *domain.SendLocationRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) SendContact(ctx context.Context, req *domain.SendContactRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) SendReaction(ctx context.Context, req *domain.SendReactionRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) RevokeMessage(ctx context.Context, req *domain.RevokeMessageRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) EditMessage(ctx context.Context, req *domain.EditMessageRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) SendChatPresence(ctx context.Context, req *domain.SendChatPresenceRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) SetPresence(ctx context.Context, req *domain.SetPresenceRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+func (m *MockMessageService) SetDisappearing(ctx context.Context, req *domain.SetDisappearingRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
 func (m *MockMessageService) GetStatus(ctx context.Context) (*domain.ServiceStatus, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -103,6 +297,11 @@ func (m *MockAuthService) ValidateCredentials(username, password string) bool {
 	return args.Bool(0)
 }
 
+func (m *MockAuthService) ValidateAPIKey(key string) (string, bool) {
+	args := m.Called(key)
+	return args.String(0), args.Bool(1)
+}
+
 // MockAIClient is a mock implementation of domain.AIClient
 type MockAIClient struct {
 	mock.Mock
@@ -128,3 +327,1664 @@ func (m *MockAIService) GenerateReply(ctx context.Context, req *domain.AIReplyRe
 	}
 	return args.Get(0).(*domain.AIReplyResponse), args.Error(1)
 }
+
+// MockTenantService is a mock implementation of domain.TenantService
+type MockTenantService struct {
+	mock.Mock
+}
+
+func (m *MockTenantService) CreateTenant(ctx context.Context, req *domain.CreateTenantRequest) (*domain.CreateTenantResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CreateTenantResponse), args.Error(1)
+}
+
+func (m *MockTenantService) GetOnboardingNudge(ctx context.Context, slug string) (*domain.OnboardingNudgeSettings, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OnboardingNudgeSettings), args.Error(1)
+}
+
+func (m *MockTenantService) SetOnboardingNudge(ctx context.Context, slug string, req *domain.OnboardingNudgeSettings) (*domain.OnboardingNudgeSettings, error) {
+	args := m.Called(ctx, slug, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OnboardingNudgeSettings), args.Error(1)
+}
+
+func (m *MockTenantService) GetLoyaltyProgram(ctx context.Context, slug string) (*domain.LoyaltyProgramSettings, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LoyaltyProgramSettings), args.Error(1)
+}
+
+func (m *MockTenantService) SetLoyaltyProgram(ctx context.Context, slug string, req *domain.LoyaltyProgramSettings) (*domain.LoyaltyProgramSettings, error) {
+	args := m.Called(ctx, slug, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LoyaltyProgramSettings), args.Error(1)
+}
+
+func (m *MockTenantService) GetMessageLog(ctx context.Context, slug string) (*domain.MessageLogSettings, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageLogSettings), args.Error(1)
+}
+
+func (m *MockTenantService) SetMessageLog(ctx context.Context, slug string, req *domain.MessageLogSettings) (*domain.MessageLogSettings, error) {
+	args := m.Called(ctx, slug, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageLogSettings), args.Error(1)
+}
+
+// MockMemberService is a mock implementation of domain.MemberService
+type MockMemberService struct {
+	mock.Mock
+}
+
+func (m *MockMemberService) EraseMember(ctx context.Context, phoneNumber string) error {
+	args := m.Called(ctx, phoneNumber)
+	return args.Error(0)
+}
+
+// MockLocationService is a mock implementation of domain.LocationService
+type MockLocationService struct {
+	mock.Mock
+}
+
+func (m *MockLocationService) GetMemberLocation(ctx context.Context, phoneNumber string) (*domain.MemberLocation, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MemberLocation), args.Error(1)
+}
+
+// MockDriverService is a mock implementation of domain.DriverService
+type MockDriverService struct {
+	mock.Mock
+}
+
+func (m *MockDriverService) CreateDriver(ctx context.Context, req *domain.CreateDriverRequest) (*domain.Driver, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Driver), args.Error(1)
+}
+
+func (m *MockDriverService) ListDrivers(ctx context.Context) ([]*domain.Driver, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Driver), args.Error(1)
+}
+
+func (m *MockDriverService) DispatchOrder(ctx context.Context, orderID int, req *domain.DispatchOrderRequest) (*domain.DispatchOrderResponse, error) {
+	args := m.Called(ctx, orderID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DispatchOrderResponse), args.Error(1)
+}
+
+// MockOrderService is a mock implementation of domain.OrderService
+type MockOrderService struct {
+	mock.Mock
+}
+
+func (m *MockOrderService) ListDraftOrders(ctx context.Context) ([]*domain.DraftOrder, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DraftOrder), args.Error(1)
+}
+
+func (m *MockOrderService) ConfirmOrder(ctx context.Context, orderID int) (*domain.ConfirmOrderResponse, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ConfirmOrderResponse), args.Error(1)
+}
+
+func (m *MockOrderService) SendReceipt(ctx context.Context, orderID int) (*domain.SendReceiptResponse, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendReceiptResponse), args.Error(1)
+}
+
+// MockPickupService is a mock implementation of domain.PickupService
+type MockPickupService struct {
+	mock.Mock
+}
+
+func (m *MockPickupService) CreatePickupSlot(ctx context.Context, req *domain.CreatePickupSlotRequest) (*domain.PickupSlot, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PickupSlot), args.Error(1)
+}
+
+func (m *MockPickupService) GetSchedule(ctx context.Context, date string) (*domain.PickupSchedule, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PickupSchedule), args.Error(1)
+}
+
+// MockReminderService is a mock implementation of domain.ReminderService
+type MockReminderService struct {
+	mock.Mock
+}
+
+func (m *MockReminderService) CreateRule(ctx context.Context, req *domain.CreateReminderRuleRequest) (*domain.ReminderRule, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReminderRule), args.Error(1)
+}
+
+func (m *MockReminderService) ListRules(ctx context.Context) ([]*domain.ReminderRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ReminderRule), args.Error(1)
+}
+
+func (m *MockReminderService) ClaimRedemption(ctx context.Context, transactionID int) error {
+	args := m.Called(ctx, transactionID)
+	return args.Error(0)
+}
+
+// MockSenderChainRepository is a mock implementation of SenderChainRepository
+type MockSenderChainRepository struct {
+	mock.Mock
+}
+
+func (m *MockSenderChainRepository) GetChain(category string) ([]string, error) {
+	args := m.Called(category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSenderChainRepository) ListChains() (map[string][]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (m *MockSenderChainRepository) SetChain(category string, senderIDs []string) error {
+	args := m.Called(category, senderIDs)
+	return args.Error(0)
+}
+
+// MockSenderChainService is a mock implementation of SenderChainService
+type MockSenderChainService struct {
+	mock.Mock
+}
+
+func (m *MockSenderChainService) ListChains(ctx context.Context) ([]*domain.SenderFallbackChain, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SenderFallbackChain), args.Error(1)
+}
+
+func (m *MockSenderChainService) SetChain(ctx context.Context, category string, req *domain.SetSenderFallbackChainRequest) (*domain.SenderFallbackChain, error) {
+	args := m.Called(ctx, category, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderFallbackChain), args.Error(1)
+}
+
+// MockProspectService is a mock implementation of domain.ProspectService
+type MockProspectService struct {
+	mock.Mock
+}
+
+func (m *MockProspectService) ListProspects(ctx context.Context, includeConverted bool) ([]*domain.Prospect, error) {
+	args := m.Called(ctx, includeConverted)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Prospect), args.Error(1)
+}
+
+func (m *MockProspectService) ConvertProspect(ctx context.Context, phoneNumber string, req *domain.ConvertProspectRequest) (*domain.ConvertProspectResponse, error) {
+	args := m.Called(ctx, phoneNumber, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ConvertProspectResponse), args.Error(1)
+}
+
+// MockTemplateRepository is a mock implementation of domain.TemplateRepository
+type MockTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTemplateRepository) ListTemplates() ([]*domain.MessageTemplate, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MessageTemplate), args.Error(1)
+}
+
+func (m *MockTemplateRepository) GetTemplate(name string) (*domain.MessageTemplate, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageTemplate), args.Error(1)
+}
+
+func (m *MockTemplateRepository) CreateTemplate(name, body string) error {
+	args := m.Called(name, body)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) UpdateTemplate(name, body string) error {
+	args := m.Called(name, body)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) DeleteTemplate(name string) error {
+	args := m.Called(name)
+	return args.Error(0)
+}
+
+// MockTemplateService is a mock implementation of domain.TemplateService
+type MockTemplateService struct {
+	mock.Mock
+}
+
+func (m *MockTemplateService) ListTemplates(ctx context.Context) ([]*domain.MessageTemplate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MessageTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) GetTemplate(ctx context.Context, name string) (*domain.MessageTemplate, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) CreateTemplate(ctx context.Context, req *domain.SaveTemplateRequest) (*domain.MessageTemplate, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) UpdateTemplate(ctx context.Context, name string, req *domain.SaveTemplateRequest) (*domain.MessageTemplate, error) {
+	args := m.Called(ctx, name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageTemplate), args.Error(1)
+}
+
+func (m *MockTemplateService) DeleteTemplate(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *MockTemplateService) SendTemplate(ctx context.Context, req *domain.SendTemplateRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+// MockAutomationRepository is a mock implementation of domain.AutomationRepository
+type MockAutomationRepository struct {
+	mock.Mock
+}
+
+func (m *MockAutomationRepository) ListRules() ([]*domain.AutomationRule, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AutomationRule), args.Error(1)
+}
+
+func (m *MockAutomationRepository) ListActiveRules(event string) ([]*domain.AutomationRule, error) {
+	args := m.Called(event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AutomationRule), args.Error(1)
+}
+
+func (m *MockAutomationRepository) CreateRule(rule *domain.AutomationRule) (int, error) {
+	args := m.Called(rule)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAutomationRepository) DeleteRule(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// MockAutomationService is a mock implementation of domain.AutomationService
+type MockAutomationService struct {
+	mock.Mock
+}
+
+func (m *MockAutomationService) ListRules(ctx context.Context) ([]*domain.AutomationRule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AutomationRule), args.Error(1)
+}
+
+func (m *MockAutomationService) CreateRule(ctx context.Context, req *domain.CreateAutomationRuleRequest) (*domain.AutomationRule, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AutomationRule), args.Error(1)
+}
+
+func (m *MockAutomationService) DeleteRule(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAutomationService) RunRules(ctx context.Context, event string, data map[string]any) int {
+	args := m.Called(ctx, event, data)
+	return args.Int(0)
+}
+
+// MockSegmentRepository is a mock implementation of domain.SegmentRepository
+type MockSegmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockSegmentRepository) ListSegmentMembers(minPoints int) ([]*domain.SegmentMember, error) {
+	args := m.Called(minPoints)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SegmentMember), args.Error(1)
+}
+
+// MockBroadcastService is a mock implementation of domain.BroadcastService
+type MockBroadcastService struct {
+	mock.Mock
+}
+
+func (m *MockBroadcastService) StartBroadcast(ctx context.Context, req *domain.BroadcastRequest) (*domain.BroadcastJob, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BroadcastJob), args.Error(1)
+}
+
+func (m *MockBroadcastService) GetBroadcast(ctx context.Context, id string) (*domain.BroadcastJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BroadcastJob), args.Error(1)
+}
+
+// MockBatchSendService is a mock implementation of domain.BatchSendService
+type MockBatchSendService struct {
+	mock.Mock
+}
+
+func (m *MockBatchSendService) SendMessages(ctx context.Context, req *domain.SendMessagesRequest) (*domain.SendMessagesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessagesResponse), args.Error(1)
+}
+
+// MockSendJobService is a mock implementation of domain.SendJobService
+type MockSendJobService struct {
+	mock.Mock
+}
+
+func (m *MockSendJobService) StartSend(ctx context.Context, kind string, send func(ctx context.Context) (*domain.SendMessageResponse, error)) (*domain.SendJob, error) {
+	args := m.Called(ctx, kind, send)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendJob), args.Error(1)
+}
+
+func (m *MockSendJobService) GetSendJob(ctx context.Context, id string) (*domain.SendJob, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendJob), args.Error(1)
+}
+
+// MockCampaignService is a mock implementation of domain.CampaignService
+type MockCampaignService struct {
+	mock.Mock
+}
+
+func (m *MockCampaignService) CreateCampaign(ctx context.Context, req *domain.CreatePointsCampaignRequest) (*domain.CreatePointsCampaignResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CreatePointsCampaignResponse), args.Error(1)
+}
+
+func (m *MockCampaignService) ListCampaigns(ctx context.Context) ([]*domain.PointsCampaign, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PointsCampaign), args.Error(1)
+}
+
+func (m *MockCampaignService) DeleteCampaign(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockVoucherService is a mock implementation of domain.VoucherService
+type MockVoucherService struct {
+	mock.Mock
+}
+
+func (m *MockVoucherService) RedeemVoucher(ctx context.Context, code string, req *domain.RedeemVoucherRequest) (*domain.Voucher, error) {
+	args := m.Called(ctx, code, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Voucher), args.Error(1)
+}
+
+func (m *MockVoucherService) GetSettlement(ctx context.Context, date string) (*domain.VoucherSettlement, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VoucherSettlement), args.Error(1)
+}
+
+// MockBranchService is a mock implementation of domain.BranchService
+type MockBranchService struct {
+	mock.Mock
+}
+
+func (m *MockBranchService) ListBranches(ctx context.Context) ([]*domain.Branch, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Branch), args.Error(1)
+}
+
+func (m *MockBranchService) GetBranch(ctx context.Context, id int) (*domain.Branch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Branch), args.Error(1)
+}
+
+func (m *MockBranchService) CreateBranch(ctx context.Context, req *domain.SaveBranchRequest) (*domain.Branch, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Branch), args.Error(1)
+}
+
+func (m *MockBranchService) UpdateBranch(ctx context.Context, id int, req *domain.SaveBranchRequest) (*domain.Branch, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Branch), args.Error(1)
+}
+
+func (m *MockBranchService) DeleteBranch(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockBranchService) SendBranchLocation(ctx context.Context, id int, req *domain.SendBranchLocationRequest) (*domain.SendMessageResponse, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendMessageResponse), args.Error(1)
+}
+
+// MockGroupRepository is a mock implementation of domain.GroupRepository
+type MockGroupRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupRepository) ListGroups(ctx context.Context, from string) ([]*domain.Group, error) {
+	args := m.Called(ctx, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) CreateGroup(ctx context.Context, from, name string, participants []string) (*domain.Group, error) {
+	args := m.Called(ctx, from, name, participants)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) UpdateParticipants(ctx context.Context, from, groupJID string, participants []string, add bool) ([]*domain.GroupParticipantChange, error) {
+	args := m.Called(ctx, from, groupJID, participants, add)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GroupParticipantChange), args.Error(1)
+}
+
+func (m *MockGroupRepository) GetInviteLink(ctx context.Context, from, groupJID string, reset bool) (string, error) {
+	args := m.Called(ctx, from, groupJID, reset)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) SetDescription(ctx context.Context, from, groupJID, description string) error {
+	args := m.Called(ctx, from, groupJID, description)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) GetGroup(ctx context.Context, from, groupJID string) (*domain.Group, error) {
+	args := m.Called(ctx, from, groupJID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+// MockGroupService is a mock implementation of domain.GroupService
+type MockGroupService struct {
+	mock.Mock
+}
+
+func (m *MockGroupService) ListGroups(ctx context.Context, from string) ([]*domain.Group, error) {
+	args := m.Called(ctx, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupService) CreateGroup(ctx context.Context, req *domain.CreateGroupRequest) (*domain.Group, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Group), args.Error(1)
+}
+
+func (m *MockGroupService) AddParticipants(ctx context.Context, groupJID string, req *domain.UpdateGroupParticipantsRequest) (*domain.UpdateGroupParticipantsResponse, error) {
+	args := m.Called(ctx, groupJID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UpdateGroupParticipantsResponse), args.Error(1)
+}
+
+func (m *MockGroupService) RemoveParticipants(ctx context.Context, groupJID string, req *domain.UpdateGroupParticipantsRequest) (*domain.UpdateGroupParticipantsResponse, error) {
+	args := m.Called(ctx, groupJID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UpdateGroupParticipantsResponse), args.Error(1)
+}
+
+func (m *MockGroupService) GetInviteLink(ctx context.Context, groupJID, from string, reset bool) (*domain.GroupInviteLink, error) {
+	args := m.Called(ctx, groupJID, from, reset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GroupInviteLink), args.Error(1)
+}
+
+func (m *MockGroupService) SetDescription(ctx context.Context, groupJID string, req *domain.SetGroupDescriptionRequest) error {
+	args := m.Called(ctx, groupJID, req)
+	return args.Error(0)
+}
+
+// MockInventoryService is a mock implementation of domain.InventoryService
+type MockInventoryService struct {
+	mock.Mock
+}
+
+func (m *MockInventoryService) ListSupplies(ctx context.Context) ([]*domain.Supply, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Supply), args.Error(1)
+}
+
+func (m *MockInventoryService) CreateSupply(ctx context.Context, req *domain.CreateSupplyRequest) (*domain.Supply, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Supply), args.Error(1)
+}
+
+func (m *MockInventoryService) AdjustStock(ctx context.Context, supplyID int, req *domain.AdjustStockRequest) (*domain.Supply, error) {
+	args := m.Called(ctx, supplyID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Supply), args.Error(1)
+}
+
+func (m *MockInventoryService) GetItemUsage(ctx context.Context, itemID int) (*domain.ItemSupplyUsage, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ItemSupplyUsage), args.Error(1)
+}
+
+func (m *MockInventoryService) SetItemUsage(ctx context.Context, itemID int, req *domain.SetItemSupplyUsageRequest) (*domain.ItemSupplyUsage, error) {
+	args := m.Called(ctx, itemID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ItemSupplyUsage), args.Error(1)
+}
+
+func (m *MockInventoryService) ConsumeOrder(ctx context.Context, orderID int) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+// MockSendQueueRepository is a mock implementation of domain.SendQueueRepository
+type MockSendQueueRepository struct {
+	mock.Mock
+}
+
+func (m *MockSendQueueRepository) Enqueue(req *domain.SendMessageRequest, requestID string, attempts int, nextAttempt time.Time, lastError string) (int64, error) {
+	args := m.Called(req, requestID, attempts, nextAttempt, lastError)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSendQueueRepository) Due(now time.Time, limit int) ([]*domain.QueuedSend, error) {
+	args := m.Called(now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QueuedSend), args.Error(1)
+}
+
+func (m *MockSendQueueRepository) MarkSent(id int64, attempts int) error {
+	args := m.Called(id, attempts)
+	return args.Error(0)
+}
+
+func (m *MockSendQueueRepository) Retry(id int64, attempts int, nextAttempt time.Time, lastError string) error {
+	args := m.Called(id, attempts, nextAttempt, lastError)
+	return args.Error(0)
+}
+
+func (m *MockSendQueueRepository) MarkFailed(id int64, attempts int, lastError string) error {
+	args := m.Called(id, attempts, lastError)
+	return args.Error(0)
+}
+
+func (m *MockSendQueueRepository) MarkExpired(id int64, attempts int, lastError string) error {
+	args := m.Called(id, attempts, lastError)
+	return args.Error(0)
+}
+
+func (m *MockSendQueueRepository) Stats() (*domain.SendQueueStatus, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SendQueueStatus), args.Error(1)
+}
+
+func (m *MockSendQueueRepository) DeadLetters(includeRequeued bool, limit int) ([]*domain.DeadLetter, error) {
+	args := m.Called(includeRequeued, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DeadLetter), args.Error(1)
+}
+
+func (m *MockSendQueueRepository) Requeue(deadLetterID int64) (*domain.DeadLetter, error) {
+	args := m.Called(deadLetterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DeadLetter), args.Error(1)
+}
+
+// MockMessageStatusRepository is a mock implementation of domain.MessageStatusRepository
+type MockMessageStatusRepository struct {
+	mock.Mock
+}
+
+func (m *MockMessageStatusRepository) Track(messageID, requestID, recipient string) error {
+	args := m.Called(messageID, requestID, recipient)
+	return args.Error(0)
+}
+
+func (m *MockMessageStatusRepository) Advance(messageID, status string) (*domain.TrackedMessage, error) {
+	args := m.Called(messageID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TrackedMessage), args.Error(1)
+}
+
+// MockMessageHistoryRepository is a mock implementation of domain.MessageHistoryRepository
+type MockMessageHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockMessageHistoryRepository) Record(record *domain.MessageRecord) error {
+	args := m.Called(record)
+	return args.Error(0)
+}
+
+func (m *MockMessageHistoryRepository) List(filter domain.MessageHistoryFilter) ([]*domain.MessageRecord, int, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*domain.MessageRecord), args.Int(1), args.Error(2)
+}
+
+func (m *MockMessageHistoryRepository) LogMode() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMessageHistoryRepository) GetByMessageID(messageID string) (*domain.MessageRecord, error) {
+	args := m.Called(messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageRecord), args.Error(1)
+}
+
+func (m *MockMessageHistoryRepository) MarkRevoked(messageID string) error {
+	args := m.Called(messageID)
+	return args.Error(0)
+}
+
+func (m *MockMessageHistoryRepository) RecordEdit(edit *domain.MessageEdit) error {
+	args := m.Called(edit)
+	return args.Error(0)
+}
+
+func (m *MockMessageHistoryRepository) ListEdits(messageID string) ([]*domain.MessageEdit, error) {
+	args := m.Called(messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MessageEdit), args.Error(1)
+}
+
+func (m *MockMessageHistoryRepository) BusiestContacts(since, until time.Time, limit int) ([]*domain.ContactVolume, error) {
+	args := m.Called(since, until, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ContactVolume), args.Error(1)
+}
+
+func (m *MockMessageHistoryRepository) Volume(since, until time.Time, interval string) ([]*domain.MessageVolume, error) {
+	args := m.Called(since, until, interval)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MessageVolume), args.Error(1)
+}
+
+// MockMessageHistoryService is a mock implementation of domain.MessageHistoryService
+type MockMessageHistoryService struct {
+	mock.Mock
+}
+
+func (m *MockMessageHistoryService) ListMessages(ctx context.Context, query *domain.MessageHistoryQuery) (*domain.MessageHistoryPage, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageHistoryPage), args.Error(1)
+}
+
+func (m *MockMessageHistoryService) ListEdits(ctx context.Context, messageID string) ([]*domain.MessageEdit, error) {
+	args := m.Called(ctx, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MessageEdit), args.Error(1)
+}
+
+func (m *MockMessageHistoryService) GetBusiestContacts(ctx context.Context, query *domain.MessageReportQuery) (*domain.BusiestContactsReport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BusiestContactsReport), args.Error(1)
+}
+
+func (m *MockMessageHistoryService) GetMessageVolume(ctx context.Context, query *domain.MessageReportQuery) (*domain.MessageVolumeReport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageVolumeReport), args.Error(1)
+}
+
+// MockIdempotencyRepository is a mock implementation of domain.IdempotencyRepository
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) Reserve(key, fingerprint string) (*domain.IdempotencyRecord, error) {
+	args := m.Called(key, fingerprint)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) Complete(key string, statusCode int, response []byte) error {
+	args := m.Called(key, statusCode, response)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Release(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+// MockStaffService is a mock implementation of domain.StaffService
+type MockStaffService struct {
+	mock.Mock
+}
+
+func (m *MockStaffService) GetDailyActivity(ctx context.Context, date string) (*domain.StaffActivityReport, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.StaffActivityReport), args.Error(1)
+}
+
+// MockSenderActivityService is a mock implementation of domain.SenderActivityService
+type MockSenderActivityService struct {
+	mock.Mock
+}
+
+func (m *MockSenderActivityService) GetActivity(ctx context.Context, senderID string, query *domain.SenderActivityQuery) (*domain.SenderActivityReport, error) {
+	args := m.Called(ctx, senderID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderActivityReport), args.Error(1)
+}
+
+// MockStorageService is a mock implementation of domain.StorageService
+type MockStorageService struct {
+	mock.Mock
+}
+
+func (m *MockStorageService) GetStorage(ctx context.Context) (*domain.StorageReport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.StorageReport), args.Error(1)
+}
+
+// MockConfirmationService is a mock implementation of domain.ConfirmationService
+type MockConfirmationService struct {
+	mock.Mock
+}
+
+func (m *MockConfirmationService) CreateConfirmation(ctx context.Context, req *domain.CreateConfirmationRequest) (*domain.Confirmation, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Confirmation), args.Error(1)
+}
+
+func (m *MockConfirmationService) Confirm(ctx context.Context, token, code, action, target string) error {
+	args := m.Called(ctx, token, code, action, target)
+	return args.Error(0)
+}
+
+// MockDeadLetterService is a mock implementation of domain.DeadLetterService
+type MockDeadLetterService struct {
+	mock.Mock
+}
+
+func (m *MockDeadLetterService) ListDeadLetters(ctx context.Context, query *domain.DeadLetterQuery) ([]*domain.DeadLetter, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DeadLetter), args.Error(1)
+}
+
+func (m *MockDeadLetterService) RetryDeadLetter(ctx context.Context, id int64) (*domain.DeadLetter, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DeadLetter), args.Error(1)
+}
+
+// MockSuppressionRepository is a mock implementation of domain.SuppressionRepository
+type MockSuppressionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSuppressionRepository) ListSuppressions() ([]*domain.Suppression, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionRepository) GetSuppression(recipient string) (*domain.Suppression, error) {
+	args := m.Called(recipient)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionRepository) CreateSuppression(recipient, reason, note string) (*domain.Suppression, error) {
+	args := m.Called(recipient, reason, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionRepository) DeleteSuppression(recipient string) error {
+	args := m.Called(recipient)
+	return args.Error(0)
+}
+
+// MockSuppressionService is a mock implementation of domain.SuppressionService
+type MockSuppressionService struct {
+	mock.Mock
+}
+
+func (m *MockSuppressionService) ListSuppressions(ctx context.Context) ([]*domain.Suppression, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionService) GetSuppression(ctx context.Context, recipient string) (*domain.Suppression, error) {
+	args := m.Called(ctx, recipient)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionService) CreateSuppression(ctx context.Context, req *domain.CreateSuppressionRequest) (*domain.Suppression, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionService) DeleteSuppression(ctx context.Context, recipient string) error {
+	args := m.Called(ctx, recipient)
+	return args.Error(0)
+}
+
+// MockLabelRepository is a mock implementation of domain.LabelRepository
+type MockLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockLabelRepository) ListLabels(ctx context.Context, from string) (string, []*domain.Label, error) {
+	args := m.Called(ctx, from)
+	if args.Get(1) == nil {
+		return args.String(0), nil, args.Error(2)
+	}
+	return args.String(0), args.Get(1).([]*domain.Label), args.Error(2)
+}
+
+func (m *MockLabelRepository) SyncLabels(ctx context.Context, from string) error {
+	args := m.Called(ctx, from)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) SaveLabel(ctx context.Context, from, id, name string, color int) (*domain.Label, error) {
+	args := m.Called(ctx, from, id, name, color)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Label), args.Error(1)
+}
+
+func (m *MockLabelRepository) DeleteLabel(ctx context.Context, from, id string) error {
+	args := m.Called(ctx, from, id)
+	return args.Error(0)
+}
+
+func (m *MockLabelRepository) ListLabelChats(ctx context.Context, from, id string) ([]string, error) {
+	args := m.Called(ctx, from, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockLabelRepository) SetLabelChat(ctx context.Context, from, id, chat string, labeled bool) error {
+	args := m.Called(ctx, from, id, chat, labeled)
+	return args.Error(0)
+}
+
+// MockAutoLabelRepository is a mock implementation of domain.AutoLabelRepository
+type MockAutoLabelRepository struct {
+	mock.Mock
+}
+
+func (m *MockAutoLabelRepository) ListLeadPhones() ([]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockAutoLabelRepository) ListPendingPickupPhones() ([]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockLabelService is a mock implementation of domain.LabelService
+type MockLabelService struct {
+	mock.Mock
+}
+
+func (m *MockLabelService) ListLabels(ctx context.Context, from string) ([]*domain.Label, error) {
+	args := m.Called(ctx, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Label), args.Error(1)
+}
+
+func (m *MockLabelService) SyncLabels(ctx context.Context, from string) ([]*domain.Label, error) {
+	args := m.Called(ctx, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Label), args.Error(1)
+}
+
+func (m *MockLabelService) CreateLabel(ctx context.Context, req *domain.SaveLabelRequest) (*domain.Label, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Label), args.Error(1)
+}
+
+func (m *MockLabelService) UpdateLabel(ctx context.Context, id string, req *domain.SaveLabelRequest) (*domain.Label, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Label), args.Error(1)
+}
+
+func (m *MockLabelService) DeleteLabel(ctx context.Context, from, id string) error {
+	args := m.Called(ctx, from, id)
+	return args.Error(0)
+}
+
+func (m *MockLabelService) ListLabelChats(ctx context.Context, from, id string) ([]string, error) {
+	args := m.Called(ctx, from, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockLabelService) LabelChat(ctx context.Context, id string, req *domain.LabelChatRequest) error {
+	args := m.Called(ctx, id, req)
+	return args.Error(0)
+}
+
+func (m *MockLabelService) UnlabelChat(ctx context.Context, id string, req *domain.LabelChatRequest) error {
+	args := m.Called(ctx, id, req)
+	return args.Error(0)
+}
+
+func (m *MockLabelService) AutoLabel(ctx context.Context, from string) (*domain.AutoLabelResult, error) {
+	args := m.Called(ctx, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AutoLabelResult), args.Error(1)
+}
+
+// MockSenderRegistrationService is a mock implementation of domain.SenderRegistrationService
+type MockSenderRegistrationService struct {
+	mock.Mock
+}
+
+func (m *MockSenderRegistrationService) StartQRRegistration(ctx context.Context) (*domain.RegisterSenderQRResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RegisterSenderQRResponse), args.Error(1)
+}
+
+func (m *MockSenderRegistrationService) StartCodeRegistration(ctx context.Context, req *domain.RegisterSenderCodeRequest) (*domain.RegisterSenderCodeResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RegisterSenderCodeResponse), args.Error(1)
+}
+
+func (m *MockSenderRegistrationService) GetRegistrationStatus(ctx context.Context, sessionID string) (*domain.RegistrationStatusResponse, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RegistrationStatusResponse), args.Error(1)
+}
+
+func (m *MockSenderRegistrationService) DeleteSender(ctx context.Context, senderID string) error {
+	args := m.Called(ctx, senderID)
+	return args.Error(0)
+}
+
+func (m *MockSenderRegistrationService) ClearSessions(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockRegistrationBatchService is a mock implementation of domain.RegistrationBatchService
+type MockRegistrationBatchService struct {
+	mock.Mock
+}
+
+func (m *MockRegistrationBatchService) StartRegistrationBatch(ctx context.Context, req *domain.RegisterSendersRequest) (*domain.RegistrationBatch, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RegistrationBatch), args.Error(1)
+}
+
+func (m *MockRegistrationBatchService) GetRegistrationBatch(ctx context.Context, id string) (*domain.RegistrationBatch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RegistrationBatch), args.Error(1)
+}
+
+// MockReportService is a mock implementation of domain.ReportService
+type MockReportService struct {
+	mock.Mock
+}
+
+func (m *MockReportService) CreateSchedule(ctx context.Context, req *domain.ReportScheduleRequest) (*domain.ReportSchedule, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReportSchedule), args.Error(1)
+}
+
+func (m *MockReportService) ListSchedules(ctx context.Context) ([]*domain.ReportSchedule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ReportSchedule), args.Error(1)
+}
+
+func (m *MockReportService) UpdateSchedule(ctx context.Context, id int, req *domain.ReportScheduleRequest) (*domain.ReportSchedule, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReportSchedule), args.Error(1)
+}
+
+func (m *MockReportService) DeleteSchedule(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockReportService) SendSchedule(ctx context.Context, id int) (*domain.ReportDelivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReportDelivery), args.Error(1)
+}
+
+func (m *MockReportService) RunDueSchedules(ctx context.Context, now time.Time) ([]*domain.ReportDelivery, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ReportDelivery), args.Error(1)
+}
+
+// MockMailer is a mock implementation of domain.Mailer
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) SendMail(ctx context.Context, to []string, subject, body string, attachments ...domain.MailAttachment) error {
+	args := m.Called(ctx, to, subject, body, attachments)
+	return args.Error(0)
+}
+
+// MockStatusPageService is a mock implementation of domain.StatusPageService
+type MockStatusPageService struct {
+	mock.Mock
+}
+
+func (m *MockStatusPageService) GetStatusPage(ctx context.Context) (*domain.StatusPage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.StatusPage), args.Error(1)
+}
+
+func (m *MockStatusPageService) CreateIncident(ctx context.Context, req *domain.IncidentRequest) (*domain.Incident, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Incident), args.Error(1)
+}
+
+func (m *MockStatusPageService) ListIncidents(ctx context.Context) ([]*domain.Incident, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Incident), args.Error(1)
+}
+
+func (m *MockStatusPageService) UpdateIncident(ctx context.Context, id int, req *domain.IncidentRequest) (*domain.Incident, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Incident), args.Error(1)
+}
+
+// MockSoftLaunchService is a mock implementation of domain.SoftLaunchService
+type MockSoftLaunchService struct {
+	mock.Mock
+}
+
+func (m *MockSoftLaunchService) GetReport(ctx context.Context, query *domain.SoftLaunchQuery) (*domain.SoftLaunchReport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SoftLaunchReport), args.Error(1)
+}
+
+// MockSenderService is a mock implementation of domain.SenderService
+type MockSenderService struct {
+	mock.Mock
+}
+
+func (m *MockSenderService) UpdateSender(ctx context.Context, senderID string, req *domain.UpdateSenderRequest) (*domain.Sender, error) {
+	args := m.Called(ctx, senderID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Sender), args.Error(1)
+}
+
+func (m *MockSenderService) PauseSender(ctx context.Context, senderID string) (*domain.Sender, error) {
+	args := m.Called(ctx, senderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Sender), args.Error(1)
+}
+
+func (m *MockSenderService) ResumeSender(ctx context.Context, senderID string) (*domain.Sender, error) {
+	args := m.Called(ctx, senderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Sender), args.Error(1)
+}
+
+func (m *MockSenderService) GetSenderHealth(ctx context.Context, senderID string) (*domain.SenderHealth, error) {
+	args := m.Called(ctx, senderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderHealth), args.Error(1)
+}
+
+func (m *MockSenderService) GetSenderQuota(ctx context.Context, senderID string) (*domain.SenderQuota, error) {
+	args := m.Called(ctx, senderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderQuota), args.Error(1)
+}
+
+func (m *MockSenderService) SetSenderQuota(ctx context.Context, senderID string, req *domain.SetSenderQuotaRequest) (*domain.SenderQuota, error) {
+	args := m.Called(ctx, senderID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderQuota), args.Error(1)
+}
+
+func (m *MockSenderService) ReconnectSender(ctx context.Context, senderID string) (*domain.SenderHealth, error) {
+	args := m.Called(ctx, senderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderHealth), args.Error(1)
+}
+
+// MockSenderClients is a mock implementation of domain.SenderClients
+type MockSenderClients struct {
+	mock.Mock
+}
+
+func (m *MockSenderClients) PauseSender(senderID string) error {
+	args := m.Called(senderID)
+	return args.Error(0)
+}
+
+func (m *MockSenderClients) ResumeSender(senderID string) error {
+	args := m.Called(senderID)
+	return args.Error(0)
+}
+
+func (m *MockSenderClients) SenderConnection(senderID string) (connected, loggedIn bool, pushName string) {
+	args := m.Called(senderID)
+	return args.Bool(0), args.Bool(1), args.String(2)
+}
+
+func (m *MockSenderClients) ReconnectSender(senderID string) error {
+	args := m.Called(senderID)
+	return args.Error(0)
+}
+
+// MockGroupSyncLogRepository is a mock implementation of domain.GroupSyncLogRepository
+type MockGroupSyncLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupSyncLogRepository) RecordChange(change *domain.GroupSyncChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockGroupSyncLogRepository) ListChanges(groupJID string, limit int) ([]*domain.GroupSyncChange, error) {
+	args := m.Called(groupJID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GroupSyncChange), args.Error(1)
+}
+
+func (m *MockGroupSyncLogRepository) InvitedSince(groupJID string, since time.Time) ([]string, error) {
+	args := m.Called(groupJID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockVIPGroupService is a mock implementation of domain.VIPGroupService
+type MockVIPGroupService struct {
+	mock.Mock
+}
+
+func (m *MockVIPGroupService) Sync(ctx context.Context) (*domain.GroupSyncResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GroupSyncResult), args.Error(1)
+}
+
+func (m *MockVIPGroupService) ListChanges(ctx context.Context, limit int) ([]*domain.GroupSyncChange, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GroupSyncChange), args.Error(1)
+}
+
+// MockBlocklistRepository is a mock implementation of domain.BlocklistRepository
+type MockBlocklistRepository struct {
+	mock.Mock
+}
+
+func (m *MockBlocklistRepository) GetBlocklist(ctx context.Context, from string) ([]string, error) {
+	args := m.Called(ctx, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockBlocklistRepository) UpdateBlocklist(ctx context.Context, from, phone string, block bool) ([]string, error) {
+	args := m.Called(ctx, from, phone, block)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// MockBlocklistService is a mock implementation of domain.BlocklistService
+type MockBlocklistService struct {
+	mock.Mock
+}
+
+func (m *MockBlocklistService) GetBlocklist(ctx context.Context, senderID string) (*domain.SenderBlocklist, error) {
+	args := m.Called(ctx, senderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderBlocklist), args.Error(1)
+}
+
+func (m *MockBlocklistService) BlockContact(ctx context.Context, senderID string, req *domain.BlockContactRequest) (*domain.SenderBlocklist, error) {
+	args := m.Called(ctx, senderID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderBlocklist), args.Error(1)
+}
+
+func (m *MockBlocklistService) UnblockContact(ctx context.Context, senderID, phone string) (*domain.SenderBlocklist, error) {
+	args := m.Called(ctx, senderID, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderBlocklist), args.Error(1)
+}
+
+// MockSenderPoolRepository is a mock implementation of SenderPoolRepository
+type MockSenderPoolRepository struct {
+	mock.Mock
+}
+
+func (m *MockSenderPoolRepository) GetPool(name string) ([]string, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSenderPoolRepository) ListPools() (map[string][]string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (m *MockSenderPoolRepository) SetPool(name string, senderIDs []string) error {
+	args := m.Called(name, senderIDs)
+	return args.Error(0)
+}
+
+// MockSenderPoolService is a mock implementation of SenderPoolService
+type MockSenderPoolService struct {
+	mock.Mock
+}
+
+func (m *MockSenderPoolService) ListPools(ctx context.Context) ([]*domain.SenderPool, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SenderPool), args.Error(1)
+}
+
+func (m *MockSenderPoolService) SetPool(ctx context.Context, name string, req *domain.SetSenderPoolRequest) (*domain.SenderPool, error) {
+	args := m.Called(ctx, name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SenderPool), args.Error(1)
+}
+
+// MockReleaseFeed is a mock implementation of ReleaseFeed
+type MockReleaseFeed struct {
+	mock.Mock
+}
+
+func (m *MockReleaseFeed) LatestVersion(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+// MockVersionService is a mock implementation of VersionService
+type MockVersionService struct {
+	mock.Mock
+}
+
+func (m *MockVersionService) GetVersion(ctx context.Context) (*domain.VersionInfo, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VersionInfo), args.Error(1)
+}
+
+// MockNotificationPreferenceService is a mock implementation of NotificationPreferenceService
+type MockNotificationPreferenceService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationPreferenceService) ListPreferences(ctx context.Context) ([]*domain.NotificationPreferences, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockNotificationPreferenceService) GetPreferences(ctx context.Context, phone string) (*domain.NotificationPreferences, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockNotificationPreferenceService) SetPreferences(ctx context.Context, phone string, req *domain.SetNotificationPreferencesRequest) (*domain.NotificationPreferences, error) {
+	args := m.Called(ctx, phone, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockNotificationPreferenceService) DeletePreferences(ctx context.Context, phone string) error {
+	args := m.Called(ctx, phone)
+	return args.Error(0)
+}
@@ -0,0 +1,87 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type AutomationHandler struct {
+	automationService domain.AutomationService
+}
+
+// NewAutomationHandler creates a new automation rule handler
+func NewAutomationHandler(automationService domain.AutomationService) *AutomationHandler {
+	return &AutomationHandler{automationService: automationService}
+}
+
+// ListRules handles GET /api/automations
+func (h *AutomationHandler) ListRules(c *gin.Context) {
+	rules, err := h.automationService.ListRules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"count": len(rules),
+	})
+}
+
+// CreateRule handles POST /api/automations
+func (h *AutomationHandler) CreateRule(c *gin.Context) {
+	var req domain.CreateAutomationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.automationService.CreateRule(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidAutomationRule) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// DeleteRule handles DELETE /api/automations/:id
+func (h *AutomationHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid automation rule ID",
+		})
+		return
+	}
+
+	if err := h.automationService.DeleteRule(c.Request.Context(), id); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrAutomationRuleNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Automation rule deleted",
+	})
+}
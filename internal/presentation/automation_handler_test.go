@@ -0,0 +1,80 @@
+package presentation
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestAutomationHandler_CreateRule(t *testing.T) {
+	// Arrange
+	mockAutomationService := &mocks.MockAutomationService{}
+	handler := NewAutomationHandler(mockAutomationService)
+
+	router := setupTestRouter()
+	router.POST("/automations", handler.CreateRule)
+
+	mockAutomationService.On("CreateRule", mock.Anything, mock.MatchedBy(func(req *domain.CreateAutomationRuleRequest) bool {
+		return req.Event == "points.changed" && req.Condition == `points > 100`
+	})).Return(&domain.AutomationRule{ID: 1, Name: "big earners", Event: "points.changed", Template: "big_earner", Active: true}, nil)
+
+	// Act
+	body := `{"name": "big earners", "event": "points.changed", "condition": "points > 100", "template": "big_earner"}`
+	req, _ := http.NewRequest("POST", "/automations", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockAutomationService.AssertExpectations(t)
+}
+
+func TestAutomationHandler_CreateRule_Invalid(t *testing.T) {
+	// Arrange
+	mockAutomationService := &mocks.MockAutomationService{}
+	handler := NewAutomationHandler(mockAutomationService)
+
+	router := setupTestRouter()
+	router.POST("/automations", handler.CreateRule)
+
+	mockAutomationService.On("CreateRule", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: unexpected end of condition", domain.ErrInvalidAutomationRule))
+
+	// Act
+	body := `{"name": "big earners", "event": "points.changed", "condition": "points >", "template": "big_earner"}`
+	req, _ := http.NewRequest("POST", "/automations", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "unexpected end of condition")
+}
+
+func TestAutomationHandler_DeleteRule_NotFound(t *testing.T) {
+	// Arrange
+	mockAutomationService := &mocks.MockAutomationService{}
+	handler := NewAutomationHandler(mockAutomationService)
+
+	router := setupTestRouter()
+	router.DELETE("/automations/:id", handler.DeleteRule)
+
+	mockAutomationService.On("DeleteRule", mock.Anything, 9).Return(domain.ErrAutomationRuleNotFound)
+
+	// Act
+	req, _ := http.NewRequest("DELETE", "/automations/9", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
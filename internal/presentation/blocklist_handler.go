@@ -0,0 +1,69 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type BlocklistHandler struct {
+	blocklistService domain.BlocklistService
+}
+
+// NewBlocklistHandler creates a new sender blocklist handler
+func NewBlocklistHandler(blocklistService domain.BlocklistService) *BlocklistHandler {
+	return &BlocklistHandler{blocklistService: blocklistService}
+}
+
+// GetBlocklist handles GET /api/senders/:id/blocklist
+func (h *BlocklistHandler) GetBlocklist(c *gin.Context) {
+	blocklist, err := h.blocklistService.GetBlocklist(c.Request.Context(), c.Param("id"))
+	h.respondBlocklist(c, blocklist, err)
+}
+
+// BlockContact handles POST /api/senders/:id/blocklist
+func (h *BlocklistHandler) BlockContact(c *gin.Context) {
+	var req domain.BlockContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	blocklist, err := h.blocklistService.BlockContact(c.Request.Context(), c.Param("id"), &req)
+	h.respondBlocklist(c, blocklist, err)
+}
+
+// UnblockContact handles DELETE /api/senders/:id/blocklist/:phone
+func (h *BlocklistHandler) UnblockContact(c *gin.Context) {
+	blocklist, err := h.blocklistService.UnblockContact(c.Request.Context(), c.Param("id"), c.Param("phone"))
+	h.respondBlocklist(c, blocklist, err)
+}
+
+func (h *BlocklistHandler) respondBlocklist(c *gin.Context, blocklist *domain.SenderBlocklist, err error) {
+	if err != nil {
+		c.JSON(blocklistStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, blocklist)
+}
+
+func blocklistStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidBlock):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrSenderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrSenderPaused):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrWhatsAppNotConnected):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
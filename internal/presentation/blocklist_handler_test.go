@@ -0,0 +1,80 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestBlocklistHandler_BlockContact(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"blocked", nil, http.StatusOK},
+		{"invalid phone", domain.ErrInvalidBlock, http.StatusBadRequest},
+		{"unknown sender", fmt.Errorf("sender not found or not initialized: 6289999999999: %w", domain.ErrSenderNotFound), http.StatusNotFound},
+		{"not connected", domain.ErrWhatsAppNotConnected, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockBlocklistService := &mocks.MockBlocklistService{}
+			handler := NewBlocklistHandler(mockBlocklistService)
+
+			router := setupTestRouter()
+			router.POST("/senders/:id/blocklist", handler.BlockContact)
+
+			var blocklist *domain.SenderBlocklist
+			if tt.err == nil {
+				blocklist = &domain.SenderBlocklist{SenderID: "6281111111111", Blocked: []string{"6281234567890"}}
+			}
+			mockBlocklistService.On("BlockContact", mock.Anything, "6281111111111", &domain.BlockContactRequest{Phone: "6281234567890"}).
+				Return(blocklist, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("POST", "/senders/6281111111111/blocklist", bytes.NewBufferString(`{"phone": "6281234567890"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockBlocklistService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBlocklistHandler_UnblockContact(t *testing.T) {
+	// Arrange
+	mockBlocklistService := &mocks.MockBlocklistService{}
+	handler := NewBlocklistHandler(mockBlocklistService)
+
+	router := setupTestRouter()
+	router.DELETE("/senders/:id/blocklist/:phone", handler.UnblockContact)
+
+	mockBlocklistService.On("UnblockContact", mock.Anything, "6281111111111", "6281234567890").
+		Return(&domain.SenderBlocklist{SenderID: "6281111111111", Blocked: []string{}}, nil)
+
+	// Act
+	req, _ := http.NewRequest("DELETE", "/senders/6281111111111/blocklist/6281234567890", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response domain.SenderBlocklist
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Empty(t, response.Blocked)
+	mockBlocklistService.AssertExpectations(t)
+}
@@ -0,0 +1,175 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type BranchHandler struct {
+	branchService domain.BranchService
+}
+
+// NewBranchHandler creates a new branch directory handler
+func NewBranchHandler(branchService domain.BranchService) *BranchHandler {
+	return &BranchHandler{branchService: branchService}
+}
+
+// ListBranches handles GET /api/branches
+func (h *BranchHandler) ListBranches(c *gin.Context) {
+	branches, err := h.branchService.ListBranches(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"branches": branches,
+		"count":    len(branches),
+	})
+}
+
+// GetBranch handles GET /api/branches/:id
+func (h *BranchHandler) GetBranch(c *gin.Context) {
+	id, ok := branchID(c)
+	if !ok {
+		return
+	}
+
+	branch, err := h.branchService.GetBranch(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(branchStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, branch)
+}
+
+// CreateBranch handles POST /api/branches
+func (h *BranchHandler) CreateBranch(c *gin.Context) {
+	var req domain.SaveBranchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	branch, err := h.branchService.CreateBranch(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(branchStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, branch)
+}
+
+// UpdateBranch handles PUT /api/branches/:id
+func (h *BranchHandler) UpdateBranch(c *gin.Context) {
+	id, ok := branchID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SaveBranchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	branch, err := h.branchService.UpdateBranch(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(branchStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, branch)
+}
+
+// DeleteBranch handles DELETE /api/branches/:id
+func (h *BranchHandler) DeleteBranch(c *gin.Context) {
+	id, ok := branchID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.branchService.DeleteBranch(c.Request.Context(), id); err != nil {
+		c.JSON(branchStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Branch deleted",
+	})
+}
+
+// SendBranchLocation handles POST /api/branches/:id/send-location
+func (h *BranchHandler) SendBranchLocation(c *gin.Context) {
+	id, ok := branchID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.SendBranchLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.branchService.SendBranchLocation(c.Request.Context(), id, &req)
+	if err != nil {
+		statusCode := branchStatusCode(err)
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber:
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// branchID reads the :id path parameter, answering 400 when it is not a branch ID
+func branchID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid branch ID",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// branchStatusCode maps branch errors to HTTP status codes
+func branchStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidBranch):
+		return http.StatusBadRequest
+	case err == domain.ErrBranchNotFound:
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
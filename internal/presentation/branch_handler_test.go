@@ -0,0 +1,139 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestBranchHandler_ListBranches(t *testing.T) {
+	// Arrange
+	mockBranchService := &mocks.MockBranchService{}
+	handler := NewBranchHandler(mockBranchService)
+
+	router := setupTestRouter()
+	router.GET("/branches", handler.ListBranches)
+
+	mockBranchService.On("ListBranches", mock.Anything).Return([]*domain.Branch{
+		{ID: 1, Name: "Dago", Address: "Jl. Dago 1", Hours: "07.00-21.00", MapsURL: "https://maps.app.goo.gl/abc123"},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/branches", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Branches []domain.Branch `json:"branches"`
+		Count    int             `json:"count"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "Dago", response.Branches[0].Name)
+}
+
+func TestBranchHandler_CreateBranch_Invalid(t *testing.T) {
+	// Arrange
+	mockBranchService := &mocks.MockBranchService{}
+	handler := NewBranchHandler(mockBranchService)
+
+	router := setupTestRouter()
+	router.POST("/branches", handler.CreateBranch)
+
+	mockBranchService.On("CreateBranch", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: latitude and longitude are required", domain.ErrInvalidBranch))
+
+	// Act
+	req, _ := http.NewRequest("POST", "/branches", bytes.NewBufferString(`{"name": "Dago", "address": "Jl. Dago 1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "latitude")
+}
+
+func TestBranchHandler_UpdateBranch_NotFound(t *testing.T) {
+	// Arrange
+	mockBranchService := &mocks.MockBranchService{}
+	handler := NewBranchHandler(mockBranchService)
+
+	router := setupTestRouter()
+	router.PUT("/branches/:id", handler.UpdateBranch)
+
+	mockBranchService.On("UpdateBranch", mock.Anything, 9, mock.Anything).Return(nil, domain.ErrBranchNotFound)
+
+	// Act
+	req, _ := http.NewRequest("PUT", "/branches/9", bytes.NewBufferString(`{"name": "Dago"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestBranchHandler_SendBranchLocation(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   *domain.SendMessageResponse
+		err        error
+		wantStatus int
+	}{
+		{"sent", &domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil, http.StatusOK},
+		{"unknown branch", &domain.SendMessageResponse{Success: false}, domain.ErrBranchNotFound, http.StatusNotFound},
+		{"not connected", &domain.SendMessageResponse{Success: false}, domain.ErrWhatsAppNotConnected, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockBranchService := &mocks.MockBranchService{}
+			handler := NewBranchHandler(mockBranchService)
+
+			router := setupTestRouter()
+			router.POST("/branches/:id/send-location", handler.SendBranchLocation)
+
+			mockBranchService.On("SendBranchLocation", mock.Anything, 2, &domain.SendBranchLocationRequest{To: "6281234567890"}).
+				Return(tt.response, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("POST", "/branches/2/send-location", bytes.NewBufferString(`{"to": "6281234567890"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockBranchService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBranchHandler_InvalidID(t *testing.T) {
+	// Arrange
+	handler := NewBranchHandler(&mocks.MockBranchService{})
+
+	router := setupTestRouter()
+	router.GET("/branches/:id", handler.GetBranch)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/branches/dago", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,64 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type BroadcastHandler struct {
+	broadcastService domain.BroadcastService
+}
+
+// NewBroadcastHandler creates a new broadcast handler
+func NewBroadcastHandler(broadcastService domain.BroadcastService) *BroadcastHandler {
+	return &BroadcastHandler{broadcastService: broadcastService}
+}
+
+// StartBroadcast handles POST /api/broadcast
+func (h *BroadcastHandler) StartBroadcast(c *gin.Context) {
+	var req domain.BroadcastRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	job, err := h.broadcastService.StartBroadcast(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidBroadcast:
+			statusCode = http.StatusBadRequest
+		case domain.ErrBroadcastTooLarge:
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Per-recipient results are served by the status endpoint
+	job.Results = nil
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetBroadcast handles GET /api/broadcast/:id
+func (h *BroadcastHandler) GetBroadcast(c *gin.Context) {
+	job, err := h.broadcastService.GetBroadcast(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrBroadcastNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
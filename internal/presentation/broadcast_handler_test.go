@@ -0,0 +1,86 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestBroadcastHandler_StartBroadcast(t *testing.T) {
+	// Arrange
+	mockBroadcastService := &mocks.MockBroadcastService{}
+	handler := NewBroadcastHandler(mockBroadcastService)
+
+	router := setupTestRouter()
+	router.POST("/broadcast", handler.StartBroadcast)
+
+	mockBroadcastService.On("StartBroadcast", mock.Anything, mock.MatchedBy(func(req *domain.BroadcastRequest) bool {
+		return req.Segment != nil && req.Segment.Tier == "Gold" && req.Message == "Promo!"
+	})).Return(&domain.BroadcastJob{
+		ID:      "job-1",
+		Status:  domain.BroadcastRunning,
+		Total:   1,
+		Results: []domain.BroadcastResult{{To: "628111", Status: domain.BroadcastPending}},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/broadcast", bytes.NewBufferString(`{"segment": {"tier": "Gold"}, "message": "Promo!"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	var response domain.BroadcastJob
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "job-1", response.ID)
+	assert.Empty(t, response.Results)
+	mockBroadcastService.AssertExpectations(t)
+}
+
+func TestBroadcastHandler_StartBroadcast_TooLarge(t *testing.T) {
+	// Arrange
+	mockBroadcastService := &mocks.MockBroadcastService{}
+	handler := NewBroadcastHandler(mockBroadcastService)
+
+	router := setupTestRouter()
+	router.POST("/broadcast", handler.StartBroadcast)
+
+	mockBroadcastService.On("StartBroadcast", mock.Anything, mock.Anything).Return(nil, domain.ErrBroadcastTooLarge)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/broadcast", bytes.NewBufferString(`{"segment": {}, "message": "Promo!"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestBroadcastHandler_GetBroadcast_NotFound(t *testing.T) {
+	// Arrange
+	mockBroadcastService := &mocks.MockBroadcastService{}
+	handler := NewBroadcastHandler(mockBroadcastService)
+
+	router := setupTestRouter()
+	router.GET("/broadcast/:id", handler.GetBroadcast)
+
+	mockBroadcastService.On("GetBroadcast", mock.Anything, "missing").Return(nil, domain.ErrBroadcastNotFound)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/broadcast/missing", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,87 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type CampaignHandler struct {
+	campaignService domain.CampaignService
+}
+
+// NewCampaignHandler creates a new points campaign handler
+func NewCampaignHandler(campaignService domain.CampaignService) *CampaignHandler {
+	return &CampaignHandler{campaignService: campaignService}
+}
+
+// CreateCampaign handles POST /api/campaigns
+func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
+	var req domain.CreatePointsCampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	resp, err := h.campaignService.CreateCampaign(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidCampaign) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListCampaigns handles GET /api/campaigns
+func (h *CampaignHandler) ListCampaigns(c *gin.Context) {
+	campaigns, err := h.campaignService.ListCampaigns(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaigns": campaigns,
+		"count":     len(campaigns),
+	})
+}
+
+// DeleteCampaign handles DELETE /api/campaigns/:id
+func (h *CampaignHandler) DeleteCampaign(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid campaign ID",
+		})
+		return
+	}
+
+	if err := h.campaignService.DeleteCampaign(c.Request.Context(), id); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrCampaignNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Campaign deleted",
+	})
+}
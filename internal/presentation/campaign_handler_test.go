@@ -0,0 +1,94 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestCampaignHandler_CreateCampaign(t *testing.T) {
+	// Arrange
+	mockCampaignService := &mocks.MockCampaignService{}
+	handler := NewCampaignHandler(mockCampaignService)
+
+	router := setupTestRouter()
+	router.POST("/campaigns", handler.CreateCampaign)
+
+	mockCampaignService.On("CreateCampaign", mock.Anything, mock.MatchedBy(func(req *domain.CreatePointsCampaignRequest) bool {
+		return req.Multiplier == 2 && req.Announce != nil && req.Announce.Message == "Poin 2x sore ini!"
+	})).Return(&domain.CreatePointsCampaignResponse{
+		Campaign:    &domain.PointsCampaign{ID: 3, Name: "Happy hour", Multiplier: 2},
+		BroadcastID: "job-1",
+	}, nil)
+
+	// Act
+	body := `{
+		"name": "Happy hour",
+		"starts_at": "2026-10-17T15:00:00+07:00",
+		"ends_at": "2026-10-17T17:00:00+07:00",
+		"multiplier": 2,
+		"announce": {"message": "Poin 2x sore ini!"}
+	}`
+	req, _ := http.NewRequest("POST", "/campaigns", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.CreatePointsCampaignResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.Campaign.ID)
+	assert.Equal(t, "job-1", response.BroadcastID)
+	mockCampaignService.AssertExpectations(t)
+}
+
+func TestCampaignHandler_CreateCampaign_Invalid(t *testing.T) {
+	// Arrange
+	mockCampaignService := &mocks.MockCampaignService{}
+	handler := NewCampaignHandler(mockCampaignService)
+
+	router := setupTestRouter()
+	router.POST("/campaigns", handler.CreateCampaign)
+
+	mockCampaignService.On("CreateCampaign", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: multiplier must be greater than 1 and at most 10", domain.ErrInvalidCampaign))
+
+	// Act
+	req, _ := http.NewRequest("POST", "/campaigns", bytes.NewBufferString(`{"name": "x", "multiplier": 50}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "multiplier")
+}
+
+func TestCampaignHandler_DeleteCampaign_NotFound(t *testing.T) {
+	// Arrange
+	mockCampaignService := &mocks.MockCampaignService{}
+	handler := NewCampaignHandler(mockCampaignService)
+
+	router := setupTestRouter()
+	router.DELETE("/campaigns/:id", handler.DeleteCampaign)
+
+	mockCampaignService.On("DeleteCampaign", mock.Anything, 4).Return(domain.ErrCampaignNotFound)
+
+	// Act
+	req, _ := http.NewRequest("DELETE", "/campaigns/4", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
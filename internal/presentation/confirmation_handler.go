@@ -0,0 +1,52 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type ConfirmationHandler struct {
+	confirmationService domain.ConfirmationService
+}
+
+// NewConfirmationHandler creates a new destructive action confirmation handler
+func NewConfirmationHandler(confirmationService domain.ConfirmationService) *ConfirmationHandler {
+	return &ConfirmationHandler{confirmationService: confirmationService}
+}
+
+// CreateConfirmation handles POST /api/confirmations
+func (h *ConfirmationHandler) CreateConfirmation(c *gin.Context) {
+	var req domain.CreateConfirmationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	confirmation, err := h.confirmationService.CreateConfirmation(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(confirmationStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, confirmation)
+}
+
+func confirmationStatusCode(err error) int {
+	switch err {
+	case domain.ErrInvalidConfirmation:
+		return http.StatusBadRequest
+	case domain.ErrConfirmationRequired:
+		return http.StatusPreconditionRequired
+	case domain.ErrConfirmationFailed:
+		return http.StatusForbidden
+	case domain.ErrConfirmationDisabled:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
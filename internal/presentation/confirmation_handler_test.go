@@ -0,0 +1,192 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestConfirmationHandler_CreateConfirmation(t *testing.T) {
+	// Arrange
+	mockConfirmationService := &mocks.MockConfirmationService{}
+	handler := NewConfirmationHandler(mockConfirmationService)
+
+	router := setupTestRouter()
+	router.POST("/confirmations", handler.CreateConfirmation)
+
+	confirmation := &domain.Confirmation{
+		Token:     "6f1c0e9a-3b0e-4d7e-9a51-2f8f0c7d1e42",
+		Action:    domain.ConfirmActionDeleteSender,
+		Target:    "6281234567890",
+		Method:    domain.ConfirmationMethodWhatsApp,
+		ExpiresAt: "2026-10-16T09:05:00Z",
+	}
+	mockConfirmationService.On("CreateConfirmation", mock.Anything, &domain.CreateConfirmationRequest{
+		Action: domain.ConfirmActionDeleteSender,
+		Target: "6281234567890",
+	}).Return(confirmation, nil)
+
+	// Act
+	body := []byte(`{"action":"delete_sender","target":"6281234567890"}`)
+	req, _ := http.NewRequest("POST", "/confirmations", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response domain.Confirmation
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, confirmation, &response)
+	mockConfirmationService.AssertExpectations(t)
+}
+
+func TestConfirmationHandler_CreateConfirmation_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unknown action", domain.ErrInvalidConfirmation, http.StatusBadRequest},
+		{"no second factor configured", domain.ErrConfirmationDisabled, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfirmationService := &mocks.MockConfirmationService{}
+			handler := NewConfirmationHandler(mockConfirmationService)
+			router := setupTestRouter()
+			router.POST("/confirmations", handler.CreateConfirmation)
+			mockConfirmationService.On("CreateConfirmation", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			body := []byte(`{"action":"drop_everything","target":"all"}`)
+			req, _ := http.NewRequest("POST", "/confirmations", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestConfirmationMiddleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		token, code string
+		err         error
+		wantStatus  int
+	}{
+		{"confirmed", "token-1", "123456", nil, http.StatusOK},
+		{"missing confirmation", "", "", domain.ErrConfirmationRequired, http.StatusPreconditionRequired},
+		{"wrong code", "token-1", "000000", domain.ErrConfirmationFailed, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockConfirmationService := &mocks.MockConfirmationService{}
+			router := setupTestRouter()
+			deleted := false
+			router.DELETE("/senders/:id", ConfirmationMiddleware(mockConfirmationService, domain.ConfirmActionDeleteSender, "id"), func(c *gin.Context) {
+				deleted = true
+				c.Status(http.StatusOK)
+			})
+			mockConfirmationService.On("Confirm", mock.Anything, tt.token, tt.code, domain.ConfirmActionDeleteSender, "6281234567890").Return(tt.err)
+
+			// Act
+			req, _ := http.NewRequest("DELETE", "/senders/6281234567890", nil)
+			if tt.token != "" {
+				req.Header.Set("X-Confirmation-Token", tt.token)
+				req.Header.Set("X-Confirmation-Code", tt.code)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.err == nil, deleted, "the handler only runs once confirmed")
+			mockConfirmationService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestConfirmationMiddleware_ActionOnEverything(t *testing.T) {
+	// Arrange
+	mockConfirmationService := &mocks.MockConfirmationService{}
+	router := setupTestRouter()
+	router.DELETE("/sessions", ConfirmationMiddleware(mockConfirmationService, domain.ConfirmActionClearSessions, ""), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	mockConfirmationService.On("Confirm", mock.Anything, "token-1", "123456", domain.ConfirmActionClearSessions, domain.ConfirmationTargetAll).Return(nil)
+
+	// Act
+	req, _ := http.NewRequest("DELETE", "/sessions", nil)
+	req.Header.Set("X-Confirmation-Token", "token-1")
+	req.Header.Set("X-Confirmation-Code", "123456")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockConfirmationService.AssertExpectations(t)
+}
+
+func TestRouter_DestructiveRoutesNeedConfirmation(t *testing.T) {
+	routes := []struct {
+		path   string
+		action string
+		target string
+	}{
+		{"/messages/3EB0ABC", domain.ConfirmActionRevokeMessage, "3EB0ABC"},
+		{"/senders/6281234567890", domain.ConfirmActionDeleteSender, "6281234567890"},
+		{"/sessions", domain.ConfirmActionClearSessions, domain.ConfirmationTargetAll},
+		{"/members/6281234567890", domain.ConfirmActionEraseMember, "6281234567890"},
+		{"/templates/welcome", domain.ConfirmActionDeleteTemplate, "welcome"},
+		{"/automations/7", domain.ConfirmActionDeleteAutomation, "7"},
+		{"/campaigns/7", domain.ConfirmActionDeleteCampaign, "7"},
+		{"/branches/7", domain.ConfirmActionDeleteBranch, "7"},
+		{"/groups/120363@g.us/participants", domain.ConfirmActionRemoveFromGroup, "120363@g.us"},
+		{"/labels/7", domain.ConfirmActionDeleteLabel, "7"},
+	}
+	// The handlers are never reached, so they need no services
+	newRouter := func() *Router {
+		return NewRouterWithRegistration(&MessageHandler{}, &SenderRegistrationHandler{}, nil, &mocks.MockAuthService{}).
+			WithMemberHandler(&MemberHandler{}).
+			WithTemplateHandler(&TemplateHandler{}).
+			WithAutomationHandler(&AutomationHandler{}).
+			WithCampaignHandler(&CampaignHandler{}).
+			WithBranchHandler(&BranchHandler{}).
+			WithGroupHandler(&GroupHandler{}).
+			WithLabelHandler(&LabelHandler{})
+	}
+	serve := func(r *Router, path string) int {
+		engine := setupTestRouter()
+		r.registerAPIRoutes(engine.Group("/"))
+		req, _ := http.NewRequest("DELETE", path, nil)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	for _, route := range routes {
+		t.Run(route.action, func(t *testing.T) {
+			// Without a confirmation service the route refuses every request
+			assert.Equal(t, http.StatusServiceUnavailable, serve(newRouter(), route.path))
+
+			mockConfirmationService := &mocks.MockConfirmationService{}
+			mockConfirmationService.On("Confirm", mock.Anything, "", "", route.action, route.target).Return(domain.ErrConfirmationRequired)
+			assert.Equal(t, http.StatusPreconditionRequired,
+				serve(newRouter().WithConfirmationHandler(NewConfirmationHandler(mockConfirmationService)), route.path))
+			mockConfirmationService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type DeadLetterHandler struct {
+	deadLetterService domain.DeadLetterService
+}
+
+// NewDeadLetterHandler creates a new dead letter handler
+func NewDeadLetterHandler(deadLetterService domain.DeadLetterService) *DeadLetterHandler {
+	return &DeadLetterHandler{deadLetterService: deadLetterService}
+}
+
+// ListDeadLetters handles GET /api/dead-letters?limit=50&include_requeued=true
+func (h *DeadLetterHandler) ListDeadLetters(c *gin.Context) {
+	var query domain.DeadLetterQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid query: " + err.Error(),
+		})
+		return
+	}
+
+	letters, err := h.deadLetterService.ListDeadLetters(c.Request.Context(), &query)
+	if err != nil {
+		c.JSON(deadLetterStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":        len(letters),
+		"dead_letters": letters,
+	})
+}
+
+// RetryDeadLetter handles POST /api/dead-letters/:id/retry
+func (h *DeadLetterHandler) RetryDeadLetter(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid dead letter ID",
+		})
+		return
+	}
+
+	letter, err := h.deadLetterService.RetryDeadLetter(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(deadLetterStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, letter)
+}
+
+func deadLetterStatusCode(err error) int {
+	switch err {
+	case domain.ErrInvalidDeadLetterQuery:
+		return http.StatusBadRequest
+	case domain.ErrDeadLetterNotFound:
+		return http.StatusNotFound
+	case domain.ErrDeadLetterRequeued:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
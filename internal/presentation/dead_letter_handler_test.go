@@ -0,0 +1,105 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestDeadLetterHandler_ListDeadLetters(t *testing.T) {
+	// Arrange
+	mockDeadLetterService := &mocks.MockDeadLetterService{}
+	handler := NewDeadLetterHandler(mockDeadLetterService)
+
+	router := setupTestRouter()
+	router.GET("/dead-letters", handler.ListDeadLetters)
+
+	letters := []*domain.DeadLetter{{
+		ID:        7,
+		QueueID:   412,
+		Request:   &domain.SendMessageRequest{To: "6281234567890", Message: "Your order is ready"},
+		Status:    "failed",
+		Attempts:  8,
+		LastError: "failed to send message",
+	}}
+	mockDeadLetterService.On("ListDeadLetters", mock.Anything, &domain.DeadLetterQuery{Limit: 20, IncludeRequeued: true}).Return(letters, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/dead-letters?limit=20&include_requeued=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Count       int                  `json:"count"`
+		DeadLetters []*domain.DeadLetter `json:"dead_letters"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, letters, response.DeadLetters)
+	mockDeadLetterService.AssertExpectations(t)
+}
+
+func TestDeadLetterHandler_RetryDeadLetter(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		setup      func(m *mocks.MockDeadLetterService)
+		wantStatus int
+	}{
+		{
+			name: "requeued",
+			id:   "7",
+			setup: func(m *mocks.MockDeadLetterService) {
+				m.On("RetryDeadLetter", mock.Anything, int64(7)).Return(&domain.DeadLetter{ID: 7, RequeueID: 530}, nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name: "already requeued",
+			id:   "7",
+			setup: func(m *mocks.MockDeadLetterService) {
+				m.On("RetryDeadLetter", mock.Anything, int64(7)).Return(nil, domain.ErrDeadLetterRequeued)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "not found",
+			id:   "99",
+			setup: func(m *mocks.MockDeadLetterService) {
+				m.On("RetryDeadLetter", mock.Anything, int64(99)).Return(nil, domain.ErrDeadLetterNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid ID",
+			id:         "abc",
+			setup:      func(m *mocks.MockDeadLetterService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDeadLetterService := &mocks.MockDeadLetterService{}
+			tt.setup(mockDeadLetterService)
+			handler := NewDeadLetterHandler(mockDeadLetterService)
+			router := setupTestRouter()
+			router.POST("/dead-letters/:id/retry", handler.RetryDeadLetter)
+
+			req, _ := http.NewRequest("POST", "/dead-letters/"+tt.id+"/retry", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockDeadLetterService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type DriverHandler struct {
+	driverService domain.DriverService
+}
+
+// NewDriverHandler creates a new driver handler
+func NewDriverHandler(driverService domain.DriverService) *DriverHandler {
+	return &DriverHandler{driverService: driverService}
+}
+
+// CreateDriver handles POST /api/drivers
+func (h *DriverHandler) CreateDriver(c *gin.Context) {
+	var req domain.CreateDriverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	driver, err := h.driverService.CreateDriver(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidDriver, domain.ErrInvalidPhoneNumber:
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, driver)
+}
+
+// ListDrivers handles GET /api/drivers
+func (h *DriverHandler) ListDrivers(c *gin.Context) {
+	drivers, err := h.driverService.ListDrivers(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"drivers": drivers,
+		"count":   len(drivers),
+	})
+}
+
+// DispatchOrder handles POST /api/orders/:id/dispatch
+func (h *DriverHandler) DispatchOrder(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || orderID <= 0 {
+		c.JSON(http.StatusBadRequest, domain.DispatchOrderResponse{
+			Success: false,
+			Message: "Invalid order ID",
+		})
+		return
+	}
+
+	var req domain.DispatchOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.DispatchOrderResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.driverService.DispatchOrder(c.Request.Context(), orderID, &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidDriver:
+			statusCode = http.StatusBadRequest
+		case domain.ErrDriverNotFound, domain.ErrOrderNotFound:
+			statusCode = http.StatusNotFound
+		case domain.ErrMessageSendFailed:
+			statusCode = http.StatusBadGateway
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,150 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestDriverHandler_CreateDriver_Success(t *testing.T) {
+	// Arrange
+	mockDriverService := &mocks.MockDriverService{}
+	handler := NewDriverHandler(mockDriverService)
+
+	router := setupTestRouter()
+	router.POST("/drivers", handler.CreateDriver)
+
+	reqBody := domain.CreateDriverRequest{Name: "Budi", PhoneNumber: "6281234567890"}
+	expected := &domain.Driver{ID: 1, Name: "Budi", PhoneNumber: "6281234567890", IsActive: true}
+	mockDriverService.On("CreateDriver", mock.Anything, &reqBody).Return(expected, nil)
+
+	// Act
+	body, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/drivers", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.Driver
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.ID)
+
+	mockDriverService.AssertExpectations(t)
+}
+
+func TestDriverHandler_CreateDriver_InvalidPhone(t *testing.T) {
+	// Arrange
+	mockDriverService := &mocks.MockDriverService{}
+	handler := NewDriverHandler(mockDriverService)
+
+	router := setupTestRouter()
+	router.POST("/drivers", handler.CreateDriver)
+
+	mockDriverService.On("CreateDriver", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidPhoneNumber)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/drivers", bytes.NewBufferString(`{"name":"Budi","phone_number":"123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockDriverService.AssertExpectations(t)
+}
+
+func TestDriverHandler_DispatchOrder_Success(t *testing.T) {
+	// Arrange
+	mockDriverService := &mocks.MockDriverService{}
+	handler := NewDriverHandler(mockDriverService)
+
+	router := setupTestRouter()
+	router.POST("/orders/:id/dispatch", handler.DispatchOrder)
+
+	expected := &domain.DispatchOrderResponse{
+		Success:   true,
+		OrderID:   42,
+		DriverID:  7,
+		Status:    "out_for_delivery",
+		MessageID: "msg-1",
+	}
+	mockDriverService.On("DispatchOrder", mock.Anything, 42, &domain.DispatchOrderRequest{DriverID: 7}).Return(expected, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/orders/42/dispatch", bytes.NewBufferString(`{"driver_id":7}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.DispatchOrderResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, "msg-1", response.MessageID)
+
+	mockDriverService.AssertExpectations(t)
+}
+
+func TestDriverHandler_DispatchOrder_InvalidOrderID(t *testing.T) {
+	// Arrange
+	mockDriverService := &mocks.MockDriverService{}
+	handler := NewDriverHandler(mockDriverService)
+
+	router := setupTestRouter()
+	router.POST("/orders/:id/dispatch", handler.DispatchOrder)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/orders/abc/dispatch", bytes.NewBufferString(`{"driver_id":7}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockDriverService.AssertNotCalled(t, "DispatchOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDriverHandler_DispatchOrder_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"driver missing", domain.ErrDriverNotFound, http.StatusNotFound},
+		{"order missing", domain.ErrOrderNotFound, http.StatusNotFound},
+		{"notification failed", domain.ErrMessageSendFailed, http.StatusBadGateway},
+		{"db failure", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDriverService := &mocks.MockDriverService{}
+			handler := NewDriverHandler(mockDriverService)
+
+			router := setupTestRouter()
+			router.POST("/orders/:id/dispatch", handler.DispatchOrder)
+
+			mockDriverService.On("DispatchOrder", mock.Anything, 42, mock.Anything).
+				Return(&domain.DispatchOrderResponse{Success: false, Message: tt.err.Error()}, tt.err)
+
+			req, _ := http.NewRequest("POST", "/orders/42/dispatch", bytes.NewBufferString(`{"driver_id":7}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
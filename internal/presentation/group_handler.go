@@ -0,0 +1,152 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type GroupHandler struct {
+	groupService domain.GroupService
+}
+
+// NewGroupHandler creates a new group management handler
+func NewGroupHandler(groupService domain.GroupService) *GroupHandler {
+	return &GroupHandler{groupService: groupService}
+}
+
+// ListGroups handles GET /api/groups; ?from= picks the sender
+func (h *GroupHandler) ListGroups(c *gin.Context) {
+	groups, err := h.groupService.ListGroups(c.Request.Context(), c.Query("from"))
+	if err != nil {
+		c.JSON(groupStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"groups": groups,
+		"count":  len(groups),
+	})
+}
+
+// CreateGroup handles POST /api/groups
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	var req domain.CreateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	group, err := h.groupService.CreateGroup(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(groupStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+// AddParticipants handles POST /api/groups/:jid/participants
+func (h *GroupHandler) AddParticipants(c *gin.Context) {
+	h.updateParticipants(c, h.groupService.AddParticipants)
+}
+
+// RemoveParticipants handles DELETE /api/groups/:jid/participants
+func (h *GroupHandler) RemoveParticipants(c *gin.Context) {
+	h.updateParticipants(c, h.groupService.RemoveParticipants)
+}
+
+// updateParticipants binds the participants of a group and applies update to
+// them. WhatsApp can refuse some participants and accept others, so a 200 lists
+// the outcome for each.
+func (h *GroupHandler) updateParticipants(c *gin.Context, update func(context.Context, string, *domain.UpdateGroupParticipantsRequest) (*domain.UpdateGroupParticipantsResponse, error)) {
+	var req domain.UpdateGroupParticipantsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := update(c.Request.Context(), c.Param("jid"), &req)
+	if err != nil {
+		c.JSON(groupStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetInviteLink handles GET /api/groups/:jid/invite-link; ?from= picks the sender
+func (h *GroupHandler) GetInviteLink(c *gin.Context) {
+	h.inviteLink(c, false)
+}
+
+// ResetInviteLink handles POST /api/groups/:jid/invite-link/reset, revoking
+// the old link; ?from= picks the sender
+func (h *GroupHandler) ResetInviteLink(c *gin.Context) {
+	h.inviteLink(c, true)
+}
+
+func (h *GroupHandler) inviteLink(c *gin.Context, reset bool) {
+	link, err := h.groupService.GetInviteLink(c.Request.Context(), c.Param("jid"), c.Query("from"), reset)
+	if err != nil {
+		c.JSON(groupStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// SetDescription handles PUT /api/groups/:jid/description
+func (h *GroupHandler) SetDescription(c *gin.Context) {
+	var req domain.SetGroupDescriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.groupService.SetDescription(c.Request.Context(), c.Param("jid"), &req); err != nil {
+		c.JSON(groupStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Group description updated",
+	})
+}
+
+// groupStatusCode maps group errors to HTTP status codes
+func groupStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidGroup):
+		return http.StatusBadRequest
+	case err == domain.ErrGroupNotFound:
+		return http.StatusNotFound
+	case err == domain.ErrNotGroupAdmin:
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrSenderNotFound):
+		return http.StatusNotFound
+	case err == domain.ErrWhatsAppNotConnected:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
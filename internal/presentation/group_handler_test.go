@@ -0,0 +1,220 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestGroupHandler_ListGroups(t *testing.T) {
+	// Arrange
+	mockGroupService := &mocks.MockGroupService{}
+	handler := NewGroupHandler(mockGroupService)
+
+	router := setupTestRouter()
+	router.GET("/groups", handler.ListGroups)
+
+	mockGroupService.On("ListGroups", mock.Anything, "sender-1").Return([]*domain.Group{
+		{JID: "120363025246125486@g.us", Name: "VIP Customers", ParticipantCount: 12},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/groups?from=sender-1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Groups []domain.Group `json:"groups"`
+		Count  int            `json:"count"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "VIP Customers", response.Groups[0].Name)
+}
+
+func TestGroupHandler_CreateGroup(t *testing.T) {
+	// Arrange
+	mockGroupService := &mocks.MockGroupService{}
+	handler := NewGroupHandler(mockGroupService)
+
+	router := setupTestRouter()
+	router.POST("/groups", handler.CreateGroup)
+
+	mockGroupService.On("CreateGroup", mock.Anything, &domain.CreateGroupRequest{
+		Name:         "VIP Customers",
+		Participants: []string{"6281234567890"},
+	}).Return(&domain.Group{JID: "120363025246125486@g.us", Name: "VIP Customers", ParticipantCount: 2}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/groups", bytes.NewBufferString(`{"name": "VIP Customers", "participants": ["6281234567890"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "120363025246125486@g.us")
+}
+
+func TestGroupHandler_CreateGroup_Invalid(t *testing.T) {
+	// Arrange
+	mockGroupService := &mocks.MockGroupService{}
+	handler := NewGroupHandler(mockGroupService)
+
+	router := setupTestRouter()
+	router.POST("/groups", handler.CreateGroup)
+
+	mockGroupService.On("CreateGroup", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: at least one participant is required", domain.ErrInvalidGroup))
+
+	// Act
+	req, _ := http.NewRequest("POST", "/groups", bytes.NewBufferString(`{"name": "VIP Customers"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGroupHandler_AddParticipants(t *testing.T) {
+	// Arrange
+	mockGroupService := &mocks.MockGroupService{}
+	handler := NewGroupHandler(mockGroupService)
+
+	router := setupTestRouter()
+	router.POST("/groups/:jid/participants", handler.AddParticipants)
+
+	mockGroupService.On("AddParticipants", mock.Anything, "120363025246125486@g.us", &domain.UpdateGroupParticipantsRequest{
+		Participants: []string{"6281234567890", "6289876543210"},
+	}).Return(&domain.UpdateGroupParticipantsResponse{
+		GroupJID: "120363025246125486@g.us",
+		Participants: []*domain.GroupParticipantChange{
+			{PhoneNumber: "6281234567890", Success: true},
+			{PhoneNumber: "6289876543210", Error: "already a participant of the group"},
+		},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/groups/120363025246125486@g.us/participants",
+		bytes.NewBufferString(`{"participants": ["6281234567890", "6289876543210"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.UpdateGroupParticipantsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Participants, 2)
+	assert.False(t, response.Participants[1].Success)
+}
+
+func TestGroupHandler_RemoveParticipants_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"not an admin", domain.ErrNotGroupAdmin, http.StatusForbidden},
+		{"group not found", domain.ErrGroupNotFound, http.StatusNotFound},
+		{"not connected", domain.ErrWhatsAppNotConnected, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockGroupService := &mocks.MockGroupService{}
+			handler := NewGroupHandler(mockGroupService)
+
+			router := setupTestRouter()
+			router.DELETE("/groups/:jid/participants", handler.RemoveParticipants)
+
+			mockGroupService.On("RemoveParticipants", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("DELETE", "/groups/120363025246125486@g.us/participants",
+				bytes.NewBufferString(`{"participants": ["6281234567890"]}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestGroupHandler_InviteLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		reset  bool
+	}{
+		{"current link", "GET", "/groups/120363025246125486@g.us/invite-link?from=sender-1", false},
+		{"reset link", "POST", "/groups/120363025246125486@g.us/invite-link/reset?from=sender-1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockGroupService := &mocks.MockGroupService{}
+			handler := NewGroupHandler(mockGroupService)
+
+			router := setupTestRouter()
+			router.GET("/groups/:jid/invite-link", handler.GetInviteLink)
+			router.POST("/groups/:jid/invite-link/reset", handler.ResetInviteLink)
+
+			link := &domain.GroupInviteLink{GroupJID: "120363025246125486@g.us", Link: "https://chat.whatsapp.com/AbCdEfGhIjK"}
+			mockGroupService.On("GetInviteLink", mock.Anything, "120363025246125486@g.us", "sender-1", tt.reset).Return(link, nil)
+
+			// Act
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			var response domain.GroupInviteLink
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, link.Link, response.Link)
+			mockGroupService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGroupHandler_SetDescription_NotAdmin(t *testing.T) {
+	// Arrange
+	mockGroupService := &mocks.MockGroupService{}
+	handler := NewGroupHandler(mockGroupService)
+
+	router := setupTestRouter()
+	router.PUT("/groups/:jid/description", handler.SetDescription)
+
+	mockGroupService.On("SetDescription", mock.Anything, "120363025246125486@g.us",
+		&domain.SetGroupDescriptionRequest{Description: "Promo khusus member Gold"}).Return(domain.ErrNotGroupAdmin)
+
+	// Act
+	req, _ := http.NewRequest("PUT", "/groups/120363025246125486@g.us/description",
+		bytes.NewBufferString(`{"description": "Promo khusus member Gold"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockGroupService.AssertExpectations(t)
+}
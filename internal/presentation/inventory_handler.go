@@ -0,0 +1,152 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type InventoryHandler struct {
+	inventoryService domain.InventoryService
+}
+
+// NewInventoryHandler creates a new supplies inventory handler
+func NewInventoryHandler(inventoryService domain.InventoryService) *InventoryHandler {
+	return &InventoryHandler{inventoryService: inventoryService}
+}
+
+// ListSupplies handles GET /api/supplies
+func (h *InventoryHandler) ListSupplies(c *gin.Context) {
+	supplies, err := h.inventoryService.ListSupplies(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"supplies": supplies,
+		"count":    len(supplies),
+	})
+}
+
+// CreateSupply handles POST /api/supplies
+func (h *InventoryHandler) CreateSupply(c *gin.Context) {
+	var req domain.CreateSupplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	supply, err := h.inventoryService.CreateSupply(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(inventoryStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, supply)
+}
+
+// AdjustStock handles POST /api/supplies/:id/adjustments
+func (h *InventoryHandler) AdjustStock(c *gin.Context) {
+	id, ok := pathID(c, "Invalid supply ID")
+	if !ok {
+		return
+	}
+
+	var req domain.AdjustStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	supply, err := h.inventoryService.AdjustStock(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(inventoryStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, supply)
+}
+
+// GetItemUsage handles GET /api/items/:id/supplies
+func (h *InventoryHandler) GetItemUsage(c *gin.Context) {
+	id, ok := pathID(c, "Invalid item ID")
+	if !ok {
+		return
+	}
+
+	usage, err := h.inventoryService.GetItemUsage(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(inventoryStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// SetItemUsage handles PUT /api/items/:id/supplies
+func (h *InventoryHandler) SetItemUsage(c *gin.Context) {
+	id, ok := pathID(c, "Invalid item ID")
+	if !ok {
+		return
+	}
+
+	var req domain.SetItemSupplyUsageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	usage, err := h.inventoryService.SetItemUsage(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(inventoryStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// pathID reads the :id path parameter, answering 400 with message when it is
+// not a positive ID
+func pathID(c *gin.Context, message string) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": message,
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// inventoryStatusCode maps inventory errors to HTTP status codes
+func inventoryStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSupply):
+		return http.StatusBadRequest
+	case err == domain.ErrSupplyNotFound, err == domain.ErrItemNotFound:
+		return http.StatusNotFound
+	case err == domain.ErrSupplyExists, err == domain.ErrStockNegative:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
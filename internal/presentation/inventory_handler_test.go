@@ -0,0 +1,143 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestInventoryHandler_ListSupplies(t *testing.T) {
+	// Arrange
+	mockInventoryService := &mocks.MockInventoryService{}
+	handler := NewInventoryHandler(mockInventoryService)
+
+	router := setupTestRouter()
+	router.GET("/supplies", handler.ListSupplies)
+
+	mockInventoryService.On("ListSupplies", mock.Anything).Return([]*domain.Supply{
+		{ID: 1, Name: "Deterjen Cair", Unit: "liter", Stock: 3, LowStockThreshold: 5, Low: true},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/supplies", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Supplies []domain.Supply `json:"supplies"`
+		Count    int             `json:"count"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.True(t, response.Supplies[0].Low)
+}
+
+func TestInventoryHandler_CreateSupply_Exists(t *testing.T) {
+	// Arrange
+	mockInventoryService := &mocks.MockInventoryService{}
+	handler := NewInventoryHandler(mockInventoryService)
+
+	router := setupTestRouter()
+	router.POST("/supplies", handler.CreateSupply)
+
+	mockInventoryService.On("CreateSupply", mock.Anything, mock.Anything).Return(nil, domain.ErrSupplyExists)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/supplies", bytes.NewBufferString(`{"name": "Deterjen Cair", "unit": "liter"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestInventoryHandler_AdjustStock(t *testing.T) {
+	// Arrange
+	mockInventoryService := &mocks.MockInventoryService{}
+	handler := NewInventoryHandler(mockInventoryService)
+
+	router := setupTestRouter()
+	router.POST("/supplies/:id/adjustments", handler.AdjustStock)
+
+	mockInventoryService.On("AdjustStock", mock.Anything, 1, &domain.AdjustStockRequest{Change: 20, Note: "delivery"}).
+		Return(&domain.Supply{ID: 1, Name: "Deterjen Cair", Unit: "liter", Stock: 23, LowStockThreshold: 5}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/supplies/1/adjustments", bytes.NewBufferString(`{"change": 20, "note": "delivery"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"stock":23`)
+}
+
+func TestInventoryHandler_AdjustStock_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid id", "/supplies/abc/adjustments", nil, http.StatusBadRequest},
+		{"not found", "/supplies/9/adjustments", domain.ErrSupplyNotFound, http.StatusNotFound},
+		{"below zero", "/supplies/1/adjustments", domain.ErrStockNegative, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockInventoryService := &mocks.MockInventoryService{}
+			handler := NewInventoryHandler(mockInventoryService)
+
+			router := setupTestRouter()
+			router.POST("/supplies/:id/adjustments", handler.AdjustStock)
+
+			mockInventoryService.On("AdjustStock", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("POST", tt.path, bytes.NewBufferString(`{"change": -50}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestInventoryHandler_SetItemUsage(t *testing.T) {
+	// Arrange
+	mockInventoryService := &mocks.MockInventoryService{}
+	handler := NewInventoryHandler(mockInventoryService)
+
+	router := setupTestRouter()
+	router.PUT("/items/:id/supplies", handler.SetItemUsage)
+
+	usage := []domain.SupplyUsage{{SupplyID: 1, PerKilo: 0.05}}
+	mockInventoryService.On("SetItemUsage", mock.Anything, 3, &domain.SetItemSupplyUsageRequest{Supplies: usage}).
+		Return(&domain.ItemSupplyUsage{ItemID: 3, Supplies: usage}, nil)
+
+	// Act
+	req, _ := http.NewRequest("PUT", "/items/3/supplies", bytes.NewBufferString(`{"supplies": [{"supply_id": 1, "per_kilo": 0.05}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"per_kilo":0.05`)
+}
@@ -0,0 +1,183 @@
+package presentation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type LabelHandler struct {
+	labelService domain.LabelService
+}
+
+// NewLabelHandler creates a new WhatsApp Business label handler
+func NewLabelHandler(labelService domain.LabelService) *LabelHandler {
+	return &LabelHandler{labelService: labelService}
+}
+
+// ListLabels handles GET /api/labels; ?from= picks the sender
+func (h *LabelHandler) ListLabels(c *gin.Context) {
+	labels, err := h.labelService.ListLabels(c.Request.Context(), c.Query("from"))
+	h.respondLabels(c, labels, err)
+}
+
+// SyncLabels handles POST /api/labels/sync; ?from= picks the sender
+func (h *LabelHandler) SyncLabels(c *gin.Context) {
+	labels, err := h.labelService.SyncLabels(c.Request.Context(), c.Query("from"))
+	h.respondLabels(c, labels, err)
+}
+
+func (h *LabelHandler) respondLabels(c *gin.Context, labels []*domain.Label, err error) {
+	if err != nil {
+		c.JSON(labelStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"labels": labels,
+		"count":  len(labels),
+	})
+}
+
+// CreateLabel handles POST /api/labels
+func (h *LabelHandler) CreateLabel(c *gin.Context) {
+	var req domain.SaveLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	label, err := h.labelService.CreateLabel(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(labelStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, label)
+}
+
+// UpdateLabel handles PUT /api/labels/:id
+func (h *LabelHandler) UpdateLabel(c *gin.Context) {
+	var req domain.SaveLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	label, err := h.labelService.UpdateLabel(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		c.JSON(labelStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+// DeleteLabel handles DELETE /api/labels/:id; ?from= picks the sender
+func (h *LabelHandler) DeleteLabel(c *gin.Context) {
+	if err := h.labelService.DeleteLabel(c.Request.Context(), c.Query("from"), c.Param("id")); err != nil {
+		c.JSON(labelStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Label deleted",
+	})
+}
+
+// ListLabelChats handles GET /api/labels/:id/chats; ?from= picks the sender
+func (h *LabelHandler) ListLabelChats(c *gin.Context) {
+	chats, err := h.labelService.ListLabelChats(c.Request.Context(), c.Query("from"), c.Param("id"))
+	if err != nil {
+		c.JSON(labelStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"chats": chats,
+		"count": len(chats),
+	})
+}
+
+// LabelChat handles POST /api/labels/:id/chats
+func (h *LabelHandler) LabelChat(c *gin.Context) {
+	h.setLabelChat(c, h.labelService.LabelChat, "Label added to chat")
+}
+
+// UnlabelChat handles DELETE /api/labels/:id/chats
+func (h *LabelHandler) UnlabelChat(c *gin.Context) {
+	h.setLabelChat(c, h.labelService.UnlabelChat, "Label removed from chat")
+}
+
+func (h *LabelHandler) setLabelChat(c *gin.Context, update func(context.Context, string, *domain.LabelChatRequest) error, message string) {
+	var req domain.LabelChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if err := update(c.Request.Context(), c.Param("id"), &req); err != nil {
+		c.JSON(labelStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+	})
+}
+
+// AutoLabel handles POST /api/labels/auto, which applies the automatic labels
+// now; ?from= picks the sender
+func (h *LabelHandler) AutoLabel(c *gin.Context) {
+	result, err := h.labelService.AutoLabel(c.Request.Context(), c.Query("from"))
+	if err != nil {
+		c.JSON(labelStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// labelStatusCode maps label errors to HTTP status codes
+func labelStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidLabel):
+		return http.StatusBadRequest
+	case err == domain.ErrLabelNotFound:
+		return http.StatusNotFound
+	case err == domain.ErrLabelExists:
+		return http.StatusConflict
+	case err == domain.ErrNotBusinessAccount:
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrSenderNotFound):
+		return http.StatusNotFound
+	case err == domain.ErrWhatsAppNotConnected:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,121 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestLabelHandler_ListLabels(t *testing.T) {
+	// Arrange
+	mockLabelService := &mocks.MockLabelService{}
+	handler := NewLabelHandler(mockLabelService)
+
+	router := setupTestRouter()
+	router.GET("/labels", handler.ListLabels)
+
+	labels := []*domain.Label{{ID: "1", Name: "VIP", Color: 2}}
+	mockLabelService.On("ListLabels", mock.Anything, "6281111111111").Return(labels, nil)
+	mockLabelService.On("ListLabels", mock.Anything, "6282222222222").Return(nil, domain.ErrNotBusinessAccount)
+
+	// Act
+	listed := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/labels?from=6281111111111", nil)
+	router.ServeHTTP(listed, req)
+
+	personal := httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/labels?from=6282222222222", nil)
+	router.ServeHTTP(personal, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, listed.Code)
+	var response struct {
+		Count  int             `json:"count"`
+		Labels []*domain.Label `json:"labels"`
+	}
+	assert.NoError(t, json.Unmarshal(listed.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, labels, response.Labels)
+	assert.Equal(t, http.StatusUnprocessableEntity, personal.Code)
+	mockLabelService.AssertExpectations(t)
+}
+
+func TestLabelHandler_CreateLabel(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"created", nil, http.StatusCreated},
+		{"invalid", domain.ErrInvalidLabel, http.StatusBadRequest},
+		{"duplicate name", domain.ErrLabelExists, http.StatusConflict},
+		{"sender offline", domain.ErrWhatsAppNotConnected, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockLabelService := &mocks.MockLabelService{}
+			handler := NewLabelHandler(mockLabelService)
+
+			router := setupTestRouter()
+			router.POST("/labels", handler.CreateLabel)
+
+			body := &domain.SaveLabelRequest{Name: "Pending pickup"}
+			if tt.err != nil {
+				mockLabelService.On("CreateLabel", mock.Anything, body).Return(nil, tt.err)
+			} else {
+				mockLabelService.On("CreateLabel", mock.Anything, body).Return(&domain.Label{ID: "4", Name: "Pending pickup"}, nil)
+			}
+
+			// Act
+			payload, _ := json.Marshal(body)
+			req, _ := http.NewRequest("POST", "/labels", bytes.NewBuffer(payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockLabelService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLabelHandler_LabelChat(t *testing.T) {
+	// Arrange
+	mockLabelService := &mocks.MockLabelService{}
+	handler := NewLabelHandler(mockLabelService)
+
+	router := setupTestRouter()
+	router.POST("/labels/:id/chats", handler.LabelChat)
+	router.DELETE("/labels/:id/chats", handler.UnlabelChat)
+
+	body := &domain.LabelChatRequest{Chat: "6281234567890"}
+	mockLabelService.On("LabelChat", mock.Anything, "1", body).Return(nil)
+	mockLabelService.On("UnlabelChat", mock.Anything, "9", body).Return(domain.ErrLabelNotFound)
+
+	// Act
+	payload, _ := json.Marshal(body)
+	labeled := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/labels/1/chats", bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(labeled, req)
+
+	missing := httptest.NewRecorder()
+	req, _ = http.NewRequest("DELETE", "/labels/9/chats", bytes.NewBuffer(payload))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(missing, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, labeled.Code)
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	mockLabelService.AssertExpectations(t)
+}
@@ -0,0 +1,37 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type LocationHandler struct {
+	locationService domain.LocationService
+}
+
+// NewLocationHandler creates a new member location handler
+func NewLocationHandler(locationService domain.LocationService) *LocationHandler {
+	return &LocationHandler{locationService: locationService}
+}
+
+// GetMemberLocation handles GET /api/members/:phone/location
+func (h *LocationHandler) GetMemberLocation(c *gin.Context) {
+	location, err := h.locationService.GetMemberLocation(c.Request.Context(), c.Param("phone"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidPhoneNumber:
+			statusCode = http.StatusBadRequest
+		case domain.ErrLocationNotFound:
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
@@ -0,0 +1,75 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestLocationHandler_GetMemberLocation_Success(t *testing.T) {
+	// Arrange
+	mockLocationService := &mocks.MockLocationService{}
+	handler := NewLocationHandler(mockLocationService)
+
+	router := setupTestRouter()
+	router.GET("/members/:phone/location", handler.GetMemberLocation)
+
+	expected := &domain.MemberLocation{
+		PhoneNumber: "6281234567890",
+		Latitude:    -6.914744,
+		Longitude:   107.609810,
+		MapsURL:     "https://www.google.com/maps/search/?api=1&query=-6.914744,107.609810",
+	}
+	mockLocationService.On("GetMemberLocation", mock.Anything, "6281234567890").Return(expected, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/members/6281234567890/location", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.MemberLocation
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, -6.914744, response.Latitude)
+	assert.Equal(t, expected.MapsURL, response.MapsURL)
+
+	mockLocationService.AssertExpectations(t)
+}
+
+func TestLocationHandler_GetMemberLocation_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"no pin shared", domain.ErrLocationNotFound, http.StatusNotFound},
+		{"bad phone", domain.ErrInvalidPhoneNumber, http.StatusBadRequest},
+		{"db failure", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLocationService := &mocks.MockLocationService{}
+			handler := NewLocationHandler(mockLocationService)
+
+			router := setupTestRouter()
+			router.GET("/members/:phone/location", handler.GetMemberLocation)
+
+			mockLocationService.On("GetMemberLocation", mock.Anything, "123").Return(nil, tt.err)
+
+			req, _ := http.NewRequest("GET", "/members/123/location", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type MemberHandler struct {
+	memberService domain.MemberService
+}
+
+// NewMemberHandler creates a new member handler
+func NewMemberHandler(memberService domain.MemberService) *MemberHandler {
+	return &MemberHandler{memberService: memberService}
+}
+
+// EraseMember handles DELETE /api/members/:phone
+func (h *MemberHandler) EraseMember(c *gin.Context) {
+	if err := h.memberService.EraseMember(c.Request.Context(), c.Param("phone")); err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidPhoneNumber:
+			statusCode = http.StatusBadRequest
+		case domain.ErrMemberNotFound:
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Member data erased",
+	})
+}
@@ -0,0 +1,62 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestMemberHandler_EraseMember_Success(t *testing.T) {
+	// Arrange
+	mockMemberService := &mocks.MockMemberService{}
+	handler := NewMemberHandler(mockMemberService)
+
+	router := setupTestRouter()
+	router.DELETE("/members/:phone", handler.EraseMember)
+
+	mockMemberService.On("EraseMember", mock.Anything, "6281234567890").Return(nil)
+
+	// Act
+	req, _ := http.NewRequest("DELETE", "/members/6281234567890", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMemberService.AssertExpectations(t)
+}
+
+func TestMemberHandler_EraseMember_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"not a member", domain.ErrMemberNotFound, http.StatusNotFound},
+		{"bad phone", domain.ErrInvalidPhoneNumber, http.StatusBadRequest},
+		{"db failure", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMemberService := &mocks.MockMemberService{}
+			handler := NewMemberHandler(mockMemberService)
+
+			router := setupTestRouter()
+			router.DELETE("/members/:phone", handler.EraseMember)
+
+			mockMemberService.On("EraseMember", mock.Anything, mock.Anything).Return(tt.err)
+
+			req, _ := http.NewRequest("DELETE", "/members/6281234567890", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
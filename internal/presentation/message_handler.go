@@ -1,15 +1,30 @@
 package presentation
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/wa-serv/internal/domain"
 )
 
+// maxIdempotencyKeyLength matches the idempotency_keys.idem_key column
+const maxIdempotencyKeyLength = 255
+
 type MessageHandler struct {
 	messageService domain.MessageService
 	authService    domain.AuthService
+	idempotency    domain.IdempotencyRepository // optional; enables the Idempotency-Key header
+	jobs           domain.SendJobService        // optional; enables async=true on send endpoints
+	batch          domain.BatchSendService      // optional; enables POST /api/send-messages
 }
 
 // NewMessageHandler creates a new message handler
@@ -20,7 +35,78 @@ func NewMessageHandler(messageService domain.MessageService, authService domain.
 	}
 }
 
-// SendMessage handles POST /api/send-message
+// WithIdempotency makes SendMessage honor the Idempotency-Key header: a repeated
+// request with the same key gets the first result instead of sending again
+func (h *MessageHandler) WithIdempotency(idempotency domain.IdempotencyRepository) *MessageHandler {
+	h.idempotency = idempotency
+	return h
+}
+
+// WithSendJobs lets send endpoints take async=true: the send runs in the
+// background and the response is a job to poll with GetSendJob
+func (h *MessageHandler) WithSendJobs(jobs domain.SendJobService) *MessageHandler {
+	h.jobs = jobs
+	return h
+}
+
+// WithBatchSend enables SendMessages, which sends many messages in one call
+func (h *MessageHandler) WithBatchSend(batch domain.BatchSendService) *MessageHandler {
+	h.batch = batch
+	return h
+}
+
+// startAsync starts send as a background job and answers with the job when the
+// request asks for async=true. It reports whether it answered the request.
+func (h *MessageHandler) startAsync(c *gin.Context, kind string, send func(ctx context.Context) (*domain.SendMessageResponse, error)) bool {
+	if !h.wantsAsync(c) {
+		return false
+	}
+
+	job, err := h.jobs.StartSend(c.Request.Context(), kind, send)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, domain.SendMessageResponse{
+			Success: false,
+			Message: "Failed to start send job: " + err.Error(),
+		})
+		return true
+	}
+	c.JSON(http.StatusAccepted, job)
+	return true
+}
+
+// wantsAsync reports whether the request asks for async=true and send jobs are
+// enabled
+func (h *MessageHandler) wantsAsync(c *gin.Context) bool {
+	async, _ := strconv.ParseBool(c.Query("async"))
+	return async && h.jobs != nil
+}
+
+// GetSendJob handles GET /api/jobs/:id
+func (h *MessageHandler) GetSendJob(c *gin.Context) {
+	if h.jobs == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": domain.ErrSendJobNotFound.Error(),
+		})
+		return
+	}
+
+	job, err := h.jobs.GetSendJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrSendJobNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// SendMessage handles POST /api/send-message. With async=true the message is
+// sent in the background; an Idempotency-Key stays reserved until it is sent.
 func (h *MessageHandler) SendMessage(c *gin.Context) {
 	var req domain.SendMessageRequest
 
@@ -33,21 +119,582 @@ func (h *MessageHandler) SendMessage(c *gin.Context) {
 		return
 	}
 
-	// Send message using service
-	response, err := h.messageService.SendMessage(c.Request.Context(), &req)
+	key := strings.TrimSpace(c.GetHeader("Idempotency-Key"))
+	if key == "" || h.idempotency == nil {
+		if h.startAsync(c, "message", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+			return h.messageService.SendMessage(ctx, &req)
+		}) {
+			return
+		}
+		statusCode, response := h.sendMessage(c.Request.Context(), &req)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Idempotency-Key is longer than 255 characters",
+		})
+		return
+	}
+	fingerprint := requestFingerprint(&req)
+	existing, err := h.idempotency.Reserve(key, fingerprint)
+	if err != nil {
+		// Sending without the key could double-send, so let the client retry
+		log.Printf("Failed to reserve idempotency key: %v", err)
+		c.JSON(http.StatusServiceUnavailable, domain.SendMessageResponse{
+			Success: false,
+			Message: "Could not check the Idempotency-Key, please retry",
+		})
+		return
+	}
+	if existing != nil {
+		replayIdempotent(c, existing, fingerprint)
+		return
+	}
+
+	// An async send keeps the key reserved until its job finishes, so a retry
+	// meanwhile is refused instead of sending again
+	if h.wantsAsync(c) {
+		job, err := h.jobs.StartSend(c.Request.Context(), "message", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+			response, err := h.messageService.SendMessage(ctx, &req)
+			h.settleIdempotencyKey(key, sendMessageStatus(response, err), response)
+			return response, err
+		})
+		if err != nil {
+			if err := h.idempotency.Release(key); err != nil {
+				log.Printf("Failed to release idempotency key: %v", err)
+			}
+			c.JSON(http.StatusInternalServerError, domain.SendMessageResponse{
+				Success: false,
+				Message: "Failed to start send job: " + err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusAccepted, job)
+		return
+	}
+
+	statusCode, response := h.sendMessage(c.Request.Context(), &req)
+	h.settleIdempotencyKey(key, statusCode, response)
+	c.JSON(statusCode, response)
+}
+
+// settleIdempotencyKey stores the result of the send made under key, or
+// releases the key when nothing was sent so a retry with it tries again
+func (h *MessageHandler) settleIdempotencyKey(key string, statusCode int, response *domain.SendMessageResponse) {
+	if response == nil || statusCode >= http.StatusInternalServerError && len(response.IDs) == 0 {
+		if err := h.idempotency.Release(key); err != nil {
+			log.Printf("Failed to release idempotency key: %v", err)
+		}
+	} else if body, err := json.Marshal(response); err == nil {
+		if err := h.idempotency.Complete(key, statusCode, body); err != nil {
+			log.Printf("Failed to store idempotency key result: %v", err)
+		}
+	}
+}
+
+// SendMessages handles POST /api/send-messages
+func (h *MessageHandler) SendMessages(c *gin.Context) {
+	var req domain.SendMessagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.batch.SendMessages(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidBatch:
+			statusCode = http.StatusBadRequest
+		case domain.ErrBatchTooLarge:
+			statusCode = http.StatusRequestEntityTooLarge
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// sendMessage sends req and returns the HTTP status and body to answer with
+func (h *MessageHandler) sendMessage(ctx context.Context, req *domain.SendMessageRequest) (int, *domain.SendMessageResponse) {
+	response, err := h.messageService.SendMessage(ctx, req)
+	return sendMessageStatus(response, err), response
+}
+
+// sendMessageStatus maps the outcome of a send to its HTTP status code
+func sendMessageStatus(response *domain.SendMessageResponse, err error) int {
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 
 		// Map domain errors to HTTP status codes
 		switch err {
-		case domain.ErrWhatsAppNotConnected:
+		case domain.ErrWhatsAppNotConnected, domain.ErrNoActiveSender:
 			statusCode = http.StatusServiceUnavailable
-		case domain.ErrInvalidPhoneNumber:
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidInteractive, domain.ErrInvalidReply, domain.ErrInvalidDisappearing, domain.ErrInvalidSenderPool:
 			statusCode = http.StatusBadRequest
-		case domain.ErrMessageSendFailed:
+		case domain.ErrSenderPoolNotFound:
+			statusCode = http.StatusNotFound
+		case domain.ErrRecipientSuppressed:
+			statusCode = http.StatusForbidden
+		case domain.ErrDuplicateSend, domain.ErrSenderPaused:
+			statusCode = http.StatusConflict
+		case domain.ErrSenderQuotaExceeded:
+			statusCode = http.StatusTooManyRequests
+		case domain.ErrMessageSendFailed, domain.ErrMessagePartlySent:
 			statusCode = http.StatusInternalServerError
 		}
 
+		return statusCode
+	}
+
+	if response.Queued {
+		return http.StatusAccepted
+	}
+	return http.StatusOK
+}
+
+// replayIdempotent answers a request whose Idempotency-Key is already in use
+func replayIdempotent(c *gin.Context, existing *domain.IdempotencyRecord, fingerprint string) {
+	switch {
+	case existing.Fingerprint != fingerprint:
+		c.JSON(http.StatusUnprocessableEntity, domain.SendMessageResponse{
+			Success: false,
+			Message: "Idempotency-Key was already used for a different request",
+		})
+	case !existing.Completed:
+		c.JSON(http.StatusConflict, domain.SendMessageResponse{
+			Success: false,
+			Message: "A request with this Idempotency-Key is still being processed",
+		})
+	default:
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(existing.StatusCode, "application/json; charset=utf-8", existing.Response)
+	}
+}
+
+// requestFingerprint hashes req so a reused key can be told apart from a retry
+func requestFingerprint(req *domain.SendMessageRequest) string {
+	body, _ := json.Marshal(req)
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// maxMediaUploadBytes bounds multipart image and audio uploads; the service
+// enforces the exact limits
+const maxMediaUploadBytes = 16<<20 + 1
+
+// SendImage handles POST /api/send-image. It accepts JSON with a base64 image or
+// a multipart form with an "image" file and to/caption/from fields.
+func (h *MessageHandler) SendImage(c *gin.Context) {
+	var req domain.SendImageRequest
+
+	if c.ContentType() == "multipart/form-data" {
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+				Success: false,
+				Message: "Invalid form: " + err.Error(),
+			})
+			return
+		}
+		data, _, err := readFormFile(c, "image", maxMediaUploadBytes)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+				Success: false,
+				Message: "Invalid image file: " + err.Error(),
+			})
+			return
+		}
+		req.Data = data
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if h.startAsync(c, "image", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+		return h.messageService.SendImage(ctx, &req)
+	}) {
+		return
+	}
+
+	response, err := h.messageService.SendImage(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidImage:
+			statusCode = http.StatusBadRequest
+		case domain.ErrRecipientSuppressed:
+			statusCode = http.StatusForbidden
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// maxDocumentUploadBytes bounds multipart document uploads; the service enforces the exact limit
+const maxDocumentUploadBytes = 100<<20 + 1
+
+// SendDocument handles POST /api/send-document. It accepts JSON with a base64
+// document or a multipart form with a "document" file and
+// to/filename/mimetype/caption/from fields. For uploads, filename and mimetype
+// default to those of the uploaded file.
+func (h *MessageHandler) SendDocument(c *gin.Context) {
+	var req domain.SendDocumentRequest
+
+	if c.ContentType() == "multipart/form-data" {
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+				Success: false,
+				Message: "Invalid form: " + err.Error(),
+			})
+			return
+		}
+		data, file, err := readFormFile(c, "document", maxDocumentUploadBytes)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+				Success: false,
+				Message: "Invalid document file: " + err.Error(),
+			})
+			return
+		}
+		req.Data = data
+		if req.FileName == "" {
+			req.FileName = file.Filename
+		}
+		if contentType := file.Header.Get("Content-Type"); req.MimeType == "" && contentType != "application/octet-stream" {
+			req.MimeType = contentType
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if h.startAsync(c, "document", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+		return h.messageService.SendDocument(ctx, &req)
+	}) {
+		return
+	}
+
+	response, err := h.messageService.SendDocument(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidDocument:
+			statusCode = http.StatusBadRequest
+		case domain.ErrRecipientSuppressed:
+			statusCode = http.StatusForbidden
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SendAudio handles POST /api/send-audio. It accepts JSON with base64 audio or
+// a multipart form with an "audio" file and to/ptt/from fields. Set ptt to
+// deliver the file as a voice note.
+func (h *MessageHandler) SendAudio(c *gin.Context) {
+	var req domain.SendAudioRequest
+
+	if c.ContentType() == "multipart/form-data" {
+		if err := c.ShouldBind(&req); err != nil {
+			c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+				Success: false,
+				Message: "Invalid form: " + err.Error(),
+			})
+			return
+		}
+		data, _, err := readFormFile(c, "audio", maxMediaUploadBytes)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+				Success: false,
+				Message: "Invalid audio file: " + err.Error(),
+			})
+			return
+		}
+		req.Data = data
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if h.startAsync(c, "audio", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+		return h.messageService.SendAudio(ctx, &req)
+	}) {
+		return
+	}
+
+	response, err := h.messageService.SendAudio(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidAudio:
+			statusCode = http.StatusBadRequest
+		case domain.ErrRecipientSuppressed:
+			statusCode = http.StatusForbidden
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SendLocation handles POST /api/send-location
+func (h *MessageHandler) SendLocation(c *gin.Context) {
+	var req domain.SendLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if h.startAsync(c, "location", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+		return h.messageService.SendLocation(ctx, &req)
+	}) {
+		return
+	}
+
+	response, err := h.messageService.SendLocation(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidLocation:
+			statusCode = http.StatusBadRequest
+		case domain.ErrRecipientSuppressed:
+			statusCode = http.StatusForbidden
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SendReaction handles POST /api/send-reaction
+func (h *MessageHandler) SendReaction(c *gin.Context) {
+	var req domain.SendReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if h.startAsync(c, "reaction", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+		return h.messageService.SendReaction(ctx, &req)
+	}) {
+		return
+	}
+
+	response, err := h.messageService.SendReaction(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidReaction:
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeMessage handles DELETE /api/messages/:id?to=...&from=..., deleting a
+// sent message for everyone. to and from default to the message history.
+func (h *MessageHandler) RevokeMessage(c *gin.Context) {
+	req := domain.RevokeMessageRequest{
+		MessageID: c.Param("id"),
+		To:        c.Query("to"),
+		From:      c.Query("from"),
+	}
+
+	response, err := h.messageService.RevokeMessage(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidRevoke:
+			statusCode = http.StatusBadRequest
+		case domain.ErrMessageNotFound:
+			statusCode = http.StatusNotFound
+		case domain.ErrRevokeWindowExpired:
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// EditMessage handles PATCH /api/messages/:id, replacing the text of a sent
+// text message. to and from default to the message history.
+func (h *MessageHandler) EditMessage(c *gin.Context) {
+	var req domain.EditMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+	req.MessageID = c.Param("id")
+
+	response, err := h.messageService.EditMessage(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidEdit:
+			statusCode = http.StatusBadRequest
+		case domain.ErrMessageNotFound:
+			statusCode = http.StatusNotFound
+		case domain.ErrEditWindowExpired, domain.ErrMessageNotEditable:
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SendChatPresence handles POST /api/send-chat-presence
+func (h *MessageHandler) SendChatPresence(c *gin.Context) {
+	var req domain.SendChatPresenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.messageService.SendChatPresence(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(presenceStatusCode(err), response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetPresence handles POST /api/set-presence
+func (h *MessageHandler) SetPresence(c *gin.Context) {
+	var req domain.SetPresenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.messageService.SetPresence(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(presenceStatusCode(err), response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SetDisappearing handles POST /api/set-disappearing
+func (h *MessageHandler) SetDisappearing(c *gin.Context) {
+	var req domain.SetDisappearingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.messageService.SetDisappearing(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(presenceStatusCode(err), response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// presenceStatusCode maps presence and disappearing timer errors to HTTP
+// status codes
+func presenceStatusCode(err error) int {
+	switch err {
+	case domain.ErrWhatsAppNotConnected:
+		return http.StatusServiceUnavailable
+	case domain.ErrInvalidPhoneNumber, domain.ErrInvalidPresence, domain.ErrInvalidDisappearing:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// SendContact handles POST /api/send-contact
+func (h *MessageHandler) SendContact(c *gin.Context) {
+	var req domain.SendContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if h.startAsync(c, "contact", func(ctx context.Context) (*domain.SendMessageResponse, error) {
+		return h.messageService.SendContact(ctx, &req)
+	}) {
+		return
+	}
+
+	response, err := h.messageService.SendContact(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidContact:
+			statusCode = http.StatusBadRequest
+		case domain.ErrRecipientSuppressed:
+			statusCode = http.StatusForbidden
+		}
 		c.JSON(statusCode, response)
 		return
 	}
@@ -55,6 +702,25 @@ func (h *MessageHandler) SendMessage(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// readFormFile reads at most limit bytes of the named multipart file
+func readFormFile(c *gin.Context, field string, limit int64) ([]byte, *multipart.FileHeader, error) {
+	file, err := c.FormFile(field)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := file.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, limit))
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, file, nil
+}
+
 // GetStatus handles GET /api/status
 func (h *MessageHandler) GetStatus(c *gin.Context) {
 	status, err := h.messageService.GetStatus(c.Request.Context())
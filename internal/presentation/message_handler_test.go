@@ -0,0 +1,1008 @@
+package presentation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+// pngHeader is enough of a PNG for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestMessageHandler_SendMessage_QueuedIsAccepted(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-message", handler.SendMessage)
+
+	reqBody := domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap diambil"}
+	mockMessageService.On("SendMessage", mock.Anything, &reqBody).
+		Return(&domain.SendMessageResponse{Success: true, Queued: true, QueueID: 7}, nil)
+
+	// Act
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/send-message", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+
+	var response domain.SendMessageResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(7), response.QueueID)
+
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendMessage_DuplicateIsConflict(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-message", handler.SendMessage)
+
+	mockMessageService.On("SendMessage", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: false, Message: "An identical message was sent to 6281234567890 less than 2m0s ago; set force to send it again"}, domain.ErrDuplicateSend)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-message", bytes.NewBufferString(`{"to": "6281234567890", "message": "Pesanan siap diambil"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "set force to send it again")
+}
+
+func TestMessageHandler_SendImage_JSON(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-image", handler.SendImage)
+
+	reqBody := domain.SendImageRequest{To: "+6281234567890", Image: "iVBORw0KGgo=", Caption: "Promo minggu ini"}
+	mockMessageService.On("SendImage", mock.Anything, &reqBody).
+		Return(&domain.SendMessageResponse{Success: true, Message: "Image sent successfully", ID: "img-1"}, nil)
+
+	// Act
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/send-image", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.SendMessageResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "img-1", response.ID)
+
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendImage_Multipart(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-image", handler.SendImage)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	_ = form.WriteField("to", "+6281234567890")
+	_ = form.WriteField("caption", "Struk Anda")
+	part, _ := form.CreateFormFile("image", "receipt.png")
+	_, _ = part.Write(pngHeader)
+	_ = form.Close()
+
+	mockMessageService.On("SendImage", mock.Anything, mock.MatchedBy(func(req *domain.SendImageRequest) bool {
+		return req.To == "+6281234567890" && req.Caption == "Struk Anda" && bytes.Equal(req.Data, pngHeader)
+	})).Return(&domain.SendMessageResponse{Success: true, ID: "img-2"}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-image", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendImage_InvalidImage(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-image", handler.SendImage)
+
+	mockMessageService.On("SendImage", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: false, Message: "image is required"}, domain.ErrInvalidImage)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-image", bytes.NewBufferString(`{"to": "+6281234567890"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendImage_MissingFile(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-image", handler.SendImage)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	_ = form.WriteField("to", "+6281234567890")
+	_ = form.Close()
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-image", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockMessageService.AssertNotCalled(t, "SendImage", mock.Anything, mock.Anything)
+}
+
+func TestMessageHandler_SendDocument_JSON(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-document", handler.SendDocument)
+
+	reqBody := domain.SendDocumentRequest{To: "+6281234567890", Document: "JVBERi0xLjc=", FileName: "invoice-0042.pdf", Caption: "Invoice"}
+	mockMessageService.On("SendDocument", mock.Anything, &reqBody).
+		Return(&domain.SendMessageResponse{Success: true, Message: "Document sent successfully", ID: "doc-1"}, nil)
+
+	// Act
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/send-document", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendDocument_MultipartDefaultsToUploadedFileName(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-document", handler.SendDocument)
+
+	pdf := []byte("%PDF-1.7 statement")
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	_ = form.WriteField("to", "+6281234567890")
+	_ = form.WriteField("from", "6289999")
+	part, _ := form.CreateFormFile("document", "statement.pdf")
+	_, _ = part.Write(pdf)
+	_ = form.Close()
+
+	mockMessageService.On("SendDocument", mock.Anything, mock.MatchedBy(func(req *domain.SendDocumentRequest) bool {
+		return req.FileName == "statement.pdf" && req.MimeType == "" && req.From == "6289999" && bytes.Equal(req.Data, pdf)
+	})).Return(&domain.SendMessageResponse{Success: true, ID: "doc-2"}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-document", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendDocument_InvalidDocument(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-document", handler.SendDocument)
+
+	mockMessageService.On("SendDocument", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: false, Message: "filename is required"}, domain.ErrInvalidDocument)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-document", bytes.NewBufferString(`{"to": "+6281234567890", "document": "JVBERi0xLjc="}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendAudio_MultipartVoiceNote(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-audio", handler.SendAudio)
+
+	ogg := []byte("OggS\x00\x02OpusHead")
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	_ = form.WriteField("to", "+6281234567890")
+	_ = form.WriteField("ptt", "true")
+	part, _ := form.CreateFormFile("audio", "promo.ogg")
+	_, _ = part.Write(ogg)
+	_ = form.Close()
+
+	mockMessageService.On("SendAudio", mock.Anything, mock.MatchedBy(func(req *domain.SendAudioRequest) bool {
+		return req.To == "+6281234567890" && req.PTT && bytes.Equal(req.Data, ogg)
+	})).Return(&domain.SendMessageResponse{Success: true, ID: "ptt-1"}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-audio", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendAudio_InvalidAudio(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-audio", handler.SendAudio)
+
+	mockMessageService.On("SendAudio", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: false, Message: "voice notes must be OGG/Opus"}, domain.ErrInvalidAudio)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-audio", bytes.NewBufferString(`{"to": "+6281234567890", "audio": "SUQzBA==", "ptt": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendLocation(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-location", handler.SendLocation)
+
+	mockMessageService.On("SendLocation", mock.Anything, mock.MatchedBy(func(req *domain.SendLocationRequest) bool {
+		return req.Latitude != nil && *req.Latitude == -6.2088 && req.Longitude != nil && *req.Longitude == 106.8456 && req.Name == "Laundry Bersih"
+	})).Return(&domain.SendMessageResponse{Success: true, ID: "loc-1"}, nil)
+
+	// Act
+	body := `{"to": "+6281234567890", "latitude": -6.2088, "longitude": 106.8456, "name": "Laundry Bersih"}`
+	req, _ := http.NewRequest("POST", "/send-location", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendLocation_InvalidLocation(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-location", handler.SendLocation)
+
+	mockMessageService.On("SendLocation", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: false, Message: "latitude and longitude are required"}, domain.ErrInvalidLocation)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-location", bytes.NewBufferString(`{"to": "+6281234567890"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendContact(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-contact", handler.SendContact)
+
+	mockMessageService.On("SendContact", mock.Anything, mock.MatchedBy(func(req *domain.SendContactRequest) bool {
+		return len(req.Contacts) == 1 && req.Contacts[0].Name == "Admin Laundry"
+	})).Return(&domain.SendMessageResponse{Success: true, ID: "vcard-1"}, nil)
+
+	// Act
+	body := `{"to": "+6281234567890", "contacts": [{"name": "Admin Laundry", "phone": "+6281122223333"}]}`
+	req, _ := http.NewRequest("POST", "/send-contact", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendContact_InvalidContact(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-contact", handler.SendContact)
+
+	mockMessageService.On("SendContact", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: false, Message: "contact 1: name is required"}, domain.ErrInvalidContact)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-contact", bytes.NewBufferString(`{"to": "+6281234567890", "contacts": [{"phone": "+6281122223333"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendReaction(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-reaction", handler.SendReaction)
+
+	mockMessageService.On("SendReaction", mock.Anything, &domain.SendReactionRequest{
+		MessageID: "3EB0C767D71A",
+		ChatJID:   "6281234567890",
+		Emoji:     "👍",
+	}).Return(&domain.SendMessageResponse{Success: true, ID: "react-1"}, nil)
+
+	// Act
+	body := `{"message_id": "3EB0C767D71A", "chat_jid": "6281234567890", "emoji": "👍"}`
+	req, _ := http.NewRequest("POST", "/send-reaction", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendReaction_Invalid(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-reaction", handler.SendReaction)
+
+	mockMessageService.On("SendReaction", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: false, Message: "message_id and a single emoji are required"}, domain.ErrInvalidReaction)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-reaction", bytes.NewBufferString(`{"chat_jid": "6281234567890", "emoji": "thanks"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestMessageHandler_RevokeMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"deleted", nil, http.StatusOK},
+		{"unknown message", domain.ErrMessageNotFound, http.StatusNotFound},
+		{"too old", domain.ErrRevokeWindowExpired, http.StatusConflict},
+		{"not connected", domain.ErrWhatsAppNotConnected, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockMessageService := &mocks.MockMessageService{}
+			handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+			router := setupTestRouter()
+			router.DELETE("/messages/:id", handler.RevokeMessage)
+
+			mockMessageService.On("RevokeMessage", mock.Anything, &domain.RevokeMessageRequest{
+				MessageID: "3EB0C767D71A",
+				To:        "6281234567890",
+			}).Return(&domain.SendMessageResponse{Success: tt.err == nil}, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("DELETE", "/messages/3EB0C767D71A?to=6281234567890", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockMessageService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageHandler_EditMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"edited", nil, http.StatusOK},
+		{"unknown message", domain.ErrMessageNotFound, http.StatusNotFound},
+		{"too old", domain.ErrEditWindowExpired, http.StatusConflict},
+		{"not a text message", domain.ErrMessageNotEditable, http.StatusConflict},
+		{"not connected", domain.ErrWhatsAppNotConnected, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockMessageService := &mocks.MockMessageService{}
+			handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+			router := setupTestRouter()
+			router.PATCH("/messages/:id", handler.EditMessage)
+
+			mockMessageService.On("EditMessage", mock.Anything, &domain.EditMessageRequest{
+				MessageID: "3EB0C767D71A",
+				Message:   "Pesanan siap diambil jam 4",
+			}).Return(&domain.SendMessageResponse{Success: tt.err == nil}, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("PATCH", "/messages/3EB0C767D71A", bytes.NewBufferString(`{"message": "Pesanan siap diambil jam 4"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockMessageService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageHandler_SendChatPresence(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-chat-presence", handler.SendChatPresence)
+
+	mockMessageService.On("SendChatPresence", mock.Anything, &domain.SendChatPresenceRequest{
+		ChatJID: "6281234567890",
+		State:   "typing",
+	}).Return(&domain.SendMessageResponse{Success: true}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-chat-presence", bytes.NewBufferString(`{"chat_jid": "6281234567890", "state": "typing"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SetPresence_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid presence", domain.ErrInvalidPresence, http.StatusBadRequest},
+		{"not connected", domain.ErrWhatsAppNotConnected, http.StatusServiceUnavailable},
+		{"send failed", domain.ErrMessageSendFailed, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockMessageService := &mocks.MockMessageService{}
+			handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+			router := setupTestRouter()
+			router.POST("/set-presence", handler.SetPresence)
+
+			mockMessageService.On("SetPresence", mock.Anything, mock.Anything).
+				Return(&domain.SendMessageResponse{Success: false}, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("POST", "/set-presence", bytes.NewBufferString(`{"presence": "available"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMessageHandler_SetDisappearing(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/set-disappearing", handler.SetDisappearing)
+
+	mockMessageService.On("SetDisappearing", mock.Anything, &domain.SetDisappearingRequest{ChatJID: "6281234567890", Timer: "24h"}).
+		Return(&domain.SendMessageResponse{Success: true}, nil)
+	mockMessageService.On("SetDisappearing", mock.Anything, &domain.SetDisappearingRequest{ChatJID: "6281234567890", Timer: "1h"}).
+		Return(&domain.SendMessageResponse{Success: false}, domain.ErrInvalidDisappearing)
+
+	for body, wantStatus := range map[string]int{
+		`{"chat_jid": "6281234567890", "timer": "24h"}`: http.StatusOK,
+		`{"chat_jid": "6281234567890", "timer": "1h"}`:  http.StatusBadRequest,
+	} {
+		// Act
+		req, _ := http.NewRequest("POST", "/set-disappearing", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, wantStatus, w.Code, body)
+	}
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendMessage_Interactive(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-message", handler.SendMessage)
+
+	mockMessageService.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *domain.SendMessageRequest) bool {
+		return req.Interactive != nil && req.Interactive.List != nil &&
+			req.Interactive.List.Sections[0].Rows[1].ID == "red"
+	})).Return(&domain.SendMessageResponse{Success: true, ID: "list-1"}, nil)
+
+	// Act
+	body := `{"to": "+6281234567890", "message": "Pilih menu:", "interactive": {"list": {"button": "Menu",
+		"sections": [{"title": "Poin", "rows": [{"id": "poin", "title": "Cek Poin"}, {"id": "red", "title": "Tukar Poin"}]}]}}}`
+	req, _ := http.NewRequest("POST", "/send-message", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendMessage_InvalidInteractive(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.POST("/send-message", handler.SendMessage)
+
+	mockMessageService.On("SendMessage", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: false, Message: "at most 3 reply buttons are allowed"}, domain.ErrInvalidInteractive)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/send-message", bytes.NewBufferString(`{"to": "+6281234567890", "message": "Hi", "interactive": {}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendMessage_Reply(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"threaded", nil, http.StatusOK},
+		{"group reply without sender", domain.ErrInvalidReply, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockMessageService := &mocks.MockMessageService{}
+			handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{})
+
+			router := setupTestRouter()
+			router.POST("/send-message", handler.SendMessage)
+
+			mockMessageService.On("SendMessage", mock.Anything, &domain.SendMessageRequest{
+				To: "+6281234567890", Message: "Siap, kami jemput jam 3", ReplyTo: "3EB0C767D71A",
+			}).Return(&domain.SendMessageResponse{Success: tt.err == nil}, tt.err)
+
+			// Act
+			body := `{"to": "+6281234567890", "message": "Siap, kami jemput jam 3", "reply_to": "3EB0C767D71A"}`
+			req, _ := http.NewRequest("POST", "/send-message", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockMessageService.AssertExpectations(t)
+		})
+	}
+}
+
+var idempotentSendRequest = domain.SendMessageRequest{To: "6281234567890", Message: "Tagihan Anda sudah dibayar"}
+
+func newIdempotentRouter(messages *mocks.MockMessageService, idempotency *mocks.MockIdempotencyRepository) *gin.Engine {
+	handler := NewMessageHandler(messages, &mocks.MockAuthService{}).WithIdempotency(idempotency)
+	router := setupTestRouter()
+	router.POST("/send-message", handler.SendMessage)
+	return router
+}
+
+func postIdempotent(router *gin.Engine, key string, body domain.SendMessageRequest) *httptest.ResponseRecorder {
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest("POST", "/send-message", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMessageHandler_SendMessage_IdempotencyKeyStoresResult(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	mockIdempotency := &mocks.MockIdempotencyRepository{}
+	router := newIdempotentRouter(mockMessageService, mockIdempotency)
+
+	fingerprint := requestFingerprint(&idempotentSendRequest)
+	response := &domain.SendMessageResponse{Success: true, Message: "Message sent successfully", ID: "msg-1"}
+	stored, _ := json.Marshal(response)
+	mockIdempotency.On("Reserve", "invoice-42", fingerprint).Return(nil, nil)
+	mockMessageService.On("SendMessage", mock.Anything, &idempotentSendRequest).Return(response, nil)
+	mockIdempotency.On("Complete", "invoice-42", http.StatusOK, stored).Return(nil)
+
+	// Act
+	w := postIdempotent(router, "invoice-42", idempotentSendRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockIdempotency.AssertExpectations(t)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendMessage_IdempotencyKeyReplays(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	mockIdempotency := &mocks.MockIdempotencyRepository{}
+	router := newIdempotentRouter(mockMessageService, mockIdempotency)
+
+	fingerprint := requestFingerprint(&idempotentSendRequest)
+	mockIdempotency.On("Reserve", "invoice-42", fingerprint).Return(&domain.IdempotencyRecord{
+		Fingerprint: fingerprint,
+		StatusCode:  http.StatusOK,
+		Response:    []byte(`{"success":true,"message":"Message sent successfully","id":"msg-1"}`),
+		Completed:   true,
+	}, nil)
+
+	// Act
+	w := postIdempotent(router, "invoice-42", idempotentSendRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Idempotent-Replayed"))
+	var response domain.SendMessageResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "msg-1", response.ID)
+	mockMessageService.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestMessageHandler_SendMessage_IdempotencyKeyConflicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		record     *domain.IdempotencyRecord
+		wantStatus int
+	}{
+		{"still in progress", &domain.IdempotencyRecord{Fingerprint: requestFingerprint(&idempotentSendRequest)}, http.StatusConflict},
+		{"different request", &domain.IdempotencyRecord{Fingerprint: "other", Completed: true, StatusCode: http.StatusOK}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockMessageService := &mocks.MockMessageService{}
+			mockIdempotency := &mocks.MockIdempotencyRepository{}
+			router := newIdempotentRouter(mockMessageService, mockIdempotency)
+
+			mockIdempotency.On("Reserve", "invoice-42", mock.Anything).Return(tt.record, nil)
+
+			// Act
+			w := postIdempotent(router, "invoice-42", idempotentSendRequest)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockMessageService.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestMessageHandler_SendMessage_IdempotencyKeyReleasedOnServerError(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	mockIdempotency := &mocks.MockIdempotencyRepository{}
+	router := newIdempotentRouter(mockMessageService, mockIdempotency)
+
+	mockIdempotency.On("Reserve", "invoice-42", mock.Anything).Return(nil, nil)
+	mockMessageService.On("SendMessage", mock.Anything, &idempotentSendRequest).
+		Return(&domain.SendMessageResponse{Success: false, Message: "WhatsApp client is not connected"}, domain.ErrWhatsAppNotConnected)
+	mockIdempotency.On("Release", "invoice-42").Return(nil)
+
+	// Act
+	w := postIdempotent(router, "invoice-42", idempotentSendRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	mockIdempotency.AssertExpectations(t)
+	mockIdempotency.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageHandler_SendMessage_Async(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	mockJobs := &mocks.MockSendJobService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{}).WithSendJobs(mockJobs)
+
+	router := setupTestRouter()
+	router.POST("/send-message", handler.SendMessage)
+
+	reqBody := domain.SendMessageRequest{To: "6281234567890", Message: "Pesanan siap diambil"}
+	job := &domain.SendJob{ID: "job-1", Kind: "message", Status: domain.SendJobPending}
+	mockJobs.On("StartSend", mock.Anything, "message", mock.Anything).Return(job, nil).Run(func(args mock.Arguments) {
+		// The job sends the bound request through the message service
+		send := args.Get(2).(func(context.Context) (*domain.SendMessageResponse, error))
+		_, _ = send(context.Background())
+	})
+	mockMessageService.On("SendMessage", mock.Anything, &reqBody).
+		Return(&domain.SendMessageResponse{Success: true, ID: "msg-1"}, nil)
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/send-message?async=true", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var response domain.SendJob
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "job-1", response.ID)
+	assert.Equal(t, domain.SendJobPending, response.Status)
+	mockJobs.AssertExpectations(t)
+	mockMessageService.AssertExpectations(t)
+}
+
+func TestMessageHandler_SendLocation_AsyncFalseSendsInline(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	mockJobs := &mocks.MockSendJobService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{}).WithSendJobs(mockJobs)
+
+	router := setupTestRouter()
+	router.POST("/send-location", handler.SendLocation)
+
+	mockMessageService.On("SendLocation", mock.Anything, mock.Anything).
+		Return(&domain.SendMessageResponse{Success: true, ID: "loc-1"}, nil)
+
+	req, _ := http.NewRequest("POST", "/send-location?async=false",
+		bytes.NewBufferString(`{"to":"6281234567890","latitude":-6.2,"longitude":106.8}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockJobs.AssertNotCalled(t, "StartSend", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageHandler_GetSendJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		job        *domain.SendJob
+		err        error
+		wantStatus int
+	}{
+		{"found", &domain.SendJob{ID: "job-1", Status: domain.SendJobSent, Result: &domain.SendMessageResponse{Success: true, ID: "msg-1"}}, nil, http.StatusOK},
+		{"not found", nil, domain.ErrSendJobNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockJobs := &mocks.MockSendJobService{}
+			handler := NewMessageHandler(&mocks.MockMessageService{}, &mocks.MockAuthService{}).WithSendJobs(mockJobs)
+
+			router := setupTestRouter()
+			router.GET("/jobs/:id", handler.GetSendJob)
+
+			mockJobs.On("GetSendJob", mock.Anything, "job-1").Return(tt.job, tt.err)
+
+			req, _ := http.NewRequest("GET", "/jobs/job-1", nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.job != nil {
+				var response domain.SendJob
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "msg-1", response.Result.ID)
+			}
+		})
+	}
+}
+
+func TestMessageHandler_SendMessages(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   *domain.SendMessagesResponse
+		err        error
+		wantStatus int
+	}{
+		{"sent", &domain.SendMessagesResponse{Total: 1, Sent: 1, Results: []domain.SendMessagesResult{{To: "6281111"}}}, nil, http.StatusOK},
+		{"empty batch", nil, domain.ErrInvalidBatch, http.StatusBadRequest},
+		{"too large", nil, domain.ErrBatchTooLarge, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockBatch := &mocks.MockBatchSendService{}
+			handler := NewMessageHandler(&mocks.MockMessageService{}, &mocks.MockAuthService{}).WithBatchSend(mockBatch)
+
+			router := setupTestRouter()
+			router.POST("/send-messages", handler.SendMessages)
+
+			reqBody := domain.SendMessagesRequest{Messages: []domain.SendMessageRequest{{To: "6281111", Message: "Halo"}}}
+			mockBatch.On("SendMessages", mock.Anything, &reqBody).Return(tt.response, tt.err)
+
+			jsonBody, _ := json.Marshal(reqBody)
+			req, _ := http.NewRequest("POST", "/send-messages", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.response != nil {
+				var response domain.SendMessagesResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, 1, response.Sent)
+				assert.Equal(t, "6281111", response.Results[0].To)
+			}
+		})
+	}
+}
+
+func TestMessageHandler_SendMessage_AsyncKeepsIdempotencyKeyUntilSent(t *testing.T) {
+	// Arrange
+	mockMessageService := &mocks.MockMessageService{}
+	mockIdempotency := &mocks.MockIdempotencyRepository{}
+	mockJobs := &mocks.MockSendJobService{}
+	handler := NewMessageHandler(mockMessageService, &mocks.MockAuthService{}).WithIdempotency(mockIdempotency).WithSendJobs(mockJobs)
+
+	router := setupTestRouter()
+	router.POST("/send-message", handler.SendMessage)
+
+	var send func(context.Context) (*domain.SendMessageResponse, error)
+	response := &domain.SendMessageResponse{Success: true, Message: "Message sent successfully", ID: "msg-1"}
+	stored, _ := json.Marshal(response)
+	mockIdempotency.On("Reserve", "invoice-42", requestFingerprint(&idempotentSendRequest)).Return(nil, nil).Once()
+	mockJobs.On("StartSend", mock.Anything, "message", mock.Anything).
+		Return(&domain.SendJob{ID: "job-1", Status: domain.SendJobPending}, nil).
+		Run(func(args mock.Arguments) {
+			send = args.Get(2).(func(context.Context) (*domain.SendMessageResponse, error))
+		})
+	mockMessageService.On("SendMessage", mock.Anything, &idempotentSendRequest).Return(response, nil).Once()
+	mockIdempotency.On("Complete", "invoice-42", http.StatusOK, stored).Return(nil).Once()
+
+	post := func() *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(idempotentSendRequest)
+		req, _ := http.NewRequest("POST", "/send-message?async=true", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "invoice-42")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act
+	first := post()
+	mockIdempotency.On("Reserve", "invoice-42", mock.Anything).
+		Return(&domain.IdempotencyRecord{Fingerprint: requestFingerprint(&idempotentSendRequest)}, nil).Once()
+	retry := post()
+	_, err := send(context.Background())
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, first.Code)
+	assert.Equal(t, http.StatusConflict, retry.Code)
+	assert.NoError(t, err)
+	mockJobs.AssertNumberOfCalls(t, "StartSend", 1)
+	mockIdempotency.AssertExpectations(t)
+	mockMessageService.AssertExpectations(t)
+}
@@ -0,0 +1,113 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type MessageHistoryHandler struct {
+	historyService domain.MessageHistoryService
+}
+
+// NewMessageHistoryHandler creates a new message history handler
+func NewMessageHistoryHandler(historyService domain.MessageHistoryService) *MessageHistoryHandler {
+	return &MessageHistoryHandler{historyService: historyService}
+}
+
+// ListMessages handles GET /api/messages
+func (h *MessageHistoryHandler) ListMessages(c *gin.Context) {
+	var query domain.MessageHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid query: " + err.Error(),
+		})
+		return
+	}
+
+	page, err := h.historyService.ListMessages(c.Request.Context(), &query)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidHistoryQuery) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// ListEdits handles GET /api/messages/:id/edits
+func (h *MessageHistoryHandler) ListEdits(c *gin.Context) {
+	edits, err := h.historyService.ListEdits(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrMessageNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message_id": c.Param("id"),
+		"edits":      edits,
+	})
+}
+
+// GetBusiestContacts handles GET /api/reports/busiest-contacts
+func (h *MessageHistoryHandler) GetBusiestContacts(c *gin.Context) {
+	var query domain.MessageReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid query: " + err.Error(),
+		})
+		return
+	}
+
+	report, err := h.historyService.GetBusiestContacts(c.Request.Context(), &query)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidReportQuery) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetMessageVolume handles GET /api/reports/message-volume
+func (h *MessageHistoryHandler) GetMessageVolume(c *gin.Context) {
+	var query domain.MessageReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid query: " + err.Error(),
+		})
+		return
+	}
+
+	report, err := h.historyService.GetMessageVolume(c.Request.Context(), &query)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrInvalidReportQuery) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,167 @@
+package presentation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestMessageHistoryHandler_ListMessages_Success(t *testing.T) {
+	// Arrange
+	mockHistoryService := &mocks.MockMessageHistoryService{}
+	handler := NewMessageHistoryHandler(mockHistoryService)
+
+	router := setupTestRouter()
+	router.GET("/messages", handler.ListMessages)
+
+	query := &domain.MessageHistoryQuery{Recipient: "6281234567890", From: "2026-10-01", Limit: 20}
+	page := &domain.MessageHistoryPage{
+		Messages: []*domain.MessageRecord{{ID: 9, Recipient: "6281234567890", Kind: "text", Status: "sent"}},
+		Total:    1,
+		Limit:    20,
+	}
+	mockHistoryService.On("ListMessages", mock.Anything, query).Return(page, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/messages?recipient=6281234567890&from=2026-10-01&limit=20", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.MessageHistoryPage
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Total)
+	assert.Equal(t, "sent", response.Messages[0].Status)
+
+	mockHistoryService.AssertExpectations(t)
+}
+
+func TestMessageHistoryHandler_ListMessages_InvalidQuery(t *testing.T) {
+	// Arrange
+	mockHistoryService := &mocks.MockMessageHistoryService{}
+	handler := NewMessageHistoryHandler(mockHistoryService)
+
+	router := setupTestRouter()
+	router.GET("/messages", handler.ListMessages)
+
+	mockHistoryService.On("ListMessages", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: from must be YYYY-MM-DD", domain.ErrInvalidHistoryQuery))
+
+	// Act
+	req, _ := http.NewRequest("GET", "/messages?from=kemarin", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockHistoryService.AssertExpectations(t)
+}
+
+func TestMessageHistoryHandler_ListMessages_NonNumericLimit(t *testing.T) {
+	// Arrange
+	mockHistoryService := &mocks.MockMessageHistoryService{}
+	handler := NewMessageHistoryHandler(mockHistoryService)
+
+	router := setupTestRouter()
+	router.GET("/messages", handler.ListMessages)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/messages?limit=all", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockHistoryService.AssertNotCalled(t, "ListMessages", mock.Anything, mock.Anything)
+}
+
+func TestMessageHistoryHandler_ListEdits(t *testing.T) {
+	// Arrange
+	mockHistoryService := &mocks.MockMessageHistoryService{}
+	handler := NewMessageHistoryHandler(mockHistoryService)
+
+	router := setupTestRouter()
+	router.GET("/messages/:id/edits", handler.ListEdits)
+
+	mockHistoryService.On("ListEdits", mock.Anything, "3EB0C767D71A").Return([]*domain.MessageEdit{
+		{MessageID: "3EB0C767D71A", PreviousContent: "jam 3", Content: "jam 4", ContentLength: 5},
+	}, nil)
+	mockHistoryService.On("ListEdits", mock.Anything, "unknown").Return(nil, domain.ErrMessageNotFound)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/messages/3EB0C767D71A/edits", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	reqUnknown, _ := http.NewRequest("GET", "/messages/unknown/edits", nil)
+	wUnknown := httptest.NewRecorder()
+	router.ServeHTTP(wUnknown, reqUnknown)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Edits []domain.MessageEdit `json:"edits"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "jam 4", response.Edits[0].Content)
+	assert.Equal(t, http.StatusNotFound, wUnknown.Code)
+	mockHistoryService.AssertExpectations(t)
+}
+
+func TestMessageHistoryHandler_GetBusiestContacts(t *testing.T) {
+	// Arrange
+	mockHistoryService := &mocks.MockMessageHistoryService{}
+	handler := NewMessageHistoryHandler(mockHistoryService)
+
+	router := setupTestRouter()
+	router.GET("/reports/busiest-contacts", handler.GetBusiestContacts)
+
+	query := &domain.MessageReportQuery{From: "2026-10-01", Limit: 5}
+	report := &domain.BusiestContactsReport{
+		From:     "2026-10-01",
+		To:       "2026-10-16",
+		Contacts: []*domain.ContactVolume{{PhoneNumber: "6281234567890", Inbound: 12, Outbound: 30, Total: 42}},
+	}
+	mockHistoryService.On("GetBusiestContacts", mock.Anything, query).Return(report, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/reports/busiest-contacts?from=2026-10-01&limit=5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response domain.BusiestContactsReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, report, &response)
+	mockHistoryService.AssertExpectations(t)
+}
+
+func TestMessageHistoryHandler_GetMessageVolume_InvalidQuery(t *testing.T) {
+	// Arrange
+	mockHistoryService := &mocks.MockMessageHistoryService{}
+	handler := NewMessageHistoryHandler(mockHistoryService)
+
+	router := setupTestRouter()
+	router.GET("/reports/message-volume", handler.GetMessageVolume)
+
+	mockHistoryService.On("GetMessageVolume", mock.Anything, &domain.MessageReportQuery{Interval: "month"}).
+		Return(nil, fmt.Errorf("%w: interval must be day or week", domain.ErrInvalidReportQuery))
+
+	// Act
+	req, _ := http.NewRequest("GET", "/reports/message-volume?interval=month", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockHistoryService.AssertExpectations(t)
+}
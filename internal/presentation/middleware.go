@@ -1,13 +1,63 @@
 package presentation
 
 import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/requestid"
 )
 
-// AuthMiddleware validates credentials using the auth service
+// requestIDContextKey stores the request ID on the gin context
+const requestIDContextKey = "request_id"
+
+// tenantContextKey stores the slug of the tenant whose API key authenticated
+// the request on the gin context
+const tenantContextKey = "tenant"
+
+// Headers carrying the confirmation of a destructive request
+const (
+	confirmationTokenHeader = "X-Confirmation-Token"
+	confirmationCodeHeader  = "X-Confirmation-Code"
+)
+
+// AuthMiddleware validates credentials using the auth service. Besides Basic
+// Auth, a tenant's API key is accepted as a bearer token; such a request is
+// scoped to that tenant and refused on the routes of any other, and on every
+// route that is not a tenant's /tenants/:slug route, since those act on the
+// whole platform.
 func AuthMiddleware(authService domain.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if key, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok {
+			tenant, valid := authService.ValidateAPIKey(strings.TrimSpace(key))
+			if !valid {
+				c.Header("WWW-Authenticate", `Bearer realm="WhatsPoints API"`)
+				c.AbortWithStatus(401)
+				return
+			}
+			slug := c.Param("slug")
+			if slug == "" {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error": "Tenant API keys are only accepted on their tenant's routes",
+				})
+				return
+			}
+			if slug != tenant {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error": "API key does not belong to tenant " + slug,
+				})
+				return
+			}
+			c.Set(tenantContextKey, tenant)
+			c.Next()
+			return
+		}
+
 		username, password, hasAuth := c.Request.BasicAuth()
 
 		if !hasAuth || !authService.ValidateCredentials(username, password) {
@@ -19,3 +69,145 @@ func AuthMiddleware(authService domain.AuthService) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// authenticatedTenant returns the tenant whose API key authenticated the
+// request, or an empty string for Basic Auth
+func authenticatedTenant(c *gin.Context) string {
+	return c.GetString(tenantContextKey)
+}
+
+// ConfirmationMiddleware lets a destructive request through only with a
+// confirmation of action on the target in URL parameter param, passed as the
+// X-Confirmation-Token and X-Confirmation-Code headers. An empty param is for
+// actions on everything, confirmed for domain.ConfirmationTargetAll. The
+// confirmation is used up even if the handler then fails.
+func ConfirmationMiddleware(confirmationService domain.ConfirmationService, action, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		target := domain.ConfirmationTargetAll
+		if param != "" {
+			target = c.Param(param)
+		}
+		err := confirmationService.Confirm(c.Request.Context(),
+			c.GetHeader(confirmationTokenHeader), c.GetHeader(confirmationCodeHeader), action, target)
+		if err != nil {
+			c.AbortWithStatusJSON(confirmationStatusCode(err), gin.H{
+				"error":  err.Error(),
+				"action": action,
+				"target": target,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequestIDMiddleware gives every request an ID, propagating a valid
+// X-Request-ID from the caller or generating one. The ID is returned on the
+// response and carried on the request context for logs, message history and
+// webhooks.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		c.Set(requestIDContextKey, id)
+		c.Header(requestid.Header, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// requestLogFormatter is gin's access log line with the request ID appended
+func requestLogFormatter(param gin.LogFormatterParams) string {
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	line := fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v | request_id=%v\n",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		param.Path,
+		param.Keys[requestIDContextKey],
+	)
+	if param.ErrorMessage != "" {
+		line += param.ErrorMessage
+	}
+	return line
+}
+
+// RateLimitMiddleware lets each client IP make at most limit requests in any
+// sliding window, answering 429 with a Retry-After header beyond that. It
+// guards the endpoints that need no credentials. The client IP is only taken
+// from X-Forwarded-For when the request comes from a trusted proxy.
+func RateLimitMiddleware(limit int, window time.Duration) gin.HandlerFunc {
+	limiter := newIPRateLimiter(limit, window)
+	return func(c *gin.Context) {
+		if retryAfter := limiter.Allow(c.ClientIP()); retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int((retryAfter+time.Second-1)/time.Second)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": "Too many requests, try again later",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ipRateLimiter counts the requests of each client IP in a sliding window
+type ipRateLimiter struct {
+	mu         sync.Mutex
+	limit      int
+	window     time.Duration
+	requests   map[string][]time.Time
+	maxClients int
+	now        func() time.Time
+}
+
+func newIPRateLimiter(limit int, window time.Duration) *ipRateLimiter {
+	return &ipRateLimiter{
+		limit:      limit,
+		window:     window,
+		requests:   make(map[string][]time.Time),
+		maxClients: 10000,
+		now:        time.Now,
+	}
+}
+
+// Allow records a request from ip and returns 0, or returns how long until ip
+// may make another without recording anything when it is over the limit, or
+// when maxClients other IPs are already tracked in the window
+func (l *ipRateLimiter) Allow(ip string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	cutoff := now.Add(-l.window)
+	recent := l.requests[ip]
+	for len(recent) > 0 && !recent[0].After(cutoff) {
+		recent = recent[1:]
+	}
+	if len(recent) >= l.limit {
+		l.requests[ip] = recent
+		return recent[0].Sub(cutoff)
+	}
+
+	if _, tracked := l.requests[ip]; !tracked && len(l.requests) >= l.maxClients {
+		for client, times := range l.requests {
+			if len(times) == 0 || !times[len(times)-1].After(cutoff) {
+				delete(l.requests, client)
+			}
+		}
+		// Still full: refuse new clients rather than grow without bound
+		if len(l.requests) >= l.maxClients {
+			return l.window
+		}
+	}
+	l.requests[ip] = append(recent, now)
+	return 0
+}
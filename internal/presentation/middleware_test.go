@@ -5,10 +5,14 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
 	"github.com/wa-serv/internal/mocks"
+	"github.com/wa-serv/requestid"
 )
 
 func TestBasicAuthMiddleware_ValidCredentials(t *testing.T) {
@@ -101,7 +105,7 @@ func TestBasicAuthMiddleware_InvalidAuthFormat(t *testing.T) {
 
 	// Prepare request with invalid auth format
 	req, _ := http.NewRequest("GET", "/test", nil)
-	req.Header.Set("Authorization", "Bearer token123")
+	req.Header.Set("Authorization", "Digest token123")
 
 	// Act
 	w := httptest.NewRecorder()
@@ -133,3 +137,253 @@ func TestBasicAuthMiddleware_InvalidBase64(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
+
+func TestAPIKeyAuthMiddleware_ScopesToTenant(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"own tenant", "/tenants/ruang-laundry/message-log", http.StatusOK},
+		{"other tenant", "/tenants/other-laundry/message-log", http.StatusForbidden},
+		{"not tenant specific", "/test", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockAuthService := &mocks.MockAuthService{}
+			router := setupTestRouter()
+			router.Use(AuthMiddleware(mockAuthService))
+			handler := func(c *gin.Context) {
+				c.JSON(200, gin.H{"tenant": authenticatedTenant(c)})
+			}
+			router.GET("/test", handler)
+			router.GET("/tenants/:slug/message-log", handler)
+
+			mockAuthService.On("ValidateAPIKey", "wp_secret").Return("ruang-laundry", true)
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			req.Header.Set("Authorization", "Bearer wp_secret")
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.JSONEq(t, `{"tenant":"ruang-laundry"}`, w.Body.String())
+			}
+			mockAuthService.AssertNotCalled(t, "ValidateCredentials", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAPIKeyAuthMiddleware_RefusedOnAdminRoutes(t *testing.T) {
+	// Arrange
+	mockAuthService := &mocks.MockAuthService{}
+	mockTenantService := &mocks.MockTenantService{}
+	router := NewRouterWithRegistration(
+		NewMessageHandler(&mocks.MockMessageService{}, mockAuthService),
+		NewSenderRegistrationHandler(&mocks.MockSenderRegistrationService{}, mockAuthService),
+		nil,
+		mockAuthService,
+	).
+		WithTenantHandler(NewTenantHandler(mockTenantService)).
+		WithMemberHandler(NewMemberHandler(&mocks.MockMemberService{})).
+		WithMessageHistoryHandler(NewMessageHistoryHandler(&mocks.MockMessageHistoryService{})).
+		WithConfirmationHandler(NewConfirmationHandler(&mocks.MockConfirmationService{})).
+		SetupRoutes()
+
+	mockAuthService.On("ValidateAPIKey", "wp_secret").Return("ruang-laundry", true)
+	mockTenantService.On("GetMessageLog", mock.Anything, "ruang-laundry").Return(&domain.MessageLogSettings{Mode: "full"}, nil)
+
+	tests := []struct {
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"GET", "/api/v1/tenants/ruang-laundry/message-log", http.StatusOK},
+		{"POST", "/api/v1/send-message", http.StatusForbidden},
+		{"POST", "/api/send-message", http.StatusForbidden},
+		{"DELETE", "/api/v1/senders/6281111111111", http.StatusForbidden},
+		{"DELETE", "/api/v1/members/6281111111111", http.StatusForbidden},
+		{"GET", "/api/v1/messages", http.StatusForbidden},
+		{"POST", "/api/v1/confirmations", http.StatusForbidden},
+		{"POST", "/api/v1/tenants", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer wp_secret")
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAPIKeyAuthMiddleware_InvalidKey(t *testing.T) {
+	// Arrange
+	mockAuthService := &mocks.MockAuthService{}
+	router := setupTestRouter()
+	router.Use(AuthMiddleware(mockAuthService))
+	router.GET("/test", func(c *gin.Context) {
+		c.JSON(200, gin.H{"message": "success"})
+	})
+
+	mockAuthService.On("ValidateAPIKey", "wp_revoked").Return("", false)
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer wp_revoked")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockAuthService.AssertExpectations(t)
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		wantSame bool
+	}{
+		{"generated when absent", "", false},
+		{"propagated from caller", "crm-order-1234", true},
+		{"replaced when unsafe", "bad id\r\nX-Injected: 1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := setupTestRouter()
+			router.Use(RequestIDMiddleware())
+			var seen string
+			router.GET("/test", func(c *gin.Context) {
+				seen = requestid.FromContext(c.Request.Context())
+				c.Status(http.StatusNoContent)
+			})
+
+			req, _ := http.NewRequest("GET", "/test", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestid.Header, tt.incoming)
+			}
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			returned := w.Header().Get(requestid.Header)
+			assert.True(t, requestid.Valid(returned))
+			assert.Equal(t, returned, seen)
+			assert.Equal(t, tt.wantSame, returned == tt.incoming)
+		})
+	}
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	// Arrange
+	router := setupTestRouter()
+	router.GET("/status-page", RateLimitMiddleware(2, time.Minute), func(c *gin.Context) {
+		c.JSON(200, gin.H{"status": "operational"})
+	})
+	request := func(ip string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", "/status-page", nil)
+		req.RemoteAddr = ip + ":40000"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Act & Assert
+	assert.Equal(t, http.StatusOK, request("203.0.113.7").Code)
+	assert.Equal(t, http.StatusOK, request("203.0.113.7").Code)
+	limited := request("203.0.113.7")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "60", limited.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, request("198.51.100.4").Code)
+}
+
+func TestRateLimitMiddleware_ForwardedFor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		wantStatus     int
+	}{
+		{"spoofed by a client", nil, http.StatusTooManyRequests},
+		{"set by a trusted proxy", []string{"10.0.0.1"}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStatusPageService := &mocks.MockStatusPageService{}
+			mockStatusPageService.On("GetStatusPage", mock.Anything).Return(&domain.StatusPage{Status: "operational"}, nil)
+			router := NewRouter(NewMessageHandler(&mocks.MockMessageService{}, &mocks.MockAuthService{}), nil, &mocks.MockAuthService{}).
+				WithStatusPageHandler(NewStatusPageHandler(mockStatusPageService, 1)).
+				WithTrustedProxies(tt.trustedProxies).
+				SetupRoutes()
+			request := func(forwardedFor string) int {
+				req, _ := http.NewRequest("GET", "/status-page", nil)
+				req.RemoteAddr = "10.0.0.1:40000"
+				req.Header.Set("X-Forwarded-For", forwardedFor)
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+				return w.Code
+			}
+
+			// Act
+			first := request("203.0.113.7")
+			second := request("198.51.100.4")
+
+			// Assert
+			assert.Equal(t, http.StatusOK, first)
+			assert.Equal(t, tt.wantStatus, second)
+		})
+	}
+}
+
+func TestIPRateLimiter_MaxClients(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	limiter := newIPRateLimiter(2, time.Minute)
+	limiter.maxClients = 2
+	limiter.now = func() time.Time { return now }
+
+	assert.Zero(t, limiter.Allow("203.0.113.7"))
+	assert.Zero(t, limiter.Allow("203.0.113.8"))
+	assert.Equal(t, time.Minute, limiter.Allow("203.0.113.9"), "new client refused while full")
+	assert.Zero(t, limiter.Allow("203.0.113.7"), "tracked clients are still served")
+	assert.Len(t, limiter.requests, 2)
+
+	// Once the tracked clients leave the window there is room again
+	now = now.Add(61 * time.Second)
+	assert.Zero(t, limiter.Allow("203.0.113.9"))
+	assert.Len(t, limiter.requests, 1)
+}
+
+func TestIPRateLimiter_SlidingWindow(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	limiter := newIPRateLimiter(2, time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	assert.Zero(t, limiter.Allow("203.0.113.7"))
+	now = now.Add(20 * time.Second)
+	assert.Zero(t, limiter.Allow("203.0.113.7"))
+	now = now.Add(20 * time.Second)
+	assert.Equal(t, 20*time.Second, limiter.Allow("203.0.113.7"))
+
+	// The first request leaves the window
+	now = now.Add(21 * time.Second)
+	assert.Zero(t, limiter.Allow("203.0.113.7"))
+}
@@ -0,0 +1,93 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type NotificationPreferenceHandler struct {
+	preferenceService domain.NotificationPreferenceService
+}
+
+// NewNotificationPreferenceHandler creates a new notification preference handler
+func NewNotificationPreferenceHandler(preferenceService domain.NotificationPreferenceService) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{preferenceService: preferenceService}
+}
+
+// ListPreferences handles GET /api/notification-preferences
+func (h *NotificationPreferenceHandler) ListPreferences(c *gin.Context) {
+	prefs, err := h.preferenceService.ListPreferences(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preferences": prefs,
+		"count":       len(prefs),
+	})
+}
+
+// GetPreferences handles GET /api/notification-preferences/:phone
+func (h *NotificationPreferenceHandler) GetPreferences(c *gin.Context) {
+	pref, err := h.preferenceService.GetPreferences(c.Request.Context(), c.Param("phone"))
+	if err != nil {
+		c.JSON(notificationPreferenceStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, pref)
+}
+
+// SetPreferences handles PUT /api/notification-preferences/:phone
+func (h *NotificationPreferenceHandler) SetPreferences(c *gin.Context) {
+	var req domain.SetNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	pref, err := h.preferenceService.SetPreferences(c.Request.Context(), c.Param("phone"), &req)
+	if err != nil {
+		c.JSON(notificationPreferenceStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, pref)
+}
+
+// DeletePreferences handles DELETE /api/notification-preferences/:phone
+func (h *NotificationPreferenceHandler) DeletePreferences(c *gin.Context) {
+	if err := h.preferenceService.DeletePreferences(c.Request.Context(), c.Param("phone")); err != nil {
+		c.JSON(notificationPreferenceStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Notification preferences deleted",
+	})
+}
+
+func notificationPreferenceStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidNotificationPreferences):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrNotificationPreferencesNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
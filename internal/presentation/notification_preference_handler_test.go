@@ -0,0 +1,80 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestNotificationPreferenceHandler_SetPreferences(t *testing.T) {
+	// Arrange
+	mockService := &mocks.MockNotificationPreferenceService{}
+	handler := NewNotificationPreferenceHandler(mockService)
+
+	router := setupTestRouter()
+	router.PUT("/notification-preferences/:phone", handler.SetPreferences)
+
+	expected := &domain.NotificationPreferences{
+		PhoneNumber: "6281234567890",
+		Email:       "ops@example.com",
+		Alerts:      map[string][]string{"sender_down": {"whatsapp", "email"}},
+	}
+	mockService.On("SetPreferences", mock.Anything, "6281234567890", &domain.SetNotificationPreferencesRequest{
+		Email:  "ops@example.com",
+		Alerts: map[string][]string{"sender_down": {"whatsapp", "email"}},
+	}).Return(expected, nil)
+
+	// Act
+	body := `{"email":"ops@example.com","alerts":{"sender_down":["whatsapp","email"]}}`
+	req, _ := http.NewRequest("PUT", "/notification-preferences/6281234567890", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response domain.NotificationPreferences
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, expected.Alerts, response.Alerts)
+	mockService.AssertExpectations(t)
+}
+
+func TestNotificationPreferenceHandler_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid preferences", domain.ErrInvalidNotificationPreferences, http.StatusBadRequest},
+		{"no preferences", domain.ErrNotificationPreferencesNotFound, http.StatusNotFound},
+		{"storage failure", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &mocks.MockNotificationPreferenceService{}
+			handler := NewNotificationPreferenceHandler(mockService)
+
+			router := setupTestRouter()
+			router.DELETE("/notification-preferences/:phone", handler.DeletePreferences)
+
+			mockService.On("DeletePreferences", mock.Anything, "6281234567890").Return(tt.err)
+
+			// Act
+			req, _ := http.NewRequest("DELETE", "/notification-preferences/6281234567890", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
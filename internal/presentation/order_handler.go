@@ -0,0 +1,92 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type OrderHandler struct {
+	orderService domain.OrderService
+}
+
+// NewOrderHandler creates a new handler for confirming chat orders
+func NewOrderHandler(orderService domain.OrderService) *OrderHandler {
+	return &OrderHandler{orderService: orderService}
+}
+
+// ListDraftOrders handles GET /api/orders/drafts
+func (h *OrderHandler) ListDraftOrders(c *gin.Context) {
+	orders, err := h.orderService.ListDraftOrders(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"orders": orders,
+		"count":  len(orders),
+	})
+}
+
+// ConfirmOrder handles POST /api/orders/:id/confirm
+func (h *OrderHandler) ConfirmOrder(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || orderID <= 0 {
+		c.JSON(http.StatusBadRequest, domain.ConfirmOrderResponse{
+			Success: false,
+			Message: "Invalid order ID",
+		})
+		return
+	}
+
+	response, err := h.orderService.ConfirmOrder(c.Request.Context(), orderID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrOrderNotFound:
+			statusCode = http.StatusNotFound
+		case domain.ErrOrderNotDraft:
+			statusCode = http.StatusConflict
+		case domain.ErrMessageSendFailed:
+			statusCode = http.StatusBadGateway
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SendReceipt handles POST /api/orders/:id/send-receipt
+func (h *OrderHandler) SendReceipt(c *gin.Context) {
+	orderID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || orderID <= 0 {
+		c.JSON(http.StatusBadRequest, domain.SendReceiptResponse{
+			Success: false,
+			Message: "Invalid order ID",
+		})
+		return
+	}
+
+	response, err := h.orderService.SendReceipt(c.Request.Context(), orderID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrOrderNotFound:
+			statusCode = http.StatusNotFound
+		case domain.ErrOrderMemberNoPhone:
+			statusCode = http.StatusUnprocessableEntity
+		case domain.ErrMessageSendFailed:
+			statusCode = http.StatusBadGateway
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,191 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestOrderHandler_ListDraftOrders_Success(t *testing.T) {
+	// Arrange
+	mockOrderService := &mocks.MockOrderService{}
+	handler := NewOrderHandler(mockOrderService)
+
+	router := setupTestRouter()
+	router.GET("/orders/drafts", handler.ListDraftOrders)
+
+	orders := []*domain.DraftOrder{
+		{ID: 42, MemberName: "Siti", PhoneNumber: "6281234567890", Service: "Cuci Kering", Kilos: 3.5, EstimatedPrice: 24500},
+	}
+	mockOrderService.On("ListDraftOrders", mock.Anything).Return(orders, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/orders/drafts", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Orders []domain.DraftOrder `json:"orders"`
+		Count  int                 `json:"count"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, 24500.0, response.Orders[0].EstimatedPrice)
+
+	mockOrderService.AssertExpectations(t)
+}
+
+func TestOrderHandler_ConfirmOrder_Success(t *testing.T) {
+	// Arrange
+	mockOrderService := &mocks.MockOrderService{}
+	handler := NewOrderHandler(mockOrderService)
+
+	router := setupTestRouter()
+	router.POST("/orders/:id/confirm", handler.ConfirmOrder)
+
+	expected := &domain.ConfirmOrderResponse{Success: true, OrderID: 42, Status: "pending", MessageID: "msg-1"}
+	mockOrderService.On("ConfirmOrder", mock.Anything, 42).Return(expected, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/orders/42/confirm", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.ConfirmOrderResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, "pending", response.Status)
+
+	mockOrderService.AssertExpectations(t)
+}
+
+func TestOrderHandler_ConfirmOrder_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unknown order", domain.ErrOrderNotFound, http.StatusNotFound},
+		{"already confirmed", domain.ErrOrderNotDraft, http.StatusConflict},
+		{"notification failed", domain.ErrMessageSendFailed, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockOrderService := &mocks.MockOrderService{}
+			handler := NewOrderHandler(mockOrderService)
+
+			router := setupTestRouter()
+			router.POST("/orders/:id/confirm", handler.ConfirmOrder)
+
+			mockOrderService.On("ConfirmOrder", mock.Anything, 42).
+				Return(&domain.ConfirmOrderResponse{Success: false}, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("POST", "/orders/42/confirm", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockOrderService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderHandler_ConfirmOrder_InvalidID(t *testing.T) {
+	// Arrange
+	mockOrderService := &mocks.MockOrderService{}
+	handler := NewOrderHandler(mockOrderService)
+
+	router := setupTestRouter()
+	router.POST("/orders/:id/confirm", handler.ConfirmOrder)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/orders/abc/confirm", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockOrderService.AssertNotCalled(t, "ConfirmOrder", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_SendReceipt_Success(t *testing.T) {
+	// Arrange
+	mockOrderService := &mocks.MockOrderService{}
+	handler := NewOrderHandler(mockOrderService)
+
+	router := setupTestRouter()
+	router.POST("/orders/:id/send-receipt", handler.SendReceipt)
+
+	expected := &domain.SendReceiptResponse{Success: true, OrderID: 42, FileName: "kwitansi-42.pdf", MessageID: "msg-1"}
+	mockOrderService.On("SendReceipt", mock.Anything, 42).Return(expected, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/orders/42/send-receipt", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.SendReceiptResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, "kwitansi-42.pdf", response.FileName)
+
+	mockOrderService.AssertExpectations(t)
+}
+
+func TestOrderHandler_SendReceipt_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid id", "/orders/abc/send-receipt", nil, http.StatusBadRequest},
+		{"unknown order", "/orders/42/send-receipt", domain.ErrOrderNotFound, http.StatusNotFound},
+		{"erased member", "/orders/42/send-receipt", domain.ErrOrderMemberNoPhone, http.StatusUnprocessableEntity},
+		{"send failed", "/orders/42/send-receipt", domain.ErrMessageSendFailed, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockOrderService := &mocks.MockOrderService{}
+			handler := NewOrderHandler(mockOrderService)
+
+			router := setupTestRouter()
+			router.POST("/orders/:id/send-receipt", handler.SendReceipt)
+
+			if tt.err != nil {
+				mockOrderService.On("SendReceipt", mock.Anything, 42).
+					Return(&domain.SendReceiptResponse{Success: false}, tt.err)
+			}
+
+			// Act
+			req, _ := http.NewRequest("POST", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockOrderService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package presentation
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type PickupHandler struct {
+	pickupService domain.PickupService
+}
+
+// NewPickupHandler creates a new pickup scheduling handler
+func NewPickupHandler(pickupService domain.PickupService) *PickupHandler {
+	return &PickupHandler{pickupService: pickupService}
+}
+
+// CreatePickupSlot handles POST /api/pickup-slots
+func (h *PickupHandler) CreatePickupSlot(c *gin.Context) {
+	var req domain.CreatePickupSlotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	slot, err := h.pickupService.CreatePickupSlot(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrInvalidPickupSlot {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, slot)
+}
+
+// GetSchedule handles GET /api/pickups?date=YYYY-MM-DD (defaults to today)
+func (h *PickupHandler) GetSchedule(c *gin.Context) {
+	date := c.DefaultQuery("date", time.Now().Format("2006-01-02"))
+
+	schedule, err := h.pickupService.GetSchedule(c.Request.Context(), date)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrInvalidDate {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
@@ -0,0 +1,121 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestPickupHandler_CreatePickupSlot_Success(t *testing.T) {
+	// Arrange
+	mockPickupService := &mocks.MockPickupService{}
+	handler := NewPickupHandler(mockPickupService)
+
+	router := setupTestRouter()
+	router.POST("/pickup-slots", handler.CreatePickupSlot)
+
+	reqBody := domain.CreatePickupSlotRequest{
+		StartsAt: "2026-10-17T09:00:00+07:00",
+		EndsAt:   "2026-10-17T11:00:00+07:00",
+		Capacity: 5,
+	}
+	expected := &domain.PickupSlot{ID: 3, StartsAt: reqBody.StartsAt, EndsAt: reqBody.EndsAt, Capacity: 5}
+	mockPickupService.On("CreatePickupSlot", mock.Anything, &reqBody).Return(expected, nil)
+
+	// Act
+	body, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/pickup-slots", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.PickupSlot
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 3, response.ID)
+
+	mockPickupService.AssertExpectations(t)
+}
+
+func TestPickupHandler_CreatePickupSlot_Invalid(t *testing.T) {
+	// Arrange
+	mockPickupService := &mocks.MockPickupService{}
+	handler := NewPickupHandler(mockPickupService)
+
+	router := setupTestRouter()
+	router.POST("/pickup-slots", handler.CreatePickupSlot)
+
+	mockPickupService.On("CreatePickupSlot", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidPickupSlot)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/pickup-slots", bytes.NewBufferString(`{"starts_at":"tomorrow","ends_at":"later","capacity":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockPickupService.AssertExpectations(t)
+}
+
+func TestPickupHandler_GetSchedule_Success(t *testing.T) {
+	// Arrange
+	mockPickupService := &mocks.MockPickupService{}
+	handler := NewPickupHandler(mockPickupService)
+
+	router := setupTestRouter()
+	router.GET("/pickups", handler.GetSchedule)
+
+	expected := &domain.PickupSchedule{
+		Date:  "2026-10-17",
+		Count: 1,
+		Pickups: []*domain.PickupScheduleEntry{
+			{BookingID: 1, SlotID: 3, MemberName: "Siti", PhoneNumber: "6281234567890"},
+		},
+	}
+	mockPickupService.On("GetSchedule", mock.Anything, "2026-10-17").Return(expected, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/pickups?date=2026-10-17", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.PickupSchedule
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "Siti", response.Pickups[0].MemberName)
+
+	mockPickupService.AssertExpectations(t)
+}
+
+func TestPickupHandler_GetSchedule_InvalidDate(t *testing.T) {
+	// Arrange
+	mockPickupService := &mocks.MockPickupService{}
+	handler := NewPickupHandler(mockPickupService)
+
+	router := setupTestRouter()
+	router.GET("/pickups", handler.GetSchedule)
+
+	mockPickupService.On("GetSchedule", mock.Anything, "17-10-2026").Return(nil, domain.ErrInvalidDate)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/pickups?date=17-10-2026", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockPickupService.AssertExpectations(t)
+}
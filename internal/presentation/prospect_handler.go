@@ -0,0 +1,75 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type ProspectHandler struct {
+	prospectService domain.ProspectService
+}
+
+// NewProspectHandler creates a new prospect handler
+func NewProspectHandler(prospectService domain.ProspectService) *ProspectHandler {
+	return &ProspectHandler{prospectService: prospectService}
+}
+
+// ListProspects handles GET /api/prospects
+func (h *ProspectHandler) ListProspects(c *gin.Context) {
+	includeConverted := false
+	if raw := c.Query("include_converted"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "include_converted must be true or false",
+			})
+			return
+		}
+		includeConverted = parsed
+	}
+
+	prospects, err := h.prospectService.ListProspects(c.Request.Context(), includeConverted)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"prospects": prospects,
+		"count":     len(prospects),
+	})
+}
+
+// ConvertProspect handles POST /api/prospects/:phone/convert
+func (h *ProspectHandler) ConvertProspect(c *gin.Context) {
+	var req domain.ConvertProspectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.ConvertProspectResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.prospectService.ConvertProspect(c.Request.Context(), c.Param("phone"), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidPhoneNumber, domain.ErrInvalidProspect:
+			statusCode = http.StatusBadRequest
+		case domain.ErrProspectNotFound:
+			statusCode = http.StatusNotFound
+		case domain.ErrProspectConverted:
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
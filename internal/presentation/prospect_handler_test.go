@@ -0,0 +1,103 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestProspectHandler_ListProspects_Success(t *testing.T) {
+	// Arrange
+	mockProspectService := &mocks.MockProspectService{}
+	handler := NewProspectHandler(mockProspectService)
+
+	router := setupTestRouter()
+	router.GET("/prospects", handler.ListProspects)
+
+	prospects := []*domain.Prospect{
+		{PhoneNumber: "6281234567890", PushName: "Budi", MessageCount: 3, LastIntent: "menu"},
+	}
+	mockProspectService.On("ListProspects", mock.Anything, true).Return(prospects, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/prospects?include_converted=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Prospects []*domain.Prospect `json:"prospects"`
+		Count     int                `json:"count"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, "Budi", response.Prospects[0].PushName)
+
+	mockProspectService.AssertExpectations(t)
+}
+
+func TestProspectHandler_ListProspects_InvalidFilter(t *testing.T) {
+	// Arrange
+	mockProspectService := &mocks.MockProspectService{}
+	handler := NewProspectHandler(mockProspectService)
+
+	router := setupTestRouter()
+	router.GET("/prospects", handler.ListProspects)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/prospects?include_converted=maybe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockProspectService.AssertNotCalled(t, "ListProspects", mock.Anything, mock.Anything)
+}
+
+func TestProspectHandler_ConvertProspect(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"converted", nil, http.StatusCreated},
+		{"missing details", domain.ErrInvalidProspect, http.StatusBadRequest},
+		{"unknown prospect", domain.ErrProspectNotFound, http.StatusNotFound},
+		{"already a member", domain.ErrProspectConverted, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockProspectService := &mocks.MockProspectService{}
+			handler := NewProspectHandler(mockProspectService)
+
+			router := setupTestRouter()
+			router.POST("/prospects/:phone/convert", handler.ConvertProspect)
+
+			reqBody := domain.ConvertProspectRequest{Name: "Budi", Address: "Jl. Merdeka 1"}
+			response := &domain.ConvertProspectResponse{Success: tt.err == nil, MemberID: 9}
+			mockProspectService.On("ConvertProspect", mock.Anything, "6281234567890", &reqBody).Return(response, tt.err)
+
+			// Act
+			body, _ := json.Marshal(reqBody)
+			req, _ := http.NewRequest("POST", "/prospects/6281234567890/convert", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockProspectService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type ReminderHandler struct {
+	reminderService domain.ReminderService
+}
+
+// NewReminderHandler creates a new reminder rule handler
+func NewReminderHandler(reminderService domain.ReminderService) *ReminderHandler {
+	return &ReminderHandler{reminderService: reminderService}
+}
+
+// CreateRule handles POST /api/reminder-rules
+func (h *ReminderHandler) CreateRule(c *gin.Context) {
+	var req domain.CreateReminderRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	rule, err := h.reminderService.CreateRule(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrInvalidReminderRule {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// ListRules handles GET /api/reminder-rules
+func (h *ReminderHandler) ListRules(c *gin.Context) {
+	rules, err := h.reminderService.ListRules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"count": len(rules),
+	})
+}
+
+// ClaimRedemption handles POST /api/redemptions/:id/claim
+func (h *ReminderHandler) ClaimRedemption(c *gin.Context) {
+	transactionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || transactionID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid redemption ID",
+		})
+		return
+	}
+
+	if err := h.reminderService.ClaimRedemption(c.Request.Context(), transactionID); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrRedemptionNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Reward marked as claimed",
+	})
+}
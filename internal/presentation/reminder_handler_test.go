@@ -0,0 +1,106 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestReminderHandler_CreateRule_Success(t *testing.T) {
+	// Arrange
+	mockReminderService := &mocks.MockReminderService{}
+	handler := NewReminderHandler(mockReminderService)
+
+	router := setupTestRouter()
+	router.POST("/reminder-rules", handler.CreateRule)
+
+	reqBody := domain.CreateReminderRuleRequest{
+		Name:          "order-ready-48h",
+		Trigger:       "order_ready",
+		DelayHours:    48,
+		MaxReminders:  3,
+		EscalateAfter: 3,
+		Message:       "Pesanan #{{subject_id}} siap diambil",
+	}
+	expected := &domain.ReminderRule{ID: 5, Name: reqBody.Name, Trigger: reqBody.Trigger, IsActive: true}
+	mockReminderService.On("CreateRule", mock.Anything, &reqBody).Return(expected, nil)
+
+	// Act
+	body, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/reminder-rules", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.ReminderRule
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 5, response.ID)
+
+	mockReminderService.AssertExpectations(t)
+}
+
+func TestReminderHandler_CreateRule_Invalid(t *testing.T) {
+	// Arrange
+	mockReminderService := &mocks.MockReminderService{}
+	handler := NewReminderHandler(mockReminderService)
+
+	router := setupTestRouter()
+	router.POST("/reminder-rules", handler.CreateRule)
+
+	mockReminderService.On("CreateRule", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidReminderRule)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/reminder-rules", bytes.NewBufferString(`{"name":"x","trigger":"unknown","message":"hi"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockReminderService.AssertExpectations(t)
+}
+
+func TestReminderHandler_ClaimRedemption(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		callsSvc   bool
+		wantStatus int
+	}{
+		{"claimed", "/redemptions/12/claim", nil, true, http.StatusOK},
+		{"not found", "/redemptions/12/claim", domain.ErrRedemptionNotFound, true, http.StatusNotFound},
+		{"bad id", "/redemptions/abc/claim", nil, false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockReminderService := &mocks.MockReminderService{}
+			handler := NewReminderHandler(mockReminderService)
+
+			router := setupTestRouter()
+			router.POST("/redemptions/:id/claim", handler.ClaimRedemption)
+
+			if tt.callsSvc {
+				mockReminderService.On("ClaimRedemption", mock.Anything, 12).Return(tt.err)
+			}
+
+			req, _ := http.NewRequest("POST", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockReminderService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type ReportHandler struct {
+	reportService domain.ReportService
+}
+
+// NewReportHandler creates a new handler for scheduled report deliveries
+func NewReportHandler(reportService domain.ReportService) *ReportHandler {
+	return &ReportHandler{reportService: reportService}
+}
+
+// CreateSchedule handles POST /api/report-schedules
+func (h *ReportHandler) CreateSchedule(c *gin.Context) {
+	var req domain.ReportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	schedule, err := h.reportService.CreateSchedule(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(reportStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// ListSchedules handles GET /api/report-schedules
+func (h *ReportHandler) ListSchedules(c *gin.Context) {
+	schedules, err := h.reportService.ListSchedules(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"schedules": schedules,
+		"count":     len(schedules),
+	})
+}
+
+// UpdateSchedule handles PUT /api/report-schedules/:id
+func (h *ReportHandler) UpdateSchedule(c *gin.Context) {
+	id, ok := reportScheduleID(c)
+	if !ok {
+		return
+	}
+	var req domain.ReportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	schedule, err := h.reportService.UpdateSchedule(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(reportStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// DeleteSchedule handles DELETE /api/report-schedules/:id
+func (h *ReportHandler) DeleteSchedule(c *gin.Context) {
+	id, ok := reportScheduleID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.reportService.DeleteSchedule(c.Request.Context(), id); err != nil {
+		c.JSON(reportStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Report schedule deleted",
+	})
+}
+
+// SendSchedule handles POST /api/report-schedules/:id/send
+func (h *ReportHandler) SendSchedule(c *gin.Context) {
+	id, ok := reportScheduleID(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.reportService.SendSchedule(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(reportStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// reportScheduleID parses the schedule ID of the path, answering 400 when it
+// is not one
+func reportScheduleID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid report schedule ID",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+func reportStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidReportSchedule):
+		return http.StatusBadRequest
+	case err == domain.ErrReportScheduleNotFound:
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
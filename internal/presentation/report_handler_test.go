@@ -0,0 +1,157 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestReportHandler_CreateSchedule(t *testing.T) {
+	// Arrange
+	mockReportService := &mocks.MockReportService{}
+	handler := NewReportHandler(mockReportService)
+
+	router := setupTestRouter()
+	router.POST("/report-schedules", handler.CreateSchedule)
+
+	req := &domain.ReportScheduleRequest{
+		Name:               "Laporan Harian",
+		Reports:            []string{domain.ReportMessageVolume},
+		Frequency:          domain.ReportDaily,
+		Hour:               7,
+		WhatsAppRecipients: []string{"6281234567890"},
+	}
+	schedule := &domain.ReportSchedule{ID: 1, Name: "Laporan Harian", Active: true, NextRunAt: "2026-10-17T07:00:00+07:00"}
+	mockReportService.On("CreateSchedule", mock.Anything, req).Return(schedule, nil)
+
+	// Act
+	body, _ := json.Marshal(req)
+	httpReq, _ := http.NewRequest("POST", "/report-schedules", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response domain.ReportSchedule
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.ID)
+	mockReportService.AssertExpectations(t)
+}
+
+func TestReportHandler_CreateSchedule_Invalid(t *testing.T) {
+	// Arrange
+	mockReportService := &mocks.MockReportService{}
+	handler := NewReportHandler(mockReportService)
+
+	router := setupTestRouter()
+	router.POST("/report-schedules", handler.CreateSchedule)
+
+	mockReportService.On("CreateSchedule", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: hour must be between 0 and 23", domain.ErrInvalidReportSchedule))
+
+	// Act
+	httpReq, _ := http.NewRequest("POST", "/report-schedules", bytes.NewBufferString(`{"name":"Daily","hour":30}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "hour must be between 0 and 23")
+}
+
+func TestReportHandler_ListSchedules(t *testing.T) {
+	// Arrange
+	mockReportService := &mocks.MockReportService{}
+	handler := NewReportHandler(mockReportService)
+
+	router := setupTestRouter()
+	router.GET("/report-schedules", handler.ListSchedules)
+
+	mockReportService.On("ListSchedules", mock.Anything).
+		Return([]*domain.ReportSchedule{{ID: 1, Name: "Daily"}, {ID: 2, Name: "Weekly"}}, nil)
+
+	// Act
+	httpReq, _ := http.NewRequest("GET", "/report-schedules", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Schedules []domain.ReportSchedule `json:"schedules"`
+		Count     int                     `json:"count"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Count)
+	assert.Equal(t, "Weekly", response.Schedules[1].Name)
+}
+
+func TestReportHandler_DeleteSchedule_NotFound(t *testing.T) {
+	// Arrange
+	mockReportService := &mocks.MockReportService{}
+	handler := NewReportHandler(mockReportService)
+
+	router := setupTestRouter()
+	router.DELETE("/report-schedules/:id", handler.DeleteSchedule)
+
+	mockReportService.On("DeleteSchedule", mock.Anything, 9).Return(domain.ErrReportScheduleNotFound)
+
+	// Act
+	httpReq, _ := http.NewRequest("DELETE", "/report-schedules/9", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockReportService.AssertExpectations(t)
+}
+
+func TestReportHandler_SendSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		delivery   *domain.ReportDelivery
+		err        error
+		wantStatus int
+	}{
+		{"sent", "/report-schedules/3/send", &domain.ReportDelivery{ScheduleID: 3, FileName: "daily-2026-10-15.xlsx"}, nil, http.StatusOK},
+		{"not found", "/report-schedules/3/send", nil, domain.ErrReportScheduleNotFound, http.StatusNotFound},
+		{"render failed", "/report-schedules/3/send", nil, errors.New("connection refused"), http.StatusInternalServerError},
+		{"bad id", "/report-schedules/abc/send", nil, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockReportService := &mocks.MockReportService{}
+			handler := NewReportHandler(mockReportService)
+
+			router := setupTestRouter()
+			router.POST("/report-schedules/:id/send", handler.SendSchedule)
+
+			if tt.delivery != nil || tt.err != nil {
+				mockReportService.On("SendSchedule", mock.Anything, 3).Return(tt.delivery, tt.err)
+			}
+
+			// Act
+			httpReq, _ := http.NewRequest("POST", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httpReq)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockReportService.AssertExpectations(t)
+		})
+	}
+}
@@ -2,18 +2,58 @@ package presentation
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/selftest"
 )
 
 type Router struct {
 	messageHandler            *MessageHandler
 	senderRegistrationHandler *SenderRegistrationHandler
 	aiHandler                 *AIHandler
+	tenantHandler             *TenantHandler
+	locationHandler           *LocationHandler
+	memberHandler             *MemberHandler
+	messageHistoryHandler     *MessageHistoryHandler
+	driverHandler             *DriverHandler
+	orderHandler              *OrderHandler
+	pickupHandler             *PickupHandler
+	reminderHandler           *ReminderHandler
+	templateHandler           *TemplateHandler
+	automationHandler         *AutomationHandler
+	broadcastHandler          *BroadcastHandler
+	campaignHandler           *CampaignHandler
+	voucherHandler            *VoucherHandler
+	branchHandler             *BranchHandler
+	groupHandler              *GroupHandler
+	vipGroupHandler           *VIPGroupHandler
+	labelHandler              *LabelHandler
+	inventoryHandler          *InventoryHandler
+	staffHandler              *StaffHandler
+	reportHandler             *ReportHandler
+	statusPageHandler         *StatusPageHandler
+	softLaunchHandler         *SoftLaunchHandler
+	senderHandler             *SenderHandler
+	senderActivityHandler     *SenderActivityHandler
+	blocklistHandler          *BlocklistHandler
+	storageHandler            *StorageHandler
+	systemInfoHandler         *SystemInfoHandler
+	versionHandler            *VersionHandler
+	prospectHandler           *ProspectHandler
+	senderChainHandler        *SenderChainHandler
+	senderPoolHandler         *SenderPoolHandler
+	notificationPrefHandler   *NotificationPreferenceHandler
+	confirmationHandler       *ConfirmationHandler
+	deadLetterHandler         *DeadLetterHandler
+	suppressionHandler        *SuppressionHandler
 	authService               domain.AuthService
+	unversionedSunset         time.Time
+	trustedProxies            []string
 }
 
 // NewRouter creates a new router
@@ -35,20 +75,275 @@ func NewRouterWithRegistration(messageHandler *MessageHandler, senderRegistratio
 	}
 }
 
+// WithTenantHandler enables the tenant onboarding endpoints
+func (r *Router) WithTenantHandler(tenantHandler *TenantHandler) *Router {
+	r.tenantHandler = tenantHandler
+	return r
+}
+
+// WithLocationHandler enables the member location endpoints
+func (r *Router) WithLocationHandler(locationHandler *LocationHandler) *Router {
+	r.locationHandler = locationHandler
+	return r
+}
+
+// WithMemberHandler enables the member erasure endpoint
+func (r *Router) WithMemberHandler(memberHandler *MemberHandler) *Router {
+	r.memberHandler = memberHandler
+	return r
+}
+
+// WithMessageHistoryHandler enables the message history endpoint
+func (r *Router) WithMessageHistoryHandler(messageHistoryHandler *MessageHistoryHandler) *Router {
+	r.messageHistoryHandler = messageHistoryHandler
+	return r
+}
+
+// WithDriverHandler enables the driver management and dispatch endpoints
+func (r *Router) WithDriverHandler(driverHandler *DriverHandler) *Router {
+	r.driverHandler = driverHandler
+	return r
+}
+
+// WithOrderHandler enables the endpoints for confirming chat orders
+func (r *Router) WithOrderHandler(orderHandler *OrderHandler) *Router {
+	r.orderHandler = orderHandler
+	return r
+}
+
+// WithPickupHandler enables the pickup scheduling endpoints
+func (r *Router) WithPickupHandler(pickupHandler *PickupHandler) *Router {
+	r.pickupHandler = pickupHandler
+	return r
+}
+
+// WithReminderHandler enables the reminder rule endpoints
+func (r *Router) WithReminderHandler(reminderHandler *ReminderHandler) *Router {
+	r.reminderHandler = reminderHandler
+	return r
+}
+
+// WithProspectHandler enables the prospect (lead) endpoints
+func (r *Router) WithProspectHandler(prospectHandler *ProspectHandler) *Router {
+	r.prospectHandler = prospectHandler
+	return r
+}
+
+// WithTemplateHandler enables the message template endpoints
+func (r *Router) WithTemplateHandler(templateHandler *TemplateHandler) *Router {
+	r.templateHandler = templateHandler
+	return r
+}
+
+// WithAutomationHandler enables the automation rule endpoints
+func (r *Router) WithAutomationHandler(automationHandler *AutomationHandler) *Router {
+	r.automationHandler = automationHandler
+	return r
+}
+
+// WithBroadcastHandler enables the bulk broadcast endpoints
+func (r *Router) WithBroadcastHandler(broadcastHandler *BroadcastHandler) *Router {
+	r.broadcastHandler = broadcastHandler
+	return r
+}
+
+// WithCampaignHandler enables the points campaign endpoints
+func (r *Router) WithCampaignHandler(campaignHandler *CampaignHandler) *Router {
+	r.campaignHandler = campaignHandler
+	return r
+}
+
+// WithVoucherHandler enables the voucher redemption endpoint
+func (r *Router) WithVoucherHandler(voucherHandler *VoucherHandler) *Router {
+	r.voucherHandler = voucherHandler
+	return r
+}
+
+// WithBranchHandler enables the branch directory endpoints
+func (r *Router) WithBranchHandler(branchHandler *BranchHandler) *Router {
+	r.branchHandler = branchHandler
+	return r
+}
+
+// WithGroupHandler enables the WhatsApp group management endpoints
+func (r *Router) WithGroupHandler(groupHandler *GroupHandler) *Router {
+	r.groupHandler = groupHandler
+	return r
+}
+
+// WithVIPGroupHandler enables the VIP group sync endpoints
+func (r *Router) WithVIPGroupHandler(vipGroupHandler *VIPGroupHandler) *Router {
+	r.vipGroupHandler = vipGroupHandler
+	return r
+}
+
+// WithLabelHandler enables the WhatsApp Business label endpoints
+func (r *Router) WithLabelHandler(labelHandler *LabelHandler) *Router {
+	r.labelHandler = labelHandler
+	return r
+}
+
+// WithInventoryHandler enables the supplies inventory endpoints
+func (r *Router) WithInventoryHandler(inventoryHandler *InventoryHandler) *Router {
+	r.inventoryHandler = inventoryHandler
+	return r
+}
+
+// WithStaffHandler enables the staff activity report endpoint
+func (r *Router) WithStaffHandler(staffHandler *StaffHandler) *Router {
+	r.staffHandler = staffHandler
+	return r
+}
+
+// WithReportHandler enables the scheduled report endpoints
+func (r *Router) WithReportHandler(reportHandler *ReportHandler) *Router {
+	r.reportHandler = reportHandler
+	return r
+}
+
+// WithStatusPageHandler enables the public status page and the incident
+// endpoints
+func (r *Router) WithStatusPageHandler(statusPageHandler *StatusPageHandler) *Router {
+	r.statusPageHandler = statusPageHandler
+	return r
+}
+
+// WithSoftLaunchHandler enables the soft launch report endpoint
+func (r *Router) WithSoftLaunchHandler(softLaunchHandler *SoftLaunchHandler) *Router {
+	r.softLaunchHandler = softLaunchHandler
+	return r
+}
+
+// WithSenderHandler enables editing, pausing and resuming senders
+func (r *Router) WithSenderHandler(senderHandler *SenderHandler) *Router {
+	r.senderHandler = senderHandler
+	return r
+}
+
+// WithBlocklistHandler enables blocking and unblocking contacts per sender
+func (r *Router) WithBlocklistHandler(blocklistHandler *BlocklistHandler) *Router {
+	r.blocklistHandler = blocklistHandler
+	return r
+}
+
+// WithSenderActivityHandler enables the sender activity heatmap endpoint
+func (r *Router) WithSenderActivityHandler(senderActivityHandler *SenderActivityHandler) *Router {
+	r.senderActivityHandler = senderActivityHandler
+	return r
+}
+
+// WithStorageHandler enables the storage usage endpoint
+func (r *Router) WithStorageHandler(storageHandler *StorageHandler) *Router {
+	r.storageHandler = storageHandler
+	return r
+}
+
+// WithSystemInfoHandler enables the boot report endpoint
+func (r *Router) WithSystemInfoHandler(systemInfoHandler *SystemInfoHandler) *Router {
+	r.systemInfoHandler = systemInfoHandler
+	return r
+}
+
+// WithVersionHandler enables the build version endpoint
+func (r *Router) WithVersionHandler(versionHandler *VersionHandler) *Router {
+	r.versionHandler = versionHandler
+	return r
+}
+
+// WithSenderChainHandler enables the sender fallback chain endpoints
+func (r *Router) WithSenderChainHandler(senderChainHandler *SenderChainHandler) *Router {
+	r.senderChainHandler = senderChainHandler
+	return r
+}
+
+// WithSenderPoolHandler enables the named sender pool endpoints
+func (r *Router) WithSenderPoolHandler(senderPoolHandler *SenderPoolHandler) *Router {
+	r.senderPoolHandler = senderPoolHandler
+	return r
+}
+
+// WithNotificationPreferenceHandler enables the admin notification preference
+// endpoints
+func (r *Router) WithNotificationPreferenceHandler(notificationPrefHandler *NotificationPreferenceHandler) *Router {
+	r.notificationPrefHandler = notificationPrefHandler
+	return r
+}
+
+// WithSuppressionHandler enables the suppression list endpoints
+func (r *Router) WithSuppressionHandler(suppressionHandler *SuppressionHandler) *Router {
+	r.suppressionHandler = suppressionHandler
+	return r
+}
+
+// WithDeadLetterHandler enables the dead letter endpoints
+func (r *Router) WithDeadLetterHandler(deadLetterHandler *DeadLetterHandler) *Router {
+	r.deadLetterHandler = deadLetterHandler
+	return r
+}
+
+// WithConfirmationHandler enables the confirmation endpoint. Without it the
+// destructive endpoints it guards refuse every request.
+func (r *Router) WithConfirmationHandler(confirmationHandler *ConfirmationHandler) *Router {
+	r.confirmationHandler = confirmationHandler
+	return r
+}
+
+// confirmed guards a destructive endpoint with a confirmation of action on the
+// target in URL parameter param
+func (r *Router) confirmed(action, param string) gin.HandlerFunc {
+	if r.confirmationHandler == nil {
+		return func(c *gin.Context) {
+			c.AbortWithStatusJSON(confirmationStatusCode(domain.ErrConfirmationDisabled), gin.H{
+				"error":  domain.ErrConfirmationDisabled.Error(),
+				"action": action,
+			})
+		}
+	}
+	return ConfirmationMiddleware(r.confirmationHandler.confirmationService, action, param)
+}
+
+// WithUnversionedSunset sets the Sunset date advertised on the deprecated
+// unversioned /api routes
+func (r *Router) WithUnversionedSunset(sunset time.Time) *Router {
+	r.unversionedSunset = sunset
+	return r
+}
+
+// WithTrustedProxies sets the reverse proxies allowed to name the client IP in
+// X-Forwarded-For. Without them the client IP is the address the request came
+// from, so a client cannot pick the IP it is rate limited as.
+func (r *Router) WithTrustedProxies(proxies []string) *Router {
+	r.trustedProxies = proxies
+	return r
+}
+
 // SetupRoutes sets up all the routes
 func (r *Router) SetupRoutes() *gin.Engine {
 	// Set Gin to release mode for production
 	gin.SetMode(gin.ReleaseMode)
 
 	router := gin.New()
+	if err := router.SetTrustedProxies(r.trustedProxies); err != nil {
+		fmt.Printf("Warning: invalid trusted proxies, trusting none: %v\n", err)
+		_ = router.SetTrustedProxies(nil)
+	}
 
 	// Middleware
-	router.Use(gin.Logger())
+	router.Use(RequestIDMiddleware())
+	router.Use(gin.LoggerWithFormatter(requestLogFormatter))
 	router.Use(gin.Recovery())
 
 	// Health check endpoint (no auth required)
 	router.GET("/health", r.messageHandler.HealthCheck)
 
+	// Public status page for customers (no auth required, rate-limited per IP)
+	if r.statusPageHandler != nil {
+		router.GET("/status-page", RateLimitMiddleware(r.statusPageHandler.perMinute, time.Minute), r.statusPageHandler.GetStatusPage)
+	}
+
+	// Postman collection mirroring the selftest contract suite (no auth required)
+	router.GET("/postman-collection.json", servePostmanCollection)
+
 	// Determine web directory path
 	webDir := r.findWebDirectory()
 	fmt.Printf("Using web directory: %s\n", webDir)
@@ -63,26 +358,15 @@ func (r *Router) SetupRoutes() *gin.Engine {
 	router.StaticFile("/register", registerPath)
 	router.Static("/web", webDir)
 
-	// API routes with Basic Auth
-	apiRoutes := router.Group("/api")
-	apiRoutes.Use(AuthMiddleware(r.authService))
-	{
-		apiRoutes.POST("/send-message", r.messageHandler.SendMessage)
-		apiRoutes.GET("/status", r.messageHandler.GetStatus)
-		apiRoutes.GET("/senders", r.messageHandler.ListSenders)
-
-		// AI reply suggestion (always registered; returns 503 when disabled)
-		if r.aiHandler != nil {
-			apiRoutes.POST("/ai/reply", r.aiHandler.GenerateAIReply)
-		}
+	// Versioned API routes with Basic Auth
+	v1Routes := router.Group("/api/" + APIVersionV1)
+	v1Routes.Use(APIVersionMiddleware(APIVersionV1), AuthMiddleware(r.authService))
+	r.registerAPIRoutes(v1Routes)
 
-		// Sender registration endpoints (if handler is available)
-		if r.senderRegistrationHandler != nil {
-			apiRoutes.POST("/register-sender-qr", r.senderRegistrationHandler.StartQRRegistration)
-			apiRoutes.POST("/register-sender-code", r.senderRegistrationHandler.StartCodeRegistration)
-			apiRoutes.GET("/register-sender-status/:sessionId", r.senderRegistrationHandler.GetRegistrationStatus)
-		}
-	}
+	// Unversioned aliases, kept for existing clients until the sunset date
+	apiRoutes := router.Group("/api")
+	apiRoutes.Use(DeprecationMiddleware(r.unversionedSunset), APIVersionMiddleware(""), AuthMiddleware(r.authService))
+	r.registerAPIRoutes(apiRoutes)
 
 	// Fallback for SPA routing
 	router.NoRoute(func(c *gin.Context) {
@@ -92,6 +376,310 @@ func (r *Router) SetupRoutes() *gin.Engine {
 	return router
 }
 
+// registerAPIRoutes registers the authenticated API endpoints on a route group
+func (r *Router) registerAPIRoutes(api *gin.RouterGroup) {
+	api.POST("/send-message", r.messageHandler.SendMessage)
+	if r.messageHandler.batch != nil {
+		api.POST("/send-messages", r.messageHandler.SendMessages)
+	}
+	api.POST("/send-image", r.messageHandler.SendImage)
+	api.POST("/send-document", r.messageHandler.SendDocument)
+	api.POST("/send-audio", r.messageHandler.SendAudio)
+	api.POST("/send-location", r.messageHandler.SendLocation)
+	api.POST("/send-contact", r.messageHandler.SendContact)
+	api.POST("/send-reaction", r.messageHandler.SendReaction)
+	api.DELETE("/messages/:id", r.confirmed(domain.ConfirmActionRevokeMessage, "id"), r.messageHandler.RevokeMessage)
+	api.PATCH("/messages/:id", r.messageHandler.EditMessage)
+	api.POST("/send-chat-presence", r.messageHandler.SendChatPresence)
+	api.POST("/set-presence", r.messageHandler.SetPresence)
+	api.POST("/set-disappearing", r.messageHandler.SetDisappearing)
+	api.GET("/jobs/:id", r.messageHandler.GetSendJob)
+	api.GET("/status", r.messageHandler.GetStatus)
+	api.GET("/senders", r.messageHandler.ListSenders)
+
+	// Queued messages given up on, and requeueing them
+	if r.deadLetterHandler != nil {
+		api.GET("/dead-letters", r.deadLetterHandler.ListDeadLetters)
+		api.POST("/dead-letters/:id/retry", r.deadLetterHandler.RetryDeadLetter)
+	}
+
+	// Recipients that opted out or are blocked; sends to them are refused
+	if r.suppressionHandler != nil {
+		api.GET("/suppressions", r.suppressionHandler.ListSuppressions)
+		api.POST("/suppressions", r.suppressionHandler.CreateSuppression)
+		api.GET("/suppressions/:recipient", r.suppressionHandler.GetSuppression)
+		api.DELETE("/suppressions/:recipient", r.suppressionHandler.DeleteSuppression)
+	}
+
+	// Audit trail of the messages sent through the API, and reports on it
+	if r.messageHistoryHandler != nil {
+		api.GET("/messages", r.messageHistoryHandler.ListMessages)
+		api.GET("/messages/:id/edits", r.messageHistoryHandler.ListEdits)
+		api.GET("/reports/busiest-contacts", r.messageHistoryHandler.GetBusiestContacts)
+		api.GET("/reports/message-volume", r.messageHistoryHandler.GetMessageVolume)
+	}
+
+	// AI reply suggestion (always registered; returns 503 when disabled)
+	if r.aiHandler != nil {
+		api.POST("/ai/reply", r.aiHandler.GenerateAIReply)
+	}
+
+	// Sender registration endpoints (if handler is available)
+	if r.senderRegistrationHandler != nil {
+		api.POST("/register-sender-qr", r.senderRegistrationHandler.StartQRRegistration)
+		api.POST("/register-sender-code", r.senderRegistrationHandler.StartCodeRegistration)
+		api.GET("/register-sender-status/:sessionId", r.senderRegistrationHandler.GetRegistrationStatus)
+		if r.senderRegistrationHandler.batches != nil {
+			api.POST("/register-senders", r.senderRegistrationHandler.StartRegistrationBatch)
+			api.GET("/register-senders/:id", r.senderRegistrationHandler.GetRegistrationBatch)
+		}
+	}
+
+	// Second-factor confirmations for the destructive actions, which are each
+	// registered guarded by r.confirmed
+	if r.confirmationHandler != nil {
+		api.POST("/confirmations", r.confirmationHandler.CreateConfirmation)
+	}
+	if r.senderRegistrationHandler != nil {
+		api.DELETE("/senders/:id", r.confirmed(domain.ConfirmActionDeleteSender, "id"), r.senderRegistrationHandler.DeleteSender)
+		api.DELETE("/sessions", r.confirmed(domain.ConfirmActionClearSessions, ""), r.senderRegistrationHandler.ClearSessions)
+	}
+
+	// Tenant onboarding (if handler is available)
+	if r.tenantHandler != nil {
+		api.POST("/tenants", r.tenantHandler.CreateTenant)
+		api.GET("/tenants/:slug/onboarding-nudge", r.tenantHandler.GetOnboardingNudge)
+		api.PUT("/tenants/:slug/onboarding-nudge", r.tenantHandler.SetOnboardingNudge)
+		api.GET("/tenants/:slug/loyalty-program", r.tenantHandler.GetLoyaltyProgram)
+		api.PUT("/tenants/:slug/loyalty-program", r.tenantHandler.SetLoyaltyProgram)
+		api.GET("/tenants/:slug/message-log", r.tenantHandler.GetMessageLog)
+		api.PUT("/tenants/:slug/message-log", r.tenantHandler.SetMessageLog)
+	}
+
+	// Member pickup/delivery locations for driver integrations
+	if r.locationHandler != nil {
+		api.GET("/members/:phone/location", r.locationHandler.GetMemberLocation)
+	}
+
+	// Erasing a member's personal data
+	if r.memberHandler != nil {
+		api.DELETE("/members/:phone", r.confirmed(domain.ConfirmActionEraseMember, "phone"), r.memberHandler.EraseMember)
+	}
+
+	// Delivery drivers and order dispatch
+	if r.driverHandler != nil {
+		api.POST("/drivers", r.driverHandler.CreateDriver)
+		api.GET("/drivers", r.driverHandler.ListDrivers)
+		api.POST("/orders/:id/dispatch", r.driverHandler.DispatchOrder)
+	}
+
+	// Orders placed in chat awaiting staff confirmation
+	if r.orderHandler != nil {
+		api.GET("/orders/drafts", r.orderHandler.ListDraftOrders)
+		api.POST("/orders/:id/confirm", r.orderHandler.ConfirmOrder)
+		api.POST("/orders/:id/send-receipt", r.orderHandler.SendReceipt)
+	}
+
+	// Pickup slots and the daily pickup schedule
+	if r.pickupHandler != nil {
+		api.POST("/pickup-slots", r.pickupHandler.CreatePickupSlot)
+		api.GET("/pickups", r.pickupHandler.GetSchedule)
+	}
+
+	// Reminder rules and reward claims
+	if r.reminderHandler != nil {
+		api.POST("/reminder-rules", r.reminderHandler.CreateRule)
+		api.GET("/reminder-rules", r.reminderHandler.ListRules)
+		api.POST("/redemptions/:id/claim", r.reminderHandler.ClaimRedemption)
+	}
+
+	// Unregistered contacts tracked as leads
+	if r.prospectHandler != nil {
+		api.GET("/prospects", r.prospectHandler.ListProspects)
+		api.POST("/prospects/:phone/convert", r.prospectHandler.ConvertProspect)
+	}
+
+	// Message templates and sending by template
+	if r.templateHandler != nil {
+		api.GET("/templates", r.templateHandler.ListTemplates)
+		api.POST("/templates", r.templateHandler.CreateTemplate)
+		api.GET("/templates/:name", r.templateHandler.GetTemplate)
+		api.PUT("/templates/:name", r.templateHandler.UpdateTemplate)
+		api.DELETE("/templates/:name", r.confirmed(domain.ConfirmActionDeleteTemplate, "name"), r.templateHandler.DeleteTemplate)
+		api.POST("/send-template", r.templateHandler.SendTemplate)
+	}
+
+	// Automation rules that send templates on domain events
+	if r.automationHandler != nil {
+		api.GET("/automations", r.automationHandler.ListRules)
+		api.POST("/automations", r.automationHandler.CreateRule)
+		api.DELETE("/automations/:id", r.confirmed(domain.ConfirmActionDeleteAutomation, "id"), r.automationHandler.DeleteRule)
+	}
+
+	// Bulk broadcasts sent in the background
+	if r.broadcastHandler != nil {
+		api.POST("/broadcast", r.broadcastHandler.StartBroadcast)
+		api.GET("/broadcast/:id", r.broadcastHandler.GetBroadcast)
+	}
+
+	// Time-limited points multipliers such as happy hours
+	if r.campaignHandler != nil {
+		api.GET("/campaigns", r.campaignHandler.ListCampaigns)
+		api.POST("/campaigns", r.campaignHandler.CreateCampaign)
+		api.DELETE("/campaigns/:id", r.confirmed(domain.ConfirmActionDeleteCampaign, "id"), r.campaignHandler.DeleteCampaign)
+	}
+
+	// Vouchers issued for points redemptions
+	if r.voucherHandler != nil {
+		api.GET("/vouchers/settlement", r.voucherHandler.GetSettlement)
+		api.POST("/vouchers/:code/redeem", r.voucherHandler.RedeemVoucher)
+	}
+
+	// Branch directory and sharing a branch's location
+	if r.branchHandler != nil {
+		api.GET("/branches", r.branchHandler.ListBranches)
+		api.POST("/branches", r.branchHandler.CreateBranch)
+		api.GET("/branches/:id", r.branchHandler.GetBranch)
+		api.PUT("/branches/:id", r.branchHandler.UpdateBranch)
+		api.DELETE("/branches/:id", r.confirmed(domain.ConfirmActionDeleteBranch, "id"), r.branchHandler.DeleteBranch)
+		api.POST("/branches/:id/send-location", r.branchHandler.SendBranchLocation)
+	}
+
+	// The sender's WhatsApp groups and their members
+	if r.groupHandler != nil {
+		api.GET("/groups", r.groupHandler.ListGroups)
+		api.POST("/groups", r.groupHandler.CreateGroup)
+		api.POST("/groups/:jid/participants", r.groupHandler.AddParticipants)
+		api.DELETE("/groups/:jid/participants", r.confirmed(domain.ConfirmActionRemoveFromGroup, "jid"), r.groupHandler.RemoveParticipants)
+		api.GET("/groups/:jid/invite-link", r.groupHandler.GetInviteLink)
+		api.POST("/groups/:jid/invite-link/reset", r.groupHandler.ResetInviteLink)
+		api.PUT("/groups/:jid/description", r.groupHandler.SetDescription)
+	}
+
+	// The VIP group kept in sync with the qualifying tiers
+	if r.vipGroupHandler != nil {
+		api.POST("/vip-group/sync", r.vipGroupHandler.Sync)
+		api.GET("/vip-group/changes", r.vipGroupHandler.ListChanges)
+	}
+
+	// The sender's WhatsApp Business labels and the chats they are on
+	if r.labelHandler != nil {
+		api.GET("/labels", r.labelHandler.ListLabels)
+		api.POST("/labels", r.labelHandler.CreateLabel)
+		api.POST("/labels/sync", r.labelHandler.SyncLabels)
+		api.POST("/labels/auto", r.labelHandler.AutoLabel)
+		api.PUT("/labels/:id", r.labelHandler.UpdateLabel)
+		api.DELETE("/labels/:id", r.confirmed(domain.ConfirmActionDeleteLabel, "id"), r.labelHandler.DeleteLabel)
+		api.GET("/labels/:id/chats", r.labelHandler.ListLabelChats)
+		api.POST("/labels/:id/chats", r.labelHandler.LabelChat)
+		api.DELETE("/labels/:id/chats", r.labelHandler.UnlabelChat)
+	}
+
+	// Supplies inventory and what each catalog item uses
+	if r.inventoryHandler != nil {
+		api.GET("/supplies", r.inventoryHandler.ListSupplies)
+		api.POST("/supplies", r.inventoryHandler.CreateSupply)
+		api.POST("/supplies/:id/adjustments", r.inventoryHandler.AdjustStock)
+		api.GET("/items/:id/supplies", r.inventoryHandler.GetItemUsage)
+		api.PUT("/items/:id/supplies", r.inventoryHandler.SetItemUsage)
+	}
+
+	// Per-staff activity from the admin chat commands
+	if r.staffHandler != nil {
+		api.GET("/staff/activity", r.staffHandler.GetDailyActivity)
+	}
+
+	// Report workbooks delivered on a schedule
+	if r.reportHandler != nil {
+		api.GET("/report-schedules", r.reportHandler.ListSchedules)
+		api.POST("/report-schedules", r.reportHandler.CreateSchedule)
+		api.PUT("/report-schedules/:id", r.reportHandler.UpdateSchedule)
+		api.DELETE("/report-schedules/:id", r.confirmed(domain.ConfirmActionDeleteReport, "id"), r.reportHandler.DeleteSchedule)
+		api.POST("/report-schedules/:id/send", r.reportHandler.SendSchedule)
+	}
+
+	// Incidents announced on the public status page
+	if r.statusPageHandler != nil {
+		api.GET("/incidents", r.statusPageHandler.ListIncidents)
+		api.POST("/incidents", r.statusPageHandler.CreateIncident)
+		api.PUT("/incidents/:id", r.statusPageHandler.UpdateIncident)
+	}
+
+	// Completion rates of the soft-launched bot flow against the live one
+	if r.softLaunchHandler != nil {
+		api.GET("/soft-launch", r.softLaunchHandler.GetReport)
+	}
+
+	// Name, description and tags of a sender, and taking it out of rotation
+	if r.senderHandler != nil {
+		api.PUT("/senders/:id", r.senderHandler.UpdateSender)
+		api.POST("/senders/:id/pause", r.senderHandler.PauseSender)
+		api.POST("/senders/:id/resume", r.senderHandler.ResumeSender)
+		api.GET("/senders/:id/health", r.senderHandler.GetSenderHealth)
+		api.POST("/senders/:id/reconnect", r.senderHandler.ReconnectSender)
+		api.GET("/senders/:id/quota", r.senderHandler.GetSenderQuota)
+		api.PUT("/senders/:id/quota", r.senderHandler.SetSenderQuota)
+	}
+
+	// Hourly sent and received counts per sender
+	if r.senderActivityHandler != nil {
+		api.GET("/senders/:id/activity", r.senderActivityHandler.GetActivity)
+	}
+
+	// Contacts each sender blocked, kept on the suppression list
+	if r.blocklistHandler != nil {
+		api.GET("/senders/:id/blocklist", r.blocklistHandler.GetBlocklist)
+		api.POST("/senders/:id/blocklist", r.blocklistHandler.BlockContact)
+		api.DELETE("/senders/:id/blocklist/:phone", r.blocklistHandler.UnblockContact)
+	}
+
+	// Database, media and log storage against their limits
+	if r.storageHandler != nil {
+		api.GET("/system/storage", r.storageHandler.GetStorage)
+	}
+
+	if r.systemInfoHandler != nil {
+		api.GET("/system/info", r.systemInfoHandler.GetSystemInfo)
+	}
+
+	if r.versionHandler != nil {
+		api.GET("/version", r.versionHandler.GetVersion)
+	}
+
+	// Per-category sender fallback chains
+	if r.senderChainHandler != nil {
+		api.GET("/sender-chains", r.senderChainHandler.ListChains)
+		api.PUT("/sender-chains/:category", r.senderChainHandler.SetChain)
+	}
+
+	if r.senderPoolHandler != nil {
+		api.GET("/sender-pools", r.senderPoolHandler.ListPools)
+		api.PUT("/sender-pools/:name", r.senderPoolHandler.SetPool)
+	}
+
+	// Which alerts each admin receives, and where
+	if r.notificationPrefHandler != nil {
+		api.GET("/notification-preferences", r.notificationPrefHandler.ListPreferences)
+		api.GET("/notification-preferences/:phone", r.notificationPrefHandler.GetPreferences)
+		api.PUT("/notification-preferences/:phone", r.notificationPrefHandler.SetPreferences)
+		api.DELETE("/notification-preferences/:phone", r.notificationPrefHandler.DeletePreferences)
+	}
+}
+
+// servePostmanCollection handles GET /postman-collection.json
+func servePostmanCollection(c *gin.Context) {
+	collection, err := selftest.PostmanCollection()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="whatspoints.postman_collection.json"`)
+	c.Data(http.StatusOK, "application/json", collection)
+}
+
 // findWebDirectory finds the web directory path, checking multiple possible locations
 func (r *Router) findWebDirectory() string {
 	// Get current working directory
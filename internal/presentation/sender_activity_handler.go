@@ -0,0 +1,42 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type SenderActivityHandler struct {
+	activityService domain.SenderActivityService
+}
+
+// NewSenderActivityHandler creates a new sender activity handler
+func NewSenderActivityHandler(activityService domain.SenderActivityService) *SenderActivityHandler {
+	return &SenderActivityHandler{activityService: activityService}
+}
+
+// GetActivity handles GET /api/senders/:id/activity?days=7
+func (h *SenderActivityHandler) GetActivity(c *gin.Context) {
+	var query domain.SenderActivityQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid query: " + err.Error(),
+		})
+		return
+	}
+
+	report, err := h.activityService.GetActivity(c.Request.Context(), c.Param("id"), &query)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrInvalidActivityQuery {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
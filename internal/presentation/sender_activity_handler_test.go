@@ -0,0 +1,64 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSenderActivityHandler_GetActivity(t *testing.T) {
+	// Arrange
+	mockActivityService := &mocks.MockSenderActivityService{}
+	handler := NewSenderActivityHandler(mockActivityService)
+
+	router := setupTestRouter()
+	router.GET("/senders/:id/activity", handler.GetActivity)
+
+	report := &domain.SenderActivityReport{
+		SenderID:  "6281234567890",
+		From:      "2026-10-10",
+		To:        "2026-10-16",
+		TotalSent: 12,
+		Heatmap:   []domain.SenderActivityCell{{Weekday: 5, Hour: 9, Sent: 12}},
+	}
+	mockActivityService.On("GetActivity", mock.Anything, "6281234567890", &domain.SenderActivityQuery{Days: 7}).Return(report, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/senders/6281234567890/activity?days=7", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response domain.SenderActivityReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, report, &response)
+	mockActivityService.AssertExpectations(t)
+}
+
+func TestSenderActivityHandler_GetActivity_InvalidDays(t *testing.T) {
+	// Arrange
+	mockActivityService := &mocks.MockSenderActivityService{}
+	handler := NewSenderActivityHandler(mockActivityService)
+
+	router := setupTestRouter()
+	router.GET("/senders/:id/activity", handler.GetActivity)
+
+	mockActivityService.On("GetActivity", mock.Anything, "6281234567890", &domain.SenderActivityQuery{Days: 365}).
+		Return(nil, domain.ErrInvalidActivityQuery)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/senders/6281234567890/activity?days=365", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockActivityService.AssertExpectations(t)
+}
@@ -0,0 +1,61 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type SenderChainHandler struct {
+	senderChainService domain.SenderChainService
+}
+
+// NewSenderChainHandler creates a new sender fallback chain handler
+func NewSenderChainHandler(senderChainService domain.SenderChainService) *SenderChainHandler {
+	return &SenderChainHandler{senderChainService: senderChainService}
+}
+
+// ListChains handles GET /api/sender-chains
+func (h *SenderChainHandler) ListChains(c *gin.Context) {
+	chains, err := h.senderChainService.ListChains(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"chains": chains,
+		"count":  len(chains),
+	})
+}
+
+// SetChain handles PUT /api/sender-chains/:category
+func (h *SenderChainHandler) SetChain(c *gin.Context) {
+	var req domain.SetSenderFallbackChainRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	chain, err := h.senderChainService.SetChain(c.Request.Context(), c.Param("category"), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidFallbackChain:
+			statusCode = http.StatusBadRequest
+		case domain.ErrSenderNotFound:
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, chain)
+}
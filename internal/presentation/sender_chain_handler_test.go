@@ -0,0 +1,98 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSenderChainHandler_SetChain_Success(t *testing.T) {
+	// Arrange
+	mockService := &mocks.MockSenderChainService{}
+	handler := NewSenderChainHandler(mockService)
+
+	router := setupTestRouter()
+	router.PUT("/sender-chains/:category", handler.SetChain)
+
+	reqBody := domain.SetSenderFallbackChainRequest{SenderIDs: []string{"6281111", "6282222"}}
+	expected := &domain.SenderFallbackChain{Category: "transactional", SenderIDs: reqBody.SenderIDs}
+	mockService.On("SetChain", mock.Anything, "transactional", &reqBody).Return(expected, nil)
+
+	// Act
+	body, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("PUT", "/sender-chains/transactional", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.SenderFallbackChain
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, expected.SenderIDs, response.SenderIDs)
+	mockService.AssertExpectations(t)
+}
+
+func TestSenderChainHandler_SetChain_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid chain", domain.ErrInvalidFallbackChain, http.StatusBadRequest},
+		{"unknown sender", domain.ErrSenderNotFound, http.StatusNotFound},
+		{"storage failure", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &mocks.MockSenderChainService{}
+			handler := NewSenderChainHandler(mockService)
+
+			router := setupTestRouter()
+			router.PUT("/sender-chains/:category", handler.SetChain)
+
+			mockService.On("SetChain", mock.Anything, "promo", mock.Anything).Return(nil, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("PUT", "/sender-chains/promo", bytes.NewBufferString(`{"sender_ids":["6281111"]}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestSenderChainHandler_ListChains(t *testing.T) {
+	// Arrange
+	mockService := &mocks.MockSenderChainService{}
+	handler := NewSenderChainHandler(mockService)
+
+	router := setupTestRouter()
+	router.GET("/sender-chains", handler.ListChains)
+
+	mockService.On("ListChains", mock.Anything).Return([]*domain.SenderFallbackChain{
+		{Category: "default", SenderIDs: []string{"6281111"}},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/sender-chains", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"count":1`)
+}
@@ -0,0 +1,145 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type SenderHandler struct {
+	senderService domain.SenderService
+}
+
+// NewSenderHandler creates a new sender handler
+func NewSenderHandler(senderService domain.SenderService) *SenderHandler {
+	return &SenderHandler{senderService: senderService}
+}
+
+// UpdateSender handles PUT /api/senders/:id
+func (h *SenderHandler) UpdateSender(c *gin.Context) {
+	var req domain.UpdateSenderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	sender, err := h.senderService.UpdateSender(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		c.JSON(senderStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, sender)
+}
+
+// PauseSender handles POST /api/senders/:id/pause
+func (h *SenderHandler) PauseSender(c *gin.Context) {
+	sender, err := h.senderService.PauseSender(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(senderStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, sender)
+}
+
+// ResumeSender handles POST /api/senders/:id/resume
+func (h *SenderHandler) ResumeSender(c *gin.Context) {
+	sender, err := h.senderService.ResumeSender(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(senderStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, sender)
+}
+
+// GetSenderHealth handles GET /api/senders/:id/health. An unhealthy sender
+// answers 503 with the same body, so an uptime check can alert on the status
+// code alone.
+func (h *SenderHandler) GetSenderHealth(c *gin.Context) {
+	health, err := h.senderService.GetSenderHealth(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(senderStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	status := http.StatusOK
+	if !health.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, health)
+}
+
+// ReconnectSender handles POST /api/senders/:id/reconnect
+func (h *SenderHandler) ReconnectSender(c *gin.Context) {
+	health, err := h.senderService.ReconnectSender(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(senderStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, health)
+}
+
+// GetSenderQuota handles GET /api/senders/:id/quota
+func (h *SenderHandler) GetSenderQuota(c *gin.Context) {
+	quota, err := h.senderService.GetSenderQuota(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(senderStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
+
+// SetSenderQuota handles PUT /api/senders/:id/quota
+func (h *SenderHandler) SetSenderQuota(c *gin.Context) {
+	var req domain.SetSenderQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	quota, err := h.senderService.SetSenderQuota(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		c.JSON(senderStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, quota)
+}
+
+func senderStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSender), errors.Is(err, domain.ErrInvalidSenderQuota):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrSenderNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrSenderNoSession):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrReconnectTooSoon):
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
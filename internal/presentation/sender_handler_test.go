@@ -0,0 +1,246 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSenderHandler_UpdateSender(t *testing.T) {
+	// Arrange
+	mockSenderService := &mocks.MockSenderService{}
+	handler := NewSenderHandler(mockSenderService)
+
+	router := setupTestRouter()
+	router.PUT("/senders/:id", handler.UpdateSender)
+
+	req := &domain.UpdateSenderRequest{Name: "Cabang Bandung", Description: "Cabang Dago", Tags: []string{"bandung"}}
+	sender := &domain.Sender{ID: "628123456789", Name: "Cabang Bandung", Description: "Cabang Dago", Tags: []string{"bandung"}, IsActive: true}
+	mockSenderService.On("UpdateSender", mock.Anything, "628123456789", req).Return(sender, nil)
+
+	// Act
+	body, _ := json.Marshal(req)
+	httpReq, _ := http.NewRequest("PUT", "/senders/628123456789", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response domain.Sender
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Cabang Bandung", response.Name)
+	assert.Equal(t, []string{"bandung"}, response.Tags)
+	mockSenderService.AssertExpectations(t)
+}
+
+func TestSenderHandler_UpdateSender_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid", `{"name":""}`, fmt.Errorf("%w: name is required", domain.ErrInvalidSender), http.StatusBadRequest},
+		{"not found", `{"name":"Cabang Bandung"}`, domain.ErrSenderNotFound, http.StatusNotFound},
+		{"bad body", `{"name":`, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSenderService := &mocks.MockSenderService{}
+			handler := NewSenderHandler(mockSenderService)
+
+			router := setupTestRouter()
+			router.PUT("/senders/:id", handler.UpdateSender)
+
+			if tt.err != nil {
+				mockSenderService.On("UpdateSender", mock.Anything, "628123456789", mock.Anything).Return(nil, tt.err)
+			}
+
+			// Act
+			httpReq, _ := http.NewRequest("PUT", "/senders/628123456789", bytes.NewBufferString(tt.body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httpReq)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockSenderService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSenderHandler_PauseSender(t *testing.T) {
+	// Arrange
+	mockSenderService := &mocks.MockSenderService{}
+	handler := NewSenderHandler(mockSenderService)
+
+	router := setupTestRouter()
+	router.POST("/senders/:id/pause", handler.PauseSender)
+
+	mockSenderService.On("PauseSender", mock.Anything, "628123456789").
+		Return(&domain.Sender{ID: "628123456789", IsActive: true, IsPaused: true}, nil)
+
+	// Act
+	httpReq, _ := http.NewRequest("POST", "/senders/628123456789/pause", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response domain.Sender
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.IsPaused)
+	mockSenderService.AssertExpectations(t)
+}
+
+func TestSenderHandler_ResumeSender_NotFound(t *testing.T) {
+	// Arrange
+	mockSenderService := &mocks.MockSenderService{}
+	handler := NewSenderHandler(mockSenderService)
+
+	router := setupTestRouter()
+	router.POST("/senders/:id/resume", handler.ResumeSender)
+
+	mockSenderService.On("ResumeSender", mock.Anything, "628000").Return(nil, domain.ErrSenderNotFound)
+
+	// Act
+	httpReq, _ := http.NewRequest("POST", "/senders/628000/resume", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockSenderService.AssertExpectations(t)
+}
+
+func TestSenderHandler_GetSenderHealth(t *testing.T) {
+	tests := []struct {
+		name       string
+		health     *domain.SenderHealth
+		err        error
+		wantStatus int
+	}{
+		{"healthy", &domain.SenderHealth{SenderID: "628123456789", Healthy: true, Connected: true, LoggedIn: true}, nil, http.StatusOK},
+		{"unhealthy", &domain.SenderHealth{SenderID: "628123456789", LoggedIn: true, LastDisconnectReason: "disconnected"}, nil, http.StatusServiceUnavailable},
+		{"unknown sender", nil, domain.ErrSenderNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSenderService := &mocks.MockSenderService{}
+			handler := NewSenderHandler(mockSenderService)
+
+			router := setupTestRouter()
+			router.GET("/senders/:id/health", handler.GetSenderHealth)
+
+			mockSenderService.On("GetSenderHealth", mock.Anything, "628123456789").Return(tt.health, tt.err)
+
+			// Act
+			httpReq, _ := http.NewRequest("GET", "/senders/628123456789/health", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httpReq)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.health != nil {
+				var response domain.SenderHealth
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.health.LastDisconnectReason, response.LastDisconnectReason)
+			}
+		})
+	}
+}
+
+func TestSenderHandler_SetSenderQuota(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"sets quota", `{"daily_quota":500}`, nil, http.StatusOK},
+		{"out of range", `{"daily_quota":-1}`, fmt.Errorf("%w: daily_quota must be 0-100000", domain.ErrInvalidSenderQuota), http.StatusBadRequest},
+		{"unknown sender", `{"daily_quota":500}`, domain.ErrSenderNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSenderService := &mocks.MockSenderService{}
+			handler := NewSenderHandler(mockSenderService)
+
+			router := setupTestRouter()
+			router.PUT("/senders/:id/quota", handler.SetSenderQuota)
+
+			var quota *domain.SenderQuota
+			if tt.err == nil {
+				remaining := 500
+				quota = &domain.SenderQuota{SenderID: "628123456789", DailyQuota: 500, Remaining: &remaining}
+			}
+			mockSenderService.On("SetSenderQuota", mock.Anything, "628123456789", mock.AnythingOfType("*domain.SetSenderQuotaRequest")).Return(quota, tt.err)
+
+			// Act
+			httpReq, _ := http.NewRequest("PUT", "/senders/628123456789/quota", bytes.NewBufferString(tt.body))
+			httpReq.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httpReq)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if quota != nil {
+				var response domain.SenderQuota
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, 500, *response.Remaining)
+			}
+			mockSenderService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSenderHandler_ReconnectSender(t *testing.T) {
+	tests := []struct {
+		name       string
+		health     *domain.SenderHealth
+		err        error
+		wantStatus int
+	}{
+		{"reconnected", &domain.SenderHealth{SenderID: "628123456789", Connected: true}, nil, http.StatusOK},
+		{"unknown sender", nil, domain.ErrSenderNotFound, http.StatusNotFound},
+		{"no session", nil, domain.ErrSenderNoSession, http.StatusConflict},
+		{"too soon", nil, domain.ErrReconnectTooSoon, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSenderService := &mocks.MockSenderService{}
+			handler := NewSenderHandler(mockSenderService)
+
+			router := setupTestRouter()
+			router.POST("/senders/:id/reconnect", handler.ReconnectSender)
+
+			mockSenderService.On("ReconnectSender", mock.Anything, "628123456789").Return(tt.health, tt.err)
+
+			// Act
+			httpReq, _ := http.NewRequest("POST", "/senders/628123456789/reconnect", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httpReq)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockSenderService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type SenderPoolHandler struct {
+	senderPoolService domain.SenderPoolService
+}
+
+// NewSenderPoolHandler creates a new named sender pool handler
+func NewSenderPoolHandler(senderPoolService domain.SenderPoolService) *SenderPoolHandler {
+	return &SenderPoolHandler{senderPoolService: senderPoolService}
+}
+
+// ListPools handles GET /api/sender-pools
+func (h *SenderPoolHandler) ListPools(c *gin.Context) {
+	pools, err := h.senderPoolService.ListPools(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"pools": pools,
+		"count": len(pools),
+	})
+}
+
+// SetPool handles PUT /api/sender-pools/:name
+func (h *SenderPoolHandler) SetPool(c *gin.Context) {
+	var req domain.SetSenderPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	pool, err := h.senderPoolService.SetPool(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidSenderPool:
+			statusCode = http.StatusBadRequest
+		case domain.ErrSenderNotFound:
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, pool)
+}
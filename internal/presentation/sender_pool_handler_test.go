@@ -0,0 +1,98 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSenderPoolHandler_SetPool_Success(t *testing.T) {
+	// Arrange
+	mockService := &mocks.MockSenderPoolService{}
+	handler := NewSenderPoolHandler(mockService)
+
+	router := setupTestRouter()
+	router.PUT("/sender-pools/:name", handler.SetPool)
+
+	reqBody := domain.SetSenderPoolRequest{SenderIDs: []string{"6281111", "6282222"}}
+	expected := &domain.SenderPool{Name: "sales", SenderIDs: reqBody.SenderIDs}
+	mockService.On("SetPool", mock.Anything, "sales", &reqBody).Return(expected, nil)
+
+	// Act
+	body, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("PUT", "/sender-pools/sales", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.SenderPool
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, expected.SenderIDs, response.SenderIDs)
+	mockService.AssertExpectations(t)
+}
+
+func TestSenderPoolHandler_SetPool_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid pool", domain.ErrInvalidSenderPool, http.StatusBadRequest},
+		{"unknown sender", domain.ErrSenderNotFound, http.StatusNotFound},
+		{"storage failure", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &mocks.MockSenderPoolService{}
+			handler := NewSenderPoolHandler(mockService)
+
+			router := setupTestRouter()
+			router.PUT("/sender-pools/:name", handler.SetPool)
+
+			mockService.On("SetPool", mock.Anything, "support", mock.Anything).Return(nil, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("PUT", "/sender-pools/support", bytes.NewBufferString(`{"sender_ids":["6281111"]}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestSenderPoolHandler_ListPools(t *testing.T) {
+	// Arrange
+	mockService := &mocks.MockSenderPoolService{}
+	handler := NewSenderPoolHandler(mockService)
+
+	router := setupTestRouter()
+	router.GET("/sender-pools", handler.ListPools)
+
+	mockService.On("ListPools", mock.Anything).Return([]*domain.SenderPool{
+		{Name: "sales", SenderIDs: []string{"6281111"}},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/sender-pools", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"count":1`)
+}
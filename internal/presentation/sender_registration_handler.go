@@ -10,6 +10,7 @@ import (
 type SenderRegistrationHandler struct {
 	registrationService domain.SenderRegistrationService
 	authService         domain.AuthService
+	batches             domain.RegistrationBatchService // optional; enables bulk registration
 }
 
 // NewSenderRegistrationHandler creates a new sender registration handler
@@ -20,6 +21,12 @@ func NewSenderRegistrationHandler(registrationService domain.SenderRegistrationS
 	}
 }
 
+// WithBatches enables registering many senders with pairing codes in one call
+func (h *SenderRegistrationHandler) WithBatches(batches domain.RegistrationBatchService) *SenderRegistrationHandler {
+	h.batches = batches
+	return h
+}
+
 // StartQRRegistration handles POST /api/register-sender-qr
 func (h *SenderRegistrationHandler) StartQRRegistration(c *gin.Context) {
 	response, err := h.registrationService.StartQRRegistration(c.Request.Context())
@@ -59,7 +66,7 @@ func (h *SenderRegistrationHandler) StartCodeRegistration(c *gin.Context) {
 // GetRegistrationStatus handles GET /api/register-sender-status/:sessionId
 func (h *SenderRegistrationHandler) GetRegistrationStatus(c *gin.Context) {
 	sessionID := c.Param("sessionId")
-	
+
 	if sessionID == "" {
 		c.JSON(http.StatusBadRequest, domain.RegistrationStatusResponse{
 			Success: false,
@@ -81,3 +88,84 @@ func (h *SenderRegistrationHandler) GetRegistrationStatus(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// DeleteSender handles DELETE /api/senders/:id
+func (h *SenderRegistrationHandler) DeleteSender(c *gin.Context) {
+	if err := h.registrationService.DeleteSender(c.Request.Context(), c.Param("id")); err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrSenderNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Sender deleted",
+	})
+}
+
+// ClearSessions handles DELETE /api/sessions
+func (h *SenderRegistrationHandler) ClearSessions(c *gin.Context) {
+	removed, err := h.registrationService.ClearSessions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   err.Error(),
+			"removed": removed,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"removed": removed,
+	})
+}
+
+// StartRegistrationBatch handles POST /api/register-senders
+func (h *SenderRegistrationHandler) StartRegistrationBatch(c *gin.Context) {
+	var req domain.RegisterSendersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	batch, err := h.batches.StartRegistrationBatch(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(registrationBatchStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, batch)
+}
+
+// GetRegistrationBatch handles GET /api/register-senders/:id
+func (h *SenderRegistrationHandler) GetRegistrationBatch(c *gin.Context) {
+	batch, err := h.batches.GetRegistrationBatch(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(registrationBatchStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
+
+// registrationBatchStatusCode maps bulk registration errors to HTTP status codes
+func registrationBatchStatusCode(err error) int {
+	switch err {
+	case domain.ErrInvalidSenderBatch, domain.ErrSenderBatchTooLarge:
+		return http.StatusBadRequest
+	case domain.ErrSenderBatchNotFound:
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,83 @@
+package presentation
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSenderRegistrationHandler_StartRegistrationBatch(t *testing.T) {
+	// Arrange
+	mockBatches := &mocks.MockRegistrationBatchService{}
+	handler := NewSenderRegistrationHandler(&mocks.MockSenderRegistrationService{}, &mocks.MockAuthService{}).WithBatches(mockBatches)
+
+	router := setupTestRouter()
+	router.POST("/register-senders", handler.StartRegistrationBatch)
+
+	mockBatches.On("StartRegistrationBatch", mock.Anything, &domain.RegisterSendersRequest{PhoneNumbers: []string{"6281111111111"}}).
+		Return(&domain.RegistrationBatch{ID: "batch-1", Status: domain.RegistrationBatchRunning, Total: 1}, nil)
+	mockBatches.On("StartRegistrationBatch", mock.Anything, &domain.RegisterSendersRequest{}).
+		Return(nil, domain.ErrInvalidSenderBatch)
+
+	for body, wantStatus := range map[string]int{
+		`{"phone_numbers": ["6281111111111"]}`: http.StatusAccepted,
+		`{}`:                                   http.StatusBadRequest,
+	} {
+		// Act
+		req, _ := http.NewRequest("POST", "/register-senders", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, wantStatus, w.Code, body)
+	}
+	mockBatches.AssertExpectations(t)
+}
+
+func TestSenderRegistrationHandler_GetRegistrationBatch_NotFound(t *testing.T) {
+	// Arrange
+	mockBatches := &mocks.MockRegistrationBatchService{}
+	handler := NewSenderRegistrationHandler(&mocks.MockSenderRegistrationService{}, &mocks.MockAuthService{}).WithBatches(mockBatches)
+
+	router := setupTestRouter()
+	router.GET("/register-senders/:id", handler.GetRegistrationBatch)
+
+	mockBatches.On("GetRegistrationBatch", mock.Anything, "missing").Return(nil, domain.ErrSenderBatchNotFound)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/register-senders/missing", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockBatches.AssertExpectations(t)
+}
+
+func TestSenderRegistrationHandler_ClearSessions(t *testing.T) {
+	// Arrange
+	mockRegistrations := &mocks.MockSenderRegistrationService{}
+	handler := NewSenderRegistrationHandler(mockRegistrations, &mocks.MockAuthService{})
+
+	router := setupTestRouter()
+	router.DELETE("/sessions", handler.ClearSessions)
+
+	mockRegistrations.On("ClearSessions", mock.Anything).Return([]string{"6281234567890"}, nil)
+
+	// Act
+	req, _ := http.NewRequest("DELETE", "/sessions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"success":true,"removed":["6281234567890"]}`, w.Body.String())
+	mockRegistrations.AssertExpectations(t)
+}
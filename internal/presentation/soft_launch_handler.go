@@ -0,0 +1,42 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type SoftLaunchHandler struct {
+	softLaunchService domain.SoftLaunchService
+}
+
+// NewSoftLaunchHandler creates a new soft launch report handler
+func NewSoftLaunchHandler(softLaunchService domain.SoftLaunchService) *SoftLaunchHandler {
+	return &SoftLaunchHandler{softLaunchService: softLaunchService}
+}
+
+// GetReport handles GET /api/soft-launch?days=7
+func (h *SoftLaunchHandler) GetReport(c *gin.Context) {
+	var query domain.SoftLaunchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid query: " + err.Error(),
+		})
+		return
+	}
+
+	report, err := h.softLaunchService.GetReport(c.Request.Context(), &query)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrInvalidActivityQuery {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
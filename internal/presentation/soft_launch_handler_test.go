@@ -0,0 +1,62 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSoftLaunchHandler_GetReport(t *testing.T) {
+	// Arrange
+	mockSoftLaunchService := &mocks.MockSoftLaunchService{}
+	handler := NewSoftLaunchHandler(mockSoftLaunchService)
+
+	router := setupTestRouter()
+	router.GET("/soft-launch", handler.GetReport)
+
+	report := &domain.SoftLaunchReport{
+		Percent: 20,
+		From:    "2026-10-10",
+		Variants: []domain.SoftLaunchVariant{
+			{Variant: "button_menu", Conversations: 40, Completions: 30, CompletionRate: 0.75},
+		},
+	}
+	mockSoftLaunchService.On("GetReport", mock.Anything, &domain.SoftLaunchQuery{Days: 7}).Return(report, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/soft-launch?days=7", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response domain.SoftLaunchReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, report, &response)
+	mockSoftLaunchService.AssertExpectations(t)
+}
+
+func TestSoftLaunchHandler_GetReport_InvalidDays(t *testing.T) {
+	// Arrange
+	mockSoftLaunchService := &mocks.MockSoftLaunchService{}
+	handler := NewSoftLaunchHandler(mockSoftLaunchService)
+
+	router := setupTestRouter()
+	router.GET("/soft-launch", handler.GetReport)
+
+	mockSoftLaunchService.On("GetReport", mock.Anything, &domain.SoftLaunchQuery{Days: 365}).Return(nil, domain.ErrInvalidActivityQuery)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/soft-launch?days=365", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,37 @@
+package presentation
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type StaffHandler struct {
+	staffService domain.StaffService
+}
+
+// NewStaffHandler creates a new staff activity handler
+func NewStaffHandler(staffService domain.StaffService) *StaffHandler {
+	return &StaffHandler{staffService: staffService}
+}
+
+// GetDailyActivity handles GET /api/staff/activity?date=YYYY-MM-DD (defaults to today)
+func (h *StaffHandler) GetDailyActivity(c *gin.Context) {
+	date := c.DefaultQuery("date", time.Now().Format("2006-01-02"))
+
+	report, err := h.staffService.GetDailyActivity(c.Request.Context(), date)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrInvalidDate {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,104 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestStaffHandler_GetDailyActivity(t *testing.T) {
+	// Arrange
+	mockStaffService := &mocks.MockStaffService{}
+	handler := NewStaffHandler(mockStaffService)
+
+	router := setupTestRouter()
+	router.GET("/staff/activity", handler.GetDailyActivity)
+
+	report := &domain.StaffActivityReport{
+		Date: "2026-10-16",
+		Staff: []domain.StaffActivity{{
+			PhoneNumber:    "6281111111111",
+			PointsCredits:  3,
+			PointsCredited: 45,
+			MembersServed:  2,
+			SelfCredits:    1,
+			Anomalies:      []string{domain.AnomalySelfCredit},
+		}},
+	}
+	mockStaffService.On("GetDailyActivity", mock.Anything, "2026-10-16").Return(report, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/staff/activity?date=2026-10-16", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response domain.StaffActivityReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, report, &response)
+	mockStaffService.AssertExpectations(t)
+}
+
+func TestStaffHandler_GetDailyActivity_DefaultsToToday(t *testing.T) {
+	// Arrange
+	mockStaffService := &mocks.MockStaffService{}
+	handler := NewStaffHandler(mockStaffService)
+
+	router := setupTestRouter()
+	router.GET("/staff/activity", handler.GetDailyActivity)
+
+	today := time.Now().Format("2006-01-02")
+	mockStaffService.On("GetDailyActivity", mock.Anything, today).
+		Return(&domain.StaffActivityReport{Date: today, Staff: []domain.StaffActivity{}}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/staff/activity", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockStaffService.AssertExpectations(t)
+}
+
+func TestStaffHandler_GetDailyActivity_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid date", domain.ErrInvalidDate, http.StatusBadRequest},
+		{"database error", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStaffService := &mocks.MockStaffService{}
+			handler := NewStaffHandler(mockStaffService)
+
+			router := setupTestRouter()
+			router.GET("/staff/activity", handler.GetDailyActivity)
+
+			mockStaffService.On("GetDailyActivity", mock.Anything, "yesterday").Return(nil, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("GET", "/staff/activity?date=yesterday", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockStaffService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type StatusPageHandler struct {
+	statusPageService domain.StatusPageService
+	perMinute         int // requests each client IP may make to the status page
+}
+
+// NewStatusPageHandler creates a new handler for the public status page and its
+// incidents, allowing each client IP perMinute status page requests a minute
+func NewStatusPageHandler(statusPageService domain.StatusPageService, perMinute int) *StatusPageHandler {
+	return &StatusPageHandler{statusPageService: statusPageService, perMinute: perMinute}
+}
+
+// GetStatusPage handles GET /status-page. It needs no credentials and may be
+// fetched from any origin, for embedding in a customer-facing page.
+func (h *StatusPageHandler) GetStatusPage(c *gin.Context) {
+	c.Header("Access-Control-Allow-Origin", "*")
+	c.Header("Cache-Control", "public, max-age=30")
+
+	page, err := h.statusPageService.GetStatusPage(c.Request.Context())
+	if err != nil {
+		// The details stay in the log; customers only learn that it failed
+		c.Error(err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Status is unavailable",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// CreateIncident handles POST /api/incidents
+func (h *StatusPageHandler) CreateIncident(c *gin.Context) {
+	var req domain.IncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	incident, err := h.statusPageService.CreateIncident(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(incidentStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, incident)
+}
+
+// ListIncidents handles GET /api/incidents
+func (h *StatusPageHandler) ListIncidents(c *gin.Context) {
+	incidents, err := h.statusPageService.ListIncidents(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"incidents": incidents,
+		"count":     len(incidents),
+	})
+}
+
+// UpdateIncident handles PUT /api/incidents/:id
+func (h *StatusPageHandler) UpdateIncident(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid incident ID",
+		})
+		return
+	}
+	var req domain.IncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	incident, err := h.statusPageService.UpdateIncident(c.Request.Context(), id, &req)
+	if err != nil {
+		c.JSON(incidentStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, incident)
+}
+
+func incidentStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidIncident):
+		return http.StatusBadRequest
+	case err == domain.ErrIncidentNotFound:
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,129 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestStatusPageHandler_GetStatusPage(t *testing.T) {
+	// Arrange
+	mockStatusPageService := &mocks.MockStatusPageService{}
+	handler := NewStatusPageHandler(mockStatusPageService, 30)
+
+	router := setupTestRouter()
+	router.GET("/status-page", handler.GetStatusPage)
+
+	page := &domain.StatusPage{
+		Status:       domain.ServiceDegraded,
+		API:          domain.ServiceOperational,
+		Messaging:    domain.ServiceDegraded,
+		LastIncident: &domain.Incident{ID: 3, Title: "Pesan terlambat", Status: domain.IncidentMonitoring},
+		UpdatedAt:    "2026-10-16T09:00:00+07:00",
+	}
+	mockStatusPageService.On("GetStatusPage", mock.Anything).Return(page, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/status-page", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	var response domain.StatusPage
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, page, &response)
+}
+
+func TestStatusPageHandler_GetStatusPage_HidesErrors(t *testing.T) {
+	// Arrange
+	mockStatusPageService := &mocks.MockStatusPageService{}
+	handler := NewStatusPageHandler(mockStatusPageService, 30)
+
+	router := setupTestRouter()
+	router.GET("/status-page", handler.GetStatusPage)
+
+	mockStatusPageService.On("GetStatusPage", mock.Anything).
+		Return(nil, errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+
+	// Act
+	req, _ := http.NewRequest("GET", "/status-page", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.NotContains(t, w.Body.String(), "10.0.0.5")
+}
+
+func TestStatusPageHandler_CreateIncident(t *testing.T) {
+	// Arrange
+	mockStatusPageService := &mocks.MockStatusPageService{}
+	handler := NewStatusPageHandler(mockStatusPageService, 30)
+
+	router := setupTestRouter()
+	router.POST("/incidents", handler.CreateIncident)
+
+	req := &domain.IncidentRequest{Title: "Pesan terlambat", Message: "Kami sedang menyelidiki."}
+	mockStatusPageService.On("CreateIncident", mock.Anything, req).
+		Return(&domain.Incident{ID: 1, Title: req.Title, Message: req.Message, Status: domain.IncidentInvestigating}, nil)
+
+	// Act
+	body, _ := json.Marshal(req)
+	httpReq, _ := http.NewRequest("POST", "/incidents", bytes.NewBuffer(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockStatusPageService.AssertExpectations(t)
+}
+
+func TestStatusPageHandler_UpdateIncident_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid status", "/incidents/1", fmt.Errorf("%w: status must be investigating, identified, monitoring or resolved", domain.ErrInvalidIncident), http.StatusBadRequest},
+		{"not found", "/incidents/1", domain.ErrIncidentNotFound, http.StatusNotFound},
+		{"bad id", "/incidents/abc", nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStatusPageService := &mocks.MockStatusPageService{}
+			handler := NewStatusPageHandler(mockStatusPageService, 30)
+
+			router := setupTestRouter()
+			router.PUT("/incidents/:id", handler.UpdateIncident)
+
+			if tt.err != nil {
+				mockStatusPageService.On("UpdateIncident", mock.Anything, 1, mock.Anything).Return(nil, tt.err)
+			}
+
+			// Act
+			httpReq, _ := http.NewRequest("PUT", tt.path, bytes.NewBufferString(`{"status":"resolved"}`))
+			httpReq.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httpReq)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockStatusPageService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type StorageHandler struct {
+	storageService domain.StorageService
+}
+
+// NewStorageHandler creates a new storage usage handler
+func NewStorageHandler(storageService domain.StorageService) *StorageHandler {
+	return &StorageHandler{storageService: storageService}
+}
+
+// GetStorage handles GET /api/system/storage
+func (h *StorageHandler) GetStorage(c *gin.Context) {
+	report, err := h.storageService.GetStorage(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,62 @@
+package presentation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestStorageHandler_GetStorage(t *testing.T) {
+	// Arrange
+	mockStorageService := &mocks.MockStorageService{}
+	handler := NewStorageHandler(mockStorageService)
+
+	router := setupTestRouter()
+	router.GET("/system/storage", handler.GetStorage)
+
+	report := &domain.StorageReport{
+		Database:  domain.StorageUsage{UsedBytes: 450 << 20, LimitBytes: 500 << 20, UsedPercent: 90},
+		Tables:    []domain.TableStorage{{Name: "message_history", Bytes: 300 << 20}},
+		Warnings:  []string{"database is at 90.0% of its 500 MB limit"},
+		CheckedAt: "2026-10-16T09:00:00Z",
+	}
+	mockStorageService.On("GetStorage", mock.Anything).Return(report, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/system/storage", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response domain.StorageReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, report, &response)
+	mockStorageService.AssertExpectations(t)
+}
+
+func TestStorageHandler_GetStorage_Error(t *testing.T) {
+	// Arrange
+	mockStorageService := &mocks.MockStorageService{}
+	handler := NewStorageHandler(mockStorageService)
+
+	router := setupTestRouter()
+	router.GET("/system/storage", handler.GetStorage)
+
+	mockStorageService.On("GetStorage", mock.Anything).Return(nil, errors.New("connection refused"))
+
+	// Act
+	req, _ := http.NewRequest("GET", "/system/storage", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
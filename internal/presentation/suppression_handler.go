@@ -0,0 +1,95 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type SuppressionHandler struct {
+	suppressionService domain.SuppressionService
+}
+
+// NewSuppressionHandler creates a new suppression list handler
+func NewSuppressionHandler(suppressionService domain.SuppressionService) *SuppressionHandler {
+	return &SuppressionHandler{suppressionService: suppressionService}
+}
+
+// ListSuppressions handles GET /api/suppressions
+func (h *SuppressionHandler) ListSuppressions(c *gin.Context) {
+	suppressions, err := h.suppressionService.ListSuppressions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"suppressions": suppressions,
+		"count":        len(suppressions),
+	})
+}
+
+// GetSuppression handles GET /api/suppressions/:recipient
+func (h *SuppressionHandler) GetSuppression(c *gin.Context) {
+	suppression, err := h.suppressionService.GetSuppression(c.Request.Context(), c.Param("recipient"))
+	if err != nil {
+		c.JSON(suppressionStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, suppression)
+}
+
+// CreateSuppression handles POST /api/suppressions
+func (h *SuppressionHandler) CreateSuppression(c *gin.Context) {
+	var req domain.CreateSuppressionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	suppression, err := h.suppressionService.CreateSuppression(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(suppressionStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, suppression)
+}
+
+// DeleteSuppression handles DELETE /api/suppressions/:recipient
+func (h *SuppressionHandler) DeleteSuppression(c *gin.Context) {
+	if err := h.suppressionService.DeleteSuppression(c.Request.Context(), c.Param("recipient")); err != nil {
+		c.JSON(suppressionStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Recipient removed from the suppression list",
+	})
+}
+
+// suppressionStatusCode maps suppression list errors to HTTP status codes
+func suppressionStatusCode(err error) int {
+	switch err {
+	case domain.ErrInvalidSuppression:
+		return http.StatusBadRequest
+	case domain.ErrSuppressionNotFound:
+		return http.StatusNotFound
+	case domain.ErrSuppressionExists:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,110 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestSuppressionHandler_ListSuppressions(t *testing.T) {
+	// Arrange
+	mockSuppressionService := &mocks.MockSuppressionService{}
+	handler := NewSuppressionHandler(mockSuppressionService)
+
+	router := setupTestRouter()
+	router.GET("/suppressions", handler.ListSuppressions)
+
+	suppressions := []*domain.Suppression{{Recipient: "6281234567890", Reason: domain.SuppressionOptedOut, CreatedAt: "2026-10-01T08:00:00Z"}}
+	mockSuppressionService.On("ListSuppressions", mock.Anything).Return(suppressions, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/suppressions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Count        int                   `json:"count"`
+		Suppressions []*domain.Suppression `json:"suppressions"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, suppressions, response.Suppressions)
+	mockSuppressionService.AssertExpectations(t)
+}
+
+func TestSuppressionHandler_CreateSuppression(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"created", nil, http.StatusCreated},
+		{"invalid", domain.ErrInvalidSuppression, http.StatusBadRequest},
+		{"already suppressed", domain.ErrSuppressionExists, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockSuppressionService := &mocks.MockSuppressionService{}
+			handler := NewSuppressionHandler(mockSuppressionService)
+
+			router := setupTestRouter()
+			router.POST("/suppressions", handler.CreateSuppression)
+
+			body := &domain.CreateSuppressionRequest{Recipient: "+6281234567890", Reason: domain.SuppressionOptedOut, Note: "balas BERHENTI"}
+			if tt.err != nil {
+				mockSuppressionService.On("CreateSuppression", mock.Anything, body).Return(nil, tt.err)
+			} else {
+				mockSuppressionService.On("CreateSuppression", mock.Anything, body).
+					Return(&domain.Suppression{Recipient: "6281234567890", Reason: domain.SuppressionOptedOut}, nil)
+			}
+
+			// Act
+			payload, _ := json.Marshal(body)
+			req, _ := http.NewRequest("POST", "/suppressions", bytes.NewBuffer(payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockSuppressionService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSuppressionHandler_DeleteSuppression(t *testing.T) {
+	// Arrange
+	mockSuppressionService := &mocks.MockSuppressionService{}
+	handler := NewSuppressionHandler(mockSuppressionService)
+
+	router := setupTestRouter()
+	router.DELETE("/suppressions/:recipient", handler.DeleteSuppression)
+
+	mockSuppressionService.On("DeleteSuppression", mock.Anything, "6281234567890").Return(nil)
+	mockSuppressionService.On("DeleteSuppression", mock.Anything, "6289999999999").Return(domain.ErrSuppressionNotFound)
+
+	// Act
+	deleted := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/suppressions/6281234567890", nil)
+	router.ServeHTTP(deleted, req)
+
+	missing := httptest.NewRecorder()
+	req, _ = http.NewRequest("DELETE", "/suppressions/6289999999999", nil)
+	router.ServeHTTP(missing, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, deleted.Code)
+	assert.Equal(t, http.StatusNotFound, missing.Code)
+	mockSuppressionService.AssertExpectations(t)
+}
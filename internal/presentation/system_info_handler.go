@@ -0,0 +1,31 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/bootreport"
+)
+
+type SystemInfoHandler struct {
+	report func() *bootreport.Report
+}
+
+// NewSystemInfoHandler creates a handler serving the boot report returned by
+// report, which is nil until startup has finished
+func NewSystemInfoHandler(report func() *bootreport.Report) *SystemInfoHandler {
+	return &SystemInfoHandler{report: report}
+}
+
+// GetSystemInfo handles GET /api/system/info
+func (h *SystemInfoHandler) GetSystemInfo(c *gin.Context) {
+	report := h.report()
+	if report == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "service is still starting",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
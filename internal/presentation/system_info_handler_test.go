@@ -0,0 +1,54 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/bootreport"
+)
+
+func TestSystemInfoHandler_GetSystemInfo(t *testing.T) {
+	// Arrange
+	report := &bootreport.Report{
+		Profile: "production",
+		Config:  map[string]string{"API_PORT": "8080", "API_PASSWORD": "********"},
+		Senders: []string{"6281111"},
+		Listen:  []string{":8080"},
+	}
+	handler := NewSystemInfoHandler(func() *bootreport.Report { return report })
+
+	router := setupTestRouter()
+	router.GET("/system/info", handler.GetSystemInfo)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/system/info", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response bootreport.Report
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "********", response.Config["API_PASSWORD"])
+	assert.Equal(t, []string{"6281111"}, response.Senders)
+}
+
+func TestSystemInfoHandler_GetSystemInfo_BeforeStartup(t *testing.T) {
+	// Arrange
+	handler := NewSystemInfoHandler(func() *bootreport.Report { return nil })
+
+	router := setupTestRouter()
+	router.GET("/system/info", handler.GetSystemInfo)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/system/info", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
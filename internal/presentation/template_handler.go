@@ -0,0 +1,149 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type TemplateHandler struct {
+	templateService domain.TemplateService
+}
+
+// NewTemplateHandler creates a new message template handler
+func NewTemplateHandler(templateService domain.TemplateService) *TemplateHandler {
+	return &TemplateHandler{templateService: templateService}
+}
+
+// ListTemplates handles GET /api/templates
+func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.templateService.ListTemplates(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"templates": templates,
+		"count":     len(templates),
+	})
+}
+
+// GetTemplate handles GET /api/templates/:name
+func (h *TemplateHandler) GetTemplate(c *gin.Context) {
+	template, err := h.templateService.GetTemplate(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		c.JSON(templateStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// CreateTemplate handles POST /api/templates
+func (h *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req domain.SaveTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.CreateTemplate(c.Request.Context(), &req)
+	if err != nil {
+		c.JSON(templateStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateTemplate handles PUT /api/templates/:name
+func (h *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	var req domain.SaveTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	template, err := h.templateService.UpdateTemplate(c.Request.Context(), c.Param("name"), &req)
+	if err != nil {
+		c.JSON(templateStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate handles DELETE /api/templates/:name
+func (h *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	if err := h.templateService.DeleteTemplate(c.Request.Context(), c.Param("name")); err != nil {
+		c.JSON(templateStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Template deleted",
+	})
+}
+
+// SendTemplate handles POST /api/send-template
+func (h *TemplateHandler) SendTemplate(c *gin.Context) {
+	var req domain.SendTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.SendMessageResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.templateService.SendTemplate(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := templateStatusCode(err)
+		switch err {
+		case domain.ErrWhatsAppNotConnected:
+			statusCode = http.StatusServiceUnavailable
+		case domain.ErrInvalidPhoneNumber:
+			statusCode = http.StatusBadRequest
+		case domain.ErrRecipientSuppressed:
+			statusCode = http.StatusForbidden
+		case domain.ErrDuplicateSend, domain.ErrSenderPaused:
+			statusCode = http.StatusConflict
+		case domain.ErrSenderQuotaExceeded:
+			statusCode = http.StatusTooManyRequests
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// templateStatusCode maps template errors to HTTP status codes
+func templateStatusCode(err error) int {
+	switch err {
+	case domain.ErrInvalidTemplate, domain.ErrMissingVariables:
+		return http.StatusBadRequest
+	case domain.ErrTemplateNotFound:
+		return http.StatusNotFound
+	case domain.ErrTemplateExists:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,121 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestTemplateHandler_CreateTemplate(t *testing.T) {
+	// Arrange
+	mockTemplateService := &mocks.MockTemplateService{}
+	handler := NewTemplateHandler(mockTemplateService)
+
+	router := setupTestRouter()
+	router.POST("/templates", handler.CreateTemplate)
+
+	reqBody := domain.SaveTemplateRequest{Name: "welcome", Body: "Halo {{name}}!"}
+	mockTemplateService.On("CreateTemplate", mock.Anything, &reqBody).
+		Return(&domain.MessageTemplate{Name: "welcome", Body: reqBody.Body, Variables: []string{"name"}}, nil)
+
+	// Act
+	body, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/templates", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.MessageTemplate
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{"name"}, response.Variables)
+	mockTemplateService.AssertExpectations(t)
+}
+
+func TestTemplateHandler_CreateTemplate_Exists(t *testing.T) {
+	// Arrange
+	mockTemplateService := &mocks.MockTemplateService{}
+	handler := NewTemplateHandler(mockTemplateService)
+
+	router := setupTestRouter()
+	router.POST("/templates", handler.CreateTemplate)
+
+	mockTemplateService.On("CreateTemplate", mock.Anything, mock.Anything).Return(nil, domain.ErrTemplateExists)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/templates", bytes.NewBufferString(`{"name": "welcome", "body": "Halo"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestTemplateHandler_DeleteTemplate_NotFound(t *testing.T) {
+	// Arrange
+	mockTemplateService := &mocks.MockTemplateService{}
+	handler := NewTemplateHandler(mockTemplateService)
+
+	router := setupTestRouter()
+	router.DELETE("/templates/:name", handler.DeleteTemplate)
+
+	mockTemplateService.On("DeleteTemplate", mock.Anything, "welcome").Return(domain.ErrTemplateNotFound)
+
+	// Act
+	req, _ := http.NewRequest("DELETE", "/templates/welcome", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockTemplateService.AssertExpectations(t)
+}
+
+func TestTemplateHandler_SendTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"sent", nil, http.StatusOK},
+		{"missing variables", domain.ErrMissingVariables, http.StatusBadRequest},
+		{"unknown template", domain.ErrTemplateNotFound, http.StatusNotFound},
+		{"not connected", domain.ErrWhatsAppNotConnected, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockTemplateService := &mocks.MockTemplateService{}
+			handler := NewTemplateHandler(mockTemplateService)
+
+			router := setupTestRouter()
+			router.POST("/send-template", handler.SendTemplate)
+
+			mockTemplateService.On("SendTemplate", mock.Anything, mock.MatchedBy(func(req *domain.SendTemplateRequest) bool {
+				return req.Template == "points_earned" && req.Variables["points"] == 50.0
+			})).Return(&domain.SendMessageResponse{Success: tt.err == nil}, tt.err)
+
+			// Act
+			body := `{"to": "+6281234567890", "template": "points_earned", "variables": {"name": "Budi", "points": 50}}`
+			req, _ := http.NewRequest("POST", "/send-template", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantCode, w.Code)
+			mockTemplateService.AssertExpectations(t)
+		})
+	}
+}
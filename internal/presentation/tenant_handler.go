@@ -0,0 +1,189 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type TenantHandler struct {
+	tenantService domain.TenantService
+}
+
+// NewTenantHandler creates a new tenant onboarding handler
+func NewTenantHandler(tenantService domain.TenantService) *TenantHandler {
+	return &TenantHandler{tenantService: tenantService}
+}
+
+// CreateTenant handles POST /api/tenants. Onboarding is for the platform
+// operator, so a tenant's API key cannot create tenants.
+func (h *TenantHandler) CreateTenant(c *gin.Context) {
+	if authenticatedTenant(c) != "" {
+		c.JSON(http.StatusForbidden, domain.CreateTenantResponse{
+			Success: false,
+			Message: "Tenant API keys cannot onboard tenants",
+		})
+		return
+	}
+
+	var req domain.CreateTenantRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, domain.CreateTenantResponse{
+			Success: false,
+			Message: "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := h.tenantService.CreateTenant(c.Request.Context(), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidTenant:
+			statusCode = http.StatusBadRequest
+		case domain.ErrTenantExists:
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetOnboardingNudge handles GET /api/tenants/:slug/onboarding-nudge
+func (h *TenantHandler) GetOnboardingNudge(c *gin.Context) {
+	settings, err := h.tenantService.GetOnboardingNudge(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrTenantNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// SetOnboardingNudge handles PUT /api/tenants/:slug/onboarding-nudge
+func (h *TenantHandler) SetOnboardingNudge(c *gin.Context) {
+	var req domain.OnboardingNudgeSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	settings, err := h.tenantService.SetOnboardingNudge(c.Request.Context(), c.Param("slug"), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidNudgeSettings:
+			statusCode = http.StatusBadRequest
+		case domain.ErrTenantNotFound:
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// GetLoyaltyProgram handles GET /api/tenants/:slug/loyalty-program
+func (h *TenantHandler) GetLoyaltyProgram(c *gin.Context) {
+	settings, err := h.tenantService.GetLoyaltyProgram(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrTenantNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// SetLoyaltyProgram handles PUT /api/tenants/:slug/loyalty-program
+func (h *TenantHandler) SetLoyaltyProgram(c *gin.Context) {
+	var req domain.LoyaltyProgramSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	settings, err := h.tenantService.SetLoyaltyProgram(c.Request.Context(), c.Param("slug"), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidLoyaltyProgram:
+			statusCode = http.StatusBadRequest
+		case domain.ErrTenantNotFound:
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// GetMessageLog handles GET /api/tenants/:slug/message-log
+func (h *TenantHandler) GetMessageLog(c *gin.Context) {
+	settings, err := h.tenantService.GetMessageLog(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrTenantNotFound {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// SetMessageLog handles PUT /api/tenants/:slug/message-log
+func (h *TenantHandler) SetMessageLog(c *gin.Context) {
+	var req domain.MessageLogSettings
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	settings, err := h.tenantService.SetMessageLog(c.Request.Context(), c.Param("slug"), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrInvalidMessageLogMode:
+			statusCode = http.StatusBadRequest
+		case domain.ErrTenantNotFound:
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,253 @@
+package presentation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestTenantHandler_CreateTenant_Success(t *testing.T) {
+	// Arrange
+	mockTenantService := &mocks.MockTenantService{}
+	handler := NewTenantHandler(mockTenantService)
+
+	router := setupTestRouter()
+	router.POST("/tenants", handler.CreateTenant)
+
+	reqBody := domain.CreateTenantRequest{
+		Name:       "Ruang Laundry",
+		AdminName:  "Budi",
+		AdminPhone: "+6281234567890",
+	}
+	expectedResponse := &domain.CreateTenantResponse{
+		Success:  true,
+		TenantID: 1,
+		Slug:     "ruang-laundry",
+		APIKey:   "wp_secret",
+	}
+	mockTenantService.On("CreateTenant", mock.Anything, &reqBody).Return(expectedResponse, nil)
+
+	jsonBody, _ := json.Marshal(reqBody)
+	req, _ := http.NewRequest("POST", "/tenants", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.CreateTenantResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, "wp_secret", response.APIKey)
+
+	mockTenantService.AssertExpectations(t)
+}
+
+func TestTenantHandler_CreateTenant_ErrorMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid", domain.ErrInvalidTenant, http.StatusBadRequest},
+		{"duplicate", domain.ErrTenantExists, http.StatusConflict},
+		{"internal", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTenantService := &mocks.MockTenantService{}
+			handler := NewTenantHandler(mockTenantService)
+
+			router := setupTestRouter()
+			router.POST("/tenants", handler.CreateTenant)
+
+			mockTenantService.On("CreateTenant", mock.Anything, mock.Anything).
+				Return(&domain.CreateTenantResponse{Success: false, Message: "failed"}, tt.err)
+
+			req, _ := http.NewRequest("POST", "/tenants", bytes.NewBufferString(`{"name":"x","admin_name":"y","admin_phone":"6281234567890"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestTenantHandler_SetOnboardingNudge(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"updated", nil, http.StatusOK},
+		{"invalid interval", domain.ErrInvalidNudgeSettings, http.StatusBadRequest},
+		{"unknown tenant", domain.ErrTenantNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockTenantService := &mocks.MockTenantService{}
+			handler := NewTenantHandler(mockTenantService)
+
+			router := setupTestRouter()
+			router.PUT("/tenants/:slug/onboarding-nudge", handler.SetOnboardingNudge)
+
+			reqBody := domain.OnboardingNudgeSettings{Enabled: true, IntervalDays: 14, Message: "Halo {{name}}, daftar yuk!"}
+			var result *domain.OnboardingNudgeSettings
+			if tt.err == nil {
+				result = &reqBody
+			}
+			mockTenantService.On("SetOnboardingNudge", mock.Anything, "ruang-laundry", &reqBody).Return(result, tt.err)
+
+			// Act
+			jsonBody, _ := json.Marshal(reqBody)
+			req, _ := http.NewRequest("PUT", "/tenants/ruang-laundry/onboarding-nudge", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockTenantService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantHandler_GetOnboardingNudge_NotFound(t *testing.T) {
+	// Arrange
+	mockTenantService := &mocks.MockTenantService{}
+	handler := NewTenantHandler(mockTenantService)
+
+	router := setupTestRouter()
+	router.GET("/tenants/:slug/onboarding-nudge", handler.GetOnboardingNudge)
+
+	mockTenantService.On("GetOnboardingNudge", mock.Anything, "missing").Return(nil, domain.ErrTenantNotFound)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/tenants/missing/onboarding-nudge", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockTenantService.AssertExpectations(t)
+}
+
+func TestTenantHandler_SetLoyaltyProgram(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"updated", nil, http.StatusOK},
+		{"invalid program", domain.ErrInvalidLoyaltyProgram, http.StatusBadRequest},
+		{"unknown tenant", domain.ErrTenantNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockTenantService := &mocks.MockTenantService{}
+			handler := NewTenantHandler(mockTenantService)
+
+			router := setupTestRouter()
+			router.PUT("/tenants/:slug/loyalty-program", handler.SetLoyaltyProgram)
+
+			reqBody := domain.LoyaltyProgramSettings{Program: "both", StampCardSize: 10, StampReward: "Gratis 1x cuci"}
+			var result *domain.LoyaltyProgramSettings
+			if tt.err == nil {
+				result = &reqBody
+			}
+			mockTenantService.On("SetLoyaltyProgram", mock.Anything, "ruang-laundry", &reqBody).Return(result, tt.err)
+
+			// Act
+			jsonBody, _ := json.Marshal(reqBody)
+			req, _ := http.NewRequest("PUT", "/tenants/ruang-laundry/loyalty-program", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockTenantService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantHandler_SetMessageLog(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"updated", nil, http.StatusOK},
+		{"invalid mode", domain.ErrInvalidMessageLogMode, http.StatusBadRequest},
+		{"unknown tenant", domain.ErrTenantNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockTenantService := &mocks.MockTenantService{}
+			handler := NewTenantHandler(mockTenantService)
+
+			router := setupTestRouter()
+			router.PUT("/tenants/:slug/message-log", handler.SetMessageLog)
+
+			reqBody := domain.MessageLogSettings{Mode: "private"}
+			var result *domain.MessageLogSettings
+			if tt.err == nil {
+				result = &reqBody
+			}
+			mockTenantService.On("SetMessageLog", mock.Anything, "ruang-laundry", &reqBody).Return(result, tt.err)
+
+			// Act
+			jsonBody, _ := json.Marshal(reqBody)
+			req, _ := http.NewRequest("PUT", "/tenants/ruang-laundry/message-log", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockTenantService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTenantHandler_CreateTenant_RefusesTenantAPIKey(t *testing.T) {
+	// Arrange
+	mockTenantService := &mocks.MockTenantService{}
+	handler := NewTenantHandler(mockTenantService)
+
+	router := setupTestRouter()
+	router.POST("/tenants", func(c *gin.Context) {
+		c.Set(tenantContextKey, "ruang-laundry")
+	}, handler.CreateTenant)
+
+	req, _ := http.NewRequest("POST", "/tenants", bytes.NewBufferString(`{"name":"x","admin_name":"y","admin_phone":"6281234567890"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockTenantService.AssertNotCalled(t, "CreateTenant", mock.Anything, mock.Anything)
+}
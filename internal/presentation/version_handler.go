@@ -0,0 +1,30 @@
+package presentation
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type VersionHandler struct {
+	versionService domain.VersionService
+}
+
+// NewVersionHandler creates a new build version handler
+func NewVersionHandler(versionService domain.VersionService) *VersionHandler {
+	return &VersionHandler{versionService: versionService}
+}
+
+// GetVersion handles GET /api/version
+func (h *VersionHandler) GetVersion(c *gin.Context) {
+	info, err := h.versionService.GetVersion(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
@@ -0,0 +1,43 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestVersionHandler_GetVersion(t *testing.T) {
+	// Arrange
+	mockService := &mocks.MockVersionService{}
+	handler := NewVersionHandler(mockService)
+
+	router := setupTestRouter()
+	router.GET("/version", handler.GetVersion)
+
+	mockService.On("GetVersion", mock.Anything).Return(&domain.VersionInfo{
+		Version:         "1.3.0",
+		Commit:          "abc1234",
+		LatestVersion:   "v1.4.0",
+		UpdateAvailable: true,
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/version", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.VersionInfo
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "1.3.0", response.Version)
+	assert.True(t, response.UpdateAvailable)
+	mockService.AssertExpectations(t)
+}
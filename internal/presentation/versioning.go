@@ -0,0 +1,111 @@
+package presentation
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIVersionV1 is the first explicitly versioned API
+const APIVersionV1 = "v1"
+
+// DefaultAPIVersion is served when a request on an unversioned route does not
+// ask for a specific version
+const DefaultAPIVersion = APIVersionV1
+
+// APIVersionHeader carries the requested version on requests and the served
+// version on responses
+const APIVersionHeader = "API-Version"
+
+// apiVersionContextKey stores the negotiated version on the gin context
+const apiVersionContextKey = "api_version"
+
+// supportedAPIVersions lists every version the server can serve
+var supportedAPIVersions = map[string]bool{
+	APIVersionV1: true,
+}
+
+// vendorMediaType matches Accept values such as application/vnd.whatspoints.v1+json
+var vendorMediaType = regexp.MustCompile(`application/vnd\.whatspoints\.(v[0-9]+)\+json`)
+
+// requestedAPIVersion returns the version the client asked for via the
+// API-Version header or a vendor media type in Accept, or "" if none
+func requestedAPIVersion(c *gin.Context) string {
+	if v := strings.ToLower(strings.TrimSpace(c.GetHeader(APIVersionHeader))); v != "" {
+		if !strings.HasPrefix(v, "v") {
+			v = "v" + v
+		}
+		return v
+	}
+	if m := vendorMediaType.FindStringSubmatch(c.GetHeader("Accept")); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// APIVersionMiddleware negotiates the API version for a request. When pinned is
+// set (routes under /api/v1) the path decides the version and a conflicting
+// request header is rejected; otherwise the requested version is used, falling
+// back to DefaultAPIVersion. Unsupported versions get 406 Not Acceptable.
+func APIVersionMiddleware(pinned string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version := requestedAPIVersion(c)
+		if version == "" {
+			version = pinned
+		}
+		if version == "" {
+			version = DefaultAPIVersion
+		}
+
+		if (pinned != "" && version != pinned) || !supportedAPIVersions[version] {
+			c.AbortWithStatusJSON(http.StatusNotAcceptable, gin.H{
+				"error":              "unsupported API version: " + version,
+				"supported_versions": supportedAPIVersionList(),
+			})
+			return
+		}
+
+		c.Set(apiVersionContextKey, version)
+		c.Header(APIVersionHeader, version)
+		c.Next()
+	}
+}
+
+// APIVersion returns the version negotiated for the request, so handlers can
+// switch response shapes when a breaking change ships in a new version
+func APIVersion(c *gin.Context) string {
+	if v, ok := c.Get(apiVersionContextKey); ok {
+		if version, ok := v.(string); ok {
+			return version
+		}
+	}
+	return DefaultAPIVersion
+}
+
+// DeprecationMiddleware marks responses from the unversioned /api aliases as
+// deprecated and points clients at the /api/v1 equivalent. The Sunset header is
+// only sent when a sunset date is configured.
+func DeprecationMiddleware(sunset time.Time) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		if !sunset.IsZero() {
+			c.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+		successor := "/api/" + APIVersionV1 + strings.TrimPrefix(c.Request.URL.Path, "/api")
+		c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+		c.Next()
+	}
+}
+
+func supportedAPIVersionList() []string {
+	versions := make([]string, 0, len(supportedAPIVersions))
+	for v := range supportedAPIVersions {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return versions
+}
@@ -0,0 +1,93 @@
+package presentation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupVersionedRouter(sunset time.Time) *gin.Engine {
+	router := setupTestRouter()
+	echo := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"version": APIVersion(c)})
+	}
+
+	v1 := router.Group("/api/v1", APIVersionMiddleware(APIVersionV1))
+	v1.GET("/status", echo)
+
+	legacy := router.Group("/api", DeprecationMiddleware(sunset), APIVersionMiddleware(""))
+	legacy.GET("/status", echo)
+	return router
+}
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		headers        map[string]string
+		expectedStatus int
+		expectedHeader string
+	}{
+		{"versioned path", "/api/v1/status", nil, http.StatusOK, "v1"},
+		{"unversioned path defaults to v1", "/api/status", nil, http.StatusOK, "v1"},
+		{"version header", "/api/status", map[string]string{"API-Version": "1"}, http.StatusOK, "v1"},
+		{"vendor media type", "/api/status", map[string]string{"Accept": "application/vnd.whatspoints.v1+json"}, http.StatusOK, "v1"},
+		{"unsupported version", "/api/status", map[string]string{"API-Version": "v9"}, http.StatusNotAcceptable, ""},
+		{"header conflicts with path", "/api/v1/status", map[string]string{"API-Version": "v2"}, http.StatusNotAcceptable, ""},
+	}
+
+	router := setupVersionedRouter(time.Time{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			// Act
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedHeader, w.Header().Get(APIVersionHeader))
+		})
+	}
+}
+
+func TestDeprecationMiddleware_UnversionedAlias(t *testing.T) {
+	// Arrange
+	sunset := time.Date(2027, 6, 30, 0, 0, 0, 0, time.UTC)
+	router := setupVersionedRouter(sunset)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/status", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Wed, 30 Jun 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `</api/v1/status>; rel="successor-version"`, w.Header().Get("Link"))
+}
+
+func TestDeprecationMiddleware_VersionedRouteNotDeprecated(t *testing.T) {
+	// Arrange
+	router := setupVersionedRouter(time.Time{})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/status", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+}
@@ -0,0 +1,59 @@
+package presentation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type VIPGroupHandler struct {
+	vipGroupService domain.VIPGroupService
+}
+
+// NewVIPGroupHandler creates a new VIP group sync handler
+func NewVIPGroupHandler(vipGroupService domain.VIPGroupService) *VIPGroupHandler {
+	return &VIPGroupHandler{vipGroupService: vipGroupService}
+}
+
+// Sync handles POST /api/vip-group/sync, which syncs the VIP group now
+func (h *VIPGroupHandler) Sync(c *gin.Context) {
+	result, err := h.vipGroupService.Sync(c.Request.Context())
+	if err != nil {
+		c.JSON(groupStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListChanges handles GET /api/vip-group/changes?limit=50
+func (h *VIPGroupHandler) ListChanges(c *gin.Context) {
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid limit",
+			})
+			return
+		}
+		limit = n
+	}
+
+	changes, err := h.vipGroupService.ListChanges(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(groupStatusCode(err), gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"changes": changes,
+		"count":   len(changes),
+	})
+}
@@ -0,0 +1,92 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestVIPGroupHandler_Sync(t *testing.T) {
+	// Arrange
+	mockService := &mocks.MockVIPGroupService{}
+	handler := NewVIPGroupHandler(mockService)
+
+	router := setupTestRouter()
+	router.POST("/vip-group/sync", handler.Sync)
+
+	mockService.On("Sync", mock.Anything).Return(&domain.GroupSyncResult{
+		GroupJID: "120363025246125486@g.us",
+		Added:    2,
+		Removed:  1,
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/vip-group/sync", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.GroupSyncResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Added)
+	assert.Equal(t, 1, response.Removed)
+}
+
+func TestVIPGroupHandler_Sync_NotAdmin(t *testing.T) {
+	// Arrange
+	mockService := &mocks.MockVIPGroupService{}
+	handler := NewVIPGroupHandler(mockService)
+
+	router := setupTestRouter()
+	router.POST("/vip-group/sync", handler.Sync)
+
+	mockService.On("Sync", mock.Anything).Return(nil, domain.ErrNotGroupAdmin)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/vip-group/sync", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestVIPGroupHandler_ListChanges(t *testing.T) {
+	// Arrange
+	mockService := &mocks.MockVIPGroupService{}
+	handler := NewVIPGroupHandler(mockService)
+
+	router := setupTestRouter()
+	router.GET("/vip-group/changes", handler.ListChanges)
+
+	mockService.On("ListChanges", mock.Anything, 10).Return([]*domain.GroupSyncChange{
+		{PhoneNumber: "6281111111111", Action: domain.GroupSyncInvited},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/vip-group/changes?limit=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	badReq, _ := http.NewRequest("GET", "/vip-group/changes?limit=ten", nil)
+	badW := httptest.NewRecorder()
+	router.ServeHTTP(badW, badReq)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Changes []domain.GroupSyncChange `json:"changes"`
+		Count   int                      `json:"count"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Count)
+	assert.Equal(t, domain.GroupSyncInvited, response.Changes[0].Action)
+	assert.Equal(t, http.StatusBadRequest, badW.Code)
+}
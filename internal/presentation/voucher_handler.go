@@ -0,0 +1,100 @@
+package presentation
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/wa-serv/internal/domain"
+)
+
+type VoucherHandler struct {
+	voucherService domain.VoucherService
+}
+
+// NewVoucherHandler creates a new voucher handler
+func NewVoucherHandler(voucherService domain.VoucherService) *VoucherHandler {
+	return &VoucherHandler{voucherService: voucherService}
+}
+
+// RedeemVoucher handles POST /api/vouchers/:code/redeem. The body, naming the
+// redeeming branch, is optional.
+func (h *VoucherHandler) RedeemVoucher(c *gin.Context) {
+	var req domain.RedeemVoucherRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid request body: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	voucher, err := h.voucherService.RedeemVoucher(c.Request.Context(), c.Param("code"), &req)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch err {
+		case domain.ErrVoucherNotFound:
+			statusCode = http.StatusNotFound
+		case domain.ErrVoucherRedeemed:
+			statusCode = http.StatusConflict
+		case domain.ErrVoucherExpired:
+			statusCode = http.StatusGone
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, voucher)
+}
+
+// GetSettlement handles GET /api/vouchers/settlement?date=YYYY-MM-DD (defaults to
+// today). Add format=csv to download it for the POS.
+func (h *VoucherHandler) GetSettlement(c *gin.Context) {
+	date := c.DefaultQuery("date", time.Now().Format("2006-01-02"))
+
+	settlement, err := h.voucherService.GetSettlement(c.Request.Context(), date)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrInvalidDate {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		writeSettlementCSV(c, settlement)
+		return
+	}
+	c.JSON(http.StatusOK, settlement)
+}
+
+// writeSettlementCSV writes one row per branch with its redemptions, then a
+// TOTAL row that also carries the issued, expired and outstanding vouchers,
+// which belong to no branch
+func writeSettlementCSV(c *gin.Context, s *domain.VoucherSettlement) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="voucher-settlement-%s.csv"`, s.Date))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"date", "branch", "issued", "issued_points", "redeemed", "redeemed_points",
+		"expired", "expired_points", "outstanding", "outstanding_points"})
+	for _, b := range s.Branches {
+		_ = w.Write([]string{s.Date, b.Branch, "", "", strconv.Itoa(b.Redeemed.Count), strconv.Itoa(b.Redeemed.Points), "", "", "", ""})
+	}
+	_ = w.Write([]string{s.Date, "TOTAL",
+		strconv.Itoa(s.Issued.Count), strconv.Itoa(s.Issued.Points),
+		strconv.Itoa(s.Redeemed.Count), strconv.Itoa(s.Redeemed.Points),
+		strconv.Itoa(s.Expired.Count), strconv.Itoa(s.Expired.Points),
+		strconv.Itoa(s.Outstanding.Count), strconv.Itoa(s.Outstanding.Points)})
+	w.Flush()
+}
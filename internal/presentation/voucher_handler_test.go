@@ -0,0 +1,144 @@
+package presentation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/wa-serv/internal/domain"
+	"github.com/wa-serv/internal/mocks"
+)
+
+func TestVoucherHandler_RedeemVoucher(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"redeemed", nil, http.StatusOK},
+		{"unknown code", domain.ErrVoucherNotFound, http.StatusNotFound},
+		{"already used", domain.ErrVoucherRedeemed, http.StatusConflict},
+		{"expired", domain.ErrVoucherExpired, http.StatusGone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockVoucherService := &mocks.MockVoucherService{}
+			handler := NewVoucherHandler(mockVoucherService)
+
+			router := setupTestRouter()
+			router.POST("/vouchers/:code/redeem", handler.RedeemVoucher)
+
+			var voucher *domain.Voucher
+			if tt.err == nil {
+				voucher = &domain.Voucher{
+					Code:        "RL-7KQ2-MX9D",
+					PhoneNumber: "6281234567890",
+					Reward:      "Gratis cuci 5 kg",
+					Points:      50,
+					IssuedAt:    "2026-10-16T09:00:00Z",
+					RedeemedAt:  "2026-10-16T10:00:00Z",
+				}
+			}
+			mockVoucherService.On("RedeemVoucher", mock.Anything, "RL-7KQ2-MX9D", &domain.RedeemVoucherRequest{Branch: "Kemang"}).Return(voucher, tt.err)
+
+			// Act
+			req, _ := http.NewRequest("POST", "/vouchers/RL-7KQ2-MX9D/redeem", strings.NewReader(`{"branch": "Kemang"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.err == nil {
+				var response domain.Voucher
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "Gratis cuci 5 kg", response.Reward)
+			}
+			mockVoucherService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVoucherHandler_RedeemVoucher_WithoutBody(t *testing.T) {
+	// Arrange
+	mockVoucherService := &mocks.MockVoucherService{}
+	handler := NewVoucherHandler(mockVoucherService)
+
+	router := setupTestRouter()
+	router.POST("/vouchers/:code/redeem", handler.RedeemVoucher)
+
+	mockVoucherService.On("RedeemVoucher", mock.Anything, "RL-7KQ2-MX9D", &domain.RedeemVoucherRequest{}).
+		Return(&domain.Voucher{Code: "RL-7KQ2-MX9D"}, nil)
+
+	// Act
+	req, _ := http.NewRequest("POST", "/vouchers/RL-7KQ2-MX9D/redeem", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockVoucherService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_GetSettlement_CSV(t *testing.T) {
+	// Arrange
+	mockVoucherService := &mocks.MockVoucherService{}
+	handler := NewVoucherHandler(mockVoucherService)
+
+	router := setupTestRouter()
+	router.GET("/vouchers/settlement", handler.GetSettlement)
+
+	mockVoucherService.On("GetSettlement", mock.Anything, "2026-10-16").Return(&domain.VoucherSettlement{
+		Date:        "2026-10-16",
+		Issued:      domain.VoucherTally{Count: 4, Points: 220},
+		Redeemed:    domain.VoucherTally{Count: 3, Points: 120},
+		Expired:     domain.VoucherTally{Count: 1, Points: 20},
+		Outstanding: domain.VoucherTally{Count: 9, Points: 600},
+		Branches: []domain.BranchVoucherRedemptions{
+			{Branch: "Kemang", Redeemed: domain.VoucherTally{Count: 2, Points: 70}},
+			{Branch: "Tebet", Redeemed: domain.VoucherTally{Count: 1, Points: 50}},
+		},
+	}, nil)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/vouchers/settlement?date=2026-10-16&format=csv", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/csv")
+	assert.Equal(t, strings.Join([]string{
+		"date,branch,issued,issued_points,redeemed,redeemed_points,expired,expired_points,outstanding,outstanding_points",
+		"2026-10-16,Kemang,,,2,70,,,,",
+		"2026-10-16,Tebet,,,1,50,,,,",
+		"2026-10-16,TOTAL,4,220,3,120,1,20,9,600",
+		"",
+	}, "\n"), w.Body.String())
+	mockVoucherService.AssertExpectations(t)
+}
+
+func TestVoucherHandler_GetSettlement_InvalidDate(t *testing.T) {
+	// Arrange
+	mockVoucherService := &mocks.MockVoucherService{}
+	handler := NewVoucherHandler(mockVoucherService)
+
+	router := setupTestRouter()
+	router.GET("/vouchers/settlement", handler.GetSettlement)
+
+	mockVoucherService.On("GetSettlement", mock.Anything, "kemarin").Return(nil, domain.ErrInvalidDate)
+
+	// Act
+	req, _ := http.NewRequest("GET", "/vouchers/settlement?date=kemarin", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"database/sql"
 	"flag"
@@ -9,34 +10,53 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
-	_ "github.com/lib/pq" // PostgreSQL driver for Supabase
+	"github.com/lib/pq" // PostgreSQL driver for Supabase
 	"github.com/wa-serv/api"
+	"github.com/wa-serv/bootreport"
+	"github.com/wa-serv/buildinfo"
 	"github.com/wa-serv/config"
 	"github.com/wa-serv/database"
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/faults"
+	"github.com/wa-serv/handlers"
+	"github.com/wa-serv/plugins"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+	"github.com/wa-serv/scheduler"
+	"github.com/wa-serv/selftest"
+	"github.com/wa-serv/webhook"
 	"github.com/wa-serv/whatsapp"
 )
 
 // Global variables
 var db *sql.DB
 var httpServer *http.Server
+var jobScheduler *scheduler.Scheduler
 
 func main() {
 
-	clearSessions := flag.Bool("clear-sessions", false, "Clear all WhatsApp sessions")
+	// Subcommands are handled before the global flags are parsed
+	if len(os.Args) > 1 && os.Args[1] == "selftest" {
+		os.Exit(runSelftest(os.Args[2:]))
+	}
+
+	clearSessions := flag.Bool("clear-sessions", false, "Clear WhatsApp sessions: all of them, or those of -sender")
+	clearSender := flag.String("sender", "", "With -clear-sessions, only clear these sender IDs (comma-separated, e.g., 6281234567890)")
+	assumeYes := flag.Bool("yes", false, "With -clear-sessions, clear without asking for confirmation")
 	addSender := flag.Bool("add-sender", false, "Add a new WhatsApp phone number using QR code")
 	addSenderWithCode := flag.String("add-sender-code", "", "Add a new WhatsApp phone number using pairing code (provide phone number with country code, e.g., +1234567890)")
+	replayEvents := flag.String("replay-events", "", "Dry-run replay of inbound events by ID (comma-separated, e.g., 12,13)")
+	replaySender := flag.String("replay-sender", "", "Dry-run replay of a sender's latest inbound events (phone number with country code)")
+	replayLimit := flag.Int("replay-limit", 20, "Number of events to replay with -replay-sender")
 	flag.Parse()
 
 	if *clearSessions {
-		if err := whatsapp.ClearAllSessions(); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to clear sessions: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println("All WhatsApp sessions cleared successfully")
-		os.Exit(0)
+		os.Exit(clearWhatsAppSessions(*clearSender, *assumeYes))
 	}
 
 	if *addSender {
@@ -51,11 +71,20 @@ func main() {
 
 	// Load environment variables
 	config.LoadEnv()
-	fmt.Println("Environment variables loaded successfully")
+
+	// Deliver domain events to the configured webhook
+	webhook.Subscribe(eventbus.Default())
 
 	// Initialize database
 	initializeDatabase()
-	fmt.Println("Database initialized successfully")
+
+	if *replayEvents != "" || *replaySender != "" {
+		replayInboundEvents(*replayEvents, *replaySender, *replayLimit)
+		os.Exit(0)
+	}
+
+	// Start custom inbound handlers registered by the deployment
+	plugins.Start(plugins.Host{DB: db})
 
 	// Initialize WhatsApp ClientManager with multi-sender support
 	connectionString := database.BuildPostgresConnectionString()
@@ -64,24 +93,37 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Failed to initialize ClientManager: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Println("WhatsApp ClientManager initialized successfully")
 
 	// Start API server with ClientManager
-	startAPIServerWithClientManager(clientManager)
+	port := startAPIServerWithClientManager(clientManager)
+
+	// Start background jobs (reminders etc.)
+	startScheduler(clientManager)
+
+	publishBootReport(clientManager, port)
 
 	// Listen for termination signals
 	waitForTerminationWithClientManager(clientManager)
 }
 
+// databaseDriverName returns the Postgres driver to use, registering a
+// fault-injecting wrapper when FAULT_INJECTION_ENABLED is set
+func databaseDriverName() string {
+	injector := faults.Default()
+	if !injector.Enabled() {
+		return "postgres"
+	}
+	sql.Register("postgres-faults", faults.WrapDriver(&pq.Driver{}, injector))
+	return "postgres-faults"
+}
+
 func initializeDatabase() {
 	// Supabase Transaction Pooler connection string
 	connectionString := database.BuildPostgresConnectionString()
 
-	fmt.Printf("Connecting to Supabase Transaction Pooler...\n")
-
 	// Connect directly with sql.DB
 	var err error
-	db, err = sql.Open("postgres", connectionString)
+	db, err = sql.Open(databaseDriverName(), connectionString)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to connect to Supabase (Postgres) database: %v\n", err)
 		os.Exit(1)
@@ -93,8 +135,6 @@ func initializeDatabase() {
 		os.Exit(1)
 	}
 
-	fmt.Println("Successfully connected to Supabase Transaction Pooler")
-
 	// Test the sql.DB connection
 	if err := db.Ping(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to ping SQL database: %v\n", err)
@@ -106,6 +146,14 @@ func initializeDatabase() {
 		fmt.Fprintf(os.Stderr, "Failed to initialize member table: %v\n", err)
 		os.Exit(1)
 	}
+	if err := database.InitMemberPushNameColumn(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to add member push name column: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitProspectsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize prospects table: %v\n", err)
+		os.Exit(1)
+	}
 	if err := database.InitImageTable(db); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize images table: %v\n", err)
 		os.Exit(1)
@@ -122,6 +170,10 @@ func initializeDatabase() {
 		fmt.Fprintf(os.Stderr, "Failed to initialize transactions table: %v\n", err)
 		os.Exit(1)
 	}
+	if err := database.InitPointTransactionStaffColumn(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to add point transaction staff column: %v\n", err)
+		os.Exit(1)
+	}
 	if err := database.InitItemsTable(db); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize items table: %v\n", err)
 		os.Exit(1)
@@ -135,15 +187,199 @@ func initializeDatabase() {
 		os.Exit(1)
 	}
 
+	if err := database.InitMemberLocationsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize member_locations table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitDriversTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize drivers table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitPickupSlotsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize pickup slot tables: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitOrderIntakeColumns(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize order intake columns: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitReminderTables(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize reminder tables: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitInboundEventsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize inbound_events table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitShadowDiffsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize shadow_diffs table: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Initialize senders table for multi-sender support
 	if err := database.InitSendersTable(db); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize senders table: %v\n", err)
 		os.Exit(1)
 	}
+	if err := database.InitSenderFallbackTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize sender_fallback_chains table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitSenderPoolsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize sender_pools table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitSenderActivityTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize sender_activity table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitDeviceKeysTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize device_keys table: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize tenant onboarding tables (order matters: tenants first for foreign keys)
+	if err := database.InitTenantsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize tenants table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitTenantAdminsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize tenant_admins table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitTenantAPIKeysTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize tenant_api_keys table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitRewardCatalogTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize reward_catalog table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitTemplatesTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize message_templates table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitAutomationRulesTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize automation_rules table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitPointsCampaignsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize points_campaigns table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitStampCardsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize stamp card tables: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitVouchersTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize vouchers table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitSendQueueTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize send queue table: %v\n", err)
+		os.Exit(1)
+	}
+	if err := database.InitDeadLettersTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize dead letters table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitSuppressionsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize suppressions table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitWhatsAppLabelsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize whatsapp labels tables: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitDisappearingTimersTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize disappearing timers table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitMessageStatusTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize message status table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitIdempotencyKeysTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize idempotency keys table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitMessageHistoryTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize message history table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitBranchesTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize branches table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitSuppliesTables(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize supplies tables: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitReportSchedulesTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize report schedules table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitIncidentsTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize incidents table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitGroupSyncChangesTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize group sync changes table: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := database.InitNotificationPreferencesTables(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize notification preferences tables: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Note: Whatsmeow session storage tables are automatically initialized by sqlstore.New()
 	// in the ClientManager, so we don't need to manually create them here
-	fmt.Println("All tables initialized successfully")
+}
+
+// publishBootReport logs how the service started and keeps the report for
+// GET /api/system/info
+func publishBootReport(clientManager *whatsapp.ClientManager, port string) {
+	tables, err := database.ListTables(db)
+	if err != nil {
+		log.Printf("Failed to list tables for the boot report: %v", err)
+	}
+	senders := clientManager.ListClients()
+	if len(senders) == 0 {
+		log.Printf("Warning: no senders available; add one with -add-sender or -add-sender-code=+PHONE_NUMBER")
+	}
+
+	build := buildinfo.Get()
+	bootreport.Publish(&bootreport.Report{
+		StartedAt:     time.Now(),
+		Version:       build.Version,
+		Commit:        build.Commit,
+		Profile:       config.LoadEnvironmentConfig().Profile,
+		Config:        config.Summary(),
+		Tables:        tables,
+		Senders:       senders,
+		DefaultSender: clientManager.GetDefaultSenderID(),
+		Listen:        []string{":" + port},
+	})
 }
 
 func startAPIServer(client *whatsapp.Client) {
@@ -168,14 +404,6 @@ func startAPIServer(client *whatsapp.Client) {
 
 	// Start server in a goroutine
 	go func() {
-		fmt.Printf("Starting API server on port %s...\n", port)
-		fmt.Printf("API endpoints:\n")
-		fmt.Printf("  POST /api/send-message - Send WhatsApp message\n")
-		fmt.Printf("  GET  /api/status       - Get service status\n")
-		fmt.Printf("  GET  /health           - Health check\n")
-		fmt.Printf("  GET  /api/senders      - List available senders\n")
-		fmt.Printf("Basic Auth: %s / %s\n", username, "***")
-
 		if err := apiServer.Start(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start API server: %v", err)
 		}
@@ -219,7 +447,9 @@ func waitForTermination(client *whatsapp.Client) {
 	fmt.Println("Shutdown complete")
 }
 
-func startAPIServerWithClientManager(clientManager *whatsapp.ClientManager) {
+// startAPIServerWithClientManager starts the API server in the background and
+// returns the port it listens on
+func startAPIServerWithClientManager(clientManager *whatsapp.ClientManager) string {
 	// Get API configuration from environment variables
 	port := os.Getenv("API_PORT")
 	if port == "" {
@@ -241,33 +471,55 @@ func startAPIServerWithClientManager(clientManager *whatsapp.ClientManager) {
 
 	// Start server in a goroutine
 	go func() {
-		fmt.Printf("Starting API server on port %s...\n", port)
-		fmt.Printf("API endpoints:\n")
-		fmt.Printf("  POST /api/send-message - Send WhatsApp message\n")
-		fmt.Printf("  GET  /api/status       - Get service status\n")
-		fmt.Printf("  GET  /health           - Health check\n")
-		fmt.Printf("  GET  /api/senders      - List available senders\n")
-		fmt.Printf("Basic Auth: %s / %s\n", username, "***")
-
-		// List available senders
-		senders := clientManager.ListClients()
-		if len(senders) > 0 {
-			fmt.Printf("\nAvailable senders: %d\n", len(senders))
-			for i, senderID := range senders {
-				fmt.Printf("  %d. %s\n", i+1, senderID)
-			}
-		} else {
-			fmt.Println("\n⚠ No senders available. Add a sender using:")
-			fmt.Println("  ./whatspoints -add-sender")
-			fmt.Println("  ./whatspoints -add-sender-code=+PHONE_NUMBER")
-		}
-
 		if err := apiServer.Start(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start API server: %v", err)
 		}
 	}()
 
 	httpServer = apiServer.GetHTTPServer()
+	return port
+}
+
+// startScheduler registers and starts the periodic background jobs
+func startScheduler(clientManager *whatsapp.ClientManager) {
+	cfg := config.LoadSchedulerConfig()
+	jobScheduler = scheduler.NewScheduler()
+
+	jobScheduler.Every("pickup-reminders", cfg.Interval, func(ctx context.Context) error {
+		client, err := clientManager.GetDefaultClient()
+		if err != nil {
+			return nil // No sender connected yet; try again next tick
+		}
+		sent, err := processor.SendPickupReminders(db, client, cfg.PickupReminderLead)
+		if sent > 0 {
+			fmt.Printf("Sent %d pickup reminder(s)\n", sent)
+		}
+		return err
+	})
+
+	jobScheduler.Every("reminder-rules", cfg.Interval, func(ctx context.Context) error {
+		client, err := clientManager.GetDefaultClient()
+		if err != nil {
+			return nil
+		}
+		sent, err := processor.RunReminderRules(db, client, cfg.EscalationPhones)
+		if sent > 0 {
+			fmt.Printf("Sent %d rule-based reminder(s)\n", sent)
+		}
+		return err
+	})
+
+	retentionCfg := config.LoadRetentionConfig()
+	jobScheduler.Every("retention", retentionCfg.Interval, func(ctx context.Context) error {
+		pruned, err := processor.PruneOperationalData(db, retentionCfg, time.Now())
+		for table, deleted := range pruned {
+			fmt.Printf("Pruned %d old row(s) from %s\n", deleted, table)
+		}
+		return err
+	})
+
+	jobScheduler.Start(context.Background())
+	fmt.Printf("Scheduler started (interval %s)\n", cfg.Interval)
 }
 
 func waitForTerminationWithClientManager(clientManager *whatsapp.ClientManager) {
@@ -289,12 +541,20 @@ func waitForTerminationWithClientManager(clientManager *whatsapp.ClientManager)
 		}
 	}
 
+	// Stop background jobs before their dependencies go away
+	if jobScheduler != nil {
+		jobScheduler.Stop()
+		fmt.Println("Scheduler stopped")
+	}
+
 	// Disconnect all WhatsApp clients
 	if clientManager != nil {
 		clientManager.DisconnectAll()
 		fmt.Println("All WhatsApp clients disconnected")
 	}
 
+	plugins.Stop()
+
 	// Close database connection
 	if db != nil {
 		db.Close()
@@ -304,6 +564,115 @@ func waitForTerminationWithClientManager(clientManager *whatsapp.ClientManager)
 	fmt.Println("Shutdown complete")
 }
 
+// replayInboundEvents dry-runs recorded inbound events against the current code
+// and prints where the interpretation or outcome differs from the original run
+func replayInboundEvents(eventIDs, sender string, limit int) {
+	var events []repository.InboundEvent
+	var err error
+	if eventIDs != "" {
+		var ids []int
+		for _, raw := range strings.Split(eventIDs, ",") {
+			id, convErr := strconv.Atoi(strings.TrimSpace(raw))
+			if convErr != nil {
+				fmt.Fprintf(os.Stderr, "Invalid event ID %q\n", raw)
+				os.Exit(1)
+			}
+			ids = append(ids, id)
+		}
+		events, err = repository.GetInboundEventsByID(db, ids)
+	} else {
+		events, err = repository.GetRecentInboundEventsBySender(db, cleanPhoneNumber(sender), limit)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load inbound events: %v\n", err)
+		os.Exit(1)
+	}
+	if len(events) == 0 {
+		fmt.Println("No inbound events found")
+		return
+	}
+
+	fmt.Printf("Replaying %d event(s) in dry-run mode (no writes, no messages sent)\n\n", len(events))
+	handlers.PrintReplayResults(os.Stdout, handlers.ReplayEvents(db, events))
+}
+
+// clearWhatsAppSessions deletes the WhatsApp sessions of the comma-separated
+// senderList, or of every device when it is empty, and deactivates their
+// senders. It asks for confirmation on the terminal unless assumeYes, and
+// returns the process exit code.
+func clearWhatsAppSessions(senderList string, assumeYes bool) int {
+	var senderIDs []string
+	for _, raw := range strings.Split(senderList, ",") {
+		if senderID := cleanPhoneNumber(raw); senderID != "" {
+			senderIDs = append(senderIDs, senderID)
+		}
+	}
+
+	config.LoadEnv()
+	connectionString := database.BuildPostgresConnectionString()
+	sessionDB, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		return 1
+	}
+	defer sessionDB.Close()
+	if err := database.InitSendersTable(sessionDB); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize senders table: %v\n", err)
+		return 1
+	}
+	if err := database.InitDeviceKeysTable(sessionDB); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize device_keys table: %v\n", err)
+		return 1
+	}
+
+	sessionStore, err := whatsapp.OpenSessionStore(sessionDB, connectionString)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	ctx := context.Background()
+	sessions, missing, err := sessionStore.Sessions(ctx, senderIDs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list sessions: %v\n", err)
+		return 1
+	}
+	for _, senderID := range missing {
+		fmt.Fprintf(os.Stderr, "No WhatsApp session found for sender %s\n", senderID)
+	}
+	if len(sessions) == 0 {
+		fmt.Println("No WhatsApp sessions to clear")
+		if len(missing) > 0 {
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Printf("The following %d WhatsApp session(s) will be removed:\n", len(sessions))
+	for _, session := range sessions {
+		fmt.Printf("  - sender %s (%s) %s\n", session.SenderID, session.JID, session.PushName)
+	}
+	if !assumeYes {
+		fmt.Print("Their senders must be registered again to send. Type 'yes' to continue: ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(strings.ToLower(answer)) != "yes" {
+			fmt.Println("\nAborted, nothing was removed (use -yes to skip this prompt)")
+			return 1
+		}
+	}
+
+	cleared, err := sessionStore.ClearSessions(ctx, sessions)
+	fmt.Printf("\nRemoved %d WhatsApp session(s)\n", len(cleared.Sessions))
+	for _, session := range cleared.Sessions {
+		fmt.Printf("  - sender %s (%s)\n", session.SenderID, session.JID)
+	}
+	fmt.Printf("Deactivated %d sender(s): %s\n", len(cleared.DeactivatedSenders), strings.Join(cleared.DeactivatedSenders, ", "))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to clear sessions: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
 // addNewSenderWithQR adds a new WhatsApp phone number using QR code scanning
 func addNewSenderWithQR() {
 	setupAndAddSender(func(clientManager *whatsapp.ClientManager) error {
@@ -365,6 +734,10 @@ func setupAndAddSender(addFunc func(*whatsapp.ClientManager) error) {
 		fmt.Fprintf(os.Stderr, "Failed to initialize senders table: %v\n", err)
 		os.Exit(1)
 	}
+	if err := database.InitDeviceKeysTable(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize device_keys table: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Note: Whatsmeow tables are automatically created by sqlstore.New() in ClientManager
 
@@ -402,3 +775,27 @@ func cleanPhoneNumber(phone string) string {
 	}
 	return cleaned
 }
+
+// runSelftest runs the API contract suite against a running instance and
+// returns the process exit code
+func runSelftest(args []string) int {
+	fs := flag.NewFlagSet("selftest", flag.ExitOnError)
+	baseURL := fs.String("base-url", "http://localhost:8080", "Base URL of the running WhatsPoints instance")
+	username := fs.String("username", os.Getenv("API_USERNAME"), "API Basic Auth username (defaults to API_USERNAME)")
+	password := fs.String("password", os.Getenv("API_PASSWORD"), "API Basic Auth password (defaults to API_PASSWORD)")
+	timeout := fs.Duration("timeout", 15*time.Second, "Per-request timeout")
+	fs.Parse(args)
+
+	report := selftest.Run(selftest.Options{
+		BaseURL:  *baseURL,
+		Username: *username,
+		Password: *password,
+		Client:   &http.Client{Timeout: *timeout},
+	})
+	selftest.PrintReport(os.Stdout, report)
+
+	if report.Failed() > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+// Package mirror copies the messages senders send or receive to an archive
+// chat on WhatsApp, giving the owner an audit trail on their own phone. It is
+// inert unless MESSAGE_MIRROR_TARGETS is set.
+package mirror
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/wa-serv/config"
+)
+
+// anySender is the MESSAGE_MIRROR_TARGETS key of senders without their own
+// archive
+const anySender = "*"
+
+// Mirror finds the archive chat of a sender's messages. A nil Mirror or one
+// without targets mirrors nothing.
+type Mirror struct {
+	cfg config.MirrorConfig
+}
+
+// New creates a mirror from cfg
+func New(cfg config.MirrorConfig) *Mirror {
+	return &Mirror{cfg: cfg}
+}
+
+// Enabled reports whether any message may be mirrored
+func (m *Mirror) Enabled() bool {
+	return m != nil && len(m.cfg.Targets) > 0 && (m.cfg.Outbound || m.cfg.Inbound)
+}
+
+// Outbound returns the archive JID of messages senderID sends, or false when
+// they are not mirrored
+func (m *Mirror) Outbound(senderID string) (string, bool) {
+	if !m.Enabled() || !m.cfg.Outbound {
+		return "", false
+	}
+	return m.archive(senderID)
+}
+
+// Inbound returns the archive JID of messages senderID receives, or false when
+// they are not mirrored
+func (m *Mirror) Inbound(senderID string) (string, bool) {
+	if !m.Enabled() || !m.cfg.Inbound {
+		return "", false
+	}
+	return m.archive(senderID)
+}
+
+func (m *Mirror) archive(senderID string) (string, bool) {
+	archive, ok := m.cfg.Targets[senderID]
+	if !ok {
+		archive, ok = m.cfg.Targets[anySender]
+	}
+	if !ok {
+		return "", false
+	}
+	if !strings.Contains(archive, "@") {
+		archive += "@s.whatsapp.net"
+	}
+	return archive, true
+}
+
+// IsArchive reports whether chat is one of the archive chats. Messages in an
+// archive are never mirrored, so mirrors cannot loop.
+func (m *Mirror) IsArchive(chat string) bool {
+	if !m.Enabled() {
+		return false
+	}
+	for senderID := range m.cfg.Targets {
+		if archive, _ := m.archive(senderID); archive == chat {
+			return true
+		}
+	}
+	return false
+}
+
+// OutboundText is the archive copy of content sent to the chat to
+func OutboundText(to, content string) string {
+	return "📤 *Ke " + chatName(to) + "*\n" + content
+}
+
+// InboundText is the archive copy of content received from sender, whose
+// WhatsApp name is pushName, in chat
+func InboundText(chat, sender, pushName, content string) string {
+	from := chatName(sender)
+	if pushName != "" {
+		from += " (" + pushName + ")"
+	}
+	if chat != sender && strings.HasSuffix(chat, "@g.us") {
+		from += " di grup " + chatName(chat)
+	}
+	return "📥 *Dari " + from + "*\n" + content
+}
+
+// MediaText describes a media message of kind, such as [gambar], by its
+// caption
+func MediaText(kind, caption string) string {
+	if caption == "" {
+		return kind
+	}
+	return kind + " " + caption
+}
+
+// chatName shortens a user JID to its phone number
+func chatName(jid string) string {
+	user, ok := strings.CutSuffix(jid, "@s.whatsapp.net")
+	if !ok {
+		return jid
+	}
+	user, _, _ = strings.Cut(user, ":")
+	return user
+}
+
+// Default mirror, built once from env on first use
+var (
+	defaultOnce   sync.Once
+	defaultMirror *Mirror
+)
+
+// Default returns the environment-configured mirror
+func Default() *Mirror {
+	defaultOnce.Do(func() {
+		cfg := config.LoadMirrorConfig()
+		defaultMirror = New(cfg)
+		if defaultMirror.Enabled() {
+			log.Printf("Mirroring messages of %d sender target(s) to archive chats (outbound=%t, inbound=%t)",
+				len(cfg.Targets), cfg.Outbound, cfg.Inbound)
+		}
+	})
+	return defaultMirror
+}
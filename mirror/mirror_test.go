@@ -0,0 +1,53 @@
+package mirror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/config"
+)
+
+func TestMirror_DisabledMirrorsNothing(t *testing.T) {
+	var nilMirror *Mirror
+	assert.False(t, nilMirror.Enabled())
+	_, ok := nilMirror.Outbound("6281111111111")
+	assert.False(t, ok)
+
+	empty := New(config.MirrorConfig{Targets: map[string]string{}, Outbound: true})
+	assert.False(t, empty.Enabled())
+}
+
+func TestMirror_FindsArchives(t *testing.T) {
+	m := New(config.MirrorConfig{
+		Targets: map[string]string{
+			"6281111111111": "120363025246125486@g.us",
+			"*":             "6289999999999",
+		},
+		Outbound: true,
+	})
+
+	archive, ok := m.Outbound("6281111111111")
+	assert.True(t, ok)
+	assert.Equal(t, "120363025246125486@g.us", archive)
+
+	archive, ok = m.Outbound("6282222222222")
+	assert.True(t, ok)
+	assert.Equal(t, "6289999999999@s.whatsapp.net", archive, "other senders use the * archive")
+
+	_, ok = m.Inbound("6281111111111")
+	assert.False(t, ok, "inbound messages are not mirrored")
+
+	assert.True(t, m.IsArchive("120363025246125486@g.us"))
+	assert.True(t, m.IsArchive("6289999999999@s.whatsapp.net"))
+	assert.False(t, m.IsArchive("6282222222222@s.whatsapp.net"))
+}
+
+func TestMirror_Texts(t *testing.T) {
+	assert.Equal(t, "📤 *Ke 6281234567890*\nHalo", OutboundText("6281234567890@s.whatsapp.net", "Halo"))
+	assert.Equal(t, "📥 *Dari 6281234567890 (Budi)*\nCek poin",
+		InboundText("6281234567890@s.whatsapp.net", "6281234567890@s.whatsapp.net", "Budi", "Cek poin"))
+	assert.Equal(t, "📥 *Dari 6281234567890 di grup 120363025246125486@g.us*\nSiap",
+		InboundText("120363025246125486@g.us", "6281234567890@s.whatsapp.net", "", "Siap"))
+	assert.Equal(t, "[gambar] Nota", MediaText("[gambar]", "Nota"))
+	assert.Equal(t, "[gambar]", MediaText("[gambar]", ""))
+}
@@ -0,0 +1,167 @@
+// Package plugins lets a deployment add its own chat commands and automations
+// without forking the core handlers. A plugin registers itself from an init
+// function in package main (optionally behind a build tag), is started once at
+// startup and is offered every inbound message the core bot does not handle.
+// Automations that react to domain events subscribe to the eventbus in Init.
+//
+// Plugins are isolated from the core and from each other: a plugin whose Init
+// fails is disabled, and errors, panics and timeouts in Handle are logged and
+// recorded without affecting the core reply or other plugins.
+package plugins
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// HandleTimeout bounds a single Handle call
+const HandleTimeout = 5 * time.Second
+
+// Plugin is a custom inbound handler
+type Plugin interface {
+	// Name identifies the plugin in logs and in the inbound event log
+	Name() string
+	// Init is called once at startup. Returning an error disables the plugin.
+	Init(host Host) error
+	// Handle is offered an inbound message no core command matched. It reports
+	// whether it handled the message; the first plugin that does wins.
+	Handle(ctx context.Context, msg *Message) (bool, error)
+	// Close is called once at shutdown
+	Close() error
+}
+
+// Host gives plugins access to core services
+type Host struct {
+	DB *sql.DB
+}
+
+// Message is an inbound message offered to plugins
+type Message struct {
+	Event  *events.Message
+	Text   string // normalized: trimmed, lower-cased, emoji-safe
+	Sender string // sender JID
+	// Reply sends a text message back to the sender
+	Reply func(ctx context.Context, text string) error
+}
+
+type registry struct {
+	mu      sync.RWMutex
+	plugins []Plugin
+	active  []Plugin
+	timeout time.Duration // bound on each Handle call
+}
+
+var defaultRegistry = &registry{timeout: HandleTimeout}
+
+// Register adds a plugin. Call it from an init function; plugins are offered
+// messages in registration order.
+func Register(p Plugin) {
+	defaultRegistry.mu.Lock()
+	defer defaultRegistry.mu.Unlock()
+	defaultRegistry.plugins = append(defaultRegistry.plugins, p)
+}
+
+// Start initializes the registered plugins, disabling any whose Init fails
+func Start(host Host) {
+	defaultRegistry.start(host)
+}
+
+// Stop closes the started plugins
+func Stop() {
+	defaultRegistry.stop()
+}
+
+// Dispatch offers msg to the started plugins in order. It returns the name of
+// the plugin that handled it, or "" when none did, and that plugin's error.
+func Dispatch(ctx context.Context, msg *Message) (string, error) {
+	return defaultRegistry.dispatch(ctx, msg)
+}
+
+func (r *registry) start(host Host) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.active = r.active[:0]
+	for _, p := range r.plugins {
+		if err := safeCall(p.Name(), "init", func() error { return p.Init(host) }); err != nil {
+			log.Printf("Plugin %s disabled: %v", p.Name(), err)
+			continue
+		}
+		log.Printf("Plugin %s started", p.Name())
+		r.active = append(r.active, p)
+	}
+}
+
+func (r *registry) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range r.active {
+		if err := safeCall(p.Name(), "close", p.Close); err != nil {
+			log.Printf("Plugin %s failed to close: %v", p.Name(), err)
+		}
+	}
+	r.active = nil
+}
+
+func (r *registry) dispatch(ctx context.Context, msg *Message) (string, error) {
+	r.mu.RLock()
+	active := append([]Plugin(nil), r.active...)
+	r.mu.RUnlock()
+
+	for _, p := range active {
+		handled, err := handle(ctx, r.timeout, p, msg)
+		if handled {
+			return p.Name(), err
+		}
+		if err != nil {
+			log.Printf("Plugin %s: %v", p.Name(), err)
+		}
+	}
+	return "", nil
+}
+
+// handle runs one Handle call under timeout. A plugin that overruns is left to
+// finish in the background and the message is treated as not handled.
+func handle(ctx context.Context, timeout time.Duration, p Plugin, msg *Message) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		handled bool
+		err     error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var handled bool
+		err := safeCall(p.Name(), "handle", func() error {
+			var err error
+			handled, err = p.Handle(ctx, msg)
+			return err
+		})
+		done <- result{handled, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.handled, res.err
+	case <-ctx.Done():
+		return false, fmt.Errorf("handle timed out: %w", ctx.Err())
+	}
+}
+
+// safeCall runs fn, turning a panic into an error
+func safeCall(name, stage string, fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("plugin %s panicked in %s: %v", name, stage, r)
+		}
+	}()
+	return fn()
+}
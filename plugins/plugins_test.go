@@ -0,0 +1,113 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePlugin struct {
+	name    string
+	initErr error
+	handle  func(ctx context.Context, msg *Message) (bool, error)
+	closed  bool
+}
+
+func (p *fakePlugin) Name() string         { return p.name }
+func (p *fakePlugin) Init(host Host) error { return p.initErr }
+func (p *fakePlugin) Close() error         { p.closed = true; return nil }
+
+func (p *fakePlugin) Handle(ctx context.Context, msg *Message) (bool, error) {
+	if p.handle == nil {
+		return false, nil
+	}
+	return p.handle(ctx, msg)
+}
+
+func handles(keyword string) func(context.Context, *Message) (bool, error) {
+	return func(_ context.Context, msg *Message) (bool, error) {
+		return msg.Text == keyword, nil
+	}
+}
+
+func TestDispatch_FirstHandlingPluginWins(t *testing.T) {
+	r := &registry{timeout: HandleTimeout}
+	r.plugins = []Plugin{
+		&fakePlugin{name: "promo", handle: handles("promo")},
+		&fakePlugin{name: "catch-all", handle: handles("promo")},
+	}
+	r.start(Host{})
+
+	name, err := r.dispatch(context.Background(), &Message{Text: "promo"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "promo", name)
+}
+
+func TestDispatch_NoPluginHandles(t *testing.T) {
+	r := &registry{timeout: HandleTimeout}
+	r.plugins = []Plugin{&fakePlugin{name: "promo", handle: handles("promo")}}
+	r.start(Host{})
+
+	name, err := r.dispatch(context.Background(), &Message{Text: "halo"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, name)
+}
+
+func TestStart_DisablesPluginWhoseInitFails(t *testing.T) {
+	r := &registry{timeout: HandleTimeout}
+	r.plugins = []Plugin{&fakePlugin{name: "broken", initErr: errors.New("missing config"), handle: handles("promo")}}
+	r.start(Host{})
+
+	name, _ := r.dispatch(context.Background(), &Message{Text: "promo"})
+
+	assert.Empty(t, name)
+}
+
+func TestDispatch_IsolatesPanicsAndTimeouts(t *testing.T) {
+	r := &registry{timeout: 20 * time.Millisecond}
+	r.plugins = []Plugin{
+		&fakePlugin{name: "panics", handle: func(context.Context, *Message) (bool, error) { panic("boom") }},
+		&fakePlugin{name: "slow", handle: func(ctx context.Context, _ *Message) (bool, error) {
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			return true, nil
+		}},
+		&fakePlugin{name: "promo", handle: handles("promo")},
+	}
+	r.start(Host{})
+
+	name, err := r.dispatch(context.Background(), &Message{Text: "promo"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "promo", name)
+}
+
+func TestDispatch_ReturnsHandlerError(t *testing.T) {
+	r := &registry{timeout: HandleTimeout}
+	r.plugins = []Plugin{&fakePlugin{name: "promo", handle: func(context.Context, *Message) (bool, error) {
+		return true, errors.New("voucher service down")
+	}}}
+	r.start(Host{})
+
+	name, err := r.dispatch(context.Background(), &Message{Text: "promo"})
+
+	assert.Equal(t, "promo", name)
+	assert.EqualError(t, err, "voucher service down")
+}
+
+func TestStop_ClosesStartedPlugins(t *testing.T) {
+	started := &fakePlugin{name: "started"}
+	disabled := &fakePlugin{name: "disabled", initErr: errors.New("no")}
+	r := &registry{plugins: []Plugin{started, disabled}, timeout: HandleTimeout}
+	r.start(Host{})
+
+	r.stop()
+
+	assert.True(t, started.closed)
+	assert.False(t, disabled.closed)
+}
@@ -0,0 +1,147 @@
+// Package pricing estimates what a laundry order costs and the points it earns.
+// It is pure: callers load the catalog and earning rules and pass them in.
+package pricing
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidQuantity = errors.New("quantity must be a positive number")
+	ErrWholeUnits      = errors.New("items priced per piece need a whole number of pieces")
+)
+
+// Item is a priced service from the items catalog
+type Item struct {
+	Name         string
+	PricePerKilo float64 // zero for services priced per piece
+	PricePerUnit float64
+}
+
+// ByKilo reports whether the item is sold by weight rather than per piece
+func (i Item) ByKilo() bool {
+	return i.PricePerKilo > 0
+}
+
+// Rules are the earning rules applied to an estimate
+type Rules struct {
+	RupiahPerPoint int     // spend that earns one point; 0 earns nothing
+	Multiplier     float64 // running campaign multiplier; 0 means none
+}
+
+// Quote is the estimated price and points of an order of one item
+type Quote struct {
+	Item       Item
+	Kilos      float64 // set for items sold by weight, rounded to 0.1 kg
+	Units      int     // set for items sold per piece
+	Price      float64 // whole rupiah
+	BasePoints int     // points before any campaign multiplier
+	Points     int
+}
+
+// Estimate prices quantity of item, in kilos or pieces depending on how the
+// item is sold, and the points it earns under rules
+func Estimate(item Item, quantity float64, rules Rules) (Quote, error) {
+	if quantity <= 0 || math.IsNaN(quantity) || math.IsInf(quantity, 0) {
+		return Quote{}, ErrInvalidQuantity
+	}
+
+	q := Quote{Item: item}
+	if item.ByKilo() {
+		q.Kilos = math.Round(quantity*10) / 10
+		if q.Kilos == 0 {
+			return Quote{}, ErrInvalidQuantity
+		}
+		q.Price = math.Round(item.PricePerKilo * q.Kilos)
+	} else {
+		if quantity != math.Trunc(quantity) {
+			return Quote{}, ErrWholeUnits
+		}
+		q.Units = int(quantity)
+		q.Price = math.Round(item.PricePerUnit * quantity)
+	}
+
+	if rules.RupiahPerPoint > 0 {
+		q.BasePoints = int(q.Price) / rules.RupiahPerPoint
+	}
+	q.Points = q.BasePoints
+	if rules.Multiplier > 0 {
+		q.Points = int(math.Round(float64(q.BasePoints) * rules.Multiplier))
+	}
+	return q, nil
+}
+
+// ParseQuantity parses an amount with an optional unit, such as "5", "3,5",
+// "3.5 kg", "5kg" or "2 pcs"
+func ParseQuantity(text string) (float64, bool) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	for _, unit := range []string{"kg", "pcs", "pc"} {
+		if strings.HasSuffix(text, unit) {
+			text = strings.TrimSpace(strings.TrimSuffix(text, unit))
+			break
+		}
+	}
+	q, err := strconv.ParseFloat(strings.Replace(text, ",", ".", 1), 64)
+	if err != nil || math.IsNaN(q) || math.IsInf(q, 0) || q < 0 {
+		return 0, false
+	}
+	return q, true
+}
+
+// MatchItems returns the items name refers to: the item with exactly that name,
+// or else every item whose name contains it. Matching ignores case.
+func MatchItems(items []Item, name string) []Item {
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if name == "" {
+		return nil
+	}
+
+	var partial []Item
+	for _, item := range items {
+		itemName := strings.ToLower(item.Name)
+		if itemName == name {
+			return []Item{item}
+		}
+		if strings.Contains(itemName, name) {
+			partial = append(partial, item)
+		}
+	}
+	return partial
+}
+
+// FormatRupiah renders an amount in whole rupiah with dots between thousands,
+// e.g. Rp24.500
+func FormatRupiah(amount float64) string {
+	digits := strconv.FormatInt(int64(math.Round(amount)), 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	return sign + "Rp" + b.String()
+}
+
+// FormatPrice renders an item's price, e.g. Rp7.000/kg or Rp15.000/pcs
+func FormatPrice(item Item) string {
+	if item.ByKilo() {
+		return FormatRupiah(item.PricePerKilo) + "/kg"
+	}
+	return FormatRupiah(item.PricePerUnit) + "/pcs"
+}
+
+// FormatQuantity renders the quantity of a quote, e.g. 3,5 kg or 2 pcs
+func FormatQuantity(q Quote) string {
+	if q.Item.ByKilo() {
+		return strings.Replace(strconv.FormatFloat(q.Kilos, 'f', -1, 64), ".", ",", 1) + " kg"
+	}
+	return strconv.Itoa(q.Units) + " pcs"
+}
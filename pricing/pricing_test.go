@@ -0,0 +1,105 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	cuciKering  = Item{Name: "Cuci Kering", PricePerKilo: 7000}
+	cuciSetrika = Item{Name: "Cuci Setrika", PricePerKilo: 9500}
+	bedCover    = Item{Name: "Bed Cover", PricePerUnit: 35000}
+)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     Item
+		quantity float64
+		rules    Rules
+		want     Quote
+	}{
+		{"by kilo", cuciKering, 5, Rules{RupiahPerPoint: 1000},
+			Quote{Item: cuciKering, Kilos: 5, Price: 35000, BasePoints: 35, Points: 35}},
+		{"kilos rounded to 0.1", cuciSetrika, 3.46, Rules{RupiahPerPoint: 1000},
+			Quote{Item: cuciSetrika, Kilos: 3.5, Price: 33250, BasePoints: 33, Points: 33}},
+		{"per piece", bedCover, 2, Rules{RupiahPerPoint: 1000},
+			Quote{Item: bedCover, Units: 2, Price: 70000, BasePoints: 70, Points: 70}},
+		{"campaign multiplier", cuciKering, 5, Rules{RupiahPerPoint: 1000, Multiplier: 1.5},
+			Quote{Item: cuciKering, Kilos: 5, Price: 35000, BasePoints: 35, Points: 53}},
+		{"no earning rule", cuciKering, 5, Rules{},
+			Quote{Item: cuciKering, Kilos: 5, Price: 35000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Estimate(tt.item, tt.quantity, tt.rules)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEstimate_Errors(t *testing.T) {
+	_, err := Estimate(cuciKering, 0, Rules{})
+	assert.Equal(t, ErrInvalidQuantity, err)
+
+	_, err = Estimate(cuciKering, 0.01, Rules{})
+	assert.Equal(t, ErrInvalidQuantity, err)
+
+	_, err = Estimate(bedCover, 1.5, Rules{})
+	assert.Equal(t, ErrWholeUnits, err)
+}
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		text string
+		want float64
+		ok   bool
+	}{
+		{"5", 5, true},
+		{"5kg", 5, true},
+		{"3,5 kg", 3.5, true},
+		{"3.5KG", 3.5, true},
+		{"2 pcs", 2, true},
+		{"kg", 0, false},
+		{"lima", 0, false},
+		{"-2", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseQuantity(tt.text)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchItems(t *testing.T) {
+	items := []Item{cuciKering, cuciSetrika, bedCover}
+
+	assert.Equal(t, []Item{cuciKering}, MatchItems(items, "cuci kering"))
+	assert.Equal(t, []Item{bedCover}, MatchItems(items, "BED  cover"))
+	assert.Equal(t, []Item{cuciKering, cuciSetrika}, MatchItems(items, "cuci"))
+	assert.Empty(t, MatchItems(items, "karpet"))
+	assert.Empty(t, MatchItems(items, " "))
+}
+
+func TestFormatting(t *testing.T) {
+	assert.Equal(t, "Rp0", FormatRupiah(0))
+	assert.Equal(t, "Rp950", FormatRupiah(950))
+	assert.Equal(t, "Rp24.500", FormatRupiah(24500))
+	assert.Equal(t, "Rp1.250.000", FormatRupiah(1250000))
+	assert.Equal(t, "-Rp5.000", FormatRupiah(-5000))
+
+	assert.Equal(t, "Rp7.000/kg", FormatPrice(cuciKering))
+	assert.Equal(t, "Rp35.000/pcs", FormatPrice(bedCover))
+
+	assert.Equal(t, "3,5 kg", FormatQuantity(Quote{Item: cuciSetrika, Kilos: 3.5}))
+	assert.Equal(t, "2 pcs", FormatQuantity(Quote{Item: bedCover, Units: 2}))
+}
@@ -0,0 +1,66 @@
+package processor
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/wa-serv/command"
+	"github.com/wa-serv/repository"
+)
+
+var ErrBranchNotFound = repository.ErrBranchNotFound
+
+// BranchMapsURL returns the branch's Google Maps link, or a search link for its
+// coordinates when it has none
+func BranchMapsURL(b repository.Branch) string {
+	if b.MapsURL != "" {
+		return b.MapsURL
+	}
+	return fmt.Sprintf("https://www.google.com/maps/search/?api=1&query=%.6f,%.6f", b.Latitude, b.Longitude)
+}
+
+// FormatBranchDirectory lists all branches as a chat message
+func FormatBranchDirectory(db *sql.DB) (string, error) {
+	branches, err := repository.GetBranches(db)
+	if err != nil {
+		return "", err
+	}
+	if len(branches) == 0 {
+		return "Maaf, daftar cabang belum tersedia.", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("🏪 *Cabang Kami*\n")
+	for _, branch := range branches {
+		fmt.Fprintf(&b, "\n%d. *%s*\n📍 %s\n", branch.ID, branch.Name, branch.Address)
+		if branch.Hours != "" {
+			fmt.Fprintf(&b, "🕘 %s\n", branch.Hours)
+		}
+	}
+	fmt.Fprintf(&b, "\nBalas dengan CABANG#<nomor> untuk lokasi dan kontak cabang. Contoh: CABANG#%d", branches[0].ID)
+	return b.String(), nil
+}
+
+// FormatBranch renders one branch with its hours, Maps link and WhatsApp number
+func FormatBranch(branch *repository.Branch) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🏪 *%s*\n\n📍 %s\n", branch.Name, branch.Address)
+	if branch.Hours != "" {
+		fmt.Fprintf(&b, "🕘 %s\n", branch.Hours)
+	}
+	if branch.WhatsAppNumber != "" {
+		fmt.Fprintf(&b, "📱 wa.me/%s\n", branch.WhatsAppNumber)
+	}
+	fmt.Fprintf(&b, "🗺 %s", BranchMapsURL(*branch))
+	return b.String()
+}
+
+// ProcessBranchDetails looks up the branch named in a CABANG#<nomor> command
+func ProcessBranchDetails(db *sql.DB, input string) (*repository.Branch, error) {
+	args, err := command.BranchDetails.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return repository.GetBranch(db, args.Int("branch_id"))
+}
@@ -0,0 +1,59 @@
+package processor
+
+import (
+	"database/sql"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/repository"
+)
+
+// ActiveCampaign returns the running points campaign with the highest
+// multiplier that applies to item, or nil when none does. Campaigns do not
+// stack; overlapping ones are resolved in favour of the member.
+func ActiveCampaign(db *sql.DB, item string, at time.Time) (*repository.Campaign, error) {
+	campaigns, err := repository.GetActiveCampaigns(db, at)
+	if err != nil {
+		return nil, err
+	}
+	return bestCampaign(campaigns, item), nil
+}
+
+func bestCampaign(campaigns []repository.Campaign, item string) *repository.Campaign {
+	item = strings.ToLower(strings.TrimSpace(item))
+
+	var best *repository.Campaign
+	for i := range campaigns {
+		c := &campaigns[i]
+		if !campaignAppliesTo(c, item) {
+			continue
+		}
+		if best == nil || c.Multiplier > best.Multiplier {
+			best = c
+		}
+	}
+	return best
+}
+
+// campaignAppliesTo reports whether c covers item. A campaign without items
+// covers everything; one with items needs the admin to name a matching item.
+func campaignAppliesTo(c *repository.Campaign, item string) bool {
+	if len(c.Items) == 0 {
+		return true
+	}
+	for _, i := range c.Items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMultiplier returns points scaled by the campaign multiplier, rounded
+func applyMultiplier(points int, c *repository.Campaign) int {
+	if c == nil {
+		return points
+	}
+	return int(math.Round(float64(points) * c.Multiplier))
+}
@@ -0,0 +1,83 @@
+package processor
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wa-serv/repository"
+)
+
+// Status codes drivers reply with, in the form CODE#<order_id>
+const (
+	DriverCodePickedUp  = "jemput"
+	DriverCodeDelivered = "selesai"
+	DriverCodeFailed    = "gagal"
+)
+
+var driverCodeStatuses = map[string]string{
+	DriverCodePickedUp:  repository.OrderStatusPickedUp,
+	DriverCodeDelivered: repository.OrderStatusDelivered,
+	DriverCodeFailed:    repository.OrderStatusDeliveryFailed,
+}
+
+var (
+	ErrNotADriver         = errors.New("sender is not a registered driver")
+	ErrInvalidDriverReply = errors.New("invalid driver status reply")
+	ErrOrderNotAssigned   = errors.New("order is not assigned to this driver")
+)
+
+// IsDriverStatusCommand reports whether msgText looks like a driver status reply
+func IsDriverStatusCommand(msgText string) bool {
+	code, _, found := strings.Cut(msgText, "#")
+	if !found {
+		return false
+	}
+	_, ok := driverCodeStatuses[strings.ToLower(code)]
+	return ok
+}
+
+// ParseDriverStatusReply parses CODE#<order_id> into an order ID and order status
+func ParseDriverStatusReply(msgText string) (int, string, error) {
+	code, rawID, found := strings.Cut(strings.TrimSpace(msgText), "#")
+	if !found {
+		return 0, "", ErrInvalidDriverReply
+	}
+	status, ok := driverCodeStatuses[strings.ToLower(code)]
+	if !ok {
+		return 0, "", ErrInvalidDriverReply
+	}
+	orderID, err := strconv.Atoi(strings.TrimSpace(rawID))
+	if err != nil || orderID <= 0 {
+		return 0, "", ErrInvalidDriverReply
+	}
+	return orderID, status, nil
+}
+
+// ProcessDriverStatusReply updates an order from a driver's status reply. The
+// sender must be an active driver and the order must be assigned to them.
+func ProcessDriverStatusReply(db *sql.DB, senderJID, msgText string) (int, string, error) {
+	orderID, status, err := ParseDriverStatusReply(msgText)
+	if err != nil {
+		return 0, "", err
+	}
+
+	driver, err := repository.GetDriverByPhoneNumber(db, extractPhoneNumber(senderJID))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to look up driver: %w", err)
+	}
+	if driver == nil || !driver.IsActive {
+		return 0, "", ErrNotADriver
+	}
+
+	updated, err := repository.UpdateOrderStatusForDriver(db, orderID, driver.DriverID, status)
+	if err != nil {
+		return 0, "", err
+	}
+	if !updated {
+		return 0, "", ErrOrderNotAssigned
+	}
+	return orderID, status, nil
+}
@@ -0,0 +1,124 @@
+package processor
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wa-serv/command"
+	"github.com/wa-serv/repository"
+)
+
+// The DryRun* functions run the same parsing and validation as their Process*
+// counterparts against the current database, but never write or send anything.
+// They back the inbound event replay tool.
+
+// DryRunUpsertPoints reports whether an INPUT# command would succeed now
+func DryRunUpsertPoints(db *sql.DB, senderJID, input string) error {
+	phoneNumber, _, _, err := parseUpsertPointsCommand(extractPhoneNumber(senderJID), input)
+	if err != nil {
+		return err
+	}
+	if _, err := GetMemberIDByPhoneNumber(db, phoneNumber); err != nil {
+		return fmt.Errorf("failed to retrieve member ID: %w", err)
+	}
+	return nil
+}
+
+// DryRunRedeemPoints reports whether redeeming pointsToRedeem would succeed now
+func DryRunRedeemPoints(db *sql.DB, senderJID string, pointsToRedeem int) error {
+	if _, err := rewardForPoints(pointsToRedeem); err != nil {
+		return err
+	}
+	memberID, err := GetMemberIDByPhoneNumber(db, senderJID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve member ID: %w", err)
+	}
+	currentPoints, err := repository.GetCurrentPoints(db, memberID)
+	if err != nil {
+		return err
+	}
+	if currentPoints < pointsToRedeem {
+		return ErrInsufficientPoints
+	}
+	return nil
+}
+
+// DryRunRegistration reports whether a REG# command would register the sender,
+// or prompt an existing member to update their details, now
+func DryRunRegistration(db *sql.DB, senderJID, message string) error {
+	if _, err := command.Registration.Parse(message); err != nil {
+		return err
+	}
+	// A repeated REG# from a member asks to update their details, which is not an error
+	_, err := repository.IsMemberRegistered(db, extractPhoneNumber(senderJID))
+	return err
+}
+
+// DryRunDriverStatusReply reports whether a driver status reply would update its order now
+func DryRunDriverStatusReply(db *sql.DB, senderJID, msgText string) error {
+	if _, _, err := ParseDriverStatusReply(msgText); err != nil {
+		return err
+	}
+	driver, err := repository.GetDriverByPhoneNumber(db, extractPhoneNumber(senderJID))
+	if err != nil {
+		return fmt.Errorf("failed to look up driver: %w", err)
+	}
+	if driver == nil || !driver.IsActive {
+		return ErrNotADriver
+	}
+	return nil
+}
+
+// DryRunPickupBooking reports whether a JADWAL# command would be accepted now,
+// short of the slot capacity check which only the booking transaction can make
+func DryRunPickupBooking(db *sql.DB, senderJID, msgText string) error {
+	parts := strings.Split(msgText, "#")
+	if len(parts) != 2 {
+		return ErrInvalidPickupCommand
+	}
+	if slotID, err := strconv.Atoi(strings.TrimSpace(parts[1])); err != nil || slotID <= 0 {
+		return ErrInvalidPickupCommand
+	}
+	registered, err := repository.IsMemberRegistered(db, extractPhoneNumber(senderJID))
+	if err != nil {
+		return fmt.Errorf("failed to check registration: %w", err)
+	}
+	if !registered {
+		return ErrMemberNotRegistered
+	}
+	return nil
+}
+
+// DryRunAddStamps reports whether a STEMPEL# command would succeed now
+func DryRunAddStamps(db *sql.DB, senderJID, input string) error {
+	args, _, err := parseStampCommand(db, command.AddStamps, senderJID, input)
+	if err != nil {
+		return err
+	}
+	if _, err := GetMemberIDByPhoneNumber(db, args["phone_number"]); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DryRunClaimStampReward reports whether a KLAIM# command would succeed now
+func DryRunClaimStampReward(db *sql.DB, senderJID, input string) error {
+	args, _, err := parseStampCommand(db, command.ClaimStampReward, senderJID, input)
+	if err != nil {
+		return err
+	}
+	memberID, err := GetMemberIDByPhoneNumber(db, args["phone_number"])
+	if err != nil {
+		return err
+	}
+	card, err := repository.GetStampCard(db, memberID)
+	if err != nil {
+		return err
+	}
+	if card.RewardsAvailable == 0 {
+		return ErrNoStampReward
+	}
+	return nil
+}
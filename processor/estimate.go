@@ -0,0 +1,134 @@
+package processor
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/pricing"
+	"github.com/wa-serv/repository"
+)
+
+var ErrInvalidEstimateCommand = errors.New("invalid price estimate format, use HITUNG <layanan> <berat/jumlah>")
+
+// priceEstimateKeyword starts a price estimate, e.g. "hitung cuci 5kg"
+const priceEstimateKeyword = "hitung"
+
+// IsPriceEstimateCommand reports whether msgText asks for a price estimate.
+// msgText must be lower-cased and trimmed.
+func IsPriceEstimateCommand(msgText string) bool {
+	return msgText == priceEstimateKeyword || strings.HasPrefix(msgText, priceEstimateKeyword+" ")
+}
+
+// ParsePriceEstimate splits "hitung <service> <quantity>" into the service name
+// and the quantity. The unit may be attached ("5kg") or separate ("5 kg").
+func ParsePriceEstimate(msgText string) (string, float64, error) {
+	fields := strings.Fields(strings.TrimPrefix(msgText, priceEstimateKeyword))
+	if len(fields) >= 2 {
+		if last := fields[len(fields)-1]; last == "kg" || last == "pcs" || last == "pc" {
+			fields = append(fields[:len(fields)-2], fields[len(fields)-2]+last)
+		}
+	}
+	if len(fields) < 2 {
+		return "", 0, ErrInvalidEstimateCommand
+	}
+
+	quantity, ok := pricing.ParseQuantity(fields[len(fields)-1])
+	if !ok || quantity <= 0 {
+		return "", 0, ErrInvalidEstimateCommand
+	}
+	return strings.Join(fields[:len(fields)-1], " "), quantity, nil
+}
+
+// ProcessPriceEstimate answers a HITUNG command with the estimated price of the
+// service and the points it would earn. A service that matches no catalog item,
+// or several, is answered with the services to choose from.
+func ProcessPriceEstimate(db *sql.DB, msgText string) (string, error) {
+	service, quantity, err := ParsePriceEstimate(msgText)
+	if err != nil {
+		return "", err
+	}
+
+	catalog, err := repository.GetCatalogItems(db)
+	if err != nil {
+		return "", err
+	}
+	if len(catalog) == 0 {
+		return "", ErrCatalogEmpty
+	}
+	items := make([]pricing.Item, 0, len(catalog))
+	for _, item := range catalog {
+		items = append(items, PricingItem(item))
+	}
+
+	matches := pricing.MatchItems(items, service)
+	switch len(matches) {
+	case 0:
+		return "Layanan \"" + service + "\" tidak ditemukan.\n\n" + formatServiceChoices(items), nil
+	case 1:
+	default:
+		return "Layanan mana yang Anda maksud?\n\n" + formatServiceChoices(matches), nil
+	}
+	item := matches[0]
+
+	rules := pricing.Rules{RupiahPerPoint: config.LoadLoyaltyConfig().RupiahPerPoint}
+	campaign, err := ActiveCampaign(db, item.Name, time.Now())
+	if err != nil {
+		// The estimate is still useful without the campaign bonus
+		fmt.Printf("Points campaigns unavailable, estimating base points: %v\n", err)
+	}
+	if campaign != nil {
+		rules.Multiplier = campaign.Multiplier
+	}
+
+	quote, err := pricing.Estimate(item, quantity, rules)
+	if err == pricing.ErrWholeUnits {
+		return fmt.Sprintf("%s dihitung per pcs. Contoh: HITUNG %s 2", item.Name, strings.ToLower(item.Name)), nil
+	}
+	if err != nil {
+		return "", ErrInvalidEstimateCommand
+	}
+
+	var b strings.Builder
+	b.WriteString("🧮 *Estimasi Harga*\n\n")
+	fmt.Fprintf(&b, "Layanan: %s (%s)\n", item.Name, pricing.FormatPrice(item))
+	fmt.Fprintf(&b, "Jumlah: %s\n", pricing.FormatQuantity(quote))
+	fmt.Fprintf(&b, "Estimasi harga: *%s*\n", pricing.FormatRupiah(quote.Price))
+	if pointsProgramRuns(db) && quote.Points > 0 {
+		if campaign != nil && quote.Points != quote.BasePoints {
+			fmt.Fprintf(&b, "Poin didapat: %d (%s x%g)\n", quote.Points, campaign.Name, campaign.Multiplier)
+		} else {
+			fmt.Fprintf(&b, "Poin didapat: %d\n", quote.Points)
+		}
+	}
+	if item.ByKilo() {
+		b.WriteString("\nHarga akhir mengikuti hasil timbang di outlet.")
+	}
+	b.WriteString("\nKetik *pesan* untuk memesan.")
+	return b.String(), nil
+}
+
+// formatServiceChoices lists services with their prices
+func formatServiceChoices(items []pricing.Item) string {
+	var b strings.Builder
+	b.WriteString("Layanan yang tersedia:\n")
+	for _, item := range items {
+		fmt.Fprintf(&b, "• %s — %s\n", item.Name, pricing.FormatPrice(item))
+	}
+	b.WriteString("\nContoh: HITUNG " + strings.ToLower(items[0].Name) + " 5kg")
+	return b.String()
+}
+
+// pointsProgramRuns reports whether the tenant runs the points program. It
+// fails open like the points commands, since that program predates the choice.
+func pointsProgramRuns(db *sql.DB) bool {
+	program, err := LoyaltyProgram(db)
+	if err != nil {
+		fmt.Printf("Failed to load loyalty program: %v\n", err)
+		return true
+	}
+	return program.PointsEnabled()
+}
@@ -0,0 +1,45 @@
+package processor
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wa-serv/repository"
+)
+
+var (
+	ErrInvalidLocation     = errors.New("invalid location coordinates")
+	ErrMemberNotRegistered = errors.New("member is not registered")
+)
+
+// ProcessLocation stores a shared location pin against the member profile and
+// their most recent order. It returns the order ID that was updated (0 if none).
+func ProcessLocation(db *sql.DB, senderJID string, latitude, longitude float64, label, address string) (int, error) {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return 0, ErrInvalidLocation
+	}
+
+	registered, err := repository.IsMemberRegistered(db, extractPhoneNumber(senderJID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to check registration: %w", err)
+	}
+	if !registered {
+		return 0, ErrMemberNotRegistered
+	}
+
+	memberID, err := GetMemberIDByPhoneNumber(db, senderJID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := repository.UpsertMemberLocation(db, memberID, latitude, longitude, label, address); err != nil {
+		return 0, err
+	}
+
+	orderID, err := repository.UpdateLatestOrderPickupLocation(db, memberID, latitude, longitude)
+	if err != nil {
+		return 0, fmt.Errorf("location saved but order update failed: %w", err)
+	}
+	return orderID, nil
+}
@@ -0,0 +1,70 @@
+package processor
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+)
+
+// DefaultOnboardingNudge is sent to unregistered contacts when the bot's
+// tenant has no onboarding_nudge template of its own
+const DefaultOnboardingNudge = `Halo {{name}}! 👋
+
+Terima kasih sudah menghubungi kami. Dengan menjadi member program poin, setiap transaksi Anda mendapatkan poin yang bisa ditukar dengan cuci gratis dan hadiah menarik 🎁
+
+Cara daftar (gratis):
+Kirim pesan dengan format *REG#Nama#Alamat*
+Contoh: REG#Budi#Jl. Merdeka No. 1
+
+Setelah terdaftar, ketik *menu* untuk melihat poin dan hadiah.`
+
+// SendOnboardingNudge explains the loyalty program and registration steps to an
+// unregistered contact, at most once per nudge interval. It returns true when
+// a nudge was sent. The contact must already be tracked as a prospect.
+func SendOnboardingNudge(db *sql.DB, client *whatsmeow.Client, senderJID string, cfg config.OnboardingConfig) (bool, error) {
+	if !cfg.Enabled {
+		return false, nil
+	}
+
+	message := DefaultOnboardingNudge
+	intervalDays := cfg.IntervalDays
+	tenantName := ""
+	if cfg.TenantSlug != "" {
+		settings, err := repository.GetOnboardingNudgeSettings(db, cfg.TenantSlug)
+		if err != nil {
+			return false, err
+		}
+		if settings == nil {
+			fmt.Printf("Onboarding tenant %q not found, using the default nudge\n", cfg.TenantSlug)
+		} else {
+			if !settings.Enabled {
+				return false, nil
+			}
+			if settings.IntervalDays > 0 {
+				intervalDays = settings.IntervalDays
+			}
+			if settings.Message != "" {
+				message = settings.Message
+			}
+			tenantName = settings.TenantName
+		}
+	}
+
+	phoneNumber := extractPhoneNumber(senderJID)
+	claimed, err := repository.ClaimProspectNudge(db, phoneNumber, intervalDays)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	vars, err := ContactTemplateVars(db, phoneNumber)
+	if err != nil {
+		vars = map[string]string{"phone": phoneNumber}
+	}
+	vars["name"] = firstNonEmpty(vars["name"], "Kak")
+	vars["tenant"] = tenantName
+	sendResponse(client, senderJID, RenderTemplate(message, vars))
+	return true, nil
+}
@@ -0,0 +1,288 @@
+package processor
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wa-serv/pricing"
+	"github.com/wa-serv/repository"
+)
+
+var ErrCatalogEmpty = errors.New("no laundry services in the items catalog")
+
+// Limits on the estimate a member can give, so a typo such as 350 kg for 3,5 kg
+// never reaches staff as a draft
+const (
+	maxOrderKilos = 50
+	maxOrderUnits = 50
+)
+
+// Keywords of the order flow. Members start it with "pesan" and leave it with
+// "batal"; any number they send in between answers the current question.
+const (
+	orderStartKeyword  = "pesan"
+	orderCancelKeyword = "batal"
+)
+
+// orderStep is the question the order flow is waiting on
+type orderStep int
+
+const (
+	orderStepService orderStep = iota
+	orderStepQuantity
+	orderStepPickup
+)
+
+// orderSession is a member's order in progress
+type orderSession struct {
+	Step      orderStep
+	Catalog   []repository.CatalogItem // services listed when the flow started
+	Item      repository.CatalogItem
+	Quote     pricing.Quote // set once the quantity is known
+	ExpiresAt time.Time
+}
+
+// Orders in progress are kept in memory like registration edits: a restart or
+// 15 quiet minutes just means the member starts again with "pesan".
+var (
+	orderSessionsMu sync.Mutex
+	orderSessions   = make(map[string]orderSession)
+	orderSessionTTL = 15 * time.Minute
+)
+
+// setOrderSession stores the session for phone, refreshing its expiry, and
+// drops expired entries
+func setOrderSession(phone string, session orderSession) {
+	orderSessionsMu.Lock()
+	defer orderSessionsMu.Unlock()
+	now := time.Now()
+	for k, s := range orderSessions {
+		if now.After(s.ExpiresAt) {
+			delete(orderSessions, k)
+		}
+	}
+	session.ExpiresAt = now.Add(orderSessionTTL)
+	orderSessions[phone] = session
+}
+
+// getOrderSession returns the unexpired session for phone
+func getOrderSession(phone string) (orderSession, bool) {
+	orderSessionsMu.Lock()
+	defer orderSessionsMu.Unlock()
+	session, ok := orderSessions[phone]
+	if !ok || time.Now().After(session.ExpiresAt) {
+		return orderSession{}, false
+	}
+	return session, true
+}
+
+func clearOrderSession(phone string) {
+	orderSessionsMu.Lock()
+	defer orderSessionsMu.Unlock()
+	delete(orderSessions, phone)
+}
+
+// IsOrderStartCommand reports whether msgText starts the order flow
+func IsOrderStartCommand(msgText string) bool {
+	return msgText == orderStartKeyword
+}
+
+// IsOrderFlowReply reports whether msgText answers the sender's order in
+// progress: a number, or "batal". msgText must be lower-cased and trimmed.
+func IsOrderFlowReply(senderJID, msgText string) bool {
+	if _, ok := getOrderSession(extractPhoneNumber(senderJID)); !ok {
+		return false
+	}
+	if msgText == orderCancelKeyword {
+		return true
+	}
+	_, ok := pricing.ParseQuantity(msgText)
+	return ok
+}
+
+// StartOrder begins the order flow for a registered member and returns the
+// service catalog to choose from
+func StartOrder(db *sql.DB, senderJID string) (string, error) {
+	phone := extractPhoneNumber(senderJID)
+	registered, err := repository.IsMemberRegistered(db, phone)
+	if err != nil {
+		return "", fmt.Errorf("failed to check registration: %w", err)
+	}
+	if !registered {
+		return "", ErrMemberNotRegistered
+	}
+
+	catalog, err := repository.GetCatalogItems(db)
+	if err != nil {
+		return "", err
+	}
+	if len(catalog) == 0 {
+		return "", ErrCatalogEmpty
+	}
+
+	setOrderSession(phone, orderSession{Step: orderStepService, Catalog: catalog})
+
+	var b strings.Builder
+	b.WriteString("🧺 *Pesan Laundry*\n\nPilih layanan dengan membalas nomornya:\n")
+	for i, item := range catalog {
+		fmt.Fprintf(&b, "%d. %s — %s\n", i+1, item.Name, pricing.FormatPrice(PricingItem(item)))
+	}
+	b.WriteString("\nKetik BATAL untuk membatalkan.")
+	return b.String(), nil
+}
+
+// ProcessOrderReply applies the sender's answer to the current question of
+// their order and returns the next question, or the draft order summary once
+// the order is placed. Invalid answers repeat the question.
+func ProcessOrderReply(db *sql.DB, senderJID, msgText string) (string, error) {
+	phone := extractPhoneNumber(senderJID)
+	session, ok := getOrderSession(phone)
+	if !ok {
+		return "Sesi pemesanan sudah berakhir. Ketik *pesan* untuk memulai lagi.", nil
+	}
+	if msgText == orderCancelKeyword {
+		clearOrderSession(phone)
+		return "Pemesanan dibatalkan. Ketik *pesan* untuk memesan lagi.", nil
+	}
+
+	switch session.Step {
+	case orderStepService:
+		n, ok := parseOrderChoice(msgText)
+		if !ok || n < 1 || n > len(session.Catalog) {
+			return fmt.Sprintf("Pilihan tidak valid. Balas dengan nomor layanan 1-%d.", len(session.Catalog)), nil
+		}
+		session.Item = session.Catalog[n-1]
+		session.Step = orderStepQuantity
+		setOrderSession(phone, session)
+		return quantityQuestion(session.Item), nil
+
+	case orderStepQuantity:
+		quantity, _ := pricing.ParseQuantity(msgText)
+		quote, err := pricing.Estimate(PricingItem(session.Item), quantity, pricing.Rules{})
+		if session.Item.PricedByKilo() && (err != nil || quantity > maxOrderKilos) {
+			return fmt.Sprintf("Berat tidak valid. Balas dengan perkiraan berat 0,5-%d kg.", maxOrderKilos), nil
+		}
+		if !session.Item.PricedByKilo() && (err != nil || quantity > maxOrderUnits) {
+			return fmt.Sprintf("Jumlah tidak valid. Balas dengan jumlah 1-%d pcs.", maxOrderUnits), nil
+		}
+		session.Quote = quote
+		session.Step = orderStepPickup
+		setOrderSession(phone, session)
+		return pickupQuestion(db, session)
+
+	default:
+		return placeDraftOrder(db, phone, session, msgText)
+	}
+}
+
+// placeDraftOrder books the chosen pickup slot (0 means the member drops the
+// laundry off) and creates the draft order
+func placeDraftOrder(db *sql.DB, phone string, session orderSession, msgText string) (string, error) {
+	slotID, ok := parseOrderChoice(msgText)
+	if !ok {
+		return "Pilihan tidak valid. Balas dengan nomor jadwal, atau 0 untuk antar sendiri.", nil
+	}
+
+	memberID, err := repository.GetMemberIDByPhoneNumber(db, phone)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve member ID: %w", err)
+	}
+
+	var slot *repository.PickupSlot
+	if slotID > 0 {
+		slot, err = repository.BookPickupSlot(db, slotID, memberID)
+		switch err {
+		case nil:
+		case repository.ErrSlotNotFound, repository.ErrSlotFull:
+			question, qerr := pickupQuestion(db, session)
+			if qerr != nil {
+				return "", qerr
+			}
+			return "Maaf, jadwal tersebut tidak tersedia.\n\n" + question, nil
+		case repository.ErrAlreadyBooked:
+			// The member booked this pickup with JADWAL# already; attach the order to it
+			slot = nil
+		default:
+			return "", err
+		}
+	}
+
+	quote := session.Quote
+	orderID, err := repository.CreateDraftOrder(db, memberID, slotID, repository.DraftOrderLine{
+		ItemID: session.Item.ItemID,
+		Kilos:  quote.Kilos,
+		Units:  quote.Units,
+		Price:  quote.Price,
+	})
+	if err != nil {
+		return "", err
+	}
+	clearOrderSession(phone)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "📝 *Pesanan #%d Diterima*\n\n", orderID)
+	fmt.Fprintf(&b, "Layanan: %s\n", session.Item.Name)
+	fmt.Fprintf(&b, "Jumlah: %s\n", formatOrderQuantity(quote))
+	fmt.Fprintf(&b, "Estimasi harga: %s\n", pricing.FormatRupiah(quote.Price))
+	switch {
+	case slot != nil:
+		fmt.Fprintf(&b, "Penjemputan: %s\n", FormatPickupWindow(slot.StartsAt, slot.EndsAt))
+	case slotID > 0:
+		b.WriteString("Penjemputan: sesuai jadwal yang sudah Anda pesan\n")
+	default:
+		b.WriteString("Pengantaran: Anda antar sendiri ke outlet\n")
+	}
+	b.WriteString("\nStaf kami akan mengonfirmasi pesanan Anda. Harga akhir mengikuti hasil timbang di outlet.")
+	return b.String(), nil
+}
+
+func quantityQuestion(item repository.CatalogItem) string {
+	price := pricing.FormatPrice(PricingItem(item))
+	if item.PricedByKilo() {
+		return fmt.Sprintf("*%s* (%s)\n\nBerapa perkiraan berat cucian Anda dalam kg? Contoh: 3,5", item.Name, price)
+	}
+	return fmt.Sprintf("*%s* (%s)\n\nBerapa jumlahnya (pcs)? Contoh: 2", item.Name, price)
+}
+
+// pickupQuestion shows the price estimate and the bookable pickup slots
+func pickupQuestion(db *sql.DB, session orderSession) (string, error) {
+	now := time.Now()
+	slots, err := repository.GetAvailablePickupSlots(db, now, now.Add(PickupBookingWindow))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Estimasi harga: *%s*\n\nPilih jadwal penjemputan dengan membalas nomornya:\n",
+		pricing.FormatRupiah(session.Quote.Price))
+	for _, s := range slots {
+		fmt.Fprintf(&b, "%d. %s (sisa %d)\n", s.SlotID, FormatPickupWindow(s.StartsAt, s.EndsAt), s.Capacity-s.BookedCount)
+	}
+	b.WriteString("0. Antar sendiri ke outlet")
+	return b.String(), nil
+}
+
+// PricingItem is the pricing view of a catalog item
+func PricingItem(item repository.CatalogItem) pricing.Item {
+	return pricing.Item{Name: item.Name, PricePerKilo: item.PricePerKilo, PricePerUnit: item.PricePerUnit}
+}
+
+// formatOrderQuantity renders the quantity of an order, marking weights as
+// estimates since laundry is weighed at the outlet
+func formatOrderQuantity(quote pricing.Quote) string {
+	if quote.Item.ByKilo() {
+		return pricing.FormatQuantity(quote) + " (perkiraan)"
+	}
+	return pricing.FormatQuantity(quote)
+}
+
+// parseOrderChoice parses a whole number picked from a list
+func parseOrderChoice(text string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(text))
+	return n, err == nil && n >= 0
+}
@@ -0,0 +1,103 @@
+package processor
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+)
+
+// PickupBookingWindow is how far ahead members can see and book pickup slots
+const PickupBookingWindow = 7 * 24 * time.Hour
+
+var (
+	ErrInvalidPickupCommand = errors.New("invalid pickup booking command")
+	ErrSlotNotFound         = repository.ErrSlotNotFound
+	ErrSlotFull             = repository.ErrSlotFull
+	ErrAlreadyBooked        = repository.ErrAlreadyBooked
+)
+
+// IsPickupBookingCommand reports whether msgText is a JADWAL#<slot_id> booking
+func IsPickupBookingCommand(msgText string) bool {
+	return len(msgText) > 7 && strings.EqualFold(msgText[:7], "jadwal#")
+}
+
+// FormatAvailablePickupSlots lists bookable slots for the coming week as a chat message
+func FormatAvailablePickupSlots(db *sql.DB) (string, error) {
+	now := time.Now()
+	slots, err := repository.GetAvailablePickupSlots(db, now, now.Add(PickupBookingWindow))
+	if err != nil {
+		return "", err
+	}
+	if len(slots) == 0 {
+		return "Maaf, belum ada jadwal penjemputan yang tersedia minggu ini.", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("🗓 *Jadwal Penjemputan Tersedia*\n\n")
+	for _, s := range slots {
+		fmt.Fprintf(&b, "%d. %s (sisa %d)\n", s.SlotID, FormatPickupWindow(s.StartsAt, s.EndsAt), s.Capacity-s.BookedCount)
+	}
+	b.WriteString("\nBalas dengan JADWAL#<nomor> untuk memesan. Contoh: JADWAL#")
+	b.WriteString(strconv.Itoa(slots[0].SlotID))
+	return b.String(), nil
+}
+
+// ProcessPickupBooking books the slot named in a JADWAL#<slot_id> command for the sender
+func ProcessPickupBooking(db *sql.DB, senderJID, msgText string) (*repository.PickupSlot, error) {
+	parts := strings.Split(msgText, "#")
+	if len(parts) != 2 {
+		return nil, ErrInvalidPickupCommand
+	}
+	slotID, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || slotID <= 0 {
+		return nil, ErrInvalidPickupCommand
+	}
+
+	registered, err := repository.IsMemberRegistered(db, extractPhoneNumber(senderJID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check registration: %w", err)
+	}
+	if !registered {
+		return nil, ErrMemberNotRegistered
+	}
+
+	memberID, err := GetMemberIDByPhoneNumber(db, senderJID)
+	if err != nil {
+		return nil, err
+	}
+	return repository.BookPickupSlot(db, slotID, memberID)
+}
+
+// SendPickupReminders messages members whose pickup starts within lead and
+// returns how many reminders were sent. Each booking is reminded at most once.
+func SendPickupReminders(db *sql.DB, client *whatsmeow.Client, lead time.Duration) (int, error) {
+	bookings, err := repository.GetBookingsDueForReminder(db, time.Now().Add(lead))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, b := range bookings {
+		text := fmt.Sprintf("⏰ Pengingat: penjemputan Anda dijadwalkan %s.\nMohon siapkan barang Anda. Terima kasih!",
+			FormatPickupWindow(b.StartsAt, b.EndsAt))
+		sendResponse(client, b.PhoneNumber+"@s.whatsapp.net", text)
+
+		if err := repository.MarkPickupReminderSent(db, b.BookingID); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// FormatPickupWindow renders a slot as "Sen 02/01 09:00-11:00"
+func FormatPickupWindow(start, end time.Time) string {
+	days := [...]string{"Min", "Sen", "Sel", "Rab", "Kam", "Jum", "Sab"}
+	return fmt.Sprintf("%s %s-%s", days[start.Weekday()], start.Format("02/01 15:04"), end.Format("15:04"))
+}
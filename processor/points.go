@@ -4,30 +4,32 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
+	"time"
 
+	"github.com/wa-serv/command"
 	"github.com/wa-serv/config"
+	"github.com/wa-serv/eventbus"
 	"github.com/wa-serv/repository"
 )
 
 // ProcessUpsertPoints handles the upsert points action
 func ProcessUpsertPoints(db *sql.DB, senderPhoneNumber, input string) error {
 	senderPhoneNumber = extractPhoneNumber(senderPhoneNumber)
-	// Check if the sender is allowed to perform this action
-	if !config.Env.AllowedPhoneNumbers[senderPhoneNumber] {
-		return errors.New("unauthorized action: phone number not allowed")
-	}
-
-	// Parse the input
-	parts := strings.Split(input, "#")
-	if len(parts) != 3 {
-		return errors.New("invalid input format: expected INPUT#phone_number#current_points")
+	phoneNumber, basePoints, item, err := parseUpsertPointsCommand(senderPhoneNumber, input)
+	if err != nil {
+		return err
 	}
 
-	phoneNumber := parts[1]
-	currentPoints, err := parsePoints(parts[2])
+	// A running campaign such as a double-points happy hour scales the credit.
+	// Failing to look campaigns up must not stop the admin crediting points.
+	campaign, err := ActiveCampaign(db, item, time.Now())
 	if err != nil {
-		return fmt.Errorf("invalid points value: %w", err)
+		fmt.Printf("Points campaigns unavailable, crediting base points: %v\n", err)
+	}
+	currentPoints := applyMultiplier(basePoints, campaign)
+	description := "Points updated via upsert"
+	if campaign != nil {
+		description = fmt.Sprintf("Points updated via upsert (%s x%g)", campaign.Name, campaign.Multiplier)
 	}
 
 	// Get the member ID by phone number
@@ -37,52 +39,93 @@ func ProcessUpsertPoints(db *sql.DB, senderPhoneNumber, input string) error {
 	}
 
 	// Upsert points for the member and track the transaction
-	err = upsertPointsWithTransaction(db, memberID, currentPoints)
+	previousAccumulated, err := upsertPointsWithTransaction(db, memberID, currentPoints, description, senderPhoneNumber)
 	if err != nil {
 		return fmt.Errorf("failed to upsert points: %w", err)
 	}
 
+	memberPhone := extractPhoneNumber(phoneNumber)
+	data := map[string]any{
+		"reason":       eventbus.ReasonEarned,
+		"member_id":    memberID,
+		"phone_number": memberPhone,
+		"points":       currentPoints,
+		"credited_by":  senderPhoneNumber,
+	}
+	if campaign != nil {
+		data["base_points"] = basePoints
+		data["campaign"] = campaign.Name
+		data["multiplier"] = campaign.Multiplier
+	}
+	eventbus.Publish(eventbus.PointsChanged, data)
+
+	oldTier := TierForPoints(previousAccumulated)
+	newTier := TierForPoints(previousAccumulated + currentPoints)
+	if oldTier != newTier {
+		eventbus.Publish(eventbus.TierChanged, map[string]any{
+			"member_id":          memberID,
+			"phone_number":       memberPhone,
+			"previous_tier":      oldTier,
+			"tier":               newTier,
+			"accumulated_points": previousAccumulated + currentPoints,
+		})
+	}
+
 	return nil
 }
 
-// parsePoints parses the points value from a string
-func parsePoints(pointsStr string) (int, error) {
-	var points int
-	_, err := fmt.Sscanf(pointsStr, "%d", &points)
+// parseUpsertPointsCommand checks the sender may credit points and parses
+// INPUT#phone_number#points[#item] into the member phone number, points and
+// the optional item
+func parseUpsertPointsCommand(senderPhoneNumber, input string) (string, int, string, error) {
+	// Check if the sender is allowed to perform this action
+	if !config.Env.AllowedPhoneNumbers[senderPhoneNumber] {
+		return "", 0, "", errors.New("unauthorized action: phone number not allowed")
+	}
+
+	args, err := command.UpsertPoints.Parse(input)
 	if err != nil {
-		return 0, err
+		return "", 0, "", err
 	}
-	return points, nil
+	return args["phone_number"], args.Int("points"), args["item"], nil
 }
 
-// upsertPointsWithTransaction performs an upsert operation for the points table and tracks the transaction
-func upsertPointsWithTransaction(db *sql.DB, memberID, currentPoints int) error {
-	tx, err := db.Begin()
+// upsertPointsWithTransaction performs an upsert operation for the points table and tracks the transaction.
+// The transaction is attributed to creditedBy, the admin phone that entered it. It returns the member's
+// accumulated points from before the upsert so callers can detect tier changes.
+func upsertPointsWithTransaction(db *sql.DB, memberID, currentPoints int, description, creditedBy string) (int, error) {
+	tx, err := repository.Begin(db)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	previousAccumulated, err := repository.GetAccumulatedPoints(tx, memberID)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
 	}
 
 	// Upsert points
 	err = repository.UpsertPoints(tx, memberID, currentPoints)
 	if err != nil {
 		tx.Rollback()
-		return err
+		return 0, err
 	}
 
 	// Track the transaction in point_transactions
-	err = repository.InsertPointTransaction(tx, memberID, currentPoints, "EARN", "Points updated via upsert")
+	err = repository.InsertPointTransaction(tx, memberID, currentPoints, "EARN", description, creditedBy)
 	if err != nil {
 		tx.Rollback()
-		return err
+		return 0, err
 	}
 
 	// Commit the transaction
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return nil
+	return previousAccumulated, nil
 }
 
 // GetCurrentPoints retrieves the current points for a member by their ID
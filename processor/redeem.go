@@ -4,7 +4,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/wa-serv/eventbus"
 	"github.com/wa-serv/repository"
 )
 
@@ -23,62 +25,96 @@ var RewardMapping = map[int]string{
 	200: "Uang tunai Rp100.000 (dapat ditransfer ke rekening atau e-wallet)",
 }
 
-// RedeemPoints handles the redemption of points for a member and returns the reward
-func RedeemPoints(db *sql.DB, phoneNumber string, pointsToRedeem int) (string, error) {
-	// Enforce minimum points rule
-	if pointsToRedeem < 20 {
-		return "", ErrMinimumPoints
-	}
+// Redemption is an approved points redemption and the voucher issued for it
+type Redemption struct {
+	Reward      string
+	Points      int
+	VoucherCode string    // shown or scanned at the counter to collect the reward
+	ExpiresAt   time.Time // last moment the voucher can be used
+}
 
-	// Check if the points to redeem match a valid reward
-	reward, exists := RewardMapping[pointsToRedeem]
-	if !exists {
-		return "", ErrInvalidPoints
+// RedeemPoints handles the redemption of points for a member, issuing a voucher
+// for the reward in the same transaction
+func RedeemPoints(db *sql.DB, phoneNumber string, pointsToRedeem int) (*Redemption, error) {
+	reward, err := rewardForPoints(pointsToRedeem)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get the member ID by phone number
 	memberID, err := GetMemberIDByPhoneNumber(db, phoneNumber)
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve member ID: %w", err)
+		return nil, fmt.Errorf("failed to retrieve member ID: %w", err)
 	}
 
 	// Start a transaction
-	tx, err := db.Begin()
+	tx, err := repository.Begin(db)
 	if err != nil {
-		return "", fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
 	// Check if the member has enough points
 	currentPoints, err := repository.GetCurrentPoints(tx, memberID)
 	if err != nil {
 		tx.Rollback()
-		return "", err
+		return nil, err
 	}
 
 	if currentPoints < pointsToRedeem {
 		tx.Rollback()
-		return "", ErrInsufficientPoints
+		return nil, ErrInsufficientPoints
 	}
 
 	// Deduct the points
 	err = repository.DeductPoints(tx, memberID, pointsToRedeem)
 	if err != nil {
 		tx.Rollback()
-		return "", err
+		return nil, err
 	}
 
 	// Track the redemption in point_transactions
-	err = repository.InsertPointTransaction(tx, memberID, -pointsToRedeem, "REDEEM", fmt.Sprintf("Redeemed for: %s", reward))
+	transactionID, err := repository.InsertPointTransactionReturningID(tx, memberID, -pointsToRedeem, "REDEEM", fmt.Sprintf("Redeemed for: %s", reward))
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	voucherCode, expiresAt, err := issueVoucher(tx, memberID, transactionID, reward, pointsToRedeem)
 	if err != nil {
 		tx.Rollback()
-		return "", err
+		return nil, err
 	}
 
 	// Commit the transaction
 	err = tx.Commit()
 	if err != nil {
-		return "", fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	eventbus.Publish(eventbus.PointsChanged, map[string]any{
+		"reason":           eventbus.ReasonRedeemed,
+		"member_id":        memberID,
+		"phone_number":     extractPhoneNumber(phoneNumber),
+		"points":           pointsToRedeem,
+		"reward":           reward,
+		"remaining_points": currentPoints - pointsToRedeem,
+		"voucher_code":     voucherCode,
+	})
+
+	return &Redemption{Reward: reward, Points: pointsToRedeem, VoucherCode: voucherCode, ExpiresAt: expiresAt}, nil
+}
+
+// rewardForPoints enforces the minimum and returns the reward for an exact point value
+func rewardForPoints(pointsToRedeem int) (string, error) {
+	// Enforce minimum points rule
+	if pointsToRedeem < 20 {
+		return "", ErrMinimumPoints
+	}
+
+	// Check if the points to redeem match a valid reward
+	reward, exists := RewardMapping[pointsToRedeem]
+	if !exists {
+		return "", ErrInvalidPoints
+	}
 	return reward, nil
 }
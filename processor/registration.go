@@ -5,7 +5,11 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/wa-serv/command"
+	"github.com/wa-serv/eventbus"
 	"github.com/wa-serv/repository"
 	"go.mau.fi/whatsmeow"
 	waProto "go.mau.fi/whatsmeow/binary/proto"
@@ -20,22 +24,13 @@ func ProcessRegistration(client *whatsmeow.Client, db *sql.DB, message string, s
 		return nil // Not a registration command
 	}
 
-	// Split the message by "#"
-	parts := strings.Split(message, "#")
-	if len(parts) != 3 {
-		sendResponse(client, senderJID, "Format salah! Gunakan: REG#Nama#Alamat")
-		return fmt.Errorf("invalid registration format")
-	}
-
-	// Extract the name and address
-	name := strings.TrimSpace(parts[1])
-	address := strings.TrimSpace(parts[2])
-
-	// Validate inputs
-	if name == "" || address == "" {
-		sendResponse(client, senderJID, "Nama dan Alamat tidak boleh kosong!")
-		return fmt.Errorf("empty name or address")
+	// Parse the name and address; the error names the field that is wrong
+	args, err := command.Registration.Parse(message)
+	if err != nil {
+		sendResponse(client, senderJID, err.Error())
+		return err
 	}
+	name, address := args["name"], args["address"]
 
 	// Extract phone number from JID format (e.g., "123456789@s.whatsapp.net")
 	phoneNumber := extractPhoneNumber(senderJID)
@@ -48,8 +43,7 @@ func ProcessRegistration(client *whatsmeow.Client, db *sql.DB, message string, s
 	}
 
 	if isRegistered {
-		sendResponse(client, senderJID, "Anda sudah terdaftar sebelumnya!")
-		return nil
+		return promptRegistrationUpdate(client, db, senderJID, phoneNumber, name, address)
 	}
 
 	// Register the member
@@ -59,6 +53,13 @@ func ProcessRegistration(client *whatsmeow.Client, db *sql.DB, message string, s
 		return err
 	}
 
+	eventbus.Publish(eventbus.MemberRegistered, map[string]any{
+		"phone_number": phoneNumber,
+		"name":         name,
+		"address":      address,
+		"tier":         TierForPoints(0),
+	})
+
 	// Send success message
 	successMsg := fmt.Sprintf("✅ Registrasi Berhasil!\n\nNama: %s\nAlamat: %s\n\nTerima kasih telah mendaftar!", name, address)
 	sendResponse(client, senderJID, successMsg)
@@ -66,6 +67,116 @@ func ProcessRegistration(client *whatsmeow.Client, db *sql.DB, message string, s
 	return nil
 }
 
+// A registered member who re-sends REG# is asked to confirm the change with YA.
+// The proposed details are kept in memory until confirmed or expired; a restart
+// just means the member has to send REG# again.
+var (
+	registrationEditsMu sync.Mutex
+	registrationEdits   = make(map[string]registrationEdit)
+	registrationEditTTL = 10 * time.Minute
+)
+
+// registrationConfirm is the reply that confirms a pending change
+const registrationConfirm = "ya"
+
+// registrationEdit is a name/address change awaiting confirmation
+type registrationEdit struct {
+	Name      string
+	Address   string
+	ExpiresAt time.Time
+}
+
+// setRegistrationEdit stores the proposed details for phone, replacing any
+// earlier proposal, and drops expired entries
+func setRegistrationEdit(phone, name, address string) {
+	registrationEditsMu.Lock()
+	defer registrationEditsMu.Unlock()
+	now := time.Now()
+	for k, e := range registrationEdits {
+		if now.After(e.ExpiresAt) {
+			delete(registrationEdits, k)
+		}
+	}
+	registrationEdits[phone] = registrationEdit{Name: name, Address: address, ExpiresAt: now.Add(registrationEditTTL)}
+}
+
+// takeRegistrationEdit returns and clears the unexpired proposal for phone
+func takeRegistrationEdit(phone string) (registrationEdit, bool) {
+	registrationEditsMu.Lock()
+	defer registrationEditsMu.Unlock()
+	edit, ok := registrationEdits[phone]
+	delete(registrationEdits, phone)
+	if !ok || time.Now().After(edit.ExpiresAt) {
+		return registrationEdit{}, false
+	}
+	return edit, true
+}
+
+// IsRegistrationConfirmation reports whether msgText confirms a pending
+// name/address change for the sender. msgText must be lower-cased and trimmed.
+func IsRegistrationConfirmation(senderJID, msgText string) bool {
+	if msgText != registrationConfirm {
+		return false
+	}
+	registrationEditsMu.Lock()
+	defer registrationEditsMu.Unlock()
+	edit, ok := registrationEdits[extractPhoneNumber(senderJID)]
+	return ok && time.Now().Before(edit.ExpiresAt)
+}
+
+// promptRegistrationUpdate asks an existing member to confirm replacing their
+// registered details with the ones from a repeated REG# command
+func promptRegistrationUpdate(client *whatsmeow.Client, db *sql.DB, senderJID, phoneNumber, name, address string) error {
+	member, err := repository.GetMemberProfile(db, phoneNumber)
+	if err != nil || member == nil {
+		sendResponse(client, senderJID, "Terjadi kesalahan saat memeriksa registrasi.")
+		if err == nil {
+			err = fmt.Errorf("member %s not found", phoneNumber)
+		}
+		return err
+	}
+
+	if member.Name == name && member.Address == address {
+		sendResponse(client, senderJID, fmt.Sprintf("Anda sudah terdaftar sebagai %s dengan alamat %s. Tidak ada data yang berubah.", member.Name, member.Address))
+		return nil
+	}
+
+	setRegistrationEdit(phoneNumber, name, address)
+	prompt := fmt.Sprintf("Anda sudah terdaftar sebagai %s.\n\nData baru:\nNama: %s\nAlamat: %s\n\nBalas YA dalam %d menit untuk memperbarui data Anda.",
+		member.Name, name, address, int(registrationEditTTL.Minutes()))
+	sendResponse(client, senderJID, prompt)
+	return nil
+}
+
+// ProcessRegistrationConfirmation applies the name/address change the sender
+// confirmed with YA
+func ProcessRegistrationConfirmation(client *whatsmeow.Client, db *sql.DB, senderJID string) error {
+	phoneNumber := extractPhoneNumber(senderJID)
+	edit, ok := takeRegistrationEdit(phoneNumber)
+	if !ok {
+		sendResponse(client, senderJID, "Permintaan perubahan data sudah kedaluwarsa. Kirim ulang REG#Nama#Alamat.")
+		return fmt.Errorf("no pending registration update")
+	}
+
+	updated, err := repository.UpdateMemberProfile(db, phoneNumber, edit.Name, edit.Address)
+	if err != nil || !updated {
+		sendResponse(client, senderJID, "Gagal memperbarui data. Silakan coba lagi.")
+		if err == nil {
+			err = fmt.Errorf("member %s not found", phoneNumber)
+		}
+		return err
+	}
+
+	eventbus.Publish(eventbus.MemberUpdated, map[string]any{
+		"phone_number": phoneNumber,
+		"name":         edit.Name,
+		"address":      edit.Address,
+	})
+
+	sendResponse(client, senderJID, fmt.Sprintf("✅ Data Berhasil Diperbarui!\n\nNama: %s\nAlamat: %s", edit.Name, edit.Address))
+	return nil
+}
+
 // extractPhoneNumber extracts the phone number from a WhatsApp JID
 func extractPhoneNumber(jid string) string {
 	parts := strings.Split(jid, "@")
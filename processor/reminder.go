@@ -0,0 +1,69 @@
+package processor
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+)
+
+// RunReminderRules evaluates every active reminder rule, messages members that
+// are due and alerts adminPhones once a subject reaches the rule's escalation
+// threshold. It returns the number of member reminders sent.
+func RunReminderRules(db *sql.DB, client *whatsmeow.Client, adminPhones []string) (int, error) {
+	rules, err := repository.GetReminderRules(db, true)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, rule := range rules {
+		candidates, err := repository.GetDueReminderCandidates(db, rule)
+		if err != nil {
+			// One misconfigured rule should not block the others
+			fmt.Printf("Reminder rule %s skipped: %v\n", rule.Name, err)
+			continue
+		}
+
+		for _, c := range candidates {
+			vars := map[string]string{
+				"name":       firstNonEmpty(c.MemberName, c.PushName),
+				"push_name":  firstNonEmpty(c.PushName, c.MemberName),
+				"subject_id": strconv.Itoa(c.SubjectID),
+				"detail":     c.Detail,
+				"count":      strconv.Itoa(c.RemindersSent + 1),
+			}
+			sendResponse(client, c.PhoneNumber+"@s.whatsapp.net", RenderTemplate(rule.Message, vars))
+
+			count, err := repository.RecordReminderSent(db, rule.RuleID, c.SubjectID, c.MemberID)
+			if err != nil {
+				return sent, err
+			}
+			sent++
+
+			if rule.EscalateAfter > 0 && count >= rule.EscalateAfter {
+				if err := escalateReminder(db, client, rule, c, count, adminPhones); err != nil {
+					return sent, err
+				}
+			}
+		}
+	}
+	return sent, nil
+}
+
+// escalateReminder alerts admins about a subject, at most once per rule and subject
+func escalateReminder(db *sql.DB, client *whatsmeow.Client, rule repository.ReminderRule, c repository.ReminderCandidate, count int, adminPhones []string) error {
+	first, err := repository.MarkReminderEscalated(db, rule.RuleID, c.SubjectID)
+	if err != nil || !first {
+		return err
+	}
+
+	alert := fmt.Sprintf("⚠️ Eskalasi pengingat (%s)\n\nPelanggan: %s (%s)\nReferensi: #%d\nPengingat terkirim: %d kali tanpa tindak lanjut.",
+		rule.Name, c.MemberName, c.PhoneNumber, c.SubjectID, count)
+	for _, phone := range adminPhones {
+		sendResponse(client, phone+"@s.whatsapp.net", alert)
+	}
+	return nil
+}
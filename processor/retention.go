@@ -0,0 +1,49 @@
+package processor
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/repository"
+)
+
+// PruneOperationalData deletes the rows of each operational table that are
+// older than the table's retention in cfg, keeping the database under quota.
+// It returns the rows deleted per table. A table that fails to prune does not
+// stop the others; the first error is returned.
+func PruneOperationalData(db *sql.DB, cfg config.RetentionConfig, now time.Time) (map[string]int64, error) {
+	policies := []struct {
+		table  repository.RetentionTable
+		retain time.Duration
+	}{
+		{repository.RetentionMessageHistory, cfg.MessageHistory},
+		{repository.RetentionInboundEvents, cfg.InboundEvents},
+		{repository.RetentionMessageStatus, cfg.MessageStatus},
+		{repository.RetentionSendQueue, cfg.SendQueue},
+		{repository.RetentionIdempotencyKeys, cfg.IdempotencyKeys},
+		{repository.RetentionShadowDiffs, cfg.ShadowDiffs},
+		{repository.RetentionSenderActivity, cfg.SenderActivity},
+		{repository.RetentionDeadLetters, cfg.DeadLetters},
+	}
+
+	pruned := make(map[string]int64)
+	var firstErr error
+	for _, p := range policies {
+		if p.retain <= 0 {
+			continue
+		}
+		deleted, err := repository.PruneRows(db, p.table, now.Add(-p.retain))
+		if deleted > 0 {
+			pruned[p.table.Name] = deleted
+		}
+		if err != nil {
+			fmt.Printf("Retention: %v\n", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return pruned, firstErr
+}
@@ -0,0 +1,78 @@
+package processor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wa-serv/alerting"
+	"go.mau.fi/whatsmeow"
+)
+
+// AnnounceDefaultSenderChange tells adminPhones, from the newly elected
+// default sender, that outgoing messages now use a different number
+func AnnounceDefaultSenderChange(client *whatsmeow.Client, adminPhones []string, previousID, newID, reason string) {
+	alert := fmt.Sprintf("⚠️ Pengirim default berganti\n\nSebelumnya: %s (%s)\nSekarang: %s\n\nDaftarkan ulang perangkat %s jika ingin mengaktifkannya kembali.",
+		previousID, reason, newID, previousID)
+	alertSenderDown(client, adminPhones, "Pengirim default berganti", alert, map[string]any{
+		"sender_id": previousID, "reason": reason, "new_default_sender_id": newID,
+	})
+}
+
+// AnnounceDefaultSenderLost tells adminPhones that the default sender stopped
+// working and, with failover disabled, was not replaced. client is nil when no
+// sender is left to send the WhatsApp alert; other channels still get it.
+func AnnounceDefaultSenderLost(client *whatsmeow.Client, adminPhones []string, senderID, reason string) {
+	alert := fmt.Sprintf("⚠️ Pengirim default tidak aktif\n\nPengirim: %s (%s)\n\nPergantian otomatis dimatikan, jadi pesan tanpa pengirim akan gagal. Daftarkan ulang perangkat %s atau pilih pengirim lain.",
+		senderID, reason, senderID)
+	alertSenderDown(client, adminPhones, "Pengirim default tidak aktif", alert, map[string]any{
+		"sender_id": senderID, "reason": reason,
+	})
+}
+
+// AnnounceConversationMuted tells adminPhones that the bot stopped replying to
+// contact because it looks like another bot stuck in a reply loop. A blocked
+// contact was also blocked on the sender and added to the suppression list.
+func AnnounceConversationMuted(client *whatsmeow.Client, adminPhones []string, contact, reason string, duration time.Duration, blocked bool) {
+	alert := fmt.Sprintf("🔁 Percakapan dengan %s dibisukan\n\nAlasan: %s\n\nBot tidak akan membalas nomor ini selama %s. Periksa apakah nomor ini bot lain.",
+		contact, reason, duration)
+	if blocked {
+		alert = fmt.Sprintf("🔁 Nomor %s diblokir\n\nAlasan: %s\n\nNomor ini diblokir di WhatsApp dan dimasukkan ke daftar supresi. Buka blokir lewat API jika ternyata bukan bot.",
+			contact, reason)
+	}
+	for _, phone := range adminPhones {
+		sendResponse(client, phone+"@s.whatsapp.net", alert)
+	}
+}
+
+// AnnounceSenderRestricted tells adminPhones that WhatsApp banned or
+// restricted a sender and that it no longer sends messages. client is nil when
+// no sender is left to send the WhatsApp alert.
+func AnnounceSenderRestricted(client *whatsmeow.Client, adminPhones []string, senderID, state, reason string) {
+	label := "dibatasi"
+	if state == "banned" {
+		label = "diblokir"
+	}
+	alert := fmt.Sprintf("🚫 Pengirim %s %s oleh WhatsApp\n\nAlasan: %s\n\nNomor ini dikeluarkan dari rotasi pengiriman.",
+		senderID, label, reason)
+	alertSenderDown(client, adminPhones, fmt.Sprintf("Pengirim %s %s oleh WhatsApp", senderID, label), alert, map[string]any{
+		"sender_id": senderID, "state": state, "reason": reason,
+	})
+}
+
+// alertSenderDown delivers a sender_down alert to adminPhones and the admins
+// who asked for it, on WhatsApp from client when there is one
+func alertSenderDown(client *whatsmeow.Client, adminPhones []string, subject, text string, data map[string]any) {
+	var send alerting.WhatsAppFunc
+	if client != nil {
+		send = func(phone, text string) {
+			sendResponse(client, phone+"@s.whatsapp.net", text)
+		}
+	}
+	alerting.Default().Notify(alerting.Alert{
+		Type:    alerting.SenderDown,
+		Subject: subject,
+		Text:    text,
+		Phones:  adminPhones,
+		Data:    data,
+	}, send)
+}
@@ -0,0 +1,177 @@
+package processor
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wa-serv/command"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/repository"
+)
+
+var (
+	ErrStampsDisabled = errors.New("the stamp card program is not active")
+	ErrPointsDisabled = errors.New("the points program is not active")
+	ErrNoStampReward  = repository.ErrNoStampReward
+)
+
+// defaultLoyaltyProgram applies when the bot has no loyalty tenant configured
+var defaultLoyaltyProgram = repository.LoyaltyProgramSettings{
+	Program:       repository.ProgramPoints,
+	StampCardSize: 10,
+	StampReward:   "Gratis 1x cuci",
+}
+
+// StampCardResult is a member's stamp card after a stamp command
+type StampCardResult struct {
+	PhoneNumber string
+	Card        *repository.StampCard
+	Completed   int // cards completed by this command
+	Settings    repository.LoyaltyProgramSettings
+}
+
+// LoyaltyProgram returns the loyalty program the bot runs: that of the
+// LOYALTY_TENANT tenant, or points only when there is none
+func LoyaltyProgram(db *sql.DB) (repository.LoyaltyProgramSettings, error) {
+	slug := config.LoadLoyaltyConfig().TenantSlug
+	if slug == "" {
+		return defaultLoyaltyProgram, nil
+	}
+	settings, err := repository.GetLoyaltyProgramSettings(db, slug)
+	if err != nil {
+		return repository.LoyaltyProgramSettings{}, err
+	}
+	if settings == nil {
+		fmt.Printf("Loyalty tenant %q not found, running the points program\n", slug)
+		return defaultLoyaltyProgram, nil
+	}
+	return *settings, nil
+}
+
+// ProcessAddStamps handles STEMPEL#phone_number[#stamps] from an admin, adding
+// stamps (1 by default) to the member's card
+func ProcessAddStamps(db *sql.DB, senderJID, input string) (*StampCardResult, error) {
+	args, settings, err := parseStampCommand(db, command.AddStamps, senderJID, input)
+	if err != nil {
+		return nil, err
+	}
+	stamps := 1
+	if args["stamps"] != "" {
+		stamps = args.Int("stamps")
+	}
+
+	phoneNumber := args["phone_number"]
+	memberID, err := GetMemberIDByPhoneNumber(db, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	card, completed, err := repository.AddStamps(db, memberID, stamps, settings.StampCardSize, extractPhoneNumber(senderJID))
+	if err != nil {
+		return nil, err
+	}
+
+	if completed > 0 {
+		eventbus.Publish(eventbus.StampCardCompleted, map[string]any{
+			"member_id":         memberID,
+			"phone_number":      phoneNumber,
+			"cards":             completed,
+			"reward":            settings.StampReward,
+			"rewards_available": card.RewardsAvailable,
+		})
+	}
+	return &StampCardResult{PhoneNumber: phoneNumber, Card: card, Completed: completed, Settings: settings}, nil
+}
+
+// ProcessClaimStampReward handles KLAIM#phone_number from an admin, using one
+// of the member's completed cards
+func ProcessClaimStampReward(db *sql.DB, senderJID, input string) (*StampCardResult, error) {
+	args, settings, err := parseStampCommand(db, command.ClaimStampReward, senderJID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	phoneNumber := args["phone_number"]
+	memberID, err := GetMemberIDByPhoneNumber(db, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := repository.ClaimStampReward(db, memberID, extractPhoneNumber(senderJID))
+	if err != nil {
+		return nil, err
+	}
+	return &StampCardResult{PhoneNumber: phoneNumber, Card: card, Settings: settings}, nil
+}
+
+// GetMemberStampCard returns the stamp card of the member sending senderJID
+func GetMemberStampCard(db *sql.DB, senderJID string) (*StampCardResult, error) {
+	settings, err := LoyaltyProgram(db)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.StampsEnabled() {
+		return nil, ErrStampsDisabled
+	}
+
+	registered, err := repository.IsMemberRegistered(db, extractPhoneNumber(senderJID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check registration: %w", err)
+	}
+	if !registered {
+		return nil, ErrMemberNotRegistered
+	}
+	memberID, err := GetMemberIDByPhoneNumber(db, senderJID)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := repository.GetStampCard(db, memberID)
+	if err != nil {
+		return nil, err
+	}
+	return &StampCardResult{PhoneNumber: extractPhoneNumber(senderJID), Card: card, Settings: settings}, nil
+}
+
+// FormatStampCard renders a stamp card for WhatsApp, e.g. 🟢🟢🟢⚪⚪⚪⚪⚪⚪⚪ 3/10
+func FormatStampCard(result *StampCardResult) string {
+	size := result.Settings.StampCardSize
+	stamps := result.Card.Stamps
+
+	var b strings.Builder
+	b.WriteString("🎟️ *Kartu Stempel*\n\n")
+	b.WriteString(strings.Repeat("🟢", stamps))
+	b.WriteString(strings.Repeat("⚪", size-stamps))
+	fmt.Fprintf(&b, " %d/%d\n\n", stamps, size)
+	if result.Completed > 0 {
+		fmt.Fprintf(&b, "🎉 Selamat! %d kartu penuh.\n", result.Completed)
+	}
+	fmt.Fprintf(&b, "Kumpulkan %d stempel untuk: %s", size, result.Settings.StampReward)
+	if result.Card.RewardsAvailable > 0 {
+		fmt.Fprintf(&b, "\n🎁 Hadiah siap diklaim: %d. Tunjukkan pesan ini ke kasir.", result.Card.RewardsAvailable)
+	}
+	return b.String()
+}
+
+// parseStampCommand checks the sender may manage stamps, that the stamp card
+// program is active and parses input against spec
+func parseStampCommand(db *sql.DB, spec command.Spec, senderJID, input string) (command.Args, repository.LoyaltyProgramSettings, error) {
+	if !config.Env.AllowedPhoneNumbers[extractPhoneNumber(senderJID)] {
+		return nil, repository.LoyaltyProgramSettings{}, errors.New("unauthorized action: phone number not allowed")
+	}
+	args, err := spec.Parse(input)
+	if err != nil {
+		return nil, repository.LoyaltyProgramSettings{}, err
+	}
+	settings, err := LoyaltyProgram(db)
+	if err != nil {
+		return nil, repository.LoyaltyProgramSettings{}, err
+	}
+	if !settings.StampsEnabled() {
+		return nil, repository.LoyaltyProgramSettings{}, ErrStampsDisabled
+	}
+	return args, settings, nil
+}
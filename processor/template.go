@@ -0,0 +1,43 @@
+package processor
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/wa-serv/repository"
+)
+
+// RenderTemplate replaces {{key}} placeholders in body with values from vars.
+// Unknown placeholders are left as-is so missing data is visible, not silent.
+func RenderTemplate(body string, vars map[string]string) string {
+	pairs := make([]string, 0, len(vars)*2)
+	for k, v := range vars {
+		pairs = append(pairs, "{{"+k+"}}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(body)
+}
+
+// ContactTemplateVars returns the {{name}}, {{push_name}} and {{phone}}
+// variables for a phone number. Contacts that have not completed registration
+// have no DB name, so {{name}} falls back to their WhatsApp push name.
+func ContactTemplateVars(db *sql.DB, phoneNumber string) (map[string]string, error) {
+	name, pushName, err := repository.GetContactNames(db, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"name":      firstNonEmpty(name, pushName),
+		"push_name": firstNonEmpty(pushName, name),
+		"phone":     phoneNumber,
+	}, nil
+}
+
+// firstNonEmpty returns the first value that is not blank
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
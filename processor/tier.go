@@ -0,0 +1,22 @@
+package processor
+
+// Tier thresholds on accumulated (lifetime) points, highest first
+var tierThresholds = []struct {
+	Name      string
+	MinPoints int
+}{
+	{"Platinum", 1000},
+	{"Gold", 500},
+	{"Silver", 100},
+	{"Bronze", 0},
+}
+
+// TierForPoints returns the membership tier for the given accumulated points
+func TierForPoints(accumulatedPoints int) string {
+	for _, t := range tierThresholds {
+		if accumulatedPoints >= t.MinPoints {
+			return t.Name
+		}
+	}
+	return tierThresholds[len(tierThresholds)-1].Name
+}
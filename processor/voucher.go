@@ -0,0 +1,75 @@
+package processor
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	qrcode "github.com/skip2/go-qrcode"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/repository"
+)
+
+// voucherAlphabet leaves out 0/O and 1/I so codes survive being read aloud or typed.
+// Its 32 letters divide 256, so taking a random byte modulo its length is unbiased.
+const voucherAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// voucherCodeAttempts bounds retries on the (astronomically rare) code collision
+const voucherCodeAttempts = 5
+
+var errVoucherCodeExhausted = errors.New("could not generate a unique voucher code")
+
+// NewVoucherCode returns a random code such as RL-7KQ2-MX9D
+func NewVoucherCode() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate voucher code: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = voucherAlphabet[int(b)%len(voucherAlphabet)]
+	}
+	return fmt.Sprintf("RL-%s-%s", buf[:4], buf[4:]), nil
+}
+
+// NormalizeVoucherCode uppercases and trims a code typed or scanned at the counter
+func NormalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// VoucherQRCode renders a voucher code as a PNG QR code for the counter to scan
+func VoucherQRCode(code string) ([]byte, error) {
+	png, err := qrcode.Encode(code, qrcode.Medium, 512)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render voucher QR code: %w", err)
+	}
+	return png, nil
+}
+
+// issueVoucher stores a voucher with a fresh code for a redemption transaction,
+// valid for VOUCHER_VALIDITY_DAYS
+func issueVoucher(exec repository.Executor, memberID, transactionID int, reward string, points int) (string, time.Time, error) {
+	expiresAt := time.Now().AddDate(0, 0, config.LoadVoucherConfig().ValidityDays)
+	for attempt := 0; attempt < voucherCodeAttempts; attempt++ {
+		code, err := NewVoucherCode()
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		created, err := repository.CreateVoucher(exec, repository.Voucher{
+			Code:          code,
+			TransactionID: transactionID,
+			MemberID:      memberID,
+			Reward:        reward,
+			Points:        points,
+			ExpiresAt:     &expiresAt,
+		})
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		if created {
+			return code, expiresAt, nil
+		}
+	}
+	return "", time.Time{}, errVoucherCodeExhausted
+}
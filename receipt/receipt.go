@@ -0,0 +1,263 @@
+// Package receipt renders order receipts as single-page PDF documents for
+// sending on WhatsApp. It is pure: callers load the order and pass it in.
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/wa-serv/pricing"
+)
+
+// Business is the letterhead printed on every receipt
+type Business struct {
+	Name    string
+	Address string // empty prints no address
+	Phone   string // empty prints no phone number
+	Footer  string // closing note under the total; empty prints none
+}
+
+// Line is one service on a receipt
+type Line struct {
+	Name  string
+	Kilos float64 // set for services sold by weight
+	Units int     // set for services sold per piece
+	Price float64
+}
+
+// Receipt is an order as printed for the member
+type Receipt struct {
+	Business    Business
+	OrderID     int
+	Date        time.Time
+	MemberName  string
+	PhoneNumber string
+	Lines       []Line
+	Total       float64
+}
+
+// FileName is the name a receipt is sent under, e.g. kwitansi-42.pdf
+func (r Receipt) FileName() string {
+	return fmt.Sprintf("kwitansi-%d.pdf", r.OrderID)
+}
+
+// An A5 page, in points
+const (
+	pageWidth  = 420.0
+	pageHeight = 595.0
+	margin     = 36.0
+)
+
+// PDF renders r as an A5 PDF. It uses the standard Helvetica fonts rather than
+// embedding one, so text outside Latin-1 prints as "?".
+func PDF(r Receipt) []byte {
+	var p page
+	right := pageWidth - margin
+
+	// Letterhead on a colored band
+	p.fill(0.05, 0.45, 0.40)
+	p.rect(0, pageHeight-78, pageWidth, 78)
+	p.fill(1, 1, 1)
+	p.text(boldFont, 18, margin, pageHeight-36, r.Business.Name)
+	contact := r.Business.Address
+	if r.Business.Phone != "" {
+		if contact != "" {
+			contact += " - "
+		}
+		contact += "Telp. " + r.Business.Phone
+	}
+	p.text(regularFont, 9, margin, pageHeight-56, contact)
+	p.fill(0, 0, 0)
+
+	y := pageHeight - 112
+	p.text(boldFont, 14, margin, y, "KWITANSI")
+	p.textRight(regularFont, 10, right, y, "No. #"+strconv.Itoa(r.OrderID))
+	y -= 24
+	for _, field := range [][2]string{
+		{"Tanggal", r.Date.Format("02/01/2006 15:04")},
+		{"Pelanggan", r.MemberName},
+		{"Telepon", r.PhoneNumber},
+	} {
+		p.text(regularFont, 10, margin, y, field[0])
+		p.text(regularFont, 10, margin+80, y, ": "+field[1])
+		y -= 16
+	}
+
+	y -= 8
+	p.rule(y + 12)
+	p.text(boldFont, 10, margin, y, "Layanan")
+	p.text(boldFont, 10, margin+200, y, "Jumlah")
+	p.textRight(boldFont, 10, right, y, "Harga")
+	y -= 8
+	p.rule(y)
+	y -= 16
+	for _, line := range r.Lines {
+		p.text(regularFont, 10, margin, y, line.Name)
+		p.text(regularFont, 10, margin+200, y, quantity(line))
+		p.textRight(regularFont, 10, right, y, pricing.FormatRupiah(line.Price))
+		y -= 16
+	}
+	p.rule(y + 8)
+	y -= 10
+	p.text(boldFont, 12, margin, y, "Total")
+	p.textRight(boldFont, 12, right, y, pricing.FormatRupiah(r.Total))
+
+	y -= 40
+	p.fill(0.35, 0.35, 0.35)
+	for _, line := range wrap(r.Business.Footer, 70) {
+		p.text(regularFont, 9, margin, y, line)
+		y -= 13
+	}
+
+	return assemble(p.content.Bytes())
+}
+
+// quantity renders the amount of a line, e.g. 3,5 kg or 2 pcs
+func quantity(line Line) string {
+	if line.Kilos > 0 {
+		return strings.Replace(strconv.FormatFloat(line.Kilos, 'f', -1, 64), ".", ",", 1) + " kg"
+	}
+	if line.Units > 0 {
+		return strconv.Itoa(line.Units) + " pcs"
+	}
+	return "-"
+}
+
+// wrap breaks text into lines of at most width characters, at spaces
+func wrap(text string, width int) []string {
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(text) {
+		if current != "" && utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, current)
+			current = ""
+		}
+		if current != "" {
+			current += " "
+		}
+		current += word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// The fonts of the page resources
+const (
+	regularFont = "F1"
+	boldFont    = "F2"
+)
+
+// page collects the drawing operators of a page's content stream
+type page struct {
+	content bytes.Buffer
+}
+
+func (p *page) fill(r, g, b float64) {
+	fmt.Fprintf(&p.content, "%s %s %s rg\n", num(r), num(g), num(b))
+}
+
+func (p *page) rect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", num(x), num(y), num(w), num(h))
+}
+
+// rule draws a thin horizontal line across the page at y
+func (p *page) rule(y float64) {
+	fmt.Fprintf(&p.content, "0.5 w %s %s m %s %s l S\n", num(margin), num(y), num(pageWidth-margin), num(y))
+}
+
+func (p *page) text(font string, size, x, y float64, s string) {
+	if s == "" {
+		return
+	}
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, num(size), num(x), num(y), escape(winAnsi(s)))
+}
+
+// textRight draws s ending at x
+func (p *page) textRight(font string, size, x, y float64, s string) {
+	p.text(font, size, x-textWidth(font, size, winAnsi(s)), y, s)
+}
+
+func num(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// winAnsi encodes s for the WinAnsi encoding of the standard fonts, which
+// matches Latin-1 for the characters receipts print
+func winAnsi(s string) string {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			b = append(b, byte(r))
+		case r == '\t' || r == '\n' || r == '\r':
+			b = append(b, ' ')
+		default:
+			b = append(b, '?')
+		}
+	}
+	return string(b)
+}
+
+// escape escapes the characters a PDF literal string treats specially
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// Glyph widths, in thousandths of the font size, of the standard Helvetica
+// fonts for the characters of right-aligned text: prices and order numbers.
+// Other characters count as an average glyph.
+var glyphWidths = map[string]map[byte]int{
+	regularFont: {' ': 278, '#': 556, '-': 333, '.': 278, ',': 278, 'R': 722, 'p': 556, 'N': 722, 'o': 556, 'H': 722, 'a': 556, 'r': 333, 'g': 556},
+	boldFont:    {' ': 278, '#': 556, '-': 333, '.': 278, ',': 278, 'R': 722, 'p': 611, 'N': 722, 'o': 611, 'H': 722, 'a': 556, 'r': 389, 'g': 611},
+}
+
+// averageGlyphWidth is the width of a digit, and close to that of most letters
+const averageGlyphWidth = 556
+
+func textWidth(font string, size float64, s string) float64 {
+	total := 0
+	for i := 0; i < len(s); i++ {
+		w, ok := glyphWidths[font][s[i]]
+		if !ok {
+			w = averageGlyphWidth
+		}
+		total += w
+	}
+	return float64(total) * size / 1000
+}
+
+// assemble wraps a page's content stream in a PDF document
+func assemble(content []byte) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /%s 4 0 R /%s 5 0 R >> >> /Contents 6 0 R >>",
+			num(pageWidth), num(pageHeight), regularFont, boldFont),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	}
+
+	var b bytes.Buffer
+	// The comment of high bytes tells readers the file is binary
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
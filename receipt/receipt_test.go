@@ -0,0 +1,89 @@
+package receipt
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReceipt() Receipt {
+	return Receipt{
+		Business:    Business{Name: "Laundry Bersih", Address: "Jl. Melati 5", Phone: "0211234567", Footer: "Terima kasih!"},
+		OrderID:     42,
+		Date:        time.Date(2026, 10, 16, 8, 30, 0, 0, time.UTC),
+		MemberName:  "Siti (VIP)",
+		PhoneNumber: "6281234567890",
+		Lines: []Line{
+			{Name: "Cuci Kering", Kilos: 3.5, Price: 24500},
+			{Name: "Bed Cover", Units: 2, Price: 70000},
+		},
+		Total: 94500,
+	}
+}
+
+func TestPDF(t *testing.T) {
+	doc := PDF(testReceipt())
+
+	assert.True(t, bytes.HasPrefix(doc, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(doc, []byte("%%EOF\n")))
+	for _, text := range []string{
+		"(Laundry Bersih)",
+		"(Jl. Melati 5 - Telp. 0211234567)",
+		"(: 16/10/2026 08:30)",
+		`(: Siti \(VIP\))`,
+		"(3,5 kg)",
+		"(2 pcs)",
+		"(Rp94.500)",
+		"(Terima kasih!)",
+	} {
+		assert.Contains(t, string(doc), text)
+	}
+}
+
+func TestPDF_CrossReference(t *testing.T) {
+	doc := PDF(testReceipt())
+
+	start := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(doc)
+	require.NotNil(t, start)
+	xref, err := strconv.Atoi(string(start[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(doc[xref:], []byte("xref\n0 7\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(doc[xref:], -1)
+	require.Len(t, entries, 6)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(doc[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+
+	length := regexp.MustCompile(`<< /Length (\d+) >>\nstream\n`).FindSubmatchIndex(doc)
+	require.NotNil(t, length)
+	n, err := strconv.Atoi(string(doc[length[2]:length[3]]))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(doc[length[1]+n:], []byte("\nendstream")))
+}
+
+func TestWinAnsi(t *testing.T) {
+	assert.Equal(t, "Caf\xe9 ? 5", winAnsi("Café 😀 5"))
+	assert.Equal(t, `a\\b \(c\)`, escape(`a\b (c)`))
+}
+
+func TestTextWidth(t *testing.T) {
+	// "Rp24.500": R, p, five digits and a dot at 10pt
+	assert.InDelta(t, 7.22+5.56+5*5.56+2.78, textWidth(regularFont, 10, "Rp24.500"), 0.001)
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"Terima kasih", "sudah mencuci", "di sini"}, wrap("Terima kasih sudah mencuci di sini", 13))
+	assert.Empty(t, wrap("  ", 10))
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "kwitansi-42.pdf", testReceipt().FileName())
+}
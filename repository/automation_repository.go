@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrAutomationRuleNotFound = errors.New("automation rule not found")
+
+// AutomationRule is an operator-defined rule that sends a template on a domain event
+type AutomationRule struct {
+	ID        int
+	Name      string
+	EventType string
+	Condition string
+	Template  string
+	Recipient string // empty sends to the event's member
+	Active    bool
+}
+
+const automationRuleColumns = `rule_id, name, event_type, condition, template_name, recipient, is_active`
+
+// GetAutomationRules returns all automation rules ordered by ID
+func GetAutomationRules(db *sql.DB) ([]AutomationRule, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`SELECT ` + automationRuleColumns + ` FROM automation_rules ORDER BY rule_id`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query automation rules: %w", err)
+	}
+	return scanAutomationRules(rows)
+}
+
+// GetActiveAutomationRules returns the active rules for an event type ordered by ID
+func GetActiveAutomationRules(db *sql.DB, eventType string) ([]AutomationRule, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT `+automationRuleColumns+` FROM automation_rules
+		WHERE event_type = $1 AND is_active
+		ORDER BY rule_id
+	`), eventType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query automation rules for %s: %w", eventType, err)
+	}
+	return scanAutomationRules(rows)
+}
+
+// CreateAutomationRule inserts a rule and returns its ID
+func CreateAutomationRule(db *sql.DB, rule AutomationRule) (int, error) {
+	var id int
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO automation_rules (name, event_type, condition, template_name, recipient, is_active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
+		RETURNING rule_id
+	`), rule.Name, rule.EventType, rule.Condition, rule.Template, rule.Recipient, rule.Active).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create automation rule: %w", err)
+	}
+	return id, nil
+}
+
+// DeleteAutomationRule removes a rule
+func DeleteAutomationRule(db *sql.DB, id int) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`DELETE FROM automation_rules WHERE rule_id = $1`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete automation rule %d: %w", id, err)
+	}
+	return requireRow(result, ErrAutomationRuleNotFound)
+}
+
+func scanAutomationRules(rows *sql.Rows) ([]AutomationRule, error) {
+	defer rows.Close()
+
+	var rules []AutomationRule
+	for rows.Next() {
+		var r AutomationRule
+		if err := rows.Scan(&r.ID, &r.Name, &r.EventType, &r.Condition, &r.Template, &r.Recipient, &r.Active); err != nil {
+			return nil, fmt.Errorf("failed to scan automation rule: %w", err)
+		}
+		rules = append(rules, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating automation rules: %w", err)
+	}
+	return rules, nil
+}
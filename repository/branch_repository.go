@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrBranchNotFound = errors.New("branch not found")
+
+// Branch is one store of the laundry in the branch directory
+type Branch struct {
+	ID             int
+	Name           string
+	Address        string
+	Hours          string // opening hours as shown to customers, e.g. "Senin-Sabtu 07.00-21.00"
+	WhatsAppNumber string // the branch's own number, empty when it has none
+	Latitude       float64
+	Longitude      float64
+	MapsURL        string // Google Maps link; empty to link the coordinates
+}
+
+const branchColumns = `branch_id, name, address, hours, whatsapp_number, latitude, longitude, maps_url`
+
+// CreateBranch inserts a branch and returns its ID
+func CreateBranch(db *sql.DB, b Branch) (int, error) {
+	var id int
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO branches (name, address, hours, whatsapp_number, latitude, longitude, maps_url, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING branch_id
+	`), b.Name, b.Address, b.Hours, b.WhatsAppNumber, b.Latitude, b.Longitude, b.MapsURL).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create branch: %w", err)
+	}
+	return id, nil
+}
+
+// GetBranches returns all branches ordered by ID, so their numbers stay stable
+// in the chat directory
+func GetBranches(db *sql.DB) ([]Branch, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`SELECT ` + branchColumns + ` FROM branches ORDER BY branch_id`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query branches: %w", err)
+	}
+	defer rows.Close()
+
+	var branches []Branch
+	for rows.Next() {
+		var b Branch
+		if err := rows.Scan(&b.ID, &b.Name, &b.Address, &b.Hours, &b.WhatsAppNumber, &b.Latitude, &b.Longitude, &b.MapsURL); err != nil {
+			return nil, fmt.Errorf("failed to scan branch: %w", err)
+		}
+		branches = append(branches, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating branches: %w", err)
+	}
+	return branches, nil
+}
+
+// GetBranch returns the branch with the given ID, or ErrBranchNotFound
+func GetBranch(db *sql.DB, id int) (*Branch, error) {
+	var b Branch
+	err := db.QueryRow(DialectOf(db).Rebind(`SELECT `+branchColumns+` FROM branches WHERE branch_id = $1`), id).
+		Scan(&b.ID, &b.Name, &b.Address, &b.Hours, &b.WhatsAppNumber, &b.Latitude, &b.Longitude, &b.MapsURL)
+	if err == sql.ErrNoRows {
+		return nil, ErrBranchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch %d: %w", id, err)
+	}
+	return &b, nil
+}
+
+// UpdateBranch replaces the details of a branch
+func UpdateBranch(db *sql.DB, b Branch) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE branches
+		SET name = $2, address = $3, hours = $4, whatsapp_number = $5, latitude = $6, longitude = $7,
+			maps_url = $8, updated_at = CURRENT_TIMESTAMP
+		WHERE branch_id = $1
+	`), b.ID, b.Name, b.Address, b.Hours, b.WhatsAppNumber, b.Latitude, b.Longitude, b.MapsURL)
+	if err != nil {
+		return fmt.Errorf("failed to update branch %d: %w", b.ID, err)
+	}
+	return requireRow(result, ErrBranchNotFound)
+}
+
+// DeleteBranch removes a branch
+func DeleteBranch(db *sql.DB, id int) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`DELETE FROM branches WHERE branch_id = $1`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete branch %d: %w", id, err)
+	}
+	return requireRow(result, ErrBranchNotFound)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrCampaignNotFound = errors.New("points campaign not found")
+
+// Campaign is a time-limited earning multiplier, e.g. double points on Friday
+// afternoons. Items lists the items it applies to; empty means every item.
+type Campaign struct {
+	ID         int
+	Name       string
+	StartsAt   time.Time
+	EndsAt     time.Time
+	Multiplier float64
+	Items      []string
+}
+
+const campaignColumns = `campaign_id, name, starts_at, ends_at, multiplier, items`
+
+// CreateCampaign inserts a campaign and returns its ID
+func CreateCampaign(db *sql.DB, c Campaign) (int, error) {
+	var id int
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO points_campaigns (name, starts_at, ends_at, multiplier, items, created_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+		RETURNING campaign_id
+	`), c.Name, c.StartsAt, c.EndsAt, c.Multiplier, strings.Join(c.Items, ",")).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create points campaign: %w", err)
+	}
+	return id, nil
+}
+
+// GetCampaigns returns all campaigns, latest start first
+func GetCampaigns(db *sql.DB) ([]Campaign, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`SELECT ` + campaignColumns + ` FROM points_campaigns ORDER BY starts_at DESC, campaign_id DESC`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query points campaigns: %w", err)
+	}
+	return scanCampaigns(rows)
+}
+
+// GetActiveCampaigns returns the campaigns whose window contains at
+func GetActiveCampaigns(db *sql.DB, at time.Time) ([]Campaign, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT `+campaignColumns+` FROM points_campaigns
+		WHERE starts_at <= $1 AND ends_at > $1
+		ORDER BY campaign_id
+	`), at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active points campaigns: %w", err)
+	}
+	return scanCampaigns(rows)
+}
+
+// DeleteCampaign removes a campaign
+func DeleteCampaign(db *sql.DB, id int) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`DELETE FROM points_campaigns WHERE campaign_id = $1`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete points campaign %d: %w", id, err)
+	}
+	return requireRow(result, ErrCampaignNotFound)
+}
+
+func scanCampaigns(rows *sql.Rows) ([]Campaign, error) {
+	defer rows.Close()
+
+	var campaigns []Campaign
+	for rows.Next() {
+		var c Campaign
+		var items string
+		if err := rows.Scan(&c.ID, &c.Name, &c.StartsAt, &c.EndsAt, &c.Multiplier, &items); err != nil {
+			return nil, fmt.Errorf("failed to scan points campaign: %w", err)
+		}
+		if items != "" {
+			c.Items = strings.Split(items, ",")
+		}
+		campaigns = append(campaigns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating points campaigns: %w", err)
+	}
+	return campaigns, nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	ErrDeadLetterRequeued = errors.New("dead letter already requeued")
+)
+
+// DeadLetter is a queued message that was given up on
+type DeadLetter struct {
+	ID         int64
+	QueueID    int64  // the send_queue row it was given up on as
+	Payload    string // the send request as JSON
+	RequestID  string
+	Status     string // failed or expired
+	Attempts   int
+	LastError  string
+	QueuedAt   time.Time
+	CreatedAt  time.Time // when it was given up on
+	RequeuedAt *time.Time
+	RequeueID  *int64 // the send_queue row it was requeued as
+}
+
+// DeadLetterSend records that queued message id was given up on with status
+// failed or expired, and copies it to dead_letters in the same statement
+func DeadLetterSend(db *sql.DB, id int64, status string, attempts int, lastError string) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		WITH given_up AS (
+			UPDATE send_queue
+			SET status = $2, attempts = $3, last_error = $4, updated_at = CURRENT_TIMESTAMP
+			WHERE queue_id = $1
+			RETURNING queue_id, payload, request_id, status, attempts, last_error, created_at
+		)
+		INSERT INTO dead_letters (queue_id, payload, request_id, status, attempts, last_error, queued_at)
+		SELECT queue_id, payload, request_id, status, attempts, last_error, created_at FROM given_up
+	`), id, status, attempts, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to dead-letter queued send: %w", err)
+	}
+	return nil
+}
+
+const deadLetterColumns = `dead_letter_id, queue_id, payload, request_id, status, attempts,
+	COALESCE(last_error, ''), queued_at, created_at, requeued_at, requeue_id`
+
+// GetDeadLetters returns up to limit dead letters, newest first. Requeued ones
+// are left out unless includeRequeued.
+func GetDeadLetters(db *sql.DB, includeRequeued bool, limit int) ([]DeadLetter, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT `+deadLetterColumns+`
+		FROM dead_letters
+		WHERE $1 OR requeued_at IS NULL
+		ORDER BY dead_letter_id DESC
+		LIMIT $2
+	`), includeRequeued, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var letters []DeadLetter
+	for rows.Next() {
+		letter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, *letter)
+	}
+	return letters, rows.Err()
+}
+
+// RequeueDeadLetter puts dead letter id back on the send queue as a new
+// pending message due at now, with its attempts reset, and returns it
+func RequeueDeadLetter(db *sql.DB, id int64, now time.Time) (*DeadLetter, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	letter, err := scanDeadLetter(tx.QueryRow(`
+		SELECT `+deadLetterColumns+`
+		FROM dead_letters
+		WHERE dead_letter_id = $1
+		FOR UPDATE
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if letter.RequeuedAt != nil {
+		return nil, ErrDeadLetterRequeued
+	}
+
+	var queueID int64
+	err = tx.QueryRow(`
+		INSERT INTO send_queue (payload, request_id, attempts, next_attempt_at, last_error)
+		VALUES ($1, $2, 0, $3, $4)
+		RETURNING queue_id
+	`, letter.Payload, letter.RequestID, now, letter.LastError).Scan(&queueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to requeue dead letter: %w", err)
+	}
+	if _, err := tx.Exec(`
+		UPDATE dead_letters SET requeued_at = $2, requeue_id = $3 WHERE dead_letter_id = $1
+	`, id, now, queueID); err != nil {
+		return nil, fmt.Errorf("failed to requeue dead letter: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	letter.RequeuedAt = &now
+	letter.RequeueID = &queueID
+	return letter, nil
+}
+
+func scanDeadLetter(row rowScanner) (*DeadLetter, error) {
+	var letter DeadLetter
+	var requeuedAt sql.NullTime
+	var requeueID sql.NullInt64
+	err := row.Scan(&letter.ID, &letter.QueueID, &letter.Payload, &letter.RequestID, &letter.Status, &letter.Attempts,
+		&letter.LastError, &letter.QueuedAt, &letter.CreatedAt, &requeuedAt, &requeueID)
+	if err == sql.ErrNoRows {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan dead letter: %w", err)
+	}
+	if requeuedAt.Valid {
+		letter.RequeuedAt = &requeuedAt.Time
+	}
+	if requeueID.Valid {
+		letter.RequeueID = &requeueID.Int64
+	}
+	return &letter, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// GetDeviceKeys returns the sealed private keys of the WhatsApp device jid, or
+// nil if none are stored
+func GetDeviceKeys(db *sql.DB, jid string) ([]byte, error) {
+	var sealed []byte
+	err := db.QueryRow(DialectOf(db).Rebind(`SELECT sealed_keys FROM device_keys WHERE jid = $1`), jid).Scan(&sealed)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device keys: %w", err)
+	}
+	return sealed, nil
+}
+
+// PutDeviceKeys stores the sealed private keys of the WhatsApp device jid
+func PutDeviceKeys(db *sql.DB, jid string, sealed []byte) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		INSERT INTO device_keys (jid, sealed_keys)
+		VALUES ($1, $2)
+		ON CONFLICT (jid) DO UPDATE SET sealed_keys = EXCLUDED.sealed_keys, updated_at = CURRENT_TIMESTAMP
+	`), jid, sealed)
+	if err != nil {
+		return fmt.Errorf("failed to store device keys: %w", err)
+	}
+	return nil
+}
+
+// DeleteDeviceKeys removes the sealed private keys of the WhatsApp device jid
+func DeleteDeviceKeys(db *sql.DB, jid string) error {
+	if _, err := db.Exec(DialectOf(db).Rebind(`DELETE FROM device_keys WHERE jid = $1`), jid); err != nil {
+		return fmt.Errorf("failed to delete device keys: %w", err)
+	}
+	return nil
+}
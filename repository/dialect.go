@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Dialect is the SQL flavor of a database. Queries are written for Postgres,
+// with $1, $2... placeholders, and rewritten for the others.
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	SQLite
+)
+
+// DialectOf returns the dialect of db's driver. Drivers it does not know, such
+// as a wrapped Postgres driver, are taken for Postgres.
+func DialectOf(db *sql.DB) Dialect {
+	if strings.Contains(strings.ToLower(fmt.Sprintf("%T", db.Driver())), "sqlite") {
+		return SQLite
+	}
+	return Postgres
+}
+
+// Rebind rewrites the $N placeholders of query for d. SQLite reads $N as a
+// named parameter bound in order of first use, so "$2 ... $1" would swap the
+// arguments; its numbered form ?N keeps them in place. Placeholders inside
+// string literals are left alone.
+func (d Dialect) Rebind(query string) string {
+	if d != SQLite {
+		return query
+	}
+	var b strings.Builder
+	inString := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'':
+			inString = !inString
+		case c == '$' && !inString && i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9':
+			c = '?'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// rebind rewrites query for the dialect of exec. A *Tx rewrites its queries
+// itself, and a bare *sql.Tx cannot tell its driver, so both are left alone.
+func rebind(exec Executor, query string) string {
+	if db, ok := exec.(*sql.DB); ok {
+		return DialectOf(db).Rebind(query)
+	}
+	return query
+}
+
+// Tx is a transaction that rewrites the placeholders of its queries for the
+// dialect of the database it was begun on
+type Tx struct {
+	*sql.Tx
+	dialect Dialect
+}
+
+// Begin starts a transaction on db
+func Begin(db *sql.DB) (*Tx, error) {
+	return BeginTx(context.Background(), db, nil)
+}
+
+// BeginTx starts a transaction on db with ctx and opts
+func BeginTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, dialect: DialectOf(db)}, nil
+}
+
+// Exec executes query, rewritten for the transaction's dialect
+func (tx *Tx) Exec(query string, args ...any) (sql.Result, error) {
+	return tx.Tx.Exec(tx.dialect.Rebind(query), args...)
+}
+
+// ExecContext executes query, rewritten for the transaction's dialect
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+// Query runs query, rewritten for the transaction's dialect
+func (tx *Tx) Query(query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.Query(tx.dialect.Rebind(query), args...)
+}
+
+// QueryContext runs query, rewritten for the transaction's dialect
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+// QueryRow runs query, rewritten for the transaction's dialect
+func (tx *Tx) QueryRow(query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRow(tx.dialect.Rebind(query), args...)
+}
+
+// QueryRowContext runs query, rewritten for the transaction's dialect
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+// serialKey matches a Postgres auto-incrementing primary key column type
+var serialKey = regexp.MustCompile(`(?i)\b(BIG)?SERIAL\s+PRIMARY\s+KEY\b`)
+
+// Schema rewrites the Postgres-only types of a CREATE TABLE statement for d.
+// SQLite only numbers rows itself for an INTEGER PRIMARY KEY, so a SERIAL key
+// would stay NULL.
+func (d Dialect) Schema(ddl string) string {
+	if d != SQLite {
+		return ddl
+	}
+	return serialKey.ReplaceAllString(ddl, "INTEGER PRIMARY KEY AUTOINCREMENT")
+}
+
+// AddColumns adds to table those of columns, each a column definition such as
+// "push_name VARCHAR(100)", it does not have yet. SQLite has no ADD COLUMN IF
+// NOT EXISTS, so its columns are looked up first and added one at a time.
+func AddColumns(db *sql.DB, table string, columns ...string) error {
+	if DialectOf(db) != SQLite {
+		adds := make([]string, len(columns))
+		for i, column := range columns {
+			adds[i] = "ADD COLUMN IF NOT EXISTS " + column
+		}
+		_, err := db.Exec("ALTER TABLE " + table + " " + strings.Join(adds, ", "))
+		return err
+	}
+
+	existing, err := sqliteColumns(db, table)
+	if err != nil {
+		return err
+	}
+	for _, column := range columns {
+		if existing[strings.Fields(column)[0]] {
+			continue
+		}
+		if _, err := db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqliteColumns returns the names of table's columns in a SQLite database
+func sqliteColumns(db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestDialect_Rebind(t *testing.T) {
+	query := "SELECT * FROM members WHERE phone_number = $1 AND name <> '$2' AND member_id = $2"
+
+	if got := Postgres.Rebind(query); got != query {
+		t.Errorf("Postgres: expected the query unchanged, got %s", got)
+	}
+	want := "SELECT * FROM members WHERE phone_number = ?1 AND name <> '$2' AND member_id = ?2"
+	if got := SQLite.Rebind(query); got != want {
+		t.Errorf("SQLite: expected %s, got %s", want, got)
+	}
+}
+
+func TestDialect_Schema(t *testing.T) {
+	ddl := "CREATE TABLE images (image_id SERIAL PRIMARY KEY, member_id INTEGER)"
+
+	if got := Postgres.Schema(ddl); got != ddl {
+		t.Errorf("Postgres: expected the DDL unchanged, got %s", got)
+	}
+	want := "CREATE TABLE images (image_id INTEGER PRIMARY KEY AUTOINCREMENT, member_id INTEGER)"
+	if got := SQLite.Schema(ddl); got != want {
+		t.Errorf("SQLite: expected %s, got %s", want, got)
+	}
+}
+
+func TestTx_RebindsForSQLite(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("CREATE TABLE pairs (a TEXT, b TEXT)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	tx, err := Begin(db)
+	if err != nil {
+		t.Fatalf("Failed to begin: %v", err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec("INSERT INTO pairs (b, a) VALUES ($2, $1)", "first", "second"); err != nil {
+		t.Fatalf("Failed to insert: %v", err)
+	}
+	var a, b string
+	if err := tx.QueryRow("SELECT a, b FROM pairs WHERE a = $1", "first").Scan(&a, &b); err != nil {
+		t.Fatalf("Failed to select: %v", err)
+	}
+	if a != "first" || b != "second" {
+		t.Errorf("Expected a=first b=second, got a=%s b=%s", a, b)
+	}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// GetDisappearingTimer returns the disappearing-message timer, in seconds, of
+// senderID's chat with chatJID; 0 when messages there do not disappear
+func GetDisappearingTimer(db *sql.DB, senderID, chatJID string) (int, error) {
+	var seconds int
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		SELECT seconds FROM disappearing_timers WHERE sender_id = $1 AND chat_jid = $2
+	`), senderID, chatJID).Scan(&seconds)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get disappearing timer of %s: %w", chatJID, err)
+	}
+	return seconds, nil
+}
+
+// SetDisappearingTimer records the disappearing-message timer of senderID's
+// chat with chatJID. A timer of 0 turns disappearing messages off.
+func SetDisappearingTimer(db *sql.DB, senderID, chatJID string, seconds int) error {
+	var err error
+	if seconds > 0 {
+		_, err = db.Exec(DialectOf(db).Rebind(`
+			INSERT INTO disappearing_timers (sender_id, chat_jid, seconds, updated_at)
+			VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+			ON CONFLICT (sender_id, chat_jid) DO UPDATE SET seconds = EXCLUDED.seconds, updated_at = EXCLUDED.updated_at
+		`), senderID, chatJID, seconds)
+	} else {
+		_, err = db.Exec(DialectOf(db).Rebind(`DELETE FROM disappearing_timers WHERE sender_id = $1 AND chat_jid = $2`), senderID, chatJID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set disappearing timer of %s: %w", chatJID, err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Order delivery statuses
+const (
+	// OrderStatusDraft is an order a member placed on WhatsApp that staff have
+	// not confirmed yet
+	OrderStatusDraft          = "draft"
+	OrderStatusPending        = "pending"
+	OrderStatusReady          = "ready"
+	OrderStatusOutForDelivery = "out_for_delivery"
+	OrderStatusPickedUp       = "picked_up"
+	OrderStatusDelivered      = "delivered"
+	OrderStatusDeliveryFailed = "delivery_failed"
+)
+
+// Driver represents a delivery driver with their own WhatsApp number
+type Driver struct {
+	DriverID    int
+	Name        string
+	PhoneNumber string
+	IsActive    bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// OrderDispatchInfo holds what a driver needs to know to deliver an order
+type OrderDispatchInfo struct {
+	OrderID     int
+	Status      string
+	MemberName  string
+	MemberPhone string
+	Address     string
+	TotalPrice  float64
+	Latitude    sql.NullFloat64 // Order pickup pin, falling back to the member's last shared pin
+	Longitude   sql.NullFloat64
+}
+
+// CreateDriver inserts a driver and returns its ID
+func CreateDriver(db *sql.DB, name, phoneNumber string) (int, error) {
+	query := `
+		INSERT INTO drivers (name, phone_number, is_active, created_at, updated_at)
+		VALUES ($1, $2, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING driver_id
+	`
+	var driverID int
+	if err := db.QueryRow(DialectOf(db).Rebind(query), name, phoneNumber).Scan(&driverID); err != nil {
+		return 0, fmt.Errorf("failed to create driver: %w", err)
+	}
+	return driverID, nil
+}
+
+// GetAllDrivers retrieves all drivers ordered by name
+func GetAllDrivers(db *sql.DB) ([]Driver, error) {
+	query := `
+		SELECT driver_id, name, phone_number, is_active, created_at, updated_at
+		FROM drivers
+		ORDER BY name ASC
+	`
+	rows, err := db.Query(DialectOf(db).Rebind(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query drivers: %w", err)
+	}
+	defer rows.Close()
+
+	var drivers []Driver
+	for rows.Next() {
+		var d Driver
+		if err := rows.Scan(&d.DriverID, &d.Name, &d.PhoneNumber, &d.IsActive, &d.CreatedAt, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan driver: %w", err)
+		}
+		drivers = append(drivers, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating drivers: %w", err)
+	}
+	return drivers, nil
+}
+
+// GetDriverByID retrieves a driver by ID, returning nil when not found
+func GetDriverByID(db *sql.DB, driverID int) (*Driver, error) {
+	return getDriver(db, "driver_id = $1", driverID)
+}
+
+// GetDriverByPhoneNumber retrieves a driver by phone number, returning nil when not found
+func GetDriverByPhoneNumber(db *sql.DB, phoneNumber string) (*Driver, error) {
+	return getDriver(db, "phone_number = $1", phoneNumber)
+}
+
+func getDriver(db *sql.DB, where string, arg interface{}) (*Driver, error) {
+	query := `
+		SELECT driver_id, name, phone_number, is_active, created_at, updated_at
+		FROM drivers
+		WHERE ` + where
+
+	var d Driver
+	err := db.QueryRow(DialectOf(db).Rebind(query), arg).Scan(&d.DriverID, &d.Name, &d.PhoneNumber, &d.IsActive, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get driver: %w", err)
+	}
+	return &d, nil
+}
+
+// GetOrderDispatchInfo loads the order, member and location details for a dispatch message
+func GetOrderDispatchInfo(db *sql.DB, orderID int) (*OrderDispatchInfo, error) {
+	query := `
+		SELECT o.order_id, COALESCE(o.status, 'pending'), COALESCE(m.name, ''), COALESCE(m.phone_number, ''),
+			COALESCE(m.address, ''), COALESCE(o.total_price, 0),
+			COALESCE(o.pickup_latitude, l.latitude), COALESCE(o.pickup_longitude, l.longitude)
+		FROM orders o
+		LEFT JOIN members m ON m.member_id = o.member_id
+		LEFT JOIN member_locations l ON l.member_id = o.member_id
+		WHERE o.order_id = $1
+	`
+	var info OrderDispatchInfo
+	err := db.QueryRow(DialectOf(db).Rebind(query), orderID).Scan(
+		&info.OrderID,
+		&info.Status,
+		&info.MemberName,
+		&info.MemberPhone,
+		&info.Address,
+		&info.TotalPrice,
+		&info.Latitude,
+		&info.Longitude,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get order dispatch info: %w", err)
+	}
+	return &info, nil
+}
+
+// AssignDriverToOrder assigns a driver and marks the order out for delivery
+func AssignDriverToOrder(db *sql.DB, orderID, driverID int) error {
+	query := `
+		UPDATE orders
+		SET driver_id = $1, status = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE order_id = $3
+	`
+	if _, err := db.Exec(DialectOf(db).Rebind(query), driverID, OrderStatusOutForDelivery, orderID); err != nil {
+		return fmt.Errorf("failed to assign driver: %w", err)
+	}
+	return nil
+}
+
+// UpdateOrderStatusForDriver updates an order's status only if it is assigned to
+// the given driver. It returns false when no such assignment exists.
+func UpdateOrderStatusForDriver(db *sql.DB, orderID, driverID int, status string) (bool, error) {
+	query := `
+		UPDATE orders
+		SET status = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE order_id = $2 AND driver_id = $3
+	`
+	result, err := db.Exec(DialectOf(db).Rebind(query), status, orderID, driverID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update order status: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
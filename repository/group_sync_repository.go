@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// GroupSyncChange is a membership change made by the VIP group sync
+type GroupSyncChange struct {
+	ID          int
+	GroupJID    string
+	PhoneNumber string
+	Action      string
+	Error       string
+	CreatedAt   time.Time
+}
+
+// InsertGroupSyncChange records a membership change
+func InsertGroupSyncChange(db *sql.DB, c GroupSyncChange) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		INSERT INTO group_sync_changes (group_jid, phone_number, action, error, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+	`), c.GroupJID, c.PhoneNumber, c.Action, c.Error)
+	if err != nil {
+		return fmt.Errorf("failed to record group sync change: %w", err)
+	}
+	return nil
+}
+
+// GetGroupSyncChanges returns the limit most recent changes to a group, newest first
+func GetGroupSyncChanges(db *sql.DB, groupJID string, limit int) ([]GroupSyncChange, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT change_id, group_jid, phone_number, action, error, created_at
+		FROM group_sync_changes
+		WHERE group_jid = $1
+		ORDER BY created_at DESC, change_id DESC
+		LIMIT $2
+	`), groupJID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query group sync changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []GroupSyncChange
+	for rows.Next() {
+		var c GroupSyncChange
+		if err := rows.Scan(&c.ID, &c.GroupJID, &c.PhoneNumber, &c.Action, &c.Error, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan group sync change: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating group sync changes: %w", err)
+	}
+	return changes, nil
+}
+
+// GetGroupSyncPhones returns the phone numbers with a change of action to a
+// group since since
+func GetGroupSyncPhones(db *sql.DB, groupJID, action string, since time.Time) ([]string, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT DISTINCT phone_number
+		FROM group_sync_changes
+		WHERE group_jid = $1 AND action = $2 AND created_at >= $3
+	`), groupJID, action, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query group sync phones: %w", err)
+	}
+	defer rows.Close()
+
+	var phones []string
+	for rows.Next() {
+		var phone string
+		if err := rows.Scan(&phone); err != nil {
+			return nil, fmt.Errorf("failed to scan group sync phone: %w", err)
+		}
+		phones = append(phones, phone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating group sync phones: %w", err)
+	}
+	return phones, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// IdempotencyRecord is the stored outcome of a request made with an Idempotency-Key
+type IdempotencyRecord struct {
+	Key         string
+	Fingerprint string // hash of the request the key was first used with
+	StatusCode  int
+	Response    string
+	Completed   bool // false while the first request is still being processed
+}
+
+// ReserveIdempotencyKey claims key for a request with the given fingerprint. It
+// returns false when the key is already held. Keys created before expiredBefore,
+// and unfinished ones started before abandonedBefore, are taken over.
+func ReserveIdempotencyKey(db *sql.DB, key, fingerprint string, expiredBefore, abandonedBefore time.Time) (bool, error) {
+	var reserved string
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO idempotency_keys (idem_key, fingerprint)
+		VALUES ($1, $2)
+		ON CONFLICT (idem_key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, response = NULL,
+			created_at = CURRENT_TIMESTAMP, completed_at = NULL
+		WHERE idempotency_keys.created_at < $3
+			OR (idempotency_keys.completed_at IS NULL AND idempotency_keys.created_at < $4)
+		RETURNING idem_key
+	`), key, fingerprint, expiredBefore, abandonedBefore).Scan(&reserved)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	return true, nil
+}
+
+// GetIdempotencyRecord returns the record for key, or nil if there is none
+func GetIdempotencyRecord(db *sql.DB, key string) (*IdempotencyRecord, error) {
+	var r IdempotencyRecord
+	var statusCode sql.NullInt64
+	var response sql.NullString
+	var completedAt sql.NullTime
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		SELECT idem_key, fingerprint, status_code, response, completed_at
+		FROM idempotency_keys WHERE idem_key = $1
+	`), key).Scan(&r.Key, &r.Fingerprint, &statusCode, &response, &completedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	r.StatusCode = int(statusCode.Int64)
+	r.Response = response.String
+	r.Completed = completedAt.Valid
+	return &r, nil
+}
+
+// CompleteIdempotencyKey stores the response to replay for key
+func CompleteIdempotencyKey(db *sql.DB, key string, statusCode int, response string) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE idempotency_keys SET status_code = $2, response = $3, completed_at = CURRENT_TIMESTAMP
+		WHERE idem_key = $1
+	`), key, statusCode, response)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteIdempotencyKey frees key so the request can be retried
+func DeleteIdempotencyKey(db *sql.DB, key string) error {
+	if _, err := db.Exec(DialectOf(db).Rebind(`DELETE FROM idempotency_keys WHERE idem_key = $1`), key); err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 // SaveImageURL saves the image URL to the database
 func SaveImageURL(db *sql.DB, memberID int, imageURL string) error {
 	query := "INSERT INTO images (member_id, image_url) VALUES ($1, $2)"
-	_, err := db.Exec(query, memberID, imageURL)
+	_, err := db.Exec(DialectOf(db).Rebind(query), memberID, imageURL)
 	if err != nil {
 		return fmt.Errorf("failed to save image URL: %w", err)
 	}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Inbound event outcomes
+const (
+	OutcomeOK    = "ok"
+	OutcomeError = "error"
+	// OutcomeThrottled marks messages left unanswered by the per-contact reply limit
+	OutcomeThrottled = "throttled"
+	// OutcomeMuted marks messages ignored because the contact was muted as a suspected bot
+	OutcomeMuted = "muted"
+)
+
+// InboundEvent is one recorded inbound command with its parsed interpretation
+// and the outcome the handler reached
+type InboundEvent struct {
+	EventID      int
+	MessageID    string
+	SenderJID    string
+	RawText      string
+	Command      string
+	Args         map[string]string
+	Outcome      string
+	ErrorMessage string
+	Variant      string // soft-launch flow the conversation was given, empty when none
+	CreatedAt    time.Time
+}
+
+// InsertInboundEvent appends an inbound command to the event log
+func InsertInboundEvent(db *sql.DB, evt InboundEvent) error {
+	args, err := json.Marshal(evt.Args)
+	if err != nil {
+		return fmt.Errorf("failed to encode event args: %w", err)
+	}
+
+	query := `
+		INSERT INTO inbound_events (message_id, sender_jid, raw_text, command, args, outcome, error_message, variant, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP)
+	`
+	_, err = db.Exec(DialectOf(db).Rebind(query), evt.MessageID, evt.SenderJID, evt.RawText, evt.Command, string(args), evt.Outcome, evt.ErrorMessage, evt.Variant)
+	if err != nil {
+		return fmt.Errorf("failed to insert inbound event: %w", err)
+	}
+	return nil
+}
+
+// GetInboundEventsByID retrieves the given events in ID order
+func GetInboundEventsByID(db *sql.DB, eventIDs []int) ([]InboundEvent, error) {
+	query := inboundEventSelect + `WHERE event_id = ANY($1) ORDER BY event_id ASC`
+	return queryInboundEvents(db, query, pq.Array(eventIDs))
+}
+
+// GetRecentInboundEventsBySender retrieves a sender's latest events, oldest first
+func GetRecentInboundEventsBySender(db *sql.DB, phoneNumber string, limit int) ([]InboundEvent, error) {
+	query := `SELECT * FROM (` + inboundEventSelect + `
+		WHERE sender_jid LIKE $1 || '@%' OR sender_jid LIKE $1 || ':%'
+		ORDER BY event_id DESC LIMIT $2
+	) recent ORDER BY event_id ASC`
+	return queryInboundEvents(db, query, phoneNumber, limit)
+}
+
+const inboundEventSelect = `
+	SELECT event_id, COALESCE(message_id, ''), sender_jid, COALESCE(raw_text, ''), command,
+		COALESCE(args, ''), outcome, COALESCE(error_message, ''), variant, created_at
+	FROM inbound_events
+`
+
+func queryInboundEvents(db *sql.DB, query string, args ...interface{}) ([]InboundEvent, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inbound events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []InboundEvent
+	for rows.Next() {
+		var e InboundEvent
+		var rawArgs string
+		if err := rows.Scan(&e.EventID, &e.MessageID, &e.SenderJID, &e.RawText, &e.Command,
+			&rawArgs, &e.Outcome, &e.ErrorMessage, &e.Variant, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan inbound event: %w", err)
+		}
+		if rawArgs != "" {
+			if err := json.Unmarshal([]byte(rawArgs), &e.Args); err != nil {
+				return nil, fmt.Errorf("failed to decode args of event %d: %w", e.EventID, err)
+			}
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating inbound events: %w", err)
+	}
+	return events, nil
+}
+
+// VariantCompletion counts the conversations of one soft-launch variant that
+// were shown the menu, and those that went on to pick one of its options
+type VariantCompletion struct {
+	Variant       string
+	Conversations int
+	Completions   int
+}
+
+// GetVariantCompletions counts, per soft-launch variant, the contacts shown
+// the menu since since and those of them that sent one of the option commands
+// successfully within window of it
+func GetVariantCompletions(db *sql.DB, menuCommand string, optionCommands []string, since time.Time, window time.Duration) ([]VariantCompletion, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT m.variant, COUNT(DISTINCT m.sender_jid), COUNT(DISTINCT o.sender_jid)
+		FROM inbound_events m
+		LEFT JOIN inbound_events o ON o.sender_jid = m.sender_jid AND o.event_id > m.event_id
+			AND o.created_at <= m.created_at + $4 * INTERVAL '1 second'
+			AND o.command = ANY($2) AND o.outcome = $5
+		WHERE m.command = $1 AND m.variant <> '' AND m.created_at >= $3
+		GROUP BY m.variant
+		ORDER BY m.variant
+	`), menuCommand, pq.Array(optionCommands), since, int(window/time.Second), OutcomeOK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query variant completions: %w", err)
+	}
+	defer rows.Close()
+
+	var completions []VariantCompletion
+	for rows.Next() {
+		var c VariantCompletion
+		if err := rows.Scan(&c.Variant, &c.Conversations, &c.Completions); err != nil {
+			return nil, fmt.Errorf("failed to scan variant completions: %w", err)
+		}
+		completions = append(completions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating variant completions: %w", err)
+	}
+	return completions, nil
+}
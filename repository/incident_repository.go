@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrIncidentNotFound = errors.New("incident not found")
+
+// IncidentStatusResolved is the status of an incident that is over
+const IncidentStatusResolved = "resolved"
+
+// Incident is an outage or degradation announced on the public status page
+type Incident struct {
+	ID         int
+	Title      string
+	Message    string
+	Status     string
+	StartedAt  time.Time
+	UpdatedAt  time.Time
+	ResolvedAt sql.NullTime // set while the status is resolved
+}
+
+const incidentColumns = `incident_id, title, message, status, started_at, updated_at, resolved_at`
+
+// CreateIncident inserts an incident and returns its ID
+func CreateIncident(db *sql.DB, i Incident) (int, error) {
+	var id int
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO incidents (title, message, status, started_at, updated_at, resolved_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CASE WHEN $4 THEN CURRENT_TIMESTAMP END)
+		RETURNING incident_id
+	`), i.Title, i.Message, i.Status, i.Status == IncidentStatusResolved).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create incident: %w", err)
+	}
+	return id, nil
+}
+
+// GetIncidents returns the limit most recently started incidents, newest first
+func GetIncidents(db *sql.DB, limit int) ([]Incident, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`SELECT `+incidentColumns+` FROM incidents ORDER BY started_at DESC, incident_id DESC LIMIT $1`), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incidents: %w", err)
+	}
+	defer rows.Close()
+
+	var incidents []Incident
+	for rows.Next() {
+		i, err := scanIncident(rows)
+		if err != nil {
+			return nil, err
+		}
+		incidents = append(incidents, *i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating incidents: %w", err)
+	}
+	return incidents, nil
+}
+
+// GetIncident returns an incident, or ErrIncidentNotFound
+func GetIncident(db *sql.DB, id int) (*Incident, error) {
+	i, err := scanIncident(db.QueryRow(DialectOf(db).Rebind(`SELECT `+incidentColumns+` FROM incidents WHERE incident_id = $1`), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrIncidentNotFound
+	}
+	return i, err
+}
+
+// UpdateIncident replaces the title, message and status of an incident. It
+// is stamped resolved when its status becomes resolved, and reopened when it
+// changes from resolved to anything else.
+func UpdateIncident(db *sql.DB, i Incident) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE incidents
+		SET title = $2, message = $3, status = $4, updated_at = CURRENT_TIMESTAMP,
+			resolved_at = CASE WHEN $5 THEN COALESCE(resolved_at, CURRENT_TIMESTAMP) END
+		WHERE incident_id = $1
+	`), i.ID, i.Title, i.Message, i.Status, i.Status == IncidentStatusResolved)
+	if err != nil {
+		return fmt.Errorf("failed to update incident %d: %w", i.ID, err)
+	}
+	return requireRow(result, ErrIncidentNotFound)
+}
+
+func scanIncident(row rowScanner) (*Incident, error) {
+	var i Incident
+	err := row.Scan(&i.ID, &i.Title, &i.Message, &i.Status, &i.StartedAt, &i.UpdatedAt, &i.ResolvedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan incident: %w", err)
+	}
+	return &i, nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Label is a WhatsApp Business label of a sender, as last synced from WhatsApp
+type Label struct {
+	SenderID  string
+	LabelID   string // numeric ID WhatsApp knows the label by
+	Name      string
+	Color     int // index into WhatsApp's label palette
+	Deleted   bool
+	UpdatedAt time.Time
+}
+
+// GetLabels returns the labels of senderID ordered by label ID, including the
+// deleted ones when includeDeleted is set
+func GetLabels(db *sql.DB, senderID string, includeDeleted bool) ([]Label, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT sender_id, label_id, name, color, deleted, updated_at FROM whatsapp_labels
+		WHERE sender_id = $1 AND ($2 OR NOT deleted)
+		ORDER BY LENGTH(label_id), label_id
+	`), senderID, includeDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []Label
+	for rows.Next() {
+		var l Label
+		if err := rows.Scan(&l.SenderID, &l.LabelID, &l.Name, &l.Color, &l.Deleted, &l.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan label: %w", err)
+		}
+		labels = append(labels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating labels: %w", err)
+	}
+	return labels, nil
+}
+
+// SaveLabel records a label of senderID as WhatsApp reported or accepted it.
+// Deleting a label also takes it off its chats.
+func SaveLabel(db *sql.DB, senderID, labelID, name string, color int, deleted bool) (*Label, error) {
+	l := Label{SenderID: senderID, LabelID: labelID, Name: name, Color: color, Deleted: deleted}
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO whatsapp_labels (sender_id, label_id, name, color, deleted, updated_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+		ON CONFLICT (sender_id, label_id) DO UPDATE
+		SET name = EXCLUDED.name, color = EXCLUDED.color, deleted = EXCLUDED.deleted, updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`), senderID, labelID, name, color, deleted).Scan(&l.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save label %s of %s: %w", labelID, senderID, err)
+	}
+
+	if deleted {
+		if _, err := db.Exec(DialectOf(db).Rebind(`DELETE FROM whatsapp_label_chats WHERE sender_id = $1 AND label_id = $2`), senderID, labelID); err != nil {
+			return nil, fmt.Errorf("failed to clear chats of label %s of %s: %w", labelID, senderID, err)
+		}
+	}
+	return &l, nil
+}
+
+// SetLabelChat records that senderID put label labelID on chatJID, or took it
+// off when labeled is false
+func SetLabelChat(db *sql.DB, senderID, labelID, chatJID string, labeled bool) error {
+	var err error
+	if labeled {
+		_, err = db.Exec(DialectOf(db).Rebind(`
+			INSERT INTO whatsapp_label_chats (sender_id, label_id, chat_jid, created_at)
+			VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+			ON CONFLICT DO NOTHING
+		`), senderID, labelID, chatJID)
+	} else {
+		_, err = db.Exec(DialectOf(db).Rebind(`
+			DELETE FROM whatsapp_label_chats WHERE sender_id = $1 AND label_id = $2 AND chat_jid = $3
+		`), senderID, labelID, chatJID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update label %s on %s: %w", labelID, chatJID, err)
+	}
+	return nil
+}
+
+// GetLabelChats returns the JIDs of the chats senderID put label labelID on
+func GetLabelChats(db *sql.DB, senderID, labelID string) ([]string, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT chat_jid FROM whatsapp_label_chats
+		WHERE sender_id = $1 AND label_id = $2
+		ORDER BY created_at, chat_jid
+	`), senderID, labelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chats of label %s: %w", labelID, err)
+	}
+	defer rows.Close()
+
+	var chats []string
+	for rows.Next() {
+		var chat string
+		if err := rows.Scan(&chat); err != nil {
+			return nil, fmt.Errorf("failed to scan label chat: %w", err)
+		}
+		chats = append(chats, chat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating label chats: %w", err)
+	}
+	return chats, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// MemberLocation represents the last location pin shared by a member
+type MemberLocation struct {
+	MemberID    int
+	PhoneNumber string
+	Latitude    float64
+	Longitude   float64
+	Label       string
+	Address     string
+	UpdatedAt   time.Time
+}
+
+// UpsertMemberLocation stores the member's latest pickup/delivery coordinates
+func UpsertMemberLocation(db *sql.DB, memberID int, latitude, longitude float64, label, address string) error {
+	query := `
+		INSERT INTO member_locations (member_id, latitude, longitude, label, address, updated_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+		ON CONFLICT (member_id) DO UPDATE SET
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			label = EXCLUDED.label,
+			address = EXCLUDED.address,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	if _, err := db.Exec(DialectOf(db).Rebind(query), memberID, latitude, longitude, label, address); err != nil {
+		return fmt.Errorf("failed to save member location: %w", err)
+	}
+	return nil
+}
+
+// UpdateLatestOrderPickupLocation copies the coordinates onto the member's most
+// recent order. It returns the order ID, or 0 when the member has no orders.
+func UpdateLatestOrderPickupLocation(db *sql.DB, memberID int, latitude, longitude float64) (int, error) {
+	query := `
+		UPDATE orders
+		SET pickup_latitude = $1, pickup_longitude = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE order_id = (
+			SELECT order_id FROM orders WHERE member_id = $3 ORDER BY order_date DESC LIMIT 1
+		)
+		RETURNING order_id
+	`
+	var orderID int
+	err := db.QueryRow(DialectOf(db).Rebind(query), latitude, longitude, memberID).Scan(&orderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to update order pickup location: %w", err)
+	}
+	return orderID, nil
+}
+
+// GetMemberLocationByPhoneNumber retrieves the stored location for a member's phone number
+func GetMemberLocationByPhoneNumber(db *sql.DB, phoneNumber string) (*MemberLocation, error) {
+	query := `
+		SELECT l.member_id, m.phone_number, l.latitude, l.longitude,
+			COALESCE(l.label, ''), COALESCE(l.address, ''), l.updated_at
+		FROM member_locations l
+		JOIN members m ON m.member_id = l.member_id
+		WHERE m.phone_number = $1
+	`
+	var loc MemberLocation
+	err := db.QueryRow(DialectOf(db).Rebind(query), phoneNumber).Scan(
+		&loc.MemberID,
+		&loc.PhoneNumber,
+		&loc.Latitude,
+		&loc.Longitude,
+		&loc.Label,
+		&loc.Address,
+		&loc.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get member location: %w", err)
+	}
+	return &loc, nil
+}
@@ -6,7 +6,7 @@ import (
 	"time"
 )
 
-// Member represents a user in the MEMBER table
+// Member represents a user in the members table
 type Member struct {
 	MemberID    int
 	PhoneNumber string
@@ -19,20 +19,36 @@ type Member struct {
 // RegisterMember adds a new member to the database
 func RegisterMember(db *sql.DB, name, address, phoneNumber string) error {
 	// Start a transaction for member registration
-	tx, err := db.Begin()
+	tx, err := Begin(db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %v", err)
 	}
 
-	// Insert into MEMBER table with current timestamp and return the member ID
-	query := `INSERT INTO members (name, address, phone_number, created_at, updated_at) 
-              VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) RETURNING member_id`
+	if _, err := registerMemberTx(tx, name, address, phoneNumber); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Commit the transaction
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return nil
+}
+
+// registerMemberTx inserts the member and their initial point record and marks
+// a matching prospect as converted. It returns the new member ID.
+func registerMemberTx(tx *Tx, name, address, phoneNumber string) (int, error) {
+	// Insert into the members table with current timestamp and return the member ID.
+	// A push name already captured while the number was a prospect is carried over.
+	query := `INSERT INTO members (name, address, phone_number, push_name, created_at, updated_at) 
+              VALUES ($1, $2, $3, (SELECT push_name FROM prospects WHERE phone_number = $3), CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) RETURNING member_id`
 
 	var memberID int
-	err = tx.QueryRow(query, name, address, phoneNumber).Scan(&memberID)
+	err := tx.QueryRow(query, name, address, phoneNumber).Scan(&memberID)
 	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to register member: %v", err)
+		return 0, fmt.Errorf("failed to register member: %v", err)
 	}
 
 	// Create initial point record for the member
@@ -40,16 +56,17 @@ func RegisterMember(db *sql.DB, name, address, phoneNumber string) error {
                    VALUES ($1, 0, 0, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)`
 	_, err = tx.Exec(pointQuery, memberID)
 	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to initialize points: %v", err)
+		return 0, fmt.Errorf("failed to initialize points: %v", err)
 	}
 
-	// Commit the transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %v", err)
+	// Close the lead if this number was tracked as a prospect
+	prospectQuery := `UPDATE prospects SET converted_member_id = $1, converted_at = CURRENT_TIMESTAMP
+                      WHERE phone_number = $2 AND converted_member_id IS NULL`
+	if _, err := tx.Exec(prospectQuery, memberID, phoneNumber); err != nil {
+		return 0, fmt.Errorf("failed to mark prospect converted: %v", err)
 	}
 
-	return nil
+	return memberID, nil
 }
 
 // IsMemberRegistered checks if a user is already registered
@@ -57,7 +74,7 @@ func IsMemberRegistered(db *sql.DB, phoneNumber string) (bool, error) {
 	query := `SELECT COUNT(*) FROM members WHERE phone_number = $1`
 
 	var count int
-	err := db.QueryRow(query, phoneNumber).Scan(&count)
+	err := db.QueryRow(DialectOf(db).Rebind(query), phoneNumber).Scan(&count)
 	if err != nil {
 		return false, err
 	}
@@ -69,7 +86,7 @@ func IsMemberRegistered(db *sql.DB, phoneNumber string) (bool, error) {
 func GetMemberIDByPhoneNumber(db *sql.DB, phoneNumber string) (int, error) {
 	var memberID int
 	query := "SELECT member_id FROM members WHERE phone_number = $1"
-	err := db.QueryRow(query, phoneNumber).Scan(&memberID)
+	err := db.QueryRow(DialectOf(db).Rebind(query), phoneNumber).Scan(&memberID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return 0, fmt.Errorf("no member found with phone number: %s", phoneNumber)
@@ -83,7 +100,7 @@ func GetMemberIDByPhoneNumber(db *sql.DB, phoneNumber string) (int, error) {
 func GetMemberNameByID(db *sql.DB, memberID int) (string, error) {
 	var memberName string
 	query := "SELECT name FROM members WHERE member_id = $1"
-	err := db.QueryRow(query, memberID).Scan(&memberName)
+	err := db.QueryRow(DialectOf(db).Rebind(query), memberID).Scan(&memberName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return "", fmt.Errorf("no member found with ID: %d", memberID)
@@ -98,7 +115,7 @@ func GetMemberDetailsByPhoneNumber(db *sql.DB, phoneNumber string) (int, string,
 	var memberID int
 	var memberName string
 	query := "SELECT member_id, name FROM members WHERE phone_number = $1"
-	err := db.QueryRow(query, phoneNumber).Scan(&memberID, &memberName)
+	err := db.QueryRow(DialectOf(db).Rebind(query), phoneNumber).Scan(&memberID, &memberName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return 0, "", fmt.Errorf("no member found with phone number: %s", phoneNumber)
@@ -107,3 +124,134 @@ func GetMemberDetailsByPhoneNumber(db *sql.DB, phoneNumber string) (int, string,
 	}
 	return memberID, memberName, nil
 }
+
+// GetMemberProfile returns the member registered with the given phone number,
+// or nil when there is none
+func GetMemberProfile(db *sql.DB, phoneNumber string) (*Member, error) {
+	var m Member
+	query := `SELECT member_id, phone_number, name, COALESCE(address, ''), created_at, updated_at
+              FROM members WHERE phone_number = $1`
+	err := db.QueryRow(DialectOf(db).Rebind(query), phoneNumber).Scan(&m.MemberID, &m.PhoneNumber, &m.Name, &m.Address, &m.CreatedAt, &m.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve member profile: %w", err)
+	}
+	return &m, nil
+}
+
+// UpdateMemberProfile changes the name and address of the member with the given
+// phone number. It returns false when no member matches.
+func UpdateMemberProfile(db *sql.DB, phoneNumber, name, address string) (bool, error) {
+	query := `UPDATE members SET name = $2, address = $3, updated_at = CURRENT_TIMESTAMP
+              WHERE phone_number = $1`
+	result, err := db.Exec(DialectOf(db).Rebind(query), phoneNumber, name, address)
+	if err != nil {
+		return false, fmt.Errorf("failed to update member profile: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update member profile: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// UpdateMemberPushName stores the WhatsApp push name for the member with the given
+// phone number. It returns false when no member matches or the name is unchanged.
+func UpdateMemberPushName(db *sql.DB, phoneNumber, pushName string) (bool, error) {
+	query := `
+		UPDATE members SET push_name = $2, push_name_updated_at = CURRENT_TIMESTAMP
+		WHERE phone_number = $1 AND push_name IS DISTINCT FROM $2`
+	result, err := db.Exec(DialectOf(db).Rebind(query), phoneNumber, pushName)
+	if err != nil {
+		return false, fmt.Errorf("failed to update member push name: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update member push name: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// MemberPoints is a member's phone number with their lifetime accumulated points
+type MemberPoints struct {
+	PhoneNumber       string
+	AccumulatedPoints int
+}
+
+// GetMembersWithPoints returns every member with a phone number and at least
+// minPoints accumulated points, ordered by member ID
+func GetMembersWithPoints(db *sql.DB, minPoints int) ([]MemberPoints, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT m.phone_number, COALESCE(p.accumulated_points, 0)
+		FROM members m
+		LEFT JOIN points p ON p.member_id = m.member_id
+		WHERE m.phone_number IS NOT NULL AND m.phone_number <> ''
+		  AND COALESCE(p.accumulated_points, 0) >= $1
+		ORDER BY m.member_id
+	`), minPoints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query members with points: %w", err)
+	}
+	defer rows.Close()
+
+	var members []MemberPoints
+	for rows.Next() {
+		var m MemberPoints
+		if err := rows.Scan(&m.PhoneNumber, &m.AccumulatedPoints); err != nil {
+			return nil, fmt.Errorf("failed to scan member points: %w", err)
+		}
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating members with points: %w", err)
+	}
+	return members, nil
+}
+
+// EraseMember removes the personal data of the member with the given phone
+// number: their receipt photos, location pins, pickup bookings, message log
+// and prospect record are deleted, and the member row keeps only its ID, so
+// points, orders and vouchers stay in the books without naming anyone. It
+// returns false when no member matches.
+func EraseMember(db *sql.DB, phoneNumber string) (bool, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var memberID int
+	err = tx.QueryRow(`SELECT member_id FROM members WHERE phone_number = $1`, phoneNumber).Scan(&memberID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to look up member: %w", err)
+	}
+
+	statements := []struct {
+		query string
+		arg   any
+	}{
+		{`DELETE FROM images WHERE member_id = $1`, memberID},
+		{`DELETE FROM member_locations WHERE member_id = $1`, memberID},
+		{`DELETE FROM pickup_bookings WHERE member_id = $1`, memberID},
+		{`UPDATE orders SET pickup_latitude = NULL, pickup_longitude = NULL WHERE member_id = $1`, memberID},
+		{`DELETE FROM message_history WHERE recipient = $1`, phoneNumber},
+		{`DELETE FROM prospects WHERE phone_number = $1`, phoneNumber},
+		{`UPDATE members SET phone_number = NULL, name = NULL, address = NULL, push_name = NULL,
+		  push_name_updated_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE member_id = $1`, memberID},
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(stmt.query, stmt.arg); err != nil {
+			return false, fmt.Errorf("failed to erase member: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
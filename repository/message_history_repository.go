@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Outcomes of a send recorded in message_history
+const (
+	MessageHistorySent    = "sent"
+	MessageHistoryFailed  = "failed"
+	MessageHistoryRevoked = "revoked" // sent, then deleted for everyone
+)
+
+// MessageHistoryEntry is one message sent, or attempted, through the API
+type MessageHistoryEntry struct {
+	ID            int64
+	Recipient     string
+	SenderID      string // empty for the default sender
+	Kind          string // text, interactive, image, document, audio, location, contact or reaction
+	Content       string // empty when the tenant's message log is private
+	ContentHash   string // SHA-256 (hex) of the content, kept in every mode
+	ContentLength int    // length of the content in characters
+	Status        string
+	MessageID     string // WhatsApp message ID of a sent message
+	Error         string // why a failed send failed
+	RequestID     string // X-Request-ID of the API call that sent it
+	CreatedAt     time.Time
+	SentAt        sql.NullTime
+}
+
+// MessageHistoryFilter narrows a message history query. Empty fields match
+// everything; Until is exclusive.
+type MessageHistoryFilter struct {
+	Recipient string
+	SenderID  string
+	RequestID string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+	Offset    int
+}
+
+// InsertMessageHistory records a send
+func InsertMessageHistory(db *sql.DB, e MessageHistoryEntry) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		INSERT INTO message_history (recipient, sender_id, kind, content, content_hash, content_length, status, message_id, error, request_id, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`), e.Recipient, e.SenderID, e.Kind, e.Content, e.ContentHash, e.ContentLength, e.Status, e.MessageID, e.Error, e.RequestID, e.SentAt)
+	if err != nil {
+		return fmt.Errorf("failed to record message history: %w", err)
+	}
+	return nil
+}
+
+// GetMessageHistory returns a page of the sends matching filter, newest first,
+// and the number of matching sends across all pages
+func GetMessageHistory(db *sql.DB, filter MessageHistoryFilter) ([]MessageHistoryEntry, int, error) {
+	var since, until sql.NullTime
+	if !filter.Since.IsZero() {
+		since = sql.NullTime{Time: filter.Since, Valid: true}
+	}
+	if !filter.Until.IsZero() {
+		until = sql.NullTime{Time: filter.Until, Valid: true}
+	}
+	const where = `
+		WHERE ($1 = '' OR recipient = $1)
+			AND ($2 = '' OR sender_id = $2)
+			AND ($3::timestamp IS NULL OR created_at >= $3)
+			AND ($4::timestamp IS NULL OR created_at < $4)
+			AND ($5 = '' OR request_id = $5)`
+
+	var total int
+	err := db.QueryRow(DialectOf(db).Rebind(`SELECT COUNT(*) FROM message_history`+where),
+		filter.Recipient, filter.SenderID, since, until, filter.RequestID).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count message history: %w", err)
+	}
+
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT history_id, recipient, sender_id, kind, content, content_hash, content_length, status, message_id, error, request_id, created_at, sent_at
+		FROM message_history`+where+`
+		ORDER BY created_at DESC, history_id DESC
+		LIMIT $6 OFFSET $7
+	`), filter.Recipient, filter.SenderID, since, until, filter.RequestID, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query message history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []MessageHistoryEntry
+	for rows.Next() {
+		var e MessageHistoryEntry
+		if err := rows.Scan(&e.ID, &e.Recipient, &e.SenderID, &e.Kind, &e.Content, &e.ContentHash, &e.ContentLength, &e.Status,
+			&e.MessageID, &e.Error, &e.RequestID, &e.CreatedAt, &e.SentAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan message history: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating message history: %w", err)
+	}
+	return entries, total, nil
+}
+
+// GetMessageHistoryByMessageID returns the sent message with WhatsApp ID
+// messageID, or nil if none was recorded
+func GetMessageHistoryByMessageID(db *sql.DB, messageID string) (*MessageHistoryEntry, error) {
+	var e MessageHistoryEntry
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		SELECT history_id, recipient, sender_id, kind, content, content_hash, content_length, status, message_id, error, request_id, created_at, sent_at
+		FROM message_history
+		WHERE message_id = $1 AND status IN ($2, $3)
+		ORDER BY history_id DESC
+		LIMIT 1
+	`), messageID, MessageHistorySent, MessageHistoryRevoked).Scan(&e.ID, &e.Recipient, &e.SenderID, &e.Kind, &e.Content, &e.ContentHash,
+		&e.ContentLength, &e.Status, &e.MessageID, &e.Error, &e.RequestID, &e.CreatedAt, &e.SentAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message history: %w", err)
+	}
+	return &e, nil
+}
+
+// MarkMessageHistoryRevoked records that the sent message with WhatsApp ID
+// messageID was deleted for everyone
+func MarkMessageHistoryRevoked(db *sql.DB, messageID string) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE message_history SET status = $2
+		WHERE message_id = $1 AND status = $3
+	`), messageID, MessageHistoryRevoked, MessageHistorySent)
+	if err != nil {
+		return fmt.Errorf("failed to mark message history revoked: %w", err)
+	}
+	return nil
+}
+
+// MessageEdit is one change to the text of a sent message
+type MessageEdit struct {
+	ID              int64
+	MessageID       string
+	PreviousContent string // empty when the tenant's message log is private
+	Content         string // empty when the tenant's message log is private
+	ContentHash     string // SHA-256 (hex) of the new text
+	ContentLength   int
+	RequestID       string
+	EditedAt        time.Time
+}
+
+// InsertMessageEdit records an edit of the sent message e.MessageID and puts
+// the new text in its message history, in one transaction
+func InsertMessageEdit(db *sql.DB, e MessageEdit) error {
+	tx, err := Begin(db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO message_edits (message_id, previous_content, content, content_hash, content_length, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, e.MessageID, e.PreviousContent, e.Content, e.ContentHash, e.ContentLength, e.RequestID)
+	if err != nil {
+		return fmt.Errorf("failed to record message edit: %w", err)
+	}
+	_, err = tx.Exec(`
+		UPDATE message_history SET content = $2, content_hash = $3, content_length = $4
+		WHERE message_id = $1 AND status = $5
+	`, e.MessageID, e.Content, e.ContentHash, e.ContentLength, MessageHistorySent)
+	if err != nil {
+		return fmt.Errorf("failed to update edited message history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit message edit: %w", err)
+	}
+	return nil
+}
+
+// GetMessageEdits returns the edits of the sent message with WhatsApp ID
+// messageID, oldest first
+func GetMessageEdits(db *sql.DB, messageID string) ([]MessageEdit, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT edit_id, message_id, previous_content, content, content_hash, content_length, request_id, edited_at
+		FROM message_edits
+		WHERE message_id = $1
+		ORDER BY edited_at, edit_id
+	`), messageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query message edits: %w", err)
+	}
+	defer rows.Close()
+
+	var edits []MessageEdit
+	for rows.Next() {
+		var e MessageEdit
+		if err := rows.Scan(&e.ID, &e.MessageID, &e.PreviousContent, &e.Content, &e.ContentHash, &e.ContentLength, &e.RequestID, &e.EditedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan message edit: %w", err)
+		}
+		edits = append(edits, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating message edits: %w", err)
+	}
+	return edits, nil
+}
+
+// ContactTraffic is how many messages one contact exchanged with the senders
+type ContactTraffic struct {
+	PhoneNumber   string
+	Inbound       int // messages the contact sent us
+	Outbound      int // messages sent to the contact through the API
+	LastMessageAt time.Time
+}
+
+// MessageVolume is the number of messages exchanged in one day or week
+type MessageVolume struct {
+	Period   time.Time // start of the day or week (Monday)
+	Inbound  int
+	Outbound int
+}
+
+// GetBusiestContacts returns the limit contacts that exchanged the most
+// messages in [since, until), busiest first. Inbound messages come from the
+// inbound event log and outbound ones from the sends recorded as sent; groups
+// are left out.
+func GetBusiestContacts(db *sql.DB, since, until time.Time, limit int) ([]ContactTraffic, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		WITH traffic AS (
+			SELECT split_part(split_part(sender_jid, '@', 1), ':', 1) AS contact,
+				1 AS inbound, 0 AS outbound, created_at
+			FROM inbound_events
+			WHERE created_at >= $1 AND created_at < $2 AND sender_jid LIKE '%@s.whatsapp.net'
+			UNION ALL
+			SELECT recipient, 0, 1, created_at
+			FROM message_history
+			WHERE created_at >= $1 AND created_at < $2 AND status = $3 AND recipient NOT LIKE '%@g.us'
+		)
+		SELECT contact, SUM(inbound), SUM(outbound), MAX(created_at)
+		FROM traffic
+		WHERE contact <> ''
+		GROUP BY contact
+		ORDER BY SUM(inbound) + SUM(outbound) DESC, contact
+		LIMIT $4
+	`), since, until, MessageHistorySent, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query busiest contacts: %w", err)
+	}
+	defer rows.Close()
+
+	var contacts []ContactTraffic
+	for rows.Next() {
+		var c ContactTraffic
+		if err := rows.Scan(&c.PhoneNumber, &c.Inbound, &c.Outbound, &c.LastMessageAt); err != nil {
+			return nil, fmt.Errorf("failed to scan busiest contact: %w", err)
+		}
+		contacts = append(contacts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating busiest contacts: %w", err)
+	}
+	return contacts, nil
+}
+
+// GetMessageVolume returns the messages exchanged in [since, until) per
+// interval, "day" or "week", oldest first. Periods without messages are left
+// out.
+func GetMessageVolume(db *sql.DB, since, until time.Time, interval string) ([]MessageVolume, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT date_trunc($3, created_at) AS period, SUM(inbound), SUM(outbound)
+		FROM (
+			SELECT created_at, 1 AS inbound, 0 AS outbound
+			FROM inbound_events
+			WHERE created_at >= $1 AND created_at < $2
+			UNION ALL
+			SELECT created_at, 0, 1
+			FROM message_history
+			WHERE created_at >= $1 AND created_at < $2 AND status = $4
+		) traffic
+		GROUP BY period
+		ORDER BY period
+	`), since, until, interval, MessageHistorySent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query message volume: %w", err)
+	}
+	defer rows.Close()
+
+	var volume []MessageVolume
+	for rows.Next() {
+		var v MessageVolume
+		if err := rows.Scan(&v.Period, &v.Inbound, &v.Outbound); err != nil {
+			return nil, fmt.Errorf("failed to scan message volume: %w", err)
+		}
+		volume = append(volume, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating message volume: %w", err)
+	}
+	return volume, nil
+}
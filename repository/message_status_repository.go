@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Delivery statuses of a tracked message, in the order they are reached
+const (
+	MessageStatusSent      = "sent"
+	MessageStatusDelivered = "delivered"
+	MessageStatusRead      = "read"
+)
+
+// TrackedMessage is a sent message whose delivery is reported to the status callback
+type TrackedMessage struct {
+	MessageID string
+	RequestID string
+	Recipient string
+}
+
+// TrackMessageStatus starts tracking the delivery of a sent message
+func TrackMessageStatus(db *sql.DB, messageID, requestID, recipient string) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		INSERT INTO message_status (message_id, request_id, recipient, status)
+		VALUES ($1, $2, $3, 'sent')
+		ON CONFLICT (message_id) DO NOTHING
+	`), messageID, requestID, recipient)
+	if err != nil {
+		return fmt.Errorf("failed to track message status: %w", err)
+	}
+	return nil
+}
+
+// AdvanceMessageStatus moves a tracked message on to status. It returns nil
+// when the message is not tracked or already reached status or a later one,
+// so each status is reported once even when receipts repeat.
+func AdvanceMessageStatus(db *sql.DB, messageID, status string) (*TrackedMessage, error) {
+	m := TrackedMessage{MessageID: messageID}
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		UPDATE message_status
+		SET status = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE message_id = $1
+			AND (status = 'sent' OR (status = 'delivered' AND $2 = 'read'))
+		RETURNING request_id, recipient
+	`), messageID, status).Scan(&m.RequestID, &m.Recipient)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to advance message status: %w", err)
+	}
+	return &m, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotificationPreferenceNotFound is returned for an admin who never saved
+// notification preferences
+var ErrNotificationPreferenceNotFound = errors.New("notification preferences not found")
+
+// NotificationPreference is how an admin, known by phone number, wants to be
+// alerted
+type NotificationPreference struct {
+	PhoneNumber string
+	Email       string
+	WebhookURL  string
+	Alerts      map[string][]string // alert type -> channels
+	UpdatedAt   time.Time
+}
+
+// ListNotificationPreferences returns the preferences of every admin who saved
+// them, by phone number
+func ListNotificationPreferences(db *sql.DB) ([]NotificationPreference, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT p.phone_number, p.email, p.webhook_url, p.updated_at,
+			COALESCE(c.alert_type, ''), COALESCE(c.channel, '')
+		FROM notification_preferences p
+		LEFT JOIN notification_alert_channels c ON c.phone_number = p.phone_number
+		ORDER BY p.phone_number, c.alert_type, c.channel
+	`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	var prefs []NotificationPreference
+	for rows.Next() {
+		var p NotificationPreference
+		var alertType, channel string
+		if err := rows.Scan(&p.PhoneNumber, &p.Email, &p.WebhookURL, &p.UpdatedAt, &alertType, &channel); err != nil {
+			return nil, fmt.Errorf("failed to scan notification preferences: %w", err)
+		}
+		if len(prefs) == 0 || prefs[len(prefs)-1].PhoneNumber != p.PhoneNumber {
+			p.Alerts = make(map[string][]string)
+			prefs = append(prefs, p)
+		}
+		if alertType != "" {
+			last := &prefs[len(prefs)-1]
+			last.Alerts[alertType] = append(last.Alerts[alertType], channel)
+		}
+	}
+	return prefs, rows.Err()
+}
+
+// GetNotificationPreference returns the preferences of the admin with phone.
+// Returns ErrNotificationPreferenceNotFound if they never saved any.
+func GetNotificationPreference(db *sql.DB, phone string) (*NotificationPreference, error) {
+	prefs, err := ListNotificationPreferences(db)
+	if err != nil {
+		return nil, err
+	}
+	for i := range prefs {
+		if prefs[i].PhoneNumber == phone {
+			return &prefs[i], nil
+		}
+	}
+	return nil, ErrNotificationPreferenceNotFound
+}
+
+// ReplaceNotificationPreference saves pref as the whole of its admin's
+// preferences, replacing the alerts they got before
+func ReplaceNotificationPreference(db *sql.DB, pref NotificationPreference) error {
+	tx, err := Begin(db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		INSERT INTO notification_preferences (phone_number, email, webhook_url, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT (phone_number) DO UPDATE
+		SET email = EXCLUDED.email, webhook_url = EXCLUDED.webhook_url, updated_at = CURRENT_TIMESTAMP
+	`, pref.PhoneNumber, pref.Email, pref.WebhookURL); err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM notification_alert_channels WHERE phone_number = $1", pref.PhoneNumber); err != nil {
+		return fmt.Errorf("failed to clear notification alert channels: %w", err)
+	}
+	for alertType, channels := range pref.Alerts {
+		for _, channel := range channels {
+			if _, err := tx.Exec(
+				"INSERT INTO notification_alert_channels (phone_number, alert_type, channel) VALUES ($1, $2, $3)",
+				pref.PhoneNumber, alertType, channel,
+			); err != nil {
+				return fmt.Errorf("failed to save notification alert channel: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteNotificationPreference forgets the preferences of the admin with
+// phone. Returns ErrNotificationPreferenceNotFound if they never saved any.
+func DeleteNotificationPreference(db *sql.DB, phone string) error {
+	result, err := db.Exec(DialectOf(db).Rebind("DELETE FROM notification_preferences WHERE phone_number = $1"), phone)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification preferences: %w", err)
+	}
+	return requireRow(result, ErrNotificationPreferenceNotFound)
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrOrderNotFound = errors.New("order not found")
+	ErrOrderNotDraft = errors.New("order is not a draft")
+)
+
+// CatalogItem is a laundry service from the items catalog
+type CatalogItem struct {
+	ItemID       int
+	Name         string
+	Description  string
+	PricePerUnit float64
+	PricePerKilo float64 // zero for services priced per piece
+}
+
+// PricedByKilo reports whether the item is sold by weight rather than per piece
+func (i CatalogItem) PricedByKilo() bool {
+	return i.PricePerKilo > 0
+}
+
+// DraftOrderLine is the single service of an order placed on WhatsApp
+type DraftOrderLine struct {
+	ItemID int
+	Kilos  float64 // estimated weight for items priced by the kilo
+	Units  int     // pieces for items priced per piece
+	Price  float64 // estimated price
+}
+
+// DraftOrder is an order placed on WhatsApp awaiting staff confirmation,
+// joined with member and pickup details
+type DraftOrder struct {
+	OrderID        int
+	MemberName     string
+	PhoneNumber    string
+	ItemName       string
+	Kilos          float64
+	Units          int
+	TotalPrice     float64
+	PickupStartsAt sql.NullTime // empty when the member drops the laundry off
+	PickupEndsAt   sql.NullTime
+	CreatedAt      time.Time
+}
+
+// GetCatalogItems returns the items with a price, ordered by ID so their
+// numbers stay stable in the chat catalog
+func GetCatalogItems(db *sql.DB) ([]CatalogItem, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT item_id, name, COALESCE(description, ''), COALESCE(price_per_unit, 0), COALESCE(price_per_kilo, 0)
+		FROM items
+		WHERE COALESCE(price_per_unit, 0) > 0 OR COALESCE(price_per_kilo, 0) > 0
+		ORDER BY item_id
+	`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []CatalogItem
+	for rows.Next() {
+		var item CatalogItem
+		if err := rows.Scan(&item.ItemID, &item.Name, &item.Description, &item.PricePerUnit, &item.PricePerKilo); err != nil {
+			return nil, fmt.Errorf("failed to scan item: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating items: %w", err)
+	}
+	return items, nil
+}
+
+// CreateDraftOrder inserts a draft order with its line for a member and returns
+// the order ID. pickupSlotID is 0 when the member drops the laundry off.
+func CreateDraftOrder(db *sql.DB, memberID, pickupSlotID int, line DraftOrderLine) (int, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var orderID int
+	err = tx.QueryRow(`
+		INSERT INTO orders (member_id, total_price, status, pickup_slot_id, order_date, created_at, updated_at)
+		VALUES ($1, $2, $3, NULLIF($4, 0), CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING order_id
+	`, memberID, line.Price, OrderStatusDraft, pickupSlotID).Scan(&orderID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create draft order: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO order_items (order_id, item_id, total_kilo, total_unit, price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, orderID, line.ItemID, line.Kilos, line.Units, line.Price)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add draft order item: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return orderID, nil
+}
+
+const draftOrderQuery = `
+	SELECT o.order_id, COALESCE(m.name, ''), COALESCE(m.phone_number, ''), COALESCE(i.name, ''),
+		COALESCE(oi.total_kilo, 0), COALESCE(oi.total_unit, 0), COALESCE(o.total_price, 0),
+		s.starts_at, s.ends_at, o.created_at
+	FROM orders o
+	LEFT JOIN members m ON m.member_id = o.member_id
+	LEFT JOIN order_items oi ON oi.order_id = o.order_id
+	LEFT JOIN items i ON i.item_id = oi.item_id
+	LEFT JOIN pickup_slots s ON s.slot_id = o.pickup_slot_id`
+
+// GetDraftOrders returns the orders awaiting staff confirmation, oldest first
+func GetDraftOrders(db *sql.DB) ([]DraftOrder, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(draftOrderQuery+` WHERE o.status = $1 ORDER BY o.created_at, o.order_id`), OrderStatusDraft)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query draft orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []DraftOrder
+	for rows.Next() {
+		o, err := scanDraftOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, *o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating draft orders: %w", err)
+	}
+	return orders, nil
+}
+
+// ConfirmDraftOrder moves a draft order to pending and returns it. It returns
+// ErrOrderNotFound for unknown orders and ErrOrderNotDraft for orders that are
+// already confirmed.
+func ConfirmDraftOrder(db *sql.DB, orderID int) (*DraftOrder, error) {
+	result, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE orders SET status = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE order_id = $1 AND status = $3
+	`), orderID, OrderStatusPending, OrderStatusDraft)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm order %d: %w", orderID, err)
+	}
+	if err := requireRow(result, ErrOrderNotDraft); err != nil {
+		var exists bool
+		if qerr := db.QueryRow(DialectOf(db).Rebind(`SELECT EXISTS (SELECT 1 FROM orders WHERE order_id = $1)`), orderID).Scan(&exists); qerr != nil {
+			return nil, fmt.Errorf("failed to check order %d: %w", orderID, qerr)
+		}
+		if !exists {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	o, err := scanDraftOrder(db.QueryRow(DialectOf(db).Rebind(draftOrderQuery+` WHERE o.order_id = $1`), orderID))
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDraftOrder(row rowScanner) (*DraftOrder, error) {
+	var o DraftOrder
+	err := row.Scan(&o.OrderID, &o.MemberName, &o.PhoneNumber, &o.ItemName, &o.Kilos, &o.Units,
+		&o.TotalPrice, &o.PickupStartsAt, &o.PickupEndsAt, &o.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan draft order: %w", err)
+	}
+	return &o, nil
+}
+
+// GetPhonesWithOrderStatus returns the phone numbers of the members with at
+// least one order in status, such as OrderStatusReady for laundry awaiting
+// pickup
+func GetPhonesWithOrderStatus(db *sql.DB, status string) ([]string, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT DISTINCT m.phone_number
+		FROM orders o
+		JOIN members m ON m.member_id = o.member_id
+		WHERE o.status = $1 AND m.phone_number IS NOT NULL AND m.phone_number <> ''
+		ORDER BY m.phone_number
+	`), status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query members with %s orders: %w", status, err)
+	}
+	defer rows.Close()
+
+	var phones []string
+	for rows.Next() {
+		var phone string
+		if err := rows.Scan(&phone); err != nil {
+			return nil, fmt.Errorf("failed to scan member phone: %w", err)
+		}
+		phones = append(phones, phone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating members with %s orders: %w", status, err)
+	}
+	return phones, nil
+}
+
+// OrderReceiptLine is one service of an order as printed on its receipt
+type OrderReceiptLine struct {
+	ItemName string
+	Kilos    float64
+	Units    int
+	Price    float64
+}
+
+// OrderReceipt is an order with every service it includes, as printed on the
+// receipt sent to the member
+type OrderReceipt struct {
+	OrderID     int
+	MemberName  string
+	PhoneNumber string
+	TotalPrice  float64
+	OrderDate   time.Time
+	Lines       []OrderReceiptLine
+}
+
+// GetOrderReceipt returns an order with its services, or ErrOrderNotFound
+func GetOrderReceipt(db *sql.DB, orderID int) (*OrderReceipt, error) {
+	o := &OrderReceipt{OrderID: orderID}
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		SELECT COALESCE(m.name, ''), COALESCE(m.phone_number, ''), COALESCE(o.total_price, 0),
+			COALESCE(o.order_date, o.created_at)
+		FROM orders o
+		LEFT JOIN members m ON m.member_id = o.member_id
+		WHERE o.order_id = $1
+	`), orderID).Scan(&o.MemberName, &o.PhoneNumber, &o.TotalPrice, &o.OrderDate)
+	if err == sql.ErrNoRows {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order %d: %w", orderID, err)
+	}
+
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT COALESCE(i.name, ''), COALESCE(oi.total_kilo, 0), COALESCE(oi.total_unit, 0), COALESCE(oi.price, 0)
+		FROM order_items oi
+		LEFT JOIN items i ON i.item_id = oi.item_id
+		WHERE oi.order_id = $1
+		ORDER BY oi.order_item_id
+	`), orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query items of order %d: %w", orderID, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line OrderReceiptLine
+		if err := rows.Scan(&line.ItemName, &line.Kilos, &line.Units, &line.Price); err != nil {
+			return nil, fmt.Errorf("failed to scan order item: %w", err)
+		}
+		o.Lines = append(o.Lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating items of order %d: %w", orderID, err)
+	}
+	return o, nil
+}
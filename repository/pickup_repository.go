@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrSlotNotFound  = errors.New("pickup slot not found")
+	ErrSlotFull      = errors.New("pickup slot is full")
+	ErrAlreadyBooked = errors.New("member already booked this slot")
+)
+
+// PickupSlot is a pickup window with a fixed capacity
+type PickupSlot struct {
+	SlotID      int
+	StartsAt    time.Time
+	EndsAt      time.Time
+	Capacity    int
+	BookedCount int
+}
+
+// PickupBooking is a member's booking of a pickup slot, joined with member details
+type PickupBooking struct {
+	BookingID   int
+	SlotID      int
+	StartsAt    time.Time
+	EndsAt      time.Time
+	MemberID    int
+	MemberName  string
+	PhoneNumber string
+	Address     string
+	Status      string
+}
+
+// CreatePickupSlot inserts a pickup window and returns its ID
+func CreatePickupSlot(db *sql.DB, startsAt, endsAt time.Time, capacity int) (int, error) {
+	query := `
+		INSERT INTO pickup_slots (starts_at, ends_at, capacity, booked_count, created_at)
+		VALUES ($1, $2, $3, 0, CURRENT_TIMESTAMP)
+		RETURNING slot_id
+	`
+	var slotID int
+	if err := db.QueryRow(DialectOf(db).Rebind(query), startsAt, endsAt, capacity).Scan(&slotID); err != nil {
+		return 0, fmt.Errorf("failed to create pickup slot: %w", err)
+	}
+	return slotID, nil
+}
+
+// GetAvailablePickupSlots lists upcoming slots with free capacity starting before until
+func GetAvailablePickupSlots(db *sql.DB, from, until time.Time) ([]PickupSlot, error) {
+	query := `
+		SELECT slot_id, starts_at, ends_at, capacity, booked_count
+		FROM pickup_slots
+		WHERE starts_at > $1 AND starts_at < $2 AND booked_count < capacity
+		ORDER BY starts_at ASC
+	`
+	rows, err := db.Query(DialectOf(db).Rebind(query), from, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pickup slots: %w", err)
+	}
+	defer rows.Close()
+
+	var slots []PickupSlot
+	for rows.Next() {
+		var s PickupSlot
+		if err := rows.Scan(&s.SlotID, &s.StartsAt, &s.EndsAt, &s.Capacity, &s.BookedCount); err != nil {
+			return nil, fmt.Errorf("failed to scan pickup slot: %w", err)
+		}
+		slots = append(slots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pickup slots: %w", err)
+	}
+	return slots, nil
+}
+
+// BookPickupSlot reserves capacity in a slot for a member. The capacity check and
+// the booking insert run in one transaction so a slot can never be overbooked.
+func BookPickupSlot(db *sql.DB, slotID, memberID int) (*PickupSlot, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRow("SELECT COUNT(*) FROM pickup_bookings WHERE slot_id = $1 AND member_id = $2 AND status = 'booked'", slotID, memberID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing booking: %w", err)
+	}
+	if exists > 0 {
+		return nil, ErrAlreadyBooked
+	}
+
+	var slot PickupSlot
+	err = tx.QueryRow(`
+		UPDATE pickup_slots
+		SET booked_count = booked_count + 1
+		WHERE slot_id = $1 AND booked_count < capacity AND starts_at > CURRENT_TIMESTAMP
+		RETURNING slot_id, starts_at, ends_at, capacity, booked_count
+	`, slotID).Scan(&slot.SlotID, &slot.StartsAt, &slot.EndsAt, &slot.Capacity, &slot.BookedCount)
+	if err == sql.ErrNoRows {
+		var count int
+		if err := tx.QueryRow("SELECT COUNT(*) FROM pickup_slots WHERE slot_id = $1 AND starts_at > CURRENT_TIMESTAMP", slotID).Scan(&count); err != nil {
+			return nil, fmt.Errorf("failed to check pickup slot: %w", err)
+		}
+		if count == 0 {
+			return nil, ErrSlotNotFound
+		}
+		return nil, ErrSlotFull
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve pickup slot: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO pickup_bookings (slot_id, member_id, status, created_at)
+		VALUES ($1, $2, 'booked', CURRENT_TIMESTAMP)
+		ON CONFLICT (slot_id, member_id) DO UPDATE SET status = 'booked', reminder_sent_at = NULL
+	`, slotID, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert pickup booking: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &slot, nil
+}
+
+// GetPickupSchedule lists booked pickups whose slot starts within [from, until)
+func GetPickupSchedule(db *sql.DB, from, until time.Time) ([]PickupBooking, error) {
+	query := `
+		SELECT b.booking_id, s.slot_id, s.starts_at, s.ends_at, m.member_id,
+			COALESCE(m.name, ''), COALESCE(m.phone_number, ''), COALESCE(m.address, ''), b.status
+		FROM pickup_bookings b
+		JOIN pickup_slots s ON s.slot_id = b.slot_id
+		JOIN members m ON m.member_id = b.member_id
+		WHERE s.starts_at >= $1 AND s.starts_at < $2 AND b.status = 'booked'
+		ORDER BY s.starts_at ASC, b.booking_id ASC
+	`
+	return queryPickupBookings(db, query, from, until)
+}
+
+// GetBookingsDueForReminder lists bookings starting before until that have not been reminded yet
+func GetBookingsDueForReminder(db *sql.DB, until time.Time) ([]PickupBooking, error) {
+	query := `
+		SELECT b.booking_id, s.slot_id, s.starts_at, s.ends_at, m.member_id,
+			COALESCE(m.name, ''), COALESCE(m.phone_number, ''), COALESCE(m.address, ''), b.status
+		FROM pickup_bookings b
+		JOIN pickup_slots s ON s.slot_id = b.slot_id
+		JOIN members m ON m.member_id = b.member_id
+		WHERE s.starts_at > CURRENT_TIMESTAMP AND s.starts_at <= $1
+			AND b.status = 'booked' AND b.reminder_sent_at IS NULL
+		ORDER BY s.starts_at ASC
+	`
+	return queryPickupBookings(db, query, until)
+}
+
+// MarkPickupReminderSent records that the reminder for a booking went out
+func MarkPickupReminderSent(db *sql.DB, bookingID int) error {
+	_, err := db.Exec(DialectOf(db).Rebind("UPDATE pickup_bookings SET reminder_sent_at = CURRENT_TIMESTAMP WHERE booking_id = $1"), bookingID)
+	if err != nil {
+		return fmt.Errorf("failed to mark pickup reminder: %w", err)
+	}
+	return nil
+}
+
+func queryPickupBookings(db *sql.DB, query string, args ...interface{}) ([]PickupBooking, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pickup bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []PickupBooking
+	for rows.Next() {
+		var b PickupBooking
+		if err := rows.Scan(&b.BookingID, &b.SlotID, &b.StartsAt, &b.EndsAt, &b.MemberID,
+			&b.MemberName, &b.PhoneNumber, &b.Address, &b.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan pickup booking: %w", err)
+		}
+		bookings = append(bookings, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pickup bookings: %w", err)
+	}
+	return bookings, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// setupPickupDB returns an in-memory database with one pickup slot of the
+// given capacity starting at startsAt, and two members to book it
+func setupPickupDB(t *testing.T, capacity int, startsAt time.Time) (*sql.DB, int) {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open SQLite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	db.SetMaxOpenConns(1)
+
+	for _, ddl := range []string{
+		"CREATE TABLE members (member_id INTEGER PRIMARY KEY, name TEXT, phone_number TEXT, address TEXT)",
+		"CREATE TABLE pickup_slots (slot_id SERIAL PRIMARY KEY, starts_at TIMESTAMP NOT NULL, ends_at TIMESTAMP NOT NULL, capacity INTEGER NOT NULL, booked_count INTEGER NOT NULL DEFAULT 0, created_at TIMESTAMP)",
+		"CREATE TABLE pickup_bookings (booking_id SERIAL PRIMARY KEY, slot_id INTEGER NOT NULL, member_id INTEGER NOT NULL, status TEXT NOT NULL, reminder_sent_at TIMESTAMP, created_at TIMESTAMP, UNIQUE (slot_id, member_id))",
+		"INSERT INTO members (member_id, name, phone_number) VALUES (1, 'Budi', '6281111111111'), (2, 'Sari', '6282222222222')",
+	} {
+		if _, err := db.Exec(SQLite.Schema(ddl)); err != nil {
+			t.Fatalf("Failed to create schema: %v", err)
+		}
+	}
+
+	slotID, err := CreatePickupSlot(db, startsAt, startsAt.Add(2*time.Hour), capacity)
+	if err != nil {
+		t.Fatalf("Failed to create slot: %v", err)
+	}
+	return db, slotID
+}
+
+// pickupCounts returns the slot's booked count and how many bookings it has
+func pickupCounts(t *testing.T, db *sql.DB, slotID int) (booked, bookings int) {
+	t.Helper()
+	if err := db.QueryRow("SELECT booked_count FROM pickup_slots WHERE slot_id = ?", slotID).Scan(&booked); err != nil {
+		t.Fatalf("Failed to read slot: %v", err)
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM pickup_bookings WHERE slot_id = ?", slotID).Scan(&bookings); err != nil {
+		t.Fatalf("Failed to count bookings: %v", err)
+	}
+	return booked, bookings
+}
+
+func TestBookPickupSlot_SlotTaken(t *testing.T) {
+	db, slotID := setupPickupDB(t, 1, time.Now().UTC().Add(24*time.Hour))
+
+	slot, err := BookPickupSlot(db, slotID, 1)
+	if err != nil {
+		t.Fatalf("Expected the first booking to succeed, got %v", err)
+	}
+	if slot.BookedCount != 1 {
+		t.Errorf("Expected booked count 1, got %d", slot.BookedCount)
+	}
+
+	if _, err := BookPickupSlot(db, slotID, 2); err != ErrSlotFull {
+		t.Errorf("Expected ErrSlotFull for another member, got %v", err)
+	}
+	if _, err := BookPickupSlot(db, slotID, 1); err != ErrAlreadyBooked {
+		t.Errorf("Expected ErrAlreadyBooked for the same member, got %v", err)
+	}
+	if booked, bookings := pickupCounts(t, db, slotID); booked != 1 || bookings != 1 {
+		t.Errorf("Expected 1 booked and 1 booking, got %d booked and %d bookings", booked, bookings)
+	}
+}
+
+func TestBookPickupSlot_PastSlot(t *testing.T) {
+	db, slotID := setupPickupDB(t, 1, time.Now().UTC().Add(-time.Hour))
+
+	if _, err := BookPickupSlot(db, slotID, 1); err != ErrSlotNotFound {
+		t.Errorf("Expected ErrSlotNotFound for a slot that already started, got %v", err)
+	}
+	if _, err := BookPickupSlot(db, slotID+1, 1); err != ErrSlotNotFound {
+		t.Errorf("Expected ErrSlotNotFound for an unknown slot, got %v", err)
+	}
+}
+
+func TestBookPickupSlot_RollsBackReservation(t *testing.T) {
+	db, slotID := setupPickupDB(t, 1, time.Now().UTC().Add(24*time.Hour))
+	if _, err := db.Exec("CREATE TRIGGER fail_bookings BEFORE INSERT ON pickup_bookings BEGIN SELECT RAISE(ABORT, 'bookings unavailable'); END"); err != nil {
+		t.Fatalf("Failed to create trigger: %v", err)
+	}
+
+	if _, err := BookPickupSlot(db, slotID, 1); err == nil {
+		t.Fatal("Expected the booking to fail")
+	}
+	// The capacity reserved before the insert failed is given back
+	if booked, bookings := pickupCounts(t, db, slotID); booked != 0 || bookings != 0 {
+		t.Errorf("Expected 0 booked and 0 bookings, got %d booked and %d bookings", booked, bookings)
+	}
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
 )
 
@@ -14,7 +15,7 @@ import (
 func GetCurrentPoints(exec Executor, memberID int) (int, error) {
 	var currentPoints int
 	query := "SELECT current_points FROM points WHERE member_id = $1"
-	err := exec.QueryRow(query, memberID).Scan(&currentPoints)
+	err := exec.QueryRow(rebind(exec, query), memberID).Scan(&currentPoints)
 	if err != nil {
 		if err.Error() == "sql: no rows in result set" {
 			return 0, fmt.Errorf("no points record found for member ID: %d", memberID)
@@ -34,7 +35,7 @@ func UpsertPoints(exec Executor, memberID, currentPoints int) error {
 		current_points = points.current_points + EXCLUDED.current_points,
 		updated_at = CURRENT_TIMESTAMP
 	`
-	_, err := exec.Exec(query, memberID, currentPoints, currentPoints)
+	_, err := exec.Exec(rebind(exec, query), memberID, currentPoints, currentPoints)
 	if err != nil {
 		return fmt.Errorf("failed to upsert points: %w", err)
 	}
@@ -49,9 +50,24 @@ func DeductPoints(exec Executor, memberID, pointsToDeduct int) error {
 		updated_at = CURRENT_TIMESTAMP
 	WHERE member_id = $2
 	`
-	_, err := exec.Exec(query, pointsToDeduct, memberID)
+	_, err := exec.Exec(rebind(exec, query), pointsToDeduct, memberID)
 	if err != nil {
 		return fmt.Errorf("failed to deduct points: %w", err)
 	}
 	return nil
 }
+
+// GetAccumulatedPoints retrieves the lifetime accumulated points for a member.
+// Members without a points row have accumulated nothing yet, so 0 is returned.
+func GetAccumulatedPoints(exec Executor, memberID int) (int, error) {
+	var accumulated sql.NullInt64
+	query := "SELECT accumulated_points FROM points WHERE member_id = $1"
+	err := exec.QueryRow(rebind(exec, query), memberID).Scan(&accumulated)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to retrieve accumulated points: %w", err)
+	}
+	return int(accumulated.Int64), nil
+}
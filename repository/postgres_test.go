@@ -0,0 +1,112 @@
+//go:build postgres
+
+package repository_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/wa-serv/database"
+	"github.com/wa-serv/repository"
+)
+
+// openPostgres connects to the database at TEST_POSTGRES_URL and creates the
+// tables the tests use. Run with:
+//
+//	TEST_POSTGRES_URL=postgres://... go test -tags postgres ./repository/
+func openPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		t.Fatalf("Failed to open Postgres: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	for _, init := range []func(*sql.DB) error{database.InitSendersTable, database.InitTenantsTable, database.InitTenantAdminsTable} {
+		if err := init(db); err != nil {
+			t.Fatalf("Failed to create tables: %v", err)
+		}
+	}
+	if dialect := repository.DialectOf(db); dialect != repository.Postgres {
+		t.Fatalf("Expected the Postgres dialect, got %v", dialect)
+	}
+	return db
+}
+
+func TestPostgres_SenderQueries(t *testing.T) {
+	db := openPostgres(t)
+	const senderID = "6289990000001"
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM sender_daily_sends WHERE sender_id = $1", senderID)
+		db.Exec("DELETE FROM senders WHERE sender_id = $1", senderID)
+	})
+
+	if err := repository.CreateSenderIfNotExists(db, senderID, senderID, "Postgres test", false); err != nil {
+		t.Fatalf("Failed to create sender: %v", err)
+	}
+	// Placeholders out of order: $2 is bound before $1
+	if err := repository.SetSenderPaused(db, senderID, true); err != nil {
+		t.Fatalf("Failed to pause sender: %v", err)
+	}
+	sender, err := repository.GetSenderByID(db, senderID)
+	if err != nil {
+		t.Fatalf("Failed to get sender: %v", err)
+	}
+	if !sender.IsPaused {
+		t.Error("Expected the sender to be paused")
+	}
+
+	if err := repository.SetSenderDailyQuota(db, senderID, 5); err != nil {
+		t.Fatalf("Failed to set quota: %v", err)
+	}
+	for range 2 {
+		if err := repository.AddSenderDailySend(db, senderID, "2026-10-16"); err != nil {
+			t.Fatalf("Failed to count send: %v", err)
+		}
+	}
+	quota, sent, err := repository.GetSenderQuotaUsage(db, senderID, "2026-10-16")
+	if err != nil {
+		t.Fatalf("Failed to get quota usage: %v", err)
+	}
+	if quota != 5 || sent != 2 {
+		t.Errorf("Expected quota 5 with 2 sent, got quota %d with %d sent", quota, sent)
+	}
+}
+
+func TestPostgres_TxRollsBack(t *testing.T) {
+	db := openPostgres(t)
+	const slug = "postgres-test-laundry"
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM tenant_admins WHERE tenant_id IN (SELECT tenant_id FROM tenants WHERE slug = $1)", slug)
+		db.Exec("DELETE FROM tenants WHERE slug = $1", slug)
+	})
+
+	tx, err := repository.Begin(db)
+	if err != nil {
+		t.Fatalf("Failed to begin: %v", err)
+	}
+	tenantID, err := repository.CreateTenant(tx, slug, "Postgres Test Laundry", "6289990000002")
+	if err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	if err := repository.CreateTenantAdmin(tx, tenantID, "Budi", "6289990000002"); err != nil {
+		t.Fatalf("Failed to create tenant admin: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Failed to roll back: %v", err)
+	}
+
+	exists, err := repository.TenantSlugExists(db, slug)
+	if err != nil {
+		t.Fatalf("Failed to check slug: %v", err)
+	}
+	if exists {
+		t.Error("Expected the rolled back tenant to be gone")
+	}
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrProspectNotFound  = errors.New("prospect not found")
+	ErrProspectConverted = errors.New("prospect already converted")
+	ErrMemberExists      = errors.New("member already registered")
+)
+
+// Prospect is a WhatsApp contact that messaged the bot without being a member
+type Prospect struct {
+	PhoneNumber       string
+	PushName          string
+	FirstSeenAt       time.Time
+	LastSeenAt        time.Time
+	MessageCount      int
+	LastIntent        string // command the last message was routed to
+	ConvertedMemberID sql.NullInt64
+	ConvertedAt       sql.NullTime
+}
+
+const prospectColumns = `phone_number, COALESCE(push_name, ''), first_seen_at, last_seen_at,
+	COALESCE(message_count, 0), COALESCE(last_intent, ''), converted_member_id, converted_at`
+
+func scanProspect(row interface{ Scan(...any) error }) (Prospect, error) {
+	var p Prospect
+	err := row.Scan(&p.PhoneNumber, &p.PushName, &p.FirstSeenAt, &p.LastSeenAt,
+		&p.MessageCount, &p.LastIntent, &p.ConvertedMemberID, &p.ConvertedAt)
+	return p, err
+}
+
+// RecordProspectMessage counts an inbound message from an unregistered number,
+// creating the prospect on first contact. An empty pushName keeps the stored one.
+func RecordProspectMessage(db *sql.DB, phoneNumber, pushName, intent string) error {
+	query := `
+		INSERT INTO prospects (phone_number, push_name, first_seen_at, last_seen_at, message_count, last_intent)
+		VALUES ($1, NULLIF($2, ''), CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 1, $3)
+		ON CONFLICT (phone_number) DO UPDATE
+		SET push_name = COALESCE(EXCLUDED.push_name, prospects.push_name),
+			last_seen_at = CURRENT_TIMESTAMP,
+			message_count = COALESCE(prospects.message_count, 0) + 1,
+			last_intent = EXCLUDED.last_intent`
+	if _, err := db.Exec(DialectOf(db).Rebind(query), phoneNumber, pushName, intent); err != nil {
+		return fmt.Errorf("failed to record prospect message: %w", err)
+	}
+	return nil
+}
+
+// ClaimProspectNudge records that an onboarding nudge is being sent to a prospect.
+// It returns false when the prospect was already nudged within intervalDays, so
+// concurrent messages from the same number produce a single nudge.
+func ClaimProspectNudge(db *sql.DB, phoneNumber string, intervalDays int) (bool, error) {
+	query := `
+		UPDATE prospects SET last_nudged_at = CURRENT_TIMESTAMP
+		WHERE phone_number = $1 AND converted_member_id IS NULL
+			AND (last_nudged_at IS NULL OR last_nudged_at <= CURRENT_TIMESTAMP - make_interval(days => $2))`
+	result, err := db.Exec(DialectOf(db).Rebind(query), phoneNumber, intervalDays)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim prospect nudge: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim prospect nudge: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ListProspects returns prospects, most recently active first. Converted
+// prospects are only included when includeConverted is set.
+func ListProspects(db *sql.DB, includeConverted bool) ([]Prospect, error) {
+	query := `SELECT ` + prospectColumns + ` FROM prospects
+		WHERE $1 OR converted_member_id IS NULL
+		ORDER BY last_seen_at DESC`
+	rows, err := db.Query(DialectOf(db).Rebind(query), includeConverted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prospects: %w", err)
+	}
+	defer rows.Close()
+
+	var prospects []Prospect
+	for rows.Next() {
+		p, err := scanProspect(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan prospect: %w", err)
+		}
+		prospects = append(prospects, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating prospects: %w", err)
+	}
+	return prospects, nil
+}
+
+// GetProspect returns the prospect for a phone number, or nil if there is none
+func GetProspect(db *sql.DB, phoneNumber string) (*Prospect, error) {
+	query := `SELECT ` + prospectColumns + ` FROM prospects WHERE phone_number = $1`
+	p, err := scanProspect(db.QueryRow(DialectOf(db).Rebind(query), phoneNumber))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get prospect: %w", err)
+	}
+	return &p, nil
+}
+
+// ConvertProspect registers a prospect as a member with the given name and
+// address and returns the new member ID
+func ConvertProspect(db *sql.DB, phoneNumber, name, address string) (int, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var convertedMemberID sql.NullInt64
+	err = tx.QueryRow(`SELECT converted_member_id FROM prospects WHERE phone_number = $1 FOR UPDATE`, phoneNumber).Scan(&convertedMemberID)
+	if err == sql.ErrNoRows {
+		return 0, ErrProspectNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load prospect: %w", err)
+	}
+	if convertedMemberID.Valid {
+		return 0, ErrProspectConverted
+	}
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM members WHERE phone_number = $1)`, phoneNumber).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("failed to check member registration: %w", err)
+	}
+	if exists {
+		return 0, ErrMemberExists
+	}
+
+	memberID, err := registerMemberTx(tx, name, address, phoneNumber)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return memberID, nil
+}
+
+// UpsertProspectPushName records the push name of an unregistered contact,
+// creating the prospect on first contact
+func UpsertProspectPushName(db *sql.DB, phoneNumber, pushName string) error {
+	query := `
+		INSERT INTO prospects (phone_number, push_name, first_seen_at, last_seen_at)
+		VALUES ($1, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (phone_number) DO UPDATE
+		SET push_name = EXCLUDED.push_name, last_seen_at = CURRENT_TIMESTAMP`
+	if _, err := db.Exec(DialectOf(db).Rebind(query), phoneNumber, pushName); err != nil {
+		return fmt.Errorf("failed to upsert prospect: %w", err)
+	}
+	return nil
+}
+
+// SavePushName stores a WhatsApp push name on the member with the given phone
+// number, or on their prospect record when they have not registered yet
+func SavePushName(db *sql.DB, phoneNumber, pushName string) error {
+	registered, err := IsMemberRegistered(db, phoneNumber)
+	if err != nil {
+		return fmt.Errorf("failed to check member registration: %w", err)
+	}
+	if registered {
+		_, err := UpdateMemberPushName(db, phoneNumber, pushName)
+		return err
+	}
+	return UpsertProspectPushName(db, phoneNumber, pushName)
+}
+
+// GetContactNames returns the registered name and the best known push name for
+// a phone number. Both are empty when the number is neither a member nor a prospect.
+func GetContactNames(db *sql.DB, phoneNumber string) (name, pushName string, err error) {
+	query := `
+		SELECT COALESCE(m.name, ''), COALESCE(m.push_name, p.push_name, '')
+		FROM (SELECT CAST($1 AS VARCHAR) AS phone_number) q
+		LEFT JOIN members m ON m.phone_number = q.phone_number
+		LEFT JOIN prospects p ON p.phone_number = q.phone_number`
+	if err := db.QueryRow(DialectOf(db).Rebind(query), phoneNumber).Scan(&name, &pushName); err != nil {
+		return "", "", fmt.Errorf("failed to get contact names: %w", err)
+	}
+	return name, pushName, nil
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Reminder trigger types
+const (
+	ReminderTriggerOrderReady      = "order_ready"      // order status is ready but not picked up
+	ReminderTriggerRewardUnclaimed = "reward_unclaimed" // redemption recorded but reward not claimed
+)
+
+// ReminderRule describes when and how often members are reminded about something
+type ReminderRule struct {
+	RuleID        int
+	Name          string
+	TriggerType   string
+	DelayHours    int // wait this long after the trigger before the first reminder
+	RepeatHours   int // minimum gap between reminders
+	MaxReminders  int
+	EscalateAfter int // notify admins once this many reminders were sent; 0 disables
+	Message       string
+	IsActive      bool
+	CreatedAt     time.Time
+}
+
+// ReminderCandidate is a subject (order or redemption) that is due for a reminder
+type ReminderCandidate struct {
+	SubjectID     int
+	MemberID      int
+	MemberName    string
+	PushName      string // WhatsApp display name, empty until seen
+	PhoneNumber   string
+	Detail        string // e.g. the reward description for redemptions
+	RemindersSent int
+}
+
+// CreateReminderRule inserts a reminder rule and returns its ID
+func CreateReminderRule(db *sql.DB, rule ReminderRule) (int, error) {
+	query := `
+		INSERT INTO reminder_rules (name, trigger_type, delay_hours, repeat_hours, max_reminders, escalate_after, message, is_active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP)
+		RETURNING rule_id
+	`
+	var ruleID int
+	err := db.QueryRow(DialectOf(db).Rebind(query), rule.Name, rule.TriggerType, rule.DelayHours, rule.RepeatHours,
+		rule.MaxReminders, rule.EscalateAfter, rule.Message, rule.IsActive).Scan(&ruleID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create reminder rule: %w", err)
+	}
+	return ruleID, nil
+}
+
+// GetReminderRules retrieves reminder rules, optionally only the active ones
+func GetReminderRules(db *sql.DB, activeOnly bool) ([]ReminderRule, error) {
+	query := `
+		SELECT rule_id, name, trigger_type, delay_hours, repeat_hours, max_reminders, escalate_after, message, is_active, created_at
+		FROM reminder_rules
+		WHERE is_active OR NOT $1
+		ORDER BY rule_id ASC
+	`
+	rows, err := db.Query(DialectOf(db).Rebind(query), activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminder rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []ReminderRule
+	for rows.Next() {
+		var r ReminderRule
+		if err := rows.Scan(&r.RuleID, &r.Name, &r.TriggerType, &r.DelayHours, &r.RepeatHours,
+			&r.MaxReminders, &r.EscalateAfter, &r.Message, &r.IsActive, &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reminder rule: %w", err)
+		}
+		rules = append(rules, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reminder rules: %w", err)
+	}
+	return rules, nil
+}
+
+// reminderSubjectQueries select (subject_id, member_id, name, push_name, phone, detail, triggered_at)
+// for each trigger type
+var reminderSubjectQueries = map[string]string{
+	ReminderTriggerOrderReady: `
+		SELECT o.order_id AS subject_id, m.member_id, COALESCE(m.name, '') AS name, COALESCE(m.push_name, '') AS push_name, m.phone_number,
+			'' AS detail, o.updated_at AS triggered_at
+		FROM orders o
+		JOIN members m ON m.member_id = o.member_id
+		WHERE o.status = 'ready'`,
+	ReminderTriggerRewardUnclaimed: `
+		SELECT t.transaction_id AS subject_id, m.member_id, COALESCE(m.name, '') AS name, COALESCE(m.push_name, '') AS push_name, m.phone_number,
+			COALESCE(t.notes, '') AS detail, t.transaction_date AS triggered_at
+		FROM point_transactions t
+		JOIN points p ON p.point_id = t.point_id
+		JOIN members m ON m.member_id = p.member_id
+		WHERE t.transaction_type = 'REDEEM' AND t.claimed_at IS NULL`,
+}
+
+// GetDueReminderCandidates lists subjects matching the rule's trigger that are past
+// the initial delay, below the reminder cap and outside the repeat window
+func GetDueReminderCandidates(db *sql.DB, rule ReminderRule) ([]ReminderCandidate, error) {
+	subjects, ok := reminderSubjectQueries[rule.TriggerType]
+	if !ok {
+		return nil, fmt.Errorf("unknown reminder trigger: %s", rule.TriggerType)
+	}
+
+	query := `
+		SELECT s.subject_id, s.member_id, s.name, s.push_name, s.phone_number, s.detail, COALESCE(d.reminders_sent, 0)
+		FROM (` + subjects + `) s
+		LEFT JOIN reminder_deliveries d ON d.rule_id = $1 AND d.subject_id = s.subject_id
+		WHERE s.triggered_at <= CURRENT_TIMESTAMP - make_interval(hours => $2)
+			AND COALESCE(d.reminders_sent, 0) < $3
+			AND (d.last_sent_at IS NULL OR d.last_sent_at <= CURRENT_TIMESTAMP - make_interval(hours => $4))
+		ORDER BY s.subject_id ASC
+	`
+	rows, err := db.Query(DialectOf(db).Rebind(query), rule.RuleID, rule.DelayHours, rule.MaxReminders, rule.RepeatHours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminder candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []ReminderCandidate
+	for rows.Next() {
+		var c ReminderCandidate
+		if err := rows.Scan(&c.SubjectID, &c.MemberID, &c.MemberName, &c.PushName, &c.PhoneNumber, &c.Detail, &c.RemindersSent); err != nil {
+			return nil, fmt.Errorf("failed to scan reminder candidate: %w", err)
+		}
+		candidates = append(candidates, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reminder candidates: %w", err)
+	}
+	return candidates, nil
+}
+
+// RecordReminderSent increments the reminder count for a subject and returns the new count
+func RecordReminderSent(db *sql.DB, ruleID, subjectID, memberID int) (int, error) {
+	query := `
+		INSERT INTO reminder_deliveries (rule_id, subject_id, member_id, reminders_sent, last_sent_at)
+		VALUES ($1, $2, $3, 1, CURRENT_TIMESTAMP)
+		ON CONFLICT (rule_id, subject_id) DO UPDATE
+		SET reminders_sent = reminder_deliveries.reminders_sent + 1, last_sent_at = CURRENT_TIMESTAMP
+		RETURNING reminders_sent
+	`
+	var count int
+	if err := db.QueryRow(DialectOf(db).Rebind(query), ruleID, subjectID, memberID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to record reminder: %w", err)
+	}
+	return count, nil
+}
+
+// MarkReminderEscalated records that admins were alerted about a subject. It
+// returns false if the subject had already been escalated.
+func MarkReminderEscalated(db *sql.DB, ruleID, subjectID int) (bool, error) {
+	result, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE reminder_deliveries SET escalated_at = CURRENT_TIMESTAMP
+		WHERE rule_id = $1 AND subject_id = $2 AND escalated_at IS NULL
+	`), ruleID, subjectID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark reminder escalated: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// MarkRedemptionClaimed records that the reward for a redemption was handed over.
+// It returns false when no unclaimed redemption with that ID exists.
+func MarkRedemptionClaimed(db *sql.DB, transactionID int) (bool, error) {
+	result, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE point_transactions SET claimed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE transaction_id = $1 AND transaction_type = 'REDEEM' AND claimed_at IS NULL
+	`), transactionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark redemption claimed: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
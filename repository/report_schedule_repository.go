@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrReportScheduleNotFound = errors.New("report schedule not found")
+
+// ReportSchedule delivers a workbook of reports on a daily or weekly schedule.
+// Reports and recipients are stored comma-separated.
+type ReportSchedule struct {
+	ID                 int
+	Name               string
+	Reports            []string
+	Frequency          string
+	Hour               int
+	Weekday            int
+	WhatsAppRecipients []string
+	EmailRecipients    []string
+	IsActive           bool
+	LastRunAt          sql.NullTime
+	LastError          string
+	CreatedAt          time.Time
+}
+
+const reportScheduleColumns = `schedule_id, name, reports, frequency, send_hour, weekday,
+	whatsapp_recipients, email_recipients, is_active, last_run_at, last_error, created_at`
+
+// CreateReportSchedule inserts a schedule and returns its ID
+func CreateReportSchedule(db *sql.DB, s ReportSchedule) (int, error) {
+	var id int
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO report_schedules (name, reports, frequency, send_hour, weekday,
+			whatsapp_recipients, email_recipients, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING schedule_id
+	`), s.Name, strings.Join(s.Reports, ","), s.Frequency, s.Hour, s.Weekday,
+		strings.Join(s.WhatsAppRecipients, ","), strings.Join(s.EmailRecipients, ","), s.IsActive).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create report schedule: %w", err)
+	}
+	return id, nil
+}
+
+// GetReportSchedules returns all schedules by ID, or only the active ones
+func GetReportSchedules(db *sql.DB, activeOnly bool) ([]ReportSchedule, error) {
+	query := `SELECT ` + reportScheduleColumns + ` FROM report_schedules`
+	if activeOnly {
+		query += ` WHERE is_active = TRUE`
+	}
+	rows, err := db.Query(DialectOf(db).Rebind(query + ` ORDER BY schedule_id`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []ReportSchedule
+	for rows.Next() {
+		s, err := scanReportSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating report schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// GetReportSchedule returns a schedule, or ErrReportScheduleNotFound
+func GetReportSchedule(db *sql.DB, id int) (*ReportSchedule, error) {
+	s, err := scanReportSchedule(db.QueryRow(DialectOf(db).Rebind(`SELECT `+reportScheduleColumns+` FROM report_schedules WHERE schedule_id = $1`), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReportScheduleNotFound
+	}
+	return s, err
+}
+
+// UpdateReportSchedule replaces the settings of a schedule, keeping when it
+// last ran
+func UpdateReportSchedule(db *sql.DB, s ReportSchedule) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE report_schedules
+		SET name = $2, reports = $3, frequency = $4, send_hour = $5, weekday = $6,
+			whatsapp_recipients = $7, email_recipients = $8, is_active = $9, updated_at = CURRENT_TIMESTAMP
+		WHERE schedule_id = $1
+	`), s.ID, s.Name, strings.Join(s.Reports, ","), s.Frequency, s.Hour, s.Weekday,
+		strings.Join(s.WhatsAppRecipients, ","), strings.Join(s.EmailRecipients, ","), s.IsActive)
+	if err != nil {
+		return fmt.Errorf("failed to update report schedule %d: %w", s.ID, err)
+	}
+	return requireRow(result, ErrReportScheduleNotFound)
+}
+
+// DeleteReportSchedule removes a schedule
+func DeleteReportSchedule(db *sql.DB, id int) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`DELETE FROM report_schedules WHERE schedule_id = $1`), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete report schedule %d: %w", id, err)
+	}
+	return requireRow(result, ErrReportScheduleNotFound)
+}
+
+// MarkReportScheduleRun records that a schedule ran at, with the deliveries
+// that failed in lastError
+func MarkReportScheduleRun(db *sql.DB, id int, at time.Time, lastError string) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`UPDATE report_schedules SET last_run_at = $2, last_error = $3 WHERE schedule_id = $1`), id, at, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to record run of report schedule %d: %w", id, err)
+	}
+	return nil
+}
+
+func scanReportSchedule(row rowScanner) (*ReportSchedule, error) {
+	var s ReportSchedule
+	var reports, whatsapp, email string
+	err := row.Scan(&s.ID, &s.Name, &reports, &s.Frequency, &s.Hour, &s.Weekday,
+		&whatsapp, &email, &s.IsActive, &s.LastRunAt, &s.LastError, &s.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan report schedule: %w", err)
+	}
+	s.Reports = splitList(reports)
+	s.WhatsAppRecipients = splitList(whatsapp)
+	s.EmailRecipients = splitList(email)
+	return &s, nil
+}
+
+// splitList splits a stored comma-separated list, empty for an empty string
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RetentionTable is an operational table whose old rows are pruned
+type RetentionTable struct {
+	Name   string
+	Column string // timestamp the rows age by
+	Where  string // further condition on the rows that may be pruned, if any
+}
+
+// Operational tables pruned by the retention job
+var (
+	RetentionMessageHistory  = RetentionTable{Name: "message_history", Column: "created_at"}
+	RetentionInboundEvents   = RetentionTable{Name: "inbound_events", Column: "created_at"}
+	RetentionMessageStatus   = RetentionTable{Name: "message_status", Column: "updated_at"}
+	RetentionSendQueue       = RetentionTable{Name: "send_queue", Column: "updated_at", Where: "status <> '" + SendQueuePending + "'"}
+	RetentionIdempotencyKeys = RetentionTable{Name: "idempotency_keys", Column: "created_at"}
+	RetentionShadowDiffs     = RetentionTable{Name: "shadow_diffs", Column: "created_at"}
+	RetentionSenderActivity  = RetentionTable{Name: "sender_activity", Column: "hour"}
+	RetentionDeadLetters     = RetentionTable{Name: "dead_letters", Column: "created_at"}
+)
+
+// pruneBatchSize bounds each DELETE so a large backlog is pruned without
+// holding locks on the table for long
+const pruneBatchSize = 5000
+
+// PruneRows deletes the table's rows older than before, in batches, and
+// returns how many were deleted
+func PruneRows(db *sql.DB, table RetentionTable, before time.Time) (int64, error) {
+	where := table.Column + " < $1"
+	if table.Where != "" {
+		where += " AND " + table.Where
+	}
+	query := fmt.Sprintf(`
+		DELETE FROM %[1]s WHERE ctid IN (
+			SELECT ctid FROM %[1]s WHERE %[2]s LIMIT $2
+		)`, table.Name, where)
+
+	var total int64
+	for {
+		result, err := db.Exec(DialectOf(db).Rebind(query), before, pruneBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to prune %s: %w", table.Name, err)
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("failed to prune %s: %w", table.Name, err)
+		}
+		total += deleted
+		if deleted < pruneBatchSize {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Send queue statuses
+const (
+	SendQueuePending = "pending"
+	SendQueueSent    = "sent"
+	SendQueueFailed  = "failed"  // gave up after the last attempt
+	SendQueueExpired = "expired" // held for a sender to reconnect for too long
+)
+
+// QueuedSend is an outbound message waiting for a retry
+type QueuedSend struct {
+	ID        int64
+	Payload   string // the send request as JSON
+	RequestID string // X-Request-ID of the API call that queued it
+	Attempts  int    // sends tried so far
+	LastError string
+	QueuedAt  time.Time
+}
+
+// SendQueueStats counts the queued messages
+type SendQueueStats struct {
+	Pending        int // messages waiting for a retry
+	Failed         int // messages given up on
+	Expired        int // messages that expired waiting for a sender
+	FailedAttempts int // failed sends of pending and failed messages
+}
+
+// EnqueueSend stores a message for a retry at nextAttempt
+func EnqueueSend(db *sql.DB, payload, requestID string, attempts int, nextAttempt time.Time, lastError string) (int64, error) {
+	var id int64
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO send_queue (payload, request_id, attempts, next_attempt_at, last_error)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING queue_id
+	`), payload, requestID, attempts, nextAttempt, lastError).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue send: %w", err)
+	}
+	return id, nil
+}
+
+// GetDueSends returns up to limit pending messages whose retry is due, oldest first
+func GetDueSends(db *sql.DB, now time.Time, limit int) ([]QueuedSend, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT queue_id, payload, request_id, attempts, COALESCE(last_error, ''), created_at
+		FROM send_queue
+		WHERE status = 'pending' AND next_attempt_at <= $1
+		ORDER BY next_attempt_at, queue_id
+		LIMIT $2
+	`), now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due sends: %w", err)
+	}
+	defer rows.Close()
+
+	var sends []QueuedSend
+	for rows.Next() {
+		var s QueuedSend
+		if err := rows.Scan(&s.ID, &s.Payload, &s.RequestID, &s.Attempts, &s.LastError, &s.QueuedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan queued send: %w", err)
+		}
+		sends = append(sends, s)
+	}
+	return sends, rows.Err()
+}
+
+// UpdateSend records the outcome of a retry: the new status, attempt count and
+// error, and when to try next while still pending
+func UpdateSend(db *sql.DB, id int64, status string, attempts int, nextAttempt time.Time, lastError string) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE send_queue
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, updated_at = CURRENT_TIMESTAMP
+		WHERE queue_id = $1
+	`), id, status, attempts, nextAttempt, lastError)
+	if err != nil {
+		return fmt.Errorf("failed to update queued send: %w", err)
+	}
+	return nil
+}
+
+// GetSendQueueStats counts the pending, failed and expired messages
+func GetSendQueueStats(db *sql.DB) (*SendQueueStats, error) {
+	var stats SendQueueStats
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COUNT(*) FILTER (WHERE status = 'failed'),
+			COUNT(*) FILTER (WHERE status = 'expired'),
+			COALESCE(SUM(attempts) FILTER (WHERE status <> 'sent'), 0)
+		FROM send_queue
+	`)).Scan(&stats.Pending, &stats.Failed, &stats.Expired, &stats.FailedAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get send queue stats: %w", err)
+	}
+	return &stats, nil
+}
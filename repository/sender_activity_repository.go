@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SenderActivityHour is what one sender sent and received in an hour
+type SenderActivityHour struct {
+	Hour     time.Time
+	Sent     int
+	Received int
+}
+
+// RecordSenderActivity adds sent and received messages to the sender's count
+// for the current hour
+func RecordSenderActivity(db *sql.DB, senderID string, sent, received int) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		INSERT INTO sender_activity (sender_id, hour, sent, received)
+		VALUES ($1, date_trunc('hour', CURRENT_TIMESTAMP), $2, $3)
+		ON CONFLICT (sender_id, hour) DO UPDATE
+		SET sent = sender_activity.sent + EXCLUDED.sent,
+			received = sender_activity.received + EXCLUDED.received
+	`), senderID, sent, received)
+	if err != nil {
+		return fmt.Errorf("failed to record sender activity: %w", err)
+	}
+	return nil
+}
+
+// GetSenderQuotaUsage returns the daily quota of the sender and how many
+// messages it sent on day, a YYYY-MM-DD date in the quota time zone
+func GetSenderQuotaUsage(db *sql.DB, senderID, day string) (quota, sent int, err error) {
+	err = db.QueryRow(DialectOf(db).Rebind(`
+		SELECT s.daily_quota, COALESCE(d.sent, 0)
+		FROM senders s
+		LEFT JOIN sender_daily_sends d ON d.sender_id = s.sender_id AND d.day = $2
+		WHERE s.sender_id = $1
+	`), senderID, day).Scan(&quota, &sent)
+	if err == sql.ErrNoRows {
+		return 0, 0, fmt.Errorf("%w: %s", ErrSenderNotFound, senderID)
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get sender quota usage: %w", err)
+	}
+	return quota, sent, nil
+}
+
+// AddSenderDailySend counts a message the sender sent on day, a YYYY-MM-DD
+// date in the quota time zone, against its daily quota
+func AddSenderDailySend(db *sql.DB, senderID, day string) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		INSERT INTO sender_daily_sends (sender_id, day, sent)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (sender_id, day) DO UPDATE
+		SET sent = sender_daily_sends.sent + 1
+	`), senderID, day)
+	if err != nil {
+		return fmt.Errorf("failed to count sender daily send: %w", err)
+	}
+	return nil
+}
+
+// GetSenderActivity returns the sender's hourly counts in [from, to), oldest
+// first. Hours without messages are left out.
+func GetSenderActivity(db *sql.DB, senderID string, from, to time.Time) ([]SenderActivityHour, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT hour, sent, received FROM sender_activity
+		WHERE sender_id = $1 AND hour >= $2 AND hour < $3
+		ORDER BY hour
+	`), senderID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sender activity: %w", err)
+	}
+	defer rows.Close()
+
+	var activity []SenderActivityHour
+	for rows.Next() {
+		var h SenderActivityHour
+		if err := rows.Scan(&h.Hour, &h.Sent, &h.Received); err != nil {
+			return nil, fmt.Errorf("failed to scan sender activity: %w", err)
+		}
+		activity = append(activity, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sender activity: %w", err)
+	}
+	return activity, nil
+}
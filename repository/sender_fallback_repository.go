@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// ErrUnknownSender is returned when a fallback chain references a sender that
+// is not registered
+var ErrUnknownSender = errors.New("unknown sender")
+
+// GetSenderFallbackChain returns the sender IDs for category in the order they
+// should be tried. An unconfigured category yields an empty chain.
+func GetSenderFallbackChain(db *sql.DB, category string) ([]string, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT sender_id FROM sender_fallback_chains
+		WHERE category = $1
+		ORDER BY position
+	`), category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fallback chain: %w", err)
+	}
+	defer rows.Close()
+
+	var chain []string
+	for rows.Next() {
+		var senderID string
+		if err := rows.Scan(&senderID); err != nil {
+			return nil, fmt.Errorf("failed to scan fallback chain: %w", err)
+		}
+		chain = append(chain, senderID)
+	}
+	return chain, rows.Err()
+}
+
+// GetSenderFallbackChains returns every configured chain keyed by category
+func GetSenderFallbackChains(db *sql.DB) (map[string][]string, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT category, sender_id FROM sender_fallback_chains
+		ORDER BY category, position
+	`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fallback chains: %w", err)
+	}
+	defer rows.Close()
+
+	chains := make(map[string][]string)
+	for rows.Next() {
+		var category, senderID string
+		if err := rows.Scan(&category, &senderID); err != nil {
+			return nil, fmt.Errorf("failed to scan fallback chain: %w", err)
+		}
+		chains[category] = append(chains[category], senderID)
+	}
+	return chains, rows.Err()
+}
+
+// ReplaceSenderFallbackChain replaces the chain for category with senderIDs,
+// in order. An empty list removes the chain. Returns ErrUnknownSender if any
+// sender is not registered.
+func ReplaceSenderFallbackChain(db *sql.DB, category string, senderIDs []string) error {
+	tx, err := Begin(db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if len(senderIDs) > 0 {
+		var known int
+		if err := tx.QueryRow(
+			"SELECT COUNT(*) FROM senders WHERE sender_id = ANY($1)",
+			pq.Array(senderIDs),
+		).Scan(&known); err != nil {
+			return fmt.Errorf("failed to check senders: %w", err)
+		}
+		if known != len(senderIDs) {
+			return ErrUnknownSender
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM sender_fallback_chains WHERE category = $1", category); err != nil {
+		return fmt.Errorf("failed to clear fallback chain: %w", err)
+	}
+	for i, senderID := range senderIDs {
+		if _, err := tx.Exec(
+			"INSERT INTO sender_fallback_chains (category, position, sender_id) VALUES ($1, $2, $3)",
+			category, i+1, senderID,
+		); err != nil {
+			return fmt.Errorf("failed to save fallback chain: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// GetSenderPool returns the sender IDs of the pool name in the order they were
+// listed. An unknown pool yields no senders.
+func GetSenderPool(db *sql.DB, name string) ([]string, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT sender_id FROM sender_pools
+		WHERE name = $1
+		ORDER BY position
+	`), name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sender pool: %w", err)
+	}
+	defer rows.Close()
+
+	var pool []string
+	for rows.Next() {
+		var senderID string
+		if err := rows.Scan(&senderID); err != nil {
+			return nil, fmt.Errorf("failed to scan sender pool: %w", err)
+		}
+		pool = append(pool, senderID)
+	}
+	return pool, rows.Err()
+}
+
+// GetSenderPools returns every sender pool keyed by name
+func GetSenderPools(db *sql.DB) (map[string][]string, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT name, sender_id FROM sender_pools
+		ORDER BY name, position
+	`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sender pools: %w", err)
+	}
+	defer rows.Close()
+
+	pools := make(map[string][]string)
+	for rows.Next() {
+		var name, senderID string
+		if err := rows.Scan(&name, &senderID); err != nil {
+			return nil, fmt.Errorf("failed to scan sender pool: %w", err)
+		}
+		pools[name] = append(pools[name], senderID)
+	}
+	return pools, rows.Err()
+}
+
+// ReplaceSenderPool replaces the members of pool name with senderIDs, in
+// order. An empty list removes the pool. Returns ErrUnknownSender if any
+// sender is not registered.
+func ReplaceSenderPool(db *sql.DB, name string, senderIDs []string) error {
+	tx, err := Begin(db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if len(senderIDs) > 0 {
+		var known int
+		if err := tx.QueryRow(
+			"SELECT COUNT(*) FROM senders WHERE sender_id = ANY($1)",
+			pq.Array(senderIDs),
+		).Scan(&known); err != nil {
+			return fmt.Errorf("failed to check senders: %w", err)
+		}
+		if known != len(senderIDs) {
+			return ErrUnknownSender
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM sender_pools WHERE name = $1", name); err != nil {
+		return fmt.Errorf("failed to clear sender pool: %w", err)
+	}
+	for i, senderID := range senderIDs {
+		if _, err := tx.Exec(
+			"INSERT INTO sender_pools (name, position, sender_id) VALUES ($1, $2, $3)",
+			name, i+1, senderID,
+		); err != nil {
+			return fmt.Errorf("failed to save sender pool: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
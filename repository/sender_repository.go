@@ -2,21 +2,47 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
-// Sender represents a WhatsApp sender in the database
+var ErrSenderNotFound = errors.New("sender not found")
+
+// Sender states; anything but active is out of send rotation
+const (
+	SenderStateActive     = "active"
+	SenderStateRestricted = "restricted"
+	SenderStateBanned     = "banned"
+)
+
+// Sender represents a WhatsApp sender in the database. Tags are stored
+// comma-separated.
 type Sender struct {
 	SenderID    string
 	PhoneNumber string
 	Name        string
+	Description string
+	Tags        []string
 	IsDefault   bool
 	IsActive    bool
+	IsPaused    bool   // taken out of rotation by an operator; still connected
+	State       string // active, restricted or banned
+	StateReason string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+
+	LastSentAt           sql.NullTime // last message sent through the sender
+	LastDisconnectReason string       // why the sender last lost its connection
+	LastDisconnectedAt   sql.NullTime
+	DailyQuota           int // most messages the sender may send a day; 0 is no cap
 }
 
+const senderColumns = `sender_id, phone_number, name, COALESCE(description, ''), COALESCE(tags, ''),
+	is_default, is_active, is_paused, COALESCE(state, 'active'), COALESCE(state_reason, ''), created_at, updated_at,
+	last_sent_at, last_disconnect_reason, last_disconnected_at, daily_quota`
+
 // CreateSenderIfNotExists creates a sender record if it doesn't already exist
 func CreateSenderIfNotExists(db *sql.DB, senderID, phoneNumber, name string, isDefault bool) error {
 	query := `
@@ -25,7 +51,7 @@ func CreateSenderIfNotExists(db *sql.DB, senderID, phoneNumber, name string, isD
 		ON CONFLICT (sender_id) DO NOTHING
 	`
 
-	_, err := db.Exec(query, senderID, phoneNumber, name, isDefault, true)
+	_, err := db.Exec(DialectOf(db).Rebind(query), senderID, phoneNumber, name, isDefault, true)
 	if err != nil {
 		return fmt.Errorf("failed to create sender record: %w", err)
 	}
@@ -36,52 +62,32 @@ func CreateSenderIfNotExists(db *sql.DB, senderID, phoneNumber, name string, isD
 // GetSenderByID retrieves a sender by their ID
 func GetSenderByID(db *sql.DB, senderID string) (*Sender, error) {
 	query := `
-		SELECT sender_id, phone_number, name, is_default, is_active, created_at, updated_at
+		SELECT ` + senderColumns + `
 		FROM senders
 		WHERE sender_id = $1
 	`
 
-	var sender Sender
-	err := db.QueryRow(query, senderID).Scan(
-		&sender.SenderID,
-		&sender.PhoneNumber,
-		&sender.Name,
-		&sender.IsDefault,
-		&sender.IsActive,
-		&sender.CreatedAt,
-		&sender.UpdatedAt,
-	)
-
+	sender, err := scanSender(db.QueryRow(DialectOf(db).Rebind(query), senderID))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("sender not found: %s", senderID)
+			return nil, fmt.Errorf("%w: %s", ErrSenderNotFound, senderID)
 		}
 		return nil, fmt.Errorf("failed to get sender: %w", err)
 	}
 
-	return &sender, nil
+	return sender, nil
 }
 
 // GetDefaultSender retrieves the default sender from the database
 func GetDefaultSender(db *sql.DB) (*Sender, error) {
 	query := `
-		SELECT sender_id, phone_number, name, is_default, is_active, created_at, updated_at
+		SELECT ` + senderColumns + `
 		FROM senders
-		WHERE is_default = true AND is_active = true
+		WHERE is_default = true AND is_active = true AND is_paused = false
 		LIMIT 1
 	`
 
-	var sender Sender
-	err := db.QueryRow(query).Scan(
-		&sender.SenderID,
-		&sender.PhoneNumber,
-		&sender.Name,
-		&sender.IsDefault,
-		&sender.IsActive,
-		&sender.CreatedAt,
-		&sender.UpdatedAt,
-	)
-
+	sender, err := scanSender(db.QueryRow(DialectOf(db).Rebind(query)))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			// If no default sender found, try to get the first active sender
@@ -90,30 +96,20 @@ func GetDefaultSender(db *sql.DB) (*Sender, error) {
 		return nil, fmt.Errorf("failed to get default sender: %w", err)
 	}
 
-	return &sender, nil
+	return sender, nil
 }
 
 // getFirstActiveSender retrieves the first active sender ordered by creation date
 func getFirstActiveSender(db *sql.DB) (*Sender, error) {
 	query := `
-		SELECT sender_id, phone_number, name, is_default, is_active, created_at, updated_at
+		SELECT ` + senderColumns + `
 		FROM senders
-		WHERE is_active = true
+		WHERE is_active = true AND is_paused = false
 		ORDER BY created_at ASC
 		LIMIT 1
 	`
 
-	var sender Sender
-	err := db.QueryRow(query).Scan(
-		&sender.SenderID,
-		&sender.PhoneNumber,
-		&sender.Name,
-		&sender.IsDefault,
-		&sender.IsActive,
-		&sender.CreatedAt,
-		&sender.UpdatedAt,
-	)
-
+	sender, err := scanSender(db.QueryRow(DialectOf(db).Rebind(query)))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("no active senders found")
@@ -121,18 +117,18 @@ func getFirstActiveSender(db *sql.DB) (*Sender, error) {
 		return nil, fmt.Errorf("failed to get first active sender: %w", err)
 	}
 
-	return &sender, nil
+	return sender, nil
 }
 
 // GetAllSenders retrieves all senders from the database
 func GetAllSenders(db *sql.DB) ([]Sender, error) {
 	query := `
-		SELECT sender_id, phone_number, name, is_default, is_active, created_at, updated_at
+		SELECT ` + senderColumns + `
 		FROM senders
 		ORDER BY is_default DESC, created_at ASC
 	`
 
-	rows, err := db.Query(query)
+	rows, err := db.Query(DialectOf(db).Rebind(query))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query senders: %w", err)
 	}
@@ -140,20 +136,11 @@ func GetAllSenders(db *sql.DB) ([]Sender, error) {
 
 	var senders []Sender
 	for rows.Next() {
-		var sender Sender
-		err := rows.Scan(
-			&sender.SenderID,
-			&sender.PhoneNumber,
-			&sender.Name,
-			&sender.IsDefault,
-			&sender.IsActive,
-			&sender.CreatedAt,
-			&sender.UpdatedAt,
-		)
+		sender, err := scanSender(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan sender: %w", err)
 		}
-		senders = append(senders, sender)
+		senders = append(senders, *sender)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -163,10 +150,43 @@ func GetAllSenders(db *sql.DB) ([]Sender, error) {
 	return senders, nil
 }
 
+// UpdateSenderProfile replaces the name, description and tags operators gave
+// a sender
+func UpdateSenderProfile(db *sql.DB, senderID, name, description string, tags []string) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE senders
+		SET name = $2, description = $3, tags = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE sender_id = $1
+	`), senderID, name, description, strings.Join(tags, ","))
+	if err != nil {
+		return fmt.Errorf("failed to update sender %s: %w", senderID, err)
+	}
+	return requireRow(result, ErrSenderNotFound)
+}
+
+// SetSenderPaused takes a sender out of send rotation, or puts it back
+func SetSenderPaused(db *sql.DB, senderID string, paused bool) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`UPDATE senders SET is_paused = $2, updated_at = CURRENT_TIMESTAMP WHERE sender_id = $1`), senderID, paused)
+	if err != nil {
+		return fmt.Errorf("failed to update paused flag of sender %s: %w", senderID, err)
+	}
+	return requireRow(result, ErrSenderNotFound)
+}
+
+// SetSenderDailyQuota caps the messages the sender may send a day; 0 removes
+// the cap
+func SetSenderDailyQuota(db *sql.DB, senderID string, quota int) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`UPDATE senders SET daily_quota = $2, updated_at = CURRENT_TIMESTAMP WHERE sender_id = $1`), senderID, quota)
+	if err != nil {
+		return fmt.Errorf("failed to update daily quota of sender %s: %w", senderID, err)
+	}
+	return requireRow(result, ErrSenderNotFound)
+}
+
 // UpdateSenderStatus updates the active status of a sender
 func UpdateSenderStatus(db *sql.DB, senderID string, isActive bool) error {
 	// Use a transaction to avoid prepared statement caching conflicts
-	tx, err := db.Begin()
+	tx, err := Begin(db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -202,7 +222,7 @@ func UpdateSenderStatus(db *sql.DB, senderID string, isActive bool) error {
 
 // SetDefaultSender sets a sender as the default sender and unsets all others
 func SetDefaultSender(db *sql.DB, senderID string) error {
-	tx, err := db.Begin()
+	tx, err := Begin(db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -238,3 +258,53 @@ func SetDefaultSender(db *sql.DB, senderID string) error {
 
 	return nil
 }
+
+// SetSenderState records a sender's account state. Restricted and banned
+// senders are also marked inactive; until is when a temporary restriction is
+// expected to lift, or nil if unknown.
+func SetSenderState(db *sql.DB, senderID, state, reason string, until *time.Time) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE senders
+		SET state = $2, state_reason = NULLIF($3, ''), state_until = $4,
+			is_active = ($2 = 'active'), updated_at = CURRENT_TIMESTAMP
+		WHERE sender_id = $1
+	`), senderID, state, reason, until)
+	if err != nil {
+		return fmt.Errorf("failed to update sender state: %w", err)
+	}
+	return nil
+}
+
+// SetSenderLastSent stamps the time a message was last sent through a sender
+func SetSenderLastSent(db *sql.DB, senderID string) error {
+	if _, err := db.Exec(DialectOf(db).Rebind(`UPDATE senders SET last_sent_at = CURRENT_TIMESTAMP WHERE sender_id = $1`), senderID); err != nil {
+		return fmt.Errorf("failed to update last send of sender %s: %w", senderID, err)
+	}
+	return nil
+}
+
+// SetSenderDisconnected records why, and when, a sender last lost its connection
+func SetSenderDisconnected(db *sql.DB, senderID, reason string) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE senders
+		SET last_disconnect_reason = $2, last_disconnected_at = CURRENT_TIMESTAMP
+		WHERE sender_id = $1
+	`), senderID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to record disconnect of sender %s: %w", senderID, err)
+	}
+	return nil
+}
+
+func scanSender(row rowScanner) (*Sender, error) {
+	var sender Sender
+	var tags string
+	err := row.Scan(&sender.SenderID, &sender.PhoneNumber, &sender.Name, &sender.Description, &tags,
+		&sender.IsDefault, &sender.IsActive, &sender.IsPaused, &sender.State, &sender.StateReason, &sender.CreatedAt, &sender.UpdatedAt,
+		&sender.LastSentAt, &sender.LastDisconnectReason, &sender.LastDisconnectedAt, &sender.DailyQuota)
+	if err != nil {
+		return nil, err
+	}
+	sender.Tags = splitList(tags)
+	return &sender, nil
+}
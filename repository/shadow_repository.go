@@ -0,0 +1,25 @@
+package repository
+
+import "fmt"
+
+// ShadowDiff records a case where a shadow implementation disagreed with the live one
+type ShadowDiff struct {
+	Component    string // which pipeline stage was shadowed, e.g. "command_router"
+	MessageID    string
+	RawText      string
+	LiveResult   string // JSON of the live decision
+	ShadowResult string // JSON of the shadow decision
+}
+
+// InsertShadowDiff stores a shadow-mode disagreement for later comparison
+func InsertShadowDiff(exec Executor, diff ShadowDiff) error {
+	query := `
+		INSERT INTO shadow_diffs (component, message_id, raw_text, live_result, shadow_result, created_at)
+		VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP)
+	`
+	_, err := exec.Exec(rebind(exec, query), diff.Component, diff.MessageID, diff.RawText, diff.LiveResult, diff.ShadowResult)
+	if err != nil {
+		return fmt.Errorf("failed to insert shadow diff: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// StaffActivity is what one admin phone entered over a period through the
+// INPUT# and STEMPEL# commands
+type StaffActivity struct {
+	PhoneNumber    string
+	PointsCredits  int // INPUT# entries
+	PointsCredited int // points those entries credited
+	StampEntries   int // STEMPEL# entries
+	StampsAdded    int
+	MembersServed  int // distinct members credited points or stamps
+	SelfCredits    int // entries crediting the admin's own membership
+	FirstEntryAt   time.Time
+	LastEntryAt    time.Time
+}
+
+// GetStaffActivity returns the points and stamp entries each admin phone made
+// in [from, to), by phone number
+func GetStaffActivity(db *sql.DB, from, to time.Time) ([]StaffActivity, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		WITH entries AS (
+			SELECT pt.performed_by AS staff, m.member_id, m.phone_number AS member_phone,
+				pt.points_changed AS points, 0 AS stamps, 'points' AS kind, pt.transaction_date AS entered_at
+			FROM point_transactions pt
+			JOIN points p ON p.point_id = pt.point_id
+			JOIN members m ON m.member_id = p.member_id
+			WHERE pt.performed_by IS NOT NULL AND pt.transaction_type = 'EARN'
+				AND pt.transaction_date >= $1 AND pt.transaction_date < $2
+			UNION ALL
+			SELECT st.performed_by, m.member_id, m.phone_number,
+				0, st.stamps, 'stamps', st.created_at
+			FROM stamp_transactions st
+			JOIN members m ON m.member_id = st.member_id
+			WHERE st.transaction_type = 'STAMP'
+				AND st.created_at >= $1 AND st.created_at < $2
+		)
+		SELECT staff,
+			COUNT(*) FILTER (WHERE kind = 'points'),
+			COALESCE(SUM(points), 0),
+			COUNT(*) FILTER (WHERE kind = 'stamps'),
+			COALESCE(SUM(stamps), 0),
+			COUNT(DISTINCT member_id),
+			COUNT(*) FILTER (WHERE member_phone = staff),
+			MIN(entered_at),
+			MAX(entered_at)
+		FROM entries
+		GROUP BY staff
+		ORDER BY staff
+	`), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query staff activity: %w", err)
+	}
+	defer rows.Close()
+
+	var activity []StaffActivity
+	for rows.Next() {
+		var a StaffActivity
+		if err := rows.Scan(&a.PhoneNumber, &a.PointsCredits, &a.PointsCredited, &a.StampEntries, &a.StampsAdded,
+			&a.MembersServed, &a.SelfCredits, &a.FirstEntryAt, &a.LastEntryAt); err != nil {
+			return nil, fmt.Errorf("failed to scan staff activity: %w", err)
+		}
+		activity = append(activity, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating staff activity: %w", err)
+	}
+	return activity, nil
+}
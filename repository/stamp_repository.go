@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Loyalty programs a tenant can run
+const (
+	ProgramPoints = "points"
+	ProgramStamps = "stamps"
+	ProgramBoth   = "both"
+)
+
+var ErrNoStampReward = errors.New("member has no stamp card reward to claim")
+
+// LoyaltyProgramSettings is a tenant's choice of loyalty program and stamp card rules
+type LoyaltyProgramSettings struct {
+	TenantID      int
+	Program       string // points, stamps or both
+	StampCardSize int    // stamps needed to complete a card
+	StampReward   string // reward earned per completed card
+}
+
+// PointsEnabled reports whether the tenant runs the points program
+func (s LoyaltyProgramSettings) PointsEnabled() bool {
+	return s.Program != ProgramStamps
+}
+
+// StampsEnabled reports whether the tenant runs the stamp card program
+func (s LoyaltyProgramSettings) StampsEnabled() bool {
+	return s.Program == ProgramStamps || s.Program == ProgramBoth
+}
+
+// StampCard is a member's progress on the stamp card program
+type StampCard struct {
+	MemberID         int
+	Stamps           int // stamps on the current card
+	RewardsAvailable int // completed cards not yet claimed
+	CardsCompleted   int // lifetime completed cards
+}
+
+// GetLoyaltyProgramSettings returns the loyalty program of the tenant with the
+// given slug, or nil if there is no such tenant
+func GetLoyaltyProgramSettings(db *sql.DB, slug string) (*LoyaltyProgramSettings, error) {
+	var s LoyaltyProgramSettings
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		SELECT tenant_id, loyalty_program, stamp_card_size, stamp_reward
+		FROM tenants WHERE slug = $1
+	`), slug).Scan(&s.TenantID, &s.Program, &s.StampCardSize, &s.StampReward)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loyalty program settings: %w", err)
+	}
+	return &s, nil
+}
+
+// UpdateLoyaltyProgramSettings stores a tenant's loyalty program settings
+func UpdateLoyaltyProgramSettings(db *sql.DB, s LoyaltyProgramSettings) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE tenants SET loyalty_program = $2, stamp_card_size = $3, stamp_reward = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE tenant_id = $1
+	`), s.TenantID, s.Program, s.StampCardSize, s.StampReward)
+	if err != nil {
+		return fmt.Errorf("failed to update loyalty program settings: %w", err)
+	}
+	return nil
+}
+
+// GetStampCard returns a member's stamp card. Members who never received a
+// stamp have an empty card.
+func GetStampCard(exec Executor, memberID int) (*StampCard, error) {
+	card := StampCard{MemberID: memberID}
+	err := exec.QueryRow(rebind(exec, `
+		SELECT stamps, rewards_available, cards_completed FROM member_stamp_cards WHERE member_id = $1
+	`), memberID).Scan(&card.Stamps, &card.RewardsAvailable, &card.CardsCompleted)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to get stamp card: %w", err)
+	}
+	return &card, nil
+}
+
+// AddStamps adds stamps to a member's card, completing as many cards as the
+// total allows. It returns the updated card and how many cards were completed.
+func AddStamps(db *sql.DB, memberID, stamps, cardSize int, creditedBy string) (*StampCard, int, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the card so concurrent stamps are not lost
+	if _, err := tx.Exec(`
+		INSERT INTO member_stamp_cards (member_id, stamps, rewards_available, cards_completed, updated_at)
+		VALUES ($1, 0, 0, 0, CURRENT_TIMESTAMP)
+		ON CONFLICT (member_id) DO NOTHING
+	`, memberID); err != nil {
+		return nil, 0, fmt.Errorf("failed to create stamp card: %w", err)
+	}
+	card := StampCard{MemberID: memberID}
+	if err := tx.QueryRow(`
+		SELECT stamps, rewards_available, cards_completed FROM member_stamp_cards WHERE member_id = $1 FOR UPDATE
+	`, memberID).Scan(&card.Stamps, &card.RewardsAvailable, &card.CardsCompleted); err != nil {
+		return nil, 0, fmt.Errorf("failed to lock stamp card: %w", err)
+	}
+
+	total := card.Stamps + stamps
+	completed := total / cardSize
+	card.Stamps = total % cardSize
+	card.RewardsAvailable += completed
+	card.CardsCompleted += completed
+
+	if _, err := tx.Exec(`
+		UPDATE member_stamp_cards
+		SET stamps = $2, rewards_available = $3, cards_completed = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE member_id = $1
+	`, memberID, card.Stamps, card.RewardsAvailable, card.CardsCompleted); err != nil {
+		return nil, 0, fmt.Errorf("failed to update stamp card: %w", err)
+	}
+	if err := insertStampTransaction(tx, memberID, stamps, "STAMP", creditedBy); err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &card, completed, nil
+}
+
+// ClaimStampReward uses one of a member's completed cards, returning
+// ErrNoStampReward when there is none
+func ClaimStampReward(db *sql.DB, memberID int, claimedBy string) (*StampCard, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	card := StampCard{MemberID: memberID}
+	err = tx.QueryRow(`
+		UPDATE member_stamp_cards
+		SET rewards_available = rewards_available - 1, updated_at = CURRENT_TIMESTAMP
+		WHERE member_id = $1 AND rewards_available > 0
+		RETURNING stamps, rewards_available, cards_completed
+	`, memberID).Scan(&card.Stamps, &card.RewardsAvailable, &card.CardsCompleted)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoStampReward
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim stamp reward: %w", err)
+	}
+	if err := insertStampTransaction(tx, memberID, 0, "CLAIM", claimedBy); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return &card, nil
+}
+
+func insertStampTransaction(exec Executor, memberID, stamps int, transactionType, by string) error {
+	_, err := exec.Exec(rebind(exec, `
+		INSERT INTO stamp_transactions (member_id, stamps, transaction_type, performed_by, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+	`), memberID, stamps, transactionType, by)
+	if err != nil {
+		return fmt.Errorf("failed to record stamp transaction: %w", err)
+	}
+	return nil
+}
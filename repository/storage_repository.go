@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// TableSize is the disk space a table takes, indexes and TOAST included
+type TableSize struct {
+	Name  string
+	Bytes int64
+}
+
+// GetDatabaseSize returns the disk space the current database takes
+func GetDatabaseSize(db *sql.DB) (int64, error) {
+	var size int64
+	if err := db.QueryRow(DialectOf(db).Rebind(`SELECT pg_database_size(current_database())`)).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+	return size, nil
+}
+
+// GetTableSizes returns the size of every table in the public schema, largest
+// first
+func GetTableSizes(db *sql.DB) ([]TableSize, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT c.relname, pg_total_relation_size(c.oid)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p')
+		ORDER BY 2 DESC, 1
+	`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table sizes: %w", err)
+	}
+	defer rows.Close()
+
+	var sizes []TableSize
+	for rows.Next() {
+		var t TableSize
+		if err := rows.Scan(&t.Name, &t.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan table size: %w", err)
+		}
+		sizes = append(sizes, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating table sizes: %w", err)
+	}
+	return sizes, nil
+}
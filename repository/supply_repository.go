@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrSupplyNotFound = errors.New("supply not found")
+	ErrSupplyExists   = errors.New("supply already exists")
+	ErrStockNegative  = errors.New("stock cannot go below zero")
+	ErrItemNotFound   = errors.New("item not found")
+)
+
+// Reasons recorded on stock movements
+const (
+	StockReasonAdjustment = "adjustment"
+	StockReasonOrder      = "order"
+)
+
+// Supply is a consumable the laundry keeps in stock, such as detergent
+type Supply struct {
+	ID                int
+	Name              string
+	Unit              string // e.g. liter, kg or pcs
+	Stock             float64
+	LowStockThreshold float64 // stock at or below this is low
+	LowAlerted        bool    // admins were told the stock is low and it has not recovered since
+}
+
+// Low reports whether the supply is at or below its low-stock threshold
+func (s Supply) Low() bool {
+	return s.Stock <= s.LowStockThreshold
+}
+
+// SupplyUsage is how much of a supply one kilo or one piece of a catalog item uses
+type SupplyUsage struct {
+	SupplyID int
+	PerKilo  float64
+	PerUnit  float64
+}
+
+const supplyColumns = `supply_id, name, unit, stock, low_stock_threshold, low_alerted`
+
+func scanSupply(row rowScanner) (*Supply, error) {
+	var s Supply
+	if err := row.Scan(&s.ID, &s.Name, &s.Unit, &s.Stock, &s.LowStockThreshold, &s.LowAlerted); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// CreateSupply adds a supply and returns its ID, or ErrSupplyExists when a
+// supply with the same name exists
+func CreateSupply(db *sql.DB, s Supply) (int, error) {
+	var id int
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO supplies (name, unit, stock, low_stock_threshold, low_alerted, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $3 <= $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT DO NOTHING
+		RETURNING supply_id
+	`), s.Name, s.Unit, s.Stock, s.LowStockThreshold).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, ErrSupplyExists
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to create supply: %w", err)
+	}
+	return id, nil
+}
+
+// GetSupplies returns all supplies by name
+func GetSupplies(db *sql.DB) ([]Supply, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`SELECT ` + supplyColumns + ` FROM supplies ORDER BY name`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query supplies: %w", err)
+	}
+	defer rows.Close()
+
+	var supplies []Supply
+	for rows.Next() {
+		s, err := scanSupply(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan supply: %w", err)
+		}
+		supplies = append(supplies, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating supplies: %w", err)
+	}
+	return supplies, nil
+}
+
+// AdjustSupplyStock adds change, negative to take stock out, to a supply and
+// records it in the ledger. It returns the updated supply and the supplies
+// that just went low, which are marked as alerted.
+func AdjustSupplyStock(db *sql.DB, supplyID int, change float64, note string) (*Supply, []Supply, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var stock float64
+	err = tx.QueryRow(`SELECT stock FROM supplies WHERE supply_id = $1 FOR UPDATE`, supplyID).Scan(&stock)
+	if err == sql.ErrNoRows {
+		return nil, nil, ErrSupplyNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get supply %d: %w", supplyID, err)
+	}
+	if stock+change < 0 {
+		return nil, nil, ErrStockNegative
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE supplies SET stock = stock + $2, updated_at = CURRENT_TIMESTAMP WHERE supply_id = $1
+	`, supplyID, change); err != nil {
+		return nil, nil, fmt.Errorf("failed to adjust supply %d: %w", supplyID, err)
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO stock_movements (supply_id, change, reason, note, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+	`, supplyID, change, StockReasonAdjustment, note); err != nil {
+		return nil, nil, fmt.Errorf("failed to record stock movement: %w", err)
+	}
+
+	low, err := claimLowStockAlerts(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	supply, err := scanSupply(tx.QueryRow(`SELECT `+supplyColumns+` FROM supplies WHERE supply_id = $1`, supplyID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get supply %d: %w", supplyID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return supply, low, nil
+}
+
+// GetItemSupplyUsage returns the supplies a catalog item uses
+func GetItemSupplyUsage(db *sql.DB, itemID int) ([]SupplyUsage, error) {
+	var exists bool
+	if err := db.QueryRow(DialectOf(db).Rebind(`SELECT EXISTS (SELECT 1 FROM items WHERE item_id = $1)`), itemID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check item %d: %w", itemID, err)
+	}
+	if !exists {
+		return nil, ErrItemNotFound
+	}
+
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT supply_id, per_kilo, per_unit FROM supply_usage WHERE item_id = $1 ORDER BY supply_id
+	`), itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query supply usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []SupplyUsage
+	for rows.Next() {
+		var u SupplyUsage
+		if err := rows.Scan(&u.SupplyID, &u.PerKilo, &u.PerUnit); err != nil {
+			return nil, fmt.Errorf("failed to scan supply usage: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating supply usage: %w", err)
+	}
+	return usage, nil
+}
+
+// SetItemSupplyUsage replaces the supplies a catalog item uses
+func SetItemSupplyUsage(db *sql.DB, itemID int, usage []SupplyUsage) error {
+	tx, err := Begin(db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM items WHERE item_id = $1)`, itemID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check item %d: %w", itemID, err)
+	}
+	if !exists {
+		return ErrItemNotFound
+	}
+
+	if _, err := tx.Exec(`DELETE FROM supply_usage WHERE item_id = $1`, itemID); err != nil {
+		return fmt.Errorf("failed to clear supply usage: %w", err)
+	}
+	for _, u := range usage {
+		if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM supplies WHERE supply_id = $1)`, u.SupplyID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check supply %d: %w", u.SupplyID, err)
+		}
+		if !exists {
+			return ErrSupplyNotFound
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO supply_usage (item_id, supply_id, per_kilo, per_unit) VALUES ($1, $2, $3, $4)
+		`, itemID, u.SupplyID, u.PerKilo, u.PerUnit); err != nil {
+			return fmt.Errorf("failed to save supply usage: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ConsumeOrderSupplies takes the supplies an order's items use out of stock.
+// An order is only consumed once; later calls change nothing. Stock is floored
+// at zero, since the laundry was already washed. It returns the supplies that
+// just went low, which are marked as alerted.
+func ConsumeOrderSupplies(db *sql.DB, orderID int) ([]Supply, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		INSERT INTO stock_movements (supply_id, change, reason, order_id, created_at)
+		SELECT u.supply_id, -SUM(oi.total_kilo * u.per_kilo + oi.total_unit * u.per_unit), $2, oi.order_id, CURRENT_TIMESTAMP
+		FROM order_items oi
+		JOIN supply_usage u ON u.item_id = oi.item_id
+		WHERE oi.order_id = $1
+		GROUP BY u.supply_id, oi.order_id
+		HAVING SUM(oi.total_kilo * u.per_kilo + oi.total_unit * u.per_unit) > 0
+		ON CONFLICT DO NOTHING
+		RETURNING supply_id, change
+	`, orderID, StockReasonOrder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record supplies of order %d: %w", orderID, err)
+	}
+	changes := make(map[int]float64)
+	for rows.Next() {
+		var supplyID int
+		var change float64
+		if err := rows.Scan(&supplyID, &change); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan stock movement: %w", err)
+		}
+		changes[supplyID] = change
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stock movements: %w", err)
+	}
+
+	for supplyID, change := range changes {
+		if _, err := tx.Exec(`
+			UPDATE supplies SET stock = GREATEST(stock + $2, 0), updated_at = CURRENT_TIMESTAMP WHERE supply_id = $1
+		`, supplyID, change); err != nil {
+			return nil, fmt.Errorf("failed to consume supply %d: %w", supplyID, err)
+		}
+	}
+
+	low, err := claimLowStockAlerts(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return low, nil
+}
+
+// claimLowStockAlerts marks supplies that went low as alerted, and clears the
+// mark of supplies restocked above their threshold so they alert again. It
+// returns the supplies that went low.
+func claimLowStockAlerts(tx *Tx) ([]Supply, error) {
+	rows, err := tx.Query(`
+		UPDATE supplies SET low_alerted = (stock <= low_stock_threshold)
+		WHERE low_alerted <> (stock <= low_stock_threshold)
+		RETURNING ` + supplyColumns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update low stock alerts: %w", err)
+	}
+	defer rows.Close()
+
+	var low []Supply
+	for rows.Next() {
+		s, err := scanSupply(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan supply: %w", err)
+		}
+		if s.LowAlerted {
+			low = append(low, *s)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating supplies: %w", err)
+	}
+	return low, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrSuppressionNotFound = errors.New("suppression not found")
+	ErrSuppressionExists   = errors.New("recipient is already suppressed")
+)
+
+// SuppressionBlocked is the reason of a recipient the business decided not to
+// message, as opposed to one that opted out
+const SuppressionBlocked = "blocked"
+
+// Suppression is a recipient that must not be messaged
+type Suppression struct {
+	Recipient string // phone number without the + sign, or group JID
+	Reason    string // opted_out or blocked
+	Note      string
+	CreatedAt time.Time
+}
+
+// GetSuppressions returns every suppressed recipient, newest first
+func GetSuppressions(db *sql.DB) ([]Suppression, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT recipient, reason, note, created_at FROM suppressions
+		ORDER BY created_at DESC, recipient
+	`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	var suppressions []Suppression
+	for rows.Next() {
+		var s Suppression
+		if err := rows.Scan(&s.Recipient, &s.Reason, &s.Note, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan suppression: %w", err)
+		}
+		suppressions = append(suppressions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating suppressions: %w", err)
+	}
+	return suppressions, nil
+}
+
+// GetSuppression returns the suppression of recipient, or
+// ErrSuppressionNotFound when it may be messaged
+func GetSuppression(db *sql.DB, recipient string) (*Suppression, error) {
+	var s Suppression
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		SELECT recipient, reason, note, created_at FROM suppressions WHERE recipient = $1
+	`), recipient).Scan(&s.Recipient, &s.Reason, &s.Note, &s.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrSuppressionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suppression of %s: %w", recipient, err)
+	}
+	return &s, nil
+}
+
+// CreateSuppression suppresses recipient, returning ErrSuppressionExists when
+// it already is
+func CreateSuppression(db *sql.DB, recipient, reason, note string) (*Suppression, error) {
+	s := Suppression{Recipient: recipient, Reason: reason, Note: note}
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		INSERT INTO suppressions (recipient, reason, note, created_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP)
+		ON CONFLICT DO NOTHING
+		RETURNING created_at
+	`), recipient, reason, note).Scan(&s.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrSuppressionExists
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to suppress %s: %w", recipient, err)
+	}
+	return &s, nil
+}
+
+// DeleteSuppression lets recipient be messaged again
+func DeleteSuppression(db *sql.DB, recipient string) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`DELETE FROM suppressions WHERE recipient = $1`), recipient)
+	if err != nil {
+		return fmt.Errorf("failed to delete suppression of %s: %w", recipient, err)
+	}
+	return requireRow(result, ErrSuppressionNotFound)
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrTemplateExists   = errors.New("template already exists")
+)
+
+// Template is a global message template, one that belongs to no tenant
+type Template struct {
+	Name      string
+	Body      string
+	UpdatedAt time.Time
+}
+
+// GetTemplates returns the global message templates ordered by name
+func GetTemplates(db *sql.DB) ([]Template, error) {
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT name, body, updated_at FROM message_templates
+		WHERE tenant_id IS NULL
+		ORDER BY name
+	`))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []Template
+	for rows.Next() {
+		var t Template
+		if err := rows.Scan(&t.Name, &t.Body, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetTemplate returns the global template with the given name, or
+// ErrTemplateNotFound
+func GetTemplate(db *sql.DB, name string) (*Template, error) {
+	var t Template
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		SELECT name, body, updated_at FROM message_templates
+		WHERE tenant_id IS NULL AND name = $1
+	`), name).Scan(&t.Name, &t.Body, &t.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template %s: %w", name, err)
+	}
+	return &t, nil
+}
+
+// CreateTemplate adds a global template, returning ErrTemplateExists when the
+// name is taken
+func CreateTemplate(db *sql.DB, name, body string) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`
+		INSERT INTO message_templates (tenant_id, name, body, created_at, updated_at)
+		VALUES (NULL, $1, $2, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT DO NOTHING
+	`), name, body)
+	if err != nil {
+		return fmt.Errorf("failed to create template %s: %w", name, err)
+	}
+	return requireRow(result, ErrTemplateExists)
+}
+
+// UpdateTemplate replaces the body of a global template
+func UpdateTemplate(db *sql.DB, name, body string) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE message_templates SET body = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE tenant_id IS NULL AND name = $1
+	`), name, body)
+	if err != nil {
+		return fmt.Errorf("failed to update template %s: %w", name, err)
+	}
+	return requireRow(result, ErrTemplateNotFound)
+}
+
+// DeleteTemplate removes a global template
+func DeleteTemplate(db *sql.DB, name string) error {
+	result, err := db.Exec(DialectOf(db).Rebind(`DELETE FROM message_templates WHERE tenant_id IS NULL AND name = $1`), name)
+	if err != nil {
+		return fmt.Errorf("failed to delete template %s: %w", name, err)
+	}
+	return requireRow(result, ErrTemplateNotFound)
+}
+
+// requireRow returns errNone when a statement affected no rows
+func requireRow(result sql.Result, errNone error) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	if n == 0 {
+		return errNone
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Tenant represents a business onboarded onto the platform
+type Tenant struct {
+	TenantID   int
+	Slug       string
+	Name       string
+	OwnerPhone string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// CreateTenant inserts a tenant row and returns its ID
+func CreateTenant(exec Executor, slug, name, ownerPhone string) (int, error) {
+	query := `
+		INSERT INTO tenants (slug, name, owner_phone, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING tenant_id
+	`
+
+	var tenantID int
+	if err := exec.QueryRow(rebind(exec, query), slug, name, ownerPhone).Scan(&tenantID); err != nil {
+		return 0, fmt.Errorf("failed to create tenant: %w", err)
+	}
+	return tenantID, nil
+}
+
+// TenantSlugExists checks whether a tenant with the given slug already exists
+func TenantSlugExists(exec Executor, slug string) (bool, error) {
+	var count int
+	err := exec.QueryRow(rebind(exec, "SELECT COUNT(*) FROM tenants WHERE slug = $1"), slug).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check tenant slug: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetTenantBySlug retrieves a tenant by its slug
+func GetTenantBySlug(db *sql.DB, slug string) (*Tenant, error) {
+	query := `
+		SELECT tenant_id, slug, name, owner_phone, created_at, updated_at
+		FROM tenants
+		WHERE slug = $1
+	`
+
+	var tenant Tenant
+	err := db.QueryRow(DialectOf(db).Rebind(query), slug).Scan(
+		&tenant.TenantID,
+		&tenant.Slug,
+		&tenant.Name,
+		&tenant.OwnerPhone,
+		&tenant.CreatedAt,
+		&tenant.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("tenant not found: %s", slug)
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+	return &tenant, nil
+}
+
+// CreateTenantAdmin inserts the administrator for a tenant
+func CreateTenantAdmin(exec Executor, tenantID int, name, phoneNumber string) error {
+	query := `
+		INSERT INTO tenant_admins (tenant_id, name, phone_number, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`
+	if _, err := exec.Exec(rebind(exec, query), tenantID, name, phoneNumber); err != nil {
+		return fmt.Errorf("failed to create tenant admin: %w", err)
+	}
+	return nil
+}
+
+// CreateTenantAPIKey stores the hash of an API key issued to a tenant
+func CreateTenantAPIKey(exec Executor, tenantID int, keyPrefix, keyHash, label string) error {
+	query := `
+		INSERT INTO tenant_api_keys (tenant_id, key_prefix, key_hash, label, created_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP)
+	`
+	if _, err := exec.Exec(rebind(exec, query), tenantID, keyPrefix, keyHash, label); err != nil {
+		return fmt.Errorf("failed to create tenant API key: %w", err)
+	}
+	return nil
+}
+
+// GetTenantSlugByAPIKeyHash returns the slug of the tenant holding the API key
+// with the given hash, or an empty slug if no tenant holds it
+func GetTenantSlugByAPIKeyHash(db *sql.DB, keyHash string) (string, error) {
+	query := `
+		SELECT t.slug
+		FROM tenant_api_keys k
+		JOIN tenants t ON t.tenant_id = k.tenant_id
+		WHERE k.key_hash = $1
+	`
+
+	var slug string
+	err := db.QueryRow(DialectOf(db).Rebind(query), keyHash).Scan(&slug)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up tenant API key: %w", err)
+	}
+	return slug, nil
+}
+
+// InsertRewardCatalogEntry adds a reward to a tenant's catalog
+func InsertRewardCatalogEntry(exec Executor, tenantID, points int, description string) error {
+	query := `
+		INSERT INTO reward_catalog (tenant_id, points, description, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, TRUE, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`
+	if _, err := exec.Exec(rebind(exec, query), tenantID, points, description); err != nil {
+		return fmt.Errorf("failed to insert reward catalog entry: %w", err)
+	}
+	return nil
+}
+
+// InsertTemplate adds a named message template for a tenant
+func InsertTemplate(exec Executor, tenantID int, name, body string) error {
+	query := `
+		INSERT INTO message_templates (tenant_id, name, body, created_at, updated_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`
+	if _, err := exec.Exec(rebind(exec, query), tenantID, name, body); err != nil {
+		return fmt.Errorf("failed to insert template %s: %w", name, err)
+	}
+	return nil
+}
+
+// OnboardingNudgeTemplate is the message template sent to unregistered contacts
+const OnboardingNudgeTemplate = "onboarding_nudge"
+
+// OnboardingNudgeSettings is a tenant's configuration for nudging unregistered contacts
+type OnboardingNudgeSettings struct {
+	TenantID     int
+	TenantName   string
+	Enabled      bool
+	IntervalDays int    // 0 when the tenant uses the global default
+	Message      string // empty when the tenant has no nudge template
+}
+
+// GetOnboardingNudgeSettings returns the nudge settings of the tenant with the
+// given slug, or nil if there is no such tenant
+func GetOnboardingNudgeSettings(db *sql.DB, slug string) (*OnboardingNudgeSettings, error) {
+	query := `
+		SELECT t.tenant_id, t.name, COALESCE(t.nudge_enabled, TRUE), COALESCE(t.nudge_interval_days, 0), COALESCE(mt.body, '')
+		FROM tenants t
+		LEFT JOIN message_templates mt ON mt.tenant_id = t.tenant_id AND mt.name = $2
+		WHERE t.slug = $1
+	`
+
+	var settings OnboardingNudgeSettings
+	err := db.QueryRow(DialectOf(db).Rebind(query), slug, OnboardingNudgeTemplate).Scan(
+		&settings.TenantID,
+		&settings.TenantName,
+		&settings.Enabled,
+		&settings.IntervalDays,
+		&settings.Message,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get onboarding nudge settings: %w", err)
+	}
+	return &settings, nil
+}
+
+// UpdateOnboardingNudgeSettings stores a tenant's nudge settings. An empty
+// message keeps the current template; intervalDays 0 reverts to the global default.
+func UpdateOnboardingNudgeSettings(db *sql.DB, tenantID int, enabled bool, intervalDays int, message string) error {
+	tx, err := Begin(db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE tenants SET nudge_enabled = $2, nudge_interval_days = NULLIF($3, 0), updated_at = CURRENT_TIMESTAMP
+		WHERE tenant_id = $1
+	`
+	if _, err := tx.Exec(query, tenantID, enabled, intervalDays); err != nil {
+		return fmt.Errorf("failed to update onboarding nudge settings: %w", err)
+	}
+
+	if message != "" {
+		templateQuery := `
+			INSERT INTO message_templates (tenant_id, name, body, created_at, updated_at)
+			VALUES ($1, $2, $3, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+			ON CONFLICT (tenant_id, name) DO UPDATE SET body = EXCLUDED.body, updated_at = CURRENT_TIMESTAMP
+		`
+		if _, err := tx.Exec(templateQuery, tenantID, OnboardingNudgeTemplate, message); err != nil {
+			return fmt.Errorf("failed to save onboarding nudge template: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Message log modes: what message_history keeps of the content of a send
+const (
+	MessageLogFull    = "full"    // the content, with its hash and length
+	MessageLogPrivate = "private" // only the content's hash and length
+)
+
+// GetMessageLogMode returns the tenant's message log mode and ID, or an empty
+// mode if there is no tenant with the given slug
+func GetMessageLogMode(db *sql.DB, slug string) (int, string, error) {
+	var tenantID int
+	var mode string
+	err := db.QueryRow(DialectOf(db).Rebind(`SELECT tenant_id, message_log_mode FROM tenants WHERE slug = $1`), slug).Scan(&tenantID, &mode)
+	if err == sql.ErrNoRows {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get message log mode: %w", err)
+	}
+	return tenantID, mode, nil
+}
+
+// UpdateMessageLogMode stores the tenant's message log mode. Messages already
+// logged keep what was stored for them.
+func UpdateMessageLogMode(db *sql.DB, tenantID int, mode string) error {
+	_, err := db.Exec(DialectOf(db).Rebind(`
+		UPDATE tenants SET message_log_mode = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE tenant_id = $1
+	`), tenantID, mode)
+	if err != nil {
+		return fmt.Errorf("failed to update message log mode: %w", err)
+	}
+	return nil
+}
@@ -4,18 +4,38 @@ import (
 	"fmt"
 )
 
-// InsertPointTransaction logs a transaction in the point_transactions table
-func InsertPointTransaction(exec Executor, memberID, pointsChanged int, transactionType, notes string) error {
+// InsertPointTransaction logs a transaction in the point_transactions table.
+// performedBy is the admin phone that entered it, empty for member self-service.
+func InsertPointTransaction(exec Executor, memberID, pointsChanged int, transactionType, notes, performedBy string) error {
 	query := `
-	INSERT INTO point_transactions (point_id, points_changed, transaction_type, transaction_date, notes)
+	INSERT INTO point_transactions (point_id, points_changed, transaction_type, transaction_date, notes, performed_by)
 	VALUES (
 		(SELECT point_id FROM points WHERE member_id = $1),
-		$2, $3, CURRENT_TIMESTAMP, $4
+		$2, $3, CURRENT_TIMESTAMP, $4, NULLIF($5, '')
 	)
 	`
-	_, err := exec.Exec(query, memberID, pointsChanged, transactionType, notes)
+	_, err := exec.Exec(rebind(exec, query), memberID, pointsChanged, transactionType, notes, performedBy)
 	if err != nil {
 		return fmt.Errorf("failed to insert point transaction: %w", err)
 	}
 	return nil
 }
+
+// InsertPointTransactionReturningID logs a transaction like InsertPointTransaction
+// and returns its transaction_id
+func InsertPointTransactionReturningID(exec Executor, memberID, pointsChanged int, transactionType, notes string) (int, error) {
+	query := `
+	INSERT INTO point_transactions (point_id, points_changed, transaction_type, transaction_date, notes)
+	VALUES (
+		(SELECT point_id FROM points WHERE member_id = $1),
+		$2, $3, CURRENT_TIMESTAMP, $4
+	)
+	RETURNING transaction_id
+	`
+	var transactionID int
+	err := exec.QueryRow(rebind(exec, query), memberID, pointsChanged, transactionType, notes).Scan(&transactionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert point transaction: %w", err)
+	}
+	return transactionID, nil
+}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrVoucherNotFound        = errors.New("voucher not found")
+	ErrVoucherAlreadyRedeemed = errors.New("voucher already redeemed")
+	ErrVoucherExpired         = errors.New("voucher expired")
+)
+
+// Voucher is the proof of a points redemption, shown at the counter to collect the reward
+type Voucher struct {
+	VoucherID     int
+	Code          string
+	TransactionID int // the REDEEM point transaction the voucher was issued for
+	MemberID      int
+	PhoneNumber   string
+	Reward        string
+	Points        int
+	IssuedAt      time.Time
+	ExpiresAt     *time.Time // nil never expires
+	RedeemedAt    *time.Time
+	Branch        string // branch that redeemed the voucher
+}
+
+// VoucherTally counts vouchers and the points they are worth
+type VoucherTally struct {
+	Count  int
+	Points int
+}
+
+// BranchRedemptions are the vouchers one branch redeemed
+type BranchRedemptions struct {
+	Branch   string // empty when the counter did not name its branch
+	Redeemed VoucherTally
+}
+
+// VoucherSettlement summarizes voucher activity in a period
+type VoucherSettlement struct {
+	Issued      VoucherTally // issued in the period
+	Redeemed    VoucherTally // redeemed in the period
+	Expired     VoucherTally // expired unused in the period
+	Outstanding VoucherTally // still redeemable at the end of the period
+	Branches    []BranchRedemptions
+}
+
+// CreateVoucher stores a voucher for a redemption. It returns false without
+// error when the code is already taken, so the caller can retry with a new code.
+func CreateVoucher(exec Executor, v Voucher) (bool, error) {
+	result, err := exec.Exec(rebind(exec, `
+		INSERT INTO vouchers (code, transaction_id, member_id, reward, points, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (code) DO NOTHING
+	`), v.Code, v.TransactionID, v.MemberID, v.Reward, v.Points, v.ExpiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to create voucher: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// GetVoucherByCode returns the voucher with the given code, or nil if there is none
+func GetVoucherByCode(exec Executor, code string) (*Voucher, error) {
+	var v Voucher
+	var expiresAt, redeemedAt sql.NullTime
+	err := exec.QueryRow(rebind(exec, `
+		SELECT v.voucher_id, v.code, v.transaction_id, v.member_id, m.phone_number,
+			v.reward, v.points, v.issued_at, v.expires_at, v.redeemed_at, COALESCE(v.branch, '')
+		FROM vouchers v
+		JOIN members m ON m.member_id = v.member_id
+		WHERE v.code = $1
+	`), code).Scan(&v.VoucherID, &v.Code, &v.TransactionID, &v.MemberID, &v.PhoneNumber,
+		&v.Reward, &v.Points, &v.IssuedAt, &expiresAt, &redeemedAt, &v.Branch)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voucher: %w", err)
+	}
+	if expiresAt.Valid {
+		v.ExpiresAt = &expiresAt.Time
+	}
+	if redeemedAt.Valid {
+		v.RedeemedAt = &redeemedAt.Time
+	}
+	return &v, nil
+}
+
+// RedeemVoucher marks a voucher used at branch and its redemption claimed. The
+// update only matches an unused, unexpired voucher, so concurrent attempts
+// redeem it exactly once.
+func RedeemVoucher(db *sql.DB, code, branch string) (*Voucher, error) {
+	tx, err := Begin(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var transactionID int
+	err = tx.QueryRow(`
+		UPDATE vouchers SET redeemed_at = CURRENT_TIMESTAMP, branch = NULLIF($2, '')
+		WHERE code = $1 AND redeemed_at IS NULL
+			AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		RETURNING transaction_id
+	`, code, branch).Scan(&transactionID)
+	if err == sql.ErrNoRows {
+		existing, err := GetVoucherByCode(tx, code)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case existing == nil:
+			return nil, ErrVoucherNotFound
+		case existing.RedeemedAt != nil:
+			return nil, ErrVoucherAlreadyRedeemed
+		default:
+			return nil, ErrVoucherExpired
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem voucher: %w", err)
+	}
+
+	// Handing over the reward also stops the reward_unclaimed reminders
+	if _, err := tx.Exec(`
+		UPDATE point_transactions SET claimed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE transaction_id = $1 AND claimed_at IS NULL
+	`, transactionID); err != nil {
+		return nil, fmt.Errorf("failed to mark redemption claimed: %w", err)
+	}
+
+	voucher, err := GetVoucherByCode(tx, code)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return voucher, nil
+}
+
+// GetVoucherSettlement summarizes the vouchers issued, redeemed and expired in
+// [from, to) and those still outstanding at to, with the redemptions per branch
+func GetVoucherSettlement(db *sql.DB, from, to time.Time) (*VoucherSettlement, error) {
+	var s VoucherSettlement
+	err := db.QueryRow(DialectOf(db).Rebind(`
+		SELECT
+			COUNT(*) FILTER (WHERE issued_at >= $1 AND issued_at < $2),
+			COALESCE(SUM(points) FILTER (WHERE issued_at >= $1 AND issued_at < $2), 0),
+			COUNT(*) FILTER (WHERE redeemed_at >= $1 AND redeemed_at < $2),
+			COALESCE(SUM(points) FILTER (WHERE redeemed_at >= $1 AND redeemed_at < $2), 0),
+			COUNT(*) FILTER (WHERE redeemed_at IS NULL AND expires_at >= $1 AND expires_at < $2),
+			COALESCE(SUM(points) FILTER (WHERE redeemed_at IS NULL AND expires_at >= $1 AND expires_at < $2), 0),
+			COUNT(*) FILTER (WHERE issued_at < $2 AND (redeemed_at IS NULL OR redeemed_at >= $2)
+				AND (expires_at IS NULL OR expires_at >= $2)),
+			COALESCE(SUM(points) FILTER (WHERE issued_at < $2 AND (redeemed_at IS NULL OR redeemed_at >= $2)
+				AND (expires_at IS NULL OR expires_at >= $2)), 0)
+		FROM vouchers
+	`), from, to).Scan(&s.Issued.Count, &s.Issued.Points, &s.Redeemed.Count, &s.Redeemed.Points,
+		&s.Expired.Count, &s.Expired.Points, &s.Outstanding.Count, &s.Outstanding.Points)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voucher settlement: %w", err)
+	}
+
+	rows, err := db.Query(DialectOf(db).Rebind(`
+		SELECT COALESCE(branch, ''), COUNT(*), SUM(points)
+		FROM vouchers
+		WHERE redeemed_at >= $1 AND redeemed_at < $2
+		GROUP BY COALESCE(branch, '')
+		ORDER BY COALESCE(branch, '')
+	`), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voucher redemptions per branch: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b BranchRedemptions
+		if err := rows.Scan(&b.Branch, &b.Redeemed.Count, &b.Redeemed.Points); err != nil {
+			return nil, fmt.Errorf("failed to scan branch redemptions: %w", err)
+		}
+		s.Branches = append(s.Branches, b)
+	}
+	return &s, rows.Err()
+}
@@ -0,0 +1,45 @@
+// Package requestid carries the ID of an API request through the code it
+// calls, so logs, message history and webhooks can all be traced back to the
+// single X-Request-ID an integrator was given.
+package requestid
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// Header carries a caller's request ID on requests and the request's ID on
+// every API response
+const Header = "X-Request-ID"
+
+// validID matches the IDs accepted from callers: up to 128 characters that are
+// safe to echo in headers, logs and JSON
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type contextKey struct{}
+
+// New generates a request ID
+func New() string {
+	return uuid.New().String()
+}
+
+// Valid reports whether id may be propagated as given by a caller
+func Valid(id string) bool {
+	return validID.MatchString(id)
+}
+
+// NewContext returns a copy of ctx carrying id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, or "" outside a request
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,42 @@
+package requestid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_IsValid(t *testing.T) {
+	id := New()
+
+	assert.True(t, Valid(id))
+	assert.NotEqual(t, id, New())
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"3f2b8c1e-8d4f-4a5b-9c1d-2e3f4a5b6c7d", true},
+		{"crm:order-1234.retry_2", true},
+		{"", false},
+		{"has space", false},
+		{"line\nbreak", false},
+		{strings.Repeat("a", 128), true},
+		{strings.Repeat("a", 129), false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Valid(tt.id), tt.id)
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := NewContext(context.Background(), "req-1")
+
+	assert.Equal(t, "req-1", FromContext(ctx))
+	assert.Equal(t, "", FromContext(context.Background()))
+}
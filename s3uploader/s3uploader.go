@@ -11,30 +11,23 @@ import (
 	"github.com/wa-serv/config"
 )
 
+// Configured reports whether AWS_REGION and S3_BUCKET_NAME are set
+func Configured() bool {
+	return config.Env.AWSRegion != "" && config.Env.S3BucketName != ""
+}
+
 // UploadToS3 uploads the given data to an S3 bucket and returns the public URL
 func UploadToS3(data []byte) (string, error) {
-	// Use region and bucket name from the centralized environment configuration
-	region := config.Env.AWSRegion
-	bucket := config.Env.S3BucketName
-
-	// Check if AWS configuration is available
-	if region == "" || bucket == "" {
-		return "", fmt.Errorf("AWS S3 is not configured. Please set AWS_REGION and S3_BUCKET_NAME environment variables")
-	}
-
-	// Create a new AWS session
-	sess, err := session.NewSession(&aws.Config{
-		Region: aws.String(region),
-	})
+	s3Client, err := newClient()
 	if err != nil {
-		return "", fmt.Errorf("failed to create AWS session: %w", err)
+		return "", err
 	}
+	bucket := config.Env.S3BucketName
 
 	// Generate a unique filename
 	fileName := uuid.New().String() + ".jpg"
 
 	// Upload the file to S3
-	s3Client := s3.New(sess)
 	_, err = s3Client.PutObject(&s3.PutObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(fileName),
@@ -47,3 +40,43 @@ func UploadToS3(data []byte) (string, error) {
 	// Return the public URL of the uploaded file
 	return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", bucket, fileName), nil
 }
+
+// BucketUsage returns the number of objects in the S3 bucket and their total
+// size in bytes. It lists the whole bucket, so it is meant for occasional checks.
+func BucketUsage() (objects, size int64, err error) {
+	s3Client, err := newClient()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = s3Client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(config.Env.S3BucketName),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			objects++
+			size += aws.Int64Value(object.Size)
+		}
+		return true
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list S3 bucket: %w", err)
+	}
+	return objects, size, nil
+}
+
+// newClient creates an S3 client for the configured region
+func newClient() (*s3.S3, error) {
+	// Check if AWS configuration is available
+	if !Configured() {
+		return nil, fmt.Errorf("AWS S3 is not configured. Please set AWS_REGION and S3_BUCKET_NAME environment variables")
+	}
+
+	// Create a new AWS session
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(config.Env.AWSRegion),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %w", err)
+	}
+	return s3.New(sess), nil
+}
@@ -0,0 +1,107 @@
+// Package safesend keeps staging and development instances, which may share
+// the production database and its customers, from messaging anyone outside a
+// list of test recipients. It is inert in the production profile.
+package safesend
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/wa-serv/config"
+)
+
+// Decision says what happens to a send
+type Decision int
+
+const (
+	Allow    Decision = iota // send to the recipient
+	Redirect                 // send to the redirect recipient instead
+	Block                    // drop the send
+)
+
+// Guard decides where sends may go. A nil Guard or one for the production
+// profile allows everything, so call sites need no guards of their own.
+type Guard struct {
+	cfg     config.EnvironmentConfig
+	allowed map[string]bool
+}
+
+// NewGuard creates a guard from cfg
+func NewGuard(cfg config.EnvironmentConfig) *Guard {
+	allowed := make(map[string]bool, len(cfg.SafeRecipients))
+	for _, recipient := range cfg.SafeRecipients {
+		allowed[normalize(recipient)] = true
+	}
+	return &Guard{cfg: cfg, allowed: allowed}
+}
+
+// Enabled reports whether sends are checked at all
+func (g *Guard) Enabled() bool {
+	return g != nil && !g.cfg.Production()
+}
+
+// Allows reports whether to, a phone number or JID, may be messaged as is
+func (g *Guard) Allows(to string) bool {
+	return !g.Enabled() || g.allowed[normalize(to)]
+}
+
+// Route decides what to do with a send to to, a phone number or JID, and
+// returns the JID to send to instead when the decision is Redirect. Every
+// redirected or blocked send is logged.
+func (g *Guard) Route(to string) (Decision, string) {
+	if g.Allows(to) {
+		return Allow, to
+	}
+	if g.cfg.RedirectTo == "" {
+		log.Printf("Safe send (%s): blocked send to %s, which is not in SAFE_SEND_RECIPIENTS", g.cfg.Profile, to)
+		return Block, ""
+	}
+	log.Printf("Safe send (%s): redirected send to %s to %s", g.cfg.Profile, to, g.cfg.RedirectTo)
+	return Redirect, g.cfg.RedirectTo + "@s.whatsapp.net"
+}
+
+// Label prefixes text redirected away from to, so testers can tell who it
+// was meant for
+func (g *Guard) Label(to, text string) string {
+	label := "[" + strings.ToUpper(g.cfg.Profile) + " → " + normalize(to) + "]"
+	if text == "" {
+		return label
+	}
+	return label + "\n" + text
+}
+
+// normalize reduces a phone number or user JID to its digits and leaves group
+// JIDs whole
+func normalize(recipient string) string {
+	recipient = strings.TrimPrefix(strings.TrimSpace(recipient), "+")
+	if user, ok := strings.CutSuffix(recipient, "@s.whatsapp.net"); ok {
+		// Drop the device part of JIDs such as 628123:12@s.whatsapp.net
+		user, _, _ = strings.Cut(user, ":")
+		return user
+	}
+	return recipient
+}
+
+// Default guard, built once from env on first use
+var (
+	defaultOnce  sync.Once
+	defaultGuard *Guard
+)
+
+// Default returns the environment-configured guard
+func Default() *Guard {
+	defaultOnce.Do(func() {
+		cfg := config.LoadEnvironmentConfig()
+		defaultGuard = NewGuard(cfg)
+		if defaultGuard.Enabled() {
+			target := "dropped"
+			if cfg.RedirectTo != "" {
+				target = "redirected to " + cfg.RedirectTo
+			}
+			log.Printf("⚠ %s PROFILE: WhatsApp sends only reach %d safe recipient(s); other sends are %s",
+				strings.ToUpper(cfg.Profile), len(cfg.SafeRecipients), target)
+		}
+	})
+	return defaultGuard
+}
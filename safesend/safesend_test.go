@@ -0,0 +1,54 @@
+package safesend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/config"
+)
+
+func TestGuard_ProductionAllowsEverything(t *testing.T) {
+	var nilGuard *Guard
+	assert.False(t, nilGuard.Enabled())
+	decision, to := nilGuard.Route("6281234567890@s.whatsapp.net")
+	assert.Equal(t, Allow, decision)
+	assert.Equal(t, "6281234567890@s.whatsapp.net", to)
+
+	production := NewGuard(config.EnvironmentConfig{Profile: config.ProfileProduction})
+	assert.False(t, production.Enabled())
+	decision, _ = production.Route("6281234567890@s.whatsapp.net")
+	assert.Equal(t, Allow, decision)
+}
+
+func TestGuard_AllowsSafeRecipients(t *testing.T) {
+	guard := NewGuard(config.EnvironmentConfig{
+		Profile:        config.ProfileStaging,
+		SafeRecipients: []string{"6281111111111", "120363025246125486@g.us"},
+	})
+
+	for _, to := range []string{"6281111111111@s.whatsapp.net", "6281111111111:12@s.whatsapp.net", "+6281111111111", "120363025246125486@g.us"} {
+		decision, routed := guard.Route(to)
+		assert.Equal(t, Allow, decision, to)
+		assert.Equal(t, to, routed)
+	}
+}
+
+func TestGuard_BlocksOthersWithoutRedirect(t *testing.T) {
+	guard := NewGuard(config.EnvironmentConfig{Profile: config.ProfileDevelopment, SafeRecipients: []string{"6281111111111"}})
+
+	decision, to := guard.Route("6289999999999@s.whatsapp.net")
+
+	assert.Equal(t, Block, decision)
+	assert.Empty(t, to)
+}
+
+func TestGuard_RedirectsOthers(t *testing.T) {
+	guard := NewGuard(config.EnvironmentConfig{Profile: config.ProfileStaging, RedirectTo: "6282222222222"})
+
+	decision, to := guard.Route("6289999999999@s.whatsapp.net")
+
+	assert.Equal(t, Redirect, decision)
+	assert.Equal(t, "6282222222222@s.whatsapp.net", to)
+	assert.Equal(t, "[STAGING → 6289999999999]\nHalo", guard.Label("6289999999999@s.whatsapp.net", "Halo"))
+	assert.Equal(t, "[STAGING → 6289999999999]", guard.Label("6289999999999@s.whatsapp.net", ""))
+}
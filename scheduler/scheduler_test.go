@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_RunsJobRepeatedly(t *testing.T) {
+	var runs int32
+	s := NewScheduler()
+	s.Every("counter", 10*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+
+	s.Start(context.Background())
+	time.Sleep(55 * time.Millisecond)
+	s.Stop()
+
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&runs), int32(3))
+}
+
+func TestScheduler_StopHaltsJobs(t *testing.T) {
+	var runs int32
+	s := NewScheduler()
+	s.Every("failing", 5*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return errors.New("boom")
+	})
+
+	s.Start(context.Background())
+	time.Sleep(20 * time.Millisecond)
+	s.Stop()
+	after := atomic.LoadInt32(&runs)
+	time.Sleep(20 * time.Millisecond)
+
+	assert.Equal(t, after, atomic.LoadInt32(&runs))
+}
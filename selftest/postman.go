@@ -0,0 +1,108 @@
+package selftest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// postmanSchema is the Postman collection format version produced by PostmanCollection
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// PostmanCollection builds a Postman v2.1 collection from the contract suite.
+// Requests use the {{baseUrl}}, {{username}} and {{password}} variables, and
+// each request carries a test script asserting the same contract as Run.
+func PostmanCollection() ([]byte, error) {
+	var items []map[string]interface{}
+	for _, check := range Checks() {
+		if check.SkipReason != "" {
+			continue
+		}
+		items = append(items, postmanItem(check))
+	}
+
+	collection := map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":        "WhatsPoints API contract",
+			"description": "Generated by whatspoints selftest. Mirrors the checks run by `whatspoints selftest --base-url`.",
+			"schema":      postmanSchema,
+		},
+		"variable": []map[string]string{
+			{"key": "baseUrl", "value": "http://localhost:8080"},
+			{"key": "username", "value": "admin"},
+			{"key": "password", "value": ""},
+		},
+		"item": items,
+	}
+	return json.MarshalIndent(collection, "", "  ")
+}
+
+func postmanItem(check Check) map[string]interface{} {
+	request := map[string]interface{}{
+		"method": check.Method,
+		"url":    "{{baseUrl}}" + check.Path,
+	}
+	if check.Auth {
+		request["auth"] = map[string]interface{}{
+			"type": "basic",
+			"basic": []map[string]string{
+				{"key": "username", "value": "{{username}}"},
+				{"key": "password", "value": "{{password}}"},
+			},
+		}
+	}
+	if check.Body != nil {
+		body, _ := json.MarshalIndent(check.Body, "", "  ")
+		request["header"] = []map[string]string{{"key": "Content-Type", "value": "application/json"}}
+		request["body"] = map[string]interface{}{"mode": "raw", "raw": string(body)}
+	}
+
+	return map[string]interface{}{
+		"name":    fmt.Sprintf("[%s] %s", check.Group, check.Name),
+		"request": request,
+		"event": []map[string]interface{}{
+			{
+				"listen": "test",
+				"script": map[string]interface{}{
+					"type": "text/javascript",
+					"exec": postmanTestScript(check),
+				},
+			},
+		},
+	}
+}
+
+func postmanTestScript(check Check) []string {
+	codes := make([]string, len(check.ExpectStatus))
+	for i, code := range check.ExpectStatus {
+		codes[i] = fmt.Sprint(code)
+	}
+	script := []string{
+		fmt.Sprintf("pm.test(%q, function () {", "status is one of "+strings.Join(codes, ", ")),
+		fmt.Sprintf("    pm.expect(pm.response.code).to.be.oneOf([%s]);", strings.Join(codes, ", ")),
+		"});",
+	}
+	for _, key := range check.ExpectKeys {
+		script = append(script,
+			fmt.Sprintf("pm.test(%q, function () {", "response has "+key),
+			fmt.Sprintf("    pm.expect(pm.response.json()).to.have.property(%q);", key),
+			"});",
+		)
+	}
+	for name, want := range check.ExpectHeaders {
+		script = append(script,
+			fmt.Sprintf("pm.test(%q, function () {", "header "+name+" is "+want),
+			fmt.Sprintf("    pm.expect(pm.response.headers.get(%q)).to.eql(%q);", name, want),
+			"});",
+		)
+	}
+	for key, want := range check.ExpectJSON {
+		value, _ := json.Marshal(want)
+		script = append(script,
+			fmt.Sprintf("pm.test(%q, function () {", key+" matches"),
+			fmt.Sprintf("    pm.expect(pm.response.json()[%q]).to.eql(%s);", key, value),
+			"});",
+		)
+	}
+	return script
+}
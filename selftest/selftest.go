@@ -0,0 +1,311 @@
+// Package selftest runs an end-to-end API contract suite against a running
+// WhatsPoints instance. It is used for post-deploy verification and also
+// exports the same checks as a Postman collection.
+package selftest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Check is a single API contract assertion
+type Check struct {
+	Name   string
+	Group  string
+	Method string
+	Path   string
+	Body   interface{}
+	// Auth controls whether Basic Auth credentials are sent
+	Auth bool
+	// ExpectStatus lists the acceptable HTTP status codes
+	ExpectStatus []int
+	// ExpectKeys lists top-level JSON keys the response body must contain
+	ExpectKeys []string
+	// ExpectJSON holds top-level JSON values the response body must match
+	ExpectJSON map[string]interface{}
+	// ExpectHeaders holds response headers that must be present with the given value
+	ExpectHeaders map[string]string
+	// SkipReason marks checks that cannot run against this API yet
+	SkipReason string
+}
+
+// Status is the outcome of a check
+type Status string
+
+const (
+	StatusPass Status = "PASS"
+	StatusFail Status = "FAIL"
+	StatusSkip Status = "SKIP"
+)
+
+// Result is the outcome of running one check
+type Result struct {
+	Check      Check
+	Status     Status
+	StatusCode int
+	Duration   time.Duration
+	Detail     string
+}
+
+// Report is the outcome of a full suite run
+type Report struct {
+	BaseURL string
+	Results []Result
+}
+
+// Failed returns the number of failed checks
+func (r *Report) Failed() int {
+	failed := 0
+	for _, res := range r.Results {
+		if res.Status == StatusFail {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Options configures a suite run
+type Options struct {
+	BaseURL  string
+	Username string
+	Password string
+	Client   *http.Client
+}
+
+// apiPrefix is the API version exercised by the suite
+const apiPrefix = "/api/v1"
+
+// selftestRecipient is the number used by the send dry-run check. Nothing is
+// sent because the request carries dry_run=true.
+const selftestRecipient = "+6281234567890"
+
+// selftestUnknownPhone is a well-formed number that should not belong to any member
+const selftestUnknownPhone = "+6280000000001"
+
+// Checks returns the contract suite in execution order
+func Checks() []Check {
+	return []Check{
+		{
+			Name:         "health check",
+			Group:        "health",
+			Method:       http.MethodGet,
+			Path:         "/health",
+			ExpectStatus: []int{http.StatusOK},
+			ExpectJSON:   map[string]interface{}{"status": "ok"},
+		},
+		{
+			Name:         "API rejects missing credentials",
+			Group:        "auth",
+			Method:       http.MethodGet,
+			Path:         apiPrefix + "/status",
+			ExpectStatus: []int{http.StatusUnauthorized},
+		},
+		{
+			Name:         "API accepts configured credentials",
+			Group:        "auth",
+			Method:       http.MethodGet,
+			Path:         apiPrefix + "/status",
+			Auth:         true,
+			ExpectStatus: []int{http.StatusOK},
+			ExpectKeys:   []string{"whatsapp"},
+		},
+		{
+			Name:   "send message dry run",
+			Group:  "send",
+			Method: http.MethodPost,
+			Path:   apiPrefix + "/send-message",
+			Body: map[string]interface{}{
+				"to":      selftestRecipient,
+				"message": "whatspoints selftest",
+				"dry_run": true,
+			},
+			Auth:         true,
+			ExpectStatus: []int{http.StatusOK},
+			ExpectJSON:   map[string]interface{}{"success": true},
+		},
+		{
+			Name:         "send message rejects invalid phone",
+			Group:        "send",
+			Method:       http.MethodPost,
+			Path:         apiPrefix + "/send-message",
+			Body:         map[string]interface{}{"to": "123", "message": "whatspoints selftest", "dry_run": true},
+			Auth:         true,
+			ExpectStatus: []int{http.StatusBadRequest},
+			ExpectJSON:   map[string]interface{}{"success": false},
+		},
+		{
+			Name:         "unversioned alias is deprecated",
+			Group:        "version",
+			Method:       http.MethodGet,
+			Path:         "/api/status",
+			Auth:         true,
+			ExpectStatus: []int{http.StatusOK},
+			ExpectHeaders: map[string]string{
+				"Deprecation": "true",
+				"API-Version": "v1",
+			},
+		},
+		{
+			Name:         "list senders",
+			Group:        "senders",
+			Method:       http.MethodGet,
+			Path:         apiPrefix + "/senders",
+			Auth:         true,
+			ExpectStatus: []int{http.StatusOK},
+			ExpectKeys:   []string{"senders"},
+		},
+		{
+			Name:         "member location lookup",
+			Group:        "members",
+			Method:       http.MethodGet,
+			Path:         apiPrefix + "/members/" + selftestUnknownPhone + "/location",
+			Auth:         true,
+			ExpectStatus: []int{http.StatusOK, http.StatusNotFound},
+		},
+		{
+			Name:       "member points balance",
+			Group:      "points",
+			Method:     http.MethodGet,
+			Path:       apiPrefix + "/members/" + selftestUnknownPhone + "/points",
+			Auth:       true,
+			SkipReason: "no points endpoint is exposed over HTTP; points are managed via WhatsApp commands",
+		},
+	}
+}
+
+// Run executes the contract suite and returns a report. Checks are run
+// sequentially so the output order is stable.
+func Run(opts Options) *Report {
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	baseURL := strings.TrimRight(opts.BaseURL, "/")
+
+	report := &Report{BaseURL: baseURL}
+	for _, check := range Checks() {
+		report.Results = append(report.Results, runCheck(client, baseURL, opts, check))
+	}
+	return report
+}
+
+func runCheck(client *http.Client, baseURL string, opts Options, check Check) Result {
+	result := Result{Check: check}
+	if check.SkipReason != "" {
+		result.Status = StatusSkip
+		result.Detail = check.SkipReason
+		return result
+	}
+
+	var body io.Reader
+	if check.Body != nil {
+		payload, err := json.Marshal(check.Body)
+		if err != nil {
+			result.Status = StatusFail
+			result.Detail = fmt.Sprintf("encode body: %v", err)
+			return result
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(check.Method, baseURL+check.Path, body)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("build request: %v", err)
+		return result
+	}
+	if check.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if check.Auth {
+		req.SetBasicAuth(opts.Username, opts.Password)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+
+	if !containsStatus(check.ExpectStatus, resp.StatusCode) {
+		result.Status = StatusFail
+		result.Detail = fmt.Sprintf("expected status %v, got %d", check.ExpectStatus, resp.StatusCode)
+		return result
+	}
+
+	for name, want := range check.ExpectHeaders {
+		if got := resp.Header.Get(name); got != want {
+			result.Status = StatusFail
+			result.Detail = fmt.Sprintf("expected header %s: %q, got %q", name, want, got)
+			return result
+		}
+	}
+
+	if len(check.ExpectKeys) > 0 || len(check.ExpectJSON) > 0 {
+		var decoded map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+			result.Status = StatusFail
+			result.Detail = fmt.Sprintf("response is not a JSON object: %v", err)
+			return result
+		}
+		for _, key := range check.ExpectKeys {
+			if _, ok := decoded[key]; !ok {
+				result.Status = StatusFail
+				result.Detail = fmt.Sprintf("response is missing %q", key)
+				return result
+			}
+		}
+		for key, want := range check.ExpectJSON {
+			if got := decoded[key]; got != want {
+				result.Status = StatusFail
+				result.Detail = fmt.Sprintf("expected %q to be %v, got %v", key, want, got)
+				return result
+			}
+		}
+	}
+
+	result.Status = StatusPass
+	return result
+}
+
+func containsStatus(statuses []int, code int) bool {
+	for _, s := range statuses {
+		if s == code {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintReport writes a human-readable report to w
+func PrintReport(w io.Writer, report *Report) {
+	fmt.Fprintf(w, "WhatsPoints selftest against %s\n\n", report.BaseURL)
+	passed, skipped := 0, 0
+	for _, res := range report.Results {
+		switch res.Status {
+		case StatusPass:
+			passed++
+		case StatusSkip:
+			skipped++
+		}
+		line := fmt.Sprintf("[%s] %-8s %-40s", res.Status, res.Check.Group, res.Check.Name)
+		if res.StatusCode != 0 {
+			line += fmt.Sprintf(" %d %s", res.StatusCode, res.Duration.Round(time.Millisecond))
+		}
+		if res.Detail != "" {
+			line += " - " + res.Detail
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "\n%d passed, %d failed, %d skipped\n", passed, report.Failed(), skipped)
+}
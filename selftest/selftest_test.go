@@ -0,0 +1,126 @@
+package selftest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI implements just enough of the API contract for the suite to pass
+func fakeAPI(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, status int, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	authed := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if u, p, ok := r.BasicAuth(); !ok || u != "admin" || p != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			next(w, r)
+		}
+	}
+
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("/api/v1/status", authed(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"whatsapp": map[string]bool{"connected": true}})
+	}))
+	mux.HandleFunc("/api/status", authed(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("API-Version", "v1")
+		writeJSON(w, http.StatusOK, map[string]interface{}{"whatsapp": map[string]bool{"connected": true}})
+	}))
+	mux.HandleFunc("/api/v1/send-message", authed(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			To     string `json:"to"`
+			DryRun bool   `json:"dry_run"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if !req.DryRun {
+			t.Errorf("selftest sent a message without dry_run")
+		}
+		if len(req.To) < 10 {
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{"success": false})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"success": true})
+	}))
+	mux.HandleFunc("/api/v1/senders", authed(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"senders": []string{}, "count": 0})
+	}))
+	mux.HandleFunc("/api/v1/members/", authed(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "location not found"})
+	}))
+	return httptest.NewServer(mux)
+}
+
+func TestRun_AllChecksPass(t *testing.T) {
+	server := fakeAPI(t)
+	defer server.Close()
+
+	report := Run(Options{BaseURL: server.URL + "/", Username: "admin", Password: "secret"})
+
+	assert.Equal(t, 0, report.Failed())
+	for _, res := range report.Results {
+		if res.Check.SkipReason != "" {
+			assert.Equal(t, StatusSkip, res.Status, res.Check.Name)
+			continue
+		}
+		assert.Equal(t, StatusPass, res.Status, "%s: %s", res.Check.Name, res.Detail)
+	}
+}
+
+func TestRun_WrongCredentialsFail(t *testing.T) {
+	server := fakeAPI(t)
+	defer server.Close()
+
+	report := Run(Options{BaseURL: server.URL, Username: "admin", Password: "wrong"})
+
+	assert.Greater(t, report.Failed(), 0)
+
+	var buf bytes.Buffer
+	PrintReport(&buf, report)
+	assert.Contains(t, buf.String(), "[FAIL]")
+	assert.Contains(t, buf.String(), "expected status [200], got 401")
+}
+
+func TestRun_UnreachableServer(t *testing.T) {
+	server := fakeAPI(t)
+	server.Close()
+
+	report := Run(Options{BaseURL: server.URL})
+
+	assert.Equal(t, len(Checks())-1, report.Failed())
+}
+
+func TestPostmanCollection(t *testing.T) {
+	data, err := PostmanCollection()
+	require.NoError(t, err)
+
+	var collection struct {
+		Info struct {
+			Schema string `json:"schema"`
+		} `json:"info"`
+		Item []struct {
+			Name    string `json:"name"`
+			Request struct {
+				URL string `json:"url"`
+			} `json:"request"`
+		} `json:"item"`
+	}
+	require.NoError(t, json.Unmarshal(data, &collection))
+
+	assert.Equal(t, postmanSchema, collection.Info.Schema)
+	assert.Len(t, collection.Item, len(Checks())-1)
+	assert.Equal(t, "{{baseUrl}}/health", collection.Item[0].Request.URL)
+}
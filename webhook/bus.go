@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"github.com/wa-serv/eventbus"
+)
+
+// busEventTypes maps domain events to the webhook event they are delivered as.
+// Events without an entry, such as message.sent, are not sent to the webhook.
+var busEventTypes = map[string]string{
+	eventbus.MemberRegistered:     EventMemberCreated,
+	eventbus.MemberUpdated:        EventMemberUpdated,
+	eventbus.TierChanged:          EventTierChanged,
+	eventbus.SenderDown:           EventSenderRestricted,
+	eventbus.DefaultSenderChanged: EventSenderDefaultChanged,
+	eventbus.MessageExpired:       EventMessageExpired,
+}
+
+// Subscribe forwards domain events from bus to the default, environment-configured
+// dispatcher, keeping the webhook event names and payloads external systems rely on
+func Subscribe(bus *eventbus.Bus) {
+	bus.Subscribe(eventbus.All, func(evt eventbus.Event) {
+		if eventType, data, ok := translate(evt); ok {
+			requestID, data := splitRequestID(data)
+			EmitRequest(requestID, eventType, data)
+		}
+	})
+}
+
+// splitRequestID moves the request ID an event carries out of its payload and
+// onto the webhook envelope
+func splitRequestID(data map[string]any) (string, map[string]any) {
+	requestID, ok := data[eventbus.DataRequestID].(string)
+	if !ok {
+		return "", data
+	}
+	payload := make(map[string]any, len(data))
+	for k, v := range data {
+		if k != eventbus.DataRequestID {
+			payload[k] = v
+		}
+	}
+	return requestID, payload
+}
+
+// translate returns the webhook event type and payload for a domain event
+func translate(evt eventbus.Event) (string, map[string]any, bool) {
+	if evt.Type != eventbus.PointsChanged {
+		eventType, ok := busEventTypes[evt.Type]
+		return eventType, evt.Data, ok
+	}
+
+	// Points changes are split into earned and redeemed webhooks, without the
+	// reason field that selects between them
+	var eventType string
+	switch evt.Data["reason"] {
+	case eventbus.ReasonEarned:
+		eventType = EventPointsEarned
+	case eventbus.ReasonRedeemed:
+		eventType = EventPointsRedeemed
+	default:
+		return "", nil, false
+	}
+	data := make(map[string]any, len(evt.Data))
+	for k, v := range evt.Data {
+		if k != "reason" {
+			data[k] = v
+		}
+	}
+	return eventType, data, true
+}
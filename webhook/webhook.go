@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wa-serv/config"
+)
+
+// Event types delivered to external systems such as a CRM
+const (
+	EventMemberCreated  = "member.created"
+	EventMemberUpdated  = "member.updated"
+	EventPointsEarned   = "points.earned"
+	EventPointsRedeemed = "points.redeemed"
+	EventTierChanged    = "tier.changed"
+
+	// Sender registration lifecycle, for provisioning systems that would
+	// otherwise poll the registration status endpoint
+	EventRegistrationCreated   = "registration.created"
+	EventRegistrationPaired    = "registration.paired"
+	EventRegistrationConnected = "registration.connected"
+	EventRegistrationFailed    = "registration.failed"
+
+	// EventSenderDefaultChanged fires when a new default sender is elected
+	EventSenderDefaultChanged = "sender.default_changed"
+	// EventSenderRestricted fires when WhatsApp bans or restricts a sender
+	EventSenderRestricted = "sender.restricted"
+
+	// EventMessageExpired fires when a message queued while no sender was
+	// connected expires before one reconnects
+	EventMessageExpired = "message.expired"
+
+	// Delivery status of a message sent through the API, posted to the status
+	// callback URL rather than the webhook
+	EventMessageSent      = "message.sent"
+	EventMessageDelivered = "message.delivered"
+	EventMessageRead      = "message.read"
+	EventMessageFailed    = "message.failed"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body when a secret is configured
+const SignatureHeader = "X-Webhook-Signature"
+
+// Event is the JSON envelope POSTed to the webhook URL
+type Event struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"` // X-Request-ID of the API call that caused the event
+	Data      any       `json:"data"`
+}
+
+// Dispatcher delivers events to a single configured webhook endpoint
+type Dispatcher struct {
+	cfg        config.WebhookConfig
+	client     *http.Client
+	maxRetries int
+	sem        chan struct{}
+}
+
+// NewDispatcher creates a dispatcher with a 10s request timeout and 3 delivery attempts
+func NewDispatcher(cfg config.WebhookConfig) *Dispatcher {
+	return &Dispatcher{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		maxRetries: 3,
+		// Caps in-flight async deliveries so a slow CRM can't pile up goroutines
+		sem: make(chan struct{}, 16),
+	}
+}
+
+// Deliver POSTs evt synchronously, returning an error on transport failure or non-2xx
+func (d *Dispatcher) Deliver(ctx context.Context, evt Event) error {
+	body, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("encode webhook event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", evt.Type)
+	if d.cfg.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(d.cfg.Secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Dispatch delivers an event in the background with retries. It never blocks the
+// caller: when the dispatcher is at capacity the event is dropped and logged.
+func (d *Dispatcher) Dispatch(eventType string, data any) {
+	d.DispatchRequest("", eventType, data)
+}
+
+// DispatchRequest is Dispatch for an event caused by the API call requestID
+func (d *Dispatcher) DispatchRequest(requestID, eventType string, data any) {
+	if !d.cfg.Wants(eventType) {
+		return
+	}
+
+	evt := Event{
+		ID:        uuid.New().String(),
+		Type:      eventType,
+		Timestamp: time.Now().UTC(),
+		RequestID: requestID,
+		Data:      data,
+	}
+
+	select {
+	case d.sem <- struct{}{}:
+		go func() {
+			defer func() { <-d.sem }()
+			d.deliverWithRetry(evt)
+		}()
+	default:
+		log.Printf("Webhook %s (%s) dropped: dispatcher at capacity", evt.Type, evt.ID)
+	}
+}
+
+// deliverWithRetry retries failed deliveries with exponential backoff (1s, 2s, ...)
+func (d *Dispatcher) deliverWithRetry(evt Event) {
+	backoff := time.Second
+	for attempt := 1; attempt <= d.maxRetries; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		err := d.Deliver(ctx, evt)
+		cancel()
+		if err == nil {
+			return
+		}
+		log.Printf("Webhook %s (%s) attempt %d/%d failed: %v", evt.Type, evt.ID, attempt, d.maxRetries, err)
+		if attempt < d.maxRetries {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of body using secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Default dispatcher, built once from env on first use
+var (
+	defaultOnce       sync.Once
+	defaultDispatcher *Dispatcher
+)
+
+// Emit sends an event through the default, environment-configured dispatcher.
+// It is a no-op when WEBHOOK_URL is unset or the event type is not subscribed.
+func Emit(eventType string, data any) {
+	EmitRequest("", eventType, data)
+}
+
+// EmitRequest is Emit for an event caused by the API call requestID
+func EmitRequest(requestID, eventType string, data any) {
+	defaultOnce.Do(func() {
+		defaultDispatcher = NewDispatcher(config.LoadWebhookConfig())
+	})
+	defaultDispatcher.DispatchRequest(requestID, eventType, data)
+}
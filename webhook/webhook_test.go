@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/eventbus"
+)
+
+func TestDispatcher_Deliver_SignsBody(t *testing.T) {
+	var gotBody []byte
+	var gotSig, gotEvent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSig = r.Header.Get(SignatureHeader)
+		gotEvent = r.Header.Get("X-Webhook-Event")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(config.WebhookConfig{URL: server.URL, Secret: "s3cret"})
+	err := d.Deliver(context.Background(), Event{ID: "1", Type: EventPointsEarned, Data: map[string]any{"points": 10}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, EventPointsEarned, gotEvent)
+	// Receivers verify authenticity by recomputing the HMAC over the raw body
+	assert.Equal(t, Sign("s3cret", gotBody), gotSig)
+
+	var evt map[string]any
+	assert.NoError(t, json.Unmarshal(gotBody, &evt))
+	assert.Equal(t, EventPointsEarned, evt["type"])
+}
+
+func TestDispatcher_Deliver_Non2xx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(config.WebhookConfig{URL: server.URL})
+	err := d.Deliver(context.Background(), Event{Type: EventMemberCreated})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+}
+
+func TestDispatcher_Dispatch_HonorsEventFilter(t *testing.T) {
+	received := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("X-Webhook-Event")
+	}))
+	defer server.Close()
+
+	d := NewDispatcher(config.WebhookConfig{
+		URL:    server.URL,
+		Events: map[string]bool{EventPointsRedeemed: true},
+	})
+	d.Dispatch(EventPointsEarned, nil) // not subscribed, must not be sent
+	d.Dispatch(EventPointsRedeemed, nil)
+
+	select {
+	case evt := <-received:
+		assert.Equal(t, EventPointsRedeemed, evt)
+	case <-time.After(2 * time.Second):
+		t.Fatal("subscribed event was not delivered")
+	}
+	select {
+	case evt := <-received:
+		t.Fatalf("unexpected event delivered: %s", evt)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestTranslate_PointsChanged(t *testing.T) {
+	eventType, data, ok := translate(eventbus.Event{Type: eventbus.PointsChanged, Data: map[string]any{
+		"reason": eventbus.ReasonRedeemed,
+		"points": 50,
+		"reward": "Free coffee",
+	}})
+
+	assert.True(t, ok)
+	assert.Equal(t, EventPointsRedeemed, eventType)
+	// The reason only selects the webhook type and is not part of the payload
+	assert.Equal(t, map[string]any{"points": 50, "reward": "Free coffee"}, data)
+}
+
+func TestTranslate_EventTypes(t *testing.T) {
+	tests := []struct {
+		busType string
+		want    string
+		ok      bool
+	}{
+		{eventbus.MemberRegistered, EventMemberCreated, true},
+		{eventbus.MemberUpdated, EventMemberUpdated, true},
+		{eventbus.TierChanged, EventTierChanged, true},
+		{eventbus.SenderDown, EventSenderRestricted, true},
+		{eventbus.DefaultSenderChanged, EventSenderDefaultChanged, true},
+		{eventbus.MessageSent, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.busType, func(t *testing.T) {
+			data := map[string]any{"phone_number": "6281234567890"}
+			eventType, got, ok := translate(eventbus.Event{Type: tt.busType, Data: data})
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, eventType)
+			if ok {
+				assert.Equal(t, data, got)
+			}
+		})
+	}
+}
+
+func TestSplitRequestID(t *testing.T) {
+	data := map[string]any{"phone_number": "6281234567890", eventbus.DataRequestID: "req-42"}
+
+	requestID, payload := splitRequestID(data)
+
+	assert.Equal(t, "req-42", requestID)
+	assert.Equal(t, map[string]any{"phone_number": "6281234567890"}, payload)
+
+	requestID, payload = splitRequestID(map[string]any{"phone_number": "6281234567890"})
+	assert.Equal(t, "", requestID)
+	assert.Equal(t, map[string]any{"phone_number": "6281234567890"}, payload)
+}
@@ -3,6 +3,7 @@ package whatsapp
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -10,6 +11,8 @@ import (
 	"time"
 
 	"github.com/mdp/qrterminal/v3"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/faults"
 	"github.com/wa-serv/repository"
 	"go.mau.fi/whatsmeow"
 	waCompanionReg "go.mau.fi/whatsmeow/proto/waCompanionReg"
@@ -29,12 +32,22 @@ func GetLogLevel() string {
 	return logLevel
 }
 
+// ErrClientNotFound is returned for a sender that has no active client
+var ErrClientNotFound = errors.New("client not found")
+
 // ClientManager manages multiple WhatsApp clients
 type ClientManager struct {
 	db              *sql.DB
-	container       *sqlstore.Container
+	container       *DeviceContainer
 	clients         map[string]*whatsmeow.Client // key: sender_id
 	defaultSenderID string
+	senderConfig    config.SenderConfig  // default sender election policy
+	restricted      map[string]string    // sender_id -> state for banned/restricted senders kept out of rotation
+	paused          map[string]bool      // senders operators took out of rotation; they stay connected
+	lastRouted      map[string]time.Time // sender_id -> when NextClient last picked it
+	nextRoute       int                  // round-robin position of NextClient
+	lastReconnect   map[string]time.Time // sender_id -> when ReconnectSender last reconnected it
+	quotaExhausted  map[string]time.Time // sender_id -> when the daily quota it has sent resets
 	mu              sync.RWMutex
 }
 
@@ -45,14 +58,30 @@ func NewClientManager(db *sql.DB, connectionString string) (*ClientManager, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database for WhatsApp sessions: %w", err)
 	}
+	sealer, err := newSessionSealer()
+	if err != nil {
+		return nil, err
+	}
 
 	cm := &ClientManager{
-		db:        db,
-		container: container,
-		clients:   make(map[string]*whatsmeow.Client),
+		db:             db,
+		container:      NewDeviceContainer(container, db, sealer),
+		clients:        make(map[string]*whatsmeow.Client),
+		senderConfig:   config.LoadSenderConfig(),
+		restricted:     make(map[string]string),
+		paused:         make(map[string]bool),
+		lastRouted:     make(map[string]time.Time),
+		lastReconnect:  make(map[string]time.Time),
+		quotaExhausted: make(map[string]time.Time),
 	}
 
-	// Initialize with existing devices
+	// Seal or re-seal the stored device keys before loading the devices
+	if err := cm.container.MigrateKeys(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to migrate device keys: %w", err)
+	}
+
+	// Initialize with existing devices, knowing which are paused
+	cm.loadPausedSenders()
 	if err := cm.loadExistingClients(); err != nil {
 		return nil, fmt.Errorf("failed to load existing clients: %w", err)
 	}
@@ -82,9 +111,10 @@ func (cm *ClientManager) loadExistingClients() error {
 			senderID := device.ID.User
 			cm.ensureSenderRecord(senderID, device.ID.User)
 
-			// Set custom device name and platform type
+			// Set custom device name, platform type and history sync limits
 			store.DeviceProps.Os = proto.String(DeviceName)
 			store.DeviceProps.PlatformType = waCompanionReg.DeviceProps_DESKTOP.Enum()
+			ApplyHistorySyncConfig()
 
 			// Create client
 			clientLog := waLog.Stdout(fmt.Sprintf("Client-%s", senderID), logLevel, true)
@@ -109,7 +139,7 @@ func (cm *ClientManager) loadExistingClients() error {
 			cm.clients[senderID] = client
 
 			// Set as default if it's the first one and no default was loaded from DB
-			if cm.defaultSenderID == "" {
+			if cm.defaultSenderID == "" && !cm.paused[senderID] {
 				cm.defaultSenderID = senderID
 				// Update database to reflect this
 				repository.SetDefaultSender(cm.db, senderID)
@@ -144,28 +174,40 @@ func (cm *ClientManager) GetClient(senderID string) (*whatsmeow.Client, error) {
 	if !exists {
 		return nil, fmt.Errorf("client not found for sender: %s", senderID)
 	}
+	if state, restricted := cm.restricted[senderID]; restricted {
+		return nil, fmt.Errorf("sender %s is %s by WhatsApp", senderID, state)
+	}
 	return client, nil
 }
 
 // GetDefaultClient returns the default client
 func (cm *ClientManager) GetDefaultClient() (*whatsmeow.Client, error) {
+	// GetClient takes the read lock itself; holding it across that call would
+	// deadlock against a writer waiting in between
 	cm.mu.RLock()
-	defer cm.mu.RUnlock()
+	defaultSenderID := cm.defaultSenderID
+	cm.mu.RUnlock()
 
-	if cm.defaultSenderID == "" {
+	if defaultSenderID == "" {
 		// Try to get first device
 		devices, err := cm.container.GetAllDevices(context.Background())
 		if err != nil || len(devices) == 0 {
 			return nil, fmt.Errorf("no default client available")
 		}
-		// Use first device
-		if devices[0].ID != nil {
-			return cm.GetClient(devices[0].ID.User)
+		// Use the first device that is not paused
+		for _, device := range devices {
+			if device.ID != nil && !cm.IsPaused(device.ID.User) {
+				return cm.GetClient(device.ID.User)
+			}
 		}
 		return nil, fmt.Errorf("no default client available")
 	}
+	if cm.IsPaused(defaultSenderID) {
+		// A paused sender only stays the default with failover disabled
+		return nil, fmt.Errorf("default sender %s is paused", defaultSenderID)
+	}
 
-	return cm.GetClient(cm.defaultSenderID)
+	return cm.GetClient(defaultSenderID)
 }
 
 // ListClients returns all available client IDs
@@ -175,19 +217,23 @@ func (cm *ClientManager) ListClients() []string {
 
 	ids := make([]string, 0, len(cm.clients))
 	for id := range cm.clients {
-		ids = append(ids, id)
+		if !cm.isRestricted(id) {
+			ids = append(ids, id)
+		}
 	}
 	return ids
 }
 
-// GetAllClients returns a copy of all clients as a map
+// GetAllClients returns a copy of all clients in send rotation as a map
 func (cm *ClientManager) GetAllClients() map[string]*whatsmeow.Client {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
 
 	clientsCopy := make(map[string]*whatsmeow.Client, len(cm.clients))
 	for id, client := range cm.clients {
-		clientsCopy[id] = client
+		if !cm.isRestricted(id) && !cm.paused[id] {
+			clientsCopy[id] = client
+		}
 	}
 	return clientsCopy
 }
@@ -250,6 +296,7 @@ func (cm *ClientManager) handleEventWithCleanup(evt interface{}, client *whatsme
 			} else {
 				log.Printf("✓ Client %s connected and marked as active", senderID)
 			}
+			cm.liftRestriction(senderID)
 		}
 	}
 
@@ -261,15 +308,22 @@ func (cm *ClientManager) handleEventWithCleanup(evt interface{}, client *whatsme
 			senderID := client.Store.ID.User
 			log.Printf("Client %s disconnected - whatsmeow will handle automatic reconnection", senderID)
 			// Don't manually reconnect - whatsmeow handles this internally
+			cm.recordDisconnect(senderID, "disconnected")
 		}
 	}
 
-	// Handle stream error events - these usually recover automatically via whatsmeow
-	if streamErr, ok := evt.(*events.StreamError); ok {
+	// Bans and restrictions reported while the device is still linked take the
+	// sender out of rotation until it connects again
+	switch evt.(type) {
+	case *events.TemporaryBan, *events.ConnectFailure, *events.StreamError:
 		if client.Store.ID != nil {
-			senderID := client.Store.ID.User
-			log.Printf("⚠ Client %s stream error (code: %s) - whatsmeow will handle recovery", senderID, streamErr.Code)
-			// Don't manually intervene - let whatsmeow handle it
+			if r := classifySenderRestriction(evt, time.Now()); r != nil {
+				cm.recordDisconnect(client.Store.ID.User, r.State+": "+r.Reason)
+				cm.restrictSender(client, r)
+			} else if streamErr, ok := evt.(*events.StreamError); ok {
+				// Other stream errors usually recover automatically via whatsmeow
+				log.Printf("⚠ Client %s stream error (code: %s) - whatsmeow will handle recovery", client.Store.ID.User, streamErr.Code)
+			}
 		}
 	}
 
@@ -281,6 +335,14 @@ func (cm *ClientManager) handleEventWithCleanup(evt interface{}, client *whatsme
 			// Reason is a ConnectFailureReason enum
 			reason := logoutEvt.Reason
 			log.Printf("[ClientManager] Client %s logged out - Reason: %d (%s)", senderID, reason, reason.String())
+			cm.recordDisconnect(senderID, "logged_out: "+reason.String())
+
+			// Some logout codes mean the account itself was banned or locked
+			restriction := classifySenderRestriction(logoutEvt, time.Now())
+			electionReason := "logged_out"
+			if restriction != nil {
+				electionReason = restriction.State
+			}
 
 			// For ANY logout event, clean up properly
 			// WhatsApp logged out this device - we should NOT try to reconnect
@@ -296,13 +358,15 @@ func (cm *ClientManager) handleEventWithCleanup(evt interface{}, client *whatsme
 			cm.mu.Lock()
 			delete(cm.clients, senderID)
 
-			// If this was the default sender, clear it
-			if cm.defaultSenderID == senderID {
-				cm.defaultSenderID = ""
-				log.Printf("Default sender %s was logged out, clearing default", senderID)
-			}
+			// If this was the default sender, clear it and elect a replacement
+			// unless failover is disabled
+			wasDefault := cm.releaseDefaultSender(senderID)
 			cm.mu.Unlock()
 
+			if wasDefault {
+				cm.failoverDefaultSender(senderID, electionReason)
+			}
+
 			log.Printf("Client %s removed from active clients", senderID)
 
 			// Delete the device session from database - session is invalid now
@@ -313,6 +377,10 @@ func (cm *ClientManager) handleEventWithCleanup(evt interface{}, client *whatsme
 			}
 
 			log.Printf("⚠ To reconnect sender %s, please re-register the device via QR code or pairing code", senderID)
+
+			if restriction != nil {
+				cm.reportRestriction(senderID, restriction)
+			}
 		}
 	}
 
@@ -322,10 +390,22 @@ func (cm *ClientManager) handleEventWithCleanup(evt interface{}, client *whatsme
 		if client.Store.ID != nil {
 			senderID := client.Store.ID.User
 			log.Printf("⚠ Client %s - stream replaced by another session (do not reconnect)", senderID)
+			cm.recordDisconnect(senderID, "stream_replaced")
 			// Don't reconnect - another session has taken over
 		}
 	}
 
+	// Fault injection: hold back receipts to simulate slow delivery reports
+	if _, ok := evt.(*events.Receipt); ok {
+		if delay := faults.Default().ReceiptDelay(); delay > 0 {
+			go func() {
+				time.Sleep(delay)
+				handleEvent(evt, cm.db, client)
+			}()
+			return
+		}
+	}
+
 	// Call the regular event handler for all events
 	handleEvent(evt, cm.db, client)
 }
@@ -341,7 +421,7 @@ func (cm *ClientManager) RemoveClient(senderID string) error {
 
 	client, exists := cm.clients[senderID]
 	if !exists {
-		return fmt.Errorf("client not found: %s", senderID)
+		return fmt.Errorf("%w: %s", ErrClientNotFound, senderID)
 	}
 
 	// Disconnect the client
@@ -355,9 +435,9 @@ func (cm *ClientManager) RemoveClient(senderID string) error {
 	// Delete from clients map
 	delete(cm.clients, senderID)
 
-	// If this was the default sender, clear it
-	if cm.defaultSenderID == senderID {
-		cm.defaultSenderID = ""
+	// If this was the default sender, fail over once the lock is released
+	if cm.releaseDefaultSender(senderID) {
+		go cm.failoverDefaultSender(senderID, "removed")
 	}
 
 	// Delete the device session
@@ -369,8 +449,8 @@ func (cm *ClientManager) RemoveClient(senderID string) error {
 	return nil
 }
 
-// GetContainer returns the sqlstore container for creating new devices
-func (cm *ClientManager) GetContainer() *sqlstore.Container {
+// GetContainer returns the device container for creating new devices
+func (cm *ClientManager) GetContainer() *DeviceContainer {
 	return cm.container
 }
 
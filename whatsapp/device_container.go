@@ -0,0 +1,361 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/envelope"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.mau.fi/whatsmeow/util/keys"
+)
+
+// ErrSessionKeysSealed is returned when a device's keys are sealed but no
+// SESSION_ENCRYPTION_KEY is configured to open them
+var ErrSessionKeysSealed = errors.New("device keys are encrypted; set SESSION_ENCRYPTION_KEY to load them")
+
+// DeviceContainer stores WhatsApp devices in the whatsmeow session tables.
+// With a sealer, the private keys of each device are sealed into device_keys
+// and the whatsmeow_device row keeps zeroed placeholders, whose columns only
+// fit the raw keys. Signal sessions, sender keys and app state sync keys are
+// sealed in place; values written before sealing was turned on stay readable
+// and are sealed when next written.
+//
+// One-time pre-keys stay in whatsmeow_pre_keys unsealed. whatsmeow generates
+// and inserts them itself, into a column that only fits a raw key, so sealing
+// them would mean replacing its pre-key store. They are not worth it: a
+// session started with a pre-key also needs the identity and signed pre-key
+// private keys, which are sealed, and a pre-key is deleted once used.
+type DeviceContainer struct {
+	container *sqlstore.Container
+	db        *sql.DB
+	sealer    *envelope.Sealer // nil leaves the session tables unencrypted
+}
+
+// NewDeviceContainer wraps container, sealing session keys with sealer unless it is nil
+func NewDeviceContainer(container *sqlstore.Container, db *sql.DB, sealer *envelope.Sealer) *DeviceContainer {
+	return &DeviceContainer{container: container, db: db, sealer: sealer}
+}
+
+// newSessionSealer creates the sealer for SESSION_ENCRYPTION_KEY, or nil when
+// session encryption is off
+func newSessionSealer() (*envelope.Sealer, error) {
+	cfg, err := config.LoadSessionEncryptionConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Key == nil {
+		return nil, nil
+	}
+	sealer, err := envelope.NewSealer(cfg.Key, cfg.PreviousKeys...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session encryption key: %w", err)
+	}
+	log.Printf("WhatsApp session keys are encrypted at rest")
+	return sealer, nil
+}
+
+// NewDevice creates a device to pair. It is stored once pairing gives it a JID.
+func (c *DeviceContainer) NewDevice() *store.Device {
+	device := c.container.NewDevice()
+	device.Container = c
+	return device
+}
+
+// MigrateKeys seals the keys of the devices stored unencrypted and re-seals
+// those sealed with a previous key, so that reading devices never has to write
+// them. It runs once at startup, before the devices are loaded, and does
+// nothing without a sealer.
+func (c *DeviceContainer) MigrateKeys(ctx context.Context) error {
+	if c.sealer == nil {
+		return nil
+	}
+	devices, err := c.GetAllDevices(ctx)
+	if err != nil {
+		return err
+	}
+	for _, device := range devices {
+		jid := device.ID.String()
+		sealed, err := repository.GetDeviceKeys(c.db, jid)
+		if err != nil {
+			return err
+		}
+		switch {
+		case sealed == nil:
+			log.Printf("Encrypting the keys of WhatsApp device %s", jid)
+		case c.sealer.NeedsRotation(sealed):
+			log.Printf("Re-encrypting the keys of WhatsApp device %s with the current key", jid)
+		default:
+			continue
+		}
+		if err := c.PutDevice(ctx, device); err != nil {
+			return fmt.Errorf("failed to migrate the keys of device %s: %w", jid, err)
+		}
+	}
+	return nil
+}
+
+// GetAllDevices loads every stored device with its private keys, without
+// writing to the store.
+func (c *DeviceContainer) GetAllDevices(ctx context.Context) ([]*store.Device, error) {
+	devices, err := c.container.GetAllDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, device := range devices {
+		if err := c.load(ctx, device); err != nil {
+			return nil, fmt.Errorf("failed to load device %s: %w", device.ID, err)
+		}
+	}
+	return devices, nil
+}
+
+// PutDevice stores device. whatsmeow calls it through Device.Save.
+func (c *DeviceContainer) PutDevice(ctx context.Context, device *store.Device) error {
+	if c.sealer == nil {
+		err := c.container.PutDevice(ctx, device)
+		device.Container = c
+		return err
+	}
+	if device.ID == nil {
+		return sqlstore.ErrDeviceIDMustBeSet
+	}
+
+	jid := device.ID.String()
+	sealed, err := c.sealer.Seal(deviceSecrets(device), deviceLabel(jid))
+	if err != nil {
+		return fmt.Errorf("failed to seal device keys: %w", err)
+	}
+	if err := repository.PutDeviceKeys(c.db, jid, sealed); err != nil {
+		return err
+	}
+
+	// The placeholder row is what whatsmeow writes; a new device is set up on
+	// the copy, so its stores are carried back
+	placeholder := *device
+	placeholder.NoiseKey = keys.NewKeyPairFromPrivateKey([32]byte{})
+	placeholder.IdentityKey = keys.NewKeyPairFromPrivateKey([32]byte{})
+	placeholder.SignedPreKey = &keys.PreKey{
+		KeyPair:   *keys.NewKeyPairFromPrivateKey([32]byte{}),
+		KeyID:     device.SignedPreKey.KeyID,
+		Signature: device.SignedPreKey.Signature,
+	}
+	placeholder.AdvSecretKey = make([]byte, len(device.AdvSecretKey))
+	if err := c.container.PutDevice(ctx, &placeholder); err != nil {
+		return err
+	}
+	if !device.Initialized {
+		device.SetAllStores(placeholder.Sessions.(store.AllSessionSpecificStores))
+		device.LIDs = placeholder.LIDs
+		device.Initialized = true
+		c.sealStores(device)
+	}
+	device.Container = c
+	return nil
+}
+
+// DeleteDevice deletes device and its sealed keys. whatsmeow calls it through
+// Device.Delete when the device is logged out.
+func (c *DeviceContainer) DeleteDevice(ctx context.Context, device *store.Device) error {
+	if device.ID == nil {
+		return sqlstore.ErrDeviceIDMustBeSet
+	}
+	jid := device.ID.String()
+	if err := c.container.DeleteDevice(ctx, device); err != nil {
+		return err
+	}
+	return repository.DeleteDeviceKeys(c.db, jid)
+}
+
+// load restores the private keys of a device loaded from whatsmeow_device and
+// routes its saves and session stores through c. A device stored unencrypted
+// loads as it is; MigrateKeys seals it.
+func (c *DeviceContainer) load(ctx context.Context, device *store.Device) error {
+	device.Container = c
+	jid := device.ID.String()
+
+	if *device.NoiseKey.Priv != [32]byte{} {
+		if c.sealer != nil {
+			c.sealStores(device)
+		}
+		return nil
+	}
+
+	if c.sealer == nil {
+		return ErrSessionKeysSealed
+	}
+	sealed, err := repository.GetDeviceKeys(c.db, jid)
+	if err != nil {
+		return err
+	}
+	if sealed == nil {
+		return fmt.Errorf("device keys are missing from device_keys")
+	}
+	secrets, err := c.sealer.Open(sealed, deviceLabel(jid))
+	if err != nil {
+		return err
+	}
+	if err := restoreDeviceSecrets(device, secrets); err != nil {
+		return err
+	}
+	c.sealStores(device)
+	return nil
+}
+
+// sealStores routes the device's Signal sessions, sender keys and app state
+// sync keys through the sealer
+func (c *DeviceContainer) sealStores(device *store.Device) {
+	label := []byte(device.ID.String())
+	device.Sessions = &sealedSessionStore{SessionStore: device.Sessions, values: c.values("sessions", label)}
+	device.SenderKeys = &sealedSenderKeyStore{SenderKeyStore: device.SenderKeys, values: c.values("sender_keys", label)}
+	device.AppStateKeys = &sealedAppStateKeyStore{AppStateSyncKeyStore: device.AppStateKeys, values: c.values("app_state_keys", label)}
+}
+
+func (c *DeviceContainer) values(table string, jid []byte) sealedValues {
+	return sealedValues{sealer: c.sealer, label: append([]byte(table+":"), jid...)}
+}
+
+// deviceLabel binds sealed device keys to the device they belong to
+func deviceLabel(jid string) []byte {
+	return []byte("device_keys:" + jid)
+}
+
+// deviceSecrets is the noise, identity and signed pre-key private keys of
+// device followed by its ADV secret
+func deviceSecrets(device *store.Device) []byte {
+	secrets := make([]byte, 0, 3*32+len(device.AdvSecretKey))
+	secrets = append(secrets, device.NoiseKey.Priv[:]...)
+	secrets = append(secrets, device.IdentityKey.Priv[:]...)
+	secrets = append(secrets, device.SignedPreKey.Priv[:]...)
+	return append(secrets, device.AdvSecretKey...)
+}
+
+// restoreDeviceSecrets puts the keys deviceSecrets packed back into device
+func restoreDeviceSecrets(device *store.Device, secrets []byte) error {
+	if len(secrets) < 3*32 {
+		return fmt.Errorf("sealed device keys are truncated")
+	}
+	device.NoiseKey = keys.NewKeyPairFromPrivateKey([32]byte(secrets[0:32]))
+	device.IdentityKey = keys.NewKeyPairFromPrivateKey([32]byte(secrets[32:64]))
+	device.SignedPreKey.KeyPair = *keys.NewKeyPairFromPrivateKey([32]byte(secrets[64:96]))
+	device.AdvSecretKey = bytes.Clone(secrets[96:])
+	return nil
+}
+
+// sealedValues seals values under one label and opens them, passing through
+// values written before sealing was turned on
+type sealedValues struct {
+	sealer *envelope.Sealer
+	label  []byte
+}
+
+func (v sealedValues) seal(value []byte) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return v.sealer.Seal(value, v.label)
+}
+
+func (v sealedValues) open(value []byte) ([]byte, error) {
+	if !envelope.IsSealed(value) {
+		return value, nil
+	}
+	return v.sealer.Open(value, v.label)
+}
+
+type sealedSessionStore struct {
+	store.SessionStore
+	values sealedValues
+}
+
+func (s *sealedSessionStore) GetSession(ctx context.Context, address string) ([]byte, error) {
+	session, err := s.SessionStore.GetSession(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return s.values.open(session)
+}
+
+func (s *sealedSessionStore) GetManySessions(ctx context.Context, addresses []string) (map[string][]byte, error) {
+	sessions, err := s.SessionStore.GetManySessions(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+	for address, session := range sessions {
+		if sessions[address], err = s.values.open(session); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+func (s *sealedSessionStore) PutSession(ctx context.Context, address string, session []byte) error {
+	sealed, err := s.values.seal(session)
+	if err != nil {
+		return err
+	}
+	return s.SessionStore.PutSession(ctx, address, sealed)
+}
+
+func (s *sealedSessionStore) PutManySessions(ctx context.Context, sessions map[string][]byte) error {
+	sealed := make(map[string][]byte, len(sessions))
+	for address, session := range sessions {
+		var err error
+		if sealed[address], err = s.values.seal(session); err != nil {
+			return err
+		}
+	}
+	return s.SessionStore.PutManySessions(ctx, sealed)
+}
+
+type sealedSenderKeyStore struct {
+	store.SenderKeyStore
+	values sealedValues
+}
+
+func (s *sealedSenderKeyStore) PutSenderKey(ctx context.Context, group, user string, session []byte) error {
+	sealed, err := s.values.seal(session)
+	if err != nil {
+		return err
+	}
+	return s.SenderKeyStore.PutSenderKey(ctx, group, user, sealed)
+}
+
+func (s *sealedSenderKeyStore) GetSenderKey(ctx context.Context, group, user string) ([]byte, error) {
+	session, err := s.SenderKeyStore.GetSenderKey(ctx, group, user)
+	if err != nil {
+		return nil, err
+	}
+	return s.values.open(session)
+}
+
+type sealedAppStateKeyStore struct {
+	store.AppStateSyncKeyStore
+	values sealedValues
+}
+
+func (s *sealedAppStateKeyStore) PutAppStateSyncKey(ctx context.Context, id []byte, key store.AppStateSyncKey) error {
+	sealed, err := s.values.seal(key.Data)
+	if err != nil {
+		return err
+	}
+	key.Data = sealed
+	return s.AppStateSyncKeyStore.PutAppStateSyncKey(ctx, id, key)
+}
+
+func (s *sealedAppStateKeyStore) GetAppStateSyncKey(ctx context.Context, id []byte) (*store.AppStateSyncKey, error) {
+	key, err := s.AppStateSyncKeyStore.GetAppStateSyncKey(ctx, id)
+	if err != nil || key == nil {
+		return key, err
+	}
+	if key.Data, err = s.values.open(key.Data); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,25 @@
+package whatsapp
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// handleDisappearingSetting records a chat's disappearing timer changed by
+// either side of the chat, so later sends disappear after the current timer
+func handleDisappearingSetting(evt *events.Message, db *sql.DB, client *whatsmeow.Client) {
+	protocol := evt.Message.GetProtocolMessage()
+	if client.Store.ID == nil || protocol.GetType() != waProto.ProtocolMessage_EPHEMERAL_SETTING {
+		return
+	}
+	senderID := client.Store.ID.User
+	chat := evt.Info.Chat.ToNonAD().String()
+	if err := repository.SetDisappearingTimer(db, senderID, chat, int(protocol.GetEphemeralExpiration())); err != nil {
+		log.Printf("Failed to record disappearing timer of %s for %s: %v", chat, senderID, err)
+	}
+}
@@ -0,0 +1,156 @@
+package whatsapp
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+)
+
+// Election policies reported when a new default sender is chosen
+const (
+	electionByPriority = "priority"
+	electionByAge      = "oldest_active"
+	electionDisabled   = "failover_disabled" // the lost default sender stays the default
+)
+
+// electDefaultSender picks the next default sender from candidates. The first
+// candidate listed in priority wins; otherwise the oldest sender (by createdAt)
+// is chosen. It returns "" when there are no candidates.
+func electDefaultSender(candidates []string, createdAt map[string]time.Time, priority []string) (string, string) {
+	if len(candidates) == 0 {
+		return "", ""
+	}
+
+	available := make(map[string]bool, len(candidates))
+	for _, id := range candidates {
+		available[id] = true
+	}
+	for _, id := range priority {
+		if available[id] {
+			return id, electionByPriority
+		}
+	}
+
+	sorted := append([]string(nil), candidates...)
+	sort.Slice(sorted, func(i, j int) bool {
+		ti, tj := createdAt[sorted[i]], createdAt[sorted[j]]
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted[0], electionByAge
+}
+
+// releaseDefaultSender reports whether senderID, which is going out of
+// rotation, is the default sender, and clears the default for re-election
+// unless failover is disabled. The caller must hold cm.mu and then call
+// failoverDefaultSender without it when senderID was the default.
+func (cm *ClientManager) releaseDefaultSender(senderID string) bool {
+	if cm.defaultSenderID != senderID || senderID == "" {
+		return false
+	}
+	if !cm.senderConfig.FailoverDisabled {
+		cm.defaultSenderID = ""
+	}
+	return true
+}
+
+// failoverDefaultSender replaces a default sender that logged out, was
+// banned, paused or removed. With failover disabled it stays the default, so
+// sends without a sender fail until it is back, and admins are alerted from
+// another connected sender. It must be called without cm.mu held.
+func (cm *ClientManager) failoverDefaultSender(previousID, reason string) {
+	if !cm.senderConfig.FailoverDisabled {
+		cm.reelectDefaultSender(previousID, reason)
+		return
+	}
+
+	log.Printf("⚠ Default sender %s is gone (%s); failover is disabled, so it stays the default until it is back", previousID, reason)
+	eventbus.Publish(eventbus.DefaultSenderChanged, map[string]any{
+		"previous_sender_id": previousID,
+		"sender_id":          "",
+		"reason":             reason,
+		"policy":             electionDisabled,
+	})
+	for _, client := range cm.GetAllClients() {
+		if client.IsConnected() {
+			processor.AnnounceDefaultSenderLost(client, cm.senderConfig.AlertPhones, previousID, reason)
+			return
+		}
+	}
+	log.Printf("⚠ No connected sender left to alert admins on WhatsApp that %s is gone", previousID)
+	processor.AnnounceDefaultSenderLost(nil, cm.senderConfig.AlertPhones, previousID, reason)
+}
+
+// reelectDefaultSender promotes another connected sender after previousID
+// stopped being usable, then alerts admins. It must be called without cm.mu held.
+func (cm *ClientManager) reelectDefaultSender(previousID, reason string) {
+	cm.mu.RLock()
+	if cm.defaultSenderID != "" {
+		// Someone already picked a new default (e.g. via SetDefaultSender)
+		cm.mu.RUnlock()
+		return
+	}
+	var candidates []string
+	for id, client := range cm.clients {
+		if id != previousID && !cm.isRestricted(id) && !cm.paused[id] && client.IsConnected() {
+			candidates = append(candidates, id)
+		}
+	}
+	cm.mu.RUnlock()
+
+	createdAt := make(map[string]time.Time)
+	if senders, err := repository.GetAllSenders(cm.db); err != nil {
+		log.Printf("Default sender election: failed to load senders, using sender ID order: %v", err)
+	} else {
+		active := make(map[string]bool)
+		for _, s := range senders {
+			createdAt[s.SenderID] = s.CreatedAt
+			active[s.SenderID] = s.IsActive
+		}
+		filtered := candidates[:0]
+		for _, id := range candidates {
+			if active[id] {
+				filtered = append(filtered, id)
+			}
+		}
+		candidates = filtered
+	}
+
+	// The "default" fallback chain from the API ranks ahead of SENDER_DEFAULT_PRIORITY
+	priority := cm.senderConfig.DefaultPriority
+	if chain, err := repository.GetSenderFallbackChain(cm.db, "default"); err != nil {
+		log.Printf("Default sender election: failed to load default fallback chain: %v", err)
+	} else if len(chain) > 0 {
+		priority = append(chain, priority...)
+	}
+
+	newID, policy := electDefaultSender(candidates, createdAt, priority)
+	event := map[string]any{
+		"previous_sender_id": previousID,
+		"sender_id":          newID,
+		"reason":             reason,
+		"policy":             policy,
+	}
+	if newID == "" {
+		log.Printf("⚠ Default sender %s is gone (%s) and no other active sender is available", previousID, reason)
+		eventbus.Publish(eventbus.DefaultSenderChanged, event)
+		return
+	}
+
+	if err := cm.SetDefaultSender(newID); err != nil {
+		log.Printf("Default sender election: failed to promote %s: %v", newID, err)
+		return
+	}
+	log.Printf("Default sender re-elected: %s -> %s (%s, policy: %s)", previousID, newID, reason, policy)
+	eventbus.Publish(eventbus.DefaultSenderChanged, event)
+
+	if client, err := cm.GetClient(newID); err == nil {
+		processor.AnnounceDefaultSenderChange(client, cm.senderConfig.AlertPhones, previousID, newID, reason)
+	}
+}
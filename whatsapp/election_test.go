@@ -0,0 +1,174 @@
+package whatsapp
+
+import (
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/eventbus"
+	"go.mau.fi/whatsmeow"
+)
+
+// newTestClientManager returns a client manager over an in-memory database
+// with the sender defaultID registered and elected as the default. Its client
+// never connects, so no other sender can take over.
+func newTestClientManager(t *testing.T, defaultID string, failoverDisabled bool) *ClientManager {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec(`CREATE TABLE senders (sender_id VARCHAR(50) PRIMARY KEY, is_paused BOOLEAN NOT NULL DEFAULT FALSE, updated_at TIMESTAMP)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO senders (sender_id) VALUES (?)`, defaultID)
+	require.NoError(t, err)
+
+	return &ClientManager{
+		db:              db,
+		clients:         map[string]*whatsmeow.Client{defaultID: {}},
+		defaultSenderID: defaultID,
+		senderConfig:    config.SenderConfig{FailoverDisabled: failoverDisabled},
+		restricted:      make(map[string]string),
+		paused:          make(map[string]bool),
+		lastRouted:      make(map[string]time.Time),
+		quotaExhausted:  make(map[string]time.Time),
+	}
+}
+
+// recordDefaultSenderChanges returns the sender.default_changed events
+// published about previousID from now on
+func recordDefaultSenderChanges(previousID string) func() []map[string]any {
+	var mu sync.Mutex
+	var events []map[string]any
+	eventbus.Subscribe(eventbus.DefaultSenderChanged, func(evt eventbus.Event) {
+		if evt.Data["previous_sender_id"] != previousID {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, evt.Data)
+	})
+	return func() []map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]map[string]any(nil), events...)
+	}
+}
+
+func TestElectDefaultSender(t *testing.T) {
+	const a, b, c = "6281111111111", "6282222222222", "6283333333333"
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := map[string]time.Time{
+		a: base.Add(2 * time.Hour),
+		b: base,
+		c: base.Add(time.Hour),
+	}
+
+	tests := []struct {
+		name       string
+		candidates []string
+		createdAt  map[string]time.Time
+		priority   []string
+		wantID     string
+		wantPolicy string
+	}{
+		{"first listed in priority", []string{a, b, c}, createdAt, []string{c, a}, c, electionByPriority},
+		{"priority skips senders that are not candidates", []string{a, b}, createdAt, []string{c, a}, a, electionByPriority},
+		{"no priority candidate falls back to oldest", []string{a, c}, createdAt, []string{b}, c, electionByAge},
+		{"oldest active without priority", []string{a, b, c}, createdAt, nil, b, electionByAge},
+		{"same age tie goes to lowest sender ID", []string{c, a, b}, map[string]time.Time{a: base, b: base, c: base}, nil, a, electionByAge},
+		{"unknown age counts as oldest", []string{a, b}, map[string]time.Time{a: base}, nil, b, electionByAge},
+		{"no candidates", nil, createdAt, []string{a}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, policy := electDefaultSender(tt.candidates, tt.createdAt, tt.priority)
+
+			assert.Equal(t, tt.wantID, id)
+			assert.Equal(t, tt.wantPolicy, policy)
+		})
+	}
+}
+
+func TestReleaseDefaultSender(t *testing.T) {
+	tests := []struct {
+		name             string
+		senderID         string
+		failoverDisabled bool
+		wantDefault      bool
+		wantDefaultAfter string
+	}{
+		{"default sender with failover", "6281111111111", false, true, ""},
+		{"default sender without failover", "6281111111111", true, true, "6281111111111"},
+		{"other sender", "6282222222222", false, false, "6281111111111"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := &ClientManager{
+				defaultSenderID: "6281111111111",
+				senderConfig:    config.SenderConfig{FailoverDisabled: tt.failoverDisabled},
+			}
+
+			assert.Equal(t, tt.wantDefault, cm.releaseDefaultSender(tt.senderID))
+			assert.Equal(t, tt.wantDefaultAfter, cm.defaultSenderID)
+		})
+	}
+}
+
+func TestPauseSender_FailsOverDefault(t *testing.T) {
+	// Arrange
+	cm := newTestClientManager(t, "6281111111111", false)
+	events := recordDefaultSenderChanges("6281111111111")
+
+	// Act
+	err := cm.PauseSender("6281111111111")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, cm.defaultSenderID, "cleared for re-election")
+	require.Len(t, events(), 1)
+	assert.Equal(t, "paused", events()[0]["reason"])
+	assert.Equal(t, "", events()[0]["sender_id"], "no other sender is connected")
+}
+
+func TestPauseSender_FailoverDisabledKeepsDefault(t *testing.T) {
+	// Arrange
+	cm := newTestClientManager(t, "6283333333333", true)
+	events := recordDefaultSenderChanges("6283333333333")
+
+	// Act
+	err := cm.PauseSender("6283333333333")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "6283333333333", cm.defaultSenderID)
+	require.Len(t, events(), 1)
+	assert.Equal(t, electionDisabled, events()[0]["policy"])
+	_, err = cm.GetDefaultClient()
+	assert.ErrorContains(t, err, "paused", "sends without a sender fail until it is resumed")
+}
+
+func TestPauseSender_NotDefault(t *testing.T) {
+	// Arrange
+	cm := newTestClientManager(t, "6284444444444", false)
+	cm.clients["6285555555555"] = &whatsmeow.Client{}
+	_, err := cm.db.Exec(`INSERT INTO senders (sender_id) VALUES (?)`, "6285555555555")
+	require.NoError(t, err)
+	events := recordDefaultSenderChanges("6285555555555")
+
+	// Act
+	err = cm.PauseSender("6285555555555")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "6284444444444", cm.defaultSenderID)
+	assert.True(t, cm.IsPaused("6285555555555"))
+	assert.Empty(t, events())
+}
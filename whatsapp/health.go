@@ -0,0 +1,30 @@
+package whatsapp
+
+import (
+	"log"
+
+	"github.com/wa-serv/repository"
+)
+
+// recordDisconnect keeps why a sender last lost its connection, for its health
+func (cm *ClientManager) recordDisconnect(senderID, reason string) {
+	if err := repository.SetSenderDisconnected(cm.db, senderID, reason); err != nil {
+		log.Printf("Failed to record disconnect of sender %s: %v", senderID, err)
+	}
+}
+
+// SenderConnection reports whether a sender's client is connected and logged
+// in, and the push name it shows. A sender without a client is neither.
+func (cm *ClientManager) SenderConnection(senderID string) (connected, loggedIn bool, pushName string) {
+	cm.mu.RLock()
+	client, ok := cm.clients[senderID]
+	cm.mu.RUnlock()
+	if !ok {
+		return false, false, ""
+	}
+
+	if client.Store != nil {
+		pushName = client.Store.PushName
+	}
+	return client.IsConnected(), client.IsLoggedIn(), pushName
+}
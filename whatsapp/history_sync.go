@@ -0,0 +1,56 @@
+package whatsapp
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+
+	"github.com/wa-serv/config"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ApplyHistorySyncConfig limits the history sync requested from the phone when
+// a new device is paired. It must be called before pairing; when the sync is
+// disabled the whatsmeow defaults are left untouched.
+func ApplyHistorySyncConfig() {
+	cfg := config.LoadHistorySyncConfig()
+	if !cfg.Enabled || store.DeviceProps.HistorySyncConfig == nil {
+		return
+	}
+	days := proto.Uint32(uint32(cfg.DaysLimit))
+	store.DeviceProps.HistorySyncConfig.FullSyncDaysLimit = days
+	store.DeviceProps.HistorySyncConfig.RecentSyncDaysLimit = days
+	store.DeviceProps.RequireFullSync = proto.Bool(false)
+}
+
+// handleHistorySync copies push names from a history sync blob onto member
+// records. Ignored unless HISTORY_SYNC_ENABLED is set.
+func handleHistorySync(evt *events.HistorySync, db *sql.DB) {
+	if !config.LoadHistorySyncConfig().Enabled || evt.Data == nil {
+		return
+	}
+
+	pushnames := evt.Data.GetPushnames()
+	for _, p := range pushnames {
+		jid, err := types.ParseJID(p.GetID())
+		if err != nil {
+			continue
+		}
+		// Only enrich existing members; synced contacts are not prospects
+		// because they never messaged the bot
+		name := strings.TrimSpace(p.GetPushname())
+		if jid.Server != types.DefaultUserServer || name == "" {
+			continue
+		}
+		if _, err := repository.UpdateMemberPushName(db, jid.User, name); err != nil {
+			log.Printf("History sync: failed to store push name for %s: %v", jid.User, err)
+		}
+	}
+	if len(pushnames) > 0 {
+		log.Printf("History sync (%s): processed %d push names", evt.Data.GetSyncType(), len(pushnames))
+	}
+}
@@ -0,0 +1,34 @@
+package whatsapp
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// handleLabelEdit records a WhatsApp Business label created, renamed or deleted
+// on any of the sender's devices, including during an app state full sync
+func handleLabelEdit(evt *events.LabelEdit, db *sql.DB, client *whatsmeow.Client) {
+	if client.Store.ID == nil || evt.Action == nil {
+		return
+	}
+	senderID := client.Store.ID.User
+	if _, err := repository.SaveLabel(db, senderID, evt.LabelID, evt.Action.GetName(), int(evt.Action.GetColor()), evt.Action.GetDeleted()); err != nil {
+		log.Printf("Failed to record label %s of %s: %v", evt.LabelID, senderID, err)
+	}
+}
+
+// handleLabelAssociationChat records a label put on or taken off a chat on any
+// of the sender's devices
+func handleLabelAssociationChat(evt *events.LabelAssociationChat, db *sql.DB, client *whatsmeow.Client) {
+	if client.Store.ID == nil || evt.Action == nil {
+		return
+	}
+	senderID := client.Store.ID.User
+	if err := repository.SetLabelChat(db, senderID, evt.LabelID, evt.JID.ToNonAD().String(), evt.Action.GetLabeled()); err != nil {
+		log.Printf("Failed to record label %s on %s for %s: %v", evt.LabelID, evt.JID, senderID, err)
+	}
+}
@@ -0,0 +1,75 @@
+package whatsapp
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/wa-serv/repository"
+)
+
+// loadPausedSenders restores which senders operators had paused before a restart
+func (cm *ClientManager) loadPausedSenders() {
+	senders, err := repository.GetAllSenders(cm.db)
+	if err != nil {
+		log.Printf("Failed to load paused senders: %v", err)
+		return
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	for _, s := range senders {
+		if s.IsPaused {
+			cm.paused[s.SenderID] = true
+		}
+	}
+}
+
+// IsPaused reports whether an operator took senderID out of rotation
+func (cm *ClientManager) IsPaused(senderID string) bool {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.paused[senderID]
+}
+
+// PauseSender takes a sender out of send rotation without logging it out. It
+// stays connected and keeps receiving messages, but sends through it are
+// refused until it is resumed. A paused default sender hands over to another
+// connected sender, unless failover is disabled.
+func (cm *ClientManager) PauseSender(senderID string) error {
+	if err := repository.SetSenderPaused(cm.db, senderID, true); err != nil {
+		return fmt.Errorf("failed to pause sender %s: %w", senderID, err)
+	}
+
+	cm.mu.Lock()
+	cm.paused[senderID] = true
+	wasDefault := cm.releaseDefaultSender(senderID)
+	cm.mu.Unlock()
+
+	log.Printf("⏸ Sender %s paused", senderID)
+	if wasDefault {
+		cm.failoverDefaultSender(senderID, "paused")
+	}
+	return nil
+}
+
+// ResumeSender puts a paused sender back into send rotation, as the default
+// sender when there is none
+func (cm *ClientManager) ResumeSender(senderID string) error {
+	if err := repository.SetSenderPaused(cm.db, senderID, false); err != nil {
+		return fmt.Errorf("failed to resume sender %s: %w", senderID, err)
+	}
+
+	cm.mu.Lock()
+	delete(cm.paused, senderID)
+	_, connected := cm.clients[senderID]
+	noDefault := cm.defaultSenderID == ""
+	cm.mu.Unlock()
+
+	log.Printf("▶ Sender %s resumed", senderID)
+	if connected && noDefault {
+		if err := cm.SetDefaultSender(senderID); err != nil {
+			log.Printf("Failed to make resumed sender %s the default: %v", senderID, err)
+		}
+	}
+	return nil
+}
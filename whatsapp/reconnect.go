@@ -0,0 +1,53 @@
+package whatsapp
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// reconnectCooldown is the least time between manual reconnects of a sender.
+// Reconnecting over and over can trip WhatsApp's security checks and log the
+// device out.
+const reconnectCooldown = time.Minute
+
+var (
+	// ErrNoSession is returned for a sender whose client has no device session
+	// left to reconnect with; it has to be registered again
+	ErrNoSession = errors.New("sender has no session")
+	// ErrReconnectTooSoon is returned for a sender reconnected less than
+	// reconnectCooldown ago
+	ErrReconnectTooSoon = errors.New("sender was reconnected too recently")
+)
+
+// ReconnectSender drops a sender's connection and opens a new one with the
+// same session, for a client that is stuck while whatsmeow thinks it is fine.
+// The sender is not logged out and keeps its place as default. A sender is
+// reconnected at most once per reconnectCooldown.
+func (cm *ClientManager) ReconnectSender(senderID string) error {
+	cm.mu.Lock()
+	client, exists := cm.clients[senderID]
+	if !exists {
+		cm.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrClientNotFound, senderID)
+	}
+	if client.Store == nil || client.Store.ID == nil {
+		cm.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrNoSession, senderID)
+	}
+	if last, ok := cm.lastReconnect[senderID]; ok && time.Since(last) < reconnectCooldown {
+		cm.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrReconnectTooSoon, senderID)
+	}
+	cm.lastReconnect[senderID] = time.Now()
+	cm.mu.Unlock()
+
+	log.Printf("🔄 Reconnecting sender %s", senderID)
+	client.Disconnect()
+	if err := client.Connect(); err != nil {
+		cm.recordDisconnect(senderID, "reconnect_failed: "+err.Error())
+		return fmt.Errorf("failed to reconnect sender %s: %w", senderID, err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package whatsapp
+
+import (
+	"log"
+	"time"
+
+	"github.com/wa-serv/eventbus"
+	"github.com/wa-serv/processor"
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// senderRestriction describes a ban or restriction reported by WhatsApp
+type senderRestriction struct {
+	State  string     // repository.SenderStateRestricted or SenderStateBanned
+	Reason string     // human-readable detail from the event
+	Until  *time.Time // when a temporary ban lifts, if known
+}
+
+// classifySenderRestriction maps whatsmeow events that indicate a ban or
+// restriction to a sender state. It returns nil for ordinary events,
+// including plain logouts.
+func classifySenderRestriction(evt interface{}, now time.Time) *senderRestriction {
+	switch v := evt.(type) {
+	case *events.TemporaryBan:
+		r := &senderRestriction{State: repository.SenderStateRestricted, Reason: v.String()}
+		if v.Expire > 0 {
+			until := now.Add(v.Expire)
+			r.Until = &until
+		}
+		return r
+	case *events.LoggedOut:
+		return restrictionForFailure(v.Reason)
+	case *events.ConnectFailure:
+		return restrictionForFailure(v.Reason)
+	case *events.StreamError:
+		// Unknown stream errors occasionally carry the connect failure codes
+		switch v.Code {
+		case events.ConnectFailureTempBanned.NumberString():
+			return restrictionForFailure(events.ConnectFailureTempBanned)
+		case events.ConnectFailureMainDeviceGone.NumberString():
+			return restrictionForFailure(events.ConnectFailureMainDeviceGone)
+		case events.ConnectFailureUnknownLogout.NumberString():
+			return restrictionForFailure(events.ConnectFailureUnknownLogout)
+		}
+	}
+	return nil
+}
+
+// restrictionForFailure classifies connect failure codes. WhatsApp Web calls
+// 406 BANNED and 403 LOCKED; 402 is a temporary ban.
+func restrictionForFailure(reason events.ConnectFailureReason) *senderRestriction {
+	switch reason {
+	case events.ConnectFailureUnknownLogout:
+		return &senderRestriction{State: repository.SenderStateBanned, Reason: reason.String()}
+	case events.ConnectFailureMainDeviceGone, events.ConnectFailureTempBanned:
+		return &senderRestriction{State: repository.SenderStateRestricted, Reason: reason.String()}
+	}
+	return nil
+}
+
+// isRestricted reports whether senderID is out of rotation. Callers must hold cm.mu.
+func (cm *ClientManager) isRestricted(senderID string) bool {
+	_, restricted := cm.restricted[senderID]
+	return restricted
+}
+
+// restrictSender takes a still-linked sender out of send rotation after a ban
+// or restriction, re-electing the default sender if needed
+func (cm *ClientManager) restrictSender(client *whatsmeow.Client, r *senderRestriction) {
+	senderID := client.Store.ID.User
+
+	cm.mu.Lock()
+	cm.restricted[senderID] = r.State
+	wasDefault := cm.releaseDefaultSender(senderID)
+	cm.mu.Unlock()
+
+	if wasDefault {
+		cm.failoverDefaultSender(senderID, r.State)
+	}
+	cm.reportRestriction(senderID, r)
+}
+
+// liftRestriction puts a sender back into rotation once it connects again
+func (cm *ClientManager) liftRestriction(senderID string) {
+	cm.mu.Lock()
+	_, wasRestricted := cm.restricted[senderID]
+	delete(cm.restricted, senderID)
+	cm.mu.Unlock()
+
+	if !wasRestricted {
+		return
+	}
+	if err := repository.SetSenderState(cm.db, senderID, repository.SenderStateActive, "", nil); err != nil {
+		log.Printf("Failed to clear restricted state for %s: %v", senderID, err)
+	}
+	log.Printf("✓ Sender %s connected again and is back in rotation", senderID)
+}
+
+// reportRestriction stores the sender state and alerts admins and event subscribers
+func (cm *ClientManager) reportRestriction(senderID string, r *senderRestriction) {
+	log.Printf("🚫 Sender %s is %s by WhatsApp: %s", senderID, r.State, r.Reason)
+
+	if err := repository.SetSenderState(cm.db, senderID, r.State, r.Reason, r.Until); err != nil {
+		log.Printf("Failed to record %s state for %s: %v", r.State, senderID, err)
+	}
+
+	event := map[string]any{
+		"sender_id": senderID,
+		"state":     r.State,
+		"reason":    r.Reason,
+	}
+	if r.Until != nil {
+		event["until"] = r.Until.UTC()
+	}
+	eventbus.Publish(eventbus.SenderDown, event)
+
+	// Alert from whichever sender is still usable; without one, admins who
+	// chose email or webhook alerts still hear of it
+	client, err := cm.GetDefaultClient()
+	if err != nil {
+		log.Printf("No sender available to alert admins about %s on WhatsApp: %v", senderID, err)
+		client = nil
+	}
+	processor.AnnounceSenderRestricted(client, cm.senderConfig.AlertPhones, senderID, r.State, r.Reason)
+}
@@ -0,0 +1,152 @@
+package whatsapp
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/wa-serv/config"
+	"go.mau.fi/whatsmeow"
+)
+
+// NextClient returns the client that sends a message without a named sender.
+// With the default routing strategy that is the default client. With
+// round_robin the connected senders in rotation take turns, and with
+// least_recently_used the one that was routed a send the longest ago goes
+// next, so a broadcast is spread across every number instead of hitting the
+// rate limits of one. Senders that have sent their daily quota sit out until
+// it resets. With no connected sender in rotation it falls back to the default
+// client.
+func (cm *ClientManager) NextClient() (*whatsmeow.Client, error) {
+	strategy := cm.senderConfig.RoutingStrategy
+	if strategy == "" || strategy == config.RoutingDefault {
+		return cm.GetDefaultClient()
+	}
+
+	cm.mu.Lock()
+	now := time.Now()
+	states := make([]routeState, 0, len(cm.clients))
+	for id, client := range cm.clients {
+		states = append(states, cm.routeState(id, client.IsConnected(), now))
+	}
+	candidates := routable(states)
+	if len(candidates) == 0 {
+		cm.mu.Unlock()
+		return cm.GetDefaultClient()
+	}
+
+	var senderID string
+	senderID, cm.nextRoute = routeSender(strategy, candidates, cm.nextRoute, cm.lastRouted)
+	cm.lastRouted[senderID] = now
+	client := cm.clients[senderID]
+	cm.mu.Unlock()
+	return client, nil
+}
+
+// ErrNoHealthySender is returned when no sender of a pool can send
+var ErrNoHealthySender = errors.New("no healthy sender in pool")
+
+// PickSender returns the sender of members, a named pool, that sends the next
+// message. Only members that are connected, logged in, neither paused nor
+// restricted, and not through their daily quota are considered. With the default routing strategy the first of
+// them in pool order sends, so the pool doubles as a failover list; otherwise
+// they share the rotation of NextClient.
+func (cm *ClientManager) PickSender(members []string) (string, error) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	now := time.Now()
+	states := make([]routeState, 0, len(members))
+	for _, id := range members {
+		client, ok := cm.clients[id]
+		states = append(states, cm.routeState(id, ok && client.IsConnected() && client.IsLoggedIn(), now))
+	}
+	candidates := routable(states)
+	if len(candidates) == 0 {
+		return "", ErrNoHealthySender
+	}
+
+	strategy := cm.senderConfig.RoutingStrategy
+	if strategy == "" || strategy == config.RoutingDefault {
+		return candidates[0], nil
+	}
+	var senderID string
+	senderID, cm.nextRoute = routeSender(strategy, candidates, cm.nextRoute, cm.lastRouted)
+	cm.lastRouted[senderID] = now
+	return senderID, nil
+}
+
+// SetQuotaExhausted keeps senderID out of send rotation until until, when the
+// daily quota it has sent resets. A zero until puts it back.
+func (cm *ClientManager) SetQuotaExhausted(senderID string, until time.Time) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if until.IsZero() {
+		delete(cm.quotaExhausted, senderID)
+		return
+	}
+	cm.quotaExhausted[senderID] = until
+}
+
+// isQuotaExhausted reports whether senderID has sent its daily quota as of
+// now. The caller must hold cm.mu.
+func (cm *ClientManager) isQuotaExhausted(senderID string, now time.Time) bool {
+	until, ok := cm.quotaExhausted[senderID]
+	return ok && now.Before(until)
+}
+
+// routeState is what routing knows of a sender
+type routeState struct {
+	id         string
+	connected  bool // and logged in, where routing asks for that
+	paused     bool
+	restricted bool
+	exhausted  bool // has sent its daily quota
+}
+
+// routeState returns the routing state of senderID as of now. The caller must
+// hold cm.mu.
+func (cm *ClientManager) routeState(senderID string, connected bool, now time.Time) routeState {
+	return routeState{
+		id:         senderID,
+		connected:  connected,
+		paused:     cm.paused[senderID],
+		restricted: cm.isRestricted(senderID),
+		exhausted:  cm.isQuotaExhausted(senderID, now),
+	}
+}
+
+// routable returns the IDs of the senders that can take a send, in the order
+// given: connected, neither paused nor restricted, and not through their
+// daily quota
+func routable(senders []routeState) []string {
+	var ids []string
+	for _, s := range senders {
+		if s.connected && !s.paused && !s.restricted && !s.exhausted {
+			ids = append(ids, s.id)
+		}
+	}
+	return ids
+}
+
+// routeSender picks the sender of the next send from candidates by strategy.
+// cursor is the round-robin position, returned advanced past the pick;
+// lastRouted holds when each sender was last picked. Candidates are taken in
+// sender ID order, so the rotation is stable as senders come and go.
+func routeSender(strategy string, candidates []string, cursor int, lastRouted map[string]time.Time) (string, int) {
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+
+	if strategy == config.RoutingLeastRecentlyUsed {
+		pick := sorted[0]
+		for _, id := range sorted[1:] {
+			if lastRouted[id].Before(lastRouted[pick]) {
+				pick = id
+			}
+		}
+		return pick, cursor
+	}
+
+	pick := sorted[cursor%len(sorted)]
+	return pick, (cursor + 1) % len(sorted)
+}
@@ -0,0 +1,157 @@
+package whatsapp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wa-serv/config"
+)
+
+func TestSetQuotaExhausted(t *testing.T) {
+	cm := &ClientManager{quotaExhausted: make(map[string]time.Time)}
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	reset := now.Add(15 * time.Hour)
+
+	cm.SetQuotaExhausted("6281111111111", reset)
+	assert.True(t, cm.isQuotaExhausted("6281111111111", now))
+	assert.False(t, cm.isQuotaExhausted("6282222222222", now))
+	assert.False(t, cm.isQuotaExhausted("6281111111111", reset), "back in rotation once the quota resets")
+
+	cm.SetQuotaExhausted("6281111111111", time.Time{})
+	assert.False(t, cm.isQuotaExhausted("6281111111111", now))
+}
+
+func TestRouteSender(t *testing.T) {
+	const a, b, c = "6281111111111", "6282222222222", "6283333333333"
+	up := func(id string) routeState { return routeState{id: id, connected: true} }
+	base := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		strategy   string
+		senders    []routeState
+		cursor     int
+		lastRouted map[string]time.Time
+		want       []string // successive picks
+	}{
+		{
+			name:     "round robin in sender ID order",
+			strategy: config.RoutingRoundRobin,
+			senders:  []routeState{up(c), up(a), up(b)},
+			want:     []string{a, b, c, a},
+		},
+		{
+			name:     "round robin wraps around from the last sender",
+			strategy: config.RoutingRoundRobin,
+			senders:  []routeState{up(a), up(b), up(c)},
+			cursor:   2,
+			want:     []string{c, a, b},
+		},
+		{
+			name:     "round robin cursor past a sender that left",
+			strategy: config.RoutingRoundRobin,
+			senders:  []routeState{up(a), up(b)},
+			cursor:   5,
+			want:     []string{b, a},
+		},
+		{
+			name:     "round robin skips paused",
+			strategy: config.RoutingRoundRobin,
+			senders:  []routeState{up(a), {id: b, connected: true, paused: true}, up(c)},
+			want:     []string{a, c, a},
+		},
+		{
+			name:     "round robin skips restricted",
+			strategy: config.RoutingRoundRobin,
+			senders:  []routeState{{id: a, connected: true, restricted: true}, up(b), up(c)},
+			want:     []string{b, c, b},
+		},
+		{
+			name:     "round robin skips disconnected",
+			strategy: config.RoutingRoundRobin,
+			senders:  []routeState{up(a), up(b), {id: c}},
+			want:     []string{a, b, a},
+		},
+		{
+			name:     "round robin skips senders through their quota",
+			strategy: config.RoutingRoundRobin,
+			senders:  []routeState{up(a), {id: b, connected: true, exhausted: true}, up(c)},
+			want:     []string{a, c, a},
+		},
+		{
+			name:     "least recently used takes turns",
+			strategy: config.RoutingLeastRecentlyUsed,
+			senders:  []routeState{up(a), up(b), up(c)},
+			lastRouted: map[string]time.Time{
+				a: base.Add(-time.Minute),
+				b: base.Add(-3 * time.Minute),
+				c: base.Add(-2 * time.Minute),
+			},
+			want: []string{b, c, a, b},
+		},
+		{
+			name:     "least recently used prefers never routed, then sender ID",
+			strategy: config.RoutingLeastRecentlyUsed,
+			senders:  []routeState{up(c), up(b), up(a)},
+			lastRouted: map[string]time.Time{
+				a: base.Add(-time.Minute),
+			},
+			want: []string{b, c, a},
+		},
+		{
+			name:     "least recently used skips paused, restricted and disconnected",
+			strategy: config.RoutingLeastRecentlyUsed,
+			senders: []routeState{
+				{id: a, connected: true, paused: true},
+				{id: b, connected: true, restricted: true},
+				{id: c},
+				up("6284444444444"),
+			},
+			want: []string{"6284444444444", "6284444444444"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lastRouted := make(map[string]time.Time)
+			for id, at := range tt.lastRouted {
+				lastRouted[id] = at
+			}
+			cursor := tt.cursor
+
+			var got []string
+			for i := range tt.want {
+				var pick string
+				pick, cursor = routeSender(tt.strategy, routable(tt.senders), cursor, lastRouted)
+				lastRouted[pick] = base.Add(time.Duration(i) * time.Minute)
+				got = append(got, pick)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRoutable_NoneEligible(t *testing.T) {
+	senders := []routeState{
+		{id: "6281111111111", connected: true, paused: true},
+		{id: "6282222222222"},
+	}
+
+	assert.Empty(t, routable(senders))
+}
+
+func TestClientManager_RouteState(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	cm := &ClientManager{
+		paused:         map[string]bool{"6281111111111": true},
+		restricted:     map[string]string{"6282222222222": "restricted"},
+		quotaExhausted: map[string]time.Time{"6283333333333": now.Add(time.Hour)},
+	}
+
+	assert.Equal(t, routeState{id: "6281111111111", connected: true, paused: true}, cm.routeState("6281111111111", true, now))
+	assert.Equal(t, routeState{id: "6282222222222", connected: true, restricted: true}, cm.routeState("6282222222222", true, now))
+	assert.Equal(t, routeState{id: "6283333333333", connected: true, exhausted: true}, cm.routeState("6283333333333", true, now))
+	assert.Equal(t, routeState{id: "6284444444444"}, cm.routeState("6284444444444", false, now))
+}
@@ -0,0 +1,103 @@
+package whatsapp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/wa-serv/repository"
+	"go.mau.fi/whatsmeow/store"
+	"go.mau.fi/whatsmeow/store/sqlstore"
+	waLog "go.mau.fi/whatsmeow/util/log"
+)
+
+// StoredSession is a WhatsApp device session kept in the whatsmeow tables
+type StoredSession struct {
+	SenderID string // phone number of the device
+	JID      string
+	PushName string
+
+	device *store.Device
+}
+
+// ClearedSessions summarizes what ClearSessions removed
+type ClearedSessions struct {
+	Sessions           []StoredSession
+	DeactivatedSenders []string
+}
+
+// SessionStore lists and clears stored WhatsApp sessions. It never loads their
+// private keys, so sessions sealed with a lost SESSION_ENCRYPTION_KEY can
+// still be cleared.
+type SessionStore struct {
+	db        *sql.DB
+	container *sqlstore.Container
+}
+
+// OpenSessionStore opens the whatsmeow session tables of the database at
+// connectionString; db is the same database, for the senders and device_keys
+// tables
+func OpenSessionStore(db *sql.DB, connectionString string) (*SessionStore, error) {
+	dbLog := waLog.Stdout("Database", GetLogLevel(), true)
+	container, err := sqlstore.New(context.Background(), "postgres", connectionString, dbLog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database for WhatsApp sessions: %w", err)
+	}
+	return &SessionStore{db: db, container: container}, nil
+}
+
+// Sessions returns the stored sessions of senderIDs, or every stored session
+// when senderIDs is empty. missing lists the sender IDs without a session.
+func (s *SessionStore) Sessions(ctx context.Context, senderIDs []string) (sessions []StoredSession, missing []string, err error) {
+	devices, err := s.container.GetAllDevices(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(senderIDs))
+	for _, senderID := range senderIDs {
+		wanted[senderID] = false
+	}
+	for _, device := range devices {
+		senderID := device.ID.User
+		if len(senderIDs) > 0 {
+			if _, ok := wanted[senderID]; !ok {
+				continue
+			}
+			wanted[senderID] = true
+		}
+		sessions = append(sessions, StoredSession{
+			SenderID: senderID,
+			JID:      device.ID.String(),
+			PushName: device.PushName,
+			device:   device,
+		})
+	}
+	for _, senderID := range senderIDs {
+		if !wanted[senderID] {
+			missing = append(missing, senderID)
+		}
+	}
+	return sessions, missing, nil
+}
+
+// ClearSessions deletes sessions and marks their senders inactive, so a
+// cleared sender is not used for sending until it is registered again. On
+// error, the summary covers what was removed before it.
+func (s *SessionStore) ClearSessions(ctx context.Context, sessions []StoredSession) (*ClearedSessions, error) {
+	cleared := &ClearedSessions{}
+	for _, session := range sessions {
+		if err := s.container.DeleteDevice(ctx, session.device); err != nil {
+			return cleared, fmt.Errorf("failed to delete device %s: %w", session.JID, err)
+		}
+		cleared.Sessions = append(cleared.Sessions, session)
+		if err := repository.DeleteDeviceKeys(s.db, session.JID); err != nil {
+			return cleared, err
+		}
+		if err := repository.UpdateSenderStatus(s.db, session.SenderID, false); err != nil {
+			return cleared, err
+		}
+		cleared.DeactivatedSenders = append(cleared.DeactivatedSenders, session.SenderID)
+	}
+	return cleared, nil
+}
@@ -12,12 +12,14 @@ import (
 	_ "github.com/lib/pq" // PostgreSQL driver for Supabase
 	"github.com/mdp/qrterminal/v3"
 	"github.com/wa-serv/database"
+	"github.com/wa-serv/eventbus"
 	"github.com/wa-serv/handlers"
 	"github.com/wa-serv/repository"
 	"go.mau.fi/whatsmeow"
 	waCompanionReg "go.mau.fi/whatsmeow/proto/waCompanionReg"
 	"go.mau.fi/whatsmeow/store"
 	"go.mau.fi/whatsmeow/store/sqlstore"
+	"go.mau.fi/whatsmeow/types"
 	"go.mau.fi/whatsmeow/types/events"
 	waLog "go.mau.fi/whatsmeow/util/log"
 	"google.golang.org/protobuf/proto"
@@ -63,9 +65,10 @@ func InitializeWhatsAppClient(db *sql.DB) *Client {
 		os.Exit(1)
 	}
 
-	// Set custom device name and platform type
+	// Set custom device name, platform type and history sync limits
 	store.DeviceProps.Os = proto.String(DeviceName)
 	store.DeviceProps.PlatformType = waCompanionReg.DeviceProps_DESKTOP.Enum()
+	ApplyHistorySyncConfig()
 
 	clientLog := waLog.Stdout("Client", "DEBUG", true)
 	whatsmeowClient := whatsmeow.NewClient(deviceStore, clientLog)
@@ -115,7 +118,10 @@ func connectToWhatsApp(client *whatsmeow.Client) {
 func HandleEvent(evt interface{}, db *sql.DB, client *whatsmeow.Client) {
 	switch v := evt.(type) {
 	case *events.Message:
+		handleDisappearingSetting(v, db, client)
 		handlers.HandleMessageEvent(v, db, client)
+	case *events.HistorySync:
+		handleHistorySync(v, db)
 	case *events.Connected:
 		handleConnected(client)
 	case *events.Disconnected:
@@ -132,6 +138,12 @@ func HandleEvent(evt interface{}, db *sql.DB, client *whatsmeow.Client) {
 		handleKeepAliveTimeout(v, client)
 	case *events.KeepAliveRestored:
 		handleKeepAliveRestored(client)
+	case *events.Receipt:
+		handleReceipt(v)
+	case *events.LabelEdit:
+		handleLabelEdit(v, db, client)
+	case *events.LabelAssociationChat:
+		handleLabelAssociationChat(v, db, client)
 	}
 }
 
@@ -206,6 +218,36 @@ func handleKeepAliveRestored(client *whatsmeow.Client) {
 	}
 }
 
+// handleReceipt announces that messages we sent were delivered to or read by
+// their recipient. Receipts from our own devices and retry or error receipts
+// are ignored.
+func handleReceipt(evt *events.Receipt) {
+	if evt.IsFromMe {
+		return
+	}
+
+	var status string
+	switch evt.Type {
+	case types.ReceiptTypeDelivered:
+		status = eventbus.StatusDelivered
+	case types.ReceiptTypeRead, types.ReceiptTypePlayed:
+		status = eventbus.StatusRead
+	default:
+		return
+	}
+
+	messageIDs := make([]string, len(evt.MessageIDs))
+	for i, id := range evt.MessageIDs {
+		messageIDs[i] = string(id)
+	}
+	eventbus.Publish(eventbus.MessageStatus, map[string]any{
+		"message_ids": messageIDs,
+		"status":      status,
+		"chat":        evt.Chat.String(),
+		"timestamp":   evt.Timestamp,
+	})
+}
+
 // handleLogout handles the LoggedOut event
 func handleLogout(evt *events.LoggedOut, db *sql.DB, client *whatsmeow.Client) {
 	reason := evt.Reason
@@ -241,36 +283,3 @@ func handleEvent(evt interface{}, db *sql.DB, client *whatsmeow.Client) {
 func (c *Client) Disconnect() {
 	c.whatsmeowClient.Disconnect()
 }
-
-func ClearAllSessions() error {
-	// Load environment variables from .env file
-	err := godotenv.Load()
-	if err != nil {
-		return fmt.Errorf("error loading .env file: %v", err)
-	}
-
-	// Build Supabase PostgreSQL connection string
-	connectionString := database.BuildPostgresConnectionString()
-
-	// Connect to the same Supabase PostgreSQL database
-	dbLog := waLog.Stdout("Database", "DEBUG", true)
-	container, err := sqlstore.New(context.Background(), "postgres", connectionString, dbLog)
-	if err != nil {
-		return fmt.Errorf("failed to connect to Supabase PostgreSQL database: %v", err)
-	}
-
-	// Get all devices
-	devices, err := container.GetAllDevices(context.Background())
-	if err != nil {
-		return fmt.Errorf("failed to get devices: %v", err)
-	}
-
-	// Delete each device
-	for _, device := range devices {
-		if err := container.DeleteDevice(context.Background(), device); err != nil {
-			return fmt.Errorf("failed to delete device %s: %v", device.ID, err)
-		}
-	}
-
-	return nil
-}
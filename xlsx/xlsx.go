@@ -0,0 +1,233 @@
+// Package xlsx writes simple spreadsheets in the Office Open XML format that
+// Excel, LibreOffice and Google Sheets open. It knows just enough for reports:
+// sheets of text and numbers under a bold header row.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MIMEType is the media type of the workbooks Write produces
+const MIMEType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Sheet is one worksheet of a workbook
+type Sheet struct {
+	Name   string
+	Header []string // bold first row; empty writes none
+	Rows   [][]any  // cells are written as numbers when they are ints or floats, as text otherwise
+}
+
+// maxSheetName is the longest sheet name Excel accepts
+const maxSheetName = 31
+
+// Write packs sheets into a workbook. Sheet names are cut to what Excel
+// accepts and made unique.
+func Write(sheets ...Sheet) ([]byte, error) {
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("a workbook needs at least one sheet")
+	}
+	names := sheetNames(sheets)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes(len(sheets))},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", workbook(names)},
+		{"xl/_rels/workbook.xml.rels", workbookRels(len(sheets))},
+		{"xl/styles.xml", styles},
+	}
+	for i, sheet := range sheets {
+		files = append(files, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheet(sheet)})
+	}
+	for _, f := range files {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s: %w", f.name, err)
+		}
+		if _, err := w.Write([]byte(f.content)); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish workbook: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+const (
+	mainNS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	relNS  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+const rootRels = xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="` + relNS + `/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles holds the default cell format and, at index 1, the bold one
+const styles = xmlHeader + `<styleSheet xmlns="` + mainNS + `">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+// boldStyle is the index of the bold format in styles
+const boldStyle = 1
+
+func contentTypes(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func workbook(names []string) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader + `<workbook xmlns="` + mainNS + `" xmlns:r="` + relNS + `"><sheets>`)
+	for i, name := range names {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+// workbookRels links the sheets as rId1..rIdN and the styles after them
+func workbookRels(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, i, relNS, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s/styles" Target="styles.xml"/>`, sheets+1, relNS)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func worksheet(sheet Sheet) string {
+	var b strings.Builder
+	b.WriteString(xmlHeader + `<worksheet xmlns="` + mainNS + `"><sheetData>`)
+	row := 0
+	if len(sheet.Header) > 0 {
+		row++
+		fmt.Fprintf(&b, `<row r="%d">`, row)
+		for col, title := range sheet.Header {
+			writeText(&b, cellRef(col, row), title, boldStyle)
+		}
+		b.WriteString(`</row>`)
+	}
+	for _, cells := range sheet.Rows {
+		row++
+		fmt.Fprintf(&b, `<row r="%d">`, row)
+		for col, value := range cells {
+			writeCell(&b, cellRef(col, row), value)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+func writeCell(b *strings.Builder, ref string, value any) {
+	var number float64
+	switch v := value.(type) {
+	case nil:
+		return
+	case int:
+		number = float64(v)
+	case int64:
+		number = float64(v)
+	case float64:
+		number = v
+	case string:
+		writeText(b, ref, v, 0)
+		return
+	default:
+		writeText(b, ref, fmt.Sprint(v), 0)
+		return
+	}
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		writeText(b, ref, fmt.Sprint(number), 0)
+		return
+	}
+	fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(number, 'f', -1, 64))
+}
+
+func writeText(b *strings.Builder, ref, text string, style int) {
+	if style != 0 {
+		fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr">`, ref, style)
+	} else {
+		fmt.Fprintf(b, `<c r="%s" t="inlineStr">`, ref)
+	}
+	fmt.Fprintf(b, `<is><t xml:space="preserve">%s</t></is></c>`, escape(text))
+}
+
+// cellRef names the cell at a zero-based column and one-based row, e.g. AB12
+func cellRef(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
+
+// sheetNames returns a valid, unique name for each sheet. Excel rejects names
+// longer than 31 characters or containing any of []:*?/\.
+func sheetNames(sheets []Sheet) []string {
+	clean := strings.NewReplacer("[", "", "]", "", ":", "", "*", "", "?", "", "/", "", `\`, "")
+	names := make([]string, len(sheets))
+	used := make(map[string]bool)
+	for i, sheet := range sheets {
+		base := strings.TrimSpace(clean.Replace(sheet.Name))
+		if base == "" {
+			base = "Sheet"
+		}
+		name := truncate(base, maxSheetName)
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = truncate(base, maxSheetName-len(suffix)) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// escape escapes text for XML, replacing characters XML cannot hold
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readParts unzips a workbook into its parts
+func readParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		require.NoError(t, err)
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
+
+// wellFormed reads every token of an XML part
+func wellFormed(part string) error {
+	d := xml.NewDecoder(strings.NewReader(part))
+	for {
+		if _, err := d.Token(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	data, err := Write(
+		Sheet{Name: "Volume", Header: []string{"Day", "Inbound"}, Rows: [][]any{{"2026-10-15", 12}, {"2026-10-16", 7.5}}},
+		Sheet{Name: "Staff & <Shifts>", Rows: [][]any{{"Siti", nil, "  padded"}}},
+	)
+	require.NoError(t, err)
+
+	parts := readParts(t, data)
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		require.Contains(t, parts, name)
+		assert.NoError(t, wellFormed(parts[name]), name)
+	}
+
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Staff &amp; &lt;Shifts&gt;" sheetId="2" r:id="rId2"/>`)
+	assert.Contains(t, parts["xl/_rels/workbook.xml.rels"], `Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"`)
+
+	sheet1 := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet1, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Day</t></is></c>`)
+	assert.Contains(t, sheet1, `<c r="B2"><v>12</v></c>`)
+	assert.Contains(t, sheet1, `<c r="B3"><v>7.5</v></c>`)
+
+	sheet2 := parts["xl/worksheets/sheet2.xml"]
+	assert.Contains(t, sheet2, `<c r="A1" t="inlineStr">`)
+	assert.NotContains(t, sheet2, `r="B1"`)
+	assert.Contains(t, sheet2, `<c r="C1" t="inlineStr"><is><t xml:space="preserve">  padded</t></is></c>`)
+}
+
+func TestWrite_NoSheets(t *testing.T) {
+	_, err := Write()
+	assert.Error(t, err)
+}
+
+func TestCellRef(t *testing.T) {
+	assert.Equal(t, "A1", cellRef(0, 1))
+	assert.Equal(t, "Z2", cellRef(25, 2))
+	assert.Equal(t, "AA3", cellRef(26, 3))
+	assert.Equal(t, "AB12", cellRef(27, 12))
+}
+
+func TestSheetNames(t *testing.T) {
+	long := strings.Repeat("x", 40)
+	names := sheetNames([]Sheet{{Name: "Report"}, {Name: "report"}, {Name: "a/b:c"}, {Name: " "}, {Name: long}, {Name: long}})
+
+	assert.Equal(t, []string{"Report", "report (2)", "abc", "Sheet", strings.Repeat("x", 31), strings.Repeat("x", 27) + " (2)"}, names)
+}